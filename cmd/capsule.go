@@ -35,13 +35,12 @@ var (
 	exportCapsuleOpts cli.ExportCapsuleOptions
 	exportSnapshotOut string
 
-	importCapsuleOpts cli.ImportCapsuleOptions
-	importSnapshotYes bool
+	importCapsuleOpts  cli.ImportCapsuleOptions
+	importSnapshotOpts cli.ImportSnapshotOptions
 
-	checkFix bool
+	checkOpts cli.CheckOptions
 
-	buildOutput  string
-	buildBaseURL string
+	buildOpts cli.BuildOptions
 )
 
 var exportCapsuleCmd = &cobra.Command{
@@ -80,7 +79,7 @@ var importSnapshotCmd = &cobra.Command{
 	Args:         cobra.ExactArgs(1),
 	SilenceUsage: true,
 	RunE: func(_ *cobra.Command, args []string) error {
-		return cli.DoImportSnapshot(args[0], importSnapshotYes)
+		return cli.DoImportSnapshot(args[0], importSnapshotOpts)
 	},
 }
 
@@ -90,7 +89,7 @@ var checkCmd = &cobra.Command{
 	Args:         cobra.MaximumNArgs(1),
 	SilenceUsage: true,
 	RunE: func(_ *cobra.Command, args []string) error {
-		return cli.DoCheck(pathArg(args, cli.DefaultCapsuleDir), checkFix)
+		return cli.DoCheck(pathArg(args, cli.DefaultCapsuleDir), checkOpts)
 	},
 }
 
@@ -100,7 +99,7 @@ var buildCmd = &cobra.Command{
 	Args:         cobra.MaximumNArgs(1),
 	SilenceUsage: true,
 	RunE: func(_ *cobra.Command, args []string) error {
-		return cli.DoBuild(pathArg(args, cli.DefaultCapsuleDir), buildOutput, buildBaseURL)
+		return cli.DoBuild(pathArg(args, cli.DefaultCapsuleDir), buildOpts)
 	},
 }
 
@@ -128,13 +127,28 @@ func init() {
 		BoolVar(&importCapsuleOpts.DryRun, "dry-run", false, "report what would change without writing")
 
 	importSnapshotCmd.Flags().
-		BoolVar(&importSnapshotYes, "yes", false, "confirm this destructive whole-instance restore")
-
-	checkCmd.Flags().BoolVar(&checkFix, "fix", false, "write back auto-fixable problems (missing ids)")
-
-	buildCmd.Flags().StringVarP(&buildOutput, "output", "o", cli.DefaultDistDir, "output directory")
+		BoolVar(&importSnapshotOpts.Yes, "yes", false, "confirm this destructive whole-instance restore")
+	importSnapshotCmd.Flags().
+		StringVar(&importSnapshotOpts.IdentityFile, "identity", "", "age identity file for an archive encrypted to recipients")
+	importSnapshotCmd.Flags().StringVar(&importSnapshotOpts.PassphraseFile, "passphrase-file", "",
+		"file holding the passphrase of an encrypted archive (or set ECH0_ARCHIVE_PASSPHRASE)")
+
+	checkCmd.Flags().
+		BoolVar(&checkOpts.Fix, "fix", false, "write back auto-fixable problems (missing ids; sizes and hashes with --deep)")
+	checkCmd.Flags().
+		BoolVar(&checkOpts.Deep, "deep", false, "read every media file to verify hashes and sizes, and check media linked from content")
+	checkCmd.Flags().
+		BoolVar(&checkOpts.CheckURLs, "check-urls", false, "send HEAD requests to external URLs (implies --deep)")
+	checkCmd.Flags().StringVar(&checkOpts.Format, "format", "text", "report format: text, json or junit")
+	checkCmd.Flags().StringVar(&checkOpts.Report, "report", "", "write the json/junit report to this file instead of stdout")
+
+	buildCmd.Flags().StringVarP(&buildOpts.Output, "output", "o", cli.DefaultDistDir, "output directory")
+	buildCmd.Flags().
+		StringVar(&buildOpts.BaseURL, "base-url", cli.DefaultBaseURL, "site root path when deploying under a sub-path")
+	buildCmd.Flags().
+		StringVar(&buildOpts.Templates, "templates", "", "directory of HTML templates overriding the built-in page templates")
 	buildCmd.Flags().
-		StringVar(&buildBaseURL, "base-url", cli.DefaultBaseURL, "site root path when deploying under a sub-path")
+		StringVar(&buildOpts.Publish, "publish", "", "publish the output to s3://bucket/prefix, git+<remote>#branch or a local directory")
 
 	exportCmd.AddCommand(exportCapsuleCmd, exportSnapshotCmd)
 	importCmd.AddCommand(importCapsuleCmd, importSnapshotCmd)
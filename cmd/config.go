@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package cmd
+
+import (
+	"github.com/lin-snow/ech0/internal/cli"
+	"github.com/lin-snow/ech0/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var configPrintOpts cli.ConfigPrintOptions
+
+// configCmd 是配置文件相关命令的父命令。
+// 它覆盖根命令的 PersistentPreRun：只记下 --config，不做 bootstrap（不初始化日志、
+// 也不因配置文件有错而直接退出——报告错误正是这些子命令的职责）。
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Validate or print the configuration",
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		config.SetFile(configFile)
+	},
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return cmd.Help()
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:          "validate",
+	Short:        "Validate the config file and ECH0_* environment overrides",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return cli.DoConfigValidate()
+	},
+}
+
+var configPrintCmd = &cobra.Command{
+	Use:   "print",
+	Short: "Print the configuration in config file format (secrets redacted)",
+	Long: "Print the configuration in config file format. Without --effective it prints the built-in defaults, " +
+		"which is a good starting point for a config file; with --effective it prints the values the server " +
+		"would run with after merging the config file and ECH0_* environment variables.",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return cli.DoConfigPrint(configPrintOpts)
+	},
+}
+
+func init() {
+	configPrintCmd.Flags().
+		BoolVar(&configPrintOpts.Effective, "effective", false, "print the merged defaults + config file + environment")
+	configPrintCmd.Flags().StringVar(&configPrintOpts.Format, "format", "yaml", "output format: yaml or toml")
+
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configPrintCmd)
+	rootCmd.AddCommand(configCmd)
+}
@@ -7,10 +7,9 @@ import (
 	_ "time/tzdata"
 
 	"github.com/lin-snow/ech0/cmd"
-	"github.com/lin-snow/ech0/internal/bootstrap"
 )
 
+// main 只交给 cobra；bootstrap 在根命令的 PersistentPreRun 里执行（需先解析 --config）。
 func main() {
-	bootstrap.Bootstrap()
 	cmd.Execute()
 }
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package cmd
+
+import (
+	"github.com/lin-snow/ech0/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+var mcpOpts cli.MCPOptions
+
+// mcpCmd 以 stdio 传输运行 MCP Server，供习惯拉起本地进程的桌面 MCP 宿主使用
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Serve MCP over stdin/stdout",
+	Long: "Serve the Model Context Protocol over stdin/stdout for MCP hosts that launch a local process. " +
+		"With --url it proxies to a remote instance using an access token; otherwise it opens the local database " +
+		"and acts as --user with --scopes (read-only by default).",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return cli.DoMCP(mcpOpts)
+	},
+}
+
+func init() {
+	mcpCmd.Flags().StringVar(&mcpOpts.URL, "url", "", "proxy to this instance (e.g. https://ech0.example.com) instead of the local database")
+	mcpCmd.Flags().
+		StringVar(&mcpOpts.Token, "token", "", "access token with the mcp-remote audience for --url (or set ECH0_MCP_TOKEN)")
+	mcpCmd.Flags().StringVar(&mcpOpts.User, "user", "", "local mode: username to act as (default: the owner)")
+	mcpCmd.Flags().
+		StringSliceVar(&mcpOpts.Scopes, "scopes", nil, "local mode: scopes granted to the session (default: read-only scopes)")
+
+	rootCmd.AddCommand(mcpCmd)
+}
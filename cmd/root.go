@@ -6,10 +6,15 @@ package cmd
 import (
 	"os"
 
+	"github.com/lin-snow/ech0/internal/bootstrap"
 	"github.com/lin-snow/ech0/internal/cli"
+	"github.com/lin-snow/ech0/internal/config"
 	"github.com/spf13/cobra"
 )
 
+// configFile 是 --config 指定的配置文件路径，空时回退 ECH0_CONFIG_FILE。
+var configFile string
+
 // rootCmd 是 Ech0 的根命令
 // 默认启动CLI With TUI
 var rootCmd = &cobra.Command{
@@ -17,6 +22,12 @@ var rootCmd = &cobra.Command{
 	Short: "A self-hosted, lightweight microblog platform for personal thoughts",
 	Long:  `Ech0 is a new-generation open-source, self-hosted, lightweight publishing platform focused on the flow of personal thoughts.`,
 
+	// bootstrap 放在 flag 解析之后，--config 才能在首次读取配置前生效。
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		config.SetFile(configFile)
+		bootstrap.Bootstrap()
+	},
+
 	// 这个 Run 会在没有子命令时执行
 	Run: func(cmd *cobra.Command, args []string) {
 		cli.DoTui()
@@ -54,6 +65,8 @@ var helloCmd = &cobra.Command{
 func init() {
 	// 解决Windows下使用 Cobra 触发 mousetrap 提示
 	cobra.MousetrapHelpText = ""
+	rootCmd.PersistentFlags().
+		StringVar(&configFile, "config", "", "path to a YAML or TOML config file (or set "+config.FileEnv+")")
 	rootCmd.AddCommand(tuiCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(helloCmd)
@@ -1,7 +1,7 @@
 // SPDX-License-Identifier: AGPL-3.0-or-later
 // Copyright (C) 2025-2026 lin-snow
 
-// Package agent 是 Ech0 的 LLM 核心：把多家协议（OpenAI 兼容 / Anthropic）的
+// Package agent 是 Ech0 的 LLM 核心：把多家协议（OpenAI 兼容 / Anthropic / Ollama 原生）的
 // 生成能力收口为统一的 Provider 抽象。对外暴露两个入口：
 //   - Generate：非流式、无工具，用于近期总结（summary）；
 //   - Run：ReAct 工具循环（function calling），模型一轮内自主决定是否检索，用于 Chat。
@@ -31,13 +31,18 @@ func validate(setting model.AgentSetting) error {
 	if setting.Protocol == "" {
 		return errors.New(commonModel.AGENT_PROTOCOL_NOT_FOUND)
 	}
-	if setting.ApiKey == "" && setting.Protocol != string(commonModel.OpenAI) {
-		// OpenAI 兼容场景下 Ollama 等本地服务允许空 ApiKey
+	if setting.ApiKey == "" && !keylessProtocol(setting.Protocol) {
 		return errors.New(commonModel.AGENT_API_KEY_MISSING)
 	}
 	return nil
 }
 
+// keylessProtocol 报告该协议是否允许空 ApiKey：OpenAI 兼容端常指向 Ollama / llama.cpp 等
+// 本地服务，Ollama 原生协议本身就没有鉴权。
+func keylessProtocol(protocol string) bool {
+	return protocol == string(commonModel.OpenAI) || protocol == string(commonModel.Ollama)
+}
+
 // applyPrompt 在 usePrompt 且配置了自定义 Prompt 时，把它作为 user 消息追加在末尾
 // （维持历史行为）。
 func applyPrompt(setting model.AgentSetting, in []Message, usePrompt bool) []Message {
@@ -88,8 +93,8 @@ func Ping(ctx context.Context, setting model.AgentSetting) error {
 	if setting.Protocol == "" {
 		return errors.New(commonModel.AGENT_PROTOCOL_NOT_FOUND)
 	}
-	if setting.ApiKey == "" && setting.Protocol != string(commonModel.OpenAI) {
-		// OpenAI 兼容场景下 Ollama 等本地服务允许空 ApiKey（与 validate 保持一致）
+	if setting.ApiKey == "" && !keylessProtocol(setting.Protocol) {
+		// 与 validate 保持一致
 		return errors.New(commonModel.AGENT_API_KEY_MISSING)
 	}
 
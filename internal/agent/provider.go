@@ -11,7 +11,7 @@ import (
 	model "github.com/lin-snow/ech0/internal/model/setting"
 )
 
-// Provider 是某个 LLM 协议（OpenAI 兼容 / Anthropic）的适配层。
+// Provider 是某个 LLM 协议（OpenAI 兼容 / Anthropic / Ollama 原生）的适配层。
 //
 // 各家 SDK 的差异——尤其流式 tool_call 的分片拼接——封死在各自实现内部，
 // 对上层只暴露统一的 Complete（非流式）与 Stream（语义 Event 流）。
@@ -32,6 +32,8 @@ func providerFor(setting model.AgentSetting) (Provider, error) {
 		return &openaiProvider{setting: setting}, nil
 	case string(commonModel.Anthropic):
 		return &anthropicProvider{setting: setting}, nil
+	case string(commonModel.Ollama):
+		return &ollamaProvider{setting: setting}, nil
 	default:
 		return nil, errors.New(commonModel.AGENT_PROTOCOL_NOT_FOUND)
 	}
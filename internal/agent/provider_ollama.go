@@ -0,0 +1,206 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	model "github.com/lin-snow/ech0/internal/model/setting"
+	"github.com/lin-snow/ech0/internal/ollama"
+)
+
+// ollamaProvider 适配 Ollama 原生 /api/chat（离线 / 内网部署）。
+//
+// 与经 OpenAI 兼容端（/v1）访问同一个 Ollama 相比：原生接口把 thinking 独立成字段、
+// 末片带 prompt_eval_count / eval_count，模型未拉取时回明确的 404（上层据此提示拉取）。
+// 工具调用整条下发、不分片，也不带调用 id——id 由这里按「消息数 + 序号」合成，
+// 同一会话内唯一即可（只用于把 tool 结果配回调用）。
+type ollamaProvider struct {
+	setting model.AgentSetting
+}
+
+func (p *ollamaProvider) buildRequest(req Request) ollama.ChatRequest {
+	chatReq := ollama.ChatRequest{
+		Model:    p.setting.Model,
+		Messages: ollamaMessages(req.Messages),
+		Tools:    ollamaTools(req.Tools),
+	}
+	options := map[string]any{}
+	if req.Temperature != nil {
+		options["temperature"] = *req.Temperature
+	}
+	if req.MaxTokens > 0 {
+		options["num_predict"] = req.MaxTokens
+	}
+	if len(options) > 0 {
+		chatReq.Options = options
+	}
+	return chatReq
+}
+
+// ollamaMessages 把内部 Message 映射为 Ollama 消息。tool 结果按 ToolCallID 回查发起它的工具名
+// 填进 tool_name（Ollama 以名字而非 id 配对）。图片只能走 base64：Ollama 不会替你去拉 URL。
+func ollamaMessages(in []Message) []ollama.Message {
+	names := make(map[string]string)
+	out := make([]ollama.Message, 0, len(in))
+	for _, m := range in {
+		msg := ollama.Message{Role: string(m.Role), Content: m.Content}
+		switch m.Role {
+		case RoleAssistant:
+			for _, tc := range m.ToolCalls {
+				names[tc.ID] = tc.Name
+				var call ollama.ToolCall
+				call.Function.Name = tc.Name
+				call.Function.Arguments = tc.Args
+				msg.ToolCalls = append(msg.ToolCalls, call)
+			}
+		case RoleTool:
+			msg.ToolName = names[m.ToolCallID]
+		case RoleUser:
+			for _, img := range m.Images {
+				if img.Base64 != "" {
+					msg.Images = append(msg.Images, img.Base64)
+				}
+			}
+		}
+		out = append(out, msg)
+	}
+	return out
+}
+
+func ollamaTools(defs []ToolDef) []ollama.Tool {
+	if len(defs) == 0 {
+		return nil
+	}
+	tools := make([]ollama.Tool, 0, len(defs))
+	for _, d := range defs {
+		tools = append(tools, ollama.Tool{
+			Type: "function",
+			Function: ollama.ToolFunction{
+				Name:        d.Name,
+				Description: d.Description,
+				Parameters:  d.Parameters,
+			},
+		})
+	}
+	return tools
+}
+
+// ollamaToolCalls 把一片里的工具调用转成内部 ToolCall，并合成调用 id。
+func ollamaToolCalls(calls []ollama.ToolCall, seq *int) []ToolCall {
+	out := make([]ToolCall, 0, len(calls))
+	for _, c := range calls {
+		args := c.Function.Arguments
+		if len(args) == 0 || string(args) == "null" {
+			args = json.RawMessage("{}")
+		}
+		*seq++
+		out = append(out, ToolCall{
+			ID:   fmt.Sprintf("call_%d", *seq),
+			Name: c.Function.Name,
+			Args: args,
+		})
+	}
+	return out
+}
+
+func ollamaUsage(c ollama.ChatChunk) Usage {
+	return Usage{InputTokens: c.PromptEvalCount, OutputTokens: c.EvalCount}
+}
+
+func (p *ollamaProvider) Complete(ctx context.Context, req Request) (Response, error) {
+	last, err := ollama.New(p.setting.BaseURL).Chat(ctx, p.buildRequest(req), nil)
+	if err != nil {
+		return Response{}, err
+	}
+	if last.Message.Content == "" {
+		return Response{}, errors.New("ollama: empty text response")
+	}
+	return Response{Text: last.Message.Content, Usage: ollamaUsage(last)}, nil
+}
+
+func (p *ollamaProvider) Stream(ctx context.Context, req Request) (<-chan Event, error) {
+	ch := make(chan Event)
+	go p.stream(ctx, req, ch)
+	return ch, nil
+}
+
+// stream 逐片消费 /api/chat：thinking 字段当推理上浮，正文与 OpenAI 兼容端一样过
+// <think> 拆分与工具调用泄漏守卫；工具调用先收齐，流结束后按序上浮。
+func (p *ollamaProvider) stream(ctx context.Context, req Request, ch chan<- Event) {
+	defer close(ch)
+
+	var (
+		calls    []ToolCall
+		seq      = len(req.Messages) * 100 // 轮间不重号：消息数随轮次单调增长
+		guard    = &toolCallLeakGuard{}
+		splitter = &reasoningSplitter{}
+		aborted  bool
+		leaked   bool
+	)
+	emitAnswer := func(text string) bool {
+		safe, tripped := guard.feed(text)
+		if tripped {
+			leaked = true
+			return false
+		}
+		return safe == "" || send(ctx, ch, Event{Kind: EventTextDelta, Text: safe})
+	}
+
+	last, err := ollama.New(p.setting.BaseURL).Chat(ctx, p.buildRequest(req), func(chunk ollama.ChatChunk) bool {
+		msg := chunk.Message
+		if msg.Thinking != "" && !send(ctx, ch, Event{Kind: EventReasoningDelta, Text: msg.Thinking}) {
+			aborted = true
+			return false
+		}
+		if msg.Content != "" {
+			answer, reasoning := splitter.feed(msg.Content)
+			if reasoning != "" && !send(ctx, ch, Event{Kind: EventReasoningDelta, Text: reasoning}) {
+				aborted = true
+				return false
+			}
+			if answer != "" && !emitAnswer(answer) {
+				aborted = !leaked
+				return false
+			}
+		}
+		calls = append(calls, ollamaToolCalls(msg.ToolCalls, &seq)...)
+		return true
+	})
+	switch {
+	case leaked:
+		send(ctx, ch, Event{Kind: EventError, Err: errTextToolCallLeak})
+		return
+	case aborted:
+		return
+	case err != nil:
+		send(ctx, ch, Event{Kind: EventError, Err: err})
+		return
+	}
+
+	ansRest, reaRest := splitter.flush()
+	if reaRest != "" && !send(ctx, ch, Event{Kind: EventReasoningDelta, Text: reaRest}) {
+		return
+	}
+	if ansRest != "" && !emitAnswer(ansRest) {
+		if leaked {
+			send(ctx, ch, Event{Kind: EventError, Err: errTextToolCallLeak})
+		}
+		return
+	}
+	if rest := guard.flush(); rest != "" {
+		if !send(ctx, ch, Event{Kind: EventTextDelta, Text: rest}) {
+			return
+		}
+	}
+	for _, tc := range calls {
+		if !send(ctx, ch, Event{Kind: EventToolCall, ToolCall: tc}) {
+			return
+		}
+	}
+	send(ctx, ch, Event{Kind: EventDone, Usage: ollamaUsage(last)})
+}
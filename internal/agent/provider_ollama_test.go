@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	model "github.com/lin-snow/ech0/internal/model/setting"
+)
+
+// ollamaServer 回放一段 /api/chat 的 NDJSON，并记录收到的请求体。
+func ollamaServer(t *testing.T, lines ...string) (*httptest.Server, *map[string]any) {
+	t.Helper()
+	got := map[string]any{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/chat" {
+			http.NotFound(w, r)
+			return
+		}
+		raw, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(raw, &got)
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &got
+}
+
+// 流式：thinking 当推理、正文当答案、工具调用合成 id 后于末尾上浮，末片 token 计数随 EventDone。
+func TestOllamaStream(t *testing.T) {
+	srv, got := ollamaServer(t,
+		`{"message":{"role":"assistant","content":"","thinking":"想一想"},"done":false}`,
+		`{"message":{"role":"assistant","content":"你好"},"done":false}`,
+		`{"message":{"role":"assistant","content":"","tool_calls":[{"function":{"name":"search_echos","arguments":{"query":"咖啡"}}}]},"done":false}`,
+		`{"message":{"role":"assistant","content":""},"done":true,"prompt_eval_count":12,"eval_count":4}`,
+	)
+	// 用户照 OpenAI 兼容习惯填了 /v1 后缀也能用。
+	p := &ollamaProvider{setting: model.AgentSetting{Model: "qwen3", BaseURL: srv.URL + "/v1"}}
+	temp := float32(0.2)
+
+	ch, err := p.Stream(context.Background(), Request{
+		Messages:    []Message{{Role: RoleUser, Content: "hi"}},
+		Tools:       []ToolDef{{Name: "search_echos", Parameters: json.RawMessage(`{"type":"object"}`)}},
+		Temperature: &temp,
+	})
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+	var evs []Event
+	for ev := range ch {
+		evs = append(evs, ev)
+	}
+
+	if len(evs) != 4 {
+		t.Fatalf("want 4 events, got %+v", evs)
+	}
+	if evs[0].Kind != EventReasoningDelta || evs[0].Text != "想一想" {
+		t.Fatalf("event 0 = %+v", evs[0])
+	}
+	if evs[1].Kind != EventTextDelta || evs[1].Text != "你好" {
+		t.Fatalf("event 1 = %+v", evs[1])
+	}
+	tc := evs[2].ToolCall
+	if evs[2].Kind != EventToolCall || tc.Name != "search_echos" || tc.ID == "" || string(tc.Args) != `{"query":"咖啡"}` {
+		t.Fatalf("event 2 = %+v", evs[2])
+	}
+	if evs[3].Kind != EventDone || evs[3].Usage != (Usage{InputTokens: 12, OutputTokens: 4}) {
+		t.Fatalf("event 3 = %+v", evs[3])
+	}
+
+	if (*got)["stream"] != true || (*got)["model"] != "qwen3" {
+		t.Fatalf("unexpected request %v", *got)
+	}
+	if opts, _ := (*got)["options"].(map[string]any); opts["temperature"] == nil {
+		t.Fatalf("temperature should be passed via options, got %v", *got)
+	}
+}
+
+// 模型未拉取：404 经 EventError 上浮，错误信息提示先拉取。
+func TestOllamaStream_ModelMissing(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"error":"model \"qwen3\" not found, try pulling it first"}`)
+	}))
+	defer srv.Close()
+	p := &ollamaProvider{setting: model.AgentSetting{Model: "qwen3", BaseURL: srv.URL}}
+
+	ch, _ := p.Stream(context.Background(), Request{Messages: []Message{{Role: RoleUser, Content: "hi"}}})
+	ev := <-ch
+	if ev.Kind != EventError || !strings.Contains(ev.Err.Error(), "pull") {
+		t.Fatalf("want not-found error, got %+v", ev)
+	}
+}
+
+// tool 结果按 ToolCallID 回查工具名；图片只保留 base64。
+func TestOllamaMessages(t *testing.T) {
+	msgs := ollamaMessages([]Message{
+		{Role: RoleSystem, Content: "sys"},
+		{Role: RoleUser, Content: "看图", Images: []ImagePart{{Base64: "abc"}, {URL: "https://x/y.png"}}},
+		{Role: RoleAssistant, ToolCalls: []ToolCall{{ID: "c1", Name: "search_echos", Args: json.RawMessage(`{"q":"x"}`)}}},
+		{Role: RoleTool, ToolCallID: "c1", Content: "hit"},
+	})
+
+	if len(msgs) != 4 || msgs[0].Role != "system" {
+		t.Fatalf("unexpected messages %+v", msgs)
+	}
+	if len(msgs[1].Images) != 1 || msgs[1].Images[0] != "abc" {
+		t.Fatalf("only base64 images are forwarded, got %v", msgs[1].Images)
+	}
+	if msgs[2].ToolCalls[0].Function.Name != "search_echos" {
+		t.Fatalf("assistant tool call lost: %+v", msgs[2])
+	}
+	if msgs[3].Role != "tool" || msgs[3].ToolName != "search_echos" {
+		t.Fatalf("tool result should carry tool_name, got %+v", msgs[3])
+	}
+}
@@ -122,7 +122,7 @@ func (p *openaiProvider) Complete(ctx context.Context, req Request) (Response, e
 	if len(resp.Choices) == 0 {
 		return Response{}, errors.New("openai: empty response")
 	}
-	return Response{Text: resp.Choices[0].Message.Content}, nil
+	return Response{Text: resp.Choices[0].Message.Content, Usage: openAIUsage(&resp.Usage)}, nil
 }
 
 func (p *openaiProvider) Stream(ctx context.Context, req Request) (<-chan Event, error) {
@@ -162,6 +162,9 @@ func (p *openaiProvider) stream(ctx context.Context, req Request, ch chan<- Even
 	guard := &toolCallLeakGuard{}
 	// splitter 把内联在正文里的 <think> 推理段从答案里拆出来（推理模型经 OpenAI 兼容端的怪癖）。
 	splitter := &reasoningSplitter{}
+	// 流式 usage 只有部分服务端会在末个 chunk 主动附带（不主动请求 stream_options，
+	// 免得不认识该字段的兼容端直接拒绝请求）；没有就是零值。
+	var usage Usage
 
 	for {
 		resp, recvErr := stream.Recv()
@@ -172,6 +175,9 @@ func (p *openaiProvider) stream(ctx context.Context, req Request, ch chan<- Even
 			send(ctx, ch, Event{Kind: EventError, Err: recvErr})
 			return
 		}
+		if resp.Usage != nil {
+			usage = openAIUsage(resp.Usage)
+		}
 		if len(resp.Choices) == 0 {
 			continue
 		}
@@ -229,7 +235,11 @@ func (p *openaiProvider) stream(ctx context.Context, req Request, ch chan<- Even
 			return
 		}
 	}
-	send(ctx, ch, Event{Kind: EventDone})
+	send(ctx, ch, Event{Kind: EventDone, Usage: usage})
+}
+
+func openAIUsage(u *openai.Usage) Usage {
+	return Usage{InputTokens: u.PromptTokens, OutputTokens: u.CompletionTokens}
 }
 
 // toolCallAccumulator 累积流式 tool_call 分片：OpenAI 把同一个调用的 arguments
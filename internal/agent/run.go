@@ -7,6 +7,7 @@ import (
 	"context"
 	"log/slog"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	logUtil "github.com/lin-snow/ech0/pkg/log"
@@ -16,6 +17,9 @@ import (
 // defaultMaxRounds 是工具轮数上限护栏：防模型反复调工具死循环烧 token。
 const defaultMaxRounds = 3
 
+// defaultConfirmTimeout 是写操作等待用户确认的默认上限。
+const defaultConfirmTimeout = 2 * time.Minute
+
 // maxParallelTools 是单轮内并发执行工具调用的上限：模型一轮发多个工具调用时并发跑（多为 I/O
 // 密集的检索），削减串行延迟，同时 clamp 住并发度避免突发打满下游。
 const maxParallelTools = 4
@@ -27,6 +31,7 @@ var defaultRunStrings = RunStrings{
 	ToolError:       "工具执行失败：",
 	ImageNote:       toolImageNote,
 	ContextTrimNote: "（早前检索结果已省略以控制长度）",
+	ActionDeclined:  "用户没有确认该操作，未执行。请告知用户操作已取消，不要重复提议。",
 }
 
 // withDefaults 用 defaultRunStrings 填充留空字段。
@@ -46,6 +51,9 @@ func (s RunStrings) withDefaults() RunStrings {
 	if s.ContextTrimNote == "" {
 		s.ContextTrimNote = defaultRunStrings.ContextTrimNote
 	}
+	if s.ActionDeclined == "" {
+		s.ActionDeclined = defaultRunStrings.ActionDeclined
+	}
 	return s
 }
 
@@ -97,12 +105,18 @@ func runLoop(ctx context.Context, provider Provider, req RunRequest, out chan<-
 
 	messages := req.Messages
 	seen := make(map[string]bool)
+	var usage Usage
 	strs := req.Strings.withDefaults()
+	confirmTimeout := req.ConfirmTimeout
+	if confirmTimeout <= 0 {
+		confirmTimeout = defaultConfirmTimeout
+	}
 
 	for round := 0; round < maxRounds; round++ {
 		// 轮内 token 预算回收：超限时把最旧的工具结果替换为占位，防多轮累积撑爆窗口。
 		trimContext(messages, req.MaxContextTokens, strs.ContextTrimNote)
 		o := streamRound(ctx, provider, out, messages, toolDefs, req.Temp)
+		usage.Add(o.usage)
 		if o.aborted {
 			return // ctx 取消
 		}
@@ -112,13 +126,13 @@ func runLoop(ctx context.Context, provider Provider, req RunRequest, out chan<-
 		}
 		if len(o.calls) == 0 {
 			// 模型本轮直接作答（无工具调用）→ 正常收尾
-			emit(ctx, out, AgentEvent{Kind: AgentDone})
+			emit(ctx, out, AgentEvent{Kind: AgentDone, Usage: usage})
 			return
 		}
 
 		// 回灌本轮 assistant 的 tool_calls（连同已产出的文本），供下一轮上下文
 		messages = append(messages, Message{Role: RoleAssistant, Content: o.assistant, ToolCalls: o.calls})
-		if !execTools(ctx, out, o.calls, toolByName, seen, &messages, strs) {
+		if !execTools(ctx, out, o.calls, toolByName, seen, &messages, strs, confirmTimeout) {
 			return // ctx 取消
 		}
 	}
@@ -126,6 +140,7 @@ func runLoop(ctx context.Context, provider Provider, req RunRequest, out chan<-
 	// 工具轮用尽仍在调工具：强制一轮「不给工具」让模型据已检索到的结果作答，保证有回答。
 	trimContext(messages, req.MaxContextTokens, strs.ContextTrimNote)
 	o := streamRound(ctx, provider, out, messages, nil, req.Temp)
+	usage.Add(o.usage)
 	if o.aborted {
 		return
 	}
@@ -133,7 +148,7 @@ func runLoop(ctx context.Context, provider Provider, req RunRequest, out chan<-
 		emit(ctx, out, AgentEvent{Kind: AgentError, Err: o.err})
 		return
 	}
-	emit(ctx, out, AgentEvent{Kind: AgentDone})
+	emit(ctx, out, AgentEvent{Kind: AgentDone, Usage: usage})
 }
 
 // roundOutcome 是一轮 provider.Stream 调用的结果。
@@ -142,6 +157,7 @@ type roundOutcome struct {
 	assistant string // 本轮产出的文本（已实时 emit，留作回灌上下文）
 	aborted   bool   // ctx 取消
 	err       error  // 传输/协议错误
+	usage     Usage  // 本轮 token 用量（取自 EventDone）
 }
 
 // streamRound 跑一次 provider.Stream：文本增量实时 emit AgentDelta，收集工具调用。
@@ -184,7 +200,7 @@ func streamRound(
 		case EventError:
 			o.err = ev.Err
 		case EventDone:
-			// 本次 Provider 调用结束，无需额外处理
+			o.usage = ev.Usage
 		}
 		if o.aborted {
 			o.assistant = b.String()
@@ -202,6 +218,7 @@ func streamRound(
 //
 //	A. 顺序预处理——去重命中 / 未知工具就地定好其 tool 结果消息，其余记为待执行；
 //	B. 有界并发执行待执行项（emit Searching + Execute），结果按 index 写入各自槽，无竞态；
+//	   Confirm 工具改为 emit PendingAction 并等待用户决定，拒绝/超时则不执行；
 //	C. 顺序收尾——按调用原序 emit ToolResult、定好 tool 结果消息与（可选）带图消息。
 //
 // 追加顺序：**先把全部 tool 结果消息按原序追加，再追加带图 user 消息**。这样一轮 assistant 的
@@ -215,12 +232,14 @@ func execTools(
 	seen map[string]bool,
 	messages *[]Message,
 	strs RunStrings,
+	confirmTimeout time.Duration,
 ) bool {
 	n := len(calls)
 	toolMsgs := make([]Message, n)   // 每个调用对应的 tool 结果消息（含去重/未知/错误/正常）
 	imageMsgs := make([]*Message, n) // 每个调用可选的带图 user 消息（多模态）
 	outputs := make([]ToolOutput, n)
 	execErrs := make([]error, n)
+	declined := make([]bool, n)
 
 	// A. 顺序预处理：去重与未知工具就地定好结果消息；其余记为待执行（保留原序 index）。
 	var runnable []int
@@ -246,10 +265,21 @@ func execTools(
 	for _, idx := range runnable {
 		idx, tc, tool := idx, calls[idx], toolByName[calls[idx].Name]
 		g.Go(func() error {
-			if !emit(ctx, out, AgentEvent{Kind: AgentSearching, ToolName: tc.Name, ToolArgs: tc.Args}) {
+			execCtx := ctx
+			if tool.Confirm {
+				approval, approved, ok := awaitApproval(ctx, out, tc, confirmTimeout)
+				if !ok {
+					return ctx.Err()
+				}
+				if !approved {
+					declined[idx] = true
+					return nil
+				}
+				execCtx = context.WithValue(ctx, approvalKey{}, approval)
+			} else if !emit(ctx, out, AgentEvent{Kind: AgentSearching, ToolName: tc.Name, ToolArgs: tc.Args}) {
 				return ctx.Err()
 			}
-			outputs[idx], execErrs[idx] = tool.Execute(ctx, tc.Args)
+			outputs[idx], execErrs[idx] = tool.Execute(execCtx, tc.Args)
 			return nil
 		})
 	}
@@ -260,6 +290,10 @@ func execTools(
 	// C. 顺序收尾：按原序 emit ToolResult、定好结果/带图消息。
 	for _, idx := range runnable {
 		tc := calls[idx]
+		if declined[idx] {
+			toolMsgs[idx] = Message{Role: RoleTool, ToolCallID: tc.ID, Content: strs.ActionDeclined}
+			continue
+		}
 		if execErrs[idx] != nil {
 			logUtil.GetLogger().Warn("agent tool execute failed",
 				slog.String("module", "agent"),
@@ -290,6 +324,25 @@ func execTools(
 	return true
 }
 
+// awaitApproval 上浮一条待确认写操作并阻塞等待领域层的决定。timeout 到期视为拒绝；
+// ctx 取消时 ok 为 false（调用方应据此停止）。
+func awaitApproval(ctx context.Context, out chan<- AgentEvent, tc ToolCall, timeout time.Duration) (approval *Approval, approved, ok bool) {
+	approval = newApproval()
+	if !emit(ctx, out, AgentEvent{Kind: AgentPendingAction, ToolName: tc.Name, ToolArgs: tc.Args, Approval: approval}) {
+		return approval, false, false
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case approved = <-approval.reply:
+		return approval, approved, true
+	case <-timer.C:
+		return approval, false, true
+	case <-ctx.Done():
+		return approval, false, false
+	}
+}
+
 // trimContext 在轮内消息上下文超 budget 时回收最旧的工具结果：把其 Content 替换为 note 占位
 // （保留消息与 ToolCallID 配对，绝不删消息——否则 tool_use/tool_result 失配会被 API 400）。
 // budget<=0 时不回收。逐条替换直到回到预算内或没有可回收的工具结果。
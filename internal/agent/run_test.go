@@ -445,3 +445,97 @@ func TestRunLoop_ToolImageNoteAppended(t *testing.T) {
 		t.Fatalf("toolImageNote message should carry the tool's image, got %+v", found.Images)
 	}
 }
+
+// drainDeciding 收集事件直到关闭，遇到 AgentPendingAction 时按 approve 作答（nil 表示不作答，等超时）。
+func drainDeciding(out <-chan AgentEvent, approve *bool) []AgentEvent {
+	var evs []AgentEvent
+	for ev := range out {
+		if ev.Kind == AgentPendingAction && approve != nil {
+			ev.Approval.Decide(*approve)
+			ev.Approval.Decide(!*approve) // 幂等：第二次不生效
+		}
+		evs = append(evs, ev)
+	}
+	return evs
+}
+
+// 写操作工具：先上浮 PendingAction，确认后才执行，且不发 Searching。
+func TestRunLoop_ConfirmApproved(t *testing.T) {
+	tool, execs := countingTool("create_draft_echo", ToolOutput{Content: "created", Meta: "m"}, nil)
+	tool.Confirm = true
+	fp := &fakeProvider{scripts: [][]Event{
+		{toolCallEvent("c1", "create_draft_echo", `{"content":"hi"}`), doneEvent()},
+		{textEvent("ok"), doneEvent()},
+	}}
+
+	approve := true
+	evs := drainDeciding(runChan(context.Background(), fp, RunRequest{Setting: enabledSetting(), Tools: []Tool{tool}}), &approve)
+
+	want := []AgentEventKind{AgentPendingAction, AgentToolResult, AgentDelta, AgentDone}
+	if got := kinds(evs); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("event kinds = %v, want %v", got, want)
+	}
+	if evs[0].ToolName != "create_draft_echo" || string(evs[0].ToolArgs) != `{"content":"hi"}` {
+		t.Fatalf("pending action = %s %s", evs[0].ToolName, evs[0].ToolArgs)
+	}
+	if *execs != 1 {
+		t.Fatalf("tool executed %d times, want 1", *execs)
+	}
+	if msg := fp.gotReqs[1].Messages[len(fp.gotReqs[1].Messages)-1]; msg.Content != "created" {
+		t.Fatalf("tool result fed back = %q, want %q", msg.Content, "created")
+	}
+}
+
+// 拒绝与确认超时都不执行工具，并把 ActionDeclined 回喂模型。
+func TestRunLoop_ConfirmDeclinedOrTimedOut(t *testing.T) {
+	decline := false
+	for name, approve := range map[string]*bool{"declined": &decline, "timeout": nil} {
+		t.Run(name, func(t *testing.T) {
+			tool, execs := countingTool("moderate_comments", ToolOutput{Content: "done"}, nil)
+			tool.Confirm = true
+			fp := &fakeProvider{scripts: [][]Event{
+				{toolCallEvent("c1", "moderate_comments", `{"approve":["x"]}`), doneEvent()},
+				{textEvent("cancelled"), doneEvent()},
+			}}
+
+			evs := drainDeciding(runChan(context.Background(), fp, RunRequest{
+				Setting:        enabledSetting(),
+				Tools:          []Tool{tool},
+				Strings:        RunStrings{ActionDeclined: "declined"},
+				ConfirmTimeout: 20 * time.Millisecond,
+			}), approve)
+
+			if *execs != 0 {
+				t.Fatalf("tool executed %d times, want 0", *execs)
+			}
+			if n := countKind(evs, AgentToolResult); n != 0 {
+				t.Fatalf("AgentToolResult count = %d, want 0", n)
+			}
+			if msg := fp.gotReqs[1].Messages[len(fp.gotReqs[1].Messages)-1]; msg.Content != "declined" || msg.ToolCallID != "c1" {
+				t.Fatalf("tool result fed back = %+v", msg)
+			}
+			if evs[len(evs)-1].Kind != AgentDone {
+				t.Fatalf("last event = %d, want AgentDone", evs[len(evs)-1].Kind)
+			}
+		})
+	}
+}
+
+// 工具循环跨轮累加 token 用量，随 AgentDone 上浮。
+func TestRunLoop_AccumulatesUsage(t *testing.T) {
+	tool, _ := countingTool("search_echos", ToolOutput{Content: "hit"}, nil)
+	fp := &fakeProvider{scripts: [][]Event{
+		{toolCallEvent("c1", "search_echos", `{"q":"x"}`), {Kind: EventDone, Usage: Usage{InputTokens: 10, OutputTokens: 2}}},
+		{textEvent("answer"), {Kind: EventDone, Usage: Usage{InputTokens: 15, OutputTokens: 5}}},
+	}}
+
+	evs := runLoopSync(context.Background(), fp, RunRequest{Setting: enabledSetting(), Tools: []Tool{tool}})
+
+	last := evs[len(evs)-1]
+	if last.Kind != AgentDone {
+		t.Fatalf("last event = %d, want AgentDone", last.Kind)
+	}
+	if last.Usage != (Usage{InputTokens: 25, OutputTokens: 7}) {
+		t.Fatalf("usage = %+v, want 25/7", last.Usage)
+	}
+}
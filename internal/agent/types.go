@@ -6,6 +6,7 @@ package agent
 import (
 	"context"
 	"encoding/json"
+	"sync"
 	"time"
 
 	model "github.com/lin-snow/ech0/internal/model/setting"
@@ -57,9 +58,13 @@ type ToolDef struct {
 }
 
 // Tool 把工具声明与执行闭包绑定；执行体由领域层（Copilot Service）注入，agent 包零领域依赖。
+//
+// Confirm 为真的是写操作工具：Loop 不会直接执行，而是先上浮 AgentPendingAction 并阻塞等待
+// 用户决定，确认后才调用 Execute；拒绝或超时则把 RunStrings.ActionDeclined 回喂模型。
 type Tool struct {
 	Def     ToolDef
 	Execute func(ctx context.Context, args json.RawMessage) (ToolOutput, error)
+	Confirm bool
 }
 
 // ToolOutput 是工具执行结果：Content 回喂模型，Meta 旁路带出领域数据（如命中的检索结果，供 SSE sources）。
@@ -80,7 +85,21 @@ type Request struct {
 
 // Response 是一次非流式生成的结果（Generate 用，无工具）。
 type Response struct {
-	Text string
+	Text  string
+	Usage Usage
+}
+
+// Usage 是一次或多次 Provider 调用消耗的 token 数。服务端未回报时为零值，
+// 不代表「没有消耗」——上层展示时应把零值当作未知。
+type Usage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+// Add 累加另一次调用的用量。
+func (u *Usage) Add(o Usage) {
+	u.InputTokens += o.InputTokens
+	u.OutputTokens += o.OutputTokens
 }
 
 // EventKind 区分 Provider 上浮的语义事件类型。
@@ -100,6 +119,7 @@ type Event struct {
 	Text     string   // EventTextDelta / EventReasoningDelta
 	ToolCall ToolCall // EventToolCall
 	Err      error    // EventError
+	Usage    Usage    // EventDone：本次调用的 token 用量（服务端未回报时为零值）
 }
 
 // RunStrings 是 Loop 在工具循环中回喂给模型 / 注入消息的少量提示文案。由领域层（知道 locale）
@@ -110,6 +130,7 @@ type RunStrings struct {
 	ToolError       string // 工具执行失败提示的前缀（后接错误信息）
 	ImageNote       string // 带图 user 消息的说明文本
 	ContextTrimNote string // 轮内 token 预算回收时，替换最旧工具结果内容的占位文案
+	ActionDeclined  string // 写操作被用户拒绝或确认超时时的 tool 结果内容
 }
 
 // RunRequest 是 Loop 层对领域层（Copilot Service）暴露的请求。
@@ -124,26 +145,65 @@ type RunRequest struct {
 	// MaxContextTokens 是工具循环里整轮消息上下文的软上限（估算 token）；>0 时超限即回收
 	// 最旧的工具结果（替换为 Strings.ContextTrimNote），防多轮工具结果累积撑爆窗口。0 → 不回收。
 	MaxContextTokens int
+	// ConfirmTimeout 是写操作等待用户确认的上限，超时视为拒绝；<=0 → defaultConfirmTimeout。
+	// 等待同样受 Timeout 约束（整轮超时先到则整轮结束）。
+	ConfirmTimeout time.Duration
 }
 
 // AgentEventKind 区分 Loop 上浮给领域层的语义事件类型。
 type AgentEventKind int
 
 const (
-	AgentDelta      AgentEventKind = iota // 文本上屏（跨轮连续）
-	AgentReasoning                        // 推理上屏（reasoning，与答案分流，不入答案/不回灌模型）
-	AgentSearching                        // 模型决定调用工具（含 name + args）
-	AgentToolResult                       // 工具执行完（Meta 即 ToolOutput.Meta，供 sources）
-	AgentDone                             // 收尾
-	AgentError                            // 中止
+	AgentDelta         AgentEventKind = iota // 文本上屏（跨轮连续）
+	AgentReasoning                           // 推理上屏（reasoning，与答案分流，不入答案/不回灌模型）
+	AgentSearching                           // 模型决定调用工具（含 name + args）
+	AgentToolResult                          // 工具执行完（Meta 即 ToolOutput.Meta，供 sources）
+	AgentPendingAction                       // 写操作待确认（含 name + args + Approval），Loop 阻塞至决定
+	AgentDone                                // 收尾
+	AgentError                               // 中止
 )
 
 // AgentEvent 是 Loop→Copilot Service 的统一事件；语义翻译（searching/sources）在此完成。
 type AgentEvent struct {
 	Kind     AgentEventKind
 	Text     string          // AgentDelta / AgentReasoning
-	ToolName string          // AgentSearching
-	ToolArgs json.RawMessage // AgentSearching
+	ToolName string          // AgentSearching / AgentPendingAction
+	ToolArgs json.RawMessage // AgentSearching / AgentPendingAction
 	Meta     any             // AgentToolResult
 	Err      error           // AgentError
+	Approval *Approval       // AgentPendingAction：领域层据用户选择调用 Decide
+	Usage    Usage           // AgentDone：整轮（含工具循环各轮）累计的 token 用量
+}
+
+// Approval 是一次待确认写操作的回执通道。领域层收到 AgentPendingAction 后把它登记起来，
+// 等用户确认/拒绝时调用 Decide；Decide 幂等，只有第一次生效。
+//
+// ID 由领域层登记时填写（如推给前端的操作 ID）；确认后 Loop 把 Approval 挂进 Execute 的 ctx，
+// 执行体可经 ApprovalFromContext 取回，使审计记录与用户确认的是同一个 ID。
+type Approval struct {
+	ID    string
+	once  sync.Once
+	reply chan bool
+}
+
+type approvalKey struct{}
+
+// ApprovalFromContext 返回本次执行所依据的用户确认；非 Confirm 工具返回 nil。
+func ApprovalFromContext(ctx context.Context) *Approval {
+	a, _ := ctx.Value(approvalKey{}).(*Approval)
+	return a
+}
+
+func newApproval() *Approval {
+	return &Approval{reply: make(chan bool, 1)}
+}
+
+// Decide 提交用户的决定（approved=true 即执行）。不会阻塞：零值 Approval 上调用是空操作。
+func (a *Approval) Decide(approved bool) {
+	a.once.Do(func() {
+		select {
+		case a.reply <- approved:
+		default:
+		}
+	})
 }
@@ -7,9 +7,11 @@ import (
 	"context"
 
 	"github.com/google/wire"
+	"github.com/lin-snow/ech0/internal/bootstrap"
 	bus "github.com/lin-snow/ech0/internal/event/bus"
 	"github.com/lin-snow/ech0/internal/job"
 	"github.com/lin-snow/ech0/internal/kvstore"
+	"github.com/lin-snow/ech0/internal/mcp"
 	"github.com/lin-snow/ech0/internal/server"
 	"github.com/lin-snow/ech0/internal/setting"
 	"github.com/lin-snow/ech0/internal/task"
@@ -21,11 +23,13 @@ func ProvideOptions(
 	taskManager *task.Manager,
 	httpServer *server.Server,
 	durableKV kvstore.Store,
+	mcpNotifier *mcp.Notifier,
 ) []Option {
 	return []Option{
 		// jobManager 排在 httpServer 前：其 Start 做启动期孤儿清理，须先于对外服务。
 		// Runner 已在构造期装配进 jobManager、Task 已装配进 taskManager，无需额外注册步骤。
-		Components(jobManager, taskManager, httpServer),
+		// configReloader 监听 SIGHUP 热加载配置，放最后：服务就绪后才接受重载。
+		Components(jobManager, taskManager, httpServer, bootstrap.NewConfigReloader()),
 		// 启动期一次性副作用，按序执行且必早于任何 component.Start：
 		// 先 seed 缺失的配置 key（此后各读路径直接命中，Get 不再承担「读时 seed」副作用），
 		// 再注册事件订阅。
@@ -35,6 +39,11 @@ func ProvideOptions(
 			}
 			return registrar.Register()
 		}),
+		// MCP 订阅是长连接，不先收掉的话 httpServer.Shutdown 会一直等到超时。
+		BeforeStop(func(context.Context) error {
+			mcpNotifier.Close()
+			return nil
+		}),
 		AfterStop(func(context.Context) error {
 			return registrar.Stop()
 		}),
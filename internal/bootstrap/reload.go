@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package bootstrap
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/lin-snow/ech0/internal/config"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+// ConfigReloader 是监听 SIGHUP 并热加载配置的应用组件。
+// 只有 config 中标记为可热加载的项会生效；其余变更只记告警，提示需要重启。
+type ConfigReloader struct {
+	sigs chan os.Signal
+	done chan struct{}
+}
+
+func NewConfigReloader() *ConfigReloader {
+	return &ConfigReloader{}
+}
+
+func (r *ConfigReloader) Name() string { return "config_reloader" }
+
+func (r *ConfigReloader) Start(context.Context) error {
+	r.sigs = make(chan os.Signal, 1)
+	r.done = make(chan struct{})
+	signal.Notify(r.sigs, syscall.SIGHUP)
+	go func() {
+		defer close(r.done)
+		for range r.sigs {
+			ReloadConfig()
+		}
+	}()
+	return nil
+}
+
+func (r *ConfigReloader) Stop(context.Context) error {
+	if r.sigs == nil {
+		return nil
+	}
+	signal.Stop(r.sigs)
+	close(r.sigs)
+	<-r.done
+	r.sigs = nil
+	return nil
+}
+
+// ReloadConfig 执行一次热加载，并把需要主动推送的变更（日志级别）应用到对应模块。
+func ReloadConfig() {
+	result, err := config.Reload()
+	if err != nil {
+		logUtil.Error("config reload rejected, keeping current configuration",
+			slog.String("module", "config"), logUtil.Err(err))
+		return
+	}
+	for _, key := range result.Applied {
+		if key == "log.level" {
+			logUtil.SetLevel(config.Config().Log.Level)
+		}
+	}
+	logUtil.Info("config reloaded",
+		slog.String("module", "config"),
+		slog.String("applied", strings.Join(result.Applied, ",")))
+	if len(result.Pending) > 0 {
+		logUtil.Warn("config changes require a restart to take effect",
+			slog.String("module", "config"),
+			slog.String("keys", strings.Join(result.Pending, ",")))
+	}
+}
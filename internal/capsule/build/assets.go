@@ -115,6 +115,9 @@ var rootPathAttr = regexp.MustCompile(`\b(href|src)="/([^/"])`)
 // rootPathAttrBare 匹配恰好指向根的 href="/"（首页链接）。
 var rootPathAttrBare = regexp.MustCompile(`\b(href|src)="/"`)
 
+// apiDiscoveryLink 匹配 <link rel="micropub"> / <link rel="webmention"> 整行（连同行首缩进与换行）。
+var apiDiscoveryLink = regexp.MustCompile(`[ \t]*<link rel="(?:micropub|webmention)"[^>]*>\r?\n?`)
+
 // firstScriptTag 定位第一个 <script，注入点必须在它之前：SPA 的入口脚本一旦
 // 开跑就会读 window.__ECH0_STATIC__，开关晚到等于没有。
 var firstScriptTag = regexp.MustCompile(`<script[\s>]`)
@@ -133,6 +136,9 @@ func renderIndex(raw []byte, baseURL string) []byte {
 	// 静态站上那个路径不存在，改指真正落盘的 rss.xml，否则订阅入口是死链。
 	html = strings.Replace(html, `href="`+baseURL+`rss"`, `href="`+baseURL+`rss.xml"`, 1)
 
+	// Micropub / Webmention 发现链接指向 serve 模式的 API；静态站没有它们，留着只会让客户端撞 404。
+	html = apiDiscoveryLink.ReplaceAllString(html, "")
+
 	// 开关值走 JSON 编码，baseURL 里的引号 / 反斜杠不会撕开脚本。
 	snippet := fmt.Sprintf(
 		"<script>window.__ECH0_STATIC__=true;window.__ECH0_STATIC_BASE__=%s;</script>\n    ",
@@ -142,6 +142,7 @@ func bake(in bakeInput) (*dataset, error) {
 			SysUsername: loaded.Manifest.Owner.Username,
 			Version:     versionPkg.Version,
 		},
+		Related: bakeRelated(echos),
 	}
 	return ds, nil
 }
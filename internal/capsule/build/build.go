@@ -34,6 +34,8 @@ type Options struct {
 	Output string
 	// BaseURL 是站点部署的子路径前缀（如 /blog/）。空值等价于根部署。
 	BaseURL string
+	// Templates 是预渲染页面的模板覆盖目录；同名文件替换内嵌默认版。空值即全用默认。
+	Templates string
 }
 
 // Result 是一次烘焙的产出摘要。
@@ -42,10 +44,11 @@ type Result struct {
 	Echoes   int
 	Files    int
 	Comments int
+	Pages    int
 }
 
 // Run 执行烘焙：拷贝内嵌 SPA、产出 dataset.json 与各类静态端点、
-// 铺开媒体字节、改写入口 HTML。
+// 铺开媒体字节、改写入口 HTML，并预渲染详情 / 标签 / 归档页。
 func Run(ctx context.Context, loaded *capsule.Loaded, opts Options) (*Result, error) {
 	return run(ctx, loaded, opts, template.WebFS)
 }
@@ -62,6 +65,12 @@ func run(ctx context.Context, loaded *capsule.Loaded, opts Options, assets fs.FS
 	dir := filepath.Clean(opts.Output)
 	baseURL := NormalizeBaseURL(opts.BaseURL)
 
+	// 模板先于任何落盘解析：覆盖模板写错时不该留下一个半成品目录。
+	tpls, err := loadTemplates(opts.Templates)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := ensureEmptyDir(dir); err != nil {
 		return nil, err
 	}
@@ -112,6 +121,11 @@ func run(ctx context.Context, loaded *capsule.Loaded, opts Options, assets fs.FS
 		return nil, err
 	}
 
+	pages, err := renderPages(dir, ds, l, tpls)
+	if err != nil {
+		return nil, err
+	}
+
 	sitemap, err := renderSitemap(ds, l, generatedAt)
 	if err != nil {
 		return nil, err
@@ -125,6 +139,7 @@ func run(ctx context.Context, loaded *capsule.Loaded, opts Options, assets fs.FS
 		Echoes:   len(ds.Echos),
 		Files:    files,
 		Comments: len(ds.Comments),
+		Pages:    pages,
 	}, nil
 }
 
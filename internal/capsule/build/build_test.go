@@ -161,6 +161,8 @@ func fixtureSPA() fs.FS {
 <link rel="icon" href="/favicon.ico" />
 <link rel="manifest" href="/app.webmanifest" />
 <link rel="alternate" type="application/atom+xml" href="/rss" />
+<link rel="micropub" href="/api/micropub" />
+<link rel="webmention" href="/api/webmention" />
 <link rel="stylesheet" href="/assets/index-abc.css" />
 <script type="module" src="/assets/index-abc.js"></script>
 </head>
@@ -231,6 +233,8 @@ func TestRunBakesDataset(t *testing.T) {
 	assert.Contains(t, string(index), "window.__ECH0_STATIC__=true")
 	assert.Contains(t, string(index), `window.__ECH0_STATIC_BASE__="/"`)
 	assert.Contains(t, string(index), `href="/rss.xml"`, "feed link must point at the baked file")
+	assert.NotContains(t, string(index), `rel="micropub"`, "static sites have no Micropub endpoint")
+	assert.NotContains(t, string(index), `rel="webmention"`, "static sites have no Webmention endpoint")
 	notFound, err := os.ReadFile(filepath.Join(dir, "404.html"))
 	require.NoError(t, err)
 	assert.Equal(t, index, notFound)
@@ -293,6 +297,10 @@ func TestRunBakesDataset(t *testing.T) {
 	assert.Nil(t, ds.Comments[0].UserID)
 	assert.Contains(t, string(mustRead(t, filepath.Join(dir, "dataset.json"))), `"parent_id":null`)
 
+	// 相关推荐：两条 Echo 的共同词项遍布全站，区分度为零，不互相推荐；字段恒在。
+	assert.NotNil(t, ds.Related)
+	assert.Contains(t, string(mustRead(t, filepath.Join(dir, "dataset.json"))), `"related":{}`)
+
 	// 热力图：最近 30 天，末端是构建当天（UTC）。
 	require.Len(t, ds.Heatmap, heatmapDays)
 	assert.Equal(t, time.Now().UTC().Format(time.DateOnly), ds.Heatmap[heatmapDays-1].Date)
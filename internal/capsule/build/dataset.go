@@ -29,6 +29,10 @@ type dataset struct {
 	CommentForm commentForm    `json:"comment_form"`
 	Connects    []connectItem  `json:"connects"`
 	Connect     connectInfo    `json:"connect"`
+
+	// Related 是烘焙期预算的相关推荐（Echo id → 相关 id），供静态 adapter
+	// 应答 GET /echo/{id}/related。新增字段对旧前端无害，不升 schema_version。
+	Related map[string][]string `json:"related"`
 }
 
 // initStatus 对应 GET /init/status。静态站永远是「已初始化」。
@@ -7,6 +7,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	stdhtml "html"
+	"net/url"
 	"strings"
 	"time"
 
@@ -135,11 +136,11 @@ type sitemapURL struct {
 	Priority   string `xml:"priority,omitempty"`
 }
 
-// renderSitemap 生成 sitemap.xml：首页 + 每条 Echo 详情页。
+// renderSitemap 生成 sitemap.xml：首页 + 每条 Echo 详情页 + 每个标签页。
 func renderSitemap(ds *dataset, l links, generatedAt time.Time) ([]byte, error) {
 	set := sitemapURLSet{
 		XMLNS: "http://www.sitemaps.org/schemas/sitemap/0.9",
-		URLs:  make([]sitemapURL, 0, len(ds.Echos)+1),
+		URLs:  make([]sitemapURL, 0, len(ds.Echos)+len(ds.Tags)+1),
 	}
 	set.URLs = append(set.URLs, sitemapURL{
 		Loc:        l.home,
@@ -157,6 +158,14 @@ func renderSitemap(ds *dataset, l links, generatedAt time.Time) ([]byte, error)
 			Priority:   "0.8",
 		})
 	}
+	for _, t := range ds.Tags {
+		set.URLs = append(set.URLs, sitemapURL{
+			Loc:        l.home + "tags/" + url.PathEscape(tagSlug(t.Name, t.ID)) + "/",
+			LastMod:    generatedAt.UTC().Format(time.DateOnly),
+			ChangeFreq: "weekly",
+			Priority:   "0.5",
+		})
+	}
 
 	body, err := xml.MarshalIndent(set, "", "  ")
 	if err != nil {
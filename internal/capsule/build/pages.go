@@ -0,0 +1,381 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package build
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	stdhtml "html"
+	"html/template"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lin-snow/ech0/internal/storage"
+	mdUtil "github.com/lin-snow/ech0/internal/util/md"
+)
+
+// 预渲染页面与 SPA 并存：index.html 仍是 SPA 入口，下面这些页面则是不依赖
+// JS 的纯 HTML，给搜索引擎、社交卡片抓取器与禁用脚本的读者看。
+// 详情页落在 echo/<id>/index.html，正好是 SPA 深链 /echo/:id 的位置——
+// 静态托管优先命中真实文件，爬虫拿到的就是有内容的页面而不是空壳。
+
+// archivePageSize 是归档页每页条数。
+const archivePageSize = 20
+
+// descriptionLimit 是 description / og:description 摘要的最大字符数。
+const descriptionLimit = 160
+
+//go:embed templates/*.html
+var defaultTemplates embed.FS
+
+// 模板文件名即覆盖约定：--templates 目录里出现同名文件就整份替换内嵌默认版。
+// layout / partials 被每种页面共享；页面模板只需定义 "content"。
+const (
+	layoutTemplate   = "layout.html"
+	partialsTemplate = "partials.html"
+	echoTemplate     = "echo.html"
+	tagTemplate      = "tag.html"
+	archiveTemplate  = "archive.html"
+)
+
+var pageTemplateNames = []string{echoTemplate, tagTemplate, archiveTemplate}
+
+// templateFuncs 是模板里可用的辅助函数。刻意只给最小集合：
+// 覆盖模板的站长能依赖的越少，日后改动越不容易破坏别人的主题。
+var templateFuncs = template.FuncMap{
+	"date": func(layout string, t time.Time) string { return t.Format(layout) },
+}
+
+// siteView 是每个页面都带的站点级信息。链接一律是站内绝对根路径（带 baseURL），
+// 页面所在深度不同也不必算相对路径。
+type siteView struct {
+	Title   string
+	Locale  string
+	Footer  string
+	Home    string
+	Archive string
+	Feed    string
+}
+
+// pageView 是页面模板的根对象；按页面种类只填其中一部分。
+type pageView struct {
+	Site        siteView
+	Title       string
+	Description string
+	Canonical   string
+	Image       string
+	OGType      string
+
+	Echo     *echoView     // echo.html
+	Comments []commentView // echo.html
+	Tag      *tagLink      // tag.html
+	Echoes   []echoView    // tag.html / archive.html
+	Tags     []tagLink     // archive.html
+	Pager    pager         // archive.html
+}
+
+type echoView struct {
+	ID        string
+	URL       string
+	Username  string
+	CreatedAt time.Time
+	Content   template.HTML
+	Media     []mediaView
+	Tags      []tagLink
+
+	description string
+	image       string
+}
+
+type mediaView struct {
+	URL      string
+	Name     string
+	Category string
+}
+
+type tagLink struct {
+	Name  string
+	URL   string
+	Count int
+
+	dir string // 相对站点根的落盘目录（未转义）
+}
+
+type commentView struct {
+	Nickname  string
+	Website   string
+	Content   string
+	CreatedAt time.Time
+}
+
+type pager struct {
+	Page  int
+	Total int
+	Prev  string
+	Next  string
+}
+
+// loadTemplates 组装三套页面模板。dir 为空即全用内嵌默认版；否则 dir 里的同名
+// 文件逐个覆盖默认版，未出现的沿用默认——站长只改详情页时不必抄一整套。
+// dir 里出现不认得的 .html 直接报错：拼错文件名的覆盖静默不生效，比报错更难查。
+func loadTemplates(dir string) (map[string]*template.Template, error) {
+	read := func(name string) ([]byte, error) {
+		return fs.ReadFile(defaultTemplates, "templates/"+name)
+	}
+	if dir != "" {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("read templates directory %s: %w", dir, err)
+		}
+		known := map[string]struct{}{layoutTemplate: {}, partialsTemplate: {}}
+		for _, name := range pageTemplateNames {
+			known[name] = struct{}{}
+		}
+		for _, e := range entries {
+			if e.IsDir() || filepath.Ext(e.Name()) != ".html" {
+				continue
+			}
+			if _, ok := known[e.Name()]; !ok {
+				return nil, fmt.Errorf("unknown template %s in %s", e.Name(), dir)
+			}
+		}
+		read = func(name string) ([]byte, error) {
+			data, err := os.ReadFile(filepath.Join(dir, name))
+			if errors.Is(err, fs.ErrNotExist) {
+				return fs.ReadFile(defaultTemplates, "templates/"+name)
+			}
+			return data, err
+		}
+	}
+
+	base := template.New("pages").Funcs(templateFuncs)
+	for _, name := range []string{layoutTemplate, partialsTemplate} {
+		src, err := read(name)
+		if err != nil {
+			return nil, fmt.Errorf("read template %s: %w", name, err)
+		}
+		if _, err := base.New(name).Parse(string(src)); err != nil {
+			return nil, fmt.Errorf("parse template %s: %w", name, err)
+		}
+	}
+
+	out := make(map[string]*template.Template, len(pageTemplateNames))
+	for _, name := range pageTemplateNames {
+		src, err := read(name)
+		if err != nil {
+			return nil, fmt.Errorf("read template %s: %w", name, err)
+		}
+		set, err := base.Clone()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := set.New(name).Parse(string(src)); err != nil {
+			return nil, fmt.Errorf("parse template %s: %w", name, err)
+		}
+		out[name] = set
+	}
+	return out, nil
+}
+
+// renderPages 产出全部预渲染页面，返回页面数。
+func renderPages(dir string, ds *dataset, l links, tpls map[string]*template.Template) (int, error) {
+	base := ds.BaseURL
+	title := ds.Settings.SiteTitle
+	if title == "" {
+		title = "Ech0"
+	}
+	site := siteView{
+		Title:   title,
+		Locale:  ds.Settings.DefaultLocale,
+		Footer:  ds.Settings.FooterContent,
+		Home:    base,
+		Archive: base + "archive/",
+		Feed:    base + "rss.xml",
+	}
+	siteDescription := ds.Settings.ServerName
+	if siteDescription == "" {
+		siteDescription = title
+	}
+
+	tagLinks := make(map[string]tagLink, len(ds.Tags))
+	allTags := make([]tagLink, 0, len(ds.Tags))
+	for _, t := range ds.Tags {
+		slug := tagSlug(t.Name, t.ID)
+		tl := tagLink{
+			Name:  t.Name,
+			URL:   base + "tags/" + url.PathEscape(slug) + "/",
+			Count: t.UsageCount,
+			dir:   "tags/" + slug + "/",
+		}
+		tagLinks[t.Name] = tl
+		allTags = append(allTags, tl)
+	}
+
+	// 只展示已通过审核的评论：待审 / 拒绝的评论进了公开页面就等于绕过审核。
+	commentsByEcho := make(map[string][]commentView)
+	for _, c := range ds.Comments {
+		if c.Status != "approved" {
+			continue
+		}
+		commentsByEcho[c.EchoID] = append(commentsByEcho[c.EchoID], commentView{
+			Nickname:  c.Nickname,
+			Website:   c.Website,
+			Content:   c.Content,
+			CreatedAt: time.Unix(c.CreatedAt, 0).UTC(),
+		})
+	}
+
+	views := make([]echoView, 0, len(ds.Echos))
+	byTag := make(map[string][]echoView)
+	for i := range ds.Echos {
+		v := newEchoView(&ds.Echos[i], base, l, tagLinks)
+		views = append(views, v)
+		for _, t := range ds.Echos[i].Tags {
+			byTag[t.Name] = append(byTag[t.Name], v)
+		}
+	}
+
+	n := 0
+	write := func(name, rel string, page pageView) error {
+		var buf bytes.Buffer
+		if err := tpls[name].ExecuteTemplate(&buf, "layout", page); err != nil {
+			return fmt.Errorf("render %s: %w", rel, err)
+		}
+		n++
+		return writeFile(dir, rel+"index.html", buf.Bytes())
+	}
+
+	for i := range views {
+		v := &views[i]
+		if err := write(echoTemplate, "echo/"+v.ID+"/", pageView{
+			Site:        site,
+			Title:       v.Username + " - " + v.CreatedAt.Format(time.DateOnly),
+			Description: v.description,
+			Canonical:   l.echoPrefix + v.ID,
+			Image:       v.image,
+			OGType:      "article",
+			Echo:        v,
+			Comments:    commentsByEcho[v.ID],
+		}); err != nil {
+			return n, err
+		}
+	}
+
+	for _, t := range allTags {
+		tag := t
+		if err := write(tagTemplate, t.dir, pageView{
+			Site:        site,
+			Title:       "#" + t.Name + " - " + title,
+			Description: siteDescription,
+			Canonical:   l.home + strings.TrimPrefix(t.URL, base),
+			OGType:      "website",
+			Tag:         &tag,
+			Echoes:      byTag[t.Name],
+		}); err != nil {
+			return n, err
+		}
+	}
+
+	// 空站也要有第 1 页：页眉的「归档」链接不能指向 404。
+	pages := max(1, (len(views)+archivePageSize-1)/archivePageSize)
+	for p := 1; p <= pages; p++ {
+		lo := (p - 1) * archivePageSize
+		hi := min(lo+archivePageSize, len(views))
+		pg := pager{Page: p, Total: pages}
+		if p > 1 {
+			pg.Prev = base + archivePagePath(p-1)
+		}
+		if p < pages {
+			pg.Next = base + archivePagePath(p+1)
+		}
+		pageTitle := title
+		if p > 1 {
+			pageTitle = title + " - " + strconv.Itoa(p)
+		}
+		if err := write(archiveTemplate, archivePagePath(p), pageView{
+			Site:        site,
+			Title:       pageTitle,
+			Description: siteDescription,
+			Canonical:   l.home + archivePagePath(p),
+			OGType:      "website",
+			Echoes:      views[lo:hi],
+			Tags:        allTags,
+			Pager:       pg,
+		}); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// newEchoView 把 dataset 里的 Echo 投影成模板视图：正文走与 RSS 同一个 Markdown
+// 渲染器（丢弃原始 HTML，见 mdUtil.MdToHTML），摘要与首图供 OG/Twitter 卡片使用。
+func newEchoView(e *echo, base string, l links, tagLinks map[string]tagLink) echoView {
+	rendered := mdUtil.MdToHTML([]byte(e.Content))
+	v := echoView{
+		ID:          e.ID,
+		URL:         base + "echo/" + e.ID + "/",
+		Username:    e.Username,
+		CreatedAt:   time.Unix(e.CreatedAt, 0).UTC(),
+		Content:     template.HTML(rendered),
+		description: excerpt(rendered),
+	}
+	for _, ef := range e.EchoFiles {
+		if ef.File.URL == "" {
+			continue
+		}
+		category := string(storage.NormalizeCategory(ef.File.Category))
+		v.Media = append(v.Media, mediaView{URL: ef.File.URL, Name: ef.File.Name, Category: category})
+		if v.image == "" && category == string(storage.CategoryImage) {
+			// 社交卡片抓取器不在本站上下文里解析，首图必须尽量绝对化。
+			v.image = l.resolve(ef.File.URL)
+		}
+	}
+	for _, t := range e.Tags {
+		v.Tags = append(v.Tags, tagLinks[t.Name])
+	}
+	return v
+}
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// excerpt 从渲染后的 HTML 里取纯文本摘要：去标签、反转义、折叠空白、按字符截断。
+func excerpt(rendered []byte) string {
+	text := stdhtml.UnescapeString(htmlTag.ReplaceAllString(string(rendered), " "))
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= descriptionLimit {
+		return text
+	}
+	runes := []rune(text)
+	return string(runes[:descriptionLimit]) + "…"
+}
+
+// tagSlug 是标签页的目录名。磁盘上用标签原名（托管会先解码 URL 再找文件），
+// 链接里另做路径转义；原名当不了目录名（含分隔符、. / ..、控制字符）时退回
+// 派生 id，免得标签名把页面写到输出目录外面去。
+func tagSlug(name, id string) string {
+	slug := name
+	if slug == "." || slug == ".." || strings.ContainsAny(slug, `/\`) ||
+		strings.ContainsFunc(slug, func(r rune) bool { return r < 0x20 || r == 0x7f }) {
+		slug = id
+	}
+	return slug
+}
+
+// archivePagePath 是第 p 页归档相对站点根的目录；第 1 页就是 archive/ 本身。
+func archivePagePath(p int) string {
+	if p <= 1 {
+		return "archive/"
+	}
+	return "archive/" + strconv.Itoa(p) + "/"
+}
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package build
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/capsule"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 预渲染页面：详情 / 标签 / 归档都落盘，且带 canonical 与社交卡片标签。
+func TestRunRendersPages(t *testing.T) {
+	dir, res := runBuild(t, "/blog/")
+	// 2 条详情 + 2 个标签（life / tech）+ 1 页归档。
+	assert.Equal(t, 5, res.Pages)
+
+	page := string(mustRead(t, filepath.Join(dir, "echo", newEchoID, "index.html")))
+	assert.Contains(t, page, "<p>newer echo</p>")
+	assert.Contains(t, page, `<link rel="canonical" href="/blog/echo/`+newEchoID+`">`)
+	assert.Contains(t, page, `<meta property="og:type" content="article">`)
+	assert.Contains(t, page, `<meta property="og:image" content="/blog/api/files/images/`+imageKey+`">`)
+	assert.Contains(t, page, `<meta name="twitter:card" content="summary_large_image">`)
+	assert.Contains(t, page, `<meta name="description" content="newer echo">`)
+	assert.Contains(t, page, `href="/blog/tags/tech/"`)
+	assert.Contains(t, page, "nice", "approved comment is rendered")
+
+	old := string(mustRead(t, filepath.Join(dir, "echo", oldEchoID, "index.html")))
+	assert.Contains(t, old, `<meta name="twitter:card" content="summary">`)
+
+	// private Echo 不出详情页。
+	assert.NoFileExists(t, filepath.Join(dir, "echo", "33333333-3333-4333-8333-333333333333", "index.html"))
+
+	tag := string(mustRead(t, filepath.Join(dir, "tags", "life", "index.html")))
+	assert.Contains(t, tag, "newer echo")
+	assert.Contains(t, tag, "older echo")
+
+	archive := string(mustRead(t, filepath.Join(dir, "archive", "index.html")))
+	assert.Contains(t, archive, `href="/blog/echo/`+oldEchoID+`/"`)
+	assert.NotContains(t, archive, "secret")
+
+	sitemap := string(mustRead(t, filepath.Join(dir, "sitemap.xml")))
+	assert.Contains(t, sitemap, "/blog/tags/tech/")
+}
+
+func TestRunTemplateOverride(t *testing.T) {
+	ctx := context.Background()
+	src, err := capsule.Open(writeCapsule(t))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = src.Close() })
+	loaded, err := capsule.Load(ctx, src)
+	require.NoError(t, err)
+
+	tplDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tplDir, echoTemplate),
+		[]byte(`{{define "content"}}<p class="custom">{{.Echo.Username}}</p>{{end}}`), 0o644))
+
+	out := filepath.Join(t.TempDir(), "dist")
+	_, err = run(ctx, loaded, Options{Output: out, Templates: tplDir}, fixtureSPA())
+	require.NoError(t, err)
+
+	page := string(mustRead(t, filepath.Join(out, "echo", newEchoID, "index.html")))
+	assert.Contains(t, page, `<p class="custom">tester</p>`)
+	assert.Contains(t, page, `<link rel="canonical"`, "layout falls back to the built-in one")
+
+	// 拼错的文件名必须报错，且不留下半成品目录。
+	require.NoError(t, os.WriteFile(filepath.Join(tplDir, "detail.html"), []byte(""), 0o644))
+	bad := filepath.Join(t.TempDir(), "dist")
+	_, err = run(ctx, loaded, Options{Output: bad, Templates: tplDir}, fixtureSPA())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown template")
+	assert.NoDirExists(t, bad)
+}
+
+func TestTagSlug(t *testing.T) {
+	assert.Equal(t, "生活", tagSlug("生活", "id"))
+	assert.Equal(t, "id", tagSlug("..", "id"))
+	assert.Equal(t, "id", tagSlug("a/b", "id"))
+	assert.Equal(t, "id", tagSlug("a\nb", "id"))
+}
@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package build
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// relatedLimit 是每条 Echo 烘焙的相关推荐条数，与活实例 GET /echo/{id}/related 的缺省 limit 一致。
+const relatedLimit = 5
+
+// tagTermWeight 是标签词项相对正文词项的权重：作者亲手打的标签比正文里
+// 偶然同现的字眼更能说明「讲的是同一件事」。
+const tagTermWeight = 3
+
+// bakeRelated 为每条公开 Echo 预算相关推荐：静态站没有向量索引，这里退而用
+// 正文 + 标签的 TF-IDF 余弦相似度。结果是 Echo id → 相关 id（相似度降序）；
+// 没有任何相似条目的 Echo 不出现在 map 里。
+//
+// 输入已按 created_at 降序，同分时保留数组序（新者在前），保证重复 build 逐字一致。
+func bakeRelated(echos []echo) map[string][]string {
+	out := make(map[string][]string)
+	if len(echos) < 2 {
+		return out
+	}
+
+	docs := make([]map[string]float64, len(echos))
+	df := make(map[string]int)
+	for i, e := range echos {
+		tf := make(map[string]float64)
+		for _, term := range terms(e.Content) {
+			tf[term]++
+		}
+		for _, name := range e.tagNames {
+			tf["#"+strings.ToLower(name)] += tagTermWeight
+		}
+		docs[i] = tf
+		for term := range tf {
+			df[term]++
+		}
+	}
+
+	// 过半数 Echo 都出现的词项（虚词、站点惯用语）区分度近乎为零，直接丢弃，
+	// 顺带让倒排表保持稀疏。只出现一次的词项不可能贡献相似度，同样不入表。
+	n := float64(len(echos))
+	common := len(echos) / 2
+	postings := make(map[string][]int)
+	norms := make([]float64, len(echos))
+	for i, tf := range docs {
+		for term, count := range tf {
+			if df[term] < 2 || (len(echos) >= 4 && df[term] > common) {
+				delete(tf, term)
+				continue
+			}
+			w := (1 + math.Log(count)) * math.Log(n/float64(df[term]))
+			tf[term] = w
+			norms[i] += w * w
+			postings[term] = append(postings[term], i)
+		}
+		norms[i] = math.Sqrt(norms[i])
+	}
+
+	type scored struct {
+		idx   int
+		score float64
+	}
+	for i, tf := range docs {
+		if norms[i] == 0 {
+			continue
+		}
+		dots := make(map[int]float64)
+		for term, w := range tf {
+			for _, j := range postings[term] {
+				if j != i {
+					dots[j] += w * docs[j][term]
+				}
+			}
+		}
+		cands := make([]scored, 0, len(dots))
+		for j, dot := range dots {
+			cands = append(cands, scored{idx: j, score: dot / (norms[i] * norms[j])})
+		}
+		sort.Slice(cands, func(a, b int) bool {
+			if cands[a].score != cands[b].score {
+				return cands[a].score > cands[b].score
+			}
+			return cands[a].idx < cands[b].idx
+		})
+		if len(cands) > relatedLimit {
+			cands = cands[:relatedLimit]
+		}
+		ids := make([]string, 0, len(cands))
+		for _, c := range cands {
+			ids = append(ids, echos[c.idx].ID)
+		}
+		if len(ids) > 0 {
+			out[echos[i].ID] = ids
+		}
+	}
+	return out
+}
+
+// terms 把正文切成词项：拉丁字母 / 数字连续段按词（小写，至少两个字符），
+// 中日韩连续段按相邻二字（单字段保留单字）——不引入分词器也能让「咖啡豆」与
+// 「手冲咖啡」因「咖啡」相连。
+func terms(content string) []string {
+	var out []string
+	var word []rune
+	var cjk []rune
+	flushWord := func() {
+		if len(word) >= 2 {
+			out = append(out, string(word))
+		}
+		word = word[:0]
+	}
+	flushCJK := func() {
+		switch {
+		case len(cjk) == 1:
+			out = append(out, string(cjk))
+		case len(cjk) > 1:
+			for i := 0; i+1 < len(cjk); i++ {
+				out = append(out, string(cjk[i:i+2]))
+			}
+		}
+		cjk = cjk[:0]
+	}
+
+	for _, r := range strings.ToLower(content) {
+		switch {
+		case isCJK(r):
+			flushWord()
+			cjk = append(cjk, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+	return out
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
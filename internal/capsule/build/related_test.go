@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerms(t *testing.T) {
+	assert.Equal(t, []string{"手冲", "冲咖", "咖啡", "v60", "好"},
+		terms("手冲咖啡 V60 a 好"))
+}
+
+func TestBakeRelated(t *testing.T) {
+	echos := []echo{
+		{ID: "coffee-1", Content: "今天手冲咖啡，耶加雪菲", tagNames: []string{"coffee"}},
+		{ID: "coffee-2", Content: "咖啡豆到了，耶加雪菲真香", tagNames: []string{"coffee"}},
+		{ID: "go-1", Content: "Go generics and iterators", tagNames: []string{"tech"}},
+		{ID: "go-2", Content: "iterators in Go are neat", tagNames: []string{"tech"}},
+		{ID: "lonely", Content: "完全无关的一条"},
+	}
+
+	got := bakeRelated(echos)
+
+	assert.Equal(t, []string{"coffee-2"}, got["coffee-1"])
+	assert.Equal(t, []string{"coffee-1"}, got["coffee-2"])
+	assert.Equal(t, []string{"go-2"}, got["go-1"])
+	assert.NotContains(t, got, "lonely", "echos without any overlap are omitted")
+
+	// 重复烘焙必须逐字一致。
+	assert.Equal(t, got, bakeRelated(echos))
+	assert.Empty(t, bakeRelated(echos[:1]))
+}
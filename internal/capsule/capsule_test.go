@@ -30,13 +30,19 @@ func TestSiteKeysMatchSystemSetting(t *testing.T) {
 
 // TestFileRefKeysAreFileColumns 守卫 files[] 与 File 列的 1:1 关系：胶囊里的每个键
 // 都必须是真实存在的 File 列，且被明确排除的运行时拓扑列不得混进来。
+// 唯一豁免是完整性字段 sha256：它只服务于 check，不入库。
 func TestFileRefKeysAreFileColumns(t *testing.T) {
+	const integrityOnly = "sha256"
+
 	fileCols := tagSet(t, reflect.TypeOf(fileModel.File{}), "json")
 	excluded := map[string]struct{}{
 		"storage_type": {}, "provider": {}, "bucket": {}, "user_id": {}, "created_at": {},
 	}
 
 	for k := range tagSet(t, reflect.TypeOf(FileRef{}), "yaml") {
+		if k == integrityOnly {
+			continue
+		}
 		if _, ok := fileCols[k]; !ok {
 			t.Errorf("FileRef.%s 不是 File 的列", k)
 		}
@@ -13,20 +13,32 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 
 	"github.com/lin-snow/ech0/internal/capsule"
 )
 
-// Options 是校验开关。Fix 目前只覆盖 spec §7 唯一列入的自动修复项
-// （缺失 id → 生成 UUIDv7 回写）；扩展修复项必须先进规格。
+// Options 是校验开关。Fix 只覆盖 spec §7 列入的自动修复项（缺失 id → 生成 UUIDv7；
+// 深度模式下再补全 files[] 的 size 与 sha256）；扩展修复项必须先进规格。
 type Options struct {
 	Fix bool
+
+	// Deep 打开深度校验（spec §7.1）：逐个读出媒体字节核对 sha256 与大小，
+	// 并把正文里的媒体链接纳入引用与缺失判定。import / build 不开它。
+	Deep bool
+	// CheckURLs 在深度模式下对外链逐个发 HEAD 请求；不可达只告警。
+	CheckURLs bool
+	// URLConcurrency / URLRate 是外链探测的并发数与每秒请求数上限，<=0 取默认值。
+	URLConcurrency int
+	URLRate        float64
+	// HTTPClient 供测试注入；nil 时用带超时的默认客户端。
+	HTTPClient *http.Client
 }
 
 // Validate 对已加载的胶囊执行全部校验规则。
 //
-// 除 --fix 的写回失败外不返回 error：一切内容缺陷都进 Report，好让用户
+// 除 --fix 的写回失败与 ctx 取消外不返回 error：一切内容缺陷都进 Report，好让用户
 // 一次看全问题清单，而不是修一个跑一次。
 func Validate(ctx context.Context, loaded *capsule.Loaded, opts Options) (*Report, error) {
 	if err := ctx.Err(); err != nil {
@@ -48,16 +60,31 @@ func Validate(ctx context.Context, loaded *capsule.Loaded, opts Options) (*Repor
 		site = loaded.Manifest.Site
 	}
 
+	var digests map[string]mediaDigest
+	if opts.Deep {
+		var err error
+		if digests, err = digestMedia(ctx, loaded); err != nil {
+			return nil, err
+		}
+	}
+
 	// Echo 先走：它建立 referenced 集合，清单里的 files 块随后往同一个集合里加，
 	// 两者合起来才是「有人认领的媒体」，悬空判定必须在其后。
-	echoIDs, referenced, err := validateEchoes(r, loaded, opts, site.ServerURL)
+	echoIDs, referenced, err := validateEchoes(r, loaded, opts, site.ServerURL, digests)
 	if err != nil {
 		return nil, err
 	}
-	validateManifest(r, loaded, site, referenced)
+	if err := validateManifest(r, loaded, opts, site, referenced, digests); err != nil {
+		return nil, err
+	}
 	validateComments(r, loaded, echoIDs)
 	validateMedia(r, loaded, referenced, site)
 	validatePaths(r, loaded)
+	if opts.Deep && opts.CheckURLs {
+		if err := checkExternalURLs(ctx, r, loaded, site, opts); err != nil {
+			return nil, err
+		}
+	}
 
 	sortIssues(r.Issues)
 	return r, nil
@@ -328,6 +328,38 @@ comments:
 	}
 }
 
+// TestValidateTombstones 锁定 deleted 块的规则：只能出现在增量胶囊里，每条都要能执行。
+func TestValidateTombstones(t *testing.T) {
+	deleted := `deleted:
+  - kind: echo
+    id: ` + echoID + `
+    deleted_at: 2026-01-02T03:04:05Z
+  - kind: tag
+    id: ""
+    deleted_at: yesterday
+`
+	dir := buildCapsule(t, map[string]string{
+		capsule.ManifestPath: "schema_version: 1\nowner:\n  username: alice\n" + deleted,
+	})
+	report := runCheck(t, dir, Options{})
+	for _, field := range []string{"deleted", "deleted[1].kind", "deleted[1].id", "deleted[1].deleted_at"} {
+		if findIssue(report, LevelError, capsule.ManifestPath, field) == nil {
+			t.Errorf("缺少 [%s] 的 error:%s", field, dumpIssues(report))
+		}
+	}
+	if findIssue(report, LevelError, capsule.ManifestPath, "deleted[0].kind") != nil {
+		t.Errorf("合法的删除记录不该报错:%s", dumpIssues(report))
+	}
+
+	dir = buildCapsule(t, map[string]string{
+		capsule.ManifestPath: "schema_version: 1\nsince: 2026-01-01T00:00:00Z\nowner:\n  username: alice\n" + deleted,
+	})
+	report = runCheck(t, dir, Options{})
+	if findIssue(report, LevelError, capsule.ManifestPath, "deleted") != nil {
+		t.Errorf("增量胶囊可以带删除记录:%s", dumpIssues(report))
+	}
+}
+
 // TestFixRejectsArchiveCapsule 锁定 --fix 的前置门：zip 无法就地改写，
 // 与其改一半不如在动手前拒绝。
 func TestFixRejectsArchiveCapsule(t *testing.T) {
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package check
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/lin-snow/ech0/internal/capsule"
+)
+
+// mediaDigest 是深度模式下一个媒体文件实际读出的字节数与 SHA-256。
+// err 非空表示读取失败，由引用它的 files[] 条目上报。
+type mediaDigest struct {
+	size int64
+	sum  string
+	err  error
+}
+
+// digestMedia 逐个读出 files/ 下的全部字节。单个文件读失败只记在它自己名下，
+// 只有 ctx 取消会中断——与 Load 一样，先一次把问题收全。
+func digestMedia(ctx context.Context, loaded *capsule.Loaded) (map[string]mediaDigest, error) {
+	out := make(map[string]mediaDigest, len(loaded.MediaPaths))
+	for _, p := range sortedMediaPaths(loaded.MediaPaths) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		out[p] = digestFile(ctx, loaded.Source, p)
+	}
+	return out, nil
+}
+
+func digestFile(ctx context.Context, src *capsule.Source, p string) mediaDigest {
+	rc, err := src.FS.Get(ctx, p)
+	if err != nil {
+		return mediaDigest{err: err}
+	}
+	defer func() { _ = rc.Close() }()
+	h := sha256.New()
+	n, err := io.Copy(h, rc)
+	if err != nil {
+		return mediaDigest{err: err}
+	}
+	return mediaDigest{size: n, sum: hex.EncodeToString(h.Sum(nil))}
+}
+
+func isSHA256(s string) bool {
+	if len(s) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+var (
+	// markdownLink 匹配 [text](target) 与 ![alt](target) 的 target，允许 <…> 包裹。
+	markdownLink = regexp.MustCompile(`!?\[[^\]]*\]\(\s*<?([^)\s>]+)`)
+	// htmlLink 匹配正文里内联 HTML 的 src / href。
+	htmlLink = regexp.MustCompile(`(?i)\b(?:src|href)\s*=\s*["']([^"']+)["']`)
+	// bareURL 匹配 GFM 自动链接：渲染器会把裸 URL 变成链接，读者看到的也是链接。
+	bareURL = regexp.MustCompile(`https?://[^\s<>()\[\]"']+`)
+)
+
+// contentLinks 提取正文里的全部链接目标，去重并保持首次出现的顺序。
+func contentLinks(content string) []string {
+	seen := make(map[string]struct{})
+	var out []string
+	add := func(link string) {
+		link = strings.TrimRight(link, ".,;:!?")
+		if link == "" {
+			return
+		}
+		if _, ok := seen[link]; ok {
+			return
+		}
+		seen[link] = struct{}{}
+		out = append(out, link)
+	}
+	for _, m := range markdownLink.FindAllStringSubmatch(content, -1) {
+		add(m[1])
+	}
+	for _, m := range htmlLink.FindAllStringSubmatch(content, -1) {
+		add(m[1])
+	}
+	for _, m := range bareURL.FindAllString(content, -1) {
+		add(m)
+	}
+	return out
+}
+
+// contentMedia 把正文链接里指向胶囊媒体的那些换算成胶囊内路径（排序去重）。
+func contentMedia(content, serverURL string) []string {
+	host := serverHost(serverURL)
+	seen := make(map[string]struct{})
+	for _, link := range contentLinks(content) {
+		if p, ok := linkMedia(link, host); ok {
+			seen[p] = struct{}{}
+		}
+	}
+
+	out := make([]string, 0, len(seen))
+	for p := range seen {
+		out = append(out, p)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// linkMedia 报告一个链接是否指向胶囊媒体，是则返回胶囊内路径。认两种写法：serve 模式的
+// /api/files/<路径>（相对，或以 server_url 为主机），以及手写胶囊里的相对 files/<路径>。
+// 别的主机上的 /api/files/ 属于别的实例，不算。
+func linkMedia(link, host string) (string, bool) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", false
+	}
+	if u.Host != "" && (host == "" || !strings.EqualFold(u.Host, host)) {
+		return "", false
+	}
+	var rel string
+	if _, after, ok := strings.Cut(u.Path, apiFilesMarker); ok {
+		rel = after
+	} else if strings.HasPrefix(u.Path, capsule.FilesDir+"/") {
+		rel = strings.TrimPrefix(u.Path, capsule.FilesDir+"/")
+	} else {
+		return "", false
+	}
+	if rel == "" || hasTraversal(rel) {
+		return "", false
+	}
+	return path.Join(capsule.FilesDir, rel), true
+}
+
+func serverHost(serverURL string) string {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
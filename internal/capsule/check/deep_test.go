@@ -0,0 +1,205 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package check
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/capsule"
+)
+
+const (
+	catSHA256     = "586be01d1ba1032ab26d754014253014de8e16e94c18bdfe231b57fb78cbaac2"
+	deepManifest  = "schema_version: 1\nsite:\n  server_url: https://demo.example\nowner:\n  username: alice\n"
+	otherEchoPath = "echoes/2026/2026-01-02-00000002.md"
+)
+
+func deepEcho(files, body string) string {
+	return "---\nid: " + echoID + "\ncreated_at: 2026-01-01T00:00:00Z\n" + files + "---\n" + body
+}
+
+// TestDeepVerifiesBytes 覆盖深度模式的字节核对：摘要不符是 error，大小按实际读出的字节比。
+func TestDeepVerifiesBytes(t *testing.T) {
+	dir := buildCapsule(t, map[string]string{
+		capsule.ManifestPath: deepManifest,
+		echoPath: deepEcho(`files:
+  - key: cat.png
+    size: 9
+    sha256: `+catSHA256+`
+  - key: dog.png
+    sha256: `+strings.Repeat("0", 64)+`
+  - key: cow.png
+    sha256: not-a-digest
+`, "body\n"),
+		"files/images/cat.png": catBytes,
+		"files/images/dog.png": "dog",
+		"files/images/cow.png": "cow",
+	})
+
+	// 浅校验不读字节：摘要不符发现不了，只有格式错误能报。
+	shallow := runCheck(t, dir, Options{})
+	if findIssue(shallow, LevelError, echoPath, "files[1].sha256") != nil {
+		t.Fatalf("浅校验不应读字节核对摘要:%s", dumpIssues(shallow))
+	}
+	if findIssue(shallow, LevelError, echoPath, "files[2].sha256") == nil {
+		t.Fatalf("非法 sha256 格式应报 error:%s", dumpIssues(shallow))
+	}
+
+	deep := runCheck(t, dir, Options{Deep: true})
+	if findIssue(deep, LevelError, echoPath, "files[0].sha256") != nil {
+		t.Errorf("摘要相符不应报错:%s", dumpIssues(deep))
+	}
+	if it := findIssue(deep, LevelError, echoPath, "files[1].sha256"); it == nil || !strings.Contains(it.Message, "hash mismatch") {
+		t.Errorf("摘要不符应报 error:%s", dumpIssues(deep))
+	}
+}
+
+// TestDeepContentMedia 覆盖正文里的媒体链接：缺字节是 error，被正文引用的媒体不再算悬空。
+func TestDeepContentMedia(t *testing.T) {
+	dir := buildCapsule(t, map[string]string{
+		capsule.ManifestPath: deepManifest,
+		echoPath: deepEcho("", "![a](/api/files/images/inline.png)\n"+
+			"<img src=\"https://demo.example/api/files/images/gone.png\">\n"+
+			"![c](https://other.example/api/files/images/theirs.png)\n"),
+		"files/images/inline.png": "inline",
+		"files/images/orphan.png": "orphan",
+	})
+
+	report := runCheck(t, dir, Options{Deep: true})
+	if it := findIssue(report, LevelError, echoPath, "content"); it == nil || !strings.Contains(it.Message, "files/images/gone.png") {
+		t.Fatalf("正文引用的缺失媒体应报 error:%s", dumpIssues(report))
+	}
+	if strings.Contains(dumpIssues(report), "theirs.png") {
+		t.Errorf("别的实例的 /api/files/ 链接不属于本胶囊:%s", dumpIssues(report))
+	}
+	if findIssue(report, LevelWarning, "files/images/inline.png", "") != nil {
+		t.Errorf("被正文引用的媒体不应报悬空:%s", dumpIssues(report))
+	}
+	if findIssue(report, LevelWarning, "files/images/orphan.png", "") == nil {
+		t.Errorf("无人引用的媒体应报悬空:%s", dumpIssues(report))
+	}
+}
+
+// TestDeepFixFillsSizeAndHash 锁定深度修复：补全 size / sha256 并写回，重跑干净。
+func TestDeepFixFillsSizeAndHash(t *testing.T) {
+	dir := buildCapsule(t, map[string]string{
+		capsule.ManifestPath:    deepManifest + "files:\n  - key: logo.png\n",
+		echoPath:                deepEcho("files:\n  - key: cat.png\n    size: 1\n", "body\n"),
+		"files/images/cat.png":  catBytes,
+		"files/images/logo.png": "logo",
+	})
+
+	report := runCheck(t, dir, Options{Deep: true, Fix: true})
+	if report.HasErrors() || len(report.Fixed) != 4 {
+		t.Fatalf("Fixed = %v, issues:%s", report.Fixed, dumpIssues(report))
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(echoPath)))
+	if err != nil {
+		t.Fatalf("read back: %v", err)
+	}
+	doc, _, err := capsule.DecodeEcho(raw)
+	if err != nil {
+		t.Fatalf("decode back: %v", err)
+	}
+	if doc.Files[0].Size != int64(len(catBytes)) || doc.Files[0].SHA256 != catSHA256 {
+		t.Errorf("回写的引用 = %+v", doc.Files[0])
+	}
+
+	again := runCheck(t, dir, Options{Deep: true})
+	if len(again.Issues) != 0 {
+		t.Fatalf("修复后重跑应干净:%s", dumpIssues(again))
+	}
+}
+
+// TestCheckURLs 覆盖外链探测：4xx 告警，HEAD 被拒时退回 GET。
+func TestCheckURLs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+		case "/gone":
+			w.WriteHeader(http.StatusNotFound)
+		case "/nohead":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		}
+	}))
+	defer srv.Close()
+
+	dir := buildCapsule(t, map[string]string{
+		capsule.ManifestPath: deepManifest,
+		echoPath: deepEcho("files:\n  - url: "+srv.URL+"/gone\n",
+			"see "+srv.URL+"/ok and [this]("+srv.URL+"/nohead)\n"),
+		otherEchoPath: "---\nid: 01947c3e-0000-7000-8000-000000000003\ncreated_at: 2026-01-02T00:00:00Z\n---\n" +
+			"again " + srv.URL + "/gone\n",
+	})
+
+	report := runCheck(t, dir, Options{Deep: true, CheckURLs: true, URLRate: 1000})
+	if it := findIssue(report, LevelWarning, echoPath, "files[0].url"); it == nil || !strings.Contains(it.Message, "404") {
+		t.Fatalf("404 外链应告警:%s", dumpIssues(report))
+	}
+	if findIssue(report, LevelWarning, otherEchoPath, "content") == nil {
+		t.Errorf("同一 URL 的每处出现都应上报:%s", dumpIssues(report))
+	}
+	if findIssue(report, LevelWarning, echoPath, "content") != nil {
+		t.Errorf("可达的外链不应告警:%s", dumpIssues(report))
+	}
+	if report.HasErrors() {
+		t.Errorf("外链失效只是警告:%s", dumpIssues(report))
+	}
+}
+
+func TestWriteReports(t *testing.T) {
+	report := &Report{Issues: []Issue{
+		{Level: LevelError, Path: echoPath, Field: "id", Message: "id is required"},
+		{Level: LevelWarning, Path: echoPath, Message: "unknown field ignored: mood"},
+		{Level: LevelWarning, Path: "files/images/x.png", Message: "dangling media"},
+	}}
+
+	var js bytes.Buffer
+	if err := report.WriteJSON(&js, "./capsule"); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	var decoded struct {
+		Errors   int `json:"errors"`
+		Warnings int `json:"warnings"`
+		Issues   []struct {
+			Level string `json:"level"`
+		} `json:"issues"`
+		Fixed []string `json:"fixed"`
+	}
+	if err := json.Unmarshal(js.Bytes(), &decoded); err != nil {
+		t.Fatalf("decode json: %v\n%s", err, js.String())
+	}
+	if decoded.Errors != 1 || decoded.Warnings != 2 || decoded.Issues[0].Level != "error" || decoded.Fixed == nil {
+		t.Errorf("json report = %s", js.String())
+	}
+
+	var junit bytes.Buffer
+	if err := report.WriteJUnit(&junit, "./capsule"); err != nil {
+		t.Fatalf("WriteJUnit: %v", err)
+	}
+	out := junit.String()
+	for _, want := range []string{`tests="2"`, `failures="1"`, `<testcase name="` + echoPath, `<failure message="1 error(s)"`, "dangling media"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("junit report missing %q:\n%s", want, out)
+		}
+	}
+
+	junit.Reset()
+	if err := (&Report{}).WriteJUnit(&junit, "./capsule"); err != nil {
+		t.Fatalf("WriteJUnit: %v", err)
+	}
+	if !strings.Contains(junit.String(), `<testcase name="capsule"`) {
+		t.Errorf("零发现时也应有一个用例:\n%s", junit.String())
+	}
+}
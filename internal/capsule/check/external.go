@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/capsule"
+)
+
+// 外链探测的默认节奏：探测的是别人的服务器，宁慢勿猛。
+const (
+	defaultURLConcurrency = 4
+	defaultURLRate        = 5 // 每秒请求数
+	defaultURLTimeout     = 15 * time.Second
+	urlUserAgent          = "ech0-check"
+)
+
+// urlRef 是胶囊里一处外链出现的位置。同一个 URL 只探测一次，但每处出现都单独上报，
+// 用户才能逐个找到去改。
+type urlRef struct {
+	path  string
+	field string
+	url   string
+}
+
+// checkExternalURLs 对胶囊里的全部外链发 HEAD（服务端不支持时退回 GET），
+// 网络错误或 4xx/5xx 记为警告：外链失效不是胶囊自身的缺陷，不该阻断导入。
+func checkExternalURLs(ctx context.Context, r *Report, loaded *capsule.Loaded, site capsule.Site, opts Options) error {
+	refs := collectExternalURLs(loaded, site)
+	if len(refs) == 0 {
+		return nil
+	}
+
+	unique := make([]string, 0, len(refs))
+	seen := make(map[string]struct{}, len(refs))
+	for _, ref := range refs {
+		if _, ok := seen[ref.url]; !ok {
+			seen[ref.url] = struct{}{}
+			unique = append(unique, ref.url)
+		}
+	}
+
+	failures, err := probeURLs(ctx, unique, opts)
+	if err != nil {
+		return err
+	}
+	for _, ref := range refs {
+		if msg, ok := failures[ref.url]; ok {
+			r.warnf(ref.path, ref.field, "external URL %s %s", ref.url, msg)
+		}
+	}
+	return nil
+}
+
+// collectExternalURLs 收集外链文件的 url、正文里的绝对 http(s) 链接与站点 logo。
+// 指向胶囊媒体的正文链接不在此列：它们已经按字节核对过了。
+func collectExternalURLs(loaded *capsule.Loaded, site capsule.Site) []urlRef {
+	var refs []urlRef
+	addFiles := func(p string, files []capsule.FileRef) {
+		for i := range files {
+			if isHTTPURL(files[i].URL) {
+				refs = append(refs, urlRef{path: p, field: fmt.Sprintf("files[%d].url", i), url: files[i].URL})
+			}
+		}
+	}
+
+	if loaded.Manifest != nil {
+		if isHTTPURL(site.ServerLogo) && instanceMarker(site.ServerLogo, site.ServerURL) == "" {
+			refs = append(refs, urlRef{path: capsule.ManifestPath, field: "site.server_logo", url: site.ServerLogo})
+		}
+		addFiles(capsule.ManifestPath, loaded.Manifest.Files)
+	}
+	host := serverHost(site.ServerURL)
+	for i := range loaded.Echoes {
+		e := &loaded.Echoes[i]
+		if e.Doc == nil {
+			continue
+		}
+		addFiles(e.Path, e.Doc.Files)
+		for _, link := range contentLinks(e.Doc.Content) {
+			if _, media := linkMedia(link, host); media || !isHTTPURL(link) {
+				continue
+			}
+			refs = append(refs, urlRef{path: e.Path, field: "content", url: link})
+		}
+	}
+	return refs
+}
+
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// probeURLs 以受限的并发与速率探测 urls，返回失败的 URL → 原因。
+func probeURLs(ctx context.Context, urls []string, opts Options) (map[string]string, error) {
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: defaultURLTimeout}
+	}
+	workers := opts.URLConcurrency
+	if workers <= 0 {
+		workers = defaultURLConcurrency
+	}
+	rate := opts.URLRate
+	if rate <= 0 {
+		rate = defaultURLRate
+	}
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / rate))
+	defer ticker.Stop()
+
+	jobs := make(chan string)
+	var (
+		mu       sync.Mutex
+		failures = make(map[string]string)
+		wg       sync.WaitGroup
+	)
+	for range min(workers, len(urls)) {
+		wg.Go(func() {
+			for u := range jobs {
+				select {
+				case <-ctx.Done():
+					continue
+				case <-ticker.C:
+				}
+				if msg := probeURL(ctx, client, u); msg != "" {
+					mu.Lock()
+					failures[u] = msg
+					mu.Unlock()
+				}
+			}
+		})
+	}
+	for _, u := range urls {
+		jobs <- u
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return failures, nil
+}
+
+// probeURL 返回失败原因，可达时返回空串。不少服务器（对象存储、CDN）对 HEAD 回 405，
+// 那时改用 GET，只读响应头就关掉连接。
+func probeURL(ctx context.Context, client *http.Client, u string) string {
+	status, err := fetchStatus(ctx, client, http.MethodHead, u)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = fetchStatus(ctx, client, http.MethodGet, u)
+	}
+	switch {
+	case err != nil:
+		return fmt.Sprintf("is unreachable: %v", err)
+	case status >= http.StatusBadRequest:
+		return fmt.Sprintf("returned HTTP %d", status)
+	default:
+		return ""
+	}
+}
+
+func fetchStatus(ctx context.Context, client *http.Client, method, u string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", urlUserAgent)
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode, nil
+}
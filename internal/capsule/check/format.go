@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package check
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// 机器可读报告的格式名，与 `ech0 check --format` 的取值一致。
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatJUnit = "junit"
+)
+
+// MarshalText 让 Level 在 JSON 里以 "error" / "warning" 出现，而不是裸整数。
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+type jsonIssue struct {
+	Level   Level  `json:"level"`
+	Path    string `json:"path,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type jsonReport struct {
+	Capsule  string      `json:"capsule"`
+	Errors   int         `json:"errors"`
+	Warnings int         `json:"warnings"`
+	Issues   []jsonIssue `json:"issues"`
+	Fixed    []string    `json:"fixed"`
+}
+
+// WriteJSON 输出 JSON 报告。issues / fixed 为空时写 []，消费端不必判 null。
+func (r *Report) WriteJSON(w io.Writer, capsulePath string) error {
+	out := jsonReport{
+		Capsule:  capsulePath,
+		Errors:   r.Count(LevelError),
+		Warnings: r.Count(LevelWarning),
+		Issues:   make([]jsonIssue, 0, len(r.Issues)),
+		Fixed:    append([]string{}, r.Fixed...),
+	}
+	for _, i := range r.Issues {
+		out.Issues = append(out.Issues, jsonIssue(i))
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+type junitSuite struct {
+	XMLName  xml.Name    `xml:"testsuite"`
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// junitCapsuleCase 是不落在具体文件上的发现所归的用例名，也是零发现时唯一的用例——
+// 不少 CI 把空的 testsuite 当成「没跑测试」。
+const junitCapsuleCase = "capsule"
+
+// WriteJUnit 输出 JUnit XML 报告：每个有发现的胶囊内路径是一个用例，错误级发现
+// 合并成该用例的 <failure>，警告写进 <system-out>——CI 只因错误变红，与退出码一致。
+func (r *Report) WriteJUnit(w io.Writer, capsulePath string) error {
+	byPath := make(map[string][]Issue)
+	for _, i := range r.Issues {
+		name := i.Path
+		if name == "" {
+			name = junitCapsuleCase
+		}
+		byPath[name] = append(byPath[name], i)
+	}
+	if len(byPath) == 0 {
+		byPath[junitCapsuleCase] = nil
+	}
+	names := make([]string, 0, len(byPath))
+	for name := range byPath {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	suite := junitSuite{Name: "ech0 check " + capsulePath, Tests: len(names)}
+	for _, name := range names {
+		c := junitCase{Name: name, ClassName: "ech0.capsule"}
+		var errs, warns []string
+		for _, i := range byPath[name] {
+			if i.Level == LevelError {
+				errs = append(errs, i.String())
+			} else {
+				warns = append(warns, i.String())
+			}
+		}
+		if len(errs) > 0 {
+			suite.Failures++
+			c.Failure = &junitFailure{
+				Message: fmt.Sprintf("%d error(s)", len(errs)),
+				Type:    LevelError.String(),
+				Body:    strings.Join(errs, "\n"),
+			}
+		}
+		c.SystemOut = strings.Join(warns, "\n")
+		suite.Cases = append(suite.Cases, c)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suite); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -17,17 +17,21 @@ import (
 	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
 )
 
-// echoFileMode 是回写 Echo 文件的权限；与导出侧产出的文件保持一致。
+// echoFileMode 是 --fix 回写文件的权限；与导出侧产出的文件保持一致。
 const echoFileMode = 0o644
 
 // validateManifest 校验 ech0.yaml（spec §3）。清单是胶囊的身份证明：
 // 它不可读或版本不认识时，后面所有字段校验都失去意义，故只报这一条。
+//
+// --fix 补全的 size/sha256 只在清单没有未知字段时写回：重新编码会丢掉它们。
 func validateManifest(
 	r *Report,
 	loaded *capsule.Loaded,
+	opts Options,
 	site capsule.Site,
 	referenced map[string]struct{},
-) {
+	digests map[string]mediaDigest,
+) error {
 	p := capsule.ManifestPath
 
 	if loaded.ManifestErr != nil {
@@ -37,7 +41,7 @@ func validateManifest(
 		r.warnf(p, "", "unknown field ignored: %s", u)
 	}
 	if loaded.Manifest == nil {
-		return
+		return nil
 	}
 
 	switch v := loaded.Manifest.SchemaVersion; {
@@ -63,15 +67,53 @@ func validateManifest(
 		r.warnf(p, "site.server_logo", "embeds source instance URL (%s), the link may break after migration", marker)
 	}
 
+	validateTombstones(r, loaded.Manifest)
+
 	// 清单里的 files 块与 frontmatter 的 files[] 同形，校验规则也完全一致——
 	// 它承载的是没挂在任何 Echo 上的文件行（logo、未使用的上传）。
-	validateFiles(r, loaded, p, loaded.Manifest.Files, referenced)
+	fix := opts.Fix && len(loaded.ManifestUnknown) == 0
+	if !validateFiles(r, loaded, p, loaded.Manifest.Files, referenced, digests, fix) {
+		return nil
+	}
+	data, err := capsule.EncodeYAML(loaded.Manifest)
+	if err != nil {
+		return fmt.Errorf("capsule check: re-encode %s: %w", p, err)
+	}
+	return writeBack(loaded, p, data)
+}
+
+// validateTombstones 校验 deleted 块（spec §13）。删除记录只在增量胶囊里有意义：全量胶囊
+// 描述的是完整快照，夹带删除只可能是手工拼错，消费端也不会执行它。
+func validateTombstones(r *Report, m *capsule.Manifest) {
+	p := capsule.ManifestPath
+	if len(m.Deleted) > 0 && m.Since == "" {
+		r.errorf(p, "deleted", "deleted is only allowed in an incremental capsule (since is empty)")
+	}
+	for i, t := range m.Deleted {
+		field := fmt.Sprintf("deleted[%d]", i)
+		if _, ok := capsule.ValidTombstoneKinds[t.Kind]; !ok {
+			r.errorf(p, field+".kind", "unknown kind %q", t.Kind)
+		}
+		if t.ID == "" {
+			r.errorf(p, field+".id", "id is required")
+		}
+		if _, err := capsule.ParseTime(t.DeletedAt); err != nil {
+			r.errorf(p, field+".deleted_at", "%v", err)
+		}
+	}
 }
 
 // validateEchoes 校验全部 Echo 内容文件（spec §4），返回胶囊内的 Echo id 集合
-// （评论孤儿判定用）与被引用的媒体路径集合（悬空媒体判定用）。
+// （评论孤儿判定用）与被引用的媒体路径集合（悬空媒体判定用）。深度模式下正文里的
+// 媒体链接也算引用（spec §7.1）。
 // 只有 --fix 的写回失败会中断校验：那是 I/O 故障，继续跑只会掩盖它。
-func validateEchoes(r *Report, loaded *capsule.Loaded, opts Options, serverURL string) (ids map[string]struct{}, referenced map[string]struct{}, err error) {
+func validateEchoes(
+	r *Report,
+	loaded *capsule.Loaded,
+	opts Options,
+	serverURL string,
+	digests map[string]mediaDigest,
+) (ids map[string]struct{}, referenced map[string]struct{}, err error) {
 	ids = make(map[string]struct{}, len(loaded.Echoes))
 	referenced = make(map[string]struct{})
 	firstSeen := make(map[string]string, len(loaded.Echoes))
@@ -90,10 +132,10 @@ func validateEchoes(r *Report, loaded *capsule.Loaded, opts Options, serverURL s
 			continue
 		}
 
+		dirty := false
 		if doc.ID == "" && opts.Fix {
-			if err := fixEchoID(r, loaded, e); err != nil {
-				return nil, nil, err
-			}
+			fixEchoID(r, e)
+			dirty = true
 		}
 
 		switch {
@@ -116,6 +158,11 @@ func validateEchoes(r *Report, loaded *capsule.Loaded, opts Options, serverURL s
 		} else if _, perr := capsule.ParseTime(doc.CreatedAt); perr != nil {
 			r.errorf(e.Path, "created_at", "%v", perr)
 		}
+		if doc.UpdatedAt != "" {
+			if _, perr := capsule.ParseTime(doc.UpdatedAt); perr != nil {
+				r.errorf(e.Path, "updated_at", "%v", perr)
+			}
+		}
 
 		// 表现层枚举不认得的取值只警告，不阻断（spec §7）：内容本身完好，消费者
 		// 退回默认值即可。活实例的写路径本来就是这么干的（service/echo 把未知
@@ -127,11 +174,33 @@ func validateEchoes(r *Report, loaded *capsule.Loaded, opts Options, serverURL s
 		}
 
 		validateExtension(r, e.Path, doc.Extension, serverURL)
-		validateFiles(r, loaded, e.Path, doc.Files, referenced)
+		if validateFiles(r, loaded, e.Path, doc.Files, referenced, digests, opts.Fix) {
+			dirty = true
+		}
 
 		if marker := instanceMarker(doc.Content, serverURL); marker != "" {
 			r.warnf(e.Path, "content", "embeds source instance URL (%s), the link may break after migration", marker)
 		}
+		if opts.Deep {
+			for _, media := range contentMedia(doc.Content, serverURL) {
+				referenced[media] = struct{}{}
+				if _, ok := loaded.MediaPaths[media]; !ok {
+					r.errorf(e.Path, "content", "content links to %s, which is missing from the capsule", media)
+				}
+			}
+		}
+
+		if dirty {
+			// 用 EncodeEcho 重写而非就地改几行 YAML：正文逐字保留由编码器保证，
+			// 手工拼字符串迟早会在 CRLF / 无正文这类边角上出错。
+			data, err := capsule.EncodeEcho(doc)
+			if err != nil {
+				return nil, nil, fmt.Errorf("capsule check: re-encode %s: %w", e.Path, err)
+			}
+			if err := writeBack(loaded, e.Path, data); err != nil {
+				return nil, nil, err
+			}
+		}
 	}
 	return ids, referenced, nil
 }
@@ -160,7 +229,19 @@ func validateExtension(r *Report, echoPath string, ext *capsule.Extension, serve
 
 // validateFiles 校验 files[]（spec §4.2 / §6）。托管条目的字节位置是
 // MediaPath(key) 的纯函数结果，胶囊不存路径，所以「字节在不在」只能这样比对。
-func validateFiles(r *Report, loaded *capsule.Loaded, echoPath string, files []capsule.FileRef, referenced map[string]struct{}) {
+//
+// digests 非 nil 即深度模式：大小改用实际读出的字节数比对，并核对 sha256。fix 时
+// 就地补全缺失或不符的 size 与缺失的 sha256，返回是否改动了 files——由调用方写回。
+// 摘要不符不修：字节与声明哪边坏了无从判断。
+func validateFiles(
+	r *Report,
+	loaded *capsule.Loaded,
+	echoPath string,
+	files []capsule.FileRef,
+	referenced map[string]struct{},
+	digests map[string]mediaDigest,
+	fix bool,
+) (changed bool) {
 	for i := range files {
 		f := files[i]
 		field := fmt.Sprintf("files[%d]", i)
@@ -196,10 +277,43 @@ func validateFiles(r *Report, loaded *capsule.Loaded, echoPath string, files []c
 			r.errorf(echoPath, field+".key", "capsule is not self-contained: %s is missing for key %q", media, f.Key)
 			continue
 		}
-		if f.Size > 0 && f.Size != size {
-			r.warnf(echoPath, field+".size", "declared size %d does not match %s (%d bytes on disk)", f.Size, media, size)
+		validSum := f.SHA256 == "" || isSHA256(f.SHA256)
+		if !validSum {
+			r.errorf(echoPath, field+".sha256", "sha256 %q is not a hex-encoded SHA-256 digest", f.SHA256)
+		}
+		if digests == nil {
+			if f.Size > 0 && f.Size != size {
+				r.warnf(echoPath, field+".size", "declared size %d does not match %s (%d bytes on disk)", f.Size, media, size)
+			}
+			continue
+		}
+
+		d := digests[media]
+		if d.err != nil {
+			r.errorf(echoPath, field+".key", "cannot read %s: %v", media, d.err)
+			continue
+		}
+		if f.SHA256 != "" && validSum && !strings.EqualFold(f.SHA256, d.sum) {
+			// 字节已不可信，size 也就没有比对的意义。
+			r.errorf(echoPath, field+".sha256", "content hash mismatch: %s hashes to %s", media, d.sum)
+			continue
+		}
+		switch {
+		case f.Size == d.size:
+		case fix:
+			files[i].Size = d.size
+			r.Fixed = append(r.Fixed, fmt.Sprintf("%s [%s.size]: set to %d", echoPath, field, d.size))
+			changed = true
+		case f.Size > 0:
+			r.warnf(echoPath, field+".size", "declared size %d does not match %s (%d bytes read)", f.Size, media, d.size)
+		}
+		if f.SHA256 == "" && fix {
+			files[i].SHA256 = d.sum
+			r.Fixed = append(r.Fixed, fmt.Sprintf("%s [%s.sha256]: filled in", echoPath, field))
+			changed = true
 		}
 	}
+	return changed
 }
 
 // validateComments 校验 comments.yaml（spec §5）。
@@ -265,6 +379,11 @@ func validateComments(r *Report, loaded *capsule.Loaded, echoIDs map[string]stru
 		} else if _, perr := capsule.ParseTime(c.CreatedAt); perr != nil {
 			r.errorf(p, at("created_at"), "%v", perr)
 		}
+		if c.UpdatedAt != "" {
+			if _, perr := capsule.ParseTime(c.UpdatedAt); perr != nil {
+				r.errorf(p, at("updated_at"), "%v", perr)
+			}
+		}
 
 		if c.Status != "" && c.Status != capsule.DefaultCommentStatus {
 			r.warnf(p, at("status"), "status %q is not %q: a capsule should only carry approved comments",
@@ -318,26 +437,22 @@ func hasTraversal(p string) bool {
 	return false
 }
 
-// fixEchoID 补全缺失的 id 并回写整个文件（spec §7 唯一的自动修复项）。
-// 用 EncodeEcho 重写而非插一行 YAML：正文逐字保留由编码器保证，
-// 手工拼字符串迟早会在 CRLF / 无正文这类边角上出错。
-func fixEchoID(r *Report, loaded *capsule.Loaded, e *capsule.LoadedEcho) error {
+// fixEchoID 补全缺失的 id，由 validateEchoes 随后整文件写回。
+//
+// 不重命名文件：文件名里的 id 前缀纯属浏览友好，消费者禁止从中解析语义
+// （spec §4.1），重命名只会制造无谓的路径变更。
+func fixEchoID(r *Report, e *capsule.LoadedEcho) {
 	id := uuidUtil.MustNewV7()
 	e.Doc.ID = id
+	r.Fixed = append(r.Fixed, fmt.Sprintf("%s: generated id %s", e.Path, id))
+}
 
-	data, err := capsule.EncodeEcho(e.Doc)
-	if err != nil {
-		return fmt.Errorf("capsule check: re-encode %s: %w", e.Path, err)
-	}
-	// 仅目录形态会走到这里（Validate 已挡掉 zip）。
-	target := filepath.Join(loaded.Source.Path, filepath.FromSlash(e.Path))
+// writeBack 覆写胶囊内一个文件。仅目录形态会走到这里（Validate 已挡掉 zip）。
+func writeBack(loaded *capsule.Loaded, rel string, data []byte) error {
+	target := filepath.Join(loaded.Source.Path, filepath.FromSlash(rel))
 	if err := os.WriteFile(target, data, echoFileMode); err != nil {
-		return fmt.Errorf("capsule check: write back %s: %w", e.Path, err)
+		return fmt.Errorf("capsule check: write back %s: %w", rel, err)
 	}
-
-	// 不重命名文件：文件名里的 id 前缀纯属浏览友好，消费者禁止从中解析语义
-	// （spec §4.1），重命名只会制造无谓的路径变更。
-	r.Fixed = append(r.Fixed, fmt.Sprintf("%s: generated id %s", e.Path, id))
 	return nil
 }
 
@@ -5,6 +5,7 @@ package capsule
 
 import (
 	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	echoModel "github.com/lin-snow/ech0/internal/model/echo"
 	"github.com/lin-snow/ech0/internal/storage"
 )
@@ -47,3 +48,9 @@ var ValidCategories = map[string]struct{}{
 
 // DefaultCommentStatus 是胶囊内评论应当具备的状态；其余状态仅告警。
 const DefaultCommentStatus = string(commentModel.StatusApproved)
+
+// ValidTombstoneKinds 是 manifest.deleted[].kind 的合法取值。
+var ValidTombstoneKinds = map[string]struct{}{
+	commonModel.TombstoneEcho:    {},
+	commonModel.TombstoneComment: {},
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/lin-snow/ech0/internal/capsule"
 	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	connectModel "github.com/lin-snow/ech0/internal/model/connect"
 	echoModel "github.com/lin-snow/ech0/internal/model/echo"
 	fileModel "github.com/lin-snow/ech0/internal/model/file"
@@ -16,6 +17,7 @@ import (
 	coreSetting "github.com/lin-snow/ech0/internal/setting"
 	"github.com/lin-snow/ech0/internal/storage"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // dataset 是一次导出的全部库内容快照。先整体读出再整体写盘，避免边读边写时
@@ -24,6 +26,7 @@ type dataset struct {
 	echoes   []echoModel.Echo
 	files    []fileModel.File // 需要写进胶囊的 files 表记录（含 external 行）
 	comments []capsule.Comment
+	deleted  []capsule.Tombstone
 	site     capsule.Site
 	owner    capsule.Owner
 	connects []capsule.Connect
@@ -42,7 +45,10 @@ func collect(ctx context.Context, deps Deps, opts Options) (*dataset, error) {
 	if err := collectFiles(db, opts, data); err != nil {
 		return nil, err
 	}
-	if err := collectComments(db, data); err != nil {
+	if err := collectComments(db, opts, data); err != nil {
+		return nil, err
+	}
+	if err := collectTombstones(db, opts, data); err != nil {
 		return nil, err
 	}
 	if err := collectSite(ctx, deps, data); err != nil {
@@ -68,13 +74,20 @@ func collectEchoes(db *gorm.DB, opts Options, data *dataset) error {
 	if !opts.IncludePrivate {
 		query = query.Where("private = ?", false)
 	}
+	if opts.Since > 0 {
+		query = query.Where(changedSince(opts.Since))
+	}
 	if err := query.Find(&data.echoes).Error; err != nil {
 		return fmt.Errorf("capsule export: load echoes: %w", err)
 	}
 
 	if !opts.IncludePrivate {
 		var private int64
-		if err := db.Model(&echoModel.Echo{}).Where("private = ?", true).Count(&private).Error; err != nil {
+		count := db.Model(&echoModel.Echo{}).Where("private = ?", true)
+		if opts.Since > 0 {
+			count = count.Where(changedSince(opts.Since))
+		}
+		if err := count.Count(&private).Error; err != nil {
 			return fmt.Errorf("capsule export: count private echoes: %w", err)
 		}
 		data.skippedPrivate = int(private)
@@ -86,7 +99,17 @@ func collectEchoes(db *gorm.DB, opts Options, data *dataset) error {
 // DataRoot）。未被任何 Echo 引用的悬空文件照常导出——它合法，check 侧只告警。
 func collectFiles(db *gorm.DB, opts Options, data *dataset) error {
 	var files []fileModel.File
-	if err := db.Find(&files).Error; err != nil {
+	query := db
+	if opts.Since > 0 {
+		// 增量胶囊只带这批 Echo 用到的文件，外加同期新上传、尚未挂到任何 Echo 上的行
+		// （站点 logo 就属此类）。旧文件对端早已有之，再搬一遍字节毫无意义。
+		query = db.Where(
+			"id IN (?) OR (created_at > ? AND id NOT IN (?))",
+			attachedFileIDs(data.echoes), opts.Since,
+			db.Model(&fileModel.EchoFile{}).Select("file_id"),
+		)
+	}
+	if err := query.Find(&files).Error; err != nil {
 		return fmt.Errorf("capsule export: load files: %w", err)
 	}
 
@@ -146,12 +169,16 @@ func privateOnlyFiles(db *gorm.DB) (map[string]struct{}, error) {
 // collectComments 只导出已通过审核的评论，且只保留指向本次导出 Echo 集合的那些：
 // private Echo 被排除后，它名下的评论就是孤儿，带出去只会让消费者报警告。
 // 出胶囊前必过 Public 投影，隐私字段（email/ip_hash/user_agent/user_id）在此剥离。
-func collectComments(db *gorm.DB, data *dataset) error {
+//
+// 增量胶囊（opts.Since > 0）里评论按自身的修改时刻取，宿主 Echo 不必也在这批变更里：
+// 老 Echo 下的新评论正是同步最常见的增量。宿主可见性规则不变。
+func collectComments(db *gorm.DB, opts Options, data *dataset) error {
+	query := db.Where("status = ?", commentModel.StatusApproved)
+	if opts.Since > 0 {
+		query = query.Where(changedSince(opts.Since))
+	}
 	var comments []commentModel.Comment
-	if err := db.
-		Where("status = ?", commentModel.StatusApproved).
-		Order("created_at asc").
-		Find(&comments).Error; err != nil {
+	if err := query.Order("created_at asc").Find(&comments).Error; err != nil {
 		return fmt.Errorf("capsule export: load comments: %w", err)
 	}
 
@@ -159,6 +186,19 @@ func collectComments(db *gorm.DB, data *dataset) error {
 	for i := range data.echoes {
 		exported[data.echoes[i].ID] = struct{}{}
 	}
+	if opts.Since > 0 {
+		hosts := db.Model(&echoModel.Echo{})
+		if !opts.IncludePrivate {
+			hosts = hosts.Where("private = ?", false)
+		}
+		var ids []string
+		if err := hosts.Pluck("id", &ids).Error; err != nil {
+			return fmt.Errorf("capsule export: load comment hosts: %w", err)
+		}
+		for _, id := range ids {
+			exported[id] = struct{}{}
+		}
+	}
 
 	for i := range comments {
 		if _, ok := exported[comments[i].EchoID]; !ok {
@@ -175,11 +215,51 @@ func collectComments(db *gorm.DB, data *dataset) error {
 			Status:    string(public.Status),
 			Source:    string(public.Source),
 			CreatedAt: capsule.FormatUnix(public.CreatedAt),
+			UpdatedAt: updatedAt(public.CreatedAt, public.UpdatedAt),
 		})
 	}
 	return nil
 }
 
+// collectTombstones 只服务增量胶囊：取 since 之后的删除记录。墓碑不记被删行是否私密，
+// 对端合并时自会按它那一侧的 include_private 跳过私密行。
+func collectTombstones(db *gorm.DB, opts Options, data *dataset) error {
+	if opts.Since <= 0 {
+		return nil
+	}
+	var rows []commonModel.Tombstone
+	if err := db.Where("deleted_at >= ?", opts.Since).Order("deleted_at asc").Find(&rows).Error; err != nil {
+		return fmt.Errorf("capsule export: load tombstones: %w", err)
+	}
+	for _, row := range rows {
+		data.deleted = append(data.deleted, capsule.Tombstone{
+			Kind:      row.Kind,
+			ID:        row.ID,
+			DeletedAt: capsule.FormatUnix(row.DeletedAt),
+		})
+	}
+	return nil
+}
+
+// changedSince 是增量导出的行筛选：创建或修改不早于水位即入选。水位是上一轮导出开始前
+// 取的秒级时钟，同一秒里、查询之后写入的行若用严格大于就永远漏掉；边界那一秒的行会被
+// 下一轮再带一次，合并按 id 幂等，重放无害。updated_at 列上线前的老行该列为 0，靠
+// created_at 那一半兜住。
+func changedSince(since int64) clause.Expr {
+	return gorm.Expr("created_at >= ? OR updated_at >= ?", since, since)
+}
+
+// attachedFileIDs 收集一批 Echo 引用到的文件 id。
+func attachedFileIDs(echoes []echoModel.Echo) []string {
+	ids := make([]string, 0)
+	for i := range echoes {
+		for _, link := range echoes[i].EchoFiles {
+			ids = append(ids, link.FileID)
+		}
+	}
+	return ids
+}
+
 // collectSite 逐字段拷贝站点设置的公开子集。这里不用整体序列化：AllowRegister 是
 // 运维行为开关，必须留在库里（spec §3）；逐字段列出让「哪些进了胶囊」一眼可查。
 func collectSite(ctx context.Context, deps Deps, data *dataset) error {
@@ -42,6 +42,9 @@ type Options struct {
 	IncludePrivate bool
 	Zip            bool
 	Generator      string // 写入 manifest.generator 的生产者标识
+	// Since > 0 时只导出该时刻（Unix 秒）之后创建或修改过的 Echo 与评论，产出增量胶囊
+	// （spec §13）。媒体随之收窄到这些 Echo 引用的文件，外加同期新建的悬空文件。
+	Since int64
 }
 
 // Result 是导出报告，供 CLI 打印。Files 为写入胶囊的 files 表记录总数（含外链），
@@ -14,6 +14,7 @@ import (
 	"github.com/lin-snow/ech0/internal/capsule"
 	"github.com/lin-snow/ech0/internal/kvstore"
 	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	connectModel "github.com/lin-snow/ech0/internal/model/connect"
 	echoModel "github.com/lin-snow/ech0/internal/model/echo"
 	fileModel "github.com/lin-snow/ech0/internal/model/file"
@@ -244,6 +245,9 @@ func TestRun_EchoDocument(t *testing.T) {
 	assert.Equal(t, "image", doc.Files[1].Category)
 	assert.Equal(t, int64(7), doc.Files[1].Size)
 	assert.Equal(t, 4, doc.Files[1].Width)
+	// sha256("PNGDATA")：摘要取自实际写进胶囊的字节，外链条目没有字节也就没有摘要。
+	assert.Equal(t, "2d4566582844690f8634a8b2534ea5221560038c6c0650c99140759bad603ae2", doc.Files[1].SHA256)
+	assert.Empty(t, doc.Files[2].SHA256)
 }
 
 func TestRun_MediaBytesAreSelfContained(t *testing.T) {
@@ -357,3 +361,73 @@ func TestUniquePath_DeduplicatesCollisions(t *testing.T) {
 	assert.Equal(t, "echoes/2023/2023-11-14-f1a1aaaa-2.md", uniquePath(used, base))
 	assert.Equal(t, "echoes/2023/2023-11-14-f1a1aaaa-3.md", uniquePath(used, base))
 }
+
+func TestRun_SinceExportsOnlyChanges(t *testing.T) {
+	deps, _ := newFixture(t)
+	// 夹具里的行由 autoUpdateTime 盖上了「现在」，先拨回各自的创建时刻，再只让公开 Echo
+	// 在水位之后被改一次。
+	require.NoError(t, deps.DB.Exec("UPDATE echos SET updated_at = created_at").Error)
+	require.NoError(t, deps.DB.Exec("UPDATE comments SET updated_at = created_at").Error)
+	require.NoError(t, deps.DB.Exec("UPDATE files SET created_at = ?", publicEchoAt).Error)
+	since := privateEchoAt + 100
+	require.NoError(t, deps.DB.Model(&echoModel.Echo{}).Where("id = ?", publicEchoID).
+		UpdateColumn("updated_at", since+50).Error)
+
+	res, out := runExport(t, deps, Options{Since: since, IncludePrivate: true})
+
+	var manifest capsule.Manifest
+	_, err := capsule.DecodeYAML(readCapsuleFile(t, out, capsule.ManifestPath), &manifest)
+	require.NoError(t, err)
+	assert.Equal(t, capsule.FormatUnix(since), manifest.Since)
+
+	assert.Equal(t, 1, res.Echoes)
+	assert.NoFileExists(t, filepath.Join(out, filepath.FromSlash(
+		capsule.EchoPath(privateEchoID, time.Unix(privateEchoAt, 0)))))
+	doc, _, err := capsule.DecodeEcho(readCapsuleFile(t, out,
+		capsule.EchoPath(publicEchoID, time.Unix(publicEchoAt, 0))))
+	require.NoError(t, err)
+	assert.Equal(t, capsule.FormatUnix(since+50), doc.UpdatedAt)
+
+	// 只带这条 Echo 引用的文件；早已存在的悬空文件与私密 Echo 独占的图片都留在原地。
+	assert.Equal(t, 3, res.Files)
+	assert.NoFileExists(t, filepath.Join(out, "files", "images", "secret.png"))
+	// 评论都早于水位，增量里一条不带。
+	assert.Equal(t, 0, res.Comments)
+}
+
+// 水位取自上一轮导出开始前的秒级时钟，恰好落在水位那一秒改动的行也要带上。
+func TestRun_SinceIncludesBoundarySecond(t *testing.T) {
+	deps, _ := newFixture(t)
+	require.NoError(t, deps.DB.Exec("UPDATE echos SET updated_at = created_at").Error)
+	require.NoError(t, deps.DB.Exec("UPDATE comments SET updated_at = created_at").Error)
+	since := privateEchoAt + 100
+	require.NoError(t, deps.DB.Model(&echoModel.Echo{}).Where("id = ?", publicEchoID).
+		UpdateColumn("updated_at", since).Error)
+
+	res, _ := runExport(t, deps, Options{Since: since, IncludePrivate: true})
+	assert.Equal(t, 1, res.Echoes)
+}
+
+// 增量胶囊带上水位之后的删除记录，全量胶囊一条不带。
+func TestRun_SinceCarriesTombstones(t *testing.T) {
+	deps, _ := newFixture(t)
+	since := privateEchoAt + 100
+	require.NoError(t, deps.DB.Create(&[]commonModel.Tombstone{
+		{Kind: commonModel.TombstoneEcho, ID: "gone-before", DeletedAt: since - 1},
+		{Kind: commonModel.TombstoneComment, ID: "gone-after", DeletedAt: since},
+	}).Error)
+
+	_, out := runExport(t, deps, Options{Since: since, IncludePrivate: true})
+	var manifest capsule.Manifest
+	_, err := capsule.DecodeYAML(readCapsuleFile(t, out, capsule.ManifestPath), &manifest)
+	require.NoError(t, err)
+	assert.Equal(t, []capsule.Tombstone{
+		{Kind: commonModel.TombstoneComment, ID: "gone-after", DeletedAt: capsule.FormatUnix(since)},
+	}, manifest.Deleted)
+
+	_, out = runExport(t, deps, Options{IncludePrivate: true})
+	manifest = capsule.Manifest{}
+	_, err = capsule.DecodeYAML(readCapsuleFile(t, out, capsule.ManifestPath), &manifest)
+	require.NoError(t, err)
+	assert.Empty(t, manifest.Deleted)
+}
@@ -6,8 +6,11 @@ package export
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -29,14 +32,23 @@ func writeCapsule(
 ) ([]string, error) {
 	keys := make([]string, 0, 1+len(data.echoes)+1+len(data.files))
 
+	// 媒体先落：引用里的 sha256 要等字节过一遍才知道。返回的 keys 仍按清单、Echo、
+	// 评论、媒体排，zip 里的条目顺序不受影响。
+	mediaKeys, sums, err := writeMedia(ctx, deps, stage, data)
+	if err != nil {
+		return nil, err
+	}
+
 	manifest := &capsule.Manifest{
 		SchemaVersion: capsule.SchemaVersion,
 		Generator:     opts.Generator,
 		ExportedAt:    capsule.FormatUnix(time.Now().Unix()),
+		Since:         since(opts.Since),
+		Deleted:       data.deleted,
 		Site:          data.site,
 		Owner:         data.owner,
 		Connects:      data.connects,
-		Files:         unattachedRefs(data),
+		Files:         unattachedRefs(data, sums),
 	}
 	body, err := capsule.EncodeYAML(manifest)
 	if err != nil {
@@ -47,7 +59,7 @@ func writeCapsule(
 	}
 	keys = append(keys, capsule.ManifestPath)
 
-	echoKeys, err := writeEchoes(ctx, stage, data)
+	echoKeys, err := writeEchoes(ctx, stage, data, sums)
 	if err != nil {
 		return nil, err
 	}
@@ -67,14 +79,10 @@ func writeCapsule(
 		keys = append(keys, capsule.CommentsPath)
 	}
 
-	mediaKeys, err := writeMedia(ctx, deps, stage, data)
-	if err != nil {
-		return nil, err
-	}
 	return append(keys, mediaKeys...), nil
 }
 
-func writeEchoes(ctx context.Context, stage virefs.FS, data *dataset) ([]string, error) {
+func writeEchoes(ctx context.Context, stage virefs.FS, data *dataset, sums map[string]string) ([]string, error) {
 	keys := make([]string, 0, len(data.echoes))
 	used := make(map[string]struct{}, len(data.echoes))
 
@@ -83,12 +91,13 @@ func writeEchoes(ctx context.Context, stage virefs.FS, data *dataset) ([]string,
 		doc := &capsule.EchoDoc{
 			ID:        echo.ID,
 			CreatedAt: capsule.FormatUnix(echo.CreatedAt),
+			UpdatedAt: updatedAt(echo.CreatedAt, echo.UpdatedAt),
 			Username:  echo.Username,
 			Tags:      tagNames(echo.Tags),
 			Layout:    echo.Layout,
 			Private:   echo.Private,
 			FavCount:  echo.FavCount,
-			Files:     fileRefs(echo.EchoFiles),
+			Files:     fileRefs(echo.EchoFiles, sums),
 			Extension: extension(echo.Extension),
 			Content:   echo.Content,
 		}
@@ -119,6 +128,22 @@ func uniquePath(used map[string]struct{}, base string) string {
 	}
 }
 
+// updatedAt 只在行确实被编辑过时写出：未改过的条目 updated_at 与 created_at 相等，
+// 写出来只会给 diff 添噪；列上线前的老行为 0，同样视为未改过。
+func updatedAt(createdAt, updatedAt int64) string {
+	if updatedAt <= createdAt {
+		return ""
+	}
+	return capsule.FormatUnix(updatedAt)
+}
+
+func since(sec int64) string {
+	if sec <= 0 {
+		return ""
+	}
+	return capsule.FormatUnix(sec)
+}
+
 func tagNames(tags []echoModel.Tag) []string {
 	if len(tags) == 0 {
 		return nil
@@ -140,20 +165,21 @@ func extension(ext *echoModel.EchoExtension) *capsule.Extension {
 // fileRefs 把 Echo 的媒体关联转成 frontmatter 引用。三种存储形态在胶囊里被归一化
 // 成两种：external 用 url 透传，local/object 统一用 key——托管文件的 URL 是运行时
 // 拓扑（AfterFind 按当前配置重算），带进胶囊只会在迁移后指回原实例（spec §11.1）。
-func fileRefs(links []fileModel.EchoFile) []capsule.FileRef {
+func fileRefs(links []fileModel.EchoFile, sums map[string]string) []capsule.FileRef {
 	if len(links) == 0 {
 		return nil
 	}
 	refs := make([]capsule.FileRef, 0, len(links))
 	for i := range links {
-		refs = append(refs, fileRef(links[i].File))
+		refs = append(refs, fileRef(links[i].File, sums))
 	}
 	return refs
 }
 
 // fileRef 把一行 File 转成胶囊引用。storage_type/provider/bucket/user_id/created_at
-// 不入胶囊：它们是运行时拓扑或行元数据，由目标实例按自己的配置重建。
-func fileRef(file fileModel.File) capsule.FileRef {
+// 不入胶囊：它们是运行时拓扑或行元数据，由目标实例按自己的配置重建。sums 是
+// writeMedia 实际写入字节的摘要（按 key），托管条目据此带上 sha256。
+func fileRef(file fileModel.File, sums map[string]string) capsule.FileRef {
 	ref := capsule.FileRef{
 		ID:          file.ID,
 		Category:    file.Category,
@@ -167,6 +193,7 @@ func fileRef(file fileModel.File) capsule.FileRef {
 		ref.URL = file.URL
 	} else {
 		ref.Key = file.Key
+		ref.SHA256 = sums[file.Key]
 	}
 	return ref
 }
@@ -175,7 +202,7 @@ func fileRef(file fileModel.File) capsule.FileRef {
 // 附件）。它们的字节本来就随记录驱动导出进了胶囊，但 frontmatter 只能表达
 // 「挂在某条 Echo 上的文件」，元数据没有落脚点——导入侧因此无法还原这些行，
 // 最直接的后果是搬家之后 site.server_logo 变成死链。清单里的 files 块就是它们的位置。
-func unattachedRefs(data *dataset) []capsule.FileRef {
+func unattachedRefs(data *dataset, sums map[string]string) []capsule.FileRef {
 	attached := make(map[string]struct{})
 	for i := range data.echoes {
 		for _, link := range data.echoes[i].EchoFiles {
@@ -188,7 +215,7 @@ func unattachedRefs(data *dataset) []capsule.FileRef {
 		if _, ok := attached[data.files[i].ID]; ok {
 			continue
 		}
-		refs = append(refs, fileRef(data.files[i]))
+		refs = append(refs, fileRef(data.files[i], sums))
 	}
 	if len(refs) == 0 {
 		return nil
@@ -203,11 +230,12 @@ type mediaFailure struct {
 	err         error
 }
 
-// writeMedia 把托管文件的字节搬进胶囊。自包含是硬承诺（spec §11.2）：任何一条取不
-// 回都不能静默略过，但也不在第一条就中断——一次跑完再把完整清单交给用户，比让他
-// 修一条再跑一次快得多。
-func writeMedia(ctx context.Context, deps Deps, stage virefs.FS, data *dataset) ([]string, error) {
+// writeMedia 把托管文件的字节搬进胶囊，边写边算 SHA-256（返回 key → 十六进制摘要）。
+// 自包含是硬承诺（spec §11.2）：任何一条取不回都不能静默略过，但也不在第一条就中断——
+// 一次跑完再把完整清单交给用户，比让他修一条再跑一次快得多。
+func writeMedia(ctx context.Context, deps Deps, stage virefs.FS, data *dataset) ([]string, map[string]string, error) {
 	keys := make([]string, 0, len(data.files))
+	sums := make(map[string]string, len(data.files))
 	var failures []mediaFailure
 
 	for i := range data.files {
@@ -228,18 +256,20 @@ func writeMedia(ctx context.Context, deps Deps, stage virefs.FS, data *dataset)
 			continue
 		}
 		key := capsule.MediaPath(file.Key)
-		err = stage.Put(ctx, key, reader)
+		h := sha256.New()
+		err = stage.Put(ctx, key, io.TeeReader(reader, h))
 		_ = reader.Close()
 		if err != nil {
-			return nil, fmt.Errorf("capsule export: write %s: %w", key, err)
+			return nil, nil, fmt.Errorf("capsule export: write %s: %w", key, err)
 		}
 		keys = append(keys, key)
+		sums[file.Key] = hex.EncodeToString(h.Sum(nil))
 	}
 
 	if len(failures) > 0 {
-		return nil, unreadableMediaError(failures)
+		return nil, nil, unreadableMediaError(failures)
 	}
-	return keys, nil
+	return keys, sums, nil
 }
 
 func unreadableMediaError(failures []mediaFailure) error {
@@ -25,7 +25,7 @@ import (
 	"gorm.io/gorm/clause"
 )
 
-// 外链文件在 files 表里也要占一行，而 key 是 not null 且参与唯一索引 idx_file_route。
+// 外链文件在 files 表里也要占一行，而 key 是 not null 且参与路由索引 idx_file_route。
 // 这里复刻 file service 对 external 的既有派生（纯函数部分，不调 service——它要
 // viewer 上下文还会发事件），好让同一外链无论从哪条路进来都收敛到同一行。
 const (
@@ -242,7 +242,7 @@ func (s *session) reuseByID(id string) (string, bool, error) {
 	}
 }
 
-// lookupRoute 按 files 表的唯一索引四元组（storage_type, provider, bucket, key）
+// lookupRoute 按 files 表的路由索引四元组（storage_type, provider, bucket, key）
 // 定位既有行；nil 表示当前后端下这个 key 还空着。
 func (s *session) lookupRoute(key string) (*fileEntry, error) {
 	rk := routeKey(string(s.storageType), s.provider, s.bucket, key)
@@ -138,7 +138,7 @@ type session struct {
 	// 不会真写进库，评论归属判定得靠它才不至于把新内容的评论全记成孤儿。
 	landed map[string]struct{}
 
-	// 当前存储后端的路由三元组，files 表的唯一索引 idx_file_route 就是按它 + key 建的。
+	// 当前存储后端的路由三元组，files 表的索引 idx_file_route 就是按它 + key 建的。
 	storageType storage.StorageType
 	provider    string
 	bucket      string
@@ -289,6 +289,10 @@ func (s *session) importEcho(ctx context.Context, path string, doc *capsule.Echo
 	if err != nil {
 		return err
 	}
+	updatedAt, err := optionalTime(doc.UpdatedAt, createdAt)
+	if err != nil {
+		return fmt.Errorf("capsule import: %s: updated_at: %w", path, err)
+	}
 	layout := doc.Layout
 	if layout == "" {
 		layout = capsule.DefaultLayout
@@ -303,7 +307,9 @@ func (s *session) importEcho(ctx context.Context, path string, doc *capsule.Echo
 		UserID:   userID,
 		FavCount: doc.FavCount,
 		// CreatedAt 带 autoCreateTime：GORM 只在字段为零值时才代填，显式赋非零值即被原样保留。
+		// UpdatedAt 同理——同步靠它比对两端谁更新，落库时被改写成导入时刻就全乱了。
 		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
 	}
 	// 关联全部手工落地（tags 需 find-or-create、files 需去重与改名），交给 GORM
 	// 级联只会绕过这些语义。
@@ -436,6 +442,10 @@ func (s *session) importComments() error {
 		if err != nil {
 			return fmt.Errorf("capsule import: comment %s: created_at: %w", c.ID, err)
 		}
+		updatedAt, err := optionalTime(c.UpdatedAt, createdAt)
+		if err != nil {
+			return fmt.Errorf("capsule import: comment %s: updated_at: %w", c.ID, err)
+		}
 		status := c.Status
 		if status == "" {
 			status = capsule.DefaultCommentStatus
@@ -457,6 +467,7 @@ func (s *session) importComments() error {
 			Status:    commentModel.Status(status),
 			Source:    commentModel.SourceType(source),
 			CreatedAt: createdAt,
+			UpdatedAt: updatedAt,
 		}
 		if err := s.db.Create(&row).Error; err != nil {
 			return fmt.Errorf("capsule import: create comment %s: %w", c.ID, err)
@@ -466,6 +477,14 @@ func (s *session) importComments() error {
 	return nil
 }
 
+// optionalTime 解析可选的 updated_at：缺省即「创建后未改过」，取 fallback（created_at）。
+func optionalTime(raw string, fallback int64) (int64, error) {
+	if raw == "" {
+		return fallback, nil
+	}
+	return capsule.ParseTime(raw)
+}
+
 // resolveCommentHosts 一次性查出 comments.yaml 引用到的 echo_id 里哪些真在库中，
 // 避免每条评论打一次库。事务内查询，本轮新建的 Echo 也算数；dry-run 下那些行不会
 // 真写进去，故并上 landed 兜底。
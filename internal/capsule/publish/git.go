@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package publish
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// gitUserName / gitUserEmail 是发布提交的作者身份。固定值而不是读全局 git 配置：服务端定时发布的进程用户
+// 通常没有配置身份，git 会直接拒绝提交。
+const (
+	gitUserName  = "Ech0"
+	gitUserEmail = "ech0@localhost"
+)
+
+// pushGit 把产物提交到 target.Branch 并推送。分支整棵树替换成本次产物（Pages 分支只放站点），
+// 内容没变就不提交、不推送。
+//
+// 调用系统 git 而不是内嵌实现：鉴权（SSH key、credential helper、URL 里的令牌）全部沿用
+// 运行用户的 git 配置，不必在这里再造一套。
+func pushGit(ctx context.Context, dir string, files map[string]string, target Target) (*Result, error) {
+	work, err := os.MkdirTemp("", "ech0-publish-*")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(work) }()
+
+	git := func(args ...string) (string, error) {
+		return runGit(ctx, work, args...)
+	}
+
+	if _, err := git("init", "--quiet"); err != nil {
+		return nil, err
+	}
+	if _, err := git("remote", "add", "origin", target.Remote); err != nil {
+		return nil, err
+	}
+
+	// ls-remote 区分「分支不存在」（新开孤儿分支）与「远端不可达」（报错）：
+	// 直接 fetch 失败时两者无从分辨，把网络故障当成新分支会覆盖掉整段历史。
+	heads, err := git("ls-remote", "--heads", "origin", target.Branch)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(heads) != "" {
+		if _, err := git("fetch", "--quiet", "--depth", "1", "origin", target.Branch); err != nil {
+			return nil, err
+		}
+		if _, err := git("checkout", "--quiet", "-B", target.Branch, "FETCH_HEAD"); err != nil {
+			return nil, err
+		}
+	} else if _, err := git("checkout", "--quiet", "--orphan", target.Branch); err != nil {
+		return nil, err
+	}
+
+	if err := replaceTree(work, dir, files); err != nil {
+		return nil, err
+	}
+	// GitHub Pages 默认跑 Jekyll，会丢掉下划线开头的文件（_plugin-vue_export-helper-*.js），SPA 直接白屏。
+	if err := os.WriteFile(filepath.Join(work, ".nojekyll"), nil, 0o644); err != nil {
+		return nil, err
+	}
+
+	if _, err := git("add", "--all"); err != nil {
+		return nil, err
+	}
+	status, err := git("diff", "--cached", "--name-status")
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Result{Target: target.String()}
+	for _, line := range strings.Split(strings.TrimSpace(status), "\n") {
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "D") {
+			res.Deleted++
+		} else {
+			res.Uploaded++
+		}
+	}
+	res.Unchanged = max(0, len(files)-res.Uploaded)
+	if res.Uploaded+res.Deleted == 0 {
+		return res, nil
+	}
+
+	message := "Publish site " + time.Now().UTC().Format(time.RFC3339)
+	if _, err := git("-c", "user.name="+gitUserName, "-c", "user.email="+gitUserEmail,
+		"commit", "--quiet", "--no-verify", "-m", message); err != nil {
+		return nil, err
+	}
+	if _, err := git("push", "--quiet", "origin", "HEAD:refs/heads/"+target.Branch); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// replaceTree 清空工作区（保留 .git）后铺上本次产物。
+func replaceTree(work, dir string, files map[string]string) error {
+	entries, err := os.ReadDir(work)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.Name() == ".git" {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(work, e.Name())); err != nil {
+			return err
+		}
+	}
+	for key := range files {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(key)))
+		if err != nil {
+			return err
+		}
+		dest := filepath.Join(work, filepath.FromSlash(key))
+		if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dest, data, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runGit 在 work 下执行一条 git 命令。关掉交互式鉴权提示：服务端没有终端，
+// 等一个永远不会来的输入只会把发布挂死。出错信息里的远端地址去掉了 userinfo。
+func runGit(ctx context.Context, work string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = work
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, redactOutput(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// redactOutput 抹掉 git 输出里形如 https://user:token@ 的凭据。
+func redactOutput(s string) string {
+	fields := strings.Fields(s)
+	for i, f := range fields {
+		if strings.Contains(f, "://") && strings.Contains(f, "@") {
+			fields[i] = redactURL(strings.Trim(f, "'\""))
+		}
+	}
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package publish
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lin-snow/ech0/pkg/virefs"
+)
+
+// ManifestKey 是增量镜像的清单在目标根下的位置。以点开头：多数静态托管不对外提供隐藏文件。
+const ManifestKey = ".ech0-publish.json"
+
+// manifestVersion 是清单格式版本；读到更高版本时按「没有清单」处理（全量上传、不删除）。
+const manifestVersion = 1
+
+// manifest 记录上一次发布出去的每个文件的内容摘要。
+type manifest struct {
+	Version int               `json:"version"`
+	Files   map[string]string `json:"files"`
+}
+
+// 缓存策略按产物布局划分：assets/ 是 Vite 带内容哈希的文件名，永不变；媒体字节按 key 寻址，
+// 基本不变；其余（HTML、dataset.json、feed、api/connect）每次构建都可能变，要求回源校验。
+const (
+	cacheImmutable  = "public, max-age=31536000, immutable"
+	cacheMedia      = "public, max-age=86400"
+	cacheRevalidate = "no-cache"
+)
+
+func cacheControl(key string) string {
+	switch {
+	case strings.HasPrefix(key, "assets/"):
+		return cacheImmutable
+	case strings.HasPrefix(key, "api/files/"):
+		return cacheMedia
+	default:
+		return cacheRevalidate
+	}
+}
+
+// contentType 按扩展名给 MIME。无扩展名的只有 api/connect（spec §10），它的 body 是 JSON。
+func contentType(key string) string {
+	ext := path.Ext(key)
+	if ext == "" {
+		return "application/json"
+	}
+	if ct := mime.TypeByExtension(ext); ct != "" {
+		return ct
+	}
+	return "application/octet-stream"
+}
+
+// hashTree 计算 dir 下每个文件的 SHA-256，键为正斜杠相对路径。
+func hashTree(dir string) (map[string]string, error) {
+	out := make(map[string]string)
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		sum, err := hashFile(p)
+		if err != nil {
+			return err
+		}
+		out[filepath.ToSlash(rel)] = sum
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan build output %s: %w", dir, err)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("build output %s is empty", dir)
+	}
+	return out, nil
+}
+
+func hashFile(p string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// mirror 把产物增量镜像到 fsys：上传变了的，删除上次有、这次没有的，最后写回清单。
+//
+// 清单最后写：中途失败时旧清单还在，下一次发布会把没传完的重传一遍，删掉的文件至多再删一次
+// （ObjectFS 删除幂等，LocalFS 的 ErrNotFound 在这里吞掉）。HTML 排在其它文件之后上传，
+// 页面上线时它引用的资源已经就位。
+func mirror(ctx context.Context, dir string, files map[string]string, fsys virefs.FS, target Target) (*Result, error) {
+	prev, err := readManifest(ctx, fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(files))
+	for k := range files {
+		keys = append(keys, k)
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		hi, hj := path.Ext(keys[i]) == ".html", path.Ext(keys[j]) == ".html"
+		if hi != hj {
+			return !hi
+		}
+		return keys[i] < keys[j]
+	})
+
+	res := &Result{Target: target.String()}
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+		if prev.Files[key] == files[key] {
+			res.Unchanged++
+			continue
+		}
+		if err := upload(ctx, fsys, dir, key); err != nil {
+			return res, err
+		}
+		res.Uploaded++
+	}
+
+	stale := make([]string, 0)
+	for key := range prev.Files {
+		if _, ok := files[key]; !ok {
+			stale = append(stale, key)
+		}
+	}
+	sort.Strings(stale)
+	for _, key := range stale {
+		if err := fsys.Delete(ctx, key); err != nil && !errors.Is(err, virefs.ErrNotFound) {
+			return res, fmt.Errorf("delete %s: %w", key, err)
+		}
+		res.Deleted++
+	}
+
+	raw, err := json.Marshal(manifest{Version: manifestVersion, Files: files})
+	if err != nil {
+		return res, err
+	}
+	if err := fsys.Put(ctx, ManifestKey, strings.NewReader(string(raw)),
+		virefs.WithContentType("application/json"), virefs.WithCacheControl(cacheRevalidate)); err != nil {
+		return res, fmt.Errorf("write publish manifest: %w", err)
+	}
+	return res, nil
+}
+
+func upload(ctx context.Context, fsys virefs.FS, dir, key string) error {
+	f, err := os.Open(filepath.Join(dir, filepath.FromSlash(key)))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	if err := fsys.Put(ctx, key, f,
+		virefs.WithContentType(contentType(key)), virefs.WithCacheControl(cacheControl(key))); err != nil {
+		return fmt.Errorf("upload %s: %w", key, err)
+	}
+	return nil
+}
+
+// readManifest 读目标根下的清单；不存在或版本不认得时返回空清单。
+func readManifest(ctx context.Context, fsys virefs.FS) (manifest, error) {
+	empty := manifest{Files: map[string]string{}}
+	rc, err := fsys.Get(ctx, ManifestKey)
+	if errors.Is(err, virefs.ErrNotFound) {
+		return empty, nil
+	}
+	if err != nil {
+		return manifest{}, fmt.Errorf("read publish manifest: %w", err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	var m manifest
+	if err := json.NewDecoder(rc).Decode(&m); err != nil {
+		return manifest{}, fmt.Errorf("decode publish manifest: %w", err)
+	}
+	if m.Version != manifestVersion || m.Files == nil {
+		return empty, nil
+	}
+	return m, nil
+}
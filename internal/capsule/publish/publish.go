@@ -0,0 +1,225 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package publish 把 `ech0 build` 的产物目录部署到托管位置（spec §10.1）。
+//
+// 三种目标共用一个入口：
+//
+//   - s3://bucket[/prefix]?endpoint=&region=&provider=：经 pkg/virefs 上传到 S3 兼容存储；
+//   - git+<remote>[#branch]：提交到一个分支，供 GitHub / Gitea Pages 这类托管；
+//   - 本地目录（裸路径或 file://）：镜像进 Web 服务器的站点根。
+//
+// S3 与本地目录走同一套增量镜像：目标根下存一份清单（相对路径 → SHA-256），只上传内容变了的
+// 文件，删掉清单里有、本次产物里没有的文件。清单之外的文件一律不碰——目标根下别人放的东西
+// 不归我们管，第一次发布（还没有清单）因此也不会删任何东西。
+package publish
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/lin-snow/ech0/pkg/virefs"
+)
+
+// Kind 是发布目标的种类。
+type Kind string
+
+const (
+	KindS3  Kind = "s3"
+	KindGit Kind = "git"
+	KindDir Kind = "dir"
+)
+
+// DefaultGitBranch 是 git 目标省略 #branch 时的分支。
+const DefaultGitBranch = "gh-pages"
+
+// Target 是解析后的发布目标。
+type Target struct {
+	Kind Kind
+
+	// S3
+	Bucket    string
+	Prefix    string
+	Endpoint  string
+	Region    string
+	Provider  virefs.Provider
+	PathStyle *bool
+
+	// git
+	Remote string
+	Branch string
+
+	// dir
+	Path string
+}
+
+// String 输出不含凭据的目标描述，供日志与 CLI 摘要使用。
+func (t Target) String() string {
+	switch t.Kind {
+	case KindS3:
+		return "s3://" + t.Bucket + "/" + t.Prefix
+	case KindGit:
+		return "git+" + redactURL(t.Remote) + "#" + t.Branch
+	default:
+		return t.Path
+	}
+}
+
+// Local 报告目标是否写进本机文件系统：目录目标，以及远端不是网络地址的 git 目标
+// （本地路径、file://、transport::address 写法）。这类目标只该由 CLI 的操作者指定，
+// 服务端的管理接口不接受。
+func (t Target) Local() bool {
+	switch t.Kind {
+	case KindS3:
+		return false
+	case KindGit:
+		return !isNetworkRemote(t.Remote)
+	default:
+		return true
+	}
+}
+
+// isNetworkRemote 只认 git 的网络传输：http(s)、ssh、git 协议的 URL，以及 scp 式的
+// [user@]host:path（冒号前没有路径分隔符，且不是 Windows 盘符）。
+func isNetworkRemote(remote string) bool {
+	if scheme, _, ok := strings.Cut(remote, "://"); ok {
+		switch strings.ToLower(scheme) {
+		case "http", "https", "ssh", "git", "git+ssh", "ssh+git":
+			return true
+		}
+		return false
+	}
+	if strings.Contains(remote, "::") {
+		return false
+	}
+	host, _, ok := strings.Cut(remote, ":")
+	return ok && len(host) > 1 && !strings.ContainsAny(host, `/\`)
+}
+
+// ParseTarget 解析 --publish 的取值。不认得的 scheme 直接报错，免得把 "s4://…" 当成
+// 一个叫这个名字的本地目录静默写进去。
+func ParseTarget(raw string) (Target, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return Target{}, fmt.Errorf("publish target is empty")
+	}
+
+	switch {
+	case strings.HasPrefix(raw, "s3://"):
+		return parseS3(raw)
+	case strings.HasPrefix(raw, "git+"):
+		remote, branch, _ := strings.Cut(strings.TrimPrefix(raw, "git+"), "#")
+		if remote == "" {
+			return Target{}, fmt.Errorf("git publish target needs a remote: %s", raw)
+		}
+		if branch == "" {
+			branch = DefaultGitBranch
+		}
+		return Target{Kind: KindGit, Remote: remote, Branch: branch}, nil
+	case strings.HasPrefix(raw, "file://"):
+		u, err := url.Parse(raw)
+		if err != nil || u.Path == "" {
+			return Target{}, fmt.Errorf("invalid file publish target: %s", raw)
+		}
+		return Target{Kind: KindDir, Path: filepath.FromSlash(u.Path)}, nil
+	case strings.Contains(raw, "://"):
+		return Target{}, fmt.Errorf("unsupported publish target: %s", raw)
+	default:
+		return Target{Kind: KindDir, Path: raw}, nil
+	}
+}
+
+func parseS3(raw string) (Target, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return Target{}, fmt.Errorf("invalid s3 publish target: %s", raw)
+	}
+	t := Target{
+		Kind:     KindS3,
+		Bucket:   u.Host,
+		Prefix:   strings.Trim(u.Path, "/"),
+		Endpoint: u.Query().Get("endpoint"),
+		Region:   u.Query().Get("region"),
+	}
+	if t.Prefix != "" {
+		t.Prefix += "/"
+	}
+	switch strings.ToLower(u.Query().Get("provider")) {
+	case "", "aws":
+		t.Provider = virefs.ProviderAWS
+	case "minio":
+		t.Provider = virefs.ProviderMinIO
+	case "r2":
+		t.Provider = virefs.ProviderR2
+	default:
+		return Target{}, fmt.Errorf("unknown s3 provider %q", u.Query().Get("provider"))
+	}
+	if v := u.Query().Get("path_style"); v != "" {
+		pathStyle := v == "true" || v == "1"
+		t.PathStyle = &pathStyle
+	}
+	return t, nil
+}
+
+// Options 是一次发布的附加参数。
+type Options struct {
+	// AccessKey / SecretKey 是 S3 静态凭据；都留空时走 SDK 默认凭据链（环境变量、共享配置、实例角色）。
+	AccessKey string
+	SecretKey string
+}
+
+// Result 是一次发布的摘要。
+type Result struct {
+	Target    string
+	Uploaded  int
+	Deleted   int
+	Unchanged int
+}
+
+// Run 把 dir 下的构建产物发布到 target。
+func Run(ctx context.Context, dir string, target Target, opts Options) (*Result, error) {
+	files, err := hashTree(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	switch target.Kind {
+	case KindS3:
+		client, err := virefs.NewS3Client(ctx, &virefs.S3Config{
+			Region:       target.Region,
+			Endpoint:     target.Endpoint,
+			AccessKey:    opts.AccessKey,
+			SecretKey:    opts.SecretKey,
+			Provider:     target.Provider,
+			UsePathStyle: target.PathStyle,
+		})
+		if err != nil {
+			return nil, err
+		}
+		fsys := virefs.NewObjectFS(client, target.Bucket, virefs.WithPrefix(target.Prefix))
+		return mirror(ctx, dir, files, fsys, target)
+	case KindDir:
+		fsys, err := virefs.NewLocalFS(target.Path, virefs.WithCreateRoot(), virefs.WithAtomicWrite())
+		if err != nil {
+			return nil, err
+		}
+		return mirror(ctx, dir, files, fsys, target)
+	case KindGit:
+		return pushGit(ctx, dir, files, target)
+	default:
+		return nil, fmt.Errorf("unsupported publish target kind %q", target.Kind)
+	}
+}
+
+// redactURL 去掉 URL 里的 userinfo：git 远端常把令牌写成 https://token@host/…。
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return raw
+	}
+	u.User = nil
+	return u.String()
+}
@@ -0,0 +1,524 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package replica 把一份增量胶囊（manifest.since 非空，spec §13）合并进本库，是实例间
+// 同步的落库端。
+//
+// importer 是 create-only 的：id 已存在即跳过。同步要的恰好是它缺的那一半——对端改过
+// 的行要覆盖过来，两端都改过的行要判冲突。本包只补这一半，新建仍整体交给 importer：
+//
+//   - Echo：判定「对端版本胜出」即删掉本地行及其 tags / files / extension 关联，随后
+//     importer 以同一 id 原样重建。删后重建而非逐列 UPDATE，是为了让标签 find-or-create、
+//     文件去重改名这些语义只有 importer 一份实现。
+//   - 评论：就地更新可编辑列（内容、状态、昵称、网址），不动 echo_id / parent_id。
+//
+// 冲突以 updated_at 判定：本地行在上次同步水位（Options.Base）之后又被改过，而对端也送来了
+// 它的新版本，即两端各改了一次。按 Options.Policy 裁决，每一起都记进 Result.Conflicts，
+// 由同步作业上报给管理员。
+//
+// 删除靠清单里的 deleted 块（墓碑）传播，与修改走同一套冲突判定：
+//
+//   - 对端删了、本地自水位以来没动过的行，直接删；本地也改过即冲突，newer 拿删除时刻与本地
+//     updated_at 比。
+//   - 本地删了、对端又送来新版本的行，同样按冲突裁决；删除胜出则不让 importer 重建它，
+//     对端胜出则重建并撤掉本地墓碑，免得下一轮又把删除推回去。
+//
+// 与 importer 一样不发布事件、不调 service 层。
+package replica
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/lin-snow/ech0/internal/capsule"
+	"github.com/lin-snow/ech0/internal/capsule/importer"
+	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	"github.com/lin-snow/ech0/internal/transaction"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"gorm.io/gorm"
+)
+
+// Policy 决定冲突时哪一端胜出。
+type Policy string
+
+const (
+	// PolicyNewer 取 updated_at 更晚的一端；相等时保留本地。缺省策略。
+	PolicyNewer Policy = "newer"
+	// PolicyLocal 冲突时一律保留本地版本。
+	PolicyLocal Policy = "local"
+	// PolicyRemote 冲突时一律采用对端版本。
+	PolicyRemote Policy = "remote"
+)
+
+// ParsePolicy 收口策略取值：空值即 PolicyNewer，未知取值报错而非静默回落。
+func ParsePolicy(raw string) (Policy, error) {
+	switch Policy(raw) {
+	case "", PolicyNewer:
+		return PolicyNewer, nil
+	case PolicyLocal, PolicyRemote:
+		return Policy(raw), nil
+	default:
+		return "", fmt.Errorf("unknown conflict policy %q", raw)
+	}
+}
+
+// 冲突所涉记录的种类，与墓碑的 kind 同一套取值。
+const (
+	KindEcho    = commonModel.TombstoneEcho
+	KindComment = commonModel.TombstoneComment
+)
+
+// 冲突的裁决结果。
+const (
+	ResolutionLocal  = "kept_local"
+	ResolutionRemote = "took_remote"
+)
+
+// 冲突中做了删除的一端。
+const (
+	DeletedLocal  = "local"
+	DeletedRemote = "remote"
+)
+
+type Options struct {
+	// Base 是上一次同步完成时本地时钟的水位（秒）。本地 updated_at 晚于它即视为「本地也改过」；
+	// 首次同步为 0，此时两端内容不同的同 id 记录一律按冲突处理。
+	Base           int64
+	Policy         Policy
+	IncludePrivate bool
+}
+
+// Conflict 是一起冲突的记录，时间戳为 Unix 秒，供前端直接渲染。Deleted 非空时，那一端的
+// 时间戳是删除时刻。
+type Conflict struct {
+	Kind            string `json:"kind"`
+	ID              string `json:"id"`
+	LocalUpdatedAt  int64  `json:"local_updated_at"`
+	RemoteUpdatedAt int64  `json:"remote_updated_at"`
+	Deleted         string `json:"deleted,omitempty"`
+	Resolution      string `json:"resolution"`
+}
+
+type Result struct {
+	Imported        *importer.Result `json:"imported"`
+	EchoesUpdated   int              `json:"echoes_updated"`
+	EchoesDeleted   int              `json:"echoes_deleted"`
+	CommentsUpdated int              `json:"comments_updated"`
+	CommentsDeleted int              `json:"comments_deleted"`
+	Conflicts       []Conflict       `json:"conflicts"`
+}
+
+// Apply 在单个事务内合并一份增量胶囊。调用方必须先跑 check 且确认无 error。
+func Apply(ctx context.Context, deps importer.Deps, loaded *capsule.Loaded, opts Options) (*Result, error) {
+	switch {
+	case loaded == nil:
+		return nil, errors.New("capsule replica: loaded capsule is nil")
+	case deps.Tx == nil:
+		return nil, errors.New("capsule replica: transactor is required")
+	case deps.DB == nil:
+		return nil, errors.New("capsule replica: database handle is required")
+	}
+	if opts.Policy == "" {
+		opts.Policy = PolicyNewer
+	}
+
+	result := &Result{Conflicts: []Conflict{}}
+	err := deps.Tx.Run(ctx, func(txCtx context.Context) error {
+		db := deps.DB
+		if tx, ok := transaction.TxFromContext(txCtx); ok {
+			db = tx
+		}
+		m := &merger{db: db.WithContext(txCtx), opts: opts, res: result, tags: make(map[string]struct{})}
+		if err := m.mergeEchoes(loaded); err != nil {
+			return err
+		}
+		if err := m.mergeComments(loaded); err != nil {
+			return err
+		}
+		if err := m.applyTombstones(loaded); err != nil {
+			return err
+		}
+		alive, err := m.skipBuried(loaded)
+		if err != nil {
+			return err
+		}
+
+		// 被让位的行已删，importer 会把它们当新行重建；它跑在外层事务里，失败则一并回滚。
+		inner := deps
+		inner.Tx = ambientTx{}
+		imported, err := importer.Run(txCtx, inner, alive, importer.Options{IncludePrivate: opts.IncludePrivate})
+		if err != nil {
+			return err
+		}
+		result.Imported = imported
+		return m.recountTags()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logUtil.GetLogger().Info("capsule replica merged",
+		slog.String("module", "capsule"),
+		slog.Int("echoes_updated", result.EchoesUpdated),
+		slog.Int("echoes_deleted", result.EchoesDeleted),
+		slog.Int("comments_updated", result.CommentsUpdated),
+		slog.Int("comments_deleted", result.CommentsDeleted),
+		slog.Int("conflicts", len(result.Conflicts)),
+	)
+	return result, nil
+}
+
+// ambientTx 让 importer 复用 Apply 已开启的事务。GormTransactor 不支持嵌套，直接交给它
+// 会另起一个事务，看不到外层刚删掉的行。
+type ambientTx struct{}
+
+func (ambientTx) Run(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type merger struct {
+	db   *gorm.DB
+	opts Options
+	res  *Result
+	// tags 收集被删关联所指的标签：它们的 usage_count 要在 importer 重建后重算，
+	// importer 只会重算它自己接触过的那些。
+	tags map[string]struct{}
+}
+
+// decide 判定对端版本是否胜出，并在两端都改过时记一起冲突。c 给出种类、id、两端时刻与
+// 删除方，Resolution 由这里填。
+func (m *merger) decide(c Conflict) bool {
+	local, remote := c.LocalUpdatedAt, c.RemoteUpdatedAt
+	if local == remote {
+		return false
+	}
+	if local <= m.opts.Base {
+		// 本地自上次同步以来没动过，对端送来的就是更新的版本。
+		return true
+	}
+
+	var takeRemote bool
+	switch m.opts.Policy {
+	case PolicyRemote:
+		takeRemote = true
+	case PolicyLocal:
+		takeRemote = false
+	default:
+		takeRemote = remote > local
+	}
+	c.Resolution = ResolutionLocal
+	if takeRemote {
+		c.Resolution = ResolutionRemote
+	}
+	m.res.Conflicts = append(m.res.Conflicts, c)
+	return takeRemote
+}
+
+func (m *merger) mergeEchoes(loaded *capsule.Loaded) error {
+	for i := range loaded.Echoes {
+		le := &loaded.Echoes[i]
+		if le.Err != nil || le.Doc == nil || le.Doc.ID == "" {
+			// 留给 importer 按它的口径报错，这里不重复一套。
+			continue
+		}
+		doc := le.Doc
+		// importer 不会重建被排除的私密 Echo，删了就真没了。
+		if doc.Private && !m.opts.IncludePrivate {
+			continue
+		}
+
+		var local echoModel.Echo
+		err := m.db.Select("id", "private", "created_at", "updated_at").
+			Where("id = ?", doc.ID).Take(&local).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("capsule replica: %s: probe echo: %w", le.Path, err)
+		}
+		if local.Private && !m.opts.IncludePrivate {
+			continue
+		}
+
+		remote, err := docTime(doc.CreatedAt, doc.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("capsule replica: %s: %w", le.Path, err)
+		}
+		if !m.decide(Conflict{
+			Kind:            KindEcho,
+			ID:              doc.ID,
+			LocalUpdatedAt:  lastModified(local.CreatedAt, local.UpdatedAt),
+			RemoteUpdatedAt: remote,
+		}) {
+			continue
+		}
+		if err := m.dropEcho(doc.ID); err != nil {
+			return fmt.Errorf("capsule replica: %s: %w", le.Path, err)
+		}
+		m.res.EchoesUpdated++
+	}
+	return nil
+}
+
+// dropEcho 删掉 Echo 行及其关联，把位置让给 importer 重建。评论挂在 echo_id 上、不随之删除，
+// 重建后原样归位。
+func (m *merger) dropEcho(id string) error {
+	var tagIDs []string
+	if err := m.db.Model(&echoModel.EchoTag{}).Where("echo_id = ?", id).Pluck("tag_id", &tagIDs).Error; err != nil {
+		return fmt.Errorf("collect tags: %w", err)
+	}
+	for _, tagID := range tagIDs {
+		m.tags[tagID] = struct{}{}
+	}
+	if err := m.db.Where("echo_id = ?", id).Delete(&echoModel.EchoTag{}).Error; err != nil {
+		return fmt.Errorf("drop tags: %w", err)
+	}
+	if err := m.db.Where("echo_id = ?", id).Delete(&fileModel.EchoFile{}).Error; err != nil {
+		return fmt.Errorf("drop files: %w", err)
+	}
+	if err := m.db.Where("echo_id = ?", id).Delete(&echoModel.EchoExtension{}).Error; err != nil {
+		return fmt.Errorf("drop extension: %w", err)
+	}
+	if err := m.db.Where("id = ?", id).Delete(&echoModel.Echo{}).Error; err != nil {
+		return fmt.Errorf("drop echo: %w", err)
+	}
+	return nil
+}
+
+func (m *merger) mergeComments(loaded *capsule.Loaded) error {
+	if loaded.Comments == nil {
+		return nil
+	}
+	for i := range loaded.Comments.Comments {
+		c := &loaded.Comments.Comments[i]
+		if c.ID == "" {
+			continue
+		}
+
+		var local commentModel.Comment
+		err := m.db.Select("id", "created_at", "updated_at").Where("id = ?", c.ID).Take(&local).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("capsule replica: probe comment %s: %w", c.ID, err)
+		}
+
+		remote, err := docTime(c.CreatedAt, c.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("capsule replica: comment %s: %w", c.ID, err)
+		}
+		if !m.decide(Conflict{
+			Kind:            KindComment,
+			ID:              c.ID,
+			LocalUpdatedAt:  lastModified(local.CreatedAt, local.UpdatedAt),
+			RemoteUpdatedAt: remote,
+		}) {
+			continue
+		}
+
+		status := c.Status
+		if status == "" {
+			status = capsule.DefaultCommentStatus
+		}
+		// UpdateColumns 不触发 autoUpdateTime：updated_at 必须是对端的值，否则下一轮
+		// 同步会把这次搬运误认成本地编辑。
+		if err := m.db.Model(&commentModel.Comment{}).Where("id = ?", c.ID).UpdateColumns(map[string]any{
+			"content":    c.Content,
+			"status":     status,
+			"nickname":   c.Nickname,
+			"website":    c.Website,
+			"updated_at": remote,
+		}).Error; err != nil {
+			return fmt.Errorf("capsule replica: update comment %s: %w", c.ID, err)
+		}
+		m.res.CommentsUpdated++
+	}
+	return nil
+}
+
+// applyTombstones 执行对端送来的删除。本地不存在的行直接略过：要么从没同步过来，要么
+// 已经删了。
+func (m *merger) applyTombstones(loaded *capsule.Loaded) error {
+	if loaded.Manifest == nil {
+		return nil
+	}
+	for _, t := range loaded.Manifest.Deleted {
+		deletedAt, err := capsule.ParseTime(t.DeletedAt)
+		if err != nil {
+			return fmt.Errorf("capsule replica: tombstone %s: %w", t.ID, err)
+		}
+		switch t.Kind {
+		case KindEcho:
+			err = m.buryEcho(t.ID, deletedAt)
+		case KindComment:
+			err = m.buryComment(t.ID, deletedAt)
+		}
+		if err != nil {
+			return fmt.Errorf("capsule replica: tombstone %s: %w", t.ID, err)
+		}
+	}
+	return nil
+}
+
+func (m *merger) buryEcho(id string, deletedAt int64) error {
+	var local echoModel.Echo
+	err := m.db.Select("id", "private", "created_at", "updated_at").Where("id = ?", id).Take(&local).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("probe echo: %w", err)
+	}
+	// 与 mergeEchoes 同一口径：不同步私密内容时，本地私密行不受对端影响。
+	if local.Private && !m.opts.IncludePrivate {
+		return nil
+	}
+	if !m.decide(Conflict{
+		Kind:            KindEcho,
+		ID:              id,
+		LocalUpdatedAt:  lastModified(local.CreatedAt, local.UpdatedAt),
+		RemoteUpdatedAt: deletedAt,
+		Deleted:         DeletedRemote,
+	}) {
+		return nil
+	}
+	if err := m.dropEcho(id); err != nil {
+		return err
+	}
+	m.res.EchoesDeleted++
+	return nil
+}
+
+func (m *merger) buryComment(id string, deletedAt int64) error {
+	var local commentModel.Comment
+	err := m.db.Select("id", "created_at", "updated_at").Where("id = ?", id).Take(&local).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("probe comment: %w", err)
+	}
+	if !m.decide(Conflict{
+		Kind:            KindComment,
+		ID:              id,
+		LocalUpdatedAt:  lastModified(local.CreatedAt, local.UpdatedAt),
+		RemoteUpdatedAt: deletedAt,
+		Deleted:         DeletedRemote,
+	}) {
+		return nil
+	}
+	if err := m.db.Where("id = ?", id).Delete(&commentModel.Comment{}).Error; err != nil {
+		return fmt.Errorf("drop comment: %w", err)
+	}
+	m.res.CommentsDeleted++
+	return nil
+}
+
+// skipBuried 处理本地已删、对端又送来的记录，返回交给 importer 的那份胶囊：删除胜出的记录
+// 从中剔除；对端胜出的照常重建，并撤掉本地墓碑。loaded 本身不改。
+func (m *merger) skipBuried(loaded *capsule.Loaded) (*capsule.Loaded, error) {
+	alive := *loaded
+	alive.Echoes = make([]capsule.LoadedEcho, 0, len(loaded.Echoes))
+	for _, le := range loaded.Echoes {
+		if le.Err == nil && le.Doc != nil && le.Doc.ID != "" && (!le.Doc.Private || m.opts.IncludePrivate) {
+			keep, err := m.exhume(KindEcho, le.Doc.ID, le.Doc.CreatedAt, le.Doc.UpdatedAt)
+			if err != nil {
+				return nil, fmt.Errorf("capsule replica: %s: %w", le.Path, err)
+			}
+			if !keep {
+				continue
+			}
+		}
+		alive.Echoes = append(alive.Echoes, le)
+	}
+
+	if loaded.Comments == nil {
+		return &alive, nil
+	}
+	doc := *loaded.Comments
+	doc.Comments = make([]capsule.Comment, 0, len(loaded.Comments.Comments))
+	for _, c := range loaded.Comments.Comments {
+		if c.ID != "" {
+			keep, err := m.exhume(KindComment, c.ID, c.CreatedAt, c.UpdatedAt)
+			if err != nil {
+				return nil, fmt.Errorf("capsule replica: comment %s: %w", c.ID, err)
+			}
+			if !keep {
+				continue
+			}
+		}
+		doc.Comments = append(doc.Comments, c)
+	}
+	alive.Comments = &doc
+	return &alive, nil
+}
+
+// exhume 判定一条本地已删的记录该不该按对端版本重建。本地没有墓碑即与删除无关，照常放行。
+func (m *merger) exhume(kind, id, createdAt, updatedAt string) (bool, error) {
+	var tomb commonModel.Tombstone
+	err := m.db.Where("kind = ? AND id = ?", kind, id).Take(&tomb).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("probe tombstone: %w", err)
+	}
+	remote, err := docTime(createdAt, updatedAt)
+	if err != nil {
+		return false, err
+	}
+	if !m.decide(Conflict{
+		Kind:            kind,
+		ID:              id,
+		LocalUpdatedAt:  tomb.DeletedAt,
+		RemoteUpdatedAt: remote,
+		Deleted:         DeletedLocal,
+	}) {
+		return false, nil
+	}
+	if err := m.db.Where("kind = ? AND id = ?", kind, id).Delete(&commonModel.Tombstone{}).Error; err != nil {
+		return false, fmt.Errorf("drop tombstone: %w", err)
+	}
+	return true, nil
+}
+
+func (m *merger) recountTags() error {
+	if len(m.tags) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(m.tags))
+	for id := range m.tags {
+		ids = append(ids, id)
+	}
+	if err := m.db.Exec(
+		"UPDATE tags SET usage_count = (SELECT COUNT(*) FROM echo_tags WHERE echo_tags.tag_id = tags.id) WHERE id IN ?",
+		ids,
+	).Error; err != nil {
+		return fmt.Errorf("capsule replica: recount tag usage: %w", err)
+	}
+	return nil
+}
+
+// docTime 取胶囊记录的最后修改时刻：updated_at 缺省即创建后未改过。
+func docTime(createdAt, updatedAt string) (int64, error) {
+	raw := updatedAt
+	if raw == "" {
+		raw = createdAt
+	}
+	ts, err := capsule.ParseTime(raw)
+	if err != nil {
+		return 0, fmt.Errorf("updated_at: %w", err)
+	}
+	return ts, nil
+}
+
+// lastModified 取库里记录的最后修改时刻。updated_at 列上线前的行为 0，按 created_at 兜底。
+func lastModified(createdAt, updatedAt int64) int64 {
+	return max(createdAt, updatedAt)
+}
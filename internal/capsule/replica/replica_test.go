@@ -0,0 +1,360 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package replica
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/capsule"
+	"github.com/lin-snow/ech0/internal/capsule/importer"
+	"github.com/lin-snow/ech0/internal/kvstore"
+	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
+	"github.com/lin-snow/ech0/internal/storage"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/lin-snow/ech0/internal/transaction"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+const (
+	echoID    = "01890000-0000-7000-8000-000000000001"
+	commentID = "01890000-0000-7000-8000-0000000000a1"
+
+	createdTS = int64(1709618828) // 2024-03-05T06:07:08Z
+)
+
+func newDeps(t *testing.T) (*gorm.DB, importer.Deps) {
+	t.Helper()
+	db := helpers.NewTestDB(t)
+	owner := userModel.User{Username: "owner", IsOwner: true, IsAdmin: true}
+	require.NoError(t, db.Create(&owner).Error)
+
+	return db, importer.Deps{
+		DB:       db,
+		Tx:       transaction.NewGormTransactor(func() *gorm.DB { return db }),
+		Selector: storage.NewStorageManagerForTest(t.TempDir()).GetSelector(),
+		KV:       kvstore.NewMemory(),
+	}
+}
+
+// seedLocal 在本库落一条带标签的 Echo 与一条评论，updated_at 由用例指定。
+func seedLocal(t *testing.T, db *gorm.DB, updatedAt int64) {
+	t.Helper()
+	var owner userModel.User
+	require.NoError(t, db.Where("is_owner = ?", true).First(&owner).Error)
+
+	require.NoError(t, db.Create(&echoModel.Echo{
+		ID:        echoID,
+		Content:   "local\n",
+		Username:  "owner",
+		UserID:    owner.ID,
+		CreatedAt: createdTS,
+		UpdatedAt: updatedAt,
+	}).Error)
+	tag := echoModel.Tag{Name: "stale", UsageCount: 1}
+	require.NoError(t, db.Create(&tag).Error)
+	require.NoError(t, db.Create(&echoModel.EchoTag{EchoID: echoID, TagID: tag.ID}).Error)
+	require.NoError(t, db.Create(&commentModel.Comment{
+		ID:        commentID,
+		EchoID:    echoID,
+		Nickname:  "visitor",
+		Content:   "local comment",
+		Status:    commentModel.Status(capsule.DefaultCommentStatus),
+		Source:    commentModel.SourceGuest,
+		CreatedAt: createdTS,
+		UpdatedAt: updatedAt,
+	}).Error)
+}
+
+// remoteCapsule 把对端送来的增量胶囊铺到磁盘上并载入，Echo 与评论的 updated_at 取同一值。
+func remoteCapsule(t *testing.T, updatedAt int64) *capsule.Loaded {
+	t.Helper()
+	dir := t.TempDir()
+	writeFile := func(rel string, data []byte) {
+		full := filepath.Join(dir, filepath.FromSlash(rel))
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, data, 0o644))
+	}
+
+	raw, err := capsule.EncodeYAML(&capsule.Manifest{
+		SchemaVersion: capsule.SchemaVersion,
+		Generator:     "ech0-test",
+		Since:         capsule.FormatUnix(createdTS),
+	})
+	require.NoError(t, err)
+	writeFile(capsule.ManifestPath, raw)
+
+	stamp := capsule.FormatUnix(updatedAt)
+	body, err := capsule.EncodeEcho(&capsule.EchoDoc{
+		ID:        echoID,
+		CreatedAt: capsule.FormatUnix(createdTS),
+		UpdatedAt: stamp,
+		Username:  "owner",
+		Tags:      []string{"fresh"},
+		Content:   "remote\n",
+	})
+	require.NoError(t, err)
+	writeFile(capsule.EchoPath(echoID, time.Unix(createdTS, 0).UTC()), body)
+
+	raw, err = capsule.EncodeYAML(&capsule.CommentsDoc{
+		SchemaVersion: capsule.SchemaVersion,
+		Comments: []capsule.Comment{{
+			ID:        commentID,
+			EchoID:    echoID,
+			Nickname:  "visitor",
+			Content:   "remote comment",
+			CreatedAt: capsule.FormatUnix(createdTS),
+			UpdatedAt: stamp,
+		}},
+	})
+	require.NoError(t, err)
+	writeFile(capsule.CommentsPath, raw)
+
+	src, err := capsule.Open(dir)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = src.Close() })
+	loaded, err := capsule.Load(context.Background(), src)
+	require.NoError(t, err)
+	return loaded
+}
+
+// remoteTombstones 是对端删掉了那条 Echo 与评论后送来的增量胶囊：清单里只有删除记录。
+func remoteTombstones(t *testing.T, deletedAt int64) *capsule.Loaded {
+	t.Helper()
+	dir := t.TempDir()
+	stamp := capsule.FormatUnix(deletedAt)
+	raw, err := capsule.EncodeYAML(&capsule.Manifest{
+		SchemaVersion: capsule.SchemaVersion,
+		Generator:     "ech0-test",
+		Since:         capsule.FormatUnix(createdTS),
+		Deleted: []capsule.Tombstone{
+			{Kind: KindEcho, ID: echoID, DeletedAt: stamp},
+			{Kind: KindComment, ID: commentID, DeletedAt: stamp},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, capsule.ManifestPath), raw, 0o644))
+
+	src, err := capsule.Open(dir)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = src.Close() })
+	loaded, err := capsule.Load(context.Background(), src)
+	require.NoError(t, err)
+	return loaded
+}
+
+func localState(t *testing.T, db *gorm.DB) (echoModel.Echo, commentModel.Comment) {
+	t.Helper()
+	var echo echoModel.Echo
+	require.NoError(t, db.Preload("Tags").Where("id = ?", echoID).First(&echo).Error)
+	var comment commentModel.Comment
+	require.NoError(t, db.Where("id = ?", commentID).First(&comment).Error)
+	return echo, comment
+}
+
+func TestApply_TakesRemoteWhenLocalUntouched(t *testing.T) {
+	db, deps := newDeps(t)
+	seedLocal(t, db, createdTS+10)
+
+	res, err := Apply(context.Background(), deps, remoteCapsule(t, createdTS+100), Options{Base: createdTS + 50})
+	require.NoError(t, err)
+	require.Equal(t, 1, res.EchoesUpdated)
+	require.Equal(t, 1, res.CommentsUpdated)
+	require.Empty(t, res.Conflicts)
+	require.Equal(t, 1, res.Imported.EchoesCreated, "被让位的 Echo 由 importer 以同一 id 重建")
+
+	echo, comment := localState(t, db)
+	require.Equal(t, "remote\n", echo.Content)
+	require.Equal(t, createdTS+100, echo.UpdatedAt, "updated_at 逐字保留对端的值")
+	require.Len(t, echo.Tags, 1)
+	require.Equal(t, "fresh", echo.Tags[0].Name)
+	require.Equal(t, "remote comment", comment.Content)
+	require.Equal(t, createdTS+100, comment.UpdatedAt)
+
+	var stale echoModel.Tag
+	require.NoError(t, db.Where("name = ?", "stale").First(&stale).Error)
+	require.Zero(t, stale.UsageCount, "被摘掉的标签要重算计数")
+}
+
+func TestApply_ConflictPolicies(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     Policy
+		remoteTS   int64
+		wantRemote bool
+	}{
+		{name: "newer picks remote when remote is later", policy: PolicyNewer, remoteTS: createdTS + 300, wantRemote: true},
+		{name: "newer keeps local when local is later", policy: PolicyNewer, remoteTS: createdTS + 100, wantRemote: false},
+		{name: "local always keeps local", policy: PolicyLocal, remoteTS: createdTS + 300, wantRemote: false},
+		{name: "remote always takes remote", policy: PolicyRemote, remoteTS: createdTS + 100, wantRemote: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, deps := newDeps(t)
+			// 本地在水位之后改过（200 > 50），对端也送来了新版本：两端各改一次即冲突。
+			seedLocal(t, db, createdTS+200)
+
+			res, err := Apply(context.Background(), deps, remoteCapsule(t, tt.remoteTS),
+				Options{Base: createdTS + 50, Policy: tt.policy})
+			require.NoError(t, err)
+			require.Len(t, res.Conflicts, 2)
+
+			want := ResolutionLocal
+			wantContent, wantComment := "local\n", "local comment"
+			if tt.wantRemote {
+				want = ResolutionRemote
+				wantContent, wantComment = "remote\n", "remote comment"
+			}
+			for _, c := range res.Conflicts {
+				require.Equal(t, want, c.Resolution)
+				require.Equal(t, createdTS+200, c.LocalUpdatedAt)
+				require.Equal(t, tt.remoteTS, c.RemoteUpdatedAt)
+			}
+			require.Equal(t, KindEcho, res.Conflicts[0].Kind)
+			require.Equal(t, KindComment, res.Conflicts[1].Kind)
+
+			echo, comment := localState(t, db)
+			require.Equal(t, wantContent, echo.Content)
+			require.Equal(t, wantComment, comment.Content)
+		})
+	}
+}
+
+func TestApply_SameVersionIsNoop(t *testing.T) {
+	db, deps := newDeps(t)
+	seedLocal(t, db, createdTS+100)
+
+	res, err := Apply(context.Background(), deps, remoteCapsule(t, createdTS+100), Options{})
+	require.NoError(t, err)
+	require.Zero(t, res.EchoesUpdated)
+	require.Zero(t, res.CommentsUpdated)
+	require.Empty(t, res.Conflicts)
+	require.Equal(t, 1, res.Imported.EchoesSkipped)
+
+	echo, _ := localState(t, db)
+	require.Equal(t, "local\n", echo.Content)
+}
+
+func TestApply_PropagatesRemoteDeletion(t *testing.T) {
+	db, deps := newDeps(t)
+	seedLocal(t, db, createdTS+10)
+
+	res, err := Apply(context.Background(), deps, remoteTombstones(t, createdTS+100), Options{Base: createdTS + 50})
+	require.NoError(t, err)
+	require.Equal(t, 1, res.EchoesDeleted)
+	require.Equal(t, 1, res.CommentsDeleted)
+	require.Empty(t, res.Conflicts)
+
+	var count int64
+	require.NoError(t, db.Model(&echoModel.Echo{}).Where("id = ?", echoID).Count(&count).Error)
+	require.Zero(t, count)
+	require.NoError(t, db.Model(&commentModel.Comment{}).Where("id = ?", commentID).Count(&count).Error)
+	require.Zero(t, count)
+
+	var stale echoModel.Tag
+	require.NoError(t, db.Where("name = ?", "stale").First(&stale).Error)
+	require.Zero(t, stale.UsageCount, "被删 Echo 的标签要重算计数")
+}
+
+func TestApply_RemoteDeletionConflicts(t *testing.T) {
+	tests := []struct {
+		name       string
+		deletedAt  int64
+		wantDelete bool
+	}{
+		{name: "deletion after the local edit wins", deletedAt: createdTS + 300, wantDelete: true},
+		{name: "local edit after the deletion wins", deletedAt: createdTS + 100, wantDelete: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, deps := newDeps(t)
+			seedLocal(t, db, createdTS+200)
+
+			res, err := Apply(context.Background(), deps, remoteTombstones(t, tt.deletedAt), Options{Base: createdTS + 50})
+			require.NoError(t, err)
+			require.Len(t, res.Conflicts, 2)
+			for _, c := range res.Conflicts {
+				require.Equal(t, DeletedRemote, c.Deleted)
+				require.Equal(t, tt.deletedAt, c.RemoteUpdatedAt)
+			}
+
+			var count int64
+			require.NoError(t, db.Model(&echoModel.Echo{}).Where("id = ?", echoID).Count(&count).Error)
+			if tt.wantDelete {
+				require.Zero(t, count)
+				require.Equal(t, 1, res.EchoesDeleted)
+			} else {
+				require.EqualValues(t, 1, count)
+				require.Zero(t, res.EchoesDeleted)
+			}
+		})
+	}
+}
+
+func TestApply_LocalDeletionIsNotResurrected(t *testing.T) {
+	tests := []struct {
+		name         string
+		remoteTS     int64
+		wantRecreate bool
+	}{
+		{name: "older remote edit stays deleted", remoteTS: createdTS + 100, wantRecreate: false},
+		{name: "newer remote edit is recreated", remoteTS: createdTS + 300, wantRecreate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, deps := newDeps(t)
+			// 本地在水位之后删掉了这两条，对端却送来了它们的新版本。
+			for _, tomb := range []commonModel.Tombstone{
+				{Kind: KindEcho, ID: echoID, DeletedAt: createdTS + 200},
+				{Kind: KindComment, ID: commentID, DeletedAt: createdTS + 200},
+			} {
+				require.NoError(t, db.Create(&tomb).Error)
+			}
+
+			res, err := Apply(context.Background(), deps, remoteCapsule(t, tt.remoteTS), Options{Base: createdTS + 50})
+			require.NoError(t, err)
+			require.Len(t, res.Conflicts, 2)
+			for _, c := range res.Conflicts {
+				require.Equal(t, DeletedLocal, c.Deleted)
+			}
+
+			var echoes, comments, tombs int64
+			require.NoError(t, db.Model(&echoModel.Echo{}).Where("id = ?", echoID).Count(&echoes).Error)
+			require.NoError(t, db.Model(&commentModel.Comment{}).Where("id = ?", commentID).Count(&comments).Error)
+			require.NoError(t, db.Model(&commonModel.Tombstone{}).Count(&tombs).Error)
+			if tt.wantRecreate {
+				require.EqualValues(t, 1, echoes)
+				require.EqualValues(t, 1, comments)
+				require.Zero(t, tombs, "重建后要撤掉墓碑，否则下一轮会把删除推回对端")
+			} else {
+				require.Zero(t, echoes)
+				require.Zero(t, comments)
+				require.EqualValues(t, 2, tombs)
+			}
+		})
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	p, err := ParsePolicy("")
+	require.NoError(t, err)
+	require.Equal(t, PolicyNewer, p)
+
+	p, err = ParsePolicy("remote")
+	require.NoError(t, err)
+	require.Equal(t, PolicyRemote, p)
+
+	_, err = ParsePolicy("coin-flip")
+	require.Error(t, err)
+}
@@ -32,6 +32,14 @@ type Manifest struct {
 	Owner         Owner     `yaml:"owner"`
 	Connects      []Connect `yaml:"connects,omitempty"`
 
+	// Since 非空表示这是一份增量胶囊：只含该时刻之后创建或修改过的 Echo 与评论，
+	// 是实例间同步交换的变更集（spec §13）。全量胶囊不写此键。
+	Since string `yaml:"since,omitempty"`
+
+	// Deleted 是 since 之后被删掉的 Echo 与评论，只出现在增量胶囊里（spec §13）。
+	// 胶囊其余部分只描述「存在什么」，删除得靠它单独表达，否则对端永远删不掉。
+	Deleted []Tombstone `yaml:"deleted,omitempty"`
+
 	// Files 是未挂在任何 Echo 上的文件行（站点 logo、上传后没用上的草稿附件）。
 	// 库里 files 是独立表，而 frontmatter 只能表达「挂在这条 Echo 上的文件」——
 	// 没有这个块，这些行就只有字节能进胶囊、元数据无处安放，导入后无法还原
@@ -40,6 +48,13 @@ type Manifest struct {
 	Files []FileRef `yaml:"files,omitempty"`
 }
 
+// Tombstone 是一条删除记录。Kind 取 echo / comment，DeletedAt 为 RFC3339。
+type Tombstone struct {
+	Kind      string `yaml:"kind"`
+	ID        string `yaml:"id"`
+	DeletedAt string `yaml:"deleted_at"`
+}
+
 // Site 是站点设置的公开子集。键名逐字对齐 SystemSetting 的 json tag——
 // 唯一被剔除的是行为开关 allow_register（spec §3：渲染所需皆入，运维行为皆弃）。
 type Site struct {
@@ -71,6 +86,7 @@ type Connect struct {
 type EchoDoc struct {
 	ID        string     `yaml:"id"`
 	CreatedAt string     `yaml:"created_at"`
+	UpdatedAt string     `yaml:"updated_at,omitempty"`
 	Username  string     `yaml:"username,omitempty"`
 	Tags      []string   `yaml:"tags,omitempty"`
 	Layout    string     `yaml:"layout,omitempty"`
@@ -83,7 +99,8 @@ type EchoDoc struct {
 }
 
 // FileRef 是 frontmatter 中的媒体引用。字段名与取值对齐 fileModel.File 的列；
-// 位置不入胶囊——由 MediaPath(Key) 纯函数派生（spec §4.2）。
+// 位置不入胶囊——由 MediaPath(Key) 纯函数派生（spec §4.2）。唯一的例外是 SHA256：
+// 它是胶囊自己的完整性字段，供 `check --deep` 核对字节，不对应任何列、不入库。
 type FileRef struct {
 	ID          string `yaml:"id,omitempty"`
 	Key         string `yaml:"key,omitempty"`
@@ -94,6 +111,7 @@ type FileRef struct {
 	Size        int64  `yaml:"size,omitempty"`
 	Width       int    `yaml:"width,omitempty"`
 	Height      int    `yaml:"height,omitempty"`
+	SHA256      string `yaml:"sha256,omitempty"`
 }
 
 // Managed 报告该引用是否为托管文件（字节随胶囊走）。key 与 url 互斥，
@@ -124,6 +142,7 @@ type Comment struct {
 	Status    string  `yaml:"status,omitempty"`
 	Source    string  `yaml:"source,omitempty"`
 	CreatedAt string  `yaml:"created_at"`
+	UpdatedAt string  `yaml:"updated_at,omitempty"`
 }
 
 // ForbiddenCommentFields 是 comments.yaml 中出现即为校验错误的键（spec §5）。
@@ -17,6 +17,7 @@ import (
 	capsuleCheck "github.com/lin-snow/ech0/internal/capsule/check"
 	capsuleExport "github.com/lin-snow/ech0/internal/capsule/export"
 	capsuleImporter "github.com/lin-snow/ech0/internal/capsule/importer"
+	capsulePublish "github.com/lin-snow/ech0/internal/capsule/publish"
 	"github.com/lin-snow/ech0/internal/config"
 	tuiUtil "github.com/lin-snow/ech0/internal/util/tui"
 	versionPkg "github.com/lin-snow/ech0/internal/version"
@@ -77,19 +78,50 @@ func DoExportCapsule(opts ExportCapsuleOptions) error {
 	return nil
 }
 
+// CheckOptions 对应 `ech0 check` 的 flag 集合。
+type CheckOptions struct {
+	Fix       bool
+	Deep      bool
+	CheckURLs bool
+	// Format 是报告格式：text（默认，人读，写 stderr）、json 或 junit。
+	Format string
+	// Report 是机器可读报告的输出文件；留空时写 stdout，此时不再打印人读报告。
+	Report string
+}
+
 // DoCheck 校验一个胶囊并按 spec §7 分级报告。存在错误级问题时返回 error（退出码 1）。
-func DoCheck(path string, fix bool) error {
+func DoCheck(path string, opts CheckOptions) error {
+	format := strings.ToLower(strings.TrimSpace(opts.Format))
+	switch format {
+	case "", capsuleCheck.FormatText:
+		format = capsuleCheck.FormatText
+	case capsuleCheck.FormatJSON, capsuleCheck.FormatJUnit:
+	default:
+		return fmt.Errorf("unknown report format %q (want text, json or junit)", opts.Format)
+	}
+
 	src, err := capsule.Open(path)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = src.Close() }()
 
-	_, report, err := capsuleCheck.Run(context.Background(), src, capsuleCheck.Options{Fix: fix})
+	_, report, err := capsuleCheck.Run(context.Background(), src, capsuleCheck.Options{
+		Fix:       opts.Fix,
+		Deep:      opts.Deep || opts.CheckURLs,
+		CheckURLs: opts.CheckURLs,
+	})
 	if err != nil {
 		return err
 	}
-	printCheckReport(path, report)
+	if format == capsuleCheck.FormatText || opts.Report != "" {
+		printCheckReport(path, report)
+	}
+	if format != capsuleCheck.FormatText {
+		if err := writeCheckReport(path, report, format, opts.Report); err != nil {
+			return err
+		}
+	}
 	if report.HasErrors() {
 		return fmt.Errorf("capsule check failed: %d error(s)", report.Count(capsuleCheck.LevelError))
 	}
@@ -191,8 +223,27 @@ Database in use: %s
 	return nil
 }
 
-// DoBuild 从胶囊编译出可静态部署的只读站点。
-func DoBuild(path, output, baseURL string) error {
+// BuildOptions 对应 `ech0 build` 的 flag 集合。
+type BuildOptions struct {
+	Output    string
+	BaseURL   string
+	Templates string
+	// Publish 非空时，构建成功后把产物发布到该目标（语法见 internal/capsule/publish）。
+	Publish string
+}
+
+// DoBuild 从胶囊编译出可静态部署的只读站点，按需发布出去。
+func DoBuild(path string, opts BuildOptions) error {
+	// 目标先于构建解析：写错的 --publish 不该等一整次构建跑完才报出来。
+	var target capsulePublish.Target
+	if opts.Publish != "" {
+		t, err := capsulePublish.ParseTarget(opts.Publish)
+		if err != nil {
+			return err
+		}
+		target = t
+	}
+
 	src, err := capsule.Open(path)
 	if err != nil {
 		return err
@@ -209,8 +260,9 @@ func DoBuild(path, output, baseURL string) error {
 	}
 
 	result, err := capsuleBuild.Run(context.Background(), loaded, capsuleBuild.Options{
-		Output:  output,
-		BaseURL: baseURL,
+		Output:    opts.Output,
+		BaseURL:   opts.BaseURL,
+		Templates: opts.Templates,
 	})
 	if err != nil {
 		return err
@@ -221,10 +273,48 @@ func DoBuild(path, output, baseURL string) error {
 		tuiUtil.CLIInfoItem{Title: "Echoes", Msg: strconv.Itoa(result.Echoes)},
 		tuiUtil.CLIInfoItem{Title: "Files", Msg: strconv.Itoa(result.Files)},
 		tuiUtil.CLIInfoItem{Title: "Comments", Msg: strconv.Itoa(result.Comments)},
+		tuiUtil.CLIInfoItem{Title: "Pages", Msg: strconv.Itoa(result.Pages)},
+	)
+
+	if opts.Publish == "" {
+		return nil
+	}
+	// S3 凭据走 SDK 默认凭据链（AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY 等），不设专门的 flag：
+	// 命令行参数会留在 shell 历史与进程列表里。
+	published, err := capsulePublish.Run(context.Background(), result.Path, target, capsulePublish.Options{})
+	if err != nil {
+		return err
+	}
+	tuiUtil.PrintCLIWithBox(
+		tuiUtil.CLIBoxHeader{Icon: "🚀", Title: "Published", Value: published.Target},
+		tuiUtil.CLIInfoItem{Title: "Uploaded", Msg: strconv.Itoa(published.Uploaded)},
+		tuiUtil.CLIInfoItem{Title: "Deleted", Msg: strconv.Itoa(published.Deleted)},
+		tuiUtil.CLIInfoItem{Title: "Unchanged", Msg: strconv.Itoa(published.Unchanged)},
 	)
 	return nil
 }
 
+// writeCheckReport 把机器可读报告写到 dest（留空即 stdout）。
+func writeCheckReport(path string, report *capsuleCheck.Report, format, dest string) (err error) {
+	w := os.Stdout
+	if dest != "" {
+		f, err := os.Create(dest)
+		if err != nil {
+			return fmt.Errorf("create check report: %w", err)
+		}
+		defer func() {
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+		}()
+		w = f
+	}
+	if format == capsuleCheck.FormatJUnit {
+		return report.WriteJUnit(w, path)
+	}
+	return report.WriteJSON(w, path)
+}
+
 // printCheckReport 把校验结果写到 stderr（stdout 留给产物摘要，便于管道消费）。
 func printCheckReport(path string, report *capsuleCheck.Report) {
 	for _, fixed := range report.Fixed {
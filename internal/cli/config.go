@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/lin-snow/ech0/internal/config"
+	tuiUtil "github.com/lin-snow/ech0/internal/util/tui"
+)
+
+// ConfigPrintOptions 对应 `ech0 config print` 的 flag 集合。
+type ConfigPrintOptions struct {
+	Effective bool
+	Format    string
+}
+
+// DoConfigValidate 按服务启动时的同一顺序（默认值 → 配置文件 → 环境变量）装配配置并校验，
+// 有任何问题都返回错误；环境变量解析失败在这里也算错误。
+func DoConfigValidate() error {
+	config.LoadDotEnv()
+	path := config.File()
+	cfg, err := config.Load(path)
+	var envErr *config.EnvError
+	if err != nil && !errors.As(err, &envErr) {
+		return err
+	}
+	if err := errors.Join(err, cfg.Validate()); err != nil {
+		return fmt.Errorf("invalid configuration:\n%w", err)
+	}
+
+	source := "defaults and environment"
+	if path != "" {
+		source = path
+	}
+	tuiUtil.PrintCLIInfo("✅ Configuration is valid", source)
+	return nil
+}
+
+// DoConfigPrint 输出配置文件格式的配置：默认输出内置默认值（可作模板），
+// --effective 时输出合并配置文件与环境变量后的生效值。敏感项一律打码。
+func DoConfigPrint(opts ConfigPrintOptions) error {
+	cfg := config.Default()
+	if opts.Effective {
+		config.LoadDotEnv()
+		loaded, err := config.Load(config.File())
+		var envErr *config.EnvError
+		if err != nil && !errors.As(err, &envErr) {
+			return err
+		}
+		if envErr != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "warning: %v\n", envErr)
+		}
+		cfg = loaded
+	}
+
+	data, err := config.Marshal(config.Redacted(cfg), opts.Format)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/lin-snow/ech0/internal/config"
+	"github.com/lin-snow/ech0/internal/di"
+	"github.com/lin-snow/ech0/internal/mcp"
+	authModel "github.com/lin-snow/ech0/internal/model/auth"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"github.com/lin-snow/ech0/pkg/viewer"
+)
+
+// mcpTokenEnv 是远端模式访问令牌的环境变量：MCP 宿主的配置文件常被同步或截图，
+// 令牌放进 env 比写进 args 更稳妥。
+const mcpTokenEnv = "ECH0_MCP_TOKEN"
+
+// MCPOptions 对应 `ech0 mcp` 的 flag 集合。
+type MCPOptions struct {
+	// URL 非空时把 stdio 代理到该实例的 /mcp，否则直连本地库。
+	URL string
+	// Token 是远端模式的访问令牌（受众需含 mcp-remote）；也可用 ECH0_MCP_TOKEN。
+	Token string
+	// User 是本地模式的会话身份（用户名），缺省为站长。
+	User string
+	// Scopes 是本地模式授予会话的 scope，缺省只读。
+	Scopes []string
+}
+
+// defaultLocalMCPScopes 是本地模式的缺省授权：与 Token 的最小权限原则一致，
+// 写操作须显式 --scopes 打开。
+var defaultLocalMCPScopes = []string{
+	authModel.ScopeEchoRead,
+	authModel.ScopeCommentRead,
+	authModel.ScopeFileRead,
+	authModel.ScopeConnectRead,
+	authModel.ScopeProfileRead,
+}
+
+// DoMCP 以 stdio 传输运行 MCP，直到宿主关闭 stdin 或进程收到中断信号。
+//
+// 两种模式共用同一个 mcp.StdioBridge：远端模式走真实 HTTP，本地模式经进程内
+// RoundTripper 直达本地装配的 MCP Server，因此 scope 校验、传输头校验与审计日志
+// 与 /mcp 端点完全一致。
+func DoMCP(opts MCPOptions) error {
+	// stdout 是协议通道，控制台日志混进去会破坏报文。
+	logUtil.RedirectConsole(os.Stderr)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	if strings.TrimSpace(opts.URL) != "" {
+		if opts.User != "" || len(opts.Scopes) > 0 {
+			return errors.New("--user and --scopes only apply to the local mode; remote scopes come from the token")
+		}
+		return serveRemoteMCP(ctx, opts)
+	}
+	if opts.Token != "" {
+		// 未配 JWT_SECRET 时密钥按进程随机生成，服务端签发的令牌在这里根本验不过，
+		// 与其给出难懂的签名错误，不如直接说明本地模式不认令牌。
+		return errors.New("--token requires --url; the local mode acts as --user with --scopes")
+	}
+	return serveLocalMCP(ctx, opts)
+}
+
+func serveRemoteMCP(ctx context.Context, opts MCPOptions) error {
+	token := opts.Token
+	if token == "" {
+		token = os.Getenv(mcpTokenEnv)
+	}
+	if token == "" {
+		return fmt.Errorf("an access token is required with --url (use --token or %s)", mcpTokenEnv)
+	}
+	endpoint, err := remoteMCPEndpoint(opts.URL)
+	if err != nil {
+		return err
+	}
+
+	// 不设 Client.Timeout：订阅流是长连接，单次调用的超时由服务端的 tool 超时兜底。
+	bridge := &mcp.StdioBridge{Endpoint: endpoint, Token: token, Client: &http.Client{}}
+	return bridge.Serve(ctx, os.Stdin, os.Stdout)
+}
+
+// remoteMCPEndpoint 接受实例根地址或完整的 /mcp 地址。
+func remoteMCPEndpoint(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid --url %q: expected http(s)://host[/path]", raw)
+	}
+	path := strings.TrimSuffix(u.Path, "/")
+	if !strings.HasSuffix(path, "/mcp") {
+		path += "/mcp"
+	}
+	u.Path = path
+	return u.String(), nil
+}
+
+func serveLocalMCP(ctx context.Context, opts MCPOptions) error {
+	rt, err := newMCPRuntime()
+	if err != nil {
+		return err
+	}
+	// 注册事件订阅，让经 MCP 的写操作照常触发 webhook、嵌入索引与订阅推送。
+	if err := rt.Registrar.Register(); err != nil {
+		return err
+	}
+	defer func() { _ = rt.Registrar.Stop() }()
+	defer rt.Notifier.Close()
+
+	v, err := localMCPViewer(ctx, rt, opts)
+	if err != nil {
+		return err
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rt.Handler.ServeHTTP(w, viewer.WithRequest(r, v))
+	})
+
+	bridge := &mcp.StdioBridge{
+		// 指向本机 Web 服务，使上传指南等资源里拼出的 REST 地址仍然可用。
+		Endpoint: "http://localhost:" + config.Config().Server.Port + "/mcp",
+		Client:   &http.Client{Transport: mcp.NewHandlerTransport(handler)},
+	}
+	return bridge.Serve(ctx, os.Stdin, os.Stdout)
+}
+
+// newMCPRuntime 装配本地模式运行时。InitDatabase 内部失败走 panic，这里同
+// newCapsuleRuntime 一样收口成 error。
+func newMCPRuntime() (rt *di.MCPRuntime, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			rt, err = nil, fmt.Errorf("initialise runtime: %v", r)
+		}
+	}()
+	return di.BuildMCPRuntime()
+}
+
+// localMCPViewer 把 --user / --scopes 折算成会话身份，等价于一枚受众为 mcp-remote 的访问令牌。
+func localMCPViewer(ctx context.Context, rt *di.MCPRuntime, opts MCPOptions) (viewer.Context, error) {
+	scopes := opts.Scopes
+	if len(scopes) == 0 {
+		scopes = defaultLocalMCPScopes
+	}
+	for _, scope := range scopes {
+		if !authModel.IsValidScope(scope) {
+			return nil, fmt.Errorf("unknown scope %q", scope)
+		}
+	}
+
+	var user userModel.User
+	var err error
+	if opts.User != "" {
+		if user, err = rt.Users.GetUserByUsername(ctx, opts.User); err != nil || user.ID == "" {
+			return nil, fmt.Errorf("user %q not found", opts.User)
+		}
+	} else if user, err = rt.Users.GetOwner(ctx); err != nil || user.ID == "" {
+		return nil, errors.New("this instance has no owner yet; finish the setup first")
+	}
+
+	return viewer.NewUserViewerWithToken(
+		user.ID,
+		authModel.TokenTypeAccess,
+		scopes,
+		[]string{authModel.AudienceMCPRemote},
+		"",
+	), nil
+}
@@ -14,8 +14,10 @@ import (
 	"strings"
 
 	"github.com/lin-snow/ech0/internal/migrator"
+	"github.com/lin-snow/ech0/internal/migrator/envelope"
 	"github.com/lin-snow/ech0/internal/migrator/snapshot"
 	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
 	tuiUtil "github.com/lin-snow/ech0/internal/util/tui"
 	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
 )
@@ -34,7 +36,7 @@ func DoExportSnapshot(output string) error {
 		return err
 	}
 
-	outcome, err := migrator.NewExportEngine(rt.storage).Export(
+	outcome, err := migrator.NewExportEngine(rt.storage, rt.kv).Export(
 		context.Background(),
 		func(phase string, _ any) {
 			if phase != "" {
@@ -60,12 +62,25 @@ func DoExportSnapshot(output string) error {
 	return nil
 }
 
+// archivePassphraseEnv 是加密快照口令的环境变量，供脚本化恢复时不把口令落进文件或命令历史。
+const archivePassphraseEnv = "ECH0_ARCHIVE_PASSPHRASE"
+
+// ImportSnapshotOptions 对应 `ech0 import snapshot` 的 flag 集合。
+type ImportSnapshotOptions struct {
+	Yes bool
+	// IdentityFile 是 age 身份文件（AGE-SECRET-KEY-1…），解 recipient 模式加密的快照。
+	IdentityFile string
+	// PassphraseFile 是只含口令的文件，解 passphrase 模式加密的快照；也可用 ECH0_ARCHIVE_PASSPHRASE。
+	PassphraseFile string
+}
+
 // DoImportSnapshot 用一份快照替换当前实例的内容。
 //
 // 破坏性操作，必须显式 --yes。落库语义与 Web 端「全局迁移」完全一致（同一个引擎），
-// 包括「已存在主键则跳过」的批量插入与迁移后配置应用。
-func DoImportSnapshot(path string, yes bool) error {
-	if !yes {
+// 包括「已存在主键则跳过」的批量插入与迁移后配置应用。加密快照在解包前透明解密：
+// 身份文件 / 口令文件 / 环境变量里给的凭据优先，本实例已存的口令兜底。
+func DoImportSnapshot(path string, opts ImportSnapshotOptions) error {
+	if !opts.Yes {
 		return errors.New("importing a snapshot rewrites instance data; pass --yes to confirm")
 	}
 	if !strings.HasSuffix(strings.ToLower(path), ".zip") {
@@ -80,6 +95,12 @@ func DoImportSnapshot(path string, yes bool) error {
 		return err
 	}
 
+	plainPath, cleanup, err := unsealSnapshot(context.Background(), path, opts, rt)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
 	// 引擎读的是已解包目录，且 resolveTmpDir 只接受 data/files/tmp 之下的相对路径，
 	// 故这里复刻 Web 上传通道的落点约定，而不是随便找个临时目录。
 	folder := "ech0_" + uuidUtil.MustNewV7()
@@ -88,7 +109,7 @@ func DoImportSnapshot(path string, yes bool) error {
 	if err := os.MkdirAll(extractDir, 0o755); err != nil {
 		return fmt.Errorf("create extract dir: %w", err)
 	}
-	if err := snapshot.Unpack(path, extractDir); err != nil {
+	if err := snapshot.Unpack(plainPath, extractDir); err != nil {
 		_ = os.RemoveAll(extractDir)
 		return fmt.Errorf("unpack snapshot: %w", err)
 	}
@@ -114,6 +135,58 @@ func DoImportSnapshot(path string, yes bool) error {
 	return nil
 }
 
+// unsealSnapshot 在 path 是加密信封时把它解密到迁移暂存目录，否则原样返回。凭据只在遇到信封时
+// 才去读：明文快照的恢复不该因为一个读不了的口令文件而失败。
+func unsealSnapshot(
+	ctx context.Context,
+	path string,
+	opts ImportSnapshotOptions,
+	rt *capsuleRuntime,
+) (string, func(), error) {
+	noop := func() {}
+	manifest, sealed, err := envelope.Inspect(path)
+	if err != nil {
+		return "", noop, err
+	}
+	if !sealed {
+		return path, noop, nil
+	}
+
+	var given migratorModel.ArchiveKeys
+	if opts.IdentityFile != "" {
+		data, err := os.ReadFile(opts.IdentityFile)
+		if err != nil {
+			return "", noop, fmt.Errorf("read identity file: %w", err)
+		}
+		given.Identity = string(data)
+	}
+	if opts.PassphraseFile != "" {
+		data, err := os.ReadFile(opts.PassphraseFile)
+		if err != nil {
+			return "", noop, fmt.Errorf("read passphrase file: %w", err)
+		}
+		given.Passphrase = strings.TrimRight(string(data), "\r\n")
+	} else {
+		given.Passphrase = os.Getenv(archivePassphraseEnv)
+	}
+
+	// 已存口令只是兜底，读不到（比如在一个全新的空实例上恢复）不算错。
+	stored, _ := coreSetting.Get(ctx, rt.kv, coreSetting.Encryption)
+	fmt.Fprintf(os.Stderr, "… decrypting %s (%s)\n", manifest.Kind, manifest.Recipient)
+	plainPath, cleanup, err := envelope.Unseal(
+		path,
+		filepath.Join("data", migrator.TmpRelativeDir),
+		envelope.KeysFor(given, stored),
+	)
+	if errors.Is(err, envelope.ErrKeyRequired) {
+		return "", noop, fmt.Errorf(
+			"%s is encrypted; pass --identity or --passphrase-file, or set %s",
+			path, archivePassphraseEnv,
+		)
+	}
+	return plainPath, cleanup, err
+}
+
 // copyArtifact 把引擎产物复制到用户指定位置（引擎只认自己的 snapshots 目录）。
 func copyArtifact(src, dst string) (string, error) {
 	if !strings.HasSuffix(strings.ToLower(dst), ".zip") {
@@ -9,200 +9,225 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"sync/atomic"
 
-	"github.com/caarlos0/env/v11"
 	"github.com/joho/godotenv"
 )
 
 var (
-	cfg  *AppConfig
-	once sync.Once
+	current atomic.Pointer[AppConfig]
+	once    sync.Once
 )
 
 type AppConfig struct {
-	Server    ServerConfig
-	OpenAPI   OpenAPIConfig
-	Database  DatabaseConfig
-	Log       LogConfig
-	Auth      AuthConfig
-	Upload    UploadConfig
-	Storage   StorageConfig
-	Event     EventConfig
-	Migration MigrationConfig
-	Setting   SettingConfig
-	Comment   CommentConfig
-	Security  SecurityConfig
-	Web       WebConfig
-	Agent     AgentConfig
+	Server    ServerConfig    `yaml:"server"`
+	OpenAPI   OpenAPIConfig   `yaml:"openapi"`
+	Database  DatabaseConfig  `yaml:"database"`
+	Log       LogConfig       `yaml:"log"`
+	Auth      AuthConfig      `yaml:"auth"`
+	Upload    UploadConfig    `yaml:"upload"`
+	Storage   StorageConfig   `yaml:"storage"`
+	Event     EventConfig     `yaml:"event"`
+	Migration MigrationConfig `yaml:"migration"`
+	Setting   SettingConfig   `yaml:"setting"`
+	Comment   CommentConfig   `yaml:"comment"`
+	Security  SecurityConfig  `yaml:"-"`
+	Web       WebConfig       `yaml:"web"`
+	Agent     AgentConfig     `yaml:"agent"`
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
 }
 
 type StorageConfig struct {
-	ObjectEnabled bool   `env:"ECH0_OBJECT_ENABLED"`    // enable object storage alongside local
-	DataRoot      string `env:"ECH0_STORAGE_DATA_ROOT"` // local root directory, default "data/files"
-	Endpoint      string `env:"ECH0_S3_ENDPOINT"`       // S3-compatible endpoint
-	AccessKey     string `env:"ECH0_S3_ACCESS_KEY"`
-	SecretKey     string `env:"ECH0_S3_SECRET_KEY"`
-	BucketName    string `env:"ECH0_S3_BUCKET"`
-	Region        string `env:"ECH0_S3_REGION"`
-	Provider      string `env:"ECH0_S3_PROVIDER"` // "aws", "r2", "minio", "other"
-	UseSSL        bool   `env:"ECH0_S3_USE_SSL"`
-	UsePathStyle  bool   `env:"ECH0_S3_USE_PATH_STYLE"` // force path-style addressing (endpoint/bucket/key)
-	CDNURL        string `env:"ECH0_S3_CDN_URL"`
-	PathPrefix    string `env:"ECH0_S3_PATH_PREFIX"`
+	ObjectEnabled bool   `env:"ECH0_OBJECT_ENABLED" yaml:"object_enabled"` // enable object storage alongside local
+	DataRoot      string `env:"ECH0_STORAGE_DATA_ROOT" yaml:"data_root"`   // local root directory, default "data/files"
+	Endpoint      string `env:"ECH0_S3_ENDPOINT" yaml:"endpoint"`          // S3-compatible endpoint
+	AccessKey     string `env:"ECH0_S3_ACCESS_KEY" yaml:"access_key" redact:"true"`
+	SecretKey     string `env:"ECH0_S3_SECRET_KEY" yaml:"secret_key" redact:"true"`
+	BucketName    string `env:"ECH0_S3_BUCKET" yaml:"bucket_name"`
+	Region        string `env:"ECH0_S3_REGION" yaml:"region"`
+	Provider      string `env:"ECH0_S3_PROVIDER" yaml:"provider"` // "aws", "r2", "minio", "other"
+	UseSSL        bool   `env:"ECH0_S3_USE_SSL" yaml:"use_ssl"`
+	UsePathStyle  bool   `env:"ECH0_S3_USE_PATH_STYLE" yaml:"use_path_style"` // force path-style addressing (endpoint/bucket/key)
+	CDNURL        string `env:"ECH0_S3_CDN_URL" yaml:"cdn_url"`
+	PathPrefix    string `env:"ECH0_S3_PATH_PREFIX" yaml:"path_prefix"`
 }
 
 type ServerConfig struct {
-	Port string `env:"ECH0_SERVER_PORT"` // 服务器端口
-	Host string `env:"ECH0_SERVER_HOST"` // 服务器主机地址
-	Mode string `env:"ECH0_SERVER_MODE"` // 运行模式，可能的值为 "debug" 或 "release"
+	Port string `env:"ECH0_SERVER_PORT" yaml:"port"` // 服务器端口
+	Host string `env:"ECH0_SERVER_HOST" yaml:"host"` // 服务器主机地址
+	Mode string `env:"ECH0_SERVER_MODE" yaml:"mode"` // 运行模式，可能的值为 "debug" 或 "release"
 }
 
 // OpenAPIConfig 是 OpenAPI 文档（/api/docs、/api/openapi.*）相关配置。
 type OpenAPIConfig struct {
 	// DocsRenderer 选择 /api/docs 的文档面板：
 	// "stoplight"（默认，Huma 内置 Stoplight Elements）或 "scalar"（离线自托管 Scalar）。
-	DocsRenderer string `env:"ECH0_OPENAPI_DOCS_RENDERER"`
+	DocsRenderer string `env:"ECH0_OPENAPI_DOCS_RENDERER" yaml:"docs_renderer"`
 }
 
 type DatabaseConfig struct {
-	Type    string `env:"ECH0_DB_TYPE"`    // 数据库类型
-	Path    string `env:"ECH0_DB_PATH"`    // 数据库文件路径
-	LogMode string `env:"ECH0_DB_LOGMODE"` // 数据库日志模式
+	Type    string `env:"ECH0_DB_TYPE" yaml:"type"`        // 数据库类型
+	Path    string `env:"ECH0_DB_PATH" yaml:"path"`        // 数据库文件路径
+	LogMode string `env:"ECH0_DB_LOGMODE" yaml:"log_mode"` // 数据库日志模式
 }
 
 type LogConfig struct {
-	Level           string `env:"ECH0_LOG_LEVEL"`
-	Format          string `env:"ECH0_LOG_FORMAT"`
-	Console         bool   `env:"ECH0_LOG_CONSOLE"`
-	FileEnable      bool   `env:"ECH0_LOG_FILE_ENABLE"`
-	FilePath        string `env:"ECH0_LOG_FILE_PATH"`
-	FileMaxSize     int    `env:"ECH0_LOG_FILE_MAX_SIZE"`
-	FileMaxBackups  int    `env:"ECH0_LOG_FILE_MAX_BACKUPS"`
-	FileMaxAge      int    `env:"ECH0_LOG_FILE_MAX_AGE"`
-	FileCompress    bool   `env:"ECH0_LOG_FILE_COMPRESS"`
-	BufferSize      int    `env:"ECH0_LOG_BUFFER_SIZE"`
-	RecentSize      int    `env:"ECH0_LOG_RECENT_SIZE"`
-	DropPolicy      string `env:"ECH0_LOG_DROP_POLICY"`
-	FlushBatch      int    `env:"ECH0_LOG_FLUSH_BATCH"`
-	FlushIntervalMs int    `env:"ECH0_LOG_FLUSH_INTERVAL_MS"`
+	Level           string `env:"ECH0_LOG_LEVEL" yaml:"level"`
+	Format          string `env:"ECH0_LOG_FORMAT" yaml:"format"`
+	Console         bool   `env:"ECH0_LOG_CONSOLE" yaml:"console"`
+	FileEnable      bool   `env:"ECH0_LOG_FILE_ENABLE" yaml:"file_enable"`
+	FilePath        string `env:"ECH0_LOG_FILE_PATH" yaml:"file_path"`
+	FileMaxSize     int    `env:"ECH0_LOG_FILE_MAX_SIZE" yaml:"file_max_size"`
+	FileMaxBackups  int    `env:"ECH0_LOG_FILE_MAX_BACKUPS" yaml:"file_max_backups"`
+	FileMaxAge      int    `env:"ECH0_LOG_FILE_MAX_AGE" yaml:"file_max_age"`
+	FileCompress    bool   `env:"ECH0_LOG_FILE_COMPRESS" yaml:"file_compress"`
+	BufferSize      int    `env:"ECH0_LOG_BUFFER_SIZE" yaml:"buffer_size"`
+	RecentSize      int    `env:"ECH0_LOG_RECENT_SIZE" yaml:"recent_size"`
+	DropPolicy      string `env:"ECH0_LOG_DROP_POLICY" yaml:"drop_policy"`
+	FlushBatch      int    `env:"ECH0_LOG_FLUSH_BATCH" yaml:"flush_batch"`
+	FlushIntervalMs int    `env:"ECH0_LOG_FLUSH_INTERVAL_MS" yaml:"flush_interval_ms"`
 }
 
 type AuthConfig struct {
-	Jwt      JWTConfig
-	Redirect RedirectConfig
-	WebAuthn WebAuthnConfig
+	Jwt      JWTConfig      `yaml:"jwt"`
+	Redirect RedirectConfig `yaml:"redirect"`
+	WebAuthn WebAuthnConfig `yaml:"webauthn"`
 }
 
 type JWTConfig struct {
-	Expires        int    `env:"ECH0_JWT_EXPIRES"`         // Access Token 过期时间，单位为秒
-	RefreshExpires int    `env:"ECH0_JWT_REFRESH_EXPIRES"` // Refresh Token 过期时间，单位为秒
-	Issuer         string `env:"ECH0_JWT_ISSUER"`          // JWT的发行者
-	Audience       string `env:"ECH0_JWT_AUDIENCE"`        // JWT的受众
+	Expires        int    `env:"ECH0_JWT_EXPIRES" yaml:"expires"`                 // Access Token 过期时间，单位为秒
+	RefreshExpires int    `env:"ECH0_JWT_REFRESH_EXPIRES" yaml:"refresh_expires"` // Refresh Token 过期时间，单位为秒
+	Issuer         string `env:"ECH0_JWT_ISSUER" yaml:"issuer"`                   // JWT的发行者
+	Audience       string `env:"ECH0_JWT_AUDIENCE" yaml:"audience"`               // JWT的受众
 }
 
 type RedirectConfig struct {
-	AllowedReturnURLs []string `env:"ECH0_AUTH_REDIRECT_ALLOWED_RETURN_URLS" envSeparator:","`
+	AllowedReturnURLs []string `env:"ECH0_AUTH_REDIRECT_ALLOWED_RETURN_URLS" envSeparator:"," yaml:"allowed_return_urls"`
 }
 
 type WebAuthnConfig struct {
-	RPID    string   `env:"ECH0_AUTH_WEBAUTHN_RP_ID"`
-	Origins []string `env:"ECH0_AUTH_WEBAUTHN_ORIGINS" envSeparator:","`
+	RPID    string   `env:"ECH0_AUTH_WEBAUTHN_RP_ID" yaml:"rp_id"`
+	Origins []string `env:"ECH0_AUTH_WEBAUTHN_ORIGINS" envSeparator:"," yaml:"origins"`
 }
 
 type UploadConfig struct {
-	AllowedTypes []string // 允许上传的文件类型
-	ImageMaxSize int      `env:"ECH0_UPLOAD_IMAGE_MAX_SIZE"` // 图片文件的最大上传大小，单位为字节
-	AudioMaxSize int      `env:"ECH0_UPLOAD_AUDIO_MAX_SIZE"` // 音频文件的最大上传大小，单位为字节
-	VideoMaxSize int      `env:"ECH0_UPLOAD_VIDEO_MAX_SIZE"` // 视频文件的最大上传大小，单位为字节
-	ImagePath    string   `env:"ECH0_UPLOAD_IMAGE_PATH"`     // 图片文件存储路径
-	AudioPath    string   `env:"ECH0_UPLOAD_AUDIO_PATH"`     // 音频文件存储路径
-	VideoPath    string   `env:"ECH0_UPLOAD_VIDEO_PATH"`     // 视频文件存储路径
+	AllowedTypes []string `yaml:"allowed_types"`                                   // 允许上传的文件类型
+	ImageMaxSize int      `env:"ECH0_UPLOAD_IMAGE_MAX_SIZE" yaml:"image_max_size"` // 图片文件的最大上传大小，单位为字节
+	AudioMaxSize int      `env:"ECH0_UPLOAD_AUDIO_MAX_SIZE" yaml:"audio_max_size"` // 音频文件的最大上传大小，单位为字节
+	VideoMaxSize int      `env:"ECH0_UPLOAD_VIDEO_MAX_SIZE" yaml:"video_max_size"` // 视频文件的最大上传大小，单位为字节
+	ImagePath    string   `env:"ECH0_UPLOAD_IMAGE_PATH" yaml:"image_path"`         // 图片文件存储路径
+	AudioPath    string   `env:"ECH0_UPLOAD_AUDIO_PATH" yaml:"audio_path"`         // 音频文件存储路径
+	VideoPath    string   `env:"ECH0_UPLOAD_VIDEO_PATH" yaml:"video_path"`         // 视频文件存储路径
+	TmpPath      string   `env:"ECH0_UPLOAD_TMP_PATH" yaml:"tmp_path"`             // 断点续传分片的暂存目录
 }
 
 type SettingConfig struct {
-	SiteTitle     string `env:"ECH0_SETTING_SITE_TITLE"`     // 网站标题
-	ServerLogo    string `env:"ECH0_SETTING_SERVER_LOGO"`    // 服务器Logo
-	Servername    string `env:"ECH0_SETTING_SERVER_NAME"`    // 服务器名称
-	Serverurl     string `env:"ECH0_SETTING_SERVER_URL"`     // 服务器 URL
-	AllowRegister bool   `env:"ECH0_SETTING_ALLOW_REGISTER"` // 是否允许注册
-	Icpnumber     string `env:"ECH0_SETTING_ICP_NUMBER"`     // ICP 备案号
-	FooterContent string `env:"ECH0_SETTING_FOOTER_CONTENT"` // 自定义页脚内容
-	FooterLink    string `env:"ECH0_SETTING_FOOTER_LINK"`    // 自定义页脚链接
-	MetingAPI     string `env:"ECH0_SETTING_METING_API"`     // Meting API 地址
-	CustomCSS     string `env:"ECH0_SETTING_CUSTOM_CSS"`     // 自定义 CSS 样式
-	CustomJS      string `env:"ECH0_SETTING_CUSTOM_JS"`      // 自定义 JS 脚本
+	SiteTitle     string `env:"ECH0_SETTING_SITE_TITLE" yaml:"site_title"`         // 网站标题
+	ServerLogo    string `env:"ECH0_SETTING_SERVER_LOGO" yaml:"server_logo"`       // 服务器Logo
+	Servername    string `env:"ECH0_SETTING_SERVER_NAME" yaml:"server_name"`       // 服务器名称
+	Serverurl     string `env:"ECH0_SETTING_SERVER_URL" yaml:"server_url"`         // 服务器 URL
+	AllowRegister bool   `env:"ECH0_SETTING_ALLOW_REGISTER" yaml:"allow_register"` // 是否允许注册
+	Icpnumber     string `env:"ECH0_SETTING_ICP_NUMBER" yaml:"icp_number"`         // ICP 备案号
+	FooterContent string `env:"ECH0_SETTING_FOOTER_CONTENT" yaml:"footer_content"` // 自定义页脚内容
+	FooterLink    string `env:"ECH0_SETTING_FOOTER_LINK" yaml:"footer_link"`       // 自定义页脚链接
+	MetingAPI     string `env:"ECH0_SETTING_METING_API" yaml:"meting_api"`         // Meting API 地址
+	CustomCSS     string `env:"ECH0_SETTING_CUSTOM_CSS" yaml:"custom_css"`         // 自定义 CSS 样式
+	CustomJS      string `env:"ECH0_SETTING_CUSTOM_JS" yaml:"custom_js"`           // 自定义 JS 脚本
 }
 
 type CommentConfig struct {
-	EnableComment         bool   `env:"ECH0_COMMENT_ENABLE"` // 是否启用评论
-	CaptchaSiteKey        string `env:"ECH0_COMMENT_CAPTCHA_SITE_KEY"`
-	CaptchaSecret         string `env:"ECH0_COMMENT_CAPTCHA_SECRET"`
-	CaptchaDifficulty     int    `env:"ECH0_COMMENT_CAPTCHA_DIFFICULTY"`
-	CaptchaChallengeCount int    `env:"ECH0_COMMENT_CAPTCHA_CHALLENGE_COUNT"`
-	CaptchaSaltSize       int    `env:"ECH0_COMMENT_CAPTCHA_SALT_SIZE"`
-	CaptchaChallengeTTL   int    `env:"ECH0_COMMENT_CAPTCHA_CHALLENGE_TTL"`
-	CaptchaRedeemTTL      int    `env:"ECH0_COMMENT_CAPTCHA_REDEEM_TTL"`
-	CaptchaGCInterval     int    `env:"ECH0_COMMENT_CAPTCHA_GC_INTERVAL"`
-	CaptchaEnableCORS     bool   `env:"ECH0_COMMENT_CAPTCHA_ENABLE_CORS"`
-	CaptchaIPHeader       string `env:"ECH0_COMMENT_CAPTCHA_IP_HEADER"`
-	CaptchaMaxBodyBytes   int    `env:"ECH0_COMMENT_CAPTCHA_MAX_BODY_BYTES"`
-	CaptchaRateLimitMax   int    `env:"ECH0_COMMENT_CAPTCHA_RATE_LIMIT_MAX"`
-	CaptchaRateLimitWin   int    `env:"ECH0_COMMENT_CAPTCHA_RATE_LIMIT_WINDOW"`
-	CaptchaRateLimitScope string `env:"ECH0_COMMENT_CAPTCHA_RATE_LIMIT_SCOPE"`
-	CaptchaLimitOnRedeem  bool   `env:"ECH0_COMMENT_CAPTCHA_RATE_LIMIT_ON_REDEEM"`
-	CaptchaLimitOnVerify  bool   `env:"ECH0_COMMENT_CAPTCHA_RATE_LIMIT_ON_SITEVERIFY"`
+	EnableComment         bool   `env:"ECH0_COMMENT_ENABLE" yaml:"enable"` // 是否启用评论
+	CaptchaSiteKey        string `env:"ECH0_COMMENT_CAPTCHA_SITE_KEY" yaml:"captcha_site_key"`
+	CaptchaSecret         string `env:"ECH0_COMMENT_CAPTCHA_SECRET" yaml:"captcha_secret" redact:"true"`
+	CaptchaDifficulty     int    `env:"ECH0_COMMENT_CAPTCHA_DIFFICULTY" yaml:"captcha_difficulty"`
+	CaptchaChallengeCount int    `env:"ECH0_COMMENT_CAPTCHA_CHALLENGE_COUNT" yaml:"captcha_challenge_count"`
+	CaptchaSaltSize       int    `env:"ECH0_COMMENT_CAPTCHA_SALT_SIZE" yaml:"captcha_salt_size"`
+	CaptchaChallengeTTL   int    `env:"ECH0_COMMENT_CAPTCHA_CHALLENGE_TTL" yaml:"captcha_challenge_ttl"`
+	CaptchaRedeemTTL      int    `env:"ECH0_COMMENT_CAPTCHA_REDEEM_TTL" yaml:"captcha_redeem_ttl"`
+	CaptchaGCInterval     int    `env:"ECH0_COMMENT_CAPTCHA_GC_INTERVAL" yaml:"captcha_gc_interval"`
+	CaptchaEnableCORS     bool   `env:"ECH0_COMMENT_CAPTCHA_ENABLE_CORS" yaml:"captcha_enable_cors"`
+	CaptchaIPHeader       string `env:"ECH0_COMMENT_CAPTCHA_IP_HEADER" yaml:"captcha_ip_header"`
+	CaptchaMaxBodyBytes   int    `env:"ECH0_COMMENT_CAPTCHA_MAX_BODY_BYTES" yaml:"captcha_max_body_bytes"`
+	CaptchaRateLimitMax   int    `env:"ECH0_COMMENT_CAPTCHA_RATE_LIMIT_MAX" yaml:"captcha_rate_limit_max"`
+	CaptchaRateLimitWin   int    `env:"ECH0_COMMENT_CAPTCHA_RATE_LIMIT_WINDOW" yaml:"captcha_rate_limit_window"`
+	CaptchaRateLimitScope string `env:"ECH0_COMMENT_CAPTCHA_RATE_LIMIT_SCOPE" yaml:"captcha_rate_limit_scope"`
+	CaptchaLimitOnRedeem  bool   `env:"ECH0_COMMENT_CAPTCHA_RATE_LIMIT_ON_REDEEM" yaml:"captcha_rate_limit_on_redeem"`
+	CaptchaLimitOnVerify  bool   `env:"ECH0_COMMENT_CAPTCHA_RATE_LIMIT_ON_SITEVERIFY" yaml:"captcha_rate_limit_on_siteverify"`
 }
 
 type SecurityConfig struct {
-	JWTSecret []byte
+	JWTSecret []byte `yaml:"-"`
 }
 
 type WebConfig struct {
-	CORS CORSConfig
+	CORS CORSConfig `yaml:"cors"`
 }
 
 type CORSConfig struct {
-	AllowedOrigins []string `env:"ECH0_WEB_CORS_ALLOWED_ORIGINS" envSeparator:","`
+	AllowedOrigins []string `env:"ECH0_WEB_CORS_ALLOWED_ORIGINS" envSeparator:"," yaml:"allowed_origins"`
 }
 
 type EventConfig struct {
-	DefaultBuffer      int    `env:"ECH0_EVENT_DEFAULT_BUFFER"`
-	DefaultOverflow    string `env:"ECH0_EVENT_DEFAULT_OVERFLOW"`
-	SystemBuffer       int    `env:"ECH0_EVENT_SYSTEM_BUFFER"`
-	AgentBuffer        int    `env:"ECH0_EVENT_AGENT_BUFFER"`
-	AgentParallelism   int    `env:"ECH0_EVENT_AGENT_PARALLELISM"`
-	WebhookPoolWorkers int    `env:"ECH0_EVENT_WEBHOOK_POOL_WORKERS"`
-	WebhookPoolQueue   int    `env:"ECH0_EVENT_WEBHOOK_POOL_QUEUE"`
+	DefaultBuffer      int    `env:"ECH0_EVENT_DEFAULT_BUFFER" yaml:"default_buffer"`
+	DefaultOverflow    string `env:"ECH0_EVENT_DEFAULT_OVERFLOW" yaml:"default_overflow"`
+	SystemBuffer       int    `env:"ECH0_EVENT_SYSTEM_BUFFER" yaml:"system_buffer"`
+	AgentBuffer        int    `env:"ECH0_EVENT_AGENT_BUFFER" yaml:"agent_buffer"`
+	AgentParallelism   int    `env:"ECH0_EVENT_AGENT_PARALLELISM" yaml:"agent_parallelism"`
+	WebhookPoolWorkers int    `env:"ECH0_EVENT_WEBHOOK_POOL_WORKERS" yaml:"webhook_pool_workers"`
+	WebhookPoolQueue   int    `env:"ECH0_EVENT_WEBHOOK_POOL_QUEUE" yaml:"webhook_pool_queue"`
 }
 
 type MigrationConfig struct {
-	WorkerEnabled   bool `env:"ECH0_MIGRATION_WORKER_ENABLED"`
-	MaxConcurrency  int  `env:"ECH0_MIGRATION_MAX_CONCURRENCY"`
-	BatchSize       int  `env:"ECH0_MIGRATION_BATCH_SIZE"`
-	RateLimitPerSec int  `env:"ECH0_MIGRATION_RATE_LIMIT_PER_SEC"`
+	WorkerEnabled   bool `env:"ECH0_MIGRATION_WORKER_ENABLED" yaml:"worker_enabled"`
+	MaxConcurrency  int  `env:"ECH0_MIGRATION_MAX_CONCURRENCY" yaml:"max_concurrency"`
+	BatchSize       int  `env:"ECH0_MIGRATION_BATCH_SIZE" yaml:"batch_size"`
+	RateLimitPerSec int  `env:"ECH0_MIGRATION_RATE_LIMIT_PER_SEC" yaml:"rate_limit_per_sec"`
+	SyncMaxBytes    int  `env:"ECH0_MIGRATION_SYNC_MAX_BYTES" yaml:"sync_max_bytes"` // 同步时单份增量胶囊（推送请求体或拉取响应）的大小上限，单位为字节
 }
 
 type AgentConfig struct {
 	// TimeoutSeconds 是单轮 Agent 运行（含整个工具循环）的整体超时，单位秒；<=0 表示不额外设超时。
-	TimeoutSeconds int `env:"ECH0_AGENT_TIMEOUT_SECONDS"`
+	TimeoutSeconds int `env:"ECH0_AGENT_TIMEOUT_SECONDS" yaml:"timeout_seconds"`
 	// MaxRounds 是 Chat 单轮问答内的工具调用轮数上限（ReAct 护栏），防模型反复调工具烧 token；
 	// <=0 时 agent 包回退内置默认。
-	MaxRounds int `env:"ECH0_AGENT_MAX_ROUNDS"`
+	MaxRounds int `env:"ECH0_AGENT_MAX_ROUNDS" yaml:"max_rounds"`
 }
 
-// Config 返回全局配置中心
+// RateLimitConfig 是按客户端 IP 的接口令牌桶限流参数（每秒速率与突发容量）。
+type RateLimitConfig struct {
+	LikeRPS         int `env:"ECH0_RATE_LIMIT_LIKE_RPS" yaml:"like_rps"` // 匿名点赞
+	LikeBurst       int `env:"ECH0_RATE_LIMIT_LIKE_BURST" yaml:"like_burst"`
+	MCPRPS          int `env:"ECH0_RATE_LIMIT_MCP_RPS" yaml:"mcp_rps"` // 远程 MCP 端点
+	MCPBurst        int `env:"ECH0_RATE_LIMIT_MCP_BURST" yaml:"mcp_burst"`
+	WebmentionRPS   int `env:"ECH0_RATE_LIMIT_WEBMENTION_RPS" yaml:"webmention_rps"` // Webmention 接收端点（每次受理都会抓取来源页）
+	WebmentionBurst int `env:"ECH0_RATE_LIMIT_WEBMENTION_BURST" yaml:"webmention_burst"`
+	SearchRPS       int `env:"ECH0_RATE_LIMIT_SEARCH_RPS" yaml:"search_rps"` // 公开的混合检索接口
+	SearchBurst     int `env:"ECH0_RATE_LIMIT_SEARCH_BURST" yaml:"search_burst"`
+}
+
+// Config 返回全局配置中心。
+// 首次调用时按「默认值 → 配置文件（见 File）→ 环境变量」的顺序装配；配置文件读不了或有未知项时
+// 直接退出，免得带着一份被悄悄忽略的配置跑起来。SIGHUP 热加载（见 Reload）会整体替换返回的指针，
+// 所以调用方应每次现取，不要长期持有。
 func Config() *AppConfig {
 	once.Do(func() {
-		if err := godotenv.Load(); err != nil {
-			_, _ = fmt.Fprintln(os.Stderr, "No .env file found, using system environment variables")
-		}
-		cfg = defaultConfig()
-		if err := env.Parse(cfg); err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Failed to parse env overrides: %v\n", err)
+		LoadDotEnv()
+		cfg, err := load(File())
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+			os.Exit(1)
 		}
 		cfg.Security.JWTSecret = getJWTSecret()
+		current.Store(cfg)
 	})
-	return cfg
+	return current.Load()
+}
+
+// LoadDotEnv 把工作目录下的 .env 载入进程环境（不覆盖已设置的变量）。
+func LoadDotEnv() {
+	if err := godotenv.Load(); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "No .env file found, using system environment variables")
+	}
 }
 
 func defaultConfig() *AppConfig {
@@ -262,6 +287,7 @@ func defaultConfig() *AppConfig {
 			ImagePath:    "data/files/images/",
 			AudioPath:    "data/files/audios/",
 			VideoPath:    "data/files/videos/",
+			TmpPath:      "data/tmp/uploads/",
 			AllowedTypes: []string{
 				"image/jpeg",
 				"image/png",
@@ -289,6 +315,7 @@ func defaultConfig() *AppConfig {
 			MaxConcurrency:  1,
 			BatchSize:       100,
 			RateLimitPerSec: 20,
+			SyncMaxBytes:    1073741824,
 		},
 		Setting: SettingConfig{
 			SiteTitle:     "Ech0",
@@ -331,6 +358,16 @@ func defaultConfig() *AppConfig {
 			TimeoutSeconds: 120,
 			MaxRounds:      4,
 		},
+		RateLimit: RateLimitConfig{
+			LikeRPS:         2,
+			LikeBurst:       5,
+			MCPRPS:          20,
+			MCPBurst:        40,
+			WebmentionRPS:   1,
+			WebmentionBurst: 5,
+			SearchRPS:       2,
+			SearchBurst:     10,
+		},
 	}
 }
 
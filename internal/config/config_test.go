@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// useConfig 让全局单例直接使用 cfg 与 path，绕开首次加载（.env、JWT 密钥等副作用）。
+func useConfig(t *testing.T, cfg *AppConfig, path string) {
+	t.Helper()
+	once.Do(func() {})
+	prev := current.Load()
+	current.Store(cfg)
+	SetFile(path)
+	t.Cleanup(func() {
+		current.Store(prev)
+		SetFile("")
+	})
+}
+
+func TestLoad_FileThenEnv(t *testing.T) {
+	path := writeConfigFile(t, "ech0.yaml", `
+server:
+  port: "8080"
+log:
+  level: debug
+upload:
+  allowed_types: [image/png]
+storage:
+  secret_key: s3cr3t
+`)
+	t.Setenv("ECH0_SERVER_PORT", "9090")
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "9090", cfg.Server.Port, "env overrides file")
+	assert.Equal(t, "debug", cfg.Log.Level)
+	assert.Equal(t, []string{"image/png"}, cfg.Upload.AllowedTypes)
+	assert.Equal(t, "s3cr3t", cfg.Storage.SecretKey)
+	assert.Equal(t, "0.0.0.0", cfg.Server.Host, "keys absent from the file keep their defaults")
+}
+
+func TestLoad_TOML(t *testing.T) {
+	path := writeConfigFile(t, "ech0.toml", `
+[rate_limit]
+like_rps = 7
+
+[auth.jwt]
+issuer = "me"
+`)
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, 7, cfg.RateLimit.LikeRPS)
+	assert.Equal(t, 5, cfg.RateLimit.LikeBurst)
+	assert.Equal(t, "me", cfg.Auth.Jwt.Issuer)
+}
+
+func TestLoad_RejectsUnknownKeysAndTypes(t *testing.T) {
+	_, err := Load(writeConfigFile(t, "ech0.yml", "log:\n  levle: debug\nsevrer: {}\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "log.levle")
+	assert.Contains(t, err.Error(), "sevrer")
+
+	_, err = Load(writeConfigFile(t, "ech0.json", "{}"))
+	require.ErrorContains(t, err, "unsupported config file type")
+
+	_, err = Load(writeConfigFile(t, "ech0.yaml", "security:\n  jwt_secret: x\n"))
+	require.ErrorContains(t, err, "security", "the JWT secret stays env-only")
+}
+
+func TestLoad_EnvErrorStillReturnsConfig(t *testing.T) {
+	t.Setenv("ECH0_UPLOAD_IMAGE_MAX_SIZE", "20MB")
+	cfg, err := Load("")
+	var envErr *EnvError
+	require.ErrorAs(t, err, &envErr)
+	require.NotNil(t, cfg)
+	assert.Equal(t, defaultConfig().Upload.ImageMaxSize, cfg.Upload.ImageMaxSize)
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, defaultConfig().Validate())
+
+	cfg := defaultConfig()
+	cfg.Server.Port = "99999"
+	cfg.Log.Level = "loud"
+	cfg.RateLimit.MCPBurst = 0
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "server.port")
+	assert.Contains(t, err.Error(), "log.level")
+	assert.Contains(t, err.Error(), "rate_limit.mcp_burst")
+}
+
+func TestMarshal_RedactedRoundTrip(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Storage.SecretKey = "s3cr3t"
+	cfg.Storage.AccessKey = ""
+
+	for _, format := range []string{"yaml", "toml"} {
+		data, err := Marshal(Redacted(cfg), format)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "s3cr3t")
+
+		// 打印出来的就是合法的配置文件。
+		loaded, err := Load(writeConfigFile(t, "ech0."+format, string(data)))
+		require.NoError(t, err, format)
+		assert.Equal(t, redactedValue, loaded.Storage.SecretKey)
+		assert.Empty(t, loaded.Storage.AccessKey, "empty secrets are not masked")
+		assert.Equal(t, cfg.Upload.AllowedTypes, loaded.Upload.AllowedTypes)
+	}
+	assert.Equal(t, "s3cr3t", cfg.Storage.SecretKey, "Redacted must not touch the original")
+}
+
+func TestReload(t *testing.T) {
+	path := writeConfigFile(t, "ech0.yaml", "log:\n  level: info\n")
+	start, err := Load(path)
+	require.NoError(t, err)
+	useConfig(t, start, path)
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+log:
+  level: debug
+rate_limit:
+  like_rps: 9
+server:
+  port: "7000"
+web:
+  cors:
+    allowed_origins: ["https://a.example"]
+`), 0o600))
+	result, err := Reload()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"log.level", "rate_limit.like_rps"}, result.Applied)
+	assert.ElementsMatch(t, []string{"server.port", "web.cors.allowed_origins"}, result.Pending)
+	assert.Equal(t, "debug", Config().Log.Level)
+	assert.Equal(t, 9, Config().RateLimit.LikeRPS)
+	assert.Equal(t, "6277", Config().Server.Port, "restart-only keys are not applied")
+	assert.Equal(t, "info", start.Log.Level, "the previous snapshot is never mutated")
+
+	require.NoError(t, os.WriteFile(path, []byte("log:\n  level: loud\n"), 0o600))
+	_, err = Reload()
+	require.ErrorContains(t, err, "log.level")
+	assert.Equal(t, "debug", Config().Log.Level, "an invalid file keeps the current configuration")
+}
@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/caarlos0/env/v11"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// FileEnv 是指定配置文件路径的环境变量；命令行 --config 优先于它。
+const FileEnv = "ECH0_CONFIG_FILE"
+
+const redactedValue = "******"
+
+var (
+	fileMu   sync.RWMutex
+	filePath string
+)
+
+// SetFile 指定配置文件路径（来自 --config），须在首次调用 Config 之前设置。
+func SetFile(path string) {
+	fileMu.Lock()
+	defer fileMu.Unlock()
+	filePath = strings.TrimSpace(path)
+}
+
+// File 返回生效的配置文件路径；为空表示只用默认值与环境变量。
+func File() string {
+	fileMu.RLock()
+	defer fileMu.RUnlock()
+	if filePath != "" {
+		return filePath
+	}
+	return strings.TrimSpace(os.Getenv(FileEnv))
+}
+
+// EnvError 表示环境变量覆盖解析失败。解析失败的项保持配置文件/默认值，
+// 启动与热加载时只告警（与引入配置文件前的行为一致），`config validate` 则视为错误。
+type EnvError struct {
+	Err error
+}
+
+func (e *EnvError) Error() string { return "parse env overrides: " + e.Err.Error() }
+
+func (e *EnvError) Unwrap() error { return e.Err }
+
+// Load 按「默认值 → 配置文件 → 环境变量」装配一份独立的配置，不影响全局单例。
+// 配置文件读不了、格式错误或含未知项时返回 nil 与错误；仅环境变量解析失败时
+// 仍返回装配结果，错误为 *EnvError。
+func Load(path string) (*AppConfig, error) {
+	cfg := defaultConfig()
+	if path != "" {
+		if err := decodeFile(cfg, path); err != nil {
+			return nil, err
+		}
+	}
+	if err := env.Parse(cfg); err != nil {
+		return cfg, &EnvError{Err: err}
+	}
+	return cfg, nil
+}
+
+// load 是 Config 与 Reload 共用的宽松装配：环境变量错误只打印告警。
+func load(path string) (*AppConfig, error) {
+	cfg, err := Load(path)
+	var envErr *EnvError
+	if errors.As(err, &envErr) {
+		_, _ = fmt.Fprintf(os.Stderr, "Failed to parse env overrides: %v\n", envErr.Err)
+		return cfg, nil
+	}
+	return cfg, err
+}
+
+// decodeFile 把 YAML / TOML 配置文件叠加到 cfg 上，文件里没写的项保持原值。
+// 两种格式共用 yaml tag：TOML 先解成通用 map 再转 YAML 解码，免得每个字段挂两套 tag。
+func decodeFile(cfg *AppConfig, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	raw := map[string]any{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	case ".toml":
+		if err := toml.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if data, err = yaml.Marshal(raw); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	default:
+		return fmt.Errorf("%s: unsupported config file type (want .yaml, .yml or .toml)", path)
+	}
+
+	if unknown := unknownKeys(reflect.TypeOf(*cfg), raw, ""); len(unknown) > 0 {
+		return fmt.Errorf("%s: unknown keys: %s", path, strings.Join(unknown, ", "))
+	}
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(cfg); err != nil && len(raw) > 0 {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// unknownKeys 对照 yaml tag 找出文件里拼错或不存在的键，按路径返回（如 "log.levle"）。
+func unknownKeys(t reflect.Type, raw map[string]any, prefix string) []string {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := range t.NumField() {
+		if name := yamlName(t.Field(i)); name != "" {
+			fields[name] = t.Field(i).Type
+		}
+	}
+
+	var unknown []string
+	for key, value := range raw {
+		ft, ok := fields[key]
+		if !ok {
+			unknown = append(unknown, prefix+key)
+			continue
+		}
+		if nested, isMap := value.(map[string]any); isMap && ft.Kind() == reflect.Struct {
+			unknown = append(unknown, unknownKeys(ft, nested, prefix+key+".")...)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// yamlName 返回字段在配置文件中的键名；yaml:"-" 或未导出字段返回空。
+func yamlName(sf reflect.StructField) string {
+	if !sf.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return strings.ToLower(sf.Name)
+	}
+	return name
+}
+
+// walkLeaves 以 "section.key" 路径遍历配置的叶子字段（跳过不进配置文件的字段）。
+func walkLeaves(v reflect.Value, prefix string, fn func(key string, field reflect.Value, sf reflect.StructField)) {
+	t := v.Type()
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		name := yamlName(sf)
+		if name == "" {
+			continue
+		}
+		field := v.Field(i)
+		if sf.Type.Kind() == reflect.Struct {
+			walkLeaves(field, prefix+name+".", fn)
+			continue
+		}
+		fn(prefix+name, field, sf)
+	}
+}
+
+// Redacted 返回 cfg 的副本，带 redact tag 的敏感项（非空时）替换为占位符。
+func Redacted(cfg *AppConfig) *AppConfig {
+	out := *cfg
+	walkLeaves(reflect.ValueOf(&out).Elem(), "", func(_ string, field reflect.Value, sf reflect.StructField) {
+		if sf.Tag.Get("redact") == "true" && field.Kind() == reflect.String && field.String() != "" {
+			field.SetString(redactedValue)
+		}
+	})
+	return &out
+}
+
+// Marshal 把配置编码为配置文件格式（"yaml" 或 "toml"），可直接作为 --config 的输入。
+func Marshal(cfg *AppConfig, format string) ([]byte, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(format) {
+	case "", "yaml", "yml":
+		return data, nil
+	case "toml":
+		raw := map[string]any{}
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return nil, err
+		}
+		return toml.Marshal(raw)
+	default:
+		return nil, fmt.Errorf("unsupported format %q (want yaml or toml)", format)
+	}
+}
+
+// Default 返回内置默认配置（不读配置文件与环境变量），用于生成配置文件模板。
+func Default() *AppConfig {
+	return defaultConfig()
+}
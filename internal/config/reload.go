@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package config
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// hotReloadable 列出运行中可直接生效的配置项（键或 "section." 前缀）。
+// 它们都是每次使用时经 Config() 现取的值；其余项在启动时已被各模块消费，改了也要重启。
+// web.cors.* 不在其中：CORS 中间件并不读它，它只是 OAuth2 设置白名单的初始默认值，
+// 报成「已生效」会让人以为跨域策略变了。
+var hotReloadable = []string{
+	"log.level",
+	"rate_limit.",
+	"upload.allowed_types",
+	"upload.image_max_size",
+	"upload.audio_max_size",
+	"upload.video_max_size",
+}
+
+var reloadMu sync.Mutex
+
+// ReloadResult 是一次热加载的结果：Applied 为已生效的变更键，Pending 为变更了但需重启才生效的键。
+type ReloadResult struct {
+	Applied []string
+	Pending []string
+}
+
+// Reload 重新装配配置并把 hotReloadable 中的变更换入全局单例。
+// 新配置校验不通过时整体放弃，当前配置保持不变。
+func Reload() (ReloadResult, error) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	next, err := load(File())
+	if err != nil {
+		return ReloadResult{}, err
+	}
+	if err := next.Validate(); err != nil {
+		return ReloadResult{}, err
+	}
+
+	cur := Config()
+	merged := *cur
+	var result ReloadResult
+	nextValue := reflect.ValueOf(next).Elem()
+	curValue := reflect.ValueOf(cur).Elem()
+	walkLeaves(reflect.ValueOf(&merged).Elem(), "", func(key string, field reflect.Value, _ reflect.StructField) {
+		newField := fieldByKey(nextValue, key)
+		if reflect.DeepEqual(fieldByKey(curValue, key).Interface(), newField.Interface()) {
+			return
+		}
+		if !isHotReloadable(key) {
+			result.Pending = append(result.Pending, key)
+			return
+		}
+		field.Set(newField)
+		result.Applied = append(result.Applied, key)
+	})
+
+	if len(result.Applied) > 0 {
+		current.Store(&merged)
+	}
+	return result, nil
+}
+
+func isHotReloadable(key string) bool {
+	for _, k := range hotReloadable {
+		if key == k || (strings.HasSuffix(k, ".") && strings.HasPrefix(key, k)) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldByKey 按 walkLeaves 的路径取出同一字段。
+func fieldByKey(v reflect.Value, key string) reflect.Value {
+	for _, name := range strings.Split(key, ".") {
+		t := v.Type()
+		for i := range t.NumField() {
+			if yamlName(t.Field(i)) == name {
+				v = v.Field(i)
+				break
+			}
+		}
+	}
+	return v
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Validate 检查配置能否让服务正常启动，返回全部问题（errors.Join），而不是遇到第一个就停。
+// 只校验取值是否合法；文件路径是否可写等运行期条件仍由各模块启动时报告。
+func (c *AppConfig) Validate() error {
+	var errs []error
+	bad := func(key, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+	oneOf := func(key, value string, allowed ...string) {
+		if !slices.Contains(allowed, value) {
+			bad(key, "%q is not one of %s", value, strings.Join(allowed, ", "))
+		}
+	}
+	positive := func(key string, value int) {
+		if value <= 0 {
+			bad(key, "must be greater than 0, got %d", value)
+		}
+	}
+
+	if port, err := strconv.Atoi(c.Server.Port); err != nil || port < 1 || port > 65535 {
+		bad("server.port", "%q is not a valid port", c.Server.Port)
+	}
+	oneOf("server.mode", c.Server.Mode, "debug", "release")
+	oneOf("openapi.docs_renderer", c.OpenAPI.DocsRenderer, "stoplight", "scalar")
+
+	oneOf("database.type", c.Database.Type, "sqlite")
+	// InitDatabase 以 "/ech0.db" 后缀切出数据目录。
+	if !strings.HasSuffix(c.Database.Path, "/ech0.db") {
+		bad("database.path", "%q must end with /ech0.db", c.Database.Path)
+	}
+
+	oneOf("log.level", strings.ToLower(c.Log.Level), "debug", "info", "warn", "warning", "error", "panic", "fatal")
+	oneOf("log.format", c.Log.Format, "json", "console")
+	oneOf("log.drop_policy", strings.ToLower(strings.TrimSpace(c.Log.DropPolicy)), "drop_oldest", "drop_newest")
+
+	positive("auth.jwt.expires", c.Auth.Jwt.Expires)
+	positive("auth.jwt.refresh_expires", c.Auth.Jwt.RefreshExpires)
+
+	positive("upload.image_max_size", c.Upload.ImageMaxSize)
+	positive("upload.audio_max_size", c.Upload.AudioMaxSize)
+	positive("upload.video_max_size", c.Upload.VideoMaxSize)
+	if len(c.Upload.AllowedTypes) == 0 {
+		bad("upload.allowed_types", "must not be empty")
+	}
+
+	if c.Storage.Provider != "" {
+		oneOf("storage.provider", c.Storage.Provider, "aws", "r2", "minio", "other")
+	}
+
+	positive("migration.sync_max_bytes", c.Migration.SyncMaxBytes)
+
+	oneOf("event.default_overflow", c.Event.DefaultOverflow, "block", "fail_fast", "drop_newest", "drop_oldest")
+
+	positive("rate_limit.like_rps", c.RateLimit.LikeRPS)
+	positive("rate_limit.like_burst", c.RateLimit.LikeBurst)
+	positive("rate_limit.mcp_rps", c.RateLimit.MCPRPS)
+	positive("rate_limit.mcp_burst", c.RateLimit.MCPBurst)
+	positive("rate_limit.webmention_rps", c.RateLimit.WebmentionRPS)
+	positive("rate_limit.webmention_burst", c.RateLimit.WebmentionBurst)
+	positive("rate_limit.search_rps", c.RateLimit.SearchRPS)
+	positive("rate_limit.search_burst", c.RateLimit.SearchBurst)
+
+	return errors.Join(errs...)
+}
@@ -21,6 +21,7 @@ import (
 	embeddingModel "github.com/lin-snow/ech0/internal/model/embedding"
 	fileModel "github.com/lin-snow/ech0/internal/model/file"
 	jobModel "github.com/lin-snow/ech0/internal/model/job"
+	notifyModel "github.com/lin-snow/ech0/internal/model/notify"
 	settingModel "github.com/lin-snow/ech0/internal/model/setting"
 	userModel "github.com/lin-snow/ech0/internal/model/user"
 	visitorModel "github.com/lin-snow/ech0/internal/model/visitor"
@@ -160,6 +161,9 @@ func InitDatabase() {
 			dbMigration.NewUserLocalAuthBackfillMigrator(),
 			dbMigration.NewUsersPasswordDropMigrator(),
 			dbMigration.NewEchoExtensionOrphansMigrator(),
+			dbMigration.NewLegacyJobsDropMigrator(),
+			dbMigration.NewFileRouteIndexRelaxMigrator(),
+			dbMigration.NewStorageUsageBackfillMigrator(),
 		),
 	)
 }
@@ -177,12 +181,18 @@ func MigrateDB() error {
 		&fileModel.File{},
 		&fileModel.EchoFile{},
 		&fileModel.TempFile{},
+		&fileModel.StorageUsage{},
 		&commonModel.KeyValue{},
+		&commonModel.Tombstone{},
 		&connectModel.Connected{},
 		&echoModel.Tag{},
 		&echoModel.EchoTag{},
 		&commentModel.Comment{},
+		&commentModel.CommentSubscription{},
+		&commentModel.EmailSuppression{},
 		&webhookModel.Webhook{},
+		&notifyModel.NotifyChannel{},
+		&notifyModel.NotifyDelivery{},
 		&jobModel.Job{},
 		&settingModel.AccessTokenSetting{},
 		&authModel.Passkey{},
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package migration
+
+import (
+	"fmt"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	"gorm.io/gorm"
+)
+
+const fileRouteIndexName = "idx_file_route"
+
+// fileRouteIndexRelaxMigrator 把 files 表的 idx_file_route 从唯一索引重建为普通索引。
+//
+// 内容去重让同一路由上的多行共享一个存储 key（各自挂在不同 Echo 上，删除时按引用计数回收），
+// 旧的唯一约束会直接拒绝这类行。AutoMigrate 只按索引名判断存在与否，不会改写已有索引的
+// 唯一性，所以这里显式 drop 再按模型定义重建；新库上同样执行一遍，结果一致。
+type fileRouteIndexRelaxMigrator struct{}
+
+func NewFileRouteIndexRelaxMigrator() Migrator {
+	return &fileRouteIndexRelaxMigrator{}
+}
+
+func (m *fileRouteIndexRelaxMigrator) Name() string {
+	return "file_route_index_relax_migrator"
+}
+
+func (m *fileRouteIndexRelaxMigrator) Key() string {
+	return commonModel.FileRouteIndexRelaxedKey
+}
+
+func (m *fileRouteIndexRelaxMigrator) CanRerun() bool {
+	return false
+}
+
+func (m *fileRouteIndexRelaxMigrator) Migrate(db *gorm.DB) error {
+	if db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	migrator := db.Migrator()
+	if !migrator.HasTable(&fileModel.File{}) {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if tx.Migrator().HasIndex(&fileModel.File{}, fileRouteIndexName) {
+			if err := tx.Migrator().DropIndex(&fileModel.File{}, fileRouteIndexName); err != nil {
+				return fmt.Errorf("drop %s: %w", fileRouteIndexName, err)
+			}
+		}
+		if err := tx.Migrator().CreateIndex(&fileModel.File{}, fileRouteIndexName); err != nil {
+			return fmt.Errorf("create %s: %w", fileRouteIndexName, err)
+		}
+		return nil
+	})
+}
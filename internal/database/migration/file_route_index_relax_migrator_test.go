@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package migration_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/database"
+	dbMigration "github.com/lin-snow/ech0/internal/database/migration"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestFileRouteIndexRelaxMigrator_AllowsSharedKeys(t *testing.T) {
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	database.SetDB(db)
+	if err := database.MigrateDB(); err != nil {
+		t.Fatalf("migrate db failed: %v", err)
+	}
+
+	// 还原成升级前的库：唯一索引 + 未打标记。
+	if err := db.Exec(`DROP INDEX IF EXISTS idx_file_route`).Error; err != nil {
+		t.Fatalf("drop index failed: %v", err)
+	}
+	if err := db.Exec(`CREATE UNIQUE INDEX idx_file_route ON files (storage_type, provider, bucket, key)`).Error; err != nil {
+		t.Fatalf("create unique index failed: %v", err)
+	}
+	if err := db.Where("key = ?", commonModel.FileRouteIndexRelaxedKey).Delete(&commonModel.KeyValue{}).Error; err != nil {
+		t.Fatalf("clear marker failed: %v", err)
+	}
+
+	dbMigration.Migrate(
+		db,
+		dbMigration.WithStopOnError(),
+		dbMigration.WithMigrators(dbMigration.NewFileRouteIndexRelaxMigrator()),
+	)
+
+	for _, id := range []string{"f1", "f2"} {
+		row := fileModel.File{ID: id, Key: "images/shared.png", StorageType: "local", UserID: "u1"}
+		if err := db.Create(&row).Error; err != nil {
+			t.Fatalf("insert %s sharing key failed: %v", id, err)
+		}
+	}
+	if !db.Migrator().HasIndex(&fileModel.File{}, "idx_file_route") {
+		t.Fatal("expected idx_file_route to be recreated")
+	}
+
+	var marker commonModel.KeyValue
+	if err := db.Where("key = ?", commonModel.FileRouteIndexRelaxedKey).First(&marker).Error; err != nil {
+		t.Fatalf("expected migrator marker, got err: %v", err)
+	}
+}
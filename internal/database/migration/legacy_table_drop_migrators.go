@@ -76,3 +76,30 @@ func (m *legacyInboxesDropMigrator) Migrate(db *gorm.DB) error {
 	}
 	return nil
 }
+
+type legacyJobsDropMigrator struct{}
+
+// NewLegacyJobsDropMigrator 删除旧的 jobs 表（主键即 type、每类型单行）。作业已改存
+// job_runs；旧表里至多每类型一条终态记录，无需搬迁。
+func NewLegacyJobsDropMigrator() Migrator {
+	return &legacyJobsDropMigrator{}
+}
+
+func (m *legacyJobsDropMigrator) Name() string {
+	return "legacy_jobs_drop_migrator"
+}
+
+func (m *legacyJobsDropMigrator) Key() string {
+	return commonModel.LegacyJobsDroppedKey
+}
+
+func (m *legacyJobsDropMigrator) CanRerun() bool {
+	return false
+}
+
+func (m *legacyJobsDropMigrator) Migrate(db *gorm.DB) error {
+	if db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return db.Exec(`DROP TABLE IF EXISTS jobs`).Error
+}
@@ -94,3 +94,49 @@ func TestLegacyInboxesDropMigrator_DropTableAndMarkDone(t *testing.T) {
 		t.Fatalf("expected migrator marker, got err: %v", err)
 	}
 }
+
+func TestLegacyJobsDropMigrator_DropTableAndKeepJobRuns(t *testing.T) {
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	database.SetDB(db)
+	if err := database.MigrateDB(); err != nil {
+		t.Fatalf("migrate db failed: %v", err)
+	}
+
+	if err := db.Exec(`CREATE TABLE jobs (type TEXT PRIMARY KEY, status TEXT)`).Error; err != nil {
+		t.Fatalf("create jobs failed: %v", err)
+	}
+	if err := db.Exec(`INSERT INTO jobs (type, status) VALUES ('reindex', 'success')`).Error; err != nil {
+		t.Fatalf("insert jobs row failed: %v", err)
+	}
+
+	dbMigration.Migrate(
+		db,
+		dbMigration.WithStopOnError(),
+		dbMigration.WithMigrators(dbMigration.NewLegacyJobsDropMigrator()),
+	)
+
+	count := func(name string) int64 {
+		var n int64
+		if err := db.Raw("SELECT COUNT(1) FROM sqlite_master WHERE type='table' AND name=?", name).Scan(&n).Error; err != nil {
+			t.Fatalf("query sqlite_master failed: %v", err)
+		}
+		return n
+	}
+	if count("jobs") != 0 {
+		t.Fatal("expected legacy jobs table to be dropped")
+	}
+	if count("job_runs") != 1 {
+		t.Fatal("expected job_runs table to be kept")
+	}
+
+	var marker commonModel.KeyValue
+	if err := db.Where("key = ?", commonModel.LegacyJobsDroppedKey).First(&marker).Error; err != nil {
+		t.Fatalf("expected migrator marker, got err: %v", err)
+	}
+}
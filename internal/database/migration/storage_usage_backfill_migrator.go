@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	"gorm.io/gorm"
+)
+
+// storageUsageBackfillMigrator 在引入存储配额后按存量文件行一次性回填用量账本。
+//
+// 此后账本由 FileService 在上传/删除时增量维护；不回填的话升级后所有用户都从 0 起算，
+// 要等第一轮定时重算才对得上，期间配额形同虚设。统计口径与重算任务一致：外链文件不计。
+type storageUsageBackfillMigrator struct{}
+
+func NewStorageUsageBackfillMigrator() Migrator {
+	return &storageUsageBackfillMigrator{}
+}
+
+func (m *storageUsageBackfillMigrator) Name() string {
+	return "storage_usage_backfill_migrator"
+}
+
+func (m *storageUsageBackfillMigrator) Key() string {
+	return commonModel.StorageUsageBackfilledKey
+}
+
+func (m *storageUsageBackfillMigrator) CanRerun() bool {
+	return false
+}
+
+func (m *storageUsageBackfillMigrator) Migrate(db *gorm.DB) error {
+	if db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	migrator := db.Migrator()
+	if !migrator.HasTable(&fileModel.File{}) || !migrator.HasTable(&fileModel.StorageUsage{}) {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&fileModel.StorageUsage{}).Error; err != nil {
+			return fmt.Errorf("clear storage usage: %w", err)
+		}
+		if err := tx.Exec(`
+INSERT INTO storage_usages (user_id, category, bytes, files, updated_at)
+SELECT user_id, category, COALESCE(SUM(size), 0), COUNT(*), ?
+FROM files
+WHERE storage_type <> 'external'
+GROUP BY user_id, category`, time.Now().UTC().Unix()).Error; err != nil {
+			return fmt.Errorf("backfill storage usage: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package migration_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/database"
+	dbMigration "github.com/lin-snow/ech0/internal/database/migration"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestStorageUsageBackfillMigrator_CountsManagedFiles(t *testing.T) {
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	database.SetDB(db)
+	if err := database.MigrateDB(); err != nil {
+		t.Fatalf("migrate db failed: %v", err)
+	}
+
+	rows := []fileModel.File{
+		{ID: "f1", Key: "images/a.png", StorageType: "local", Category: "image", Size: 100, UserID: "u1"},
+		{ID: "f2", Key: "images/b.png", StorageType: "object", Category: "image", Size: 50, UserID: "u1"},
+		{ID: "f3", Key: "videos/c.mp4", StorageType: "local", Category: "video", Size: 700, UserID: "u1"},
+		{ID: "f4", Key: "external/d", StorageType: "external", Category: "image", Size: 999, UserID: "u1"},
+		{ID: "f5", Key: "images/e.png", StorageType: "local", Category: "image", Size: 10, UserID: "u2"},
+	}
+	if err := db.Create(&rows).Error; err != nil {
+		t.Fatalf("insert files failed: %v", err)
+	}
+
+	dbMigration.Migrate(
+		db,
+		dbMigration.WithStopOnError(),
+		dbMigration.WithMigrators(dbMigration.NewStorageUsageBackfillMigrator()),
+	)
+
+	var usages []fileModel.StorageUsage
+	if err := db.Order("user_id, category").Find(&usages).Error; err != nil {
+		t.Fatalf("list usage failed: %v", err)
+	}
+	want := []struct {
+		user, category string
+		bytes, files   int64
+	}{
+		{"u1", "image", 150, 2},
+		{"u1", "video", 700, 1},
+		{"u2", "image", 10, 1},
+	}
+	if len(usages) != len(want) {
+		t.Fatalf("expected %d usage rows, got %d: %+v", len(want), len(usages), usages)
+	}
+	for i, w := range want {
+		got := usages[i]
+		if got.UserID != w.user || got.Category != w.category || got.Bytes != w.bytes || got.Files != w.files {
+			t.Errorf("row %d: got %+v, want %+v", i, got, w)
+		}
+	}
+
+	var marker commonModel.KeyValue
+	if err := db.Where("key = ?", commonModel.StorageUsageBackfilledKey).First(&marker).Error; err != nil {
+		t.Fatalf("expected migrator marker, got err: %v", err)
+	}
+}
@@ -18,14 +18,18 @@ import (
 	"github.com/lin-snow/ech0/internal/job"
 	jobRunner "github.com/lin-snow/ech0/internal/job/runner"
 	"github.com/lin-snow/ech0/internal/kvstore"
+	"github.com/lin-snow/ech0/internal/mcp"
 	"github.com/lin-snow/ech0/internal/middleware"
 	"github.com/lin-snow/ech0/internal/migrator"
 	jobModel "github.com/lin-snow/ech0/internal/model/job"
+	"github.com/lin-snow/ech0/internal/notify"
 	"github.com/lin-snow/ech0/internal/repository"
 	keyvalueRepository "github.com/lin-snow/ech0/internal/repository/keyvalue"
 	"github.com/lin-snow/ech0/internal/server"
 	"github.com/lin-snow/ech0/internal/service"
 	copilotService "github.com/lin-snow/ech0/internal/service/copilot"
+	fileService "github.com/lin-snow/ech0/internal/service/file"
+	migratorService "github.com/lin-snow/ech0/internal/service/migrator"
 	userService "github.com/lin-snow/ech0/internal/service/user"
 	"github.com/lin-snow/ech0/internal/storage"
 	"github.com/lin-snow/ech0/internal/task"
@@ -33,6 +37,7 @@ import (
 	"github.com/lin-snow/ech0/internal/transaction"
 	"github.com/lin-snow/ech0/internal/visitor"
 	"github.com/lin-snow/ech0/internal/webhook"
+	"github.com/lin-snow/ech0/internal/webmention"
 	"github.com/lin-snow/ech0/pkg/busen"
 	"gorm.io/gorm"
 )
@@ -44,6 +49,10 @@ var AppSet = app.ProviderSet
 // 顶层引入一次,统一下沉给 BuildHandlers 和 BuildTasker。
 var VisitorSet = wire.NewSet(visitor.NewTracker)
 
+// MCPNotifierSet 同 VisitorSet：*mcp.Notifier 须全进程一个实例——BuildEventRegistrar 把事件喂给它，
+// BuildHandlers 里的 MCP Server 从它读订阅推送，两边各建一份就会「事件进了 #1、订阅流挂在 #2」。
+var MCPNotifierSet = wire.NewSet(mcp.NewNotifier)
+
 // ProvideJobManager 构造已装配好 Runner 的共享单例 *job.Manager（在构造期一次性
 // 完成注册）。Runner 只依赖 EmbeddingService / migrator.ImportEngine（均不含 *job.Manager），
 // 故不会与「MigratorService 需要 Manager」形成构造环。
@@ -52,11 +61,29 @@ func ProvideJobManager(
 	reindex *jobRunner.ReindexRunner,
 	migration *jobRunner.MigrationRunner,
 	export *jobRunner.ExportRunner,
+	sync *jobRunner.SyncRunner,
+	publish *jobRunner.PublishRunner,
+	modelPull *jobRunner.ModelPullRunner,
+	storageMigration *jobRunner.StorageMigrationRunner,
+	fileDedupe *jobRunner.FileDedupeRunner,
+	webmention *jobRunner.WebmentionRunner,
 ) *job.Manager {
 	m := job.NewManager(repo)
-	m.Register(jobModel.TypeReindex, job.Adapt(reindex.Run))
+	// 迁移会改写整库且依赖暂存目录，既不排队也不续跑；其余 Runner 按原 payload 重跑是安全的。
+	// 导出与发布允许排队（发布只需多排一轮：排着的那轮已能带上之后的改动），同步本就增量，互斥即可。
+	m.Register(jobModel.TypeReindex, job.Adapt(reindex.Run), job.Resumable())
 	m.Register(jobModel.TypeMigration, job.Adapt(migration.Run))
-	m.Register(jobModel.TypeExport, job.Adapt(export.Run))
+	m.Register(jobModel.TypeExport, job.Adapt(export.Run), job.WithQueue(3), job.Resumable())
+	m.Register(jobModel.TypeSync, job.Adapt(sync.Run), job.Resumable())
+	m.Register(jobModel.TypePublish, job.Adapt(publish.Run), job.WithQueue(1), job.Resumable())
+	// 模型拉取可排队（先拉生成模型、再拉向量模型）；Ollama 拉取断点续传，重启后续跑是安全的。
+	m.Register(jobModel.TypeModelPull, job.Adapt(modelPull.Run), job.WithQueue(3), job.Resumable())
+	// 存储迁移互斥、不排队；已改写的行不再出现在源路由上，按原 payload 续跑即从断点接着搬。
+	m.Register(jobModel.TypeStorageMigration, job.Adapt(storageMigration.Run), job.Resumable())
+	// 内容去重互斥；回填与改写都按行守卫，重跑只会接着处理剩下的行。
+	m.Register(jobModel.TypeFileDedupe, job.Adapt(fileDedupe.Run), job.Resumable())
+	// 外发 Webmention 每次保存 Echo 排一条，串行投递即可；对方按 (source, target) 幂等，续跑安全。
+	m.Register(jobModel.TypeWebmention, job.Adapt(webmention.Run), job.WithQueue(100), job.Resumable())
 	return m
 }
 
@@ -66,8 +93,12 @@ func ProvideTaskManager(
 	cleanup *scheduled.Cleanup,
 	snapshot *scheduled.Snapshot,
 	visitorSnapshot *scheduled.VisitorSnapshot,
+	sync *scheduled.Sync,
+	publish *scheduled.Publish,
+	quotaRecount *scheduled.QuotaRecount,
+	digest *scheduled.Digest,
 ) (*task.Manager, error) {
-	return task.NewManager(cleanup, snapshot, visitorSnapshot)
+	return task.NewManager(cleanup, snapshot, visitorSnapshot, sync, publish, quotaRecount, digest)
 }
 
 // StorageSet 提供进程级共享单例 *storage.Manager。storage.Manager 是有状态基础设施
@@ -119,12 +150,27 @@ var EventSet = wire.NewSet(
 
 	repository.KeyValueSet,
 	repository.WebhookSet,
+	repository.NotifySet,
 	repository.EmbeddingSet,
 
 	webhook.NewDispatcher,
+	notify.NewDispatcher,
+	webmention.NewDispatcher,
 	eventsubscriber.NewAgentProcessor,
 	eventsubscriber.NewEmbeddingProcessor,
 	service.EmbeddingSet,
+
+	// 新 Echo 的标签 / 替代文本建议：经 echo / file 服务回写，故带上其依赖。
+	repository.CommonSet,
+	repository.FileSet,
+	service.CommonSet,
+	service.FileSet,
+	service.EchoSet,
+	service.SuggestSet,
+	// 建议的替代文本写回跨域绑定到 file 服务（管理员校验在其内部完成）。
+	wire.Bind(new(copilotService.AltTextWriter), new(*fileService.FileService)),
+	eventsubscriber.NewSuggestionProcessor,
+
 	ProvideSubscriptionProviders,
 	eventbus.NewEventRegistry,
 )
@@ -158,6 +204,8 @@ var HandlerSet = wire.NewSet(
 
 	repository.WebhookSet,
 	webhook.NewSender,
+	repository.NotifySet,
+	notify.NewSender,
 	repository.KeyValueSet,
 
 	repository.SettingSet,
@@ -169,21 +217,36 @@ var HandlerSet = wire.NewSet(
 	handler.ConnectSet,
 
 	service.DashboardSet,
+	service.DigestSet,
 	handler.DashboardSet,
 
 	repository.EmbeddingSet,
 	service.EmbeddingSet,
 	handler.EmbeddingSet,
 
+	service.SearchSet,
+	handler.SearchSet,
+
 	service.CopilotSet,
 	// Copilot 的 UserReader 跨域绑定到 user 服务（取当前对话用户：展示名 + 检索按作者收口）。
 	wire.Bind(new(copilotService.UserReader), new(*userService.UserService)),
+	service.SuggestSet,
+	wire.Bind(new(copilotService.AltTextWriter), new(*fileService.FileService)),
 	handler.CopilotSet,
 
+	// 同步端点 ← migrator.CapsuleEngine（与 BuildJobManager 内那份一样无状态，各建一份无妨）
+	ProvideGormDB,
+	migrator.NewCapsuleEngine,
+	wire.Bind(new(migratorService.SyncEngine), new(*migrator.CapsuleEngine)),
 	service.MigratorSet,
 	handler.MigrationSet,
 
+	// 跨类型的作业历史 / 取消端点，直接读共享的 *job.Manager。
+	handler.JobSet,
+
 	handler.MCPSet,
+	handler.MicropubSet,
+	handler.WebmentionSet,
 
 	handler.NewBundle,
 )
@@ -197,6 +260,9 @@ var TaskerSet = wire.NewSet(
 	repository.FileSet,
 	repository.KeyValueSet,
 	repository.WebhookSet,
+	webhook.NewSender,
+	repository.NotifySet,
+	notify.NewSender,
 
 	// SettingService 需要 TokenRevoker (管理员删 token 时写黑名单)，
 	// 而 TokenRevoker 由 AuthSet 提供，因此 Tasker 也得包含一份。
@@ -212,6 +278,19 @@ var TaskerSet = wire.NewSet(
 	service.CommonSet,
 
 	repository.VisitorSet,
+	// scheduled.Digest 以 owner 身份汇总评论、Echo、Webhook、互联与 Copilot 总结，经评论系统发信。
+	repository.CommentSet,
+	service.CommentSet,
+	repository.ConnectSet,
+	service.ConnectSet,
+	repository.UserSet,
+	service.UserSet,
+	repository.EmbeddingSet,
+	service.EmbeddingSet,
+	service.CopilotSet,
+	wire.Bind(new(copilotService.UserReader), new(*userService.UserService)),
+	service.DigestSet,
+
 	// scheduled.Snapshot 依赖 migrator.ExportEngine（打包 + 尽力 S3），定时快照不走 job.Manager。
 	migrator.NewExportEngine,
 	scheduled.ProviderSet,
@@ -223,6 +302,7 @@ func BuildApp() (*app.App, error) {
 	wire.Build(
 		InfraSet,
 		VisitorSet,
+		MCPNotifierSet,
 		// StorageSet 内含 ProvideStorageKV：同一份 kvstore.Store 既给 storage.Manager
 		// 读 S3 设置，也供 AppSet 的启动 seeder 使用。
 		StorageSet,
@@ -238,13 +318,16 @@ func BuildEventRegistrar(
 	ebProvider func() *busen.Bus,
 	appCache cache.ICache[string, any],
 	tx transaction.Transactor,
+	notifier *mcp.Notifier,
+	storageManager *storage.Manager,
+	jobManager *job.Manager,
 ) (*eventbus.EventRegistrar, error) {
 	wire.Build(EventSet)
 	return &eventbus.EventRegistrar{}, nil
 }
 
 // BuildHandlers 使用 wire 生成的代码来构建 Handlers 实例。
-// tracker 由顶层 BuildApp/BuildServer 注入,保证整个进程只有一个 visitor.Tracker 实例。
+// tracker 由顶层 BuildApp/BuildServer 注入,保证整个进程只有一个 visitor.Tracker 实例；notifier 同理。
 func BuildHandlers(
 	dbProvider func() *gorm.DB,
 	appCache cache.ICache[string, any],
@@ -253,6 +336,7 @@ func BuildHandlers(
 	tracker *visitor.Tracker,
 	jobManager *job.Manager,
 	storageManager *storage.Manager,
+	notifier *mcp.Notifier,
 ) (*handler.Bundle, error) {
 	wire.Build(HandlerSet)
 	return &handler.Bundle{}, nil
@@ -280,9 +364,13 @@ func BuildJobManager(
 		migrator.NewImportEngine,
 		// ExportRunner ← migrator.ExportEngine（无状态导出，不含 *job.Manager）+ bus（发 SystemSnapshot）
 		migrator.NewExportEngine,
-		// 两个 Runner 的胶囊分支 ← migrator.CapsuleEngine（直连 GORM + 事务，胶囊包刻意不过 service 层）
+		// 两个 Runner 的胶囊分支与 SyncRunner ← migrator.CapsuleEngine（直连 GORM + 事务，胶囊包刻意不过 service 层）
 		ProvideGormDB,
 		migrator.NewCapsuleEngine,
+		// StorageMigrationRunner ← FileService（与 handler 侧共用 storageManager 单例）
+		repository.CommonSet,
+		repository.FileSet,
+		service.FileSet,
 		jobRunner.ProviderSet,
 		ProvideJobManager,
 	)
@@ -303,6 +391,7 @@ func BuildServer() (*server.Server, error) {
 	wire.Build(
 		InfraSet,
 		VisitorSet,
+		MCPNotifierSet,
 		StorageSet,
 		BuildJobManager,
 		BuildHandlers,
@@ -312,6 +401,49 @@ func BuildServer() (*server.Server, error) {
 	return &server.Server{}, nil
 }
 
+// MCPRuntime 是 `ech0 mcp` 本地模式的运行时：直连本地库装配出与 /mcp 同一个 MCP Handler，
+// 外加事件注册器（让 MCP 写操作照常触发 webhook / 嵌入 / 订阅推送）与用户仓储（定位会话身份）。
+// 它刻意不含 HTTP server、作业管理器的启动与定时任务——stdio 进程由宿主按需拉起，
+// 生命周期跟着一次会话走。
+type MCPRuntime struct {
+	Handler   *mcp.Handler
+	Notifier  *mcp.Notifier
+	Registrar *eventbus.EventRegistrar
+	Users     userService.Repository
+}
+
+// ProvideMCPRuntime 从 Handler 聚合里取出 MCP Handler，与其余件收口成 MCPRuntime。
+func ProvideMCPRuntime(
+	bundle *handler.Bundle,
+	notifier *mcp.Notifier,
+	registrar *eventbus.EventRegistrar,
+	users userService.Repository,
+) *MCPRuntime {
+	return &MCPRuntime{
+		Handler:   bundle.MCPHandler,
+		Notifier:  notifier,
+		Registrar: registrar,
+		Users:     users,
+	}
+}
+
+// BuildMCPRuntime 构建 `ech0 mcp` 本地模式的运行时。与 BuildServer 共用同一组顶层单例，
+// 保证 MCP Handler 与事件注册器看到的是同一个 Notifier / storage.Manager。
+func BuildMCPRuntime() (*MCPRuntime, error) {
+	wire.Build(
+		InfraSet,
+		VisitorSet,
+		MCPNotifierSet,
+		StorageSet,
+		BuildJobManager,
+		BuildHandlers,
+		BuildEventRegistrar,
+		repository.UserSet,
+		ProvideMCPRuntime,
+	)
+	return &MCPRuntime{}, nil
+}
+
 func BuildTasker(
 	dbProvider func() *gorm.DB,
 	appCache cache.ICache[string, any],
@@ -319,6 +451,7 @@ func BuildTasker(
 	ebProvider func() *busen.Bus,
 	tracker *visitor.Tracker,
 	storageManager *storage.Manager,
+	jobManager *job.Manager,
 ) (*task.Manager, error) {
 	wire.Build(TaskerSet)
 	return &task.Manager{}, nil
@@ -327,7 +460,11 @@ func BuildTasker(
 func ProvideSubscriptionProviders(
 	ap *eventsubscriber.AgentProcessor,
 	ep *eventsubscriber.EmbeddingProcessor,
+	sp *eventsubscriber.SuggestionProcessor,
 	disp *webhook.Dispatcher,
+	notifyDisp *notify.Dispatcher,
+	webmentionDisp *webmention.Dispatcher,
+	notifier *mcp.Notifier,
 ) []eventbus.Subscriber {
-	return []eventbus.Subscriber{ap, ep, disp}
+	return []eventbus.Subscriber{ap, ep, sp, disp, notifyDisp, webmentionDisp, notifier}
 }
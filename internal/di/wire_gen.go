@@ -24,7 +24,9 @@ import (
 	handler15 "github.com/lin-snow/ech0/internal/handler/embedding"
 	handler6 "github.com/lin-snow/ech0/internal/handler/file"
 	handler8 "github.com/lin-snow/ech0/internal/handler/init"
+	handler17 "github.com/lin-snow/ech0/internal/handler/job"
 	handler12 "github.com/lin-snow/ech0/internal/handler/migrator"
+	handler16 "github.com/lin-snow/ech0/internal/handler/search"
 	handler10 "github.com/lin-snow/ech0/internal/handler/setting"
 	handler3 "github.com/lin-snow/ech0/internal/handler/user"
 	handler2 "github.com/lin-snow/ech0/internal/handler/web"
@@ -32,45 +34,50 @@ import (
 	"github.com/lin-snow/ech0/internal/job/runner"
 	"github.com/lin-snow/ech0/internal/kvstore"
 	"github.com/lin-snow/ech0/internal/mcp"
+	"github.com/lin-snow/ech0/internal/micropub"
 	"github.com/lin-snow/ech0/internal/middleware"
 	"github.com/lin-snow/ech0/internal/migrator"
 	"github.com/lin-snow/ech0/internal/model/job"
-	repository14 "github.com/lin-snow/ech0/internal/repository"
-	repository7 "github.com/lin-snow/ech0/internal/repository/auth"
-	repository8 "github.com/lin-snow/ech0/internal/repository/comment"
-	repository5 "github.com/lin-snow/ech0/internal/repository/common"
-	repository11 "github.com/lin-snow/ech0/internal/repository/connect"
+	"github.com/lin-snow/ech0/internal/notify"
+	repository15 "github.com/lin-snow/ech0/internal/repository"
+	repository8 "github.com/lin-snow/ech0/internal/repository/auth"
+	repository9 "github.com/lin-snow/ech0/internal/repository/comment"
+	repository3 "github.com/lin-snow/ech0/internal/repository/common"
+	repository12 "github.com/lin-snow/ech0/internal/repository/connect"
 	repository2 "github.com/lin-snow/ech0/internal/repository/echo"
 	"github.com/lin-snow/ech0/internal/repository/embedding"
-	repository6 "github.com/lin-snow/ech0/internal/repository/file"
-	repository9 "github.com/lin-snow/ech0/internal/repository/init"
-	repository12 "github.com/lin-snow/ech0/internal/repository/job"
+	repository4 "github.com/lin-snow/ech0/internal/repository/file"
+	repository10 "github.com/lin-snow/ech0/internal/repository/init"
+	repository13 "github.com/lin-snow/ech0/internal/repository/job"
 	"github.com/lin-snow/ech0/internal/repository/keyvalue"
-	repository10 "github.com/lin-snow/ech0/internal/repository/setting"
-	repository4 "github.com/lin-snow/ech0/internal/repository/user"
-	repository13 "github.com/lin-snow/ech0/internal/repository/visitor"
-	repository3 "github.com/lin-snow/ech0/internal/repository/webhook"
+	repository6 "github.com/lin-snow/ech0/internal/repository/notify"
+	repository11 "github.com/lin-snow/ech0/internal/repository/setting"
+	repository7 "github.com/lin-snow/ech0/internal/repository/user"
+	repository14 "github.com/lin-snow/ech0/internal/repository/visitor"
+	repository5 "github.com/lin-snow/ech0/internal/repository/webhook"
 	"github.com/lin-snow/ech0/internal/server"
-	service13 "github.com/lin-snow/ech0/internal/service"
+	service14 "github.com/lin-snow/ech0/internal/service"
 	"github.com/lin-snow/ech0/internal/service/auth"
-	service6 "github.com/lin-snow/ech0/internal/service/comment"
-	service4 "github.com/lin-snow/ech0/internal/service/common"
-	service9 "github.com/lin-snow/ech0/internal/service/connect"
-	service12 "github.com/lin-snow/ech0/internal/service/copilot"
-	service11 "github.com/lin-snow/ech0/internal/service/dashboard"
-	service5 "github.com/lin-snow/ech0/internal/service/echo"
+	service7 "github.com/lin-snow/ech0/internal/service/comment"
+	service2 "github.com/lin-snow/ech0/internal/service/common"
+	service10 "github.com/lin-snow/ech0/internal/service/connect"
+	service5 "github.com/lin-snow/ech0/internal/service/copilot"
+	service12 "github.com/lin-snow/ech0/internal/service/dashboard"
+	service4 "github.com/lin-snow/ech0/internal/service/echo"
 	"github.com/lin-snow/ech0/internal/service/embedding"
-	service2 "github.com/lin-snow/ech0/internal/service/file"
-	service8 "github.com/lin-snow/ech0/internal/service/init"
-	service10 "github.com/lin-snow/ech0/internal/service/migrator"
-	service7 "github.com/lin-snow/ech0/internal/service/setting"
-	service3 "github.com/lin-snow/ech0/internal/service/user"
+	service3 "github.com/lin-snow/ech0/internal/service/file"
+	service9 "github.com/lin-snow/ech0/internal/service/init"
+	service11 "github.com/lin-snow/ech0/internal/service/migrator"
+	service13 "github.com/lin-snow/ech0/internal/service/search"
+	service8 "github.com/lin-snow/ech0/internal/service/setting"
+	service6 "github.com/lin-snow/ech0/internal/service/user"
 	"github.com/lin-snow/ech0/internal/storage"
 	"github.com/lin-snow/ech0/internal/task"
 	"github.com/lin-snow/ech0/internal/task/scheduled"
 	"github.com/lin-snow/ech0/internal/transaction"
 	"github.com/lin-snow/ech0/internal/visitor"
 	"github.com/lin-snow/ech0/internal/webhook"
+	"github.com/lin-snow/ech0/internal/webmention"
 	"github.com/lin-snow/ech0/pkg/busen"
 	"gorm.io/gorm"
 )
@@ -86,10 +93,7 @@ func BuildApp() (*app.App, error) {
 		return nil, err
 	}
 	gormTransactor := transaction.NewGormTransactor(v)
-	eventRegistrar, err := BuildEventRegistrar(v, v2, iCache, gormTransactor)
-	if err != nil {
-		return nil, err
-	}
+	notifier := mcp.NewNotifier()
 	keyValueRepository := keyvalue.NewKeyValueRepository(v, iCache)
 	store := ProvideStorageKV(keyValueRepository)
 	manager := storage.ProvideStorageManager(store)
@@ -97,13 +101,17 @@ func BuildApp() (*app.App, error) {
 	if err != nil {
 		return nil, err
 	}
+	eventRegistrar, err := BuildEventRegistrar(v, v2, iCache, gormTransactor, notifier, manager, jobManager)
+	if err != nil {
+		return nil, err
+	}
 	tracker := visitor.NewTracker()
-	taskManager, err := BuildTasker(v, iCache, gormTransactor, v2, tracker, manager)
+	taskManager, err := BuildTasker(v, iCache, gormTransactor, v2, tracker, manager, jobManager)
 	if err != nil {
 		return nil, err
 	}
 	engine := server.ProvideGinEngine()
-	bundle, err := BuildHandlers(v, iCache, gormTransactor, v2, tracker, jobManager, manager)
+	bundle, err := BuildHandlers(v, iCache, gormTransactor, v2, tracker, jobManager, manager, notifier)
 	if err != nil {
 		return nil, err
 	}
@@ -112,12 +120,12 @@ func BuildApp() (*app.App, error) {
 		return nil, err
 	}
 	serverServer := server.ProvideHTTPServer(engine, bundle, deps)
-	v3 := app.ProvideOptions(eventRegistrar, jobManager, taskManager, serverServer, store)
+	v3 := app.ProvideOptions(eventRegistrar, jobManager, taskManager, serverServer, store, notifier)
 	appApp := app.NewApp(v3)
 	return appApp, nil
 }
 
-func BuildEventRegistrar(dbProvider func() *gorm.DB, ebProvider func() *busen.Bus, appCache cache.ICache[string, any], tx transaction.Transactor) (*bus.EventRegistrar, error) {
+func BuildEventRegistrar(dbProvider func() *gorm.DB, ebProvider func() *busen.Bus, appCache cache.ICache[string, any], tx transaction.Transactor, notifier *mcp.Notifier, storageManager *storage.Manager, jobManager *job.Manager) (*bus.EventRegistrar, error) {
 	keyValueRepository := keyvalue.NewKeyValueRepository(dbProvider, appCache)
 	persistent := kvstore.NewPersistent(keyValueRepository)
 	agentProcessor := subscriber.NewAgentProcessor(persistent)
@@ -125,60 +133,81 @@ func BuildEventRegistrar(dbProvider func() *gorm.DB, ebProvider func() *busen.Bu
 	echoRepository := repository2.NewEchoRepository(dbProvider, appCache)
 	embeddingService := service.NewEmbeddingService(embeddingRepository, persistent, echoRepository)
 	embeddingProcessor := subscriber.NewEmbeddingProcessor(embeddingService)
-	webhookRepository := repository3.NewWebhookRepository(dbProvider)
+	commonRepository := repository3.NewCommonRepository(dbProvider)
+	commonService := service2.NewCommonService(commonRepository, appCache)
+	fileRepository := repository4.NewFileRepository(dbProvider)
+	fileService := service3.NewFileService(tx, commonRepository, fileRepository, storageManager, ebProvider, persistent)
+	echoService := service4.NewEchoService(tx, commonService, fileService, echoRepository, ebProvider)
+	suggester := service5.NewSuggester(echoService, fileService, persistent, storageManager)
+	suggestionProcessor := subscriber.NewSuggestionProcessor(suggester)
+	webhookRepository := repository5.NewWebhookRepository(dbProvider)
 	dispatcher := webhook.NewDispatcher(webhookRepository)
-	v := ProvideSubscriptionProviders(agentProcessor, embeddingProcessor, dispatcher)
+	notifyRepository := repository6.NewNotifyRepository(dbProvider)
+	notifyDispatcher := notify.NewDispatcher(notifyRepository, persistent)
+	webmentionDispatcher := webmention.NewDispatcher(persistent, jobManager)
+	v := ProvideSubscriptionProviders(agentProcessor, embeddingProcessor, suggestionProcessor, dispatcher, notifyDispatcher, webmentionDispatcher, notifier)
 	eventRegistrar := bus.NewEventRegistry(ebProvider, v)
 	return eventRegistrar, nil
 }
 
 // BuildHandlers 使用 wire 生成的代码来构建 Handlers 实例。
-// tracker 由顶层 BuildApp/BuildServer 注入,保证整个进程只有一个 visitor.Tracker 实例。
-func BuildHandlers(dbProvider func() *gorm.DB, appCache cache.ICache[string, any], tx transaction.Transactor, ebProvider func() *busen.Bus, tracker *visitor.Tracker, jobManager *job.Manager, storageManager *storage.Manager) (*handler.Bundle, error) {
+// tracker 由顶层 BuildApp/BuildServer 注入,保证整个进程只有一个 visitor.Tracker 实例；notifier 同理。
+func BuildHandlers(dbProvider func() *gorm.DB, appCache cache.ICache[string, any], tx transaction.Transactor, ebProvider func() *busen.Bus, tracker *visitor.Tracker, jobManager *job.Manager, storageManager *storage.Manager, notifier *mcp.Notifier) (*handler.Bundle, error) {
 	webHandler := handler2.NewWebHandler(tracker)
-	userRepository := repository4.NewUserRepository(dbProvider, appCache)
+	userRepository := repository7.NewUserRepository(dbProvider, appCache)
 	keyValueRepository := keyvalue.NewKeyValueRepository(dbProvider, appCache)
 	persistent := kvstore.NewPersistent(keyValueRepository)
-	commonRepository := repository5.NewCommonRepository(dbProvider)
-	fileRepository := repository6.NewFileRepository(dbProvider)
-	fileService := service2.NewFileService(tx, commonRepository, fileRepository, storageManager, ebProvider)
-	userService := service3.NewUserService(tx, userRepository, persistent, fileService, ebProvider)
+	commonRepository := repository3.NewCommonRepository(dbProvider)
+	fileRepository := repository4.NewFileRepository(dbProvider)
+	fileService := service3.NewFileService(tx, commonRepository, fileRepository, storageManager, ebProvider, persistent)
+	userService := service6.NewUserService(tx, userRepository, persistent, fileService, ebProvider)
 	userHandler := handler3.NewUserHandler(userService)
-	authRepository := repository7.NewAuthRepository(dbProvider, appCache)
-	authService := auth.NewAuthService(tx, authRepository, authRepository, persistent)
+	authRepository := repository8.NewAuthRepository(dbProvider, appCache)
+	authService := auth.NewAuthService(tx, authRepository, authRepository, persistent, ebProvider)
 	authHandler := handler4.NewAuthHandler(authService, userService)
-	commonService := service4.NewCommonService(commonRepository, appCache)
+	commonService := service2.NewCommonService(commonRepository, appCache)
 	echoRepository := repository2.NewEchoRepository(dbProvider, appCache)
-	echoService := service5.NewEchoService(tx, commonService, fileService, echoRepository, ebProvider)
+	echoService := service4.NewEchoService(tx, commonService, fileService, echoRepository, ebProvider)
 	echoHandler := handler5.NewEchoHandler(echoService)
-	fileHandler := handler6.NewFileHandler(fileService)
-	commentRepository := repository8.NewCommentRepository(dbProvider)
-	goMailSender := service6.NewGoMailSender()
-	commentService := service6.NewCommentService(commonService, commentRepository, persistent, ebProvider, goMailSender)
+	fileHandler := handler6.NewFileHandler(fileService, jobManager)
+	commentRepository := repository9.NewCommentRepository(dbProvider)
+	goMailSender := service7.NewGoMailSender()
+	commentService := service7.NewCommentService(commonService, commentRepository, persistent, ebProvider, goMailSender)
 	commentHandler := handler7.NewCommentHandler(commentService)
-	initRepository := repository9.NewInitRepository(dbProvider)
-	settingRepository := repository10.NewSettingRepository(dbProvider)
-	webhookRepository := repository3.NewWebhookRepository(dbProvider)
+	initRepository := repository10.NewInitRepository(dbProvider)
+	settingRepository := repository11.NewSettingRepository(dbProvider)
+	webhookRepository := repository5.NewWebhookRepository(dbProvider)
 	sender := webhook.NewSender()
-	settingService := service7.NewSettingService(tx, commonService, fileService, storageManager, persistent, settingRepository, webhookRepository, sender, authRepository, ebProvider)
-	initService := service8.NewInitService(initRepository, userService, settingService)
+	notifyRepository := repository6.NewNotifyRepository(dbProvider)
+	notifySender := notify.NewSender(notifyRepository)
+	settingService := service8.NewSettingService(tx, commonService, fileService, storageManager, persistent, settingRepository, webhookRepository, sender, notifyRepository, notifySender, authRepository, ebProvider)
+	initService := service9.NewInitService(initRepository, userService, settingService)
 	initHandler := handler8.NewInitHandler(initService)
 	commonHandler := handler9.NewCommonHandler(commonService)
 	settingHandler := handler10.NewSettingHandler(settingService)
-	connectRepository := repository11.NewConnectRepository(dbProvider)
-	connectService := service9.NewConnectService(tx, connectRepository, echoRepository, commonService, persistent)
+	connectRepository := repository12.NewConnectRepository(dbProvider)
+	connectService := service10.NewConnectService(tx, connectRepository, echoRepository, commonService, persistent)
 	connectHandler := handler11.NewConnectHandler(connectService)
-	migratorService := service10.NewMigratorService(commonService, jobManager, ebProvider)
+	db := ProvideGormDB(dbProvider)
+	capsuleEngine := migrator.NewCapsuleEngine(db, storageManager, persistent, tx)
+	migratorService := service11.NewMigratorService(commonService, jobManager, ebProvider, capsuleEngine, persistent)
 	migrationHandler := handler12.NewMigrationHandler(migratorService)
-	dashboardService := service11.NewDashboardService(tracker)
-	dashboardHandler := handler13.NewDashboardHandler(dashboardService)
+	dashboardService := service12.NewDashboardService(tracker)
 	embeddingRepository := repository.NewEmbeddingRepository(dbProvider)
 	embeddingService := service.NewEmbeddingService(embeddingRepository, persistent, echoRepository)
-	copilotService := service12.NewCopilotService(echoService, embeddingService, userService, persistent, storageManager)
-	copilotHandler := handler14.NewCopilotHandler(copilotService, copilotService)
+	copilotService := service5.NewCopilotService(echoService, embeddingService, userService, persistent, storageManager, commentService)
+	digester := service12.NewDigester(persistent, ebProvider, commonService, commentService, echoService, settingService, connectService, copilotService, tracker)
+	dashboardHandler := handler13.NewDashboardHandler(dashboardService, digester)
+	suggester := service5.NewSuggester(echoService, fileService, persistent, storageManager)
+	copilotHandler := handler14.NewCopilotHandler(copilotService, copilotService, suggester)
 	embeddingHandler := handler15.NewEmbeddingHandler(jobManager)
-	mcpHandler := mcp.NewHandler(echoService, userService, commentService, fileService, commonService, connectService, copilotService, settingService, dashboardService)
-	bundle := handler.NewBundle(webHandler, userHandler, authHandler, echoHandler, fileHandler, commentHandler, initHandler, commonHandler, settingHandler, connectHandler, migrationHandler, dashboardHandler, copilotHandler, embeddingHandler, mcpHandler)
+	searchService := service13.NewSearchService(echoService, embeddingService)
+	searchHandler := handler16.NewSearchHandler(searchService)
+	jobHandler := handler17.NewJobHandler(jobManager)
+	mcpHandler := mcp.NewHandler(echoService, userService, commentService, fileService, commonService, connectService, copilotService, settingService, dashboardService, embeddingService, notifier)
+	micropubHandler := micropub.NewHandler(echoService, fileService, persistent)
+	webmentionHandler := webmention.NewHandler(commentService, echoService, persistent)
+	bundle := handler.NewBundle(webHandler, userHandler, authHandler, echoHandler, fileHandler, commentHandler, initHandler, commonHandler, settingHandler, connectHandler, migrationHandler, dashboardHandler, copilotHandler, embeddingHandler, searchHandler, jobHandler, mcpHandler, micropubHandler, webmentionHandler)
 	return bundle, nil
 }
 
@@ -187,7 +216,7 @@ func BuildHandlers(dbProvider func() *gorm.DB, appCache cache.ICache[string, any
 // 含 *job.Manager，故无构造环。storageManager 由顶层共享单例注入，确保迁移导入 S3
 // 设置时 reload 的就是文件服务在用的那份 Manager。
 func BuildJobManager(dbProvider func() *gorm.DB, appCache cache.ICache[string, any], storageManager *storage.Manager, ebProvider func() *busen.Bus, tx transaction.Transactor) (*job.Manager, error) {
-	jobRepository := repository12.NewJobRepository(dbProvider)
+	jobRepository := repository13.NewJobRepository(dbProvider)
 	embeddingRepository := repository.NewEmbeddingRepository(dbProvider)
 	keyValueRepository := keyvalue.NewKeyValueRepository(dbProvider, appCache)
 	persistent := kvstore.NewPersistent(keyValueRepository)
@@ -198,15 +227,24 @@ func BuildJobManager(dbProvider func() *gorm.DB, appCache cache.ICache[string, a
 	db := ProvideGormDB(dbProvider)
 	capsuleEngine := migrator.NewCapsuleEngine(db, storageManager, persistent, tx)
 	migrationRunner := runner.NewMigrationRunner(importEngine, capsuleEngine)
-	exportEngine := migrator.NewExportEngine(storageManager)
+	exportEngine := migrator.NewExportEngine(storageManager, persistent)
 	exportRunner := runner.NewExportRunner(exportEngine, capsuleEngine, ebProvider)
-	manager := ProvideJobManager(jobRepository, reindexRunner, migrationRunner, exportRunner)
+	syncRunner := runner.NewSyncRunner(capsuleEngine)
+	publishRunner := runner.NewPublishRunner(capsuleEngine)
+	modelPullRunner := runner.NewModelPullRunner()
+	commonRepository := repository3.NewCommonRepository(dbProvider)
+	fileRepository := repository4.NewFileRepository(dbProvider)
+	fileService := service3.NewFileService(tx, commonRepository, fileRepository, storageManager, ebProvider, persistent)
+	storageMigrationRunner := runner.NewStorageMigrationRunner(fileService)
+	fileDedupeRunner := runner.NewFileDedupeRunner(fileService)
+	webmentionRunner := runner.NewWebmentionRunner()
+	manager := ProvideJobManager(jobRepository, reindexRunner, migrationRunner, exportRunner, syncRunner, publishRunner, modelPullRunner, storageMigrationRunner, fileDedupeRunner, webmentionRunner)
 	return manager, nil
 }
 
 // BuildMiddlewares 构建中间件依赖。
 func BuildMiddlewares(dbProvider func() *gorm.DB, appCache cache.ICache[string, any]) (*middleware.Deps, error) {
-	authRepository := repository7.NewAuthRepository(dbProvider, appCache)
+	authRepository := repository8.NewAuthRepository(dbProvider, appCache)
 	deps := middleware.NewDeps(authRepository)
 	return deps, nil
 }
@@ -229,7 +267,8 @@ func BuildServer() (*server.Server, error) {
 	if err != nil {
 		return nil, err
 	}
-	bundle, err := BuildHandlers(v, iCache, gormTransactor, v2, tracker, jobManager, manager)
+	notifier := mcp.NewNotifier()
+	bundle, err := BuildHandlers(v, iCache, gormTransactor, v2, tracker, jobManager, manager, notifier)
 	if err != nil {
 		return nil, err
 	}
@@ -241,18 +280,75 @@ func BuildServer() (*server.Server, error) {
 	return serverServer, nil
 }
 
-func BuildTasker(dbProvider func() *gorm.DB, appCache cache.ICache[string, any], tx transaction.Transactor, ebProvider func() *busen.Bus, tracker *visitor.Tracker, storageManager *storage.Manager) (*task.Manager, error) {
-	commonRepository := repository5.NewCommonRepository(dbProvider)
-	fileRepository := repository6.NewFileRepository(dbProvider)
-	fileService := service2.NewFileService(tx, commonRepository, fileRepository, storageManager, ebProvider)
-	cleanup := scheduled.NewCleanup(fileService)
+// BuildMCPRuntime 构建 `ech0 mcp` 本地模式的运行时。与 BuildServer 共用同一组顶层单例，
+// 保证 MCP Handler 与事件注册器看到的是同一个 Notifier / storage.Manager。
+func BuildMCPRuntime() (*MCPRuntime, error) {
+	v := database.ProvideDBProvider()
+	iCache, err := cache.ProvideCache()
+	if err != nil {
+		return nil, err
+	}
+	gormTransactor := transaction.NewGormTransactor(v)
+	v2 := bus.ProvideProvider()
+	tracker := visitor.NewTracker()
+	keyValueRepository := keyvalue.NewKeyValueRepository(v, iCache)
+	store := ProvideStorageKV(keyValueRepository)
+	manager := storage.ProvideStorageManager(store)
+	jobManager, err := BuildJobManager(v, iCache, manager, v2, gormTransactor)
+	if err != nil {
+		return nil, err
+	}
+	notifier := mcp.NewNotifier()
+	bundle, err := BuildHandlers(v, iCache, gormTransactor, v2, tracker, jobManager, manager, notifier)
+	if err != nil {
+		return nil, err
+	}
+	eventRegistrar, err := BuildEventRegistrar(v, v2, iCache, gormTransactor, notifier, manager, jobManager)
+	if err != nil {
+		return nil, err
+	}
+	userRepository := repository7.NewUserRepository(v, iCache)
+	mcpRuntime := ProvideMCPRuntime(bundle, notifier, eventRegistrar, userRepository)
+	return mcpRuntime, nil
+}
+
+func BuildTasker(dbProvider func() *gorm.DB, appCache cache.ICache[string, any], tx transaction.Transactor, ebProvider func() *busen.Bus, tracker *visitor.Tracker, storageManager *storage.Manager, jobManager *job.Manager) (*task.Manager, error) {
+	commonRepository := repository3.NewCommonRepository(dbProvider)
+	fileRepository := repository4.NewFileRepository(dbProvider)
 	keyValueRepository := keyvalue.NewKeyValueRepository(dbProvider, appCache)
 	persistent := kvstore.NewPersistent(keyValueRepository)
-	exportEngine := migrator.NewExportEngine(storageManager)
+	fileService := service3.NewFileService(tx, commonRepository, fileRepository, storageManager, ebProvider, persistent)
+	cleanup := scheduled.NewCleanup(fileService)
+	exportEngine := migrator.NewExportEngine(storageManager, persistent)
 	snapshot := scheduled.NewSnapshot(persistent, exportEngine, ebProvider)
-	visitorRepository := repository13.NewVisitorRepository(dbProvider)
+	visitorRepository := repository14.NewVisitorRepository(dbProvider)
 	visitorSnapshot := scheduled.NewVisitorSnapshot(tracker, visitorRepository)
-	manager, err := ProvideTaskManager(cleanup, snapshot, visitorSnapshot)
+	sync := scheduled.NewSync(persistent, jobManager)
+	publish := scheduled.NewPublish(persistent, jobManager, ebProvider)
+	quotaRecount := scheduled.NewQuotaRecount(fileService)
+	commonService := service2.NewCommonService(commonRepository, appCache)
+	commentRepository := repository9.NewCommentRepository(dbProvider)
+	goMailSender := service7.NewGoMailSender()
+	commentService := service7.NewCommentService(commonService, commentRepository, persistent, ebProvider, goMailSender)
+	echoRepository := repository2.NewEchoRepository(dbProvider, appCache)
+	echoService := service4.NewEchoService(tx, commonService, fileService, echoRepository, ebProvider)
+	settingRepository := repository11.NewSettingRepository(dbProvider)
+	webhookRepository := repository5.NewWebhookRepository(dbProvider)
+	sender := webhook.NewSender()
+	notifyRepository := repository6.NewNotifyRepository(dbProvider)
+	notifySender := notify.NewSender(notifyRepository)
+	authRepository := repository8.NewAuthRepository(dbProvider, appCache)
+	settingService := service8.NewSettingService(tx, commonService, fileService, storageManager, persistent, settingRepository, webhookRepository, sender, notifyRepository, notifySender, authRepository, ebProvider)
+	connectRepository := repository12.NewConnectRepository(dbProvider)
+	connectService := service10.NewConnectService(tx, connectRepository, echoRepository, commonService, persistent)
+	embeddingRepository := repository.NewEmbeddingRepository(dbProvider)
+	embeddingService := service.NewEmbeddingService(embeddingRepository, persistent, echoRepository)
+	userRepository := repository7.NewUserRepository(dbProvider, appCache)
+	userService := service6.NewUserService(tx, userRepository, persistent, fileService, ebProvider)
+	copilotService := service5.NewCopilotService(echoService, embeddingService, userService, persistent, storageManager, commentService)
+	digester := service12.NewDigester(persistent, ebProvider, commonService, commentService, echoService, settingService, connectService, copilotService, tracker)
+	digest := scheduled.NewDigest(persistent, digester, ebProvider)
+	manager, err := ProvideTaskManager(cleanup, snapshot, visitorSnapshot, sync, publish, quotaRecount, digest)
 	if err != nil {
 		return nil, err
 	}
@@ -268,6 +364,10 @@ var AppSet = app.ProviderSet
 // 顶层引入一次,统一下沉给 BuildHandlers 和 BuildTasker。
 var VisitorSet = wire.NewSet(visitor.NewTracker)
 
+// MCPNotifierSet 同 VisitorSet：*mcp.Notifier 须全进程一个实例——BuildEventRegistrar 把事件喂给它，
+// BuildHandlers 里的 MCP Server 从它读订阅推送，两边各建一份就会「事件进了 #1、订阅流挂在 #2」。
+var MCPNotifierSet = wire.NewSet(mcp.NewNotifier)
+
 // ProvideJobManager 构造已装配好 Runner 的共享单例 *job.Manager（在构造期一次性
 // 完成注册）。Runner 只依赖 EmbeddingService / migrator.ImportEngine（均不含 *job.Manager），
 // 故不会与「MigratorService 需要 Manager」形成构造环。
@@ -276,11 +376,27 @@ func ProvideJobManager(
 	reindex *runner.ReindexRunner,
 	migration *runner.MigrationRunner,
 	export *runner.ExportRunner,
+	sync *runner.SyncRunner,
+	publish *runner.PublishRunner,
+	modelPull *runner.ModelPullRunner,
+	storageMigration *runner.StorageMigrationRunner,
+	fileDedupe *runner.FileDedupeRunner, webmention2 *runner.WebmentionRunner,
 ) *job.Manager {
 	m := job.NewManager(repo)
-	m.Register(model.TypeReindex, job.Adapt(reindex.Run))
+
+	m.Register(model.TypeReindex, job.Adapt(reindex.Run), job.Resumable())
 	m.Register(model.TypeMigration, job.Adapt(migration.Run))
-	m.Register(model.TypeExport, job.Adapt(export.Run))
+	m.Register(model.TypeExport, job.Adapt(export.Run), job.WithQueue(3), job.Resumable())
+	m.Register(model.TypeSync, job.Adapt(sync.Run), job.Resumable())
+	m.Register(model.TypePublish, job.Adapt(publish.Run), job.WithQueue(1), job.Resumable())
+
+	m.Register(model.TypeModelPull, job.Adapt(modelPull.Run), job.WithQueue(3), job.Resumable())
+
+	m.Register(model.TypeStorageMigration, job.Adapt(storageMigration.Run), job.Resumable())
+
+	m.Register(model.TypeFileDedupe, job.Adapt(fileDedupe.Run), job.Resumable())
+
+	m.Register(model.TypeWebmention, job.Adapt(webmention2.Run), job.WithQueue(100), job.Resumable())
 	return m
 }
 
@@ -290,8 +406,12 @@ func ProvideTaskManager(
 	cleanup *scheduled.Cleanup,
 	snapshot *scheduled.Snapshot,
 	visitorSnapshot *scheduled.VisitorSnapshot,
+	sync *scheduled.Sync,
+	publish *scheduled.Publish,
+	quotaRecount *scheduled.QuotaRecount,
+	digest *scheduled.Digest,
 ) (*task.Manager, error) {
-	return task.NewManager(cleanup, snapshot, visitorSnapshot)
+	return task.NewManager(cleanup, snapshot, visitorSnapshot, sync, publish, quotaRecount, digest)
 }
 
 // StorageSet 提供进程级共享单例 *storage.Manager。storage.Manager 是有状态基础设施
@@ -327,18 +447,48 @@ var InfraSet = wire.NewSet(database.ProviderSet, bus.ProvideProvider, cache.Prov
 
 var RuntimeSet = server.ProviderSet
 
-var EventSet = wire.NewSet(repository14.EchoSet, repository14.UserSet, repository14.KeyValueSet, repository14.WebhookSet, repository14.EmbeddingSet, webhook.NewDispatcher, subscriber.NewAgentProcessor, subscriber.NewEmbeddingProcessor, service13.EmbeddingSet, ProvideSubscriptionProviders, bus.NewEventRegistry)
+var EventSet = wire.NewSet(repository15.EchoSet, repository15.UserSet, repository15.KeyValueSet, repository15.WebhookSet, repository15.NotifySet, repository15.EmbeddingSet, webhook.NewDispatcher, notify.NewDispatcher, webmention.NewDispatcher, subscriber.NewAgentProcessor, subscriber.NewEmbeddingProcessor, service14.EmbeddingSet, repository15.CommonSet, repository15.FileSet, service14.CommonSet, service14.FileSet, service14.EchoSet, service14.SuggestSet, wire.Bind(new(service5.AltTextWriter), new(*service3.FileService)), subscriber.NewSuggestionProcessor, ProvideSubscriptionProviders, bus.NewEventRegistry)
+
+var HandlerSet = wire.NewSet(repository15.FileSet, handler.WebSet, repository15.UserSet, repository15.AuthSet, service14.UserSet, service14.AuthSet, handler.UserSet, handler.AuthSet, repository15.EchoSet, service14.EchoSet, handler.EchoSet, repository15.CommentSet, service14.CommentSet, handler.CommentSet, repository15.CommonSet, service14.FileSet, handler.FileSet, repository15.InitSet, service14.InitSet, handler.InitSet, service14.CommonSet, handler.CommonSet, repository15.WebhookSet, webhook.NewSender, repository15.NotifySet, notify.NewSender, repository15.KeyValueSet, repository15.SettingSet, service14.SettingSet, handler.SettingSet, repository15.ConnectSet, service14.ConnectSet, handler.ConnectSet, service14.DashboardSet, service14.DigestSet, handler.DashboardSet, repository15.EmbeddingSet, service14.EmbeddingSet, handler.EmbeddingSet, service14.SearchSet, handler.SearchSet, service14.CopilotSet, wire.Bind(new(service5.UserReader), new(*service6.UserService)), service14.SuggestSet, wire.Bind(new(service5.AltTextWriter), new(*service3.FileService)), handler.CopilotSet, ProvideGormDB, migrator.NewCapsuleEngine, wire.Bind(new(service11.SyncEngine), new(*migrator.CapsuleEngine)), service14.MigratorSet, handler.MigrationSet, handler.JobSet, handler.MCPSet, handler.MicropubSet, handler.WebmentionSet, handler.NewBundle)
+
+var MiddlewareSet = wire.NewSet(repository15.AuthSet, middleware.ProviderSet)
 
-var HandlerSet = wire.NewSet(repository14.FileSet, handler.WebSet, repository14.UserSet, repository14.AuthSet, service13.UserSet, service13.AuthSet, handler.UserSet, handler.AuthSet, repository14.EchoSet, service13.EchoSet, handler.EchoSet, repository14.CommentSet, service13.CommentSet, handler.CommentSet, repository14.CommonSet, service13.FileSet, handler.FileSet, repository14.InitSet, service13.InitSet, handler.InitSet, service13.CommonSet, handler.CommonSet, repository14.WebhookSet, webhook.NewSender, repository14.KeyValueSet, repository14.SettingSet, service13.SettingSet, handler.SettingSet, repository14.ConnectSet, service13.ConnectSet, handler.ConnectSet, service13.DashboardSet, handler.DashboardSet, repository14.EmbeddingSet, service13.EmbeddingSet, handler.EmbeddingSet, service13.CopilotSet, wire.Bind(new(service12.UserReader), new(*service3.UserService)), handler.CopilotSet, service13.MigratorSet, handler.MigrationSet, handler.MCPSet, handler.NewBundle)
+var TaskerSet = wire.NewSet(repository15.FileSet, repository15.KeyValueSet, repository15.WebhookSet, webhook.NewSender, repository15.NotifySet, notify.NewSender, repository15.AuthSet, repository15.SettingSet, service14.SettingSet, repository15.EchoSet, service14.EchoSet, repository15.CommonSet, service14.FileSet, service14.CommonSet, repository15.VisitorSet, repository15.CommentSet, service14.CommentSet, repository15.ConnectSet, service14.ConnectSet, repository15.UserSet, service14.UserSet, repository15.EmbeddingSet, service14.EmbeddingSet, service14.CopilotSet, wire.Bind(new(service5.UserReader), new(*service6.UserService)), service14.DigestSet, migrator.NewExportEngine, scheduled.ProviderSet, ProvideTaskManager)
 
-var MiddlewareSet = wire.NewSet(repository14.AuthSet, middleware.ProviderSet)
+// MCPRuntime 是 `ech0 mcp` 本地模式的运行时：直连本地库装配出与 /mcp 同一个 MCP Handler，
+// 外加事件注册器（让 MCP 写操作照常触发 webhook / 嵌入 / 订阅推送）与用户仓储（定位会话身份）。
+// 它刻意不含 HTTP server、作业管理器的启动与定时任务——stdio 进程由宿主按需拉起，
+// 生命周期跟着一次会话走。
+type MCPRuntime struct {
+	Handler   *mcp.Handler
+	Notifier  *mcp.Notifier
+	Registrar *bus.EventRegistrar
+	Users     service6.Repository
+}
 
-var TaskerSet = wire.NewSet(repository14.FileSet, repository14.KeyValueSet, repository14.WebhookSet, repository14.AuthSet, repository14.SettingSet, service13.SettingSet, repository14.EchoSet, service13.EchoSet, repository14.CommonSet, service13.FileSet, service13.CommonSet, repository14.VisitorSet, migrator.NewExportEngine, scheduled.ProviderSet, ProvideTaskManager)
+// ProvideMCPRuntime 从 Handler 聚合里取出 MCP Handler，与其余件收口成 MCPRuntime。
+func ProvideMCPRuntime(
+	bundle *handler.Bundle,
+	notifier *mcp.Notifier,
+	registrar *bus.EventRegistrar,
+	users service6.Repository,
+) *MCPRuntime {
+	return &MCPRuntime{
+		Handler:   bundle.MCPHandler,
+		Notifier:  notifier,
+		Registrar: registrar,
+		Users:     users,
+	}
+}
 
 func ProvideSubscriptionProviders(
 	ap *subscriber.AgentProcessor,
 	ep *subscriber.EmbeddingProcessor,
+	sp *subscriber.SuggestionProcessor,
 	disp *webhook.Dispatcher,
+	notifyDisp *notify.Dispatcher,
+	webmentionDisp *webmention.Dispatcher,
+	notifier *mcp.Notifier,
 ) []bus.Subscriber {
-	return []bus.Subscriber{ap, ep, disp}
+	return []bus.Subscriber{ap, ep, sp, disp, notifyDisp, webmentionDisp, notifier}
 }
@@ -1,7 +1,8 @@
 // SPDX-License-Identifier: AGPL-3.0-or-later
 // Copyright (C) 2025-2026 lin-snow
 
-// Package embedding 封装向量 Embedding 的外部 API 调用（OpenAI 兼容 /v1/embeddings）。
+// Package embedding 封装向量 Embedding 的提供商调用：OpenAI 兼容 /v1/embeddings、
+// Ollama 原生 /api/embed、llama.cpp 原生 /embedding，以及零外部依赖的进程内哈希向量。
 // 与 internal/agent 平级：agent 负责文本生成，embedding 负责向量化。
 package embedding
 
@@ -12,6 +13,7 @@ import (
 	"strings"
 
 	settingModel "github.com/lin-snow/ech0/internal/model/setting"
+	"github.com/lin-snow/ech0/internal/ollama"
 	openai "github.com/sashabaranov/go-openai"
 )
 
@@ -29,9 +31,30 @@ var (
 // OpenAI 等可承受更多，但 64 作为保守默认对所有人都安全，用户可在设置里调大/调小。
 const defaultBatchSize = 64
 
-// Embed 批量生成文本向量（OpenAI 兼容 /v1/embeddings）。
+// backend 是某个提供商的一次批量向量化调用，返回与 batch 一一对应的向量与本批消耗的
+// token 数（提供商不回报时为 0）。实例按一次 Embed 调用构造，可在批次间保存状态。
+type backend interface {
+	embed(ctx context.Context, batch []string) ([][]float32, int, error)
+}
+
+// backendFor 按 setting.Provider 选择实现；空串与未知取值按 OpenAI 兼容处理（旧配置无此字段）。
+func backendFor(setting settingModel.EmbeddingSetting) backend {
+	switch setting.Provider {
+	case settingModel.EmbeddingProviderOllama:
+		return &ollamaBackend{client: ollama.New(setting.BaseURL), model: setting.Model, dim: setting.Dim}
+	case settingModel.EmbeddingProviderLlamaCpp:
+		return newLlamaCppBackend(setting)
+	case settingModel.EmbeddingProviderHash:
+		return hashBackend{dim: setting.Dim}
+	default:
+		return newOpenAIBackend(setting)
+	}
+}
+
+// Embed 批量生成文本向量，按 setting.Provider 分派到对应提供商。
 // 返回的切片顺序与 inputs 一一对应；inputs 超过批次上限时自动分多次请求，
 // 避免触发提供商对单次 input 数组条数的限制（如 "input数组最大不得超过64条"）。
+// ctx 经 WithUsageMeter 挂了计量器时，各批次回报的 token 数累计进去。
 func Embed(
 	ctx context.Context,
 	setting settingModel.EmbeddingSetting,
@@ -52,44 +75,21 @@ func Embed(
 		batchSize = defaultBatchSize
 	}
 
-	cfg := openai.DefaultConfig(setting.ApiKey)
-	if setting.BaseURL != "" {
-		// base_url 按字面量透传，由 go-openai 统一拼接 "/embeddings" 后缀
-		// （对齐 OpenAI / go-openai 惯例）。用户应填到 ".../v4"，不要带 /embeddings。
-		cfg.BaseURL = setting.BaseURL
-	}
-	client := openai.NewClientWithConfig(cfg)
-
-	// sendDim 跟踪是否向 API 传 dimensions 参数。初始值为用户配置的维度；
-	// 若 provider 不支持该参数（首批请求报错），自动降级为 0（omitempty 省略），
-	// 后续批次复用该结论，不再重试。
-	sendDim := setting.Dim
-
+	be := backendFor(setting)
 	out := make([][]float32, 0, len(inputs))
 	for start := 0; start < len(inputs); start += batchSize {
 		end := min(start+batchSize, len(inputs))
 		batch := inputs[start:end]
 
-		resp, err := client.CreateEmbeddings(ctx, openai.EmbeddingRequest{
-			Model:      openai.EmbeddingModel(setting.Model),
-			Input:      batch,
-			Dimensions: sendDim,
-		})
-		if err != nil && sendDim != 0 && isDimensionsRejected(err) {
-			sendDim = 0
-			resp, err = client.CreateEmbeddings(ctx, openai.EmbeddingRequest{
-				Model: openai.EmbeddingModel(setting.Model),
-				Input: batch,
-			})
-		}
+		vecs, tokens, err := be.embed(ctx, batch)
 		if err != nil {
 			return nil, err
 		}
-		if len(resp.Data) != len(batch) {
+		if len(vecs) != len(batch) {
 			return nil, ErrEmptyResponse
 		}
-		for i := range resp.Data {
-			vec := resp.Data[i].Embedding
+		RecordUsage(ctx, tokens)
+		for _, vec := range vecs {
 			if setting.Dim > 0 && len(vec) != setting.Dim {
 				return nil, fmt.Errorf(
 					"embedding: 模型 %s 返回维度 %d，与配置维度 %d 不一致，"+
@@ -103,6 +103,64 @@ func Embed(
 	return out, nil
 }
 
+// openAIBackend 走 OpenAI 兼容 /v1/embeddings。
+type openAIBackend struct {
+	client *openai.Client
+	model  string
+	// sendDim 跟踪是否向 API 传 dimensions 参数。初始值为用户配置的维度；
+	// 若 provider 不支持该参数（首批请求报错），自动降级为 0（omitempty 省略），
+	// 后续批次复用该结论，不再重试。
+	sendDim int
+}
+
+func newOpenAIBackend(setting settingModel.EmbeddingSetting) *openAIBackend {
+	cfg := openai.DefaultConfig(setting.ApiKey)
+	if setting.BaseURL != "" {
+		// base_url 按字面量透传，由 go-openai 统一拼接 "/embeddings" 后缀
+		// （对齐 OpenAI / go-openai 惯例）。用户应填到 ".../v4"，不要带 /embeddings。
+		cfg.BaseURL = setting.BaseURL
+	}
+	return &openAIBackend{client: openai.NewClientWithConfig(cfg), model: setting.Model, sendDim: setting.Dim}
+}
+
+func (b *openAIBackend) embed(ctx context.Context, batch []string) ([][]float32, int, error) {
+	resp, err := b.client.CreateEmbeddings(ctx, openai.EmbeddingRequest{
+		Model:      openai.EmbeddingModel(b.model),
+		Input:      batch,
+		Dimensions: b.sendDim,
+	})
+	if err != nil && b.sendDim != 0 && isDimensionsRejected(err) {
+		b.sendDim = 0
+		resp, err = b.client.CreateEmbeddings(ctx, openai.EmbeddingRequest{
+			Model: openai.EmbeddingModel(b.model),
+			Input: batch,
+		})
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	vecs := make([][]float32, len(resp.Data))
+	for i := range resp.Data {
+		vecs[i] = resp.Data[i].Embedding
+	}
+	return vecs, resp.Usage.PromptTokens, nil
+}
+
+// ollamaBackend 走 Ollama 原生 /api/embed（服务端按 dimensions 截断，需模型支持）。
+type ollamaBackend struct {
+	client *ollama.Client
+	model  string
+	dim    int
+}
+
+func (b *ollamaBackend) embed(ctx context.Context, batch []string) ([][]float32, int, error) {
+	resp, err := b.client.Embed(ctx, b.model, batch, b.dim)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.Embeddings, resp.PromptEvalCount, nil
+}
+
 // isDimensionsRejected 判断 API 错误是否因 provider 不接受 dimensions 参数导致。
 func isDimensionsRejected(err error) bool {
 	return strings.Contains(strings.ToLower(err.Error()), "dimension")
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package embedding
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cosine(a, b []float32) float64 {
+	var dot float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+	}
+	return dot // HashVector 已归一化
+}
+
+func TestHashVector(t *testing.T) {
+	v := HashVector("手冲咖啡 pour over", 64)
+	require.Len(t, v, 64)
+	assert.Equal(t, v, HashVector("手冲咖啡 pour over", 64), "must be deterministic")
+
+	var norm float64
+	for _, x := range v {
+		norm += float64(x) * float64(x)
+	}
+	assert.InDelta(t, 1, math.Sqrt(norm), 1e-6)
+
+	near := cosine(v, HashVector("咖啡豆 pour", 64))
+	far := cosine(v, HashVector("Go generics iterators", 64))
+	assert.Greater(t, near, far)
+
+	// 纯标点也不得出零向量。
+	assert.NotEqual(t, make([]float32, 8), HashVector("!!!", 8))
+}
+
+// hash 提供商按批次上限分批，向量数与维度与输入对齐，且不计 token。
+func TestEmbed_HashProviderBatches(t *testing.T) {
+	setting := settingModel.EmbeddingSetting{
+		Enable: true, Provider: settingModel.EmbeddingProviderHash, Model: HashModel, Dim: 32, BatchSize: 2,
+	}
+	meter := &UsageMeter{}
+	vecs, err := Embed(WithUsageMeter(context.Background(), meter), setting, []string{"a", "b", "c"})
+
+	require.NoError(t, err)
+	require.Len(t, vecs, 3)
+	assert.Len(t, vecs[2], 32)
+	assert.Zero(t, meter.Tokens())
+}
+
+// Ollama 原生 /api/embed：dimensions 透传，prompt_eval_count 计入用量。
+func TestEmbed_OllamaProvider(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/embed", r.URL.Path)
+		raw, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(raw, &got)
+		_, _ = io.WriteString(w, `{"embeddings":[[1,0],[0,1]],"prompt_eval_count":7}`)
+	}))
+	defer srv.Close()
+
+	setting := settingModel.EmbeddingSetting{
+		Enable: true, Provider: settingModel.EmbeddingProviderOllama, Model: "nomic-embed-text", BaseURL: srv.URL, Dim: 2,
+	}
+	meter := &UsageMeter{}
+	vecs, err := Embed(WithUsageMeter(context.Background(), meter), setting, []string{"x", "y"})
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 0}, {0, 1}}, vecs)
+	assert.Equal(t, int64(7), meter.Tokens())
+	assert.Equal(t, "nomic-embed-text", got["model"])
+	assert.EqualValues(t, 2, got["dimensions"])
+}
+
+// 维度与配置不一致时报错，而不是把错维向量写进 vec0。
+func TestEmbed_DimensionMismatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"embeddings":[[1,0,0]]}`)
+	}))
+	defer srv.Close()
+
+	_, err := Embed(context.Background(), settingModel.EmbeddingSetting{
+		Enable: true, Provider: settingModel.EmbeddingProviderOllama, Model: "m", BaseURL: srv.URL, Dim: 2,
+	}, []string{"x"})
+	assert.ErrorContains(t, err, "维度")
+}
+
+func TestParseLlamaCppEmbeddings(t *testing.T) {
+	cases := []struct {
+		name, body string
+		want       [][]float32
+		wantErr    bool
+	}{
+		{"pooled array, out of order", `[{"index":1,"embedding":[[3,4]]},{"index":0,"embedding":[[1,2]]}]`, [][]float32{{1, 2}, {3, 4}}, false},
+		{"flat array item", `[{"index":0,"embedding":[1,2]}]`, [][]float32{{1, 2}}, false},
+		{"legacy single object", `{"embedding":[5,6]}`, [][]float32{{5, 6}}, false},
+		{"per-token vectors", `[{"index":0,"embedding":[[1,2],[3,4]]}]`, nil, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := parseLlamaCppEmbeddings([]byte(c.body))
+			if c.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestEmbed_LlamaCppProvider(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/embedding", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		_, _ = io.WriteString(w, `[{"index":0,"embedding":[[1,2]]}]`)
+	}))
+	defer srv.Close()
+
+	vec, err := EmbedOne(context.Background(), settingModel.EmbeddingSetting{
+		Enable: true, Provider: settingModel.EmbeddingProviderLlamaCpp, Model: "bge-m3",
+		BaseURL: srv.URL + "/v1", ApiKey: "secret", Dim: 2,
+	}, "x")
+	require.NoError(t, err)
+	assert.Equal(t, []float32{1, 2}, vec)
+}
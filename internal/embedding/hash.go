@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package embedding
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+const (
+	// HashModel 是进程内哈希向量的「模型名」。哈希方案一旦变化必须换名，
+	// 索引状态按模型名判等，换名即触发清库重建。
+	HashModel = "ech0-hash-v1"
+	// DefaultHashDim 是哈希向量未配置维度时的缺省值：几百维对微博客体量已足够稀疏。
+	DefaultHashDim = 256
+)
+
+// hashBackend 是确定性的特征哈希（hashing trick）向量化：把词项哈希进 dim 个桶，
+// 符号位再取一位哈希以抵消碰撞偏置，最后 L2 归一化。没有语义泛化能力，只刻画
+// 词面重合，但零外部依赖、同输入恒得同向量——供测试与不愿接模型服务的小实例使用。
+type hashBackend struct {
+	dim int
+}
+
+func (b hashBackend) embed(_ context.Context, batch []string) ([][]float32, int, error) {
+	dim := b.dim
+	if dim <= 0 {
+		dim = DefaultHashDim
+	}
+	out := make([][]float32, len(batch))
+	for i, text := range batch {
+		out[i] = HashVector(text, dim)
+	}
+	return out, 0, nil
+}
+
+// HashVector 计算 text 的 dim 维哈希向量（已 L2 归一化）。
+func HashVector(text string, dim int) []float32 {
+	counts := make(map[string]int)
+	for _, f := range hashFeatures(text) {
+		counts[f]++
+	}
+	if len(counts) == 0 {
+		// 纯标点 / 空白：退化为整串一个特征，保证不出零向量（余弦距离对零向量无定义）。
+		counts[text]++
+	}
+
+	vec := make([]float64, dim)
+	for f, n := range counts {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(f))
+		sum := h.Sum64()
+		w := 1 + math.Log(float64(n))
+		if sum>>63 == 1 {
+			w = -w
+		}
+		vec[sum%uint64(dim)] += w
+	}
+
+	var norm float64
+	for _, v := range vec {
+		norm += v * v
+	}
+	norm = math.Sqrt(norm)
+	out := make([]float32, dim)
+	for i, v := range vec {
+		if norm > 0 {
+			out[i] = float32(v / norm)
+		}
+	}
+	return out
+}
+
+// hashFeatures 切词：拉丁字母 / 数字连续段按词（小写），中日韩连续段取单字 + 相邻二字，
+// 不依赖分词器也能让「咖啡豆」与「手冲咖啡」在「咖」「啡」「咖啡」上重合。
+func hashFeatures(text string) []string {
+	var (
+		out  []string
+		word []rune
+		cjk  []rune
+	)
+	flushWord := func() {
+		if len(word) > 0 {
+			out = append(out, string(word))
+			word = word[:0]
+		}
+	}
+	flushCJK := func() {
+		for i, r := range cjk {
+			out = append(out, string(r))
+			if i+1 < len(cjk) {
+				out = append(out, string(cjk[i:i+2]))
+			}
+		}
+		cjk = cjk[:0]
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			flushWord()
+			cjk = append(cjk, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+	return out
+}
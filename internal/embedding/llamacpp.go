@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
+	"github.com/lin-snow/ech0/internal/util/egress"
+)
+
+// defaultLlamaCppBaseURL 是 llama-server 的缺省监听地址。
+const defaultLlamaCppBaseURL = "http://localhost:8080"
+
+// llamaCppBackend 走 llama.cpp server 原生 /embedding（需以 --embedding 启动）。
+// 模型在服务端启动时就已加载，请求里不带模型名；setting.Model 仅用于标识索引。
+type llamaCppBackend struct {
+	baseURL string
+	apiKey  string
+	http    *http.Client
+}
+
+func newLlamaCppBackend(setting settingModel.EmbeddingSetting) *llamaCppBackend {
+	base := strings.TrimRight(strings.TrimSpace(setting.BaseURL), "/")
+	if base == "" {
+		base = defaultLlamaCppBaseURL
+	}
+	// llama.cpp 服务通常跑在本机或内网，地址由管理员配置，不启用 SSRF 防护。
+	return &llamaCppBackend{
+		baseURL: strings.TrimSuffix(base, "/v1"),
+		apiKey:  setting.ApiKey,
+		http:    egress.NewClient(),
+	}
+}
+
+func (b *llamaCppBackend) embed(ctx context.Context, batch []string) ([][]float32, int, error) {
+	payload, err := json.Marshal(map[string]any{"content": batch})
+	if err != nil {
+		return nil, 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.baseURL+"/embedding", bytes.NewReader(payload))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if b.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+b.apiKey)
+	}
+
+	resp, err := b.http.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, 0, fmt.Errorf("llama.cpp: /embedding: status %d: %s", resp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+
+	vecs, err := parseLlamaCppEmbeddings(raw)
+	if err != nil {
+		return nil, 0, err
+	}
+	// 原生接口不回报 token 数。
+	return vecs, 0, nil
+}
+
+// parseLlamaCppEmbeddings 兼容 llama-server 历代的两种响应形状：
+//   - 新版：[{"index":0,"embedding":[[...]]}, ...]，embedding 外包一层（池化后只有一行）；
+//   - 旧版：{"embedding":[...]}，仅单条输入。
+//
+// 池化方式为 none 时每个 token 一行向量，无法直接用于检索，报错提示调整服务端参数。
+func parseLlamaCppEmbeddings(raw []byte) ([][]float32, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		var single struct {
+			Embedding json.RawMessage `json:"embedding"`
+		}
+		if err := json.Unmarshal(trimmed, &single); err != nil {
+			return nil, fmt.Errorf("llama.cpp: decode embedding: %w", err)
+		}
+		vec, err := pooledVector(single.Embedding)
+		if err != nil {
+			return nil, err
+		}
+		return [][]float32{vec}, nil
+	}
+
+	var items []struct {
+		Index     int             `json:"index"`
+		Embedding json.RawMessage `json:"embedding"`
+	}
+	if err := json.Unmarshal(trimmed, &items); err != nil {
+		return nil, fmt.Errorf("llama.cpp: decode embeddings: %w", err)
+	}
+	out := make([][]float32, len(items))
+	for _, it := range items {
+		if it.Index < 0 || it.Index >= len(items) {
+			return nil, fmt.Errorf("llama.cpp: embedding index %d out of range", it.Index)
+		}
+		vec, err := pooledVector(it.Embedding)
+		if err != nil {
+			return nil, err
+		}
+		out[it.Index] = vec
+	}
+	return out, nil
+}
+
+// pooledVector 解出一条池化后的向量：扁平数组原样返回，外包一层且仅一行时取该行。
+func pooledVector(raw json.RawMessage) ([]float32, error) {
+	var flat []float32
+	if err := json.Unmarshal(raw, &flat); err == nil {
+		return flat, nil
+	}
+	var nested [][]float32
+	if err := json.Unmarshal(raw, &nested); err != nil {
+		return nil, fmt.Errorf("llama.cpp: decode embedding vector: %w", err)
+	}
+	if len(nested) != 1 {
+		return nil, errors.New("llama.cpp: got per-token embeddings; start llama-server with --pooling mean (or cls/last)")
+	}
+	return nested[0], nil
+}
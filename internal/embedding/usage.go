@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package embedding
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// UsageMeter 累计一段流程（如一次全量回填）里向量化消耗的 token 数。
+// 以 ctx 旁路挂载而不是改 Embed 的返回值：调用链上的 Embedder 接口与替身保持不变，
+// 只有关心用量的调用方才需要挂计量器。并发安全。
+type UsageMeter struct {
+	tokens atomic.Int64
+}
+
+// Tokens 返回目前累计的 token 数。提供商不回报用量时（hash、部分 llama.cpp 版本）恒为 0。
+func (m *UsageMeter) Tokens() int64 {
+	return m.tokens.Load()
+}
+
+func (m *UsageMeter) add(n int) {
+	if n > 0 {
+		m.tokens.Add(int64(n))
+	}
+}
+
+type usageMeterKey struct{}
+
+// WithUsageMeter 返回挂载了 m 的 ctx；经此 ctx 的 Embed / EmbedOne 会把用量累计进 m。
+func WithUsageMeter(ctx context.Context, m *UsageMeter) context.Context {
+	return context.WithValue(ctx, usageMeterKey{}, m)
+}
+
+// RecordUsage 把 n 个 token 记到 ctx 上挂载的计量器；未挂载时什么也不做。
+// 供 Embed 之外的 Embedder 实现（替身、包装器）按同一口径上报。
+func RecordUsage(ctx context.Context, n int) {
+	if m, _ := ctx.Value(usageMeterKey{}).(*UsageMeter); m != nil {
+		m.add(n)
+	}
+}
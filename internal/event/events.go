@@ -11,6 +11,7 @@ import (
 	echoModel "github.com/lin-snow/ech0/internal/model/echo"
 	settingModel "github.com/lin-snow/ech0/internal/model/setting"
 	userModel "github.com/lin-snow/ech0/internal/model/user"
+	webhookModel "github.com/lin-snow/ech0/internal/model/webhook"
 )
 
 // Named 是事件的稳定外部名（用作 webhook 观察的 topic / X-Ech0-Event）。所有事件都实现它。
@@ -52,6 +53,16 @@ type (
 		Key string `json:"-"`
 	}
 
+	// StorageQuotaWarning 表示用户的存储用量越过了配额的 80% 或 100%（Threshold）。
+	// Rejected 为 true 时是一次因超额被拒的上传，此时 Used 仍是上传前的用量。
+	StorageQuotaWarning struct {
+		User      userModel.User
+		Used      int64
+		Quota     int64
+		Threshold int
+		Rejected  bool
+	}
+
 	SystemSnapshot struct {
 		Info string
 		Size int64
@@ -60,10 +71,46 @@ type (
 		Info string
 		Size int64
 	}
+	// SystemSnapshotFailed 表示一次快照失败：定时快照（Source 为 "schedule"）或导出作业（"export"）。
+	SystemSnapshotFailed struct {
+		Source string
+		Error  string
+	}
+
+	// WebhookDisabled 表示一个 Webhook 由启用变为停用。
+	WebhookDisabled struct {
+		Webhook webhookModel.Webhook
+	}
+
+	// UserLoginNewDevice 表示用户从此前未见过的设备登录成功。设备按 User-Agent 识别，
+	// Method 为登录方式（password / passkey / oauth）。
+	UserLoginNewDevice struct {
+		User      userModel.User
+		IP        string
+		UserAgent string
+		Method    string
+	}
 
 	UpdateSnapshotSchedule struct {
 		Schedule settingModel.SnapshotSchedule
 	}
+
+	// UpdateDigestSchedule 表示站长定期摘要的设置已保存，定时任务据此重挂作业。
+	UpdateDigestSchedule struct {
+		Setting settingModel.DigestSetting
+	}
+
+	// FeatureToggled 表示某项可选功能的生效状态翻转（开 ↔ 关），供随功能显隐的观察者
+	// （如 MCP 工具列表）刷新。只在状态真正变化时发布。
+	FeatureToggled struct {
+		Feature string
+		Enabled bool
+	}
+)
+
+// FeatureToggled.Feature 的取值。
+const (
+	FeatureEmbedding = "embedding"
 )
 
 // EventName —— 稳定外部名，必须与历史 topic 字符串逐字一致（webhook 的 topic 字段兼容）。
@@ -77,9 +124,15 @@ func (CommentCreated) EventName() string         { return "comment.created" }
 func (CommentStatusUpdated) EventName() string   { return "comment.status.updated" }
 func (CommentDeleted) EventName() string         { return "comment.deleted" }
 func (ResourceUploaded) EventName() string       { return "resource.uploaded" }
+func (StorageQuotaWarning) EventName() string    { return "resource.quota.warning" }
 func (SystemSnapshot) EventName() string         { return "system.snapshot" }
 func (SystemExport) EventName() string           { return "system.export" }
+func (SystemSnapshotFailed) EventName() string   { return "system.snapshot.failed" }
+func (WebhookDisabled) EventName() string        { return "webhook.disabled" }
+func (UserLoginNewDevice) EventName() string     { return "user.login.new_device" }
 func (UpdateSnapshotSchedule) EventName() string { return "system.snapshot_schedule.updated" }
+func (UpdateDigestSchedule) EventName() string   { return "system.digest_schedule.updated" }
+func (FeatureToggled) EventName() string         { return "system.feature.toggled" }
 
 // OrderingKey —— 局部有序键，仅实现于历史上带 WithKey 的事件。
 func (e UserCreated) OrderingKey() string          { return e.User.ID }
@@ -92,3 +145,4 @@ func (e CommentCreated) OrderingKey() string       { return e.Comment.ID }
 func (e CommentStatusUpdated) OrderingKey() string { return e.Comment.ID }
 func (e CommentDeleted) OrderingKey() string       { return e.Comment.ID }
 func (e ResourceUploaded) OrderingKey() string     { return e.Key }
+func (e StorageQuotaWarning) OrderingKey() string  { return e.User.ID }
@@ -32,13 +32,19 @@ func TestEventName(t *testing.T) {
 		{"CommentStatusUpdated", CommentStatusUpdated{}, "comment.status.updated"},
 		{"CommentDeleted", CommentDeleted{}, "comment.deleted"},
 		{"ResourceUploaded", ResourceUploaded{}, "resource.uploaded"},
+		{"StorageQuotaWarning", StorageQuotaWarning{}, "resource.quota.warning"},
 		{"SystemSnapshot", SystemSnapshot{}, "system.snapshot"},
 		{"SystemExport", SystemExport{}, "system.export"},
+		{"SystemSnapshotFailed", SystemSnapshotFailed{}, "system.snapshot.failed"},
+		{"WebhookDisabled", WebhookDisabled{}, "webhook.disabled"},
+		{"UserLoginNewDevice", UserLoginNewDevice{}, "user.login.new_device"},
 		{"UpdateSnapshotSchedule", UpdateSnapshotSchedule{}, "system.snapshot_schedule.updated"},
+		{"UpdateDigestSchedule", UpdateDigestSchedule{}, "system.digest_schedule.updated"},
+		{"FeatureToggled", FeatureToggled{}, "system.feature.toggled"},
 	}
 
 	// 守卫事件总数：新增/删除事件时此处必须同步更新，避免遗漏 topic 契约锁定。
-	require.Len(t, cases, 13, "expected exactly 13 named events")
+	require.Len(t, cases, 19, "expected exactly 19 named events")
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -60,9 +66,15 @@ func TestEventNamesUnique(t *testing.T) {
 		CommentStatusUpdated{},
 		CommentDeleted{},
 		ResourceUploaded{},
+		StorageQuotaWarning{},
 		SystemSnapshot{},
 		SystemExport{},
+		SystemSnapshotFailed{},
+		WebhookDisabled{},
+		UserLoginNewDevice{},
 		UpdateSnapshotSchedule{},
+		UpdateDigestSchedule{},
+		FeatureToggled{},
 	}
 
 	seen := make(map[string]string, len(names))
@@ -79,7 +91,7 @@ func TestEventNamesUnique(t *testing.T) {
 }
 
 // TestOrderingKey 锁定局部有序键：busen 对 async 订阅者按 per-key 保序。
-// 仅历史上带 WithKey 的 10 个事件实现 Keyed，键值取对应资源 ID。
+// 历史上带 WithKey 的 10 个事件与按用户保序的配额告警实现 Keyed，键值取对应资源 ID。
 func TestOrderingKey(t *testing.T) {
 	const (
 		userID    = "user-key-0001"
@@ -103,10 +115,11 @@ func TestOrderingKey(t *testing.T) {
 		{"CommentStatusUpdated", CommentStatusUpdated{Comment: commentModel.Comment{ID: commentID}}, commentID},
 		{"CommentDeleted", CommentDeleted{Comment: commentModel.Comment{ID: commentID}}, commentID},
 		{"ResourceUploaded", ResourceUploaded{Key: storeKey}, storeKey},
+		{"StorageQuotaWarning", StorageQuotaWarning{User: userModel.User{ID: userID}}, userID},
 	}
 
 	// 守卫 Keyed 事件总数。
-	require.Len(t, cases, 10, "expected exactly 10 keyed events")
+	require.Len(t, cases, 11, "expected exactly 11 keyed events")
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -133,6 +146,8 @@ func TestSystemEventsNotKeyed(t *testing.T) {
 		{"SystemSnapshot", SystemSnapshot{}},
 		{"SystemExport", SystemExport{}},
 		{"UpdateSnapshotSchedule", UpdateSnapshotSchedule{Schedule: settingModel.SnapshotSchedule{}}},
+		{"UpdateDigestSchedule", UpdateDigestSchedule{Setting: settingModel.DigestSetting{}}},
+		{"FeatureToggled", FeatureToggled{Feature: FeatureEmbedding}},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -155,6 +170,7 @@ func TestKeyedEventsAlsoNamed(t *testing.T) {
 		CommentStatusUpdated{},
 		CommentDeleted{},
 		ResourceUploaded{},
+		StorageQuotaWarning{},
 	}
 	for _, k := range keyed {
 		n, ok := k.(Named)
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package subscriber
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/lin-snow/ech0/internal/event"
+	eventbus "github.com/lin-snow/ech0/internal/event/bus"
+	copilotService "github.com/lin-snow/ech0/internal/service/copilot"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+// SuggestionProcessor 订阅 Echo 新建 / 删除事件，驱动 Agent 为新 Echo 建议标签与配图替代文本
+// （AgentSetting.AutoTag / AutoAltText，未开启时为 no-op）。生成失败只记录日志，不影响发布主流程。
+type SuggestionProcessor struct {
+	suggester copilotService.SuggestionService
+}
+
+func NewSuggestionProcessor(suggester copilotService.SuggestionService) *SuggestionProcessor {
+	return &SuggestionProcessor{suggester: suggester}
+}
+
+func (sp *SuggestionProcessor) HandleEchoCreated(ctx context.Context, e event.EchoCreated) error {
+	if err := sp.suggester.SuggestForEcho(ctx, e.Echo, e.User); err != nil {
+		logUtil.GetLogger().Warn("copilot suggestion failed",
+			slog.String("module", "copilot"),
+			slog.String("echo_id", e.Echo.ID),
+			logUtil.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (sp *SuggestionProcessor) HandleEchoDeleted(ctx context.Context, e event.EchoDeleted) error {
+	return sp.suggester.Forget(ctx, e.Echo.ID)
+}
+
+func (sp *SuggestionProcessor) Registrations() []eventbus.Registration {
+	return []eventbus.Registration{
+		eventbus.On(sp.HandleEchoCreated, eventbus.AsyncParallel()...),
+		eventbus.On(sp.HandleEchoDeleted, eventbus.AsyncParallel()...),
+	}
+}
@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package subscriber_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/event"
+	"github.com/lin-snow/ech0/internal/event/subscriber"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/lin-snow/ech0/internal/test/mocks/copilotmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSuggestionProcessor_EchoCreated forwards the new echo and its author to
+// the suggester and surfaces its error (the bus only logs it).
+func TestSuggestionProcessor_EchoCreated(t *testing.T) {
+	t.Run("forwards echo and author", func(t *testing.T) {
+		sug := copilotmock.NewMockSuggestionService(t)
+		e := helpers.NewEcho(func(x *echoModel.Echo) { x.ID = "echo-s1" })
+		u := helpers.NewUser()
+		sug.EXPECT().SuggestForEcho(mock.Anything, e, u).Return(nil).Once()
+
+		sp := subscriber.NewSuggestionProcessor(sug)
+		require.NoError(t, sp.HandleEchoCreated(helpers.CtxAnonymous(), event.EchoCreated{Echo: e, User: u}))
+	})
+
+	t.Run("error surfaces", func(t *testing.T) {
+		sug := copilotmock.NewMockSuggestionService(t)
+		boom := errors.New("llm down")
+		sug.EXPECT().SuggestForEcho(mock.Anything, mock.Anything, mock.Anything).Return(boom).Once()
+
+		sp := subscriber.NewSuggestionProcessor(sug)
+		err := sp.HandleEchoCreated(helpers.CtxAnonymous(), event.EchoCreated{Echo: helpers.NewEcho()})
+		require.ErrorIs(t, err, boom)
+	})
+}
+
+// TestSuggestionProcessor_EchoDeleted drops any pending suggestion for the echo.
+func TestSuggestionProcessor_EchoDeleted(t *testing.T) {
+	sug := copilotmock.NewMockSuggestionService(t)
+	e := helpers.NewEcho(func(x *echoModel.Echo) { x.ID = "echo-d1" })
+	sug.EXPECT().Forget(mock.Anything, "echo-d1").Return(nil).Once()
+
+	sp := subscriber.NewSuggestionProcessor(sug)
+	require.NoError(t, sp.HandleEchoDeleted(helpers.CtxAnonymous(), event.EchoDeleted{Echo: e}))
+	require.Len(t, sp.Registrations(), 2)
+}
@@ -53,7 +53,7 @@ func (f *fakeAuthService) ExchangeOAuthCode(code string) (*authModel.TokenPair,
 	return nil, errors.New("not implemented")
 }
 
-func (f *fakeAuthService) Login(*authModel.LoginDto) (*authModel.TokenPair, error) {
+func (f *fakeAuthService) Login(*authModel.LoginDto, authModel.LoginClient) (*authModel.TokenPair, error) {
 	panic("not called in auth handler tests")
 }
 
@@ -65,7 +65,7 @@ func (f *fakeAuthService) GetOAuthLoginURL(string, string) (string, error) {
 	panic("not called")
 }
 
-func (f *fakeAuthService) HandleOAuthCallback(string, string, string) (string, error) {
+func (f *fakeAuthService) HandleOAuthCallback(string, string, string, authModel.LoginClient) (string, error) {
 	panic("not called")
 }
 
@@ -85,7 +85,9 @@ func (f *fakeAuthService) PasskeyLoginBegin(string, string) (authModel.PasskeyLo
 	panic("not called")
 }
 
-func (f *fakeAuthService) PasskeyLoginFinish(string, string, string, json.RawMessage) (*authModel.TokenPair, error) {
+func (f *fakeAuthService) PasskeyLoginFinish(
+	string, string, string, json.RawMessage, authModel.LoginClient,
+) (*authModel.TokenPair, error) {
 	panic("not called")
 }
 
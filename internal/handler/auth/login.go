@@ -22,7 +22,7 @@ func (h *AuthHandler) Login() gin.HandlerFunc {
 			}
 		}
 
-		tokenPair, err := h.authService.Login(&loginDto)
+		tokenPair, err := h.authService.Login(&loginDto, loginClient(ctx))
 		if err != nil {
 			return res.Response{
 				Msg: "",
@@ -37,3 +37,11 @@ func (h *AuthHandler) Login() gin.HandlerFunc {
 		}
 	})
 }
+
+// loginClient 取出发起登录的客户端信息，供服务层识别新设备。
+func loginClient(ctx *gin.Context) authModel.LoginClient {
+	return authModel.LoginClient{
+		IP:        ctx.ClientIP(),
+		UserAgent: ctx.Request.UserAgent(),
+	}
+}
@@ -75,7 +75,7 @@ func (h *AuthHandler) OAuthCallback() gin.HandlerFunc {
 			return res.Response{Msg: commonModel.INVALID_PARAMS}
 		}
 
-		redirectURL, err := h.authService.HandleOAuthCallback(provider, code, state)
+		redirectURL, err := h.authService.HandleOAuthCallback(provider, code, state, loginClient(ctx))
 		if err != nil || redirectURL == "" {
 			return res.Response{Msg: commonModel.INVALID_PARAMS, Err: err}
 		}
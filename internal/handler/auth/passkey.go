@@ -72,7 +72,7 @@ func (h *AuthHandler) PasskeyLoginFinishV2() gin.HandlerFunc {
 		if origin == "" || rpID == "" {
 			return res.Response{Msg: commonModel.INVALID_PARAMS}
 		}
-		tokenPair, err := h.authService.PasskeyLoginFinish(rpID, origin, req.Nonce, req.Credential)
+		tokenPair, err := h.authService.PasskeyLoginFinish(rpID, origin, req.Nonce, req.Credential, loginClient(ctx))
 		if err != nil {
 			return res.Response{Err: err}
 		}
@@ -14,29 +14,37 @@ import (
 	embeddingHandler "github.com/lin-snow/ech0/internal/handler/embedding"
 	fileHandler "github.com/lin-snow/ech0/internal/handler/file"
 	initHandler "github.com/lin-snow/ech0/internal/handler/init"
+	jobHandler "github.com/lin-snow/ech0/internal/handler/job"
 	migratorHandler "github.com/lin-snow/ech0/internal/handler/migrator"
+	searchHandler "github.com/lin-snow/ech0/internal/handler/search"
 	settingHandler "github.com/lin-snow/ech0/internal/handler/setting"
 	userHandler "github.com/lin-snow/ech0/internal/handler/user"
 	webHandler "github.com/lin-snow/ech0/internal/handler/web"
 	"github.com/lin-snow/ech0/internal/mcp"
+	"github.com/lin-snow/ech0/internal/micropub"
+	"github.com/lin-snow/ech0/internal/webmention"
 )
 
 type Bundle struct {
-	WebHandler       *webHandler.WebHandler
-	UserHandler      *userHandler.UserHandler
-	AuthHandler      *authHandler.AuthHandler
-	EchoHandler      *echoHandler.EchoHandler
-	FileHandler      *fileHandler.FileHandler
-	CommentHandler   *commentHandler.CommentHandler
-	InitHandler      *initHandler.InitHandler
-	CommonHandler    *commonHandler.CommonHandler
-	SettingHandler   *settingHandler.SettingHandler
-	ConnectHandler   *connectHandler.ConnectHandler
-	MigrationHandler *migratorHandler.MigrationHandler
-	DashboardHandler *dashboardHandler.DashboardHandler
-	CopilotHandler   *copilotHandler.CopilotHandler
-	EmbeddingHandler *embeddingHandler.EmbeddingHandler
-	MCPHandler       *mcp.Handler
+	WebHandler        *webHandler.WebHandler
+	UserHandler       *userHandler.UserHandler
+	AuthHandler       *authHandler.AuthHandler
+	EchoHandler       *echoHandler.EchoHandler
+	FileHandler       *fileHandler.FileHandler
+	CommentHandler    *commentHandler.CommentHandler
+	InitHandler       *initHandler.InitHandler
+	CommonHandler     *commonHandler.CommonHandler
+	SettingHandler    *settingHandler.SettingHandler
+	ConnectHandler    *connectHandler.ConnectHandler
+	MigrationHandler  *migratorHandler.MigrationHandler
+	DashboardHandler  *dashboardHandler.DashboardHandler
+	CopilotHandler    *copilotHandler.CopilotHandler
+	EmbeddingHandler  *embeddingHandler.EmbeddingHandler
+	SearchHandler     *searchHandler.SearchHandler
+	JobHandler        *jobHandler.JobHandler
+	MCPHandler        *mcp.Handler
+	MicropubHandler   *micropub.Handler
+	WebmentionHandler *webmention.Handler
 }
 
 func NewBundle(
@@ -54,23 +62,31 @@ func NewBundle(
 	dashboardHandler *dashboardHandler.DashboardHandler,
 	copilotHandler *copilotHandler.CopilotHandler,
 	embeddingHandler *embeddingHandler.EmbeddingHandler,
+	searchHandler *searchHandler.SearchHandler,
+	jobHandler *jobHandler.JobHandler,
 	mcpHandler *mcp.Handler,
+	micropubHandler *micropub.Handler,
+	webmentionHandler *webmention.Handler,
 ) *Bundle {
 	return &Bundle{
-		WebHandler:       webHandler,
-		UserHandler:      userHandler,
-		AuthHandler:      authHandler,
-		EchoHandler:      echoHandler,
-		FileHandler:      fileHandler,
-		CommentHandler:   commentHandler,
-		InitHandler:      initHandler,
-		CommonHandler:    commonHandler,
-		SettingHandler:   settingHandler,
-		ConnectHandler:   connectHandler,
-		MigrationHandler: migratorHandler,
-		DashboardHandler: dashboardHandler,
-		CopilotHandler:   copilotHandler,
-		EmbeddingHandler: embeddingHandler,
-		MCPHandler:       mcpHandler,
+		WebHandler:        webHandler,
+		UserHandler:       userHandler,
+		AuthHandler:       authHandler,
+		EchoHandler:       echoHandler,
+		FileHandler:       fileHandler,
+		CommentHandler:    commentHandler,
+		InitHandler:       initHandler,
+		CommonHandler:     commonHandler,
+		SettingHandler:    settingHandler,
+		ConnectHandler:    connectHandler,
+		MigrationHandler:  migratorHandler,
+		DashboardHandler:  dashboardHandler,
+		CopilotHandler:    copilotHandler,
+		EmbeddingHandler:  embeddingHandler,
+		SearchHandler:     searchHandler,
+		JobHandler:        jobHandler,
+		MCPHandler:        mcpHandler,
+		MicropubHandler:   micropubHandler,
+		WebmentionHandler: webmentionHandler,
 	}
 }
@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	model "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+)
+
+// subscriptionPage 是确认 / 退订链接落地的极简页面：访问者来自邮件客户端，不经过前端 SPA。
+var subscriptionPage = template.Must(template.New("subscription").Parse(`<!doctype html>
+<html lang="zh-CN"><head><meta charset="utf-8"><meta name="viewport" content="width=device-width,initial-scale=1">
+<meta name="robots" content="noindex"><title>{{.Title}} - Ech0</title></head>
+<body style="margin:0;padding:48px 16px;background:#f4f1ec;font-family:-apple-system,BlinkMacSystemFont,'PingFang SC','Microsoft YaHei',sans-serif;color:#3a3329;">
+<div style="max-width:480px;margin:0 auto;padding:24px;background:#fff;border:1px solid #e6dfd4;">
+<h1 style="margin:0 0 12px;font-size:18px;">{{.Title}}</h1>
+<p style="margin:0;line-height:1.7;font-size:14px;color:#4f473b;">{{.Message}}</p>
+{{if .Token}}<form method="post" style="margin-top:18px;"><input type="hidden" name="token" value="{{.Token}}">
+<button type="submit" style="padding:8px 14px;background:#fff;border:1px solid #cbc4b8;color:#5f574a;font-size:13px;font-weight:600;cursor:pointer;">{{.Action}}</button></form>{{end}}
+</div></body></html>`))
+
+type subscriptionPageData struct {
+	Title   string
+	Message string
+	// Token 非空时页面带一个 POST 表单，Action 是它的按钮文案。
+	Token  string
+	Action string
+}
+
+func renderSubscriptionPage(ctx *gin.Context, status int, data subscriptionPageData) {
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("Content-Type", "text/html; charset=utf-8")
+	ctx.Status(status)
+	_ = subscriptionPage.Execute(ctx.Writer, data)
+}
+
+// subscriptionFailure 把业务错误的提示原样展示给访问者，其余错误只给通用说明。
+func subscriptionFailure(ctx *gin.Context, title string, err error) {
+	var bizErr *commonModel.BizError
+	if errors.As(err, &bizErr) {
+		renderSubscriptionPage(ctx, http.StatusBadRequest, subscriptionPageData{Title: title, Message: bizErr.Msg})
+		return
+	}
+	renderSubscriptionPage(ctx, http.StatusInternalServerError, subscriptionPageData{
+		Title:   title,
+		Message: "操作失败，请稍后再试。",
+	})
+}
+
+func subscriptionToken(ctx *gin.Context) string {
+	if token := strings.TrimSpace(ctx.Query("token")); token != "" {
+		return token
+	}
+	return strings.TrimSpace(ctx.PostForm("token"))
+}
+
+// ConfirmSubscriptionPage 展示订阅确认页。与退订页同理，GET 不做任何修改，
+// 免得邮件安全网关预取链接时替收件人完成确认；由页面表单 POST 到 ConfirmSubscription。
+func (h *CommentHandler) ConfirmSubscriptionPage() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token := subscriptionToken(ctx)
+		if token == "" {
+			renderSubscriptionPage(ctx, http.StatusBadRequest, subscriptionPageData{
+				Title:   "订阅确认",
+				Message: "链接无效或已过期",
+			})
+			return
+		}
+		renderSubscriptionPage(ctx, http.StatusOK, subscriptionPageData{
+			Title:   "确认订阅评论通知",
+			Message: "确认后，这条 Echo 下有新评论时会邮件通知你。",
+			Token:   token,
+			Action:  "确认订阅",
+		})
+	}
+}
+
+// ConfirmSubscription 执行确认页的表单提交，确认后订阅才生效（double opt-in）。
+func (h *CommentHandler) ConfirmSubscription() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if err := h.commentService.ConfirmSubscription(ctx.Request.Context(), subscriptionToken(ctx)); err != nil {
+			subscriptionFailure(ctx, "订阅确认失败", err)
+			return
+		}
+		renderSubscriptionPage(ctx, http.StatusOK, subscriptionPageData{
+			Title:   "订阅已确认",
+			Message: "这条 Echo 下有新评论时会邮件通知你，每封邮件底部都有退订链接。",
+		})
+	}
+}
+
+// UnsubscribePage 展示退订确认页。邮件安全网关常会预取链接，因此 GET 不做任何修改，由页面表单 POST 完成退订。
+func (h *CommentHandler) UnsubscribePage() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token := subscriptionToken(ctx)
+		if token == "" {
+			renderSubscriptionPage(ctx, http.StatusBadRequest, subscriptionPageData{
+				Title:   "退订",
+				Message: "链接无效或已过期",
+			})
+			return
+		}
+		renderSubscriptionPage(ctx, http.StatusOK, subscriptionPageData{
+			Title:   "退订邮件通知",
+			Message: "确认后将不再收到这类评论邮件。",
+			Token:   token,
+			Action:  "确认退订",
+		})
+	}
+}
+
+// Unsubscribe 执行退订：既处理退订页的表单提交，也处理邮件客户端按 RFC 8058 发起的一键退订
+// （POST，body 为 List-Unsubscribe=One-Click，token 在 URL 查询串里）。
+func (h *CommentHandler) Unsubscribe() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		scope, err := h.commentService.Unsubscribe(ctx.Request.Context(), subscriptionToken(ctx))
+		if err != nil {
+			subscriptionFailure(ctx, "退订失败", err)
+			return
+		}
+		message := "已退订这条 Echo 的评论通知。"
+		if scope == model.UnsubscribeAll {
+			message = "已退订，此后不会再收到本站评论系统发出的邮件。"
+		}
+		renderSubscriptionPage(ctx, http.StatusOK, subscriptionPageData{Title: "退订成功", Message: message})
+	}
+}
+
+type (
+	ListEmailSuppressionsInput  struct{}
+	DeleteEmailSuppressionInput struct {
+		Email string `path:"email" doc:"退订邮箱"`
+	}
+	EmailSuppressionsOutput = commonModel.Result[[]model.EmailSuppression]
+)
+
+// ListEmailSuppressions 列出退订名单。
+func (h *CommentHandler) ListEmailSuppressions(ctx context.Context, _ *ListEmailSuppressionsInput) (EmailSuppressionsOutput, error) {
+	data, err := h.commentService.ListEmailSuppressions(ctx)
+	if err != nil {
+		return EmailSuppressionsOutput{}, err
+	}
+	return commonModel.OK(data), nil
+}
+
+// DeleteEmailSuppression 把地址移出退订名单，此后可重新收到邮件。
+func (h *CommentHandler) DeleteEmailSuppression(ctx context.Context, in *DeleteEmailSuppressionInput) (EmptyOutput, error) {
+	if err := h.commentService.DeleteEmailSuppression(ctx, in.Email); err != nil {
+		return EmptyOutput{}, err
+	}
+	return commonModel.OK[any](nil, commonModel.DELETE_SUCCESS), nil
+}
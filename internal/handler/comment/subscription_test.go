@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	handler "github.com/lin-snow/ech0/internal/handler/comment"
+	model "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	"github.com/lin-snow/ech0/internal/test/mocks/commentmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newSubscriptionRouter(svc *commentmock.MockService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	h := handler.NewCommentHandler(svc)
+	r.GET("/api/comments/subscription/confirm", h.ConfirmSubscriptionPage())
+	r.POST("/api/comments/subscription/confirm", h.ConfirmSubscription())
+	r.GET("/api/comments/unsubscribe", h.UnsubscribePage())
+	r.POST("/api/comments/unsubscribe", h.Unsubscribe())
+	return r
+}
+
+func serve(r *gin.Engine, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCommentHandler_ConfirmSubscription(t *testing.T) {
+	t.Run("GET only renders a form", func(t *testing.T) {
+		svc := commentmock.NewMockService(t) // 无期望：GET 不得调用 ConfirmSubscription
+
+		rec := serve(newSubscriptionRouter(svc), httptest.NewRequest(http.MethodGet, "/api/comments/subscription/confirm?token=tok", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.True(t, strings.HasPrefix(rec.Header().Get("Content-Type"), "text/html"))
+		body := rec.Body.String()
+		assert.Contains(t, body, `method="post"`)
+		assert.Contains(t, body, `value="tok"`)
+		assert.Contains(t, body, "确认订阅")
+	})
+
+	t.Run("GET without token", func(t *testing.T) {
+		svc := commentmock.NewMockService(t)
+
+		rec := serve(newSubscriptionRouter(svc), httptest.NewRequest(http.MethodGet, "/api/comments/subscription/confirm", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.NotContains(t, rec.Body.String(), `method="post"`)
+	})
+
+	t.Run("form POST confirms", func(t *testing.T) {
+		svc := commentmock.NewMockService(t)
+		svc.EXPECT().ConfirmSubscription(mock.Anything, "tok").Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/comments/subscription/confirm", strings.NewReader("token=tok"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := serve(newSubscriptionRouter(svc), req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "订阅已确认")
+	})
+
+	t.Run("biz error message is shown", func(t *testing.T) {
+		svc := commentmock.NewMockService(t)
+		svc.EXPECT().ConfirmSubscription(mock.Anything, "bad").
+			Return(commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "链接无效或已过期")).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/comments/subscription/confirm", strings.NewReader("token=bad"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := serve(newSubscriptionRouter(svc), req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "链接无效或已过期")
+	})
+}
+
+func TestCommentHandler_Unsubscribe(t *testing.T) {
+	t.Run("GET only renders a form", func(t *testing.T) {
+		svc := commentmock.NewMockService(t) // 无期望：GET 不得调用 Unsubscribe
+
+		rec := serve(newSubscriptionRouter(svc), httptest.NewRequest(http.MethodGet, "/api/comments/unsubscribe?token=t%3Cx", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		body := rec.Body.String()
+		assert.Contains(t, body, `method="post"`)
+		assert.Contains(t, body, `value="t&lt;x"`, "token 经 html/template 转义")
+	})
+
+	t.Run("RFC 8058 one-click POST", func(t *testing.T) {
+		svc := commentmock.NewMockService(t)
+		svc.EXPECT().Unsubscribe(mock.Anything, "tok").Return(model.UnsubscribeThread, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/comments/unsubscribe?token=tok",
+			strings.NewReader("List-Unsubscribe=One-Click"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := serve(newSubscriptionRouter(svc), req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "已退订这条 Echo")
+	})
+
+	t.Run("form POST carries the token in the body", func(t *testing.T) {
+		svc := commentmock.NewMockService(t)
+		svc.EXPECT().Unsubscribe(mock.Anything, "tok").Return(model.UnsubscribeAll, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/comments/unsubscribe", strings.NewReader("token=tok"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := serve(newSubscriptionRouter(svc), req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "不会再收到")
+	})
+
+	t.Run("unexpected error hides details", func(t *testing.T) {
+		svc := commentmock.NewMockService(t)
+		svc.EXPECT().Unsubscribe(mock.Anything, "tok").Return(model.UnsubscribeScope(""), errBoom).Once()
+
+		rec := serve(newSubscriptionRouter(svc), httptest.NewRequest(http.MethodPost, "/api/comments/unsubscribe?token=tok", nil))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "boom")
+	})
+}
+
+func TestCommentHandler_EmailSuppressions(t *testing.T) {
+	svc := commentmock.NewMockService(t)
+	svc.EXPECT().ListEmailSuppressions(mock.Anything).
+		Return([]model.EmailSuppression{{Email: "a@example.com"}}, nil).Once()
+	svc.EXPECT().DeleteEmailSuppression(mock.Anything, "a@example.com").Return(nil).Once()
+
+	h := handler.NewCommentHandler(svc)
+	out, err := h.ListEmailSuppressions(bg(), &handler.ListEmailSuppressionsInput{})
+	require.NoError(t, err)
+	require.Len(t, out.Data, 1)
+
+	del, err := h.DeleteEmailSuppression(bg(), &handler.DeleteEmailSuppressionInput{Email: "a@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, commonModel.DELETE_SUCCESS, del.Message)
+}
@@ -15,17 +15,20 @@ import (
 )
 
 type CopilotHandler struct {
-	summaryService copilotService.SummaryService
-	chatService    copilotService.ChatService
+	summaryService    copilotService.SummaryService
+	chatService       copilotService.ChatService
+	suggestionService copilotService.SuggestionService
 }
 
 func NewCopilotHandler(
 	summaryService copilotService.SummaryService,
 	chatService copilotService.ChatService,
+	suggestionService copilotService.SuggestionService,
 ) *CopilotHandler {
 	return &CopilotHandler{
-		summaryService: summaryService,
-		chatService:    chatService,
+		summaryService:    summaryService,
+		chatService:       chatService,
+		suggestionService: suggestionService,
 	}
 }
 
@@ -33,12 +36,29 @@ type (
 	GetRecentInput    struct{}
 	GetSessionInput   struct{}
 	ClearSessionInput struct{}
+	ListActionsInput  struct{}
+	DecideActionInput struct {
+		ID   string `path:"id" doc:"待确认操作 ID（SSE pending_action 事件中的 id）"`
+		Body struct {
+			Approve bool `json:"approve" doc:"true 执行该操作，false 拒绝"`
+		}
+	}
+	ListSuggestionsInput struct{}
+	ApplySuggestionInput struct {
+		EchoID string `path:"echoId" doc:"建议对应的 Echo ID"`
+		Body   copilotService.ApplySuggestionDto
+	}
+	DismissSuggestionInput struct {
+		EchoID string `path:"echoId" doc:"建议对应的 Echo ID"`
+	}
 )
 
 type (
-	RecentOutput  = commonModel.Result[string]
-	SessionOutput = commonModel.Result[[]copilotService.ChatMessage]
-	EmptyOutput   = commonModel.Result[any]
+	RecentOutput      = commonModel.Result[string]
+	SessionOutput     = commonModel.Result[[]copilotService.ChatMessage]
+	ActionsOutput     = commonModel.Result[[]copilotService.ActionRecord]
+	SuggestionsOutput = commonModel.Result[[]copilotService.Suggestion]
+	EmptyOutput       = commonModel.Result[any]
 )
 
 func (h *CopilotHandler) GetRecent(ctx context.Context, _ *GetRecentInput) (RecentOutput, error) {
@@ -64,6 +84,48 @@ func (h *CopilotHandler) ClearSession(ctx context.Context, _ *ClearSessionInput)
 	return commonModel.OK[any](nil, commonModel.CHAT_SESSION_CLEAR_SUCCESS), nil
 }
 
+// DecideAction 确认或拒绝 Chat 中模型提议的写操作；Chat 流在收到决定后继续。
+func (h *CopilotHandler) DecideAction(ctx context.Context, in *DecideActionInput) (EmptyOutput, error) {
+	if err := h.chatService.DecideAction(ctx, in.ID, in.Body.Approve); err != nil {
+		return EmptyOutput{}, err
+	}
+	return commonModel.OK[any](nil, commonModel.CHAT_ACTION_DECIDE_SUCCESS), nil
+}
+
+// ListActions 返回 Copilot 写操作的审计记录（最新在前）。
+func (h *CopilotHandler) ListActions(ctx context.Context, _ *ListActionsInput) (ActionsOutput, error) {
+	records, err := h.chatService.ListActions(ctx)
+	if err != nil {
+		return ActionsOutput{}, err
+	}
+	return commonModel.OK(records, commonModel.CHAT_ACTION_LIST_SUCCESS), nil
+}
+
+// ListSuggestions 返回 Agent 为新 Echo 生成、等待审核的标签 / 替代文本建议（最新在前）。
+func (h *CopilotHandler) ListSuggestions(ctx context.Context, _ *ListSuggestionsInput) (SuggestionsOutput, error) {
+	list, err := h.suggestionService.ListSuggestions(ctx)
+	if err != nil {
+		return SuggestionsOutput{}, err
+	}
+	return commonModel.OK(list, commonModel.SUGGESTION_LIST_SUCCESS), nil
+}
+
+// ApplySuggestion 应用一条建议（可在请求体中改写标签 / 替代文本）。
+func (h *CopilotHandler) ApplySuggestion(ctx context.Context, in *ApplySuggestionInput) (EmptyOutput, error) {
+	if err := h.suggestionService.ApplySuggestion(ctx, in.EchoID, in.Body); err != nil {
+		return EmptyOutput{}, err
+	}
+	return commonModel.OK[any](nil, commonModel.SUGGESTION_APPLY_SUCCESS), nil
+}
+
+// DismissSuggestion 忽略一条建议。
+func (h *CopilotHandler) DismissSuggestion(ctx context.Context, in *DismissSuggestionInput) (EmptyOutput, error) {
+	if err := h.suggestionService.DismissSuggestion(ctx, in.EchoID); err != nil {
+		return EmptyOutput{}, err
+	}
+	return commonModel.OK[any](nil, commonModel.SUGGESTION_DISMISS_SUCCESS), nil
+}
+
 type askRequest struct {
 	Question string `json:"question"`
 }
@@ -35,7 +35,7 @@ func TestGetRecent(t *testing.T) {
 		chat := copilotmock.NewMockChatService(t) // 不应触达
 		summary.EXPECT().GetRecent(mock.Anything).Return("近期总结文本", nil).Once()
 
-		h := NewCopilotHandler(summary, chat)
+		h := NewCopilotHandler(summary, chat, nil)
 		out, err := h.GetRecent(context.Background(), &GetRecentInput{})
 
 		require.NoError(t, err)
@@ -50,7 +50,7 @@ func TestGetRecent(t *testing.T) {
 		sentinel := errors.New("agent down")
 		summary.EXPECT().GetRecent(mock.Anything).Return("", sentinel).Once()
 
-		h := NewCopilotHandler(summary, chat)
+		h := NewCopilotHandler(summary, chat, nil)
 		out, err := h.GetRecent(context.Background(), &GetRecentInput{})
 
 		require.ErrorIs(t, err, sentinel)
@@ -73,7 +73,7 @@ func TestGetSession(t *testing.T) {
 		}
 		chat.EXPECT().GetSession(mock.Anything).Return(want, nil).Once()
 
-		h := NewCopilotHandler(summary, chat)
+		h := NewCopilotHandler(summary, chat, nil)
 		out, err := h.GetSession(context.Background(), &GetSessionInput{})
 
 		require.NoError(t, err)
@@ -88,7 +88,7 @@ func TestGetSession(t *testing.T) {
 		sentinel := errors.New("load session failed")
 		chat.EXPECT().GetSession(mock.Anything).Return(nil, sentinel).Once()
 
-		h := NewCopilotHandler(summary, chat)
+		h := NewCopilotHandler(summary, chat, nil)
 		out, err := h.GetSession(context.Background(), &GetSessionInput{})
 
 		require.ErrorIs(t, err, sentinel)
@@ -106,7 +106,7 @@ func TestClearSession(t *testing.T) {
 		chat := copilotmock.NewMockChatService(t)
 		chat.EXPECT().ClearSession(mock.Anything).Return(nil).Once()
 
-		h := NewCopilotHandler(summary, chat)
+		h := NewCopilotHandler(summary, chat, nil)
 		out, err := h.ClearSession(context.Background(), &ClearSessionInput{})
 
 		require.NoError(t, err)
@@ -121,7 +121,7 @@ func TestClearSession(t *testing.T) {
 		sentinel := errors.New("clear failed")
 		chat.EXPECT().ClearSession(mock.Anything).Return(sentinel).Once()
 
-		h := NewCopilotHandler(summary, chat)
+		h := NewCopilotHandler(summary, chat, nil)
 		out, err := h.ClearSession(context.Background(), &ClearSessionInput{})
 
 		require.ErrorIs(t, err, sentinel)
@@ -129,6 +129,97 @@ func TestClearSession(t *testing.T) {
 	})
 }
 
+// ---------------------------------------------------------------------------
+// DecideAction / ListActions（框架中立）
+// ---------------------------------------------------------------------------
+
+func TestDecideAction(t *testing.T) {
+	t.Run("success-forwards-decision", func(t *testing.T) {
+		summary := copilotmock.NewMockSummaryService(t)
+		chat := copilotmock.NewMockChatService(t)
+		chat.EXPECT().DecideAction(mock.Anything, "act-1", true).Return(nil).Once()
+
+		h := NewCopilotHandler(summary, chat, nil)
+		in := &DecideActionInput{ID: "act-1"}
+		in.Body.Approve = true
+		out, err := h.DecideAction(context.Background(), in)
+
+		require.NoError(t, err)
+		assert.Equal(t, commonModel.CHAT_ACTION_DECIDE_SUCCESS, out.Message)
+		assert.Nil(t, out.Data)
+	})
+
+	t.Run("service-error-passthrough", func(t *testing.T) {
+		summary := copilotmock.NewMockSummaryService(t)
+		chat := copilotmock.NewMockChatService(t)
+		sentinel := errors.New(commonModel.CHAT_ACTION_NOT_FOUND)
+		chat.EXPECT().DecideAction(mock.Anything, "gone", false).Return(sentinel).Once()
+
+		h := NewCopilotHandler(summary, chat, nil)
+		out, err := h.DecideAction(context.Background(), &DecideActionInput{ID: "gone"})
+
+		require.ErrorIs(t, err, sentinel)
+		assert.Equal(t, EmptyOutput{}, out)
+	})
+}
+
+func TestListActions(t *testing.T) {
+	summary := copilotmock.NewMockSummaryService(t)
+	chat := copilotmock.NewMockChatService(t)
+	records := []copilotService.ActionRecord{{ID: "act-2", Tool: "tag_echos", Status: copilotService.ActionSucceeded}}
+	chat.EXPECT().ListActions(mock.Anything).Return(records, nil).Once()
+
+	h := NewCopilotHandler(summary, chat, nil)
+	out, err := h.ListActions(context.Background(), &ListActionsInput{})
+
+	require.NoError(t, err)
+	assert.Equal(t, commonModel.CHAT_ACTION_LIST_SUCCESS, out.Message)
+	assert.Equal(t, records, out.Data)
+}
+
+// ---------------------------------------------------------------------------
+// Suggestions（框架中立）
+// ---------------------------------------------------------------------------
+
+func TestSuggestions(t *testing.T) {
+	t.Run("list", func(t *testing.T) {
+		sug := copilotmock.NewMockSuggestionService(t)
+		list := []copilotService.Suggestion{{EchoID: "echo-1", Tags: []string{"travel"}}}
+		sug.EXPECT().ListSuggestions(mock.Anything).Return(list, nil).Once()
+
+		h := NewCopilotHandler(nil, nil, sug)
+		out, err := h.ListSuggestions(context.Background(), &ListSuggestionsInput{})
+
+		require.NoError(t, err)
+		assert.Equal(t, commonModel.SUGGESTION_LIST_SUCCESS, out.Message)
+		assert.Equal(t, list, out.Data)
+	})
+
+	t.Run("apply-forwards-overrides", func(t *testing.T) {
+		sug := copilotmock.NewMockSuggestionService(t)
+		dto := copilotService.ApplySuggestionDto{Tags: []string{"travel"}}
+		sug.EXPECT().ApplySuggestion(mock.Anything, "echo-1", dto).Return(nil).Once()
+
+		h := NewCopilotHandler(nil, nil, sug)
+		out, err := h.ApplySuggestion(context.Background(), &ApplySuggestionInput{EchoID: "echo-1", Body: dto})
+
+		require.NoError(t, err)
+		assert.Equal(t, commonModel.SUGGESTION_APPLY_SUCCESS, out.Message)
+	})
+
+	t.Run("dismiss-error-passthrough", func(t *testing.T) {
+		sug := copilotmock.NewMockSuggestionService(t)
+		sentinel := errors.New(commonModel.SUGGESTION_NOT_FOUND)
+		sug.EXPECT().DismissSuggestion(mock.Anything, "gone").Return(sentinel).Once()
+
+		h := NewCopilotHandler(nil, nil, sug)
+		out, err := h.DismissSuggestion(context.Background(), &DismissSuggestionInput{EchoID: "gone"})
+
+		require.ErrorIs(t, err, sentinel)
+		assert.Equal(t, EmptyOutput{}, out)
+	})
+}
+
 // ---------------------------------------------------------------------------
 // Ask（裸 gin SSE）：断 header → timezone 归一化 + AskStream 被调用
 // ---------------------------------------------------------------------------
@@ -152,7 +243,7 @@ func TestAsk_TimezoneNormalizationAndStream(t *testing.T) {
 				AskStream(mock.Anything, "今天怎么样", "zh-CN", tc.wantNormTZ, mock.Anything).
 				Return(nil).Once()
 
-			h := NewCopilotHandler(summary, chat)
+			h := NewCopilotHandler(summary, chat, nil)
 			r := gin.New()
 			r.POST("/chat/ask", h.Ask())
 
@@ -179,7 +270,7 @@ func TestAsk_InvalidBodyStillStreamsEmptyQuestion(t *testing.T) {
 		AskStream(mock.Anything, "", "zh-CN", "UTC", mock.Anything).
 		Return(nil).Once()
 
-	h := NewCopilotHandler(summary, chat)
+	h := NewCopilotHandler(summary, chat, nil)
 	r := gin.New()
 	r.POST("/chat/ask", h.Ask())
 
@@ -12,6 +12,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
 	service "github.com/lin-snow/ech0/internal/service/dashboard"
 	githubUtil "github.com/lin-snow/ech0/internal/util/github"
 	jwtUtil "github.com/lin-snow/ech0/internal/util/jwt"
@@ -23,11 +24,13 @@ import (
 
 type DashboardHandler struct {
 	dashboardService service.Service
+	digestService    service.DigestService
 }
 
-func NewDashboardHandler(dashboardService service.Service) *DashboardHandler {
+func NewDashboardHandler(dashboardService service.Service, digestService service.DigestService) *DashboardHandler {
 	return &DashboardHandler{
 		dashboardService: dashboardService,
+		digestService:    digestService,
 	}
 }
 
@@ -39,6 +42,11 @@ type (
 		Keyword string `query:"keyword" doc:"关键词过滤"`
 	}
 	GetVisitorStatsInput struct{}
+	GetDigestInput       struct{}
+	UpdateDigestInput    struct {
+		Body settingModel.DigestSettingDto
+	}
+	SendDigestInput struct{}
 
 	CheckUpdateResponse struct {
 		CurrentVersion string `json:"current_version"`
@@ -51,6 +59,8 @@ type (
 	CheckUpdateOutput  = commonModel.Result[CheckUpdateResponse]
 	LogsOutput         = commonModel.Result[[]logUtil.LogEntry]
 	VisitorStatsOutput = commonModel.Result[[]visitor.DayStat]
+	DigestOutput       = commonModel.Result[settingModel.DigestSetting]
+	EmptyOutput        = commonModel.Result[any]
 )
 
 func (dashboardHandler *DashboardHandler) CheckUpdate(ctx context.Context, _ *CheckUpdateInput) (CheckUpdateOutput, error) {
@@ -108,6 +118,31 @@ func (dashboardHandler *DashboardHandler) GetVisitorStats(ctx context.Context, _
 	return commonModel.OK(dashboardHandler.dashboardService.GetVisitorStats()), nil
 }
 
+// GetDigestSetting 获取站长定期摘要设置（admin:settings）。
+func (dashboardHandler *DashboardHandler) GetDigestSetting(ctx context.Context, _ *GetDigestInput) (DigestOutput, error) {
+	setting, err := dashboardHandler.digestService.GetDigestSetting(ctx)
+	if err != nil {
+		return DigestOutput{}, err
+	}
+	return commonModel.OK(setting), nil
+}
+
+// UpdateDigestSetting 更新站长定期摘要设置（admin:settings）。
+func (dashboardHandler *DashboardHandler) UpdateDigestSetting(ctx context.Context, in *UpdateDigestInput) (EmptyOutput, error) {
+	if err := dashboardHandler.digestService.UpdateDigestSetting(ctx, &in.Body); err != nil {
+		return EmptyOutput{}, err
+	}
+	return commonModel.OK[any](nil, commonModel.UPDATE_DIGEST_SUCCESS), nil
+}
+
+// SendDigest 立即给 owner 发送一期摘要（admin:settings）。
+func (dashboardHandler *DashboardHandler) SendDigest(ctx context.Context, _ *SendDigestInput) (EmptyOutput, error) {
+	if err := dashboardHandler.digestService.SendDigest(ctx); err != nil {
+		return EmptyOutput{}, err
+	}
+	return commonModel.OK[any](nil, commonModel.SEND_DIGEST_SUCCESS), nil
+}
+
 func (dashboardHandler *DashboardHandler) WSSubscribeSystemLogs() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		token := ctx.Query("token")
@@ -13,6 +13,7 @@ import (
 	"github.com/gin-gonic/gin"
 	dashboardHandler "github.com/lin-snow/ech0/internal/handler/dashboard"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
 	dashboardService "github.com/lin-snow/ech0/internal/service/dashboard"
 	dashboardmock "github.com/lin-snow/ech0/internal/test/mocks/dashboardmock"
 	"github.com/lin-snow/ech0/internal/visitor"
@@ -63,7 +64,7 @@ func TestGetSystemLogs_TailParsing(t *testing.T) {
 				}).
 				Return(want, nil).Once()
 
-			h := dashboardHandler.NewDashboardHandler(svc)
+			h := dashboardHandler.NewDashboardHandler(svc, nil)
 			out, err := h.GetSystemLogs(context.Background(), &tc.input)
 
 			require.NoError(t, err)
@@ -88,7 +89,7 @@ func TestGetSystemLogs_InvalidTail(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// 无 EXPECT：非法 tail 必须在触达 service 前短路。
 			svc := dashboardmock.NewMockService(t)
-			h := dashboardHandler.NewDashboardHandler(svc)
+			h := dashboardHandler.NewDashboardHandler(svc, nil)
 
 			out, err := h.GetSystemLogs(context.Background(), &dashboardHandler.GetSystemLogsInput{Tail: tc.tail})
 
@@ -107,7 +108,7 @@ func TestGetSystemLogs_ServiceError(t *testing.T) {
 	sentinel := errors.New("read log file failed")
 	svc.EXPECT().GetSystemLogs(dashboardService.SystemLogQuery{Tail: 200}).Return(nil, sentinel).Once()
 
-	h := dashboardHandler.NewDashboardHandler(svc)
+	h := dashboardHandler.NewDashboardHandler(svc, nil)
 	out, err := h.GetSystemLogs(context.Background(), &dashboardHandler.GetSystemLogsInput{})
 
 	require.ErrorIs(t, err, sentinel)
@@ -126,7 +127,7 @@ func TestGetVisitorStats(t *testing.T) {
 	}
 	svc.EXPECT().GetVisitorStats().Return(want).Once()
 
-	h := dashboardHandler.NewDashboardHandler(svc)
+	h := dashboardHandler.NewDashboardHandler(svc, nil)
 	out, err := h.GetVisitorStats(context.Background(), &dashboardHandler.GetVisitorStatsInput{})
 
 	require.NoError(t, err)
@@ -134,6 +135,60 @@ func TestGetVisitorStats(t *testing.T) {
 	assert.Equal(t, want, out.Data)
 }
 
+// ---------------------------------------------------------------------------
+// 定期摘要（框架中立）
+// ---------------------------------------------------------------------------
+
+func TestGetDigestSetting(t *testing.T) {
+	digest := dashboardmock.NewMockDigestService(t)
+	want := settingModel.DigestSetting{Enable: true, CronExpression: "0 9 * * 1", Period: "weekly", Timezone: "UTC"}
+	digest.EXPECT().GetDigestSetting(context.Background()).Return(want, nil).Once()
+
+	h := dashboardHandler.NewDashboardHandler(nil, digest)
+	out, err := h.GetDigestSetting(context.Background(), &dashboardHandler.GetDigestInput{})
+
+	require.NoError(t, err)
+	assert.Equal(t, want, out.Data)
+}
+
+func TestUpdateDigestSetting(t *testing.T) {
+	t.Run("passes body through", func(t *testing.T) {
+		digest := dashboardmock.NewMockDigestService(t)
+		in := &dashboardHandler.UpdateDigestInput{Body: settingModel.DigestSettingDto{Enable: true, Period: "daily"}}
+		digest.EXPECT().UpdateDigestSetting(context.Background(), &in.Body).Return(nil).Once()
+
+		h := dashboardHandler.NewDashboardHandler(nil, digest)
+		out, err := h.UpdateDigestSetting(context.Background(), in)
+
+		require.NoError(t, err)
+		assert.Equal(t, commonModel.UPDATE_DIGEST_SUCCESS, out.Message)
+	})
+
+	t.Run("service error is returned", func(t *testing.T) {
+		digest := dashboardmock.NewMockDigestService(t)
+		sentinel := errors.New(commonModel.INVALID_CRON_EXPRESSION)
+		in := &dashboardHandler.UpdateDigestInput{}
+		digest.EXPECT().UpdateDigestSetting(context.Background(), &in.Body).Return(sentinel).Once()
+
+		h := dashboardHandler.NewDashboardHandler(nil, digest)
+		out, err := h.UpdateDigestSetting(context.Background(), in)
+
+		require.ErrorIs(t, err, sentinel)
+		assert.Equal(t, dashboardHandler.EmptyOutput{}, out)
+	})
+}
+
+func TestSendDigest(t *testing.T) {
+	digest := dashboardmock.NewMockDigestService(t)
+	digest.EXPECT().SendDigest(context.Background()).Return(nil).Once()
+
+	h := dashboardHandler.NewDashboardHandler(nil, digest)
+	out, err := h.SendDigest(context.Background(), &dashboardHandler.SendDigestInput{})
+
+	require.NoError(t, err)
+	assert.Equal(t, commonModel.SEND_DIGEST_SUCCESS, out.Message)
+}
+
 // ---------------------------------------------------------------------------
 // WS/SSE 认证守卫（仅早退分支：缺/坏 token 在触达流式逻辑前 401，不涉及真实流）
 // ---------------------------------------------------------------------------
@@ -155,7 +210,7 @@ func TestStreamSubscribe_AuthGuard(t *testing.T) {
 		for _, tc := range tokenCases {
 			t.Run(routeName+"/"+tc.name, func(t *testing.T) {
 				svc := dashboardmock.NewMockService(t)
-				h := dashboardHandler.NewDashboardHandler(svc)
+				h := dashboardHandler.NewDashboardHandler(svc, nil)
 				r := gin.New()
 				r.GET("/stream", build(h))
 
@@ -8,9 +8,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strings"
 
 	"github.com/lin-snow/ech0/internal/job"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	embeddingModel "github.com/lin-snow/ech0/internal/model/embedding"
 	jobModel "github.com/lin-snow/ech0/internal/model/job"
 )
 
@@ -47,6 +49,26 @@ type (
 
 type ReindexOutput = commonModel.Result[ReindexStatusResponse]
 
+// ModelPullStatusResponse 是本地模型拉取作业的状态响应，字段与 ReindexStatusResponse 一一对应，
+// 仅 payload 换成 ModelPullProgress。
+type ModelPullStatusResponse struct {
+	Status     string          `json:"status" doc:"作业状态：idle/pending/running/succeeded/failed/cancelled" example:"running"`
+	Phase      string          `json:"phase,omitempty" doc:"当前阶段"`
+	Error      string          `json:"error,omitempty" doc:"失败原因（status=failed 时，如模型名不存在）"`
+	Payload    json.RawMessage `json:"payload,omitempty" doc:"拉取进度 ModelPullProgress: model/status/digest/total/completed"`
+	StartedAt  *int64          `json:"started_at,omitempty" doc:"开始时间（Unix 秒）"`
+	FinishedAt *int64          `json:"finished_at,omitempty" doc:"结束时间（Unix 秒）"`
+}
+
+type (
+	PullModelInput struct {
+		Body embeddingModel.ModelPullPayload
+	}
+	PullModelStatusInput struct{}
+)
+
+type PullModelOutput = commonModel.Result[ModelPullStatusResponse]
+
 func mapJobToReindexStatus(jb jobModel.Job) ReindexStatusResponse {
 	resp := ReindexStatusResponse{
 		Status:     string(jb.Status),
@@ -83,7 +105,7 @@ func (embeddingHandler *EmbeddingHandler) ReindexStatus(ctx context.Context, _ *
 }
 
 func (embeddingHandler *EmbeddingHandler) CancelReindex(ctx context.Context, _ *CancelReindexInput) (ReindexOutput, error) {
-	_ = embeddingHandler.jobManager.Cancel(jobModel.TypeReindex)
+	_ = embeddingHandler.jobManager.Cancel(ctx, jobModel.TypeReindex)
 	jb, err := embeddingHandler.jobManager.Get(ctx, jobModel.TypeReindex)
 	if errors.Is(err, job.ErrNotFound) {
 		return commonModel.OK(ReindexStatusResponse{Status: reindexStatusIdle}), nil
@@ -93,3 +115,33 @@ func (embeddingHandler *EmbeddingHandler) CancelReindex(ctx context.Context, _ *
 	}
 	return commonModel.OK(mapJobToReindexStatus(jb)), nil
 }
+
+// PullModel 提交一次本地模型拉取作业（Ollama），起即返回；同类型排队，前端轮询 PullModelStatus。
+func (embeddingHandler *EmbeddingHandler) PullModel(ctx context.Context, in *PullModelInput) (PullModelOutput, error) {
+	in.Body.Model = strings.TrimSpace(in.Body.Model)
+	in.Body.BaseURL = strings.TrimSpace(in.Body.BaseURL)
+	if in.Body.Model == "" {
+		return PullModelOutput{}, commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, commonModel.LOCAL_MODEL_MISSING)
+	}
+	raw, err := json.Marshal(in.Body)
+	if err != nil {
+		return PullModelOutput{}, err
+	}
+	jb, err := embeddingHandler.jobManager.Submit(ctx, jobModel.TypeModelPull, raw)
+	if err != nil {
+		return PullModelOutput{}, err
+	}
+	return commonModel.OK(ModelPullStatusResponse(mapJobToReindexStatus(jb))), nil
+}
+
+// PullModelStatus 查询最近一次模型拉取作业状态；查无作业行时合成 idle。
+func (embeddingHandler *EmbeddingHandler) PullModelStatus(ctx context.Context, _ *PullModelStatusInput) (PullModelOutput, error) {
+	jb, err := embeddingHandler.jobManager.Get(ctx, jobModel.TypeModelPull)
+	if errors.Is(err, job.ErrNotFound) {
+		return commonModel.OK(ModelPullStatusResponse{Status: reindexStatusIdle}), nil
+	}
+	if err != nil {
+		return PullModelOutput{}, err
+	}
+	return commonModel.OK(ModelPullStatusResponse(mapJobToReindexStatus(jb))), nil
+}
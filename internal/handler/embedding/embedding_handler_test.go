@@ -7,9 +7,11 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/lin-snow/ech0/internal/job"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	embeddingModel "github.com/lin-snow/ech0/internal/model/embedding"
 	jobModel "github.com/lin-snow/ech0/internal/model/job"
 	jobRepository "github.com/lin-snow/ech0/internal/repository/job"
 	"github.com/lin-snow/ech0/internal/test/helpers"
@@ -113,7 +115,8 @@ func TestReindexStatus_NoJobSynthesizesIdle(t *testing.T) {
 
 func TestReindexStatus_ExistingJobMapped(t *testing.T) {
 	h, repo := newEmbeddingHandlerWithDB(t)
-	require.NoError(t, repo.Upsert(context.Background(), &jobModel.Job{
+	require.NoError(t, repo.Create(context.Background(), &jobModel.Job{
+		ID:        "job-1",
 		Type:      jobModel.TypeReindex,
 		Status:    jobModel.StatusRunning,
 		Phase:     "embedding",
@@ -149,7 +152,8 @@ func TestCancelReindex_NoJobSynthesizesIdle(t *testing.T) {
 
 func TestCancelReindex_TerminalRowMapped(t *testing.T) {
 	h, repo := newEmbeddingHandlerWithDB(t)
-	require.NoError(t, repo.Upsert(context.Background(), &jobModel.Job{
+	require.NoError(t, repo.Create(context.Background(), &jobModel.Job{
+		ID:     "job-1",
 		Type:   jobModel.TypeReindex,
 		Status: jobModel.StatusSuccess,
 		Phase:  "done",
@@ -176,3 +180,55 @@ func TestReindex_NoRunnerReturnsErrNoRunner(t *testing.T) {
 	require.ErrorIs(t, err, job.ErrNoRunner)
 	assert.Equal(t, ReindexOutput{}, out)
 }
+
+// ---------------------------------------------------------------------------
+// PullModel / PullModelStatus
+// ---------------------------------------------------------------------------
+
+func TestPullModel_BlankModelRejected(t *testing.T) {
+	h, _ := newEmbeddingHandlerWithDB(t)
+
+	_, err := h.PullModel(context.Background(), &PullModelInput{
+		Body: embeddingModel.ModelPullPayload{Model: "   "},
+	})
+
+	var be *commonModel.BizError
+	require.ErrorAs(t, err, &be)
+	assert.Equal(t, commonModel.LOCAL_MODEL_MISSING, be.Msg)
+}
+
+func TestPullModel_SubmitsTrimmedPayload(t *testing.T) {
+	h, repo := newEmbeddingHandlerWithDB(t)
+	// 注册一个回显 payload 的 runner：只验证提交与解码，不真去拉模型。
+	h.jobManager.Register(jobModel.TypeModelPull, job.Adapt(
+		func(_ context.Context, p embeddingModel.ModelPullPayload, _ job.ReportFunc) (any, error) {
+			return p, nil
+		}))
+
+	out, err := h.PullModel(context.Background(), &PullModelInput{
+		Body: embeddingModel.ModelPullPayload{BaseURL: " http://gpu:11434 ", Model: " qwen3:8b "},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, string(jobModel.StatusPending), out.Data.Status)
+	assert.JSONEq(t, `{"base_url":"http://gpu:11434","model":"qwen3:8b"}`, string(out.Data.Payload))
+
+	// 等作业收尾再结束测试，免得后台 goroutine 在测试库关闭后才落终态。
+	require.Eventually(t, func() bool {
+		jb, err := repo.Latest(context.Background(), jobModel.TypeModelPull)
+		return err == nil && jb.Status.IsTerminal()
+	}, 5*time.Second, 10*time.Millisecond)
+	status, err := h.PullModelStatus(context.Background(), &PullModelStatusInput{})
+	require.NoError(t, err)
+	assert.Equal(t, string(jobModel.StatusSuccess), status.Data.Status)
+	assert.JSONEq(t, `{"base_url":"http://gpu:11434","model":"qwen3:8b"}`, string(status.Data.Payload))
+}
+
+func TestPullModelStatus_NoJobSynthesizesIdle(t *testing.T) {
+	h, _ := newEmbeddingHandlerWithDB(t)
+
+	out, err := h.PullModelStatus(context.Background(), &PullModelStatusInput{})
+
+	require.NoError(t, err)
+	assert.Equal(t, reindexStatusIdle, out.Data.Status)
+}
@@ -6,20 +6,26 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/gin-gonic/gin"
 	res "github.com/lin-snow/ech0/internal/handler/response"
+	"github.com/lin-snow/ech0/internal/job"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	jobModel "github.com/lin-snow/ech0/internal/model/job"
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
 	service "github.com/lin-snow/ech0/internal/service/file"
 	"github.com/lin-snow/ech0/internal/storage"
 )
 
 type FileHandler struct {
 	fileService service.Service
+	jobManager  *job.Manager
 }
 
-func NewFileHandler(fileService service.Service) *FileHandler {
-	return &FileHandler{fileService: fileService}
+func NewFileHandler(fileService service.Service, jobManager *job.Manager) *FileHandler {
+	return &FileHandler{fileService: fileService, jobManager: jobManager}
 }
 
 type (
@@ -49,14 +55,38 @@ type (
 	GetFilePresignURLInput struct {
 		Body commonModel.GetPresignURLDto
 	}
+	StartStorageMigrationInput struct {
+		Body fileModel.StorageMigrationPayload
+	}
+	StartFileDedupeInput struct{}
+	EmptyInput           struct{}
+	StorageQuotaInput    struct {
+		Body settingModel.StorageQuotaSettingDto
+	}
 )
 
+// FileJobResponse 是提交文件类作业（存储迁移、内容去重）后的作业视图；进度经通用作业端点
+// GET /jobs/{id} 轮询，payload 在运行中与终态为对应作业的进度结构。
+type FileJobResponse struct {
+	ID      string          `json:"id" doc:"作业 ID，用于 GET /jobs/{id} 轮询与取消"`
+	Status  string          `json:"status" doc:"作业状态：pending/running/success/failed/cancelled" example:"pending"`
+	Phase   string          `json:"phase,omitempty" doc:"当前阶段"`
+	Error   string          `json:"error,omitempty" doc:"失败原因（status=failed 时）"`
+	Payload json.RawMessage `json:"payload,omitempty" doc:"提交时为输入，运行后为进度 StorageMigrationProgress / FileDedupeProgress"`
+}
+
 type (
 	FileListOutput = commonModel.Result[commonModel.FileListResultDto]
 	FileTreeOutput = commonModel.Result[commonModel.FileTreeResultDto]
 	FileOutput     = commonModel.Result[commonModel.FileDto]
 	PresignOutput  = commonModel.Result[commonModel.PresignDto]
 	EmptyOutput    = commonModel.Result[any]
+
+	FileJobOutput = commonModel.Result[FileJobResponse]
+
+	StorageQuotaOutput        = commonModel.Result[settingModel.StorageQuotaSetting]
+	StorageUsageOutput        = commonModel.Result[fileModel.StorageUsageReport]
+	StorageUsageRecountOutput = commonModel.Result[fileModel.StorageUsageRecount]
 )
 
 func (fileHandler *FileHandler) ListFiles(ctx context.Context, in *ListFilesInput) (FileListOutput, error) {
@@ -117,6 +147,79 @@ func (fileHandler *FileHandler) GetFilePresignURL(ctx context.Context, in *GetFi
 	return commonModel.OK(presignDto, commonModel.GET_S3_PRESIGN_URL_SUCCESS), nil
 }
 
+// StartStorageMigration 提交一次存储迁移作业，起即返回；同类型互斥，已有迁移未结束时提交失败。
+func (fileHandler *FileHandler) StartStorageMigration(
+	ctx context.Context,
+	in *StartStorageMigrationInput,
+) (FileJobOutput, error) {
+	if err := fileHandler.fileService.PrepareStorageMigration(ctx, &in.Body); err != nil {
+		return FileJobOutput{}, err
+	}
+	raw, err := json.Marshal(in.Body)
+	if err != nil {
+		return FileJobOutput{}, err
+	}
+	jb, err := fileHandler.jobManager.Submit(ctx, jobModel.TypeStorageMigration, raw)
+	if err != nil {
+		return FileJobOutput{}, err
+	}
+	return commonModel.OK(mapJobToFileJobResponse(jb), commonModel.SUBMIT_STORAGE_MIGRATION_SUCCESS), nil
+}
+
+// StartFileDedupe 提交一次内容去重作业（回填存量文件的内容哈希并合并重复对象），起即返回；同类型互斥。
+func (fileHandler *FileHandler) StartFileDedupe(ctx context.Context, _ *StartFileDedupeInput) (FileJobOutput, error) {
+	if err := fileHandler.fileService.PrepareFileDedupe(ctx); err != nil {
+		return FileJobOutput{}, err
+	}
+	jb, err := fileHandler.jobManager.Submit(ctx, jobModel.TypeFileDedupe, nil)
+	if err != nil {
+		return FileJobOutput{}, err
+	}
+	return commonModel.OK(mapJobToFileJobResponse(jb), commonModel.SUBMIT_FILE_DEDUPE_SUCCESS), nil
+}
+
+func (fileHandler *FileHandler) GetStorageQuota(ctx context.Context, _ *EmptyInput) (StorageQuotaOutput, error) {
+	setting, err := fileHandler.fileService.GetStorageQuotaSetting(ctx)
+	if err != nil {
+		return StorageQuotaOutput{}, err
+	}
+	return commonModel.OK(setting, commonModel.GET_SETTINGS_SUCCESS), nil
+}
+
+func (fileHandler *FileHandler) UpdateStorageQuota(ctx context.Context, in *StorageQuotaInput) (EmptyOutput, error) {
+	if err := fileHandler.fileService.UpdateStorageQuotaSetting(ctx, in.Body); err != nil {
+		return EmptyOutput{}, err
+	}
+	return commonModel.OK[any](nil, commonModel.UPDATE_SETTINGS_SUCCESS), nil
+}
+
+func (fileHandler *FileHandler) GetStorageUsage(ctx context.Context, _ *EmptyInput) (StorageUsageOutput, error) {
+	report, err := fileHandler.fileService.GetStorageUsage(ctx)
+	if err != nil {
+		return StorageUsageOutput{}, err
+	}
+	return commonModel.OK(report, commonModel.GET_STORAGE_USAGE_SUCCESS), nil
+}
+
+func (fileHandler *FileHandler) RecountStorageUsage(
+	ctx context.Context,
+	_ *EmptyInput,
+) (StorageUsageRecountOutput, error) {
+	result, err := fileHandler.fileService.RecountStorageUsage(ctx)
+	if err != nil {
+		return StorageUsageRecountOutput{}, err
+	}
+	return commonModel.OK(result, commonModel.RECOUNT_STORAGE_USAGE_SUCCESS), nil
+}
+
+func mapJobToFileJobResponse(jb jobModel.Job) FileJobResponse {
+	resp := FileJobResponse{ID: jb.ID, Status: string(jb.Status), Phase: jb.Phase, Error: jb.Error}
+	if jb.Payload != "" {
+		resp.Payload = json.RawMessage(jb.Payload)
+	}
+	return resp
+}
+
 // --- 以下为非 JSON 端点，仍走裸 gin（multipart 上传 / 二进制流式下载） ---
 
 func (fileHandler *FileHandler) UploadFile() gin.HandlerFunc {
@@ -12,6 +12,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
 	filemock "github.com/lin-snow/ech0/internal/test/mocks/filemock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -38,7 +40,7 @@ func newGinCtx(t *testing.T, rawURL string) (*gin.Context, *httptest.ResponseRec
 // 空 id 时 handler 自己短路返回 400，绝不调用 service。
 func TestStreamFileByID_EmptyID_NoServiceCall(t *testing.T) {
 	mockSvc := filemock.NewMockService(t) // 无任何 EXPECT：一旦被调用即 panic
-	h := NewFileHandler(mockSvc)
+	h := NewFileHandler(mockSvc, nil)
 
 	c, _ := newGinCtx(t, "/")
 	// 不设置 id 参数 -> ctx.Param("id") == ""
@@ -83,7 +85,7 @@ func TestStreamFileByID_DelegatesToService(t *testing.T) {
 				Return().
 				Once()
 
-			h := NewFileHandler(mockSvc)
+			h := NewFileHandler(mockSvc, nil)
 			c, rec := newGinCtx(t, "/files/file-1")
 			c.Params = gin.Params{{Key: "id", Value: "file-1"}}
 
@@ -117,7 +119,7 @@ func TestStreamFileByPath_BadQuery_NoServiceCall(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			mockSvc := filemock.NewMockService(t)
-			h := NewFileHandler(mockSvc)
+			h := NewFileHandler(mockSvc, nil)
 
 			c, rec := newGinCtx(t, tc.rawURL)
 			h.StreamFileByPath(c)
@@ -143,7 +145,7 @@ func TestStreamFileByPath_DelegatesParsedQuery(t *testing.T) {
 		Return().
 		Once()
 
-	h := NewFileHandler(mockSvc)
+	h := NewFileHandler(mockSvc, nil)
 	c, rec := newGinCtx(
 		t,
 		"/files/stream?storage_type=local&path=img%2Fa.png&name=a.png&content_type=image%2Fpng",
@@ -175,7 +177,7 @@ func TestListFiles(t *testing.T) {
 			Return(commonModel.FileListResultDto{Total: 7}, nil).
 			Once()
 
-		h := NewFileHandler(mockSvc)
+		h := NewFileHandler(mockSvc, nil)
 		out, err := h.ListFiles(context.Background(), &ListFilesInput{
 			Page: 2, PageSize: 20, Search: "kw", StorageType: "s3",
 		})
@@ -193,7 +195,7 @@ func TestListFiles(t *testing.T) {
 			Return(commonModel.FileListResultDto{}, errBoom).
 			Once()
 
-		h := NewFileHandler(mockSvc)
+		h := NewFileHandler(mockSvc, nil)
 		out, err := h.ListFiles(context.Background(), &ListFilesInput{})
 
 		require.ErrorIs(t, err, errBoom)
@@ -211,7 +213,7 @@ func TestListFileTree(t *testing.T) {
 			Return(commonModel.FileTreeResultDto{}, nil).
 			Once()
 
-		h := NewFileHandler(mockSvc)
+		h := NewFileHandler(mockSvc, nil)
 		out, err := h.ListFileTree(context.Background(), &ListFileTreeInput{StorageType: "local", Prefix: "img/"})
 
 		require.NoError(t, err)
@@ -226,7 +228,7 @@ func TestListFileTree(t *testing.T) {
 			Return(commonModel.FileTreeResultDto{}, errBoom).
 			Once()
 
-		h := NewFileHandler(mockSvc)
+		h := NewFileHandler(mockSvc, nil)
 		out, err := h.ListFileTree(context.Background(), &ListFileTreeInput{StorageType: "local"})
 
 		require.ErrorIs(t, err, errBoom)
@@ -242,7 +244,7 @@ func TestGetFileByID(t *testing.T) {
 			Return(commonModel.FileDto{ID: "f-9", Name: "a.png"}, nil).
 			Once()
 
-		h := NewFileHandler(mockSvc)
+		h := NewFileHandler(mockSvc, nil)
 		out, err := h.GetFileByID(context.Background(), &GetFileByIDInput{ID: "f-9"})
 
 		require.NoError(t, err)
@@ -257,7 +259,7 @@ func TestGetFileByID(t *testing.T) {
 			Return(commonModel.FileDto{}, errBoom).
 			Once()
 
-		h := NewFileHandler(mockSvc)
+		h := NewFileHandler(mockSvc, nil)
 		out, err := h.GetFileByID(context.Background(), &GetFileByIDInput{ID: "missing"})
 
 		require.ErrorIs(t, err, errBoom)
@@ -279,7 +281,7 @@ func TestUpdateFileMeta(t *testing.T) {
 			Return(commonModel.FileDto{ID: "f-1"}, nil).
 			Once()
 
-		h := NewFileHandler(mockSvc)
+		h := NewFileHandler(mockSvc, nil)
 		body := commonModel.UpdateFileMetaDto{Size: 123}
 		out, err := h.UpdateFileMeta(context.Background(), &UpdateFileMetaInput{ID: "f-1", Body: body})
 
@@ -296,7 +298,7 @@ func TestUpdateFileMeta(t *testing.T) {
 			Return(commonModel.FileDto{}, errBoom).
 			Once()
 
-		h := NewFileHandler(mockSvc)
+		h := NewFileHandler(mockSvc, nil)
 		out, err := h.UpdateFileMeta(context.Background(), &UpdateFileMetaInput{ID: "f-1"})
 
 		require.ErrorIs(t, err, errBoom)
@@ -312,7 +314,7 @@ func TestCreateExternalFile(t *testing.T) {
 			Return(commonModel.FileDto{ID: "ext-1"}, nil).
 			Once()
 
-		h := NewFileHandler(mockSvc)
+		h := NewFileHandler(mockSvc, nil)
 		out, err := h.CreateExternalFile(context.Background(), &CreateExternalFileInput{
 			Body: commonModel.CreateExternalFileDto{URL: "https://x/y.png"},
 		})
@@ -329,7 +331,7 @@ func TestCreateExternalFile(t *testing.T) {
 			Return(commonModel.FileDto{}, errBoom).
 			Once()
 
-		h := NewFileHandler(mockSvc)
+		h := NewFileHandler(mockSvc, nil)
 		out, err := h.CreateExternalFile(context.Background(), &CreateExternalFileInput{})
 
 		require.ErrorIs(t, err, errBoom)
@@ -342,7 +344,7 @@ func TestDeleteFile(t *testing.T) {
 		mockSvc := filemock.NewMockService(t)
 		mockSvc.EXPECT().DeleteFile(mock.Anything, "f-1").Return(nil).Once()
 
-		h := NewFileHandler(mockSvc)
+		h := NewFileHandler(mockSvc, nil)
 		out, err := h.DeleteFile(context.Background(), &DeleteFileInput{ID: "f-1"})
 
 		require.NoError(t, err)
@@ -354,7 +356,7 @@ func TestDeleteFile(t *testing.T) {
 		mockSvc := filemock.NewMockService(t)
 		mockSvc.EXPECT().DeleteFile(mock.Anything, "f-1").Return(errBoom).Once()
 
-		h := NewFileHandler(mockSvc)
+		h := NewFileHandler(mockSvc, nil)
 		out, err := h.DeleteFile(context.Background(), &DeleteFileInput{ID: "f-1"})
 
 		require.ErrorIs(t, err, errBoom)
@@ -370,7 +372,7 @@ func TestGetFilePresignURL(t *testing.T) {
 			Return(commonModel.PresignDto{ID: "p-1", PresignURL: "https://x/put"}, nil).
 			Once()
 
-		h := NewFileHandler(mockSvc)
+		h := NewFileHandler(mockSvc, nil)
 		out, err := h.GetFilePresignURL(context.Background(), &GetFilePresignURLInput{
 			Body: commonModel.GetPresignURLDto{FileName: "a.png"},
 		})
@@ -387,10 +389,120 @@ func TestGetFilePresignURL(t *testing.T) {
 			Return(commonModel.PresignDto{}, errBoom).
 			Once()
 
-		h := NewFileHandler(mockSvc)
+		h := NewFileHandler(mockSvc, nil)
 		out, err := h.GetFilePresignURL(context.Background(), &GetFilePresignURLInput{})
 
 		require.ErrorIs(t, err, errBoom)
 		assert.Equal(t, PresignOutput{}, out)
 	})
 }
+
+// ---------------------------------------------------------------------------
+// StartStorageMigration
+// ---------------------------------------------------------------------------
+
+// 校验（权限 / payload）未过时直接返回，不提交作业（jobManager 为 nil，一旦提交即 panic）。
+func TestStartStorageMigration_RejectedBeforeSubmit(t *testing.T) {
+	mockSvc := filemock.NewMockService(t)
+	mockSvc.EXPECT().
+		PrepareStorageMigration(mock.Anything, mock.Anything).
+		Return(errBoom).
+		Once()
+
+	h := NewFileHandler(mockSvc, nil)
+	out, err := h.StartStorageMigration(context.Background(), &StartStorageMigrationInput{})
+
+	require.ErrorIs(t, err, errBoom)
+	assert.Equal(t, FileJobOutput{}, out)
+}
+
+// ---------------------------------------------------------------------------
+// StartFileDedupe
+// ---------------------------------------------------------------------------
+
+func TestStartFileDedupe_RejectedBeforeSubmit(t *testing.T) {
+	mockSvc := filemock.NewMockService(t)
+	mockSvc.EXPECT().
+		PrepareFileDedupe(mock.Anything).
+		Return(errBoom).
+		Once()
+
+	h := NewFileHandler(mockSvc, nil)
+	out, err := h.StartFileDedupe(context.Background(), &StartFileDedupeInput{})
+
+	require.ErrorIs(t, err, errBoom)
+	assert.Equal(t, FileJobOutput{}, out)
+}
+
+// ---------------------------------------------------------------------------
+// Storage quota / usage
+// ---------------------------------------------------------------------------
+
+func TestStorageQuota(t *testing.T) {
+	t.Run("get returns setting", func(t *testing.T) {
+		mockSvc := filemock.NewMockService(t)
+		mockSvc.EXPECT().
+			GetStorageQuotaSetting(mock.Anything).
+			Return(settingModel.StorageQuotaSetting{UserQuota: 1 << 20}, nil).
+			Once()
+
+		h := NewFileHandler(mockSvc, nil)
+		out, err := h.GetStorageQuota(context.Background(), &EmptyInput{})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1<<20), out.Data.UserQuota)
+	})
+
+	t.Run("update forwards body", func(t *testing.T) {
+		mockSvc := filemock.NewMockService(t)
+		body := settingModel.StorageQuotaSettingDto{AdminQuota: 10, UserQuotas: map[string]int64{"u-1": 5}}
+		mockSvc.EXPECT().UpdateStorageQuotaSetting(mock.Anything, body).Return(nil).Once()
+
+		h := NewFileHandler(mockSvc, nil)
+		_, err := h.UpdateStorageQuota(context.Background(), &StorageQuotaInput{Body: body})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("update error", func(t *testing.T) {
+		mockSvc := filemock.NewMockService(t)
+		mockSvc.EXPECT().UpdateStorageQuotaSetting(mock.Anything, mock.Anything).Return(errBoom).Once()
+
+		h := NewFileHandler(mockSvc, nil)
+		out, err := h.UpdateStorageQuota(context.Background(), &StorageQuotaInput{})
+
+		require.ErrorIs(t, err, errBoom)
+		assert.Equal(t, EmptyOutput{}, out)
+	})
+}
+
+func TestStorageUsage(t *testing.T) {
+	t.Run("report", func(t *testing.T) {
+		mockSvc := filemock.NewMockService(t)
+		mockSvc.EXPECT().
+			GetStorageUsage(mock.Anything).
+			Return(fileModel.StorageUsageReport{Used: 42, Files: 2}, nil).
+			Once()
+
+		h := NewFileHandler(mockSvc, nil)
+		out, err := h.GetStorageUsage(context.Background(), &EmptyInput{})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), out.Data.Used)
+		assert.Equal(t, commonModel.GET_STORAGE_USAGE_SUCCESS, out.Message)
+	})
+
+	t.Run("recount error", func(t *testing.T) {
+		mockSvc := filemock.NewMockService(t)
+		mockSvc.EXPECT().
+			RecountStorageUsage(mock.Anything).
+			Return(fileModel.StorageUsageRecount{}, errBoom).
+			Once()
+
+		h := NewFileHandler(mockSvc, nil)
+		out, err := h.RecountStorageUsage(context.Background(), &EmptyInput{})
+
+		require.ErrorIs(t, err, errBoom)
+		assert.Equal(t, StorageUsageRecountOutput{}, out)
+	})
+}
@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package handler
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	errorUtil "github.com/lin-snow/ech0/internal/util/err"
+)
+
+// 断点续传走 tus 1.0（core + creation + termination + expiration），裸 gin 实现：
+// 协议靠状态码与头部通信，请求体是原始字节流，不适合 JSON 端点。
+// OPTIONS 由全局 CORS 中间件统一应答，这里不单独提供能力发现。
+const (
+	tusVersion         = "1.0.0"
+	tusExtensions      = "creation,termination,expiration"
+	tusOffsetMediaType = "application/offset+octet-stream"
+	// tusFileIDHeader 是 Ech0 的扩展头：会话完成后携带入库文件的 ID，前端据此取 FileDto。
+	tusFileIDHeader = "Ech0-File-Id"
+)
+
+// CreateResumableUpload 创建断点续传会话（tus POST）。Upload-Metadata 支持
+// filename / category / storage_type 三个键，值按协议为 base64。
+func (fileHandler *FileHandler) CreateResumableUpload(ctx *gin.Context) {
+	if !checkTusResumable(ctx) {
+		return
+	}
+	length, err := strconv.ParseInt(ctx.GetHeader("Upload-Length"), 10, 64)
+	if err != nil {
+		writeTusError(ctx, errors.New(commonModel.UPLOAD_LENGTH_INVALID))
+		return
+	}
+	meta, err := parseTusMetadata(ctx.GetHeader("Upload-Metadata"))
+	if err != nil {
+		writeTusError(ctx, errors.New(commonModel.INVALID_REQUEST_BODY))
+		return
+	}
+
+	upload, err := fileHandler.fileService.CreateResumableUpload(ctx.Request.Context(), fileModel.ResumableUploadCreate{
+		Filename:    meta["filename"],
+		Category:    meta["category"],
+		StorageType: meta["storage_type"],
+		Length:      length,
+	})
+	if err != nil {
+		writeTusError(ctx, err)
+		return
+	}
+	ctx.Header("Location", strings.TrimSuffix(ctx.Request.URL.Path, "/")+"/"+upload.ID)
+	ctx.Header("Upload-Offset", "0")
+	ctx.Header("Upload-Expires", upload.ExpiresAt.Format(http.TimeFormat))
+	ctx.Status(http.StatusCreated)
+}
+
+// GetResumableUpload 返回会话当前偏移量（tus HEAD），已完成的会话额外带入库文件 ID。
+func (fileHandler *FileHandler) GetResumableUpload(ctx *gin.Context) {
+	if !checkTusResumable(ctx) {
+		return
+	}
+	upload, err := fileHandler.fileService.GetResumableUpload(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		writeTusError(ctx, err)
+		return
+	}
+	ctx.Header("Cache-Control", "no-store")
+	writeTusState(ctx, upload)
+	ctx.Status(http.StatusOK)
+}
+
+// PatchResumableUpload 追加一段字节（tus PATCH）。Upload-Offset 须与服务端一致，否则 409；
+// 同一会话已有写入在进行时 423。
+func (fileHandler *FileHandler) PatchResumableUpload(ctx *gin.Context) {
+	if !checkTusResumable(ctx) {
+		return
+	}
+	if ctx.ContentType() != tusOffsetMediaType {
+		ctx.Status(http.StatusUnsupportedMediaType)
+		return
+	}
+	offset, err := strconv.ParseInt(ctx.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		writeTusError(ctx, errors.New(commonModel.UPLOAD_OFFSET_MISMATCH))
+		return
+	}
+
+	upload, err := fileHandler.fileService.AppendResumableUpload(
+		ctx.Request.Context(), ctx.Param("id"), offset, ctx.Request.Body,
+	)
+	if err != nil {
+		if upload.ID != "" {
+			writeTusState(ctx, upload)
+		}
+		writeTusError(ctx, err)
+		return
+	}
+	writeTusState(ctx, upload)
+	ctx.Status(http.StatusNoContent)
+}
+
+// DeleteResumableUpload 放弃会话并删除已收到的分片（tus termination）。
+func (fileHandler *FileHandler) DeleteResumableUpload(ctx *gin.Context) {
+	if !checkTusResumable(ctx) {
+		return
+	}
+	if err := fileHandler.fileService.DeleteResumableUpload(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		writeTusError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// checkTusResumable 校验 Tus-Resumable 版本头，并给所有 tus 响应带上协议头。
+func checkTusResumable(ctx *gin.Context) bool {
+	ctx.Header("Tus-Resumable", tusVersion)
+	if ctx.GetHeader("Tus-Resumable") != tusVersion {
+		ctx.Header("Tus-Version", tusVersion)
+		ctx.Header("Tus-Extension", tusExtensions)
+		ctx.Status(http.StatusPreconditionFailed)
+		return false
+	}
+	return true
+}
+
+func writeTusState(ctx *gin.Context, upload fileModel.ResumableUpload) {
+	ctx.Header("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	ctx.Header("Upload-Length", strconv.FormatInt(upload.Length, 10))
+	if !upload.ExpiresAt.IsZero() {
+		ctx.Header("Upload-Expires", upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	}
+	if upload.Completed() {
+		ctx.Header(tusFileIDHeader, upload.FileID)
+	}
+}
+
+// writeTusError 把业务错误映射成 tus 约定的状态码；响应体仍是统一的失败封套，供前端展示原因。
+func writeTusError(ctx *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch err.Error() {
+	case commonModel.NO_PERMISSION_DENIED:
+		status = http.StatusForbidden
+	case commonModel.UPLOAD_SESSION_NOT_FOUND:
+		status = http.StatusNotFound
+	case commonModel.UPLOAD_OFFSET_MISMATCH:
+		status = http.StatusConflict
+	case commonModel.UPLOAD_SESSION_BUSY:
+		status = http.StatusLocked
+	case commonModel.UPLOAD_SESSION_LIMIT:
+		status = http.StatusTooManyRequests
+	case commonModel.FILE_SIZE_EXCEED_LIMIT, commonModel.STORAGE_QUOTA_EXCEEDED:
+		status = http.StatusRequestEntityTooLarge
+	case commonModel.FILE_TYPE_NOT_ALLOWED,
+		commonModel.UPLOAD_LENGTH_INVALID,
+		commonModel.INVALID_REQUEST_BODY:
+		status = http.StatusBadRequest
+	}
+	msg := errorUtil.HandleError(&commonModel.ServerError{Err: err})
+	ctx.AbortWithStatusJSON(status, commonModel.Fail[string](msg))
+}
+
+// parseTusMetadata 解析 Upload-Metadata："key base64value, key2 base64value2"，值可省略。
+func parseTusMetadata(raw string) (map[string]string, error) {
+	meta := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, encoded, _ := strings.Cut(pair, " ")
+		value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, err
+		}
+		meta[key] = string(value)
+	}
+	return meta, nil
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	filemock "github.com/lin-snow/ech0/internal/test/mocks/filemock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTusEngine 把 tus 端点挂到一个裸 engine 上，路径与 router 一致。
+func newTusEngine(h *FileHandler) *gin.Engine {
+	r := gin.New()
+	g := r.Group("/api/upload/tus")
+	g.POST("", h.CreateResumableUpload)
+	g.HEAD("/:id", h.GetResumableUpload)
+	g.PATCH("/:id", h.PatchResumableUpload)
+	g.DELETE("/:id", h.DeleteResumableUpload)
+	return r
+}
+
+func serveTus(r *gin.Engine, method, target, body string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Tus-Resumable", tusVersion)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestTus_CreateParsesMetadataAndReturnsLocation(t *testing.T) {
+	mockSvc := filemock.NewMockService(t)
+	expires := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockSvc.EXPECT().
+		CreateResumableUpload(mock.Anything, fileModel.ResumableUploadCreate{
+			Filename: "clip.mp4", Category: "video", StorageType: "object", Length: 1024,
+		}).
+		Return(fileModel.ResumableUpload{ID: "u-1", Length: 1024, ExpiresAt: expires}, nil)
+
+	rec := serveTus(newTusEngine(NewFileHandler(mockSvc, nil)), http.MethodPost, "/api/upload/tus", "", map[string]string{
+		"Upload-Length": "1024",
+		// clip.mp4 / video / object
+		"Upload-Metadata": "filename Y2xpcC5tcDQ=,category dmlkZW8=, storage_type b2JqZWN0",
+	})
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "/api/upload/tus/u-1", rec.Header().Get("Location"))
+	assert.Equal(t, "0", rec.Header().Get("Upload-Offset"))
+	assert.Equal(t, "Fri, 02 Jan 2026 03:04:05 GMT", rec.Header().Get("Upload-Expires"))
+	assert.Equal(t, tusVersion, rec.Header().Get("Tus-Resumable"))
+}
+
+// 缺少或版本不符的 Tus-Resumable 直接 412，不触达 service。
+func TestTus_RejectsUnsupportedVersion(t *testing.T) {
+	mockSvc := filemock.NewMockService(t)
+	req := httptest.NewRequest(http.MethodPost, "/api/upload/tus", nil)
+	req.Header.Set("Upload-Length", "10")
+	rec := httptest.NewRecorder()
+	newTusEngine(NewFileHandler(mockSvc, nil)).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	assert.Equal(t, tusVersion, rec.Header().Get("Tus-Version"))
+}
+
+func TestTus_PatchReportsOffsetAndFileID(t *testing.T) {
+	mockSvc := filemock.NewMockService(t)
+	mockSvc.EXPECT().
+		AppendResumableUpload(mock.Anything, "u-1", int64(5), mock.Anything).
+		Return(fileModel.ResumableUpload{ID: "u-1", Length: 10, Offset: 10, FileID: "file-9"}, nil)
+
+	rec := serveTus(newTusEngine(NewFileHandler(mockSvc, nil)), http.MethodPatch, "/api/upload/tus/u-1", "world", map[string]string{
+		"Content-Type":  tusOffsetMediaType,
+		"Upload-Offset": "5",
+	})
+
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "10", rec.Header().Get("Upload-Offset"))
+	assert.Equal(t, "file-9", rec.Header().Get(tusFileIDHeader))
+}
+
+func TestTus_PatchRequiresOffsetContentType(t *testing.T) {
+	mockSvc := filemock.NewMockService(t)
+	rec := serveTus(newTusEngine(NewFileHandler(mockSvc, nil)), http.MethodPatch, "/api/upload/tus/u-1", "x", map[string]string{
+		"Content-Type":  "application/json",
+		"Upload-Offset": "0",
+	})
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+}
+
+func TestTus_ErrorStatusMapping(t *testing.T) {
+	cases := []struct {
+		err  string
+		want int
+	}{
+		{commonModel.UPLOAD_OFFSET_MISMATCH, http.StatusConflict},
+		{commonModel.UPLOAD_SESSION_NOT_FOUND, http.StatusNotFound},
+		{commonModel.UPLOAD_SESSION_BUSY, http.StatusLocked},
+		{commonModel.STORAGE_QUOTA_EXCEEDED, http.StatusRequestEntityTooLarge},
+		{commonModel.FILE_SIZE_EXCEED_LIMIT, http.StatusRequestEntityTooLarge},
+		{commonModel.FILE_TYPE_NOT_ALLOWED, http.StatusBadRequest},
+		{"disk full", http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		t.Run(tc.err, func(t *testing.T) {
+			mockSvc := filemock.NewMockService(t)
+			mockSvc.EXPECT().
+				AppendResumableUpload(mock.Anything, "u-1", int64(0), mock.Anything).
+				Return(fileModel.ResumableUpload{}, errors.New(tc.err))
+
+			rec := serveTus(newTusEngine(NewFileHandler(mockSvc, nil)), http.MethodPatch, "/api/upload/tus/u-1", "x", map[string]string{
+				"Content-Type":  tusOffsetMediaType,
+				"Upload-Offset": "0",
+			})
+			assert.Equal(t, tc.want, rec.Code)
+		})
+	}
+}
+
+func TestParseTusMetadata(t *testing.T) {
+	meta, err := parseTusMetadata("filename YS5wbmc=, flag")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"filename": "a.png", "flag": ""}, meta)
+
+	_, err = parseTusMetadata("filename !!!")
+	assert.Error(t, err)
+}
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package handler 暴露通用作业（job.Manager）的历史查询与取消接口（Huma type-first）。
+// 各领域自己的状态端点（重建索引、迁移、导出…）仍按类型轮询；这里是跨类型的统一视图。
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/lin-snow/ech0/internal/job"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	jobModel "github.com/lin-snow/ech0/internal/model/job"
+)
+
+// errJobNotFound 是对外的作业不存在提示。
+var errJobNotFound = errors.New("作业不存在")
+
+type JobHandler struct {
+	jobManager *job.Manager
+}
+
+func NewJobHandler(jobManager *job.Manager) *JobHandler {
+	return &JobHandler{jobManager: jobManager}
+}
+
+// JobView 是一次作业提交的对外视图。payload 用 RawMessage 内嵌成对象，结构由 type 决定。
+type JobView struct {
+	ID         string          `json:"id" doc:"作业 ID"`
+	Type       string          `json:"type" doc:"作业类型：reindex/migration/export/sync/publish" example:"export"`
+	Status     string          `json:"status" doc:"作业状态：pending/running/success/failed/cancelled" example:"success"`
+	Phase      string          `json:"phase,omitempty" doc:"当前（或结束时）阶段"`
+	Error      string          `json:"error,omitempty" doc:"失败原因（status=failed 时）"`
+	Payload    json.RawMessage `json:"payload,omitempty" doc:"领域输入 / 进度 / 结果，结构随 type 而定"`
+	Attempts   int             `json:"attempts" doc:"已启动次数（重启后续跑会累加）"`
+	CreatedAt  int64           `json:"created_at" doc:"提交时间（Unix 秒）"`
+	StartedAt  *int64          `json:"started_at,omitempty" doc:"最近一次开始时间（Unix 秒）；排队中为空"`
+	FinishedAt *int64          `json:"finished_at,omitempty" doc:"结束时间（Unix 秒）"`
+	Duration   *int64          `json:"duration,omitempty" doc:"耗时（秒），仅已结束的作业有"`
+}
+
+type (
+	ListJobsInput struct {
+		Type     string `query:"type" doc:"按作业类型过滤"`
+		Status   string `query:"status" enum:"pending,running,success,failed,cancelled," doc:"按状态过滤"`
+		Page     int    `query:"page" doc:"页码，从 1 开始"`
+		PageSize int    `query:"pageSize" doc:"每页数量，默认 20，最多 100"`
+	}
+	GetJobInput struct {
+		ID string `path:"id" doc:"作业 ID"`
+	}
+	CancelJobInput struct {
+		ID string `path:"id" doc:"作业 ID"`
+	}
+)
+
+type (
+	JobListOutput = commonModel.Result[commonModel.PageQueryResult[[]JobView]]
+	JobOutput     = commonModel.Result[JobView]
+)
+
+func toJobView(jb jobModel.Job) JobView {
+	view := JobView{
+		ID:         jb.ID,
+		Type:       jb.Type,
+		Status:     string(jb.Status),
+		Phase:      jb.Phase,
+		Error:      jb.Error,
+		Attempts:   jb.Attempts,
+		CreatedAt:  jb.CreatedAt,
+		StartedAt:  jb.StartedAt,
+		FinishedAt: jb.FinishedAt,
+		Duration:   jb.Duration(),
+	}
+	if jb.Payload != "" && json.Valid([]byte(jb.Payload)) {
+		view.Payload = json.RawMessage(jb.Payload)
+	}
+	return view
+}
+
+// ListJobs 按提交时间倒序分页列出作业历史，含排队中与在跑的作业。
+func (jobHandler *JobHandler) ListJobs(ctx context.Context, in *ListJobsInput) (JobListOutput, error) {
+	page, pageSize := in.Page, in.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+	rows, total, err := jobHandler.jobManager.List(ctx, job.ListQuery{
+		Type:   in.Type,
+		Status: jobModel.Status(in.Status),
+		Limit:  pageSize,
+		Offset: (page - 1) * pageSize,
+	})
+	if err != nil {
+		return JobListOutput{}, err
+	}
+	items := make([]JobView, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, toJobView(row))
+	}
+	return commonModel.OK(commonModel.PageQueryResult[[]JobView]{Total: total, Items: items}), nil
+}
+
+func (jobHandler *JobHandler) GetJob(ctx context.Context, in *GetJobInput) (JobOutput, error) {
+	jb, err := jobHandler.jobManager.GetByID(ctx, in.ID)
+	if errors.Is(err, job.ErrNotFound) {
+		return JobOutput{}, errJobNotFound
+	}
+	if err != nil {
+		return JobOutput{}, err
+	}
+	return commonModel.OK(toJobView(jb)), nil
+}
+
+// CancelJob 取消指定作业：在跑的协作式退出（轮询收敛到 cancelled），排队中的直接置 cancelled；
+// 已结束的作业原样返回。
+func (jobHandler *JobHandler) CancelJob(ctx context.Context, in *CancelJobInput) (JobOutput, error) {
+	err := jobHandler.jobManager.CancelByID(ctx, in.ID)
+	if errors.Is(err, job.ErrNotFound) {
+		return JobOutput{}, errJobNotFound
+	}
+	if err != nil {
+		return JobOutput{}, err
+	}
+	return jobHandler.GetJob(ctx, &GetJobInput{ID: in.ID})
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/job"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	jobModel "github.com/lin-snow/ech0/internal/model/job"
+	jobRepository "github.com/lin-snow/ech0/internal/repository/job"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func i64(v int64) *int64 { return &v }
+
+// newJobHandlerWithDB 用 DB-backed job manager 构造 handler。
+func newJobHandlerWithDB(t *testing.T) (*JobHandler, *jobRepository.JobRepository) {
+	t.Helper()
+	db := helpers.NewTestDB(t)
+	repo := jobRepository.NewJobRepository(func() *gorm.DB { return db })
+	return NewJobHandler(job.NewManager(repo)), repo
+}
+
+// ---------------------------------------------------------------------------
+// toJobView（纯函数）
+// ---------------------------------------------------------------------------
+
+func TestToJobView_DurationAndPayload(t *testing.T) {
+	view := toJobView(jobModel.Job{
+		ID:         "job-1",
+		Type:       jobModel.TypeExport,
+		Status:     jobModel.StatusSuccess,
+		Payload:    `{"file":"a.zip"}`,
+		Attempts:   1,
+		StartedAt:  i64(100),
+		FinishedAt: i64(130),
+	})
+
+	assert.Equal(t, "success", view.Status)
+	require.NotNil(t, view.Duration)
+	assert.Equal(t, int64(30), *view.Duration)
+	assert.JSONEq(t, `{"file":"a.zip"}`, string(view.Payload))
+
+	// 非法 JSON 的 payload 不内嵌，免得整个响应序列化失败。
+	broken := toJobView(jobModel.Job{Status: jobModel.StatusPending, Payload: "not-json"})
+	assert.Nil(t, broken.Payload)
+	assert.Nil(t, broken.Duration)
+}
+
+// ---------------------------------------------------------------------------
+// ListJobs / GetJob / CancelJob
+// ---------------------------------------------------------------------------
+
+func TestListJobs_FiltersAndPaginates(t *testing.T) {
+	h, repo := newJobHandlerWithDB(t)
+	ctx := context.Background()
+	for _, id := range []string{"job-1", "job-2", "job-3"} {
+		require.NoError(t, repo.Create(ctx, &jobModel.Job{
+			ID:     id,
+			Type:   jobModel.TypeExport,
+			Status: jobModel.StatusSuccess,
+		}))
+	}
+	require.NoError(t, repo.Create(ctx, &jobModel.Job{
+		ID:     "job-4",
+		Type:   jobModel.TypeReindex,
+		Status: jobModel.StatusFailed,
+	}))
+
+	out, err := h.ListJobs(ctx, &ListJobsInput{Type: jobModel.TypeExport, Page: 2, PageSize: 2})
+
+	require.NoError(t, err)
+	assert.Equal(t, commonModel.DEFAULT_SUCCESS_CODE, out.Code)
+	assert.Equal(t, int64(3), out.Data.Total)
+	require.Len(t, out.Data.Items, 1)
+	assert.Equal(t, "job-1", out.Data.Items[0].ID)
+}
+
+func TestGetJob_NotFound(t *testing.T) {
+	h, _ := newJobHandlerWithDB(t)
+
+	_, err := h.GetJob(context.Background(), &GetJobInput{ID: "missing"})
+
+	require.ErrorIs(t, err, errJobNotFound)
+}
+
+func TestCancelJob_PendingBecomesCancelled(t *testing.T) {
+	h, repo := newJobHandlerWithDB(t)
+	require.NoError(t, repo.Create(context.Background(), &jobModel.Job{
+		ID:     "job-1",
+		Type:   jobModel.TypeExport,
+		Status: jobModel.StatusPending,
+	}))
+
+	out, err := h.CancelJob(context.Background(), &CancelJobInput{ID: "job-1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, string(jobModel.StatusCancelled), out.Data.Status)
+	assert.NotNil(t, out.Data.FinishedAt)
+}
+
+func TestCancelJob_NotFound(t *testing.T) {
+	h, _ := newJobHandlerWithDB(t)
+
+	_, err := h.CancelJob(context.Background(), &CancelJobInput{ID: "missing"})
+
+	require.ErrorIs(t, err, errJobNotFound)
+}
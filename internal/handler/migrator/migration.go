@@ -1,11 +1,12 @@
 // SPDX-License-Identifier: AGPL-3.0-or-later
 // Copyright (C) 2025-2026 lin-snow
 
-// Package handler 暴露数据迁移（导入/导出快照）的 HTTP 接口。
+// Package handler 暴露数据迁移（导入/导出快照、实例间同步）的 HTTP 接口。
 package handler
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	response "github.com/lin-snow/ech0/internal/handler/response"
@@ -34,13 +35,42 @@ type (
 	StartExportInput        struct {
 		Body migratorModel.StartExportRequest
 	}
-	GetExportStatusInput struct{}
-	CancelExportInput    struct{}
+	GetExportStatusInput   struct{}
+	CancelExportInput      struct{}
+	GetSyncSettingInput    struct{}
+	UpdateSyncSettingInput struct {
+		Body migratorModel.SyncSetting
+	}
+	StartSyncInput            struct{}
+	GetSyncStatusInput        struct{}
+	CancelSyncInput           struct{}
+	GetPublishSettingInput    struct{}
+	UpdatePublishSettingInput struct {
+		Body migratorModel.PublishSetting
+	}
+	StartPublishInput     struct{}
+	GetPublishStatusInput struct{}
+	ListSnapshotsInput    struct{}
+	RestoreSnapshotInput  struct {
+		Name string `path:"name" doc:"快照文件名（见 GET /migration/snapshots）"`
+		// Body 只在快照加密时需要；省略则用已存口令解密。
+		Body *migratorModel.ArchiveKeys
+	}
+	GetEncryptionSettingInput    struct{}
+	UpdateEncryptionSettingInput struct {
+		Body migratorModel.EncryptionSetting
+	}
 )
 
 type (
 	GlobalMigrationOutput = commonModel.Result[migratorModel.GlobalMigrationStateDTO]
 	ExportOutput          = commonModel.Result[migratorModel.ExportStateDTO]
+	SyncSettingOutput     = commonModel.Result[migratorModel.SyncSetting]
+	SyncOutput            = commonModel.Result[migratorModel.SyncStateDTO]
+	PublishSettingOutput  = commonModel.Result[migratorModel.PublishSetting]
+	PublishOutput         = commonModel.Result[migratorModel.PublishStateDTO]
+	SnapshotListOutput    = commonModel.Result[[]migratorModel.SnapshotEntry]
+	EncryptionOutput      = commonModel.Result[migratorModel.EncryptionSetting]
 	EmptyOutput           = commonModel.Result[any]
 )
 
@@ -99,6 +129,117 @@ func (h *MigrationHandler) CancelExport(ctx context.Context, _ *CancelExportInpu
 	return commonModel.OK(data), nil
 }
 
+func (h *MigrationHandler) GetSyncSetting(ctx context.Context, _ *GetSyncSettingInput) (SyncSettingOutput, error) {
+	data, err := h.migrationService.GetSyncSetting(ctx)
+	if err != nil {
+		return SyncSettingOutput{}, err
+	}
+	return commonModel.OK(data), nil
+}
+
+func (h *MigrationHandler) UpdateSyncSetting(ctx context.Context, in *UpdateSyncSettingInput) (EmptyOutput, error) {
+	if err := h.migrationService.UpdateSyncSetting(ctx, in.Body); err != nil {
+		return EmptyOutput{}, err
+	}
+	return commonModel.OK[any](nil), nil
+}
+
+func (h *MigrationHandler) StartSync(ctx context.Context, _ *StartSyncInput) (SyncOutput, error) {
+	data, err := h.migrationService.StartSync(ctx)
+	if err != nil {
+		return SyncOutput{}, err
+	}
+	return commonModel.OK(data), nil
+}
+
+func (h *MigrationHandler) GetSyncStatus(ctx context.Context, _ *GetSyncStatusInput) (SyncOutput, error) {
+	data, err := h.migrationService.GetSyncStatus(ctx)
+	if err != nil {
+		return SyncOutput{}, err
+	}
+	return commonModel.OK(data), nil
+}
+
+func (h *MigrationHandler) CancelSync(ctx context.Context, _ *CancelSyncInput) (SyncOutput, error) {
+	data, err := h.migrationService.CancelSync(ctx)
+	if err != nil {
+		return SyncOutput{}, err
+	}
+	return commonModel.OK(data), nil
+}
+
+func (h *MigrationHandler) GetPublishSetting(ctx context.Context, _ *GetPublishSettingInput) (PublishSettingOutput, error) {
+	data, err := h.migrationService.GetPublishSetting(ctx)
+	if err != nil {
+		return PublishSettingOutput{}, err
+	}
+	return commonModel.OK(data), nil
+}
+
+func (h *MigrationHandler) UpdatePublishSetting(ctx context.Context, in *UpdatePublishSettingInput) (EmptyOutput, error) {
+	if err := h.migrationService.UpdatePublishSetting(ctx, in.Body); err != nil {
+		return EmptyOutput{}, err
+	}
+	return commonModel.OK[any](nil), nil
+}
+
+func (h *MigrationHandler) StartPublish(ctx context.Context, _ *StartPublishInput) (PublishOutput, error) {
+	data, err := h.migrationService.StartPublish(ctx)
+	if err != nil {
+		return PublishOutput{}, err
+	}
+	return commonModel.OK(data), nil
+}
+
+func (h *MigrationHandler) GetPublishStatus(ctx context.Context, _ *GetPublishStatusInput) (PublishOutput, error) {
+	data, err := h.migrationService.GetPublishStatus(ctx)
+	if err != nil {
+		return PublishOutput{}, err
+	}
+	return commonModel.OK(data), nil
+}
+
+func (h *MigrationHandler) ListSnapshots(ctx context.Context, _ *ListSnapshotsInput) (SnapshotListOutput, error) {
+	data, err := h.migrationService.ListSnapshots(ctx)
+	if err != nil {
+		return SnapshotListOutput{}, err
+	}
+	return commonModel.OK(data), nil
+}
+
+func (h *MigrationHandler) RestoreSnapshot(ctx context.Context, in *RestoreSnapshotInput) (GlobalMigrationOutput, error) {
+	var keys migratorModel.ArchiveKeys
+	if in.Body != nil {
+		keys = *in.Body
+	}
+	data, err := h.migrationService.RestoreSnapshot(ctx, in.Name, keys)
+	if err != nil {
+		return GlobalMigrationOutput{}, err
+	}
+	return commonModel.OK(data), nil
+}
+
+func (h *MigrationHandler) GetEncryptionSetting(
+	ctx context.Context,
+	_ *GetEncryptionSettingInput,
+) (EncryptionOutput, error) {
+	data, err := h.migrationService.GetEncryptionSetting(ctx)
+	if err != nil {
+		return EncryptionOutput{}, err
+	}
+	return commonModel.OK(data), nil
+}
+
+func (h *MigrationHandler) UpdateEncryptionSetting(
+	ctx context.Context,
+	in *UpdateEncryptionSettingInput,
+) (EmptyOutput, error) {
+	if err := h.migrationService.UpdateEncryptionSetting(ctx, in.Body); err != nil {
+		return EmptyOutput{}, err
+	}
+	return commonModel.OK[any](nil), nil
+}
+
 // --- 以下为非 JSON 端点，仍走裸 gin（multipart 上传 / 二进制快照下载） ---
 
 func (h *MigrationHandler) UploadSourceZip() gin.HandlerFunc {
@@ -112,7 +253,12 @@ func (h *MigrationHandler) UploadSourceZip() gin.HandlerFunc {
 			return response.Response{Msg: commonModel.INVALID_REQUEST_BODY, Err: err}
 		}
 
-		data, err := h.migrationService.UploadSourceZip(ctx.Request.Context(), sourceType, file)
+		// 加密归档的凭据随表单提交（passphrase / identity 均可省略，省略时用已存口令）。
+		keys := migratorModel.ArchiveKeys{
+			Passphrase: ctx.PostForm("passphrase"),
+			Identity:   ctx.PostForm("identity"),
+		}
+		data, err := h.migrationService.UploadSourceZip(ctx.Request.Context(), sourceType, file, keys)
 		if err != nil {
 			return response.Response{Msg: "", Err: err}
 		}
@@ -120,12 +266,47 @@ func (h *MigrationHandler) UploadSourceZip() gin.HandlerFunc {
 	})
 }
 
-// DownloadExport 取回上一次导出作业的产物（二进制 zip）。format 决定取快照还是胶囊槽位。
+// DownloadExport 取回导出产物（二进制 zip）。format 决定取快照还是胶囊槽位，name 指定
+// 留存快照中的某一份（省略即最新一份）。
 func (h *MigrationHandler) DownloadExport() gin.HandlerFunc {
 	return response.Execute(func(ctx *gin.Context) response.Response {
-		if err := h.migrationService.DownloadExport(ctx, ctx.Request.Context(), ctx.Query("format")); err != nil {
+		err := h.migrationService.DownloadExport(ctx, ctx.Request.Context(), ctx.Query("format"), ctx.Query("name"))
+		if err != nil {
 			return response.Response{Msg: "", Err: err}
 		}
 		return response.Response{Msg: commonModel.EXPORT_SNAPSHOT_SUCCESS}
 	})
 }
+
+// PullSyncChanges 是对端拉取增量胶囊的出口（二进制 zip，本端时钟随 X-Ech0-Sync-Cursor 头下发）。
+func (h *MigrationHandler) PullSyncChanges() gin.HandlerFunc {
+	return response.Execute(func(ctx *gin.Context) response.Response {
+		since, err := strconv.ParseInt(ctx.DefaultQuery("since", "0"), 10, 64)
+		if err != nil {
+			return response.Response{Msg: commonModel.INVALID_REQUEST_BODY, Err: err}
+		}
+		includePrivate := ctx.Query("include_private") == "true"
+		if err := h.migrationService.PullSyncChanges(ctx, ctx.Request.Context(), since, includePrivate); err != nil {
+			return response.Response{Msg: "", Err: err}
+		}
+		return response.Response{Msg: commonModel.SUCCESS_MESSAGE}
+	})
+}
+
+// PushSyncChanges 是对端推送增量胶囊的入口：请求体即 zip，合并报告以 JSON 返回。
+func (h *MigrationHandler) PushSyncChanges() gin.HandlerFunc {
+	return response.Execute(func(ctx *gin.Context) response.Response {
+		base, err := strconv.ParseInt(ctx.DefaultQuery("base", "0"), 10, 64)
+		if err != nil {
+			return response.Response{Msg: commonModel.INVALID_REQUEST_BODY, Err: err}
+		}
+		includePrivate := ctx.Query("include_private") == "true"
+		data, err := h.migrationService.PushSyncChanges(
+			ctx.Request.Context(), ctx.Request.Body, base, ctx.Query("conflict"), includePrivate,
+		)
+		if err != nil {
+			return response.Response{Msg: "", Err: err}
+		}
+		return response.Response{Msg: commonModel.SUCCESS_MESSAGE, Data: data}
+	})
+}
@@ -240,3 +240,50 @@ func TestCancelExport(t *testing.T) {
 		assert.Equal(t, ExportOutput{}, out)
 	})
 }
+
+func TestRestoreSnapshot(t *testing.T) {
+	t.Run("success forwards path name", func(t *testing.T) {
+		mockSvc := migratormock.NewMockService(t)
+		mockSvc.EXPECT().
+			RestoreSnapshot(mock.Anything, "ech0_snapshot_2026-01-01_00-00-00.zip", migratorModel.ArchiveKeys{}).
+			Return(migratorModel.GlobalMigrationStateDTO{Status: "pending", SourceType: "ech0"}, nil).
+			Once()
+
+		h := NewMigrationHandler(mockSvc)
+		out, err := h.RestoreSnapshot(context.Background(), &RestoreSnapshotInput{Name: "ech0_snapshot_2026-01-01_00-00-00.zip"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "pending", out.Data.Status)
+	})
+
+	t.Run("forwards archive keys from body", func(t *testing.T) {
+		keys := migratorModel.ArchiveKeys{Passphrase: "correct horse"}
+		mockSvc := migratormock.NewMockService(t)
+		mockSvc.EXPECT().
+			RestoreSnapshot(mock.Anything, "ech0_snapshot_2026-01-01_00-00-00.zip", keys).
+			Return(migratorModel.GlobalMigrationStateDTO{Status: "pending"}, nil).
+			Once()
+
+		h := NewMigrationHandler(mockSvc)
+		_, err := h.RestoreSnapshot(context.Background(), &RestoreSnapshotInput{
+			Name: "ech0_snapshot_2026-01-01_00-00-00.zip",
+			Body: &keys,
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockSvc := migratormock.NewMockService(t)
+		mockSvc.EXPECT().
+			RestoreSnapshot(mock.Anything, mock.Anything, mock.Anything).
+			Return(migratorModel.GlobalMigrationStateDTO{}, errBoom).
+			Once()
+
+		h := NewMigrationHandler(mockSvc)
+		out, err := h.RestoreSnapshot(context.Background(), &RestoreSnapshotInput{})
+
+		require.ErrorIs(t, err, errBoom)
+		assert.Equal(t, GlobalMigrationOutput{}, out)
+	})
+}
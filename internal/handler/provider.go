@@ -15,27 +15,35 @@ import (
 	embeddingHandler "github.com/lin-snow/ech0/internal/handler/embedding"
 	fileHandler "github.com/lin-snow/ech0/internal/handler/file"
 	initHandler "github.com/lin-snow/ech0/internal/handler/init"
+	jobHandler "github.com/lin-snow/ech0/internal/handler/job"
 	migratorHandler "github.com/lin-snow/ech0/internal/handler/migrator"
+	searchHandler "github.com/lin-snow/ech0/internal/handler/search"
 	settingHandler "github.com/lin-snow/ech0/internal/handler/setting"
 	userHandler "github.com/lin-snow/ech0/internal/handler/user"
 	webHandler "github.com/lin-snow/ech0/internal/handler/web"
 	"github.com/lin-snow/ech0/internal/mcp"
+	"github.com/lin-snow/ech0/internal/micropub"
+	"github.com/lin-snow/ech0/internal/webmention"
 )
 
 var (
-	WebSet       = wire.NewSet(webHandler.NewWebHandler)
-	UserSet      = wire.NewSet(userHandler.NewUserHandler)
-	AuthSet      = wire.NewSet(authHandler.NewAuthHandler)
-	EchoSet      = wire.NewSet(echoHandler.NewEchoHandler)
-	FileSet      = wire.NewSet(fileHandler.NewFileHandler)
-	CommentSet   = wire.NewSet(commentHandler.NewCommentHandler)
-	InitSet      = wire.NewSet(initHandler.NewInitHandler)
-	CommonSet    = wire.NewSet(commonHandler.NewCommonHandler)
-	SettingSet   = wire.NewSet(settingHandler.NewSettingHandler)
-	ConnectSet   = wire.NewSet(connectHandler.NewConnectHandler)
-	DashboardSet = wire.NewSet(dashboardHandler.NewDashboardHandler)
-	CopilotSet   = wire.NewSet(copilotHandler.NewCopilotHandler)
-	EmbeddingSet = wire.NewSet(embeddingHandler.NewEmbeddingHandler)
-	MigrationSet = wire.NewSet(migratorHandler.NewMigrationHandler)
-	MCPSet       = wire.NewSet(mcp.NewHandler)
+	WebSet        = wire.NewSet(webHandler.NewWebHandler)
+	UserSet       = wire.NewSet(userHandler.NewUserHandler)
+	AuthSet       = wire.NewSet(authHandler.NewAuthHandler)
+	EchoSet       = wire.NewSet(echoHandler.NewEchoHandler)
+	FileSet       = wire.NewSet(fileHandler.NewFileHandler)
+	CommentSet    = wire.NewSet(commentHandler.NewCommentHandler)
+	InitSet       = wire.NewSet(initHandler.NewInitHandler)
+	CommonSet     = wire.NewSet(commonHandler.NewCommonHandler)
+	SettingSet    = wire.NewSet(settingHandler.NewSettingHandler)
+	ConnectSet    = wire.NewSet(connectHandler.NewConnectHandler)
+	DashboardSet  = wire.NewSet(dashboardHandler.NewDashboardHandler)
+	CopilotSet    = wire.NewSet(copilotHandler.NewCopilotHandler)
+	EmbeddingSet  = wire.NewSet(embeddingHandler.NewEmbeddingHandler)
+	SearchSet     = wire.NewSet(searchHandler.NewSearchHandler)
+	JobSet        = wire.NewSet(jobHandler.NewJobHandler)
+	MigrationSet  = wire.NewSet(migratorHandler.NewMigrationHandler)
+	MCPSet        = wire.NewSet(mcp.NewHandler)
+	MicropubSet   = wire.NewSet(micropub.NewHandler)
+	WebmentionSet = wire.NewSet(webmention.NewHandler)
 )
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package handler 暴露混合检索与相关 Echo 推荐的 HTTP 接口（Huma type-first）。
+package handler
+
+import (
+	"context"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	service "github.com/lin-snow/ech0/internal/service/search"
+)
+
+type SearchHandler struct {
+	searchService service.Service
+}
+
+func NewSearchHandler(searchService service.Service) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+	}
+}
+
+type (
+	HybridSearchInput struct {
+		Q        string   `query:"q" maxLength:"200" doc:"检索词（语义 + 关键词），最长 200 字"`
+		TagIDs   []string `query:"tagIds" doc:"按标签过滤（任一命中），逗号分隔"`
+		DateFrom int64    `query:"dateFrom" doc:"created_at 下界（Unix 秒，含）"`
+		DateTo   int64    `query:"dateTo" doc:"created_at 上界（Unix 秒，含）"`
+		Limit    int      `query:"limit" default:"10" doc:"返回条数，默认 10，最大 50"`
+	}
+	RelatedEchosInput struct {
+		ID    string `path:"id" format:"uuid" doc:"Echo ID"`
+		Limit int    `query:"limit" default:"5" doc:"返回条数，默认 5，最大 20"`
+	}
+)
+
+type (
+	HybridSearchOutput = commonModel.Result[service.HybridResult]
+	RelatedEchosOutput = commonModel.Result[service.RelatedResult]
+)
+
+// HybridSearch 混合检索：向量近邻与关键词命中按倒数排名融合；Embedding 未启用或匿名访问时退化为关键词（mode=keyword）。
+func (searchHandler *SearchHandler) HybridSearch(ctx context.Context, in *HybridSearchInput) (HybridSearchOutput, error) {
+	result, err := searchHandler.searchService.Hybrid(ctx, service.HybridQuery{
+		Query:    in.Q,
+		TagIDs:   in.TagIDs,
+		DateFrom: in.DateFrom,
+		DateTo:   in.DateTo,
+		Limit:    in.Limit,
+	})
+	if err != nil {
+		return HybridSearchOutput{}, err
+	}
+	return commonModel.OK(result, commonModel.SEARCH_ECHOS_SUCCESS), nil
+}
+
+// RelatedEchos 相关 Echo：按该 Echo 已存向量取近邻，未建索引时按共同标签降级（mode=tags）。
+func (searchHandler *SearchHandler) RelatedEchos(ctx context.Context, in *RelatedEchosInput) (RelatedEchosOutput, error) {
+	result, err := searchHandler.searchService.Related(ctx, in.ID, in.Limit)
+	if err != nil {
+		return RelatedEchosOutput{}, err
+	}
+	return commonModel.OK(result, commonModel.GET_RELATED_ECHOS_SUCCESS), nil
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	service "github.com/lin-snow/ech0/internal/service/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSearchService 记录入参并回放预置结果。
+type stubSearchService struct {
+	gotQuery service.HybridQuery
+	gotID    string
+	gotLimit int
+	hybrid   service.HybridResult
+	related  service.RelatedResult
+	err      error
+}
+
+func (f *stubSearchService) Hybrid(_ context.Context, q service.HybridQuery) (service.HybridResult, error) {
+	f.gotQuery = q
+	return f.hybrid, f.err
+}
+
+func (f *stubSearchService) Related(_ context.Context, id string, limit int) (service.RelatedResult, error) {
+	f.gotID, f.gotLimit = id, limit
+	return f.related, f.err
+}
+
+func TestHybridSearch(t *testing.T) {
+	t.Run("maps query params", func(t *testing.T) {
+		svc := &stubSearchService{hybrid: service.HybridResult{Mode: service.ModeHybrid}}
+		out, err := NewSearchHandler(svc).HybridSearch(context.Background(), &HybridSearchInput{
+			Q: "coffee", TagIDs: []string{"t1"}, DateFrom: 1, DateTo: 2, Limit: 7,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, service.HybridQuery{Query: "coffee", TagIDs: []string{"t1"}, DateFrom: 1, DateTo: 2, Limit: 7}, svc.gotQuery)
+		assert.Equal(t, commonModel.SEARCH_ECHOS_SUCCESS, out.Message)
+		assert.Equal(t, service.ModeHybrid, out.Data.Mode)
+	})
+
+	t.Run("service error passthrough", func(t *testing.T) {
+		sentinel := errors.New(commonModel.SEARCH_QUERY_EMPTY)
+		_, err := NewSearchHandler(&stubSearchService{err: sentinel}).HybridSearch(context.Background(), &HybridSearchInput{})
+		require.ErrorIs(t, err, sentinel)
+	})
+}
+
+func TestRelatedEchos(t *testing.T) {
+	svc := &stubSearchService{related: service.RelatedResult{Mode: service.ModeTags}}
+	out, err := NewSearchHandler(svc).RelatedEchos(context.Background(), &RelatedEchosInput{ID: "e1", Limit: 3})
+
+	require.NoError(t, err)
+	assert.Equal(t, "e1", svc.gotID)
+	assert.Equal(t, 3, svc.gotLimit)
+	assert.Equal(t, commonModel.GET_RELATED_ECHOS_SUCCESS, out.Message)
+	assert.Equal(t, service.ModeTags, out.Data.Mode)
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	notifyModel "github.com/lin-snow/ech0/internal/model/notify"
 	model "github.com/lin-snow/ech0/internal/model/setting"
 	webhookModel "github.com/lin-snow/ech0/internal/model/webhook"
 	service "github.com/lin-snow/ech0/internal/service/setting"
@@ -37,6 +38,14 @@ type (
 		ID   string `path:"id" format:"uuid" doc:"Webhook ID（UUID）"`
 		Body model.WebhookDto
 	}
+	NotifyChannelInput       struct{ Body notifyModel.NotifyChannelDto }
+	NotifyChannelIDBodyInput struct {
+		ID   string `path:"id" format:"uuid" doc:"通知渠道 ID（UUID）"`
+		Body notifyModel.NotifyChannelDto
+	}
+	NotifyDeliveryListInput struct {
+		ChannelID string `query:"channel_id" doc:"只看该通知渠道的投递日志（可选）"`
+	}
 	AccessTokenInput      struct{ Body model.AccessTokenSettingDto }
 	SnapshotScheduleInput struct{ Body model.SnapshotScheduleDto }
 	AgentSettingInput     struct{ Body model.AgentSettingDto }
@@ -44,19 +53,21 @@ type (
 )
 
 type (
-	SystemSettingOutput    = commonModel.Result[model.SystemSetting]
-	OAuth2StatusOutput     = commonModel.Result[model.OAuth2Status]
-	PasskeyStatusOutput    = commonModel.Result[model.PasskeyStatus]
-	AgentSettingOutput     = commonModel.Result[model.AgentSetting]
-	S3SettingOutput        = commonModel.Result[model.S3Setting]
-	OAuth2SettingOutput    = commonModel.Result[model.OAuth2Setting]
-	PasskeySettingOutput   = commonModel.Result[model.PasskeySetting]
-	WebhookListOutput      = commonModel.Result[[]webhookModel.Webhook]
-	SnapshotScheduleOutput = commonModel.Result[model.SnapshotSchedule]
-	EmbeddingSettingOutput = commonModel.Result[model.EmbeddingSetting]
-	AccessTokenListOutput  = commonModel.Result[[]model.AccessTokenSetting]
-	StringOutput           = commonModel.Result[string]
-	EmptyOutput            = commonModel.Result[any]
+	SystemSettingOutput      = commonModel.Result[model.SystemSetting]
+	OAuth2StatusOutput       = commonModel.Result[model.OAuth2Status]
+	PasskeyStatusOutput      = commonModel.Result[model.PasskeyStatus]
+	AgentSettingOutput       = commonModel.Result[model.AgentSetting]
+	S3SettingOutput          = commonModel.Result[model.S3Setting]
+	OAuth2SettingOutput      = commonModel.Result[model.OAuth2Setting]
+	PasskeySettingOutput     = commonModel.Result[model.PasskeySetting]
+	WebhookListOutput        = commonModel.Result[[]webhookModel.Webhook]
+	NotifyChannelListOutput  = commonModel.Result[[]notifyModel.NotifyChannel]
+	NotifyDeliveryListOutput = commonModel.Result[[]notifyModel.NotifyDelivery]
+	SnapshotScheduleOutput   = commonModel.Result[model.SnapshotSchedule]
+	EmbeddingSettingOutput   = commonModel.Result[model.EmbeddingSetting]
+	AccessTokenListOutput    = commonModel.Result[[]model.AccessTokenSetting]
+	StringOutput             = commonModel.Result[string]
+	EmptyOutput              = commonModel.Result[any]
 )
 
 func (h *SettingHandler) GetSettings(ctx context.Context, _ *EmptyInput) (SystemSettingOutput, error) {
@@ -191,6 +202,53 @@ func (h *SettingHandler) TestWebhook(ctx context.Context, in *IDInput) (EmptyOut
 	return commonModel.OK[any](nil, commonModel.TEST_WEBHOOK_SUCCESS), nil
 }
 
+func (h *SettingHandler) GetNotifyChannels(ctx context.Context, _ *EmptyInput) (NotifyChannelListOutput, error) {
+	result, err := h.settingService.GetAllNotifyChannels(ctx)
+	if err != nil {
+		return NotifyChannelListOutput{}, err
+	}
+	return commonModel.OK(result, commonModel.GET_NOTIFY_CHANNEL_SUCCESS), nil
+}
+
+func (h *SettingHandler) CreateNotifyChannel(ctx context.Context, in *NotifyChannelInput) (EmptyOutput, error) {
+	if err := h.settingService.CreateNotifyChannel(ctx, &in.Body); err != nil {
+		return EmptyOutput{}, err
+	}
+	return commonModel.OK[any](nil, commonModel.CREATE_NOTIFY_CHANNEL_SUCCESS), nil
+}
+
+func (h *SettingHandler) UpdateNotifyChannel(ctx context.Context, in *NotifyChannelIDBodyInput) (EmptyOutput, error) {
+	if err := h.settingService.UpdateNotifyChannel(ctx, in.ID, &in.Body); err != nil {
+		return EmptyOutput{}, err
+	}
+	return commonModel.OK[any](nil, commonModel.UPDATE_NOTIFY_CHANNEL_SUCCESS), nil
+}
+
+func (h *SettingHandler) DeleteNotifyChannel(ctx context.Context, in *IDInput) (EmptyOutput, error) {
+	if err := h.settingService.DeleteNotifyChannel(ctx, in.ID); err != nil {
+		return EmptyOutput{}, err
+	}
+	return commonModel.OK[any](nil, commonModel.DELETE_NOTIFY_CHANNEL_SUCCESS), nil
+}
+
+func (h *SettingHandler) TestNotifyChannel(ctx context.Context, in *IDInput) (EmptyOutput, error) {
+	if err := h.settingService.TestNotifyChannel(ctx, in.ID); err != nil {
+		return EmptyOutput{}, err
+	}
+	return commonModel.OK[any](nil, commonModel.TEST_NOTIFY_CHANNEL_SUCCESS), nil
+}
+
+func (h *SettingHandler) GetNotifyDeliveries(
+	ctx context.Context,
+	in *NotifyDeliveryListInput,
+) (NotifyDeliveryListOutput, error) {
+	result, err := h.settingService.ListNotifyDeliveries(ctx, in.ChannelID)
+	if err != nil {
+		return NotifyDeliveryListOutput{}, err
+	}
+	return commonModel.OK(result, commonModel.GET_NOTIFY_DELIVERIES_SUCCESS), nil
+}
+
 func (h *SettingHandler) GetSnapshotScheduleSetting(ctx context.Context, _ *EmptyInput) (SnapshotScheduleOutput, error) {
 	var snapshotSchedule model.SnapshotSchedule
 	if err := h.settingService.GetSnapshotScheduleSetting(&snapshotSchedule); err != nil {
@@ -10,6 +10,7 @@ import (
 
 	settingHandler "github.com/lin-snow/ech0/internal/handler/setting"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	notifyModel "github.com/lin-snow/ech0/internal/model/notify"
 	settingModel "github.com/lin-snow/ech0/internal/model/setting"
 	webhookModel "github.com/lin-snow/ech0/internal/model/webhook"
 	settingmock "github.com/lin-snow/ech0/internal/test/mocks/settingmock"
@@ -444,6 +445,77 @@ func TestSettingHandler_Webhooks(t *testing.T) {
 	})
 }
 
+func TestSettingHandler_NotifyChannels(t *testing.T) {
+	t.Run("list success", func(t *testing.T) {
+		svc := settingmock.NewMockService(t)
+		want := []notifyModel.NotifyChannel{{ID: "c1", Name: "phone", Type: notifyModel.ChannelNtfy}}
+		svc.EXPECT().GetAllNotifyChannels(mock.Anything).Return(want, nil).Once()
+
+		h := settingHandler.NewSettingHandler(svc)
+		out, err := h.GetNotifyChannels(context.Background(), &settingHandler.EmptyInput{})
+
+		require.NoError(t, err)
+		assert.Equal(t, commonModel.GET_NOTIFY_CHANNEL_SUCCESS, out.Message)
+		assert.Equal(t, want, out.Data)
+	})
+
+	t.Run("create error", func(t *testing.T) {
+		svc := settingmock.NewMockService(t)
+		be := commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, commonModel.INVALID_NOTIFY_CHANNEL)
+		svc.EXPECT().CreateNotifyChannel(mock.Anything, mock.Anything).Return(be).Once()
+
+		h := settingHandler.NewSettingHandler(svc)
+		_, err := h.CreateNotifyChannel(context.Background(), &settingHandler.NotifyChannelInput{})
+
+		assertBizErr(t, err, commonModel.ErrCodeInvalidRequest)
+	})
+
+	t.Run("update success passes id through", func(t *testing.T) {
+		svc := settingmock.NewMockService(t)
+		svc.EXPECT().UpdateNotifyChannel(mock.Anything, "c-9", mock.Anything).Return(nil).Once()
+
+		h := settingHandler.NewSettingHandler(svc)
+		out, err := h.UpdateNotifyChannel(context.Background(), &settingHandler.NotifyChannelIDBodyInput{ID: "c-9"})
+
+		require.NoError(t, err)
+		assert.Equal(t, commonModel.UPDATE_NOTIFY_CHANNEL_SUCCESS, out.Message)
+	})
+
+	t.Run("delete success passes id through", func(t *testing.T) {
+		svc := settingmock.NewMockService(t)
+		svc.EXPECT().DeleteNotifyChannel(mock.Anything, "c-3").Return(nil).Once()
+
+		h := settingHandler.NewSettingHandler(svc)
+		out, err := h.DeleteNotifyChannel(context.Background(), &settingHandler.IDInput{ID: "c-3"})
+
+		require.NoError(t, err)
+		assert.Equal(t, commonModel.DELETE_NOTIFY_CHANNEL_SUCCESS, out.Message)
+	})
+
+	t.Run("test error", func(t *testing.T) {
+		svc := settingmock.NewMockService(t)
+		svc.EXPECT().TestNotifyChannel(mock.Anything, "c-7").Return(bizErr()).Once()
+
+		h := settingHandler.NewSettingHandler(svc)
+		_, err := h.TestNotifyChannel(context.Background(), &settingHandler.IDInput{ID: "c-7"})
+
+		assertBizErr(t, err, commonModel.ErrCodeInternal)
+	})
+
+	t.Run("deliveries filter by channel", func(t *testing.T) {
+		svc := settingmock.NewMockService(t)
+		want := []notifyModel.NotifyDelivery{{ID: "d1", ChannelID: "c1"}}
+		svc.EXPECT().ListNotifyDeliveries(mock.Anything, "c1").Return(want, nil).Once()
+
+		h := settingHandler.NewSettingHandler(svc)
+		out, err := h.GetNotifyDeliveries(context.Background(), &settingHandler.NotifyDeliveryListInput{ChannelID: "c1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, commonModel.GET_NOTIFY_DELIVERIES_SUCCESS, out.Message)
+		assert.Equal(t, want, out.Data)
+	})
+}
+
 func TestSettingHandler_SnapshotSchedule(t *testing.T) {
 	t.Run("get success", func(t *testing.T) {
 		svc := settingmock.NewMockService(t)
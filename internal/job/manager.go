@@ -13,143 +13,276 @@ import (
 	"time"
 
 	jobModel "github.com/lin-snow/ech0/internal/model/job"
+	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
 	logUtil "github.com/lin-snow/ech0/pkg/log"
 )
 
 const logModule = "job"
 
+const (
+	// historyKeep 是每个类型保留的终态历史条数，更早的在作业结束时删掉。
+	historyKeep = 50
+	// maxAttempts 是一次提交最多被启动的次数（首跑 + 重启后续跑）。反复在跑到一半时
+	// 进程退出的作业多半自己就是元凶，不再无限续跑。
+	maxAttempts = 3
+)
+
 var (
 	// ErrNoRunner 提交了未注册类型的作业。
 	ErrNoRunner = errors.New("no runner registered for job type")
-	// ErrAlreadyRunning 该类型已有一条非终态作业（同类型互斥）。
+	// ErrAlreadyRunning 该类型已有一条非终态作业，且该类型不排队（同类型互斥）。
 	ErrAlreadyRunning = errors.New("a job of this type is already running")
+	// ErrQueueFull 该类型排队的作业已达上限。
+	ErrQueueFull = errors.New("too many queued jobs of this type")
 )
 
-// Manager 管理所有作业的生命周期：Runner 注册表、durable 持久化、内存实时进度、
-// 取消句柄。它从不解析领域 payload，只搬运 JSON。实现 app.Component。
+// Option 调整某类型作业的调度策略，见 Register。
+type Option func(*registration)
+
+// WithQueue 让同类型的后续提交排队（最多 limit 条在等），而不是以 ErrAlreadyRunning 拒绝。
+// 同一类型任意时刻仍只跑一条，队列按提交先后出队。
+func WithQueue(limit int) Option {
+	return func(r *registration) { r.queueLimit = limit }
+}
+
+// Resumable 声明该类型的 Runner 可以按原 payload 安全重跑：进程重启（含优雅停机）打断的
+// 作业会在下次启动时重新入队，而不是被扫成 failed。
+func Resumable() Option {
+	return func(r *registration) { r.resumable = true }
+}
+
+type registration struct {
+	runner     Runner
+	queueLimit int
+	resumable  bool
+}
+
+// Manager 管理所有作业的生命周期：Runner 注册表、durable 持久化、同类型排队、内存实时
+// 进度、取消句柄。不同类型的作业并发执行，同一类型串行。它从不解析领域 payload，只搬运
+// JSON。实现 app.Component。
 type Manager struct {
 	repo JobRepository
 
-	mu      sync.Mutex
-	runners map[string]Runner
-	live    map[string]*Progress
-	cancels map[string]context.CancelFunc
+	mu       sync.Mutex
+	wg       sync.WaitGroup
+	stopping bool
+	runners  map[string]registration
+	running  map[string]string // type → 在跑作业 ID
+	live     map[string]*Progress
+	cancels  map[string]context.CancelFunc
 }
 
 func NewManager(repo JobRepository) *Manager {
 	return &Manager{
 		repo:    repo,
-		runners: make(map[string]Runner),
+		runners: make(map[string]registration),
+		running: make(map[string]string),
 		live:    make(map[string]*Progress),
 		cancels: make(map[string]context.CancelFunc),
 	}
 }
 
-// Register 登记某类型的 Runner，须在任何 Submit 之前于启动期调用。
-func (m *Manager) Register(jobType string, r Runner) {
+// Register 登记某类型的 Runner 及其调度策略，须在任何 Submit 之前于启动期调用。
+// 不带 Option 即「同类型互斥、重启不续跑」。
+func (m *Manager) Register(jobType string, r Runner, opts ...Option) {
+	reg := registration{runner: r}
+	for _, opt := range opts {
+		opt(&reg)
+	}
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.runners[jobType] = r
+	m.runners[jobType] = reg
 }
 
-// Submit 提交一次作业：校验已注册、同类型互斥、upsert pending、登记取消句柄，
-// 起 goroutine 执行，返回 pending 行。
+// Submit 提交一次作业：校验已注册、按类型策略互斥或排队、落 pending 行，同类型空闲时立即
+// 起 goroutine 执行。返回新建的 pending 行。
 func (m *Manager) Submit(ctx context.Context, jobType string, payload []byte) (jobModel.Job, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	runner, ok := m.runners[jobType]
+	reg, ok := m.runners[jobType]
 	if !ok {
 		return jobModel.Job{}, fmt.Errorf("%w: %s", ErrNoRunner, jobType)
 	}
 
-	// 同类型互斥：现存非终态行则拒绝。持锁读判断+upsert，单进程下即原子。
-	if existing, err := m.repo.GetByType(ctx, jobType); err == nil {
-		if !existing.Status.IsTerminal() {
+	// 持锁读判断 + 建行，单进程下即原子。
+	active, err := m.repo.Active(ctx, jobType)
+	if err != nil {
+		return jobModel.Job{}, err
+	}
+	if len(active) > 0 {
+		if reg.queueLimit <= 0 {
 			return jobModel.Job{}, ErrAlreadyRunning
 		}
-	} else if !errors.Is(err, ErrNotFound) {
-		return jobModel.Job{}, err
+		if countQueued(active, m.running[jobType]) >= reg.queueLimit {
+			return jobModel.Job{}, ErrQueueFull
+		}
 	}
 
-	now := time.Now().UTC().Unix()
 	pending := jobModel.Job{
-		Type:      jobType,
-		Status:    jobModel.StatusPending,
-		Payload:   string(payload),
-		StartedAt: &now,
+		ID:      uuidUtil.MustNewV7(),
+		Type:    jobType,
+		Status:  jobModel.StatusPending,
+		Payload: string(payload),
 	}
-	if err := m.repo.Upsert(ctx, &pending); err != nil {
+	if err := m.repo.Create(ctx, &pending); err != nil {
 		return jobModel.Job{}, err
 	}
+	logUtil.GetLogger().Info("job submitted", slog.String("module", logModule),
+		slog.String("type", jobType), slog.String("id", pending.ID))
 
-	// 作业独立于触发它的 HTTP 请求，用 background 派生可取消 ctx；持锁登记取消句柄，
-	// 消除「pending 已建、cancel 未登记」的窗口。
-	runCtx, cancel := context.WithCancel(context.Background())
-	m.cancels[jobType] = cancel
-	delete(m.live, jobType)
-
-	go m.run(runCtx, jobType, runner, pending)
-
-	logUtil.GetLogger().Info("job submitted", slog.String("module", logModule), slog.String("type", jobType))
+	m.dispatchLocked(jobType)
 	return pending, nil
 }
 
-// run 在独立 goroutine 内推进作业：running → success/failed/cancelled。
-func (m *Manager) run(runCtx context.Context, jobType string, runner Runner, base jobModel.Job) {
+// dispatchLocked 在该类型空闲时取最早的 pending 行开跑。调用方须持有 m.mu。
+func (m *Manager) dispatchLocked(jobType string) {
+	if m.stopping {
+		return
+	}
+	if _, busy := m.running[jobType]; busy {
+		return
+	}
+	reg, ok := m.runners[jobType]
+	if !ok {
+		return
+	}
+	active, err := m.repo.Active(context.Background(), jobType)
+	if err != nil {
+		logUtil.GetLogger().Error("job dispatch failed",
+			slog.String("module", logModule), slog.String("type", jobType), logUtil.Err(err))
+		return
+	}
+	for _, next := range active {
+		if next.Status != jobModel.StatusPending {
+			continue
+		}
+		// 作业独立于触发它的 HTTP 请求，用 background 派生可取消 ctx；持锁登记取消句柄，
+		// 消除「已出队、cancel 未登记」的窗口。
+		runCtx, cancel := context.WithCancel(context.Background())
+		m.running[jobType] = next.ID
+		m.cancels[next.ID] = cancel
+		delete(m.live, next.ID)
+		m.wg.Add(1)
+		go m.run(runCtx, reg, next)
+		return
+	}
+}
+
+// run 在独立 goroutine 内推进作业：running → success/failed/cancelled，结束后让同类型的
+// 下一条出队。
+func (m *Manager) run(runCtx context.Context, reg registration, base jobModel.Job) {
+	defer m.wg.Done()
 	// durable 写用 background ctx，避免取消后终态行写不进去。
 	dbCtx := context.Background()
-	report := func(phase string, snapshot any) { m.setLive(jobType, phase, snapshot) }
+	report := func(phase string, snapshot any) { m.setLive(base.ID, phase, snapshot) }
+	input := base.Payload
 
+	now := time.Now().UTC().Unix()
 	base.Status = jobModel.StatusRunning
-	if err := m.repo.Upsert(dbCtx, &base); err != nil {
-		logUtil.GetLogger().Error("job mark running failed",
-			slog.String("module", logModule), slog.String("type", jobType), logUtil.Err(err))
+	base.StartedAt = &now
+	base.FinishedAt = nil
+	base.Error = ""
+	base.Attempts++
+	if err := m.repo.Save(dbCtx, &base); err != nil {
+		logUtil.GetLogger().Error("job mark running failed", slog.String("module", logModule),
+			slog.String("type", base.Type), slog.String("id", base.ID), logUtil.Err(err))
 	}
 
-	result, runErr := runner.Run(runCtx, []byte(base.Payload), report)
+	result, runErr := reg.runner.Run(runCtx, []byte(input), report)
 
-	now := time.Now().UTC().Unix()
-	base.FinishedAt = &now
-	base.Phase = m.takeLivePhase(jobType)
+	finished := time.Now().UTC().Unix()
+	base.FinishedAt = &finished
+	base.Phase = m.takeLivePhase(base.ID)
 
 	switch {
+	case errors.Is(runCtx.Err(), context.Canceled) && m.isStopping():
+		// 停机打断而非用户取消：可续跑的放回 pending 等下次启动，其余如实记失败。
+		if reg.resumable {
+			base.Status = jobModel.StatusPending
+			base.Phase = ""
+			base.StartedAt = nil
+			base.FinishedAt = nil
+		} else {
+			base.Status = jobModel.StatusFailed
+			base.Error = "interrupted by shutdown"
+		}
+		logUtil.GetLogger().Warn("job interrupted by shutdown", slog.String("module", logModule),
+			slog.String("type", base.Type), slog.String("id", base.ID))
 	case errors.Is(runCtx.Err(), context.Canceled):
 		base.Status = jobModel.StatusCancelled
 		base.Error = ""
-		logUtil.GetLogger().Warn("job cancelled", slog.String("module", logModule), slog.String("type", jobType))
+		logUtil.GetLogger().Warn("job cancelled", slog.String("module", logModule),
+			slog.String("type", base.Type), slog.String("id", base.ID))
 	case runErr != nil:
 		base.Status = jobModel.StatusFailed
 		base.Error = runErr.Error()
-		logUtil.GetLogger().Error("job failed",
-			slog.String("module", logModule), slog.String("type", jobType), logUtil.Err(runErr))
+		logUtil.GetLogger().Error("job failed", slog.String("module", logModule),
+			slog.String("type", base.Type), slog.String("id", base.ID), logUtil.Err(runErr))
 	default:
 		base.Status = jobModel.StatusSuccess
 		base.Error = ""
 		if result != nil {
 			base.Payload = mustJSON(result)
 		}
-		logUtil.GetLogger().Info("job succeeded", slog.String("module", logModule), slog.String("type", jobType))
+		logUtil.GetLogger().Info("job succeeded", slog.String("module", logModule),
+			slog.String("type", base.Type), slog.String("id", base.ID))
 	}
 
-	if err := m.repo.Upsert(dbCtx, &base); err != nil {
-		logUtil.GetLogger().Error("job persist terminal failed",
-			slog.String("module", logModule), slog.String("type", jobType),
+	if err := m.repo.Save(dbCtx, &base); err != nil {
+		logUtil.GetLogger().Error("job persist terminal failed", slog.String("module", logModule),
+			slog.String("type", base.Type), slog.String("id", base.ID),
 			slog.String("status", string(base.Status)), logUtil.Err(err))
 	}
+	if base.Status.IsTerminal() {
+		if err := m.repo.Prune(dbCtx, base.Type, historyKeep); err != nil {
+			logUtil.GetLogger().Warn("job history prune failed", slog.String("module", logModule),
+				slog.String("type", base.Type), logUtil.Err(err))
+		}
+	}
 
-	m.clear(jobType)
+	m.mu.Lock()
+	delete(m.live, base.ID)
+	delete(m.cancels, base.ID)
+	delete(m.running, base.Type)
+	m.dispatchLocked(base.Type)
+	m.mu.Unlock()
 }
 
-// Get 返回 durable 行；本进程正在跑时叠加内存实时进度。snapshot 为 nil 时只覆盖
-// Phase，不动 durable Payload。
+// Get 返回该类型最近一次未收起的提交；本进程正在跑时叠加内存实时进度。snapshot 为 nil
+// 时只覆盖 Phase，不动 durable Payload。
 func (m *Manager) Get(ctx context.Context, jobType string) (jobModel.Job, error) {
-	row, err := m.repo.GetByType(ctx, jobType)
+	row, err := m.repo.Latest(ctx, jobType)
 	if err != nil {
 		return row, err
 	}
+	return m.overlay(row), nil
+}
+
+// GetByID 按 ID 返回一次提交，规则同 Get。
+func (m *Manager) GetByID(ctx context.Context, id string) (jobModel.Job, error) {
+	row, err := m.repo.GetByID(ctx, id)
+	if err != nil {
+		return row, err
+	}
+	return m.overlay(row), nil
+}
+
+// List 按提交时间倒序列出作业历史（含在跑与排队中的），并返回筛选后的总数。
+func (m *Manager) List(ctx context.Context, q ListQuery) ([]jobModel.Job, int64, error) {
+	rows, total, err := m.repo.List(ctx, q)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range rows {
+		rows[i] = m.overlay(rows[i])
+	}
+	return rows, total, nil
+}
+
+func (m *Manager) overlay(row jobModel.Job) jobModel.Job {
 	m.mu.Lock()
-	p := m.live[jobType]
+	p := m.live[row.ID]
 	m.mu.Unlock()
 	if p != nil {
 		row.Phase = p.Phase
@@ -157,66 +290,130 @@ func (m *Manager) Get(ctx context.Context, jobType string) (jobModel.Job, error)
 			row.Payload = mustJSON(p.Snapshot)
 		}
 	}
-	return row, nil
+	return row
 }
 
-// Delete 删除该类型的行并清空内存进度，使其回到「无作业」。仅应在终态时调用。
-func (m *Manager) Delete(ctx context.Context, jobType string) error {
-	if err := m.repo.Delete(ctx, jobType); err != nil {
+// Dismiss 收起该类型的终态作业，使 Get 回到「无作业」；行本身留在历史里。
+func (m *Manager) Dismiss(ctx context.Context, jobType string) error {
+	return m.repo.Dismiss(ctx, jobType)
+}
+
+// Cancel 取消该类型所有未结束的作业：在跑的触发 ctx 取消，排队中的直接置 cancelled。
+func (m *Manager) Cancel(ctx context.Context, jobType string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	active, err := m.repo.Active(ctx, jobType)
+	if err != nil {
 		return err
 	}
-	m.clear(jobType)
+	for _, j := range active {
+		if err := m.cancelLocked(ctx, j); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-// Cancel 触发该类型在跑作业的 ctx 取消；无在跑/已终态则 no-op。
-func (m *Manager) Cancel(jobType string) error {
+// CancelByID 取消指定作业；已是终态则 no-op，查无返回 ErrNotFound。
+func (m *Manager) CancelByID(ctx context.Context, id string) error {
 	m.mu.Lock()
-	cancel := m.cancels[jobType]
-	m.mu.Unlock()
-	if cancel != nil {
+	defer m.mu.Unlock()
+	row, err := m.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return m.cancelLocked(ctx, row)
+}
+
+// cancelLocked 取消一条作业。调用方须持有 m.mu。
+func (m *Manager) cancelLocked(ctx context.Context, row jobModel.Job) error {
+	if cancel := m.cancels[row.ID]; cancel != nil {
 		cancel()
+		return nil
+	}
+	if row.Status != jobModel.StatusPending {
+		return nil
 	}
+	now := time.Now().UTC().Unix()
+	row.Status = jobModel.StatusCancelled
+	row.FinishedAt = &now
+	if err := m.repo.Save(ctx, &row); err != nil {
+		return err
+	}
+	logUtil.GetLogger().Info("queued job cancelled", slog.String("module", logModule),
+		slog.String("type", row.Type), slog.String("id", row.ID))
 	return nil
 }
 
-func (m *Manager) setLive(jobType, phase string, snapshot any) {
+func (m *Manager) setLive(id, phase string, snapshot any) {
 	m.mu.Lock()
-	m.live[jobType] = &Progress{Phase: phase, Snapshot: snapshot}
+	m.live[id] = &Progress{Phase: phase, Snapshot: snapshot}
 	m.mu.Unlock()
 }
 
-func (m *Manager) takeLivePhase(jobType string) string {
+func (m *Manager) takeLivePhase(id string) string {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	if p := m.live[jobType]; p != nil {
+	if p := m.live[id]; p != nil {
 		return p.Phase
 	}
 	return ""
 }
 
-func (m *Manager) clear(jobType string) {
+func (m *Manager) isStopping() bool {
 	m.mu.Lock()
-	delete(m.live, jobType)
-	delete(m.cancels, jobType)
-	m.mu.Unlock()
+	defer m.mu.Unlock()
+	return m.stopping
 }
 
 // Name 实现 app.Namer。
 func (m *Manager) Name() string { return "job" }
 
-// Start 把上次进程残留的 pending/running 行扫成 failed，避免前端永久转圈。幂等。
+// Start 处理上次进程留下的未结束作业，然后让各类型的队列开跑。从未开始过的排队作业原样
+// 保留；被打断的作业若其类型 Resumable 且未超过 maxAttempts 则放回队列续跑，否则置 failed，
+// 避免前端永久转圈。
 func (m *Manager) Start(ctx context.Context) error {
-	if err := m.repo.SweepRunning(ctx, "interrupted by restart"); err != nil {
-		logUtil.GetLogger().Error("sweep orphan jobs failed", slog.String("module", logModule), logUtil.Err(err))
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	active, err := m.repo.Active(ctx, "")
+	if err != nil {
+		logUtil.GetLogger().Error("load unfinished jobs failed", slog.String("module", logModule), logUtil.Err(err))
 		return err
 	}
+	now := time.Now().UTC().Unix()
+	for _, j := range active {
+		if j.Status == jobModel.StatusPending && j.Attempts == 0 {
+			continue
+		}
+		reg, ok := m.runners[j.Type]
+		if ok && reg.resumable && j.Attempts < maxAttempts {
+			j.Status = jobModel.StatusPending
+			j.Phase = ""
+			logUtil.GetLogger().Info("job resumed after restart", slog.String("module", logModule),
+				slog.String("type", j.Type), slog.String("id", j.ID), slog.Int("attempts", j.Attempts))
+		} else {
+			j.Status = jobModel.StatusFailed
+			j.Error = "interrupted by restart"
+			j.FinishedAt = &now
+		}
+		if err := m.repo.Save(ctx, &j); err != nil {
+			logUtil.GetLogger().Error("sweep orphan jobs failed", slog.String("module", logModule), logUtil.Err(err))
+			return err
+		}
+	}
+
+	m.stopping = false
+	for jobType := range m.runners {
+		m.dispatchLocked(jobType)
+	}
 	return nil
 }
 
-// Stop 取消所有在跑作业，使其协作退出。
-func (m *Manager) Stop(context.Context) error {
+// Stop 取消所有在跑作业，并等它们把停机状态落库（至多等到 ctx 截止）。此后不再出队新作业。
+func (m *Manager) Stop(ctx context.Context) error {
 	m.mu.Lock()
+	m.stopping = true
 	cancels := make([]context.CancelFunc, 0, len(m.cancels))
 	for _, c := range m.cancels {
 		cancels = append(cancels, c)
@@ -225,7 +422,29 @@ func (m *Manager) Stop(context.Context) error {
 	for _, c := range cancels {
 		c()
 	}
-	return nil
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// countQueued 数出排队中的作业。已出队但还没来得及落 running 的那条按在跑算。
+func countQueued(active []jobModel.Job, runningID string) int {
+	n := 0
+	for _, j := range active {
+		if j.Status == jobModel.StatusPending && j.ID != runningID {
+			n++
+		}
+	}
+	return n
 }
 
 func mustJSON(v any) string {
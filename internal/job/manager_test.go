@@ -6,12 +6,14 @@ package job_test
 import (
 	"context"
 	"errors"
+	"sort"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/lin-snow/ech0/internal/job"
 	jobModel "github.com/lin-snow/ech0/internal/model/job"
+	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
 )
 
 // stubRepo 是内存态 JobRepository，用于确定性测试 Manager 状态机（不碰 DB）。
@@ -22,43 +24,79 @@ type stubRepo struct {
 
 func newStubRepo() *stubRepo { return &stubRepo{rows: map[string]jobModel.Job{}} }
 
-func (r *stubRepo) Upsert(_ context.Context, j *jobModel.Job) error {
+func (r *stubRepo) Create(_ context.Context, j *jobModel.Job) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.rows[j.Type] = *j
+	r.rows[j.ID] = *j
 	return nil
 }
 
-func (r *stubRepo) GetByType(_ context.Context, t string) (jobModel.Job, error) {
+func (r *stubRepo) Save(ctx context.Context, j *jobModel.Job) error { return r.Create(ctx, j) }
+
+func (r *stubRepo) GetByID(_ context.Context, id string) (jobModel.Job, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	j, ok := r.rows[t]
+	j, ok := r.rows[id]
 	if !ok {
 		return jobModel.Job{}, job.ErrNotFound
 	}
 	return j, nil
 }
 
-func (r *stubRepo) SweepRunning(_ context.Context, reason string) error {
+// filter 按 ID（即提交先后）升序返回命中的行。调用方须持锁。
+func (r *stubRepo) filter(keep func(jobModel.Job) bool) []jobModel.Job {
+	var out []jobModel.Job
+	for _, j := range r.rows {
+		if keep(j) {
+			out = append(out, j)
+		}
+	}
+	sort.Slice(out, func(a, b int) bool { return out[a].ID < out[b].ID })
+	return out
+}
+
+func (r *stubRepo) Latest(_ context.Context, t string) (jobModel.Job, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	for k, j := range r.rows {
-		if !j.Status.IsTerminal() {
-			j.Status = jobModel.StatusFailed
-			j.Error = reason
-			r.rows[k] = j
-		}
+	rows := r.filter(func(j jobModel.Job) bool { return j.Type == t && !j.Dismissed })
+	if len(rows) == 0 {
+		return jobModel.Job{}, job.ErrNotFound
 	}
-	return nil
+	return rows[len(rows)-1], nil
+}
+
+func (r *stubRepo) Active(_ context.Context, t string) ([]jobModel.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.filter(func(j jobModel.Job) bool {
+		return (t == "" || j.Type == t) && !j.Status.IsTerminal()
+	}), nil
+}
+
+func (r *stubRepo) List(_ context.Context, q job.ListQuery) ([]jobModel.Job, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows := r.filter(func(j jobModel.Job) bool {
+		return (q.Type == "" || j.Type == q.Type) && (q.Status == "" || j.Status == q.Status)
+	})
+	sort.Slice(rows, func(a, b int) bool { return rows[a].ID > rows[b].ID })
+	return rows, int64(len(rows)), nil
 }
 
-func (r *stubRepo) Delete(_ context.Context, t string) error {
+func (r *stubRepo) Dismiss(_ context.Context, t string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	delete(r.rows, t)
+	for id, j := range r.rows {
+		if j.Type == t && j.Status.IsTerminal() {
+			j.Dismissed = true
+			r.rows[id] = j
+		}
+	}
 	return nil
 }
 
+func (r *stubRepo) Prune(context.Context, string, int) error { return nil }
+
 // waitForStatus 轮询 Get 直到命中目标状态或超时，消除 goroutine 时序 flakiness。
 func waitForStatus(t *testing.T, mgr *job.Manager, jobType string, want jobModel.Status) jobModel.Job {
 	t.Helper()
@@ -75,6 +113,35 @@ func waitForStatus(t *testing.T, mgr *job.Manager, jobType string, want jobModel
 	return jobModel.Job{}
 }
 
+// waitForID 同 waitForStatus，但盯住某一次提交。
+func waitForID(t *testing.T, mgr *job.Manager, id string, want jobModel.Status) jobModel.Job {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		jb, err := mgr.GetByID(context.Background(), id)
+		if err == nil && jb.Status == want {
+			return jb
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	jb, _ := mgr.GetByID(context.Background(), id)
+	t.Fatalf("job %s did not reach status %q in time; last=%q", id, want, jb.Status)
+	return jobModel.Job{}
+}
+
+// blockingRunner 每跑一次先报到 started，再等 release 放行（或 ctx 取消）。
+func blockingRunner(started chan<- string, release <-chan struct{}) job.Runner {
+	return job.Adapt(func(ctx context.Context, p struct{ N string }, _ job.ReportFunc) (any, error) {
+		started <- p.N
+		select {
+		case <-release:
+			return nil, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})
+}
+
 func TestSubmit_Success(t *testing.T) {
 	mgr := job.NewManager(newStubRepo())
 	mgr.Register("t", job.Adapt(func(_ context.Context, _ struct{}, report job.ReportFunc) (any, error) {
@@ -86,14 +153,20 @@ func TestSubmit_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("submit failed: %v", err)
 	}
-	if jb.Status != jobModel.StatusPending {
-		t.Fatalf("expected pending on submit, got %q", jb.Status)
+	if jb.Status != jobModel.StatusPending || jb.ID == "" {
+		t.Fatalf("expected pending row with id on submit, got %+v", jb)
 	}
 
 	done := waitForStatus(t, mgr, "t", jobModel.StatusSuccess)
-	if done.FinishedAt == nil || done.StartedAt == nil {
+	if done.ID != jb.ID {
+		t.Fatalf("expected Get to return the submitted job, got %q want %q", done.ID, jb.ID)
+	}
+	if done.FinishedAt == nil || done.StartedAt == nil || done.Duration() == nil {
 		t.Fatalf("expected started/finished timestamps, got %+v", done)
 	}
+	if done.Attempts != 1 {
+		t.Fatalf("expected 1 attempt, got %d", done.Attempts)
+	}
 	if done.Payload != `{"ok":"yes"}` {
 		t.Fatalf("expected result persisted to payload, got %q", done.Payload)
 	}
@@ -123,13 +196,9 @@ func TestSubmit_UnknownType(t *testing.T) {
 
 func TestSubmit_MutexRejectsConcurrent(t *testing.T) {
 	mgr := job.NewManager(newStubRepo())
+	started := make(chan string, 1)
 	release := make(chan struct{})
-	started := make(chan struct{})
-	mgr.Register("t", job.Adapt(func(ctx context.Context, _ struct{}, _ job.ReportFunc) (any, error) {
-		close(started)
-		<-release
-		return nil, nil
-	}))
+	mgr.Register("t", blockingRunner(started, release))
 
 	if _, err := mgr.Submit(context.Background(), "t", nil); err != nil {
 		t.Fatalf("first submit failed: %v", err)
@@ -144,28 +213,135 @@ func TestSubmit_MutexRejectsConcurrent(t *testing.T) {
 	waitForStatus(t, mgr, "t", jobModel.StatusSuccess)
 }
 
+func TestSubmit_QueueRunsSerially(t *testing.T) {
+	mgr := job.NewManager(newStubRepo())
+	started := make(chan string, 3)
+	release := make(chan struct{})
+	mgr.Register("t", blockingRunner(started, release), job.WithQueue(2))
+
+	var ids []string
+	for _, n := range []string{"1", "2", "3"} {
+		jb, err := mgr.Submit(context.Background(), "t", []byte(`{"N":"`+n+`"}`))
+		if err != nil {
+			t.Fatalf("submit %s failed: %v", n, err)
+		}
+		ids = append(ids, jb.ID)
+	}
+	if _, err := mgr.Submit(context.Background(), "t", nil); !errors.Is(err, job.ErrQueueFull) {
+		t.Fatalf("expected ErrQueueFull beyond the limit, got %v", err)
+	}
+
+	// 同类型串行、先进先出：每放行一条，下一条才开始。
+	for _, want := range []string{"1", "2", "3"} {
+		select {
+		case got := <-started:
+			if got != want {
+				t.Fatalf("expected job %s to start next, got %s", want, got)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("job %s did not start", want)
+		}
+		select {
+		case extra := <-started:
+			t.Fatalf("job %s started while another was running", extra)
+		default:
+		}
+		release <- struct{}{}
+	}
+	for _, id := range ids {
+		waitForID(t, mgr, id, jobModel.StatusSuccess)
+	}
+
+	rows, total, err := mgr.List(context.Background(), job.ListQuery{Type: "t"})
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if total != 3 || rows[0].ID != ids[2] {
+		t.Fatalf("expected 3 history rows newest first, got total=%d first=%q", total, rows[0].ID)
+	}
+}
+
+func TestSubmit_DifferentTypesRunConcurrently(t *testing.T) {
+	mgr := job.NewManager(newStubRepo())
+	started := make(chan string, 2)
+	release := make(chan struct{})
+	mgr.Register("a", blockingRunner(started, release))
+	mgr.Register("b", blockingRunner(started, release))
+
+	for _, jobType := range []string{"a", "b"} {
+		if _, err := mgr.Submit(context.Background(), jobType, []byte(`{"N":"`+jobType+`"}`)); err != nil {
+			t.Fatalf("submit %s failed: %v", jobType, err)
+		}
+	}
+	for range 2 {
+		select {
+		case <-started:
+		case <-time.After(2 * time.Second):
+			t.Fatal("expected both types to run at the same time")
+		}
+	}
+	close(release)
+	waitForStatus(t, mgr, "a", jobModel.StatusSuccess)
+	waitForStatus(t, mgr, "b", jobModel.StatusSuccess)
+}
+
 func TestCancel_RunningJob(t *testing.T) {
 	mgr := job.NewManager(newStubRepo())
-	started := make(chan struct{})
-	mgr.Register("t", job.Adapt(func(ctx context.Context, _ struct{}, _ job.ReportFunc) (any, error) {
-		close(started)
-		<-ctx.Done() // 协作式取消
-		return nil, ctx.Err()
-	}))
+	started := make(chan string, 1)
+	mgr.Register("t", blockingRunner(started, nil))
 
 	if _, err := mgr.Submit(context.Background(), "t", nil); err != nil {
 		t.Fatalf("submit failed: %v", err)
 	}
 	<-started
-	if err := mgr.Cancel("t"); err != nil {
+	if err := mgr.Cancel(context.Background(), "t"); err != nil {
 		t.Fatalf("cancel failed: %v", err)
 	}
 	waitForStatus(t, mgr, "t", jobModel.StatusCancelled)
 }
 
+func TestCancelByID_QueuedJob(t *testing.T) {
+	mgr := job.NewManager(newStubRepo())
+	started := make(chan string, 2)
+	release := make(chan struct{})
+	mgr.Register("t", blockingRunner(started, release), job.WithQueue(1))
+
+	first, err := mgr.Submit(context.Background(), "t", []byte(`{"N":"1"}`))
+	if err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+	<-started
+	queued, err := mgr.Submit(context.Background(), "t", []byte(`{"N":"2"}`))
+	if err != nil {
+		t.Fatalf("queue submit failed: %v", err)
+	}
+
+	if err := mgr.CancelByID(context.Background(), queued.ID); err != nil {
+		t.Fatalf("cancel queued failed: %v", err)
+	}
+	got := waitForID(t, mgr, queued.ID, jobModel.StatusCancelled)
+	if got.StartedAt != nil {
+		t.Fatalf("cancelled queued job must never start, got %+v", got)
+	}
+
+	close(release)
+	waitForID(t, mgr, first.ID, jobModel.StatusSuccess)
+	select {
+	case n := <-started:
+		t.Fatalf("cancelled job %s was started", n)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if err := mgr.CancelByID(context.Background(), "missing"); !errors.Is(err, job.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown id, got %v", err)
+	}
+}
+
 func TestStart_SweepsOrphans(t *testing.T) {
 	repo := newStubRepo()
-	_ = repo.Upsert(context.Background(), &jobModel.Job{Type: "t", Status: jobModel.StatusRunning})
+	_ = repo.Create(context.Background(), &jobModel.Job{
+		ID: uuidUtil.MustNewV7(), Type: "t", Status: jobModel.StatusRunning, Attempts: 1,
+	})
 	mgr := job.NewManager(repo)
 
 	if err := mgr.Start(context.Background()); err != nil {
@@ -175,23 +351,101 @@ func TestStart_SweepsOrphans(t *testing.T) {
 	if err != nil {
 		t.Fatalf("get failed: %v", err)
 	}
-	if jb.Status != jobModel.StatusFailed {
-		t.Fatalf("expected orphan swept to failed, got %q", jb.Status)
+	if jb.Status != jobModel.StatusFailed || jb.Error != "interrupted by restart" {
+		t.Fatalf("expected orphan swept to failed, got %+v", jb)
 	}
 }
 
-func TestSubmit_AfterTerminalReplaces(t *testing.T) {
+func TestStart_ResumesInterruptedAndQueued(t *testing.T) {
+	repo := newStubRepo()
+	interrupted := jobModel.Job{
+		ID: uuidUtil.MustNewV7(), Type: "t", Status: jobModel.StatusRunning, Attempts: 1, Payload: `{"N":"1"}`,
+	}
+	queued := jobModel.Job{ID: uuidUtil.MustNewV7(), Type: "t", Status: jobModel.StatusPending, Payload: `{"N":"2"}`}
+	exhausted := jobModel.Job{ID: uuidUtil.MustNewV7(), Type: "x", Status: jobModel.StatusRunning, Attempts: 3}
+	for _, j := range []jobModel.Job{interrupted, queued, exhausted} {
+		_ = repo.Create(context.Background(), &j)
+	}
+
+	mgr := job.NewManager(repo)
+	started := make(chan string, 2)
+	release := make(chan struct{})
+	mgr.Register("t", blockingRunner(started, release), job.WithQueue(1), job.Resumable())
+	mgr.Register("x", blockingRunner(started, release), job.Resumable())
+
+	if err := mgr.Start(context.Background()); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	if got := <-started; got != "1" {
+		t.Fatalf("expected the interrupted job to resume first, got %s", got)
+	}
+	release <- struct{}{}
+	if got := <-started; got != "2" {
+		t.Fatalf("expected the queued job to follow, got %s", got)
+	}
+	release <- struct{}{}
+
+	resumed := waitForID(t, mgr, interrupted.ID, jobModel.StatusSuccess)
+	if resumed.Attempts != 2 {
+		t.Fatalf("expected resumed job to count a second attempt, got %d", resumed.Attempts)
+	}
+	waitForID(t, mgr, queued.ID, jobModel.StatusSuccess)
+	if got := waitForID(t, mgr, exhausted.ID, jobModel.StatusFailed); got.Error != "interrupted by restart" {
+		t.Fatalf("expected job past maxAttempts to fail, got %+v", got)
+	}
+}
+
+func TestStop_KeepsResumableJobPending(t *testing.T) {
+	repo := newStubRepo()
+	mgr := job.NewManager(repo)
+	started := make(chan string, 2)
+	mgr.Register("r", blockingRunner(started, nil), job.Resumable())
+	mgr.Register("n", blockingRunner(started, nil))
+
+	resumable, _ := mgr.Submit(context.Background(), "r", nil)
+	plain, _ := mgr.Submit(context.Background(), "n", nil)
+	<-started
+	<-started
+
+	if err := mgr.Stop(context.Background()); err != nil {
+		t.Fatalf("stop failed: %v", err)
+	}
+	if got, _ := repo.GetByID(context.Background(), resumable.ID); got.Status != jobModel.StatusPending {
+		t.Fatalf("expected resumable job parked as pending, got %q", got.Status)
+	}
+	if got, _ := repo.GetByID(context.Background(), plain.ID); got.Status != jobModel.StatusFailed {
+		t.Fatalf("expected non-resumable job to fail on shutdown, got %q", got.Status)
+	}
+}
+
+func TestSubmit_AfterTerminalKeepsHistory(t *testing.T) {
 	mgr := job.NewManager(newStubRepo())
 	mgr.Register("t", job.Adapt(func(_ context.Context, _ struct{}, _ job.ReportFunc) (any, error) {
 		return nil, nil
 	}))
-	if _, err := mgr.Submit(context.Background(), "t", nil); err != nil {
+	first, err := mgr.Submit(context.Background(), "t", nil)
+	if err != nil {
 		t.Fatalf("submit failed: %v", err)
 	}
-	waitForStatus(t, mgr, "t", jobModel.StatusSuccess)
-	// 终态后可再次提交（upsert 覆盖旧行）。
-	if _, err := mgr.Submit(context.Background(), "t", nil); err != nil {
+	waitForID(t, mgr, first.ID, jobModel.StatusSuccess)
+	// 终态后可再次提交，新建一行，旧行留作历史。
+	second, err := mgr.Submit(context.Background(), "t", nil)
+	if err != nil {
 		t.Fatalf("resubmit after terminal failed: %v", err)
 	}
-	waitForStatus(t, mgr, "t", jobModel.StatusSuccess)
+	waitForID(t, mgr, second.ID, jobModel.StatusSuccess)
+	if _, total, _ := mgr.List(context.Background(), job.ListQuery{Type: "t"}); total != 2 {
+		t.Fatalf("expected two history rows, got %d", total)
+	}
+
+	// Dismiss 让 Get 回到「无作业」，但历史仍在。
+	if err := mgr.Dismiss(context.Background(), "t"); err != nil {
+		t.Fatalf("dismiss failed: %v", err)
+	}
+	if _, err := mgr.Get(context.Background(), "t"); !errors.Is(err, job.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after dismiss, got %v", err)
+	}
+	if _, total, _ := mgr.List(context.Background(), job.ListQuery{Type: "t"}); total != 2 {
+		t.Fatalf("expected history kept after dismiss, got %d", total)
+	}
 }
@@ -2,10 +2,10 @@
 // Copyright (C) 2025-2026 lin-snow
 
 // Package job 是长时有状态作业的通用框架：统一承载状态机、goroutine 生命周期、
-// 取消、持久化与状态查询；各领域只实现 Runner，触发器只调 Submit。
+// 同类型排队、取消、持久化、历史与状态查询；各领域只实现 Runner，触发器只调 Submit。
 //
-// 本包仅依赖 internal/model/job 与标准库，不 import 任何领域 service；领域 Runner
-// 放在子包 internal/job/runner，故无 import 环。
+// 本包仅依赖 internal/model/job、ID 生成工具与标准库，不 import 任何领域 service；领域
+// Runner 放在子包 internal/job/runner，故无 import 环。
 package job
 
 import (
@@ -15,7 +15,7 @@ import (
 	jobModel "github.com/lin-snow/ech0/internal/model/job"
 )
 
-// ErrNotFound 表示该类型当前没有作业行。上层据此合成领域哨兵（如 migration 的 idle）。
+// ErrNotFound 表示查无作业行。上层据此合成领域哨兵（如 migration 的 idle）。
 var ErrNotFound = errors.New("job not found")
 
 // ReportFunc 供 Runner 上报实时进度（仅进内存，不落库）。phase 必填；snapshot 可为
@@ -29,14 +29,31 @@ type Runner interface {
 	Run(ctx context.Context, payload []byte, report ReportFunc) (result any, err error)
 }
 
-// JobRepository 是 jobs 表的持久化抽象（每类型单行）。
+// ListQuery 是作业历史的筛选条件；零值字段不参与过滤。
+type ListQuery struct {
+	Type   string
+	Status jobModel.Status
+	Limit  int
+	Offset int
+}
+
+// JobRepository 是 job_runs 表的持久化抽象（每次提交一行）。
 type JobRepository interface {
-	Upsert(ctx context.Context, j *jobModel.Job) error
-	// GetByType 查无返回 (零值, ErrNotFound)。
-	GetByType(ctx context.Context, jobType string) (jobModel.Job, error)
-	// SweepRunning 把残留的 pending/running 行批量置 failed（启动期孤儿清理）。
-	SweepRunning(ctx context.Context, reason string) error
-	Delete(ctx context.Context, jobType string) error
+	Create(ctx context.Context, j *jobModel.Job) error
+	// Save 按 ID 覆盖整行。
+	Save(ctx context.Context, j *jobModel.Job) error
+	// GetByID 查无返回 (零值, ErrNotFound)。
+	GetByID(ctx context.Context, id string) (jobModel.Job, error)
+	// Latest 返回该类型最近一次未被 Dismiss 的提交；查无返回 ErrNotFound。
+	Latest(ctx context.Context, jobType string) (jobModel.Job, error)
+	// Active 按提交先后返回 pending/running 行；jobType 为空即全部类型。
+	Active(ctx context.Context, jobType string) ([]jobModel.Job, error)
+	// List 按提交时间倒序分页列出作业，并返回筛选后的总数。
+	List(ctx context.Context, q ListQuery) ([]jobModel.Job, int64, error)
+	// Dismiss 把该类型的终态行标记为已收起：保留在历史里，但不再作为 Latest 返回。
+	Dismiss(ctx context.Context, jobType string) error
+	// Prune 只保留该类型最近 keep 条终态行，删掉更早的历史。
+	Prune(ctx context.Context, jobType string, keep int) error
 }
 
 // Progress 是内存态的实时进度。
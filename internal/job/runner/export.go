@@ -63,6 +63,10 @@ func (r *ExportRunner) Run(
 
 	outcome, err := r.exporter.Export(ctx, report)
 	if err != nil {
+		// 取消不算失败，不打扰管理员。
+		if ctx.Err() == nil {
+			eventbus.Notify(ctx, r.bus, event.SystemSnapshotFailed{Source: "export", Error: err.Error()})
+		}
 		return nil, err
 	}
 
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package runner
+
+import (
+	"context"
+
+	"github.com/lin-snow/ech0/internal/job"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	fileService "github.com/lin-snow/ech0/internal/service/file"
+)
+
+// FileDedupePayload 无输入（全量处理全部受管文件）。
+type FileDedupePayload struct{}
+
+// FileDedupeRunner 把 FileService.DedupeFiles 包成作业 Runner：为存量文件回填内容哈希，
+// 把同内容的文件行合并到同一个存储对象上。
+type FileDedupeRunner struct {
+	svc fileService.Service
+}
+
+func NewFileDedupeRunner(svc fileService.Service) *FileDedupeRunner {
+	return &FileDedupeRunner{svc: svc}
+}
+
+// Run 跑一次去重，每处理完一个文件上报累计进度；终态 result 为 FileDedupeProgress。
+func (r *FileDedupeRunner) Run(ctx context.Context, _ FileDedupePayload, report job.ReportFunc) (any, error) {
+	res, err := r.svc.DedupeFiles(ctx, func(progress fileModel.FileDedupeProgress) {
+		report("deduplicating", progress)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
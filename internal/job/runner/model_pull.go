@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/lin-snow/ech0/internal/job"
+	embeddingModel "github.com/lin-snow/ech0/internal/model/embedding"
+	"github.com/lin-snow/ech0/internal/ollama"
+)
+
+// ModelPullRunner 经 Ollama /api/pull 把模型拉到本地。拉取动辄数 GB、耗时数分钟，
+// 包成作业后前端按类型轮询进度，请求本身不必挂着等。
+type ModelPullRunner struct{}
+
+func NewModelPullRunner() *ModelPullRunner {
+	return &ModelPullRunner{}
+}
+
+// Run 拉取 payload 指定的模型，逐条上报 Ollama 的进度；终态 result 为最后一条进度（status=success）。
+// 已拉取过的模型 Ollama 只校验清单，很快即 success，故重复提交无害。
+func (r *ModelPullRunner) Run(ctx context.Context, p embeddingModel.ModelPullPayload, report job.ReportFunc) (any, error) {
+	model := strings.TrimSpace(p.Model)
+	if model == "" {
+		return nil, errors.New("model pull: model is required")
+	}
+	last := embeddingModel.ModelPullProgress{Model: model, Status: "pending"}
+	err := ollama.New(p.BaseURL).Pull(ctx, model, func(progress ollama.PullProgress) {
+		last = embeddingModel.ModelPullProgress{
+			Model:     model,
+			Status:    progress.Status,
+			Digest:    progress.Digest,
+			Total:     progress.Total,
+			Completed: progress.Completed,
+		}
+		report("pulling", last)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return last, nil
+}
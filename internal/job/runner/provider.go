@@ -10,4 +10,10 @@ var ProviderSet = wire.NewSet(
 	NewReindexRunner,
 	NewMigrationRunner,
 	NewExportRunner,
+	NewSyncRunner,
+	NewPublishRunner,
+	NewModelPullRunner,
+	NewStorageMigrationRunner,
+	NewFileDedupeRunner,
+	NewWebmentionRunner,
 )
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package runner
+
+import (
+	"context"
+
+	"github.com/lin-snow/ech0/internal/job"
+	coreMigrator "github.com/lin-snow/ech0/internal/migrator"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+)
+
+// Publisher 是静态站发布执行端（由 migrator.CapsuleEngine 满足）。
+type Publisher interface {
+	Publish(ctx context.Context, report func(phase string, snapshot any)) (migratorModel.PublishReport, error)
+}
+
+var _ Publisher = (*coreMigrator.CapsuleEngine)(nil)
+
+// PublishRunner 把一次「重建 + 发布」包成作业 Runner。内容变更触发与手动触发共用同一作业类型，
+// 两次发布叠跑会对同一目标交错上传、互相覆盖清单。
+type PublishRunner struct {
+	publisher Publisher
+}
+
+func NewPublishRunner(publisher *coreMigrator.CapsuleEngine) *PublishRunner {
+	return &PublishRunner{publisher: publisher}
+}
+
+// Run 跑一次发布；终态 result 为 PublishReport。
+func (r *PublishRunner) Run(ctx context.Context, _ migratorModel.PublishPayload, report job.ReportFunc) (any, error) {
+	return r.publisher.Publish(ctx, report)
+}
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package runner
+
+import (
+	"context"
+
+	"github.com/lin-snow/ech0/internal/job"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	fileService "github.com/lin-snow/ech0/internal/service/file"
+)
+
+// StorageMigrationRunner 把 FileService.MigrateStorage 包成作业 Runner：在本地盘与对象存储之间
+// （或两个桶之间）搬运全部受管文件，逐个改写文件行的路由列。
+type StorageMigrationRunner struct {
+	svc fileService.Service
+}
+
+func NewStorageMigrationRunner(svc fileService.Service) *StorageMigrationRunner {
+	return &StorageMigrationRunner{svc: svc}
+}
+
+// Run 跑一次迁移，每处理完一个文件上报累计进度；终态 result 为 StorageMigrationProgress。
+func (r *StorageMigrationRunner) Run(
+	ctx context.Context,
+	p fileModel.StorageMigrationPayload,
+	report job.ReportFunc,
+) (any, error) {
+	res, err := r.svc.MigrateStorage(ctx, p, func(progress fileModel.StorageMigrationProgress) {
+		report("migrating", progress)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
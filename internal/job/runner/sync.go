@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package runner
+
+import (
+	"context"
+
+	"github.com/lin-snow/ech0/internal/job"
+	coreMigrator "github.com/lin-snow/ech0/internal/migrator"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+)
+
+// Syncer 是实例间同步执行端（由 migrator.CapsuleEngine 满足）。
+type Syncer interface {
+	Sync(ctx context.Context, report func(phase string, snapshot any)) (migratorModel.SyncReport, error)
+}
+
+var _ Syncer = (*coreMigrator.CapsuleEngine)(nil)
+
+// SyncRunner 把一轮同步包成作业 Runner。手动触发与定时触发共用同一作业类型，天然互斥：
+// 两轮同步叠跑会拿同一水位各拉一遍，冲突报告也会重复。
+type SyncRunner struct {
+	syncer Syncer
+}
+
+func NewSyncRunner(syncer *coreMigrator.CapsuleEngine) *SyncRunner {
+	return &SyncRunner{syncer: syncer}
+}
+
+// Run 跑一轮同步；终态 result 为 SyncReport，冲突明细就在其中。
+func (r *SyncRunner) Run(ctx context.Context, _ migratorModel.SyncPayload, report job.ReportFunc) (any, error) {
+	return r.syncer.Sync(ctx, report)
+}
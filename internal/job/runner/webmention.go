@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package runner
+
+import (
+	"context"
+
+	"github.com/lin-snow/ech0/internal/job"
+	"github.com/lin-snow/ech0/internal/webmention"
+)
+
+// WebmentionRunner 投递一条 Echo 的外发 Webmention。排队的投递随作业行落库，进程重启后
+// 按原 payload 续跑；Webmention 按 (source, target) 幂等，已送达的目标重发一次无害。
+type WebmentionRunner struct {
+	sender *webmention.Sender
+}
+
+func NewWebmentionRunner() *WebmentionRunner {
+	return &WebmentionRunner{sender: webmention.NewSender()}
+}
+
+// Run 通知 payload 中的全部目标；终态 result 为 SendResult。
+func (r *WebmentionRunner) Run(ctx context.Context, p webmention.SendPayload, report job.ReportFunc) (any, error) {
+	result, err := r.sender.SendAll(ctx, p, report)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
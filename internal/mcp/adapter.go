@@ -14,6 +14,7 @@ import (
 	copilotService "github.com/lin-snow/ech0/internal/service/copilot"
 	dashboardService "github.com/lin-snow/ech0/internal/service/dashboard"
 	echoService "github.com/lin-snow/ech0/internal/service/echo"
+	embeddingService "github.com/lin-snow/ech0/internal/service/embedding"
 	fileService "github.com/lin-snow/ech0/internal/service/file"
 	settingService "github.com/lin-snow/ech0/internal/service/setting"
 	userService "github.com/lin-snow/ech0/internal/service/user"
@@ -29,6 +30,7 @@ type Adapter struct {
 	agentSvc     copilotService.SummaryService
 	settingSvc   settingService.Service
 	dashboardSvc dashboardService.Service
+	embeddingSvc embeddingService.Service
 }
 
 func NewAdapter(
@@ -41,6 +43,7 @@ func NewAdapter(
 	agentSvc copilotService.SummaryService,
 	settingSvc settingService.Service,
 	dashboardSvc dashboardService.Service,
+	embeddingSvc embeddingService.Service,
 ) *Adapter {
 	return &Adapter{
 		echoSvc:      echoSvc,
@@ -52,6 +55,7 @@ func NewAdapter(
 		agentSvc:     agentSvc,
 		settingSvc:   settingSvc,
 		dashboardSvc: dashboardSvc,
+		embeddingSvc: embeddingSvc,
 	}
 }
 
@@ -69,6 +73,8 @@ func (a *Adapter) RegisterAll(reg *Registry) {
 	a.registerAgentTools(reg)
 	a.registerWebhookTools(reg)
 	a.registerDashboardResources(reg)
+	a.registerEmbeddingTools(reg)
+	a.registerPrompts(reg)
 }
 
 // --- Argument helpers ---
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	authModel "github.com/lin-snow/ech0/internal/model/auth"
+	"github.com/lin-snow/ech0/pkg/viewer"
+)
+
+// registerEmbeddingTools registers tools backed by the vector index. They
+// only appear while embedding is active; toggling it publishes
+// event.FeatureToggled, which the Notifier turns into tools/list_changed.
+func (a *Adapter) registerEmbeddingTools(reg *Registry) {
+	reg.RegisterConditionalTool(ToolDefinition{
+		Name:  "semantic_search_posts",
+		Title: "Semantic Search Posts",
+		Description: "Find the token owner's posts by meaning rather than exact keywords, using the vector index. " +
+			"Returns up to limit hits ordered by similarity: [{echo_id, content, username, echo_created, distance}]. " +
+			"Only available while embedding is enabled in settings.",
+		InputSchema: map[string]any{
+			"type":     "object",
+			"required": []string{"query"},
+			"properties": map[string]any{
+				"query": map[string]any{"type": "string", "description": "Natural-language description of what to find"},
+				"limit": map[string]any{"type": "integer", "description": "Maximum hits (1–50)", "default": 10},
+			},
+		},
+	}, a.semanticSearchPosts, a.embeddingEnabled, authModel.ScopeEchoRead)
+}
+
+func (a *Adapter) embeddingEnabled(ctx context.Context) bool {
+	return a.embeddingSvc != nil && a.embeddingSvc.Enabled(ctx)
+}
+
+func (a *Adapter) semanticSearchPosts(ctx context.Context, args map[string]any) (*ToolCallResult, error) {
+	query := stringArg(args, "query")
+	if query == "" {
+		return textError("query is required"), nil
+	}
+	limit := intArg(args, "limit", 10)
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	// Same isolation as Copilot search: hits are limited to the token owner's
+	// own posts, so a multi-user instance never surfaces someone else's
+	// private echoes through the index.
+	user, err := a.userSvc.GetUserByID(viewer.MustFromContext(ctx).UserID())
+	if err != nil {
+		return nil, fmt.Errorf("resolve token owner: %w", err)
+	}
+	results, err := a.embeddingSvc.Search(ctx, query, limit, user.Username)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResult(results)
+}
@@ -0,0 +1,349 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	authModel "github.com/lin-snow/ech0/internal/model/auth"
+	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	"github.com/lin-snow/ech0/pkg/viewer"
+)
+
+const (
+	promptDateLayout = "2006-01-02"
+	// promptMaxPosts caps the posts embedded in one prompt (QueryEchos' page
+	// size limit); longer ranges are reported as truncated.
+	promptMaxPosts = 100
+	// promptExcerptRunes bounds each embedded post or comment so a single
+	// long entry cannot crowd out the rest of the context.
+	promptExcerptRunes = 1500
+	// promptMaxRangeDays bounds weekly_review ranges.
+	promptMaxRangeDays = 366
+)
+
+func (a *Adapter) registerPrompts(reg *Registry) {
+	reg.RegisterPrompt(PromptDefinition{
+		Name:        "weekly_review",
+		Title:       "Weekly Review",
+		Description: "Review the posts published in a date range (default: the last 7 days): themes, highlights, open threads and suggestions for what to write next.",
+		Arguments: []PromptArgument{
+			{Name: "from", Description: "First day, YYYY-MM-DD (default: 6 days before `to`)"},
+			{Name: "to", Description: "Last day, YYYY-MM-DD (default: today)"},
+			{Name: "timezone", Description: "IANA timezone for day boundaries (default: UTC)"},
+		},
+	}, a.promptWeeklyReview, authModel.ScopeEchoRead)
+
+	reg.RegisterPrompt(PromptDefinition{
+		Name:        "draft_in_my_voice",
+		Title:       "Draft an Echo in My Voice",
+		Description: "Draft a new post on a topic, seeded with the token owner's recent posts as style examples. The draft is returned for review, not published.",
+		Arguments: []PromptArgument{
+			{Name: "topic", Description: "What the new post should be about", Required: true},
+			{Name: "examples", Description: "How many recent posts to use as style examples, 1–30 (default: 10)"},
+		},
+	}, a.promptDraftInMyVoice, authModel.ScopeEchoRead)
+
+	reg.RegisterPrompt(PromptDefinition{
+		Name:        "summarize_discussion",
+		Title:       "Summarise Discussion",
+		Description: "Summarise the discussion on a post: the post itself plus its approved comments and replies.",
+		Arguments: []PromptArgument{
+			{Name: "echo_id", Description: "UUID of the post", Required: true},
+		},
+	}, a.promptSummarizeDiscussion, authModel.ScopeEchoRead, authModel.ScopeCommentRead)
+
+	reg.RegisterPrompt(PromptDefinition{
+		Name:        "triage_pending_comments",
+		Title:       "Triage Pending Comments",
+		Description: "Recommend approve or reject for each comment waiting in the moderation queue, with the post it was left on for context. Admin only.",
+		Arguments: []PromptArgument{
+			{Name: "limit", Description: "Maximum comments to triage, 1–100 (default: 20)"},
+		},
+	}, a.promptTriagePendingComments, authModel.ScopeCommentMod)
+}
+
+func (a *Adapter) promptWeeklyReview(ctx context.Context, args map[string]string) (*PromptGetResult, error) {
+	loc, err := promptLocation(args["timezone"])
+	if err != nil {
+		return nil, err
+	}
+	to := time.Now().In(loc)
+	if args["to"] != "" {
+		if to, err = promptDate(args["to"], loc, "to"); err != nil {
+			return nil, err
+		}
+	}
+	to = startOfDay(to)
+	from := to.AddDate(0, 0, -6)
+	if args["from"] != "" {
+		if from, err = promptDate(args["from"], loc, "from"); err != nil {
+			return nil, err
+		}
+	}
+	if from.After(to) {
+		return nil, fmt.Errorf("%w: from must not be after to", errInvalidPromptArgument)
+	}
+	if to.Sub(from) > promptMaxRangeDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: range must not exceed %d days", errInvalidPromptArgument, promptMaxRangeDays)
+	}
+
+	result, err := a.echoSvc.QueryEchos(ctx, commonModel.EchoQueryDto{
+		Page:      1,
+		PageSize:  promptMaxPosts,
+		SortBy:    "created_at",
+		SortOrder: "asc",
+		DateFrom:  from.Unix(),
+		DateTo:    to.AddDate(0, 0, 1).Unix() - 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	period := from.Format(promptDateLayout) + " – " + to.Format(promptDateLayout)
+	var b strings.Builder
+	fmt.Fprintf(&b, "Write a review of what I posted on my microblog between %s (%s).\n\n", period, loc)
+	b.WriteString("Cover:\n")
+	b.WriteString("1. The main themes, and how they developed over the period.\n")
+	b.WriteString("2. Highlights worth revisiting, citing posts by date.\n")
+	b.WriteString("3. Open threads: questions, plans or ideas I mentioned but did not follow up on.\n")
+	b.WriteString("4. Two or three suggestions for what to write next.\n\n")
+	b.WriteString("Keep it concise and write in the language most of the posts use.\n\n")
+	if len(result.Items) == 0 {
+		b.WriteString("There are no posts in this period; say so briefly and suggest a few prompts to get writing again.\n")
+	} else {
+		fmt.Fprintf(&b, "Posts (%d, oldest first):\n\n", len(result.Items))
+		writePromptPosts(&b, result.Items, loc)
+		writeTruncationNote(&b, result.Total, len(result.Items))
+	}
+	return userPrompt("Review of posts from "+period, b.String()), nil
+}
+
+func (a *Adapter) promptDraftInMyVoice(ctx context.Context, args map[string]string) (*PromptGetResult, error) {
+	examples, err := promptIntArg(args, "examples", 10, 1, 30)
+	if err != nil {
+		return nil, err
+	}
+	topic := strings.TrimSpace(args["topic"])
+
+	// Seed with the token owner's own posts only: on a multi-user instance the
+	// voice to imitate is theirs, not whoever posted last.
+	result, err := a.echoSvc.QueryEchos(ctx, commonModel.EchoQueryDto{
+		Page:     1,
+		PageSize: examples,
+		UserID:   viewer.MustFromContext(ctx).UserID(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Draft a new post for my microblog about: %s\n\n", topic)
+	b.WriteString("Match my voice: tone, length, language, formatting habits (Markdown, line breaks, emoji) and how I use tags. ")
+	b.WriteString("Do not invent facts about me. Return only the draft text, ready for me to edit; do not publish it.\n\n")
+	if len(result.Items) == 0 {
+		b.WriteString("I have not posted anything yet, so keep the draft short, plain and personal.\n")
+	} else {
+		fmt.Fprintf(&b, "My %d most recent posts, as style examples (newest first):\n\n", len(result.Items))
+		writePromptPosts(&b, result.Items, time.UTC)
+	}
+	return userPrompt("Draft about "+topic, b.String()), nil
+}
+
+func (a *Adapter) promptSummarizeDiscussion(ctx context.Context, args map[string]string) (*PromptGetResult, error) {
+	echoID := strings.TrimSpace(args["echo_id"])
+	echo, err := a.echoSvc.GetEchoById(ctx, echoID)
+	if err != nil {
+		return nil, err
+	}
+	if echo == nil {
+		return nil, fmt.Errorf("%w: post %q not found", errInvalidPromptArgument, echoID)
+	}
+	comments, err := a.commentSvc.ListPublicByEchoID(ctx, echoID)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString("Summarise the discussion on this post from my microblog.\n\n")
+	b.WriteString("Cover the main points readers raised, where they agree or disagree, questions still waiting for my answer, ")
+	b.WriteString("and anything that deserves a follow-up post. Attribute points by nickname. ")
+	b.WriteString("If there are no comments, just say so.\n\n")
+	b.WriteString("Post:\n\n")
+	writePromptPosts(&b, []echoModel.Echo{*echo}, time.UTC)
+	fmt.Fprintf(&b, "\nComments (%d, oldest first; replies are indented under the comment they answer):\n\n", len(comments))
+	writePromptThread(&b, comments)
+	return userPrompt("Discussion on post "+echoID, b.String()), nil
+}
+
+func (a *Adapter) promptTriagePendingComments(ctx context.Context, args map[string]string) (*PromptGetResult, error) {
+	limit, err := promptIntArg(args, "limit", 20, 1, 100)
+	if err != nil {
+		return nil, err
+	}
+	page, err := a.commentSvc.ListPanelComments(ctx, commentModel.ListCommentQuery{
+		Page:     1,
+		PageSize: limit,
+		Status:   string(commentModel.StatusPending),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString("Triage the comments waiting for moderation on my microblog.\n\n")
+	b.WriteString("For each comment recommend approve or reject, with a one-line reason. Reject spam, advertising, abuse and ")
+	b.WriteString("comments unrelated to the post; approve genuine reactions and questions even when they are critical. ")
+	b.WriteString("Flag anything you are unsure about instead of guessing. Answer with a table: comment id, recommendation, reason.\n\n")
+	if len(page.Items) == 0 {
+		b.WriteString("The moderation queue is empty; say so.\n")
+		return userPrompt("Pending comment triage", b.String()), nil
+	}
+
+	fmt.Fprintf(&b, "Pending comments (%d):\n\n", len(page.Items))
+	excerpts := make(map[string]string)
+	for _, c := range page.Items {
+		excerpt, seen := excerpts[c.EchoID]
+		if !seen {
+			// The post gives the reviewer context for "unrelated" and spam calls.
+			if echo, err := a.echoSvc.GetEchoById(ctx, c.EchoID); err == nil && echo != nil {
+				excerpt = truncateRunes(oneLine(echo.Content), 200)
+			}
+			excerpts[c.EchoID] = excerpt
+		}
+		fmt.Fprintf(&b, "- id: %s | %s | by %s", c.ID, formatPromptTime(c.CreatedAt, time.UTC), c.Nickname)
+		if c.Website != "" {
+			fmt.Fprintf(&b, " (%s)", c.Website)
+		}
+		fmt.Fprintf(&b, " | source: %s\n", c.Source)
+		if excerpt != "" {
+			fmt.Fprintf(&b, "  on post: %s\n", excerpt)
+		}
+		fmt.Fprintf(&b, "  %s\n", indent(truncateRunes(c.Content, promptExcerptRunes), "  "))
+	}
+	writeTruncationNote(&b, page.Total, len(page.Items))
+	return userPrompt("Pending comment triage", b.String()), nil
+}
+
+// --- Prompt formatting helpers ---
+
+func writePromptPosts(b *strings.Builder, posts []echoModel.Echo, loc *time.Location) {
+	for _, p := range posts {
+		fmt.Fprintf(b, "- [%s] id: %s", formatPromptTime(p.CreatedAt, loc), p.ID)
+		if len(p.Tags) > 0 {
+			names := make([]string, len(p.Tags))
+			for i, t := range p.Tags {
+				names[i] = t.Name
+			}
+			fmt.Fprintf(b, " | tags: %s", strings.Join(names, ", "))
+		}
+		if p.Private {
+			b.WriteString(" | private")
+		}
+		if n := len(p.EchoFiles); n > 0 {
+			fmt.Fprintf(b, " | %d attachment(s)", n)
+		}
+		if p.Extension != nil {
+			fmt.Fprintf(b, " | extension: %s", p.Extension.Type)
+		}
+		b.WriteByte('\n')
+		if content := strings.TrimSpace(p.Content); content != "" {
+			fmt.Fprintf(b, "  %s\n", indent(truncateRunes(content, promptExcerptRunes), "  "))
+		}
+	}
+}
+
+// writePromptThread renders comments two levels deep, mirroring how the
+// site displays them (replies under their top-level comment).
+func writePromptThread(b *strings.Builder, comments []commentModel.PublicComment) {
+	replies := make(map[string][]commentModel.PublicComment)
+	for _, c := range comments {
+		if c.ParentID != nil && *c.ParentID != "" {
+			replies[*c.ParentID] = append(replies[*c.ParentID], c)
+		}
+	}
+	write := func(c commentModel.PublicComment, prefix string) {
+		fmt.Fprintf(b, "%s- %s (%s): %s\n", prefix, c.Nickname, formatPromptTime(c.CreatedAt, time.UTC),
+			indent(truncateRunes(c.Content, promptExcerptRunes), prefix+"  "))
+	}
+	for _, c := range comments {
+		if c.ParentID != nil && *c.ParentID != "" {
+			continue
+		}
+		write(c, "")
+		for _, r := range replies[c.ID] {
+			write(r, "  ")
+		}
+	}
+}
+
+func writeTruncationNote(b *strings.Builder, total int64, shown int) {
+	if total > int64(shown) {
+		fmt.Fprintf(b, "\n(Only the first %d of %d are included.)\n", shown, total)
+	}
+}
+
+func formatPromptTime(unix int64, loc *time.Location) string {
+	return time.Unix(unix, 0).In(loc).Format("2006-01-02 15:04")
+}
+
+func promptLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown timezone %q", errInvalidPromptArgument, name)
+	}
+	return loc, nil
+}
+
+func promptDate(value string, loc *time.Location, name string) (time.Time, error) {
+	t, err := time.ParseInLocation(promptDateLayout, strings.TrimSpace(value), loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s must be YYYY-MM-DD", errInvalidPromptArgument, name)
+	}
+	return t, nil
+}
+
+// promptIntArg parses an optional integer argument. Prompt arguments are
+// strings on the wire, unlike tool arguments.
+func promptIntArg(args map[string]string, key string, fallback, lo, hi int) (int, error) {
+	raw := strings.TrimSpace(args[key])
+	if raw == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < lo || n > hi {
+		return 0, fmt.Errorf("%w: %s must be an integer between %d and %d", errInvalidPromptArgument, key, lo, hi)
+	}
+	return n, nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n]) + "…"
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func indent(s, prefix string) string {
+	return strings.ReplaceAll(strings.TrimSpace(s), "\n", "\n"+prefix)
+}
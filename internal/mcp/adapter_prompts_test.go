@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package mcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	authModel "github.com/lin-snow/ech0/internal/model/auth"
+	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	"github.com/lin-snow/ech0/internal/test/mocks/commentmock"
+	"github.com/lin-snow/ech0/internal/test/mocks/echomock"
+	"github.com/lin-snow/ech0/pkg/viewer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func promptCtx() context.Context {
+	return viewer.WithContext(context.Background(), testViewer())
+}
+
+func promptText(t *testing.T, result *PromptGetResult) string {
+	t.Helper()
+	require.Len(t, result.Messages, 1)
+	assert.Equal(t, "user", result.Messages[0].Role)
+	return result.Messages[0].Content.Text
+}
+
+func TestAdapterRegistersPrompts(t *testing.T) {
+	reg := NewRegistry()
+	NewAdapter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).RegisterAll(reg)
+
+	want := map[string][]string{
+		"weekly_review":           {authModel.ScopeEchoRead},
+		"draft_in_my_voice":       {authModel.ScopeEchoRead},
+		"summarize_discussion":    {authModel.ScopeEchoRead, authModel.ScopeCommentRead},
+		"triage_pending_comments": {authModel.ScopeCommentMod},
+	}
+	assert.Len(t, reg.PromptDefinitions(), len(want))
+	for name, scopes := range want {
+		_, _, got, ok := reg.LookupPrompt(name)
+		require.True(t, ok, "prompt %q not registered", name)
+		assert.Equal(t, scopes, got, "prompt %q scopes", name)
+	}
+}
+
+func TestPromptWeeklyReview(t *testing.T) {
+	echoSvc := echomock.NewMockService(t)
+	a := &Adapter{echoSvc: echoSvc}
+
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, shanghai)
+	echoSvc.EXPECT().QueryEchos(mock.Anything, mock.MatchedBy(func(q commonModel.EchoQueryDto) bool {
+		return q.DateFrom == from.Unix() &&
+			q.DateTo == from.AddDate(0, 0, 7).Unix()-1 &&
+			q.SortOrder == "asc"
+	})).Return(commonModel.PageQueryResult[[]echoModel.Echo]{
+		Total: 101,
+		Items: []echoModel.Echo{{
+			ID:        "e1",
+			Content:   "Shipped the MCP prompts",
+			Tags:      []echoModel.Tag{{Name: "dev"}},
+			CreatedAt: from.Add(9 * time.Hour).Unix(),
+		}},
+	}, nil)
+
+	result, err := a.promptWeeklyReview(promptCtx(), map[string]string{
+		"from": "2026-03-02", "to": "2026-03-08", "timezone": "Asia/Shanghai",
+	})
+	require.NoError(t, err)
+	text := promptText(t, result)
+	assert.Contains(t, text, "2026-03-02 – 2026-03-08")
+	assert.Contains(t, text, "[2026-03-02 09:00] id: e1 | tags: dev")
+	assert.Contains(t, text, "Shipped the MCP prompts")
+	assert.Contains(t, text, "Only the first 1 of 101")
+}
+
+func TestPromptWeeklyReviewRejectsBadArguments(t *testing.T) {
+	a := &Adapter{}
+	for _, args := range []map[string]string{
+		{"from": "2026-03-09", "to": "2026-03-08"},
+		{"from": "last monday"},
+		{"timezone": "Mars/Olympus"},
+		{"from": "2020-01-01", "to": "2026-01-01"},
+	} {
+		_, err := a.promptWeeklyReview(promptCtx(), args)
+		assert.ErrorIs(t, err, errInvalidPromptArgument, "args %v", args)
+	}
+}
+
+func TestPromptDraftInMyVoice(t *testing.T) {
+	echoSvc := echomock.NewMockService(t)
+	a := &Adapter{echoSvc: echoSvc}
+
+	echoSvc.EXPECT().QueryEchos(mock.Anything, mock.MatchedBy(func(q commonModel.EchoQueryDto) bool {
+		return q.UserID == "test-user" && q.PageSize == 3
+	})).Return(commonModel.PageQueryResult[[]echoModel.Echo]{
+		Total: 1,
+		Items: []echoModel.Echo{{ID: "e1", Content: "今天也很好 ☀️"}},
+	}, nil)
+
+	result, err := a.promptDraftInMyVoice(promptCtx(), map[string]string{"topic": "spring", "examples": "3"})
+	require.NoError(t, err)
+	text := promptText(t, result)
+	assert.Contains(t, text, "about: spring")
+	assert.Contains(t, text, "do not publish it")
+	assert.Contains(t, text, "今天也很好 ☀️")
+
+	_, err = a.promptDraftInMyVoice(promptCtx(), map[string]string{"topic": "spring", "examples": "99"})
+	assert.ErrorIs(t, err, errInvalidPromptArgument)
+}
+
+func TestPromptSummarizeDiscussion(t *testing.T) {
+	echoSvc := echomock.NewMockService(t)
+	commentSvc := commentmock.NewMockService(t)
+	a := &Adapter{echoSvc: echoSvc, commentSvc: commentSvc}
+
+	parent := "c1"
+	echoSvc.EXPECT().GetEchoById(mock.Anything, "e1").Return(&echoModel.Echo{ID: "e1", Content: "Tabs or spaces?"}, nil)
+	commentSvc.EXPECT().ListPublicByEchoID(mock.Anything, "e1").Return([]commentModel.PublicComment{
+		{ID: "c1", Nickname: "alice", Content: "Tabs."},
+		{ID: "c2", Nickname: "bob", Content: "Spaces!", ParentID: &parent},
+		{ID: "c3", Nickname: "carol", Content: "Whatever gofmt says."},
+	}, nil)
+
+	result, err := a.promptSummarizeDiscussion(promptCtx(), map[string]string{"echo_id": "e1"})
+	require.NoError(t, err)
+	text := promptText(t, result)
+	assert.Contains(t, text, "Tabs or spaces?")
+	// Replies sit under their parent, before the next top-level comment.
+	assert.Regexp(t, `(?s)- alice .*Tabs\.\n  - bob .*Spaces!\n- carol `, text)
+
+	echoSvc.EXPECT().GetEchoById(mock.Anything, "missing").Return(nil, errors.New("echo not found"))
+	_, err = a.promptSummarizeDiscussion(promptCtx(), map[string]string{"echo_id": "missing"})
+	assert.Error(t, err)
+}
+
+func TestPromptTriagePendingComments(t *testing.T) {
+	echoSvc := echomock.NewMockService(t)
+	commentSvc := commentmock.NewMockService(t)
+	a := &Adapter{echoSvc: echoSvc, commentSvc: commentSvc}
+
+	commentSvc.EXPECT().ListPanelComments(mock.Anything, mock.MatchedBy(func(q commentModel.ListCommentQuery) bool {
+		return q.Status == string(commentModel.StatusPending) && q.PageSize == 20
+	})).Return(commentModel.PageResult[commentModel.Comment]{
+		Total: 2,
+		Items: []commentModel.Comment{
+			{ID: "c1", EchoID: "e1", Nickname: "spammer", Email: "spam@example.com", Content: "Cheap pills"},
+			{ID: "c2", EchoID: "e1", Nickname: "reader", Email: "reader@example.com", Content: "Nice photo!"},
+		},
+	}, nil)
+	// The post excerpt is fetched once per distinct echo.
+	echoSvc.EXPECT().GetEchoById(mock.Anything, "e1").Return(&echoModel.Echo{ID: "e1", Content: "Sunset\nat the pier"}, nil).Once()
+
+	result, err := a.promptTriagePendingComments(promptCtx(), nil)
+	require.NoError(t, err)
+	text := promptText(t, result)
+	assert.Contains(t, text, "id: c1")
+	assert.Contains(t, text, "id: c2")
+	assert.Contains(t, text, "on post: Sunset at the pier")
+	assert.NotContains(t, text, "@example.com", "commenter emails must not reach the model")
+}
@@ -31,9 +31,10 @@ const (
 	cacheScopePrivate = "private"
 )
 
-// Freshness hints in milliseconds. Tool and resource definitions are fixed
-// at process start, so discover/list results cache well; read results are
-// live data and marked immediately stale.
+// Freshness hints in milliseconds. Tool and resource definitions rarely
+// change (feature-gated tools are announced via tools/list_changed), so
+// discover/list results cache well; read results are live data and marked
+// immediately stale.
 const (
 	discoverTTLMs = 60 * 60 * 1000
 	listTTLMs     = 5 * 60 * 1000
@@ -42,6 +43,7 @@ const (
 type ServerCapabilities struct {
 	Tools     *ToolsCapability     `json:"tools,omitempty"`
 	Resources *ResourcesCapability `json:"resources,omitempty"`
+	Prompts   *PromptsCapability   `json:"prompts,omitempty"`
 }
 
 type ToolsCapability struct {
@@ -53,6 +55,10 @@ type ResourcesCapability struct {
 	ListChanged bool `json:"listChanged"`
 }
 
+type PromptsCapability struct {
+	ListChanged bool `json:"listChanged"`
+}
+
 // ResultEnvelope carries the fields every 2026-07-28 result must include:
 // the mandatory resultType, and the _meta serverInfo the spec recommends.
 // Server.handlePost stamps it on every successful result via complete().
@@ -73,7 +79,8 @@ func (e *ResultEnvelope) complete(info ServerInfo) {
 type completer interface{ complete(info ServerInfo) }
 
 // CacheInfo is the CacheableResult contract: required on server/discover,
-// tools/list, resources/list and resources/read results.
+// tools/list, resources/list, resources/read, prompts/list and prompts/get
+// results.
 type CacheInfo struct {
 	TTLMs      int64  `json:"ttlMs"`
 	CacheScope string `json:"cacheScope"`
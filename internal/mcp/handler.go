@@ -4,6 +4,8 @@
 package mcp
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	commentService "github.com/lin-snow/ech0/internal/service/comment"
 	commonService "github.com/lin-snow/ech0/internal/service/common"
@@ -11,6 +13,7 @@ import (
 	copilotService "github.com/lin-snow/ech0/internal/service/copilot"
 	dashboardService "github.com/lin-snow/ech0/internal/service/dashboard"
 	echoService "github.com/lin-snow/ech0/internal/service/echo"
+	embeddingService "github.com/lin-snow/ech0/internal/service/embedding"
 	fileService "github.com/lin-snow/ech0/internal/service/file"
 	settingService "github.com/lin-snow/ech0/internal/service/setting"
 	userService "github.com/lin-snow/ech0/internal/service/user"
@@ -30,11 +33,19 @@ func NewHandler(
 	agentSvc copilotService.SummaryService,
 	settingSvc settingService.Service,
 	dashboardSvc dashboardService.Service,
+	embeddingSvc embeddingService.Service,
+	notifier *Notifier,
 ) *Handler {
 	registry := NewRegistry()
-	adapter := NewAdapter(echoSvc, userSvc, commentSvc, fileSvc, commonSvc, connectSvc, agentSvc, settingSvc, dashboardSvc)
+	adapter := NewAdapter(echoSvc, userSvc, commentSvc, fileSvc, commonSvc, connectSvc, agentSvc, settingSvc, dashboardSvc, embeddingSvc)
 	adapter.RegisterAll(registry)
-	return &Handler{server: NewServer(registry)}
+	return &Handler{server: NewServer(registry, notifier)}
+}
+
+// ServeHTTP serves the MCP endpoint outside Gin, e.g. in-process for the
+// stdio transport. The caller must attach a viewer to the request context.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.server.ServeHTTP(w, r)
 }
 
 func (h *Handler) ServeEndpoint() gin.HandlerFunc {
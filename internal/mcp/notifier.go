@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package mcp
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/lin-snow/ech0/internal/event"
+	eventbus "github.com/lin-snow/ech0/internal/event/bus"
+)
+
+// Server-to-client notification methods.
+const (
+	methodResourcesUpdated = "notifications/resources/updated"
+	methodToolsListChanged = "notifications/tools/list_changed"
+)
+
+// Resources that accept resources/subscribe. Each is driven by domain
+// events in Notifier.Registrations.
+const (
+	uriRecentPosts    = "ech0://posts/recent"
+	uriRecentComments = "ech0://comments/recent"
+)
+
+// streamBuffer bounds the notifications queued per open stream. When a slow
+// client falls behind, further notifications are dropped: one pending
+// resources/updated already tells it to re-read, so nothing is lost.
+const streamBuffer = 16
+
+// Notification is a JSON-RPC notification pushed to subscribers.
+type Notification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+// ResourceUpdatedParams is the payload of notifications/resources/updated.
+type ResourceUpdatedParams struct {
+	URI string `json:"uri"`
+}
+
+// SubscribeParams is the body of resources/subscribe.
+type SubscribeParams struct {
+	URI string `json:"uri"`
+}
+
+// SubscribeResult closes a subscription stream. It is only sent when the
+// server ends the stream (shutdown); clients should then re-subscribe.
+type SubscribeResult struct {
+	ResultEnvelope
+}
+
+// subscriptionKey maps a subscribe URI onto the resource it watches. Query
+// parameters (e.g. ?limit=5) do not change which events apply.
+func subscriptionKey(uri string) (string, bool) {
+	base, _, _ := strings.Cut(uri, "?")
+	switch base {
+	case uriRecentPosts, uriRecentComments:
+		return base, true
+	}
+	return "", false
+}
+
+type stream struct {
+	key string
+	uri string
+	ch  chan Notification
+}
+
+// Notifier fans domain events out to open subscription streams. It is a
+// process-wide singleton: the event registrar feeds it, the MCP server reads
+// from it. Close ends every stream so HTTP shutdown is not held up by
+// long-lived responses.
+type Notifier struct {
+	mu      sync.Mutex
+	streams map[*stream]struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+func NewNotifier() *Notifier {
+	return &Notifier{
+		streams: make(map[*stream]struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// open registers a stream for uri. The returned func must be called when
+// the client goes away.
+func (n *Notifier) open(key, uri string) (*stream, func()) {
+	st := &stream{key: key, uri: uri, ch: make(chan Notification, streamBuffer)}
+	n.mu.Lock()
+	n.streams[st] = struct{}{}
+	n.mu.Unlock()
+	return st, func() {
+		n.mu.Lock()
+		delete(n.streams, st)
+		n.mu.Unlock()
+	}
+}
+
+// Done is closed once the Notifier is shut down.
+func (n *Notifier) Done() <-chan struct{} {
+	return n.done
+}
+
+// Close ends all subscription streams. It is idempotent.
+func (n *Notifier) Close() {
+	n.once.Do(func() { close(n.done) })
+}
+
+// ResourceUpdated notifies every stream subscribed to the resource key.
+func (n *Notifier) ResourceUpdated(key string) {
+	n.broadcast(func(st *stream) (Notification, bool) {
+		if st.key != key {
+			return Notification{}, false
+		}
+		return Notification{
+			JSONRPC: "2.0",
+			Method:  methodResourcesUpdated,
+			Params:  ResourceUpdatedParams{URI: st.uri},
+		}, true
+	})
+}
+
+// ToolsListChanged tells every open stream to refetch tools/list.
+func (n *Notifier) ToolsListChanged() {
+	n.broadcast(func(*stream) (Notification, bool) {
+		return Notification{JSONRPC: "2.0", Method: methodToolsListChanged}, true
+	})
+}
+
+func (n *Notifier) broadcast(build func(*stream) (Notification, bool)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for st := range n.streams {
+		note, ok := build(st)
+		if !ok {
+			continue
+		}
+		select {
+		case st.ch <- note:
+		default:
+		}
+	}
+}
+
+// Registrations subscribes the Notifier to the events that change
+// subscribable resources or the tool list. Delivery only enqueues, so the
+// subscriptions are synchronous.
+func (n *Notifier) Registrations() []eventbus.Registration {
+	posts := func() { n.ResourceUpdated(uriRecentPosts) }
+	comments := func() { n.ResourceUpdated(uriRecentComments) }
+	return []eventbus.Registration{
+		onEvent[event.EchoCreated](posts),
+		onEvent[event.EchoUpdated](posts),
+		onEvent[event.EchoDeleted](posts),
+		onEvent[event.CommentCreated](comments),
+		onEvent[event.CommentStatusUpdated](comments),
+		onEvent[event.CommentDeleted](comments),
+		onEvent[event.FeatureToggled](n.ToolsListChanged),
+	}
+}
+
+func onEvent[T any](fn func()) eventbus.Registration {
+	return eventbus.On(func(context.Context, T) error {
+		fn()
+		return nil
+	})
+}
@@ -0,0 +1,236 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/event"
+	eventbus "github.com/lin-snow/ech0/internal/event/bus"
+	"github.com/lin-snow/ech0/pkg/busen"
+	"github.com/lin-snow/ech0/pkg/viewer"
+)
+
+// setupSubscribeServer serves a Server with the subscribable recent-posts
+// resource over a real listener, since the SSE stream is read while it is
+// still being written.
+func setupSubscribeServer(t *testing.T) (*httptest.Server, *Notifier) {
+	t.Helper()
+	reg := NewRegistry()
+	reg.RegisterResource(ResourceDefinition{URI: uriRecentPosts, Name: "recent_posts"},
+		func(_ context.Context, uri string) (*ResourceReadResult, error) {
+			return &ResourceReadResult{Contents: []ResourceContent{{URI: uri, Text: "[]"}}}, nil
+		}, "echo:read")
+	reg.RegisterResource(ResourceDefinition{URI: uriRecentComments, Name: "recent_comments"},
+		func(_ context.Context, uri string) (*ResourceReadResult, error) {
+			return &ResourceReadResult{Contents: []ResourceContent{{URI: uri, Text: "[]"}}}, nil
+		}, "admin:settings")
+	notifier := NewNotifier()
+	srv := NewServer(reg, notifier)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv.ServeHTTP(w, r.WithContext(viewer.WithContext(r.Context(), testViewer())))
+	}))
+	t.Cleanup(func() {
+		notifier.Close()
+		ts.Close()
+	})
+	return ts, notifier
+}
+
+// postSubscribe issues resources/subscribe and returns the raw response.
+func postSubscribe(t *testing.T, ts *httptest.Server, uri string) *http.Response {
+	t.Helper()
+	params, _ := json.Marshal(withMeta(map[string]any{"uri": uri}, ProtocolVersion))
+	body, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage(`7`), Method: "resources/subscribe", Params: params})
+	req, _ := http.NewRequest(http.MethodPost, ts.URL, strings.NewReader(string(body)))
+	req.Header.Set("MCP-Protocol-Version", ProtocolVersion)
+	req.Header.Set("Mcp-Method", "resources/subscribe")
+	req.Header.Set("Mcp-Name", uri)
+	resp, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+// subscribe opens a resources/subscribe stream and returns the response plus
+// a reader yielding decoded `data:` payloads (nil once the stream ends).
+func subscribe(t *testing.T, ts *httptest.Server, uri string) (*http.Response, func() map[string]any) {
+	t.Helper()
+	resp := postSubscribe(t, ts, uri)
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+				lines <- data
+			}
+		}
+	}()
+	next := func() map[string]any {
+		t.Helper()
+		select {
+		case data, ok := <-lines:
+			if !ok {
+				return nil
+			}
+			var msg map[string]any
+			if err := json.Unmarshal([]byte(data), &msg); err != nil {
+				t.Fatalf("decode event %q: %v", data, err)
+			}
+			return msg
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for stream event")
+			return nil
+		}
+	}
+	return resp, next
+}
+
+func TestSubscribeStreamsResourceUpdates(t *testing.T) {
+	ts, notifier := setupSubscribeServer(t)
+	resp, next := subscribe(t, ts, uriRecentPosts+"?limit=5")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q, want text/event-stream", ct)
+	}
+
+	// Headers are flushed after the stream registers, so broadcasts from here on are seen.
+	notifier.ResourceUpdated(uriRecentComments) // not subscribed: must not arrive
+	notifier.ResourceUpdated(uriRecentPosts)
+	msg := next()
+	if msg["method"] != methodResourcesUpdated {
+		t.Fatalf("method = %v, want %s", msg["method"], methodResourcesUpdated)
+	}
+	params, _ := msg["params"].(map[string]any)
+	if params["uri"] != uriRecentPosts+"?limit=5" {
+		t.Errorf("uri = %v, want the subscribed URI", params["uri"])
+	}
+
+	notifier.ToolsListChanged()
+	if msg := next(); msg["method"] != methodToolsListChanged {
+		t.Errorf("method = %v, want %s", msg["method"], methodToolsListChanged)
+	}
+
+	// Shutdown ends the stream with the final result for the subscribe request.
+	notifier.Close()
+	final := next()
+	if final["id"] != float64(7) || final["result"] == nil {
+		t.Errorf("final message = %v, want result for id 7", final)
+	}
+	if msg := next(); msg != nil {
+		t.Errorf("stream should end after the final result, got %v", msg)
+	}
+}
+
+func TestSubscribeRejectsUnsupportedResource(t *testing.T) {
+	srv := setupTestServer()
+	_, resp := doModern(t, srv, "resources/subscribe", map[string]any{"uri": "ech0://test"})
+	if resp.Error == nil || resp.Error.Code != ErrCodeInvalidParams {
+		t.Fatalf("expected invalid params, got %v", resp.Error)
+	}
+}
+
+func TestSubscribeRequiresResourceScopes(t *testing.T) {
+	ts, _ := setupSubscribeServer(t)
+	// recent comments needs admin:settings, which the test viewer lacks.
+	resp := postSubscribe(t, ts, uriRecentComments)
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("Content-Type = %q, want a plain JSON error", ct)
+	}
+	var out Response
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if out.Error == nil || !strings.Contains(out.Error.Message, "permission denied") {
+		t.Errorf("expected permission error, got %+v", out.Error)
+	}
+}
+
+func TestSubscribeUnavailableWithoutNotifier(t *testing.T) {
+	reg := NewRegistry()
+	srv := NewServer(reg, nil)
+	_, resp := doModern(t, srv, "server/discover", nil)
+	result := unmarshalResult[DiscoverResult](t, resp)
+	if result.Capabilities.Resources.Subscribe || result.Capabilities.Tools.ListChanged {
+		t.Errorf("capabilities = %+v, want subscribe/listChanged off", result.Capabilities)
+	}
+	_, resp = doModern(t, srv, "resources/subscribe", map[string]any{"uri": uriRecentPosts})
+	if resp.Error == nil || resp.Error.Code != ErrCodeMethodNotFound {
+		t.Errorf("expected method not found, got %v", resp.Error)
+	}
+}
+
+func TestNotifierRegistrationsFollowEvents(t *testing.T) {
+	notifier := NewNotifier()
+	bus := busen.New()
+	for _, reg := range notifier.Registrations() {
+		unsub, err := reg(bus)
+		if err != nil {
+			t.Fatalf("register: %v", err)
+		}
+		defer unsub()
+	}
+	posts, closePosts := notifier.open(uriRecentPosts, uriRecentPosts)
+	defer closePosts()
+	comments, closeComments := notifier.open(uriRecentComments, uriRecentComments)
+	defer closeComments()
+
+	ctx := context.Background()
+	_ = eventbus.Emit(ctx, bus, event.EchoCreated{})
+	_ = eventbus.Emit(ctx, bus, event.CommentCreated{})
+	_ = eventbus.Emit(ctx, bus, event.FeatureToggled{Feature: event.FeatureEmbedding, Enabled: true})
+
+	want := map[*stream][]string{
+		posts:    {methodResourcesUpdated, methodToolsListChanged},
+		comments: {methodResourcesUpdated, methodToolsListChanged},
+	}
+	for st, methods := range want {
+		for _, method := range methods {
+			select {
+			case note := <-st.ch:
+				if note.Method != method {
+					t.Errorf("%s stream: method = %q, want %q", st.key, note.Method, method)
+				}
+			default:
+				t.Errorf("%s stream: missing %q", st.key, method)
+			}
+		}
+	}
+}
+
+func TestConditionalToolFollowsAvailability(t *testing.T) {
+	enabled := false
+	reg := NewRegistry()
+	reg.RegisterConditionalTool(ToolDefinition{Name: "gated", InputSchema: map[string]any{"type": "object"}},
+		func(context.Context, map[string]any) (*ToolCallResult, error) { return textResult("ok"), nil },
+		func(context.Context) bool { return enabled }, "echo:read")
+	srv := NewServer(reg, NewNotifier())
+
+	_, resp := doModern(t, srv, "tools/list", nil)
+	if got := unmarshalResult[ToolsListResult](t, resp); len(got.Tools) != 0 {
+		t.Fatalf("disabled tool listed: %v", got.Tools)
+	}
+	_, resp = doModern(t, srv, "tools/call", map[string]any{"name": "gated", "arguments": map[string]any{}})
+	if resp.Error == nil || resp.Error.Code != ErrCodeInvalidParams {
+		t.Fatalf("disabled tool should be unknown, got %v", resp.Error)
+	}
+
+	enabled = true
+	_, resp = doModern(t, srv, "tools/list", nil)
+	if got := unmarshalResult[ToolsListResult](t, resp); len(got.Tools) != 1 {
+		t.Fatalf("enabled tool missing: %v", got.Tools)
+	}
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package mcp
+
+import "errors"
+
+type PromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+type PromptDefinition struct {
+	Name        string           `json:"name"`
+	Title       string           `json:"title,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Arguments   []PromptArgument `json:"arguments,omitempty"`
+}
+
+type PromptsListResult struct {
+	ResultEnvelope
+	CacheInfo
+	Prompts []PromptDefinition `json:"prompts"`
+}
+
+type PromptGetParams struct {
+	Name      string            `json:"name"`
+	Arguments map[string]string `json:"arguments"`
+}
+
+type PromptMessage struct {
+	Role    string      `json:"role"`
+	Content ContentItem `json:"content"`
+}
+
+type PromptGetResult struct {
+	ResultEnvelope
+	CacheInfo
+	Description string          `json:"description,omitempty"`
+	Messages    []PromptMessage `json:"messages"`
+}
+
+// errInvalidPromptArgument marks a prompt handler error caused by the
+// caller's arguments; prompts/get reports it as invalid params rather than
+// an internal error.
+var errInvalidPromptArgument = errors.New("invalid prompt argument")
+
+func userPrompt(description, text string) *PromptGetResult {
+	return &PromptGetResult{
+		Description: description,
+		Messages:    []PromptMessage{{Role: "user", Content: ContentItem{Type: "text", Text: text}}},
+	}
+}
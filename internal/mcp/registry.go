@@ -10,10 +10,15 @@ import (
 
 type ToolHandler func(ctx context.Context, args map[string]any) (*ToolCallResult, error)
 
+// ToolAvailability reports whether a tool is currently usable, e.g. because
+// the feature behind it is switched on in settings.
+type ToolAvailability func(ctx context.Context) bool
+
 type registeredTool struct {
 	definition ToolDefinition
 	handler    ToolHandler
 	scopes     []string
+	available  ToolAvailability
 }
 
 type ResourceHandler func(ctx context.Context, uri string) (*ResourceReadResult, error)
@@ -25,15 +30,26 @@ type registeredResource struct {
 	uriPrefix  string
 }
 
+type PromptHandler func(ctx context.Context, args map[string]string) (*PromptGetResult, error)
+
+type registeredPrompt struct {
+	definition PromptDefinition
+	handler    PromptHandler
+	scopes     []string
+}
+
 type Registry struct {
-	tools     []registeredTool
-	resources []registeredResource
-	toolIndex map[string]int
+	tools       []registeredTool
+	resources   []registeredResource
+	prompts     []registeredPrompt
+	toolIndex   map[string]int
+	promptIndex map[string]int
 }
 
 func NewRegistry() *Registry {
 	return &Registry{
-		toolIndex: make(map[string]int),
+		toolIndex:   make(map[string]int),
+		promptIndex: make(map[string]int),
 	}
 }
 
@@ -46,6 +62,14 @@ func (r *Registry) RegisterTool(def ToolDefinition, handler ToolHandler, scopes
 	})
 }
 
+// RegisterConditionalTool registers a tool that is only listed and callable
+// while available returns true. Whoever flips the underlying switch must
+// make the Notifier emit notifications/tools/list_changed.
+func (r *Registry) RegisterConditionalTool(def ToolDefinition, handler ToolHandler, available ToolAvailability, scopes ...string) {
+	r.RegisterTool(def, handler, scopes...)
+	r.tools[len(r.tools)-1].available = available
+}
+
 func (r *Registry) RegisterResource(def ResourceDefinition, handler ResourceHandler, scopes ...string) {
 	var prefix string
 	if idx := strings.Index(def.URI, "{"); idx > 0 {
@@ -59,10 +83,22 @@ func (r *Registry) RegisterResource(def ResourceDefinition, handler ResourceHand
 	})
 }
 
-func (r *Registry) ToolDefinitions() []ToolDefinition {
-	defs := make([]ToolDefinition, len(r.tools))
-	for i, t := range r.tools {
-		defs[i] = t.definition
+func (r *Registry) RegisterPrompt(def PromptDefinition, handler PromptHandler, scopes ...string) {
+	r.promptIndex[def.Name] = len(r.prompts)
+	r.prompts = append(r.prompts, registeredPrompt{
+		definition: def,
+		handler:    handler,
+		scopes:     scopes,
+	})
+}
+
+func (r *Registry) ToolDefinitions(ctx context.Context) []ToolDefinition {
+	defs := make([]ToolDefinition, 0, len(r.tools))
+	for _, t := range r.tools {
+		if t.available != nil && !t.available(ctx) {
+			continue
+		}
+		defs = append(defs, t.definition)
 	}
 	return defs
 }
@@ -75,12 +111,24 @@ func (r *Registry) ResourceDefinitions() []ResourceDefinition {
 	return defs
 }
 
-func (r *Registry) LookupTool(name string) (ToolHandler, []string, bool) {
+func (r *Registry) PromptDefinitions() []PromptDefinition {
+	defs := make([]PromptDefinition, len(r.prompts))
+	for i, p := range r.prompts {
+		defs[i] = p.definition
+	}
+	return defs
+}
+
+// LookupTool treats a currently unavailable tool as unknown.
+func (r *Registry) LookupTool(ctx context.Context, name string) (ToolHandler, []string, bool) {
 	idx, ok := r.toolIndex[name]
 	if !ok {
 		return nil, nil, false
 	}
 	t := r.tools[idx]
+	if t.available != nil && !t.available(ctx) {
+		return nil, nil, false
+	}
 	return t.handler, t.scopes, true
 }
 
@@ -97,3 +145,12 @@ func (r *Registry) LookupResource(uri string) (ResourceHandler, []string, bool)
 	}
 	return nil, nil, false
 }
+
+func (r *Registry) LookupPrompt(name string) (PromptDefinition, PromptHandler, []string, bool) {
+	idx, ok := r.promptIndex[name]
+	if !ok {
+		return PromptDefinition{}, nil, nil, false
+	}
+	p := r.prompts[idx]
+	return p.definition, p.handler, p.scopes, true
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -21,6 +22,10 @@ import (
 
 const toolTimeout = 10 * time.Second
 
+// keepAliveInterval paces SSE comment lines on idle subscription streams so
+// proxies do not reap the connection.
+const keepAliveInterval = 25 * time.Second
+
 type ctxKey int
 
 const (
@@ -40,10 +45,13 @@ func BaseURLFromContext(ctx context.Context) string {
 
 type Server struct {
 	registry *Registry
+	notifier *Notifier
 }
 
-func NewServer(registry *Registry) *Server {
-	return &Server{registry: registry}
+// NewServer builds a Server. notifier may be nil, in which case
+// resources/subscribe is not offered.
+func NewServer(registry *Registry, notifier *Notifier) *Server {
+	return &Server{registry: registry, notifier: notifier}
 }
 
 func serverInfo() ServerInfo {
@@ -119,6 +127,10 @@ func (s *Server) handlePost(w http.ResponseWriter, r *http.Request) {
 		writeRPCError(w, req.ID, rpcErr)
 		return
 	}
+	if sub, ok := result.(*subscription); ok {
+		s.serveSubscription(w, r, req.ID, sub)
+		return
+	}
 	if c, ok := result.(completer); ok {
 		c.complete(serverInfo())
 	}
@@ -162,23 +174,32 @@ func (s *Server) dispatch(r *http.Request, req *Request, v viewer.Context) (any,
 	case "server/discover":
 		return s.handleDiscover(), nil
 	case "tools/list":
-		return s.handleToolsList()
+		return s.handleToolsList(r.Context())
 	case "tools/call":
 		return s.handleToolsCall(r, req, v)
 	case "resources/list":
 		return s.handleResourcesList()
 	case "resources/read":
 		return s.handleResourcesRead(r, req, v)
-	default:
-		return nil, &RPCError{Code: ErrCodeMethodNotFound, Message: fmt.Sprintf("method %q not found", req.Method)}
+	case "resources/subscribe":
+		if s.notifier == nil {
+			break
+		}
+		return s.handleResourcesSubscribe(req, v)
+	case "prompts/list":
+		return s.handlePromptsList()
+	case "prompts/get":
+		return s.handlePromptsGet(r, req, v)
 	}
+	return nil, &RPCError{Code: ErrCodeMethodNotFound, Message: fmt.Sprintf("method %q not found", req.Method)}
 }
 
 // validateTransport enforces the Streamable HTTP request metadata rules:
 // the MCP-Protocol-Version header must be present, match the version in
 // params._meta, and name a supported revision; Mcp-Method must match the
-// body method; tools/call and resources/read must carry a matching
-// Mcp-Name (Base64 sentinel decoded).
+// body method; tools/call, prompts/get, resources/read and
+// resources/subscribe must carry a matching Mcp-Name (Base64 sentinel
+// decoded).
 func validateTransport(r *http.Request, method string, params *requestParams) *RPCError {
 	headerVersion := r.Header.Get("Mcp-Protocol-Version")
 	if headerVersion == "" {
@@ -207,12 +228,14 @@ func validateTransport(r *http.Request, method string, params *requestParams) *R
 		return headerMismatch(fmt.Sprintf("Mcp-Method header %q does not match body method %q", headerMethod, method))
 	}
 
-	if method != "tools/call" && method != "resources/read" {
-		return nil
-	}
-	bodyName := params.Name
-	if method == "resources/read" {
+	var bodyName string
+	switch method {
+	case "tools/call", "prompts/get":
+		bodyName = params.Name
+	case "resources/read", "resources/subscribe":
 		bodyName = params.URI
+	default:
+		return nil
 	}
 	headerName, err := decodeSentinel(r.Header.Get("Mcp-Name"))
 	if err != nil {
@@ -255,18 +278,20 @@ func (s *Server) handleDiscover() *DiscoverResult {
 	return &DiscoverResult{
 		SupportedVersions: SupportedVersions,
 		Capabilities: ServerCapabilities{
-			Tools:     &ToolsCapability{ListChanged: false},
-			Resources: &ResourcesCapability{Subscribe: false, ListChanged: false},
+			Tools:     &ToolsCapability{ListChanged: s.notifier != nil},
+			Resources: &ResourcesCapability{Subscribe: s.notifier != nil, ListChanged: false},
+			Prompts:   &PromptsCapability{ListChanged: false},
 		},
-		Instructions: "Ech0 personal microblog. Manage posts, tags, comments, files, connects and webhooks via tools; read site data via ech0:// resources.",
-		CacheInfo:    CacheInfo{TTLMs: discoverTTLMs, CacheScope: cacheScopePublic},
+		Instructions: "Ech0 personal microblog. Manage posts, tags, comments, files, connects and webhooks via tools; read site data via ech0:// resources; " +
+			"use prompts for reviews, drafting in the owner's voice and comment triage.",
+		CacheInfo: CacheInfo{TTLMs: discoverTTLMs, CacheScope: cacheScopePublic},
 	}
 }
 
-func (s *Server) handleToolsList() (*ToolsListResult, *RPCError) {
+func (s *Server) handleToolsList(ctx context.Context) (*ToolsListResult, *RPCError) {
 	return &ToolsListResult{
 		CacheInfo: CacheInfo{TTLMs: listTTLMs, CacheScope: cacheScopePublic},
-		Tools:     s.registry.ToolDefinitions(),
+		Tools:     s.registry.ToolDefinitions(ctx),
 	}, nil
 }
 
@@ -276,7 +301,7 @@ func (s *Server) handleToolsCall(r *http.Request, req *Request, v viewer.Context
 		return nil, &RPCError{Code: ErrCodeInvalidParams, Message: "invalid tool call params"}
 	}
 
-	handler, requiredScopes, ok := s.registry.LookupTool(params.Name)
+	handler, requiredScopes, ok := s.registry.LookupTool(r.Context(), params.Name)
 	if !ok {
 		return nil, &RPCError{Code: ErrCodeInvalidParams, Message: fmt.Sprintf("tool %q not found", params.Name)}
 	}
@@ -339,6 +364,129 @@ func (s *Server) handleResourcesRead(r *http.Request, req *Request, v viewer.Con
 	return result, nil
 }
 
+func (s *Server) handlePromptsList() (*PromptsListResult, *RPCError) {
+	return &PromptsListResult{
+		CacheInfo: CacheInfo{TTLMs: listTTLMs, CacheScope: cacheScopePublic},
+		Prompts:   s.registry.PromptDefinitions(),
+	}, nil
+}
+
+func (s *Server) handlePromptsGet(r *http.Request, req *Request, v viewer.Context) (*PromptGetResult, *RPCError) {
+	var params PromptGetParams
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return nil, &RPCError{Code: ErrCodeInvalidParams, Message: "invalid prompt get params"}
+	}
+
+	def, handler, requiredScopes, ok := s.registry.LookupPrompt(params.Name)
+	if !ok {
+		return nil, &RPCError{Code: ErrCodeInvalidParams, Message: fmt.Sprintf("prompt %q not found", params.Name)}
+	}
+	for _, arg := range def.Arguments {
+		if arg.Required && strings.TrimSpace(params.Arguments[arg.Name]) == "" {
+			return nil, &RPCError{Code: ErrCodeInvalidParams, Message: fmt.Sprintf("missing required argument %q", arg.Name)}
+		}
+	}
+
+	if !checkScopes(v.Scopes(), requiredScopes) {
+		return nil, &RPCError{Code: ErrCodeInternal, Message: "permission denied: insufficient scopes"}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), toolTimeout)
+	defer cancel()
+
+	args := params.Arguments
+	if args == nil {
+		args = map[string]string{}
+	}
+	result, err := handler(ctx, args)
+	if err != nil {
+		if errors.Is(err, errInvalidPromptArgument) {
+			return nil, &RPCError{Code: ErrCodeInvalidParams, Message: err.Error()}
+		}
+		return nil, &RPCError{Code: ErrCodeInternal, Message: err.Error()}
+	}
+	// Prompts embed live, authorization-scoped instance data, like reads.
+	result.CacheInfo = CacheInfo{TTLMs: 0, CacheScope: cacheScopePrivate}
+	return result, nil
+}
+
+// subscription is the validated outcome of resources/subscribe; handlePost
+// turns it into a streamed response instead of a single JSON body.
+type subscription struct {
+	key string
+	uri string
+}
+
+func (s *Server) handleResourcesSubscribe(req *Request, v viewer.Context) (*subscription, *RPCError) {
+	var params SubscribeParams
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return nil, &RPCError{Code: ErrCodeInvalidParams, Message: "invalid resource subscribe params"}
+	}
+	key, ok := subscriptionKey(params.URI)
+	if !ok {
+		return nil, &RPCError{Code: ErrCodeInvalidParams, Message: fmt.Sprintf("resource %q does not support subscriptions", params.URI)}
+	}
+	_, requiredScopes, ok := s.registry.LookupResource(key)
+	if !ok {
+		return nil, &RPCError{Code: ErrCodeInvalidParams, Message: fmt.Sprintf("resource %q not found", params.URI)}
+	}
+	if !checkScopes(v.Scopes(), requiredScopes) {
+		return nil, &RPCError{Code: ErrCodeInternal, Message: "permission denied: insufficient scopes"}
+	}
+	return &subscription{key: key, uri: params.URI}, nil
+}
+
+// serveSubscription answers resources/subscribe with an SSE stream. The
+// stream carries notifications/resources/updated for the subscribed URI and
+// notifications/tools/list_changed; it stays open until the client
+// disconnects. On server shutdown the final JSON-RPC result is written and
+// the stream ends, telling the client to re-subscribe later.
+func (s *Server) serveSubscription(w http.ResponseWriter, r *http.Request, id json.RawMessage, sub *subscription) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeRPCError(w, id, &RPCError{Code: ErrCodeInternal, Message: "streaming is not supported by this connection"})
+		return
+	}
+	st, closeStream := s.notifier.open(sub.key, sub.uri)
+	defer closeStream()
+
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.notifier.Done():
+			result := &SubscribeResult{}
+			result.complete(serverInfo())
+			writeSSE(w, Response{JSONRPC: "2.0", ID: id, Result: result})
+			flusher.Flush()
+			return
+		case note := <-st.ch:
+			writeSSE(w, note)
+			flusher.Flush()
+		case <-ticker.C:
+			_, _ = io.WriteString(w, ": keep-alive\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+func writeSSE(w io.Writer, msg any) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+}
+
 func checkScopes(actual, required []string) bool {
 	if len(required) == 0 {
 		return true
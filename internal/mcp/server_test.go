@@ -8,6 +8,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -65,7 +66,7 @@ func setupTestServer() *Server {
 		}, nil
 	}, "echo:read")
 
-	return NewServer(reg)
+	return NewServer(reg, NewNotifier())
 }
 
 // withMeta injects the 2026-07-28 per-request metadata into params.
@@ -459,10 +460,10 @@ func TestGetMethodNotAllowed(t *testing.T) {
 // security-sensitive decision this test guards (REST gates it behind admin too).
 func TestAdapterRegistersDiscoveryCapabilities(t *testing.T) {
 	reg := NewRegistry()
-	NewAdapter(nil, nil, nil, nil, nil, nil, nil, nil, nil).RegisterAll(reg)
+	NewAdapter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).RegisterAll(reg)
 
 	for _, name := range []string{"get_hot_posts", "get_random_post", "get_on_this_day_posts"} {
-		_, scopes, ok := reg.LookupTool(name)
+		_, scopes, ok := reg.LookupTool(context.Background(), name)
 		if !ok {
 			t.Errorf("tool %q not registered", name)
 			continue
@@ -491,3 +492,70 @@ func TestInvalidJSON(t *testing.T) {
 		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
 	}
 }
+
+func setupPromptServer() *Server {
+	srv := setupTestServer()
+	srv.registry.RegisterPrompt(PromptDefinition{
+		Name:      "greet",
+		Arguments: []PromptArgument{{Name: "who", Required: true}},
+	}, func(_ context.Context, args map[string]string) (*PromptGetResult, error) {
+		if args["who"] == "nobody" {
+			return nil, fmt.Errorf("%w: who must be somebody", errInvalidPromptArgument)
+		}
+		return userPrompt("greeting", "Say hello to "+args["who"]), nil
+	}, "echo:read")
+	srv.registry.RegisterPrompt(PromptDefinition{Name: "admin_prompt"},
+		func(_ context.Context, _ map[string]string) (*PromptGetResult, error) {
+			return userPrompt("", "secret"), nil
+		}, "comment:moderate")
+	return srv
+}
+
+func TestPromptsList(t *testing.T) {
+	_, resp := doModern(t, setupPromptServer(), "prompts/list", nil)
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %v", resp.Error)
+	}
+	result := unmarshalResult[PromptsListResult](t, resp)
+	if len(result.Prompts) != 2 || result.Prompts[0].Name != "greet" {
+		t.Errorf("prompts = %v, want greet and admin_prompt", result.Prompts)
+	}
+	if result.TTLMs <= 0 || result.CacheScope != cacheScopePublic {
+		t.Errorf("cache hints = (%d, %q), want positive ttl and %q", result.TTLMs, result.CacheScope, cacheScopePublic)
+	}
+}
+
+func TestPromptsGetSuccess(t *testing.T) {
+	_, resp := doModern(t, setupPromptServer(), "prompts/get", map[string]any{"name": "greet", "arguments": map[string]any{"who": "Ech0"}})
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %v", resp.Error)
+	}
+	result := unmarshalResult[PromptGetResult](t, resp)
+	if len(result.Messages) != 1 || result.Messages[0].Role != "user" || result.Messages[0].Content.Text != "Say hello to Ech0" {
+		t.Errorf("unexpected messages: %+v", result.Messages)
+	}
+	if result.ResultType != resultTypeComplete || result.CacheScope != cacheScopePrivate {
+		t.Errorf("result envelope = (%q, %q), want (%q, %q)", result.ResultType, result.CacheScope, resultTypeComplete, cacheScopePrivate)
+	}
+}
+
+func TestPromptsGetErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		params map[string]any
+		code   int
+	}{
+		{"missing required argument", map[string]any{"name": "greet"}, ErrCodeInvalidParams},
+		{"handler rejects argument", map[string]any{"name": "greet", "arguments": map[string]any{"who": "nobody"}}, ErrCodeInvalidParams},
+		{"unknown prompt", map[string]any{"name": "nonexistent"}, ErrCodeInvalidParams},
+		{"insufficient scopes", map[string]any{"name": "admin_prompt"}, ErrCodeInternal},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, resp := doModern(t, setupPromptServer(), "prompts/get", tc.params)
+			if resp.Error == nil || resp.Error.Code != tc.code {
+				t.Fatalf("expected error code %d, got %v", tc.code, resp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,317 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// maxStdioMessage bounds one line read from the host. It is generous on
+// purpose: oversized requests should reach the server and be rejected there
+// with a proper JSON-RPC error rather than kill the scanner.
+const maxStdioMessage = 1024 * 1024
+
+// StdioBridge carries MCP over stdio for hosts that launch a local process.
+// Every newline-delimited JSON-RPC message read from the host becomes one
+// Streamable HTTP POST to Endpoint, and every message of the reply — a JSON
+// body, or each event of a resources/subscribe stream — is written back as
+// one line. The transport headers the HTTP binding requires are derived from
+// the message itself, so the server applies exactly the same validation,
+// scope checks and audit logging as for remote clients.
+//
+// Messages are forwarded concurrently: an open subscription must not block
+// later calls. Replies may therefore arrive out of order, which JSON-RPC
+// allows since they carry the request id.
+type StdioBridge struct {
+	// Endpoint is the URL of the /mcp endpoint.
+	Endpoint string
+	// Token is sent as a Bearer token when non-empty.
+	Token string
+	// Client performs the requests. Use NewHandlerTransport to serve them
+	// in-process instead of over the network.
+	Client *http.Client
+}
+
+// Serve relays messages until in reaches EOF or ctx is cancelled. On EOF it
+// ends open subscriptions and waits for in-flight requests to answer, so
+// piping a single request into the process still yields its response.
+func (b *StdioBridge) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	subCtx, endSubscriptions := context.WithCancel(ctx)
+	defer endSubscriptions()
+
+	w := &lineWriter{w: out}
+	var wg sync.WaitGroup
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStdioMessage)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		msg := append([]byte(nil), line...)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b.forward(ctx, subCtx, msg, w)
+		}()
+	}
+
+	endSubscriptions()
+	wg.Wait()
+	return scanner.Err()
+}
+
+// stdioEnvelope is the part of a host message the bridge needs to build the
+// transport headers.
+type stdioEnvelope struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+func (b *StdioBridge) forward(ctx, subCtx context.Context, msg []byte, w *lineWriter) {
+	var env stdioEnvelope
+	if err := json.Unmarshal(msg, &env); err != nil {
+		w.writeJSON(Response{JSONRPC: "2.0", Error: &RPCError{Code: ErrCodeParse, Message: "invalid JSON"}})
+		return
+	}
+	if env.Method == "resources/subscribe" {
+		ctx = subCtx
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.Endpoint, bytes.NewReader(msg))
+	if err != nil {
+		b.fail(w, env.ID, err.Error())
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	setTransportHeaders(req.Header, &env)
+	if b.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+b.Token)
+	}
+
+	resp, err := b.Client.Do(req)
+	if err != nil {
+		if ctx.Err() == nil {
+			b.fail(w, env.ID, "MCP endpoint unreachable: "+err.Error())
+		}
+		return
+	}
+	defer resp.Body.Close()
+
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType == "text/event-stream" {
+		relaySSE(resp.Body, w)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxStdioMessage))
+	if err != nil {
+		b.fail(w, env.ID, "failed to read MCP response: "+err.Error())
+		return
+	}
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		// 202 Accepted for a notification: nothing to relay.
+		return
+	}
+	var reply struct {
+		JSONRPC string `json:"jsonrpc"`
+	}
+	if json.Unmarshal(body, &reply) == nil && reply.JSONRPC == "2.0" {
+		w.writeRaw(body)
+		return
+	}
+	// Rejected before reaching the MCP server (auth, audience, rate limit):
+	// the body is an Ech0 API error, not JSON-RPC.
+	b.fail(w, env.ID, fmt.Sprintf("MCP endpoint returned HTTP %d: %s", resp.StatusCode, httpErrorMessage(resp.StatusCode, body)))
+}
+
+// fail answers a request with a JSON-RPC internal error. Notifications get
+// no reply, as JSON-RPC requires.
+func (b *StdioBridge) fail(w *lineWriter, id json.RawMessage, msg string) {
+	if len(id) == 0 {
+		return
+	}
+	w.writeJSON(Response{JSONRPC: "2.0", ID: id, Error: &RPCError{Code: ErrCodeInternal, Message: msg}})
+}
+
+// setTransportHeaders mirrors the request metadata into the headers the
+// Streamable HTTP binding requires (see validateTransport).
+func setTransportHeaders(h http.Header, env *stdioEnvelope) {
+	h.Set("Mcp-Method", env.Method)
+
+	var params requestParams
+	if len(env.Params) > 0 && json.Unmarshal(env.Params, &params) != nil {
+		// Not an object: let the server report it.
+		return
+	}
+	if version, _ := params.Meta[metaKeyProtocolVersion].(string); version != "" {
+		h.Set("Mcp-Protocol-Version", version)
+	}
+	var name string
+	switch env.Method {
+	case "tools/call", "prompts/get":
+		name = params.Name
+	case "resources/read", "resources/subscribe":
+		name = params.URI
+	}
+	if name != "" {
+		h.Set("Mcp-Name", encodeSentinel(name))
+	}
+}
+
+// encodeSentinel is the inverse of decodeSentinel: values that are not
+// header-safe printable ASCII are wrapped in the Base64 sentinel.
+func encodeSentinel(v string) string {
+	safe := v == strings.TrimSpace(v)
+	for i := 0; safe && i < len(v); i++ {
+		safe = v[i] >= 0x20 && v[i] < 0x7f
+	}
+	if safe {
+		return v
+	}
+	return b64SentinelPrefix + base64.StdEncoding.EncodeToString([]byte(v)) + b64SentinelSuffix
+}
+
+// httpErrorMessage extracts the message of an Ech0 API error body, falling
+// back to the raw text or the status text.
+func httpErrorMessage(status int, body []byte) string {
+	var apiErr struct {
+		Msg string `json:"msg"`
+	}
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Msg != "" {
+		return apiErr.Msg
+	}
+	if text := strings.TrimSpace(string(body)); text != "" && !bytes.HasPrefix(body, []byte("{")) {
+		return text
+	}
+	return http.StatusText(status)
+}
+
+// relaySSE writes the data of every event in an SSE stream as one line.
+// Comment lines (keep-alives) are dropped.
+func relaySSE(r io.Reader, w *lineWriter) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStdioMessage)
+	var data []byte
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		switch {
+		case len(line) == 0:
+			if len(data) > 0 {
+				w.writeRaw(data)
+				data = nil
+			}
+		case bytes.HasPrefix(line, []byte("data:")):
+			if data != nil {
+				data = append(data, '\n')
+			}
+			data = append(data, bytes.TrimPrefix(bytes.TrimPrefix(line, []byte("data:")), []byte(" "))...)
+		}
+	}
+	if len(data) > 0 {
+		w.writeRaw(data)
+	}
+}
+
+// lineWriter serialises messages onto the host's stdout, one per line.
+type lineWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (lw *lineWriter) writeJSON(v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	lw.writeRaw(data)
+}
+
+// writeRaw compacts msg so a pretty-printed body cannot span lines.
+func (lw *lineWriter) writeRaw(msg []byte) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, msg); err != nil {
+		return
+	}
+	buf.WriteByte('\n')
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	_, _ = lw.w.Write(buf.Bytes())
+}
+
+// NewHandlerTransport returns a RoundTripper that serves requests in-process
+// with h. The response body is a pipe fed while h runs, so streamed replies
+// reach the caller as they are written.
+func NewHandlerTransport(h http.Handler) http.RoundTripper {
+	return handlerTransport{handler: h}
+}
+
+type handlerTransport struct {
+	handler http.Handler
+}
+
+func (t handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	pr, pw := io.Pipe()
+	rw := &pipeResponseWriter{header: make(http.Header), body: pw, ready: make(chan struct{})}
+	go func() {
+		defer pw.Close()
+		// A handler that writes nothing still answers 200, as net/http does.
+		defer rw.WriteHeader(http.StatusOK)
+		t.handler.ServeHTTP(rw, req)
+	}()
+	<-rw.ready
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rw.status, http.StatusText(rw.status)),
+		StatusCode:    rw.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        rw.sent,
+		Body:          pr,
+		ContentLength: -1,
+		Request:       req,
+	}, nil
+}
+
+// pipeResponseWriter is the in-process ResponseWriter behind handlerTransport.
+// WriteHeader snapshots the headers and releases RoundTrip; the body flows
+// through the pipe, which is unbuffered, so Flush has nothing to do.
+type pipeResponseWriter struct {
+	header http.Header
+	sent   http.Header
+	status int
+	body   *io.PipeWriter
+	ready  chan struct{}
+	once   sync.Once
+}
+
+func (w *pipeResponseWriter) Header() http.Header { return w.header }
+
+func (w *pipeResponseWriter) WriteHeader(status int) {
+	w.once.Do(func() {
+		w.status = status
+		w.sent = w.header.Clone()
+		close(w.ready)
+	})
+}
+
+func (w *pipeResponseWriter) Write(p []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.body.Write(p)
+}
+
+func (w *pipeResponseWriter) Flush() {}
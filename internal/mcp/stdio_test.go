@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lin-snow/ech0/pkg/viewer"
+)
+
+type stdioHarness struct {
+	in    *io.PipeWriter
+	lines chan map[string]any
+	done  chan error
+}
+
+// startBridge runs bridge over pipes standing in for the host's stdio.
+func startBridge(t *testing.T, bridge *StdioBridge) *stdioHarness {
+	t.Helper()
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	h := &stdioHarness{in: inW, lines: make(chan map[string]any, 16), done: make(chan error, 1)}
+	go func() {
+		h.done <- bridge.Serve(context.Background(), inR, outW)
+		_ = outW.Close()
+	}()
+	go func() {
+		defer close(h.lines)
+		scanner := bufio.NewScanner(outR)
+		for scanner.Scan() {
+			var msg map[string]any
+			if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+				t.Errorf("bridge wrote a non-JSON line %q", scanner.Text())
+				continue
+			}
+			h.lines <- msg
+		}
+	}()
+	t.Cleanup(func() {
+		_ = inW.Close()
+		<-h.done
+	})
+	return h
+}
+
+func (h *stdioHarness) sendRaw(t *testing.T, line string) {
+	t.Helper()
+	if _, err := io.WriteString(h.in, line+"\n"); err != nil {
+		t.Fatalf("write stdin: %v", err)
+	}
+}
+
+func (h *stdioHarness) send(t *testing.T, id int, method string, params map[string]any) {
+	t.Helper()
+	paramsJSON, _ := json.Marshal(withMeta(params, ProtocolVersion))
+	idJSON, _ := json.Marshal(id)
+	line, _ := json.Marshal(Request{JSONRPC: "2.0", ID: idJSON, Method: method, Params: paramsJSON})
+	h.sendRaw(t, string(line))
+}
+
+func (h *stdioHarness) next(t *testing.T) map[string]any {
+	t.Helper()
+	select {
+	case msg, ok := <-h.lines:
+		if !ok {
+			t.Fatal("stdout closed")
+		}
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a message on stdout")
+		return nil
+	}
+}
+
+// localBridge serves srv in-process, as `ech0 mcp` does in local mode.
+func localBridge(srv *Server) *StdioBridge {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv.ServeHTTP(w, viewer.WithRequest(r, testViewer()))
+	})
+	return &StdioBridge{
+		Endpoint: "http://localhost:6277/mcp",
+		Client:   &http.Client{Transport: NewHandlerTransport(handler)},
+	}
+}
+
+func TestStdioBridge_RelaysRequests(t *testing.T) {
+	h := startBridge(t, localBridge(setupTestServer()))
+
+	// The bridge derives MCP-Protocol-Version / Mcp-Method / Mcp-Name from the
+	// message, so a bare stdio message passes the server's header validation.
+	h.send(t, 1, "tools/call", map[string]any{"name": "echo_tool", "arguments": map[string]any{}})
+	msg := h.next(t)
+	if msg["error"] != nil {
+		t.Fatalf("tools/call failed: %v", msg["error"])
+	}
+	content := msg["result"].(map[string]any)["content"].([]any)
+	if text := content[0].(map[string]any)["text"]; text != "hello" {
+		t.Fatalf("unexpected tool output %v", text)
+	}
+
+	// Non-ASCII names travel in the Base64 sentinel form.
+	h.send(t, 2, "resources/read", map[string]any{"uri": "ech0://items/日记"})
+	msg = h.next(t)
+	if msg["error"] != nil {
+		t.Fatalf("resources/read failed: %v", msg["error"])
+	}
+	if msg["id"] != float64(2) {
+		t.Fatalf("unexpected id %v", msg["id"])
+	}
+}
+
+func TestStdioBridge_StreamsSubscription(t *testing.T) {
+	srv := setupTestServer()
+	srv.registry.RegisterResource(ResourceDefinition{URI: uriRecentPosts, Name: "recent_posts"},
+		func(_ context.Context, uri string) (*ResourceReadResult, error) {
+			return &ResourceReadResult{Contents: []ResourceContent{{URI: uri, Text: "[]"}}}, nil
+		}, "echo:read")
+	h := startBridge(t, localBridge(srv))
+
+	h.send(t, 1, "resources/subscribe", map[string]any{"uri": uriRecentPosts + "?limit=5"})
+	waitForStreams(t, srv.notifier, 1)
+	srv.notifier.ResourceUpdated(uriRecentPosts)
+
+	msg := h.next(t)
+	if msg["method"] != methodResourcesUpdated {
+		t.Fatalf("expected %s, got %v", methodResourcesUpdated, msg)
+	}
+	if uri := msg["params"].(map[string]any)["uri"]; uri != uriRecentPosts+"?limit=5" {
+		t.Fatalf("unexpected uri %v", uri)
+	}
+
+	// Closing stdin ends the open subscription instead of hanging forever.
+	_ = h.in.Close()
+	select {
+	case err := <-h.done:
+		if err != nil {
+			t.Fatalf("Serve returned %v", err)
+		}
+		h.done <- nil
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not return after stdin closed")
+	}
+}
+
+func TestStdioBridge_ReportsTransportErrors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = io.WriteString(w, `{"code":0,"msg":"token revoked"}`)
+	}))
+	t.Cleanup(ts.Close)
+	h := startBridge(t, &StdioBridge{Endpoint: ts.URL, Token: "tok", Client: ts.Client()})
+
+	h.send(t, 3, "tools/list", nil)
+	msg := h.next(t)
+	rpcErr, _ := msg["error"].(map[string]any)
+	if rpcErr == nil || rpcErr["code"] != float64(ErrCodeInternal) {
+		t.Fatalf("expected internal error, got %v", msg)
+	}
+	if !strings.Contains(rpcErr["message"].(string), "HTTP 401: token revoked") {
+		t.Fatalf("unexpected message %v", rpcErr["message"])
+	}
+
+	h.sendRaw(t, "{not json")
+	msg = h.next(t)
+	if rpcErr, _ := msg["error"].(map[string]any); rpcErr == nil || rpcErr["code"] != float64(ErrCodeParse) {
+		t.Fatalf("expected parse error, got %v", msg)
+	}
+}
+
+func TestEncodeSentinel_RoundTrips(t *testing.T) {
+	for _, v := range []string{"search_posts", "ech0://posts/recent", "ech0://items/日记", " padded "} {
+		encoded := encodeSentinel(v)
+		decoded, err := decodeSentinel(encoded)
+		if err != nil || decoded != v {
+			t.Fatalf("round trip of %q gave %q (%v)", v, decoded, err)
+		}
+	}
+	if got := encodeSentinel("tools"); got != "tools" {
+		t.Fatalf("plain value should pass through, got %q", got)
+	}
+}
+
+func waitForStreams(t *testing.T, n *Notifier, want int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		n.mu.Lock()
+		got := len(n.streams)
+		n.mu.Unlock()
+		if got >= want {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("expected %d open streams", want)
+}
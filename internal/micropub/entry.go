@@ -0,0 +1,361 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package micropub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	"github.com/lin-snow/ech0/internal/storage"
+)
+
+// mediaProperties are the h-entry properties that carry attachments, paired
+// with the file category each maps to.
+var mediaProperties = []struct {
+	Name     string
+	Category storage.Category
+}{
+	{"photo", storage.CategoryImage},
+	{"video", storage.CategoryVideo},
+	{"audio", storage.CategoryAudio},
+}
+
+// mediaFragmentPrefix tags URLs handed out by the media endpoint with the
+// file ID ("…/photo.jpg#file=<id>"). Clients post the URL back verbatim, so
+// the exact uploaded file is attached; the fragment never reaches a server
+// when the image itself is fetched.
+const mediaFragmentPrefix = "file="
+
+// entry is the part of an h-entry that maps onto an Echo.
+type entry struct {
+	Content   string
+	Tags      []string
+	Location  *location
+	Media     []mediaRef
+	Private   bool
+	Published int64
+}
+
+type mediaRef struct {
+	Category storage.Category
+	URL      string
+	Alt      string
+}
+
+type location struct {
+	Latitude  float64
+	Longitude float64
+	Name      string
+}
+
+// entryFromProperties maps h-entry properties onto Echo fields. Properties
+// Ech0 has no equivalent for (syndication, in-reply-to, …) are ignored.
+func entryFromProperties(props map[string][]any) (entry, error) {
+	var e entry
+
+	content := firstText(props["content"])
+	if name := firstText(props["name"]); name != "" && name != content {
+		if content == "" {
+			content = name
+		} else {
+			content = "# " + name + "\n\n" + content
+		}
+	}
+	e.Content = content
+
+	for _, v := range props["category"] {
+		tag := strings.TrimPrefix(textValue(v), "#")
+		if tag != "" && !slices.Contains(e.Tags, tag) {
+			e.Tags = append(e.Tags, tag)
+		}
+	}
+
+	if raw := props["location"]; len(raw) > 0 {
+		loc, err := parseLocation(raw[0])
+		if err != nil {
+			return entry{}, err
+		}
+		e.Location = loc
+	}
+
+	for _, prop := range mediaProperties {
+		for _, v := range props[prop.Name] {
+			ref := mediaRef{Category: prop.Category}
+			switch value := v.(type) {
+			case string:
+				ref.URL = strings.TrimSpace(value)
+			case map[string]any:
+				ref.URL = textValue(value["value"])
+				ref.Alt = textValue(value["alt"])
+			}
+			if ref.URL == "" {
+				return entry{}, invalidRequest(prop.Name + " must be a URL")
+			}
+			e.Media = append(e.Media, ref)
+		}
+	}
+
+	visibility := strings.ToLower(firstText(props["visibility"]))
+	status := strings.ToLower(firstText(props["post-status"]))
+	e.Private = visibility == "private" || visibility == "unlisted" || status == "draft"
+
+	if published := firstText(props["published"]); published != "" {
+		t, err := parsePublished(published)
+		if err != nil {
+			return entry{}, invalidRequest("published must be an ISO 8601 date-time")
+		}
+		e.Published = t.Unix()
+	}
+	return e, nil
+}
+
+// propertiesFromEcho renders an Echo as h-entry properties, for q=source and
+// as the base that update operations are applied to.
+func propertiesFromEcho(echo *echoModel.Echo, baseURL string) map[string][]any {
+	props := map[string][]any{
+		"content":   {echo.Content},
+		"published": {time.Unix(echo.CreatedAt, 0).UTC().Format(time.RFC3339)},
+		"url":       {echoURL(baseURL, echo.ID)},
+	}
+	if echo.UpdatedAt > 0 {
+		props["updated"] = []any{time.Unix(echo.UpdatedAt, 0).UTC().Format(time.RFC3339)}
+	}
+	if echo.Private {
+		props["visibility"] = []any{"private"}
+	}
+	for _, tag := range echo.Tags {
+		props["category"] = append(props["category"], tag.Name)
+	}
+	if loc := locationFromExtension(echo.Extension); loc != nil {
+		props["location"] = []any{map[string]any{
+			"type": []any{"h-geo"},
+			"properties": map[string]any{
+				"latitude":  []any{loc.Latitude},
+				"longitude": []any{loc.Longitude},
+				"name":      []any{loc.Name},
+			},
+		}}
+	}
+
+	files := slices.Clone(echo.EchoFiles)
+	slices.SortStableFunc(files, func(a, b echoModel.EchoFile) int { return a.SortOrder - b.SortOrder })
+	for _, f := range files {
+		name := mediaPropertyFor(storage.Category(f.File.Category))
+		if name == "" || f.File.URL == "" {
+			continue
+		}
+		var value any = absoluteURL(baseURL, f.File.URL)
+		if f.File.AltText != "" {
+			value = map[string]any{"value": value, "alt": f.File.AltText}
+		}
+		props[name] = append(props[name], value)
+	}
+	return props
+}
+
+// filterProperties narrows q=source output to the requested properties.
+func filterProperties(props map[string][]any, names []string) map[string][]any {
+	if len(names) == 0 {
+		return props
+	}
+	filtered := make(map[string][]any, len(names))
+	for _, name := range names {
+		if values, ok := props[name]; ok {
+			filtered[name] = values
+		}
+	}
+	return filtered
+}
+
+func mediaPropertyFor(category storage.Category) string {
+	for _, prop := range mediaProperties {
+		if prop.Category == category {
+			return prop.Name
+		}
+	}
+	return ""
+}
+
+func (loc *location) extension() *echoModel.EchoExtension {
+	return &echoModel.EchoExtension{
+		Type: echoModel.Extension_LOCATION,
+		Payload: map[string]interface{}{
+			"latitude":    loc.Latitude,
+			"longitude":   loc.Longitude,
+			"placeholder": loc.Name,
+		},
+	}
+}
+
+func locationFromExtension(ext *echoModel.EchoExtension) *location {
+	if ext == nil || ext.Type != echoModel.Extension_LOCATION {
+		return nil
+	}
+	lat, okLat := numberValue(ext.Payload["latitude"])
+	lng, okLng := numberValue(ext.Payload["longitude"])
+	if !okLat || !okLng {
+		return nil
+	}
+	name, _ := ext.Payload["placeholder"].(string)
+	return &location{Latitude: lat, Longitude: lng, Name: name}
+}
+
+// parseLocation accepts a geo: URI (RFC 5870) or an h-geo / h-card / h-adr
+// object with latitude and longitude. Echo locations need coordinates, so a
+// plain place name is rejected rather than silently dropped.
+func parseLocation(v any) (*location, error) {
+	loc := &location{}
+	switch value := v.(type) {
+	case string:
+		if !strings.HasPrefix(strings.ToLower(value), "geo:") {
+			return nil, invalidRequest("location must be a geo: URI or an object with latitude and longitude")
+		}
+		coords, _, _ := strings.Cut(value[len("geo:"):], ";")
+		parts := strings.Split(coords, ",")
+		if len(parts) < 2 {
+			return nil, invalidRequest("malformed geo: URI")
+		}
+		var errLat, errLng error
+		loc.Latitude, errLat = strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+		loc.Longitude, errLng = strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if errLat != nil || errLng != nil {
+			return nil, invalidRequest("malformed geo: URI")
+		}
+	case map[string]any:
+		props, _ := value["properties"].(map[string]any)
+		lat, okLat := numberValue(firstOf(props["latitude"]))
+		lng, okLng := numberValue(firstOf(props["longitude"]))
+		if !okLat || !okLng {
+			return nil, invalidRequest("location must include latitude and longitude")
+		}
+		loc.Latitude, loc.Longitude = lat, lng
+		for _, key := range []string{"name", "label", "locality"} {
+			if name := textValue(firstOf(props[key])); name != "" {
+				loc.Name = name
+				break
+			}
+		}
+	default:
+		return nil, invalidRequest("location must be a geo: URI or an object with latitude and longitude")
+	}
+
+	if loc.Latitude < -90 || loc.Latitude > 90 || loc.Longitude < -180 || loc.Longitude > 180 {
+		return nil, invalidRequest("location coordinates out of range")
+	}
+	if loc.Name == "" {
+		loc.Name = fmt.Sprintf("%.5f, %.5f", loc.Latitude, loc.Longitude)
+	}
+	return loc, nil
+}
+
+var publishedLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+}
+
+func parsePublished(value string) (time.Time, error) {
+	var err error
+	for _, layout := range publishedLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// textValue reads a plain string, or the value / html of an embedded object
+// such as {"html": "<p>…</p>"} for content.
+func textValue(v any) string {
+	switch value := v.(type) {
+	case string:
+		return strings.TrimSpace(value)
+	case map[string]any:
+		if s := textValue(value["value"]); s != "" {
+			return s
+		}
+		return textValue(value["html"])
+	}
+	return ""
+}
+
+func firstText(values []any) string {
+	return textValue(firstOf(values))
+}
+
+// firstOf returns the first element of a property value list, tolerating a
+// bare scalar where a list was expected.
+func firstOf(v any) any {
+	switch values := v.(type) {
+	case []any:
+		if len(values) > 0 {
+			return values[0]
+		}
+		return nil
+	default:
+		return values
+	}
+}
+
+func numberValue(v any) (float64, bool) {
+	switch value := v.(type) {
+	case float64:
+		return value, true
+	case int:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case json.Number:
+		f, err := value.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func echoURL(baseURL, id string) string {
+	return baseURL + "/echo/" + url.PathEscape(id)
+}
+
+// echoIDFromURL extracts the Echo ID from a post URL of the form …/echo/{id}.
+func echoIDFromURL(raw string) (string, bool) {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", false
+	}
+	_, id, found := strings.Cut(parsed.Path, "/echo/")
+	id = strings.Trim(id, "/")
+	if !found || id == "" || strings.Contains(id, "/") {
+		return "", false
+	}
+	return id, true
+}
+
+// fileIDFromURL extracts the file ID from a media endpoint URL (see
+// mediaFragmentPrefix).
+func fileIDFromURL(raw string) (string, bool) {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+	id, found := strings.CutPrefix(parsed.Fragment, mediaFragmentPrefix)
+	return id, found && id != ""
+}
+
+func absoluteURL(baseURL, ref string) string {
+	if strings.HasPrefix(ref, "/") && !strings.HasPrefix(ref, "//") {
+		return baseURL + ref
+	}
+	return ref
+}
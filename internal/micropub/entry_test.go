@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package micropub
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	"github.com/lin-snow/ech0/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRequest_Form(t *testing.T) {
+	body := "h=entry&content=hello&category[]=go&category[]=web&photo=https://img.example/a.jpg&access_token=secret&mp-slug=x"
+	r := httptest.NewRequest(http.MethodPost, "/api/micropub", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	req, err := parseRequest(r)
+	require.NoError(t, err)
+	assert.Equal(t, actionCreate, req.Action)
+	assert.Equal(t, typeEntry, req.Type)
+	assert.Equal(t, []any{"hello"}, req.Properties["content"])
+	assert.Equal(t, []any{"go", "web"}, req.Properties["category"])
+	assert.Equal(t, []any{"https://img.example/a.jpg"}, req.Properties["photo"])
+	assert.NotContains(t, req.Properties, "access_token")
+	assert.NotContains(t, req.Properties, "mp-slug")
+}
+
+func TestParseRequest_JSONUpdate(t *testing.T) {
+	body := `{"action":"update","url":"https://ech0.example/echo/1",
+		"replace":{"content":["new"]},"add":{"category":["x"]},"delete":["location"]}`
+	r := httptest.NewRequest(http.MethodPost, "/api/micropub", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	req, err := parseRequest(r)
+	require.NoError(t, err)
+	assert.Equal(t, actionUpdate, req.Action)
+	assert.Equal(t, "https://ech0.example/echo/1", req.URL)
+	assert.True(t, req.touched("content"))
+	assert.True(t, req.touched("category"))
+	assert.True(t, req.touched("location"))
+	assert.False(t, req.touched("photo"))
+	assert.Nil(t, req.Delete["location"])
+}
+
+func TestParseRequest_UnsupportedContentType(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/api/micropub", strings.NewReader("x"))
+	r.Header.Set("Content-Type", "text/plain")
+
+	_, err := parseRequest(r)
+	var mpErr *mpError
+	require.ErrorAs(t, err, &mpErr)
+	assert.Equal(t, "invalid_request", mpErr.code)
+}
+
+func TestApplyUpdate(t *testing.T) {
+	props := map[string][]any{
+		"content":  {"old"},
+		"category": {"a", "b", "c"},
+		"location": {"geo:1,2"},
+	}
+	applyUpdate(props, request{
+		Replace: map[string][]any{"content": {"new"}},
+		Add:     map[string][]any{"category": {"d"}},
+		Delete:  map[string][]any{"category": {"b"}, "location": nil},
+	})
+
+	assert.Equal(t, []any{"new"}, props["content"])
+	assert.Equal(t, []any{"a", "c", "d"}, props["category"])
+	assert.NotContains(t, props, "location")
+}
+
+func TestEntryFromProperties(t *testing.T) {
+	e, err := entryFromProperties(map[string][]any{
+		"name":        {"Title"},
+		"content":     {map[string]any{"html": "<p>body</p>"}},
+		"category":    {"go", "#go", "web"},
+		"location":    {"geo:37.78,-122.41;u=35"},
+		"photo":       {"https://img.example/a.jpg", map[string]any{"value": "https://img.example/b.jpg", "alt": "b"}},
+		"published":   {"2026-01-02T03:04:05Z"},
+		"post-status": {"draft"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "# Title\n\n<p>body</p>", e.Content)
+	assert.Equal(t, []string{"go", "web"}, e.Tags)
+	require.NotNil(t, e.Location)
+	assert.InDelta(t, 37.78, e.Location.Latitude, 1e-9)
+	assert.InDelta(t, -122.41, e.Location.Longitude, 1e-9)
+	assert.Equal(t, "37.78000, -122.41000", e.Location.Name)
+	assert.Equal(t, []mediaRef{
+		{Category: storage.CategoryImage, URL: "https://img.example/a.jpg"},
+		{Category: storage.CategoryImage, URL: "https://img.example/b.jpg", Alt: "b"},
+	}, e.Media)
+	assert.True(t, e.Private)
+	assert.Equal(t, int64(1767323045), e.Published)
+}
+
+func TestParseLocation(t *testing.T) {
+	cases := []struct {
+		name    string
+		in      any
+		want    *location
+		wantErr bool
+	}{
+		{
+			name: "h-card with locality",
+			in: map[string]any{
+				"type":       []any{"h-card"},
+				"properties": map[string]any{"latitude": []any{"48.85"}, "longitude": []any{2.35}, "locality": []any{"Paris"}},
+			},
+			want: &location{Latitude: 48.85, Longitude: 2.35, Name: "Paris"},
+		},
+		{name: "plain place name", in: "Paris", wantErr: true},
+		{name: "malformed geo", in: "geo:abc", wantErr: true},
+		{name: "out of range", in: "geo:91,0", wantErr: true},
+		{
+			name:    "object without coordinates",
+			in:      map[string]any{"properties": map[string]any{"name": []any{"Home"}}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseLocation(tc.in)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestPropertiesFromEcho(t *testing.T) {
+	echo := &echoModel.Echo{
+		ID:        "e1",
+		Content:   "hello",
+		Private:   true,
+		CreatedAt: 1767323045,
+		Tags:      []echoModel.Tag{{Name: "go"}},
+		Extension: (&location{Latitude: 1, Longitude: 2, Name: "Home"}).extension(),
+		EchoFiles: []echoModel.EchoFile{
+			{FileID: "f2", SortOrder: 1, File: fileModel.File{URL: "https://cdn.example/b.jpg", Category: "image"}},
+			{FileID: "f1", SortOrder: 0, File: fileModel.File{URL: "/api/files/a.jpg", Category: "image", AltText: "a"}},
+		},
+	}
+
+	props := propertiesFromEcho(echo, "https://ech0.example")
+
+	assert.Equal(t, []any{"hello"}, props["content"])
+	assert.Equal(t, []any{"go"}, props["category"])
+	assert.Equal(t, []any{"private"}, props["visibility"])
+	assert.Equal(t, []any{"2026-01-02T03:04:05Z"}, props["published"])
+	assert.Equal(t, []any{"https://ech0.example/echo/e1"}, props["url"])
+	assert.Equal(t, []any{
+		map[string]any{"value": "https://ech0.example/api/files/a.jpg", "alt": "a"},
+		"https://cdn.example/b.jpg",
+	}, props["photo"])
+
+	// Round trip: the rendered properties map back onto the same fields.
+	e, err := entryFromProperties(props)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", e.Content)
+	assert.True(t, e.Private)
+	assert.Equal(t, &location{Latitude: 1, Longitude: 2, Name: "Home"}, e.Location)
+}
+
+func TestEchoIDFromURL(t *testing.T) {
+	cases := []struct {
+		in     string
+		want   string
+		wantOK bool
+	}{
+		{"https://ech0.example/echo/abc", "abc", true},
+		{"https://ech0.example/echo/abc/", "abc", true},
+		{"/echo/abc", "abc", true},
+		{"https://ech0.example/echo/", "", false},
+		{"https://ech0.example/echo/a/b", "", false},
+		{"https://ech0.example/other/abc", "", false},
+	}
+	for _, tc := range cases {
+		got, ok := echoIDFromURL(tc.in)
+		assert.Equal(t, tc.wantOK, ok, tc.in)
+		assert.Equal(t, tc.want, got, tc.in)
+	}
+}
@@ -0,0 +1,415 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package micropub
+
+import (
+	"context"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lin-snow/ech0/internal/config"
+	"github.com/lin-snow/ech0/internal/kvstore"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	echoService "github.com/lin-snow/ech0/internal/service/echo"
+	fileService "github.com/lin-snow/ech0/internal/service/file"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	"github.com/lin-snow/ech0/internal/storage"
+	errUtil "github.com/lin-snow/ech0/internal/util/err"
+)
+
+// MediaEndpointPath is where the media endpoint is mounted; it is advertised
+// to clients through q=config and must match the route registration.
+const MediaEndpointPath = "/api/micropub/media"
+
+var supportedQueries = []string{"config", "source", "syndicate-to", "category"}
+
+// Handler serves the W3C Micropub endpoint (https://www.w3.org/TR/micropub/)
+// on top of the Echo and File services. Authentication and scope checks are
+// done by the router; every service call runs as the token's owner.
+type Handler struct {
+	echoService echoService.Service
+	fileService fileService.Service
+	durableKV   kvstore.Store
+}
+
+func NewHandler(
+	echoSvc echoService.Service,
+	fileSvc fileService.Service,
+	durableKV kvstore.Store,
+) *Handler {
+	return &Handler{
+		echoService: echoSvc,
+		fileService: fileSvc,
+		durableKV:   durableKV,
+	}
+}
+
+// Query handles GET requests: q=config, q=source, q=syndicate-to and
+// q=category.
+func (h *Handler) Query(c *gin.Context) {
+	switch c.Query("q") {
+	case "config":
+		c.JSON(http.StatusOK, gin.H{
+			"media-endpoint": h.baseURL(c) + MediaEndpointPath,
+			"syndicate-to":   []any{},
+			"post-types": []gin.H{
+				{"type": "note", "name": "Echo"},
+				{"type": "photo", "name": "Photo"},
+				{"type": "video", "name": "Video"},
+				{"type": "audio", "name": "Audio"},
+			},
+			"q": supportedQueries,
+		})
+	case "syndicate-to":
+		c.JSON(http.StatusOK, gin.H{"syndicate-to": []any{}})
+	case "category":
+		h.queryCategories(c)
+	case "source":
+		h.querySource(c)
+	default:
+		writeError(c, invalidRequest("unsupported query"))
+	}
+}
+
+func (h *Handler) queryCategories(c *gin.Context) {
+	tags, err := h.echoService.GetAllTags()
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	filter := strings.ToLower(strings.TrimSpace(c.Query("filter")))
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if filter == "" || strings.HasPrefix(strings.ToLower(tag.Name), filter) {
+			names = append(names, tag.Name)
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"categories": names})
+}
+
+func (h *Handler) querySource(c *gin.Context) {
+	id, ok := echoIDFromURL(c.Query("url"))
+	if !ok {
+		writeError(c, invalidRequest("url must point to an echo"))
+		return
+	}
+	echo, err := h.echoService.GetEchoById(c.Request.Context(), id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	names := append(c.QueryArray("properties[]"), c.QueryArray("properties")...)
+	props := filterProperties(propertiesFromEcho(echo, h.baseURL(c)), names)
+	if len(names) > 0 {
+		// The spec omits "type" when specific properties were requested.
+		c.JSON(http.StatusOK, gin.H{"properties": props})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"type": []string{typeEntry}, "properties": props})
+}
+
+// Post handles create, update and delete.
+func (h *Handler) Post(c *gin.Context) {
+	req, err := parseRequest(c.Request)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	switch req.Action {
+	case actionCreate:
+		h.create(c, req)
+	case actionUpdate:
+		h.update(c, req)
+	case actionDelete:
+		h.delete(c, req)
+	case actionUndelete:
+		writeError(c, invalidRequest("undelete is not supported; deleted echoes cannot be restored"))
+	default:
+		writeError(c, invalidRequest("unknown action"))
+	}
+}
+
+func (h *Handler) create(c *gin.Context, req request) {
+	if req.Type != "" && req.Type != typeEntry {
+		writeError(c, invalidRequest("only h-entry posts are supported"))
+		return
+	}
+	e, err := entryFromProperties(req.Properties)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	base := h.baseURL(c)
+	files, err := h.attachMedia(ctx, e.Media, req.Files, nil, base)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	echo := &echoModel.Echo{
+		Content:   e.Content,
+		Private:   e.Private,
+		CreatedAt: e.Published,
+		EchoFiles: files,
+		Tags:      tagsOf(e.Tags),
+	}
+	if e.Location != nil {
+		echo.Extension = e.Location.extension()
+	}
+	if err := h.echoService.PostEcho(ctx, echo); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Header("Location", echoURL(base, echo.ID))
+	c.Status(http.StatusCreated)
+}
+
+// update applies replace/add/delete to the Echo's current properties and
+// writes back the fields Micropub can express. Extensions other than
+// LOCATION and the layout are left untouched.
+func (h *Handler) update(c *gin.Context, req request) {
+	id, ok := echoIDFromURL(req.URL)
+	if !ok {
+		writeError(c, invalidRequest("url must point to an echo"))
+		return
+	}
+	ctx := c.Request.Context()
+	echo, err := h.echoService.GetEchoById(ctx, id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	base := h.baseURL(c)
+	props := propertiesFromEcho(echo, base)
+	applyUpdate(props, req)
+	e, err := entryFromProperties(props)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	echo.Content = e.Content
+	echo.Private = e.Private
+	echo.Tags = tagsOf(e.Tags)
+	if req.touched("published") {
+		echo.CreatedAt = e.Published
+	}
+	if req.touched("location") {
+		switch {
+		case e.Location != nil:
+			echo.Extension = e.Location.extension()
+		case echo.Extension != nil && echo.Extension.Type == echoModel.Extension_LOCATION:
+			echo.Extension = nil
+		}
+	}
+
+	mediaTouched := false
+	for _, prop := range mediaProperties {
+		mediaTouched = mediaTouched || req.touched(prop.Name)
+	}
+	if mediaTouched {
+		files, err := h.attachMedia(ctx, e.Media, nil, echo.EchoFiles, base)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+		echo.EchoFiles = files
+	}
+
+	if err := h.echoService.UpdateEcho(ctx, echo); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) delete(c *gin.Context, req request) {
+	id, ok := echoIDFromURL(req.URL)
+	if !ok {
+		writeError(c, invalidRequest("url must point to an echo"))
+		return
+	}
+	if err := h.echoService.DeleteEchoById(c.Request.Context(), id); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Media handles the media endpoint: a multipart upload with a single "file"
+// part. The Location URL carries the file ID so a later create can attach
+// the exact upload instead of registering the URL as an external file.
+func (h *Handler) Media(c *gin.Context) {
+	fh, err := c.FormFile("file")
+	if err != nil {
+		writeError(c, invalidRequest("missing file part"))
+		return
+	}
+	ctx := c.Request.Context()
+	dto, err := h.fileService.UploadFile(ctx, fh, categoryOf(fh), h.storageType(ctx))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Header("Location", absoluteURL(h.baseURL(c), dto.URL)+"#"+mediaFragmentPrefix+dto.ID)
+	c.Status(http.StatusCreated)
+}
+
+// attachMedia resolves media references and multipart uploads into echo
+// files, in property order with uploads last. existing are the files
+// currently on the Echo, so their URLs map back to them on update.
+func (h *Handler) attachMedia(
+	ctx context.Context,
+	refs []mediaRef,
+	uploads map[string][]*multipart.FileHeader,
+	existing []echoModel.EchoFile,
+	base string,
+) ([]echoModel.EchoFile, error) {
+	var files []echoModel.EchoFile
+	attach := func(fileID string) {
+		files = append(files, echoModel.EchoFile{FileID: fileID, SortOrder: len(files)})
+	}
+
+	for _, ref := range refs {
+		fileID, alt, err := h.resolveMedia(ctx, ref, existing, base)
+		if err != nil {
+			return nil, err
+		}
+		if ref.Alt != "" && ref.Alt != alt {
+			if err := h.fileService.UpdateFileAltText(ctx, fileID, ref.Alt); err != nil {
+				return nil, err
+			}
+		}
+		attach(fileID)
+	}
+
+	for _, prop := range mediaProperties {
+		for _, fh := range uploads[prop.Name] {
+			dto, err := h.fileService.UploadFile(ctx, fh, prop.Category, h.storageType(ctx))
+			if err != nil {
+				return nil, err
+			}
+			attach(dto.ID)
+		}
+	}
+	return files, nil
+}
+
+// resolveMedia maps a media URL to a file ID and returns the file's current
+// alt text, registering unknown URLs as external files.
+func (h *Handler) resolveMedia(
+	ctx context.Context,
+	ref mediaRef,
+	existing []echoModel.EchoFile,
+	base string,
+) (string, string, error) {
+	if fileID, ok := fileIDFromURL(ref.URL); ok {
+		return fileID, "", nil
+	}
+	for _, ef := range existing {
+		if ef.File.URL != "" && (ef.File.URL == ref.URL || absoluteURL(base, ef.File.URL) == ref.URL) {
+			return ef.FileID, ef.File.AltText, nil
+		}
+	}
+	dto, err := h.fileService.CreateExternalFile(ctx, commonModel.CreateExternalFileDto{
+		URL:      ref.URL,
+		Category: string(ref.Category),
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return dto.ID, dto.AltText, nil
+}
+
+func (h *Handler) storageType(ctx context.Context) storage.StorageType {
+	if h.durableKV != nil {
+		if s3, _ := coreSetting.Get(ctx, h.durableKV, coreSetting.S3); s3.Enable {
+			return storage.StorageTypeObject
+		}
+	}
+	return storage.StorageTypeLocal
+}
+
+// baseURL is the public origin used in Location headers and q=source: the
+// configured server URL, falling back to the request's own origin.
+func (h *Handler) baseURL(c *gin.Context) string {
+	if h.durableKV != nil {
+		if value, err := h.durableKV.Get(c.Request.Context(), commonModel.ServerURLKey); err == nil {
+			if value = strings.TrimSuffix(strings.TrimSpace(value), "/"); value != "" {
+				return value
+			}
+		}
+	}
+	if value := strings.TrimSuffix(strings.TrimSpace(config.Config().Setting.Serverurl), "/"); value != "" {
+		return value
+	}
+	scheme := "http"
+	if c.Request.TLS != nil || strings.EqualFold(c.GetHeader("X-Forwarded-Proto"), "https") {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host
+}
+
+func categoryOf(fh *multipart.FileHeader) storage.Category {
+	contentType := strings.ToLower(fh.Header.Get("Content-Type"))
+	switch {
+	case strings.HasPrefix(contentType, "video/"):
+		return storage.CategoryVideo
+	case strings.HasPrefix(contentType, "audio/"):
+		return storage.CategoryAudio
+	default:
+		return storage.CategoryImage
+	}
+}
+
+func tagsOf(names []string) []echoModel.Tag {
+	tags := make([]echoModel.Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, echoModel.Tag{Name: name})
+	}
+	return tags
+}
+
+// mpError is a Micropub error response
+// ({"error": code, "error_description": desc}).
+type mpError struct {
+	status int
+	code   string
+	desc   string
+}
+
+func (e *mpError) Error() string {
+	return e.code + ": " + e.desc
+}
+
+func invalidRequest(desc string) error {
+	return &mpError{status: http.StatusBadRequest, code: "invalid_request", desc: desc}
+}
+
+// writeError renders err in the Micropub error format. Service errors become
+// forbidden when they are permission failures and invalid_request otherwise.
+func writeError(c *gin.Context, err error) {
+	var mpErr *mpError
+	if !errors.As(err, &mpErr) {
+		desc := errUtil.HandleError(&commonModel.ServerError{Err: err})
+		code, _, _ := commonModel.ResolveFailureFields(err, desc)
+		if code == commonModel.ErrCodePermissionDenied || desc == commonModel.NO_PERMISSION_DENIED {
+			mpErr = &mpError{status: http.StatusForbidden, code: "forbidden", desc: desc}
+		} else {
+			mpErr = &mpError{status: http.StatusBadRequest, code: "invalid_request", desc: desc}
+		}
+	}
+	c.AbortWithStatusJSON(mpErr.status, gin.H{"error": mpErr.code, "error_description": mpErr.desc})
+}
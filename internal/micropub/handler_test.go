@@ -0,0 +1,278 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package micropub_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lin-snow/ech0/internal/kvstore"
+	"github.com/lin-snow/ech0/internal/micropub"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	"github.com/lin-snow/ech0/internal/storage"
+	"github.com/lin-snow/ech0/internal/test/mocks/echomock"
+	"github.com/lin-snow/ech0/internal/test/mocks/filemock"
+	"github.com/lin-snow/ech0/internal/test/mocks/kvmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+type fixture struct {
+	echo   *echomock.MockService
+	file   *filemock.MockService
+	engine *gin.Engine
+}
+
+func newFixture(t *testing.T) *fixture {
+	kv := kvmock.NewMockStore(t)
+	kv.EXPECT().Get(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, key string) (string, error) {
+		if key == commonModel.ServerURLKey {
+			return "https://ech0.example/", nil
+		}
+		return "", kvstore.ErrNotFound
+	}).Maybe()
+
+	f := &fixture{echo: echomock.NewMockService(t), file: filemock.NewMockService(t), engine: gin.New()}
+	h := micropub.NewHandler(f.echo, f.file, kv)
+	f.engine.GET("/api/micropub", h.Query)
+	f.engine.POST("/api/micropub", h.Post)
+	f.engine.POST("/api/micropub/media", h.Media)
+	return f
+}
+
+func (f *fixture) do(method, target, contentType string, body io.Reader) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, body)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	rec := httptest.NewRecorder()
+	f.engine.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestQueryConfig(t *testing.T) {
+	f := newFixture(t)
+
+	rec := f.do(http.MethodGet, "/api/micropub?q=config", "", nil)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+	assert.Equal(t, "https://ech0.example"+micropub.MediaEndpointPath, out["media-endpoint"])
+	assert.Contains(t, out["q"], "source")
+}
+
+func TestQueryUnsupported(t *testing.T) {
+	f := newFixture(t)
+
+	rec := f.do(http.MethodGet, "/api/micropub?q=nope", "", nil)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"error":"invalid_request","error_description":"unsupported query"}`, rec.Body.String())
+}
+
+func TestQuerySource(t *testing.T) {
+	f := newFixture(t)
+	f.echo.EXPECT().GetEchoById(mock.Anything, "e1").Return(&echoModel.Echo{
+		ID: "e1", Content: "hello", Tags: []echoModel.Tag{{Name: "go"}},
+	}, nil).Once()
+
+	rec := f.do(http.MethodGet, "/api/micropub?q=source&url=https://ech0.example/echo/e1&properties[]=content", "", nil)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"properties":{"content":["hello"]}}`, rec.Body.String())
+}
+
+func TestCreate_Form(t *testing.T) {
+	f := newFixture(t)
+	f.file.EXPECT().CreateExternalFile(mock.Anything, commonModel.CreateExternalFileDto{
+		URL: "https://img.example/a.jpg", Category: string(storage.CategoryImage),
+	}).Return(commonModel.FileDto{ID: "f1"}, nil).Once()
+
+	var posted *echoModel.Echo
+	f.echo.EXPECT().PostEcho(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, e *echoModel.Echo) error {
+		e.ID = "e1"
+		posted = e
+		return nil
+	}).Once()
+
+	rec := f.do(http.MethodPost, "/api/micropub", "application/x-www-form-urlencoded",
+		strings.NewReader("h=entry&content=hello&category[]=go&location=geo:1.5,2.5&photo=https://img.example/a.jpg"))
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "https://ech0.example/echo/e1", rec.Header().Get("Location"))
+	require.NotNil(t, posted)
+	assert.Equal(t, "hello", posted.Content)
+	assert.Equal(t, []echoModel.Tag{{Name: "go"}}, posted.Tags)
+	assert.Equal(t, []echoModel.EchoFile{{FileID: "f1"}}, posted.EchoFiles)
+	require.NotNil(t, posted.Extension)
+	assert.Equal(t, echoModel.Extension_LOCATION, posted.Extension.Type)
+	assert.Equal(t, 1.5, posted.Extension.Payload["latitude"])
+}
+
+func TestCreate_MediaEndpointURL(t *testing.T) {
+	f := newFixture(t)
+	// The #file= fragment handed out by the media endpoint maps straight to the
+	// upload; no external file is registered.
+	f.file.EXPECT().UpdateFileAltText(mock.Anything, "f9", "a cat").Return(nil).Once()
+
+	var posted *echoModel.Echo
+	f.echo.EXPECT().PostEcho(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, e *echoModel.Echo) error {
+		e.ID = "e2"
+		posted = e
+		return nil
+	}).Once()
+
+	rec := f.do(http.MethodPost, "/api/micropub", "application/json", strings.NewReader(`{"type":["h-entry"],"properties":{
+		"content":["meow"],
+		"photo":[{"value":"https://ech0.example/api/files/cat.jpg#file=f9","alt":"a cat"}]}}`))
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, []echoModel.EchoFile{{FileID: "f9"}}, posted.EchoFiles)
+}
+
+func TestCreate_RejectsNonEntry(t *testing.T) {
+	f := newFixture(t)
+
+	rec := f.do(http.MethodPost, "/api/micropub", "application/json", strings.NewReader(`{"type":["h-event"],"properties":{"name":["x"]}}`))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestCreate_PermissionDenied(t *testing.T) {
+	f := newFixture(t)
+	f.echo.EXPECT().PostEcho(mock.Anything, mock.Anything).Return(errors.New(commonModel.NO_PERMISSION_DENIED)).Once()
+
+	rec := f.do(http.MethodPost, "/api/micropub", "application/x-www-form-urlencoded", strings.NewReader("h=entry&content=hi"))
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	var out map[string]string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+	assert.Equal(t, "forbidden", out["error"])
+}
+
+func TestUpdate(t *testing.T) {
+	f := newFixture(t)
+	existing := &echoModel.Echo{
+		ID:      "e1",
+		Content: "old",
+		Tags:    []echoModel.Tag{{Name: "go"}},
+		Extension: &echoModel.EchoExtension{Type: echoModel.Extension_LOCATION, Payload: map[string]interface{}{
+			"latitude": 1.0, "longitude": 2.0, "placeholder": "Home",
+		}},
+		EchoFiles: []echoModel.EchoFile{
+			{FileID: "f1", File: fileModel.File{URL: "/api/files/a.jpg", Category: "image"}},
+		},
+	}
+	f.echo.EXPECT().GetEchoById(mock.Anything, "e1").Return(existing, nil).Once()
+
+	var updated *echoModel.Echo
+	f.echo.EXPECT().UpdateEcho(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, e *echoModel.Echo) error {
+		updated = e
+		return nil
+	}).Once()
+
+	rec := f.do(http.MethodPost, "/api/micropub", "application/json", strings.NewReader(`{"action":"update",
+		"url":"https://ech0.example/echo/e1",
+		"replace":{"content":["new"]},"add":{"category":["web"]},"delete":["location"]}`))
+
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.NotNil(t, updated)
+	assert.Equal(t, "new", updated.Content)
+	assert.Equal(t, []echoModel.Tag{{Name: "go"}, {Name: "web"}}, updated.Tags)
+	assert.Nil(t, updated.Extension)
+	// Media was not touched, so the attachments are left exactly as they were.
+	assert.Equal(t, "f1", updated.EchoFiles[0].FileID)
+}
+
+func TestUpdate_AddPhotoKeepsExistingFile(t *testing.T) {
+	f := newFixture(t)
+	f.echo.EXPECT().GetEchoById(mock.Anything, "e1").Return(&echoModel.Echo{
+		ID:      "e1",
+		Content: "x",
+		EchoFiles: []echoModel.EchoFile{
+			{FileID: "f1", File: fileModel.File{URL: "/api/files/a.jpg", Category: "image"}},
+		},
+	}, nil).Once()
+	f.file.EXPECT().CreateExternalFile(mock.Anything, mock.Anything).Return(commonModel.FileDto{ID: "f2"}, nil).Once()
+
+	var updated *echoModel.Echo
+	f.echo.EXPECT().UpdateEcho(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, e *echoModel.Echo) error {
+		updated = e
+		return nil
+	}).Once()
+
+	rec := f.do(http.MethodPost, "/api/micropub", "application/json", strings.NewReader(`{"action":"update",
+		"url":"https://ech0.example/echo/e1",
+		"add":{"photo":["https://img.example/b.jpg"]}}`))
+
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, []echoModel.EchoFile{{FileID: "f1"}, {FileID: "f2", SortOrder: 1}}, updated.EchoFiles)
+}
+
+func TestDelete(t *testing.T) {
+	f := newFixture(t)
+	f.echo.EXPECT().DeleteEchoById(mock.Anything, "e1").Return(nil).Once()
+
+	rec := f.do(http.MethodPost, "/api/micropub", "application/x-www-form-urlencoded",
+		strings.NewReader("action=delete&url=https://ech0.example/echo/e1"))
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestUndeleteUnsupported(t *testing.T) {
+	f := newFixture(t)
+
+	rec := f.do(http.MethodPost, "/api/micropub", "application/x-www-form-urlencoded",
+		strings.NewReader("action=undelete&url=https://ech0.example/echo/e1"))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMedia(t *testing.T) {
+	f := newFixture(t)
+	f.file.EXPECT().UploadFile(mock.Anything, mock.Anything, storage.CategoryVideo, storage.StorageTypeLocal).
+		Return(commonModel.FileDto{ID: "f7", URL: "/api/files/clip.mp4"}, nil).Once()
+
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="file"; filename="clip.mp4"`)
+	header.Set("Content-Type", "video/mp4")
+	part, err := w.CreatePart(header)
+	require.NoError(t, err)
+	_, _ = part.Write([]byte("data"))
+	require.NoError(t, w.Close())
+
+	rec := f.do(http.MethodPost, micropub.MediaEndpointPath, w.FormDataContentType(), buf)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "https://ech0.example/api/files/clip.mp4#file=f7", rec.Header().Get("Location"))
+}
+
+func TestMedia_MissingFile(t *testing.T) {
+	f := newFixture(t)
+
+	rec := f.do(http.MethodPost, micropub.MediaEndpointPath, "application/x-www-form-urlencoded", strings.NewReader("x=1"))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.True(t, strings.Contains(rec.Body.String(), "invalid_request"))
+}
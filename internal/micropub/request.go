@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package micropub
+
+import (
+	"encoding/json"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+const (
+	actionCreate   = ""
+	actionUpdate   = "update"
+	actionDelete   = "delete"
+	actionUndelete = "undelete"
+
+	typeEntry = "h-entry"
+
+	// maxMultipartMemory bounds how much of a multipart body is held in memory;
+	// larger parts spill to temp files, as with the regular upload endpoint.
+	maxMultipartMemory = 32 << 20
+)
+
+// request is a Micropub POST normalized across the three body encodings
+// (form-encoded, multipart and JSON). Property values are either strings or,
+// for JSON bodies, nested microformats objects such as {"value", "alt"}.
+type request struct {
+	Action     string
+	URL        string
+	Type       string
+	Properties map[string][]any
+
+	// Update operations (JSON only). A nil slice in Delete removes the whole
+	// property; a non-nil one removes just those values.
+	Replace map[string][]any
+	Add     map[string][]any
+	Delete  map[string][]any
+
+	// Files holds multipart parts keyed by property (photo, video, audio).
+	Files map[string][]*multipart.FileHeader
+}
+
+// reservedFormKeys are form fields that carry request metadata rather than
+// h-entry properties.
+var reservedFormKeys = map[string]struct{}{
+	"access_token": {},
+	"h":            {},
+	"action":       {},
+	"url":          {},
+}
+
+func parseRequest(r *http.Request) (request, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/json":
+		return parseJSONRequest(r)
+	case "multipart/form-data":
+		if err := r.ParseMultipartForm(maxMultipartMemory); err != nil {
+			return request{}, invalidRequest("malformed multipart body")
+		}
+	case "application/x-www-form-urlencoded":
+		if err := r.ParseForm(); err != nil {
+			return request{}, invalidRequest("malformed form body")
+		}
+	default:
+		return request{}, invalidRequest("unsupported content type")
+	}
+	return parseFormRequest(r), nil
+}
+
+func parseFormRequest(r *http.Request) request {
+	req := request{
+		Action:     strings.ToLower(strings.TrimSpace(r.PostForm.Get("action"))),
+		URL:        strings.TrimSpace(r.PostForm.Get("url")),
+		Properties: map[string][]any{},
+	}
+	if h := strings.TrimSpace(r.PostForm.Get("h")); h != "" {
+		req.Type = "h-" + strings.ToLower(h)
+	}
+
+	for key, values := range r.PostForm {
+		if _, ok := reservedFormKeys[key]; ok || strings.HasPrefix(key, "mp-") {
+			continue
+		}
+		name := strings.TrimSuffix(key, "[]")
+		for _, value := range values {
+			req.Properties[name] = append(req.Properties[name], value)
+		}
+	}
+
+	if r.MultipartForm != nil && len(r.MultipartForm.File) > 0 {
+		req.Files = map[string][]*multipart.FileHeader{}
+		for key, files := range r.MultipartForm.File {
+			name := strings.TrimSuffix(key, "[]")
+			req.Files[name] = append(req.Files[name], files...)
+		}
+	}
+	return req
+}
+
+func parseJSONRequest(r *http.Request) (request, error) {
+	var body struct {
+		Type       []string         `json:"type"`
+		Properties map[string][]any `json:"properties"`
+		Action     string           `json:"action"`
+		URL        string           `json:"url"`
+		Replace    map[string][]any `json:"replace"`
+		Add        map[string][]any `json:"add"`
+		Delete     json.RawMessage  `json:"delete"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return request{}, invalidRequest("malformed JSON body")
+	}
+
+	req := request{
+		Action:     strings.ToLower(strings.TrimSpace(body.Action)),
+		URL:        strings.TrimSpace(body.URL),
+		Properties: body.Properties,
+		Replace:    body.Replace,
+		Add:        body.Add,
+	}
+	if len(body.Type) > 0 {
+		req.Type = body.Type[0]
+	}
+	if req.Properties == nil {
+		req.Properties = map[string][]any{}
+	}
+
+	if len(body.Delete) > 0 {
+		deletes, err := parseDeleteOp(body.Delete)
+		if err != nil {
+			return request{}, err
+		}
+		req.Delete = deletes
+	}
+	return req, nil
+}
+
+// parseDeleteOp accepts both delete forms from the spec: a list of property
+// names, or a map of property → values to remove.
+func parseDeleteOp(raw json.RawMessage) (map[string][]any, error) {
+	var names []string
+	if err := json.Unmarshal(raw, &names); err == nil {
+		deletes := make(map[string][]any, len(names))
+		for _, name := range names {
+			deletes[name] = nil
+		}
+		return deletes, nil
+	}
+	var values map[string][]any
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, invalidRequest("delete must be a list of properties or a map of values")
+	}
+	return values, nil
+}
+
+// applyUpdate applies replace, add and delete (in that order, as the spec
+// requires) to props in place.
+func applyUpdate(props map[string][]any, req request) {
+	for name, values := range req.Replace {
+		props[name] = values
+	}
+	for name, values := range req.Add {
+		props[name] = append(props[name], values...)
+	}
+	for name, values := range req.Delete {
+		if values == nil {
+			delete(props, name)
+			continue
+		}
+		kept := props[name][:0:0]
+		for _, existing := range props[name] {
+			if !containsValue(values, existing) {
+				kept = append(kept, existing)
+			}
+		}
+		if len(kept) == 0 {
+			delete(props, name)
+		} else {
+			props[name] = kept
+		}
+	}
+}
+
+// touched reports whether an update request changes the given property.
+func (req request) touched(name string) bool {
+	_, replaced := req.Replace[name]
+	_, added := req.Add[name]
+	_, deleted := req.Delete[name]
+	return replaced || added || deleted
+}
+
+func containsValue(values []any, target any) bool {
+	targetStr, ok := target.(string)
+	if !ok {
+		return false
+	}
+	for _, v := range values {
+		if s, ok := v.(string); ok && s == targetStr {
+			return true
+		}
+	}
+	return false
+}
@@ -25,12 +25,14 @@ func Cors() gin.HandlerFunc {
 
 		c.Header(
 			"Access-Control-Allow-Headers",
-			"Content-Type, Authorization, Accept-Language, Range, X-Timezone, X-Locale, X-Direct-URL",
+			"Content-Type, Authorization, Accept-Language, Range, X-Timezone, X-Locale, X-Direct-URL, "+
+				"Tus-Resumable, Upload-Length, Upload-Metadata, Upload-Offset",
 		)
-		c.Header("Access-Control-Allow-Methods", "POST, GET, OPTIONS, DELETE, PATCH, PUT")
+		c.Header("Access-Control-Allow-Methods", "POST, GET, HEAD, OPTIONS, DELETE, PATCH, PUT")
 		c.Header(
 			"Access-Control-Expose-Headers",
-			"Content-Length, Content-Range, Access-Control-Allow-Origin, Access-Control-Allow-Headers, Content-Type",
+			"Content-Length, Content-Range, Access-Control-Allow-Origin, Access-Control-Allow-Headers, Content-Type, "+
+				"Location, Tus-Resumable, Tus-Version, Tus-Extension, Upload-Offset, Upload-Length, Upload-Expires, Ech0-File-Id",
 		)
 		if method == "OPTIONS" {
 			c.AbortWithStatus(http.StatusNoContent)
@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package middleware
+
+import (
+	"mime"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FormAccessToken 将表单体中的 access_token 提升为 Authorization 头，须挂在 RequireAuth 之前。
+// Micropub 规范允许客户端把 token 放在 form-encoded / multipart 请求体里而非请求头；
+// 已带 Authorization 头时不做任何处理，JSON 等其他请求体也不解析。
+func FormAccessToken() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if strings.TrimSpace(ctx.GetHeader("Authorization")) == "" && ctx.Request.Method == "POST" {
+			mediaType, _, _ := mime.ParseMediaType(ctx.GetHeader("Content-Type"))
+			if mediaType == "application/x-www-form-urlencoded" || mediaType == "multipart/form-data" {
+				if token := strings.TrimSpace(ctx.PostForm("access_token")); token != "" {
+					ctx.Request.Header.Set("Authorization", "Bearer "+token)
+				}
+			}
+		}
+		ctx.Next()
+	}
+}
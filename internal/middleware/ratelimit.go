@@ -11,11 +11,14 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// RateLimits 返回当前的每秒速率与突发容量。每次放行判断都会调用，
+// 因此读 config.Config() 的实现能随配置热加载即时生效。
+type RateLimits func() (rps, burst int)
+
 type rateLimiter struct {
 	mu      sync.Mutex
 	buckets map[string]*tokenBucket
-	rps     int
-	burst   int
+	limits  RateLimits
 }
 
 type tokenBucket struct {
@@ -25,28 +28,35 @@ type tokenBucket struct {
 	burst     float64
 }
 
-func newRateLimiter(rps, burst int) *rateLimiter {
+func newRateLimiter(limits RateLimits) *rateLimiter {
 	return &rateLimiter{
 		buckets: make(map[string]*tokenBucket),
-		rps:     rps,
-		burst:   burst,
+		limits:  limits,
 	}
 }
 
+// fixedLimits 把常量阈值包装成 RateLimits。
+func fixedLimits(rps, burst int) RateLimits {
+	return func() (int, int) { return rps, burst }
+}
+
 func (rl *rateLimiter) allow(key string) bool {
+	rps, burst := rl.limits()
+
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
 	b, ok := rl.buckets[key]
 	if !ok {
 		b = &tokenBucket{
-			tokens:    float64(rl.burst),
-			lastTime:  time.Now(),
-			ratePerNs: float64(rl.rps) / float64(time.Second),
-			burst:     float64(rl.burst),
+			tokens:   float64(burst),
+			lastTime: time.Now(),
 		}
 		rl.buckets[key] = b
 	}
+	// 阈值可能已被热加载改掉，已有的桶也按新值续算。
+	b.ratePerNs = float64(rps) / float64(time.Second)
+	b.burst = float64(burst)
 
 	now := time.Now()
 	elapsed := now.Sub(b.lastTime)
@@ -64,7 +74,12 @@ func (rl *rateLimiter) allow(key string) bool {
 }
 
 func RateLimit(rps, burst int) gin.HandlerFunc {
-	limiter := newRateLimiter(rps, burst)
+	return RateLimitFrom(fixedLimits(rps, burst))
+}
+
+// RateLimitFrom 与 RateLimit 相同，但阈值每次从 limits 现取。
+func RateLimitFrom(limits RateLimits) gin.HandlerFunc {
+	limiter := newRateLimiter(limits)
 	startBucketGC(limiter, 5*time.Minute, 10*time.Minute)
 
 	return func(c *gin.Context) {
@@ -91,12 +106,12 @@ func RateLimit(rps, burst int) gin.HandlerFunc {
 // onIdempotent 必须自行写出响应并 Abort；推荐返回与正常成功路径形状一致的响应，
 // 使客户端无感知。
 func RateLimitWithIdempotency(
-	rps, burst int,
+	limits RateLimits,
 	dedupTTL time.Duration,
 	resourceParam string,
 	onIdempotent gin.HandlerFunc,
 ) gin.HandlerFunc {
-	limiter := newRateLimiter(rps, burst)
+	limiter := newRateLimiter(limits)
 	dedup := newIdempotencyStore(dedupTTL)
 
 	gcInterval := max(dedupTTL, time.Minute)
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -33,3 +34,33 @@ func TestRateLimitBasic(t *testing.T) {
 		t.Errorf("rate limited request: status = %d, want %d", rec.Code, http.StatusTooManyRequests)
 	}
 }
+
+func TestRateLimitFrom_PicksUpNewLimits(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rps, burst := 1, 1
+	r := gin.New()
+	r.Use(RateLimitFrom(func() (int, int) { return rps, burst }))
+	r.GET("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	do := func() int {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+		return rec.Code
+	}
+
+	if code := do(); code != http.StatusOK {
+		t.Fatalf("first request: status = %d, want %d", code, http.StatusOK)
+	}
+	if code := do(); code != http.StatusTooManyRequests {
+		t.Fatalf("second request: status = %d, want %d", code, http.StatusTooManyRequests)
+	}
+
+	// 阈值被热加载放宽后，已有的桶按新速率与容量续算，不必等桶被回收。
+	rps, burst = 10000, 100
+	time.Sleep(10 * time.Millisecond)
+	for i := range 50 {
+		if code := do(); code != http.StatusOK {
+			t.Fatalf("request %d after raising limits: status = %d, want %d", i, code, http.StatusOK)
+		}
+	}
+}
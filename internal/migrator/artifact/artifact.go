@@ -1,11 +1,12 @@
 // SPDX-License-Identifier: AGPL-3.0-or-later
 // Copyright (C) 2025-2026 lin-snow
 
-// Package artifact 管理「只保留最新一份」的导出产物槽位。
+// Package artifact 管理导出产物槽位及其留存策略。
 //
-// 快照与胶囊各占一个槽位，这不是整洁强迫症而是正确性要求：两者都遵循「保留最新一份」，
-// 挤在同一目录里就会互删——定时快照走 gocron 直连 ExportEngine、不经过 job.Manager，
-// 作业互斥拦不住它，用户导完胶囊还没点下载，定时快照一响就把胶囊清掉了。
+// 快照与胶囊各占一个槽位，这不是整洁强迫症而是正确性要求：胶囊「只保留最新一份」、快照按
+// GFS 策略（见 Retention）清理，挤在同一目录里就会互删——定时快照走 gocron 直连
+// ExportEngine、不经过 job.Manager，作业互斥拦不住它，用户导完胶囊还没点下载，
+// 定时快照一响就把胶囊清掉了。
 package artifact
 
 import (
@@ -24,7 +25,7 @@ var ErrNone = errors.New("artifact: no artifact available")
 // 即最新一份，无需 stat 每个文件。
 const timeLayout = "2006-01-02_15-04-05"
 
-// 产物目录布局。三者都在 data/ 下，且都**必须**被排除在快照之外——快照是「data/ 的 zip」，
+// 产物目录布局。都在 data/ 下，且都**必须**被排除在快照之外——快照是「data/ 的 zip」，
 // 把派生产物打进去会让快照套娃式膨胀。新增产物目录时改这里，Excluded 会自动带上，
 // 不会漏掉排除这一步。
 const (
@@ -32,6 +33,8 @@ const (
 	SnapshotDir = "files/snapshots"
 	CapsuleDir  = "files/capsules"
 	TmpDir      = "files/tmp"
+	// UploadTmpDir 是断点续传分片的默认暂存目录（ECH0_UPLOAD_TMP_PATH），半截上传不进快照。
+	UploadTmpDir = "tmp"
 )
 
 // Snapshots 是快照产物槽位（整个 data/ 的 zip，含账号与凭据）。
@@ -39,15 +42,15 @@ func Snapshots() Slot {
 	return NewSlot(filepath.Join(DataDir, SnapshotDir), "ech0_snapshot")
 }
 
-// Capsules 是胶囊产物槽位（可分享的内容子集）。与快照分居两个目录，否则「只保留最新一份」
-// 会让两者互删。
+// Capsules 是胶囊产物槽位（可分享的内容子集）。与快照分居两个目录，否则两边的清理
+// 会互删对方的产物。
 func Capsules() Slot {
 	return NewSlot(filepath.Join(DataDir, CapsuleDir), "ech0_capsule")
 }
 
 // Excluded 返回不进快照的子树（相对 data/）。
 func Excluded() []string {
-	return []string{SnapshotDir, CapsuleDir, TmpDir}
+	return []string{SnapshotDir, CapsuleDir, TmpDir, UploadTmpDir}
 }
 
 // Slot 是一个产物目录加文件名前缀。零值不可用，用 NewSlot 构造。
@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package artifact
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Retention 是祖父-父-子（GFS）留存策略：保留最近 Daily 个自然日、Weekly 个 ISO 周、
+// Monthly 个自然月里各自最新的一份。三档取并集，且最新一份无条件保留——全为零即退化成
+// 「只保留最新一份」，与引入留存策略之前的行为一致。
+//
+// 分桶按 UTC 划分：产物文件名里的时间戳就是 UTC，换算成本地时区反而会让同一份产物
+// 在夏令时切换前后落进不同的桶。
+type Retention struct {
+	Daily   int
+	Weekly  int
+	Monthly int
+}
+
+// Entry 是槽位里的一份已完成产物。
+type Entry struct {
+	Name      string
+	Size      int64
+	CreatedAt time.Time
+}
+
+// ParseName 从产物文件名里取回生成时刻。不是本槽位产物（前缀不符、时间戳残缺）时返回 false。
+func (s Slot) ParseName(name string) (time.Time, bool) {
+	rest, ok := strings.CutPrefix(name, s.prefix+"_")
+	if !ok {
+		return time.Time{}, false
+	}
+	stamp, ok := strings.CutSuffix(rest, ".zip")
+	if !ok {
+		return time.Time{}, false
+	}
+	at, err := time.Parse(timeLayout, stamp)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return at, true
+}
+
+// List 按从新到旧列出槽位里的已完成产物。空槽位返回空切片而非 ErrNone——「没有」对列表是合法答案。
+func (s Slot) List() ([]Entry, error) {
+	dirEntries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read artifact dir: %w", err)
+	}
+
+	var out []Entry
+	for _, de := range dirEntries {
+		if de.IsDir() {
+			continue
+		}
+		at, ok := s.ParseName(de.Name())
+		if !ok {
+			continue
+		}
+		info, err := de.Info()
+		if err != nil {
+			continue
+		}
+		out = append(out, Entry{Name: de.Name(), Size: info.Size(), CreatedAt: at})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name > out[j].Name })
+	return out, nil
+}
+
+// Lookup 按文件名取回一份已完成产物的路径。只接受本槽位自己生成的名字，
+// 名字来自请求参数时借此挡住路径穿越。
+func (s Slot) Lookup(name string) (string, error) {
+	if _, ok := s.ParseName(name); !ok {
+		return "", ErrNone
+	}
+	p := s.Path(name)
+	info, err := os.Stat(p)
+	if err != nil || info.IsDir() {
+		return "", ErrNone
+	}
+	return p, nil
+}
+
+// Prune 按 policy 清理槽位，返回被删除的文件名。不属于本槽位的文件（含打包中的临时文件）不动。
+func (s Slot) Prune(policy Retention) ([]string, error) {
+	entries, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	times := make([]time.Time, len(entries))
+	for i, e := range entries {
+		times[i] = e.CreatedAt
+	}
+	keep := policy.Select(times)
+
+	var removed []string
+	for i, e := range entries {
+		if keep[i] {
+			continue
+		}
+		if err := os.Remove(s.Path(e.Name)); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("prune artifact %s: %w", e.Name, err)
+		}
+		removed = append(removed, e.Name)
+	}
+	return removed, nil
+}
+
+// Select 对一组产物时刻给出去留：返回与 times 等长的切片，true 表示保留。
+// times 的顺序任意；同一桶内只留最新的一份。
+func (p Retention) Select(times []time.Time) []bool {
+	keep := make([]bool, len(times))
+	if len(times) == 0 {
+		return keep
+	}
+	order := make([]int, len(times))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return times[order[a]].After(times[order[b]]) })
+
+	keep[order[0]] = true
+	for _, tier := range []struct {
+		limit  int
+		bucket func(time.Time) string
+	}{
+		{p.Daily, func(t time.Time) string { return t.UTC().Format("2006-01-02") }},
+		{p.Weekly, func(t time.Time) string {
+			year, week := t.UTC().ISOWeek()
+			return fmt.Sprintf("%d-W%02d", year, week)
+		}},
+		{p.Monthly, func(t time.Time) string { return t.UTC().Format("2006-01") }},
+	} {
+		if tier.limit <= 0 {
+			continue
+		}
+		seen := make(map[string]struct{}, tier.limit)
+		for _, i := range order {
+			key := tier.bucket(times[i])
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			if len(seen) == tier.limit {
+				break
+			}
+			seen[key] = struct{}{}
+			keep[i] = true
+		}
+	}
+	return keep
+}
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package artifact
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRetentionSelect(t *testing.T) {
+	base := time.Date(2026, 3, 15, 2, 0, 0, 0, time.UTC) // 周日
+	var times []time.Time
+	// 每天两份（02:00 与 14:00），共 70 天，逆序给出以验证 Select 不依赖输入顺序。
+	for d := 0; d < 70; d++ {
+		day := base.AddDate(0, 0, -d)
+		times = append(times, day, day.Add(12*time.Hour))
+	}
+
+	kept := func(policy Retention) map[time.Time]bool {
+		out := make(map[time.Time]bool)
+		for i, keep := range policy.Select(times) {
+			if keep {
+				out[times[i]] = true
+			}
+		}
+		return out
+	}
+
+	latest := base.Add(12 * time.Hour)
+	if got := kept(Retention{}); len(got) != 1 || !got[latest] {
+		t.Fatalf("zero policy should keep only the latest, got %v", got)
+	}
+
+	got := kept(Retention{Daily: 3})
+	if len(got) != 3 {
+		t.Fatalf("Daily=3 should keep 3 snapshots, got %d", len(got))
+	}
+	for d := 0; d < 3; d++ {
+		if at := base.AddDate(0, 0, -d).Add(12 * time.Hour); !got[at] {
+			t.Fatalf("Daily=3 should keep the newest of day %d (%s)", d, at)
+		}
+	}
+
+	// 日、周、月三档取并集：3 天 + 更早的 2 个 ISO 周 + 更早的 1 个月。
+	got = kept(Retention{Daily: 3, Weekly: 3, Monthly: 3})
+	for _, at := range []time.Time{
+		latest,
+		base.AddDate(0, 0, -7).Add(12 * time.Hour),  // 上一周的周日
+		base.AddDate(0, 0, -14).Add(12 * time.Hour), // 上上周的周日
+		time.Date(2026, 2, 28, 14, 0, 0, 0, time.UTC),
+		time.Date(2026, 1, 31, 14, 0, 0, 0, time.UTC),
+	} {
+		if !got[at] {
+			t.Fatalf("union policy should keep %s", at)
+		}
+	}
+	if len(got) != 7 {
+		t.Fatalf("union policy should keep 7 snapshots, got %d: %v", len(got), got)
+	}
+}
+
+func TestSlotListLookupAndPrune(t *testing.T) {
+	dir := t.TempDir()
+	slot := NewSlot(dir, "ech0_snapshot")
+	start := time.Date(2026, 5, 1, 3, 0, 0, 0, time.UTC)
+	for d := 0; d < 5; d++ {
+		name := slot.Name(start.AddDate(0, 0, d))
+		if err := os.WriteFile(slot.Path(name), []byte("zip"), 0o644); err != nil {
+			t.Fatalf("write snapshot failed: %v", err)
+		}
+	}
+	for _, stray := range []string{"legacy.zip", ".ech0_snapshot_2026-05-09_03-00-00.zip.tmp"} {
+		if err := os.WriteFile(filepath.Join(dir, stray), []byte("x"), 0o644); err != nil {
+			t.Fatalf("write stray file failed: %v", err)
+		}
+	}
+
+	entries, err := slot.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(entries) != 5 {
+		t.Fatalf("List should only return slot artifacts, got %d", len(entries))
+	}
+	if want := slot.Name(start.AddDate(0, 0, 4)); entries[0].Name != want {
+		t.Fatalf("List should be newest first, got %s want %s", entries[0].Name, want)
+	}
+	if entries[0].Size != 3 || !entries[0].CreatedAt.Equal(start.AddDate(0, 0, 4)) {
+		t.Fatalf("unexpected entry metadata: %+v", entries[0])
+	}
+
+	for _, name := range []string{"../secret.zip", "legacy.zip", "ech0_snapshot_2026-05-30_03-00-00.zip"} {
+		if _, err := slot.Lookup(name); err != ErrNone {
+			t.Fatalf("Lookup(%q) should fail with ErrNone, got %v", name, err)
+		}
+	}
+	if _, err := slot.Lookup(entries[1].Name); err != nil {
+		t.Fatalf("Lookup of retained snapshot failed: %v", err)
+	}
+
+	removed, err := slot.Prune(Retention{Daily: 2})
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if len(removed) != 3 {
+		t.Fatalf("Prune should remove 3 snapshots, got %v", removed)
+	}
+	for _, stray := range []string{"legacy.zip", ".ech0_snapshot_2026-05-09_03-00-00.zip.tmp"} {
+		if _, err := os.Stat(filepath.Join(dir, stray)); err != nil {
+			t.Fatalf("Prune should leave %s alone: %v", stray, err)
+		}
+	}
+}
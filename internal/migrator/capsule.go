@@ -17,6 +17,7 @@ import (
 	capsuleImporter "github.com/lin-snow/ech0/internal/capsule/importer"
 	"github.com/lin-snow/ech0/internal/kvstore"
 	"github.com/lin-snow/ech0/internal/migrator/artifact"
+	"github.com/lin-snow/ech0/internal/migrator/envelope"
 	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
 	"github.com/lin-snow/ech0/internal/storage"
 	"github.com/lin-snow/ech0/internal/transaction"
@@ -48,8 +49,8 @@ func NewCapsuleEngine(
 
 // Export 把当前实例导出成一个胶囊 zip，落在胶囊槽位里。
 //
-// 产出形态与快照对齐（同一个 ExportOutcome、同样「只保留最新一份」），因此下载出口、作业
-// 状态机、前端进度卡三者都无需为胶囊分叉。
+// 产出形态与快照对齐（同一个 ExportOutcome，下载缺省取槽位里最新一份；胶囊只留这一份），因此下载出口、作业
+// 状态机、前端进度卡三者都无需为胶囊分叉。开启归档加密时胶囊 zip 打完即原地封进加密信封。
 func (e *CapsuleEngine) Export(
 	ctx context.Context,
 	includePrivate bool,
@@ -79,6 +80,18 @@ func (e *CapsuleEngine) Export(
 		return ExportOutcome{}, err
 	}
 
+	sealer, err := archiveSealer(ctx, e.durableKV)
+	if err != nil {
+		_ = os.Remove(outPath)
+		return ExportOutcome{}, err
+	}
+	if sealer != nil {
+		if err := sealer.SealInPlace(envelope.KindCapsule, outPath); err != nil {
+			_ = os.Remove(outPath)
+			return ExportOutcome{}, err
+		}
+	}
+
 	info, err := os.Stat(outPath)
 	if err != nil {
 		return ExportOutcome{}, fmt.Errorf("stat capsule artifact: %w", err)
@@ -0,0 +1,318 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package envelope 是快照与胶囊归档的加密信封。信封本身仍是一个 zip（仅存储、不压缩），
+// 里面只有两项：
+//
+//   - ech0-envelope.json：明文清单，标明这是加密归档、原归档的种类与收件人类型；
+//   - payload.age：原归档 zip 的 age v1 密文（口令走 scrypt，公钥走 X25519）。
+//
+// 选 zip 套壳而不是自定义二进制头，是为了让加密归档沿用 .zip 扩展名与既有槽位 / 下载 / 上传
+// 通道不变，且不装 ech0 也能手工解开：unzip 后 `age -d payload.age > snapshot.zip` 即得原归档。
+// 读取端靠清单识别信封（Inspect），不是信封的归档原样放行，加密前的旧归档因此无需迁移。
+package envelope
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+)
+
+const (
+	// Format 是清单格式标识，破坏性变更时递增版本。
+	Format = "ech0-envelope/v1"
+	// ManifestName 与 PayloadName 是信封 zip 内的两项固定条目。
+	ManifestName = "ech0-envelope.json"
+	PayloadName  = "payload.age"
+	// CipherAge 是唯一支持的密文格式。
+	CipherAge = "age"
+
+	// 收件人类型，写进清单供恢复端提示用户该给口令还是身份文件。
+	RecipientScrypt = "scrypt"
+	RecipientX25519 = "x25519"
+
+	// 归档种类，与 migratorModel.ExportFormat* 同值。
+	KindSnapshot = migratorModel.ExportFormatSnapshot
+	KindCapsule  = migratorModel.ExportFormatCapsule
+)
+
+var (
+	// ErrKeyRequired 表示归档已加密，但调用方没有给出任何可用的口令或身份。
+	ErrKeyRequired = errors.New("归档已加密，请提供口令或身份文件")
+	// ErrWrongKey 表示给出的口令或身份都解不开这份归档。
+	ErrWrongKey = errors.New("口令或身份文件不正确，无法解密归档")
+)
+
+// scryptWorkFactor 覆盖口令加密的 scrypt 代价（log2 N），0 即沿用 age 默认（约 1 秒）。
+// 只有测试会调低它。
+var scryptWorkFactor = 0
+
+// Manifest 是信封的明文清单。它不含任何秘密，只用来识别与提示。
+type Manifest struct {
+	Format    string `json:"format"`
+	Kind      string `json:"kind"`
+	Cipher    string `json:"cipher"`
+	Recipient string `json:"recipient"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// Sealer 按加密配置把归档封进信封。未开启加密时 NewSealer 返回 nil，调用方据此跳过封装。
+type Sealer struct {
+	recipients    []age.Recipient
+	recipientType string
+}
+
+// NewSealer 由加密配置构造 Sealer。未开启时返回 (nil, nil)；开启但缺口令 / 公钥非法时报错——
+// 宁可导出失败，也不能在管理员以为「已加密」时悄悄产出明文归档。
+func NewSealer(setting migratorModel.EncryptionSetting) (*Sealer, error) {
+	if !setting.Enable {
+		return nil, nil
+	}
+	switch setting.Mode {
+	case migratorModel.EncryptionModeRecipient:
+		recipients, err := ParseRecipients(setting.Recipients)
+		if err != nil {
+			return nil, err
+		}
+		return &Sealer{recipients: recipients, recipientType: RecipientX25519}, nil
+	default:
+		if strings.TrimSpace(setting.Passphrase) == "" {
+			return nil, errors.New("归档加密已开启，但未设置口令")
+		}
+		recipient, err := age.NewScryptRecipient(setting.Passphrase)
+		if err != nil {
+			return nil, err
+		}
+		if scryptWorkFactor > 0 {
+			recipient.SetWorkFactor(scryptWorkFactor)
+		}
+		return &Sealer{recipients: []age.Recipient{recipient}, recipientType: RecipientScrypt}, nil
+	}
+}
+
+// ParseRecipients 解析 age1… 公钥列表，忽略空行。至少要有一个。
+func ParseRecipients(lines []string) ([]age.Recipient, error) {
+	var recipients []age.Recipient
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		recipient, err := age.ParseX25519Recipient(line)
+		if err != nil {
+			return nil, fmt.Errorf("无效的 age 公钥 %q", line)
+		}
+		recipients = append(recipients, recipient)
+	}
+	if len(recipients) == 0 {
+		return nil, errors.New("归档加密已开启，但未配置任何 age 公钥")
+	}
+	return recipients, nil
+}
+
+// Seal 把 src 归档加密后写成 dst 信封。dst 写到一半失败时会被删除。
+func (s *Sealer) Seal(kind, src, dst string) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("open archive: %w", err)
+	}
+	defer func() { _ = in.Close() }()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("create envelope: %w", err)
+	}
+	defer func() {
+		if closeErr := out.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("close envelope: %w", closeErr)
+		}
+		if err != nil {
+			_ = os.Remove(dst)
+		}
+	}()
+
+	zw := zip.NewWriter(out)
+	manifest, err := json.MarshalIndent(Manifest{
+		Format:    Format,
+		Kind:      kind,
+		Cipher:    CipherAge,
+		Recipient: s.recipientType,
+		CreatedAt: time.Now().UTC().Unix(),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	mw, err := zw.CreateHeader(&zip.FileHeader{Name: ManifestName, Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	if _, err := mw.Write(manifest); err != nil {
+		return err
+	}
+
+	// 密文不可压缩，Store 省掉一趟无用的 deflate。
+	pw, err := zw.CreateHeader(&zip.FileHeader{Name: PayloadName, Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	enc, err := age.Encrypt(pw, s.recipients...)
+	if err != nil {
+		return fmt.Errorf("encrypt archive: %w", err)
+	}
+	if _, err := io.Copy(enc, in); err != nil {
+		return fmt.Errorf("encrypt archive: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("encrypt archive: %w", err)
+	}
+	return zw.Close()
+}
+
+// SealInPlace 把 path 处的归档原地替换为信封（先写同目录临时文件再 rename）。
+func (s *Sealer) SealInPlace(kind, path string) error {
+	tmp := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".sealing")
+	if err := s.Seal(kind, path, tmp); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("finalize envelope: %w", err)
+	}
+	return nil
+}
+
+// Inspect 判断 path 是否为加密信封，是则返回其清单。不是 zip 的文件也按「非信封」处理，
+// 交给后续的解包去报它自己的错。
+func Inspect(path string) (Manifest, bool, error) {
+	zr, err := zip.OpenReader(path)
+	if errors.Is(err, zip.ErrFormat) {
+		return Manifest{}, false, nil
+	}
+	if err != nil {
+		return Manifest{}, false, err
+	}
+	defer func() { _ = zr.Close() }()
+	return readManifest(&zr.Reader)
+}
+
+func readManifest(zr *zip.Reader) (Manifest, bool, error) {
+	for _, f := range zr.File {
+		if f.Name != ManifestName {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return Manifest{}, false, err
+		}
+		defer func() { _ = rc.Close() }()
+		var manifest Manifest
+		if err := json.NewDecoder(io.LimitReader(rc, 64<<10)).Decode(&manifest); err != nil {
+			return Manifest{}, false, fmt.Errorf("read envelope manifest: %w", err)
+		}
+		if manifest.Format != Format {
+			return Manifest{}, false, fmt.Errorf("不支持的加密归档格式 %q", manifest.Format)
+		}
+		return manifest, true, nil
+	}
+	return Manifest{}, false, nil
+}
+
+// Open 用 keys 解开 src 信封，把原归档写到 dst。
+func Open(src, dst string, keys Keys) (_ Manifest, err error) {
+	zr, err := zip.OpenReader(src)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("open envelope: %w", err)
+	}
+	defer func() { _ = zr.Close() }()
+
+	manifest, sealed, err := readManifest(&zr.Reader)
+	if err != nil {
+		return Manifest{}, err
+	}
+	if !sealed {
+		return Manifest{}, errors.New("归档未加密")
+	}
+	identities, err := keys.identities()
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	var payload *zip.File
+	for _, f := range zr.File {
+		if f.Name == PayloadName {
+			payload = f
+			break
+		}
+	}
+	if payload == nil {
+		return Manifest{}, fmt.Errorf("加密归档缺少 %s", PayloadName)
+	}
+	rc, err := payload.Open()
+	if err != nil {
+		return Manifest{}, err
+	}
+	defer func() { _ = rc.Close() }()
+
+	plain, err := age.Decrypt(rc, identities...)
+	if err != nil {
+		var noMatch *age.NoIdentityMatchError
+		if errors.As(err, &noMatch) {
+			return Manifest{}, ErrWrongKey
+		}
+		return Manifest{}, fmt.Errorf("decrypt archive: %w", err)
+	}
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("create decrypted archive: %w", err)
+	}
+	defer func() {
+		if closeErr := out.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("close decrypted archive: %w", closeErr)
+		}
+		if err != nil {
+			_ = os.Remove(dst)
+		}
+	}()
+	// 每个 64KiB 块都带认证标签，篡改或截断会在这里报错，不会解出半截归档。
+	if _, err := io.Copy(out, plain); err != nil {
+		return Manifest{}, fmt.Errorf("decrypt archive: %w", err)
+	}
+	return manifest, nil
+}
+
+// Unseal 是读取端的统一入口：path 不是信封时原样返回；是信封则解密到 tmpDir 下的临时文件，
+// 返回其路径与清理函数。调用方无论哪种情况都应 defer cleanup()。
+func Unseal(path, tmpDir string, keys Keys) (string, func(), error) {
+	noop := func() {}
+	if _, sealed, err := Inspect(path); err != nil {
+		return "", noop, err
+	} else if !sealed {
+		return path, noop, nil
+	}
+
+	if err := os.MkdirAll(tmpDir, 0o755); err != nil {
+		return "", noop, fmt.Errorf("create decrypt dir: %w", err)
+	}
+	f, err := os.CreateTemp(tmpDir, "unsealed-*.zip")
+	if err != nil {
+		return "", noop, fmt.Errorf("create decrypted archive: %w", err)
+	}
+	plainPath := f.Name()
+	_ = f.Close()
+
+	if _, err := Open(path, plainPath, keys); err != nil {
+		_ = os.Remove(plainPath)
+		return "", noop, err
+	}
+	return plainPath, func() { _ = os.Remove(plainPath) }, nil
+}
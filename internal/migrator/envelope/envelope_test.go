@@ -0,0 +1,201 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package envelope
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+)
+
+func init() {
+	// 测试里口令加密只求正确，不求抗暴力破解。
+	scryptWorkFactor = 10
+}
+
+func writePlainZip(t *testing.T, path string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("ech0.db")
+	if err != nil {
+		t.Fatalf("create zip entry failed: %v", err)
+	}
+	if _, err := w.Write(bytes.Repeat([]byte("db"), 70<<10)); err != nil {
+		t.Fatalf("write zip entry failed: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("close zip failed: %v", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("write zip failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestSealAndUnseal_Passphrase(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "snapshot.zip")
+	plain := writePlainZip(t, archive)
+
+	sealer, err := NewSealer(migratorModel.EncryptionSetting{Enable: true, Passphrase: "correct horse"})
+	if err != nil {
+		t.Fatalf("NewSealer failed: %v", err)
+	}
+	if err := sealer.SealInPlace(KindSnapshot, archive); err != nil {
+		t.Fatalf("SealInPlace failed: %v", err)
+	}
+
+	manifest, sealed, err := Inspect(archive)
+	if err != nil || !sealed {
+		t.Fatalf("Inspect = (%+v, %v, %v), want sealed", manifest, sealed, err)
+	}
+	if manifest.Kind != KindSnapshot || manifest.Recipient != RecipientScrypt || manifest.Cipher != CipherAge {
+		t.Fatalf("unexpected manifest: %+v", manifest)
+	}
+
+	if _, _, err := Unseal(archive, dir, Keys{}); !errors.Is(err, ErrKeyRequired) {
+		t.Fatalf("Unseal without keys error = %v, want ErrKeyRequired", err)
+	}
+	if _, _, err := Unseal(archive, dir, Keys{Passphrases: []string{"wrong"}}); !errors.Is(err, ErrWrongKey) {
+		t.Fatalf("Unseal with wrong passphrase error = %v, want ErrWrongKey", err)
+	}
+
+	// 给错的口令在前、对的在后：逐个尝试，和「请求口令 + 已存口令」的组合一致。
+	plainPath, cleanup, err := Unseal(archive, dir, Keys{Passphrases: []string{"wrong", "correct horse"}})
+	if err != nil {
+		t.Fatalf("Unseal failed: %v", err)
+	}
+	got, err := os.ReadFile(plainPath)
+	if err != nil {
+		t.Fatalf("read unsealed archive failed: %v", err)
+	}
+	if !bytes.Equal(got, plain) {
+		t.Fatal("unsealed archive should match the original bytes")
+	}
+	cleanup()
+	if _, err := os.Stat(plainPath); !os.IsNotExist(err) {
+		t.Fatalf("cleanup should remove the decrypted copy, stat err: %v", err)
+	}
+}
+
+func TestSealAndOpen_Recipients(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "capsule.zip")
+	plain := writePlainZip(t, archive)
+
+	alice, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("generate identity failed: %v", err)
+	}
+	bob, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("generate identity failed: %v", err)
+	}
+
+	sealer, err := NewSealer(migratorModel.EncryptionSetting{
+		Enable:     true,
+		Mode:       migratorModel.EncryptionModeRecipient,
+		Recipients: []string{alice.Recipient().String(), "", bob.Recipient().String()},
+	})
+	if err != nil {
+		t.Fatalf("NewSealer failed: %v", err)
+	}
+	sealed := filepath.Join(dir, "sealed.zip")
+	if err := sealer.Seal(KindCapsule, archive, sealed); err != nil {
+		t.Fatalf("Seal failed: %v", err)
+	}
+
+	identityFile := "# created: 2026-10-19\n# public key: " + bob.Recipient().String() + "\n" + bob.String() + "\n"
+	out := filepath.Join(dir, "opened.zip")
+	manifest, err := Open(sealed, out, Keys{Identity: identityFile})
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if manifest.Kind != KindCapsule || manifest.Recipient != RecipientX25519 {
+		t.Fatalf("unexpected manifest: %+v", manifest)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read opened archive failed: %v", err)
+	}
+	if !bytes.Equal(got, plain) {
+		t.Fatal("opened archive should match the original bytes")
+	}
+
+	stranger, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("generate identity failed: %v", err)
+	}
+	if _, err := Open(sealed, out, Keys{Identity: stranger.String()}); !errors.Is(err, ErrWrongKey) {
+		t.Fatalf("Open with a foreign identity error = %v, want ErrWrongKey", err)
+	}
+}
+
+func TestUnseal_PlainArchivePassesThrough(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "snapshot.zip")
+	writePlainZip(t, archive)
+
+	if _, sealed, err := Inspect(archive); err != nil || sealed {
+		t.Fatalf("Inspect plain zip = (%v, %v), want not sealed", sealed, err)
+	}
+	path, cleanup, err := Unseal(archive, dir, Keys{})
+	if err != nil {
+		t.Fatalf("Unseal plain zip failed: %v", err)
+	}
+	defer cleanup()
+	if path != archive {
+		t.Fatalf("Unseal should return the plain archive as is, got %s", path)
+	}
+
+	notZip := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(notZip, []byte("hello"), 0o644); err != nil {
+		t.Fatalf("write file failed: %v", err)
+	}
+	if _, sealed, err := Inspect(notZip); err != nil || sealed {
+		t.Fatalf("Inspect non-zip = (%v, %v), want not sealed without error", sealed, err)
+	}
+}
+
+func TestNewSealer_Validation(t *testing.T) {
+	if sealer, err := NewSealer(migratorModel.EncryptionSetting{Enable: false, Passphrase: "x"}); sealer != nil || err != nil {
+		t.Fatalf("disabled setting should yield no sealer, got (%v, %v)", sealer, err)
+	}
+	if _, err := NewSealer(migratorModel.EncryptionSetting{Enable: true}); err == nil {
+		t.Fatal("passphrase mode without a passphrase should fail")
+	}
+	if _, err := NewSealer(migratorModel.EncryptionSetting{
+		Enable:     true,
+		Mode:       migratorModel.EncryptionModeRecipient,
+		Recipients: []string{"age1notakey"},
+	}); err == nil {
+		t.Fatal("invalid recipient should fail")
+	}
+	if _, err := NewSealer(migratorModel.EncryptionSetting{Enable: true, Mode: migratorModel.EncryptionModeRecipient}); err == nil {
+		t.Fatal("recipient mode without recipients should fail")
+	}
+}
+
+func TestKeysFor(t *testing.T) {
+	keys := KeysFor(
+		migratorModel.ArchiveKeys{Passphrase: "given", Identity: "AGE-SECRET-KEY-1"},
+		migratorModel.EncryptionSetting{Passphrase: "stored"},
+	)
+	if len(keys.Passphrases) != 2 || keys.Passphrases[0] != "given" || keys.Passphrases[1] != "stored" {
+		t.Fatalf("given passphrase should be tried before the stored one, got %v", keys.Passphrases)
+	}
+	if keys.Identity != "AGE-SECRET-KEY-1" {
+		t.Fatalf("identity should be carried over, got %q", keys.Identity)
+	}
+	if keys := KeysFor(migratorModel.ArchiveKeys{}, migratorModel.EncryptionSetting{}); len(keys.Passphrases) != 0 {
+		t.Fatalf("blank passphrases should be dropped, got %v", keys.Passphrases)
+	}
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package envelope
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"filippo.io/age"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+)
+
+// Keys 是解密一份信封可用的全部凭据，按给出的顺序逐个尝试。
+type Keys struct {
+	// Passphrases 是候选口令（通常是「本次请求给的」+「服务端已存的」）。
+	Passphrases []string
+	// Identity 是 age 身份文件的内容，可含多行 AGE-SECRET-KEY-1… 与 # 注释。
+	Identity string
+}
+
+// KeysFor 汇总一次解密的候选凭据：请求里给的口令 / 身份优先，已存口令兜底——这样恢复本机
+// 产出的口令加密快照时无需再输一遍，而换了口令后的旧快照仍可由用户显式给出旧口令打开。
+func KeysFor(given migratorModel.ArchiveKeys, stored migratorModel.EncryptionSetting) Keys {
+	keys := Keys{Identity: given.Identity}
+	for _, p := range []string{given.Passphrase, stored.Passphrase} {
+		if strings.TrimSpace(p) != "" {
+			keys.Passphrases = append(keys.Passphrases, p)
+		}
+	}
+	return keys
+}
+
+func (k Keys) identities() ([]age.Identity, error) {
+	var identities []age.Identity
+	if strings.TrimSpace(k.Identity) != "" {
+		parsed, err := age.ParseIdentities(strings.NewReader(k.Identity))
+		if err != nil {
+			return nil, errors.New("无效的 age 身份文件")
+		}
+		identities = append(identities, parsed...)
+	}
+	for _, p := range k.Passphrases {
+		identity, err := age.NewScryptIdentity(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid passphrase: %w", err)
+		}
+		identities = append(identities, identity)
+	}
+	if len(identities) == 0 {
+		return nil, ErrKeyRequired
+	}
+	return identities, nil
+}
@@ -7,8 +7,14 @@ import (
 	"context"
 	"strings"
 
+	"github.com/lin-snow/ech0/internal/kvstore"
+	"github.com/lin-snow/ech0/internal/migrator/artifact"
+	"github.com/lin-snow/ech0/internal/migrator/envelope"
+	"github.com/lin-snow/ech0/internal/migrator/snapshot"
 	"github.com/lin-snow/ech0/internal/migrator/spec"
 	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
 )
 
 // ExportOutcome 是一次导出的产物描述。ArtifactPath 为本地归档路径(供同步下载流式下发,不暴露给
@@ -25,13 +31,14 @@ type ExportOutcome struct {
 
 // ExportEngine 跑导出编排:按目的地选 Exporter 适配器(配了对象存储用 s3,否则 fs)→ 运行 →
 // 返回产物。与 ImportEngine 对称,不感知作业状态机(只接受裸 report 回调)。S3 上传逻辑在 s3
-// 适配器内,故本编排体只需 storageManager 来判定目的地并构造适配器。
+// 适配器内,故本编排体只需 storageManager 来判定目的地并构造适配器;durableKV 用来现读归档加密配置。
 type ExportEngine struct {
 	storageManager StorageManager
+	durableKV      kvstore.Store
 }
 
-func NewExportEngine(storageManager StorageManager) *ExportEngine {
-	return &ExportEngine{storageManager: storageManager}
+func NewExportEngine(storageManager StorageManager, durableKV kvstore.Store) *ExportEngine {
+	return &ExportEngine{storageManager: storageManager, durableKV: durableKV}
 }
 
 // Export 选目的地适配器(fs / s3)→ 产出 Snapshot。两种目的地都会落本地产物(s3 额外上传),
@@ -51,14 +58,24 @@ func (ex *ExportEngine) Export(
 	if err != nil {
 		return ExportOutcome{}, err
 	}
+	sealer, err := archiveSealer(ctx, ex.durableKV)
+	if err != nil {
+		return ExportOutcome{}, err
+	}
 
-	result, err := exporter.Export(ctx, spec.ExportRequest{
+	req := spec.ExportRequest{
 		UpdateProgress: func(progress spec.ExportProgress) {
 			if phase := strings.TrimSpace(progress.CurrentPhase); phase != "" {
 				report(phase, nil)
 			}
 		},
-	})
+	}
+	if sealer != nil {
+		req.Seal = func(src, dst string) error {
+			return sealer.Seal(envelope.KindSnapshot, src, dst)
+		}
+	}
+	result, err := exporter.Export(ctx, req)
 	if err != nil {
 		return ExportOutcome{}, err
 	}
@@ -70,3 +87,36 @@ func (ex *ExportEngine) Export(
 		Format:       migratorModel.ExportFormatSnapshot,
 	}, nil
 }
+
+// archiveSealer 现读归档加密配置；未开启时返回 nil。没有 KV（只在测试里）视同未开启。
+// 读配置失败要让导出失败：否则一次瞬时读故障就会让本该加密的快照以明文落盘。
+func archiveSealer(ctx context.Context, durableKV kvstore.Store) (*envelope.Sealer, error) {
+	if durableKV == nil {
+		return nil, nil
+	}
+	setting, err := coreSetting.Get(ctx, durableKV, coreSetting.Encryption)
+	if err != nil {
+		return nil, err
+	}
+	return envelope.NewSealer(setting)
+}
+
+// PruneSnapshots 按留存策略清理本地快照；配了对象存储时对 S3 上的 snapshots/ 套用同一策略。
+// S3 清理是尽力而为（与上传同理），失败只记日志，不影响本地清理的结果。
+func (ex *ExportEngine) PruneSnapshots(ctx context.Context, policy artifact.Retention) ([]string, error) {
+	removed, err := snapshot.Prune(policy)
+	if err != nil {
+		return removed, err
+	}
+
+	if ex.storageManager == nil {
+		return removed, nil
+	}
+	if sel := ex.storageManager.GetSelector(); sel == nil || !sel.ObjectEnabled() {
+		return removed, nil
+	}
+	if _, err := snapshot.PruneS3(ctx, ex.storageManager.GetStorageConfig(ctx), policy); err != nil {
+		logUtil.GetLogger().Warn("Failed to prune S3 snapshots", logUtil.Err(err))
+	}
+	return removed, nil
+}
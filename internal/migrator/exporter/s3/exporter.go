@@ -34,7 +34,11 @@ func New(storageManager *storage.Manager) *Exporter {
 func (e *Exporter) Export(_ context.Context, req spec.ExportRequest) (spec.ExportResult, error) {
 	emit(req, migratorModel.ExportPhasePacking)
 	// 导出发生在运行中的实例上,必须用 VACUUM INTO 产出的一致性副本代替实时库文件。
-	path, fileName, err := snapshot.Create(snapshot.WithConsistentDB(database.SnapshotTo))
+	opts := []snapshot.CreateOption{snapshot.WithConsistentDB(database.SnapshotTo)}
+	if req.Seal != nil {
+		opts = append(opts, snapshot.WithSeal(req.Seal))
+	}
+	path, fileName, err := snapshot.Create(opts...)
 	if err != nil {
 		return spec.ExportResult{}, err
 	}
@@ -9,18 +9,27 @@ import (
 	fsExporter "github.com/lin-snow/ech0/internal/migrator/exporter/fs"
 	s3Exporter "github.com/lin-snow/ech0/internal/migrator/exporter/s3"
 	ech0Importer "github.com/lin-snow/ech0/internal/migrator/importer/ech0"
+	markdownImporter "github.com/lin-snow/ech0/internal/migrator/importer/markdown"
+	mastodonImporter "github.com/lin-snow/ech0/internal/migrator/importer/mastodon"
 	memosImporter "github.com/lin-snow/ech0/internal/migrator/importer/memos"
+	twitterImporter "github.com/lin-snow/ech0/internal/migrator/importer/twitter"
 	"github.com/lin-snow/ech0/internal/migrator/spec"
 	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
 )
 
-// BuildImporter 按来源选导入适配器(ech0 / memos),与 BuildExporter 对称。
+// BuildImporter 按来源选导入适配器(ech0 / memos / markdown / twitter / mastodon),与 BuildExporter 对称。
 func BuildImporter(source string) (spec.Importer, error) {
 	switch source {
 	case migratorModel.MigrationSourceEch0:
 		return ech0Importer.New(), nil
 	case migratorModel.MigrationSourceMemos:
 		return memosImporter.New(), nil
+	case migratorModel.MigrationSourceMarkdown:
+		return markdownImporter.New(), nil
+	case migratorModel.MigrationSourceTwitter:
+		return twitterImporter.New(), nil
+	case migratorModel.MigrationSourceMastodon:
+		return mastodonImporter.New(), nil
 	default:
 		return nil, fmt.Errorf("unsupported import source: %s", source)
 	}
@@ -0,0 +1,325 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package loader 是「笔记类」导入适配器（markdown / twitter / mastodon）共用的落库端。
+//
+// 各适配器只负责把来源格式解析成 Item，落库语义统一收在这里：
+//
+//   - Echo id 由「来源 + 来源内 id」确定性派生，重复导入同一份归档即按 id 跳过，
+//     不覆盖、不合并——与胶囊导入的幂等纪律一致。
+//   - 每条 Item 独立一个事务：单条写坏只记一条 FailedItem，不拖垮整批。
+//   - 媒体按内容寻址落到本地存储（key 取字节的 sha256），同一张图被多条引用、
+//     或重复导入，都收敛到同一行 files。
+//   - 归属一律挂站主：来源里的账号与本实例没有对应关系。
+//   - 不发事件、不调 service 层，与其它 importer 相同。
+package loader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lin-snow/ech0/internal/database"
+	"github.com/lin-snow/ech0/internal/migrator/spec"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
+	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	PhaseExtracting = "extracting"
+	PhaseLoading    = "loading"
+	PhaseReporting  = "reporting"
+	PhaseCompleted  = "completed"
+
+	// progressEvery 控制 loading 阶段回报进度的粒度，逐条回报会把作业行写成热点。
+	progressEvery = 50
+)
+
+// idNamespace 是导入 Echo id 的派生命名空间。改动它等于让所有已导入内容在下次导入时重复一遍。
+var idNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://github.com/lin-snow/Ech0/migrator/import"))
+
+// ErrNoOwner 表示目标库还没有站主，导入内容无处挂靠。
+var ErrNoOwner = errors.New("target instance has no owner account")
+
+// Item 是一条待导入的内容，由各来源适配器解析得到。
+type Item struct {
+	// SourceID 是来源内的稳定标识（笔记相对路径、tweet id、活动 id），既是去重锚点，
+	// 也是失败记录里给用户看的定位信息。
+	SourceID  string
+	Content   string
+	CreatedAt int64
+	Tags      []string
+	Private   bool
+	Media     []Media
+}
+
+// Media 是一个待落地的附件：Path 为本地磁盘上的源文件（已解压的归档内）。
+type Media struct {
+	Path string
+	Name string
+}
+
+// EchoID 返回 source/sourceID 对应的确定性 Echo id。
+func EchoID(source, sourceID string) string {
+	return uuid.NewSHA1(idNamespace, []byte(source+":"+sourceID)).String()
+}
+
+// Run 把 items 逐条落库，汇总成 ImportResult。failed 是解析阶段就已判死的条目，原样并入报告。
+func Run(
+	ctx context.Context,
+	req spec.ImportRequest,
+	source string,
+	items []Item,
+	failed []spec.FailedItem,
+) (spec.ImportResult, error) {
+	db := database.GetDB()
+	if db == nil {
+		return spec.ImportResult{}, errors.New("database is not initialized")
+	}
+	db = db.WithContext(ctx)
+
+	var owner userModel.User
+	if err := db.Where("is_owner = ?", true).First(&owner).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return spec.ImportResult{}, ErrNoOwner
+		}
+		return spec.ImportResult{}, fmt.Errorf("locate owner user: %w", err)
+	}
+
+	jobID := uuidUtil.MustNewV7()
+	total := int64(len(items) + len(failed))
+	s := &session{
+		db:          db,
+		source:      source,
+		owner:       owner,
+		tagIDByName: make(map[string]string),
+		store:       newMediaStore(),
+	}
+	progress := func(phase string, processed int64) {
+		if req.UpdateProgress == nil {
+			return
+		}
+		req.UpdateProgress(spec.ImportProgress{
+			CurrentPhase: phase,
+			Processed:    processed,
+			Total:        total,
+			SuccessCount: s.created + s.skipped,
+			FailCount:    int64(len(failed)),
+		})
+	}
+
+	logUtil.GetLogger().Info("migration import started",
+		slog.String("module", "migration"),
+		slog.String("source_type", source),
+		slog.String("job_id", jobID),
+		slog.Int64("total", total),
+	)
+	processed := int64(len(failed))
+	progress(PhaseLoading, processed)
+	for i := range items {
+		if err := ctx.Err(); err != nil {
+			return spec.ImportResult{}, err
+		}
+		if err := s.importItem(ctx, &items[i]); err != nil {
+			failed = append(failed, spec.FailedItem{SourceID: items[i].SourceID, Reason: err.Error()})
+		}
+		processed++
+		if processed%progressEvery == 0 {
+			progress(PhaseLoading, processed)
+		}
+	}
+	if err := s.recountTagUsage(); err != nil {
+		return spec.ImportResult{}, err
+	}
+
+	progress(PhaseReporting, processed)
+	success := s.created + s.skipped
+	failCount := int64(len(failed))
+	report := map[string]any{
+		"job_id":        jobID,
+		"processed":     processed,
+		"success_count": success,
+		"created_count": s.created,
+		"skipped_count": s.skipped,
+		"fail_count":    failCount,
+		"failed_items":  failed,
+	}
+	progress(PhaseCompleted, processed)
+
+	logUtil.GetLogger().Info("migration import finished",
+		slog.String("module", "migration"),
+		slog.String("source_type", source),
+		slog.String("job_id", jobID),
+		slog.Int64("created", s.created),
+		slog.Int64("skipped", s.skipped),
+		slog.Int64("fail_count", failCount),
+	)
+	return spec.ImportResult{
+		Processed:    processed,
+		Total:        total,
+		SuccessCount: success,
+		FailCount:    failCount,
+		ErrorSummary: fmt.Sprintf("导入完成: created=%d skipped=%d fail=%d", s.created, s.skipped, failCount),
+		JobID:        jobID,
+		Report:       report,
+	}, nil
+}
+
+type session struct {
+	db     *gorm.DB
+	source string
+	owner  userModel.User
+	store  *mediaStore
+
+	// tagIDByName 缓存本次接触过的标签，收尾时只重算这些标签的 usage_count。
+	tagIDByName map[string]string
+
+	created, skipped int64
+}
+
+func (s *session) importItem(ctx context.Context, item *Item) error {
+	if strings.TrimSpace(item.Content) == "" && len(item.Media) == 0 {
+		return errors.New("empty content")
+	}
+	id := EchoID(s.source, item.SourceID)
+
+	var existing int64
+	if err := s.db.Model(&echoModel.Echo{}).Where("id = ?", id).Count(&existing).Error; err != nil {
+		return fmt.Errorf("probe echo: %w", err)
+	}
+	if existing > 0 {
+		s.skipped++
+		return nil
+	}
+
+	// 字节先于事务落盘：按内容寻址，事务回滚留下的孤儿文件下次导入会被原样复用。
+	media := make([]storedMedia, 0, len(item.Media))
+	for _, m := range item.Media {
+		stored, err := s.store.put(m)
+		if err != nil {
+			return fmt.Errorf("media %s: %w", m.Name, err)
+		}
+		media = append(media, stored)
+	}
+
+	tagIDs := make(map[string]string, len(item.Tags))
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		echo := echoModel.Echo{
+			ID:       id,
+			Content:  item.Content,
+			Username: s.owner.Username,
+			Private:  item.Private,
+			UserID:   s.owner.ID,
+			// 显式赋非零值，autoCreateTime / autoUpdateTime 不会改写成导入时刻。
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.CreatedAt,
+		}
+		if err := tx.Omit(clause.Associations).Create(&echo).Error; err != nil {
+			return fmt.Errorf("create echo: %w", err)
+		}
+		for _, name := range item.Tags {
+			tagID, err := ensureTag(tx, s.tagIDByName, name)
+			if err != nil {
+				return fmt.Errorf("tag %q: %w", name, err)
+			}
+			tagIDs[name] = tagID
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&echoModel.EchoTag{EchoID: id, TagID: tagID}).Error; err != nil {
+				return fmt.Errorf("link tag %q: %w", name, err)
+			}
+		}
+		for idx, m := range media {
+			fileID, err := s.ensureFile(tx, m)
+			if err != nil {
+				return fmt.Errorf("media %s: %w", m.name, err)
+			}
+			link := fileModel.EchoFile{EchoID: id, FileID: fileID, SortOrder: idx}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Omit(clause.Associations).
+				Create(&link).Error; err != nil {
+				return fmt.Errorf("link media %s: %w", m.name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	// 事务提交后才记入缓存：回滚掉的新标签不能被后续条目当成已存在。
+	for name, tagID := range tagIDs {
+		s.tagIDByName[name] = tagID
+	}
+	s.created++
+	return nil
+}
+
+func ensureTag(tx *gorm.DB, cache map[string]string, name string) (string, error) {
+	if id, ok := cache[name]; ok {
+		return id, nil
+	}
+	var tag echoModel.Tag
+	err := tx.Where("name = ?", name).First(&tag).Error
+	switch {
+	case err == nil:
+		return tag.ID, nil
+	case errors.Is(err, gorm.ErrRecordNotFound):
+	default:
+		return "", err
+	}
+	tag = echoModel.Tag{ID: uuidUtil.MustNewV7(), Name: name}
+	if err := tx.Create(&tag).Error; err != nil {
+		return "", err
+	}
+	return tag.ID, nil
+}
+
+func (s *session) ensureFile(tx *gorm.DB, m storedMedia) (string, error) {
+	var row fileModel.File
+	err := tx.Where("storage_type = ? AND provider = ? AND bucket = ? AND key = ?",
+		localStorageType, "", "", m.key).First(&row).Error
+	switch {
+	case err == nil:
+		return row.ID, nil
+	case errors.Is(err, gorm.ErrRecordNotFound):
+	default:
+		return "", err
+	}
+	row = fileModel.File{
+		Key:         m.key,
+		StorageType: localStorageType,
+		Name:        m.name,
+		ContentType: m.contentType,
+		Size:        m.size,
+		Category:    string(m.category),
+		UserID:      s.owner.ID,
+	}
+	if err := tx.Create(&row).Error; err != nil {
+		return "", err
+	}
+	return row.ID, nil
+}
+
+// recountTagUsage 只重算本次接触过的标签，与胶囊导入同一口径。
+func (s *session) recountTagUsage() error {
+	if len(s.tagIDByName) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(s.tagIDByName))
+	for _, id := range s.tagIDByName {
+		ids = append(ids, id)
+	}
+	if err := s.db.Exec(
+		"UPDATE tags SET usage_count = (SELECT COUNT(*) FROM echo_tags WHERE echo_tags.tag_id = tags.id) WHERE id IN ?",
+		ids,
+	).Error; err != nil {
+		return fmt.Errorf("recount tag usage: %w", err)
+	}
+	return nil
+}
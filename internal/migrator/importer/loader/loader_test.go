@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package loader
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/database"
+	"github.com/lin-snow/ech0/internal/migrator/spec"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupDB(t *testing.T, withOwner bool) *gorm.DB {
+	t.Helper()
+	root := t.TempDir()
+	oldWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd failed: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldWD) })
+	if err := os.Chdir(root); err != nil {
+		t.Fatalf("chdir failed: %v", err)
+	}
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(root, "target.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open db failed: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&userModel.User{},
+		&echoModel.Echo{},
+		&echoModel.Tag{},
+		&echoModel.EchoTag{},
+		&fileModel.File{},
+		&fileModel.EchoFile{},
+	); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	if withOwner {
+		owner := userModel.User{ID: "00000000-0000-0000-0000-000000000001", Username: "owner", IsOwner: true, IsAdmin: true}
+		if err := db.Create(&owner).Error; err != nil {
+			t.Fatalf("seed owner failed: %v", err)
+		}
+	}
+	database.SetDB(db)
+	return db
+}
+
+func TestRunImportsAndDedupes(t *testing.T) {
+	db := setupDB(t, true)
+	if err := os.WriteFile("photo.png", []byte("PNG"), 0o644); err != nil {
+		t.Fatalf("write media failed: %v", err)
+	}
+
+	items := []Item{
+		{SourceID: "a", Content: "first", CreatedAt: 1700000000, Tags: []string{"go", "notes"},
+			Media: []Media{{Path: "photo.png", Name: "photo.png"}}},
+		{SourceID: "b", Content: "second", CreatedAt: 1700000100, Tags: []string{"go"}, Private: true,
+			Media: []Media{{Path: "photo.png", Name: "again.png"}}},
+		{SourceID: "c"},
+	}
+	parseFailed := []spec.FailedItem{{SourceID: "d", Reason: "bad frontmatter"}}
+
+	var phases []string
+	req := spec.ImportRequest{UpdateProgress: func(p spec.ImportProgress) { phases = append(phases, p.CurrentPhase) }}
+	res, err := Run(context.Background(), req, "markdown", items, parseFailed)
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if res.SuccessCount != 2 || res.FailCount != 2 || res.Total != 4 {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	failed, _ := res.Report["failed_items"].([]spec.FailedItem)
+	if len(failed) != 2 || failed[1].SourceID != "c" {
+		t.Fatalf("unexpected failed items: %+v", failed)
+	}
+	if phases[len(phases)-1] != PhaseCompleted {
+		t.Fatalf("last phase = %q", phases[len(phases)-1])
+	}
+
+	var echo echoModel.Echo
+	if err := db.First(&echo, "id = ?", EchoID("markdown", "a")).Error; err != nil {
+		t.Fatalf("echo a missing: %v", err)
+	}
+	if echo.CreatedAt != 1700000000 || echo.Username != "owner" {
+		t.Fatalf("unexpected echo: %+v", echo)
+	}
+	assertCount(t, db, &fileModel.File{}, 1)
+	assertCount(t, db, &fileModel.EchoFile{}, 2)
+	var tag echoModel.Tag
+	if err := db.First(&tag, "name = ?", "go").Error; err != nil || tag.UsageCount != 2 {
+		t.Fatalf("tag go usage = %d, err %v", tag.UsageCount, err)
+	}
+
+	again, err := Run(context.Background(), spec.ImportRequest{}, "markdown", items[:2], nil)
+	if err != nil {
+		t.Fatalf("rerun failed: %v", err)
+	}
+	if again.Report["created_count"] != int64(0) || again.Report["skipped_count"] != int64(2) {
+		t.Fatalf("rerun should skip everything: %+v", again.Report)
+	}
+	assertCount(t, db, &echoModel.Echo{}, 2)
+	assertCount(t, db, &fileModel.File{}, 1)
+}
+
+func TestRunRequiresOwner(t *testing.T) {
+	setupDB(t, false)
+	_, err := Run(context.Background(), spec.ImportRequest{}, "markdown", nil, nil)
+	if !errors.Is(err, ErrNoOwner) {
+		t.Fatalf("expected ErrNoOwner, got %v", err)
+	}
+}
+
+func TestWithinRejectsEscapes(t *testing.T) {
+	root := filepath.Join("data", "files", "tmp", "x")
+	if _, ok := Within(root, root, "../../etc/passwd"); ok {
+		t.Fatal("parent traversal must be rejected")
+	}
+	if _, ok := Within(root, root, "/etc/passwd"); ok {
+		t.Fatal("absolute path must be rejected")
+	}
+	if p, ok := Within(root, filepath.Join(root, "notes"), "../img/a.png"); !ok || p != filepath.Join(root, "img", "a.png") {
+		t.Fatalf("sibling path inside root should resolve, got %q %v", p, ok)
+	}
+}
+
+func assertCount(t *testing.T, db *gorm.DB, model any, want int64) {
+	t.Helper()
+	var got int64
+	if err := db.Model(model).Count(&got).Error; err != nil {
+		t.Fatalf("count failed: %v", err)
+	}
+	if got != want {
+		t.Fatalf("count %T = %d, want %d", model, got, want)
+	}
+}
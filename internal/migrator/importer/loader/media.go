@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package loader
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lin-snow/ech0/internal/storage"
+	"github.com/lin-snow/ech0/pkg/virefs"
+)
+
+const (
+	localStorageType   = string(storage.StorageTypeLocal)
+	defaultContentType = "application/octet-stream"
+	// keyPrefix 标出导入来的媒体，便于在存储里一眼分辨。
+	keyPrefix = "import_"
+)
+
+// mediaStore 把附件按内容寻址写进 data/files。与 ech0 importer 一样直接落本地磁盘，
+// 不经 selector：导入适配器没有 DI 依赖，且本地行的 URL 由 File.AfterFind 按当前配置重算。
+type mediaStore struct {
+	root   string
+	schema *virefs.Schema
+}
+
+type storedMedia struct {
+	key         string
+	name        string
+	contentType string
+	category    storage.Category
+	size        int64
+}
+
+func newMediaStore() *mediaStore {
+	return &mediaStore{root: filepath.Join("data", "files"), schema: storage.NewFileSchema()}
+}
+
+// put 把 m 拷进存储，key 为 import_<sha256 前 32 位><扩展名>。目标已存在即不重写。
+func (ms *mediaStore) put(m Media) (storedMedia, error) {
+	src, err := os.Open(m.Path)
+	if err != nil {
+		return storedMedia{}, err
+	}
+	defer func() { _ = src.Close() }()
+	info, err := src.Stat()
+	if err != nil {
+		return storedMedia{}, err
+	}
+	if info.IsDir() {
+		return storedMedia{}, errors.New("is a directory")
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, src); err != nil {
+		return storedMedia{}, err
+	}
+	name := m.Name
+	if name == "" {
+		name = filepath.Base(m.Path)
+	}
+	ext := strings.ToLower(filepath.Ext(name))
+	key := keyPrefix + hex.EncodeToString(h.Sum(nil))[:32] + ext
+
+	dest := filepath.Join(ms.root, filepath.FromSlash(ms.schema.Resolve(key)))
+	if _, err := os.Stat(dest); errors.Is(err, os.ErrNotExist) {
+		if _, err := src.Seek(0, io.SeekStart); err != nil {
+			return storedMedia{}, err
+		}
+		if err := writeFile(dest, src); err != nil {
+			return storedMedia{}, fmt.Errorf("store media: %w", err)
+		}
+	} else if err != nil {
+		return storedMedia{}, err
+	}
+
+	contentType := mime.TypeByExtension(ext)
+	if contentType == "" {
+		contentType = defaultContentType
+	}
+	return storedMedia{
+		key:         key,
+		name:        name,
+		contentType: contentType,
+		category:    CategoryForExt(ext),
+		size:        info.Size(),
+	}, nil
+}
+
+// writeFile 先写临时文件再改名，中途失败不会留下半截的目标文件被后续导入当成已存在。
+func writeFile(dest string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dest), ".import-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := io.Copy(tmp, r); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dest)
+}
+
+// CategoryForExt 按扩展名推导文件分类，路由表与 storage.NewFileSchema 同源。
+func CategoryForExt(ext string) storage.Category {
+	switch strings.ToLower(ext) {
+	case ".jpg", ".jpeg", ".png", ".gif", ".webp", ".svg", ".avif", ".bmp":
+		return storage.CategoryImage
+	case ".mp3", ".flac", ".wav", ".m4a", ".ogg":
+		return storage.CategoryAudio
+	case ".mp4", ".avi", ".mkv", ".webm", ".mov":
+		return storage.CategoryVideo
+	case ".pdf":
+		return storage.CategoryPDF
+	case ".md", ".markdown":
+		return storage.CategoryMarkdown
+	default:
+		return storage.CategoryFile
+	}
+}
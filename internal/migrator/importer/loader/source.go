@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package loader
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ResolveRoot 把 source_payload.tmp_dir 解析成已解压归档的根目录（data/<tmp_dir>）。
+func ResolveRoot(payload map[string]any) (string, error) {
+	tmpDir, ok := payload["tmp_dir"].(string)
+	if !ok || strings.TrimSpace(tmpDir) == "" {
+		return "", errors.New("source_payload.tmp_dir is required")
+	}
+	root := filepath.Join("data", filepath.FromSlash(strings.TrimSpace(tmpDir)))
+	info, err := os.Stat(root)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return "", errors.New("source_payload.tmp_dir is not a directory")
+	}
+	return root, nil
+}
+
+// Within 把归档内引用的相对路径 rel 拼到 base 下；越出 root 的引用（../、绝对路径）返回 false。
+// 归档内容不可信，附件路径一律经它解析。
+func Within(root, base, rel string) (string, bool) {
+	rel = filepath.FromSlash(strings.TrimSpace(rel))
+	if rel == "" || filepath.IsAbs(rel) {
+		return "", false
+	}
+	full := filepath.Clean(filepath.Join(base, rel))
+	cleanRoot := filepath.Clean(root)
+	if full != cleanRoot && !strings.HasPrefix(full, cleanRoot+string(os.PathSeparator)) {
+		return "", false
+	}
+	return full, true
+}
+
+// IsFile 报告 p 是否为存在的普通文件。
+func IsFile(p string) bool {
+	info, err := os.Stat(p)
+	return err == nil && info.Mode().IsRegular()
+}
@@ -0,0 +1,324 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package markdown 是 Markdown 文件夹 / Obsidian 库 → Ech0 的导入适配器。
+//
+// 每个 .md 文件一条 Echo：YAML frontmatter 提供时间、标签与可见性，正文原样作为内容；
+// 正文里引用到的本地附件（Obsidian 的 ![[x.png]] 与标准的 ![](x.png)）挂为 Echo 的文件，
+// 引用本身从正文里去掉——它们在 Ech0 里没有可解析的目标。
+package markdown
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/migrator/importer/loader"
+	"github.com/lin-snow/ech0/internal/migrator/spec"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+	"gopkg.in/yaml.v3"
+)
+
+type Importer struct{}
+
+func New() *Importer {
+	return &Importer{}
+}
+
+func (e *Importer) Import(ctx context.Context, req spec.ImportRequest) (spec.ImportResult, error) {
+	root, err := loader.ResolveRoot(req.SourcePayload)
+	if err != nil {
+		return spec.ImportResult{}, err
+	}
+	if req.UpdateProgress != nil {
+		req.UpdateProgress(spec.ImportProgress{CurrentPhase: loader.PhaseExtracting})
+	}
+	items, failed, err := Parse(root)
+	if err != nil {
+		return spec.ImportResult{}, err
+	}
+	return loader.Run(ctx, req, migratorModel.MigrationSourceMarkdown, items, failed)
+}
+
+// Parse 遍历 root 下的全部笔记。解析失败的笔记进 failed，不中断其余笔记。
+func Parse(root string) ([]loader.Item, []spec.FailedItem, error) {
+	var notes []string
+	index := make(map[string][]string)
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if p != root && (strings.HasPrefix(name, ".") || name == "__MACOSX") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(name, ".") || !d.Type().IsRegular() {
+			return nil
+		}
+		if isNote(name) {
+			notes = append(notes, p)
+			return nil
+		}
+		index[strings.ToLower(name)] = append(index[strings.ToLower(name)], p)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("walk markdown folder: %w", err)
+	}
+
+	vault := vaultRoot(root)
+	items := make([]loader.Item, 0, len(notes))
+	var failed []spec.FailedItem
+	for _, p := range notes {
+		rel, _ := filepath.Rel(root, p)
+		rel = filepath.ToSlash(rel)
+		item, err := parseNote(root, vault, p, index)
+		if err != nil {
+			failed = append(failed, spec.FailedItem{SourceID: rel, Reason: err.Error()})
+			continue
+		}
+		item.SourceID = rel
+		items = append(items, item)
+	}
+	return items, failed, nil
+}
+
+func isNote(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".md" || ext == ".markdown"
+}
+
+// vaultRoot 定位 Obsidian 库根（含 .obsidian/ 的目录）。整库打包时通常多套一层目录，
+// 「相对库根」的附件路径要从这里起算；找不到就以解压根为准。
+func vaultRoot(root string) string {
+	if info, err := os.Stat(filepath.Join(root, ".obsidian")); err == nil && info.IsDir() {
+		return root
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return root
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		if info, err := os.Stat(filepath.Join(root, e.Name(), ".obsidian")); err == nil && info.IsDir() {
+			return filepath.Join(root, e.Name())
+		}
+	}
+	return root
+}
+
+// frontmatter 只取导入关心的字段，其余键忽略。
+type frontmatter struct {
+	Title      string `yaml:"title"`
+	Date       any    `yaml:"date"`
+	Created    any    `yaml:"created"`
+	CreatedAt  any    `yaml:"created_at"`
+	Published  any    `yaml:"published"`
+	Tags       any    `yaml:"tags"`
+	Tag        any    `yaml:"tag"`
+	Private    bool   `yaml:"private"`
+	Visibility string `yaml:"visibility"`
+}
+
+func parseNote(root, vault, p string, index map[string][]string) (loader.Item, error) {
+	raw, err := os.ReadFile(p)
+	if err != nil {
+		return loader.Item{}, err
+	}
+	meta, body, err := splitFrontmatter(raw)
+	if err != nil {
+		return loader.Item{}, err
+	}
+
+	createdAt, err := noteTime(meta, p)
+	if err != nil {
+		return loader.Item{}, err
+	}
+	tags := parseTags(meta.Tags)
+	tags = append(tags, parseTags(meta.Tag)...)
+
+	content, media := extractAttachments(body, root, vault, filepath.Dir(p), index)
+	if meta.Title != "" && !strings.HasPrefix(content, "#") {
+		content = "# " + meta.Title + "\n\n" + content
+	}
+	return loader.Item{
+		Content:   content,
+		CreatedAt: createdAt,
+		Tags:      dedupe(tags),
+		Private:   meta.Private || strings.EqualFold(meta.Visibility, "private"),
+		Media:     media,
+	}, nil
+}
+
+// splitFrontmatter 剥出首部 --- 包裹的 YAML。没有 frontmatter 的笔记整篇都是正文。
+func splitFrontmatter(raw []byte) (frontmatter, string, error) {
+	var meta frontmatter
+	text := strings.TrimPrefix(string(bytes.ReplaceAll(raw, []byte("\r\n"), []byte("\n"))), "\ufeff")
+	if !strings.HasPrefix(text, "---\n") {
+		return meta, strings.TrimSpace(text), nil
+	}
+	rest := text[len("---\n"):]
+	end := strings.Index(rest, "\n---")
+	if end < 0 {
+		return meta, strings.TrimSpace(text), nil
+	}
+	if err := yaml.Unmarshal([]byte(rest[:end]), &meta); err != nil {
+		return meta, "", fmt.Errorf("frontmatter: %w", err)
+	}
+	body := rest[end+len("\n---"):]
+	if i := strings.IndexByte(body, '\n'); i >= 0 {
+		body = body[i+1:]
+	} else {
+		body = ""
+	}
+	return meta, strings.TrimSpace(body), nil
+}
+
+var (
+	dateLayouts = []string{
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04:05",
+		"2006-01-02T15:04",
+		"2006-01-02 15:04",
+		"2006-01-02",
+	}
+	// dailyNoteName 匹配日记笔记的文件名（2024-01-02.md），frontmatter 没写时间时用它。
+	dailyNoteName = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})`)
+)
+
+// noteTime 依次取 frontmatter 时间、日记文件名、文件修改时间。
+func noteTime(meta frontmatter, p string) (int64, error) {
+	for _, v := range []any{meta.Date, meta.Created, meta.CreatedAt, meta.Published} {
+		if v == nil {
+			continue
+		}
+		if t, ok := v.(time.Time); ok {
+			return t.Unix(), nil
+		}
+		s := strings.TrimSpace(fmt.Sprint(v))
+		for _, layout := range dateLayouts {
+			if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+				return t.Unix(), nil
+			}
+		}
+		return 0, fmt.Errorf("unrecognized date %q", s)
+	}
+	if m := dailyNoteName.FindStringSubmatch(filepath.Base(p)); m != nil {
+		if t, err := time.ParseInLocation("2006-01-02", m[1], time.Local); err == nil {
+			return t.Unix(), nil
+		}
+	}
+	info, err := os.Stat(p)
+	if err != nil {
+		return 0, err
+	}
+	return info.ModTime().Unix(), nil
+}
+
+// parseTags 兼容列表与「逗号/空白分隔的字符串」两种写法，并去掉 Obsidian 习惯的 # 前缀。
+func parseTags(v any) []string {
+	var raw []string
+	switch t := v.(type) {
+	case nil:
+		return nil
+	case string:
+		raw = strings.FieldsFunc(t, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+	case []any:
+		for _, x := range t {
+			raw = append(raw, fmt.Sprint(x))
+		}
+	default:
+		raw = []string{fmt.Sprint(t)}
+	}
+	out := make([]string, 0, len(raw))
+	for _, s := range raw {
+		s = strings.TrimPrefix(strings.TrimSpace(s), "#")
+		if s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func dedupe(in []string) []string {
+	seen := make(map[string]struct{}, len(in))
+	out := in[:0]
+	for _, s := range in {
+		if _, ok := seen[s]; ok {
+			continue
+		}
+		seen[s] = struct{}{}
+		out = append(out, s)
+	}
+	return out
+}
+
+var (
+	// wikiEmbed 匹配 ![[file.png]] 与 ![[file.png|300]]。
+	wikiEmbed = regexp.MustCompile(`!\[\[([^\]|#]+)(?:[|#][^\]]*)?\]\]`)
+	// mdEmbed 匹配 ![alt](path) 与 ![alt](<path with spaces>)。
+	mdEmbed = regexp.MustCompile(`!\[[^\]]*\]\(\s*(<[^>]+>|[^)\s]+)(?:\s+"[^"]*")?\s*\)`)
+)
+
+// extractAttachments 把能在归档里找到的本地附件引用摘出来。外链与找不到的引用原样留在正文。
+func extractAttachments(body, root, vault, dir string, index map[string][]string) (string, []loader.Media) {
+	var media []loader.Media
+	seen := make(map[string]struct{})
+	replace := func(match string, target string) string {
+		p, ok := resolveAttachment(root, vault, dir, target, index)
+		if !ok {
+			return match
+		}
+		if _, dup := seen[p]; !dup {
+			seen[p] = struct{}{}
+			media = append(media, loader.Media{Path: p, Name: filepath.Base(p)})
+		}
+		return ""
+	}
+	body = wikiEmbed.ReplaceAllStringFunc(body, func(m string) string {
+		return replace(m, wikiEmbed.FindStringSubmatch(m)[1])
+	})
+	body = mdEmbed.ReplaceAllStringFunc(body, func(m string) string {
+		target := strings.Trim(mdEmbed.FindStringSubmatch(m)[1], "<>")
+		if strings.Contains(target, "://") || strings.HasPrefix(target, "data:") {
+			return m
+		}
+		return replace(m, target)
+	})
+	return strings.TrimSpace(collapseBlankLines(body)), media
+}
+
+// resolveAttachment 依 Obsidian 的查找顺序定位附件：相对笔记 → 相对库根 → 全库同名文件。
+func resolveAttachment(root, vault, dir, target string, index map[string][]string) (string, bool) {
+	target = strings.TrimSpace(strings.ReplaceAll(target, "%20", " "))
+	if target == "" || isNote(target) || filepath.Ext(target) == "" {
+		return "", false
+	}
+	for _, base := range []string{dir, vault} {
+		if p, ok := loader.Within(root, base, target); ok && loader.IsFile(p) {
+			return p, true
+		}
+	}
+	if found := index[strings.ToLower(filepath.Base(filepath.FromSlash(target)))]; len(found) > 0 {
+		return found[0], true
+	}
+	return "", false
+}
+
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+func collapseBlankLines(s string) string {
+	return blankLines.ReplaceAllString(s, "\n\n")
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package markdown
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir failed: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+}
+
+func TestParseVault(t *testing.T) {
+	root := t.TempDir()
+	vault := filepath.Join(root, "MyVault")
+	writeFile(t, filepath.Join(vault, ".obsidian", "app.json"), "{}")
+	writeFile(t, filepath.Join(vault, "attachments", "cat.png"), "PNG")
+	writeFile(t, filepath.Join(vault, "assets", "dog.jpg"), "JPG")
+	writeFile(t, filepath.Join(vault, "journal", "note.md"), `---
+date: 2024-03-05 08:30
+tags: [life, "#cats", life]
+private: true
+---
+Morning walk.
+
+![[cat.png|300]]
+
+![dog](assets/dog.jpg)
+
+![remote](https://example.com/x.png)
+![[missing.png]]
+`)
+	writeFile(t, filepath.Join(vault, "2024-01-02.md"), "daily entry")
+	writeFile(t, filepath.Join(vault, "broken.md"), "---\ntags: [unclosed\n---\nbody")
+
+	items, failed, err := Parse(root)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("items = %d, want 2", len(items))
+	}
+	if len(failed) != 1 || failed[0].SourceID != "MyVault/broken.md" {
+		t.Fatalf("unexpected failed: %+v", failed)
+	}
+
+	byID := map[string]int{}
+	for i, it := range items {
+		byID[it.SourceID] = i
+	}
+	note := items[byID["MyVault/journal/note.md"]]
+	want := time.Date(2024, 3, 5, 8, 30, 0, 0, time.Local).Unix()
+	if note.CreatedAt != want {
+		t.Fatalf("created_at = %d, want %d", note.CreatedAt, want)
+	}
+	if !note.Private {
+		t.Fatal("note should be private")
+	}
+	if len(note.Tags) != 2 || note.Tags[0] != "life" || note.Tags[1] != "cats" {
+		t.Fatalf("tags = %v", note.Tags)
+	}
+	if len(note.Media) != 2 || note.Media[0].Name != "cat.png" || note.Media[1].Name != "dog.jpg" {
+		t.Fatalf("media = %+v", note.Media)
+	}
+	wantContent := "Morning walk.\n\n![remote](https://example.com/x.png)\n![[missing.png]]"
+	if note.Content != wantContent {
+		t.Fatalf("content = %q", note.Content)
+	}
+
+	daily := items[byID["MyVault/2024-01-02.md"]]
+	if daily.CreatedAt != time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local).Unix() {
+		t.Fatalf("daily note should take its date from the file name, got %d", daily.CreatedAt)
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	got := parseTags("a, #b c")
+	if len(got) != 3 || got[0] != "a" || got[1] != "b" || got[2] != "c" {
+		t.Fatalf("parseTags = %v", got)
+	}
+}
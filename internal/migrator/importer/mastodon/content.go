@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package mastodon
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+// htmlToText 把嘟文的 HTML 正文还原成纯文本：段落与换行保留，@提及与 #话题取其文字，
+// 普通链接取 href——Mastodon 把长链接的显示文字切成了带省略号的若干 span，用不得。
+func htmlToText(src string) (string, error) {
+	root, err := html.Parse(strings.NewReader(src))
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			b.WriteString(n.Data)
+			return
+		case html.ElementNode:
+			switch n.Data {
+			case "br":
+				b.WriteString("\n")
+				return
+			case "a":
+				if href := attr(n, "href"); href != "" && !isMentionOrTag(n) {
+					b.WriteString(href)
+					return
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if n.Type == html.ElementNode && n.Data == "p" {
+			b.WriteString("\n\n")
+		}
+	}
+	walk(root)
+	return strings.TrimSpace(blankLines.ReplaceAllString(b.String(), "\n\n")), nil
+}
+
+func isMentionOrTag(n *html.Node) bool {
+	for _, class := range strings.Fields(attr(n, "class")) {
+		if class == "mention" || class == "hashtag" || class == "u-url" {
+			return true
+		}
+	}
+	return attr(n, "rel") == "tag"
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
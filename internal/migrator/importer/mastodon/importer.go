@@ -0,0 +1,234 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package mastodon 是 Mastodon 账号归档 → Ech0 的导入适配器。
+//
+// 归档是一个 tar.gz：outbox.json（ActivityStreams 的 OrderedCollection）加 media_attachments/。
+// 上传时既可以直接传这个 tar.gz，也可以把 outbox.json 与媒体包一起打进 zip——暂存目录里
+// 的 tar 包在解析前先就地解开。只导入本人的 Create/Note；转嘟（Announce）不是本人内容，
+// 略过。非公开（仅关注者 / 私信）嘟文导入为私密 Echo。
+package mastodon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/migrator/importer/loader"
+	"github.com/lin-snow/ech0/internal/migrator/spec"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+)
+
+const publicAudience = "https://www.w3.org/ns/activitystreams#Public"
+
+type Importer struct{}
+
+func New() *Importer {
+	return &Importer{}
+}
+
+func (e *Importer) Import(ctx context.Context, req spec.ImportRequest) (spec.ImportResult, error) {
+	root, err := loader.ResolveRoot(req.SourcePayload)
+	if err != nil {
+		return spec.ImportResult{}, err
+	}
+	if req.UpdateProgress != nil {
+		req.UpdateProgress(spec.ImportProgress{CurrentPhase: loader.PhaseExtracting})
+	}
+	items, failed, err := Parse(root)
+	if err != nil {
+		return spec.ImportResult{}, err
+	}
+	return loader.Run(ctx, req, migratorModel.MigrationSourceMastodon, items, failed)
+}
+
+type outbox struct {
+	OrderedItems []activity `json:"orderedItems"`
+}
+
+type activity struct {
+	ID     string          `json:"id"`
+	Type   string          `json:"type"`
+	To     audience        `json:"to"`
+	Cc     audience        `json:"cc"`
+	Object json.RawMessage `json:"object"`
+}
+
+type note struct {
+	ID         string       `json:"id"`
+	Type       string       `json:"type"`
+	Summary    string       `json:"summary"`
+	Published  string       `json:"published"`
+	Content    string       `json:"content"`
+	To         audience     `json:"to"`
+	Cc         audience     `json:"cc"`
+	Attachment []attachment `json:"attachment"`
+	Tag        []struct {
+		Type string `json:"type"`
+		Name string `json:"name"`
+	} `json:"tag"`
+}
+
+type attachment struct {
+	MediaType string `json:"mediaType"`
+	URL       string `json:"url"`
+}
+
+// audience 兼容 to/cc 写成单个字符串或字符串数组两种形态。
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*a = audience{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+func (a audience) public() bool {
+	for _, s := range a {
+		if s == publicAudience || s == "as:Public" || s == "Public" {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse 解开暂存目录里的 tar 包后读出 outbox.json。
+func Parse(root string) ([]loader.Item, []spec.FailedItem, error) {
+	bases, err := unpackTarballs(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	outboxPath, err := locateOutbox(root, bases)
+	if err != nil {
+		return nil, nil, err
+	}
+	raw, err := os.ReadFile(outboxPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	var box outbox
+	if err := json.Unmarshal(raw, &box); err != nil {
+		return nil, nil, fmt.Errorf("outbox.json: %w", err)
+	}
+	// 附件 url 是相对实例根的路径（/media_attachments/files/...），媒体可能与 outbox.json 同处，
+	// 也可能在单独上传的媒体包里，都要找一遍。
+	bases = append([]string{filepath.Dir(outboxPath)}, bases...)
+
+	var items []loader.Item
+	var failed []spec.FailedItem
+	for _, act := range box.OrderedItems {
+		if act.Type != "Create" {
+			continue
+		}
+		var n note
+		if err := json.Unmarshal(act.Object, &n); err != nil {
+			failed = append(failed, spec.FailedItem{SourceID: act.ID, Reason: "object: " + err.Error()})
+			continue
+		}
+		if n.Type != "Note" || n.ID == "" {
+			continue
+		}
+		item, err := toItem(root, bases, act, n)
+		if err != nil {
+			failed = append(failed, spec.FailedItem{SourceID: n.ID, Reason: err.Error()})
+			continue
+		}
+		items = append(items, item)
+	}
+	return items, failed, nil
+}
+
+func locateOutbox(root string, bases []string) (string, error) {
+	candidates := []string{root}
+	if entries, err := os.ReadDir(root); err == nil {
+		for _, e := range entries {
+			if e.IsDir() {
+				candidates = append(candidates, filepath.Join(root, e.Name()))
+			}
+		}
+	}
+	candidates = append(candidates, bases...)
+	for _, dir := range candidates {
+		if p := filepath.Join(dir, "outbox.json"); loader.IsFile(p) {
+			return p, nil
+		}
+	}
+	return "", errors.New("outbox.json not found in archive")
+}
+
+func toItem(root string, bases []string, act activity, n note) (loader.Item, error) {
+	published, err := time.Parse(time.RFC3339, n.Published)
+	if err != nil {
+		return loader.Item{}, fmt.Errorf("published: %w", err)
+	}
+
+	content, err := htmlToText(n.Content)
+	if err != nil {
+		return loader.Item{}, fmt.Errorf("content: %w", err)
+	}
+	// 内容警告（CW）在 Ech0 里没有对应字段，作为首行保留，免得被折叠的内容裸露出来却毫无提示。
+	if summary := strings.TrimSpace(n.Summary); summary != "" {
+		content = "CW: " + summary + "\n\n" + content
+	}
+
+	media := make([]loader.Media, 0, len(n.Attachment))
+	for _, a := range n.Attachment {
+		p, err := resolveMedia(root, bases, a.URL)
+		if err != nil {
+			return loader.Item{}, err
+		}
+		media = append(media, loader.Media{Path: p, Name: filepath.Base(p)})
+	}
+
+	tags := make([]string, 0, len(n.Tag))
+	for _, t := range n.Tag {
+		if t.Type == "Hashtag" {
+			if name := strings.TrimPrefix(strings.TrimSpace(t.Name), "#"); name != "" {
+				tags = append(tags, name)
+			}
+		}
+	}
+
+	public := n.To.public() || n.Cc.public() || act.To.public() || act.Cc.public()
+	return loader.Item{
+		SourceID:  n.ID,
+		Content:   content,
+		CreatedAt: published.Unix(),
+		Tags:      tags,
+		Private:   !public,
+		Media:     media,
+	}, nil
+}
+
+// resolveMedia 在各候选根下找附件。完整 URL 只取其路径部分：部分实例导出的是绝对地址。
+func resolveMedia(root string, bases []string, raw string) (string, error) {
+	rel := raw
+	if u, err := url.Parse(raw); err == nil && u.Host != "" {
+		rel = u.Path
+	}
+	rel = strings.TrimPrefix(path.Clean("/"+rel), "/")
+	if rel == "" {
+		return "", errors.New("attachment without url")
+	}
+	for _, base := range bases {
+		if p, ok := loader.Within(root, base, rel); ok && loader.IsFile(p) {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("media %s not found in archive", rel)
+}
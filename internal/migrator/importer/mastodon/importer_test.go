@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package mastodon
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const outboxJSON = `{
+  "type": "OrderedCollection",
+  "orderedItems": [
+    {
+      "id": "https://m.example/users/me/statuses/1/activity",
+      "type": "Create",
+      "to": ["https://www.w3.org/ns/activitystreams#Public"],
+      "object": {
+        "id": "https://m.example/users/me/statuses/1",
+        "type": "Note",
+        "published": "2023-05-01T10:00:00Z",
+        "content": "<p>Hello <a href=\"https://m.example/tags/cats\" class=\"mention hashtag\" rel=\"tag\">#<span>cats</span></a></p><p>see <a href=\"https://example.com/a/long/path\"><span class=\"invisible\">https://</span><span class=\"ellipsis\">example.com/a/lo</span></a><br>bye &amp; thanks</p>",
+        "to": ["https://www.w3.org/ns/activitystreams#Public"],
+        "attachment": [{"type": "Document", "mediaType": "image/png", "url": "/media_attachments/files/000/001/original/cat.png"}],
+        "tag": [{"type": "Hashtag", "name": "#cats"}, {"type": "Mention", "name": "@friend"}]
+      }
+    },
+    {
+      "id": "https://m.example/users/me/statuses/2/activity",
+      "type": "Create",
+      "to": "https://m.example/users/me/followers",
+      "object": {
+        "id": "https://m.example/users/me/statuses/2",
+        "type": "Note",
+        "summary": "spoilers",
+        "published": "2023-05-02T10:00:00Z",
+        "content": "<p>followers only</p>",
+        "to": "https://m.example/users/me/followers"
+      }
+    },
+    {"id": "https://m.example/users/me/statuses/3/activity", "type": "Announce", "object": "https://other.example/notes/9"},
+    {
+      "id": "https://m.example/users/me/statuses/4/activity",
+      "type": "Create",
+      "object": {
+        "id": "https://m.example/users/me/statuses/4",
+        "type": "Note",
+        "published": "2023-05-03T10:00:00Z",
+        "content": "<p>lost</p>",
+        "attachment": [{"url": "/media_attachments/files/gone.png"}]
+      }
+    }
+  ]
+}`
+
+func writeTarGz(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("tar header failed: %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("tar write failed: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar close failed: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip close failed: %v", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("write tarball failed: %v", err)
+	}
+}
+
+func TestParseTarball(t *testing.T) {
+	root := t.TempDir()
+	writeTarGz(t, filepath.Join(root, "archive.tar.gz"), map[string]string{
+		"outbox.json": outboxJSON,
+		"media_attachments/files/000/001/original/cat.png": "PNG",
+	})
+
+	items, failed, err := Parse(root)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("items = %d, want 2", len(items))
+	}
+	if len(failed) != 1 || failed[0].SourceID != "https://m.example/users/me/statuses/4" {
+		t.Fatalf("unexpected failed: %+v", failed)
+	}
+
+	first := items[0]
+	if first.Private || first.CreatedAt != 1682935200 {
+		t.Fatalf("unexpected first item: %+v", first)
+	}
+	if first.Content != "Hello #cats\n\nsee https://example.com/a/long/path\nbye & thanks" {
+		t.Fatalf("content = %q", first.Content)
+	}
+	if len(first.Tags) != 1 || first.Tags[0] != "cats" {
+		t.Fatalf("tags = %v", first.Tags)
+	}
+	if len(first.Media) != 1 || first.Media[0].Name != "cat.png" {
+		t.Fatalf("media = %+v", first.Media)
+	}
+
+	second := items[1]
+	if !second.Private || second.Content != "CW: spoilers\n\nfollowers only" {
+		t.Fatalf("unexpected second item: %+v", second)
+	}
+
+	// 再次解析复用已解开的目录。
+	if _, _, err := Parse(root); err != nil {
+		t.Fatalf("reparse failed: %v", err)
+	}
+}
+
+func TestUntarRejectsTraversal(t *testing.T) {
+	root := t.TempDir()
+	writeTarGz(t, filepath.Join(root, "evil.tgz"), map[string]string{"../escape.txt": "x"})
+	if _, err := unpackTarballs(root); err == nil {
+		t.Fatal("expected traversal entry to be rejected")
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(root), "escape.txt")); err == nil {
+		t.Fatal("entry escaped the destination")
+	}
+}
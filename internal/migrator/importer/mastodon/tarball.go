@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package mastodon
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lin-snow/ech0/internal/migrator/importer/loader"
+)
+
+// unpackTarballs 把 root 顶层的 .tar / .tar.gz / .tgz 就地解到同名目录，返回这些目录。
+// 同名目录已存在（重复解析同一暂存目录）就直接复用。
+func unpackTarballs(root string) ([]string, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	var dirs []string
+	for _, e := range entries {
+		name := e.Name()
+		stem, ok := tarballStem(name)
+		if e.IsDir() || !ok {
+			continue
+		}
+		dest := filepath.Join(root, stem)
+		if info, err := os.Stat(dest); err == nil && info.IsDir() {
+			dirs = append(dirs, dest)
+			continue
+		}
+		if err := untar(filepath.Join(root, name), dest); err != nil {
+			return nil, fmt.Errorf("unpack %s: %w", name, err)
+		}
+		dirs = append(dirs, dest)
+	}
+	return dirs, nil
+}
+
+func tarballStem(name string) (string, bool) {
+	lower := strings.ToLower(name)
+	for _, ext := range []string{".tar.gz", ".tgz", ".tar"} {
+		if strings.HasSuffix(lower, ext) && len(name) > len(ext) {
+			return name[:len(name)-len(ext)], true
+		}
+	}
+	return "", false
+}
+
+// untar 只落普通文件与目录，链接一律忽略；条目路径越出 dest 即整包拒绝——归档不可信。
+func untar(src, dest string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	var r io.Reader = f
+	if !strings.HasSuffix(strings.ToLower(src), ".tar") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = gz.Close() }()
+		r = gz
+	}
+
+	tmp := dest + ".partial"
+	if err := os.RemoveAll(tmp); err != nil {
+		return err
+	}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			_ = os.RemoveAll(tmp)
+			return err
+		}
+		target, ok := loader.Within(tmp, tmp, hdr.Name)
+		if !ok {
+			_ = os.RemoveAll(tmp)
+			return fmt.Errorf("illegal entry path %q", hdr.Name)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				_ = os.RemoveAll(tmp)
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeEntry(target, tr); err != nil {
+				_ = os.RemoveAll(tmp)
+				return err
+			}
+		}
+	}
+	// 解完再改名：中途失败不会留下一个被下次当成「已解开」的半截目录。
+	return os.Rename(tmp, dest)
+}
+
+func writeEntry(target string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	out, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
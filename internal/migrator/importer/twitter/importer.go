@@ -0,0 +1,240 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package twitter 是 Twitter/X 数据归档 → Ech0 的导入适配器。
+//
+// 归档里的 data/tweets.js（旧版为 tweet.js，超大归档拆成 tweets-part1.js ...）是一段
+// 「window.YTD.tweets.part0 = [...]」的 JS 赋值，去掉前缀即 JSON。媒体按
+// <tweet id>-<文件名> 存放在 data/tweets_media/（旧版 tweet_media/）。
+// 转推不是本人内容，直接略过。
+package twitter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/migrator/importer/loader"
+	"github.com/lin-snow/ech0/internal/migrator/spec"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+)
+
+// createdAtLayout 是归档里 created_at 的格式（Wed Oct 10 20:19:24 +0000 2018）。
+const createdAtLayout = "Mon Jan 02 15:04:05 -0700 2006"
+
+type Importer struct{}
+
+func New() *Importer {
+	return &Importer{}
+}
+
+func (e *Importer) Import(ctx context.Context, req spec.ImportRequest) (spec.ImportResult, error) {
+	root, err := loader.ResolveRoot(req.SourcePayload)
+	if err != nil {
+		return spec.ImportResult{}, err
+	}
+	if req.UpdateProgress != nil {
+		req.UpdateProgress(spec.ImportProgress{CurrentPhase: loader.PhaseExtracting})
+	}
+	items, failed, err := Parse(root)
+	if err != nil {
+		return spec.ImportResult{}, err
+	}
+	return loader.Run(ctx, req, migratorModel.MigrationSourceTwitter, items, failed)
+}
+
+type tweetEnvelope struct {
+	Tweet tweet `json:"tweet"`
+}
+
+type tweet struct {
+	ID        string `json:"id_str"`
+	FullText  string `json:"full_text"`
+	CreatedAt string `json:"created_at"`
+	Entities  struct {
+		Hashtags []struct {
+			Text string `json:"text"`
+		} `json:"hashtags"`
+		URLs []struct {
+			URL         string `json:"url"`
+			ExpandedURL string `json:"expanded_url"`
+		} `json:"urls"`
+	} `json:"entities"`
+	ExtendedEntities struct {
+		Media []struct {
+			URL           string `json:"url"`
+			MediaURLHTTPS string `json:"media_url_https"`
+			Type          string `json:"type"`
+			VideoInfo     struct {
+				Variants []variant `json:"variants"`
+			} `json:"video_info"`
+		} `json:"media"`
+	} `json:"extended_entities"`
+}
+
+type variant struct {
+	Bitrate string `json:"bitrate"`
+	URL     string `json:"url"`
+}
+
+// Parse 读出归档 root 下全部推文。
+func Parse(root string) ([]loader.Item, []spec.FailedItem, error) {
+	dataDir, files, err := locateTweets(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	mediaDir := filepath.Join(dataDir, "tweets_media")
+	if !isDir(mediaDir) {
+		mediaDir = filepath.Join(dataDir, "tweet_media")
+	}
+
+	var items []loader.Item
+	var failed []spec.FailedItem
+	for _, f := range files {
+		tweets, err := readTweetsJS(f)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", filepath.Base(f), err)
+		}
+		for _, env := range tweets {
+			t := env.Tweet
+			if t.ID == "" || strings.HasPrefix(t.FullText, "RT @") {
+				continue
+			}
+			item, err := toItem(root, mediaDir, t)
+			if err != nil {
+				failed = append(failed, spec.FailedItem{SourceID: t.ID, Reason: err.Error()})
+				continue
+			}
+			items = append(items, item)
+		}
+	}
+	return items, failed, nil
+}
+
+// locateTweets 找到 tweets.js 所在的 data 目录。归档常被多套一层目录再打包，故向下搜一层。
+func locateTweets(root string) (string, []string, error) {
+	candidates := []string{filepath.Join(root, "data"), root}
+	if entries, err := os.ReadDir(root); err == nil {
+		for _, e := range entries {
+			if e.IsDir() {
+				candidates = append(candidates, filepath.Join(root, e.Name(), "data"))
+			}
+		}
+	}
+	for _, dir := range candidates {
+		var files []string
+		for _, pattern := range []string{"tweets.js", "tweets-part*.js", "tweet.js", "tweet-part*.js"} {
+			matched, _ := filepath.Glob(filepath.Join(dir, pattern))
+			files = append(files, matched...)
+		}
+		if len(files) > 0 {
+			sort.Strings(files)
+			return dir, files, nil
+		}
+	}
+	return "", nil, errors.New("tweets.js not found in archive")
+}
+
+// readTweetsJS 去掉「window.YTD.xxx = 」前缀后按 JSON 解析。
+func readTweetsJS(p string) ([]tweetEnvelope, error) {
+	raw, err := os.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	start := strings.IndexByte(string(raw), '[')
+	if start < 0 {
+		return nil, errors.New("no tweet array found")
+	}
+	var out []tweetEnvelope
+	if err := json.Unmarshal(raw[start:], &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func toItem(root, mediaDir string, t tweet) (loader.Item, error) {
+	createdAt, err := time.Parse(createdAtLayout, t.CreatedAt)
+	if err != nil {
+		return loader.Item{}, fmt.Errorf("created_at: %w", err)
+	}
+
+	text := t.FullText
+	// 短链还原成原始链接；媒体的 t.co 链接只是指向推文自身的占位，整段去掉。
+	for _, u := range t.Entities.URLs {
+		if u.URL != "" && u.ExpandedURL != "" {
+			text = strings.ReplaceAll(text, u.URL, u.ExpandedURL)
+		}
+	}
+	var media []loader.Media
+	seen := make(map[string]struct{})
+	for _, m := range t.ExtendedEntities.Media {
+		if m.URL != "" {
+			text = strings.ReplaceAll(text, m.URL, "")
+		}
+		name := mediaFileName(m.MediaURLHTTPS, m.Type, m.VideoInfo.Variants)
+		if name == "" {
+			continue
+		}
+		p, ok := loader.Within(root, mediaDir, t.ID+"-"+name)
+		if !ok || !loader.IsFile(p) {
+			return loader.Item{}, fmt.Errorf("media %s not found in archive", name)
+		}
+		if _, dup := seen[p]; dup {
+			continue
+		}
+		seen[p] = struct{}{}
+		media = append(media, loader.Media{Path: p, Name: name})
+	}
+
+	tags := make([]string, 0, len(t.Entities.Hashtags))
+	for _, h := range t.Entities.Hashtags {
+		if h.Text != "" {
+			tags = append(tags, h.Text)
+		}
+	}
+	return loader.Item{
+		SourceID:  t.ID,
+		Content:   strings.TrimSpace(html.UnescapeString(text)),
+		CreatedAt: createdAt.Unix(),
+		Tags:      tags,
+		Media:     media,
+	}, nil
+}
+
+// mediaFileName 推出归档里媒体文件的名字。图片取 media_url 的文件名；视频/GIF 的 media_url
+// 是封面图，真正落盘的是码率最高的 mp4 变体。
+func mediaFileName(mediaURL, kind string, variants []variant) string {
+	if kind == "video" || kind == "animated_gif" {
+		best, bestRate := "", -1
+		for _, v := range variants {
+			var rate int
+			_, _ = fmt.Sscan(v.Bitrate, &rate)
+			if strings.Contains(v.URL, ".mp4") && rate > bestRate {
+				best, bestRate = v.URL, rate
+			}
+		}
+		if best != "" {
+			mediaURL = best
+		}
+	}
+	if i := strings.IndexByte(mediaURL, '?'); i >= 0 {
+		mediaURL = mediaURL[:i]
+	}
+	if mediaURL == "" {
+		return ""
+	}
+	return path.Base(mediaURL)
+}
+
+func isDir(p string) bool {
+	info, err := os.Stat(p)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package twitter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const tweetsJS = `window.YTD.tweets.part0 = [
+  {"tweet": {
+    "id_str": "100",
+    "created_at": "Wed Oct 10 20:19:24 +0000 2018",
+    "full_text": "Reading https://t.co/abc &amp; more #golang https://t.co/pic",
+    "entities": {
+      "hashtags": [{"text": "golang"}],
+      "urls": [{"url": "https://t.co/abc", "expanded_url": "https://go.dev/blog"}]
+    },
+    "extended_entities": {"media": [
+      {"url": "https://t.co/pic", "media_url_https": "https://pbs.twimg.com/media/Abc.jpg", "type": "photo"},
+      {"url": "https://t.co/pic", "media_url_https": "https://pbs.twimg.com/media/thumb.jpg", "type": "video",
+       "video_info": {"variants": [
+         {"bitrate": "256000", "url": "https://video.twimg.com/low.mp4?tag=1"},
+         {"bitrate": "2176000", "url": "https://video.twimg.com/high.mp4?tag=1"},
+         {"url": "https://video.twimg.com/pl.m3u8"}
+       ]}}
+    ]}
+  }},
+  {"tweet": {"id_str": "101", "created_at": "Wed Oct 10 21:00:00 +0000 2018", "full_text": "RT @someone: hi"}},
+  {"tweet": {"id_str": "102", "created_at": "yesterday", "full_text": "bad date"}},
+  {"tweet": {
+    "id_str": "103",
+    "created_at": "Wed Oct 10 22:00:00 +0000 2018",
+    "full_text": "lost photo https://t.co/x",
+    "extended_entities": {"media": [{"url": "https://t.co/x", "media_url_https": "https://pbs.twimg.com/media/gone.png", "type": "photo"}]}
+  }}
+]`
+
+func TestParseArchive(t *testing.T) {
+	root := t.TempDir()
+	data := filepath.Join(root, "twitter-2024", "data")
+	for name, content := range map[string]string{
+		"tweets.js":                 tweetsJS,
+		"tweets_media/100-Abc.jpg":  "JPG",
+		"tweets_media/100-high.mp4": "MP4",
+	} {
+		p := filepath.Join(data, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatalf("mkdir failed: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatalf("write failed: %v", err)
+		}
+	}
+
+	items, failed, err := Parse(root)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("items = %d, want 1 (retweet dropped)", len(items))
+	}
+	if len(failed) != 2 || failed[0].SourceID != "102" || failed[1].SourceID != "103" {
+		t.Fatalf("unexpected failed: %+v", failed)
+	}
+
+	it := items[0]
+	if it.SourceID != "100" || it.CreatedAt != 1539202764 {
+		t.Fatalf("unexpected item: %+v", it)
+	}
+	if it.Content != "Reading https://go.dev/blog & more #golang" {
+		t.Fatalf("content = %q", it.Content)
+	}
+	if len(it.Tags) != 1 || it.Tags[0] != "golang" {
+		t.Fatalf("tags = %v", it.Tags)
+	}
+	if len(it.Media) != 2 || it.Media[0].Name != "Abc.jpg" || it.Media[1].Name != "high.mp4" {
+		t.Fatalf("media = %+v", it.Media)
+	}
+}
+
+func TestParseMissingTweets(t *testing.T) {
+	if _, _, err := Parse(t.TempDir()); err == nil {
+		t.Fatal("expected error for archive without tweets.js")
+	}
+}
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/lin-snow/ech0/internal/capsule"
+	capsuleBuild "github.com/lin-snow/ech0/internal/capsule/build"
+	capsuleCheck "github.com/lin-snow/ech0/internal/capsule/check"
+	capsuleExport "github.com/lin-snow/ech0/internal/capsule/export"
+	capsulePublish "github.com/lin-snow/ech0/internal/capsule/publish"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
+	versionPkg "github.com/lin-snow/ech0/internal/version"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+// Publish 按 PublishSetting 把当前实例重建成静态站并发布出去：导出公开内容的胶囊目录 →
+// 走与 CLI 相同的校验与烘焙 → 增量上传。中间产物落在暂存目录，结束即删。
+//
+// 静态站是公开站，私密 Echo 一律不导出，与 `ech0 build` 的剔除规则一致。
+func (e *CapsuleEngine) Publish(
+	ctx context.Context,
+	report func(phase string, snapshot any),
+) (migratorModel.PublishReport, error) {
+	setting, err := coreSetting.Get(ctx, e.durableKV, coreSetting.Publish)
+	if err != nil {
+		return migratorModel.PublishReport{}, err
+	}
+	if setting.Target == "" {
+		return migratorModel.PublishReport{}, errors.New("发布目标未配置")
+	}
+	target, err := capsulePublish.ParseTarget(setting.Target)
+	if err != nil {
+		return migratorModel.PublishReport{}, err
+	}
+	// 旧版本可能存下过本地目标；管理接口已不再接受，这里同样拒绝。
+	if target.Local() {
+		return migratorModel.PublishReport{}, errors.New(commonModel.PUBLISH_TARGET_LOCAL)
+	}
+
+	work := filepath.Join("data", TmpRelativeDir, "publish_"+uuidUtil.MustNewV7())
+	if err := os.MkdirAll(work, 0o755); err != nil {
+		return migratorModel.PublishReport{}, fmt.Errorf("create publish tmp dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(work) }()
+	capsuleDir := filepath.Join(work, "capsule")
+	distDir := filepath.Join(work, "dist")
+
+	report(migratorModel.PublishPhaseExporting, nil)
+	if _, err := capsuleExport.Run(ctx, capsuleExport.Deps{
+		DB:       e.db,
+		Selector: e.selector(),
+		KV:       e.durableKV,
+	}, capsuleExport.Options{
+		Output:    capsuleDir,
+		Generator: "ech0 v" + versionPkg.Version,
+	}); err != nil {
+		return migratorModel.PublishReport{}, err
+	}
+
+	report(migratorModel.PublishPhaseBuilding, nil)
+	src, err := capsule.Open(capsuleDir)
+	if err != nil {
+		return migratorModel.PublishReport{}, err
+	}
+	defer func() { _ = src.Close() }()
+	loaded, checkReport, err := capsuleCheck.Run(ctx, src, capsuleCheck.Options{})
+	if err != nil {
+		return migratorModel.PublishReport{}, err
+	}
+	if checkReport.HasErrors() {
+		return migratorModel.PublishReport{}, fmt.Errorf("capsule failed validation, refusing to build: %s",
+			checkReport.ErrorSummary())
+	}
+	built, err := capsuleBuild.Run(ctx, loaded, capsuleBuild.Options{
+		Output:  distDir,
+		BaseURL: setting.BaseURL,
+	})
+	if err != nil {
+		return migratorModel.PublishReport{}, err
+	}
+
+	report(migratorModel.PublishPhaseUploading, nil)
+	published, err := capsulePublish.Run(ctx, distDir, target, capsulePublish.Options{
+		AccessKey: setting.AccessKey,
+		SecretKey: setting.SecretKey,
+	})
+	if err != nil {
+		return migratorModel.PublishReport{}, err
+	}
+	report(migratorModel.PublishPhaseCompleted, nil)
+
+	logUtil.GetLogger().Info("static site published",
+		slog.String("module", "migration"),
+		slog.String("target", published.Target),
+		slog.Int("uploaded", published.Uploaded),
+		slog.Int("deleted", published.Deleted),
+	)
+	return migratorModel.PublishReport{
+		Target:    published.Target,
+		Echoes:    built.Echoes,
+		Pages:     built.Pages,
+		Uploaded:  published.Uploaded,
+		Deleted:   published.Deleted,
+		Unchanged: published.Unchanged,
+	}, nil
+}
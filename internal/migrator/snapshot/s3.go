@@ -8,19 +8,18 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
-	"sort"
+	"path"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/lin-snow/ech0/internal/config"
+	"github.com/lin-snow/ech0/internal/migrator/artifact"
 	logUtil "github.com/lin-snow/ech0/pkg/log"
 	"github.com/lin-snow/ech0/pkg/virefs"
 )
 
-const (
-	s3SnapshotPrefix    = "snapshots/"
-	s3SnapshotKeepCount = 3
-)
+const s3SnapshotPrefix = "snapshots/"
 
 // BuildS3FS creates a VireFS ObjectFS dedicated to snapshot operations.
 // It respects the user's PathPrefix but omits Schema (no file-type classification),
@@ -62,8 +61,8 @@ func virefsS3Config(cfg config.StorageConfig) *virefs.S3Config {
 	return s3cfg
 }
 
-// UploadToS3 uploads the local snapshot ZIP to S3 at snapshots/<fileName>,
-// then cleans up old snapshots keeping only the most recent ones.
+// UploadToS3 uploads the local snapshot ZIP to S3 at snapshots/<fileName>.
+// Old remote snapshots are pruned separately by PruneS3.
 //
 // VireFS opts every non-AWS target (MinIO, R2, "other" S3-compatible services)
 // out of request/response checksum calculation (see NewS3Client), avoiding the
@@ -94,52 +93,57 @@ func UploadToS3(ctx context.Context, snapshotFilePath, fileName string, cfg conf
 	logUtil.GetLogger().Info("Snapshot uploaded to S3",
 		slog.String("key", s3Key))
 
-	if err := cleanupOldS3Snapshots(ctx, s3FS, s3SnapshotKeepCount); err != nil {
-		logUtil.GetLogger().Warn("Failed to cleanup old S3 snapshots",
-			logUtil.Err(err))
-	}
-
 	return nil
 }
 
-// cleanupOldS3Snapshots lists files under the snapshots/ prefix and removes
-// all but the most recent keepCount files (sorted by name, which embeds
-// a UTC timestamp).
-func cleanupOldS3Snapshots(ctx context.Context, s3FS virefs.FS, keepCount int) error {
+// PruneS3 applies the same GFS retention policy as the local slot to the
+// snapshots/ prefix on S3 and returns the deleted keys. Objects whose names
+// do not parse as snapshot artifacts are left alone.
+func PruneS3(ctx context.Context, cfg config.StorageConfig, policy artifact.Retention) ([]string, error) {
+	s3FS, err := BuildS3FS(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return pruneS3Snapshots(ctx, s3FS, policy)
+}
+
+func pruneS3Snapshots(ctx context.Context, s3FS virefs.FS, policy artifact.Retention) ([]string, error) {
 	result, err := s3FS.List(ctx, s3SnapshotPrefix)
 	if err != nil {
-		return fmt.Errorf("list s3 snapshots: %w", err)
+		return nil, fmt.Errorf("list s3 snapshots: %w", err)
 	}
 
-	var snapshotFiles []string
+	slot := Slot()
+	var keys []string
+	var times []time.Time
 	for _, item := range result.Files {
 		key := strings.Trim(item.Key, "/")
-		if item.IsDir || key == "" {
+		if item.IsDir || !strings.HasPrefix(key, s3SnapshotPrefix) {
 			continue
 		}
-		if strings.HasPrefix(key, s3SnapshotPrefix) && strings.HasSuffix(key, ".zip") {
-			snapshotFiles = append(snapshotFiles, key)
+		at, ok := slot.ParseName(path.Base(key))
+		if !ok {
+			continue
 		}
+		keys = append(keys, key)
+		times = append(times, at)
 	}
 
-	if len(snapshotFiles) <= keepCount {
-		return nil
-	}
-
-	sort.Strings(snapshotFiles)
-
-	toDelete := snapshotFiles[:len(snapshotFiles)-keepCount]
-	for _, key := range toDelete {
-		if err := s3FS.Delete(ctx, key); err != nil {
+	var deleted []string
+	for i, keep := range policy.Select(times) {
+		if keep {
+			continue
+		}
+		if err := s3FS.Delete(ctx, keys[i]); err != nil {
 			logUtil.GetLogger().Warn("Failed to delete old S3 snapshot",
-				slog.String("key", key), logUtil.Err(err))
-		} else {
-			logUtil.GetLogger().Info("Deleted old S3 snapshot",
-				slog.String("key", key))
+				slog.String("key", keys[i]), logUtil.Err(err))
+			continue
 		}
+		logUtil.GetLogger().Info("Deleted old S3 snapshot",
+			slog.String("key", keys[i]))
+		deleted = append(deleted, keys[i])
 	}
-
-	return nil
+	return deleted, nil
 }
 
 func mapS3Provider(raw string) virefs.Provider {
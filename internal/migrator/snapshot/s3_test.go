@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/migrator/artifact"
+	"github.com/lin-snow/ech0/pkg/virefs"
+)
+
+func TestPruneS3Snapshots_AppliesRetentionToSnapshotKeys(t *testing.T) {
+	ctx := context.Background()
+	fs, err := virefs.NewLocalFS(t.TempDir(), virefs.WithCreateRoot())
+	if err != nil {
+		t.Fatalf("open fs failed: %v", err)
+	}
+
+	start := time.Date(2026, 6, 1, 2, 0, 0, 0, time.UTC)
+	var names []string
+	for d := 0; d < 4; d++ {
+		names = append(names, Slot().Name(start.AddDate(0, 0, d)))
+	}
+	for _, key := range append(append([]string{}, names...), "notes.txt") {
+		if err := fs.Put(ctx, s3SnapshotPrefix+key, bytes.NewReader([]byte("zip"))); err != nil {
+			t.Fatalf("put %s failed: %v", key, err)
+		}
+	}
+
+	deleted, err := pruneS3Snapshots(ctx, fs, artifact.Retention{Daily: 2})
+	if err != nil {
+		t.Fatalf("pruneS3Snapshots failed: %v", err)
+	}
+	sort.Strings(deleted)
+	want := []string{s3SnapshotPrefix + names[0], s3SnapshotPrefix + names[1]}
+	if len(deleted) != 2 || deleted[0] != want[0] || deleted[1] != want[1] {
+		t.Fatalf("deleted=%v, want %v", deleted, want)
+	}
+
+	result, err := fs.List(ctx, s3SnapshotPrefix)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(result.Files) != 3 {
+		t.Fatalf("should keep 2 snapshots and the unrelated object, got %d", len(result.Files))
+	}
+}
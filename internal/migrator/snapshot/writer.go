@@ -52,6 +52,7 @@ type CreateOption func(*createConfig)
 
 type createConfig struct {
 	dbCopy func(dstPath string) error
+	seal   func(src, dst string) error
 }
 
 // WithConsistentDB 注册「把数据库一致性副本写到指定路径」的函数(线上即 database.SnapshotTo)。
@@ -64,9 +65,17 @@ func WithConsistentDB(copyFn func(dstPath string) error) CreateOption {
 	}
 }
 
+// WithSeal 注册加密函数:打包出的明文 zip 先经它写成加密产物,再 rename 成最终快照,
+// 明文从不以快照之名出现在快照目录里(也就不会被下载或上传出去)。
+func WithSeal(seal func(src, dst string) error) CreateOption {
+	return func(cfg *createConfig) {
+		cfg.seal = seal
+	}
+}
+
 // Create packs the data/ directory into a zip snapshot using VireFS and returns
 // the path and file name of the produced archive. It excludes the snapshots/ and
-// tmp/ subtrees and keeps only the latest snapshot locally.
+// tmp/ subtrees. Older snapshots are left in place; retention is applied by Prune.
 func Create(opts ...CreateOption) (string, string, error) {
 	var cfg createConfig
 	for _, opt := range opts {
@@ -145,18 +154,30 @@ func Create(opts ...CreateOption) (string, string, error) {
 		return "", "", fmt.Errorf("close zip file: %w", err)
 	}
 
-	if err := os.Rename(tempPath, snapshotPath); err != nil {
+	if cfg.seal != nil {
+		sealedPath := slot.Path("." + fileName + ".sealed.tmp")
+		err := cfg.seal(tempPath, sealedPath)
 		_ = os.Remove(tempPath)
-		return "", "", fmt.Errorf("finalize snapshot zip: %w", err)
+		if err != nil {
+			_ = os.Remove(sealedPath)
+			return "", "", fmt.Errorf("seal snapshot zip: %w", err)
+		}
+		tempPath = sealedPath
 	}
 
-	if err := slot.KeepOnly(fileName); err != nil {
-		return "", "", err
+	if err := os.Rename(tempPath, snapshotPath); err != nil {
+		_ = os.Remove(tempPath)
+		return "", "", fmt.Errorf("finalize snapshot zip: %w", err)
 	}
 
 	return snapshotPath, fileName, nil
 }
 
+// Prune 按留存策略清理本地快照，返回被删除的文件名。
+func Prune(policy artifact.Retention) ([]string, error) {
+	return Slot().Prune(policy)
+}
+
 // LatestPath 返回最新一份快照 zip 的路径，无可用快照时返回 ErrNoSnapshot。
 // 供同步下载出口取回「上一次导出作业产出的快照」。
 func LatestPath() (string, error) {
@@ -11,9 +11,10 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
-func TestCreate_KeepOnlyLatestAndExcludeSnapshotDir(t *testing.T) {
+func TestCreate_KeepsOlderSnapshotsAndExcludesSnapshotDir(t *testing.T) {
 	workspace := t.TempDir()
 	prevWD, err := os.Getwd()
 	if err != nil {
@@ -45,9 +46,9 @@ func TestCreate_KeepOnlyLatestAndExcludeSnapshotDir(t *testing.T) {
 	if err := os.WriteFile(filepath.Join(dataDir, tmpRelativeDir, "payload.txt"), []byte("tmp payload"), 0o644); err != nil {
 		t.Fatalf("write tmp file failed: %v", err)
 	}
-	legacySnapshotPath := filepath.Join(dataDir, snapshotRelativeDir, "legacy.zip")
-	if err := os.WriteFile(legacySnapshotPath, []byte("legacy"), 0o644); err != nil {
-		t.Fatalf("write legacy snapshot failed: %v", err)
+	previousName := Slot().Name(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err := os.WriteFile(Slot().Path(previousName), []byte("previous"), 0o644); err != nil {
+		t.Fatalf("write previous snapshot failed: %v", err)
 	}
 
 	snapshotPath, fileName, err := Create()
@@ -61,15 +62,13 @@ func TestCreate_KeepOnlyLatestAndExcludeSnapshotDir(t *testing.T) {
 		t.Fatal("snapshot filename should not be empty")
 	}
 
-	entries, err := os.ReadDir(filepath.Join(dataDir, snapshotRelativeDir))
+	// 留存交给 Prune：Create 本身不再删除旧快照。
+	entries, err := Slot().List()
 	if err != nil {
-		t.Fatalf("readdir snapshot dir failed: %v", err)
+		t.Fatalf("list snapshots failed: %v", err)
 	}
-	if len(entries) != 1 {
-		t.Fatalf("snapshot dir should keep only one file, got %d", len(entries))
-	}
-	if entries[0].Name() != fileName {
-		t.Fatalf("kept file should be latest snapshot %s, got %s", fileName, entries[0].Name())
+	if len(entries) != 2 || entries[0].Name != fileName || entries[1].Name != previousName {
+		t.Fatalf("snapshot dir should hold the new and previous snapshots, got %+v", entries)
 	}
 
 	zr, err := zip.OpenReader(snapshotPath)
@@ -221,6 +220,9 @@ func TestShouldExcludeFromSnapshot(t *testing.T) {
 		// 胶囊产物也是 data/ 下的派生物：漏掉它，每次快照都会把上一个胶囊打进去并雪球式膨胀。
 		{key: "files/capsules", expected: true},
 		{key: "files/capsules/ech0_capsule_2026-08-02_10-00-00.zip", expected: true},
+		// 断点续传的半截分片。
+		{key: "tmp/uploads/0190a1b2.part", expected: true},
+		{key: "tmpfile.txt", expected: false},
 		{key: "files/images/a.png", expected: false},
 		{key: "ech0.db", expected: false},
 	}
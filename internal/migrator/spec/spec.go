@@ -52,6 +52,9 @@ type Importer interface {
 // ExportRequest 是导出的输入(进度回调),与 ImportRequest 对称。
 type ExportRequest struct {
 	UpdateProgress func(progress ExportProgress)
+	// Seal 非 nil 时，适配器在产物落定前用它把明文归档 src 加密成 dst（见 migrator/envelope），
+	// 落盘与上传 S3 的都是加密后的产物。
+	Seal func(src, dst string) error
 }
 
 // ExportProgress 是导出的实时进度,与 ImportProgress 对称。
@@ -0,0 +1,449 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package migrator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/capsule"
+	capsuleCheck "github.com/lin-snow/ech0/internal/capsule/check"
+	capsuleExport "github.com/lin-snow/ech0/internal/capsule/export"
+	capsuleImporter "github.com/lin-snow/ech0/internal/capsule/importer"
+	"github.com/lin-snow/ech0/internal/capsule/replica"
+	"github.com/lin-snow/ech0/internal/config"
+	"github.com/lin-snow/ech0/internal/kvstore"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	"github.com/lin-snow/ech0/internal/util/egress"
+	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
+	versionPkg "github.com/lin-snow/ech0/internal/version"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+// syncCursorKeyPrefix 是同步水位在 durableKV 中的键前缀，后缀为对端地址的摘要：换对端即从零开始，
+// 不会拿旧对端的水位去跟新对端对账。
+const syncCursorKeyPrefix = "sync_cursor:"
+
+// syncHTTPTimeout 覆盖一次完整的拉取或推送（含响应体）。增量胶囊带着媒体字节，给足余量。
+const syncHTTPTimeout = 10 * time.Minute
+
+// SyncArchive 是一份落在暂存目录里的增量胶囊。Cursor 是导出开始前本端的时钟：导出期间新改的行
+// 不早于水位，下一轮自然会被带上。
+type SyncArchive struct {
+	Path   string
+	Cursor int64
+}
+
+// Remove 删除暂存的胶囊文件，幂等。
+func (a SyncArchive) Remove() {
+	if a.Path != "" {
+		_ = os.Remove(a.Path)
+	}
+}
+
+// ApplyOptions 是合并增量胶囊的参数，语义见 internal/capsule/replica。
+type ApplyOptions struct {
+	Base           int64
+	Policy         string
+	IncludePrivate bool
+}
+
+// ExportChanges 把 since 之后的本地变更导出成一份暂存的增量胶囊 zip，调用方用完须 Remove。
+func (e *CapsuleEngine) ExportChanges(ctx context.Context, since int64, includePrivate bool) (SyncArchive, error) {
+	archive := SyncArchive{Cursor: time.Now().Unix()}
+	path, err := syncTmpPath()
+	if err != nil {
+		return SyncArchive{}, err
+	}
+	if _, err := capsuleExport.Run(ctx, capsuleExport.Deps{
+		DB:       e.db,
+		Selector: e.selector(),
+		KV:       e.durableKV,
+	}, capsuleExport.Options{
+		Output:         path,
+		IncludePrivate: includePrivate,
+		Zip:            true,
+		Generator:      "ech0 v" + versionPkg.Version,
+		Since:          since,
+	}); err != nil {
+		_ = os.Remove(path)
+		return SyncArchive{}, err
+	}
+	archive.Path = path
+	return archive, nil
+}
+
+// ApplyChanges 校验并合并一份增量胶囊。与 Import 一样校验是硬前置；返回的 Cursor 是合并开始前
+// 本端的时钟，供对端存作下一轮的水位。
+func (e *CapsuleEngine) ApplyChanges(
+	ctx context.Context,
+	archivePath string,
+	opts ApplyOptions,
+) (migratorModel.SyncApplyResult, error) {
+	cursor := time.Now().Unix()
+	policy, err := replica.ParsePolicy(opts.Policy)
+	if err != nil {
+		return migratorModel.SyncApplyResult{}, err
+	}
+
+	src, err := capsule.Open(archivePath)
+	if err != nil {
+		return migratorModel.SyncApplyResult{}, err
+	}
+	defer func() { _ = src.Close() }()
+
+	loaded, checkReport, err := capsuleCheck.Run(ctx, src, capsuleCheck.Options{})
+	if err != nil {
+		return migratorModel.SyncApplyResult{}, err
+	}
+	if checkReport.HasErrors() {
+		return migratorModel.SyncApplyResult{}, fmt.Errorf(
+			"capsule failed validation, refusing to sync: %s", checkReport.ErrorSummary())
+	}
+
+	res, err := replica.Apply(ctx, capsuleImporter.Deps{
+		DB:       e.db,
+		Tx:       e.tx,
+		Selector: e.selector(),
+		KV:       e.durableKV,
+	}, loaded, replica.Options{Base: opts.Base, Policy: policy, IncludePrivate: opts.IncludePrivate})
+	if err != nil {
+		return migratorModel.SyncApplyResult{}, err
+	}
+	return toApplyResult(cursor, res), nil
+}
+
+// Sync 与 SyncSetting 里配置的对端跑一轮同步：按方向拉取、推送增量胶囊，各自合并，成功后推进水位。
+// 任一步失败即整体失败且不推进水位——下一轮会从同一水位重来，合并本身按 id 幂等，重放无害。
+func (e *CapsuleEngine) Sync(
+	ctx context.Context,
+	report func(phase string, snapshot any),
+) (migratorModel.SyncReport, error) {
+	cfg, err := coreSetting.Get(ctx, e.durableKV, coreSetting.Sync)
+	if err != nil {
+		return migratorModel.SyncReport{}, err
+	}
+	if cfg.PeerURL == "" || strings.TrimSpace(cfg.AccessToken) == "" {
+		return migratorModel.SyncReport{}, errors.New("同步对端未配置")
+	}
+	policy, err := replica.ParsePolicy(cfg.ConflictPolicy)
+	if err != nil {
+		return migratorModel.SyncReport{}, err
+	}
+
+	pull := cfg.Direction != migratorModel.SyncDirectionPush
+	push := cfg.Direction != migratorModel.SyncDirectionPull
+	cursor := e.loadCursor(ctx, cfg.PeerURL)
+	peer := newSyncPeer(cfg.PeerURL, cfg.AccessToken, cfg.AllowPrivatePeer)
+	out := migratorModel.SyncReport{PeerURL: cfg.PeerURL}
+	next := cursor
+
+	// 先导出本地增量再拉取：刚拉进来的行不该在同一轮里原样推回对端。
+	outbound := SyncArchive{Cursor: time.Now().Unix()}
+	if push {
+		outbound, err = e.ExportChanges(ctx, cursor.Local, cfg.IncludePrivate)
+		if err != nil {
+			return migratorModel.SyncReport{}, err
+		}
+		defer outbound.Remove()
+	}
+	next.Local = outbound.Cursor
+
+	if pull {
+		report(migratorModel.SyncPhasePulling, nil)
+		inbound, err := peer.pull(ctx, cursor.Remote, cfg.IncludePrivate)
+		if err != nil {
+			return migratorModel.SyncReport{}, err
+		}
+		defer inbound.Remove()
+
+		pulled, err := e.ApplyChanges(ctx, inbound.Path, ApplyOptions{
+			Base:           cursor.Local,
+			Policy:         string(policy),
+			IncludePrivate: cfg.IncludePrivate,
+		})
+		if err != nil {
+			return migratorModel.SyncReport{}, err
+		}
+		out.Pulled = &pulled
+		next.Remote = inbound.Cursor
+	}
+
+	if push {
+		report(migratorModel.SyncPhasePushing, nil)
+		pushed, err := peer.push(ctx, outbound.Path, cursor.Remote, mirrorPolicy(policy), cfg.IncludePrivate)
+		if err != nil {
+			return migratorModel.SyncReport{}, err
+		}
+		out.Pushed = &pushed
+		if !pull {
+			next.Remote = pushed.Cursor
+		}
+	}
+
+	if err := e.saveCursor(ctx, cfg.PeerURL, next); err != nil {
+		return migratorModel.SyncReport{}, err
+	}
+	report(migratorModel.SyncPhaseCompleted, nil)
+
+	logUtil.GetLogger().Info("capsule sync completed",
+		slog.String("module", "migration"),
+		slog.String("peer", cfg.PeerURL),
+		slog.String("direction", cfg.Direction),
+		slog.Int("conflicts", conflictCount(out)),
+	)
+	return out, nil
+}
+
+// mirrorPolicy 把发起方视角的策略翻成对端视角：设置里的 local 指「发起方这一端」，推到对端
+// 合并时它就成了 remote。
+func mirrorPolicy(p replica.Policy) string {
+	switch p {
+	case replica.PolicyLocal:
+		return string(replica.PolicyRemote)
+	case replica.PolicyRemote:
+		return string(replica.PolicyLocal)
+	default:
+		return string(p)
+	}
+}
+
+func (e *CapsuleEngine) loadCursor(ctx context.Context, peerURL string) migratorModel.SyncCursor {
+	var cursor migratorModel.SyncCursor
+	raw, err := e.durableKV.Get(ctx, syncCursorKey(peerURL))
+	if err != nil {
+		if !errors.Is(err, kvstore.ErrNotFound) {
+			logUtil.GetLogger().Warn("Failed to read sync cursor, starting from scratch",
+				slog.String("module", "migration"), logUtil.Err(err))
+		}
+		return cursor
+	}
+	if err := json.Unmarshal([]byte(raw), &cursor); err != nil {
+		return migratorModel.SyncCursor{}
+	}
+	return cursor
+}
+
+func (e *CapsuleEngine) saveCursor(ctx context.Context, peerURL string, cursor migratorModel.SyncCursor) error {
+	raw, err := json.Marshal(cursor)
+	if err != nil {
+		return err
+	}
+	return e.durableKV.Set(ctx, syncCursorKey(peerURL), string(raw))
+}
+
+func syncCursorKey(peerURL string) string {
+	sum := sha256.Sum256([]byte(peerURL))
+	return syncCursorKeyPrefix + hex.EncodeToString(sum[:8])
+}
+
+// syncTmpPath 在迁移暂存目录下分配一个增量胶囊路径。
+func syncTmpPath() (string, error) {
+	dir := filepath.Join("data", TmpRelativeDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create sync tmp dir: %w", err)
+	}
+	return filepath.Join(dir, "sync_"+uuidUtil.MustNewV7()+".zip"), nil
+}
+
+// ErrSyncUploadTooLarge 表示增量胶囊超过了 migration.sync_max_bytes。
+var ErrSyncUploadTooLarge = errors.New("sync capsule exceeds migration.sync_max_bytes")
+
+// SaveSyncUpload 把对端推来的请求体（或拉取到的响应体）落成暂存胶囊，供 ApplyChanges 打开
+// （zip 需要随机读）。超过 migration.sync_max_bytes 即中止并删掉已写的部分。
+func SaveSyncUpload(body io.Reader) (SyncArchive, error) {
+	path, err := syncTmpPath()
+	if err != nil {
+		return SyncArchive{}, err
+	}
+	if err := writeFile(path, body, int64(config.Config().Migration.SyncMaxBytes)); err != nil {
+		_ = os.Remove(path)
+		return SyncArchive{}, fmt.Errorf("save sync upload: %w", err)
+	}
+	return SyncArchive{Path: path}, nil
+}
+
+// writeFile 把 body 写入 path，至多 limit 字节；多读一个字节用来分辨「恰好写满」与「超限」。
+func writeFile(path string, body io.Reader, limit int64) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(f, io.LimitReader(body, limit+1))
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	if n > limit {
+		_ = f.Close()
+		return ErrSyncUploadTooLarge
+	}
+	return f.Close()
+}
+
+func toApplyResult(cursor int64, res *replica.Result) migratorModel.SyncApplyResult {
+	out := migratorModel.SyncApplyResult{
+		Cursor:          cursor,
+		EchoesUpdated:   res.EchoesUpdated,
+		EchoesDeleted:   res.EchoesDeleted,
+		CommentsUpdated: res.CommentsUpdated,
+		CommentsDeleted: res.CommentsDeleted,
+		Conflicts:       make([]migratorModel.SyncConflict, 0, len(res.Conflicts)),
+	}
+	if res.Imported != nil {
+		// 被让位后由 importer 重建的 Echo 已计入 EchoesUpdated，这里扣掉，免得一条记两次。
+		out.EchoesCreated = res.Imported.EchoesCreated - res.EchoesUpdated
+		out.CommentsCreated = res.Imported.CommentsCreated
+		out.FilesCreated = res.Imported.FilesCreated
+	}
+	for _, c := range res.Conflicts {
+		out.Conflicts = append(out.Conflicts, migratorModel.SyncConflict{
+			Kind:            c.Kind,
+			ID:              c.ID,
+			LocalUpdatedAt:  c.LocalUpdatedAt,
+			RemoteUpdatedAt: c.RemoteUpdatedAt,
+			Deleted:         c.Deleted,
+			Resolution:      c.Resolution,
+		})
+	}
+	return out
+}
+
+func conflictCount(r migratorModel.SyncReport) int {
+	n := 0
+	if r.Pulled != nil {
+		n += len(r.Pulled.Conflicts)
+	}
+	if r.Pushed != nil {
+		n += len(r.Pushed.Conflicts)
+	}
+	return n
+}
+
+// syncPeer 是对端 /api/migration/sync/changes 的客户端。
+type syncPeer struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// newSyncPeer 构造对端客户端。对端地址在面板里填写，请求还带着访问令牌、会把对端的响应写进本地库，
+// 所以默认启用 SSRF 防护：这个地址不能被用来探测或调用本机与内网的服务。公开实例与预发布实例
+// 在同一内网是常见部署，此时由管理员显式打开 allowPrivate 放行。
+func newSyncPeer(baseURL, token string, allowPrivate bool) *syncPeer {
+	opts := []egress.Option{egress.Timeout(syncHTTPTimeout)}
+	if !allowPrivate {
+		opts = append(opts, egress.Guard())
+	}
+	return &syncPeer{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   strings.TrimSpace(token),
+		client:  egress.NewClient(opts...),
+	}
+}
+
+func (p *syncPeer) endpoint(query url.Values) string {
+	return p.baseURL + "/api/migration/sync/changes?" + query.Encode()
+}
+
+// pull 取回对端 since 之后的增量胶囊，落到暂存目录；对端时钟从响应头取。
+func (p *syncPeer) pull(ctx context.Context, since int64, includePrivate bool) (SyncArchive, error) {
+	query := url.Values{}
+	query.Set("since", strconv.FormatInt(since, 10))
+	query.Set("include_private", strconv.FormatBool(includePrivate))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint(query), nil)
+	if err != nil {
+		return SyncArchive{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return SyncArchive{}, fmt.Errorf("pull sync changes: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/zip") {
+		return SyncArchive{}, fmt.Errorf("pull sync changes: %s", peerError(resp))
+	}
+	cursor, err := strconv.ParseInt(resp.Header.Get(migratorModel.SyncCursorHeader), 10, 64)
+	if err != nil {
+		return SyncArchive{}, fmt.Errorf("pull sync changes: missing %s header", migratorModel.SyncCursorHeader)
+	}
+
+	archive, err := SaveSyncUpload(resp.Body)
+	if err != nil {
+		return SyncArchive{}, err
+	}
+	archive.Cursor = cursor
+	return archive, nil
+}
+
+// push 把本地增量胶囊推给对端合并，返回对端的合并报告。
+func (p *syncPeer) push(
+	ctx context.Context,
+	archivePath string,
+	base int64,
+	policy string,
+	includePrivate bool,
+) (migratorModel.SyncApplyResult, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return migratorModel.SyncApplyResult{}, err
+	}
+	defer func() { _ = f.Close() }()
+
+	query := url.Values{}
+	query.Set("base", strconv.FormatInt(base, 10))
+	query.Set("conflict", policy)
+	query.Set("include_private", strconv.FormatBool(includePrivate))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint(query), f)
+	if err != nil {
+		return migratorModel.SyncApplyResult{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.token)
+	req.Header.Set("Content-Type", "application/zip")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return migratorModel.SyncApplyResult{}, fmt.Errorf("push sync changes: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return migratorModel.SyncApplyResult{}, fmt.Errorf("push sync changes: %s", peerError(resp))
+	}
+
+	var envelope commonModel.Result[migratorModel.SyncApplyResult]
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return migratorModel.SyncApplyResult{}, fmt.Errorf("push sync changes: decode response: %w", err)
+	}
+	if envelope.Code != commonModel.DEFAULT_SUCCESS_CODE {
+		return migratorModel.SyncApplyResult{}, fmt.Errorf("push sync changes: %s", envelope.Message)
+	}
+	return envelope.Data, nil
+}
+
+// peerError 尽量从对端的失败信封里取出可读的消息，取不到就退回状态行。
+func peerError(resp *http.Response) string {
+	var envelope commonModel.Result[any]
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err := json.Unmarshal(body, &envelope); err == nil && envelope.Message != "" {
+		return fmt.Sprintf("%s: %s", resp.Status, envelope.Message)
+	}
+	return resp.Status
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package migrator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFileLimit(t *testing.T) {
+	dir := t.TempDir()
+
+	exact := filepath.Join(dir, "exact.zip")
+	require.NoError(t, writeFile(exact, strings.NewReader("12345"), 5), "body at the limit should be accepted")
+	data, err := os.ReadFile(exact)
+	require.NoError(t, err)
+	assert.Equal(t, "12345", string(data))
+
+	over := filepath.Join(dir, "over.zip")
+	assert.ErrorIs(t, writeFile(over, strings.NewReader("123456"), 5), ErrSyncUploadTooLarge)
+}
+
+// 对端默认走带 SSRF 防护的 client，本机回环上的对端连不上；显式放行后才会发出请求。
+func TestSyncPeerPrivateNetworkOptIn(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(srv.Close)
+
+	_, err := newSyncPeer(srv.URL, "token", false).pull(context.Background(), 0, false)
+	require.Error(t, err)
+	assert.Zero(t, hits.Load())
+
+	_, err = newSyncPeer(srv.URL, "token", true).pull(context.Background(), 0, false)
+	require.Error(t, err)
+	assert.Equal(t, int32(1), hits.Load())
+}
@@ -9,6 +9,12 @@ type LoginDto struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// LoginClient 是发起登录的客户端信息，由 handler 从请求中取得，用于新设备登录提醒
+type LoginClient struct {
+	IP        string
+	UserAgent string
+}
+
 // RegisterDto 是用户注册时的请求数据传输对象
 type RegisterDto struct {
 	Username string `json:"username" binding:"required"`
@@ -22,6 +22,7 @@ const (
 	SourceGuest       SourceType = "guest"
 	SourceSystem      SourceType = "system"
 	SourceIntegration SourceType = "integration"
+	SourceWebmention  SourceType = "webmention"
 )
 
 const (
@@ -104,6 +105,7 @@ type CreateCommentDto struct {
 	HoneypotField string `json:"hp_field"`
 	FormToken     string `json:"form_token" binding:"required"`
 	CaptchaToken  string `json:"captcha_token"`
+	Subscribe     bool   `json:"subscribe"` // 可选：订阅该 Echo 的后续评论（需邮件确认）
 }
 
 type CreateCommentResult struct {
@@ -111,6 +113,15 @@ type CreateCommentResult struct {
 	Status Status `json:"status"`
 }
 
+// Webmention 是一条已校验来源的 Webmention，落库为 Source=webmention 的评论。
+// 同一 (EchoID, Source) 只保留一条，来源页更新时覆盖昵称与内容。
+type Webmention struct {
+	EchoID  string
+	Source  string // 来源页面 URL，同时写入评论的 Website 字段
+	Author  string
+	Content string
+}
+
 type CreateIntegrationCommentDto struct {
 	EchoID   string `json:"echo_id" binding:"required"`
 	Content  string `json:"content" binding:"required"`
@@ -138,6 +149,8 @@ type ListCommentQuery struct {
 	Status   string
 	EchoID   string
 	Hot      *bool
+	// Since 只取该 Unix 秒之后创建的评论，0 表示不限；仅供服务内部使用（如定期摘要）。
+	Since int64
 }
 
 type PageResult[T any] struct {
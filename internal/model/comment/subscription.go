@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package model
+
+import (
+	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
+	"gorm.io/gorm"
+)
+
+type SubscriptionStatus string
+
+const (
+	SubscriptionPending SubscriptionStatus = "pending" // 已申请，等待邮件确认（double opt-in）
+	SubscriptionActive  SubscriptionStatus = "active"
+)
+
+// UnsubscribeScope 标识一次退订的范围：只退订某条 Echo 的讨论，或退订全部邮件。
+type UnsubscribeScope string
+
+const (
+	UnsubscribeThread UnsubscribeScope = "thread"
+	UnsubscribeAll    UnsubscribeScope = "all"
+)
+
+// CommentSubscription 是访客对某条 Echo 评论区的邮件订阅；同一邮箱对同一 Echo 只有一行。
+// Email 统一存小写，便于与退订名单比对。
+type CommentSubscription struct {
+	ID          string             `gorm:"type:char(36);primaryKey" json:"id"`
+	EchoID      string             `gorm:"type:char(36);not null;uniqueIndex:idx_comment_sub_echo_email" json:"echo_id"`
+	Email       string             `gorm:"size:255;not null;uniqueIndex:idx_comment_sub_echo_email;index" json:"email"`
+	Status      SubscriptionStatus `gorm:"type:varchar(20);not null;index" json:"status"`
+	CreatedAt   int64              `gorm:"autoCreateTime" json:"created_at"`
+	ConfirmedAt int64              `json:"confirmed_at,omitempty"`
+}
+
+func (s *CommentSubscription) BeforeCreate(_ *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = uuidUtil.MustNewV7()
+	}
+	return nil
+}
+
+// EmailSuppression 是退订名单：名单内的地址不再收到评论系统发出的任何邮件。
+type EmailSuppression struct {
+	Email     string `gorm:"size:255;primaryKey" json:"email"`
+	CreatedAt int64  `gorm:"autoCreateTime" json:"created_at"`
+}
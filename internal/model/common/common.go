@@ -47,6 +47,8 @@ const (
 	OpenAI AgentProtocol = "openai"
 	// Anthropic 协议
 	Anthropic AgentProtocol = "anthropic"
+	// Ollama 原生协议（/api/chat），离线 / 内网部署直连本地模型，API Key 可留空
+	Ollama AgentProtocol = "ollama"
 )
 
 const (
@@ -63,6 +65,20 @@ type KeyValue struct {
 	Value string `json:"value"`
 }
 
+// Tombstone 记下一次 Echo 或评论的删除。行本身已经没了，实例间同步只能靠它把「删掉了什么」
+// 带给对端（capsule spec §13）；同一条记录只留最后一次删除的时刻。
+type Tombstone struct {
+	Kind      string `json:"kind"       gorm:"type:varchar(20);primaryKey"`
+	ID        string `json:"id"         gorm:"type:char(36);primaryKey"`
+	DeletedAt int64  `json:"deleted_at" gorm:"not null;index"`
+}
+
+// Tombstone.Kind 的取值。
+const (
+	TombstoneEcho    = "echo"
+	TombstoneComment = "comment"
+)
+
 // 键值对相关
 const (
 	// SystemSettingsKey 是系统设置的键
@@ -77,12 +93,22 @@ const (
 	PasskeySettingKey = "passkey_setting"
 	// ServerURLKey 是服务器URL设置的键
 	ServerURLKey = "server_url"
+	// SyncSettingKey 是实例间同步对端配置的键
+	SyncSettingKey = "sync_setting"
+	// PublishSettingKey 是静态站自动发布配置的键
+	PublishSettingKey = "publish_setting"
+	// ArchiveEncryptionKey 是快照与胶囊归档加密配置的键
+	ArchiveEncryptionKey = "archive_encryption"
 	// SnapshotScheduleKey 是定时快照计划设置的键
 	SnapshotScheduleKey = "snapshot_schedule"
+	// DigestSettingKey 是站长定期摘要设置的键
+	DigestSettingKey = "digest_setting"
 	// AgentSettingKey 是 Agent 设置的键
 	AgentSettingKey = "agent_setting"
 	// EmbeddingSettingKey 是 Embedding 向量设置的键
 	EmbeddingSettingKey = "embedding_setting"
+	// StorageQuotaSettingKey 是用户存储配额设置的键
+	StorageQuotaSettingKey = "storage_quota_setting"
 	// EmbeddingIndexStateKey 记录当前已建索引所用的 model/dim（换模型后据此判定需重建）
 	EmbeddingIndexStateKey = "embedding_index_state"
 	// ReleaseVersionKey 是发布版本号的键
@@ -115,8 +141,20 @@ const (
 	UserLocalAuthBackfilledKey = "user_local_auth_backfilled_v1"
 	// UsersPasswordColumnDroppedKey 是回填后删除 users.password 遗留列的幂等标记键
 	UsersPasswordColumnDroppedKey = "users_password_column_dropped_v1"
+	// LegacyJobsDroppedKey 是作业改为每次提交一行（job_runs）后删除旧 jobs 表的幂等标记键
+	LegacyJobsDroppedKey = "legacy_jobs_dropped_v1"
+	// FileRouteIndexRelaxedKey 是内容去重后把 files.idx_file_route 由唯一索引放宽为普通索引的幂等标记键
+	FileRouteIndexRelaxedKey = "file_route_index_relaxed_v1"
+	// StorageUsageBackfilledKey 是引入存储配额后按存量文件回填用量账本的幂等标记键
+	StorageUsageBackfilledKey = "storage_usage_backfilled_v1"
 	// ChatSessionKeyPrefix 是 Chat 持久化会话的键前缀（每个 userID 一条，键为前缀 + userID）
 	ChatSessionKeyPrefix = "chat_session:"
+	// LoginDevicesKeyPrefix 是用户已知登录设备指纹列表的键前缀（每个 userID 一条，键为前缀 + userID）
+	LoginDevicesKeyPrefix = "login_devices:"
+	// CopilotActionLogKey 是 Copilot 写操作审计记录的键
+	CopilotActionLogKey = "copilot_action_log"
+	// CopilotSuggestionsKey 是新 Echo 待审核的标签 / 替代文本建议的键
+	CopilotSuggestionsKey = "copilot_suggestions"
 )
 
 // PageQueryResult 用于分页查询的结果数据传输对象
@@ -34,6 +34,9 @@ type EchoQueryDto struct {
 	// （公开 /echo/query 等调用方留空即保持原行为）；Copilot Chat 用它把检索
 	// 收口到当前对话用户本人发布的 Echo。不暴露给前端 JSON 契约，仅服务内部设置。
 	UserID string `json:"-"`
+	// IDs：把结果限定在给定的 Echo ID 集合内（可见性与其余过滤照常生效）。同样仅供服务内部
+	// 使用——混合检索用它对向量命中做可见性 / 标签 / 日期复核。空切片表示不限定。
+	IDs []string `json:"-"`
 }
 
 // FileDto is the unified response for file operations.
@@ -51,6 +54,7 @@ type FileDto struct {
 	Size        int64  `json:"size,omitempty"`
 	Width       int    `json:"width,omitempty"`
 	Height      int    `json:"height,omitempty"`
+	AltText     string `json:"alt_text,omitempty"`
 }
 
 // FileDeleteDto is the request body for deleting a file.
@@ -97,6 +101,7 @@ type CreateExternalFileDto struct {
 //
 // swagger:model UpdateFileMetaDto
 type UpdateFileMetaDto struct {
+	// Size 是客户端上报的大小，仅作校验；服务端以存储里对象的实际大小为准。
 	Size        int64  `json:"size" binding:"required,min=0"`
 	Width       *int   `json:"width,omitempty"`
 	Height      *int   `json:"height,omitempty"`
@@ -96,23 +96,32 @@ const (
 	ECHO_CAN_NOT_BE_EMPTY      = "ECHO 内容不能为空"
 	ECHO_NOT_FOUND             = "找不到Echo"
 	ECHO_MIXED_FILE_CATEGORIES = "一条 Echo 只能包含同一类型的文件"
+	SEARCH_QUERY_EMPTY         = "搜索关键词不能为空"
+	SEARCH_QUERY_TOO_LONG      = "搜索关键词过长"
 )
 
 // Common 错误相关常量
 const (
-	NO_FILE_UPLOAD_ERROR   = "找不到上传的文件"
-	NO_FILE_STORAGE_ERROR  = "未知存储方式"
-	FILE_TYPE_NOT_ALLOWED  = "不支持的文件类型"
-	FILE_SIZE_EXCEED_LIMIT = "文件大小超过限制"
-	IMAGE_NOT_FOUND        = "图片未找到"
-	INVALID_PARAMS         = "错误的参数"
-	SIGNUP_FIRST           = "请先初始化Owner账号"
-	S3_NOT_ENABLED         = "S3存储未启用"
-	S3_NOT_CONFIGURED      = "S3存储未配置"
-	S3_CONFIG_ERROR        = "S3存储配置错误"
-	SYSTEM_ALREADY_INITED  = "系统已初始化"
-	OWNER_ALREADY_EXISTS   = "Owner已存在"
-	ONLY_OWNER_CAN_MANAGE  = "仅Owner可管理管理员权限"
+	NO_FILE_UPLOAD_ERROR      = "找不到上传的文件"
+	NO_FILE_STORAGE_ERROR     = "未知存储方式"
+	FILE_TYPE_NOT_ALLOWED     = "不支持的文件类型"
+	FILE_SIZE_EXCEED_LIMIT    = "文件大小超过限制"
+	UPLOAD_SESSION_NOT_FOUND  = "上传会话不存在或已过期"
+	UPLOAD_OFFSET_MISMATCH    = "上传偏移量与服务端不一致"
+	UPLOAD_SESSION_BUSY       = "上传会话正在写入，请稍后重试"
+	UPLOAD_SESSION_LIMIT      = "未完成的上传会话过多，请先完成或取消已有上传"
+	UPLOAD_LENGTH_INVALID     = "无效的上传长度"
+	STORAGE_QUOTA_EXCEEDED    = "存储空间已超出配额"
+	IMAGE_NOT_FOUND           = "图片未找到"
+	INVALID_PARAMS            = "错误的参数"
+	SIGNUP_FIRST              = "请先初始化Owner账号"
+	S3_NOT_ENABLED            = "S3存储未启用"
+	S3_NOT_CONFIGURED         = "S3存储未配置"
+	S3_CONFIG_ERROR           = "S3存储配置错误"
+	STORAGE_MIGRATION_INVALID = "存储迁移的源与目标无效"
+	SYSTEM_ALREADY_INITED     = "系统已初始化"
+	OWNER_ALREADY_EXISTS      = "Owner已存在"
+	ONLY_OWNER_CAN_MANAGE     = "仅Owner可管理管理员权限"
 )
 
 // User 错误相关常量
@@ -137,6 +146,9 @@ const (
 	WEBHOOK_NAME_OR_URL_CANNOT_BE_EMPTY = "未填写 Webhook 名称或 URL"
 	INVALID_WEBHOOK_URL                 = "webhook URL 不合法或不安全"
 	INVALID_CRON_EXPRESSION             = "无效的 Cron 表达式"
+	NOTIFY_CHANNEL_NAME_CANNOT_BE_EMPTY = "未填写通知渠道名称"
+	INVALID_NOTIFY_CHANNEL              = "通知渠道配置无效"
+	NOTIFY_CHANNEL_TEST_FAILED          = "通知渠道测试发送失败"
 )
 
 // Snapshot 错误相关常量
@@ -148,6 +160,7 @@ const (
 // Migration 错误相关常量
 const (
 	MIGRATION_JOB_NOT_FOUND = "迁移任务不存在"
+	PUBLISH_TARGET_LOCAL    = "本机目录与本地 git 仓库只能通过 ech0 build --publish 发布"
 )
 
 // Dashboard 错误相关常量
@@ -162,4 +175,7 @@ const (
 	AGENT_API_KEY_MISSING    = "未配置 Agent API Key 或 API Key 为空"
 	AGENT_MODEL_MISSING      = "未配置 Agent 模型名称或模型名称不能为空"
 	AGENT_SETTING_NOT_FOUND  = "未找到 Agent 设置"
+	CHAT_ACTION_NOT_FOUND    = "待确认的操作不存在或已过期"
+	LOCAL_MODEL_MISSING      = "未指定要拉取的本地模型名称"
+	SUGGESTION_NOT_FOUND     = "建议不存在或已处理"
 )
@@ -32,17 +32,23 @@ const (
 	GET_HOT_ECHOS_SUCCESS         = "获取热门Echos成功"
 	GET_RANDOM_ECHO_SUCCESS       = "随机获取Echo成功"
 	GET_ON_THIS_DAY_ECHOS_SUCCESS = "获取那年今日Echos成功"
+	SEARCH_ECHOS_SUCCESS          = "搜索Echos成功"
+	GET_RELATED_ECHOS_SUCCESS     = "获取相关Echos成功"
 )
 
 // Common 成功相关常量
 const (
-	UPLOAD_SUCCESS             = "上传成功"
-	DELETE_SUCCESS             = "删除成功"
-	GET_HEATMAP_SUCCESS        = "获取热力图成功"
-	GET_HELLO_SUCCESS          = "获取Hello成功"
-	GET_HEALTHZ_SUCCESS        = "健康检查"
-	GET_S3_PRESIGN_URL_SUCCESS = "获取 S3 预签名 URL 成功"
-	GET_WEBSITE_TITLE_SUCCESS  = "获取网站标题成功"
+	UPLOAD_SUCCESS                   = "上传成功"
+	DELETE_SUCCESS                   = "删除成功"
+	GET_HEATMAP_SUCCESS              = "获取热力图成功"
+	GET_HELLO_SUCCESS                = "获取Hello成功"
+	GET_HEALTHZ_SUCCESS              = "健康检查"
+	GET_S3_PRESIGN_URL_SUCCESS       = "获取 S3 预签名 URL 成功"
+	SUBMIT_STORAGE_MIGRATION_SUCCESS = "已提交存储迁移作业"
+	SUBMIT_FILE_DEDUPE_SUCCESS       = "已提交文件去重作业"
+	GET_STORAGE_USAGE_SUCCESS        = "获取存储用量成功"
+	RECOUNT_STORAGE_USAGE_SUCCESS    = "存储用量已重新统计"
+	GET_WEBSITE_TITLE_SUCCESS        = "获取网站标题成功"
 )
 
 // Setting 成功相关常量
@@ -62,11 +68,19 @@ const (
 	UPDATE_WEBHOOK_SUCCESS          = "更新 Webhook 成功"
 	CREATE_WEBHOOK_SUCCESS          = "创建 Webhook 成功"
 	TEST_WEBHOOK_SUCCESS            = "测试 Webhook 成功"
+	GET_NOTIFY_CHANNEL_SUCCESS      = "获取通知渠道成功"
+	CREATE_NOTIFY_CHANNEL_SUCCESS   = "创建通知渠道成功"
+	UPDATE_NOTIFY_CHANNEL_SUCCESS   = "更新通知渠道成功"
+	DELETE_NOTIFY_CHANNEL_SUCCESS   = "删除通知渠道成功"
+	TEST_NOTIFY_CHANNEL_SUCCESS     = "测试通知发送成功"
+	GET_NOTIFY_DELIVERIES_SUCCESS   = "获取通知投递日志成功"
 	TEST_S3_CONNECTION_SUCCESS      = "S3 存储连接测试成功"
 	LIST_ACCESS_TOKENS_SUCCESS      = "列出访问令牌成功"
 	CREATE_ACCESS_TOKEN_SUCCESS     = "创建访问令牌成功"
 	DELETE_ACCESS_TOKEN_SUCCESS     = "删除访问令牌成功"
 	SCHEDULE_SNAPSHOT_SUCCESS       = "设置定时快照计划成功"
+	UPDATE_DIGEST_SUCCESS           = "更新定期摘要设置成功"
+	SEND_DIGEST_SUCCESS             = "摘要邮件已发送"
 )
 
 // User 成功相关常量
@@ -106,4 +120,9 @@ const (
 const (
 	CHAT_SESSION_GET_SUCCESS   = "获取会话成功"
 	CHAT_SESSION_CLEAR_SUCCESS = "清除会话成功"
+	CHAT_ACTION_DECIDE_SUCCESS = "已提交操作决定"
+	CHAT_ACTION_LIST_SUCCESS   = "获取操作记录成功"
+	SUGGESTION_LIST_SUCCESS    = "获取建议成功"
+	SUGGESTION_APPLY_SUCCESS   = "已应用建议"
+	SUGGESTION_DISMISS_SUCCESS = "已忽略建议"
 )
@@ -24,6 +24,9 @@ type Echo struct {
 	Tags      []Tag          `gorm:"many2many:echo_tags;"                          json:"tags,omitempty"`
 	FavCount  int            `gorm:"default:0"                                     json:"fav_count"`
 	CreatedAt int64          `gorm:"autoCreateTime;index:idx_echos_private_created,priority:2" json:"created_at"`
+	// UpdatedAt 是内容最后一次被编辑的时刻，供实例间同步按时间水位取增量、判冲突。
+	// 点赞走 UpdateColumn 不触发它；早于该列上线的行为 0，读方按 CreatedAt 兜底。
+	UpdatedAt int64 `gorm:"autoUpdateTime;index" json:"updated_at"`
 }
 
 type EchoExtension struct {
@@ -49,3 +49,20 @@ type IndexState struct {
 	Model string `json:"model"`
 	Dim   int    `json:"dim"`
 }
+
+// ModelPullPayload 是本地模型拉取作业的输入：向哪个 Ollama 端点拉哪个模型。
+// BaseURL 为空即 Ollama 缺省地址。
+type ModelPullPayload struct {
+	BaseURL string `json:"base_url,omitempty"`
+	Model   string `json:"model"`
+}
+
+// ModelPullProgress 是拉取作业的进度快照与终态结果。Total/Completed 为当前分层的字节数，
+// 仅在下载分层时非零；Status 原样取 Ollama 的进度文案（pulling manifest / success 等）。
+type ModelPullProgress struct {
+	Model     string `json:"model"`
+	Status    string `json:"status"`
+	Digest    string `json:"digest,omitempty"`
+	Total     int64  `json:"total,omitempty"`
+	Completed int64  `json:"completed,omitempty"`
+}
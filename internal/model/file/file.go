@@ -29,12 +29,14 @@ func RegisterURLResolver(fn func(storageType, key string) string) {
 type File struct {
 	ID string `gorm:"type:char(36);primaryKey" json:"id"`
 
-	// 存储键（本地文件名或对象存储 object key）
-	Key string `gorm:"type:varchar(500);not null;uniqueIndex:idx_file_route,priority:4" json:"key"`
+	// 存储键（本地文件名或对象存储 object key）。内容去重后同一路由上的多行可共享一个 key，
+	// 存储对象的引用计数即共享它的行数，见 FileService.DeleteStoredFile。
+	Key string `gorm:"type:varchar(500);not null;index:idx_file_route,priority:4" json:"key"`
 
-	StorageType string `gorm:"type:varchar(20);not null;uniqueIndex:idx_file_route,priority:1" json:"storage_type"` // local|object|external
-	Provider    string `gorm:"type:varchar(50);uniqueIndex:idx_file_route,priority:2" json:"provider,omitempty"`    // object 提供商，如 aws/r2/minio/external
-	Bucket      string `gorm:"type:varchar(120);uniqueIndex:idx_file_route,priority:3" json:"bucket,omitempty"`     // local/external 可空
+	StorageType string `gorm:"type:varchar(20);not null;index:idx_file_route,priority:1" json:"storage_type"` // local|object|external
+	Provider    string `gorm:"type:varchar(50);index:idx_file_route,priority:2" json:"provider,omitempty"`    // object 提供商，如 aws/r2/minio/external
+	Bucket      string `gorm:"type:varchar(120);index:idx_file_route,priority:3" json:"bucket,omitempty"`     // local/external 可空
+	Hash        string `gorm:"type:char(64);index" json:"hash,omitempty"`                                     // 内容 SHA-256（hex），external 与尚未回填的行为空
 
 	URL         string `gorm:"type:text" json:"url"` // 前端直链快照
 	Name        string `gorm:"type:varchar(255)" json:"name"`
@@ -42,6 +44,7 @@ type File struct {
 	Size        int64  `gorm:"default:0" json:"size"`
 	Width       int    `gorm:"default:0" json:"width,omitempty"`
 	Height      int    `gorm:"default:0" json:"height,omitempty"`
+	AltText     string `gorm:"type:text" json:"alt_text,omitempty"` // 图片替代文本，可由 Agent 自动生成
 
 	Category  string `gorm:"type:varchar(20);index" json:"category"` // image|video|audio|pdf|markdown|file，见 storage.Category
 	UserID    string `gorm:"type:char(36);index;not null" json:"user_id"`
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package model
+
+// StorageRoute 是文件行的路由列，与 Key 一起构成索引 idx_file_route。
+type StorageRoute struct {
+	StorageType string
+	Provider    string
+	Bucket      string
+}
+
+// StorageMigrationPayload 是存储迁移作业的输入：把 From 路由上的全部受管文件搬到 To。
+// object 端的端点与凭据取当前 S3 设置；FromBucket 非空时从该桶搬出（同账号换桶），
+// 目标桶恒为当前设置里的桶。
+type StorageMigrationPayload struct {
+	From         string `json:"from"`                  // local|object
+	FromBucket   string `json:"from_bucket,omitempty"` // 仅 From=object 有意义
+	To           string `json:"to"`                    // local|object
+	DeleteSource bool   `json:"delete_source,omitempty"`
+}
+
+// FileJobFailure 记录文件类作业（存储迁移、内容去重）中未能处理的单个文件，该文件行保持原样。
+type FileJobFailure struct {
+	FileID string `json:"file_id"`
+	Key    string `json:"key"`
+	Error  string `json:"error"`
+}
+
+// StorageMigrationProgress 是迁移作业的进度快照与终态结果。Total 为开始时源路由上的文件数；
+// Reused 是目标已存在同内容对象、免拷贝直接改写的文件数（续跑时常见）；Failures 只保留前若干条。
+type StorageMigrationProgress struct {
+	Total    int              `json:"total"`
+	Migrated int              `json:"migrated"`
+	Reused   int              `json:"reused"`
+	Failed   int              `json:"failed"`
+	Bytes    int64            `json:"bytes"`
+	Current  string           `json:"current,omitempty"`
+	Failures []FileJobFailure `json:"failures,omitempty"`
+}
+
+// ContentGroup 标识一组内容相同（同一路由、同一 SHA-256）却存成了多个 key 的文件行。
+type ContentGroup struct {
+	StorageType string
+	Bucket      string
+	Hash        string
+}
+
+// FileDedupeProgress 是内容去重作业的进度快照与终态结果。作业分两段：先为缺哈希的受管文件
+// 回填 SHA-256（Hashed；对象已丢失的记入 Missing），再把每个重复组的行改指向组内最早的 key
+// （Relinked），不再被引用的冗余对象随即删除（Freed / FreedBytes）。
+type FileDedupeProgress struct {
+	Hashed     int              `json:"hashed"`
+	Missing    int              `json:"missing"`
+	Groups     int              `json:"groups"`
+	Relinked   int              `json:"relinked"`
+	Freed      int              `json:"freed"`
+	FreedBytes int64            `json:"freed_bytes"`
+	Failed     int              `json:"failed"`
+	Current    string           `json:"current,omitempty"`
+	Failures   []FileJobFailure `json:"failures,omitempty"`
+}
@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package model
+
+import "time"
+
+// ResumableUploadCreate 是创建断点续传会话的输入，对应 tus 的 Upload-Length 与 Upload-Metadata。
+type ResumableUploadCreate struct {
+	Filename    string
+	Category    string
+	StorageType string
+	Length      int64
+}
+
+// ResumableUpload 是一次断点续传（tus）会话的状态。会话以 JSON 存在上传暂存目录的
+// {id}.info，已收到的字节追加在 {id}.part；Offset 取 .part 的实际大小，进程中途崩溃也不会错位。
+// FileID 非空表示字节已收齐、内容已写入存储并建好了 File + TempFile 记录。
+type ResumableUpload struct {
+	ID          string    `json:"id"`
+	Filename    string    `json:"filename"`
+	Category    string    `json:"category"`
+	StorageType string    `json:"storage_type"`
+	Length      int64     `json:"length"`
+	Offset      int64     `json:"-"`
+	UserID      string    `json:"user_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	FileID      string    `json:"file_id,omitempty"`
+}
+
+// Completed 报告会话是否已收齐全部字节并完成入库。
+func (u ResumableUpload) Completed() bool {
+	return u.FileID != ""
+}
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package model
+
+// StorageUsage 是存储配额的用量账本：某用户在某分类下受管文件（local/object）的字节数与文件数。
+// 上传、删除时增量记账；外链文件不占空间，不入账。账本与 files 表可能因导入、恢复等绕过
+// FileService 的写入而漂移，由定时重算任务按实际文件行校正。
+type StorageUsage struct {
+	UserID    string `gorm:"type:char(36);primaryKey"  json:"user_id"`
+	Category  string `gorm:"type:varchar(20);primaryKey" json:"category"`
+	Bytes     int64  `gorm:"not null;default:0"         json:"bytes"`
+	Files     int64  `gorm:"not null;default:0"         json:"files"`
+	UpdatedAt int64  `gorm:"autoUpdateTime"             json:"updated_at"`
+}
+
+// CategoryUsage 是用量报表中单个分类的用量。
+type CategoryUsage struct {
+	Category string `json:"category"`
+	Bytes    int64  `json:"bytes"`
+	Files    int64  `json:"files"`
+}
+
+// UserStorageUsage 是用量报表中的一个用户：总用量、生效额度（0 不限）与按分类的明细。
+type UserStorageUsage struct {
+	UserID     string          `json:"user_id"`
+	Username   string          `json:"username"`
+	Role       string          `json:"role"` // owner|admin|user
+	Quota      int64           `json:"quota"`
+	Used       int64           `json:"used"`
+	Files      int64           `json:"files"`
+	Categories []CategoryUsage `json:"categories"`
+}
+
+// StorageUsageReport 是管理员查看的全站用量报表，按用量从高到低排列。
+type StorageUsageReport struct {
+	Users []UserStorageUsage `json:"users"`
+	Used  int64              `json:"used"`
+	Files int64              `json:"files"`
+}
+
+// StorageUsageRecount 是一次用量重算的结果：Checked 为按文件行统计出的 (用户, 分类) 条数，
+// Corrected 为账本中与之不符（含多余、缺失）而被改写的条数。
+type StorageUsageRecount struct {
+	Checked   int `json:"checked"`
+	Corrected int `json:"corrected"`
+}
@@ -23,28 +23,49 @@ func (s Status) IsTerminal() bool {
 	return s == StatusSuccess || s == StatusFailed || s == StatusCancelled
 }
 
-// 作业类型常量：作为 Job 主键 Type 的取值，供 handler/runner 共用。
+// 作业类型常量：作为 Job.Type 的取值，供 handler/runner 共用。
 const (
-	TypeReindex   = "reindex"
-	TypeMigration = "migration"
-	TypeExport    = "export"
+	TypeReindex          = "reindex"
+	TypeMigration        = "migration"
+	TypeExport           = "export"
+	TypeSync             = "sync"
+	TypePublish          = "publish"
+	TypeModelPull        = "model_pull"
+	TypeStorageMigration = "storage_migration"
+	TypeFileDedupe       = "file_dedupe"
+	TypeWebmention       = "webmention"
 )
 
-// Job 是通用作业的持久化行。主键即 Type，结构性保证「每类型单行」：新一次 Submit
-// upsert 覆盖旧终态行，无 id、无历史。领域专属的输入/进度/结果序列化进 Payload(JSON)，
-// 框架不解析它，只有对应 Runner 与前端认得。
+// Job 是一次作业提交的持久化行：每次 Submit 新建一行（ID 为 UUIDv7，天然按提交先后有序），
+// 同类型的多次运行因此留下历史，排队中的提交也以 pending 行的形式落库、重启不丢。
+// 领域专属的输入/进度/结果序列化进 Payload(JSON)，框架不解析它，只有对应 Runner 与前端认得。
+// 未到终态前 Payload 保持为提交时的输入，重启后的续跑即凭它重放。
 type Job struct {
-	Type       string `gorm:"primaryKey;size:64"      json:"type"`
-	Status     Status `gorm:"type:varchar(32);index"  json:"status"`
-	Phase      string `gorm:"type:varchar(64)"        json:"phase"`
-	Error      string `gorm:"type:text"               json:"error"`
-	Payload    string `gorm:"type:text"               json:"payload"`
-	StartedAt  *int64 `                               json:"started_at"`
-	FinishedAt *int64 `                               json:"finished_at"`
-	UpdatedAt  int64  `gorm:"autoUpdateTime"          json:"updated_at"`
+	ID         string `gorm:"primaryKey;size:36"          json:"id"`
+	Type       string `gorm:"size:64;index"               json:"type"`
+	Status     Status `gorm:"type:varchar(32);index"      json:"status"`
+	Phase      string `gorm:"type:varchar(64)"            json:"phase"`
+	Error      string `gorm:"type:text"                   json:"error"`
+	Payload    string `gorm:"type:text"                   json:"payload"`
+	Attempts   int    `gorm:"not null;default:0"          json:"attempts"`
+	Dismissed  bool   `gorm:"not null;default:false"      json:"dismissed"`
+	CreatedAt  int64  `gorm:"autoCreateTime"              json:"created_at"`
+	StartedAt  *int64 `                                   json:"started_at"`
+	FinishedAt *int64 `                                   json:"finished_at"`
+	UpdatedAt  int64  `gorm:"autoUpdateTime"              json:"updated_at"`
 }
 
-// TableName 固定表名为 jobs。
+// Duration 返回已结束作业的耗时（秒）；尚未开始或未结束时为 nil。
+func (j Job) Duration() *int64 {
+	if j.StartedAt == nil || j.FinishedAt == nil {
+		return nil
+	}
+	d := *j.FinishedAt - *j.StartedAt
+	return &d
+}
+
+// TableName 固定表名为 job_runs。旧的 jobs 表（主键即 type、每类型单行）由
+// database/migration 的 jobs drop 迁移器删除。
 func (Job) TableName() string {
-	return "jobs"
+	return "job_runs"
 }
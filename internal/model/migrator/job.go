@@ -3,10 +3,14 @@
 
 package model
 
-// 导入来源类型(对应 importer/ 下的适配器)。
+// 导入来源类型(对应 importer/ 下的适配器)。markdown / twitter / mastodon 是按条追加的
+// 内容导入,按来源 id 去重,重复执行安全。
 const (
-	MigrationSourceEch0  = "ech0"
-	MigrationSourceMemos = "memos"
+	MigrationSourceEch0     = "ech0"
+	MigrationSourceMemos    = "memos"
+	MigrationSourceMarkdown = "markdown"
+	MigrationSourceTwitter  = "twitter"
+	MigrationSourceMastodon = "mastodon"
 )
 
 // 导出目的地类型(对应 exporter/ 下的适配器):fs=本地目录,s3=对象存储。
@@ -56,3 +60,35 @@ const (
 
 // MigrationSourceCapsule 是胶囊导入的来源标识(与 ech0/memos 并列,走 source_type 分派)。
 const MigrationSourceCapsule = "capsule"
+
+// 同步方向。pull 只把对端变更拉进本库，push 只把本库变更推给对端，both 先拉后推。
+const (
+	SyncDirectionPull = "pull"
+	SyncDirectionPush = "push"
+	SyncDirectionBoth = "both"
+)
+
+// 归档加密方式。passphrase 用口令（age scrypt），recipient 用一个或多个 age 公钥（X25519）——
+// 后者服务端只存公钥，解密须由持有私钥的人提供身份文件。
+const (
+	EncryptionModePassphrase = "passphrase"
+	EncryptionModeRecipient  = "recipient"
+)
+
+// 同步阶段。
+const (
+	SyncPhasePulling   = "pulling"
+	SyncPhasePushing   = "pushing"
+	SyncPhaseCompleted = "completed"
+)
+
+// SyncCursorHeader 是拉取端点回传对端时钟的响应头：增量胶囊本身就是响应体，水位只能走头部。
+const SyncCursorHeader = "X-Ech0-Sync-Cursor"
+
+// 静态站发布阶段：导出胶囊 → 烘焙站点 → 上传到发布目标。
+const (
+	PublishPhaseExporting = "exporting"
+	PublishPhaseBuilding  = "building"
+	PublishPhaseUploading = "uploading"
+	PublishPhaseCompleted = "completed"
+)
@@ -65,3 +65,158 @@ type ExportStateDTO struct {
 	UpdatedAt  *int64 `json:"updated_at,omitempty"`
 	FinishedAt *int64 `json:"finished_at,omitempty"`
 }
+
+// SnapshotEntry 是 GET /migration/snapshots 列出的一份本地留存快照。Name 即下载（?name=）
+// 与恢复（/migration/snapshots/{name}/restore）时引用它的标识。
+type SnapshotEntry struct {
+	Name      string `json:"name"`
+	Size      int64  `json:"size"`
+	CreatedAt int64  `json:"created_at"`
+	// Encrypted 表示这份快照是加密信封，恢复时需要口令或身份文件。
+	Encrypted bool `json:"encrypted"`
+}
+
+// EncryptionSetting 是快照与胶囊归档的加密配置（durableKV 键 archive_encryption）。开启后导出
+// 产物是「明文清单 + age 密文」的信封 zip，格式见 internal/migrator/envelope。
+//
+// Passphrase 的出参脱敏同 SyncSetting.AccessToken：读出时清空并置 PassphraseSet，写入时留空即
+// 保留原值。服务端留着口令是为了定时快照无人值守也能加密，恢复时也可缺省用它解密；recipient 模式
+// 下服务端只有公钥，丢了私钥的归档谁也打不开。
+type EncryptionSetting struct {
+	Enable bool `json:"enable"`
+	// Mode 取 EncryptionMode*，缺省 passphrase。
+	Mode          string `json:"mode"`
+	Passphrase    string `json:"passphrase,omitempty"`
+	PassphraseSet bool   `json:"passphrase_set,omitempty"`
+	// Recipients 是 age1… 公钥，每项一个。
+	Recipients []string `json:"recipients"`
+}
+
+// ArchiveKeys 是解密一份加密归档的凭据：口令与 age 身份文件内容（AGE-SECRET-KEY-1…）二选一，
+// 都留空时服务端回落到已存的口令。
+type ArchiveKeys struct {
+	Passphrase string `json:"passphrase,omitempty"`
+	Identity   string `json:"identity,omitempty"`
+}
+
+// SyncSetting 是实例间同步的对端配置（durableKV 键 sync_setting）。对端凭据只落在这里，
+// 不进作业 payload——失败的作业行会原样保留 payload，令牌不该跟着躺在 jobs 表里。
+//
+// AccessToken 的出参脱敏沿用评论 SMTP 密码的做法：读出时清空并置 AccessTokenSet，
+// 写入时留空即保留原值。
+type SyncSetting struct {
+	// Enable 只管定时同步；手动触发不看它，配了对端即可跑。
+	Enable         bool   `json:"enable"`
+	PeerURL        string `json:"peer_url"`
+	AccessToken    string `json:"access_token,omitempty"`
+	AccessTokenSet bool   `json:"access_token_set,omitempty"`
+	// Direction 取 SyncDirection*，缺省 both。
+	Direction      string `json:"direction"`
+	IncludePrivate bool   `json:"include_private"`
+	// ConflictPolicy 取 newer / local / remote，缺省 newer，语义见 internal/capsule/replica。
+	ConflictPolicy  string `json:"conflict_policy"`
+	IntervalMinutes int    `json:"interval_minutes"`
+	// AllowPrivatePeer 放行指向内网 / 本机的对端（如同一内网里的预发布实例）；默认拒绝。
+	AllowPrivatePeer bool `json:"allow_private_peer"`
+}
+
+// SyncPayload 是同步作业的领域 payload。对端配置一律现读 SyncSetting，这里只记触发来源，
+// 便于区分手动与定时。
+type SyncPayload struct {
+	Trigger string `json:"trigger,omitempty"`
+}
+
+// SyncCursor 是与某个对端的同步水位，两端各用自己的时钟：Local 供本地判冲突与导出增量，
+// Remote 原样回传给对端。
+type SyncCursor struct {
+	Local  int64 `json:"local"`
+	Remote int64 `json:"remote"`
+}
+
+// SyncApplyResult 是一端合并增量胶囊后的报告，推送端点原样返回它。Cursor 是合并开始前
+// 该端的时钟，发起方把它存作下一轮的 Remote 水位。
+type SyncApplyResult struct {
+	Cursor          int64          `json:"cursor"`
+	EchoesCreated   int            `json:"echoes_created"`
+	EchoesUpdated   int            `json:"echoes_updated"`
+	EchoesDeleted   int            `json:"echoes_deleted"`
+	CommentsCreated int            `json:"comments_created"`
+	CommentsUpdated int            `json:"comments_updated"`
+	CommentsDeleted int            `json:"comments_deleted"`
+	FilesCreated    int            `json:"files_created"`
+	Conflicts       []SyncConflict `json:"conflicts"`
+}
+
+// SyncConflict 是一起冲突，字段与 replica.Conflict 对齐；模型层不反向依赖胶囊包，故单列。
+type SyncConflict struct {
+	Kind            string `json:"kind"`
+	ID              string `json:"id"`
+	LocalUpdatedAt  int64  `json:"local_updated_at"`
+	RemoteUpdatedAt int64  `json:"remote_updated_at"`
+	Deleted         string `json:"deleted,omitempty"`
+	Resolution      string `json:"resolution"`
+}
+
+// SyncReport 是同步作业的终态 result：两个方向各一份合并报告，未跑的方向为 nil。
+type SyncReport struct {
+	PeerURL string           `json:"peer_url"`
+	Pulled  *SyncApplyResult `json:"pulled,omitempty"`
+	Pushed  *SyncApplyResult `json:"pushed,omitempty"`
+}
+
+// SyncStateDTO 是同步作业对前端的状态契约，与 ExportStateDTO 对称。Report 在终态成功时
+// 由作业 Payload 补出，冲突明细就在其中。
+type SyncStateDTO struct {
+	Version      int         `json:"version"`
+	Status       string      `json:"status"`
+	Phase        string      `json:"phase,omitempty"`
+	ErrorMessage string      `json:"error_message"`
+	Report       *SyncReport `json:"report,omitempty"`
+	StartedAt    *int64      `json:"started_at,omitempty"`
+	UpdatedAt    *int64      `json:"updated_at,omitempty"`
+	FinishedAt   *int64      `json:"finished_at,omitempty"`
+}
+
+// PublishSetting 是静态站自动发布的配置（durableKV 键 publish_setting）。Target 语法见
+// internal/capsule/publish：s3://bucket/prefix?endpoint=…、git+<remote>#branch 或本地目录。
+//
+// SecretKey 的出参脱敏同 SyncSetting.AccessToken：读出时清空并置 SecretKeySet，写入时留空即保留原值。
+// AccessKey / SecretKey 都留空时 S3 走 SDK 默认凭据链；git 目标的鉴权沿用运行用户的 git 配置。
+type PublishSetting struct {
+	// Enable 只管「内容变了自动重发」；手动触发不看它，配了目标即可跑。
+	Enable       bool   `json:"enable"`
+	Target       string `json:"target"`
+	BaseURL      string `json:"base_url"`
+	AccessKey    string `json:"access_key"`
+	SecretKey    string `json:"secret_key,omitempty"`
+	SecretKeySet bool   `json:"secret_key_set,omitempty"`
+	// DebounceSeconds 是最后一次内容变更后等多久再重发，连发几条只触发一次；缺省 60。
+	DebounceSeconds int `json:"debounce_seconds"`
+}
+
+// PublishPayload 是发布作业的领域 payload，只记触发来源；目标与凭据一律现读 PublishSetting。
+type PublishPayload struct {
+	Trigger string `json:"trigger,omitempty"`
+}
+
+// PublishReport 是发布作业的终态 result。
+type PublishReport struct {
+	Target    string `json:"target"`
+	Echoes    int    `json:"echoes"`
+	Pages     int    `json:"pages"`
+	Uploaded  int    `json:"uploaded"`
+	Deleted   int    `json:"deleted"`
+	Unchanged int    `json:"unchanged"`
+}
+
+// PublishStateDTO 是发布作业对前端的状态契约，与 SyncStateDTO 对称。
+type PublishStateDTO struct {
+	Version      int            `json:"version"`
+	Status       string         `json:"status"`
+	Phase        string         `json:"phase,omitempty"`
+	ErrorMessage string         `json:"error_message"`
+	Report       *PublishReport `json:"report,omitempty"`
+	StartedAt    *int64         `json:"started_at,omitempty"`
+	UpdatedAt    *int64         `json:"updated_at,omitempty"`
+	FinishedAt   *int64         `json:"finished_at,omitempty"`
+}
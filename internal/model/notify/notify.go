@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package model
+
+import (
+	"slices"
+
+	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
+	"gorm.io/gorm"
+)
+
+// 通知渠道类型
+const (
+	ChannelNtfy     = "ntfy"
+	ChannelGotify   = "gotify"
+	ChannelBark     = "bark"
+	ChannelTelegram = "telegram"
+	ChannelMatrix   = "matrix"
+	ChannelHTTP     = "http"
+)
+
+// 渠道可订阅的通知事件
+const (
+	EventCommentNew      = "comment.new"      // 新评论（已直接通过审核）
+	EventCommentPending  = "comment.pending"  // 新评论待审核
+	EventSnapshotFailed  = "snapshot.failed"  // 快照 / 导出失败
+	EventWebhookDisabled = "webhook.disabled" // Webhook 被停用
+	EventLoginNewDevice  = "login.new_device" // 新设备登录
+	EventTest            = "test"             // 设置页的测试发送，只记投递日志，不可订阅
+)
+
+// 投递状态
+const (
+	DeliverySuccess = "success"
+	DeliveryFailed  = "failed"
+)
+
+// ChannelTypes 是全部受支持的渠道类型。
+var ChannelTypes = []string{
+	ChannelNtfy, ChannelGotify, ChannelBark, ChannelTelegram, ChannelMatrix, ChannelHTTP,
+}
+
+// Events 是渠道可订阅的全部事件。
+var Events = []string{
+	EventCommentNew, EventCommentPending, EventSnapshotFailed, EventWebhookDisabled, EventLoginNewDevice,
+}
+
+// NotifyChannel 定义通知渠道实体。Config 按 Type 存放各渠道自己的参数（服务地址、令牌等），
+// 其中的密钥项在返回前端前会被清空，见 service 层。
+type NotifyChannel struct {
+	ID           string            `gorm:"type:char(36);primaryKey" json:"id"`            // 渠道 ID
+	Name         string            `                                json:"name"`          // 渠道名称
+	Type         string            `                                json:"type"`          // 渠道类型（ntfy/gotify/bark/telegram/matrix/http）
+	Config       map[string]string `gorm:"serializer:json"          json:"config"`        // 渠道参数
+	Events       []string          `gorm:"serializer:json"          json:"events"`        // 订阅的事件
+	IsActive     bool              `gorm:"not null"                 json:"is_active"`     // 启用/禁用状态（不设列默认值，新建时停用也能落库）
+	BlockPrivate bool              `gorm:"not null;default:false"   json:"block_private"` // 拒绝指向内网 / 本机的地址（SSRF 防护），默认关闭
+	LastStatus   string            `                                json:"last_status"`   // 最近投递状态（success, failed）
+	LastTrigger  int64             `                                json:"last_trigger"`  // 最近投递时间
+	CreatedAt    int64             `gorm:"autoCreateTime"           json:"created_at"`    // 创建时间
+	UpdatedAt    int64             `gorm:"autoUpdateTime"           json:"updated_at"`    // 更新时间
+}
+
+func (c *NotifyChannel) BeforeCreate(_ *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = uuidUtil.MustNewV7()
+	}
+	return nil
+}
+
+// Subscribes 判断渠道是否订阅了某个事件。
+func (c *NotifyChannel) Subscribes(event string) bool {
+	return slices.Contains(c.Events, event)
+}
+
+// NotifyDelivery 是一次通知投递的日志，正式投递与测试发送都会记录。
+type NotifyDelivery struct {
+	ID          string `gorm:"type:char(36);primaryKey" json:"id"`
+	ChannelID   string `gorm:"type:char(36);index"      json:"channel_id"`   // 渠道 ID
+	ChannelName string `                                json:"channel_name"` // 投递时的渠道名称
+	Event       string `                                json:"event"`        // 通知事件
+	Title       string `                                json:"title"`        // 通知标题
+	Status      string `                                json:"status"`       // 投递状态（success, failed）
+	Error       string `                                json:"error"`        // 失败原因
+	DurationMs  int64  `                                json:"duration_ms"`  // 耗时（毫秒）
+	CreatedAt   int64  `gorm:"autoCreateTime;index"     json:"created_at"`   // 投递时间
+}
+
+func (d *NotifyDelivery) BeforeCreate(_ *gorm.DB) error {
+	if d.ID == "" {
+		d.ID = uuidUtil.MustNewV7()
+	}
+	return nil
+}
+
+// NotifyChannelDto 定义通知渠道的创建/更新参数。更新时 Config 中留空的密钥项沿用原值。
+type NotifyChannelDto struct {
+	Name         string            `json:"name"`          // 渠道名称
+	Type         string            `json:"type"`          // 渠道类型
+	Config       map[string]string `json:"config"`        // 渠道参数
+	Events       []string          `json:"events"`        // 订阅的事件
+	IsActive     bool              `json:"is_active"`     // 启用/禁用状态
+	BlockPrivate bool              `json:"block_private"` // 拒绝指向内网 / 本机的地址
+}
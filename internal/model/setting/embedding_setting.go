@@ -3,12 +3,18 @@
 
 package model
 
+// Embedding 提供商取值。缺省（空串，旧配置）即 OpenAI 兼容。
+const (
+	EmbeddingProviderOpenAI   = "openai"   // OpenAI 兼容 /v1/embeddings（OpenAI、Qwen/DashScope、Jina 等）
+	EmbeddingProviderOllama   = "ollama"   // Ollama 原生 /api/embed
+	EmbeddingProviderLlamaCpp = "llamacpp" // llama.cpp server 原生 /embedding
+	EmbeddingProviderHash     = "hash"     // 进程内特征哈希，零外部依赖（测试与小实例）
+)
+
 // EmbeddingSetting 定义向量 Embedding 设置实体（独立于 Agent 的生成 LLM 配置）。
-//
-// v1 仅支持 OpenAI 兼容的 /v1/embeddings 接口（覆盖 OpenAI、Qwen/DashScope、
-// Ollama、Jina 等绝大多数提供商），因此不带 Protocol 字段。
 type EmbeddingSetting struct {
 	Enable    bool   `json:"enable"`     // 是否启用 Embedding（Chat 检索的前置条件）
+	Provider  string `json:"provider"`   // 提供商，见 EmbeddingProvider* 常量；空串按 OpenAI 兼容处理
 	Model     string `json:"model"`      // Embedding 模型名，如 text-embedding-3-small
 	ApiKey    string `json:"api_key"`    // API Key（本地服务如 Ollama 可留空）
 	BaseURL   string `json:"base_url"`   // 自定义 API URL（可选）
@@ -16,9 +22,15 @@ type EmbeddingSetting struct {
 	BatchSize int    `json:"batch_size"` // 单次请求最多向量化的文本条数（0=用默认值）；部分提供商限制 64/25 条
 }
 
+// Active 报告 Embedding 是否实际可用：开关打开且模型与维度已配置。
+func (s EmbeddingSetting) Active() bool {
+	return s.Enable && s.Model != "" && s.Dim > 0
+}
+
 // EmbeddingSettingDto 是更新 Embedding 设置的入参
 type EmbeddingSettingDto struct {
 	Enable    bool   `json:"enable"`
+	Provider  string `json:"provider"`
 	Model     string `json:"model"`
 	ApiKey    string `json:"api_key"`
 	BaseURL   string `json:"base_url"`
@@ -101,7 +101,7 @@ func (a *AccessTokenSetting) BeforeCreate(_ *gorm.DB) error {
 // AgentSetting 定义 LLM Agent 设置实体
 type AgentSetting struct {
 	Enable     bool   `json:"enable"`     // 是否启用 Agent 功能
-	Protocol   string `json:"protocol"`   // LLM 接口协议（OpenAI 兼容/Anthropic，OpenAI 兼容覆盖 DeepSeek、Qwen、Ollama 等）
+	Protocol   string `json:"protocol"`   // LLM 接口协议（OpenAI 兼容/Anthropic/Ollama 原生，OpenAI 兼容覆盖 DeepSeek、Qwen、llama.cpp 等）
 	Model      string `json:"model"`      // LLM 模型名称
 	ApiKey     string `json:"api_key"`    // LLM API Key
 	Prompt     string `json:"prompt"`     // Agent 额外使用的提示词
@@ -111,9 +111,42 @@ type AgentSetting struct {
 	// 用于区间聚合（年终/月度总结）时的取数预算：窗口越大越倾向「一次塞满全部 Echo」，
 	// 越小越早转入按月 map-reduce 分层总结。前端以 256k/1m 形式填写、解析成 token 数后存此。
 	ContextWindow int `json:"context_window"`
+	// 新 Echo 发布后的自动增强：AutoTag 从已有标签中建议标签，AutoAltText 为配图生成替代文本（需开启 Multimodal）。
+	// AutoApply 为 true 时直接写回 Echo，否则暂存为建议，等待站长在 Copilot 中审核。
+	AutoTag     bool `json:"auto_tag"`
+	AutoAltText bool `json:"auto_alt_text"`
+	AutoApply   bool `json:"auto_apply"`
 }
 
 type SnapshotSchedule struct {
 	Enable         bool   `json:"enable"`          // 是否启用定时快照
 	CronExpression string `json:"cron_expression"` // 定时快照的 Cron 表达式
+	KeepDaily      int    `json:"keep_daily"`      // 按日保留的快照份数（每天最新一份）
+	KeepWeekly     int    `json:"keep_weekly"`     // 按周保留的快照份数（每个 ISO 周最新一份）
+	KeepMonthly    int    `json:"keep_monthly"`    // 按月保留的快照份数（每月最新一份）
+}
+
+// DigestSetting 是站长定期摘要邮件的计划与内容开关。摘要经评论系统的 SMTP 设置发往 owner 邮箱。
+type DigestSetting struct {
+	Enable         bool   `json:"enable"`          // 是否启用定期摘要
+	CronExpression string `json:"cron_expression"` // 发送时间的 Cron 表达式，按 Timezone 解释
+	Period         string `json:"period"`          // 统计窗口：daily（最近 24 小时）/ weekly（最近 7 天）
+	Timezone       string `json:"timezone"`        // Cron、邮件日期与「那年今日」使用的时区
+	OnThisDay      bool   `json:"on_this_day"`     // 是否附带「那年今日」
+	AISummary      bool   `json:"ai_summary"`      // 是否附带 Copilot 近期总结（需启用 Agent）
+}
+
+// DigestSetting.Period 的取值。
+const (
+	DigestPeriodDaily  = "daily"
+	DigestPeriodWeekly = "weekly"
+)
+
+// StorageQuotaSetting 是用户存储配额（字节，0 表示不限）。先按角色取默认额度，
+// UserQuotas 按用户 ID 覆盖角色默认（覆盖值为 0 即对该用户不限）。外链文件不占配额。
+type StorageQuotaSetting struct {
+	OwnerQuota int64            `json:"owner_quota"` // 站长的默认额度
+	AdminQuota int64            `json:"admin_quota"` // 管理员的默认额度
+	UserQuota  int64            `json:"user_quota"`  // 普通用户的默认额度
+	UserQuotas map[string]int64 `json:"user_quotas"` // 按用户 ID 的单独额度
 }
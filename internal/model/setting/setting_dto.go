@@ -87,6 +87,18 @@ type AccessTokenSettingDto struct {
 type SnapshotScheduleDto struct {
 	Enable         bool   `json:"enable"`          // 是否启用定时快照
 	CronExpression string `json:"cron_expression"` // 定时快照的 Cron 表达式
+	KeepDaily      int    `json:"keep_daily"`      // 按日保留的快照份数
+	KeepWeekly     int    `json:"keep_weekly"`     // 按周保留的快照份数
+	KeepMonthly    int    `json:"keep_monthly"`    // 按月保留的快照份数
+}
+
+type DigestSettingDto struct {
+	Enable         bool   `json:"enable"`          // 是否启用定期摘要
+	CronExpression string `json:"cron_expression"` // 发送时间的 Cron 表达式
+	Period         string `json:"period"`          // 统计窗口（daily/weekly）
+	Timezone       string `json:"timezone"`        // 时区（IANA 名称）
+	OnThisDay      bool   `json:"on_this_day"`     // 是否附带「那年今日」
+	AISummary      bool   `json:"ai_summary"`      // 是否附带 Copilot 近期总结
 }
 
 type AgentSettingDto struct {
@@ -98,5 +110,15 @@ type AgentSettingDto struct {
 	BaseURL    string `json:"base_url"`   // 自定义 API URL（可选）
 	Multimodal bool   `json:"multimodal"` // 多模态支持：检索命中带图 Echo 时把配图递给模型（需模型支持视觉）
 	// ContextWindow 是模型上下文窗口的 token 数（0=未配置，按保守默认处理）；用于区间聚合的取数预算。
-	ContextWindow int `json:"context_window"`
+	ContextWindow int  `json:"context_window"`
+	AutoTag       bool `json:"auto_tag"`      // 新 Echo 自动建议标签
+	AutoAltText   bool `json:"auto_alt_text"` // 新 Echo 配图自动生成替代文本（需开启多模态）
+	AutoApply     bool `json:"auto_apply"`    // 建议直接应用，否则等待审核
+}
+
+type StorageQuotaSettingDto struct {
+	OwnerQuota int64            `json:"owner_quota"` // 站长的默认额度（字节，0 不限）
+	AdminQuota int64            `json:"admin_quota"` // 管理员的默认额度（字节，0 不限）
+	UserQuota  int64            `json:"user_quota"`  // 普通用户的默认额度（字节，0 不限）
+	UserQuotas map[string]int64 `json:"user_quotas"` // 按用户 ID 的单独额度（字节，0 不限）
 }
@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"text/template"
+	"time"
+
+	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
+)
+
+const userAgent = "Ech0-Notify-Client"
+
+// defaultTemplate 是通用 HTTP 渠道未填写模板时的请求体。
+const defaultTemplate = `{"event":{{json .Event}},"title":{{json .Title}},"body":{{json .Body}},"url":{{json .URL}},"time":{{json .Time}}}`
+
+var templateFuncs = template.FuncMap{
+	// json 把值编码成 JSON 字面量，模板里拼 JSON 时用它转义字符串。
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// ntfy 通过 JSON 发布接口推送：https://docs.ntfy.sh/publish/#publish-as-json
+type ntfy struct {
+	client *http.Client
+	cfg    map[string]string
+}
+
+func (n *ntfy) Notify(ctx context.Context, msg Message) error {
+	payload := map[string]any{
+		"topic":   n.cfg["topic"],
+		"title":   msg.Title,
+		"message": msg.Body,
+	}
+	if msg.URL != "" {
+		payload["click"] = msg.URL
+	}
+	if p, err := strconv.Atoi(n.cfg["priority"]); err == nil && p > 0 {
+		payload["priority"] = p
+	}
+	headers := http.Header{}
+	if n.cfg["token"] != "" {
+		headers.Set("Authorization", "Bearer "+n.cfg["token"])
+	}
+	return postJSON(ctx, n.client, http.MethodPost, n.cfg["server"], headers, payload)
+}
+
+// gotify 调用应用消息接口：POST /message，应用令牌走 X-Gotify-Key。
+type gotify struct {
+	client *http.Client
+	cfg    map[string]string
+}
+
+func (g *gotify) Notify(ctx context.Context, msg Message) error {
+	payload := map[string]any{
+		"title":   msg.Title,
+		"message": msg.Body,
+	}
+	if p, err := strconv.Atoi(g.cfg["priority"]); err == nil {
+		payload["priority"] = p
+	}
+	if msg.URL != "" {
+		payload["extras"] = map[string]any{
+			"client::notification": map[string]any{"click": map[string]string{"url": msg.URL}},
+		}
+	}
+	headers := http.Header{}
+	headers.Set("X-Gotify-Key", g.cfg["token"])
+	return postJSON(ctx, g.client, http.MethodPost, g.cfg["server"]+"/message", headers, payload)
+}
+
+// bark 调用 v2 推送接口：POST /push。
+type bark struct {
+	client *http.Client
+	cfg    map[string]string
+}
+
+func (b *bark) Notify(ctx context.Context, msg Message) error {
+	payload := map[string]any{
+		"device_key": b.cfg["device_key"],
+		"title":      msg.Title,
+		"body":       msg.Body,
+		"group":      "Ech0",
+	}
+	if msg.URL != "" {
+		payload["url"] = msg.URL
+	}
+	return postJSON(ctx, b.client, http.MethodPost, b.cfg["server"]+"/push", nil, payload)
+}
+
+// telegram 调用 Bot API 的 sendMessage。
+type telegram struct {
+	client *http.Client
+	cfg    map[string]string
+}
+
+func (t *telegram) Notify(ctx context.Context, msg Message) error {
+	endpoint := t.cfg["server"] + "/bot" + t.cfg["bot_token"] + "/sendMessage"
+	return postJSON(ctx, t.client, http.MethodPost, endpoint, nil, map[string]any{
+		"chat_id":                  t.cfg["chat_id"],
+		"text":                     msg.Text(),
+		"disable_web_page_preview": true,
+	})
+}
+
+// matrix 以 m.text 消息发到房间：PUT /_matrix/client/v3/rooms/{room}/send/m.room.message/{txnId}。
+type matrix struct {
+	client *http.Client
+	cfg    map[string]string
+}
+
+func (m *matrix) Notify(ctx context.Context, msg Message) error {
+	endpoint := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s",
+		m.cfg["homeserver"], url.PathEscape(m.cfg["room_id"]), uuidUtil.MustNewV7())
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer "+m.cfg["access_token"])
+	return postJSON(ctx, m.client, http.MethodPut, endpoint, headers, map[string]any{
+		"msgtype": "m.text",
+		"body":    msg.Text(),
+	})
+}
+
+// httpPost 按模板渲染请求体后 POST 到任意地址。
+type httpPost struct {
+	client  *http.Client
+	cfg     map[string]string
+	tmpl    *template.Template
+	headers http.Header
+}
+
+// templateData 是通用 HTTP 模板可用的字段。
+type templateData struct {
+	Event string
+	Title string
+	Body  string
+	URL   string
+	Time  string
+}
+
+func (h *httpPost) Notify(ctx context.Context, msg Message) error {
+	var body bytes.Buffer
+	if err := h.tmpl.Execute(&body, templateData{
+		Event: msg.Event,
+		Title: msg.Title,
+		Body:  msg.Body,
+		URL:   msg.URL,
+		Time:  msg.Time.UTC().Format(time.RFC3339),
+	}); err != nil {
+		return fmt.Errorf("render template: %w", err)
+	}
+	headers := h.headers.Clone()
+	headers.Set("Content-Type", h.cfg["content_type"])
+	return send(ctx, h.client, http.MethodPost, h.cfg["url"], headers, body.Bytes())
+}
+
+func postJSON(ctx context.Context, client *http.Client, method, endpoint string, headers http.Header, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	if headers == nil {
+		headers = http.Header{}
+	}
+	headers.Set("Content-Type", "application/json")
+	return send(ctx, client, method, endpoint, headers, body)
+}
+
+// send 发出请求，非 2xx 时把响应体开头带进错误，便于在投递日志里排查。
+func send(ctx context.Context, client *http.Client, method, endpoint string, headers http.Header, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for name, values := range headers {
+		req.Header[name] = values
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		// *url.Error 会带上完整地址，而 Telegram 的 bot token 就在路径里，只保留底层原因。
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return fmt.Errorf("%s %s: %w", method, req.URL.Host, urlErr.Err)
+		}
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+	if len(snippet) > 0 {
+		return fmt.Errorf("unexpected status code: %d: %s", resp.StatusCode, bytes.TrimSpace(snippet))
+	}
+	return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+}
@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lin-snow/ech0/internal/config"
+	"github.com/lin-snow/ech0/internal/event"
+	eventbus "github.com/lin-snow/ech0/internal/event/bus"
+	"github.com/lin-snow/ech0/internal/kvstore"
+	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	notifyModel "github.com/lin-snow/ech0/internal/model/notify"
+	asyncUtil "github.com/lin-snow/ech0/internal/util/async"
+)
+
+// previewRunes 是通知正文里评论内容的最大长度。
+const previewRunes = 200
+
+// Store 是 Dispatcher 所需的仓储能力：列出启用的渠道并记录投递结果。
+type Store interface {
+	DeliveryStore
+	ListActiveNotifyChannels(ctx context.Context) ([]notifyModel.NotifyChannel, error)
+}
+
+// Dispatcher 订阅领域事件，翻译成 Message 后投递给订阅了该事件的启用渠道。
+// 发送在 worker pool 里进行，与 webhook 共用同一组池参数。
+type Dispatcher struct {
+	sender *Sender
+	repo   Store
+	kv     kvstore.Store
+	pool   *asyncUtil.WorkerPool
+}
+
+func NewDispatcher(repo Store, kv kvstore.Store) *Dispatcher {
+	return &Dispatcher{
+		sender: NewSender(repo),
+		repo:   repo,
+		kv:     kv,
+		pool: asyncUtil.NewWorkerPool(
+			config.Config().Event.WebhookPoolWorkers,
+			config.Config().Event.WebhookPoolQueue,
+		),
+	}
+}
+
+// Registrations 为每个可通知的事件登记一条同步订阅；并发由 worker pool 承担。
+func (d *Dispatcher) Registrations() []eventbus.Registration {
+	return []eventbus.Registration{
+		eventbus.On(func(ctx context.Context, e event.CommentCreated) error {
+			return d.Publish(ctx, d.commentMessage(ctx, e.Comment))
+		}),
+		eventbus.On(func(ctx context.Context, e event.SystemSnapshotFailed) error {
+			return d.Publish(ctx, snapshotFailedMessage(e))
+		}),
+		eventbus.On(func(ctx context.Context, e event.WebhookDisabled) error {
+			return d.Publish(ctx, webhookDisabledMessage(e))
+		}),
+		eventbus.On(func(ctx context.Context, e event.UserLoginNewDevice) error {
+			return d.Publish(ctx, loginNewDeviceMessage(e))
+		}),
+	}
+}
+
+// Publish 把消息提交给所有订阅了 msg.Event 的启用渠道。
+func (d *Dispatcher) Publish(ctx context.Context, msg Message) error {
+	channels, err := d.repo.ListActiveNotifyChannels(ctx)
+	if err != nil {
+		return err
+	}
+	ctx = context.WithoutCancel(ctx)
+	for _, ch := range channels {
+		if !ch.Subscribes(msg.Event) {
+			continue
+		}
+		ch := ch
+		d.pool.Submit(func() error {
+			_ = d.sender.Deliver(ctx, &ch, msg)
+			return nil
+		})
+	}
+	return nil
+}
+
+func (d *Dispatcher) Wait() {
+	d.pool.Wait()
+}
+
+func (d *Dispatcher) Stop() {
+	d.pool.Stop()
+}
+
+func (d *Dispatcher) commentMessage(ctx context.Context, c commentModel.Comment) Message {
+	msg := Message{
+		Event: notifyModel.EventCommentNew,
+		Title: "收到新评论",
+		Body:  fmt.Sprintf("%s：%s", fallback(c.Nickname, "匿名用户"), preview(c.Content)),
+		Time:  unixOrNow(c.CreatedAt),
+	}
+	if c.Status == commentModel.StatusPending {
+		msg.Event = notifyModel.EventCommentPending
+		msg.Title = "新评论待审核"
+	}
+	if base := d.serverURL(ctx); base != "" && c.EchoID != "" {
+		msg.URL = base + "/echo/" + url.PathEscape(c.EchoID)
+	}
+	return msg
+}
+
+func snapshotFailedMessage(e event.SystemSnapshotFailed) Message {
+	title := "快照失败"
+	if e.Source == "export" {
+		title = "导出失败"
+	}
+	return Message{
+		Event: notifyModel.EventSnapshotFailed,
+		Title: title,
+		Body:  e.Error,
+		Time:  time.Now().UTC(),
+	}
+}
+
+func webhookDisabledMessage(e event.WebhookDisabled) Message {
+	return Message{
+		Event: notifyModel.EventWebhookDisabled,
+		Title: "Webhook 已停用",
+		Body:  fmt.Sprintf("%s（%s）已停用，后续事件不再推送。", e.Webhook.Name, e.Webhook.URL),
+		Time:  time.Now().UTC(),
+	}
+}
+
+func loginNewDeviceMessage(e event.UserLoginNewDevice) Message {
+	return Message{
+		Event: notifyModel.EventLoginNewDevice,
+		Title: "新设备登录",
+		Body: fmt.Sprintf("用户 %s 通过 %s 从新设备登录。\nIP：%s\n设备：%s",
+			e.User.Username, e.Method, fallback(e.IP, "未知"), fallback(e.UserAgent, "未知")),
+		Time: time.Now().UTC(),
+	}
+}
+
+// serverURL 取站点地址，用于拼接跳转链接；未配置时返回空，消息不带链接。
+func (d *Dispatcher) serverURL(ctx context.Context) string {
+	if d.kv != nil {
+		if v, err := d.kv.Get(ctx, commonModel.ServerURLKey); err == nil {
+			if v = strings.TrimSuffix(strings.TrimSpace(v), "/"); v != "" {
+				return v
+			}
+		}
+	}
+	return strings.TrimSuffix(strings.TrimSpace(config.Config().Setting.Serverurl), "/")
+}
+
+func preview(s string) string {
+	s = strings.TrimSpace(s)
+	if utf8.RuneCountInString(s) <= previewRunes {
+		return s
+	}
+	return string([]rune(s)[:previewRunes]) + "…"
+}
+
+func fallback(s, def string) string {
+	if strings.TrimSpace(s) == "" {
+		return def
+	}
+	return s
+}
+
+func unixOrNow(ts int64) time.Time {
+	if ts > 0 {
+		return time.Unix(ts, 0).UTC()
+	}
+	return time.Now().UTC()
+}
@@ -0,0 +1,230 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package notify 是 SMTP 之外的通知出口：ntfy、Gotify、Bark、Telegram、Matrix 与模板化的通用 HTTP POST。
+// 每个渠道实现 Notifier；Sender 负责构造渠道、发送并记投递日志；Dispatcher 订阅领域事件，
+// 把它们翻译成 Message 后按渠道的订阅投递。
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	notifyModel "github.com/lin-snow/ech0/internal/model/notify"
+)
+
+// Message 是一条与渠道无关的通知，各渠道按自己的格式渲染。
+type Message struct {
+	Event string    // 通知事件（notifyModel.EventXxx）
+	Title string    // 标题
+	Body  string    // 正文（纯文本）
+	URL   string    // 可选的跳转链接
+	Time  time.Time // 事件发生时间
+}
+
+// Text 把消息拼成纯文本，供不区分标题与正文的渠道（Telegram、Matrix）使用。
+func (m Message) Text() string {
+	parts := []string{m.Title}
+	if m.Body != "" {
+		parts = append(parts, m.Body)
+	}
+	if m.URL != "" {
+		parts = append(parts, m.URL)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// Notifier 是一个可投递通知的渠道。
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// field 描述渠道的一个配置项。
+type field struct {
+	key      string
+	required bool
+	secret   bool   // 密钥项：不回显给前端，更新时留空沿用原值
+	def      string // 默认值，仅非必填项
+	isURL    bool
+}
+
+var specs = map[string][]field{
+	notifyModel.ChannelNtfy: {
+		{key: "server", def: "https://ntfy.sh", isURL: true},
+		{key: "topic", required: true},
+		{key: "token", secret: true},
+		{key: "priority"},
+	},
+	notifyModel.ChannelGotify: {
+		{key: "server", required: true, isURL: true},
+		{key: "token", required: true, secret: true},
+		{key: "priority"},
+	},
+	notifyModel.ChannelBark: {
+		{key: "server", def: "https://api.day.app", isURL: true},
+		{key: "device_key", required: true, secret: true},
+	},
+	notifyModel.ChannelTelegram: {
+		{key: "server", def: "https://api.telegram.org", isURL: true},
+		{key: "bot_token", required: true, secret: true},
+		{key: "chat_id", required: true},
+	},
+	notifyModel.ChannelMatrix: {
+		{key: "homeserver", required: true, isURL: true},
+		{key: "access_token", required: true, secret: true},
+		{key: "room_id", required: true},
+	},
+	notifyModel.ChannelHTTP: {
+		{key: "url", required: true, isURL: true},
+		{key: "content_type", def: "application/json"},
+		{key: "template"},
+		{key: "headers", secret: true},
+	},
+}
+
+// SecretKeys 返回某类渠道的密钥配置项。
+func SecretKeys(channelType string) []string {
+	var keys []string
+	for _, f := range specs[channelType] {
+		if f.secret {
+			keys = append(keys, f.key)
+		}
+	}
+	return keys
+}
+
+// URLKeys 返回某类渠道中填写地址的配置项。
+func URLKeys(channelType string) []string {
+	var keys []string
+	for _, f := range specs[channelType] {
+		if f.isURL {
+			keys = append(keys, f.key)
+		}
+	}
+	return keys
+}
+
+// Validate 检查渠道类型、配置项与订阅事件是否合法。
+func Validate(channelType string, config map[string]string, events []string) error {
+	spec, ok := specs[channelType]
+	if !ok {
+		return fmt.Errorf("unsupported channel type %q", channelType)
+	}
+	for key := range config {
+		if !slices.ContainsFunc(spec, func(f field) bool { return f.key == key }) {
+			return fmt.Errorf("unknown config key %q for %s", key, channelType)
+		}
+	}
+	for _, f := range spec {
+		value := strings.TrimSpace(config[f.key])
+		if value == "" {
+			if f.required {
+				return fmt.Errorf("%s is required", f.key)
+			}
+			continue
+		}
+		if f.isURL {
+			u, err := url.Parse(value)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("%s must be an http(s) URL", f.key)
+			}
+		}
+	}
+	if p := strings.TrimSpace(config["priority"]); p != "" {
+		// ntfy 的优先级是 1–5，Gotify 是 0–10。
+		lo, hi := 0, 10
+		if channelType == notifyModel.ChannelNtfy {
+			lo, hi = 1, 5
+		}
+		if n, err := strconv.Atoi(p); err != nil || n < lo || n > hi {
+			return fmt.Errorf("priority must be an integer between %d and %d", lo, hi)
+		}
+	}
+	if channelType == notifyModel.ChannelHTTP {
+		if _, err := parseTemplate(config["template"]); err != nil {
+			return fmt.Errorf("template: %w", err)
+		}
+		if _, err := parseHeaders(config["headers"]); err != nil {
+			return err
+		}
+	}
+	for _, e := range events {
+		if !slices.Contains(notifyModel.Events, e) {
+			return fmt.Errorf("unknown event %q", e)
+		}
+	}
+	return nil
+}
+
+// New 按渠道类型构造 Notifier。config 须已通过 Validate；缺省项在此补上默认值。
+func New(ch *notifyModel.NotifyChannel, client *http.Client) (Notifier, error) {
+	spec, ok := specs[ch.Type]
+	if !ok {
+		return nil, fmt.Errorf("unsupported channel type %q", ch.Type)
+	}
+	cfg := make(map[string]string, len(spec))
+	for _, f := range spec {
+		cfg[f.key] = strings.TrimSpace(ch.Config[f.key])
+		if cfg[f.key] == "" {
+			cfg[f.key] = f.def
+		}
+	}
+	for _, key := range []string{"server", "homeserver"} {
+		cfg[key] = strings.TrimSuffix(cfg[key], "/")
+	}
+
+	switch ch.Type {
+	case notifyModel.ChannelNtfy:
+		return &ntfy{client: client, cfg: cfg}, nil
+	case notifyModel.ChannelGotify:
+		return &gotify{client: client, cfg: cfg}, nil
+	case notifyModel.ChannelBark:
+		return &bark{client: client, cfg: cfg}, nil
+	case notifyModel.ChannelTelegram:
+		return &telegram{client: client, cfg: cfg}, nil
+	case notifyModel.ChannelMatrix:
+		return &matrix{client: client, cfg: cfg}, nil
+	default:
+		tmpl, err := parseTemplate(cfg["template"])
+		if err != nil {
+			return nil, err
+		}
+		headers, err := parseHeaders(cfg["headers"])
+		if err != nil {
+			return nil, err
+		}
+		return &httpPost{client: client, cfg: cfg, tmpl: tmpl, headers: headers}, nil
+	}
+}
+
+// parseHeaders 解析通用 HTTP 渠道的附加请求头：每行一个 "Name: Value"。
+func parseHeaders(raw string) (http.Header, error) {
+	headers := make(http.Header)
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("header %q must look like \"Name: Value\"", line)
+		}
+		headers.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	return headers, nil
+}
+
+// parseTemplate 解析通用 HTTP 渠道的请求体模板（text/template），留空时用 defaultTemplate。
+func parseTemplate(raw string) (*template.Template, error) {
+	if strings.TrimSpace(raw) == "" {
+		raw = defaultTemplate
+	}
+	return template.New("body").Funcs(templateFuncs).Parse(raw)
+}
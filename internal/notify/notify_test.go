@@ -0,0 +1,393 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/event"
+	"github.com/lin-snow/ech0/internal/kvstore"
+	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	notifyModel "github.com/lin-snow/ech0/internal/model/notify"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
+	webhookModel "github.com/lin-snow/ech0/internal/model/webhook"
+	asyncUtil "github.com/lin-snow/ech0/internal/util/async"
+	"github.com/lin-snow/ech0/internal/util/egress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captured 是 stub 服务端收到的一次请求。
+type captured struct {
+	method string
+	path   string
+	header http.Header
+	body   []byte
+}
+
+func (c captured) json(t *testing.T) map[string]any {
+	t.Helper()
+	var m map[string]any
+	require.NoError(t, json.Unmarshal(c.body, &m))
+	return m
+}
+
+// stubServer 启动一个记录请求的本地服务端，status 为其统一返回的状态码。
+func stubServer(t *testing.T, status int) (*httptest.Server, func() []captured) {
+	t.Helper()
+	var (
+		mu   sync.Mutex
+		reqs []captured
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		reqs = append(reqs, captured{method: r.Method, path: r.URL.EscapedPath(), header: r.Header.Clone(), body: body})
+		mu.Unlock()
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"ok":false,"description":"nope"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []captured {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]captured(nil), reqs...)
+	}
+}
+
+var testMsg = Message{
+	Event: notifyModel.EventCommentPending,
+	Title: "新评论待审核",
+	Body:  "alice：\"hi\"",
+	URL:   "https://ech0.example/echo/e1",
+	Time:  time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC),
+}
+
+func TestChannels_AgainstStubServers(t *testing.T) {
+	client := egress.NewClient(egress.Timeout(5 * time.Second))
+
+	cases := []struct {
+		name   string
+		typ    string
+		config func(base string) map[string]string
+		check  func(t *testing.T, req captured)
+	}{
+		{
+			name: "ntfy",
+			typ:  notifyModel.ChannelNtfy,
+			config: func(base string) map[string]string {
+				return map[string]string{"server": base + "/", "topic": "ech0", "token": "tk", "priority": "4"}
+			},
+			check: func(t *testing.T, req captured) {
+				assert.Equal(t, http.MethodPost, req.method)
+				assert.Equal(t, "/", req.path)
+				assert.Equal(t, "Bearer tk", req.header.Get("Authorization"))
+				body := req.json(t)
+				assert.Equal(t, "ech0", body["topic"])
+				assert.Equal(t, testMsg.Title, body["title"])
+				assert.Equal(t, testMsg.Body, body["message"])
+				assert.Equal(t, testMsg.URL, body["click"])
+				assert.EqualValues(t, 4, body["priority"])
+			},
+		},
+		{
+			name: "gotify",
+			typ:  notifyModel.ChannelGotify,
+			config: func(base string) map[string]string {
+				return map[string]string{"server": base, "token": "app"}
+			},
+			check: func(t *testing.T, req captured) {
+				assert.Equal(t, "/message", req.path)
+				assert.Equal(t, "app", req.header.Get("X-Gotify-Key"))
+				body := req.json(t)
+				assert.Equal(t, testMsg.Title, body["title"])
+				assert.Equal(t, testMsg.Body, body["message"])
+				assert.NotContains(t, body, "priority", "unset priority falls back to the app default")
+				assert.Contains(t, string(req.body), testMsg.URL)
+			},
+		},
+		{
+			name: "bark",
+			typ:  notifyModel.ChannelBark,
+			config: func(base string) map[string]string {
+				return map[string]string{"server": base, "device_key": "dk"}
+			},
+			check: func(t *testing.T, req captured) {
+				assert.Equal(t, "/push", req.path)
+				body := req.json(t)
+				assert.Equal(t, "dk", body["device_key"])
+				assert.Equal(t, testMsg.Body, body["body"])
+				assert.Equal(t, testMsg.URL, body["url"])
+			},
+		},
+		{
+			name: "telegram",
+			typ:  notifyModel.ChannelTelegram,
+			config: func(base string) map[string]string {
+				return map[string]string{"server": base, "bot_token": "123:abc", "chat_id": "-100"}
+			},
+			check: func(t *testing.T, req captured) {
+				assert.Equal(t, "/bot123:abc/sendMessage", req.path)
+				body := req.json(t)
+				assert.Equal(t, "-100", body["chat_id"])
+				assert.Equal(t, testMsg.Text(), body["text"])
+			},
+		},
+		{
+			name: "matrix",
+			typ:  notifyModel.ChannelMatrix,
+			config: func(base string) map[string]string {
+				return map[string]string{"homeserver": base, "access_token": "syt", "room_id": "!room:local"}
+			},
+			check: func(t *testing.T, req captured) {
+				assert.Equal(t, http.MethodPut, req.method)
+				assert.True(t, strings.HasPrefix(req.path, "/_matrix/client/v3/rooms/%21room:local/send/m.room.message/"), req.path)
+				assert.Equal(t, "Bearer syt", req.header.Get("Authorization"))
+				body := req.json(t)
+				assert.Equal(t, "m.text", body["msgtype"])
+				assert.Equal(t, testMsg.Text(), body["body"])
+			},
+		},
+		{
+			name: "http default template",
+			typ:  notifyModel.ChannelHTTP,
+			config: func(base string) map[string]string {
+				return map[string]string{"url": base + "/hook", "headers": "Authorization: Bearer x\nX-Extra: 1"}
+			},
+			check: func(t *testing.T, req captured) {
+				assert.Equal(t, "/hook", req.path)
+				assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+				assert.Equal(t, "Bearer x", req.header.Get("Authorization"))
+				assert.Equal(t, "1", req.header.Get("X-Extra"))
+				body := req.json(t)
+				assert.Equal(t, testMsg.Event, body["event"])
+				assert.Equal(t, testMsg.Body, body["body"], "quotes survive the json func")
+				assert.Equal(t, "2026-10-19T08:00:00Z", body["time"])
+			},
+		},
+		{
+			name: "http custom template",
+			typ:  notifyModel.ChannelHTTP,
+			config: func(base string) map[string]string {
+				return map[string]string{
+					"url":          base,
+					"content_type": "text/plain",
+					"template":     "[{{.Event}}] {{.Title}}",
+				}
+			},
+			check: func(t *testing.T, req captured) {
+				assert.Equal(t, "text/plain", req.header.Get("Content-Type"))
+				assert.Equal(t, "[comment.pending] 新评论待审核", string(req.body))
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, reqs := stubServer(t, http.StatusOK)
+			cfg := tc.config(srv.URL)
+			require.NoError(t, Validate(tc.typ, cfg, nil))
+
+			n, err := New(&notifyModel.NotifyChannel{Type: tc.typ, Config: cfg}, client)
+			require.NoError(t, err)
+			require.NoError(t, n.Notify(context.Background(), testMsg))
+
+			got := reqs()
+			require.Len(t, got, 1)
+			assert.Equal(t, userAgent, got[0].header.Get("User-Agent"))
+			tc.check(t, got[0])
+		})
+	}
+}
+
+func TestNotify_ErrorCarriesStatusAndBody(t *testing.T) {
+	srv, _ := stubServer(t, http.StatusBadRequest)
+	n, err := New(&notifyModel.NotifyChannel{
+		Type:   notifyModel.ChannelTelegram,
+		Config: map[string]string{"server": srv.URL, "bot_token": "123:abc", "chat_id": "1"},
+	}, egress.NewClient())
+	require.NoError(t, err)
+
+	err = n.Notify(context.Background(), testMsg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "400")
+	assert.Contains(t, err.Error(), "nope")
+}
+
+func TestNotify_TransportErrorHidesToken(t *testing.T) {
+	srv, _ := stubServer(t, http.StatusOK)
+	base := srv.URL
+	srv.Close()
+
+	n, err := New(&notifyModel.NotifyChannel{
+		Type:   notifyModel.ChannelTelegram,
+		Config: map[string]string{"server": base, "bot_token": "123:secret", "chat_id": "1"},
+	}, egress.NewClient())
+	require.NoError(t, err)
+
+	err = n.Notify(context.Background(), testMsg)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "123:secret")
+}
+
+// 默认允许本机 / 内网地址（自建服务常在内网）；渠道开启 BlockPrivate 后，解析到本机回环的地址
+// 连不上，也不会写出请求。
+func TestSender_BlockPrivateIsPerChannel(t *testing.T) {
+	srv, reqs := stubServer(t, http.StatusOK)
+	sender := NewSender(&memStore{})
+	ch := &notifyModel.NotifyChannel{
+		ID:     "c1",
+		Type:   notifyModel.ChannelHTTP,
+		Config: map[string]string{"url": srv.URL},
+	}
+
+	require.NoError(t, sender.SendTest(context.Background(), ch))
+	assert.Len(t, reqs(), 1)
+
+	ch.BlockPrivate = true
+	require.Error(t, sender.SendTest(context.Background(), ch))
+	assert.Len(t, reqs(), 1)
+}
+
+func TestSecretKeys(t *testing.T) {
+	assert.Equal(t, []string{"token"}, SecretKeys(notifyModel.ChannelNtfy))
+	assert.Equal(t, []string{"bot_token"}, SecretKeys(notifyModel.ChannelTelegram))
+	assert.Equal(t, []string{"headers"}, SecretKeys(notifyModel.ChannelHTTP))
+	assert.Empty(t, SecretKeys("pager"))
+}
+
+// memStore 是内存版 Store，记录投递日志与渠道状态。
+type memStore struct {
+	mu         sync.Mutex
+	channels   []notifyModel.NotifyChannel
+	deliveries []notifyModel.NotifyDelivery
+	status     map[string]string
+}
+
+func (s *memStore) ListActiveNotifyChannels(context.Context) ([]notifyModel.NotifyChannel, error) {
+	var active []notifyModel.NotifyChannel
+	for _, ch := range s.channels {
+		if ch.IsActive {
+			active = append(active, ch)
+		}
+	}
+	return active, nil
+}
+
+func (s *memStore) CreateNotifyDelivery(_ context.Context, d *notifyModel.NotifyDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deliveries = append(s.deliveries, *d)
+	return nil
+}
+
+func (s *memStore) PruneNotifyDeliveries(context.Context, int) error { return nil }
+
+func (s *memStore) UpdateNotifyChannelDeliveryStatus(_ context.Context, id, status string, _ int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.status == nil {
+		s.status = map[string]string{}
+	}
+	s.status[id] = status
+	return nil
+}
+
+func newTestDispatcher(store *memStore) *Dispatcher {
+	return &Dispatcher{
+		sender: NewSender(store),
+		repo:   store,
+		pool:   asyncUtil.NewWorkerPool(2, 16),
+	}
+}
+
+func TestDispatcher_RoutesBySubscription(t *testing.T) {
+	okSrv, okReqs := stubServer(t, http.StatusOK)
+	badSrv, _ := stubServer(t, http.StatusInternalServerError)
+
+	store := &memStore{channels: []notifyModel.NotifyChannel{
+		{
+			ID: "c-all", Name: "all", Type: notifyModel.ChannelHTTP, IsActive: true,
+			Config: map[string]string{"url": okSrv.URL, "template": "{{.Event}}"},
+			Events: notifyModel.Events,
+		},
+		{
+			ID: "c-pending", Name: "pending only", Type: notifyModel.ChannelHTTP, IsActive: true,
+			Config: map[string]string{"url": badSrv.URL},
+			Events: []string{notifyModel.EventCommentPending},
+		},
+		{
+			ID: "c-off", Name: "disabled", Type: notifyModel.ChannelHTTP, IsActive: false,
+			Config: map[string]string{"url": okSrv.URL},
+			Events: notifyModel.Events,
+		},
+	}}
+	d := newTestDispatcher(store)
+	ctx := context.Background()
+
+	approved := commentModel.Comment{ID: "cm1", EchoID: "e1", Nickname: "bob", Content: "nice", Status: commentModel.StatusApproved}
+	require.NoError(t, d.Publish(ctx, d.commentMessage(ctx, approved)))
+	d.Wait()
+
+	pending := approved
+	pending.Status = commentModel.StatusPending
+	require.NoError(t, d.Publish(ctx, d.commentMessage(ctx, pending)))
+	d.Wait()
+
+	require.NoError(t, d.Publish(ctx, snapshotFailedMessage(event.SystemSnapshotFailed{Source: "schedule", Error: "disk full"})))
+	require.NoError(t, d.Publish(ctx, webhookDisabledMessage(event.WebhookDisabled{Webhook: webhookModel.Webhook{Name: "ci"}})))
+	require.NoError(t, d.Publish(ctx, loginNewDeviceMessage(event.UserLoginNewDevice{User: userModel.User{Username: "admin"}})))
+	d.Wait()
+	d.Stop()
+
+	var bodies []string
+	for _, r := range okReqs() {
+		bodies = append(bodies, string(r.body))
+	}
+	assert.ElementsMatch(t, []string{
+		notifyModel.EventCommentNew,
+		notifyModel.EventCommentPending,
+		notifyModel.EventSnapshotFailed,
+		notifyModel.EventWebhookDisabled,
+		notifyModel.EventLoginNewDevice,
+	}, bodies, "the disabled channel receives nothing")
+
+	// 5 条成功 + 1 条失败（pending only 渠道，重试后记一条）。
+	require.Len(t, store.deliveries, 6)
+	var failed []notifyModel.NotifyDelivery
+	for _, delivery := range store.deliveries {
+		if delivery.Status == notifyModel.DeliveryFailed {
+			failed = append(failed, delivery)
+		}
+	}
+	require.Len(t, failed, 1)
+	assert.Equal(t, "c-pending", failed[0].ChannelID)
+	assert.Equal(t, notifyModel.EventCommentPending, failed[0].Event)
+	assert.Contains(t, failed[0].Error, "500")
+	assert.Equal(t, notifyModel.DeliverySuccess, store.status["c-all"])
+	assert.Equal(t, notifyModel.DeliveryFailed, store.status["c-pending"])
+}
+
+func TestCommentMessage(t *testing.T) {
+	kv := kvstore.NewMemory()
+	require.NoError(t, kv.Set(context.Background(), commonModel.ServerURLKey, "https://blog.example.com/ "))
+	d := &Dispatcher{kv: kv}
+	long := strings.Repeat("评", previewRunes+10)
+	msg := d.commentMessage(context.Background(), commentModel.Comment{
+		EchoID: "e 1", Content: long, Status: commentModel.StatusApproved,
+	})
+	assert.Equal(t, notifyModel.EventCommentNew, msg.Event)
+	assert.True(t, strings.HasPrefix(msg.Body, "匿名用户："))
+	assert.True(t, strings.HasSuffix(msg.Body, "…"))
+	assert.Equal(t, "https://blog.example.com/echo/e%201", msg.URL)
+}
@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package notify
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	notifyModel "github.com/lin-snow/ech0/internal/model/notify"
+	"github.com/lin-snow/ech0/internal/util/egress"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+const (
+	defaultNotifyTimeout = 10 * time.Second
+
+	deliverMaxRetries = 3
+	deliverBackoff    = 500 * time.Millisecond
+
+	// deliveryLogKeep 是投递日志保留的最近条数，超出的旧记录在每次写入后清理。
+	deliveryLogKeep = 500
+)
+
+// DeliveryStore 是 Sender 记录投递结果所需的仓储能力。
+type DeliveryStore interface {
+	CreateNotifyDelivery(ctx context.Context, delivery *notifyModel.NotifyDelivery) error
+	PruneNotifyDeliveries(ctx context.Context, keep int) error
+	UpdateNotifyChannelDeliveryStatus(ctx context.Context, id string, status string, lastTrigger int64) error
+}
+
+// Sender 是通知渠道的唯一出网出口：构造渠道、发送、记投递日志。
+// 正式投递（Dispatcher）与设置页的测试发送共用它。
+// 渠道地址都由管理员配置，自建的 ntfy / Gotify / Matrix 常在内网，故默认与 OIDC、LLM 后端一样不启用
+// SSRF 防护；渠道开启 BlockPrivate 时改用带防护的 client，拒绝解析到内网 / 本机的目标。
+type Sender struct {
+	client  *http.Client
+	guarded *http.Client
+	store   DeliveryStore
+}
+
+func NewSender(store DeliveryStore) *Sender {
+	return &Sender{
+		client:  egress.NewClient(egress.Timeout(defaultNotifyTimeout)),
+		guarded: egress.NewClient(egress.Guard(), egress.Timeout(defaultNotifyTimeout)),
+		store:   store,
+	}
+}
+
+// Deliver 投递一次正式通知，失败时即时重试；最终结果写入投递日志。
+func (s *Sender) Deliver(ctx context.Context, ch *notifyModel.NotifyChannel, msg Message) error {
+	return s.send(ctx, ch, msg, deliverMaxRetries)
+}
+
+// SendTest 向渠道发送一条测试通知，不重试，结果同样写入投递日志。
+func (s *Sender) SendTest(ctx context.Context, ch *notifyModel.NotifyChannel) error {
+	return s.send(ctx, ch, Message{
+		Event: notifyModel.EventTest,
+		Title: "Ech0 测试通知",
+		Body:  "收到这条消息说明通知渠道「" + ch.Name + "」（" + ch.Type + "）工作正常。",
+		Time:  time.Now().UTC(),
+	}, 1)
+}
+
+func (s *Sender) send(ctx context.Context, ch *notifyModel.NotifyChannel, msg Message, attempts int) error {
+	start := time.Now()
+	client := s.client
+	if ch.BlockPrivate {
+		client = s.guarded
+	}
+	notifier, err := New(ch, client)
+	if err == nil {
+		err = egress.Retry(attempts, deliverBackoff, func() error {
+			return notifier.Notify(ctx, msg)
+		})
+	}
+	s.record(ctx, ch, msg, err, time.Since(start))
+	return err
+}
+
+func (s *Sender) record(ctx context.Context, ch *notifyModel.NotifyChannel, msg Message, sendErr error, took time.Duration) {
+	delivery := &notifyModel.NotifyDelivery{
+		ChannelID:   ch.ID,
+		ChannelName: ch.Name,
+		Event:       msg.Event,
+		Title:       msg.Title,
+		Status:      notifyModel.DeliverySuccess,
+		DurationMs:  took.Milliseconds(),
+	}
+	if sendErr != nil {
+		delivery.Status = notifyModel.DeliveryFailed
+		delivery.Error = sendErr.Error()
+		logUtil.GetLogger().Warn("notify delivery failed",
+			slog.String("channel", ch.Name),
+			slog.String("type", ch.Type),
+			slog.String("event", msg.Event),
+			logUtil.Err(sendErr))
+	}
+	if s.store == nil {
+		return
+	}
+	// 投递日志与请求生命周期脱钩：测试发送的请求结束后日志也要落库。
+	ctx = context.WithoutCancel(ctx)
+	if err := s.store.CreateNotifyDelivery(ctx, delivery); err != nil {
+		logUtil.GetLogger().Warn("record notify delivery failed", slog.String("channel", ch.Name), logUtil.Err(err))
+		return
+	}
+	if err := s.store.PruneNotifyDeliveries(ctx, deliveryLogKeep); err != nil {
+		logUtil.GetLogger().Warn("prune notify deliveries failed", logUtil.Err(err))
+	}
+	if ch.ID == "" {
+		return
+	}
+	if err := s.store.UpdateNotifyChannelDeliveryStatus(ctx, ch.ID, delivery.Status, time.Now().UTC().Unix()); err != nil {
+		logUtil.GetLogger().Warn("update notify channel status failed",
+			slog.String("channel_id", ch.ID), logUtil.Err(err))
+	}
+}
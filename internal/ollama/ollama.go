@@ -0,0 +1,269 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package ollama 是 Ollama 原生 HTTP API（/api/chat、/api/embed、/api/pull、/api/show）的
+// 最小客户端，供 internal/agent（生成）与 internal/embedding（向量化）在离线 / 内网部署下
+// 直连本地模型服务。与 OpenAI 兼容端（/v1）相比，原生接口能拿到拉取进度与逐次 token 计数，
+// 模型缺失时也有明确的 404 可判别。
+package ollama
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/lin-snow/ech0/internal/util/egress"
+)
+
+// DefaultBaseURL 是 Ollama 的缺省监听地址。
+const DefaultBaseURL = "http://localhost:11434"
+
+// ErrModelNotFound 表示服务端没有该模型（需先拉取）。
+var ErrModelNotFound = errors.New("ollama: model not found, pull it first")
+
+// maxLineSize 是 NDJSON 单行上限：/api/chat 的单个 chunk 很小，但 done 行会带上下文统计，留足余量。
+const maxLineSize = 1 << 20
+
+// Client 是一个 Ollama 服务端点。零值不可用，经 New 构造。
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+// New 按 baseURL 构造客户端。空串取 DefaultBaseURL；用户照 OpenAI 兼容习惯填了
+// ".../v1" 或 ".../api" 后缀的，一并剥掉，原生接口路径由本包自己拼。
+//
+// 不设整体超时（流式生成与模型拉取都可能很长），由调用方的 ctx 控制；
+// 也不启用 SSRF 防护：管理员配置的模型服务本就常在本机或内网。
+func New(baseURL string) *Client {
+	base := strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	if base == "" {
+		base = DefaultBaseURL
+	}
+	for _, suffix := range []string{"/v1", "/api"} {
+		base = strings.TrimSuffix(base, suffix)
+	}
+	// Ollama 通常跑在本机或内网，地址由管理员配置，不启用 SSRF 防护。
+	return &Client{baseURL: base, http: egress.NewClient()}
+}
+
+// Message 是 /api/chat 的一条消息。Images 为 base64（不含 data: 前缀）。
+type Message struct {
+	Role      string     `json:"role"`
+	Content   string     `json:"content"`
+	Thinking  string     `json:"thinking,omitempty"`
+	Images    []string   `json:"images,omitempty"`
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+	ToolName  string     `json:"tool_name,omitempty"`
+}
+
+// ToolCall 是模型发起的工具调用。Ollama 不分片、也不带调用 id，arguments 是 JSON 对象。
+type ToolCall struct {
+	Function struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	} `json:"function"`
+}
+
+// Tool 是对模型声明的函数工具。
+type Tool struct {
+	Type     string       `json:"type"`
+	Function ToolFunction `json:"function"`
+}
+
+type ToolFunction struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters"`
+}
+
+// ChatRequest 是 /api/chat 的请求体。Options 透传模型参数（temperature、num_predict 等）。
+type ChatRequest struct {
+	Model    string         `json:"model"`
+	Messages []Message      `json:"messages"`
+	Tools    []Tool         `json:"tools,omitempty"`
+	Stream   bool           `json:"stream"`
+	Options  map[string]any `json:"options,omitempty"`
+}
+
+// ChatChunk 是 /api/chat 的一个 NDJSON 片段；Done 为真的末片带 token 计数。
+type ChatChunk struct {
+	Message         Message `json:"message"`
+	Done            bool    `json:"done"`
+	PromptEvalCount int     `json:"prompt_eval_count"`
+	EvalCount       int     `json:"eval_count"`
+}
+
+// Chat 调用 /api/chat，返回最后一片（末片带 token 计数）。onChunk 非 nil 时走流式并逐片回调，
+// 返回 false 即停止读取；为 nil 时非流式，唯一一片即完整回复。
+func (c *Client) Chat(ctx context.Context, req ChatRequest, onChunk func(ChatChunk) bool) (ChatChunk, error) {
+	req.Stream = onChunk != nil
+	var last ChatChunk
+	if onChunk == nil {
+		err := c.postJSON(ctx, "/api/chat", req, &last)
+		return last, err
+	}
+	err := c.post(ctx, "/api/chat", req, func(line []byte) (bool, error) {
+		var chunk ChatChunk
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			return false, fmt.Errorf("ollama: decode chat chunk: %w", err)
+		}
+		last = chunk
+		return onChunk(chunk) && !chunk.Done, nil
+	})
+	return last, err
+}
+
+// EmbedResponse 是 /api/embed 的响应。
+type EmbedResponse struct {
+	Embeddings      [][]float32 `json:"embeddings"`
+	PromptEvalCount int         `json:"prompt_eval_count"`
+}
+
+// Embed 调用 /api/embed 批量向量化。dim>0 时请求服务端截断到该维度（需模型支持）。
+func (c *Client) Embed(ctx context.Context, model string, inputs []string, dim int) (EmbedResponse, error) {
+	body := map[string]any{"model": model, "input": inputs}
+	if dim > 0 {
+		body["dimensions"] = dim
+	}
+	var out EmbedResponse
+	if err := c.postJSON(ctx, "/api/embed", body, &out); err != nil {
+		return EmbedResponse{}, err
+	}
+	return out, nil
+}
+
+// PullProgress 是模型拉取的一次进度上报。Total/Completed 以字节计，仅在下载分层时非零。
+type PullProgress struct {
+	Status    string `json:"status"`
+	Digest    string `json:"digest,omitempty"`
+	Total     int64  `json:"total,omitempty"`
+	Completed int64  `json:"completed,omitempty"`
+}
+
+// Pull 调用 /api/pull 拉取模型，逐条回调进度；以 status=success 收尾，否则报错。
+func (c *Client) Pull(ctx context.Context, model string, onProgress func(PullProgress)) error {
+	succeeded := false
+	err := c.post(ctx, "/api/pull", map[string]any{"model": model, "stream": true}, func(line []byte) (bool, error) {
+		var p struct {
+			PullProgress
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(line, &p); err != nil {
+			return false, fmt.Errorf("ollama: decode pull progress: %w", err)
+		}
+		if p.Error != "" {
+			return false, fmt.Errorf("ollama: pull %s: %s", model, p.Error)
+		}
+		if onProgress != nil {
+			onProgress(p.PullProgress)
+		}
+		succeeded = p.Status == "success"
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+	if !succeeded {
+		return fmt.Errorf("ollama: pull %s ended without success", model)
+	}
+	return nil
+}
+
+// HasModel 经 /api/show 判断本地是否已有该模型。
+func (c *Client) HasModel(ctx context.Context, model string) (bool, error) {
+	err := c.postJSON(ctx, "/api/show", map[string]any{"model": model}, nil)
+	if errors.Is(err, ErrModelNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// postJSON 发起非流式 JSON POST，把整个响应体解码进 out（nil 即丢弃）。
+func (c *Client) postJSON(ctx context.Context, path string, body, out any) error {
+	respBody, err := c.do(ctx, path, body)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = respBody.Close() }()
+	if out == nil {
+		_, _ = io.Copy(io.Discard, respBody)
+		return nil
+	}
+	if err := json.NewDecoder(respBody).Decode(out); err != nil {
+		return fmt.Errorf("ollama: decode %s response: %w", path, err)
+	}
+	return nil
+}
+
+// post 发起 JSON POST，并把流式响应体按 NDJSON 逐行交给 onLine。
+// onLine 返回 false 时停止读取。
+func (c *Client) post(ctx context.Context, path string, body any, onLine func([]byte) (bool, error)) error {
+	respBody, err := c.do(ctx, path, body)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = respBody.Close() }()
+
+	scanner := bufio.NewScanner(respBody)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		more, err := onLine(line)
+		if err != nil {
+			return err
+		}
+		if !more {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// do 发送请求并返回 2xx 的响应体。非 2xx 统一转成错误（优先取服务端的 error 字段）；
+// 404 转为 ErrModelNotFound——Ollama 对未拉取的模型一律回 404。
+func (c *Client) do(ctx context.Context, path string, body any) (io.ReadCloser, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.Body, nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	var e struct {
+		Error string `json:"error"`
+	}
+	msg := strings.TrimSpace(string(raw))
+	if json.Unmarshal(raw, &e) == nil && e.Error != "" {
+		msg = e.Error
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrModelNotFound, msg)
+	}
+	return nil, fmt.Errorf("ollama: POST %s: status %d: %s", path, resp.StatusCode, msg)
+}
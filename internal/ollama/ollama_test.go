@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package ollama
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_NormalizesBaseURL(t *testing.T) {
+	assert.Equal(t, DefaultBaseURL, New("").baseURL)
+	assert.Equal(t, "http://gpu:11434", New(" http://gpu:11434/v1/ ").baseURL)
+	assert.Equal(t, "http://gpu:11434", New("http://gpu:11434/api").baseURL)
+}
+
+func TestPull_ReportsProgress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/pull", r.URL.Path)
+		_, _ = io.WriteString(w, `{"status":"pulling manifest"}
+{"status":"downloading","digest":"sha256:ab","total":100,"completed":40}
+{"status":"success"}
+`)
+	}))
+	defer srv.Close()
+
+	var seen []PullProgress
+	err := New(srv.URL).Pull(context.Background(), "qwen3", func(p PullProgress) { seen = append(seen, p) })
+
+	require.NoError(t, err)
+	require.Len(t, seen, 3)
+	assert.Equal(t, int64(40), seen[1].Completed)
+	assert.Equal(t, "success", seen[2].Status)
+}
+
+func TestPull_StreamedError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"status":"pulling manifest"}
+{"error":"pull model manifest: file does not exist"}
+`)
+	}))
+	defer srv.Close()
+
+	err := New(srv.URL).Pull(context.Background(), "nope", nil)
+	assert.ErrorContains(t, err, "file does not exist")
+}
+
+func TestHasModel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) == `{"model":"present"}` {
+			_, _ = io.WriteString(w, `{"details":{}}`)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"error":"model 'missing' not found"}`)
+	}))
+	defer srv.Close()
+	c := New(srv.URL)
+
+	ok, err := c.HasModel(context.Background(), "present")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = c.HasModel(context.Background(), "missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = c.Embed(context.Background(), "missing", []string{"x"}, 0)
+	assert.True(t, errors.Is(err, ErrModelNotFound))
+}
@@ -9,9 +9,11 @@ import (
 	"time"
 
 	model "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	commentService "github.com/lin-snow/ech0/internal/service/comment"
 	"github.com/lin-snow/ech0/internal/transaction"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CommentRepository struct {
@@ -68,6 +70,9 @@ func (r *CommentRepository) ListComments(
 	if query.Hot != nil {
 		db = db.Where("hot = ?", *query.Hot)
 	}
+	if query.Since > 0 {
+		db = db.Where("created_at >= ?", query.Since)
+	}
 	if strings.TrimSpace(query.Keyword) != "" {
 		kw := "%" + strings.TrimSpace(query.Keyword) + "%"
 		db = db.Where(
@@ -128,7 +133,27 @@ func (r *CommentRepository) UpdateCommentHot(
 }
 
 func (r *CommentRepository) DeleteComment(ctx context.Context, id string) error {
-	return r.getDB(ctx).Where("id = ?", id).Delete(&model.Comment{}).Error
+	return r.deleteComments(ctx, []string{id})
+}
+
+// GetWebmention 按目标 Echo 与来源 URL 查找已落库的 Webmention 评论。
+func (r *CommentRepository) GetWebmention(ctx context.Context, echoID, source string) (model.Comment, error) {
+	var item model.Comment
+	err := r.getDB(ctx).
+		Where("echo_id = ? AND source = ? AND website = ?", echoID, model.SourceWebmention, source).
+		First(&item).Error
+	return item, err
+}
+
+func (r *CommentRepository) UpdateWebmention(
+	ctx context.Context,
+	id, nickname, content string,
+	status model.Status,
+) error {
+	return r.getDB(ctx).
+		Model(&model.Comment{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"nickname": nickname, "content": content, "status": status}).Error
 }
 
 func (r *CommentRepository) BatchUpdateStatus(
@@ -149,7 +174,27 @@ func (r *CommentRepository) BatchDelete(ctx context.Context, ids []string) error
 	if len(ids) == 0 {
 		return nil
 	}
-	return r.getDB(ctx).Where("id IN ?", ids).Delete(&model.Comment{}).Error
+	return r.deleteComments(ctx, ids)
+}
+
+// deleteComments 删行并留下墓碑，实例间同步据此把删除带给对端。两步同在一个事务里，
+// 不会只删不记。
+func (r *CommentRepository) deleteComments(ctx context.Context, ids []string) error {
+	return r.getDB(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id IN ?", ids).Delete(&model.Comment{}).Error; err != nil {
+			return err
+		}
+		now := time.Now().Unix()
+		tombstones := make([]commonModel.Tombstone, 0, len(ids))
+		for _, id := range ids {
+			tombstones = append(tombstones, commonModel.Tombstone{
+				Kind:      commonModel.TombstoneComment,
+				ID:        id,
+				DeletedAt: now,
+			})
+		}
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&tombstones).Error
+	})
 }
 
 func (r *CommentRepository) CountByIPWithin(ctx context.Context, ipHash string, seconds int64) (int64, error) {
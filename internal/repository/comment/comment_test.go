@@ -9,6 +9,7 @@ import (
 	"time"
 
 	model "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	commentRepository "github.com/lin-snow/ech0/internal/repository/comment"
 	"github.com/lin-snow/ech0/internal/test/helpers"
 	"github.com/stretchr/testify/assert"
@@ -475,6 +476,35 @@ func TestCreateGetUpdateDelete(t *testing.T) {
 	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
 }
 
+func TestGetUpdateWebmention(t *testing.T) {
+	repo, _ := newRepo(t)
+	ctx := context.Background()
+
+	id := insert(t, repo, newComment(func(c *model.Comment) {
+		c.Source = model.SourceWebmention
+		c.Website = "https://blog.example/post"
+		c.Email = ""
+	}))
+	// 同一网址的普通访客评论不应被当作 Webmention 命中。
+	insert(t, repo, newComment(func(c *model.Comment) { c.Website = "https://blog.example/other" }))
+
+	got, err := repo.GetWebmention(ctx, "echo-1", "https://blog.example/post")
+	require.NoError(t, err)
+	assert.Equal(t, id, got.ID)
+
+	_, err = repo.GetWebmention(ctx, "echo-1", "https://blog.example/other")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	_, err = repo.GetWebmention(ctx, "echo-2", "https://blog.example/post")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	require.NoError(t, repo.UpdateWebmention(ctx, id, "bob", "updated", model.StatusPending))
+	got, err = repo.GetCommentByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "bob", got.Nickname)
+	assert.Equal(t, "updated", got.Content)
+	assert.Equal(t, model.StatusPending, got.Status)
+}
+
 func TestListPublicByEchoID(t *testing.T) {
 	repo, _ := newRepo(t)
 	ctx := context.Background()
@@ -542,3 +572,24 @@ func TestListPublicComments(t *testing.T) {
 		assert.Len(t, out, 3, "pending 不计入")
 	})
 }
+
+// 删除要留下墓碑，实例间同步据此把删除带给对端；再删一次只刷新删除时刻。
+func TestDeleteComments_LeaveTombstones(t *testing.T) {
+	repo, db := newRepo(t)
+	ctx := context.Background()
+	single := insert(t, repo, newComment())
+	batch := []string{insert(t, repo, newComment()), insert(t, repo, newComment())}
+
+	require.NoError(t, repo.DeleteComment(ctx, single))
+	require.NoError(t, repo.BatchDelete(ctx, batch))
+	require.NoError(t, repo.BatchDelete(ctx, batch))
+	assert.Zero(t, countRows(t, db))
+
+	var tombs []commonModel.Tombstone
+	require.NoError(t, db.Order("id").Find(&tombs).Error)
+	require.Len(t, tombs, 3)
+	for _, tomb := range tombs {
+		assert.Equal(t, commonModel.TombstoneComment, tomb.Kind)
+		assert.NotZero(t, tomb.DeletedAt)
+	}
+}
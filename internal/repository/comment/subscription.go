@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package repository
+
+import (
+	"context"
+
+	model "github.com/lin-snow/ech0/internal/model/comment"
+	"gorm.io/gorm/clause"
+)
+
+func (r *CommentRepository) GetCommentSubscription(
+	ctx context.Context,
+	echoID, email string,
+) (model.CommentSubscription, error) {
+	var sub model.CommentSubscription
+	err := r.getDB(ctx).Where("echo_id = ? AND email = ?", echoID, email).First(&sub).Error
+	return sub, err
+}
+
+// SaveCommentSubscription 按 (echo_id, email) 写入或覆盖订阅。
+func (r *CommentRepository) SaveCommentSubscription(ctx context.Context, sub *model.CommentSubscription) error {
+	return r.getDB(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "echo_id"}, {Name: "email"}},
+		DoUpdates: clause.AssignmentColumns([]string{"status", "created_at", "confirmed_at"}),
+	}).Create(sub).Error
+}
+
+func (r *CommentRepository) ConfirmCommentSubscription(ctx context.Context, id string, confirmedAt int64) error {
+	return r.getDB(ctx).
+		Model(&model.CommentSubscription{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"status": model.SubscriptionActive, "confirmed_at": confirmedAt}).Error
+}
+
+func (r *CommentRepository) ListActiveSubscriberEmails(ctx context.Context, echoID string) ([]string, error) {
+	var emails []string
+	err := r.getDB(ctx).
+		Model(&model.CommentSubscription{}).
+		Where("echo_id = ? AND status = ?", echoID, model.SubscriptionActive).
+		Order("created_at asc").
+		Pluck("email", &emails).Error
+	return emails, err
+}
+
+func (r *CommentRepository) DeleteCommentSubscription(ctx context.Context, echoID, email string) error {
+	return r.getDB(ctx).
+		Where("echo_id = ? AND email = ?", echoID, email).
+		Delete(&model.CommentSubscription{}).Error
+}
+
+func (r *CommentRepository) DeleteCommentSubscriptionsByEmail(ctx context.Context, email string) error {
+	return r.getDB(ctx).Where("email = ?", email).Delete(&model.CommentSubscription{}).Error
+}
+
+func (r *CommentRepository) IsEmailSuppressed(ctx context.Context, email string) (bool, error) {
+	var count int64
+	err := r.getDB(ctx).Model(&model.EmailSuppression{}).Where("email = ?", email).Count(&count).Error
+	return count > 0, err
+}
+
+// AddEmailSuppression 把地址加入退订名单；已存在时保持原记录不变。
+func (r *CommentRepository) AddEmailSuppression(ctx context.Context, email string) error {
+	return r.getDB(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.EmailSuppression{Email: email}).Error
+}
+
+func (r *CommentRepository) ListEmailSuppressions(ctx context.Context) ([]model.EmailSuppression, error) {
+	var out []model.EmailSuppression
+	err := r.getDB(ctx).Order("created_at desc").Find(&out).Error
+	return out, err
+}
+
+func (r *CommentRepository) DeleteEmailSuppression(ctx context.Context, email string) error {
+	return r.getDB(ctx).Where("email = ?", email).Delete(&model.EmailSuppression{}).Error
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	model "github.com/lin-snow/ech0/internal/model/comment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentSubscription_Lifecycle(t *testing.T) {
+	repo, db := newRepo(t)
+	ctx := context.Background()
+
+	require.NoError(t, repo.SaveCommentSubscription(ctx, &model.CommentSubscription{
+		EchoID: "echo-1", Email: "a@example.com", Status: model.SubscriptionPending, CreatedAt: 100,
+	}))
+	first, err := repo.GetCommentSubscription(ctx, "echo-1", "a@example.com")
+	require.NoError(t, err)
+	require.NotEmpty(t, first.ID)
+
+	// 重复申请按 (echo_id, email) 覆盖，不产生新行、保留原 ID。
+	require.NoError(t, repo.SaveCommentSubscription(ctx, &model.CommentSubscription{
+		EchoID: "echo-1", Email: "a@example.com", Status: model.SubscriptionPending, CreatedAt: 200,
+	}))
+	again, err := repo.GetCommentSubscription(ctx, "echo-1", "a@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, again.ID)
+	assert.Equal(t, int64(200), again.CreatedAt)
+	var n int64
+	require.NoError(t, db.Model(&model.CommentSubscription{}).Count(&n).Error)
+	assert.Equal(t, int64(1), n)
+
+	emails, err := repo.ListActiveSubscriberEmails(ctx, "echo-1")
+	require.NoError(t, err)
+	assert.Empty(t, emails, "未确认的订阅不收通知")
+
+	require.NoError(t, repo.ConfirmCommentSubscription(ctx, first.ID, 300))
+	require.NoError(t, repo.SaveCommentSubscription(ctx, &model.CommentSubscription{
+		EchoID: "echo-1", Email: "b@example.com", Status: model.SubscriptionPending,
+	}))
+	require.NoError(t, repo.SaveCommentSubscription(ctx, &model.CommentSubscription{
+		EchoID: "echo-2", Email: "a@example.com", Status: model.SubscriptionActive,
+	}))
+	emails, err = repo.ListActiveSubscriberEmails(ctx, "echo-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a@example.com"}, emails)
+
+	require.NoError(t, repo.DeleteCommentSubscription(ctx, "echo-1", "a@example.com"))
+	_, err = repo.GetCommentSubscription(ctx, "echo-1", "a@example.com")
+	require.Error(t, err)
+
+	require.NoError(t, repo.DeleteCommentSubscriptionsByEmail(ctx, "a@example.com"))
+	_, err = repo.GetCommentSubscription(ctx, "echo-2", "a@example.com")
+	require.Error(t, err)
+	_, err = repo.GetCommentSubscription(ctx, "echo-1", "b@example.com")
+	require.NoError(t, err, "其他邮箱的订阅不受影响")
+}
+
+func TestEmailSuppression(t *testing.T) {
+	repo, _ := newRepo(t)
+	ctx := context.Background()
+
+	suppressed, err := repo.IsEmailSuppressed(ctx, "a@example.com")
+	require.NoError(t, err)
+	assert.False(t, suppressed)
+
+	require.NoError(t, repo.AddEmailSuppression(ctx, "a@example.com"))
+	require.NoError(t, repo.AddEmailSuppression(ctx, "a@example.com"), "重复退订是幂等的")
+	suppressed, err = repo.IsEmailSuppressed(ctx, "a@example.com")
+	require.NoError(t, err)
+	assert.True(t, suppressed)
+
+	list, err := repo.ListEmailSuppressions(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "a@example.com", list[0].Email)
+	assert.NotZero(t, list[0].CreatedAt)
+
+	require.NoError(t, repo.DeleteEmailSuppression(ctx, "a@example.com"))
+	suppressed, err = repo.IsEmailSuppressed(ctx, "a@example.com")
+	require.NoError(t, err)
+	assert.False(t, suppressed)
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"testing"
 
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	echoModel "github.com/lin-snow/ech0/internal/model/echo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -38,3 +39,15 @@ func TestEchoRepository_DeleteEchoById_RemovesExtension(t *testing.T) {
 	require.NoError(t, db.Model(&echoModel.EchoExtension{}).Where("echo_id = ?", "e2").Count(&count).Error)
 	assert.EqualValues(t, 1, count, "other echo's extension should stay")
 }
+
+// 删除要留下墓碑，实例间同步据此把删除带给对端。
+func TestEchoRepository_DeleteEchoById_LeavesTombstone(t *testing.T) {
+	repo, db := newEchoRepo(t)
+	seedEcho(t, db, "e1", "gone", false, 0, 100)
+
+	require.NoError(t, repo.DeleteEchoById(context.Background(), "e1"))
+
+	var tomb commonModel.Tombstone
+	require.NoError(t, db.Where("kind = ? AND id = ?", commonModel.TombstoneEcho, "e1").Take(&tomb).Error)
+	assert.NotZero(t, tomb.DeletedAt)
+}
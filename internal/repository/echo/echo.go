@@ -17,6 +17,7 @@ import (
 	"github.com/lin-snow/ech0/internal/transaction"
 	timezoneUtil "github.com/lin-snow/ech0/internal/util/timezone"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type EchoRepository struct {
@@ -176,7 +177,12 @@ func (echoRepository *EchoRepository) DeleteEchoById(ctx context.Context, id str
 		return gorm.ErrRecordNotFound
 	}
 
-	return nil
+	// 留下墓碑，实例间同步据此把删除带给对端。与删行同在调用方的事务里，不会只删不记。
+	return echoRepository.getDB(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&commonModel.Tombstone{
+		Kind:      commonModel.TombstoneEcho,
+		ID:        id,
+		DeletedAt: time.Now().Unix(),
+	}).Error
 }
 
 func (echoRepository *EchoRepository) GetTodayEchos(showPrivate bool, timezone string) []model.Echo {
@@ -409,6 +415,9 @@ func (echoRepository *EchoRepository) QueryEchos(
 		if queryDto.UserID != "" {
 			db = db.Where("echos.user_id = ?", queryDto.UserID)
 		}
+		if len(queryDto.IDs) > 0 {
+			db = db.Where("echos.id IN ?", queryDto.IDs)
+		}
 		if queryDto.Search != "" {
 			db = db.Where("echos.content LIKE ?", "%"+queryDto.Search+"%")
 		}
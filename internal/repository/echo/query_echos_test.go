@@ -304,3 +304,19 @@ func TestEchoRepository_QueryEchos_UserIDFilter(t *testing.T) {
 	require.Len(t, echos, 1)
 	assert.Equal(t, "e-alice", echos[0].ID)
 }
+
+// IDs 把结果限定在给定集合内，且不绕过可见性：私密条目对无权限查询仍被过滤。
+func TestEchoRepository_QueryEchos_IDsFilter(t *testing.T) {
+	repo, db := newEchoRepo(t)
+	seedEcho(t, db, "e1", "one", false, 0, 100)
+	seedEcho(t, db, "e2", "two", true, 0, 200)
+	seedEcho(t, db, "e3", "three", false, 0, 300)
+
+	echos, total, err := repo.QueryEchos(
+		commonModel.EchoQueryDto{Page: 1, PageSize: 10, SortBy: "created_at", SortOrder: "desc", IDs: []string{"e1", "e2"}},
+		false,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, []string{"e1"}, echoIDs(echos))
+}
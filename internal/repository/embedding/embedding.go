@@ -182,6 +182,75 @@ func (r *EmbeddingRepository) Search(ctx context.Context, vector []float32, k in
 	return results, nil
 }
 
+// Related 以 echoID 已存的向量做 KNN，返回与之最近的 k 条（不含自身）。
+// 该 Echo 尚未建索引（或向量表还未创建）时 found=false，由调用方决定降级策略。
+func (r *EmbeddingRepository) Related(ctx context.Context, echoID string, k int) ([]model.SearchResult, bool, error) {
+	if k <= 0 {
+		k = 6
+	}
+	var stored []string
+	if err := r.getDB(ctx).Raw(
+		"SELECT vec_to_json(embedding) FROM "+vecTable+" WHERE echo_id = ?", echoID,
+	).Scan(&stored).Error; err != nil {
+		if strings.Contains(err.Error(), "no such table") {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	if len(stored) == 0 || stored[0] == "" {
+		return nil, false, nil
+	}
+
+	type knnRow struct {
+		EchoID   string
+		Distance float64
+	}
+	var rows []knnRow
+	if err := r.getDB(ctx).Raw(
+		"SELECT echo_id, distance FROM "+vecTable+" WHERE embedding MATCH ? ORDER BY distance LIMIT ?",
+		stored[0], k+1,
+	).Scan(&rows).Error; err != nil {
+		return nil, true, err
+	}
+
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if row.EchoID != echoID {
+			ids = append(ids, row.EchoID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, true, nil
+	}
+	var metas []model.EchoEmbedding
+	if err := r.getDB(ctx).Where("echo_id IN ?", ids).Find(&metas).Error; err != nil {
+		return nil, true, err
+	}
+	metaByID := make(map[string]model.EchoEmbedding, len(metas))
+	for _, m := range metas {
+		metaByID[m.EchoID] = m
+	}
+
+	results := make([]model.SearchResult, 0, min(k, len(ids)))
+	for _, row := range rows {
+		m, ok := metaByID[row.EchoID]
+		if !ok || row.EchoID == echoID {
+			continue
+		}
+		results = append(results, model.SearchResult{
+			EchoID:      m.EchoID,
+			Content:     m.Content,
+			Username:    m.Username,
+			EchoCreated: m.EchoCreated,
+			Distance:    row.Distance,
+		})
+		if len(results) >= k {
+			break
+		}
+	}
+	return results, true, nil
+}
+
 func (r *EmbeddingRepository) ClearAll(ctx context.Context) error {
 	db := r.getDB(ctx)
 	if err := db.Where("1 = 1").Delete(&model.EchoEmbedding{}).Error; err != nil {
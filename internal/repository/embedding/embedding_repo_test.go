@@ -383,3 +383,36 @@ func assertAscending(t *testing.T, res []model.SearchResult) {
 		assert.LessOrEqual(t, res[i-1].Distance, res[i].Distance, "results must be in ascending distance order")
 	}
 }
+
+func TestEmbeddingRepository_Related(t *testing.T) {
+	t.Run("missing table or vector reports not found", func(t *testing.T) {
+		repo, _ := newEmbeddingRepo(t)
+		ctx := context.Background()
+
+		res, found, err := repo.Related(ctx, "ghost", 3)
+		require.NoError(t, err)
+		assert.False(t, found)
+		assert.Nil(t, res)
+
+		require.NoError(t, repo.EnsureVecTable(ctx, 4))
+		_, found, err = repo.Related(ctx, "ghost", 3)
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("nearest neighbours of the stored vector excluding itself", func(t *testing.T) {
+		repo, _ := newEmbeddingRepo(t)
+		ctx := context.Background()
+		require.NoError(t, repo.EnsureVecTable(ctx, 4))
+		for i := 1; i <= 5; i++ {
+			seed(t, repo, ctx, idAt(i), "u", float32(i))
+		}
+
+		res, found, err := repo.Related(ctx, idAt(3), 2)
+		require.NoError(t, err)
+		assert.True(t, found)
+		require.Len(t, res, 2)
+		assert.ElementsMatch(t, []string{idAt(2), idAt(4)}, ids(res))
+		assertAscending(t, res)
+	})
+}
@@ -6,13 +6,18 @@ package repository
 import (
 	"context"
 	"strings"
+	"time"
 
 	model "github.com/lin-snow/ech0/internal/model/file"
 	fileService "github.com/lin-snow/ech0/internal/service/file"
 	"github.com/lin-snow/ech0/internal/transaction"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// storageTypeExternal 与 storage.StorageTypeExternal 同值：外链文件没有受管字节，不参与内容哈希。
+const storageTypeExternal = "external"
+
 type FileRepository struct {
 	db func() *gorm.DB
 }
@@ -151,6 +156,143 @@ func (r *FileRepository) UpdateMetaByID(
 	return r.GetByID(ctx, id)
 }
 
+func (r *FileRepository) UpdateAltTextByID(ctx context.Context, id string, altText string) error {
+	return r.getDB(ctx).Model(&model.File{}).Where("id = ?", id).Update("alt_text", altText).Error
+}
+
+// CountByRoute 统计某路由上的文件数。
+func (r *FileRepository) CountByRoute(ctx context.Context, storageType, bucket string) (int64, error) {
+	var total int64
+	err := r.getDB(ctx).Model(&model.File{}).
+		Where("storage_type = ? AND bucket = ?", storageType, bucket).
+		Count(&total).Error
+	return total, err
+}
+
+// ListByRoute 按 id 游标分页列出某路由上的文件（afterID 为上一页末行 id，首页传空）。
+// 迁移途中被改写走的行自然从结果集里消失，游标只需单调前进。
+func (r *FileRepository) ListByRoute(
+	ctx context.Context,
+	storageType, bucket, afterID string,
+	limit int,
+) ([]model.File, error) {
+	var files []model.File
+	err := r.getDB(ctx).
+		Where("storage_type = ? AND bucket = ? AND id > ?", storageType, bucket, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&files).Error
+	return files, err
+}
+
+// UpdateRouteByID 以一条 UPDATE 把文件行从 from 路由改写到 to 路由并刷新 URL 快照。
+// 条件带上 from：行已被删除或已不在 from 上时不改写，返回 false。
+func (r *FileRepository) UpdateRouteByID(
+	ctx context.Context,
+	id string,
+	from, to model.StorageRoute,
+	url string,
+) (bool, error) {
+	result := r.getDB(ctx).Model(&model.File{}).
+		Where("id = ? AND storage_type = ? AND bucket = ?", id, from.StorageType, from.Bucket).
+		Updates(map[string]any{
+			"storage_type": to.StorageType,
+			"provider":     to.Provider,
+			"bucket":       to.Bucket,
+			"url":          url,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// CountByRouteKey 统计某路由上引用 key 的文件行数（即该存储对象的引用计数），
+// excludeID 非空时不计该行——调用方正要删它、但行还在库里。
+func (r *FileRepository) CountByRouteKey(
+	ctx context.Context,
+	storageType, bucket, key, excludeID string,
+) (int64, error) {
+	var total int64
+	db := r.getDB(ctx).Model(&model.File{}).
+		Where("storage_type = ? AND bucket = ? AND key = ?", storageType, bucket, key)
+	if excludeID != "" {
+		db = db.Where("id <> ?", excludeID)
+	}
+	err := db.Count(&total).Error
+	return total, err
+}
+
+// GetByContentHash 取某路由上内容哈希为 hash 的最早一行，作为上传去重的复用目标。
+func (r *FileRepository) GetByContentHash(
+	ctx context.Context,
+	storageType, bucket, hash string,
+) (*model.File, error) {
+	var f model.File
+	if err := r.getDB(ctx).
+		Where("storage_type = ? AND bucket = ? AND hash = ?", storageType, bucket, hash).
+		Order("created_at ASC, id ASC").
+		First(&f).Error; err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// ListUnhashed 按 id 游标分页列出还没有内容哈希的受管文件（external 无字节可算，排除在外）。
+func (r *FileRepository) ListUnhashed(ctx context.Context, afterID string, limit int) ([]model.File, error) {
+	var files []model.File
+	err := r.getDB(ctx).
+		Where("hash = '' AND key <> '' AND storage_type <> ? AND id > ?", storageTypeExternal, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&files).Error
+	return files, err
+}
+
+func (r *FileRepository) UpdateHashByID(ctx context.Context, id string, hash string) error {
+	return r.getDB(ctx).Model(&model.File{}).Where("id = ?", id).Update("hash", hash).Error
+}
+
+// ListDuplicateContentGroups 找出同一路由上哈希相同、却分散在多个 key 上的文件组。
+func (r *FileRepository) ListDuplicateContentGroups(ctx context.Context) ([]model.ContentGroup, error) {
+	var groups []model.ContentGroup
+	err := r.getDB(ctx).Model(&model.File{}).
+		Select("storage_type, bucket, hash").
+		Where("hash <> '' AND storage_type <> ?", storageTypeExternal).
+		Group("storage_type, bucket, hash").
+		Having("COUNT(DISTINCT key) > 1").
+		Order("storage_type, bucket, hash").
+		Scan(&groups).Error
+	return groups, err
+}
+
+// ListByContentGroup 列出一个重复组里的全部行，最早的在前。
+func (r *FileRepository) ListByContentGroup(ctx context.Context, group model.ContentGroup) ([]model.File, error) {
+	var files []model.File
+	err := r.getDB(ctx).
+		Where("storage_type = ? AND bucket = ? AND hash = ?", group.StorageType, group.Bucket, group.Hash).
+		Order("created_at ASC, id ASC").
+		Find(&files).Error
+	return files, err
+}
+
+// UpdateKeyByID 把 route 上的文件行从 fromKey 改指向 toKey 并刷新 URL 快照；
+// 行已被删除、搬离 route 或不在 fromKey 上时不改写，返回 false。
+func (r *FileRepository) UpdateKeyByID(
+	ctx context.Context,
+	id string,
+	route model.StorageRoute,
+	fromKey, toKey, url string,
+) (bool, error) {
+	result := r.getDB(ctx).Model(&model.File{}).
+		Where("id = ? AND storage_type = ? AND bucket = ? AND key = ?", id, route.StorageType, route.Bucket, fromKey).
+		Updates(map[string]any{"key": toKey, "url": url})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
 func (r *FileRepository) Delete(ctx context.Context, id string) error {
 	return r.getDB(ctx).Where("id = ?", id).Delete(&model.File{}).Error
 }
@@ -200,3 +342,63 @@ func (r *FileRepository) ListByIDs(ctx context.Context, ids []string) ([]model.F
 	err := r.getDB(ctx).Where("id IN ?", ids).Find(&files).Error
 	return files, err
 }
+
+// AddUsage 给 (userID, category) 的用量账本加上 bytes / files 的增量（可为负），
+// 账本行不存在时创建；结果不会低于 0，漂移留给定时重算校正。
+func (r *FileRepository) AddUsage(ctx context.Context, userID, category string, bytes, files int64) error {
+	usage := model.StorageUsage{
+		UserID:   userID,
+		Category: category,
+		Bytes:    max(bytes, 0),
+		Files:    max(files, 0),
+	}
+	return r.getDB(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "category"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"bytes":      gorm.Expr("MAX(storage_usages.bytes + ?, 0)", bytes),
+			"files":      gorm.Expr("MAX(storage_usages.files + ?, 0)", files),
+			"updated_at": time.Now().UTC().Unix(),
+		}),
+	}).Create(&usage).Error
+}
+
+// SumUsageByUser 返回账本中某用户各分类用量之和（字节）。
+func (r *FileRepository) SumUsageByUser(ctx context.Context, userID string) (int64, error) {
+	var total int64
+	err := r.getDB(ctx).Model(&model.StorageUsage{}).
+		Select("COALESCE(SUM(bytes), 0)").
+		Where("user_id = ?", userID).
+		Scan(&total).Error
+	return total, err
+}
+
+// ListUsage 列出用量账本的全部行。
+func (r *FileRepository) ListUsage(ctx context.Context) ([]model.StorageUsage, error) {
+	var usages []model.StorageUsage
+	err := r.getDB(ctx).Order("user_id ASC, category ASC").Find(&usages).Error
+	return usages, err
+}
+
+// AggregateUsage 按实际文件行统计每个 (用户, 分类) 的受管文件用量，外链文件不计。
+func (r *FileRepository) AggregateUsage(ctx context.Context) ([]model.StorageUsage, error) {
+	var usages []model.StorageUsage
+	err := r.getDB(ctx).Model(&model.File{}).
+		Select("user_id, category, COALESCE(SUM(size), 0) AS bytes, COUNT(*) AS files").
+		Where("storage_type <> ?", storageTypeExternal).
+		Group("user_id, category").
+		Order("user_id ASC, category ASC").
+		Scan(&usages).Error
+	return usages, err
+}
+
+// ReplaceUsage 用 usages 整体替换用量账本，调用方负责放进事务。
+func (r *FileRepository) ReplaceUsage(ctx context.Context, usages []model.StorageUsage) error {
+	db := r.getDB(ctx)
+	if err := db.Where("1 = 1").Delete(&model.StorageUsage{}).Error; err != nil {
+		return err
+	}
+	if len(usages) == 0 {
+		return nil
+	}
+	return db.Create(&usages).Error
+}
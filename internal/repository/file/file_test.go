@@ -281,6 +281,127 @@ func TestFileRepository_DeleteByRoute(t *testing.T) {
 	})
 }
 
+func TestFileRepository_ListAndCountByRoute(t *testing.T) {
+	repo, db := newFileRepo(t)
+	for _, id := range []string{"lr-1", "lr-2", "lr-3"} {
+		insertFile(t, db, fileModel.File{ID: id, Key: id, StorageType: "local", UserID: "u-1"})
+	}
+	insertFile(t, db, fileModel.File{
+		ID: "lr-obj", Key: "lr-obj", StorageType: "object", Provider: "r2", Bucket: "main", UserID: "u-1",
+	})
+
+	total, err := repo.CountByRoute(context.Background(), "local", "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+
+	page, err := repo.ListByRoute(context.Background(), "local", "", "", 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, "lr-1", page[0].ID)
+
+	rest, err := repo.ListByRoute(context.Background(), "local", "", page[1].ID, 2)
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	assert.Equal(t, "lr-3", rest[0].ID)
+
+	objects, err := repo.ListByRoute(context.Background(), "object", "main", "", 10)
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	assert.Equal(t, "lr-obj", objects[0].ID)
+}
+
+func TestFileRepository_UpdateRouteByID(t *testing.T) {
+	repo, db := newFileRepo(t)
+	insertFile(t, db, fileModel.File{ID: "ur-1", Key: "urk", StorageType: "local", URL: "/api/files/urk", UserID: "u-1"})
+	local := fileModel.StorageRoute{StorageType: "local"}
+	object := fileModel.StorageRoute{StorageType: "object", Provider: "r2", Bucket: "main"}
+
+	t.Run("stale source route is not rewritten", func(t *testing.T) {
+		moved, err := repo.UpdateRouteByID(context.Background(), "ur-1", object, local, "x")
+		require.NoError(t, err)
+		assert.False(t, moved)
+	})
+
+	t.Run("rewrites route columns and url", func(t *testing.T) {
+		moved, err := repo.UpdateRouteByID(context.Background(), "ur-1", local, object, "https://cdn/urk")
+		require.NoError(t, err)
+		assert.True(t, moved)
+
+		var got fileModel.File
+		require.NoError(t, db.Session(&gorm.Session{SkipHooks: true}).First(&got, "id = ?", "ur-1").Error)
+		assert.Equal(t, "object", got.StorageType)
+		assert.Equal(t, "r2", got.Provider)
+		assert.Equal(t, "main", got.Bucket)
+		assert.Equal(t, "https://cdn/urk", got.URL)
+	})
+}
+
+func TestFileRepository_ContentHash(t *testing.T) {
+	repo, db := newFileRepo(t)
+	ctx := context.Background()
+	insertFile(t, db, fileModel.File{ID: "h-1", Key: "a.png", StorageType: "local", Hash: "sum1", CreatedAt: 1, UserID: "u-1"})
+	insertFile(t, db, fileModel.File{ID: "h-2", Key: "a.png", StorageType: "local", Hash: "sum1", CreatedAt: 2, UserID: "u-1"})
+	insertFile(t, db, fileModel.File{ID: "h-3", Key: "b.png", StorageType: "local", Hash: "sum1", CreatedAt: 3, UserID: "u-1"})
+	insertFile(t, db, fileModel.File{ID: "h-4", Key: "c.png", StorageType: "local", CreatedAt: 4, UserID: "u-1"})
+	insertFile(t, db, fileModel.File{ID: "h-5", Key: "external/x", StorageType: "external", CreatedAt: 5, UserID: "u-1"})
+
+	t.Run("count by route key honours exclude", func(t *testing.T) {
+		n, err := repo.CountByRouteKey(ctx, "local", "", "a.png", "")
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), n)
+		n, err = repo.CountByRouteKey(ctx, "local", "", "a.png", "h-1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+	})
+
+	t.Run("get by content hash returns the oldest row", func(t *testing.T) {
+		got, err := repo.GetByContentHash(ctx, "local", "", "sum1")
+		require.NoError(t, err)
+		assert.Equal(t, "h-1", got.ID)
+		_, err = repo.GetByContentHash(ctx, "object", "main", "sum1")
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+
+	t.Run("list unhashed skips external", func(t *testing.T) {
+		files, err := repo.ListUnhashed(ctx, "", 10)
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		assert.Equal(t, "h-4", files[0].ID)
+	})
+
+	t.Run("duplicate groups need more than one key", func(t *testing.T) {
+		groups, err := repo.ListDuplicateContentGroups(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []fileModel.ContentGroup{{StorageType: "local", Hash: "sum1"}}, groups)
+
+		files, err := repo.ListByContentGroup(ctx, groups[0])
+		require.NoError(t, err)
+		require.Len(t, files, 3)
+		assert.Equal(t, "h-1", files[0].ID)
+	})
+
+	t.Run("update key is guarded by route and old key", func(t *testing.T) {
+		local := fileModel.StorageRoute{StorageType: "local"}
+		moved, err := repo.UpdateKeyByID(ctx, "h-3", fileModel.StorageRoute{StorageType: "object", Bucket: "main"}, "b.png", "a.png", "/a")
+		require.NoError(t, err)
+		assert.False(t, moved)
+		moved, err = repo.UpdateKeyByID(ctx, "h-3", local, "b.png", "a.png", "/api/files/a.png")
+		require.NoError(t, err)
+		assert.True(t, moved)
+
+		groups, err := repo.ListDuplicateContentGroups(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, groups)
+	})
+
+	t.Run("update hash", func(t *testing.T) {
+		require.NoError(t, repo.UpdateHashByID(ctx, "h-4", "sum4"))
+		files, err := repo.ListUnhashed(ctx, "", 10)
+		require.NoError(t, err)
+		assert.Empty(t, files)
+	})
+}
+
 func TestFileRepository_TempLifecycle(t *testing.T) {
 	repo, db := newFileRepo(t)
 
@@ -1,22 +1,20 @@
 // SPDX-License-Identifier: AGPL-3.0-or-later
 // Copyright (C) 2025-2026 lin-snow
 
-// Package repository 实现 job.JobRepository（jobs 表的 GORM 持久化）。
+// Package repository 实现 job.JobRepository（job_runs 表的 GORM 持久化）。
 package repository
 
 import (
 	"context"
 	"errors"
-	"time"
 
 	"github.com/lin-snow/ech0/internal/job"
 	jobModel "github.com/lin-snow/ech0/internal/model/job"
 	"github.com/lin-snow/ech0/internal/transaction"
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
-// JobRepository 是 jobs 表的 GORM 实现。
+// JobRepository 是 job_runs 表的 GORM 实现。
 type JobRepository struct {
 	db func() *gorm.DB
 }
@@ -36,37 +34,90 @@ func (r *JobRepository) getDB(ctx context.Context) *gorm.DB {
 	return r.db()
 }
 
-// Upsert 按主键 Type 原子写入/覆盖（每 type 单行）。
-func (r *JobRepository) Upsert(ctx context.Context, j *jobModel.Job) error {
-	return r.getDB(ctx).Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "type"}},
-		UpdateAll: true,
-	}).Create(j).Error
+var activeStatuses = []jobModel.Status{jobModel.StatusPending, jobModel.StatusRunning}
+
+// Create 新建一次提交的行。
+func (r *JobRepository) Create(ctx context.Context, j *jobModel.Job) error {
+	return r.getDB(ctx).Create(j).Error
+}
+
+// Save 按 ID 覆盖整行。
+func (r *JobRepository) Save(ctx context.Context, j *jobModel.Job) error {
+	return r.getDB(ctx).Save(j).Error
+}
+
+// GetByID 查无返回 (零值, job.ErrNotFound)。
+func (r *JobRepository) GetByID(ctx context.Context, id string) (jobModel.Job, error) {
+	var j jobModel.Job
+	err := r.getDB(ctx).Where("id = ?", id).First(&j).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return jobModel.Job{}, job.ErrNotFound
+	}
+	return j, err
 }
 
-// GetByType 查无返回 (零值, job.ErrNotFound)。
-func (r *JobRepository) GetByType(ctx context.Context, jobType string) (jobModel.Job, error) {
+// Latest 返回该类型最近一次未收起的提交（ID 为 UUIDv7，按 ID 倒序即按提交倒序）。
+func (r *JobRepository) Latest(ctx context.Context, jobType string) (jobModel.Job, error) {
 	var j jobModel.Job
-	err := r.getDB(ctx).Where("type = ?", jobType).First(&j).Error
+	err := r.getDB(ctx).
+		Where("type = ? AND dismissed = ?", jobType, false).
+		Order("id DESC").
+		First(&j).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return jobModel.Job{}, job.ErrNotFound
 	}
 	return j, err
 }
 
-// SweepRunning 把残留的 pending/running 行批量置 failed（启动期孤儿清理）。幂等。
-func (r *JobRepository) SweepRunning(ctx context.Context, reason string) error {
-	now := time.Now().UTC().Unix()
+// Active 按提交先后返回 pending/running 行；jobType 为空即全部类型。
+func (r *JobRepository) Active(ctx context.Context, jobType string) ([]jobModel.Job, error) {
+	query := r.getDB(ctx).Where("status IN ?", activeStatuses)
+	if jobType != "" {
+		query = query.Where("type = ?", jobType)
+	}
+	var rows []jobModel.Job
+	err := query.Order("id ASC").Find(&rows).Error
+	return rows, err
+}
+
+// List 按提交倒序分页列出作业，并返回筛选后的总数。Limit 缺省 20。
+func (r *JobRepository) List(ctx context.Context, q job.ListQuery) ([]jobModel.Job, int64, error) {
+	query := r.getDB(ctx).Model(&jobModel.Job{})
+	if q.Type != "" {
+		query = query.Where("type = ?", q.Type)
+	}
+	if q.Status != "" {
+		query = query.Where("status = ?", q.Status)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	var rows []jobModel.Job
+	err := query.Order("id DESC").Limit(limit).Offset(q.Offset).Find(&rows).Error
+	return rows, total, err
+}
+
+// Dismiss 把该类型的终态行标记为已收起。
+func (r *JobRepository) Dismiss(ctx context.Context, jobType string) error {
 	return r.getDB(ctx).Model(&jobModel.Job{}).
-		Where("status IN ?", []jobModel.Status{jobModel.StatusPending, jobModel.StatusRunning}).
-		Updates(map[string]any{
-			"status":      jobModel.StatusFailed,
-			"error":       reason,
-			"finished_at": now,
-		}).Error
+		Where("type = ? AND status NOT IN ?", jobType, activeStatuses).
+		Update("dismissed", true).Error
 }
 
-// Delete 删除该 type 的行。
-func (r *JobRepository) Delete(ctx context.Context, jobType string) error {
-	return r.getDB(ctx).Where("type = ?", jobType).Delete(&jobModel.Job{}).Error
+// Prune 只保留该类型最近 keep 条终态行。
+func (r *JobRepository) Prune(ctx context.Context, jobType string, keep int) error {
+	db := r.getDB(ctx)
+	keepIDs := db.Model(&jobModel.Job{}).
+		Select("id").
+		Where("type = ? AND status NOT IN ?", jobType, activeStatuses).
+		Order("id DESC").
+		Limit(keep)
+	return db.
+		Where("type = ? AND status NOT IN ? AND id NOT IN (?)", jobType, activeStatuses, keepIDs).
+		Delete(&jobModel.Job{}).Error
 }
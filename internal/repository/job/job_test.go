@@ -11,6 +11,7 @@ import (
 	"github.com/lin-snow/ech0/internal/job"
 	jobModel "github.com/lin-snow/ech0/internal/model/job"
 	jobRepository "github.com/lin-snow/ech0/internal/repository/job"
+	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -30,76 +31,166 @@ func newTestRepo(t *testing.T) (*jobRepository.JobRepository, *gorm.DB) {
 	return jobRepository.NewJobRepository(func() *gorm.DB { return db }), db
 }
 
-func TestRepo_GetByType_NotFound(t *testing.T) {
+// create 以 UUIDv7 新建一行并返回其 ID；先建的 ID 更小。
+func create(t *testing.T, repo *jobRepository.JobRepository, jobType string, status jobModel.Status) string {
+	t.Helper()
+	j := &jobModel.Job{ID: uuidUtil.MustNewV7(), Type: jobType, Status: status}
+	if err := repo.Create(context.Background(), j); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	return j.ID
+}
+
+func TestRepo_GetByID_NotFound(t *testing.T) {
 	repo, _ := newTestRepo(t)
-	if _, err := repo.GetByType(context.Background(), "reindex"); !errors.Is(err, job.ErrNotFound) {
+	if _, err := repo.GetByID(context.Background(), "missing"); !errors.Is(err, job.ErrNotFound) {
 		t.Fatalf("expected ErrNotFound, got %v", err)
 	}
+	if _, err := repo.Latest(context.Background(), "reindex"); !errors.Is(err, job.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound from Latest, got %v", err)
+	}
 }
 
-func TestRepo_UpsertOverwritesByType(t *testing.T) {
+func TestRepo_KeepsOneRowPerSubmission(t *testing.T) {
 	repo, db := newTestRepo(t)
 	ctx := context.Background()
-	if err := repo.Upsert(ctx, &jobModel.Job{Type: "reindex", Status: jobModel.StatusPending}); err != nil {
-		t.Fatalf("upsert pending failed: %v", err)
+	first := create(t, repo, "reindex", jobModel.StatusSuccess)
+	second := create(t, repo, "reindex", jobModel.StatusPending)
+
+	got, err := repo.Latest(ctx, "reindex")
+	if err != nil {
+		t.Fatalf("latest failed: %v", err)
 	}
-	if err := repo.Upsert(ctx, &jobModel.Job{Type: "reindex", Status: jobModel.StatusSuccess, Payload: `{"indexed":3}`}); err != nil {
-		t.Fatalf("upsert success failed: %v", err)
+	if got.ID != second {
+		t.Fatalf("expected latest submission %s, got %s", second, got.ID)
 	}
-	got, err := repo.GetByType(ctx, "reindex")
-	if err != nil {
-		t.Fatalf("get failed: %v", err)
+
+	got.Status = jobModel.StatusSuccess
+	got.Payload = `{"indexed":3}`
+	if err := repo.Save(ctx, &got); err != nil {
+		t.Fatalf("save failed: %v", err)
 	}
-	if got.Status != jobModel.StatusSuccess || got.Payload != `{"indexed":3}` {
-		t.Fatalf("expected single overwritten row, got %+v", got)
+	reloaded, err := repo.GetByID(ctx, second)
+	if err != nil || reloaded.Payload != `{"indexed":3}` {
+		t.Fatalf("expected saved payload, got %+v err=%v", reloaded, err)
 	}
-	// 主键即 type → 同 type 仅一行。
+	if old, err := repo.GetByID(ctx, first); err != nil || old.Status != jobModel.StatusSuccess {
+		t.Fatalf("earlier submission must be kept, got %+v err=%v", old, err)
+	}
+
 	var count int64
 	if err := db.Model(&jobModel.Job{}).Where("type = ?", "reindex").Count(&count).Error; err != nil {
 		t.Fatalf("count failed: %v", err)
 	}
-	if count != 1 {
-		t.Fatalf("expected exactly 1 row per type, got %d", count)
+	if count != 2 {
+		t.Fatalf("expected 2 rows for 2 submissions, got %d", count)
 	}
 }
 
-func TestRepo_SweepRunning(t *testing.T) {
+func TestRepo_Active_OrderedBySubmission(t *testing.T) {
 	repo, _ := newTestRepo(t)
 	ctx := context.Background()
-	_ = repo.Upsert(ctx, &jobModel.Job{Type: "reindex", Status: jobModel.StatusRunning})
-	if err := repo.SweepRunning(ctx, "interrupted by restart"); err != nil {
-		t.Fatalf("sweep failed: %v", err)
+	create(t, repo, "export", jobModel.StatusSuccess)
+	running := create(t, repo, "export", jobModel.StatusRunning)
+	pending := create(t, repo, "export", jobModel.StatusPending)
+	other := create(t, repo, "sync", jobModel.StatusPending)
+
+	rows, err := repo.Active(ctx, "export")
+	if err != nil {
+		t.Fatalf("active failed: %v", err)
 	}
-	got, err := repo.GetByType(ctx, "reindex")
+	if len(rows) != 2 || rows[0].ID != running || rows[1].ID != pending {
+		t.Fatalf("expected [running, pending] for export, got %+v", rows)
+	}
+
+	all, err := repo.Active(ctx, "")
 	if err != nil {
-		t.Fatalf("get failed: %v", err)
+		t.Fatalf("active all failed: %v", err)
 	}
-	if got.Status != jobModel.StatusFailed || got.Error != "interrupted by restart" || got.FinishedAt == nil {
-		t.Fatalf("expected swept to failed with reason+finished, got %+v", got)
+	if len(all) != 3 || all[2].ID != other {
+		t.Fatalf("expected 3 active rows across types, got %+v", all)
 	}
 }
 
-func TestRepo_SweepRunning_LeavesTerminal(t *testing.T) {
+func TestRepo_List_FiltersAndPaginates(t *testing.T) {
 	repo, _ := newTestRepo(t)
 	ctx := context.Background()
-	_ = repo.Upsert(ctx, &jobModel.Job{Type: "reindex", Status: jobModel.StatusSuccess})
-	if err := repo.SweepRunning(ctx, "interrupted by restart"); err != nil {
-		t.Fatalf("sweep failed: %v", err)
+	var ids []string
+	for i := 0; i < 5; i++ {
+		ids = append(ids, create(t, repo, "export", jobModel.StatusSuccess))
 	}
-	got, _ := repo.GetByType(ctx, "reindex")
-	if got.Status != jobModel.StatusSuccess {
-		t.Fatalf("sweep must not touch terminal rows, got %q", got.Status)
+	create(t, repo, "export", jobModel.StatusFailed)
+	create(t, repo, "sync", jobModel.StatusSuccess)
+
+	rows, total, err := repo.List(ctx, job.ListQuery{
+		Type:   "export",
+		Status: jobModel.StatusSuccess,
+		Limit:  2,
+		Offset: 1,
+	})
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if total != 5 {
+		t.Fatalf("expected total 5, got %d", total)
+	}
+	// 倒序：第二页起点是倒数第二个。
+	if len(rows) != 2 || rows[0].ID != ids[3] || rows[1].ID != ids[2] {
+		t.Fatalf("unexpected page %+v", rows)
+	}
+
+	_, total, err = repo.List(ctx, job.ListQuery{})
+	if err != nil || total != 7 {
+		t.Fatalf("expected unfiltered total 7, got %d err=%v", total, err)
+	}
+}
+
+func TestRepo_Dismiss_HidesTerminalFromLatest(t *testing.T) {
+	repo, _ := newTestRepo(t)
+	ctx := context.Background()
+	create(t, repo, "migration", jobModel.StatusSuccess)
+	if err := repo.Dismiss(ctx, "migration"); err != nil {
+		t.Fatalf("dismiss failed: %v", err)
+	}
+	if _, err := repo.Latest(ctx, "migration"); !errors.Is(err, job.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after dismiss, got %v", err)
+	}
+	// 收起不删除：历史仍可列出。
+	if _, total, err := repo.List(ctx, job.ListQuery{Type: "migration"}); err != nil || total != 1 {
+		t.Fatalf("dismissed row must stay in history, total=%d err=%v", total, err)
+	}
+
+	pending := create(t, repo, "migration", jobModel.StatusPending)
+	if err := repo.Dismiss(ctx, "migration"); err != nil {
+		t.Fatalf("dismiss failed: %v", err)
+	}
+	got, err := repo.Latest(ctx, "migration")
+	if err != nil || got.ID != pending {
+		t.Fatalf("dismiss must not touch active rows, got %+v err=%v", got, err)
 	}
 }
 
-func TestRepo_Delete(t *testing.T) {
+func TestRepo_Prune_KeepsNewestTerminal(t *testing.T) {
 	repo, _ := newTestRepo(t)
 	ctx := context.Background()
-	_ = repo.Upsert(ctx, &jobModel.Job{Type: "migration", Status: jobModel.StatusSuccess})
-	if err := repo.Delete(ctx, "migration"); err != nil {
-		t.Fatalf("delete failed: %v", err)
+	var ids []string
+	for i := 0; i < 4; i++ {
+		ids = append(ids, create(t, repo, "sync", jobModel.StatusSuccess))
 	}
-	if _, err := repo.GetByType(ctx, "migration"); !errors.Is(err, job.ErrNotFound) {
-		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	running := create(t, repo, "sync", jobModel.StatusRunning)
+	other := create(t, repo, "export", jobModel.StatusSuccess)
+
+	if err := repo.Prune(ctx, "sync", 2); err != nil {
+		t.Fatalf("prune failed: %v", err)
+	}
+	for _, id := range ids[:2] {
+		if _, err := repo.GetByID(ctx, id); !errors.Is(err, job.ErrNotFound) {
+			t.Fatalf("expected oldest row %s pruned, got %v", id, err)
+		}
+	}
+	for _, id := range append(ids[2:], running, other) {
+		if _, err := repo.GetByID(ctx, id); err != nil {
+			t.Fatalf("expected row %s kept, got %v", id, err)
+		}
 	}
 }
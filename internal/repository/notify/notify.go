@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package repository
+
+import (
+	"context"
+	"errors"
+
+	model "github.com/lin-snow/ech0/internal/model/notify"
+	"github.com/lin-snow/ech0/internal/notify"
+	settingService "github.com/lin-snow/ech0/internal/service/setting"
+	"github.com/lin-snow/ech0/internal/transaction"
+	"gorm.io/gorm"
+)
+
+type NotifyRepository struct {
+	db func() *gorm.DB
+}
+
+var (
+	_ settingService.NotifyRepository = (*NotifyRepository)(nil)
+	_ notify.Store                    = (*NotifyRepository)(nil)
+)
+
+func NewNotifyRepository(dbProvider func() *gorm.DB) *NotifyRepository {
+	return &NotifyRepository{
+		db: dbProvider,
+	}
+}
+
+func (notifyRepository *NotifyRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := transaction.TxFromContext(ctx); ok {
+		return tx
+	}
+	return notifyRepository.db()
+}
+
+// CreateNotifyChannel 创建通知渠道
+func (notifyRepository *NotifyRepository) CreateNotifyChannel(
+	ctx context.Context,
+	channel *model.NotifyChannel,
+) error {
+	return notifyRepository.getDB(ctx).Create(channel).Error
+}
+
+// UpdateNotifyChannelByID 根据 ID 更新通知渠道
+func (notifyRepository *NotifyRepository) UpdateNotifyChannelByID(
+	ctx context.Context,
+	id string,
+	channel *model.NotifyChannel,
+) error {
+	// 走结构体 + Select 而非 map，serializer:json 的 Config / Events 才会被序列化。
+	tx := notifyRepository.getDB(ctx).
+		Model(&model.NotifyChannel{}).
+		Where("id = ?", id).
+		Select("name", "type", "config", "events", "is_active", "block_private").
+		Updates(channel)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return errors.New("notify channel not found")
+	}
+	return nil
+}
+
+// GetAllNotifyChannels 获取所有通知渠道
+func (notifyRepository *NotifyRepository) GetAllNotifyChannels(ctx context.Context) ([]model.NotifyChannel, error) {
+	var channels []model.NotifyChannel
+	if err := notifyRepository.getDB(ctx).Order("created_at ASC").Find(&channels).Error; err != nil {
+		return nil, err
+	}
+	return channels, nil
+}
+
+// GetNotifyChannelByID 根据 ID 获取通知渠道
+func (notifyRepository *NotifyRepository) GetNotifyChannelByID(
+	ctx context.Context,
+	id string,
+) (*model.NotifyChannel, error) {
+	var channel model.NotifyChannel
+	if err := notifyRepository.getDB(ctx).Where("id = ?", id).First(&channel).Error; err != nil {
+		return nil, err
+	}
+	return &channel, nil
+}
+
+// DeleteNotifyChannelByID 根据 ID 删除通知渠道，连同它的投递日志
+func (notifyRepository *NotifyRepository) DeleteNotifyChannelByID(ctx context.Context, id string) error {
+	db := notifyRepository.getDB(ctx)
+	if err := db.Where("channel_id = ?", id).Delete(&model.NotifyDelivery{}).Error; err != nil {
+		return err
+	}
+	return db.Where("id = ?", id).Delete(&model.NotifyChannel{}).Error
+}
+
+// ListActiveNotifyChannels 列出所有启用的通知渠道
+func (notifyRepository *NotifyRepository) ListActiveNotifyChannels(ctx context.Context) ([]model.NotifyChannel, error) {
+	var channels []model.NotifyChannel
+	if err := notifyRepository.getDB(ctx).Where("is_active = ?", true).Find(&channels).Error; err != nil {
+		return nil, err
+	}
+	return channels, nil
+}
+
+// UpdateNotifyChannelDeliveryStatus 更新通知渠道最近投递状态
+func (notifyRepository *NotifyRepository) UpdateNotifyChannelDeliveryStatus(
+	ctx context.Context,
+	id string,
+	status string,
+	lastTrigger int64,
+) error {
+	return notifyRepository.getDB(ctx).
+		Model(&model.NotifyChannel{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]any{
+			"last_status":  status,
+			"last_trigger": lastTrigger,
+		}).Error
+}
+
+// CreateNotifyDelivery 写入一条投递日志
+func (notifyRepository *NotifyRepository) CreateNotifyDelivery(
+	ctx context.Context,
+	delivery *model.NotifyDelivery,
+) error {
+	return notifyRepository.getDB(ctx).Create(delivery).Error
+}
+
+// ListNotifyDeliveries 按时间倒序列出投递日志，channelID 非空时只看该渠道
+func (notifyRepository *NotifyRepository) ListNotifyDeliveries(
+	ctx context.Context,
+	channelID string,
+	limit int,
+) ([]model.NotifyDelivery, error) {
+	query := notifyRepository.getDB(ctx).Order("created_at DESC").Order("id DESC").Limit(limit)
+	if channelID != "" {
+		query = query.Where("channel_id = ?", channelID)
+	}
+	var deliveries []model.NotifyDelivery
+	if err := query.Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// PruneNotifyDeliveries 只保留最近 keep 条投递日志
+func (notifyRepository *NotifyRepository) PruneNotifyDeliveries(ctx context.Context, keep int) error {
+	db := notifyRepository.getDB(ctx)
+	keepIDs := db.Model(&model.NotifyDelivery{}).
+		Select("id").
+		Order("created_at DESC").
+		Order("id DESC").
+		Limit(keep)
+	return db.Where("id NOT IN (?)", keepIDs).Delete(&model.NotifyDelivery{}).Error
+}
@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	notifyModel "github.com/lin-snow/ech0/internal/model/notify"
+	notifyRepository "github.com/lin-snow/ech0/internal/repository/notify"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newNotifyRepo(t *testing.T) (*notifyRepository.NotifyRepository, *gorm.DB) {
+	t.Helper()
+	db := helpers.NewTestDB(t)
+	return notifyRepository.NewNotifyRepository(func() *gorm.DB { return db }), db
+}
+
+func makeChannel(t *testing.T, repo *notifyRepository.NotifyRepository, name string, active bool) string {
+	t.Helper()
+	ch := &notifyModel.NotifyChannel{
+		Name:     name,
+		Type:     notifyModel.ChannelNtfy,
+		Config:   map[string]string{"topic": name},
+		Events:   []string{notifyModel.EventCommentNew},
+		IsActive: active,
+	}
+	require.NoError(t, repo.CreateNotifyChannel(context.Background(), ch))
+	require.NotEmpty(t, ch.ID)
+	return ch.ID
+}
+
+func TestNotifyRepository_CRUD(t *testing.T) {
+	repo, _ := newNotifyRepo(t)
+	ctx := context.Background()
+
+	id := makeChannel(t, repo, "phone", true)
+	got, err := repo.GetNotifyChannelByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"topic": "phone"}, got.Config, "config 以 JSON 往返")
+	assert.Equal(t, []string{notifyModel.EventCommentNew}, got.Events)
+	assert.True(t, got.IsActive)
+
+	require.NoError(t, repo.UpdateNotifyChannelByID(ctx, id, &notifyModel.NotifyChannel{
+		Name:   "tg",
+		Type:   notifyModel.ChannelTelegram,
+		Config: map[string]string{"bot_token": "1:a", "chat_id": "2"},
+		Events: []string{notifyModel.EventSnapshotFailed},
+	}))
+	got, err = repo.GetNotifyChannelByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "tg", got.Name)
+	assert.Equal(t, "2", got.Config["chat_id"])
+	assert.Equal(t, []string{notifyModel.EventSnapshotFailed}, got.Events)
+	assert.False(t, got.IsActive, "Select 显式列出 is_active，false 也要写入")
+
+	require.Error(t, repo.UpdateNotifyChannelByID(ctx, "missing", &notifyModel.NotifyChannel{Name: "x"}))
+
+	require.NoError(t, repo.DeleteNotifyChannelByID(ctx, id))
+	_, err = repo.GetNotifyChannelByID(ctx, id)
+	require.Error(t, err)
+}
+
+func TestNotifyRepository_ListActive(t *testing.T) {
+	repo, _ := newNotifyRepo(t)
+	ctx := context.Background()
+
+	active := makeChannel(t, repo, "on", true)
+	makeChannel(t, repo, "off", false)
+
+	all, err := repo.GetAllNotifyChannels(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	list, err := repo.ListActiveNotifyChannels(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 1, "新建时的 IsActive=false 不应被列默认值覆盖")
+	assert.Equal(t, active, list[0].ID)
+}
+
+func TestNotifyRepository_Deliveries(t *testing.T) {
+	repo, db := newNotifyRepo(t)
+	ctx := context.Background()
+
+	a := makeChannel(t, repo, "a", true)
+	b := makeChannel(t, repo, "b", true)
+	base := time.Now().UTC().Unix()
+	for i := range 6 {
+		channelID := a
+		if i%2 == 1 {
+			channelID = b
+		}
+		require.NoError(t, repo.CreateNotifyDelivery(ctx, &notifyModel.NotifyDelivery{
+			ChannelID: channelID,
+			Event:     notifyModel.EventCommentNew,
+			Title:     fmt.Sprintf("t%d", i),
+			Status:    notifyModel.DeliverySuccess,
+			CreatedAt: base + int64(i),
+		}))
+	}
+
+	onlyA, err := repo.ListNotifyDeliveries(ctx, a, 10)
+	require.NoError(t, err)
+	require.Len(t, onlyA, 3)
+	assert.Equal(t, "t4", onlyA[0].Title, "按时间倒序")
+
+	limited, err := repo.ListNotifyDeliveries(ctx, "", 2)
+	require.NoError(t, err)
+	require.Len(t, limited, 2)
+	assert.Equal(t, "t5", limited[0].Title)
+
+	require.NoError(t, repo.PruneNotifyDeliveries(ctx, 4))
+	var titles []string
+	require.NoError(t, db.Model(&notifyModel.NotifyDelivery{}).Order("created_at ASC").Pluck("title", &titles).Error)
+	assert.Equal(t, []string{"t2", "t3", "t4", "t5"}, titles, "只保留最近 keep 条")
+
+	require.NoError(t, repo.UpdateNotifyChannelDeliveryStatus(ctx, a, notifyModel.DeliveryFailed, base))
+	got, err := repo.GetNotifyChannelByID(ctx, a)
+	require.NoError(t, err)
+	assert.Equal(t, notifyModel.DeliveryFailed, got.LastStatus)
+	assert.Equal(t, base, got.LastTrigger)
+
+	// 删除渠道连带清理它的投递日志。
+	require.NoError(t, repo.DeleteNotifyChannelByID(ctx, b))
+	rest, err := repo.ListNotifyDeliveries(ctx, "", 10)
+	require.NoError(t, err)
+	for _, d := range rest {
+		assert.Equal(t, a, d.ChannelID)
+	}
+	assert.Len(t, rest, 2)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/google/wire"
 	"github.com/lin-snow/ech0/internal/job"
 	"github.com/lin-snow/ech0/internal/kvstore"
+	"github.com/lin-snow/ech0/internal/notify"
 	authRepository "github.com/lin-snow/ech0/internal/repository/auth"
 	commentRepository "github.com/lin-snow/ech0/internal/repository/comment"
 	commonRepository "github.com/lin-snow/ech0/internal/repository/common"
@@ -17,6 +18,7 @@ import (
 	initRepository "github.com/lin-snow/ech0/internal/repository/init"
 	jobRepository "github.com/lin-snow/ech0/internal/repository/job"
 	keyvalueRepository "github.com/lin-snow/ech0/internal/repository/keyvalue"
+	notifyRepository "github.com/lin-snow/ech0/internal/repository/notify"
 	settingRepository "github.com/lin-snow/ech0/internal/repository/setting"
 	userRepository "github.com/lin-snow/ech0/internal/repository/user"
 	visitorRepository "github.com/lin-snow/ech0/internal/repository/visitor"
@@ -96,6 +98,12 @@ var (
 		wire.Bind(new(settingService.WebhookRepository), new(*webhookRepository.WebhookRepository)),
 		wire.Bind(new(webhookmodule.WebhookStore), new(*webhookRepository.WebhookRepository)),
 	)
+	NotifySet = wire.NewSet(
+		notifyRepository.NewNotifyRepository,
+		wire.Bind(new(settingService.NotifyRepository), new(*notifyRepository.NotifyRepository)),
+		wire.Bind(new(notify.Store), new(*notifyRepository.NotifyRepository)),
+		wire.Bind(new(notify.DeliveryStore), new(*notifyRepository.NotifyRepository)),
+	)
 	JobSet = wire.NewSet(
 		jobRepository.NewJobRepository,
 		wire.Bind(new(job.JobRepository), new(*jobRepository.JobRepository)),
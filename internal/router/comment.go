@@ -16,13 +16,20 @@ import (
 	authService "github.com/lin-snow/ech0/internal/service/auth"
 )
 
-// setupCommentRoutes 仅保留 captcha 挂载走裸 gin（gin.WrapH，非 JSON-REST）。
-func setupCommentRoutes(appRouterGroup *AppRouterGroup, _ *handler.Bundle) {
+// setupCommentRoutes 挂载走裸 gin 的非 JSON-REST 端点：captcha（gin.WrapH），
+// 以及邮件里订阅确认 / 退订链接落地的 HTML 页面。
+func setupCommentRoutes(appRouterGroup *AppRouterGroup, h *handler.Bundle) {
 	captchaHandler, err := captcha.NewHTTPHandler("/api")
 	if err != nil {
 		panic(err)
 	}
 	appRouterGroup.PublicRouterGroup.Any("/cap/*any", gin.WrapH(captchaHandler))
+
+	appRouterGroup.PublicRouterGroup.GET("/comments/subscription/confirm", h.CommentHandler.ConfirmSubscriptionPage())
+	appRouterGroup.PublicRouterGroup.POST("/comments/subscription/confirm", h.CommentHandler.ConfirmSubscription())
+	appRouterGroup.PublicRouterGroup.GET("/comments/unsubscribe", h.CommentHandler.UnsubscribePage())
+	// RFC 8058 一键退订由邮件客户端直接 POST 到 List-Unsubscribe 地址。
+	appRouterGroup.PublicRouterGroup.POST("/comments/unsubscribe", h.CommentHandler.Unsubscribe())
 }
 
 // registerComment 注册评论的 JSON 端点。
@@ -147,6 +154,22 @@ func registerComment(api huma.API, h *handler.Bundle, revoker authService.TokenR
 		Tags:        []string{"Comment"},
 	}, h.CommentHandler.UpdateCommentSetting)
 
+	route(api, moderate, huma.Operation{
+		OperationID: "comment-panel-suppressions-list",
+		Method:      http.MethodGet,
+		Path:        "/panel/comments/suppressions",
+		Summary:     "列出邮件退订名单",
+		Tags:        []string{"Comment"},
+	}, h.CommentHandler.ListEmailSuppressions)
+
+	route(api, moderate, huma.Operation{
+		OperationID: "comment-panel-suppressions-delete",
+		Method:      http.MethodDelete,
+		Path:        "/panel/comments/suppressions/{email}",
+		Summary:     "将邮箱移出退订名单",
+		Tags:        []string{"Comment"},
+	}, h.CommentHandler.DeleteEmailSuppression)
+
 	route(api, moderate, huma.Operation{
 		OperationID: "comment-panel-test-email",
 		Method:      http.MethodPost,
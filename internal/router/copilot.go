@@ -47,4 +47,48 @@ func registerCopilot(api huma.API, h *handler.Bundle, revoker authService.TokenR
 		Summary:     "清除持久化 Chat 会话",
 		Tags:        []string{"Copilot"},
 	}, h.CopilotHandler.ClearSession)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "copilot-action-decide",
+		Method:      http.MethodPost,
+		Path:        "/chat/actions/{id}/decision",
+		Summary:     "确认或拒绝 Chat 提议的写操作",
+		Description: "Chat 流中的 pending_action 事件需由发起对话的用户在此确认后才会执行；拒绝或超时则不执行。",
+		Tags:        []string{"Copilot"},
+	}, h.CopilotHandler.DecideAction)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "copilot-action-list",
+		Method:      http.MethodGet,
+		Path:        "/chat/actions",
+		Summary:     "获取 Copilot 写操作审计记录",
+		Description: "列出经用户确认后执行的写操作（最新在前，保留最近 200 条），含参数、结果与失败原因。",
+		Tags:        []string{"Copilot"},
+	}, h.CopilotHandler.ListActions)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "copilot-suggestion-list",
+		Method:      http.MethodGet,
+		Path:        "/copilot/suggestions",
+		Summary:     "获取待审核的标签 / 替代文本建议",
+		Description: "开启 Agent 自动标签或替代文本且未开启自动应用时，新 Echo 的建议会暂存于此等待审核（最新在前，保留最近 200 条）。",
+		Tags:        []string{"Copilot"},
+	}, h.CopilotHandler.ListSuggestions)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "copilot-suggestion-apply",
+		Method:      http.MethodPost,
+		Path:        "/copilot/suggestions/{echoId}/apply",
+		Summary:     "应用标签 / 替代文本建议",
+		Description: "把建议的标签追加到 Echo、替代文本写入配图；请求体可改写要应用的标签与替代文本，省略的字段使用建议值。",
+		Tags:        []string{"Copilot"},
+	}, h.CopilotHandler.ApplySuggestion)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "copilot-suggestion-dismiss",
+		Method:      http.MethodDelete,
+		Path:        "/copilot/suggestions/{echoId}",
+		Summary:     "忽略标签 / 替代文本建议",
+		Tags:        []string{"Copilot"},
+	}, h.CopilotHandler.DismissSuggestion)
 }
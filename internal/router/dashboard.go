@@ -48,4 +48,28 @@ func registerDashboard(api huma.API, h *handler.Bundle, revoker authService.Toke
 		Summary:     "获取近七天访客统计",
 		Tags:        []string{"Dashboard"},
 	}, h.DashboardHandler.GetVisitorStats)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "dashboard-digest-get",
+		Method:      http.MethodGet,
+		Path:        "/system/digest",
+		Summary:     "获取站长定期摘要设置",
+		Tags:        []string{"Dashboard"},
+	}, h.DashboardHandler.GetDigestSetting)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "dashboard-digest-update",
+		Method:      http.MethodPut,
+		Path:        "/system/digest",
+		Summary:     "更新站长定期摘要设置",
+		Tags:        []string{"Dashboard"},
+	}, h.DashboardHandler.UpdateDigestSetting)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "dashboard-digest-send",
+		Method:      http.MethodPost,
+		Path:        "/system/digest/send",
+		Summary:     "立即发送一期站长摘要",
+		Tags:        []string{"Dashboard"},
+	}, h.DashboardHandler.SendDigest)
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/gin-gonic/gin"
+	"github.com/lin-snow/ech0/internal/config"
 	"github.com/lin-snow/ech0/internal/handler"
 	"github.com/lin-snow/ech0/internal/handler/humares"
 	"github.com/lin-snow/ech0/internal/middleware"
@@ -26,7 +27,7 @@ func registerEcho(api huma.API, h *handler.Bundle, revoker authService.TokenRevo
 		Path:        "/echo/like/{id}",
 		Summary:     "点赞 Echo",
 		Tags:        []string{"Echo"},
-		Middlewares: huma.Middlewares{humares.Bridge(middleware.RateLimitWithIdempotency(2, 5, time.Hour, "id", func(c *gin.Context) {
+		Middlewares: huma.Middlewares{humares.Bridge(middleware.RateLimitWithIdempotency(likeRateLimits, time.Hour, "id", func(c *gin.Context) {
 			c.JSON(http.StatusOK, commonModel.OK[any](nil, commonModel.LIKE_ECHO_SUCCESS))
 		}))},
 	}, h.EchoHandler.LikeEcho)
@@ -153,3 +154,8 @@ func registerEcho(api huma.API, h *handler.Bundle, revoker authService.TokenRevo
 		Tags:        []string{"Echo"},
 	}, h.EchoHandler.DeleteTag)
 }
+
+func likeRateLimits() (int, int) {
+	rl := config.Config().RateLimit
+	return rl.LikeRPS, rl.LikeBurst
+}
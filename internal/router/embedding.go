@@ -41,4 +41,22 @@ func registerEmbedding(api huma.API, h *handler.Bundle, revoker authService.Toke
 		Description: "取消后返回最新状态（轮询收敛到 cancelled）。",
 		Tags:        []string{"Embedding"},
 	}, h.EmbeddingHandler.CancelReindex)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "embedding-local-model-pull",
+		Method:      http.MethodPost,
+		Path:        "/embedding/local-model/pull",
+		Summary:     "拉取本地模型",
+		Description: "提交一次 Ollama 模型拉取作业（生成或向量模型均可），起即返回（异步）；已拉取的模型会很快完成。",
+		Tags:        []string{"Embedding"},
+	}, h.EmbeddingHandler.PullModel)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "embedding-local-model-pull-status",
+		Method:      http.MethodGet,
+		Path:        "/embedding/local-model/pull/status",
+		Summary:     "查询本地模型拉取作业状态",
+		Description: "前端按类型轮询；查无作业行时返回 status=idle。",
+		Tags:        []string{"Embedding"},
+	}, h.EmbeddingHandler.PullModelStatus)
 }
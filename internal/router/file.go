@@ -31,6 +31,14 @@ func setupFileRoutes(appRouterGroup *AppRouterGroup, h *handler.Bundle) {
 		middleware.RequireScopes(authModel.ScopeFileWrite),
 		h.FileHandler.UploadFile(),
 	)
+
+	// 断点续传（tus 1.0），大视频在弱网下中断后从断点继续。
+	// 不挂在 /files 下：/api/files/*filepath 已被本地文件的静态服务占用（含 HEAD）。
+	tus := appRouterGroup.AuthRouterGroup.Group("/upload/tus", middleware.RequireScopes(authModel.ScopeFileWrite))
+	tus.POST("", h.FileHandler.CreateResumableUpload)
+	tus.HEAD("/:id", h.FileHandler.GetResumableUpload)
+	tus.PATCH("/:id", h.FileHandler.PatchResumableUpload)
+	tus.DELETE("/:id", h.FileHandler.DeleteResumableUpload)
 }
 
 // registerFile 注册文件的 JSON 端点。
@@ -90,4 +98,57 @@ func registerFile(api huma.API, h *handler.Bundle, revoker authService.TokenRevo
 		Summary:     "获取对象存储直传预签名 URL",
 		Tags:        []string{"File"},
 	}, h.FileHandler.GetFilePresignURL)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "file-storage-migration",
+		Method:      http.MethodPost,
+		Path:        "/files/storage-migration",
+		Summary:     "迁移受管文件的存储位置",
+		Description: "提交一次存储迁移作业（本地 ↔ 对象存储，或对象存储换桶），起即返回（异步）；进度经 GET /jobs/{id} 轮询。",
+		Tags:        []string{"File"},
+	}, h.FileHandler.StartStorageMigration)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "file-dedupe",
+		Method:      http.MethodPost,
+		Path:        "/files/dedupe",
+		Summary:     "合并内容相同的受管文件",
+		Description: "提交一次内容去重作业：为存量文件回填内容哈希，把同内容的文件行指向同一存储对象并删除冗余副本，起即返回（异步）；进度经 GET /jobs/{id} 轮询。",
+		Tags:        []string{"File"},
+	}, h.FileHandler.StartFileDedupe)
+
+	// 存储配额与用量。GET 不挂在 /files 下：/api/files/*filepath 已被本地文件的静态服务占用。
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "file-quota-get",
+		Method:      http.MethodGet,
+		Path:        "/file/quota",
+		Summary:     "获取用户存储配额设置",
+		Tags:        []string{"File"},
+	}, h.FileHandler.GetStorageQuota)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "file-quota-update",
+		Method:      http.MethodPut,
+		Path:        "/file/quota",
+		Summary:     "更新用户存储配额设置",
+		Description: "按角色（站长/管理员/普通用户）设置默认额度，并可按用户 ID 单独覆盖；单位为字节，0 表示不限。外链文件不占配额。",
+		Tags:        []string{"File"},
+	}, h.FileHandler.UpdateStorageQuota)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "file-usage",
+		Method:      http.MethodGet,
+		Path:        "/file/usage",
+		Summary:     "获取按用户与分类拆分的存储用量",
+		Tags:        []string{"File"},
+	}, h.FileHandler.GetStorageUsage)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "file-usage-recount",
+		Method:      http.MethodPost,
+		Path:        "/file/usage/recount",
+		Summary:     "按实际文件重算存储用量",
+		Description: "用 files 表的统计整体校正用量账本；定时任务每天也会执行一次。",
+		Tags:        []string{"File"},
+	}, h.FileHandler.RecountStorageUsage)
 }
@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package router
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/lin-snow/ech0/internal/handler"
+	authModel "github.com/lin-snow/ech0/internal/model/auth"
+	authService "github.com/lin-snow/ech0/internal/service/auth"
+)
+
+// registerJob 注册通用作业的历史与取消端点（owner / 管理员，需 admin:settings scope）。
+// 重建索引、迁移、导出、同步、发布都记在这里；各领域的按类型状态端点保持不变。
+func registerJob(api huma.API, h *handler.Bundle, revoker authService.TokenRevoker) {
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "job-list",
+		Method:      http.MethodGet,
+		Path:        "/jobs",
+		Summary:     "列出作业历史",
+		Description: "按提交时间倒序分页列出各类后台作业（含排队中与在跑的），可按类型 / 状态过滤。",
+		Tags:        []string{"Job"},
+	}, h.JobHandler.ListJobs)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "job-get",
+		Method:      http.MethodGet,
+		Path:        "/jobs/{id}",
+		Summary:     "查询单个作业",
+		Description: "返回该作业的状态、阶段、耗时与错误；在跑时叠加实时进度。",
+		Tags:        []string{"Job"},
+	}, h.JobHandler.GetJob)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "job-cancel",
+		Method:      http.MethodPost,
+		Path:        "/jobs/{id}/cancel",
+		Summary:     "取消作业",
+		Description: "在跑的作业协作式退出（轮询收敛到 cancelled），排队中的直接取消；已结束的作业原样返回。",
+		Tags:        []string{"Job"},
+	}, h.JobHandler.CancelJob)
+}
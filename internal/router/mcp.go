@@ -4,6 +4,7 @@
 package router
 
 import (
+	"github.com/lin-snow/ech0/internal/config"
 	"github.com/lin-snow/ech0/internal/handler"
 	"github.com/lin-snow/ech0/internal/middleware"
 	authModel "github.com/lin-snow/ech0/internal/model/auth"
@@ -12,7 +13,7 @@ import (
 func setupMCPRoutes(groups *AppRouterGroup, h *handler.Bundle) {
 	g := groups.MCPRouterGroup
 	g.Use(
-		middleware.RateLimit(20, 40),
+		middleware.RateLimitFrom(mcpRateLimits),
 		middleware.OriginGuard(nil),
 		middleware.RequireAudience(authModel.AudienceMCPRemote),
 	)
@@ -22,3 +23,8 @@ func setupMCPRoutes(groups *AppRouterGroup, h *handler.Bundle) {
 	g.GET("", h.MCPHandler.ServeEndpoint())
 	g.DELETE("", h.MCPHandler.ServeEndpoint())
 }
+
+func mcpRateLimits() (int, int) {
+	rl := config.Config().RateLimit
+	return rl.MCPRPS, rl.MCPBurst
+}
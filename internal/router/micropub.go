@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package router
+
+import (
+	"github.com/lin-snow/ech0/internal/handler"
+	"github.com/lin-snow/ech0/internal/middleware"
+	authModel "github.com/lin-snow/ech0/internal/model/auth"
+	authService "github.com/lin-snow/ech0/internal/service/auth"
+)
+
+// setupMicropubRoutes 挂载 Micropub 端点（裸 gin：请求体可能是表单 / multipart / JSON，
+// 错误响应也须遵循 Micropub 自己的格式）。FormAccessToken 须在 RequireAuth 之前，
+// 以支持把 access_token 放在表单体里的客户端。
+func setupMicropubRoutes(groups *AppRouterGroup, h *handler.Bundle, revoker authService.TokenRevoker) {
+	g := groups.PublicRouterGroup.Group("/micropub",
+		middleware.NoCache(),
+		middleware.FormAccessToken(),
+		middleware.RequireAuth(revoker),
+	)
+	g.GET("", middleware.RequireScopes(authModel.ScopeEchoWrite), h.MicropubHandler.Query)
+	g.POST("", middleware.RequireScopes(authModel.ScopeEchoWrite), h.MicropubHandler.Post)
+	g.POST("/media", middleware.RequireScopes(authModel.ScopeFileWrite), h.MicropubHandler.Media)
+}
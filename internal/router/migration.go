@@ -13,7 +13,8 @@ import (
 	authService "github.com/lin-snow/ech0/internal/service/auth"
 )
 
-// setupMigrationRoutes 仅保留非 JSON 端点走裸 gin：multipart 上传源 zip + 二进制快照下载。
+// setupMigrationRoutes 仅保留非 JSON 端点走裸 gin：multipart 上传源 zip、二进制快照下载，
+// 以及实例间同步交换增量胶囊的一对端点（对端持 admin:settings 访问令牌调用）。
 func setupMigrationRoutes(appRouterGroup *AppRouterGroup, h *handler.Bundle) {
 	appRouterGroup.AuthRouterGroup.POST(
 		"/migration/upload",
@@ -25,6 +26,16 @@ func setupMigrationRoutes(appRouterGroup *AppRouterGroup, h *handler.Bundle) {
 		middleware.RequireScopes(authModel.ScopeAdminSettings),
 		h.MigrationHandler.DownloadExport(),
 	)
+	appRouterGroup.AuthRouterGroup.GET(
+		"/migration/sync/changes",
+		middleware.RequireScopes(authModel.ScopeAdminSettings),
+		h.MigrationHandler.PullSyncChanges(),
+	)
+	appRouterGroup.AuthRouterGroup.POST(
+		"/migration/sync/changes",
+		middleware.RequireScopes(authModel.ScopeAdminSettings),
+		h.MigrationHandler.PushSyncChanges(),
+	)
 }
 
 // registerMigration 注册数据迁移控制面的 JSON 端点（admin:settings）。
@@ -86,4 +97,108 @@ func registerMigration(api huma.API, h *handler.Bundle, revoker authService.Toke
 		Summary:     "取消导出作业",
 		Tags:        []string{"Migration"},
 	}, h.MigrationHandler.CancelExport)
+
+	route(api, admin, huma.Operation{
+		OperationID: "migration-snapshots",
+		Method:      http.MethodGet,
+		Path:        "/migration/snapshots",
+		Summary:     "列出本地留存的快照",
+		Tags:        []string{"Migration"},
+	}, h.MigrationHandler.ListSnapshots)
+
+	route(api, admin, huma.Operation{
+		OperationID: "migration-snapshot-restore",
+		Method:      http.MethodPost,
+		Path:        "/migration/snapshots/{name}/restore",
+		Summary:     "从留存快照恢复",
+		Tags:        []string{"Migration"},
+	}, h.MigrationHandler.RestoreSnapshot)
+
+	route(api, admin, huma.Operation{
+		OperationID: "migration-encryption-setting",
+		Method:      http.MethodGet,
+		Path:        "/migration/encryption/setting",
+		Summary:     "获取归档加密设置",
+		Tags:        []string{"Migration"},
+	}, h.MigrationHandler.GetEncryptionSetting)
+
+	route(api, admin, huma.Operation{
+		OperationID: "migration-encryption-setting-update",
+		Method:      http.MethodPut,
+		Path:        "/migration/encryption/setting",
+		Summary:     "更新归档加密设置",
+		Tags:        []string{"Migration"},
+	}, h.MigrationHandler.UpdateEncryptionSetting)
+
+	route(api, admin, huma.Operation{
+		OperationID: "migration-sync-setting",
+		Method:      http.MethodGet,
+		Path:        "/migration/sync/setting",
+		Summary:     "获取实例同步设置",
+		Tags:        []string{"Migration"},
+	}, h.MigrationHandler.GetSyncSetting)
+
+	route(api, admin, huma.Operation{
+		OperationID: "migration-sync-setting-update",
+		Method:      http.MethodPut,
+		Path:        "/migration/sync/setting",
+		Summary:     "更新实例同步设置",
+		Tags:        []string{"Migration"},
+	}, h.MigrationHandler.UpdateSyncSetting)
+
+	route(api, admin, huma.Operation{
+		OperationID: "migration-sync",
+		Method:      http.MethodPost,
+		Path:        "/migration/sync",
+		Summary:     "提交一轮实例同步",
+		Tags:        []string{"Migration"},
+	}, h.MigrationHandler.StartSync)
+
+	route(api, admin, huma.Operation{
+		OperationID: "migration-sync-status",
+		Method:      http.MethodGet,
+		Path:        "/migration/sync/status",
+		Summary:     "查询实例同步状态",
+		Tags:        []string{"Migration"},
+	}, h.MigrationHandler.GetSyncStatus)
+
+	route(api, admin, huma.Operation{
+		OperationID: "migration-sync-cancel",
+		Method:      http.MethodPost,
+		Path:        "/migration/sync/cancel",
+		Summary:     "取消进行中的实例同步",
+		Tags:        []string{"Migration"},
+	}, h.MigrationHandler.CancelSync)
+
+	route(api, admin, huma.Operation{
+		OperationID: "migration-publish-setting",
+		Method:      http.MethodGet,
+		Path:        "/migration/publish/setting",
+		Summary:     "获取静态站发布设置",
+		Tags:        []string{"Migration"},
+	}, h.MigrationHandler.GetPublishSetting)
+
+	route(api, admin, huma.Operation{
+		OperationID: "migration-publish-setting-update",
+		Method:      http.MethodPut,
+		Path:        "/migration/publish/setting",
+		Summary:     "更新静态站发布设置",
+		Tags:        []string{"Migration"},
+	}, h.MigrationHandler.UpdatePublishSetting)
+
+	route(api, admin, huma.Operation{
+		OperationID: "migration-publish",
+		Method:      http.MethodPost,
+		Path:        "/migration/publish",
+		Summary:     "重建并发布静态站",
+		Tags:        []string{"Migration"},
+	}, h.MigrationHandler.StartPublish)
+
+	route(api, admin, huma.Operation{
+		OperationID: "migration-publish-status",
+		Method:      http.MethodGet,
+		Path:        "/migration/publish/status",
+		Summary:     "查询静态站发布状态",
+		Tags:        []string{"Migration"},
+	}, h.MigrationHandler.GetPublishStatus)
 }
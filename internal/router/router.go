@@ -46,6 +46,8 @@ func SetupRouter(r *gin.Engine, h *handler.Bundle, mwDeps *middleware.Deps) {
 	registerOperations(api, h, revoker) // 所有已迁移到 Huma 的 JSON 端点
 	setupMigrationRoutes(groups, h)
 	setupMCPRoutes(groups, h)
+	setupMicropubRoutes(groups, h, revoker)
+	setupWebmentionRoutes(groups, h)
 }
 
 // setupStaticFiles 挂载本地上传文件的静态服务（/api/files），带目录穿越防护。
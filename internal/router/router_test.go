@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -23,16 +24,20 @@ import (
 	embeddingHandler "github.com/lin-snow/ech0/internal/handler/embedding"
 	fileHandler "github.com/lin-snow/ech0/internal/handler/file"
 	initHandler "github.com/lin-snow/ech0/internal/handler/init"
+	jobHandler "github.com/lin-snow/ech0/internal/handler/job"
 	migratorHandler "github.com/lin-snow/ech0/internal/handler/migrator"
+	searchHandler "github.com/lin-snow/ech0/internal/handler/search"
 	settingHandler "github.com/lin-snow/ech0/internal/handler/setting"
 	userHandler "github.com/lin-snow/ech0/internal/handler/user"
 	webHandler "github.com/lin-snow/ech0/internal/handler/web"
 	"github.com/lin-snow/ech0/internal/mcp"
+	"github.com/lin-snow/ech0/internal/micropub"
 	"github.com/lin-snow/ech0/internal/middleware"
 	authModel "github.com/lin-snow/ech0/internal/model/auth"
 	userModel "github.com/lin-snow/ech0/internal/model/user"
 	jwtUtil "github.com/lin-snow/ech0/internal/util/jwt"
 	"github.com/lin-snow/ech0/internal/visitor"
+	"github.com/lin-snow/ech0/internal/webmention"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -61,6 +66,16 @@ func TestSetupRouter_RegistersKeyRoutes(t *testing.T) {
 		{method: http.MethodGet, path: "/api/system/logs"},
 		{method: http.MethodGet, path: "/api/system/logs/stream"},
 		{method: http.MethodGet, path: "/ws/system/logs"},
+		{method: http.MethodPost, path: "/api/upload/tus"},
+		{method: http.MethodHead, path: "/api/upload/tus/:id"},
+		{method: http.MethodPatch, path: "/api/upload/tus/:id"},
+		{method: http.MethodDelete, path: "/api/upload/tus/:id"},
+		{method: http.MethodGet, path: "/api/file/usage"},
+		{method: http.MethodGet, path: "/api/micropub"},
+		{method: http.MethodPost, path: "/api/micropub"},
+		{method: http.MethodPost, path: "/api/micropub/media"},
+		{method: http.MethodPost, path: "/api/webmention"},
+		{method: http.MethodGet, path: "/api/webmention/source/:id"},
 	}
 
 	routes := engine.Routes()
@@ -322,6 +337,50 @@ func TestSetupRouter_IntegrationCommentRejectsWrongAudience(t *testing.T) {
 	}
 }
 
+// TestSetupRouter_MicropubFormAccessToken 验证 Micropub 接受表单体里的 access_token：
+// token 被识别（否则是 401），只因缺 echo:write 而被 403 拦下，不触达 handler。
+func TestSetupRouter_MicropubFormAccessToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	initTestDatabase(t)
+	engine := gin.New()
+	SetupRouter(engine, buildTestHandlers(), buildTestMWDeps())
+
+	user := userModel.User{ID: "u-micropub-1", Username: "micropub-user"}
+	token, err := jwtUtil.GenerateToken(
+		jwtUtil.CreateAccessClaimsWithExpiry(
+			user,
+			int64(time.Hour),
+			[]string{authModel.ScopeEchoRead},
+			authModel.AudienceIntegration,
+			"jti-micropub-form",
+		),
+	)
+	if err != nil {
+		t.Fatalf("generate token failed: %v", err)
+	}
+
+	cases := []struct {
+		name string
+		body string
+		want int
+	}{
+		{name: "no-token", body: "h=entry&content=hi", want: http.StatusUnauthorized},
+		{name: "form-token-wrong-scope", body: "h=entry&content=hi&access_token=" + token, want: http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/micropub", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+
+			if rec.Code != tc.want {
+				t.Fatalf("expected status %d, got %d", tc.want, rec.Code)
+			}
+		})
+	}
+}
+
 func containsRoute(routes []gin.RouteInfo, method, path string) bool {
 	for _, route := range routes {
 		if route.Method == method && route.Path == path {
@@ -342,17 +401,21 @@ func buildTestHandlers() *handler.Bundle {
 		userHandler.NewUserHandler(nil),
 		authHandler.NewAuthHandler(nil, nil),
 		echoHandler.NewEchoHandler(nil),
-		fileHandler.NewFileHandler(nil),
+		fileHandler.NewFileHandler(nil, nil),
 		commentHandler.NewCommentHandler(nil),
 		initHandler.NewInitHandler(nil),
 		commonHandler.NewCommonHandler(nil),
 		settingHandler.NewSettingHandler(nil),
 		connectHandler.NewConnectHandler(nil),
 		migratorHandler.NewMigrationHandler(nil),
-		dashboardHandler.NewDashboardHandler(nil),
-		copilotHandler.NewCopilotHandler(nil, nil),
+		dashboardHandler.NewDashboardHandler(nil, nil),
+		copilotHandler.NewCopilotHandler(nil, nil, nil),
 		embeddingHandler.NewEmbeddingHandler(nil),
-		mcp.NewHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil),
+		searchHandler.NewSearchHandler(nil),
+		jobHandler.NewJobHandler(nil),
+		mcp.NewHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil),
+		micropub.NewHandler(nil, nil, nil),
+		webmention.NewHandler(nil, nil, nil),
 	)
 }
 
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package router
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/lin-snow/ech0/internal/config"
+	"github.com/lin-snow/ech0/internal/handler"
+	"github.com/lin-snow/ech0/internal/handler/humares"
+	"github.com/lin-snow/ech0/internal/middleware"
+	authService "github.com/lin-snow/ech0/internal/service/auth"
+)
+
+// registerSearch 注册混合检索与相关 Echo 推荐（可匿名降级：匿名只见公开 Echo，管理员含私密）。
+// 检索接口公开且每次都要跑全文检索，登录用户还会调用 Embedding 接口，故单独限流。
+func registerSearch(api huma.API, h *handler.Bundle, revoker authService.TokenRevoker) {
+	route(api, optional(revoker), huma.Operation{
+		OperationID: "echo-search",
+		Method:      http.MethodGet,
+		Path:        "/search",
+		Summary:     "混合检索 Echo",
+		Description: "向量语义检索与关键词匹配按倒数排名融合（RRF）；Embedding 未启用或匿名访问时退化为纯关键词（mode=keyword）。",
+		Tags:        []string{"Echo"},
+		Middlewares: huma.Middlewares{humares.Bridge(middleware.RateLimitFrom(searchRateLimits))},
+	}, h.SearchHandler.HybridSearch)
+
+	route(api, optional(revoker), huma.Operation{
+		OperationID: "echo-related",
+		Method:      http.MethodGet,
+		Path:        "/echo/{id}/related",
+		Summary:     "获取相关 Echo",
+		Description: "按该 Echo 已存的向量取近邻（mode=vector）；未启用 Embedding 或尚未建索引时按共同标签推荐（mode=tags）。",
+		Tags:        []string{"Echo"},
+	}, h.SearchHandler.RelatedEchos)
+}
+
+func searchRateLimits() (int, int) {
+	rl := config.Config().RateLimit
+	return rl.SearchRPS, rl.SearchBurst
+}
@@ -155,6 +155,54 @@ func registerSetting(api huma.API, h *handler.Bundle, revoker authService.TokenR
 		Tags:        []string{"Setting"},
 	}, h.SettingHandler.TestWebhook)
 
+	route(api, adminSettings, huma.Operation{
+		OperationID: "notify-channel-list",
+		Method:      http.MethodGet,
+		Path:        "/notify/channel",
+		Summary:     "获取所有通知渠道",
+		Tags:        []string{"Setting"},
+	}, h.SettingHandler.GetNotifyChannels)
+
+	route(api, adminSettings, huma.Operation{
+		OperationID: "notify-channel-create",
+		Method:      http.MethodPost,
+		Path:        "/notify/channel",
+		Summary:     "创建通知渠道",
+		Tags:        []string{"Setting"},
+	}, h.SettingHandler.CreateNotifyChannel)
+
+	route(api, adminSettings, huma.Operation{
+		OperationID: "notify-channel-update",
+		Method:      http.MethodPut,
+		Path:        "/notify/channel/{id}",
+		Summary:     "更新通知渠道",
+		Tags:        []string{"Setting"},
+	}, h.SettingHandler.UpdateNotifyChannel)
+
+	route(api, adminSettings, huma.Operation{
+		OperationID: "notify-channel-delete",
+		Method:      http.MethodDelete,
+		Path:        "/notify/channel/{id}",
+		Summary:     "删除通知渠道",
+		Tags:        []string{"Setting"},
+	}, h.SettingHandler.DeleteNotifyChannel)
+
+	route(api, adminSettings, huma.Operation{
+		OperationID: "notify-channel-test",
+		Method:      http.MethodPost,
+		Path:        "/notify/channel/{id}/test",
+		Summary:     "发送测试通知",
+		Tags:        []string{"Setting"},
+	}, h.SettingHandler.TestNotifyChannel)
+
+	route(api, adminSettings, huma.Operation{
+		OperationID: "notify-delivery-list",
+		Method:      http.MethodGet,
+		Path:        "/notify/delivery",
+		Summary:     "获取通知投递日志",
+		Tags:        []string{"Setting"},
+	}, h.SettingHandler.GetNotifyDeliveries)
+
 	route(api, adminSettings, huma.Operation{
 		OperationID: "snapshot-schedule-get",
 		Method:      http.MethodGet,
@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package router
+
+import (
+	"github.com/lin-snow/ech0/internal/config"
+	"github.com/lin-snow/ech0/internal/handler"
+	"github.com/lin-snow/ech0/internal/middleware"
+)
+
+// setupWebmentionRoutes 挂载 Webmention 接收端点与 h-entry 来源页（裸 gin：请求体是表单，
+// 响应是纯文本 / HTML）。接收端点公开且每次受理都会出网抓取来源页，故单独限流。
+func setupWebmentionRoutes(groups *AppRouterGroup, h *handler.Bundle) {
+	g := groups.PublicRouterGroup.Group("/webmention", middleware.NoCache())
+	g.POST("", middleware.RateLimitFrom(webmentionRateLimits), h.WebmentionHandler.Receive)
+	g.GET("/source/:id", h.WebmentionHandler.Source)
+}
+
+func webmentionRateLimits() (int, int) {
+	rl := config.Config().RateLimit
+	return rl.WebmentionRPS, rl.WebmentionBurst
+}
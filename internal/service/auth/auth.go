@@ -17,6 +17,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-webauthn/webauthn/protocol"
@@ -32,6 +33,7 @@ import (
 	cryptoUtil "github.com/lin-snow/ech0/internal/util/crypto"
 	"github.com/lin-snow/ech0/internal/util/egress"
 	jwtUtil "github.com/lin-snow/ech0/internal/util/jwt"
+	"github.com/lin-snow/ech0/pkg/busen"
 	logUtil "github.com/lin-snow/ech0/pkg/log"
 	"github.com/lin-snow/ech0/pkg/viewer"
 	"golang.org/x/oauth2"
@@ -50,6 +52,9 @@ type AuthService struct {
 	repository Repository
 	authRepo   AuthRepo
 	durableKV  kvstore.Store
+	bus        *busen.Bus
+	// deviceMu 串行化已知登录设备列表的读-改-写
+	deviceMu sync.Mutex
 	// resolveAdapter 解析 OAuth provider 适配器；默认 getOAuthProviderAdapter，
 	// 测试可注入返回 canned identity 的 fake，从而覆盖 HandleOAuthCallback/resolveOAuthCallback
 	// 全流程而不触发真实 OAuth token/userinfo HTTP。
@@ -61,12 +66,14 @@ func NewAuthService(
 	repository Repository,
 	authRepo AuthRepo,
 	durableKV kvstore.Store,
+	busProvider func() *busen.Bus,
 ) *AuthService {
 	return &AuthService{
 		transactor:     tx,
 		repository:     repository,
 		authRepo:       authRepo,
 		durableKV:      durableKV,
+		bus:            busProvider(),
 		resolveAdapter: getOAuthProviderAdapter,
 	}
 }
@@ -93,7 +100,10 @@ func (authService *AuthService) ExchangeOAuthCode(code string) (*authModel.Token
 	return authService.authRepo.GetAndDeleteOAuthCode(code)
 }
 
-func (authService *AuthService) Login(loginDto *authModel.LoginDto) (*authModel.TokenPair, error) {
+func (authService *AuthService) Login(
+	loginDto *authModel.LoginDto,
+	client authModel.LoginClient,
+) (*authModel.TokenPair, error) {
 	if loginDto.Username == "" || loginDto.Password == "" {
 		return nil, errors.New(commonModel.USERNAME_OR_PASSWORD_NOT_BE_EMPTY)
 	}
@@ -137,7 +147,12 @@ func (authService *AuthService) Login(loginDto *authModel.LoginDto) (*authModel.
 		}
 	}
 
-	return authService.issueUserToken(user)
+	tokenPair, err := authService.issueUserToken(user)
+	if err != nil {
+		return nil, err
+	}
+	authService.noteLoginDevice(user, client, LoginMethodPassword)
+	return tokenPair, nil
 }
 
 func (authService *AuthService) issueUserToken(user model.User) (*authModel.TokenPair, error) {
@@ -242,6 +257,7 @@ func (authService *AuthService) HandleOAuthCallback(
 	provider string,
 	code string,
 	state string,
+	client authModel.LoginClient,
 ) (string, error) {
 	setting, err := authService.getOAuthSetting(provider)
 	if err != nil {
@@ -273,6 +289,7 @@ func (authService *AuthService) HandleOAuthCallback(
 		identity.ExternalID,
 		identity.Issuer,
 		identity.AuthType,
+		client,
 	)
 }
 
@@ -383,6 +400,7 @@ func bindingPermissionError(provider string) error {
 func (authService *AuthService) resolveOAuthCallback(
 	oauthState *authModel.OAuthState,
 	provider, externalID, issuer, authType string,
+	client authModel.LoginClient,
 ) (string, error) {
 	switch oauthState.Action {
 	case string(authModel.OAuth2ActionLogin):
@@ -448,6 +466,7 @@ func (authService *AuthService) resolveOAuthCallback(
 		if err != nil {
 			return "", err
 		}
+		authService.noteLoginDevice(user, client, LoginMethodOAuth)
 
 		code := cryptoUtil.GenerateRandomString(32)
 		authService.authRepo.StoreOAuthCode(code, tokenPair, 60*time.Second)
@@ -851,6 +870,7 @@ func (authService *AuthService) PasskeyLoginBegin(
 func (authService *AuthService) PasskeyLoginFinish(
 	rpID, origin, nonce string,
 	credential json.RawMessage,
+	client authModel.LoginClient,
 ) (*authModel.TokenPair, error) {
 	cacheKey := getPasskeyLoginSessionKey(nonce)
 	cached, err := authService.repository.CacheGetPasskeySession(cacheKey)
@@ -935,6 +955,7 @@ func (authService *AuthService) PasskeyLoginFinish(
 	if err != nil {
 		return nil, err
 	}
+	authService.noteLoginDevice(u, client, LoginMethodPasskey)
 	return tokenPair, nil
 }
 
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/lin-snow/ech0/internal/event"
+	eventbus "github.com/lin-snow/ech0/internal/event/bus"
+	"github.com/lin-snow/ech0/internal/kvstore"
+	authModel "github.com/lin-snow/ech0/internal/model/auth"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	model "github.com/lin-snow/ech0/internal/model/user"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+const (
+	LoginMethodPassword = "password"
+	LoginMethodPasskey  = "passkey"
+	LoginMethodOAuth    = "oauth"
+
+	// maxKnownDevices 是每个用户保留的已知设备指纹数，超出时淘汰最久未用的。
+	maxKnownDevices = 20
+)
+
+// uaVersionPattern 匹配 User-Agent 中的版本号，浏览器 / 系统小版本升级不应算作新设备。
+var uaVersionPattern = regexp.MustCompile(`[0-9][0-9._]*`)
+
+// deviceFingerprint 由去掉版本号的 User-Agent 计算设备指纹。
+func deviceFingerprint(userAgent string) string {
+	normalized := uaVersionPattern.ReplaceAllString(strings.TrimSpace(userAgent), "")
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:16])
+}
+
+// noteLoginDevice 记录本次登录的设备；设备此前未见过时发布 UserLoginNewDevice。
+// 用户首次登录（尚无任何已知设备）只登记不提醒。best-effort：失败只告警，不影响登录。
+func (authService *AuthService) noteLoginDevice(
+	user model.User,
+	client authModel.LoginClient,
+	method string,
+) {
+	if authService.durableKV == nil {
+		return
+	}
+	ctx := context.Background()
+	key := commonModel.LoginDevicesKeyPrefix + user.ID
+	fingerprint := deviceFingerprint(client.UserAgent)
+
+	authService.deviceMu.Lock()
+	known, err := authService.loadKnownDevices(ctx, key)
+	if err != nil {
+		authService.deviceMu.Unlock()
+		logUtil.GetLogger().Warn("load known login devices failed",
+			slog.String("module", "auth"), slog.String("user_id", user.ID), logUtil.Err(err))
+		return
+	}
+	isNew := !slices.Contains(known, fingerprint)
+	firstLogin := len(known) == 0
+
+	// 最近使用的排在最前，超出上限时截掉末尾。
+	known = slices.DeleteFunc(known, func(f string) bool { return f == fingerprint })
+	known = append([]string{fingerprint}, known...)
+	if len(known) > maxKnownDevices {
+		known = known[:maxKnownDevices]
+	}
+	raw, _ := json.Marshal(known)
+	err = authService.durableKV.Set(ctx, key, string(raw))
+	authService.deviceMu.Unlock()
+	if err != nil {
+		logUtil.GetLogger().Warn("save known login devices failed",
+			slog.String("module", "auth"), slog.String("user_id", user.ID), logUtil.Err(err))
+	}
+
+	if !isNew || firstLogin || authService.bus == nil {
+		return
+	}
+	eventbus.Notify(ctx, authService.bus, event.UserLoginNewDevice{
+		User:      user,
+		IP:        client.IP,
+		UserAgent: client.UserAgent,
+		Method:    method,
+	})
+}
+
+func (authService *AuthService) loadKnownDevices(ctx context.Context, key string) ([]string, error) {
+	raw, err := authService.durableKV.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, kvstore.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var known []string
+	if err := json.Unmarshal([]byte(raw), &known); err != nil {
+		// 记录损坏时当作空列表重建，不反复报错。
+		return nil, nil
+	}
+	return known, nil
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/event"
+	"github.com/lin-snow/ech0/internal/kvstore"
+	authModel "github.com/lin-snow/ech0/internal/model/auth"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
+	"github.com/lin-snow/ech0/pkg/busen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	firefoxUA = "Mozilla/5.0 (X11; Linux x86_64; rv:130.0) Gecko/20100101 Firefox/130.0"
+	safariUA  = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 Mobile/15E148"
+)
+
+func TestDeviceFingerprint_IgnoresVersions(t *testing.T) {
+	upgraded := "Mozilla/5.0 (X11; Linux x86_64; rv:131.0) Gecko/20100101 Firefox/131.0.2"
+	assert.Equal(t, deviceFingerprint(firefoxUA), deviceFingerprint(upgraded))
+	assert.NotEqual(t, deviceFingerprint(firefoxUA), deviceFingerprint(safariUA))
+}
+
+func TestNoteLoginDevice(t *testing.T) {
+	kv := kvstore.NewMemory()
+	bus := busen.New()
+	svc := &AuthService{durableKV: kv, bus: bus}
+	user := userModel.User{ID: "u1", Username: "admin"}
+
+	var got []event.UserLoginNewDevice
+	unsub, err := busen.Subscribe(bus, func(_ context.Context, e busen.Event[event.UserLoginNewDevice]) error {
+		got = append(got, e.Value)
+		return nil
+	})
+	require.NoError(t, err)
+	defer unsub()
+
+	svc.noteLoginDevice(user, authModel.LoginClient{IP: "10.0.0.1", UserAgent: firefoxUA}, LoginMethodPassword)
+	assert.Empty(t, got, "first ever login only registers the device")
+
+	svc.noteLoginDevice(user, authModel.LoginClient{IP: "10.0.0.2", UserAgent: firefoxUA}, LoginMethodPassword)
+	assert.Empty(t, got, "known device does not notify")
+
+	svc.noteLoginDevice(user, authModel.LoginClient{IP: "203.0.113.9", UserAgent: safariUA}, LoginMethodPasskey)
+	require.Len(t, got, 1)
+	assert.Equal(t, "u1", got[0].User.ID)
+	assert.Equal(t, "203.0.113.9", got[0].IP)
+	assert.Equal(t, safariUA, got[0].UserAgent)
+	assert.Equal(t, LoginMethodPasskey, got[0].Method)
+
+	svc.noteLoginDevice(user, authModel.LoginClient{UserAgent: safariUA}, LoginMethodOAuth)
+	assert.Len(t, got, 1, "device is remembered after the first notification")
+
+	// 其他用户的设备列表相互独立。
+	svc.noteLoginDevice(userModel.User{ID: "u2"}, authModel.LoginClient{UserAgent: safariUA}, LoginMethodPassword)
+	assert.Len(t, got, 1)
+}
+
+func TestNoteLoginDevice_CapsKnownDevices(t *testing.T) {
+	kv := kvstore.NewMemory()
+	svc := &AuthService{durableKV: kv}
+	user := userModel.User{ID: "u1"}
+
+	for i := range maxKnownDevices + 5 {
+		ua := "Agent/" + string(rune('a'+i))
+		svc.noteLoginDevice(user, authModel.LoginClient{UserAgent: ua}, LoginMethodPassword)
+	}
+	known, err := svc.loadKnownDevices(context.Background(), commonModel.LoginDevicesKeyPrefix+user.ID)
+	require.NoError(t, err)
+	assert.Len(t, known, maxKnownDevices)
+	assert.Equal(t, deviceFingerprint("Agent/"+string(rune('a'+maxKnownDevices+4))), known[0], "most recent first")
+}
@@ -134,7 +134,7 @@ func TestLogin(t *testing.T) {
 			svc, repo, _, _ := newSvc(t, kvstore.NewMemory())
 			tc.setupRepo(repo)
 
-			pair, err := svc.Login(&tc.dto)
+			pair, err := svc.Login(&tc.dto, authModel.LoginClient{})
 			require.EqualError(t, err, tc.wantErr)
 			assert.Nil(t, pair)
 		})
@@ -156,7 +156,7 @@ func TestLogin(t *testing.T) {
 			Once()
 		// 已是 bcrypt：不应触发惰性升级写入（未对 UpdateLocalAuthPassword 设期望）。
 
-		pair, err := svc.Login(&authModel.LoginDto{Username: username, Password: plainPassword})
+		pair, err := svc.Login(&authModel.LoginDto{Username: username, Password: plainPassword}, authModel.LoginClient{})
 		require.NoError(t, err)
 		require.NotNil(t, pair)
 		assert.NotEmpty(t, pair.AccessToken)
@@ -184,7 +184,7 @@ func TestLogin(t *testing.T) {
 			Return(nil).
 			Once()
 
-		pair, err := svc.Login(&authModel.LoginDto{Username: username, Password: plainPassword})
+		pair, err := svc.Login(&authModel.LoginDto{Username: username, Password: plainPassword}, authModel.LoginClient{})
 		require.NoError(t, err)
 		require.NotNil(t, pair)
 		assert.NotEmpty(t, pair.AccessToken)
@@ -20,6 +20,7 @@ import (
 	authmock "github.com/lin-snow/ech0/internal/test/mocks/authmock"
 	txmock "github.com/lin-snow/ech0/internal/test/mocks/txmock"
 	jwtUtil "github.com/lin-snow/ech0/internal/util/jwt"
+	"github.com/lin-snow/ech0/pkg/busen"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -86,7 +87,7 @@ func newSvc(
 	repo := authmock.NewMockRepository(t)
 	authRepo := authmock.NewMockAuthRepo(t)
 	tx := txmock.NewMockTransactor(t)
-	svc := NewAuthService(tx, repo, authRepo, kv)
+	svc := NewAuthService(tx, repo, authRepo, kv, func() *busen.Bus { return nil })
 	return svc, repo, authRepo, tx
 }
 
@@ -173,7 +174,7 @@ func TestHandleOAuthCallback_ValidationErrors(t *testing.T) {
 				return nil, nil
 			}
 
-			out, err := svc.HandleOAuthCallback(tc.provider, "code-123", tc.state)
+			out, err := svc.HandleOAuthCallback(tc.provider, "code-123", tc.state, authModel.LoginClient{})
 			require.Error(t, err)
 			require.EqualError(t, err, tc.wantErr)
 			assert.Empty(t, out)
@@ -190,7 +191,7 @@ func TestHandleOAuthCallback_InvalidState(t *testing.T) {
 		return nil, nil
 	}
 
-	out, err := svc.HandleOAuthCallback(string(commonModel.OAuth2GITHUB), "code-123", "not-a-jwt")
+	out, err := svc.HandleOAuthCallback(string(commonModel.OAuth2GITHUB), "code-123", "not-a-jwt", authModel.LoginClient{})
 	require.Error(t, err) // ParseOAuthState 失败：非空、非业务常量错误
 	assert.Empty(t, out)
 }
@@ -208,7 +209,7 @@ func TestHandleOAuthCallback_AdapterErrors(t *testing.T) {
 		sentinel := errors.New("adapter unavailable")
 		svc.resolveAdapter = func(string) (oauthProviderAdapter, error) { return nil, sentinel }
 
-		out, err := svc.HandleOAuthCallback(string(commonModel.OAuth2GITHUB), "code-123", state)
+		out, err := svc.HandleOAuthCallback(string(commonModel.OAuth2GITHUB), "code-123", state, authModel.LoginClient{})
 		require.ErrorIs(t, err, sentinel)
 		assert.Empty(t, out)
 	})
@@ -220,7 +221,7 @@ func TestHandleOAuthCallback_AdapterErrors(t *testing.T) {
 			return &fakeAdapter{err: sentinel}, nil
 		}
 
-		out, err := svc.HandleOAuthCallback(string(commonModel.OAuth2GITHUB), "code-123", state)
+		out, err := svc.HandleOAuthCallback(string(commonModel.OAuth2GITHUB), "code-123", state, authModel.LoginClient{})
 		require.ErrorIs(t, err, sentinel)
 		assert.Empty(t, out)
 	})
@@ -256,7 +257,7 @@ func TestHandleOAuthCallback_LoginSuccess(t *testing.T) {
 		Run(func(code string, _ *authModel.TokenPair, _ time.Duration) { storedCode = code }).
 		Once()
 
-	out, err := svc.HandleOAuthCallback(string(commonModel.OAuth2GITHUB), "code-123", state)
+	out, err := svc.HandleOAuthCallback(string(commonModel.OAuth2GITHUB), "code-123", state, authModel.LoginClient{})
 	require.NoError(t, err)
 
 	parsed, perr := url.Parse(out)
@@ -333,7 +334,7 @@ func TestResolveOAuthCallback_PureValidation(t *testing.T) {
 			svc, _, _, _ := newSvc(t, seedOAuth2KV(t, fullOAuth2Setting(string(commonModel.OAuth2GITHUB))))
 
 			out, err := svc.resolveOAuthCallback(
-				tc.state, string(commonModel.OAuth2GITHUB), "ext-1", "", string(authModel.AuthTypeOAuth2),
+				tc.state, string(commonModel.OAuth2GITHUB), "ext-1", "", string(authModel.AuthTypeOAuth2), authModel.LoginClient{},
 			)
 			require.EqualError(t, err, commonModel.INVALID_PARAMS)
 			assert.Empty(t, out)
@@ -365,7 +366,7 @@ func TestResolveOAuthCallback_LoginOAuthSuccess(t *testing.T) {
 
 	out, err := svc.resolveOAuthCallback(
 		loginState(allowedReturnURL),
-		string(commonModel.OAuth2GITHUB), "ext-oauth", "", string(authModel.AuthTypeOAuth2),
+		string(commonModel.OAuth2GITHUB), "ext-oauth", "", string(authModel.AuthTypeOAuth2), authModel.LoginClient{},
 	)
 	require.NoError(t, err)
 
@@ -392,7 +393,7 @@ func TestResolveOAuthCallback_LoginOIDCSuccess(t *testing.T) {
 
 	out, err := svc.resolveOAuthCallback(
 		loginState(allowedReturnURL),
-		string(commonModel.OAuth2GITHUB), "sub-123", "https://idp.example.com", string(authModel.AuthTypeOIDC),
+		string(commonModel.OAuth2GITHUB), "sub-123", "https://idp.example.com", string(authModel.AuthTypeOIDC), authModel.LoginClient{},
 	)
 	require.NoError(t, err)
 	assert.Contains(t, out, "code=")
@@ -412,7 +413,7 @@ func TestResolveOAuthCallback_LoginLookupFailure(t *testing.T) {
 
 	out, err := svc.resolveOAuthCallback(
 		loginState(allowedReturnURL),
-		string(commonModel.OAuth2GITHUB), "ext-unbound", "", string(authModel.AuthTypeOAuth2),
+		string(commonModel.OAuth2GITHUB), "ext-unbound", "", string(authModel.AuthTypeOAuth2), authModel.LoginClient{},
 	)
 	require.ErrorIs(t, err, notBound)
 	assert.Empty(t, out)
@@ -432,7 +433,7 @@ func TestResolveOAuthCallback_LoginRedirectRejected(t *testing.T) {
 
 	out, err := svc.resolveOAuthCallback(
 		loginState("https://evil.example.net/auth"),
-		string(commonModel.OAuth2GITHUB), "ext-1", "", string(authModel.AuthTypeOAuth2),
+		string(commonModel.OAuth2GITHUB), "ext-1", "", string(authModel.AuthTypeOAuth2), authModel.LoginClient{},
 	)
 	require.EqualError(t, err, commonModel.INVALID_PARAMS)
 	assert.Empty(t, out)
@@ -451,7 +452,7 @@ func TestResolveOAuthCallback_BindSuccess(t *testing.T) {
 
 	out, err := svc.resolveOAuthCallback(
 		bindState("u-7", allowedReturnURL),
-		string(commonModel.OAuth2GITHUB), "ext-bind", "", string(authModel.AuthTypeOAuth2),
+		string(commonModel.OAuth2GITHUB), "ext-bind", "", string(authModel.AuthTypeOAuth2), authModel.LoginClient{},
 	)
 	require.NoError(t, err)
 
@@ -475,7 +476,7 @@ func TestResolveOAuthCallback_BindPersistFailure(t *testing.T) {
 
 	out, err := svc.resolveOAuthCallback(
 		bindState("u-7", allowedReturnURL),
-		string(commonModel.OAuth2GITHUB), "ext-bind", "", string(authModel.AuthTypeOAuth2),
+		string(commonModel.OAuth2GITHUB), "ext-bind", "", string(authModel.AuthTypeOAuth2), authModel.LoginClient{},
 	)
 	require.ErrorIs(t, err, persistErr)
 	assert.Empty(t, out)
@@ -495,7 +496,7 @@ func TestResolveOAuthCallback_BindRedirectRejected(t *testing.T) {
 
 	out, err := svc.resolveOAuthCallback(
 		bindState("u-7", "https://evil.example.net/panel"),
-		string(commonModel.OAuth2GITHUB), "ext-bind", "", string(authModel.AuthTypeOAuth2),
+		string(commonModel.OAuth2GITHUB), "ext-bind", "", string(authModel.AuthTypeOAuth2), authModel.LoginClient{},
 	)
 	require.EqualError(t, err, commonModel.INVALID_PARAMS)
 	assert.Empty(t, out)
@@ -13,16 +13,25 @@ import (
 )
 
 type Service interface {
-	Login(loginDto *authModel.LoginDto) (*authModel.TokenPair, error)
+	Login(loginDto *authModel.LoginDto, client authModel.LoginClient) (*authModel.TokenPair, error)
 	BindOAuth(ctx context.Context, provider string, redirectURI string) (string, error)
 	GetOAuthLoginURL(provider string, redirectURI string) (string, error)
-	HandleOAuthCallback(provider string, code string, state string) (string, error)
+	HandleOAuthCallback(
+		provider string,
+		code string,
+		state string,
+		client authModel.LoginClient,
+	) (string, error)
 	ExchangeOAuthCode(code string) (*authModel.TokenPair, error)
 	GetOAuthInfo(ctx context.Context, provider string) (model.OAuthInfoDto, error)
 	PasskeyRegisterBegin(ctx context.Context, rpID, origin, deviceName string) (authModel.PasskeyRegisterBeginResp, error)
 	PasskeyRegisterFinish(ctx context.Context, rpID, origin, nonce string, credential json.RawMessage) error
 	PasskeyLoginBegin(rpID, origin string) (authModel.PasskeyLoginBeginResp, error)
-	PasskeyLoginFinish(rpID, origin, nonce string, credential json.RawMessage) (*authModel.TokenPair, error)
+	PasskeyLoginFinish(
+		rpID, origin, nonce string,
+		credential json.RawMessage,
+		client authModel.LoginClient,
+	) (*authModel.TokenPair, error)
 	ListPasskeys(ctx context.Context) ([]authModel.PasskeyDeviceDto, error)
 	DeletePasskey(ctx context.Context, passkeyID string) error
 	UpdatePasskeyDeviceName(ctx context.Context, passkeyID string, deviceName string) error
@@ -216,6 +216,10 @@ func (s *CommentService) CreateComment(
 		s.notifyOwnerAsync(ctx, "created", comment)
 	}
 	s.notifyReplyTargetAsync(ctx, comment)
+	s.notifySubscribersAsync(ctx, comment)
+	if dto.Subscribe && comment.Source == model.SourceGuest {
+		s.requestSubscription(ctx, comment)
+	}
 	return model.CreateCommentResult{
 		ID:     comment.ID,
 		Status: comment.Status,
@@ -332,6 +336,7 @@ func (s *CommentService) CreateIntegrationComment(
 	if shouldNotifyOwnerOnCreate(comment.Source) {
 		s.notifyOwnerAsync(ctx, "created", comment)
 	}
+	s.notifySubscribersAsync(ctx, comment)
 	return model.CreateCommentResult{
 		ID:     comment.ID,
 		Status: comment.Status,
@@ -436,6 +441,7 @@ func (s *CommentService) UpdateCommentStatus(ctx context.Context, id string, sta
 	if updated, err := s.repo.GetCommentByID(ctx, id); err == nil && updated.ID != "" {
 		s.emitCommentStatusUpdated(ctx, updated)
 		s.notifyOwnerAsync(ctx, "status", updated)
+		s.notifySubscribersAsync(ctx, updated)
 	}
 	return nil
 }
@@ -485,6 +491,7 @@ func (s *CommentService) BatchAction(ctx context.Context, action string, ids []s
 			if updated, err := s.repo.GetCommentByID(ctx, id); err == nil && updated.ID != "" {
 				s.emitCommentStatusUpdated(ctx, updated)
 				s.notifyOwnerAsync(ctx, "status", updated)
+				s.notifySubscribersAsync(ctx, updated)
 			}
 		}
 		return nil
@@ -579,6 +586,7 @@ func (s *CommentService) SendTestEmail(ctx context.Context, setting model.System
 		return err
 	}
 	serverURL := s.resolveServerURL(ctx)
+	unsubscribeURL := s.unsubscribeURL(serverURL, "", ownerEmail)
 	if err := validateEmailNotifySetting(setting.EmailNotify, ownerEmail); err != nil {
 		return commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, err.Error())
 	}
@@ -589,13 +597,46 @@ func (s *CommentService) SendTestEmail(ctx context.Context, setting model.System
 		Content:   "这是一条测试评论内容，用于预览 Ech0 邮件通知样式。",
 		Status:    model.StatusPending,
 		CreatedAt: time.Now().UTC().Unix(),
-	}, serverURL)
-	return s.sendOwnerMail(ctx, setting.EmailNotify, MailMessage{
-		To:       ownerEmail,
-		Subject:  content.Subject,
-		TextBody: content.TextBody,
-		HTMLBody: content.HTMLBody,
+	}, serverURL, unsubscribeURL)
+	err = s.sendOwnerMail(ctx, setting.EmailNotify, MailMessage{
+		To:             ownerEmail,
+		Subject:        content.Subject,
+		TextBody:       content.TextBody,
+		HTMLBody:       content.HTMLBody,
+		UnsubscribeURL: unsubscribeURL,
 	})
+	if errors.Is(err, errRecipientSuppressed) {
+		return commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "owner 邮箱已退订，请先从退订名单中移除")
+	}
+	return err
+}
+
+// SendOwnerMail 按评论系统的邮件设置给 owner 发一封邮件，要求邮件通知已启用。
+func (s *CommentService) SendOwnerMail(ctx context.Context, mail OwnerMail) error {
+	setting, err := s.getSystemSettingRaw(ctx)
+	if err != nil {
+		return err
+	}
+	if !setting.EmailNotify.Enabled {
+		return commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "邮件通知未启用，请先在评论设置中配置 SMTP")
+	}
+	ownerEmail, err := s.resolveOwnerEmail()
+	if err != nil {
+		return commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, err.Error())
+	}
+	unsubscribeURL := s.unsubscribeURL(s.resolveServerURL(ctx), "", ownerEmail)
+	heading := fallbackText(mail.Heading, "Ech0")
+	err = s.sendOwnerMail(ctx, setting.EmailNotify, MailMessage{
+		To:             ownerEmail,
+		Subject:        mail.Subject,
+		TextBody:       mail.TextBody + buildUnsubscribeText(unsubscribeURL),
+		HTMLBody:       wrapMailHTMLWithHeading(heading, mail.InnerHTML, unsubscribeURL),
+		UnsubscribeURL: unsubscribeURL,
+	})
+	if errors.Is(err, errRecipientSuppressed) {
+		return commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "owner 邮箱已退订，请先从退订名单中移除")
+	}
+	return err
 }
 
 func (s *CommentService) notifyOwnerAsync(ctx context.Context, kind string, comment model.Comment) {
@@ -624,18 +665,20 @@ func (s *CommentService) notifyOwnerAsync(ctx context.Context, kind string, comm
 		recipient = ownerEmail
 	}
 	serverURL := s.resolveServerURL(ctx)
-	content := buildNotifyContent(kind, comment, serverURL)
+	unsubscribeURL := s.unsubscribeURL(serverURL, "", recipient)
+	content := buildNotifyContent(kind, comment, serverURL, unsubscribeURL)
 	go func(cfg model.EmailNotifySetting, msg MailMessage) {
 		notifyCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
-		if err := s.sendOwnerMail(notifyCtx, cfg, msg); err != nil {
+		if err := s.sendOwnerMail(notifyCtx, cfg, msg); err != nil && !errors.Is(err, errRecipientSuppressed) {
 			logUtil.GetLogger().Warn("comment notify mail failed", logUtil.Err(err), slog.String("comment_id", comment.ID))
 		}
 	}(setting.EmailNotify, MailMessage{
-		To:       recipient,
-		Subject:  content.Subject,
-		TextBody: content.TextBody,
-		HTMLBody: content.HTMLBody,
+		To:             recipient,
+		Subject:        content.Subject,
+		TextBody:       content.TextBody,
+		HTMLBody:       content.HTMLBody,
+		UnsubscribeURL: unsubscribeURL,
 	})
 }
 
@@ -665,19 +708,21 @@ func (s *CommentService) notifyReplyTargetAsync(ctx context.Context, comment mod
 		return
 	}
 	serverURL := s.resolveServerURL(ctx)
-	content := buildNotifyContent("reply", comment, serverURL)
+	unsubscribeURL := s.unsubscribeURL(serverURL, "", targetEmail)
+	content := buildNotifyContent("reply", comment, serverURL, unsubscribeURL)
 	go func(cfg model.EmailNotifySetting, msg MailMessage) {
 		notifyCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
-		if err := s.sendOwnerMail(notifyCtx, cfg, msg); err != nil {
+		if err := s.sendOwnerMail(notifyCtx, cfg, msg); err != nil && !errors.Is(err, errRecipientSuppressed) {
 			logUtil.GetLogger().Warn("comment reply notify mail failed",
 				logUtil.Err(err), slog.String("comment_id", comment.ID))
 		}
 	}(setting.EmailNotify, MailMessage{
-		To:       targetEmail,
-		Subject:  content.Subject,
-		TextBody: content.TextBody,
-		HTMLBody: content.HTMLBody,
+		To:             targetEmail,
+		Subject:        content.Subject,
+		TextBody:       content.TextBody,
+		HTMLBody:       content.HTMLBody,
+		UnsubscribeURL: unsubscribeURL,
 	})
 }
 
@@ -710,6 +755,16 @@ func (s *CommentService) sendOwnerMail(ctx context.Context, cfg model.EmailNotif
 	if err := validateEmailNotifySetting(cfg, msg.To); err != nil {
 		return err
 	}
+	// 退订名单对所有发信路径生效。
+	if s.repo != nil {
+		suppressed, err := s.repo.IsEmailSuppressed(ctx, normalizeEmail(msg.To))
+		if err != nil {
+			return err
+		}
+		if suppressed {
+			return errRecipientSuppressed
+		}
+	}
 	return s.mailer.Send(ctx, MailerConfig{
 		Host:     strings.TrimSpace(cfg.SMTPHost),
 		Port:     cfg.SMTPPort,
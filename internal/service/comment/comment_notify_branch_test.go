@@ -25,6 +25,14 @@ func mailEnabledSetting() commentModel.SystemSetting {
 	return s
 }
 
+// expectNoSubscribers 让评论所在 Echo 没有订阅者，notifySubscribersAsync 随即返回。
+func (d deps) expectNoSubscribers(echoID string) {
+	d.repo.EXPECT().
+		ListActiveSubscriberEmails(mock.Anything, echoID).
+		Return(nil, nil).
+		Once()
+}
+
 // notifyOwnerAsync：status 类通知用「评论者邮箱」作收件人；邮箱无效时跳过发信。
 func TestUpdateCommentStatus_NotifySkipsInvalidRecipient(t *testing.T) {
 	d := newDeps(t)
@@ -69,6 +77,7 @@ func TestCreateComment_NotifyOwnerSkipsWhenOwnerEmailMissing(t *testing.T) {
 		Run(func(_ context.Context, c *commentModel.Comment) { c.ID = "g-1" }).
 		Return(nil).
 		Once()
+	d.expectNoSubscribers("echo-1")
 	// resolveOwnerEmail：owner 邮箱为空 => 返回错误 => notifyOwnerAsync 在 spawn 前返回。
 	d.common.EXPECT().GetOwner().Return(helpers.NewUser(), nil).Once()
 
@@ -118,6 +127,7 @@ func TestCreateComment_ReplyNotifySkipsInvalidParentEmail(t *testing.T) {
 		Run(func(_ context.Context, c *commentModel.Comment) { c.ID = "child-1" }).
 		Return(nil).
 		Once()
+	d.expectNoSubscribers("echo-1")
 
 	res, err := d.service().CreateComment(helpers.CtxAsUser("owner-1"), testIP, "ua",
 		&commentModel.CreateCommentDto{
@@ -163,6 +173,7 @@ func TestCreateComment_ReplyNotifySkipsWhenTargetIsOwner(t *testing.T) {
 		Run(func(_ context.Context, c *commentModel.Comment) { c.ID = "child-2" }).
 		Return(nil).
 		Once()
+	d.expectNoSubscribers("echo-1")
 	// 被回复邮箱 == owner 邮箱 => 在 spawn 前返回。
 	ownerWithEmail := helpers.NewUser(helpers.AsOwner)
 	ownerWithEmail.Email = "shared@example.com"
@@ -6,6 +6,7 @@ package service_test
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	commentModel "github.com/lin-snow/ech0/internal/model/comment"
@@ -324,8 +325,14 @@ func TestSendTestEmail(t *testing.T) {
 		d.kv.EXPECT().
 			Get(mock.Anything, commonModel.ServerURLKey).
 			Return("https://example.com", nil)
+		d.repo.EXPECT().
+			IsEmailSuppressed(mock.Anything, "owner@example.com").
+			Return(false, nil).
+			Once()
 		d.mailer.EXPECT().
-			Send(mock.Anything, mock.Anything, mock.Anything).
+			Send(mock.Anything, mock.Anything, mock.MatchedBy(func(msg commentService.MailMessage) bool {
+				return strings.HasPrefix(msg.UnsubscribeURL, "https://example.com/api/comments/unsubscribe?token=")
+			})).
 			Return(nil).
 			Once()
 		err := d.service().SendTestEmail(helpers.CtxAsUser("admin-1"), commentModel.SystemSetting{
@@ -333,6 +340,92 @@ func TestSendTestEmail(t *testing.T) {
 		})
 		require.NoError(t, err)
 	})
+
+	t.Run("suppressed owner address is reported", func(t *testing.T) {
+		d := newDeps(t)
+		expectAdmin(t, d, "admin-1")
+		d.expectSetting(t, enabledSetting())
+		owner := helpers.NewUser()
+		owner.Email = "Owner@Example.com"
+		d.common.EXPECT().GetOwner().Return(owner, nil).Once()
+		d.kv.EXPECT().
+			Get(mock.Anything, commonModel.ServerURLKey).
+			Return("https://example.com", nil)
+		d.repo.EXPECT().
+			IsEmailSuppressed(mock.Anything, "owner@example.com").
+			Return(true, nil).
+			Once()
+		err := d.service().SendTestEmail(helpers.CtxAsUser("admin-1"), commentModel.SystemSetting{
+			EmailNotify: validEmailNotify(),
+		})
+		assertBiz(t, err, commonModel.ErrCodeInvalidRequest, "")
+	})
+}
+
+// --- SendOwnerMail -----------------------------------------------------------
+
+func TestSendOwnerMailToOwner(t *testing.T) {
+	mail := commentService.OwnerMail{
+		Heading:   "Ech0 站点摘要",
+		Subject:   "[Ech0] 每周摘要",
+		TextBody:  "body",
+		InnerHTML: "<p>body</p>",
+	}
+
+	t.Run("disabled email notify is rejected", func(t *testing.T) {
+		d := newDeps(t)
+		d.expectSetting(t, enabledSetting()) // EmailNotify.Enabled 为 false
+		err := d.service().SendOwnerMail(helpers.CtxAnonymous(), mail)
+		assertBiz(t, err, commonModel.ErrCodeInvalidRequest, "")
+	})
+
+	t.Run("happy path wraps body with heading and unsubscribe link", func(t *testing.T) {
+		d := newDeps(t)
+		setting := enabledSetting()
+		setting.EmailNotify = validEmailNotify()
+		d.expectSetting(t, setting)
+		owner := helpers.NewUser()
+		owner.Email = "owner@example.com"
+		d.common.EXPECT().GetOwner().Return(owner, nil).Once()
+		d.kv.EXPECT().
+			Get(mock.Anything, commonModel.ServerURLKey).
+			Return("https://example.com", nil)
+		d.repo.EXPECT().
+			IsEmailSuppressed(mock.Anything, "owner@example.com").
+			Return(false, nil).
+			Once()
+		d.mailer.EXPECT().
+			Send(mock.Anything, mock.Anything, mock.MatchedBy(func(msg commentService.MailMessage) bool {
+				return msg.To == "owner@example.com" &&
+					msg.Subject == mail.Subject &&
+					strings.HasPrefix(msg.TextBody, "body") &&
+					strings.Contains(msg.HTMLBody, "Ech0 站点摘要") &&
+					strings.Contains(msg.HTMLBody, "<p>body</p>") &&
+					strings.HasPrefix(msg.UnsubscribeURL, "https://example.com/api/comments/unsubscribe?token=")
+			})).
+			Return(nil).
+			Once()
+		require.NoError(t, d.service().SendOwnerMail(helpers.CtxAnonymous(), mail))
+	})
+
+	t.Run("suppressed owner address is reported", func(t *testing.T) {
+		d := newDeps(t)
+		setting := enabledSetting()
+		setting.EmailNotify = validEmailNotify()
+		d.expectSetting(t, setting)
+		owner := helpers.NewUser()
+		owner.Email = "owner@example.com"
+		d.common.EXPECT().GetOwner().Return(owner, nil).Once()
+		d.kv.EXPECT().
+			Get(mock.Anything, commonModel.ServerURLKey).
+			Return("https://example.com", nil)
+		d.repo.EXPECT().
+			IsEmailSuppressed(mock.Anything, "owner@example.com").
+			Return(true, nil).
+			Once()
+		err := d.service().SendOwnerMail(helpers.CtxAnonymous(), mail)
+		assertBiz(t, err, commonModel.ErrCodeInvalidRequest, "")
+	})
 }
 
 // --- ParseOptionalUserIDFromAuthHeader -------------------------------------
@@ -0,0 +1,231 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	commentService "github.com/lin-snow/ech0/internal/service/comment"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/stretchr/testify/assert"
+	mock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// 订阅相关的发信都在 goroutine 里进行：mailer 的 Run 把消息投进 channel，测试在此等待，
+// 从而既能断言邮件内容，又保证 goroutine 在测试结束前完成对 mock 的调用。
+
+const subscriptionServerURL = "https://blog.test"
+
+var confirmLinkPattern = regexp.MustCompile(`/api/comments/subscription/confirm\?token=(\S+)`)
+
+// sendingSetting 打开邮件通知并给出可通过校验的 SMTP 配置。
+func sendingSetting() commentModel.SystemSetting {
+	s := enabledSetting()
+	s.EmailNotify = validEmailNotify()
+	return s
+}
+
+func (d deps) captureMail(t *testing.T, times int) <-chan commentService.MailMessage {
+	t.Helper()
+	sent := make(chan commentService.MailMessage, times)
+	d.mailer.EXPECT().
+		Send(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, _ commentService.MailerConfig, msg commentService.MailMessage) { sent <- msg }).
+		Return(nil).
+		Times(times)
+	return sent
+}
+
+func waitMail(t *testing.T, sent <-chan commentService.MailMessage) commentService.MailMessage {
+	t.Helper()
+	select {
+	case msg := <-sent:
+		return msg
+	case <-time.After(2 * time.Second):
+		t.Fatal("mail was not sent")
+		return commentService.MailMessage{}
+	}
+}
+
+func (d deps) expectGuestCreate(commentID string) {
+	d.repo.EXPECT().CountByIPWithin(mock.Anything, mock.Anything, mock.Anything).Return(int64(0), nil)
+	d.repo.EXPECT().CountByEmailWithin(mock.Anything, mock.Anything, mock.Anything).Return(int64(0), nil)
+	d.repo.EXPECT().
+		ExistsRecentDuplicate(
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything, mock.Anything,
+		).
+		Return(false, nil).
+		Once()
+	d.repo.EXPECT().
+		CreateComment(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, c *commentModel.Comment) { c.ID = commentID }).
+		Return(nil).
+		Once()
+	// owner 邮箱为空：「有新评论」通知在发信前返回，只剩订阅确认邮件。
+	d.common.EXPECT().GetOwner().Return(helpers.NewUser(), nil).Once()
+}
+
+func subscribeDto() *commentModel.CreateCommentDto {
+	return &commentModel.CreateCommentDto{
+		EchoID:    "echo-1",
+		Content:   "hello",
+		Nickname:  "Guest",
+		Email:     "Guest@Example.com",
+		FormToken: freshToken(),
+		Subscribe: true,
+	}
+}
+
+func TestSubscription_DoubleOptInAndUnsubscribe(t *testing.T) {
+	helpers.SetJWTSecret(t, testSecret)
+	d := newDeps(t)
+	d.expectSetting(t, sendingSetting())
+	d.expectGuestCreate("g-1")
+	d.kv.EXPECT().Get(mock.Anything, commonModel.ServerURLKey).Return(subscriptionServerURL, nil)
+	d.repo.EXPECT().IsEmailSuppressed(mock.Anything, "guest@example.com").Return(false, nil).Times(2)
+	d.repo.EXPECT().
+		GetCommentSubscription(mock.Anything, "echo-1", "guest@example.com").
+		Return(commentModel.CommentSubscription{}, errors.New("record not found")).
+		Once()
+	d.repo.EXPECT().
+		SaveCommentSubscription(mock.Anything, mock.MatchedBy(func(sub *commentModel.CommentSubscription) bool {
+			return sub.EchoID == "echo-1" && sub.Email == "guest@example.com" &&
+				sub.Status == commentModel.SubscriptionPending
+		})).
+		Return(nil).
+		Once()
+	sent := d.captureMail(t, 1)
+
+	svc := d.service()
+	_, err := svc.CreateComment(helpers.CtxAnonymous(), testIP, "ua", subscribeDto())
+	require.NoError(t, err)
+
+	msg := waitMail(t, sent)
+	assert.Equal(t, "guest@example.com", msg.To)
+	assert.Equal(t, "[Ech0] 请确认订阅评论通知", msg.Subject)
+	assert.True(t, strings.HasPrefix(msg.UnsubscribeURL, subscriptionServerURL+"/api/comments/unsubscribe?token="))
+	match := confirmLinkPattern.FindStringSubmatch(msg.TextBody)
+	require.Len(t, match, 2, "确认邮件应带确认链接")
+
+	// 点击确认链接：pending -> active。
+	d.repo.EXPECT().
+		GetCommentSubscription(mock.Anything, "echo-1", "guest@example.com").
+		Return(commentModel.CommentSubscription{ID: "sub-1", Status: commentModel.SubscriptionPending}, nil).
+		Once()
+	d.repo.EXPECT().ConfirmCommentSubscription(mock.Anything, "sub-1", mock.Anything).Return(nil).Once()
+	require.NoError(t, svc.ConfirmSubscription(context.Background(), match[1]))
+
+	_, err = svc.Unsubscribe(context.Background(), match[1])
+	assertBiz(t, err, commonModel.ErrCodeInvalidRequest, "")
+
+	// 确认邮件里的退订链接是全局退订：加入退订名单并清空订阅。
+	d.repo.EXPECT().AddEmailSuppression(mock.Anything, "guest@example.com").Return(nil).Once()
+	d.repo.EXPECT().DeleteCommentSubscriptionsByEmail(mock.Anything, "guest@example.com").Return(nil).Once()
+	scope, err := svc.Unsubscribe(context.Background(), strings.SplitN(msg.UnsubscribeURL, "token=", 2)[1])
+	require.NoError(t, err)
+	assert.Equal(t, commentModel.UnsubscribeAll, scope)
+}
+
+func TestSubscription_SkipsRecentPendingAndSuppressed(t *testing.T) {
+	t.Run("pending request within the resend window", func(t *testing.T) {
+		helpers.SetJWTSecret(t, testSecret)
+		d := newDeps(t)
+		d.expectSetting(t, sendingSetting())
+		d.expectGuestCreate("g-2")
+		d.repo.EXPECT().IsEmailSuppressed(mock.Anything, "guest@example.com").Return(false, nil).Once()
+		d.repo.EXPECT().
+			GetCommentSubscription(mock.Anything, "echo-1", "guest@example.com").
+			Return(commentModel.CommentSubscription{
+				ID:        "sub-1",
+				Status:    commentModel.SubscriptionPending,
+				CreatedAt: time.Now().Unix() - 60,
+			}, nil).
+			Once()
+
+		_, err := d.service().CreateComment(helpers.CtxAnonymous(), testIP, "ua", subscribeDto())
+		require.NoError(t, err)
+	})
+
+	t.Run("suppressed address", func(t *testing.T) {
+		helpers.SetJWTSecret(t, testSecret)
+		d := newDeps(t)
+		d.expectSetting(t, sendingSetting())
+		d.expectGuestCreate("g-3")
+		d.repo.EXPECT().IsEmailSuppressed(mock.Anything, "guest@example.com").Return(true, nil).Once()
+
+		_, err := d.service().CreateComment(helpers.CtxAnonymous(), testIP, "ua", subscribeDto())
+		require.NoError(t, err)
+	})
+}
+
+func TestSubscription_ThreadNotifyOnApproval(t *testing.T) {
+	helpers.SetJWTSecret(t, testSecret)
+	d := newDeps(t)
+	expectAdmin(t, d, "admin-1")
+	d.expectSetting(t, sendingSetting())
+	d.kv.EXPECT().Get(mock.Anything, commonModel.ServerURLKey).Return(subscriptionServerURL, nil)
+	approved := commentModel.Comment{
+		ID:      "c-1",
+		EchoID:  "echo-1",
+		Email:   "author@example.com",
+		Content: "approved now",
+		Status:  commentModel.StatusApproved,
+	}
+	d.repo.EXPECT().UpdateCommentStatus(mock.Anything, "c-1", commentModel.StatusApproved).Return(nil).Once()
+	d.repo.EXPECT().GetCommentByID(mock.Anything, "c-1").Return(approved, nil).Once()
+	owner := helpers.NewUser(helpers.AsOwner)
+	owner.Email = "owner@example.com"
+	d.common.EXPECT().GetOwner().Return(owner, nil).Once()
+	d.repo.EXPECT().
+		ListActiveSubscriberEmails(mock.Anything, "echo-1").
+		Return([]string{"author@example.com", "owner@example.com", "fan@example.com"}, nil).
+		Once()
+	d.repo.EXPECT().IsEmailSuppressed(mock.Anything, mock.Anything).Return(false, nil)
+	// 审核通过同时给评论者发状态邮件，再给其余订阅者发讨论邮件。
+	sent := d.captureMail(t, 2)
+
+	require.NoError(t, d.service().UpdateCommentStatus(helpers.CtxAsUser("admin-1"), "c-1", commentModel.StatusApproved))
+
+	byRecipient := map[string]commentService.MailMessage{}
+	for range 2 {
+		msg := waitMail(t, sent)
+		byRecipient[msg.To] = msg
+	}
+	require.Contains(t, byRecipient, "author@example.com")
+	thread, ok := byRecipient["fan@example.com"]
+	require.True(t, ok, "只有非评论者、非 owner 的订阅者收到讨论邮件")
+	assert.Equal(t, "[Ech0] 你订阅的讨论有新评论", thread.Subject)
+	assert.Contains(t, thread.TextBody, "a***@example.com")
+	assert.NotContains(t, thread.TextBody, "author@example.com", "不向其他订阅者暴露评论者邮箱")
+
+	// 讨论邮件的退订链接只取消这条 Echo 的订阅。
+	d.repo.EXPECT().DeleteCommentSubscription(mock.Anything, "echo-1", "fan@example.com").Return(nil).Once()
+	scope, err := d.service().Unsubscribe(context.Background(), strings.SplitN(thread.UnsubscribeURL, "token=", 2)[1])
+	require.NoError(t, err)
+	assert.Equal(t, commentModel.UnsubscribeThread, scope)
+}
+
+func TestEmailSuppressionAdmin(t *testing.T) {
+	t.Run("anonymous is denied", func(t *testing.T) {
+		d := newDeps(t)
+		_, err := d.service().ListEmailSuppressions(helpers.CtxAnonymous())
+		assertBiz(t, err, commonModel.ErrCodePermissionDenied, "")
+	})
+
+	t.Run("delete normalizes the address", func(t *testing.T) {
+		d := newDeps(t)
+		expectAdmin(t, d, "admin-1")
+		d.repo.EXPECT().DeleteEmailSuppression(mock.Anything, "fan@example.com").Return(nil).Once()
+		require.NoError(t, d.service().DeleteEmailSuppression(helpers.CtxAsUser("admin-1"), " Fan@Example.com "))
+	})
+}
@@ -116,6 +116,15 @@ func TestBuildNotifyContent(t *testing.T) {
 			wantInText:  "wow",
 			wantLink:    true,
 		},
+		{
+			name:        "thread",
+			kind:        "thread",
+			comment:     model.Comment{EchoID: "e1", Content: "new one", Status: model.StatusApproved},
+			serverURL:   server,
+			wantSubject: "[Ech0] 你订阅的讨论有新评论",
+			wantInText:  "new one",
+			wantLink:    true,
+		},
 		{
 			name:        "test kind with empty content and missing author falls back, no link",
 			kind:        "test",
@@ -137,7 +146,7 @@ func TestBuildNotifyContent(t *testing.T) {
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			got := buildNotifyContent(tc.kind, tc.comment, tc.serverURL)
+			got := buildNotifyContent(tc.kind, tc.comment, tc.serverURL, "")
 			assert.Equal(t, tc.wantSubject, got.Subject)
 			assert.NotEmpty(t, got.TextBody)
 			assert.NotEmpty(t, got.HTMLBody)
@@ -148,15 +157,44 @@ func TestBuildNotifyContent(t *testing.T) {
 			} else {
 				assert.NotContains(t, got.TextBody, "查看 Echo")
 			}
+			assert.NotContains(t, got.TextBody, "退订")
 		})
 	}
 
+	t.Run("unsubscribe link lands in both bodies", func(t *testing.T) {
+		unsubscribe := server + "/api/comments/unsubscribe?token=a.b&x=1"
+		got := buildNotifyContent("reply", model.Comment{EchoID: "e1", Content: "re"}, server, unsubscribe)
+		assert.Contains(t, got.TextBody, "退订:\n"+unsubscribe)
+		assert.Contains(t, got.HTMLBody, `href="`+server+`/api/comments/unsubscribe?token=a.b&amp;x=1"`)
+	})
+
 	t.Run("test kind injects placeholder body when content empty", func(t *testing.T) {
-		got := buildNotifyContent("test", model.Comment{Status: model.StatusPending}, "")
+		got := buildNotifyContent("test", model.Comment{Status: model.StatusPending}, "", "")
 		assert.Contains(t, got.TextBody, "测试邮件")
 	})
 }
 
+func TestBuildSubscribeConfirmContent(t *testing.T) {
+	got := buildSubscribeConfirmContent(
+		"https://ech0.example.com/echo/e1",
+		"https://ech0.example.com/api/comments/subscription/confirm?token=c",
+		"https://ech0.example.com/api/comments/unsubscribe?token=u",
+	)
+	assert.Equal(t, "[Ech0] 请确认订阅评论通知", got.Subject)
+	assert.Contains(t, got.TextBody, "subscription/confirm?token=c")
+	assert.Contains(t, got.TextBody, "/echo/e1")
+	assert.Contains(t, got.TextBody, "unsubscribe?token=u")
+	assert.Contains(t, got.HTMLBody, "确认订阅")
+	assert.Contains(t, got.HTMLBody, "unsubscribe?token=u")
+}
+
+func TestMaskEmail(t *testing.T) {
+	assert.Equal(t, "a***@example.com", maskEmail("alice@example.com"))
+	assert.Equal(t, "张***@example.com", maskEmail("张三@example.com"))
+	assert.Empty(t, maskEmail("not-an-email"))
+	assert.Empty(t, maskEmail(""))
+}
+
 func TestBuildEchoLink(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -8,6 +8,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -105,3 +106,59 @@ func TestComputeHMAC(t *testing.T) {
 	_, err := base64.RawURLEncoding.DecodeString(a)
 	assert.NoError(t, err)
 }
+
+func TestSubscriptionToken(t *testing.T) {
+	helpers.SetJWTSecret(t, "subscription-secret")
+	s := &CommentService{}
+
+	token := s.signSubscriptionToken(subscriptionToken{
+		Action: tokenActionConfirm,
+		EchoID: "echo-1",
+		Email:  "a@example.com",
+		Exp:    time.Now().Add(time.Hour).Unix(),
+	})
+	got, err := s.parseSubscriptionToken(token, tokenActionConfirm)
+	require.NoError(t, err)
+	assert.Equal(t, "echo-1", got.EchoID)
+	assert.Equal(t, "a@example.com", got.Email)
+
+	_, err = s.parseSubscriptionToken(token, tokenActionUnsubscribe)
+	assert.Error(t, err, "确认 token 不能当退订 token 用")
+
+	payload, sig, _ := strings.Cut(token, ".")
+	forged := base64.RawURLEncoding.EncodeToString([]byte(`{"a":"confirm","e":"echo-1","m":"b@example.com"}`))
+	_, err = s.parseSubscriptionToken(forged+"."+sig, tokenActionConfirm)
+	assert.Error(t, err, "篡改载荷后签名不再匹配")
+	_, err = s.parseSubscriptionToken(payload, tokenActionConfirm)
+	assert.Error(t, err)
+
+	expired := s.signSubscriptionToken(subscriptionToken{
+		Action: tokenActionConfirm,
+		Email:  "a@example.com",
+		Exp:    time.Now().Add(-time.Minute).Unix(),
+	})
+	_, err = s.parseSubscriptionToken(expired, tokenActionConfirm)
+	assert.Error(t, err)
+
+	// 表单 token 的签名不能挪用到订阅链接上（签名带域前缀）。
+	formSig := s.computeHMAC(payload)
+	_, err = s.parseSubscriptionToken(payload+"."+formSig, tokenActionConfirm)
+	assert.Error(t, err)
+}
+
+func TestUnsubscribeURL(t *testing.T) {
+	helpers.SetJWTSecret(t, "subscription-secret")
+	s := &CommentService{}
+
+	assert.Empty(t, s.unsubscribeURL("", "", "a@example.com"), "没有站点地址时不生成链接")
+	assert.Empty(t, s.unsubscribeURL("https://x.test", "", " "))
+
+	link := s.unsubscribeURL("https://x.test", "echo-1", " A@Example.com ")
+	prefix := "https://x.test/api/comments/unsubscribe?token="
+	require.True(t, strings.HasPrefix(link, prefix))
+	got, err := s.parseSubscriptionToken(strings.TrimPrefix(link, prefix), tokenActionUnsubscribe)
+	require.NoError(t, err)
+	assert.Equal(t, "a@example.com", got.Email)
+	assert.Equal(t, "echo-1", got.EchoID)
+	assert.Zero(t, got.Exp, "退订链接不过期")
+}
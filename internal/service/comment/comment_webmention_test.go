@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	"github.com/stretchr/testify/assert"
+	mock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+const testSource = "https://blog.example/posts/1"
+
+func TestSaveWebmention_CommentDisabled(t *testing.T) {
+	d := newDeps(t)
+	s := enabledSetting()
+	s.EnableComment = false
+	d.expectSetting(t, s)
+
+	_, err := d.service().SaveWebmention(context.Background(),
+		commentModel.Webmention{EchoID: "echo-1", Source: testSource})
+	assertBiz(t, err, commonModel.ErrCodeInvalidRequest, "评论功能未启用")
+}
+
+// Webmention 在后台落库，不带 viewer；审核开关与普通评论一致，昵称缺省退回来源主机名。
+func TestSaveWebmention_Create(t *testing.T) {
+	cases := []struct {
+		name            string
+		requireApproval bool
+		author          string
+		content         string
+		wantNickname    string
+		wantContent     string
+		wantStatus      commentModel.Status
+	}{
+		{"pending, host nickname", true, "", "  nice\n post ", "blog.example", "nice post", commentModel.StatusPending},
+		{"approved, author nickname", false, "Alice", "", "Alice", testSource, commentModel.StatusApproved},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newDeps(t)
+			s := enabledSetting()
+			s.RequireApproval = tc.requireApproval
+			d.expectSetting(t, s)
+			d.repo.EXPECT().
+				GetWebmention(mock.Anything, "echo-1", testSource).
+				Return(commentModel.Comment{}, gorm.ErrRecordNotFound).
+				Once()
+
+			var captured commentModel.Comment
+			d.repo.EXPECT().
+				CreateComment(mock.Anything, mock.Anything).
+				Run(func(_ context.Context, c *commentModel.Comment) {
+					c.ID = "wm-1"
+					captured = *c
+				}).
+				Return(nil).
+				Once()
+
+			res, err := d.service().SaveWebmention(context.Background(), commentModel.Webmention{
+				EchoID:  "echo-1",
+				Source:  testSource,
+				Author:  tc.author,
+				Content: tc.content,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, "wm-1", res.ID)
+			assert.Equal(t, tc.wantStatus, res.Status)
+			assert.Equal(t, commentModel.SourceWebmention, captured.Source)
+			assert.Equal(t, testSource, captured.Website)
+			assert.Equal(t, tc.wantNickname, captured.Nickname)
+			assert.Equal(t, tc.wantContent, captured.Content)
+			assert.Empty(t, captured.Email)
+			assert.Nil(t, captured.UserID)
+		})
+	}
+}
+
+func TestSaveWebmention_TruncatesContent(t *testing.T) {
+	d := newDeps(t)
+	d.expectSetting(t, enabledSetting())
+	d.repo.EXPECT().
+		GetWebmention(mock.Anything, "echo-1", testSource).
+		Return(commentModel.Comment{}, gorm.ErrRecordNotFound).
+		Once()
+
+	var captured commentModel.Comment
+	d.repo.EXPECT().
+		CreateComment(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, c *commentModel.Comment) { captured = *c }).
+		Return(nil).
+		Once()
+
+	_, err := d.service().SaveWebmention(context.Background(), commentModel.Webmention{
+		EchoID:  "echo-1",
+		Source:  testSource,
+		Content: strings.Repeat("字", 500),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 200, utf8.RuneCountInString(captured.Content))
+	assert.True(t, strings.HasSuffix(captured.Content, "…"))
+}
+
+// 重复投递且内容有变时覆盖原记录：开启审核则回到 pending 重新审核，关闭审核则保留原状态；
+// 内容未变时不写库。
+func TestSaveWebmention_UpdatesExisting(t *testing.T) {
+	existing := commentModel.Comment{
+		ID:       "wm-1",
+		EchoID:   "echo-1",
+		Nickname: "Alice",
+		Content:  "old",
+		Website:  testSource,
+		Status:   commentModel.StatusApproved,
+		Source:   commentModel.SourceWebmention,
+	}
+
+	cases := []struct {
+		name            string
+		requireApproval bool
+		wantStatus      commentModel.Status
+	}{
+		{"changed, approval required", true, commentModel.StatusPending},
+		{"changed, no approval", false, commentModel.StatusApproved},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newDeps(t)
+			s := enabledSetting()
+			s.RequireApproval = tc.requireApproval
+			d.expectSetting(t, s)
+			d.repo.EXPECT().GetWebmention(mock.Anything, "echo-1", testSource).Return(existing, nil).Once()
+			d.repo.EXPECT().UpdateWebmention(mock.Anything, "wm-1", "Alice", "new", tc.wantStatus).Return(nil).Once()
+
+			res, err := d.service().SaveWebmention(context.Background(), commentModel.Webmention{
+				EchoID: "echo-1", Source: testSource, Author: "Alice", Content: "new",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, commentModel.CreateCommentResult{ID: "wm-1", Status: tc.wantStatus}, res)
+		})
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		d := newDeps(t)
+		d.expectSetting(t, enabledSetting())
+		d.repo.EXPECT().GetWebmention(mock.Anything, "echo-1", testSource).Return(existing, nil).Once()
+
+		res, err := d.service().SaveWebmention(context.Background(), commentModel.Webmention{
+			EchoID: "echo-1", Source: testSource, Author: "Alice", Content: "old",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, commentModel.StatusApproved, res.Status)
+	})
+}
+
+func TestDeleteWebmention(t *testing.T) {
+	t.Run("existing", func(t *testing.T) {
+		d := newDeps(t)
+		d.repo.EXPECT().
+			GetWebmention(mock.Anything, "echo-1", testSource).
+			Return(commentModel.Comment{ID: "wm-1", EchoID: "echo-1", Website: testSource}, nil).
+			Once()
+		d.repo.EXPECT().DeleteComment(mock.Anything, "wm-1").Return(nil).Once()
+
+		require.NoError(t, d.service().DeleteWebmention(context.Background(), "echo-1", testSource))
+	})
+
+	t.Run("missing is a no-op", func(t *testing.T) {
+		d := newDeps(t)
+		d.repo.EXPECT().
+			GetWebmention(mock.Anything, "echo-1", testSource).
+			Return(commentModel.Comment{}, gorm.ErrRecordNotFound).
+			Once()
+
+		require.NoError(t, d.service().DeleteWebmention(context.Background(), "echo-1", testSource))
+	})
+
+	t.Run("delete error", func(t *testing.T) {
+		d := newDeps(t)
+		d.repo.EXPECT().
+			GetWebmention(mock.Anything, "echo-1", testSource).
+			Return(commentModel.Comment{ID: "wm-1"}, nil).
+			Once()
+		d.repo.EXPECT().DeleteComment(mock.Anything, "wm-1").Return(errors.New("db down")).Once()
+
+		require.Error(t, d.service().DeleteWebmention(context.Background(), "echo-1", testSource))
+	})
+}
@@ -19,7 +19,8 @@ type notifyContent struct {
 	HTMLBody string
 }
 
-func buildNotifyContent(kind string, comment model.Comment, serverURL string) notifyContent {
+// buildNotifyContent 渲染评论通知邮件；unsubscribeURL 非空时在页脚附上退订链接。
+func buildNotifyContent(kind string, comment model.Comment, serverURL, unsubscribeURL string) notifyContent {
 	subject := notifySubject(kind, comment.Status)
 	eventTitle := notifyEventTitle(kind, comment.Status)
 	statusLabel, statusColor, statusBg := notifyStatusStyle(kind, comment.Status)
@@ -35,7 +36,8 @@ func buildNotifyContent(kind string, comment model.Comment, serverURL string) no
 	}
 	echoLink := buildEchoLink(serverURL, comment.EchoID)
 	contentHTML := strings.ReplaceAll(stdhtml.EscapeString(contentText), "\n", "<br/>")
-	text := buildNotifyText(eventTitle, statusLabel, createdAt, nickname, authorEmail, contentText, echoLink)
+	text := buildNotifyText(eventTitle, statusLabel, createdAt, nickname, authorEmail, contentText, echoLink) +
+		buildUnsubscribeText(unsubscribeURL)
 	actionHTML := ""
 	if echoLink != "" {
 		actionHTML = fmt.Sprintf(
@@ -43,16 +45,8 @@ func buildNotifyContent(kind string, comment model.Comment, serverURL string) no
 			stdhtml.EscapeString(echoLink),
 		)
 	}
-	htmlBody := fmt.Sprintf(
-		`<!doctype html><html><body style="margin:0;padding:0;background:#f4f1ec;font-family:'SF Pro Text','PingFang SC','Hiragino Sans GB','Microsoft YaHei',-apple-system,BlinkMacSystemFont,'Segoe UI',Roboto,Arial,sans-serif;color:#3a3329;">
-<table role="presentation" width="100%%" cellpadding="0" cellspacing="0" style="padding:28px 14px;">
-  <tr><td align="center">
-    <table role="presentation" width="100%%" cellpadding="0" cellspacing="0" style="max-width:640px;background:#ffffff;border:1px solid #e6dfd4;border-radius:0;overflow:hidden;">
-      <tr><td style="padding:16px 20px;border-bottom:1px solid #ebe5db;">
-        <div style="font-size:16px;font-weight:700;color:#3a3329;">Ech0 评论通知</div>
-      </td></tr>
-      <tr><td style="padding:20px;">
-        <div style="display:inline-block;padding:3px 10px;border-radius:0;font-size:12px;font-weight:600;color:%s;background:%s;border:1px solid %s;">%s</div>
+	innerHTML := fmt.Sprintf(
+		`<div style="display:inline-block;padding:3px 10px;border-radius:0;font-size:12px;font-weight:600;color:%s;background:%s;border:1px solid %s;">%s</div>
         <div style="margin-top:12px;font-size:18px;font-weight:700;color:#3a3329;">%s</div>
         <div style="margin-top:14px;padding:14px;border:1px solid #e8e2d8;border-radius:0;background:#fffcf8;line-height:1.7;font-size:14px;color:#4f473b;word-break:break-word;">%s</div>
         <table role="presentation" width="100%%" cellpadding="0" cellspacing="0" style="margin-top:10px;border-collapse:collapse;background:#faf7f2;border:1px solid #e8e2d8;border-radius:0;">
@@ -61,13 +55,7 @@ func buildNotifyContent(kind string, comment model.Comment, serverURL string) no
           <tr><td style="padding:7px 10px;font-size:12px;line-height:1.45;color:#8b8377;">昵称</td><td style="padding:7px 10px;font-size:12px;line-height:1.45;color:#5f574a;">%s</td></tr>
           <tr><td style="padding:7px 10px;font-size:12px;line-height:1.45;color:#8b8377;">邮箱</td><td style="padding:7px 10px;font-size:12px;line-height:1.45;color:#5f574a;">%s</td></tr>
         </table>
-        %s
-        <div style="margin-top:14px;font-size:12px;line-height:1.6;color:#958d80;">此邮件由 Ech0 评论系统自动发送。</div>
-      </td></tr>
-    </table>
-  </td></tr>
-</table>
-</body></html>`,
+        %s`,
 		statusColor,
 		statusBg,
 		statusBg,
@@ -80,6 +68,7 @@ func buildNotifyContent(kind string, comment model.Comment, serverURL string) no
 		stdhtml.EscapeString(authorEmail),
 		actionHTML,
 	)
+	htmlBody := wrapMailHTML(innerHTML, unsubscribeURL)
 	return notifyContent{
 		Subject:  subject,
 		TextBody: text,
@@ -87,6 +76,79 @@ func buildNotifyContent(kind string, comment model.Comment, serverURL string) no
 	}
 }
 
+// buildSubscribeConfirmContent 渲染订阅确认邮件（double opt-in）：收件人点击 confirmURL 后订阅才生效。
+func buildSubscribeConfirmContent(echoLink, confirmURL, unsubscribeURL string) notifyContent {
+	text := "Ech0 评论通知\n\n你在评论时勾选了「订阅后续评论」。请打开下方链接确认订阅；如果不是你本人操作，忽略此邮件即可。\n\n确认订阅:\n" + confirmURL
+	if echoLink != "" {
+		text += fmt.Sprintf("\n\n查看 Echo:\n%s", echoLink)
+	}
+	text += buildUnsubscribeText(unsubscribeURL)
+	echoHTML := ""
+	if echoLink != "" {
+		echoHTML = fmt.Sprintf(
+			`<div style="margin-top:12px;font-size:12px;line-height:1.6;color:#8b8377;">订阅的 Echo：<a href="%s" target="_blank" rel="noopener noreferrer" style="color:#5f574a;">%s</a></div>`,
+			stdhtml.EscapeString(echoLink),
+			stdhtml.EscapeString(echoLink),
+		)
+	}
+	innerHTML := fmt.Sprintf(
+		`<div style="font-size:18px;font-weight:700;color:#3a3329;">确认订阅评论通知</div>
+        <div style="margin-top:14px;line-height:1.7;font-size:14px;color:#4f473b;">你在评论时勾选了「订阅后续评论」。确认后，这条 Echo 下有新评论时会邮件通知你；如果不是你本人操作，忽略此邮件即可。</div>
+        <div style="margin-top:16px;"><a href="%s" target="_blank" rel="noopener noreferrer" style="display:inline-block;padding:8px 14px;border-radius:0;background:#ffffff;border:1px solid #cbc4b8;color:#5f574a;text-decoration:none;font-size:13px;font-weight:600;">确认订阅</a></div>
+        %s`,
+		stdhtml.EscapeString(confirmURL),
+		echoHTML,
+	)
+	return notifyContent{
+		Subject:  "[Ech0] 请确认订阅评论通知",
+		TextBody: text,
+		HTMLBody: wrapMailHTML(innerHTML, unsubscribeURL),
+	}
+}
+
+// wrapMailHTML 为评论通知邮件套上统一的外框与页脚。
+func wrapMailHTML(innerHTML, unsubscribeURL string) string {
+	return wrapMailHTMLWithHeading("Ech0 评论通知", innerHTML, unsubscribeURL)
+}
+
+// wrapMailHTMLWithHeading 同 wrapMailHTML，但使用给定的页眉标题。
+func wrapMailHTMLWithHeading(heading, innerHTML, unsubscribeURL string) string {
+	footer := "此邮件由 Ech0 评论系统自动发送。"
+	if unsubscribeURL != "" {
+		footer += fmt.Sprintf(
+			` 不想再收到此类邮件？<a href="%s" target="_blank" rel="noopener noreferrer" style="color:#958d80;">退订</a>`,
+			stdhtml.EscapeString(unsubscribeURL),
+		)
+	}
+	return fmt.Sprintf(
+		`<!doctype html><html><body style="margin:0;padding:0;background:#f4f1ec;font-family:'SF Pro Text','PingFang SC','Hiragino Sans GB','Microsoft YaHei',-apple-system,BlinkMacSystemFont,'Segoe UI',Roboto,Arial,sans-serif;color:#3a3329;">
+<table role="presentation" width="100%%" cellpadding="0" cellspacing="0" style="padding:28px 14px;">
+  <tr><td align="center">
+    <table role="presentation" width="100%%" cellpadding="0" cellspacing="0" style="max-width:640px;background:#ffffff;border:1px solid #e6dfd4;border-radius:0;overflow:hidden;">
+      <tr><td style="padding:16px 20px;border-bottom:1px solid #ebe5db;">
+        <div style="font-size:16px;font-weight:700;color:#3a3329;">%s</div>
+      </td></tr>
+      <tr><td style="padding:20px;">
+        %s
+        <div style="margin-top:14px;font-size:12px;line-height:1.6;color:#958d80;">%s</div>
+      </td></tr>
+    </table>
+  </td></tr>
+</table>
+</body></html>`,
+		stdhtml.EscapeString(heading),
+		innerHTML,
+		footer,
+	)
+}
+
+func buildUnsubscribeText(unsubscribeURL string) string {
+	if unsubscribeURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n\n退订:\n%s", unsubscribeURL)
+}
+
 func buildNotifyText(
 	eventTitle string,
 	statusLabel string,
@@ -146,6 +208,8 @@ func notifySubject(kind string, status model.Status) string {
 		return prefix + " 评论状态已更新"
 	case "hot":
 		return prefix + " 您的评论被标为精选"
+	case "thread":
+		return prefix + " 你订阅的讨论有新评论"
 	default:
 		return prefix + " 邮件通知测试"
 	}
@@ -167,6 +231,8 @@ func notifyEventTitle(kind string, status model.Status) string {
 		return "评论状态已更新"
 	case "hot":
 		return "评论已被设为 Hot"
+	case "thread":
+		return "订阅的讨论有新评论"
 	default:
 		return "评论通知测试邮件"
 	}
@@ -178,6 +244,8 @@ func notifyStatusStyle(kind string, status model.Status) (label, color, bg strin
 		return "HOT", "#7c3aed", "#f3e8ff"
 	case "reply":
 		return "回复", "#0369a1", "#e0f2fe"
+	case "thread":
+		return "订阅", "#0369a1", "#e0f2fe"
 	case "status":
 		if status == model.StatusApproved {
 			return "已通过", "#059669", "#ecfdf5"
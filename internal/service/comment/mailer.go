@@ -56,20 +56,33 @@ func (s *GoMailSender) Send(ctx context.Context, cfg MailerConfig, msg MailMessa
 	}
 	defer func() { _ = client.Close() }()
 
+	m, err := buildMailMsg(from, to, msg)
+	if err != nil {
+		return err
+	}
+	return client.Send(m)
+}
+
+func buildMailMsg(from, to string, msg MailMessage) (*mail.Msg, error) {
 	m := mail.NewMsg()
 	if err := m.From(from); err != nil {
-		return err
+		return nil, err
 	}
 	if err := m.To(to); err != nil {
-		return err
+		return nil, err
 	}
 	m.Subject(strings.TrimSpace(msg.Subject))
+	if unsubscribeURL := strings.TrimSpace(msg.UnsubscribeURL); unsubscribeURL != "" {
+		// RFC 8058：邮件客户端据此展示「退订」按钮，并以 POST 一键退订。
+		m.SetGenHeader(mail.HeaderListUnsubscribe, "<"+unsubscribeURL+">")
+		m.SetGenHeader(mail.HeaderListUnsubscribePost, "List-Unsubscribe=One-Click")
+	}
 	if strings.TrimSpace(msg.HTMLBody) != "" {
 		m.SetBodyString(mail.TypeTextHTML, msg.HTMLBody)
 	} else {
 		m.SetBodyString(mail.TypeTextPlain, msg.TextBody)
 	}
-	return client.Send(m)
+	return m, nil
 }
 
 func defaultPort(port int) int {
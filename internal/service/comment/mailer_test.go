@@ -69,3 +69,26 @@ func TestResolveSMTPTransport(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildMailMsg_ListUnsubscribe(t *testing.T) {
+	msg := MailMessage{Subject: "s", TextBody: "t", HTMLBody: "<p>t</p>"}
+	m, err := buildMailMsg("noreply@example.com", "a@example.com", msg)
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if got := m.GetGenHeader(mail.HeaderListUnsubscribe); len(got) != 0 {
+		t.Fatalf("expected no List-Unsubscribe header, got %v", got)
+	}
+
+	msg.UnsubscribeURL = "https://blog.test/api/comments/unsubscribe?token=x"
+	m, err = buildMailMsg("noreply@example.com", "a@example.com", msg)
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if got := m.GetGenHeader(mail.HeaderListUnsubscribe); len(got) != 1 || got[0] != "<"+msg.UnsubscribeURL+">" {
+		t.Fatalf("unexpected List-Unsubscribe header: %v", got)
+	}
+	if got := m.GetGenHeader(mail.HeaderListUnsubscribePost); len(got) != 1 || got[0] != "List-Unsubscribe=One-Click" {
+		t.Fatalf("unexpected List-Unsubscribe-Post header: %v", got)
+	}
+}
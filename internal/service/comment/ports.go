@@ -25,6 +25,8 @@ type Service interface {
 		userAgent string,
 		dto *model.CreateIntegrationCommentDto,
 	) (model.CreateCommentResult, error)
+	SaveWebmention(ctx context.Context, mention model.Webmention) (model.CreateCommentResult, error)
+	DeleteWebmention(ctx context.Context, echoID, source string) error
 	ListPublicByEchoID(ctx context.Context, echoID string) ([]model.PublicComment, error)
 	ListPublicComments(ctx context.Context, limit int) ([]model.PublicComment, error)
 	ListPanelComments(ctx context.Context, query model.ListCommentQuery) (model.PageResult[model.Comment], error)
@@ -36,6 +38,11 @@ type Service interface {
 	GetSystemSetting(ctx context.Context) (model.SystemSetting, error)
 	UpdateSystemSetting(ctx context.Context, setting model.SystemSetting) error
 	SendTestEmail(ctx context.Context, setting model.SystemSetting) error
+	ConfirmSubscription(ctx context.Context, token string) error
+	Unsubscribe(ctx context.Context, token string) (model.UnsubscribeScope, error)
+	ListEmailSuppressions(ctx context.Context) ([]model.EmailSuppression, error)
+	DeleteEmailSuppression(ctx context.Context, email string) error
+	SendOwnerMail(ctx context.Context, mail OwnerMail) error
 }
 
 type Repository interface {
@@ -47,6 +54,8 @@ type Repository interface {
 	UpdateCommentStatus(ctx context.Context, id string, status model.Status) error
 	UpdateCommentHot(ctx context.Context, id string, hot bool) error
 	DeleteComment(ctx context.Context, id string) error
+	GetWebmention(ctx context.Context, echoID, source string) (model.Comment, error)
+	UpdateWebmention(ctx context.Context, id, nickname, content string, status model.Status) error
 	BatchUpdateStatus(ctx context.Context, ids []string, status model.Status) error
 	BatchDelete(ctx context.Context, ids []string) error
 	CountByIPWithin(ctx context.Context, ipHash string, seconds int64) (int64, error)
@@ -57,6 +66,16 @@ type Repository interface {
 		echoID, content, email, ipHash, userID string,
 		seconds int64,
 	) (bool, error)
+	GetCommentSubscription(ctx context.Context, echoID, email string) (model.CommentSubscription, error)
+	SaveCommentSubscription(ctx context.Context, sub *model.CommentSubscription) error
+	ConfirmCommentSubscription(ctx context.Context, id string, confirmedAt int64) error
+	ListActiveSubscriberEmails(ctx context.Context, echoID string) ([]string, error)
+	DeleteCommentSubscription(ctx context.Context, echoID, email string) error
+	DeleteCommentSubscriptionsByEmail(ctx context.Context, email string) error
+	IsEmailSuppressed(ctx context.Context, email string) (bool, error)
+	AddEmailSuppression(ctx context.Context, email string) error
+	ListEmailSuppressions(ctx context.Context) ([]model.EmailSuppression, error)
+	DeleteEmailSuppression(ctx context.Context, email string) error
 }
 
 type CommonService = commonService.Service
@@ -71,6 +90,17 @@ type MailMessage struct {
 	Subject  string
 	TextBody string
 	HTMLBody string
+	// UnsubscribeURL 非空时作为 RFC 8058 一键退订地址写入 List-Unsubscribe 头。
+	UnsubscribeURL string
+}
+
+// OwnerMail 是其他模块借评论系统的 SMTP 设置发给 owner 的邮件（如定期摘要）。
+// InnerHTML 是外框内的正文片段，由调用方负责转义；外框、页脚与退订链接由评论系统统一补上。
+type OwnerMail struct {
+	Heading   string
+	Subject   string
+	TextBody  string
+	InnerHTML string
 }
 
 type MailerConfig struct {
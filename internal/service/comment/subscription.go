@@ -0,0 +1,276 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	model "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+const (
+	subscribeConfirmTTL           = 48 * time.Hour
+	subscribeResendSec      int64 = 3600
+	subscriptionTokenDomain       = "comment-subscription:"
+
+	tokenActionConfirm     = "confirm"
+	tokenActionUnsubscribe = "unsubscribe"
+)
+
+// errRecipientSuppressed 表示收件人在退订名单中，发信被跳过。
+var errRecipientSuppressed = errors.New("recipient has unsubscribed")
+
+// subscriptionToken 是确认 / 退订链接里携带的签名载荷。
+// EchoID 为空的退订 token 表示退订全部邮件；退订 token 不过期，旧邮件里的链接始终可用。
+type subscriptionToken struct {
+	Action string `json:"a"`
+	EchoID string `json:"e,omitempty"`
+	Email  string `json:"m"`
+	Exp    int64  `json:"x,omitempty"`
+}
+
+func (s *CommentService) signSubscriptionToken(t subscriptionToken) string {
+	raw, _ := json.Marshal(t)
+	payload := base64.RawURLEncoding.EncodeToString(raw)
+	return payload + "." + s.computeHMAC(subscriptionTokenDomain+payload)
+}
+
+func (s *CommentService) parseSubscriptionToken(token, action string) (subscriptionToken, error) {
+	invalid := commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "链接无效或已过期")
+	payload, sig, ok := strings.Cut(strings.TrimSpace(token), ".")
+	if !ok || payload == "" {
+		return subscriptionToken{}, invalid
+	}
+	if !hmac.Equal([]byte(sig), []byte(s.computeHMAC(subscriptionTokenDomain+payload))) {
+		return subscriptionToken{}, invalid
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return subscriptionToken{}, invalid
+	}
+	var t subscriptionToken
+	if err := json.Unmarshal(raw, &t); err != nil || t.Action != action || t.Email == "" {
+		return subscriptionToken{}, invalid
+	}
+	if t.Exp > 0 && time.Now().Unix() > t.Exp {
+		return subscriptionToken{}, invalid
+	}
+	return t, nil
+}
+
+// unsubscribeURL 生成一键退订地址；echoID 为空时退订全部邮件。站点地址或邮箱缺失时返回空串。
+func (s *CommentService) unsubscribeURL(serverURL, echoID, email string) string {
+	email = normalizeEmail(email)
+	if serverURL == "" || email == "" {
+		return ""
+	}
+	token := s.signSubscriptionToken(subscriptionToken{
+		Action: tokenActionUnsubscribe,
+		EchoID: echoID,
+		Email:  email,
+	})
+	return serverURL + "/api/comments/unsubscribe?token=" + url.QueryEscape(token)
+}
+
+// requestSubscription 处理评论表单里的「订阅后续评论」：登记待确认订阅并发送确认邮件（double opt-in）。
+// 邮件通知未开启、地址已退订、已订阅或一小时内刚发过确认邮件时跳过。best-effort，不影响评论提交。
+func (s *CommentService) requestSubscription(ctx context.Context, comment model.Comment) {
+	setting, err := s.getSystemSettingRaw(ctx)
+	if err != nil || !setting.EmailNotify.Enabled {
+		return
+	}
+	email, ok := parseValidEmail(comment.Email)
+	if !ok {
+		return
+	}
+	email = normalizeEmail(email)
+	if suppressed, err := s.repo.IsEmailSuppressed(ctx, email); err != nil || suppressed {
+		return
+	}
+	now := time.Now().UTC().Unix()
+	if existing, err := s.repo.GetCommentSubscription(ctx, comment.EchoID, email); err == nil && existing.ID != "" {
+		if existing.Status == model.SubscriptionActive || now-existing.CreatedAt < subscribeResendSec {
+			return
+		}
+	}
+	serverURL := s.resolveServerURL(ctx)
+	if serverURL == "" {
+		logUtil.GetLogger().Warn("skip comment subscription: server url is not configured",
+			slog.String("echo_id", comment.EchoID))
+		return
+	}
+	if err := s.repo.SaveCommentSubscription(ctx, &model.CommentSubscription{
+		EchoID:    comment.EchoID,
+		Email:     email,
+		Status:    model.SubscriptionPending,
+		CreatedAt: now,
+	}); err != nil {
+		logUtil.GetLogger().Warn("save comment subscription failed",
+			logUtil.Err(err), slog.String("echo_id", comment.EchoID))
+		return
+	}
+
+	token := s.signSubscriptionToken(subscriptionToken{
+		Action: tokenActionConfirm,
+		EchoID: comment.EchoID,
+		Email:  email,
+		Exp:    time.Now().Add(subscribeConfirmTTL).Unix(),
+	})
+	confirmURL := serverURL + "/api/comments/subscription/confirm?token=" + url.QueryEscape(token)
+	unsubscribeURL := s.unsubscribeURL(serverURL, "", email)
+	content := buildSubscribeConfirmContent(buildEchoLink(serverURL, comment.EchoID), confirmURL, unsubscribeURL)
+	go func(cfg model.EmailNotifySetting, msg MailMessage) {
+		notifyCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := s.sendOwnerMail(notifyCtx, cfg, msg); err != nil && !errors.Is(err, errRecipientSuppressed) {
+			logUtil.GetLogger().Warn("comment subscription confirm mail failed",
+				logUtil.Err(err), slog.String("echo_id", comment.EchoID))
+		}
+	}(setting.EmailNotify, MailMessage{
+		To:             email,
+		Subject:        content.Subject,
+		TextBody:       content.TextBody,
+		HTMLBody:       content.HTMLBody,
+		UnsubscribeURL: unsubscribeURL,
+	})
+}
+
+// notifySubscribersAsync 在评论公开可见后通知该 Echo 的订阅者。
+// 评论者本人、被回复者（已由回复通知覆盖）与 owner（已由「有新评论」覆盖）不重复通知。
+func (s *CommentService) notifySubscribersAsync(ctx context.Context, comment model.Comment) {
+	if comment.Status != model.StatusApproved {
+		return
+	}
+	setting, err := s.getSystemSettingRaw(ctx)
+	if err != nil || !setting.EmailNotify.Enabled {
+		return
+	}
+	subscribers, err := s.repo.ListActiveSubscriberEmails(ctx, comment.EchoID)
+	if err != nil || len(subscribers) == 0 {
+		return
+	}
+
+	skip := map[string]bool{normalizeEmail(comment.Email): true}
+	if ownerEmail, ownerErr := s.resolveOwnerEmail(); ownerErr == nil {
+		skip[normalizeEmail(ownerEmail)] = true
+	}
+	if parentID := derefString(comment.ParentID); parentID != "" {
+		if parent, parentErr := s.repo.GetCommentByID(ctx, parentID); parentErr == nil {
+			skip[normalizeEmail(parent.Email)] = true
+		}
+	}
+
+	serverURL := s.resolveServerURL(ctx)
+	// 订阅者彼此陌生，邮件里只展示打码后的评论者邮箱。
+	masked := comment
+	masked.Email = maskEmail(comment.Email)
+	messages := make([]MailMessage, 0, len(subscribers))
+	for _, email := range subscribers {
+		if skip[email] {
+			continue
+		}
+		unsubscribeURL := s.unsubscribeURL(serverURL, comment.EchoID, email)
+		content := buildNotifyContent("thread", masked, serverURL, unsubscribeURL)
+		messages = append(messages, MailMessage{
+			To:             email,
+			Subject:        content.Subject,
+			TextBody:       content.TextBody,
+			HTMLBody:       content.HTMLBody,
+			UnsubscribeURL: unsubscribeURL,
+		})
+	}
+	if len(messages) == 0 {
+		return
+	}
+	go func(cfg model.EmailNotifySetting, messages []MailMessage) {
+		for _, msg := range messages {
+			notifyCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			err := s.sendOwnerMail(notifyCtx, cfg, msg)
+			cancel()
+			if err != nil && !errors.Is(err, errRecipientSuppressed) {
+				logUtil.GetLogger().Warn("comment thread notify mail failed",
+					logUtil.Err(err), slog.String("comment_id", comment.ID))
+			}
+		}
+	}(setting.EmailNotify, messages)
+}
+
+func (s *CommentService) ConfirmSubscription(ctx context.Context, token string) error {
+	t, err := s.parseSubscriptionToken(token, tokenActionConfirm)
+	if err != nil {
+		return err
+	}
+	sub, err := s.repo.GetCommentSubscription(ctx, t.EchoID, t.Email)
+	if err != nil || sub.ID == "" {
+		return commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "订阅不存在或已取消")
+	}
+	if sub.Status == model.SubscriptionActive {
+		return nil
+	}
+	return s.repo.ConfirmCommentSubscription(ctx, sub.ID, time.Now().UTC().Unix())
+}
+
+// Unsubscribe 执行退订链接：带 EchoID 的只取消该 Echo 的订阅，否则把地址加入退订名单并清空其全部订阅。
+func (s *CommentService) Unsubscribe(ctx context.Context, token string) (model.UnsubscribeScope, error) {
+	t, err := s.parseSubscriptionToken(token, tokenActionUnsubscribe)
+	if err != nil {
+		return "", err
+	}
+	if t.EchoID != "" {
+		if err := s.repo.DeleteCommentSubscription(ctx, t.EchoID, t.Email); err != nil {
+			return "", err
+		}
+		return model.UnsubscribeThread, nil
+	}
+	if err := s.repo.AddEmailSuppression(ctx, t.Email); err != nil {
+		return "", err
+	}
+	if err := s.repo.DeleteCommentSubscriptionsByEmail(ctx, t.Email); err != nil {
+		return "", err
+	}
+	return model.UnsubscribeAll, nil
+}
+
+func (s *CommentService) ListEmailSuppressions(ctx context.Context) ([]model.EmailSuppression, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return s.repo.ListEmailSuppressions(ctx)
+}
+
+func (s *CommentService) DeleteEmailSuppression(ctx context.Context, email string) error {
+	if err := s.requireAdmin(ctx); err != nil {
+		return err
+	}
+	email = normalizeEmail(email)
+	if email == "" {
+		return commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "邮箱不能为空")
+	}
+	return s.repo.DeleteEmailSuppression(ctx, email)
+}
+
+func normalizeEmail(raw string) string {
+	return strings.ToLower(strings.TrimSpace(raw))
+}
+
+// maskEmail 保留首字符与域名，例如 alice@example.com -> a***@example.com。
+func maskEmail(raw string) string {
+	local, domain, ok := strings.Cut(strings.TrimSpace(raw), "@")
+	if !ok || local == "" {
+		return ""
+	}
+	first, _ := utf8.DecodeRuneInString(local)
+	return string(first) + "***@" + domain
+}
@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	model "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+const maxWebmentionNicknameRunes = 100
+
+// SaveWebmention 落库一条已校验来源的 Webmention，走与普通评论相同的审核状态。
+// 同一来源重复投递且昵称或内容有变时覆盖原记录；开启审核时改动过的内容须重新审核，
+// 状态回到 pending 并再次提醒站长，避免已通过的提及被对方改成未经审核的内容。
+// 调用方（Webmention 接收端）在后台执行，不带 viewer。
+func (s *CommentService) SaveWebmention(
+	ctx context.Context,
+	mention model.Webmention,
+) (model.CreateCommentResult, error) {
+	setting, err := s.GetSystemSetting(ctx)
+	if err != nil {
+		return model.CreateCommentResult{}, err
+	}
+	if !setting.EnableComment {
+		return model.CreateCommentResult{},
+			commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "评论功能未启用")
+	}
+
+	echoID := strings.TrimSpace(mention.EchoID)
+	source := strings.TrimSpace(mention.Source)
+	if echoID == "" || source == "" {
+		return model.CreateCommentResult{},
+			commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "Webmention 缺少目标或来源")
+	}
+	nickname := webmentionNickname(mention.Author, source)
+	content := truncateRunes(strings.Join(strings.Fields(mention.Content), " "), maxCommentRunes)
+	if content == "" {
+		content = source
+	}
+
+	if existing, err := s.repo.GetWebmention(ctx, echoID, source); err == nil && existing.ID != "" {
+		if existing.Nickname == nickname && existing.Content == content {
+			return model.CreateCommentResult{ID: existing.ID, Status: existing.Status}, nil
+		}
+		return s.updateWebmention(ctx, setting, existing, nickname, content)
+	}
+
+	comment := model.Comment{
+		EchoID:   echoID,
+		Nickname: nickname,
+		Website:  source,
+		Content:  content,
+		Status:   model.StatusPending,
+		Source:   model.SourceWebmention,
+	}
+	if !setting.RequireApproval {
+		comment.Status = model.StatusApproved
+	}
+	if err := s.repo.CreateComment(ctx, &comment); err != nil {
+		return model.CreateCommentResult{}, err
+	}
+
+	logUtil.GetLogger().Info("webmention received",
+		slog.String("comment_id", comment.ID),
+		slog.String("echo_id", comment.EchoID),
+		slog.String("source_url", source),
+		slog.String("status", string(comment.Status)),
+	)
+
+	s.emitCommentCreated(ctx, comment)
+	s.notifyOwnerAsync(ctx, "created", comment)
+	s.notifySubscribersAsync(ctx, comment)
+	return model.CreateCommentResult{
+		ID:     comment.ID,
+		Status: comment.Status,
+	}, nil
+}
+
+// updateWebmention 覆盖已有提及的昵称与内容。开启审核时状态重置为 pending，
+// 按新评论再提醒站长；状态因此变化时同步发出状态变更事件，让公开列表等下游撤下旧内容。
+func (s *CommentService) updateWebmention(
+	ctx context.Context,
+	setting model.SystemSetting,
+	existing model.Comment,
+	nickname, content string,
+) (model.CreateCommentResult, error) {
+	updated := existing
+	updated.Nickname = nickname
+	updated.Content = content
+	if setting.RequireApproval {
+		updated.Status = model.StatusPending
+	}
+	if err := s.repo.UpdateWebmention(ctx, updated.ID, nickname, content, updated.Status); err != nil {
+		return model.CreateCommentResult{}, err
+	}
+
+	logUtil.GetLogger().Info("webmention updated",
+		slog.String("comment_id", updated.ID),
+		slog.String("echo_id", updated.EchoID),
+		slog.String("source_url", updated.Website),
+		slog.String("status", string(updated.Status)),
+	)
+
+	if updated.Status != existing.Status {
+		s.emitCommentStatusUpdated(ctx, updated)
+	}
+	if setting.RequireApproval {
+		s.notifyOwnerAsync(ctx, "created", updated)
+	}
+	return model.CreateCommentResult{ID: updated.ID, Status: updated.Status}, nil
+}
+
+// DeleteWebmention 删除来源已下线或不再链接目标的 Webmention；不存在时视为成功。
+func (s *CommentService) DeleteWebmention(ctx context.Context, echoID, source string) error {
+	existing, err := s.repo.GetWebmention(ctx, strings.TrimSpace(echoID), strings.TrimSpace(source))
+	if err != nil || existing.ID == "" {
+		return nil
+	}
+	if err := s.repo.DeleteComment(ctx, existing.ID); err != nil {
+		return err
+	}
+	logUtil.GetLogger().Info("webmention deleted",
+		slog.String("comment_id", existing.ID),
+		slog.String("echo_id", existing.EchoID),
+		slog.String("source_url", existing.Website),
+	)
+	s.emitCommentDeleted(ctx, existing)
+	return nil
+}
+
+// webmentionNickname 优先取来源页 h-card 作者名，缺省时退回来源站点的主机名。
+func webmentionNickname(author, source string) string {
+	nickname := strings.Join(strings.Fields(author), " ")
+	if nickname == "" {
+		if u, err := url.Parse(source); err == nil && u.Hostname() != "" {
+			nickname = u.Hostname()
+		} else {
+			nickname = "Webmention"
+		}
+	}
+	return truncateRunes(nickname, maxWebmentionNicknameRunes)
+}
+
+func truncateRunes(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit-1]) + "…"
+}
@@ -0,0 +1,498 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/agent"
+	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"github.com/lin-snow/ech0/pkg/viewer"
+)
+
+// 写操作工具（create_draft_echo / tag_echos / moderate_comments）全部以 agent.Tool.Confirm 声明：
+// 模型只能「提议」，Loop 上浮 AgentPendingAction 后由 AskStream 以 SSE pending_action 推给前端，
+// 用户经 DecideAction 确认才真正执行；每次执行（无论成败）都落一条审计记录。
+
+// actionConfirmTimeout 是写操作等待用户确认的上限（同样受整轮 Agent 超时约束）。
+const actionConfirmTimeout = 90 * time.Second
+
+// maxActionTargets 是单次批量写操作（打标签 / 审核评论）涉及条目数的上限。
+const maxActionTargets = 50
+
+// maxActionLog 是持久化审计记录的保留条数（超出取最近 N 条）。
+const maxActionLog = 200
+
+// 审计记录的执行结果。
+const (
+	ActionSucceeded = "succeeded"
+	ActionFailed    = "failed"
+)
+
+// ActionRecord 是一次经用户确认后执行的写操作的审计记录。
+type ActionRecord struct {
+	ID         string          `json:"id"`
+	UserID     string          `json:"user_id"`
+	Tool       string          `json:"tool"`
+	Args       json.RawMessage `json:"args"`
+	Summary    string          `json:"summary"`
+	Status     string          `json:"status"`
+	Result     string          `json:"result,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	ExecutedAt int64           `json:"executed_at"`
+}
+
+// PendingAction 是推给前端的待确认写操作（SSE pending_action 载荷）。
+type PendingAction struct {
+	ID        string          `json:"id"`
+	Tool      string          `json:"tool"`
+	Args      json.RawMessage `json:"args"`
+	Summary   string          `json:"summary"`
+	ExpiresAt int64           `json:"expires_at"`
+}
+
+// actionResult 是写操作执行完的 Meta，AskStream 据此发 SSE action_result。
+type actionResult struct {
+	ID      string `json:"id"`
+	Tool    string `json:"tool"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// pendingAction 是登记在服务内、等待用户决定的写操作。
+type pendingAction struct {
+	userID   string
+	approval *agent.Approval
+}
+
+// registerPending 登记一条待确认写操作，分配的 ID 同时写回 approval.ID。
+func (s *CopilotService) registerPending(userID string, approval *agent.Approval) string {
+	approval.ID = uuidUtil.MustNewV7()
+	s.pendingMu.Lock()
+	defer s.pendingMu.Unlock()
+	if s.pending == nil {
+		s.pending = make(map[string]pendingAction)
+	}
+	s.pending[approval.ID] = pendingAction{userID: userID, approval: approval}
+	return approval.ID
+}
+
+// dropPending 撤销本轮遗留的待确认操作（流结束或断开时），按拒绝处理。
+func (s *CopilotService) dropPending(ids []string) {
+	s.pendingMu.Lock()
+	defer s.pendingMu.Unlock()
+	for _, id := range ids {
+		if p, ok := s.pending[id]; ok {
+			p.approval.Decide(false)
+			delete(s.pending, id)
+		}
+	}
+}
+
+// DecideAction 提交用户对一条待确认写操作的决定。只有发起该轮对话的用户本人能决定；
+// 操作已执行、已拒绝或已超时都视为不存在。
+func (s *CopilotService) DecideAction(ctx context.Context, id string, approve bool) error {
+	userID := viewer.MustFromContext(ctx).UserID()
+	s.pendingMu.Lock()
+	p, ok := s.pending[id]
+	if ok && p.userID == userID {
+		delete(s.pending, id)
+	}
+	s.pendingMu.Unlock()
+	if !ok || p.userID != userID {
+		return errors.New(commonModel.CHAT_ACTION_NOT_FOUND)
+	}
+	p.approval.Decide(approve)
+	return nil
+}
+
+// ListActions 返回写操作审计记录（最新在前）。
+func (s *CopilotService) ListActions(ctx context.Context) ([]ActionRecord, error) {
+	records := s.loadActionLog(ctx)
+	out := make([]ActionRecord, 0, len(records))
+	for i := len(records) - 1; i >= 0; i-- {
+		out = append(out, records[i])
+	}
+	return out, nil
+}
+
+func (s *CopilotService) loadActionLog(ctx context.Context) []ActionRecord {
+	raw, err := s.durableKV.Get(ctx, commonModel.CopilotActionLogKey)
+	if err != nil {
+		return nil
+	}
+	var records []ActionRecord
+	if err := json.Unmarshal([]byte(raw), &records); err != nil {
+		return nil
+	}
+	return records
+}
+
+// recordAction 写审计：结构化日志 + 持久化记录（封顶最近 maxActionLog 条）。
+// 持久化失败只告警——操作已经执行，不能因审计落盘失败而报错误导用户重试。
+func (s *CopilotService) recordAction(ctx context.Context, rec ActionRecord) {
+	logUtil.GetLogger().Info("copilot audit",
+		slog.String("module", "copilot"),
+		slog.String("action_id", rec.ID),
+		slog.String("user_id", rec.UserID),
+		slog.String("tool", rec.Tool),
+		slog.String("result", rec.Status),
+		slog.String("error", rec.Error))
+
+	s.actionLogMu.Lock()
+	defer s.actionLogMu.Unlock()
+	records := append(s.loadActionLog(ctx), rec)
+	if len(records) > maxActionLog {
+		records = records[len(records)-maxActionLog:]
+	}
+	payload, err := json.Marshal(records)
+	if err != nil {
+		return
+	}
+	if err := s.durableKV.Set(ctx, commonModel.CopilotActionLogKey, string(payload)); err != nil {
+		logUtil.GetLogger().Warn("failed to persist copilot action log",
+			slog.String("module", "copilot"), logUtil.Err(err))
+	}
+}
+
+// audited 包装写操作的执行体：执行后按结果落审计，并把结果作为 actionResult Meta 上浮。
+func (s *CopilotService) audited(name, locale string, user chatUser, exec func(ctx context.Context, args json.RawMessage) (string, error)) func(ctx context.Context, args json.RawMessage) (agent.ToolOutput, error) {
+	return func(ctx context.Context, args json.RawMessage) (agent.ToolOutput, error) {
+		var id string
+		if approval := agent.ApprovalFromContext(ctx); approval != nil {
+			id = approval.ID
+		}
+		rec := ActionRecord{
+			ID:         id,
+			UserID:     user.ID,
+			Tool:       name,
+			Args:       args,
+			Summary:    describeAction(name, args, locale),
+			ExecutedAt: time.Now().UTC().Unix(),
+		}
+		result, err := exec(ctx, args)
+		if err != nil {
+			rec.Status, rec.Error = ActionFailed, err.Error()
+			s.recordAction(context.WithoutCancel(ctx), rec)
+			return agent.ToolOutput{}, err
+		}
+		rec.Status, rec.Result = ActionSucceeded, result
+		s.recordAction(context.WithoutCancel(ctx), rec)
+		return agent.ToolOutput{
+			Content: result,
+			Meta:    actionResult{ID: id, Tool: name, Status: ActionSucceeded, Message: result},
+		}, nil
+	}
+}
+
+// actionTools 返回注入 Chat 的写操作工具。
+func (s *CopilotService) actionTools(locale string, user chatUser) []agent.Tool {
+	return []agent.Tool{
+		s.createDraftTool(locale, user),
+		s.tagEchosTool(locale, user),
+		s.moderateCommentsTool(locale, user),
+	}
+}
+
+// draftArgs 是 create_draft_echo 的入参。
+type draftArgs struct {
+	Content string   `json:"content"`
+	Tags    []string `json:"tags"`
+}
+
+// createDraftTool 把模型起草的内容保存为一条私密 Echo（Ech0 没有独立的草稿状态，私密即仅本人可见，
+// 用户满意后在面板里改为公开即可发布）。
+func (s *CopilotService) createDraftTool(locale string, user chatUser) agent.Tool {
+	const name = "create_draft_echo"
+	return agent.Tool{
+		Def: agent.ToolDef{
+			Name:        name,
+			Description: "把一段内容保存为草稿 Echo（私密，仅用户本人可见，之后可在面板中公开发布）。这是写操作：调用后会先请用户确认，用户确认后才会真正创建。只在用户明确要求起草/保存时使用。",
+			Parameters:  json.RawMessage(`{"type":"object","properties":{"content":{"type":"string","description":"Echo 正文（Markdown）"},"tags":{"type":"array","items":{"type":"string"},"description":"可选，标签名；不存在的标签会自动创建"}},"required":["content"]}`),
+		},
+		Confirm: true,
+		Execute: s.audited(name, locale, user, func(ctx context.Context, args json.RawMessage) (string, error) {
+			var a draftArgs
+			if err := json.Unmarshal(args, &a); err != nil || strings.TrimSpace(a.Content) == "" {
+				return "", errors.New("create_draft_echo 需要非空的 content")
+			}
+			echo := &echoModel.Echo{
+				Content: a.Content,
+				Private: true,
+				Tags:    tagsFromNames(a.Tags),
+			}
+			if err := s.echoService.PostEcho(ctx, echo); err != nil {
+				return "", err
+			}
+			if localeIsZH(locale) {
+				return fmt.Sprintf("已创建草稿 Echo（私密），ID：%s", echo.ID), nil
+			}
+			return fmt.Sprintf("Draft Echo created (private), ID: %s", echo.ID), nil
+		}),
+	}
+}
+
+// tagArgs 是 tag_echos 的入参：对 echo_ids 中每条 Echo 添加 add、移除 remove 中的标签。
+type tagArgs struct {
+	EchoIDs []string `json:"echo_ids"`
+	Add     []string `json:"add"`
+	Remove  []string `json:"remove"`
+}
+
+// tagEchosTool 批量增删标签。只作用于当前用户本人的 Echo，与检索的作者收口一致。
+func (s *CopilotService) tagEchosTool(locale string, user chatUser) agent.Tool {
+	const name = "tag_echos"
+	return agent.Tool{
+		Def: agent.ToolDef{
+			Name:        name,
+			Description: "为一组 Echo 批量添加和/或移除标签。echo_ids 来自之前检索结果中的 ID。这是写操作：调用后会先请用户确认，用户确认后才会真正修改。",
+			Parameters:  json.RawMessage(`{"type":"object","properties":{"echo_ids":{"type":"array","items":{"type":"string"},"description":"要修改的 Echo ID（最多 50 条）"},"add":{"type":"array","items":{"type":"string"},"description":"要添加的标签名"},"remove":{"type":"array","items":{"type":"string"},"description":"要移除的标签名"}},"required":["echo_ids"]}`),
+		},
+		Confirm: true,
+		Execute: s.audited(name, locale, user, func(ctx context.Context, args json.RawMessage) (string, error) {
+			var a tagArgs
+			if err := json.Unmarshal(args, &a); err != nil {
+				return "", errors.New("tag_echos 参数无效")
+			}
+			ids := dedupeNonEmpty(a.EchoIDs)
+			if len(ids) == 0 || len(ids) > maxActionTargets {
+				return "", fmt.Errorf("tag_echos 需要 1~%d 个 echo_ids", maxActionTargets)
+			}
+			if len(dedupeNonEmpty(a.Add)) == 0 && len(dedupeNonEmpty(a.Remove)) == 0 {
+				return "", errors.New("tag_echos 需要 add 或 remove 至少其一")
+			}
+
+			var updated int
+			var failures []string
+			for _, id := range ids {
+				if err := s.retagEcho(ctx, user, id, a.Add, a.Remove); err != nil {
+					failures = append(failures, id+": "+err.Error())
+					continue
+				}
+				updated++
+			}
+			if updated == 0 {
+				return "", errors.New(strings.Join(failures, "; "))
+			}
+			var b strings.Builder
+			if localeIsZH(locale) {
+				fmt.Fprintf(&b, "已更新 %d 条 Echo 的标签", updated)
+				if len(failures) > 0 {
+					fmt.Fprintf(&b, "；%d 条失败：%s", len(failures), strings.Join(failures, "; "))
+				}
+			} else {
+				fmt.Fprintf(&b, "Updated tags on %d Echos", updated)
+				if len(failures) > 0 {
+					fmt.Fprintf(&b, "; %d failed: %s", len(failures), strings.Join(failures, "; "))
+				}
+			}
+			return b.String(), nil
+		}),
+	}
+}
+
+// retagEcho 读取整条 Echo、改写标签后整体回写（UpdateEcho 是全量替换语义）。
+func (s *CopilotService) retagEcho(ctx context.Context, user chatUser, id string, add, remove []string) error {
+	echo, err := s.echoService.GetEchoById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if echo == nil || echo.UserID != user.ID {
+		return errors.New(commonModel.ECHO_NOT_FOUND)
+	}
+	removeSet := make(map[string]bool, len(remove))
+	for _, n := range remove {
+		removeSet[strings.ToLower(strings.TrimSpace(n))] = true
+	}
+	names := make([]string, 0, len(echo.Tags)+len(add))
+	for _, t := range echo.Tags {
+		if !removeSet[strings.ToLower(t.Name)] {
+			names = append(names, t.Name)
+		}
+	}
+	names = append(names, add...)
+	echo.Tags = tagsFromNames(names)
+	return s.echoService.UpdateEcho(ctx, echo)
+}
+
+// moderateArgs 是 moderate_comments 的入参：approve / reject 分别列出要通过 / 拒绝的评论 ID。
+type moderateArgs struct {
+	Approve []string `json:"approve"`
+	Reject  []string `json:"reject"`
+}
+
+// moderateCommentsTool 审核待审评论（通过 / 拒绝）。评论服务自身要求管理员身份。
+func (s *CopilotService) moderateCommentsTool(locale string, user chatUser) agent.Tool {
+	const name = "moderate_comments"
+	return agent.Tool{
+		Def: agent.ToolDef{
+			Name:        name,
+			Description: "审核评论：通过或拒绝指定 ID 的评论。可先用 list_pending_comments 查看待审评论。这是写操作：调用后会先请用户确认，用户确认后才会真正生效。",
+			Parameters:  json.RawMessage(`{"type":"object","properties":{"approve":{"type":"array","items":{"type":"string"},"description":"要通过的评论 ID"},"reject":{"type":"array","items":{"type":"string"},"description":"要拒绝的评论 ID"}}}`),
+		},
+		Confirm: true,
+		Execute: s.audited(name, locale, user, func(ctx context.Context, args json.RawMessage) (string, error) {
+			var a moderateArgs
+			if err := json.Unmarshal(args, &a); err != nil {
+				return "", errors.New("moderate_comments 参数无效")
+			}
+			approve, reject := dedupeNonEmpty(a.Approve), dedupeNonEmpty(a.Reject)
+			if n := len(approve) + len(reject); n == 0 || n > maxActionTargets {
+				return "", fmt.Errorf("moderate_comments 需要 1~%d 个评论 ID", maxActionTargets)
+			}
+			if len(approve) > 0 {
+				if err := s.commentService.BatchAction(ctx, "approve", approve); err != nil {
+					return "", err
+				}
+			}
+			if len(reject) > 0 {
+				if err := s.commentService.BatchAction(ctx, "reject", reject); err != nil {
+					return "", err
+				}
+			}
+			if localeIsZH(locale) {
+				return fmt.Sprintf("已通过 %d 条、拒绝 %d 条评论", len(approve), len(reject)), nil
+			}
+			return fmt.Sprintf("Approved %d and rejected %d comments", len(approve), len(reject)), nil
+		}),
+	}
+}
+
+// listPendingCommentsTool 是 moderate_comments 的只读配套：列出待审评论供模型给出审核建议。
+func (s *CopilotService) listPendingCommentsTool(loc *time.Location) agent.Tool {
+	return agent.Tool{
+		Def: agent.ToolDef{
+			Name:        "list_pending_comments",
+			Description: "列出等待审核的评论（ID、所在 Echo、昵称、时间、内容），用于给出审核建议或配合 moderate_comments 使用。",
+			Parameters:  json.RawMessage(`{"type":"object","properties":{"limit":{"type":"integer","description":"可选，返回条数（1~50），默认 20"}}}`),
+		},
+		Execute: func(ctx context.Context, args json.RawMessage) (agent.ToolOutput, error) {
+			var a struct {
+				Limit int `json:"limit"`
+			}
+			_ = json.Unmarshal(args, &a)
+			if a.Limit <= 0 || a.Limit > maxActionTargets {
+				a.Limit = 20
+			}
+			page, err := s.commentService.ListPanelComments(ctx, commentModel.ListCommentQuery{
+				Page:     1,
+				PageSize: a.Limit,
+				Status:   string(commentModel.StatusPending),
+			})
+			if err != nil {
+				return agent.ToolOutput{}, err
+			}
+			return agent.ToolOutput{Content: formatPendingComments(page.Items, page.Total, loc)}, nil
+		},
+	}
+}
+
+// formatPendingComments 把待审评论拼成回喂模型的文本。不含邮箱等联系方式。
+func formatPendingComments(items []commentModel.Comment, total int64, loc *time.Location) string {
+	if len(items) == 0 {
+		return "（没有待审核的评论）"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "待审核评论共 %d 条，以下 %d 条：\n", total, len(items))
+	for _, c := range items {
+		day := time.Unix(c.CreatedAt, 0).In(loc).Format("2006-01-02 15:04")
+		fmt.Fprintf(&b, "- id=%s echo=%s (%s) %s：%s\n", c.ID, c.EchoID, day, c.Nickname, strings.TrimSpace(c.Content))
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// describeAction 把写操作渲染成一句人读描述，供确认卡片与审计记录展示。
+func describeAction(name string, args json.RawMessage, locale string) string {
+	zh := localeIsZH(locale)
+	switch name {
+	case "create_draft_echo":
+		var a draftArgs
+		_ = json.Unmarshal(args, &a)
+		excerpt := truncateRunes(strings.Join(strings.Fields(a.Content), " "), 80)
+		if zh {
+			return fmt.Sprintf("创建私密草稿：「%s」%s", excerpt, tagSuffix(a.Tags))
+		}
+		return fmt.Sprintf("Create a private draft: \"%s\"%s", excerpt, tagSuffix(a.Tags))
+	case "tag_echos":
+		var a tagArgs
+		_ = json.Unmarshal(args, &a)
+		add, remove := strings.Join(dedupeNonEmpty(a.Add), ", "), strings.Join(dedupeNonEmpty(a.Remove), ", ")
+		if zh {
+			return fmt.Sprintf("修改 %d 条 Echo 的标签（添加：%s；移除：%s）", len(dedupeNonEmpty(a.EchoIDs)), orDash(add), orDash(remove))
+		}
+		return fmt.Sprintf("Retag %d Echos (add: %s; remove: %s)", len(dedupeNonEmpty(a.EchoIDs)), orDash(add), orDash(remove))
+	case "moderate_comments":
+		var a moderateArgs
+		_ = json.Unmarshal(args, &a)
+		if zh {
+			return fmt.Sprintf("通过 %d 条、拒绝 %d 条评论", len(dedupeNonEmpty(a.Approve)), len(dedupeNonEmpty(a.Reject)))
+		}
+		return fmt.Sprintf("Approve %d and reject %d comments", len(dedupeNonEmpty(a.Approve)), len(dedupeNonEmpty(a.Reject)))
+	}
+	return name
+}
+
+func tagSuffix(tags []string) string {
+	names := dedupeNonEmpty(tags)
+	if len(names) == 0 {
+		return ""
+	}
+	return " #" + strings.Join(names, " #")
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// tagsFromNames 把标签名转成待 ProcessEchoTags 解析的 Tag（按名大小写不敏感去重）。
+func tagsFromNames(names []string) []echoModel.Tag {
+	seen := make(map[string]bool, len(names))
+	tags := make([]echoModel.Tag, 0, len(names))
+	for _, n := range names {
+		n = strings.TrimSpace(n)
+		if n == "" || seen[strings.ToLower(n)] {
+			continue
+		}
+		seen[strings.ToLower(n)] = true
+		tags = append(tags, echoModel.Tag{Name: n})
+	}
+	return tags
+}
+
+// truncateRunes 按 rune 截断到 n 个字符，超出时补省略号。
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n]) + "…"
+}
+
+// dedupeNonEmpty 去掉空白项与重复项，保持原序。
+func dedupeNonEmpty(in []string) []string {
+	seen := make(map[string]bool, len(in))
+	out := make([]string, 0, len(in))
+	for _, v := range in {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		out = append(out, v)
+	}
+	return out
+}
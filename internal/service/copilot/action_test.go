@@ -0,0 +1,242 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/agent"
+	"github.com/lin-snow/ech0/internal/kvstore"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	"github.com/lin-snow/ech0/pkg/viewer"
+)
+
+// writableEchoSvc 在 stubEchoSvc 之上记录 PostEcho / UpdateEcho 的写入。
+type writableEchoSvc struct {
+	stubEchoSvc
+	posted  []*echoModel.Echo
+	updated []*echoModel.Echo
+}
+
+func (f *writableEchoSvc) PostEcho(_ context.Context, echo *echoModel.Echo) error {
+	echo.ID = "new-echo"
+	f.posted = append(f.posted, echo)
+	return nil
+}
+
+func (f *writableEchoSvc) UpdateEcho(_ context.Context, echo *echoModel.Echo) error {
+	f.updated = append(f.updated, echo)
+	return nil
+}
+
+// stubCommentSvc 记录 BatchAction 调用。
+type stubCommentSvc struct {
+	CommentService
+	actions map[string][]string
+	err     error
+}
+
+func (f *stubCommentSvc) BatchAction(_ context.Context, action string, ids []string) error {
+	if f.err != nil {
+		return f.err
+	}
+	if f.actions == nil {
+		f.actions = make(map[string][]string)
+	}
+	f.actions[action] = append(f.actions[action], ids...)
+	return nil
+}
+
+func userCtx(id string) context.Context {
+	return viewer.WithContext(context.Background(), viewer.NewUserViewer(id))
+}
+
+// DecideAction 只允许发起者本人决定；他人或未知 ID 一律报不存在且不消费登记。
+func TestDecideAction_OwnerOnly(t *testing.T) {
+	s := &CopilotService{}
+	id := s.registerPending("u1", &agent.Approval{})
+
+	if err := s.DecideAction(userCtx("u2"), id, true); err == nil || err.Error() != commonModel.CHAT_ACTION_NOT_FOUND {
+		t.Fatalf("other user should get not-found, got %v", err)
+	}
+	if err := s.DecideAction(userCtx("u1"), "unknown", true); err == nil {
+		t.Fatal("unknown id should fail")
+	}
+	if err := s.DecideAction(userCtx("u1"), id, true); err != nil {
+		t.Fatalf("owner decide: %v", err)
+	}
+	// 决定后登记即被消费，重复提交视为已过期。
+	if err := s.DecideAction(userCtx("u1"), id, false); err == nil {
+		t.Fatal("second decision should fail")
+	}
+}
+
+// dropPending 清掉本轮遗留登记，之后再决定报不存在。
+func TestDropPending(t *testing.T) {
+	s := &CopilotService{}
+	id := s.registerPending("u1", &agent.Approval{})
+	s.dropPending([]string{id, "unknown"})
+
+	if err := s.DecideAction(userCtx("u1"), id, true); err == nil {
+		t.Fatal("dropped action should be gone")
+	}
+}
+
+// audited 成功/失败都落审计，ListActions 最新在前；成功时上浮 actionResult Meta。
+func TestAudited_RecordsBothOutcomes(t *testing.T) {
+	s := &CopilotService{durableKV: kvstore.NewMemory()}
+	user := chatUser{ID: "u1"}
+
+	ok := s.audited("create_draft_echo", "zh-CN", user, func(context.Context, json.RawMessage) (string, error) {
+		return "done", nil
+	})
+	out, err := ok(context.Background(), json.RawMessage(`{"content":"hello"}`))
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	meta, isResult := out.Meta.(actionResult)
+	if !isResult || meta.Status != ActionSucceeded || meta.Message != "done" {
+		t.Fatalf("unexpected meta %#v", out.Meta)
+	}
+
+	failing := s.audited("tag_echos", "zh-CN", user, func(context.Context, json.RawMessage) (string, error) {
+		return "", errors.New("boom")
+	})
+	if _, err := failing(context.Background(), json.RawMessage(`{"echo_ids":["e1"]}`)); err == nil {
+		t.Fatal("want exec error")
+	}
+
+	records, _ := s.ListActions(context.Background())
+	if len(records) != 2 {
+		t.Fatalf("want 2 records, got %d", len(records))
+	}
+	if records[0].Tool != "tag_echos" || records[0].Status != ActionFailed || records[0].Error != "boom" {
+		t.Fatalf("newest record should be the failure, got %#v", records[0])
+	}
+	if records[1].Status != ActionSucceeded || !strings.Contains(records[1].Summary, "hello") {
+		t.Fatalf("unexpected success record %#v", records[1])
+	}
+}
+
+// recordAction 只保留最近 maxActionLog 条。
+func TestRecordAction_Caps(t *testing.T) {
+	s := &CopilotService{durableKV: kvstore.NewMemory()}
+	for i := 0; i < maxActionLog+5; i++ {
+		s.recordAction(context.Background(), ActionRecord{Tool: "tag_echos"})
+	}
+	if got := len(s.loadActionLog(context.Background())); got != maxActionLog {
+		t.Fatalf("want %d records, got %d", maxActionLog, got)
+	}
+}
+
+// create_draft_echo 保存为私密 Echo，标签去重。
+func TestCreateDraftTool(t *testing.T) {
+	echoSvc := &writableEchoSvc{}
+	s := &CopilotService{echoService: echoSvc, durableKV: kvstore.NewMemory()}
+	tool := s.createDraftTool("zh-CN", chatUser{ID: "u1"})
+	if !tool.Confirm {
+		t.Fatal("draft tool must require confirmation")
+	}
+
+	out, err := tool.Execute(context.Background(), mustArgs(t, draftArgs{Content: "草稿", Tags: []string{"a", "A", " "}}))
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	if len(echoSvc.posted) != 1 || !echoSvc.posted[0].Private || len(echoSvc.posted[0].Tags) != 1 {
+		t.Fatalf("unexpected posted echo %#v", echoSvc.posted)
+	}
+	if !strings.Contains(out.Content, "new-echo") {
+		t.Fatalf("result should carry the new id, got %q", out.Content)
+	}
+
+	if _, err := tool.Execute(context.Background(), mustArgs(t, draftArgs{Content: "  "})); err == nil {
+		t.Fatal("empty content should fail")
+	}
+}
+
+// tag_echos 只改本人的 Echo：他人的计入失败，本人的增删标签后整体回写。
+func TestTagEchosTool_OwnEchosOnly(t *testing.T) {
+	echoSvc := &writableEchoSvc{stubEchoSvc: stubEchoSvc{
+		getByIDFn: func(id string) (*echoModel.Echo, error) {
+			owner := "u1"
+			if id == "theirs" {
+				owner = "u2"
+			}
+			return &echoModel.Echo{ID: id, UserID: owner, Tags: []echoModel.Tag{{Name: "old"}, {Name: "keep"}}}, nil
+		},
+	}}
+	s := &CopilotService{echoService: echoSvc, durableKV: kvstore.NewMemory()}
+	tool := s.tagEchosTool("en-US", chatUser{ID: "u1"})
+
+	out, err := tool.Execute(context.Background(), mustArgs(t, tagArgs{
+		EchoIDs: []string{"mine", "theirs"},
+		Add:     []string{"new"},
+		Remove:  []string{"OLD"},
+	}))
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	if len(echoSvc.updated) != 1 || echoSvc.updated[0].ID != "mine" {
+		t.Fatalf("only own echo should be updated, got %#v", echoSvc.updated)
+	}
+	var names []string
+	for _, tag := range echoSvc.updated[0].Tags {
+		names = append(names, tag.Name)
+	}
+	if strings.Join(names, ",") != "keep,new" {
+		t.Fatalf("unexpected tags %v", names)
+	}
+	if !strings.Contains(out.Content, "Updated tags on 1 Echos; 1 failed") {
+		t.Fatalf("unexpected result %q", out.Content)
+	}
+
+	if _, err := tool.Execute(context.Background(), mustArgs(t, tagArgs{EchoIDs: []string{"mine"}})); err == nil {
+		t.Fatal("no add/remove should fail")
+	}
+}
+
+// moderate_comments 把 approve / reject 分别转给 BatchAction。
+func TestModerateCommentsTool(t *testing.T) {
+	comments := &stubCommentSvc{}
+	s := &CopilotService{commentService: comments, durableKV: kvstore.NewMemory()}
+	tool := s.moderateCommentsTool("zh-CN", chatUser{ID: "u1"})
+
+	out, err := tool.Execute(context.Background(), mustArgs(t, moderateArgs{Approve: []string{"c1", "c1"}, Reject: []string{"c2"}}))
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	if strings.Join(comments.actions["approve"], ",") != "c1" || strings.Join(comments.actions["reject"], ",") != "c2" {
+		t.Fatalf("unexpected batch actions %#v", comments.actions)
+	}
+	if out.Content != "已通过 1 条、拒绝 1 条评论" {
+		t.Fatalf("unexpected result %q", out.Content)
+	}
+
+	if _, err := tool.Execute(context.Background(), mustArgs(t, moderateArgs{})); err == nil {
+		t.Fatal("empty moderation should fail")
+	}
+}
+
+func TestDescribeAction(t *testing.T) {
+	cases := []struct {
+		name, tool, args, locale, want string
+	}{
+		{"draft zh", "create_draft_echo", `{"content":"今天\n天气好","tags":["日常"]}`, "zh-CN", "创建私密草稿：「今天 天气好」 #日常"},
+		{"tag en", "tag_echos", `{"echo_ids":["a","b","a"],"add":["x"]}`, "en-US", "Retag 2 Echos (add: x; remove: -)"},
+		{"moderate zh", "moderate_comments", `{"approve":["c1"],"reject":["c2","c3"]}`, "zh-CN", "通过 1 条、拒绝 2 条评论"},
+		{"unknown", "other", `{}`, "zh-CN", "other"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := describeAction(c.tool, json.RawMessage(c.args), c.locale); got != c.want {
+				t.Fatalf("describeAction = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
@@ -42,6 +42,7 @@ func (s *CopilotService) agentSetting(ctx context.Context) (settingModel.AgentSe
 // 设计上：尽早写出 SSE 头，之后所有错误都以 SSE "error" 事件回传，而非 HTTP 状态码。
 // SSE 事件：searching（模型决定检索）/ sources（命中来源，可多次）/ reasoning（推理增量，
 // 推理模型才有）/ reasoning_done（推理结束，含耗时 duration_ms）/ delta（文本增量）/
+// pending_action（写操作待确认，前端经 DecideAction 回复）/ action_result（写操作已执行）/
 // done（收尾）/ error（中止）。
 func (s *CopilotService) AskStream(ctx context.Context, question string, locale string, timezone string, w http.ResponseWriter) error {
 	flusher, ok := w.(http.Flusher)
@@ -116,16 +117,18 @@ func (s *CopilotService) AskStream(ctx context.Context, question string, locale
 	stream, err := agent.Run(ctx, agent.RunRequest{
 		Setting:  agentSetting,
 		Messages: buildChatMessages(history, question, locale, today, tagNames, currentUser.Username),
-		Tools: []agent.Tool{
+		Tools: append([]agent.Tool{
 			s.searchEchosTool(allTags, agentSetting.Multimodal, locale, loc, agentSetting.ContextWindow, user), // 点查：top-k 检索
 			s.summarizeEchosTool(allTags, agentSetting, locale, loc, user),                                     // 聚合：区间穷举 + 窗口自适应总结
 			s.statsOverviewTool(allTags, locale, loc, user),                                                    // 量化：区间精确统计（纯 SQL）
-		},
+			s.listPendingCommentsTool(loc),                                                                     // 待审评论（只读）
+		}, s.actionTools(locale, user)...), // 写操作：须经用户确认（pending_action）
 		MaxRounds:        config.Config().Agent.MaxRounds,
 		Temp:             &temp,
 		Strings:          runStringsFor(locale),
 		Timeout:          time.Duration(config.Config().Agent.TimeoutSeconds) * time.Second,
 		MaxContextTokens: chatContextBudgetTokens(agentSetting),
+		ConfirmTimeout:   actionConfirmTimeout,
 	})
 	if err != nil {
 		writeSSE(w, flusher, "error", map[string]string{"message": err.Error()})
@@ -135,6 +138,10 @@ func (s *CopilotService) AskStream(ctx context.Context, question string, locale
 	keepAlive := time.NewTicker(15 * time.Second)
 	defer keepAlive.Stop()
 
+	// 本轮登记的待确认写操作：无论以何种方式收尾都撤销残留项，Loop 不会悬挂等待。
+	var pendingIDs []string
+	defer func() { s.dropPending(pendingIDs) }()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -183,14 +190,28 @@ func (s *CopilotService) AskStream(ctx context.Context, question string, locale
 					writeSSE(w, flusher, "sources", meta)
 				case aggregateCoverage:
 					writeSSE(w, flusher, "coverage", meta)
+				case actionResult:
+					writeSSE(w, flusher, "action_result", meta)
 				}
+			case agent.AgentPendingAction:
+				// 写操作提议：登记回执并推给前端确认卡片；Loop 在用户决定（或超时）前阻塞。
+				id := s.registerPending(userID, ev.Approval)
+				pendingIDs = append(pendingIDs, id)
+				writeSSE(w, flusher, "pending_action", PendingAction{
+					ID:        id,
+					Tool:      ev.ToolName,
+					Args:      ev.ToolArgs,
+					Summary:   describeAction(ev.ToolName, ev.ToolArgs, locale),
+					ExpiresAt: time.Now().Add(actionConfirmTimeout).Unix(),
+				})
 			case agent.AgentDone:
 				endReasoning() // 纯推理无答案时也定格耗时
 				s.persistTurn(ctx, userID, question, assistantTurn{
 					answer: assistantBuf.String(), sources: collectedSources,
 					reasoning: reasoningBuf.String(), reasoningMs: reasoningMs,
 				})
-				writeSSE(w, flusher, "done", map[string]bool{"done": true})
+				// usage 为整轮累计 token（含工具循环各轮）；提供商不回报时为 0，旧前端忽略该字段。
+				writeSSE(w, flusher, "done", map[string]any{"done": true, "usage": ev.Usage})
 				return nil
 			case agent.AgentError:
 				writeSSE(w, flusher, "error", map[string]string{"message": ev.Err.Error()})
@@ -4,6 +4,8 @@
 package service
 
 import (
+	"sync"
+
 	"github.com/lin-snow/ech0/internal/kvstore"
 	"github.com/lin-snow/ech0/internal/storage"
 	"golang.org/x/sync/singleflight"
@@ -17,7 +19,14 @@ type CopilotService struct {
 	userReader     UserReader // 取当前对话用户：展示名 + 检索按作者收口
 	durableKV      kvstore.Store
 	storage        *storage.Manager // 多模态：读取命中 Echo 配图字节用于注入模型
+	commentService CommentService   // 写操作：评论审核
 	recentGenGroup singleflight.Group
+
+	// pending 登记等待用户确认的写操作（action ID → 回执），见 action.go。
+	pendingMu sync.Mutex
+	pending   map[string]pendingAction
+	// actionLogMu 串行化审计记录的读改写。
+	actionLogMu sync.Mutex
 }
 
 var (
@@ -38,12 +47,14 @@ func NewCopilotService(
 	userReader UserReader,
 	durableKV kvstore.Store,
 	storageManager *storage.Manager,
+	commentService CommentService,
 ) *CopilotService {
 	return &CopilotService{
-		echoService: echoService,
-		embedding:   embedding,
-		userReader:  userReader,
-		durableKV:   durableKV,
-		storage:     storageManager,
+		echoService:    echoService,
+		embedding:      embedding,
+		userReader:     userReader,
+		durableKV:      durableKV,
+		storage:        storageManager,
+		commentService: commentService,
 	}
 }
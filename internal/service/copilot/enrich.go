@@ -116,9 +116,14 @@ func formatExtension(ext *echoModel.EchoExtension) string {
 	return ""
 }
 
-// loadImagePart 把单个 File 读成 ImagePart：external 直接用公网直链；local/object 读字节做 base64。
-// 非图片、超限、读失败均返回 ok=false 由调用方跳过。
+// loadImagePart 把单个 File 读成 ImagePart，见 readImagePart。
 func (s *CopilotService) loadImagePart(ctx context.Context, f fileModel.File) (agent.ImagePart, bool) {
+	return readImagePart(ctx, s.storage, f)
+}
+
+// readImagePart 把单个 File 读成 ImagePart：external 直接用公网直链；local/object 读字节做 base64。
+// 非图片、超限、读失败（含未注入存储）均返回 ok=false 由调用方跳过。Chat 与新 Echo 建议共用。
+func readImagePart(ctx context.Context, mgr *storage.Manager, f fileModel.File) (agent.ImagePart, bool) {
 	if !storage.NormalizeCategory(f.Category).IsImageLike() {
 		return agent.ImagePart{}, false
 	}
@@ -135,10 +140,10 @@ func (s *CopilotService) loadImagePart(ctx context.Context, f fileModel.File) (a
 		return agent.ImagePart{MediaType: mediaType, URL: f.URL}, true
 	}
 
-	if f.Size > maxImageBytes {
+	if f.Size > maxImageBytes || mgr == nil {
 		return agent.ImagePart{}, false
 	}
-	reader, err := s.storage.GetSelector().Get(ctx, st, f.Key)
+	reader, err := mgr.GetSelector().Get(ctx, st, f.Key)
 	if err != nil {
 		return agent.ImagePart{}, false
 	}
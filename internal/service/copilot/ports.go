@@ -10,7 +10,9 @@ import (
 	"context"
 	"net/http"
 
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
 	userModel "github.com/lin-snow/ech0/internal/model/user"
+	commentService "github.com/lin-snow/ech0/internal/service/comment"
 	echoService "github.com/lin-snow/ech0/internal/service/echo"
 	embeddingService "github.com/lin-snow/ech0/internal/service/embedding"
 )
@@ -27,11 +29,30 @@ type ChatService interface {
 	GetSession(ctx context.Context) ([]ChatMessage, error)
 	// ClearSession 删除当前登录用户的持久化会话。
 	ClearSession(ctx context.Context) error
+	// DecideAction 确认或拒绝一条待确认的写操作（实现见 action.go）。
+	DecideAction(ctx context.Context, id string, approve bool) error
+	// ListActions 返回经确认执行的写操作审计记录（最新在前）。
+	ListActions(ctx context.Context) ([]ActionRecord, error)
+}
+
+// SuggestionService 暴露新 Echo 的标签 / 替代文本建议（实现见 suggest.go）。
+type SuggestionService interface {
+	// ListSuggestions 返回待审核的建议（最新在前）。
+	ListSuggestions(ctx context.Context) ([]Suggestion, error)
+	// ApplySuggestion 应用一条建议（可改写标签 / 替代文本）并将其移出待审核列表。
+	ApplySuggestion(ctx context.Context, echoID string, dto ApplySuggestionDto) error
+	// DismissSuggestion 忽略一条建议。
+	DismissSuggestion(ctx context.Context, echoID string) error
+	// SuggestForEcho 由 EchoCreated 订阅者调用，为新 Echo 生成建议。
+	SuggestForEcho(ctx context.Context, echo echoModel.Echo, user userModel.User) error
+	// Forget 由 EchoDeleted 订阅者调用，清掉已删除 Echo 的建议。
+	Forget(ctx context.Context, echoID string) error
 }
 
 type (
 	EchoService      = echoService.Service
 	EmbeddingService = embeddingService.Service
+	CommentService   = commentService.Service
 )
 
 // UserReader 用于按 ID 取当前对话用户信息（展示名 + 作为检索作者收口的依据）。
@@ -39,3 +60,8 @@ type (
 type UserReader interface {
 	GetUserByID(userID string) (userModel.User, error)
 }
+
+// AltTextWriter 用于写回配图替代文本；窄接口而非整个 file 服务，便于测试替身。
+type AltTextWriter interface {
+	UpdateFileAltText(ctx context.Context, id string, altText string) error
+}
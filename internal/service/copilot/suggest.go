@@ -0,0 +1,430 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/agent"
+	"github.com/lin-snow/ech0/internal/kvstore"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	"github.com/lin-snow/ech0/internal/storage"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"github.com/lin-snow/ech0/pkg/viewer"
+)
+
+// 新 Echo 发布后的自动增强（AgentSetting.AutoTag / AutoAltText）：由 EchoCreated 订阅者调用
+// SuggestForEcho，让模型从已有标签中挑选标签、为配图写替代文本。AutoApply 开启时直接写回，
+// 否则暂存为建议，等站长在 Copilot 面板中应用或忽略。
+
+// suggestTimeout 是单条 Echo 生成建议的上限（含读图与一次非流式 LLM 调用）。
+const suggestTimeout = 2 * time.Minute
+
+// maxSuggestedTags 是单条 Echo 建议标签数上限。
+const maxSuggestedTags = 3
+
+// maxAltTextRunes 是单张图替代文本的字符上限。
+const maxAltTextRunes = 250
+
+// maxSuggestions 是暂存待审核建议的条数上限（超出丢弃最早的）。
+const maxSuggestions = 200
+
+// suggestionExcerptRunes 是建议中 Echo 正文摘录的字符数，供审核时辨认是哪条 Echo。
+const suggestionExcerptRunes = 80
+
+// suggestionsMu 串行化建议列表的读改写。用包级锁而非实例锁：事件订阅与 HTTP 端点各自
+// 装配一份 Suggester，但读写的是同一个 KV 键。
+var suggestionsMu sync.Mutex
+
+// AltTextSuggestion 是为单张配图建议的替代文本。
+type AltTextSuggestion struct {
+	FileID  string `json:"file_id"`
+	URL     string `json:"url,omitempty"`
+	AltText string `json:"alt_text"`
+}
+
+// Suggestion 是为一条新 Echo 生成、等待站长审核的建议（每条 Echo 至多一条）。
+type Suggestion struct {
+	EchoID    string              `json:"echo_id"`
+	UserID    string              `json:"user_id"`
+	Excerpt   string              `json:"excerpt"`
+	Tags      []string            `json:"tags,omitempty"`
+	AltTexts  []AltTextSuggestion `json:"alt_texts,omitempty"`
+	CreatedAt int64               `json:"created_at"`
+}
+
+// ApplySuggestionDto 是应用建议时的可选改写：字段为 nil 时应用暂存的建议值，
+// 传空数组则跳过该项（如只应用标签、不写替代文本）。
+type ApplySuggestionDto struct {
+	Tags     []string            `json:"tags,omitempty" doc:"要添加的标签名；省略则使用建议值"`
+	AltTexts []AltTextSuggestion `json:"alt_texts,omitempty" doc:"要写入的替代文本；省略则使用建议值，仅限建议中出现的文件"`
+}
+
+// Suggester 实现 SuggestionService。
+type Suggester struct {
+	echoService EchoService
+	altWriter   AltTextWriter
+	durableKV   kvstore.Store
+	storage     *storage.Manager // 读取配图字节用于多模态生成替代文本
+}
+
+var _ SuggestionService = (*Suggester)(nil)
+
+func NewSuggester(
+	echoService EchoService,
+	altWriter AltTextWriter,
+	durableKV kvstore.Store,
+	storageManager *storage.Manager,
+) *Suggester {
+	return &Suggester{
+		echoService: echoService,
+		altWriter:   altWriter,
+		durableKV:   durableKV,
+		storage:     storageManager,
+	}
+}
+
+// suggestionOutput 是模型按提示词返回的 JSON。alt_texts 与提交的图片一一按序对应。
+type suggestionOutput struct {
+	Tags     []string `json:"tags"`
+	AltTexts []string `json:"alt_texts"`
+}
+
+// SuggestForEcho 为新发布的 Echo 生成标签 / 替代文本建议。未启用 Agent 或两项增强都未开启时为 no-op；
+// 标签只从已有标签中挑选（不创造新标签），替代文本只为尚无替代文本的图片生成且需开启多模态。
+func (s *Suggester) SuggestForEcho(ctx context.Context, echo echoModel.Echo, user userModel.User) error {
+	setting, err := coreSetting.Get(ctx, s.durableKV, coreSetting.Agent)
+	if err != nil {
+		return err
+	}
+	wantTags := setting.AutoTag
+	wantAlt := setting.AutoAltText && setting.Multimodal
+	if !setting.Enable || (!wantTags && !wantAlt) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, suggestTimeout)
+	defer cancel()
+	ctx = viewer.WithContext(ctx, viewer.NewUserViewer(user.ID))
+
+	// 事件载荷未必带齐附件，回查一次（GetEchoById 带缓存）。
+	if full, err := s.echoService.GetEchoById(ctx, echo.ID); err == nil && full != nil {
+		echo = *full
+	}
+
+	var vocabulary []string
+	if wantTags {
+		vocabulary = s.vocabulary(echo)
+	}
+	var files []fileModel.File
+	var images []agent.ImagePart
+	if wantAlt {
+		files, images = s.pendingImages(ctx, echo)
+	}
+	if len(vocabulary) == 0 && len(images) == 0 {
+		return nil
+	}
+
+	// 提示词随作者偏好的语言；替代文本本身按正文语言生成。
+	locale := user.Locale
+	temperature := float32(0.2)
+	output, err := agent.Generate(ctx, setting, []agent.Message{
+		{Role: agent.RoleSystem, Content: suggestSystemPromptFor(locale)},
+		{Role: agent.RoleUser, Content: suggestUserPrompt(echo.Content, vocabulary, len(images)), Images: images},
+	}, false, &temperature)
+	if err != nil {
+		return err
+	}
+
+	sug := parseSuggestion(output, vocabulary, files)
+	if len(sug.Tags) == 0 && len(sug.AltTexts) == 0 {
+		return nil
+	}
+	sug.EchoID = echo.ID
+	sug.UserID = user.ID
+	sug.Excerpt = truncateRunes(strings.TrimSpace(echo.Content), suggestionExcerptRunes)
+	sug.CreatedAt = time.Now().UTC().Unix()
+
+	if setting.AutoApply {
+		err := s.apply(ctx, echo.ID, sug.Tags, sug.AltTexts)
+		if err == nil {
+			logUtil.GetLogger().Info("copilot suggestion applied",
+				slog.String("module", "copilot"),
+				slog.String("echo_id", echo.ID),
+				slog.Int("tags", len(sug.Tags)),
+				slog.Int("alt_texts", len(sug.AltTexts)))
+			return nil
+		}
+		// 自动应用失败（如作者不是管理员）时退回待审核，不丢建议。
+		logUtil.GetLogger().Warn("failed to auto-apply copilot suggestion",
+			slog.String("module", "copilot"),
+			slog.String("echo_id", echo.ID),
+			logUtil.Err(err))
+	}
+	return s.save(context.WithoutCancel(ctx), sug)
+}
+
+// vocabulary 返回可供建议的已有标签名（排除 Echo 已带的标签）。
+func (s *Suggester) vocabulary(echo echoModel.Echo) []string {
+	tags, err := s.echoService.GetAllTags()
+	if err != nil {
+		return nil
+	}
+	have := make(map[string]bool, len(echo.Tags))
+	for _, t := range echo.Tags {
+		have[strings.ToLower(t.Name)] = true
+	}
+	names := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if name := strings.TrimSpace(t.Name); name != "" && !have[strings.ToLower(name)] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// pendingImages 收集尚无替代文本的配图（至多 maxChatImages 张），返回的 files 与 images 一一对应。
+func (s *Suggester) pendingImages(ctx context.Context, echo echoModel.Echo) ([]fileModel.File, []agent.ImagePart) {
+	var files []fileModel.File
+	var images []agent.ImagePart
+	for _, ef := range echo.EchoFiles {
+		if len(images) >= maxChatImages {
+			break
+		}
+		if strings.TrimSpace(ef.File.AltText) != "" {
+			continue
+		}
+		if part, ok := readImagePart(ctx, s.storage, ef.File); ok {
+			files = append(files, ef.File)
+			images = append(images, part)
+		}
+	}
+	return files, images
+}
+
+// parseSuggestion 解析模型输出：标签按词表（不区分大小写）收口为已有写法并封顶 maxSuggestedTags，
+// 替代文本按序对应 files、截断到 maxAltTextRunes。无法解析时返回空建议。
+func parseSuggestion(output string, vocabulary []string, files []fileModel.File) Suggestion {
+	var out suggestionOutput
+	start, end := strings.Index(output, "{"), strings.LastIndex(output, "}")
+	if start < 0 || end <= start {
+		return Suggestion{}
+	}
+	if err := json.Unmarshal([]byte(output[start:end+1]), &out); err != nil {
+		return Suggestion{}
+	}
+
+	canonical := make(map[string]string, len(vocabulary))
+	for _, name := range vocabulary {
+		canonical[strings.ToLower(name)] = name
+	}
+	var sug Suggestion
+	seen := make(map[string]bool)
+	for _, t := range out.Tags {
+		key := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(t), "#"))
+		name, ok := canonical[key]
+		if !ok || seen[key] {
+			continue
+		}
+		seen[key] = true
+		sug.Tags = append(sug.Tags, name)
+		if len(sug.Tags) >= maxSuggestedTags {
+			break
+		}
+	}
+	for i, alt := range out.AltTexts {
+		if i >= len(files) {
+			break
+		}
+		alt = strings.TrimSpace(alt)
+		if alt == "" {
+			continue
+		}
+		if r := []rune(alt); len(r) > maxAltTextRunes {
+			alt = string(r[:maxAltTextRunes])
+		}
+		sug.AltTexts = append(sug.AltTexts, AltTextSuggestion{FileID: files[i].ID, URL: files[i].URL, AltText: alt})
+	}
+	return sug
+}
+
+// apply 把标签追加到 Echo（UpdateEcho 是全量替换语义，故先读后写），再写替代文本。
+// 替代文本放在 UpdateEcho 之后，避免回写 Echo 附件时覆盖刚写入的值。ctx 需携带管理员 viewer。
+func (s *Suggester) apply(ctx context.Context, echoID string, tags []string, alts []AltTextSuggestion) error {
+	if len(tags) > 0 {
+		echo, err := s.echoService.GetEchoById(ctx, echoID)
+		if err != nil {
+			return err
+		}
+		names := make([]string, 0, len(echo.Tags)+len(tags))
+		for _, t := range echo.Tags {
+			names = append(names, t.Name)
+		}
+		echo.Tags = tagsFromNames(append(names, tags...))
+		if err := s.echoService.UpdateEcho(ctx, echo); err != nil {
+			return err
+		}
+	}
+	for _, a := range alts {
+		if err := s.altWriter.UpdateFileAltText(ctx, a.FileID, a.AltText); err != nil {
+			return fmt.Errorf("file %s: %w", a.FileID, err)
+		}
+	}
+	return nil
+}
+
+// ListSuggestions 返回待审核的建议（最新在前）。
+func (s *Suggester) ListSuggestions(ctx context.Context) ([]Suggestion, error) {
+	list := s.load(ctx)
+	out := make([]Suggestion, 0, len(list))
+	for i := len(list) - 1; i >= 0; i-- {
+		out = append(out, list[i])
+	}
+	return out, nil
+}
+
+// ApplySuggestion 应用一条建议并将其移出待审核列表。dto 中的替代文本只接受建议里出现过的文件。
+func (s *Suggester) ApplySuggestion(ctx context.Context, echoID string, dto ApplySuggestionDto) error {
+	sug, ok := s.find(ctx, echoID)
+	if !ok {
+		return errors.New(commonModel.SUGGESTION_NOT_FOUND)
+	}
+	tags, alts := sug.Tags, sug.AltTexts
+	if dto.Tags != nil {
+		tags = dedupeNonEmpty(dto.Tags)
+	}
+	if dto.AltTexts != nil {
+		allowed := make(map[string]bool, len(sug.AltTexts))
+		for _, a := range sug.AltTexts {
+			allowed[a.FileID] = true
+		}
+		alts = alts[:0:0]
+		for _, a := range dto.AltTexts {
+			if allowed[a.FileID] && strings.TrimSpace(a.AltText) != "" {
+				alts = append(alts, a)
+			}
+		}
+	}
+	if err := s.apply(ctx, echoID, tags, alts); err != nil {
+		return err
+	}
+	return s.remove(ctx, echoID)
+}
+
+// DismissSuggestion 忽略一条建议（不修改 Echo）。
+func (s *Suggester) DismissSuggestion(ctx context.Context, echoID string) error {
+	if _, ok := s.find(ctx, echoID); !ok {
+		return errors.New(commonModel.SUGGESTION_NOT_FOUND)
+	}
+	return s.remove(ctx, echoID)
+}
+
+// Forget 在 Echo 被删除时清掉其建议（没有则忽略）。
+func (s *Suggester) Forget(ctx context.Context, echoID string) error {
+	if _, ok := s.find(ctx, echoID); !ok {
+		return nil
+	}
+	return s.remove(ctx, echoID)
+}
+
+func (s *Suggester) load(ctx context.Context) []Suggestion {
+	raw, err := s.durableKV.Get(ctx, commonModel.CopilotSuggestionsKey)
+	if err != nil {
+		return nil
+	}
+	var list []Suggestion
+	if err := json.Unmarshal([]byte(raw), &list); err != nil {
+		return nil
+	}
+	return list
+}
+
+func (s *Suggester) find(ctx context.Context, echoID string) (Suggestion, bool) {
+	for _, sug := range s.load(ctx) {
+		if sug.EchoID == echoID {
+			return sug, true
+		}
+	}
+	return Suggestion{}, false
+}
+
+// save 写入一条建议：同一 Echo 的旧建议被替换，总数封顶 maxSuggestions。
+func (s *Suggester) save(ctx context.Context, sug Suggestion) error {
+	suggestionsMu.Lock()
+	defer suggestionsMu.Unlock()
+	list := s.load(ctx)
+	kept := list[:0]
+	for _, existing := range list {
+		if existing.EchoID != sug.EchoID {
+			kept = append(kept, existing)
+		}
+	}
+	kept = append(kept, sug)
+	if len(kept) > maxSuggestions {
+		kept = kept[len(kept)-maxSuggestions:]
+	}
+	return s.store(ctx, kept)
+}
+
+func (s *Suggester) remove(ctx context.Context, echoID string) error {
+	suggestionsMu.Lock()
+	defer suggestionsMu.Unlock()
+	list := s.load(ctx)
+	kept := list[:0]
+	for _, existing := range list {
+		if existing.EchoID != echoID {
+			kept = append(kept, existing)
+		}
+	}
+	return s.store(ctx, kept)
+}
+
+func (s *Suggester) store(ctx context.Context, list []Suggestion) error {
+	payload, err := json.Marshal(list)
+	if err != nil {
+		return err
+	}
+	return s.durableKV.Set(ctx, commonModel.CopilotSuggestionsKey, string(payload))
+}
+
+// suggestSystemPromptFor 按系统语言选择建议生成的系统提示词：只输出 JSON，标签只能取自词表。
+func suggestSystemPromptFor(locale string) string {
+	if localeIsZH(locale) {
+		return "你负责为一条刚发布的 Echo（短动态）整理元数据。只输出一个 JSON 对象，不要任何解释或 Markdown：" +
+			`{"tags":["..."],"alt_texts":["..."]}` + "。" +
+			"tags：从用户给出的已有标签中挑选最贴切的至多 3 个，必须原样使用词表中的写法，没有合适的就给空数组，绝不创造新标签。" +
+			"alt_texts：按图片顺序为每张图写一句简洁客观的替代文本（供读屏软件朗读，描述画面内容，不超过 120 字），语言与 Echo 正文一致；没有图片则给空数组。"
+	}
+	return "You tidy up metadata for an Echo (a short post) that was just published. Output a single JSON object and nothing else, no explanation or Markdown: " +
+		`{"tags":["..."],"alt_texts":["..."]}` + ". " +
+		"tags: pick at most 3 of the most fitting tags from the existing tags the user lists, spelled exactly as in that list; use an empty array if none fit and never invent new tags. " +
+		"alt_texts: one concise, objective alt text per image in order (read aloud by screen readers; describe what is shown, under 120 characters), in the same language as the Echo text; use an empty array if there are no images."
+}
+
+// suggestUserPrompt 拼出本条 Echo 的正文、可选标签与图片数。
+func suggestUserPrompt(content string, vocabulary []string, images int) string {
+	var b strings.Builder
+	b.WriteString("Echo:\n")
+	b.WriteString(strings.TrimSpace(content))
+	b.WriteString("\n\n")
+	if len(vocabulary) > 0 {
+		b.WriteString("Existing tags: ")
+		b.WriteString(strings.Join(vocabulary, ", "))
+	} else {
+		b.WriteString("Existing tags: (none, return an empty tags array)")
+	}
+	fmt.Fprintf(&b, "\nImages attached: %d", images)
+	return b.String()
+}
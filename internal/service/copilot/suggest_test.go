@@ -0,0 +1,226 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/kvstore"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
+	"github.com/lin-snow/ech0/internal/storage"
+)
+
+// stubAltWriter 记录替代文本写入；err 非空时写入失败。
+type stubAltWriter struct {
+	written map[string]string
+	err     error
+}
+
+func (f *stubAltWriter) UpdateFileAltText(_ context.Context, id string, altText string) error {
+	if f.err != nil {
+		return f.err
+	}
+	if f.written == nil {
+		f.written = make(map[string]string)
+	}
+	f.written[id] = altText
+	return nil
+}
+
+// ollamaReply 起一个回放固定回复的 Ollama /api/chat，返回 BaseURL。
+func ollamaReply(t *testing.T, content string) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		line, _ := json.Marshal(map[string]any{
+			"message": map[string]any{"role": "assistant", "content": content},
+			"done":    true,
+		})
+		_, _ = w.Write(append(line, '\n'))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+// externalImageEcho 返回带一张外链图（不触碰存储）的 Echo。
+func externalImageEcho() *echoModel.Echo {
+	return &echoModel.Echo{
+		ID:      "e1",
+		UserID:  "u1",
+		Content: "周末去海边看日落",
+		Tags:    []echoModel.Tag{{Name: "日常"}},
+		EchoFiles: []fileModel.EchoFile{{File: fileModel.File{
+			ID:          "f1",
+			URL:         "https://img.example.com/a.jpg",
+			StorageType: string(storage.StorageTypeExternal),
+			Category:    string(storage.CategoryImage),
+		}}},
+	}
+}
+
+// 标签收口到词表原写法、去重、排除词表外与已有标签，替代文本按序对应文件并截断。
+func TestParseSuggestion(t *testing.T) {
+	files := []fileModel.File{{ID: "f1", URL: "u1"}, {ID: "f2", URL: "u2"}}
+	out := "```json\n" + `{"tags":["#travel","TRAVEL","made-up","Sea"],"alt_texts":["  夕阳  ","","` +
+		strings.Repeat("长", maxAltTextRunes+10) + `"]}` + "\n```"
+
+	sug := parseSuggestion(out, []string{"Travel", "sea", "food"}, files)
+	if strings.Join(sug.Tags, ",") != "Travel,sea" {
+		t.Fatalf("unexpected tags %v", sug.Tags)
+	}
+	if len(sug.AltTexts) != 1 || sug.AltTexts[0].FileID != "f1" || sug.AltTexts[0].AltText != "夕阳" {
+		t.Fatalf("unexpected alt texts %#v", sug.AltTexts)
+	}
+
+	if got := parseSuggestion("not json", []string{"a"}, nil); len(got.Tags) != 0 || len(got.AltTexts) != 0 {
+		t.Fatalf("unparsable output should yield empty suggestion, got %#v", got)
+	}
+}
+
+// 未开启自动增强时不调用模型、不落建议。
+func TestSuggestForEcho_Disabled(t *testing.T) {
+	kv := kvstore.NewMemory()
+	seedAgentSetting(t, kv, settingModel.AgentSetting{Enable: true, Protocol: "ollama", Model: "m"})
+	s := NewSuggester(&stubEchoSvc{}, &stubAltWriter{}, kv, nil)
+
+	if err := s.SuggestForEcho(context.Background(), echoModel.Echo{ID: "e1"}, userModel.User{ID: "u1"}); err != nil {
+		t.Fatalf("suggest: %v", err)
+	}
+	if list, _ := s.ListSuggestions(context.Background()); len(list) != 0 {
+		t.Fatalf("no suggestion expected, got %#v", list)
+	}
+}
+
+// 未开启自动应用时建议落库待审核；应用时追加标签并写替代文本，之后移出列表。
+func TestSuggestForEcho_PendingThenApply(t *testing.T) {
+	kv := kvstore.NewMemory()
+	seedAgentSetting(t, kv, settingModel.AgentSetting{
+		Enable:      true,
+		Protocol:    "ollama",
+		Model:       "m",
+		BaseURL:     ollamaReply(t, `{"tags":["旅行","日常"],"alt_texts":["海边的日落"]}`),
+		Multimodal:  true,
+		AutoTag:     true,
+		AutoAltText: true,
+	})
+	echoSvc := &writableEchoSvc{stubEchoSvc: stubEchoSvc{
+		getByIDFn: func(string) (*echoModel.Echo, error) { return externalImageEcho(), nil },
+		tags:      []echoModel.Tag{{Name: "旅行"}, {Name: "日常"}, {Name: "美食"}},
+	}}
+	alt := &stubAltWriter{}
+	s := NewSuggester(echoSvc, alt, kv, nil)
+
+	if err := s.SuggestForEcho(context.Background(), echoModel.Echo{ID: "e1"}, userModel.User{ID: "u1"}); err != nil {
+		t.Fatalf("suggest: %v", err)
+	}
+	list, _ := s.ListSuggestions(context.Background())
+	if len(list) != 1 {
+		t.Fatalf("want one pending suggestion, got %#v", list)
+	}
+	// 「日常」已在 Echo 上，不再建议。
+	if got := list[0]; got.EchoID != "e1" || strings.Join(got.Tags, ",") != "旅行" ||
+		len(got.AltTexts) != 1 || got.AltTexts[0].AltText != "海边的日落" {
+		t.Fatalf("unexpected suggestion %#v", got)
+	}
+	if len(echoSvc.updated) != 0 || len(alt.written) != 0 {
+		t.Fatal("pending suggestion must not be applied")
+	}
+
+	if err := s.ApplySuggestion(userCtx("u1"), "e1", ApplySuggestionDto{
+		AltTexts: []AltTextSuggestion{{FileID: "f1", AltText: "改写后的描述"}, {FileID: "other", AltText: "x"}},
+	}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	var names []string
+	for _, tag := range echoSvc.updated[0].Tags {
+		names = append(names, tag.Name)
+	}
+	if strings.Join(names, ",") != "日常,旅行" {
+		t.Fatalf("unexpected tags %v", names)
+	}
+	if len(alt.written) != 1 || alt.written["f1"] != "改写后的描述" {
+		t.Fatalf("only suggested files may be written, got %v", alt.written)
+	}
+	if list, _ := s.ListSuggestions(context.Background()); len(list) != 0 {
+		t.Fatalf("applied suggestion should be removed, got %#v", list)
+	}
+	if err := s.DismissSuggestion(context.Background(), "e1"); err == nil || err.Error() != commonModel.SUGGESTION_NOT_FOUND {
+		t.Fatalf("want not-found, got %v", err)
+	}
+}
+
+// 自动应用直接写回；写回失败则退回待审核，不丢建议。
+func TestSuggestForEcho_AutoApply(t *testing.T) {
+	setting := settingModel.AgentSetting{
+		Enable:    true,
+		Protocol:  "ollama",
+		Model:     "m",
+		BaseURL:   ollamaReply(t, `{"tags":["旅行"],"alt_texts":[]}`),
+		AutoTag:   true,
+		AutoApply: true,
+	}
+	newEchoSvc := func() *writableEchoSvc {
+		return &writableEchoSvc{stubEchoSvc: stubEchoSvc{
+			getByIDFn: func(string) (*echoModel.Echo, error) { return externalImageEcho(), nil },
+			tags:      []echoModel.Tag{{Name: "旅行"}},
+		}}
+	}
+
+	t.Run("applied", func(t *testing.T) {
+		kv := kvstore.NewMemory()
+		seedAgentSetting(t, kv, setting)
+		echoSvc := newEchoSvc()
+		s := NewSuggester(echoSvc, &stubAltWriter{}, kv, nil)
+
+		if err := s.SuggestForEcho(context.Background(), echoModel.Echo{ID: "e1"}, userModel.User{ID: "u1"}); err != nil {
+			t.Fatalf("suggest: %v", err)
+		}
+		if len(echoSvc.updated) != 1 {
+			t.Fatalf("echo should be updated once, got %d", len(echoSvc.updated))
+		}
+		if list, _ := s.ListSuggestions(context.Background()); len(list) != 0 {
+			t.Fatalf("applied suggestion should not be stored, got %#v", list)
+		}
+	})
+
+	t.Run("falls back to pending", func(t *testing.T) {
+		kv := kvstore.NewMemory()
+		seedAgentSetting(t, kv, setting)
+		echoSvc := &failingUpdateEchoSvc{writableEchoSvc: newEchoSvc()}
+		s := NewSuggester(echoSvc, &stubAltWriter{}, kv, nil)
+
+		if err := s.SuggestForEcho(context.Background(), echoModel.Echo{ID: "e1"}, userModel.User{ID: "u1"}); err != nil {
+			t.Fatalf("suggest: %v", err)
+		}
+		if list, _ := s.ListSuggestions(context.Background()); len(list) != 1 {
+			t.Fatalf("failed auto-apply should leave a pending suggestion, got %#v", list)
+		}
+		if err := s.Forget(context.Background(), "e1"); err != nil {
+			t.Fatalf("forget: %v", err)
+		}
+		if list, _ := s.ListSuggestions(context.Background()); len(list) != 0 {
+			t.Fatalf("forget should drop the suggestion, got %#v", list)
+		}
+	})
+}
+
+// failingUpdateEchoSvc 的 UpdateEcho 总是失败（如作者不是管理员）。
+type failingUpdateEchoSvc struct {
+	*writableEchoSvc
+}
+
+func (f *failingUpdateEchoSvc) UpdateEcho(context.Context, *echoModel.Echo) error {
+	return errors.New(commonModel.NO_PERMISSION_DENIED)
+}
@@ -0,0 +1,312 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/event"
+	eventbus "github.com/lin-snow/ech0/internal/event/bus"
+	"github.com/lin-snow/ech0/internal/kvstore"
+	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	connectModel "github.com/lin-snow/ech0/internal/model/connect"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
+	webhookModel "github.com/lin-snow/ech0/internal/model/webhook"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	fmtUtil "github.com/lin-snow/ech0/internal/util/format"
+	timezoneUtil "github.com/lin-snow/ech0/internal/util/timezone"
+	"github.com/lin-snow/ech0/internal/visitor"
+	"github.com/lin-snow/ech0/pkg/busen"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"github.com/lin-snow/ech0/pkg/viewer"
+)
+
+const (
+	// digestPendingSamples / digestEchoSamples 是摘要里逐条列出的上限，其余只计数。
+	digestPendingSamples = 5
+	digestEchoSamples    = 10
+)
+
+// Digester 汇总站点在一个统计窗口内的动态，经评论系统的 SMTP 设置发给 owner。
+// 各板块尽力而为：某项数据取不到只记日志并略过该板块，不影响整封摘要。
+//
+// 数据一律以 owner 身份经各领域 service 读取（含私密 Echo 与后台列表），不绕过其鉴权。
+type Digester struct {
+	durableKV      kvstore.Store
+	bus            *busen.Bus
+	commonService  CommonService
+	commentService CommentService
+	echoService    EchoService
+	settingService SettingService
+	connectService ConnectService
+	summaryService SummaryService
+	visitorTracker *visitor.Tracker
+	now            func() time.Time
+}
+
+func NewDigester(
+	durableKV kvstore.Store,
+	busProvider func() *busen.Bus,
+	commonService CommonService,
+	commentService CommentService,
+	echoService EchoService,
+	settingService SettingService,
+	connectService ConnectService,
+	summaryService SummaryService,
+	visitorTracker *visitor.Tracker,
+) *Digester {
+	return &Digester{
+		durableKV:      durableKV,
+		bus:            busProvider(),
+		commonService:  commonService,
+		commentService: commentService,
+		echoService:    echoService,
+		settingService: settingService,
+		connectService: connectService,
+		summaryService: summaryService,
+		visitorTracker: visitorTracker,
+		now:            time.Now,
+	}
+}
+
+// GetDigestSetting 获取定期摘要设置。
+func (d *Digester) GetDigestSetting(ctx context.Context) (settingModel.DigestSetting, error) {
+	if err := d.requireAdmin(ctx); err != nil {
+		return settingModel.DigestSetting{}, err
+	}
+	return coreSetting.Get(ctx, d.durableKV, coreSetting.Digest)
+}
+
+// UpdateDigestSetting 更新定期摘要设置，保存成功后通知定时任务按新计划重挂作业。
+func (d *Digester) UpdateDigestSetting(ctx context.Context, dto *settingModel.DigestSettingDto) error {
+	if err := d.requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	updated := settingModel.DigestSetting{
+		Enable:         dto.Enable,
+		CronExpression: strings.TrimSpace(dto.CronExpression),
+		Period:         dto.Period,
+		Timezone:       strings.TrimSpace(dto.Timezone),
+		OnThisDay:      dto.OnThisDay,
+		AISummary:      dto.AISummary,
+	}
+	if err := fmtUtil.ValidateCrontabExpression(updated.CronExpression); err != nil {
+		return errors.New(commonModel.INVALID_CRON_EXPRESSION)
+	}
+	if updated.Period != settingModel.DigestPeriodDaily && updated.Period != settingModel.DigestPeriodWeekly {
+		return commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "统计周期只能是 daily 或 weekly")
+	}
+	if updated.Timezone != "" {
+		if _, err := time.LoadLocation(updated.Timezone); err != nil {
+			return commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "无效的时区")
+		}
+	}
+
+	if err := coreSetting.Set(ctx, d.durableKV, coreSetting.Digest, updated); err != nil {
+		return err
+	}
+
+	// 写入成功后再发布事件，避免失败时出现幽灵事件。
+	eventbus.Notify(context.Background(), d.bus, event.UpdateDigestSchedule{Setting: updated})
+	return nil
+}
+
+// SendDigest 立即发送一期摘要。
+func (d *Digester) SendDigest(ctx context.Context) error {
+	if err := d.requireAdmin(ctx); err != nil {
+		return err
+	}
+	setting, err := coreSetting.Get(ctx, d.durableKV, coreSetting.Digest)
+	if err != nil {
+		return err
+	}
+	return d.send(ctx, setting)
+}
+
+// SendScheduledDigest 按计划发送一期摘要。
+func (d *Digester) SendScheduledDigest(ctx context.Context) error {
+	setting, err := coreSetting.Get(ctx, d.durableKV, coreSetting.Digest)
+	if err != nil {
+		return err
+	}
+	if !setting.Enable {
+		return nil
+	}
+	return d.send(ctx, setting)
+}
+
+func (d *Digester) send(ctx context.Context, setting settingModel.DigestSetting) error {
+	report, err := d.collect(ctx, setting)
+	if err != nil {
+		return err
+	}
+	return d.commentService.SendOwnerMail(ctx, renderDigest(report))
+}
+
+// digestReport 是一期摘要的全部数据；先汇总成它再渲染，便于测试。
+type digestReport struct {
+	Period    string
+	From      time.Time // 统计窗口起点（含）
+	To        time.Time
+	Location  *time.Location
+	ServerURL string
+
+	NewComments     int64
+	PendingComments int64
+	PendingSamples  []commentModel.Comment
+
+	VisitorDays []visitor.DayStat
+	PV          int64
+	UV          int64 // 各日 UV 之和，跨日去重不可得
+
+	EchoCount int64
+	Echos     []echoModel.Echo
+
+	FailingWebhooks []webhookModel.Webhook
+	OfflinePeers    []connectModel.ConnectedHealth
+
+	OnThisDay []echoModel.Echo
+	AISummary string
+}
+
+// collect 以 owner 身份汇总统计窗口内的各项数据。
+func (d *Digester) collect(ctx context.Context, setting settingModel.DigestSetting) (digestReport, error) {
+	owner, err := d.commonService.GetOwner()
+	if err != nil {
+		return digestReport{}, err
+	}
+	ownerCtx := viewer.WithContext(ctx, viewer.NewUserViewer(owner.ID))
+
+	loc := timezoneUtil.LoadLocationOrUTC(setting.Timezone)
+	to := d.now().In(loc)
+	from := to.AddDate(0, 0, -7)
+	if setting.Period == settingModel.DigestPeriodDaily {
+		from = to.Add(-24 * time.Hour)
+	}
+	report := digestReport{
+		Period:    setting.Period,
+		From:      from,
+		To:        to,
+		Location:  loc,
+		ServerURL: d.serverURL(ctx),
+	}
+
+	if recent, err := d.commentService.ListPanelComments(ownerCtx, commentModel.ListCommentQuery{
+		PageSize: 1,
+		Since:    from.Unix(),
+	}); err != nil {
+		logDigestSkip("comments", err)
+	} else {
+		report.NewComments = recent.Total
+	}
+	if pending, err := d.commentService.ListPanelComments(ownerCtx, commentModel.ListCommentQuery{
+		PageSize: digestPendingSamples,
+		Status:   string(commentModel.StatusPending),
+	}); err != nil {
+		logDigestSkip("pending comments", err)
+	} else {
+		report.PendingComments = pending.Total
+		report.PendingSamples = pending.Items
+	}
+
+	if d.visitorTracker != nil {
+		// tracker 的日期 key 是 UTC 日期，按窗口起点所在的 UTC 日截取。
+		since := from.UTC().Format("2006-01-02")
+		for _, day := range d.visitorTracker.Last7Days() {
+			if day.Date < since {
+				continue
+			}
+			report.VisitorDays = append(report.VisitorDays, day)
+			report.PV += day.PV
+			report.UV += day.UV
+		}
+	}
+
+	if echos, err := d.echoService.QueryEchos(ownerCtx, commonModel.EchoQueryDto{
+		PageSize: digestEchoSamples,
+		DateFrom: from.Unix(),
+		DateTo:   to.Unix(),
+	}); err != nil {
+		logDigestSkip("echos", err)
+	} else {
+		report.EchoCount = echos.Total
+		report.Echos = echos.Items
+	}
+
+	if webhooks, err := d.settingService.GetAllWebhooks(ownerCtx); err != nil {
+		logDigestSkip("webhooks", err)
+	} else {
+		for _, wh := range webhooks {
+			if wh.IsActive && wh.LastStatus == "failed" {
+				report.FailingWebhooks = append(report.FailingWebhooks, wh)
+			}
+		}
+	}
+
+	if peers, err := d.connectService.GetConnectsHealth(); err != nil {
+		logDigestSkip("connects", err)
+	} else {
+		for _, peer := range peers {
+			if peer.Status != "online" {
+				report.OfflinePeers = append(report.OfflinePeers, peer)
+			}
+		}
+	}
+
+	if setting.OnThisDay {
+		if echos, err := d.echoService.GetOnThisDayEchos(ownerCtx, loc.String()); err != nil {
+			logDigestSkip("on this day", err)
+		} else {
+			report.OnThisDay = echos
+		}
+	}
+
+	if setting.AISummary && d.summaryService != nil {
+		if summary, err := d.summaryService.GetRecent(ownerCtx); err != nil {
+			logDigestSkip("ai summary", err)
+		} else {
+			report.AISummary = strings.TrimSpace(summary)
+		}
+	}
+
+	return report, nil
+}
+
+func (d *Digester) serverURL(ctx context.Context) string {
+	if d.durableKV == nil {
+		return ""
+	}
+	value, err := d.durableKV.Get(ctx, commonModel.ServerURLKey)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(strings.TrimSpace(value), "/")
+}
+
+func (d *Digester) requireAdmin(ctx context.Context) error {
+	userID := viewer.MustFromContext(ctx).UserID()
+	if userID == "" {
+		return commonModel.NewBizError(commonModel.ErrCodePermissionDenied, commonModel.NO_PERMISSION_DENIED)
+	}
+	user, err := d.commonService.CommonGetUserByUserId(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.IsAdmin {
+		return commonModel.NewBizError(commonModel.ErrCodePermissionDenied, commonModel.NO_PERMISSION_DENIED)
+	}
+	return nil
+}
+
+func logDigestSkip(section string, err error) {
+	logUtil.GetLogger().Warn("Skip digest section",
+		slog.String("module", "dashboard"), slog.String("section", section), logUtil.Err(err))
+}
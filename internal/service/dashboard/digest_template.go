@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/url"
+	"strings"
+	"time"
+
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
+	commentService "github.com/lin-snow/ech0/internal/service/comment"
+)
+
+// digestExcerptRunes 是摘要里单条 Echo / 评论正文的截断长度。
+const digestExcerptRunes = 80
+
+// 外框、页脚与退订链接由评论系统补上，这里只渲染正文片段，配色与评论通知邮件一致。
+var digestHTML = template.Must(template.New("digest").Funcs(template.FuncMap{
+	"excerpt": excerpt,
+}).Parse(`<div style="font-size:18px;font-weight:700;color:#3a3329;">{{.Title}}</div>
+<div style="margin-top:4px;font-size:12px;color:#8b8377;">{{.Range}}</div>
+<table role="presentation" width="100%" cellpadding="0" cellspacing="0" style="margin-top:14px;border-collapse:collapse;background:#faf7f2;border:1px solid #e8e2d8;">
+{{range .Stats}}<tr><td style="padding:7px 10px;font-size:12px;color:#8b8377;width:96px;">{{.Label}}</td><td style="padding:7px 10px;font-size:13px;font-weight:600;color:#3a3329;">{{.Value}}</td></tr>
+{{end}}</table>
+{{range .Sections}}<div style="margin-top:18px;font-size:14px;font-weight:700;color:#3a3329;">{{.Title}}</div>
+{{if .Text}}<div style="margin-top:8px;padding:12px;border:1px solid #e8e2d8;background:#fffcf8;line-height:1.7;font-size:13px;color:#4f473b;white-space:pre-wrap;">{{.Text}}</div>{{end}}
+{{range .Items}}<div style="margin-top:8px;padding:8px 10px;border-left:3px solid #e0d6c6;background:#fffcf8;font-size:13px;line-height:1.6;color:#4f473b;">
+{{if .Link}}<a href="{{.Link}}" target="_blank" rel="noopener noreferrer" style="color:#5f574a;text-decoration:none;">{{excerpt .Text}}</a>{{else}}{{excerpt .Text}}{{end}}
+{{if .Meta}}<div style="font-size:12px;color:#958d80;">{{.Meta}}</div>{{end}}
+</div>
+{{end}}{{end}}
+{{if .PanelURL}}<div style="margin-top:18px;"><a href="{{.PanelURL}}" target="_blank" rel="noopener noreferrer" style="display:inline-block;padding:8px 14px;background:#ffffff;border:1px solid #cbc4b8;color:#5f574a;text-decoration:none;font-size:13px;font-weight:600;">打开管理面板</a></div>{{end}}`))
+
+type digestView struct {
+	Title    string
+	Range    string
+	Stats    []digestStat
+	Sections []digestSection
+	PanelURL string
+}
+
+type digestStat struct {
+	Label string
+	Value string
+}
+
+type digestSection struct {
+	Title string
+	Text  string
+	Items []digestItem
+}
+
+type digestItem struct {
+	Text string
+	Meta string
+	Link string
+}
+
+// renderDigest 把一期摘要渲染成纯文本与 HTML 正文。
+func renderDigest(r digestReport) commentService.OwnerMail {
+	view := buildDigestView(r)
+	var html bytes.Buffer
+	if err := digestHTML.Execute(&html, view); err != nil {
+		// 模板是编译期常量，只有数据无法渲染时才会走到这里；退回只发纯文本。
+		html.Reset()
+	}
+	return commentService.OwnerMail{
+		Heading:   "Ech0 站点摘要",
+		Subject:   "[Ech0] " + view.Title + " · " + view.Range,
+		TextBody:  digestText(view),
+		InnerHTML: html.String(),
+	}
+}
+
+func buildDigestView(r digestReport) digestView {
+	title := "每周摘要"
+	if r.Period == settingModel.DigestPeriodDaily {
+		title = "每日摘要"
+	}
+	view := digestView{
+		Title: title,
+		Range: r.From.Format("2006-01-02 15:04") + " – " + r.To.Format("2006-01-02 15:04 MST"),
+		Stats: []digestStat{
+			{Label: "新评论", Value: fmt.Sprint(r.NewComments)},
+			{Label: "待审核评论", Value: fmt.Sprint(r.PendingComments)},
+			{Label: "新 Echo", Value: fmt.Sprint(r.EchoCount)},
+			{Label: "访问量", Value: fmt.Sprintf("PV %d / UV %d", r.PV, r.UV)},
+		},
+	}
+	if r.ServerURL != "" {
+		view.PanelURL = r.ServerURL + "/panel/dashboard"
+	}
+
+	if len(r.PendingSamples) > 0 {
+		section := digestSection{Title: "待审核评论"}
+		for _, c := range r.PendingSamples {
+			section.Items = append(section.Items, digestItem{
+				Text: c.Content,
+				Meta: strings.TrimSpace(c.Nickname) + " · " + digestTime(c.CreatedAt, r.Location),
+			})
+		}
+		if more := r.PendingComments - int64(len(r.PendingSamples)); more > 0 {
+			section.Text = fmt.Sprintf("另有 %d 条待审核，请到管理面板处理。", more)
+		}
+		view.Sections = append(view.Sections, section)
+	}
+
+	if len(r.Echos) > 0 {
+		section := digestSection{Title: "本期 Echo"}
+		for _, e := range r.Echos {
+			meta := digestTime(e.CreatedAt, r.Location)
+			if e.Private {
+				meta += " · 私密"
+			}
+			section.Items = append(section.Items, digestItem{
+				Text: e.Content,
+				Meta: meta,
+				Link: echoLink(r.ServerURL, e.ID),
+			})
+		}
+		if more := r.EchoCount - int64(len(r.Echos)); more > 0 {
+			section.Text = fmt.Sprintf("共 %d 条，以下为最新的 %d 条。", r.EchoCount, len(r.Echos))
+		}
+		view.Sections = append(view.Sections, section)
+	}
+
+	if len(r.FailingWebhooks) > 0 {
+		section := digestSection{Title: "投递失败的 Webhook"}
+		for _, wh := range r.FailingWebhooks {
+			section.Items = append(section.Items, digestItem{
+				Text: wh.Name,
+				Meta: wh.URL + " · 最近失败于 " + digestTime(wh.LastTrigger, r.Location),
+			})
+		}
+		view.Sections = append(view.Sections, section)
+	}
+
+	if len(r.OfflinePeers) > 0 {
+		section := digestSection{Title: "离线的互联实例"}
+		for _, peer := range r.OfflinePeers {
+			section.Items = append(section.Items, digestItem{Text: peer.ConnectURL})
+		}
+		view.Sections = append(view.Sections, section)
+	}
+
+	if len(r.OnThisDay) > 0 {
+		section := digestSection{Title: "那年今日"}
+		for _, e := range r.OnThisDay {
+			section.Items = append(section.Items, digestItem{
+				Text: e.Content,
+				Meta: digestTime(e.CreatedAt, r.Location),
+				Link: echoLink(r.ServerURL, e.ID),
+			})
+		}
+		view.Sections = append(view.Sections, section)
+	}
+
+	if r.AISummary != "" {
+		view.Sections = append(view.Sections, digestSection{Title: "Copilot 近期总结", Text: r.AISummary})
+	}
+	return view
+}
+
+func digestText(view digestView) string {
+	var b strings.Builder
+	b.WriteString("Ech0 " + view.Title + "\n" + view.Range + "\n")
+	for _, stat := range view.Stats {
+		b.WriteString("\n" + stat.Label + ": " + stat.Value)
+	}
+	for _, section := range view.Sections {
+		b.WriteString("\n\n## " + section.Title)
+		if section.Text != "" {
+			b.WriteString("\n" + section.Text)
+		}
+		for _, item := range section.Items {
+			b.WriteString("\n- " + excerpt(item.Text))
+			if item.Meta != "" {
+				b.WriteString(" (" + item.Meta + ")")
+			}
+			if item.Link != "" {
+				b.WriteString("\n  " + item.Link)
+			}
+		}
+	}
+	if view.PanelURL != "" {
+		b.WriteString("\n\n管理面板:\n" + view.PanelURL)
+	}
+	return b.String()
+}
+
+// excerpt 把正文压成一行并按字符截断。
+func excerpt(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	if len(runes) <= digestExcerptRunes {
+		return text
+	}
+	return string(runes[:digestExcerptRunes]) + "…"
+}
+
+func digestTime(ts int64, loc *time.Location) string {
+	if ts <= 0 {
+		return "未知时间"
+	}
+	return time.Unix(ts, 0).In(loc).Format("2006-01-02 15:04")
+}
+
+func echoLink(serverURL, echoID string) string {
+	if serverURL == "" || echoID == "" {
+		return ""
+	}
+	return serverURL + "/echo/" + url.PathEscape(echoID)
+}
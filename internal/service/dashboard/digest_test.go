@@ -0,0 +1,236 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	connectModel "github.com/lin-snow/ech0/internal/model/connect"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
+	webhookModel "github.com/lin-snow/ech0/internal/model/webhook"
+	commentService "github.com/lin-snow/ech0/internal/service/comment"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/lin-snow/ech0/internal/test/mocks/commentmock"
+	"github.com/lin-snow/ech0/internal/test/mocks/commonmock"
+	"github.com/lin-snow/ech0/internal/test/mocks/connectmock"
+	"github.com/lin-snow/ech0/internal/test/mocks/echomock"
+	"github.com/lin-snow/ech0/internal/test/mocks/settingmock"
+	"github.com/lin-snow/ech0/pkg/viewer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type digestDeps struct {
+	common  *commonmock.MockService
+	comment *commentmock.MockService
+	echo    *echomock.MockService
+	setting *settingmock.MockService
+	connect *connectmock.MockService
+	d       *Digester
+}
+
+func newDigestDeps(t *testing.T, now time.Time) *digestDeps {
+	t.Helper()
+	deps := &digestDeps{
+		common:  commonmock.NewMockService(t),
+		comment: commentmock.NewMockService(t),
+		echo:    echomock.NewMockService(t),
+		setting: settingmock.NewMockService(t),
+		connect: connectmock.NewMockService(t),
+	}
+	deps.d = &Digester{
+		commonService:  deps.common,
+		commentService: deps.comment,
+		echoService:    deps.echo,
+		settingService: deps.setting,
+		connectService: deps.connect,
+		now:            func() time.Time { return now },
+	}
+	return deps
+}
+
+func asOwner(ownerID string) any {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		return viewer.MustFromContext(ctx).UserID() == ownerID
+	})
+}
+
+// TestDigesterCollect 覆盖窗口计算、各板块的筛选，以及单个板块失败时整封摘要仍能生成。
+func TestDigesterCollect(t *testing.T) {
+	now := time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+	deps := newDigestDeps(t, now)
+	owner := helpers.NewUser(helpers.AsOwner)
+	from := now.AddDate(0, 0, -7)
+
+	deps.common.EXPECT().GetOwner().Return(owner, nil).Once()
+	deps.comment.EXPECT().
+		ListPanelComments(asOwner(owner.ID), commentModel.ListCommentQuery{PageSize: 1, Since: from.Unix()}).
+		Return(commentModel.PageResult[commentModel.Comment]{Total: 12}, nil).Once()
+	deps.comment.EXPECT().
+		ListPanelComments(asOwner(owner.ID), commentModel.ListCommentQuery{
+			PageSize: digestPendingSamples,
+			Status:   string(commentModel.StatusPending),
+		}).
+		Return(commentModel.PageResult[commentModel.Comment]{
+			Total: 3,
+			Items: []commentModel.Comment{{Nickname: "alice", Content: "hi"}},
+		}, nil).Once()
+	deps.echo.EXPECT().
+		QueryEchos(asOwner(owner.ID), commonModel.EchoQueryDto{
+			PageSize: digestEchoSamples,
+			DateFrom: from.Unix(),
+			DateTo:   now.Unix(),
+		}).
+		Return(commonModel.PageQueryResult[[]echoModel.Echo]{Total: 2, Items: []echoModel.Echo{helpers.NewEcho()}}, nil).Once()
+	deps.setting.EXPECT().GetAllWebhooks(asOwner(owner.ID)).Return([]webhookModel.Webhook{
+		{Name: "ok", IsActive: true, LastStatus: "success"},
+		{Name: "broken", IsActive: true, LastStatus: "failed"},
+		{Name: "paused", IsActive: false, LastStatus: "failed"},
+	}, nil).Once()
+	deps.connect.EXPECT().GetConnectsHealth().Return(nil, errors.New("boom")).Once()
+	deps.echo.EXPECT().GetOnThisDayEchos(asOwner(owner.ID), "UTC").Return([]echoModel.Echo{helpers.NewEcho()}, nil).Once()
+
+	report, err := deps.d.collect(context.Background(), settingModel.DigestSetting{
+		Period:    settingModel.DigestPeriodWeekly,
+		Timezone:  "UTC",
+		OnThisDay: true,
+		AISummary: true, // summaryService 为 nil 时直接略过
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, from, report.From)
+	assert.Equal(t, now, report.To)
+	assert.EqualValues(t, 12, report.NewComments)
+	assert.EqualValues(t, 3, report.PendingComments)
+	assert.Len(t, report.PendingSamples, 1)
+	assert.EqualValues(t, 2, report.EchoCount)
+	require.Len(t, report.FailingWebhooks, 1)
+	assert.Equal(t, "broken", report.FailingWebhooks[0].Name)
+	assert.Empty(t, report.OfflinePeers, "connect 失败时略过该板块")
+	assert.Len(t, report.OnThisDay, 1)
+	assert.Empty(t, report.AISummary)
+}
+
+// TestDigesterCollect_Daily 确认 daily 周期的窗口为 24 小时，且按设置的时区计算。
+func TestDigesterCollect_Daily(t *testing.T) {
+	now := time.Date(2026, 3, 9, 1, 0, 0, 0, time.UTC)
+	deps := newDigestDeps(t, now)
+
+	deps.common.EXPECT().GetOwner().Return(helpers.NewUser(helpers.AsOwner), nil).Once()
+	deps.comment.EXPECT().ListPanelComments(mock.Anything, mock.Anything).
+		Return(commentModel.PageResult[commentModel.Comment]{}, nil).Twice()
+	deps.echo.EXPECT().QueryEchos(mock.Anything, mock.Anything).
+		Return(commonModel.PageQueryResult[[]echoModel.Echo]{}, nil).Once()
+	deps.setting.EXPECT().GetAllWebhooks(mock.Anything).Return(nil, nil).Once()
+	deps.connect.EXPECT().GetConnectsHealth().Return([]connectModel.ConnectedHealth{
+		{ConnectURL: "https://a.example", Status: "online"},
+		{ConnectURL: "https://b.example", Status: "offline"},
+	}, nil).Once()
+
+	report, err := deps.d.collect(context.Background(), settingModel.DigestSetting{
+		Period:   settingModel.DigestPeriodDaily,
+		Timezone: "Asia/Shanghai",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 24*time.Hour, report.To.Sub(report.From))
+	assert.Equal(t, "Asia/Shanghai", report.To.Location().String())
+	require.Len(t, report.OfflinePeers, 1)
+	assert.Equal(t, "https://b.example", report.OfflinePeers[0].ConnectURL)
+}
+
+func TestDigesterCollect_OwnerMissing(t *testing.T) {
+	deps := newDigestDeps(t, time.Now())
+	deps.common.EXPECT().GetOwner().Return(helpers.NewUser(), errors.New("no owner")).Once()
+
+	_, err := deps.d.collect(context.Background(), settingModel.DigestSetting{Timezone: "UTC"})
+	require.Error(t, err)
+}
+
+func TestDigesterSend(t *testing.T) {
+	now := time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+	deps := newDigestDeps(t, now)
+
+	deps.common.EXPECT().GetOwner().Return(helpers.NewUser(helpers.AsOwner), nil).Once()
+	deps.comment.EXPECT().ListPanelComments(mock.Anything, mock.Anything).
+		Return(commentModel.PageResult[commentModel.Comment]{}, nil).Twice()
+	deps.echo.EXPECT().QueryEchos(mock.Anything, mock.Anything).
+		Return(commonModel.PageQueryResult[[]echoModel.Echo]{}, nil).Once()
+	deps.setting.EXPECT().GetAllWebhooks(mock.Anything).Return(nil, nil).Once()
+	deps.connect.EXPECT().GetConnectsHealth().Return(nil, nil).Once()
+
+	var sent commentService.OwnerMail
+	deps.comment.EXPECT().SendOwnerMail(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, mail commentService.OwnerMail) { sent = mail }).
+		Return(nil).Once()
+
+	err := deps.d.send(context.Background(), settingModel.DigestSetting{
+		Period:   settingModel.DigestPeriodWeekly,
+		Timezone: "UTC",
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sent.Subject, "[Ech0] 每周摘要 · "))
+	assert.Equal(t, "Ech0 站点摘要", sent.Heading)
+}
+
+// TestRenderDigest 覆盖摘要的文本与 HTML 渲染：空板块不出现、正文截断、HTML 转义与链接拼接。
+func TestRenderDigest(t *testing.T) {
+	loc := time.UTC
+	to := time.Date(2026, 3, 9, 9, 0, 0, 0, loc)
+	long := strings.Repeat("长", digestExcerptRunes+10)
+
+	mail := renderDigest(digestReport{
+		Period:          settingModel.DigestPeriodDaily,
+		From:            to.Add(-24 * time.Hour),
+		To:              to,
+		Location:        loc,
+		ServerURL:       "https://ech0.example",
+		NewComments:     4,
+		PendingComments: 7,
+		PendingSamples: []commentModel.Comment{
+			{Nickname: "bob", Content: "<script>alert(1)</script>", CreatedAt: to.Unix()},
+		},
+		PV:        30,
+		UV:        9,
+		EchoCount: 1,
+		Echos:     []echoModel.Echo{{ID: "e1", Content: long, CreatedAt: to.Unix()}},
+	})
+
+	assert.Equal(t, "[Ech0] 每日摘要 · 2026-03-08 09:00 – 2026-03-09 09:00 UTC", mail.Subject)
+
+	assert.Contains(t, mail.TextBody, "新评论: 4")
+	assert.Contains(t, mail.TextBody, "访问量: PV 30 / UV 9")
+	assert.Contains(t, mail.TextBody, "另有 6 条待审核")
+	assert.Contains(t, mail.TextBody, "https://ech0.example/echo/e1")
+	assert.Contains(t, mail.TextBody, strings.Repeat("长", digestExcerptRunes)+"…")
+	assert.NotContains(t, mail.TextBody, long)
+	assert.Contains(t, mail.TextBody, "https://ech0.example/panel/dashboard")
+	assert.NotContains(t, mail.TextBody, "那年今日")
+	assert.NotContains(t, mail.TextBody, "Webhook")
+
+	assert.NotContains(t, mail.InnerHTML, "<script>")
+	assert.Contains(t, mail.InnerHTML, "&lt;script&gt;")
+	assert.Contains(t, mail.InnerHTML, `href="https://ech0.example/echo/e1"`)
+}
+
+func TestRenderDigest_NoServerURL(t *testing.T) {
+	mail := renderDigest(digestReport{
+		Period:   settingModel.DigestPeriodWeekly,
+		Location: time.UTC,
+		Echos:    []echoModel.Echo{{ID: "e1", Content: "hello"}},
+	})
+
+	assert.NotContains(t, mail.TextBody, "/echo/e1")
+	assert.NotContains(t, mail.TextBody, "管理面板")
+	assert.NotContains(t, mail.InnerHTML, "href=")
+	assert.Contains(t, mail.TextBody, "未知时间")
+}
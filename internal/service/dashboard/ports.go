@@ -4,8 +4,16 @@
 package service
 
 import (
+	"context"
 	"net/http"
 
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
+	commentService "github.com/lin-snow/ech0/internal/service/comment"
+	commonService "github.com/lin-snow/ech0/internal/service/common"
+	connectService "github.com/lin-snow/ech0/internal/service/connect"
+	copilotService "github.com/lin-snow/ech0/internal/service/copilot"
+	echoService "github.com/lin-snow/ech0/internal/service/echo"
+	settingService "github.com/lin-snow/ech0/internal/service/setting"
 	"github.com/lin-snow/ech0/internal/visitor"
 	logUtil "github.com/lin-snow/ech0/pkg/log"
 )
@@ -27,3 +35,22 @@ type Service interface {
 	WSSubscribeSystemLogs(w http.ResponseWriter, r *http.Request, filter SystemLogStreamFilter) error
 	SSESubscribeSystemLogs(w http.ResponseWriter, r *http.Request, filter SystemLogStreamFilter) error
 }
+
+// DigestService 暴露站长定期摘要邮件（实现见 digest.go）。
+type DigestService interface {
+	GetDigestSetting(ctx context.Context) (settingModel.DigestSetting, error)
+	UpdateDigestSetting(ctx context.Context, dto *settingModel.DigestSettingDto) error
+	// SendDigest 按当前设置立即汇总并发送一期摘要，不要求已启用定时发送。
+	SendDigest(ctx context.Context) error
+	// SendScheduledDigest 由定时任务调用：未启用时直接返回。
+	SendScheduledDigest(ctx context.Context) error
+}
+
+type (
+	CommonService  = commonService.Service
+	CommentService = commentService.Service
+	EchoService    = echoService.Service
+	SettingService = settingService.Service
+	ConnectService = connectService.Service
+	SummaryService = copilotService.SummaryService
+)
@@ -58,7 +58,7 @@ func (s *EmbeddingService) Enabled(ctx context.Context) bool {
 	if err != nil {
 		return false
 	}
-	return setting.Enable && setting.Model != "" && setting.Dim > 0
+	return setting.Active()
 }
 
 // ensureReady 确保 vec0 表存在且维度与当前配置一致；维度/模型变化则清库重建（随后由回填重填）。
@@ -112,7 +112,7 @@ func (s *EmbeddingService) IndexEcho(ctx context.Context, echo echoModel.Echo) e
 	if err != nil {
 		return err
 	}
-	if !setting.Enable || setting.Model == "" || setting.Dim <= 0 {
+	if !setting.Active() {
 		return nil // 未启用 → 跳过
 	}
 
@@ -157,7 +157,7 @@ func (s *EmbeddingService) Search(ctx context.Context, query string, k int, auth
 	if err != nil {
 		return nil, err
 	}
-	if !setting.Enable || setting.Model == "" || setting.Dim <= 0 {
+	if !setting.Active() {
 		return nil, embedding.ErrNotEnabled
 	}
 	if k <= 0 {
@@ -170,6 +170,20 @@ func (s *EmbeddingService) Search(ctx context.Context, query string, k int, auth
 	return s.repo.Search(ctx, vec, k, authorUsername)
 }
 
+func (s *EmbeddingService) Related(ctx context.Context, echoID string, k int) ([]model.SearchResult, bool, error) {
+	setting, err := s.getSetting(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if !setting.Active() {
+		return nil, false, embedding.ErrNotEnabled
+	}
+	if k <= 0 {
+		k = defaultTopK
+	}
+	return s.repo.Related(ctx, echoID, k)
+}
+
 func (s *EmbeddingService) Backfill(ctx context.Context, onProgress func(BackfillResult)) (BackfillResult, error) {
 	var result BackfillResult
 
@@ -177,13 +191,17 @@ func (s *EmbeddingService) Backfill(ctx context.Context, onProgress func(Backfil
 	if err != nil {
 		return result, err
 	}
-	if !setting.Enable || setting.Model == "" || setting.Dim <= 0 {
+	if !setting.Active() {
 		return result, embedding.ErrNotEnabled
 	}
 	if err := s.ensureReady(ctx, setting); err != nil {
 		return result, err
 	}
 
+	// 经 ctx 挂载用量计数器，Embedder 接口不变即可拿到各批次的 token 数。
+	meter := &embedding.UsageMeter{}
+	meteredCtx := embedding.WithUsageMeter(ctx, meter)
+
 	const pageSize = 100
 	page := 1
 	var lastErr error
@@ -212,7 +230,7 @@ func (s *EmbeddingService) Backfill(ctx context.Context, onProgress func(Backfil
 		}
 
 		if len(texts) > 0 {
-			vecs, embErr := s.embedder.Embed(ctx, setting, texts)
+			vecs, embErr := s.embedder.Embed(meteredCtx, setting, texts)
 			if embErr != nil {
 				logUtil.GetLogger().Error("backfill embed failed", logUtil.Err(embErr))
 				result.Failed += len(texts)
@@ -236,6 +254,7 @@ func (s *EmbeddingService) Backfill(ctx context.Context, onProgress func(Backfil
 			}
 		}
 
+		result.Tokens = meter.Tokens()
 		// 每页结束上报累计计数（仅进内存，不落库）。
 		if onProgress != nil {
 			onProgress(result)
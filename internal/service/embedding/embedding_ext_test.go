@@ -326,3 +326,27 @@ func TestSearch_PreSeamGates(t *testing.T) {
 		assert.Nil(t, res)
 	})
 }
+
+func TestRelated(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not enabled returns ErrNotEnabled", func(t *testing.T) {
+		svc, _, kv := newSvc(t)
+		kv.EXPECT().Get(ctx, commonModel.EmbeddingSettingKey).Return("", kvstore.ErrNotFound).Once()
+		res, found, err := svc.Related(ctx, "e1", 5)
+		require.ErrorIs(t, err, embedding.ErrNotEnabled)
+		assert.False(t, found)
+		assert.Nil(t, res)
+	})
+
+	t.Run("k<=0 falls back to defaultTopK and passes through", func(t *testing.T) {
+		svc, repo, kv := newSvc(t)
+		kv.EXPECT().Get(ctx, commonModel.EmbeddingSettingKey).Return(enabledSettingJSON(t), nil).Once()
+		repo.EXPECT().Related(ctx, "e1", 6).Return([]embModel.SearchResult{{EchoID: "e2"}}, true, nil).Once()
+		res, found, err := svc.Related(ctx, "e1", 0)
+		require.NoError(t, err)
+		assert.True(t, found)
+		require.Len(t, res, 1)
+		assert.Equal(t, "e2", res[0].EchoID)
+	})
+}
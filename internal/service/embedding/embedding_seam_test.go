@@ -19,6 +19,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/lin-snow/ech0/internal/embedding"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	echoModel "github.com/lin-snow/ech0/internal/model/echo"
 	embModel "github.com/lin-snow/ech0/internal/model/embedding"
@@ -255,7 +256,7 @@ func TestBackfill_SinglePageIndexesAll(t *testing.T) {
 	expectEnsureReadyFastPath(t, repo, kv, ctx)
 	reader.EXPECT().GetEchosByPage(1, 100, "", true).
 		Return([]echoModel.Echo{e1, e2}, int64(2)).Once()
-	emb.EXPECT().Embed(ctx, enabledSetting(), []string{"a", "b"}).
+	emb.EXPECT().Embed(mock.Anything, enabledSetting(), []string{"a", "b"}).
 		Return([][]float32{{1, 1}, {2, 2}}, nil).Once()
 
 	var metas []*embModel.EchoEmbedding
@@ -296,7 +297,7 @@ func TestBackfill_SkipsEmptyText(t *testing.T) {
 	reader.EXPECT().GetEchosByPage(1, 100, "", true).
 		Return([]echoModel.Echo{e1, eEmpty, e2}, int64(3)).Once()
 	// Embed receives only the non-empty texts, in order.
-	emb.EXPECT().Embed(ctx, enabledSetting(), []string{"x", "y"}).
+	emb.EXPECT().Embed(mock.Anything, enabledSetting(), []string{"x", "y"}).
 		Return([][]float32{{1}, {2}}, nil).Once()
 	repo.EXPECT().Upsert(ctx, mock.Anything, mock.Anything).Return(nil).Times(2)
 
@@ -317,7 +318,7 @@ func TestBackfill_EmbedError_ReturnsLastErr(t *testing.T) {
 	expectEnsureReadyFastPath(t, repo, kv, ctx)
 	reader.EXPECT().GetEchosByPage(1, 100, "", true).
 		Return([]echoModel.Echo{newBackfillEcho("e1", "a", "u", 1), newBackfillEcho("e2", "b", "u", 2)}, int64(2)).Once()
-	emb.EXPECT().Embed(ctx, enabledSetting(), []string{"a", "b"}).Return(nil, boom).Once()
+	emb.EXPECT().Embed(mock.Anything, enabledSetting(), []string{"a", "b"}).Return(nil, boom).Once()
 	// No Upsert: the page never reaches persistence.
 
 	res, err := svc.Backfill(ctx, nil)
@@ -338,7 +339,7 @@ func TestBackfill_PartialUpsertFailure(t *testing.T) {
 	expectEnsureReadyFastPath(t, repo, kv, ctx)
 	reader.EXPECT().GetEchosByPage(1, 100, "", true).
 		Return([]echoModel.Echo{newBackfillEcho("e1", "a", "u", 1), newBackfillEcho("e2", "b", "u", 2)}, int64(2)).Once()
-	emb.EXPECT().Embed(ctx, enabledSetting(), []string{"a", "b"}).
+	emb.EXPECT().Embed(mock.Anything, enabledSetting(), []string{"a", "b"}).
 		Return([][]float32{{1}, {2}}, nil).Once()
 	repo.EXPECT().Upsert(ctx, mock.MatchedBy(func(m *embModel.EchoEmbedding) bool { return m.EchoID == "e1" }), mock.Anything).
 		Return(nil).Once()
@@ -362,7 +363,7 @@ func TestBackfill_AllUpsertFail_NoError(t *testing.T) {
 	expectEnsureReadyFastPath(t, repo, kv, ctx)
 	reader.EXPECT().GetEchosByPage(1, 100, "", true).
 		Return([]echoModel.Echo{newBackfillEcho("e1", "a", "u", 1), newBackfillEcho("e2", "b", "u", 2)}, int64(2)).Once()
-	emb.EXPECT().Embed(ctx, enabledSetting(), []string{"a", "b"}).
+	emb.EXPECT().Embed(mock.Anything, enabledSetting(), []string{"a", "b"}).
 		Return([][]float32{{1}, {2}}, nil).Once()
 	repo.EXPECT().Upsert(ctx, mock.Anything, mock.Anything).
 		Return(errors.New("upsert boom")).Times(2)
@@ -386,8 +387,8 @@ func TestBackfill_MultiPage(t *testing.T) {
 		Return([]echoModel.Echo{newBackfillEcho("e1", "a", "u", 1)}, int64(150)).Once()
 	reader.EXPECT().GetEchosByPage(2, 100, "", true).
 		Return([]echoModel.Echo{newBackfillEcho("e2", "b", "u", 2)}, int64(150)).Once()
-	emb.EXPECT().Embed(ctx, enabledSetting(), []string{"a"}).Return([][]float32{{1}}, nil).Once()
-	emb.EXPECT().Embed(ctx, enabledSetting(), []string{"b"}).Return([][]float32{{2}}, nil).Once()
+	emb.EXPECT().Embed(mock.Anything, enabledSetting(), []string{"a"}).Return([][]float32{{1}}, nil).Once()
+	emb.EXPECT().Embed(mock.Anything, enabledSetting(), []string{"b"}).Return([][]float32{{2}}, nil).Once()
 	repo.EXPECT().Upsert(ctx, mock.Anything, mock.Anything).Return(nil).Times(2)
 
 	res, err := svc.Backfill(ctx, nil)
@@ -420,7 +421,7 @@ func TestBackfill_OnProgress(t *testing.T) {
 	expectEnsureReadyFastPath(t, repo, kv, ctx)
 	reader.EXPECT().GetEchosByPage(1, 100, "", true).
 		Return([]echoModel.Echo{newBackfillEcho("e1", "a", "u", 1)}, int64(1)).Once()
-	emb.EXPECT().Embed(ctx, enabledSetting(), []string{"a"}).Return([][]float32{{1}}, nil).Once()
+	emb.EXPECT().Embed(mock.Anything, enabledSetting(), []string{"a"}).Return([][]float32{{1}}, nil).Once()
 	repo.EXPECT().Upsert(ctx, mock.Anything, mock.Anything).Return(nil).Once()
 
 	var progress []embeddingService.BackfillResult
@@ -433,6 +434,34 @@ func TestBackfill_OnProgress(t *testing.T) {
 	assert.Equal(t, progress[0], res)
 }
 
+// TestBackfill_AccumulatesTokens sums the usage each embed call records on the
+// metered ctx into the progress snapshots and the final result.
+func TestBackfill_AccumulatesTokens(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, kv, reader, emb := newSeamSvc(t)
+
+	kv.EXPECT().Get(ctx, commonModel.EmbeddingSettingKey).Return(enabledSettingJSON(t), nil).Once()
+	expectEnsureReadyFastPath(t, repo, kv, ctx)
+	reader.EXPECT().GetEchosByPage(1, 100, "", true).
+		Return([]echoModel.Echo{newBackfillEcho("e1", "a", "u", 1)}, int64(150)).Once()
+	reader.EXPECT().GetEchosByPage(2, 100, "", true).
+		Return([]echoModel.Echo{newBackfillEcho("e2", "b", "u", 2)}, int64(150)).Once()
+	emb.EXPECT().Embed(mock.Anything, enabledSetting(), mock.Anything).
+		RunAndReturn(func(ctx context.Context, _ settingModel.EmbeddingSetting, texts []string) ([][]float32, error) {
+			embedding.RecordUsage(ctx, 5)
+			return [][]float32{{1}}, nil
+		}).Twice()
+	repo.EXPECT().Upsert(ctx, mock.Anything, mock.Anything).Return(nil).Twice()
+
+	var progress []int64
+	res, err := svc.Backfill(ctx, func(r embeddingService.BackfillResult) {
+		progress = append(progress, r.Tokens)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{5, 10}, progress)
+	assert.Equal(t, int64(10), res.Tokens)
+}
+
 // TestBackfill_ContextCancelledMidLoop: cancelling during the page-1 progress
 // callback makes the next iteration's ctx.Err() guard abort, returning the
 // partial result accumulated so far.
@@ -446,7 +475,7 @@ func TestBackfill_ContextCancelledMidLoop(t *testing.T) {
 	// progress so page 2 is never fetched.
 	reader.EXPECT().GetEchosByPage(1, 100, "", true).
 		Return([]echoModel.Echo{newBackfillEcho("e1", "a", "u", 1)}, int64(150)).Once()
-	emb.EXPECT().Embed(ctx, enabledSetting(), []string{"a"}).Return([][]float32{{1}}, nil).Once()
+	emb.EXPECT().Embed(mock.Anything, enabledSetting(), []string{"a"}).Return([][]float32{{1}}, nil).Once()
 	repo.EXPECT().Upsert(ctx, mock.Anything, mock.Anything).Return(nil).Once()
 
 	res, err := svc.Backfill(ctx, func(embeddingService.BackfillResult) { cancel() })
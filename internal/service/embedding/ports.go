@@ -33,6 +33,9 @@ type Service interface {
 	// Search 做语义检索。authorUsername 非空时把命中收口到该作者发布的 Echo
 	// （Copilot Chat 用它隔离多用户实例下的他人 Echo）；空串表示不限定作者。
 	Search(ctx context.Context, query string, k int, authorUsername string) ([]model.SearchResult, error)
+	// Related 以 echoID 已存的向量检索最相近的 k 条（不含自身）。该 Echo 尚未建索引时
+	// found=false（而非报错），调用方据此降级。
+	Related(ctx context.Context, echoID string, k int) (results []model.SearchResult, found bool, err error)
 	Enabled(ctx context.Context) bool
 }
 
@@ -52,6 +55,8 @@ type Repository interface {
 	// Search 做向量 KNN 检索。authorUsername 非空时把命中收口到该作者
 	// （over-fetch 后按 username 过滤，仍返回最多 k 条）；空串表示不限定作者。
 	Search(ctx context.Context, vector []float32, k int, authorUsername string) ([]model.SearchResult, error)
+	// Related 以 echoID 已存的向量做 KNN（不含自身）；无该向量或向量表未建时 found=false。
+	Related(ctx context.Context, echoID string, k int) ([]model.SearchResult, bool, error)
 	ClearAll(ctx context.Context) error
 	Count(ctx context.Context) (int64, error)
 }
@@ -63,8 +68,9 @@ type EchoReader interface {
 
 // BackfillResult 是回填结果统计。
 type BackfillResult struct {
-	Total   int `json:"total"`
-	Indexed int `json:"indexed"`
-	Skipped int `json:"skipped"`
-	Failed  int `json:"failed"`
+	Total   int   `json:"total"`
+	Indexed int   `json:"indexed"`
+	Skipped int   `json:"skipped"`
+	Failed  int   `json:"failed"`
+	Tokens  int64 `json:"tokens"` // 提供商回报的累计输入 token（本地 llama.cpp / hash 不回报，恒为 0）
 }
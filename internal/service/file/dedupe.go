@@ -0,0 +1,261 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	"github.com/lin-snow/ech0/internal/storage"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"github.com/lin-snow/ech0/pkg/viewer"
+	"github.com/lin-snow/ech0/pkg/virefs"
+)
+
+const fileDedupePageSize = 100
+
+// PrepareFileDedupe 在提交内容去重作业前校验权限（仅管理员）。
+func (s *FileService) PrepareFileDedupe(ctx context.Context) error {
+	userid := viewer.MustFromContext(ctx).UserID()
+	user, err := s.commonRepository.GetUserByUserId(context.Background(), userid)
+	if err != nil {
+		return err
+	}
+	if !user.IsAdmin {
+		return errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+	if s.storageManager == nil {
+		return errors.New(commonModel.NO_FILE_STORAGE_ERROR)
+	}
+	return nil
+}
+
+// DedupeFiles 为存量受管文件做一次内容去重：先给缺哈希的行回填 SHA-256，再把每个重复组
+// 的行改指向组内最早且对象仍在的 key，不再被任何行引用的冗余对象随即删除。
+// 单个文件失败只记入 Failures；改写按「行 + 路由 + 旧 key」守卫，重跑即续跑。
+// 作业上下文里没有 viewer，权限由提交端点把关。
+func (s *FileService) DedupeFiles(
+	ctx context.Context,
+	onProgress func(fileModel.FileDedupeProgress),
+) (fileModel.FileDedupeProgress, error) {
+	var progress fileModel.FileDedupeProgress
+	if s.storageManager == nil {
+		return progress, errors.New(commonModel.NO_FILE_STORAGE_ERROR)
+	}
+	report := func() {
+		if onProgress != nil {
+			onProgress(progress)
+		}
+	}
+	fail := func(f fileModel.File, err error) {
+		progress.Failed++
+		if len(progress.Failures) < storageMigrationMaxFailures {
+			progress.Failures = append(progress.Failures, fileModel.FileJobFailure{
+				FileID: f.ID,
+				Key:    f.Key,
+				Error:  err.Error(),
+			})
+		}
+	}
+	routes := newRouteCache(s.storageManager)
+	report()
+
+	afterID := ""
+	for {
+		files, err := s.fileRepository.ListUnhashed(ctx, afterID, fileDedupePageSize)
+		if err != nil {
+			return progress, err
+		}
+		if len(files) == 0 {
+			break
+		}
+		for _, f := range files {
+			if err := ctx.Err(); err != nil {
+				return progress, err
+			}
+			afterID = f.ID
+			progress.Current = f.Key
+
+			route, err := routes.open(ctx, f.StorageType, f.Bucket)
+			if err != nil {
+				fail(f, err)
+				report()
+				continue
+			}
+			sum, _, err := hashStored(ctx, route.FS, f.Key)
+			switch {
+			case errors.Is(err, virefs.ErrNotFound):
+				progress.Missing++
+			case err != nil:
+				fail(f, err)
+			default:
+				if err := s.fileRepository.UpdateHashByID(ctx, f.ID, sum); err != nil {
+					fail(f, err)
+				} else {
+					progress.Hashed++
+				}
+			}
+			report()
+		}
+	}
+
+	groups, err := s.fileRepository.ListDuplicateContentGroups(ctx)
+	if err != nil {
+		return progress, err
+	}
+	progress.Groups = len(groups)
+	report()
+	for _, group := range groups {
+		if err := ctx.Err(); err != nil {
+			return progress, err
+		}
+		if err := s.dedupeGroup(ctx, routes, group, &progress, fail, report); err != nil {
+			return progress, err
+		}
+	}
+	progress.Current = ""
+	return progress, nil
+}
+
+// dedupeGroup 合并一个重复组。规范 key 取组内最早且对象仍在的那个；其余行逐个改指向它，
+// 组内旧 key 全部处理完后，不再被引用的旧对象才删除（同组可能有多行共享同一个旧 key）。
+func (s *FileService) dedupeGroup(
+	ctx context.Context,
+	routes *routeCache,
+	group fileModel.ContentGroup,
+	progress *fileModel.FileDedupeProgress,
+	fail func(fileModel.File, error),
+	report func(),
+) error {
+	files, err := s.fileRepository.ListByContentGroup(ctx, group)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	route, err := routes.open(ctx, group.StorageType, group.Bucket)
+	if err != nil {
+		fail(files[0], err)
+		report()
+		return nil
+	}
+
+	// 规范 key 从探测到组内各行改指向它都持有它的锁，免得它原有的引用者此时被删、
+	// 对象随之回收（见 releaseStoredFile）。
+	canonical := ""
+	var unlock func()
+	for _, f := range files {
+		release := s.lockStoredKey(f.Key)
+		ok, err := route.FS.Exists(ctx, f.Key)
+		if err != nil {
+			release()
+			fail(f, err)
+			report()
+			return nil
+		}
+		if ok {
+			canonical, unlock = f.Key, release
+			break
+		}
+		release()
+	}
+	if canonical == "" {
+		return nil
+	}
+
+	columns := fileModel.StorageRoute{StorageType: group.StorageType, Bucket: group.Bucket}
+	released := make(map[string]struct{})
+	for _, f := range files {
+		if f.Key == canonical {
+			continue
+		}
+		progress.Current = f.Key
+		moved, err := s.fileRepository.UpdateKeyByID(ctx, f.ID, columns, f.Key, canonical, route.ResolveURL(canonical))
+		if err == nil && !moved {
+			err = errors.New("file record changed during dedupe")
+		}
+		if err != nil {
+			fail(f, err)
+		} else {
+			progress.Relinked++
+			released[f.Key] = struct{}{}
+		}
+		report()
+	}
+	unlock()
+
+	for key := range released {
+		if size, ok := s.releaseDuplicate(ctx, route, group, key); ok {
+			progress.Freed++
+			progress.FreedBytes += size
+		}
+	}
+	report()
+	return nil
+}
+
+// releaseDuplicate 删除已无行引用的旧 key 对象，返回释放的字节数与是否删掉了。
+// 数引用与删除在 key 锁内完成，与上传复用已有对象互斥（见 reuseStoredContent）。
+func (s *FileService) releaseDuplicate(
+	ctx context.Context,
+	route storage.RouteFS,
+	group fileModel.ContentGroup,
+	key string,
+) (int64, bool) {
+	unlock := s.lockStoredKey(key)
+	defer unlock()
+
+	refs, err := s.fileRepository.CountByRouteKey(ctx, group.StorageType, group.Bucket, key, "")
+	if err != nil || refs > 0 {
+		return 0, false
+	}
+	var size int64
+	info, err := route.FS.Stat(ctx, key)
+	switch {
+	case errors.Is(err, virefs.ErrNotFound):
+		return 0, false
+	case err == nil:
+		size = info.Size
+	}
+	if err := route.FS.Delete(ctx, key); err != nil && !errors.Is(err, virefs.ErrNotFound) {
+		logUtil.GetLogger().Warn(
+			"Failed to delete duplicate stored file",
+			slog.String("file_key", key),
+			slog.String("storage_type", group.StorageType),
+			logUtil.Err(err),
+		)
+		return 0, false
+	}
+	return size, true
+}
+
+// routeCache 按（存储类型, 桶）缓存打开的路由，连同打开失败的错误：
+// 同一路由上的成百上千行不必反复构建对象存储客户端。
+type routeCache struct {
+	manager *storage.Manager
+	entries map[string]routeCacheEntry
+}
+
+type routeCacheEntry struct {
+	route storage.RouteFS
+	err   error
+}
+
+func newRouteCache(manager *storage.Manager) *routeCache {
+	return &routeCache{manager: manager, entries: make(map[string]routeCacheEntry)}
+}
+
+func (c *routeCache) open(ctx context.Context, storageType, bucket string) (storage.RouteFS, error) {
+	cacheKey := storageType + "|" + bucket
+	if entry, ok := c.entries[cacheKey]; ok {
+		return entry.route, entry.err
+	}
+	route, err := c.manager.OpenRoute(ctx, storage.StorageType(storageType), bucket)
+	c.entries[cacheKey] = routeCacheEntry{route: route, err: err}
+	return route, err
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	"github.com/lin-snow/ech0/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedLocalFile 模拟去重上线前的存量文件：直接写对象与文件行，不带内容哈希。
+func (f *fileFix) seedLocalFile(t *testing.T, key string, content []byte) fileModel.File {
+	t.Helper()
+	require.NoError(t, f.mgr.GetSelector().Put(context.Background(), storage.StorageTypeLocal, key, bytes.NewReader(content)))
+	row := fileModel.File{
+		Key:         key,
+		StorageType: "local",
+		URL:         "/api/files/" + key,
+		Name:        key,
+		Size:        int64(len(content)),
+		Category:    "image",
+		UserID:      fileTestUserID,
+	}
+	require.NoError(t, f.db.Create(&row).Error)
+	return row
+}
+
+func TestFileService_DedupeFiles(t *testing.T) {
+	t.Run("hashes legacy rows and merges duplicates", func(t *testing.T) {
+		f := newFileFix(t)
+		content := pngBytes(t, 4, 4)
+		first := f.seedLocalFile(t, "first.png", content)
+		dup := f.seedLocalFile(t, "dup.png", content)
+		other := f.seedLocalFile(t, "other.png", pngBytes(t, 5, 5))
+		lost := f.seedLocalFile(t, "lost.png", content)
+		require.NoError(t, f.mgr.GetSelector().Delete(context.Background(), storage.StorageTypeLocal, lost.Key))
+
+		var reports int
+		res, err := f.svc.DedupeFiles(context.Background(), func(fileModel.FileDedupeProgress) { reports++ })
+		require.NoError(t, err)
+		assert.Equal(t, 3, res.Hashed)
+		assert.Equal(t, 1, res.Missing)
+		assert.Equal(t, 1, res.Groups)
+		assert.Equal(t, 1, res.Relinked)
+		assert.Equal(t, 1, res.Freed)
+		assert.Equal(t, int64(len(content)), res.FreedBytes)
+		assert.Zero(t, res.Failed)
+		assert.Positive(t, reports)
+
+		assert.Equal(t, first.Key, f.reload(t, dup.ID).Key)
+		assert.Equal(t, f.reload(t, first.ID).Hash, f.reload(t, dup.ID).Hash)
+		assert.Equal(t, other.Key, f.reload(t, other.ID).Key)
+		assert.Empty(t, f.reload(t, lost.ID).Hash)
+		assert.True(t, storedExists(t, f.mgr, first.Key))
+		assert.False(t, storedExists(t, f.mgr, dup.Key))
+
+		// 再跑一遍无事可做：丢失的对象仍只记为 Missing。
+		res, err = f.svc.DedupeFiles(context.Background(), nil)
+		require.NoError(t, err)
+		assert.Zero(t, res.Hashed)
+		assert.Zero(t, res.Groups)
+		assert.Equal(t, 1, res.Missing)
+	})
+
+	t.Run("skips a canonical key whose object is gone", func(t *testing.T) {
+		f := newFileFix(t)
+		content := pngBytes(t, 4, 4)
+		sum := sha256.Sum256(content)
+		gone := f.seedLocalFile(t, "gone.png", content)
+		kept := f.seedLocalFile(t, "kept.png", content)
+		dup := f.seedLocalFile(t, "dup.png", content)
+		// gone 上传时就记了哈希，之后对象丢了：它最早，却不能当规范 key。
+		require.NoError(t, f.mgr.GetSelector().Delete(context.Background(), storage.StorageTypeLocal, gone.Key))
+		require.NoError(t, f.db.Model(&fileModel.File{}).Where("id = ?", gone.ID).
+			Update("hash", hex.EncodeToString(sum[:])).Error)
+
+		res, err := f.svc.DedupeFiles(context.Background(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, 2, res.Hashed)
+		assert.Equal(t, 2, res.Relinked)
+		assert.Equal(t, 1, res.Freed, "the missing object is not counted as freed")
+		for _, id := range []string{gone.ID, dup.ID} {
+			assert.Equal(t, kept.Key, f.reload(t, id).Key)
+		}
+		assert.True(t, storedExists(t, f.mgr, kept.Key))
+		assert.False(t, storedExists(t, f.mgr, dup.Key))
+	})
+
+	t.Run("prepare requires admin", func(t *testing.T) {
+		f := newFileFix(t)
+		f.expectNonAdmin()
+		err := f.svc.PrepareFileDedupe(f.adminCtx())
+		require.Error(t, err)
+		assert.Equal(t, commonModel.NO_PERMISSION_DENIED, err.Error())
+	})
+}
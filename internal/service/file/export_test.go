@@ -0,0 +1,9 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+// LockStoredKey 把 key 锁暴露给外部测试包，用来确定性地编排与复用 / 删除的交错。
+func (s *FileService) LockStoredKey(key string) func() {
+	return s.lockStoredKey(key)
+}
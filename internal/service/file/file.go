@@ -8,6 +8,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
+	"hash/fnv"
 	"io"
 	"log/slog"
 	"mime/multipart"
@@ -18,14 +19,17 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/lin-snow/ech0/internal/config"
 	"github.com/lin-snow/ech0/internal/event"
 	eventbus "github.com/lin-snow/ech0/internal/event/bus"
+	"github.com/lin-snow/ech0/internal/kvstore"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
 	"github.com/lin-snow/ech0/internal/storage"
 	"github.com/lin-snow/ech0/internal/transaction"
 	imgUtil "github.com/lin-snow/ech0/internal/util/img"
@@ -44,6 +48,8 @@ const (
 	treeNodeTypeFolder   = "folder"
 	tempFileTTL          = 24 * time.Hour
 	tempCleanupDryRunEnv = "ECH0_FILE_TEMP_CLEANUP_DRY_RUN"
+	// uploadPartSize 是写入对象存储时的分片大小，小于它的文件仍是一次 PutObject。
+	uploadPartSize = 8 << 20
 )
 
 type FileService struct {
@@ -52,7 +58,17 @@ type FileService struct {
 	storageManager   *storage.Manager
 	fileRepository   FileRepository
 	bus              *busen.Bus
+	kv               kvstore.Store
 	keyGen           storage.KeyGenerator
+
+	// quotaMu 串行化额度校验与记账，见 reserveUsage。
+	quotaMu sync.Mutex
+	// storedKeyMu 是存储对象的分片锁，见 lockStoredKey。
+	storedKeyMu [64]sync.Mutex
+
+	// resumableBusy 记录正在写入的断点续传会话，同一会话的并发写入直接拒绝。
+	resumableMu   sync.Mutex
+	resumableBusy map[string]struct{}
 }
 
 func NewFileService(
@@ -61,6 +77,7 @@ func NewFileService(
 	fileRepo FileRepository,
 	storageManager *storage.Manager,
 	busProvider func() *busen.Bus,
+	kv kvstore.Store,
 ) *FileService {
 	return &FileService{
 		transactor:       tx,
@@ -68,7 +85,9 @@ func NewFileService(
 		fileRepository:   fileRepo,
 		storageManager:   storageManager,
 		bus:              busProvider(),
+		kv:               kv,
 		keyGen:           storage.NewRandomKeyGenerator(),
+		resumableBusy:    make(map[string]struct{}),
 	}
 }
 
@@ -103,7 +122,32 @@ func (s *FileService) UploadFile(
 		return commonModel.FileDto{}, errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 
-	reader, err := file.Open()
+	return s.storeUpload(user, uploadSource{
+		Filename: file.Filename,
+		Size:     file.Size,
+		Open:     file.Open,
+	}, category, storageType)
+}
+
+// uploadSource 是一次上传的内容来源：普通上传来自 multipart 表单，
+// 断点续传来自 data/tmp 下组装完成的临时文件，此时 SessionID 是对应的会话。
+// Open 可多次调用，每次从头读。
+type uploadSource struct {
+	Filename  string
+	Size      int64
+	Open      func() (multipart.File, error)
+	SessionID string
+}
+
+// storeUpload 是 UploadFile 与断点续传共用的落盘流程：嗅探并校验类型、按分类限制大小、
+// 计算内容哈希并复用同内容对象、写入当前存储，最后创建 File + TempFile 记录并发出上传事件。
+func (s *FileService) storeUpload(
+	user userModel.User,
+	src uploadSource,
+	category storage.Category,
+	storageType storage.StorageType,
+) (commonModel.FileDto, error) {
+	reader, err := src.Open()
 	if err != nil {
 		return commonModel.FileDto{}, err
 	}
@@ -113,75 +157,108 @@ func (s *FileService) UploadFile(
 		return commonModel.FileDto{}, err
 	}
 
-	if err := validateFileUpload(file.Filename, detectedMIME, config.Config().Upload.AllowedTypes); err != nil {
+	if err := validateFileUpload(src.Filename, detectedMIME, config.Config().Upload.AllowedTypes); err != nil {
 		return commonModel.FileDto{}, err
 	}
 
 	// Use the canonical MIME for the extension rather than the raw sniffed
 	// value (which may be "application/octet-stream" for formats Go cannot
 	// identify by magic bytes alone, e.g. AVIF, FLAC).
-	contentType := resolveContentType(file.Filename, detectedMIME)
+	contentType := resolveContentType(src.Filename, detectedMIME)
 
 	maxSize, uploadType := uploadPolicyFor(category)
-	if file.Size > int64(maxSize) {
+	if src.Size > int64(maxSize) {
 		return commonModel.FileDto{}, errors.New(commonModel.FILE_SIZE_EXCEED_LIMIT)
 	}
-
-	gen := s.keyGenForCategory(category, file.Filename)
-	key, err := gen.GenerateKey(category, user.ID, file.Filename)
-	if err != nil {
+	if err := s.reserveUsage(context.Background(), user, string(category), src.Size, 1, src.SessionID); err != nil {
 		return commonModel.FileDto{}, err
 	}
+	committed := false
+	defer func() {
+		if !committed {
+			s.refundUsage(context.Background(), user.ID, string(category), src.Size, 1)
+		}
+	}()
 
-	uploadReader, err := file.Open()
+	contentHash, err := hashUpload(src)
 	if err != nil {
 		return commonModel.FileDto{}, err
 	}
-	defer func() { _ = uploadReader.Close() }()
-
-	var opts []virefs.PutOption
-	if contentType != "" {
-		opts = append(opts, virefs.WithContentType(contentType))
-	}
 
 	targetStorageType := storage.NormalizeStorageType(string(storageType))
 	if targetStorageType == storage.StorageTypeExternal {
 		targetStorageType = storage.StorageTypeLocal
 	}
 	selector := s.getSelector()
-	if err := selector.Put(context.Background(), targetStorageType, key, uploadReader, opts...); err != nil {
-		return commonModel.FileDto{}, err
-	}
+	routeStorageType, provider, bucket := currentStorageRoute(selector, targetStorageType)
 
 	width, height := 0, 0
 	if category.IsImageLike() {
-		width, height, err = imgUtil.GetImageSizeFromFile(file)
+		imageReader, err := src.Open()
+		if err != nil {
+			return commonModel.FileDto{}, err
+		}
+		width, height, err = imgUtil.GetImageSizeFromReader(imageReader)
+		_ = imageReader.Close()
 		if err != nil {
 			return commonModel.FileDto{}, err
 		}
 	}
 
-	fileURL := selector.ResolveURL(targetStorageType, key)
-	routeStorageType, provider, bucket := currentStorageRoute(selector, targetStorageType)
-
 	fileRecord := &fileModel.File{
-		Key:         key,
 		StorageType: routeStorageType,
 		Provider:    provider,
 		Bucket:      bucket,
-		URL:         fileURL,
-		Name:        file.Filename,
+		Hash:        contentHash,
+		Name:        src.Filename,
 		ContentType: contentType,
-		Size:        file.Size,
+		Size:        src.Size,
 		Category:    string(category),
 		Width:       width,
 		Height:      height,
 		UserID:      user.ID,
 	}
-	nowUTC := time.Now().UTC()
-	if err := s.fileRepository.Create(context.Background(), fileRecord); err != nil {
+
+	// 同一路由上已有同内容的对象时不再写存储，新行直接共享它的 key：每个 Echo 仍各持一行，
+	// 删除时按引用计数回收（见 DeleteStoredFile），而不是把已有的行交给第二个 Echo。
+	reused, err := s.reuseStoredContent(selector, targetStorageType, fileRecord)
+	if err != nil {
 		return commonModel.FileDto{}, err
 	}
+	if !reused {
+		gen := s.keyGenForCategory(category, src.Filename)
+		key, err := gen.GenerateKey(category, user.ID, src.Filename)
+		if err != nil {
+			return commonModel.FileDto{}, err
+		}
+
+		uploadReader, err := src.Open()
+		if err != nil {
+			return commonModel.FileDto{}, err
+		}
+		defer func() { _ = uploadReader.Close() }()
+
+		var opts []virefs.PutOption
+		if contentType != "" {
+			opts = append(opts, virefs.WithContentType(contentType))
+		}
+		// 大文件走分片写入（对象存储用 S3 Multipart），不支持分片的后端自动回退为普通 Put。
+		if err := selector.PutMultipart(
+			context.Background(), targetStorageType, key, uploadReader, uploadPartSize, opts...,
+		); err != nil {
+			return commonModel.FileDto{}, err
+		}
+
+		fileRecord.Key = key
+		fileRecord.URL = selector.ResolveURL(targetStorageType, key)
+		if err := s.fileRepository.Create(context.Background(), fileRecord); err != nil {
+			_ = selector.Delete(context.Background(), targetStorageType, key)
+			return commonModel.FileDto{}, err
+		}
+	}
+	key, fileURL := fileRecord.Key, fileRecord.URL
+
+	nowUTC := time.Now().UTC()
 	if err := s.fileRepository.CreateTemp(context.Background(), &fileModel.TempFile{
 		FileID:     fileRecord.ID,
 		UploaderID: user.ID,
@@ -191,15 +268,16 @@ func (s *FileService) UploadFile(
 		_ = s.DeleteStoredFile(fileRecord.StorageType, fileRecord.Key)
 		return commonModel.FileDto{}, err
 	}
+	committed = true
 
 	if err := eventbus.Emit(
 		context.Background(),
 		s.bus,
 		event.ResourceUploaded{
 			User:     user,
-			FileName: file.Filename,
+			FileName: src.Filename,
 			URL:      fileURL,
-			Size:     file.Size,
+			Size:     src.Size,
 			Type:     string(uploadType),
 			Key:      key,
 		},
@@ -209,13 +287,13 @@ func (s *FileService) UploadFile(
 
 	return commonModel.FileDto{
 		ID:          fileRecord.ID,
-		Name:        file.Filename,
+		Name:        src.Filename,
 		Key:         key,
 		StorageType: routeStorageType,
 		URL:         fileURL,
 		ContentType: contentType,
 		Category:    string(category),
-		Size:        file.Size,
+		Size:        src.Size,
 		Width:       width,
 		Height:      height,
 	}, nil
@@ -292,6 +370,7 @@ func (s *FileService) CreateExternalFile(
 			Size:        existing.Size,
 			Width:       existing.Width,
 			Height:      existing.Height,
+			AltText:     existing.AltText,
 		}, nil
 	}
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -349,6 +428,7 @@ func (s *FileService) CreateExternalFile(
 		Size:        0,
 		Width:       fileRecord.Width,
 		Height:      fileRecord.Height,
+		AltText:     fileRecord.AltText,
 	}, nil
 }
 
@@ -415,6 +495,7 @@ func (s *FileService) GetFileByID(ctx context.Context, id string) (commonModel.F
 		Size:        fileRecord.Size,
 		Width:       fileRecord.Width,
 		Height:      fileRecord.Height,
+		AltText:     fileRecord.AltText,
 	}, nil
 }
 
@@ -450,6 +531,7 @@ func (s *FileService) GetFilesByIDs(ctx context.Context, ids []string) ([]common
 			Size:        f.Size,
 			Width:       f.Width,
 			Height:      f.Height,
+			AltText:     f.AltText,
 		})
 	}
 	return dtos, nil
@@ -486,6 +568,22 @@ func (s *FileService) UpdateFileMeta(
 		return commonModel.FileDto{}, errors.New(commonModel.INVALID_PARAMS)
 	}
 
+	// 直传的字节不经过服务端，大小以存储里的对象为准，客户端上报的 dto.Size 不作数。
+	info, err := s.getSelector().Stat(context.Background(), storage.StorageTypeObject, fileRecord.Key)
+	if err != nil {
+		return commonModel.FileDto{}, err
+	}
+	owner := s.fileOwner(fileRecord, user)
+	delta := info.Size - fileRecord.Size
+	if delta > 0 {
+		if err := s.reserveUsage(context.Background(), owner, fileRecord.Category, delta, 0, ""); err != nil {
+			if err.Error() == commonModel.STORAGE_QUOTA_EXCEEDED {
+				s.discardDirectUpload(fileRecord)
+			}
+			return commonModel.FileDto{}, err
+		}
+	}
+
 	var contentTypePtr *string
 	if contentType := strings.TrimSpace(dto.ContentType); contentType != "" {
 		contentTypePtr = &contentType
@@ -494,14 +592,20 @@ func (s *FileService) UpdateFileMeta(
 	updated, err := s.fileRepository.UpdateMetaByID(
 		context.Background(),
 		id,
-		dto.Size,
+		info.Size,
 		dto.Width,
 		dto.Height,
 		contentTypePtr,
 	)
 	if err != nil {
+		if delta > 0 {
+			s.refundUsage(context.Background(), owner.ID, fileRecord.Category, delta, 0)
+		}
 		return commonModel.FileDto{}, err
 	}
+	if delta < 0 {
+		s.refundUsage(context.Background(), owner.ID, fileRecord.Category, -delta, 0)
+	}
 
 	return commonModel.FileDto{
 		ID:          updated.ID,
@@ -514,9 +618,29 @@ func (s *FileService) UpdateFileMeta(
 		Size:        updated.Size,
 		Width:       updated.Width,
 		Height:      updated.Height,
+		AltText:     updated.AltText,
 	}, nil
 }
 
+// UpdateFileAltText 更新文件的替代文本（仅管理员）
+func (s *FileService) UpdateFileAltText(ctx context.Context, id string, altText string) error {
+	userid := viewer.MustFromContext(ctx).UserID()
+	user, err := s.commonRepository.GetUserByUserId(context.Background(), userid)
+	if err != nil {
+		return err
+	}
+	if !user.IsAdmin {
+		return errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+	if strings.TrimSpace(id) == "" {
+		return errors.New(commonModel.INVALID_PARAMS)
+	}
+	if _, err := s.fileRepository.GetByID(context.Background(), id); err != nil {
+		return err
+	}
+	return s.fileRepository.UpdateAltTextByID(context.Background(), id, strings.TrimSpace(altText))
+}
+
 func (s *FileService) ListFiles(
 	ctx context.Context,
 	query commonModel.FileListQueryDto,
@@ -865,6 +989,17 @@ func (s *FileService) GetFilePresignURL(
 	if err := validateFileUploadByName(dto.FileName, contentType, config.Config().Upload.AllowedTypes); err != nil {
 		return result, err
 	}
+	// 直传前不知道文件大小：先只占一个文件数，额度已用满时直接拒绝；
+	// 实际大小在 UpdateFileMeta 按存储里的对象回填并记账。
+	if err := s.reserveUsage(context.Background(), user, string(category), 0, 1, ""); err != nil {
+		return result, err
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			s.refundUsage(context.Background(), user.ID, string(category), 0, 1)
+		}
+	}()
 
 	key, err := s.keyGen.GenerateKey(category, userid, dto.FileName)
 	if err != nil {
@@ -902,6 +1037,7 @@ func (s *FileService) GetFilePresignURL(
 		_ = s.fileRepository.Delete(context.Background(), fileRecord.ID)
 		return result, err
 	}
+	committed = true
 
 	result.ID = fileRecord.ID
 	result.FileName = dto.FileName
@@ -916,6 +1052,9 @@ func (s *FileService) CleanupOrphanFiles() error {
 	ctx := context.Background()
 	threshold := time.Now().UTC().Unix()
 	dryRun := isTempCleanupDryRun()
+	if !dryRun {
+		s.purgeExpiredResumableUploads(time.Now().UTC())
+	}
 
 	temps, err := s.fileRepository.ListExpiredTemps(ctx, threshold)
 	if err != nil {
@@ -950,7 +1089,7 @@ func (s *FileService) CleanupOrphanFiles() error {
 		}
 
 		if fileRecord.Key != "" && storage.NormalizeStorageType(fileRecord.StorageType) != storage.StorageTypeExternal {
-			if err := s.DeleteStoredFile(fileRecord.StorageType, fileRecord.Key); err != nil {
+			if err := s.releaseStoredFile(ctx, fileRecord.StorageType, fileRecord.Key, fileRecord.ID); err != nil {
 				logUtil.GetLogger().Warn(
 					"Failed to delete temp stored file",
 					slog.String("temp_id", temp.ID),
@@ -960,7 +1099,7 @@ func (s *FileService) CleanupOrphanFiles() error {
 				continue
 			}
 		}
-		if err := s.fileRepository.Delete(ctx, temp.FileID); err != nil {
+		if err := s.DeleteFileRecord(ctx, temp.FileID); err != nil {
 			logUtil.GetLogger().Warn(
 				"Failed to delete temp file record",
 				slog.String("temp_id", temp.ID),
@@ -1007,11 +1146,31 @@ func (s *FileService) ConfirmTempFiles(ctx context.Context, fileIDs []string) er
 	return nil
 }
 
+// DeleteFileRecord 删除文件行并退回它占用的存储用量；在调用方的事务内执行时两者一同提交。
 func (s *FileService) DeleteFileRecord(ctx context.Context, id string) error {
-	return s.fileRepository.Delete(ctx, id)
+	fileRecord, err := s.fileRepository.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if err := s.fileRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	return s.releaseUsage(ctx, fileRecord)
 }
 
+// DeleteStoredFile 删除当前路由上 key 对应的存储对象，调用方应先删掉文件行。
+// 内容去重后多行可以共享同一个 key：仍有行引用它时对象保留，最后一个引用者离开时才真正删除。
 func (s *FileService) DeleteStoredFile(storageType string, key string) error {
+	return s.releaseStoredFile(context.Background(), storageType, key, "")
+}
+
+// releaseStoredFile 在 key 不再被任何文件行引用时删除存储对象。excludeID 是调用方即将删除、
+// 但此刻仍在库里的那一行（CleanupOrphanFiles 先删对象、成功后才删行），不计入引用。
+// 数引用与删除在 key 锁内完成，见 reuseStoredContent。
+func (s *FileService) releaseStoredFile(ctx context.Context, storageType, key, excludeID string) error {
 	if key == "" {
 		return nil
 	}
@@ -1019,7 +1178,72 @@ func (s *FileService) DeleteStoredFile(storageType string, key string) error {
 	if normalizedStorageType == storage.StorageTypeExternal {
 		return nil
 	}
-	return s.getSelector().Delete(context.Background(), normalizedStorageType, key)
+	unlock := s.lockStoredKey(key)
+	defer unlock()
+
+	selector := s.getSelector()
+	routeStorageType, _, bucket := currentStorageRoute(selector, normalizedStorageType)
+	refs, err := s.fileRepository.CountByRouteKey(ctx, routeStorageType, bucket, key, excludeID)
+	if err != nil {
+		return err
+	}
+	if refs > 0 {
+		return nil
+	}
+	return selector.Delete(ctx, normalizedStorageType, key)
+}
+
+// reuseStoredContent 在 record 的路由上已有同内容（record.Hash）且对象仍在存储里时，让 record
+// 共享那个 key 并建行，返回是否复用。探测对象与建行在 key 锁内完成，与 releaseStoredFile 的
+// 数引用、删对象互斥：要么这一行先落库、删除方数到它而保留对象，要么删除在先、这里探测不到对象。
+// 查询或探测出错只会让这次上传退回正常写入，不影响上传本身。
+func (s *FileService) reuseStoredContent(
+	selector *storage.StorageSelector,
+	storageType storage.StorageType,
+	record *fileModel.File,
+) (bool, error) {
+	ctx := context.Background()
+	existing, err := s.fileRepository.GetByContentHash(ctx, record.StorageType, record.Bucket, record.Hash)
+	if err != nil || existing.Key == "" {
+		return false, nil
+	}
+	unlock := s.lockStoredKey(existing.Key)
+	defer unlock()
+
+	ok, err := selector.Exists(ctx, storageType, existing.Key)
+	if err != nil || !ok {
+		return false, nil
+	}
+	record.Key = existing.Key
+	record.URL = selector.ResolveURL(storageType, existing.Key)
+	if err := s.fileRepository.Create(ctx, record); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// lockStoredKey 锁住存储对象 key，返回解锁函数。锁按 key 的哈希分片，不同 key 偶尔共用一把，
+// 持锁期间不能再锁别的 key。
+func (s *FileService) lockStoredKey(key string) func() {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	mu := &s.storedKeyMu[h.Sum32()%uint32(len(s.storedKeyMu))]
+	mu.Lock()
+	return mu.Unlock
+}
+
+// hashUpload 计算上传内容的 SHA-256（hex）。
+func hashUpload(src uploadSource) (string, error) {
+	reader, err := src.Open()
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = reader.Close() }()
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, reader); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
 }
 
 func (s *FileService) keyGenForCategory(category storage.Category, fileName string) storage.KeyGenerator {
@@ -18,15 +18,18 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/lin-snow/ech0/internal/config"
+	"github.com/lin-snow/ech0/internal/kvstore"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
 	fileRepository "github.com/lin-snow/ech0/internal/repository/file"
 	fileService "github.com/lin-snow/ech0/internal/service/file"
 	"github.com/lin-snow/ech0/internal/storage"
 	"github.com/lin-snow/ech0/internal/test/helpers"
-	"github.com/lin-snow/ech0/internal/test/mocks/commonmock"
+	"github.com/lin-snow/ech0/internal/test/mocks/filemock"
 	"github.com/lin-snow/ech0/internal/transaction"
 	"github.com/lin-snow/ech0/pkg/busen"
+	"github.com/lin-snow/ech0/pkg/virefs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -41,22 +44,37 @@ const fileTestUserID = "user-test-0001"
 // storage manager + real gorm transactor, with only the user lookup mocked.
 type fileFix struct {
 	svc    *fileService.FileService
-	common *commonmock.MockCommonRepository
+	common *filemock.MockCommonRepository
 	repo   *fileRepository.FileRepository
 	db     *gorm.DB
 	mgr    *storage.Manager
+	kv     kvstore.Store
+	bus    *busen.Bus
 }
 
 func newFileFix(t *testing.T) *fileFix {
+	t.Helper()
+	return newFileFixWithStorage(t, helpers.NewTestStorage(t))
+}
+
+// newObjectFileFix 与 newFileFix 相同，但挂了一个本地目录模拟的对象存储，
+// 返回的 FS 用来模拟客户端直传的对象。
+func newObjectFileFix(t *testing.T) (*fileFix, virefs.FS) {
+	t.Helper()
+	mgr, objectFS := helpers.NewTestStorageWithObject(t)
+	return newFileFixWithStorage(t, mgr), objectFS
+}
+
+func newFileFixWithStorage(t *testing.T, mgr *storage.Manager) *fileFix {
 	t.Helper()
 	db := helpers.NewTestDB(t)
 	repo := fileRepository.NewFileRepository(func() *gorm.DB { return db })
 	tx := transaction.NewGormTransactor(func() *gorm.DB { return db })
-	mgr := helpers.NewTestStorage(t)
 	bus := helpers.NewTestBus(t)
-	common := commonmock.NewMockCommonRepository(t)
-	svc := fileService.NewFileService(tx, common, repo, mgr, func() *busen.Bus { return bus })
-	return &fileFix{svc: svc, common: common, repo: repo, db: db, mgr: mgr}
+	common := filemock.NewMockCommonRepository(t)
+	kv := kvstore.NewMemory()
+	svc := fileService.NewFileService(tx, common, repo, mgr, func() *busen.Bus { return bus }, kv)
+	return &fileFix{svc: svc, common: common, repo: repo, db: db, mgr: mgr, kv: kv, bus: bus}
 }
 
 // expectAdmin registers a single user lookup that resolves to an admin user.
@@ -228,6 +246,33 @@ func TestFileService_UploadFile(t *testing.T) {
 		assert.True(t, storedExists(t, fix.mgr, dto.Key))
 	})
 
+	t.Run("identical content shares the stored object", func(t *testing.T) {
+		fix := newFileFix(t)
+		first := fix.uploadPNG(t, "a.png", 5, 5)
+		second := fix.uploadPNG(t, "b.png", 5, 5)
+		other := fix.uploadPNG(t, "c.png", 6, 6)
+
+		assert.NotEqual(t, first.ID, second.ID)
+		assert.Equal(t, first.Key, second.Key)
+		assert.Equal(t, "b.png", second.Name)
+		assert.NotEqual(t, first.Key, other.Key)
+		assert.Equal(t, int64(3), countFiles(t, fix.db))
+
+		var row fileModel.File
+		require.NoError(t, fix.db.Where("id = ?", second.ID).First(&row).Error)
+		assert.Len(t, row.Hash, 64)
+	})
+
+	t.Run("lost object is written again instead of shared", func(t *testing.T) {
+		fix := newFileFix(t)
+		first := fix.uploadPNG(t, "a.png", 5, 5)
+		require.NoError(t, fix.mgr.GetSelector().Delete(context.Background(), storage.StorageTypeLocal, first.Key))
+
+		second := fix.uploadPNG(t, "b.png", 5, 5)
+		assert.NotEqual(t, first.Key, second.Key)
+		assert.True(t, storedExists(t, fix.mgr, second.Key))
+	})
+
 	t.Run("non-admin is denied", func(t *testing.T) {
 		fix := newFileFix(t)
 		fix.expectNonAdmin()
@@ -449,17 +494,28 @@ func TestFileService_UpdateFileMeta(t *testing.T) {
 			UserID:      fileTestUserID,
 		}
 		require.NoError(t, fix.db.Create(f).Error)
+		require.NoError(t, fix.db.Create(&fileModel.TempFile{
+			FileID:     f.ID,
+			UploaderID: fileTestUserID,
+			ExpireAt:   time.Now().Add(time.Hour).Unix(),
+		}).Error)
+		require.NoError(t, fix.repo.AddUsage(context.Background(), fileTestUserID, "image", 0, 1))
 		return f
 	}
+	putObject := func(t *testing.T, objectFS virefs.FS, size int) {
+		t.Helper()
+		require.NoError(t, objectFS.Put(context.Background(), "obj_key.png", bytes.NewReader(make([]byte, size))))
+	}
 
-	t.Run("success updates object metadata", func(t *testing.T) {
-		fix := newFileFix(t)
+	t.Run("success updates object metadata with the stored size", func(t *testing.T) {
+		fix, objectFS := newObjectFileFix(t)
 		f := newObjectFile(t, fix)
+		putObject(t, objectFS, 4096)
 		fix.expectAdmin()
 
 		w, h := 320, 240
 		dto, err := fix.svc.UpdateFileMeta(fix.adminCtx(), f.ID, commonModel.UpdateFileMetaDto{
-			Size:        4096,
+			Size:        1,
 			Width:       &w,
 			Height:      &h,
 			ContentType: "image/webp",
@@ -469,6 +525,36 @@ func TestFileService_UpdateFileMeta(t *testing.T) {
 		assert.Equal(t, 320, dto.Width)
 		assert.Equal(t, 240, dto.Height)
 		assert.Equal(t, "image/webp", dto.ContentType)
+		assert.Equal(t, int64(4096), fix.usageOf(t, fileTestUserID)["image"].Bytes)
+	})
+
+	t.Run("missing object rejected", func(t *testing.T) {
+		fix, _ := newObjectFileFix(t)
+		f := newObjectFile(t, fix)
+		fix.expectAdmin()
+
+		_, err := fix.svc.UpdateFileMeta(fix.adminCtx(), f.ID, commonModel.UpdateFileMetaDto{Size: 10})
+		require.Error(t, err)
+		assert.Equal(t, int64(0), fix.usageOf(t, fileTestUserID)["image"].Bytes)
+	})
+
+	t.Run("over quota discards the direct upload", func(t *testing.T) {
+		fix, objectFS := newObjectFileFix(t)
+		f := newObjectFile(t, fix)
+		putObject(t, objectFS, 4096)
+		fix.expectAdmin()
+		fix.setQuota(t, settingModel.StorageQuotaSetting{AdminQuota: 1024})
+
+		_, err := fix.svc.UpdateFileMeta(fix.adminCtx(), f.ID, commonModel.UpdateFileMetaDto{Size: 10})
+		require.EqualError(t, err, commonModel.STORAGE_QUOTA_EXCEEDED)
+		assert.Equal(t, int64(0), countFiles(t, fix.db))
+		assert.Equal(t, int64(0), countTemps(t, fix.db))
+		exists, err := objectFS.Exists(context.Background(), "obj_key.png")
+		require.NoError(t, err)
+		assert.False(t, exists)
+		usage := fix.usageOf(t, fileTestUserID)["image"]
+		assert.Equal(t, int64(0), usage.Bytes)
+		assert.Equal(t, int64(0), usage.Files)
 	})
 
 	t.Run("empty id rejected", func(t *testing.T) {
@@ -814,6 +900,21 @@ func TestFileService_DeleteFile(t *testing.T) {
 		assert.False(t, storedExists(t, fix.mgr, dto.Key))
 	})
 
+	t.Run("shared blob survives until the last reference is deleted", func(t *testing.T) {
+		fix := newFileFix(t)
+		first := fix.uploadPNG(t, "a.png", 3, 3)
+		second := fix.uploadPNG(t, "b.png", 3, 3)
+		require.Equal(t, first.Key, second.Key)
+
+		fix.expectAdmin()
+		require.NoError(t, fix.svc.DeleteFile(fix.adminCtx(), first.ID))
+		assert.True(t, storedExists(t, fix.mgr, first.Key))
+
+		require.NoError(t, fix.svc.DeleteFile(fix.adminCtx(), second.ID))
+		assert.False(t, storedExists(t, fix.mgr, first.Key))
+		assert.Equal(t, int64(0), countFiles(t, fix.db))
+	})
+
 	t.Run("external file removes record only", func(t *testing.T) {
 		fix := newFileFix(t)
 		ext := &fileModel.File{
@@ -868,13 +969,21 @@ func TestFileService_DeleteStoredFile(t *testing.T) {
 		require.NoError(t, fix.svc.DeleteStoredFile("external", "some/key"))
 	})
 
-	t.Run("local removes blob", func(t *testing.T) {
+	t.Run("local removes unreferenced blob", func(t *testing.T) {
 		fix := newFileFix(t)
 		dto := fix.uploadPNG(t, "photo.png", 3, 3)
 		require.True(t, storedExists(t, fix.mgr, dto.Key))
+		require.NoError(t, fix.svc.DeleteFileRecord(context.Background(), dto.ID))
 		require.NoError(t, fix.svc.DeleteStoredFile("local", dto.Key))
 		assert.False(t, storedExists(t, fix.mgr, dto.Key))
 	})
+
+	t.Run("blob still referenced by a row is kept", func(t *testing.T) {
+		fix := newFileFix(t)
+		dto := fix.uploadPNG(t, "photo.png", 3, 3)
+		require.NoError(t, fix.svc.DeleteStoredFile("local", dto.Key))
+		assert.True(t, storedExists(t, fix.mgr, dto.Key))
+	})
 }
 
 func TestFileService_DeleteFileRecord(t *testing.T) {
@@ -946,6 +1055,19 @@ func TestFileService_CleanupOrphanFiles(t *testing.T) {
 		assert.True(t, storedExists(t, fix.mgr, dto.Key))
 	})
 
+	t.Run("keeps blob shared with a confirmed upload", func(t *testing.T) {
+		fix := newFileFix(t)
+		kept := fix.uploadPNG(t, "a.png", 3, 3)
+		orphan := fix.uploadPNG(t, "b.png", 3, 3)
+		require.Equal(t, kept.Key, orphan.Key)
+		require.NoError(t, fix.svc.ConfirmTempFiles(context.Background(), []string{kept.ID}))
+		expireTemp(t, fix, orphan.ID)
+
+		require.NoError(t, fix.svc.CleanupOrphanFiles())
+		assert.Equal(t, int64(1), countFiles(t, fix.db))
+		assert.True(t, storedExists(t, fix.mgr, kept.Key))
+	})
+
 	t.Run("deletes expired unconfirmed external record", func(t *testing.T) {
 		fix := newFileFix(t)
 		fix.expectAdmin()
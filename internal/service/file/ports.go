@@ -5,11 +5,13 @@ package service
 
 import (
 	"context"
+	"io"
 	"mime/multipart"
 
 	"github.com/gin-gonic/gin"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
 	userModel "github.com/lin-snow/ech0/internal/model/user"
 	"github.com/lin-snow/ech0/internal/storage"
 )
@@ -21,6 +23,12 @@ type Service interface {
 		category storage.Category,
 		storageType storage.StorageType,
 	) (commonModel.FileDto, error)
+	// 断点续传（tus）：创建会话、查询偏移量、追加分片、放弃会话。
+	// AppendResumableUpload 在字节收齐后完成入库，返回的会话带 FileID。
+	CreateResumableUpload(ctx context.Context, in fileModel.ResumableUploadCreate) (fileModel.ResumableUpload, error)
+	GetResumableUpload(ctx context.Context, id string) (fileModel.ResumableUpload, error)
+	AppendResumableUpload(ctx context.Context, id string, offset int64, body io.Reader) (fileModel.ResumableUpload, error)
+	DeleteResumableUpload(ctx context.Context, id string) error
 	CreateExternalFile(ctx context.Context, dto commonModel.CreateExternalFileDto) (commonModel.FileDto, error)
 	DeleteFile(ctx context.Context, id string) error
 	GetFileByID(ctx context.Context, id string) (commonModel.FileDto, error)
@@ -28,6 +36,28 @@ type Service interface {
 	ListFiles(ctx context.Context, query commonModel.FileListQueryDto) (commonModel.FileListResultDto, error)
 	ListFileTree(ctx context.Context, query commonModel.FileTreeQueryDto) (commonModel.FileTreeResultDto, error)
 	UpdateFileMeta(ctx context.Context, id string, dto commonModel.UpdateFileMetaDto) (commonModel.FileDto, error)
+	UpdateFileAltText(ctx context.Context, id string, altText string) error
+	PrepareStorageMigration(ctx context.Context, payload *fileModel.StorageMigrationPayload) error
+	// MigrateStorage 把 payload.From 路由上的全部受管文件搬到 payload.To（存储迁移作业的执行体）。
+	// onProgress 非 nil 时每处理完一个文件回调累计进度；长循环尊重 ctx 取消。
+	MigrateStorage(
+		ctx context.Context,
+		payload fileModel.StorageMigrationPayload,
+		onProgress func(fileModel.StorageMigrationProgress),
+	) (fileModel.StorageMigrationProgress, error)
+	PrepareFileDedupe(ctx context.Context) error
+	// DedupeFiles 为存量受管文件回填内容哈希并合并同内容的重复对象（内容去重作业的执行体）。
+	DedupeFiles(
+		ctx context.Context,
+		onProgress func(fileModel.FileDedupeProgress),
+	) (fileModel.FileDedupeProgress, error)
+	// 存储配额：配额设置、按用户/分类的用量报表，以及按实际文件行重算用量账本。
+	// ReconcileStorageUsage 不做鉴权，供定时重算任务调用。
+	GetStorageQuotaSetting(ctx context.Context) (settingModel.StorageQuotaSetting, error)
+	UpdateStorageQuotaSetting(ctx context.Context, dto settingModel.StorageQuotaSettingDto) error
+	GetStorageUsage(ctx context.Context) (fileModel.StorageUsageReport, error)
+	RecountStorageUsage(ctx context.Context) (fileModel.StorageUsageRecount, error)
+	ReconcileStorageUsage() (fileModel.StorageUsageRecount, error)
 	StreamFileByID(ctx *gin.Context, id string)
 	StreamFileByPath(ctx *gin.Context, query commonModel.FilePathStreamQueryDto)
 	GetFilePresignURL(ctx context.Context, dto *commonModel.GetPresignURLDto) (commonModel.PresignDto, error)
@@ -39,6 +69,7 @@ type Service interface {
 
 type CommonRepository interface {
 	GetUserByUserId(ctx context.Context, id string) (userModel.User, error)
+	GetAllUsers(ctx context.Context) ([]userModel.User, error)
 }
 
 type FileRepository interface {
@@ -64,10 +95,27 @@ type FileRepository interface {
 		height *int,
 		contentType *string,
 	) (*fileModel.File, error)
+	UpdateAltTextByID(ctx context.Context, id string, altText string) error
+	CountByRoute(ctx context.Context, storageType, bucket string) (int64, error)
+	ListByRoute(ctx context.Context, storageType, bucket, afterID string, limit int) ([]fileModel.File, error)
+	UpdateRouteByID(ctx context.Context, id string, from, to fileModel.StorageRoute, url string) (bool, error)
+	CountByRouteKey(ctx context.Context, storageType, bucket, key, excludeID string) (int64, error)
+	GetByContentHash(ctx context.Context, storageType, bucket, hash string) (*fileModel.File, error)
+	ListUnhashed(ctx context.Context, afterID string, limit int) ([]fileModel.File, error)
+	UpdateHashByID(ctx context.Context, id string, hash string) error
+	ListDuplicateContentGroups(ctx context.Context) ([]fileModel.ContentGroup, error)
+	ListByContentGroup(ctx context.Context, group fileModel.ContentGroup) ([]fileModel.File, error)
+	UpdateKeyByID(ctx context.Context, id string, route fileModel.StorageRoute, fromKey, toKey, url string) (bool, error)
 	CreateTemp(ctx context.Context, temp *fileModel.TempFile) error
 	DeleteTempByFileID(ctx context.Context, fileID string) error
 	DeleteTempByID(ctx context.Context, id string) error
 	ListExpiredTemps(ctx context.Context, before int64) ([]fileModel.TempFile, error)
 	Delete(ctx context.Context, id string) error
 	DeleteByRoute(ctx context.Context, storageType, provider, bucket, key string) error
+	// 存储用量账本：按 (用户, 分类) 增量记账，定时按实际文件行整体重算。
+	AddUsage(ctx context.Context, userID, category string, bytes, files int64) error
+	SumUsageByUser(ctx context.Context, userID string) (int64, error)
+	ListUsage(ctx context.Context) ([]fileModel.StorageUsage, error)
+	AggregateUsage(ctx context.Context) ([]fileModel.StorageUsage, error)
+	ReplaceUsage(ctx context.Context, usages []fileModel.StorageUsage) error
 }
@@ -0,0 +1,383 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/event"
+	eventbus "github.com/lin-snow/ech0/internal/event/bus"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	"github.com/lin-snow/ech0/internal/storage"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"github.com/lin-snow/ech0/pkg/viewer"
+)
+
+// quotaWarnThresholds 是发出配额告警的用量百分比，从低到高。
+var quotaWarnThresholds = []int{80, 100}
+
+const (
+	quotaRoleOwner = "owner"
+	quotaRoleAdmin = "admin"
+	quotaRoleUser  = "user"
+)
+
+// quotaState 是某用户此刻的用量与生效额度，quota 为 0 表示不限。
+type quotaState struct {
+	used  int64
+	quota int64
+}
+
+// allows 判断再占用 size 字节后是否仍在额度内。
+func (q quotaState) allows(size int64) bool {
+	return q.quota <= 0 || q.used+size <= q.quota
+}
+
+// GetStorageQuotaSetting 返回用户存储配额设置（仅管理员）。
+func (s *FileService) GetStorageQuotaSetting(ctx context.Context) (settingModel.StorageQuotaSetting, error) {
+	userid := viewer.MustFromContext(ctx).UserID()
+	user, err := s.commonRepository.GetUserByUserId(context.Background(), userid)
+	if err != nil {
+		return settingModel.StorageQuotaSetting{}, err
+	}
+	if !user.IsAdmin {
+		return settingModel.StorageQuotaSetting{}, errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+	return coreSetting.Get(ctx, s.kv, coreSetting.StorageQuota)
+}
+
+// UpdateStorageQuotaSetting 更新用户存储配额设置（仅管理员）。额度下调不会删除已有文件，
+// 只是此后的上传会被拒绝，直到用量回落到额度以内。
+func (s *FileService) UpdateStorageQuotaSetting(
+	ctx context.Context,
+	dto settingModel.StorageQuotaSettingDto,
+) error {
+	userid := viewer.MustFromContext(ctx).UserID()
+	user, err := s.commonRepository.GetUserByUserId(context.Background(), userid)
+	if err != nil {
+		return err
+	}
+	if !user.IsAdmin {
+		return errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+	for id, quota := range dto.UserQuotas {
+		if strings.TrimSpace(id) == "" || quota < 0 {
+			return errors.New(commonModel.INVALID_PARAMS)
+		}
+	}
+	if dto.OwnerQuota < 0 || dto.AdminQuota < 0 || dto.UserQuota < 0 {
+		return errors.New(commonModel.INVALID_PARAMS)
+	}
+	return coreSetting.Set(ctx, s.kv, coreSetting.StorageQuota, settingModel.StorageQuotaSetting{
+		OwnerQuota: dto.OwnerQuota,
+		AdminQuota: dto.AdminQuota,
+		UserQuota:  dto.UserQuota,
+		UserQuotas: dto.UserQuotas,
+	})
+}
+
+// GetStorageUsage 返回全站按用户、分类拆分的存储用量与各自的生效额度（仅管理员）。
+// 数据取自用量账本；没有任何文件的用户也列出，便于核对额度。
+func (s *FileService) GetStorageUsage(ctx context.Context) (fileModel.StorageUsageReport, error) {
+	report := fileModel.StorageUsageReport{Users: []fileModel.UserStorageUsage{}}
+	userid := viewer.MustFromContext(ctx).UserID()
+	user, err := s.commonRepository.GetUserByUserId(context.Background(), userid)
+	if err != nil {
+		return report, err
+	}
+	if !user.IsAdmin {
+		return report, errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+	users, err := s.commonRepository.GetAllUsers(ctx)
+	if err != nil {
+		return report, err
+	}
+	usages, err := s.fileRepository.ListUsage(ctx)
+	if err != nil {
+		return report, err
+	}
+	quotaSetting := s.loadQuotaSetting(ctx)
+
+	byUser := make(map[string]*fileModel.UserStorageUsage, len(users))
+	order := make([]string, 0, len(users))
+	for _, user := range users {
+		byUser[user.ID] = &fileModel.UserStorageUsage{
+			UserID:     user.ID,
+			Username:   user.Username,
+			Role:       quotaRole(user),
+			Quota:      quotaFor(quotaSetting, user),
+			Categories: []fileModel.CategoryUsage{},
+		}
+		order = append(order, user.ID)
+	}
+	for _, usage := range usages {
+		if usage.Bytes == 0 && usage.Files == 0 {
+			continue
+		}
+		entry, ok := byUser[usage.UserID]
+		if !ok {
+			// 用户已删除但文件仍在：照样列出，占用的是站点空间。
+			entry = &fileModel.UserStorageUsage{UserID: usage.UserID, Categories: []fileModel.CategoryUsage{}}
+			byUser[usage.UserID] = entry
+			order = append(order, usage.UserID)
+		}
+		entry.Used += usage.Bytes
+		entry.Files += usage.Files
+		entry.Categories = append(entry.Categories, fileModel.CategoryUsage{
+			Category: usage.Category,
+			Bytes:    usage.Bytes,
+			Files:    usage.Files,
+		})
+		report.Used += usage.Bytes
+		report.Files += usage.Files
+	}
+
+	for _, id := range order {
+		report.Users = append(report.Users, *byUser[id])
+	}
+	sort.SliceStable(report.Users, func(i, j int) bool {
+		return report.Users[i].Used > report.Users[j].Used
+	})
+	return report, nil
+}
+
+// RecountStorageUsage 立即按实际文件行重算用量账本（仅管理员）。
+func (s *FileService) RecountStorageUsage(ctx context.Context) (fileModel.StorageUsageRecount, error) {
+	userid := viewer.MustFromContext(ctx).UserID()
+	user, err := s.commonRepository.GetUserByUserId(context.Background(), userid)
+	if err != nil {
+		return fileModel.StorageUsageRecount{}, err
+	}
+	if !user.IsAdmin {
+		return fileModel.StorageUsageRecount{}, errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+	return s.ReconcileStorageUsage()
+}
+
+// ReconcileStorageUsage 用 files 表的实际统计整体替换用量账本，校正导入、恢复、同步等
+// 绕过 FileService 的写入造成的漂移（定时重算任务的执行体）。统计与替换在同一事务内，
+// 不会与并发上传的记账交错。
+func (s *FileService) ReconcileStorageUsage() (fileModel.StorageUsageRecount, error) {
+	var result fileModel.StorageUsageRecount
+	err := s.transactor.Run(context.Background(), func(ctx context.Context) error {
+		actual, err := s.fileRepository.AggregateUsage(ctx)
+		if err != nil {
+			return err
+		}
+		recorded, err := s.fileRepository.ListUsage(ctx)
+		if err != nil {
+			return err
+		}
+		result = diffUsage(recorded, actual)
+		if result.Corrected == 0 {
+			return nil
+		}
+		return s.fileRepository.ReplaceUsage(ctx, actual)
+	})
+	if err != nil {
+		return fileModel.StorageUsageRecount{}, err
+	}
+	if result.Corrected > 0 {
+		logUtil.GetLogger().Info(
+			"Storage usage reconciled",
+			slog.Int("checked", result.Checked),
+			slog.Int("corrected", result.Corrected),
+		)
+	}
+	return result, nil
+}
+
+// diffUsage 统计账本 recorded 与实际 actual 之间不一致的 (用户, 分类) 条数；
+// 账本里多出来的非零行与缺失的行都算一条。
+func diffUsage(recorded, actual []fileModel.StorageUsage) fileModel.StorageUsageRecount {
+	type usageKey struct{ user, category string }
+	current := make(map[usageKey]fileModel.StorageUsage, len(recorded))
+	for _, usage := range recorded {
+		current[usageKey{usage.UserID, usage.Category}] = usage
+	}
+	result := fileModel.StorageUsageRecount{Checked: len(actual)}
+	for _, usage := range actual {
+		key := usageKey{usage.UserID, usage.Category}
+		if got, ok := current[key]; !ok || got.Bytes != usage.Bytes || got.Files != usage.Files {
+			result.Corrected++
+		}
+		delete(current, key)
+	}
+	for _, stale := range current {
+		if stale.Bytes != 0 || stale.Files != 0 {
+			result.Corrected++
+		}
+	}
+	return result
+}
+
+// quotaStateFor 读取用户当前的用量与生效额度。额度不限时不查账本。用量包含该用户未完成的
+// 断点续传会话按声明长度预占的字节；except 是正在组装入库的会话，它的字节改由账本计入。
+func (s *FileService) quotaStateFor(ctx context.Context, user userModel.User, except string) (quotaState, error) {
+	quota := quotaFor(s.loadQuotaSetting(ctx), user)
+	if quota <= 0 {
+		return quotaState{}, nil
+	}
+	used, err := s.fileRepository.SumUsageByUser(ctx, user.ID)
+	if err != nil {
+		return quotaState{}, err
+	}
+	_, pending := pendingResumableUploads(resumableDir(), user.ID, except, time.Now().UTC())
+	return quotaState{used: used + pending, quota: quota}, nil
+}
+
+// reserveUsage 在写入前把 bytes/files 记入 user 的用量账本：校验与记账在 quotaMu 下一次完成，
+// 并发上传不会同时通过同一份余量。超额时发出 100% 告警（Rejected）并拒绝；用量因此越过
+// 告警阈值时发出告警（一次写入跨过多档只报最高的一档）。bytes 为 0 的预留（直传建行时还不知道大小）
+// 至少要求额度没有用满。写入失败时调用方用 refundUsage 退回。session 是断点续传组装入库时
+// 对应的会话 ID，其余调用传空。
+func (s *FileService) reserveUsage(
+	ctx context.Context,
+	user userModel.User,
+	category string,
+	bytes, files int64,
+	session string,
+) error {
+	s.quotaMu.Lock()
+	defer s.quotaMu.Unlock()
+
+	before, err := s.quotaStateFor(ctx, user, session)
+	if err != nil {
+		return err
+	}
+	if !before.allows(max(bytes, 1)) {
+		s.emitQuotaWarning(user, before, quotaWarnThresholds[len(quotaWarnThresholds)-1], true)
+		return errors.New(commonModel.STORAGE_QUOTA_EXCEEDED)
+	}
+	if err := s.fileRepository.AddUsage(ctx, user.ID, category, bytes, files); err != nil {
+		return err
+	}
+	if before.quota <= 0 || bytes <= 0 {
+		return nil
+	}
+	after := quotaState{used: before.used + bytes, quota: before.quota}
+	crossed := 0
+	for _, threshold := range quotaWarnThresholds {
+		limit := before.quota * int64(threshold) / 100
+		if before.used < limit && after.used >= limit {
+			crossed = threshold
+		}
+	}
+	if crossed > 0 {
+		s.emitQuotaWarning(user, after, crossed, false)
+	}
+	return nil
+}
+
+// refundUsage 退回 reserveUsage 预留、但最终没有落成文件行的用量。失败只记日志：
+// 账本的偏差由定时重算校正。
+func (s *FileService) refundUsage(ctx context.Context, userID, category string, bytes, files int64) {
+	if err := s.fileRepository.AddUsage(ctx, userID, category, -bytes, -files); err != nil {
+		logUtil.GetLogger().Warn(
+			"Failed to refund storage usage",
+			slog.String("user_id", userID),
+			slog.String("category", category),
+			logUtil.Err(err),
+		)
+	}
+}
+
+// fileOwner 返回文件的上传者，用量记在上传者名下；editor 就是上传者时免去一次查询。
+// 上传者已不存在时只保留 ID，按普通用户的额度计。
+func (s *FileService) fileOwner(file *fileModel.File, editor userModel.User) userModel.User {
+	if file.UserID == editor.ID {
+		return editor
+	}
+	owner, err := s.commonRepository.GetUserByUserId(context.Background(), file.UserID)
+	if err != nil {
+		return userModel.User{ID: file.UserID}
+	}
+	return owner
+}
+
+// discardDirectUpload 删除超出额度的直传：文件行（连同建行时占的用量）、临时记录与存储对象。
+func (s *FileService) discardDirectUpload(file *fileModel.File) {
+	ctx := context.Background()
+	err := s.transactor.Run(ctx, func(txCtx context.Context) error {
+		if err := s.DeleteFileRecord(txCtx, file.ID); err != nil {
+			return err
+		}
+		return s.fileRepository.DeleteTempByFileID(txCtx, file.ID)
+	})
+	if err == nil {
+		err = s.DeleteStoredFile(file.StorageType, file.Key)
+	}
+	if err != nil {
+		logUtil.GetLogger().Warn(
+			"Failed to discard over-quota direct upload",
+			slog.String("file_id", file.ID),
+			logUtil.Err(err),
+		)
+	}
+}
+
+// releaseUsage 在文件行删除后退回它占用的用量，外链文件不入账故跳过。
+func (s *FileService) releaseUsage(ctx context.Context, file *fileModel.File) error {
+	if storage.NormalizeStorageType(file.StorageType) == storage.StorageTypeExternal {
+		return nil
+	}
+	return s.fileRepository.AddUsage(ctx, file.UserID, file.Category, -file.Size, -1)
+}
+
+func (s *FileService) emitQuotaWarning(user userModel.User, state quotaState, threshold int, rejected bool) {
+	if err := eventbus.Emit(context.Background(), s.bus, event.StorageQuotaWarning{
+		User:      user,
+		Used:      state.used,
+		Quota:     state.quota,
+		Threshold: threshold,
+		Rejected:  rejected,
+	}); err != nil {
+		logUtil.GetLogger().Error("Failed to publish storage quota warning event", logUtil.Err(err))
+	}
+}
+
+// loadQuotaSetting 读取配额设置；读取失败时 setting.Get 仍返回默认值（不限），只记日志，
+// 不因配置存储的故障拦下上传。
+func (s *FileService) loadQuotaSetting(ctx context.Context) settingModel.StorageQuotaSetting {
+	quotaSetting, err := coreSetting.Get(ctx, s.kv, coreSetting.StorageQuota)
+	if err != nil {
+		logUtil.GetLogger().Warn("Failed to load storage quota setting", logUtil.Err(err))
+	}
+	return quotaSetting
+}
+
+// quotaFor 返回用户的生效额度：单独设置优先，否则取所属角色的默认额度。
+func quotaFor(quotaSetting settingModel.StorageQuotaSetting, user userModel.User) int64 {
+	if quota, ok := quotaSetting.UserQuotas[user.ID]; ok {
+		return quota
+	}
+	switch quotaRole(user) {
+	case quotaRoleOwner:
+		return quotaSetting.OwnerQuota
+	case quotaRoleAdmin:
+		return quotaSetting.AdminQuota
+	default:
+		return quotaSetting.UserQuota
+	}
+}
+
+func quotaRole(user userModel.User) string {
+	switch {
+	case user.IsOwner:
+		return quotaRoleOwner
+	case user.IsAdmin:
+		return quotaRoleAdmin
+	default:
+		return quotaRoleUser
+	}
+}
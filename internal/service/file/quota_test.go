@@ -0,0 +1,262 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/event"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	"github.com/lin-snow/ech0/internal/storage"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/lin-snow/ech0/pkg/busen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func (f *fileFix) setQuota(t *testing.T, quota settingModel.StorageQuotaSetting) {
+	t.Helper()
+	require.NoError(t, coreSetting.Set(context.Background(), f.kv, coreSetting.StorageQuota, quota))
+}
+
+// captureQuotaWarnings 收集总线上的配额告警（默认订阅是同步投递）。
+func (f *fileFix) captureQuotaWarnings(t *testing.T) *[]event.StorageQuotaWarning {
+	t.Helper()
+	var got []event.StorageQuotaWarning
+	unsub, err := busen.Subscribe(f.bus, func(_ context.Context, e busen.Event[event.StorageQuotaWarning]) error {
+		got = append(got, e.Value)
+		return nil
+	})
+	require.NoError(t, err)
+	t.Cleanup(unsub)
+	return &got
+}
+
+func (f *fileFix) usageOf(t *testing.T, userID string) map[string]fileModel.StorageUsage {
+	t.Helper()
+	usages, err := f.repo.ListUsage(context.Background())
+	require.NoError(t, err)
+	out := make(map[string]fileModel.StorageUsage)
+	for _, u := range usages {
+		if u.UserID == userID {
+			out[u.Category] = u
+		}
+	}
+	return out
+}
+
+func TestFileService_StorageQuota(t *testing.T) {
+	t.Run("uploads are charged, warned at 80% and rejected past the quota", func(t *testing.T) {
+		fix := newFileFix(t)
+		size := int64(len(pngBytes(t, 9, 7)))
+		fix.setQuota(t, settingModel.StorageQuotaSetting{AdminQuota: size * 5 / 4})
+		warnings := fix.captureQuotaWarnings(t)
+
+		fix.uploadPNG(t, "a.png", 9, 7)
+		usage := fix.usageOf(t, fileTestUserID)["image"]
+		assert.Equal(t, size, usage.Bytes)
+		assert.Equal(t, int64(1), usage.Files)
+		require.Len(t, *warnings, 1)
+		assert.Equal(t, 80, (*warnings)[0].Threshold)
+		assert.False(t, (*warnings)[0].Rejected)
+		assert.Equal(t, size, (*warnings)[0].Used)
+
+		header := makeFileHeader(t, "b.png", pngBytes(t, 9, 7))
+		_, err := fix.svc.UploadFile(fix.adminCtx(), header, storage.CategoryImage, storage.StorageTypeLocal)
+		require.EqualError(t, err, commonModel.STORAGE_QUOTA_EXCEEDED)
+		assert.Equal(t, int64(1), countFiles(t, fix.db))
+		require.Len(t, *warnings, 2)
+		assert.Equal(t, 100, (*warnings)[1].Threshold)
+		assert.True(t, (*warnings)[1].Rejected)
+	})
+
+	t.Run("owner role default and per-user override", func(t *testing.T) {
+		fix := newFileFix(t)
+		fix.common.EXPECT().
+			GetUserByUserId(mock.Anything, fileTestUserID).
+			Return(helpers.NewUser(helpers.AsOwner), nil)
+		// 站长默认额度很小，但单独设置为 0（不限）覆盖了它。
+		fix.setQuota(t, settingModel.StorageQuotaSetting{
+			OwnerQuota: 1,
+			UserQuotas: map[string]int64{fileTestUserID: 0},
+		})
+		header := makeFileHeader(t, "a.png", pngBytes(t, 9, 7))
+		_, err := fix.svc.UploadFile(fix.adminCtx(), header, storage.CategoryImage, storage.StorageTypeLocal)
+		require.NoError(t, err)
+
+		fix.setQuota(t, settingModel.StorageQuotaSetting{OwnerQuota: 1})
+		header = makeFileHeader(t, "b.png", pngBytes(t, 9, 8))
+		_, err = fix.svc.UploadFile(fix.adminCtx(), header, storage.CategoryImage, storage.StorageTypeLocal)
+		require.EqualError(t, err, commonModel.STORAGE_QUOTA_EXCEEDED)
+	})
+
+	t.Run("concurrent uploads cannot overshoot the quota", func(t *testing.T) {
+		fix := newFileFix(t)
+		fix.expectAdmin()
+		size := int64(len(pngBytes(t, 9, 7)))
+		fix.setQuota(t, settingModel.StorageQuotaSetting{AdminQuota: size * 3 / 2})
+
+		var wg sync.WaitGroup
+		errs := make([]error, 4)
+		for i := range errs {
+			header := makeFileHeader(t, fmt.Sprintf("%d.png", i), pngBytes(t, 9, 7))
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, errs[i] = fix.svc.UploadFile(fix.adminCtx(), header, storage.CategoryImage, storage.StorageTypeLocal)
+			}()
+		}
+		wg.Wait()
+
+		succeeded := 0
+		for _, err := range errs {
+			if err == nil {
+				succeeded++
+				continue
+			}
+			assert.EqualError(t, err, commonModel.STORAGE_QUOTA_EXCEEDED)
+		}
+		assert.Equal(t, 1, succeeded)
+		assert.Equal(t, size, fix.usageOf(t, fileTestUserID)["image"].Bytes)
+	})
+
+	t.Run("deletes release usage and external files are not charged", func(t *testing.T) {
+		fix := newFileFix(t)
+		dto := fix.uploadPNG(t, "a.png", 9, 7)
+		_, err := fix.svc.CreateExternalFile(fix.adminCtx(), commonModel.CreateExternalFileDto{
+			URL:      "https://example.com/pic.png",
+			Category: "image",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, dto.Size, fix.usageOf(t, fileTestUserID)["image"].Bytes)
+
+		require.NoError(t, fix.svc.DeleteFile(fix.adminCtx(), dto.ID))
+		usage := fix.usageOf(t, fileTestUserID)["image"]
+		assert.Equal(t, int64(0), usage.Bytes)
+		assert.Equal(t, int64(0), usage.Files)
+	})
+
+	t.Run("resumable sessions reserve their declared length until finished or aborted", func(t *testing.T) {
+		useResumableDir(t)
+		fix := newFileFix(t)
+		content := pngBytes(t, 9, 7)
+		size := int64(len(content))
+		fix.setQuota(t, settingModel.StorageQuotaSetting{AdminQuota: size * 3 / 2})
+
+		first := fix.createResumable(t, "a.png", size)
+		_, err := fix.svc.CreateResumableUpload(fix.adminCtx(), fileModel.ResumableUploadCreate{
+			Filename: "b.png", Category: "image", Length: size,
+		})
+		require.EqualError(t, err, commonModel.STORAGE_QUOTA_EXCEEDED)
+		header := makeFileHeader(t, "c.png", content)
+		_, err = fix.svc.UploadFile(fix.adminCtx(), header, storage.CategoryImage, storage.StorageTypeLocal)
+		require.EqualError(t, err, commonModel.STORAGE_QUOTA_EXCEEDED)
+
+		// 放弃会话即释放预占；完成时会话自身的预占不与入库的记账重复计算。
+		require.NoError(t, fix.svc.DeleteResumableUpload(fix.adminCtx(), first.ID))
+		second := fix.createResumable(t, "b.png", size)
+		got, err := fix.svc.AppendResumableUpload(fix.adminCtx(), second.ID, 0, bytes.NewReader(content))
+		require.NoError(t, err)
+		assert.True(t, got.Completed())
+		assert.Equal(t, size, fix.usageOf(t, fileTestUserID)["image"].Bytes)
+	})
+
+	t.Run("setting update validates and requires admin", func(t *testing.T) {
+		fix := newFileFix(t)
+		fix.expectAdmin()
+		err := fix.svc.UpdateStorageQuotaSetting(fix.adminCtx(), settingModel.StorageQuotaSettingDto{AdminQuota: -1})
+		require.EqualError(t, err, commonModel.INVALID_PARAMS)
+
+		require.NoError(t, fix.svc.UpdateStorageQuotaSetting(fix.adminCtx(), settingModel.StorageQuotaSettingDto{
+			UserQuota:  1 << 30,
+			UserQuotas: map[string]int64{"u-2": 5 << 20},
+		}))
+		got, err := fix.svc.GetStorageQuotaSetting(fix.adminCtx())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1<<30), got.UserQuota)
+		assert.Equal(t, int64(5<<20), got.UserQuotas["u-2"])
+
+		other := newFileFix(t)
+		other.expectNonAdmin()
+		err = other.svc.UpdateStorageQuotaSetting(other.adminCtx(), settingModel.StorageQuotaSettingDto{})
+		require.EqualError(t, err, commonModel.NO_PERMISSION_DENIED)
+	})
+}
+
+func TestFileService_GetStorageUsage(t *testing.T) {
+	fix := newFileFix(t)
+	fix.expectAdmin()
+	admin := helpers.NewUser(helpers.AsAdmin)
+	writer := userModel.User{ID: "u-2", Username: "writer"}
+	fix.common.EXPECT().GetAllUsers(mock.Anything).Return([]userModel.User{admin, writer}, nil)
+	fix.setQuota(t, settingModel.StorageQuotaSetting{
+		AdminQuota: 1000,
+		UserQuota:  500,
+		UserQuotas: map[string]int64{"u-2": 2000},
+	})
+
+	ctx := context.Background()
+	require.NoError(t, fix.repo.AddUsage(ctx, admin.ID, "image", 100, 2))
+	require.NoError(t, fix.repo.AddUsage(ctx, "u-2", "image", 300, 1))
+	require.NoError(t, fix.repo.AddUsage(ctx, "u-2", "video", 700, 1))
+	require.NoError(t, fix.repo.AddUsage(ctx, "u-gone", "audio", 50, 1))
+
+	report, err := fix.svc.GetStorageUsage(fix.adminCtx())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1150), report.Used)
+	assert.Equal(t, int64(5), report.Files)
+	require.Len(t, report.Users, 3)
+
+	top := report.Users[0]
+	assert.Equal(t, "u-2", top.UserID)
+	assert.Equal(t, "user", top.Role)
+	assert.Equal(t, int64(2000), top.Quota)
+	assert.Equal(t, int64(1000), top.Used)
+	assert.ElementsMatch(t, []fileModel.CategoryUsage{
+		{Category: "image", Bytes: 300, Files: 1},
+		{Category: "video", Bytes: 700, Files: 1},
+	}, top.Categories)
+
+	assert.Equal(t, admin.ID, report.Users[1].UserID)
+	assert.Equal(t, "admin", report.Users[1].Role)
+	assert.Equal(t, int64(1000), report.Users[1].Quota)
+	assert.Equal(t, "u-gone", report.Users[2].UserID)
+}
+
+func TestFileService_ReconcileStorageUsage(t *testing.T) {
+	fix := newFileFix(t)
+	dto := fix.uploadPNG(t, "a.png", 9, 7)
+
+	// 绕过 FileService 写入的行（如导入）与账本里凭空多出的用量，都应被重算校正。
+	require.NoError(t, fix.db.Create(&fileModel.File{
+		Key: "videos/imported.mp4", StorageType: "local", Category: "video", Size: 4096, UserID: fileTestUserID,
+	}).Error)
+	require.NoError(t, fix.db.Create(&fileModel.File{
+		Key: "external/x", StorageType: "external", Category: "image", Size: 999, UserID: fileTestUserID,
+	}).Error)
+	require.NoError(t, fix.repo.AddUsage(context.Background(), "u-stale", "image", 10, 1))
+
+	result, err := fix.svc.ReconcileStorageUsage()
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Checked)
+	assert.Equal(t, 2, result.Corrected)
+
+	usage := fix.usageOf(t, fileTestUserID)
+	assert.Equal(t, dto.Size, usage["image"].Bytes)
+	assert.Equal(t, int64(4096), usage["video"].Bytes)
+	assert.Empty(t, fix.usageOf(t, "u-stale"))
+
+	again, err := fix.svc.ReconcileStorageUsage()
+	require.NoError(t, err)
+	assert.Equal(t, 0, again.Corrected)
+}
@@ -0,0 +1,369 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"log/slog"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/config"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	"github.com/lin-snow/ech0/internal/storage"
+	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"github.com/lin-snow/ech0/pkg/viewer"
+)
+
+const (
+	defaultResumableDir = "data/tmp/uploads"
+	// resumableUploadTTL 是断点续传会话的有效期，每次成功写入分片后顺延。
+	resumableUploadTTL = 24 * time.Hour
+	resumableInfoExt   = ".info"
+	resumablePartExt   = ".part"
+	// maxResumableUploadsPerUser 是每个用户同时未完成的断点续传会话上限。
+	maxResumableUploadsPerUser = 8
+)
+
+// CreateResumableUpload 创建一个断点续传会话（tus creation）。此时只有文件名与声明的长度：
+// 按扩展名预检类型、按分类上限拦截过大的文件，免得客户端传完几百 MB 才被拒；
+// 内容嗅探与最终校验在字节收齐后走与 UploadFile 相同的 storeUpload。
+// 声明的长度在创建时即计入该用户的额度，会话完成、放弃或过期后释放；未完成的会话数有上限，
+// 免得靠大量空会话占住额度或磁盘。
+func (s *FileService) CreateResumableUpload(
+	ctx context.Context,
+	in fileModel.ResumableUploadCreate,
+) (fileModel.ResumableUpload, error) {
+	userID := viewer.MustFromContext(ctx).UserID()
+	user, err := s.commonRepository.GetUserByUserId(context.Background(), userID)
+	if err != nil {
+		return fileModel.ResumableUpload{}, err
+	}
+	if !user.IsAdmin {
+		return fileModel.ResumableUpload{}, errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+
+	filename := strings.TrimSpace(in.Filename)
+	if err := validateFileUploadByName(
+		filename, canonicalMIMEForExt(filename), config.Config().Upload.AllowedTypes,
+	); err != nil {
+		return fileModel.ResumableUpload{}, err
+	}
+	category := storage.NormalizeCategory(in.Category)
+	if in.Length <= 0 {
+		return fileModel.ResumableUpload{}, errors.New(commonModel.UPLOAD_LENGTH_INVALID)
+	}
+	if maxSize, _ := uploadPolicyFor(category); in.Length > int64(maxSize) {
+		return fileModel.ResumableUpload{}, errors.New(commonModel.FILE_SIZE_EXCEED_LIMIT)
+	}
+
+	dir := resumableDir()
+	now := time.Now().UTC()
+	// 校验与写入 .info 都在 quotaMu 下完成，并发创建的会话不会同时通过同一份余量。
+	// 这里不发告警；字节收齐后 storeUpload 会按当时的用量再校验一次。
+	s.quotaMu.Lock()
+	defer s.quotaMu.Unlock()
+	if open, _ := pendingResumableUploads(dir, user.ID, "", now); open >= maxResumableUploadsPerUser {
+		return fileModel.ResumableUpload{}, errors.New(commonModel.UPLOAD_SESSION_LIMIT)
+	}
+	quota, err := s.quotaStateFor(ctx, user, "")
+	if err != nil {
+		return fileModel.ResumableUpload{}, err
+	}
+	if !quota.allows(in.Length) {
+		return fileModel.ResumableUpload{}, errors.New(commonModel.STORAGE_QUOTA_EXCEEDED)
+	}
+
+	id, err := uuidUtil.NewV7()
+	if err != nil {
+		return fileModel.ResumableUpload{}, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fileModel.ResumableUpload{}, err
+	}
+	upload := fileModel.ResumableUpload{
+		ID:          id,
+		Filename:    filename,
+		Category:    string(category),
+		StorageType: string(storage.NormalizeStorageType(in.StorageType)),
+		Length:      in.Length,
+		UserID:      user.ID,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(resumableUploadTTL),
+	}
+	part, err := os.OpenFile(resumablePath(dir, id, resumablePartExt), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fileModel.ResumableUpload{}, err
+	}
+	_ = part.Close()
+	if err := writeResumableInfo(dir, upload); err != nil {
+		_ = os.Remove(resumablePath(dir, id, resumablePartExt))
+		return fileModel.ResumableUpload{}, err
+	}
+	return upload, nil
+}
+
+// GetResumableUpload 返回会话当前的偏移量与状态（tus HEAD）。会话只对创建者可见。
+func (s *FileService) GetResumableUpload(ctx context.Context, id string) (fileModel.ResumableUpload, error) {
+	return loadOwnedResumableUpload(resumableDir(), id, viewer.MustFromContext(ctx).UserID())
+}
+
+// AppendResumableUpload 把 body 追加到会话的 offset 处（tus PATCH）。offset 必须等于服务端
+// 已收到的字节数；超出声明长度的部分被截断。字节收齐后立即组装入库，返回的会话带 FileID。
+// 入库失败（如嗅探出的类型不被允许）时会话随之作废，客户端需重新上传。
+func (s *FileService) AppendResumableUpload(
+	ctx context.Context,
+	id string,
+	offset int64,
+	body io.Reader,
+) (fileModel.ResumableUpload, error) {
+	userID := viewer.MustFromContext(ctx).UserID()
+	dir := resumableDir()
+	if !s.lockResumable(id) {
+		return fileModel.ResumableUpload{}, errors.New(commonModel.UPLOAD_SESSION_BUSY)
+	}
+	defer s.unlockResumable(id)
+
+	upload, err := loadOwnedResumableUpload(dir, id, userID)
+	if err != nil {
+		return fileModel.ResumableUpload{}, err
+	}
+	if upload.Completed() || offset != upload.Offset {
+		return upload, errors.New(commonModel.UPLOAD_OFFSET_MISMATCH)
+	}
+
+	partPath := resumablePath(dir, id, resumablePartExt)
+	part, err := os.OpenFile(partPath, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fileModel.ResumableUpload{}, err
+	}
+	// 连接中途断开时已写入的字节照样保留，客户端 HEAD 拿到新偏移量后从断点续传。
+	written, copyErr := io.Copy(part, io.LimitReader(body, upload.Length-upload.Offset))
+	closeErr := part.Close()
+	upload.Offset += written
+	upload.ExpiresAt = time.Now().UTC().Add(resumableUploadTTL)
+	if err := writeResumableInfo(dir, upload); err != nil {
+		return upload, err
+	}
+	if copyErr != nil {
+		return upload, copyErr
+	}
+	if closeErr != nil {
+		return upload, closeErr
+	}
+	if upload.Offset < upload.Length {
+		return upload, nil
+	}
+
+	user, err := s.commonRepository.GetUserByUserId(context.Background(), upload.UserID)
+	if err != nil {
+		return upload, err
+	}
+	fileDto, err := s.storeUpload(user, uploadSource{
+		Filename:  upload.Filename,
+		Size:      upload.Length,
+		Open:      func() (multipart.File, error) { return os.Open(partPath) },
+		SessionID: id,
+	}, storage.NormalizeCategory(upload.Category), storage.StorageType(upload.StorageType))
+	if err != nil {
+		removeResumableUpload(dir, id)
+		return upload, err
+	}
+
+	// 分片文件已无用；.info 保留到过期，让完成后重发的 HEAD 仍能拿到终态与 FileID。
+	_ = os.Remove(partPath)
+	upload.FileID = fileDto.ID
+	if err := writeResumableInfo(dir, upload); err != nil {
+		logUtil.GetLogger().Warn(
+			"Failed to record completed resumable upload",
+			slog.String("upload_id", id),
+			slog.String("file_id", fileDto.ID),
+			logUtil.Err(err),
+		)
+	}
+	return upload, nil
+}
+
+// DeleteResumableUpload 放弃一个会话并删除已收到的分片（tus termination）。
+// 已完成的会话只删会话本身，入库的文件照常走临时文件的确认与清理流程。
+func (s *FileService) DeleteResumableUpload(ctx context.Context, id string) error {
+	userID := viewer.MustFromContext(ctx).UserID()
+	dir := resumableDir()
+	if !s.lockResumable(id) {
+		return errors.New(commonModel.UPLOAD_SESSION_BUSY)
+	}
+	defer s.unlockResumable(id)
+
+	if _, err := loadOwnedResumableUpload(dir, id, userID); err != nil {
+		return err
+	}
+	removeResumableUpload(dir, id)
+	return nil
+}
+
+// purgeExpiredResumableUploads 删除过期会话及其分片，连同找不到 .info 的孤儿分片，
+// 由 CleanupOrphanFiles 顺带调用。正在写入的会话跳过，留给下一轮。
+func (s *FileService) purgeExpiredResumableUploads(now time.Time) {
+	dir := resumableDir()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			logUtil.GetLogger().Warn("Failed to list resumable uploads", logUtil.Err(err))
+		}
+		return
+	}
+
+	var purged int
+	for _, entry := range entries {
+		name := entry.Name()
+		ext := filepath.Ext(name)
+		id := strings.TrimSuffix(name, ext)
+		if entry.IsDir() || !uuidUtil.IsValid(id) || !s.lockResumable(id) {
+			continue
+		}
+		expired := false
+		switch ext {
+		case resumableInfoExt:
+			upload, err := readResumableInfo(dir, id)
+			expired = err != nil || now.After(upload.ExpiresAt)
+		case resumablePartExt:
+			if _, err := os.Stat(resumablePath(dir, id, resumableInfoExt)); errors.Is(err, fs.ErrNotExist) {
+				info, err := entry.Info()
+				expired = err == nil && now.Sub(info.ModTime()) > resumableUploadTTL
+			}
+		}
+		if expired {
+			removeResumableUpload(dir, id)
+			purged++
+		}
+		s.unlockResumable(id)
+	}
+	if purged > 0 {
+		logUtil.GetLogger().Info("Expired resumable uploads purged", slog.Int("purged", purged))
+	}
+}
+
+// lockResumable 为会话加写锁，已被占用时立即返回 false：tus 要求同一会话的并发 PATCH
+// 直接拒绝，而不是排队。
+func (s *FileService) lockResumable(id string) bool {
+	s.resumableMu.Lock()
+	defer s.resumableMu.Unlock()
+	if _, busy := s.resumableBusy[id]; busy {
+		return false
+	}
+	s.resumableBusy[id] = struct{}{}
+	return true
+}
+
+func (s *FileService) unlockResumable(id string) {
+	s.resumableMu.Lock()
+	delete(s.resumableBusy, id)
+	s.resumableMu.Unlock()
+}
+
+// loadOwnedResumableUpload 读取会话并校验归属与有效期。他人的、过期的和不存在的会话
+// 一律按不存在处理，不泄露会话 ID 是否有效。
+func loadOwnedResumableUpload(dir, id, userID string) (fileModel.ResumableUpload, error) {
+	notFound := errors.New(commonModel.UPLOAD_SESSION_NOT_FOUND)
+	if !uuidUtil.IsValid(id) {
+		return fileModel.ResumableUpload{}, notFound
+	}
+	upload, err := readResumableInfo(dir, id)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fileModel.ResumableUpload{}, notFound
+		}
+		return fileModel.ResumableUpload{}, err
+	}
+	if upload.UserID != userID || time.Now().UTC().After(upload.ExpiresAt) {
+		return fileModel.ResumableUpload{}, notFound
+	}
+	if upload.Completed() {
+		upload.Offset = upload.Length
+		return upload, nil
+	}
+	info, err := os.Stat(resumablePath(dir, id, resumablePartExt))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fileModel.ResumableUpload{}, notFound
+		}
+		return fileModel.ResumableUpload{}, err
+	}
+	upload.Offset = info.Size()
+	return upload, nil
+}
+
+// pendingResumableUploads 统计 userID 名下未完成且未过期的会话数与声明长度之和，
+// 跳过 except。已完成的会话由用量账本计入，过期的不再占额度，等清理任务删除。
+func pendingResumableUploads(dir, userID, except string, now time.Time) (int, int64) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, 0
+	}
+	var count int
+	var bytes int64
+	for _, entry := range entries {
+		name := entry.Name()
+		id := strings.TrimSuffix(name, resumableInfoExt)
+		if entry.IsDir() || id == name || id == except || !uuidUtil.IsValid(id) {
+			continue
+		}
+		upload, err := readResumableInfo(dir, id)
+		if err != nil || upload.UserID != userID || upload.Completed() || now.After(upload.ExpiresAt) {
+			continue
+		}
+		count++
+		bytes += upload.Length
+	}
+	return count, bytes
+}
+
+func readResumableInfo(dir, id string) (fileModel.ResumableUpload, error) {
+	var upload fileModel.ResumableUpload
+	raw, err := os.ReadFile(resumablePath(dir, id, resumableInfoExt))
+	if err != nil {
+		return upload, err
+	}
+	err = json.Unmarshal(raw, &upload)
+	return upload, err
+}
+
+// writeResumableInfo 先写临时文件再 rename，崩溃时不会留下半截 JSON。
+func writeResumableInfo(dir string, upload fileModel.ResumableUpload) error {
+	raw, err := json.Marshal(upload)
+	if err != nil {
+		return err
+	}
+	target := resumablePath(dir, upload.ID, resumableInfoExt)
+	tmp := target + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, target)
+}
+
+func removeResumableUpload(dir, id string) {
+	_ = os.Remove(resumablePath(dir, id, resumablePartExt))
+	_ = os.Remove(resumablePath(dir, id, resumableInfoExt))
+}
+
+func resumablePath(dir, id, ext string) string {
+	return filepath.Join(dir, id+ext)
+}
+
+func resumableDir() string {
+	if dir := strings.TrimSpace(config.Config().Upload.TmpPath); dir != "" {
+		return dir
+	}
+	return defaultResumableDir
+}
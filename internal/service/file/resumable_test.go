@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/config"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useResumableDir 把断点续传暂存目录指到测试临时目录，结束时还原配置。
+func useResumableDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	cfg := config.Config()
+	prev := cfg.Upload.TmpPath
+	cfg.Upload.TmpPath = dir
+	t.Cleanup(func() { cfg.Upload.TmpPath = prev })
+	return dir
+}
+
+func (f *fileFix) createResumable(t *testing.T, filename string, length int64) fileModel.ResumableUpload {
+	t.Helper()
+	f.expectAdmin()
+	upload, err := f.svc.CreateResumableUpload(f.adminCtx(), fileModel.ResumableUploadCreate{
+		Filename:    filename,
+		Category:    "image",
+		StorageType: "local",
+		Length:      length,
+	})
+	require.NoError(t, err)
+	return upload
+}
+
+func TestFileService_ResumableUpload(t *testing.T) {
+	t.Run("chunks resume from the server offset and finish like UploadFile", func(t *testing.T) {
+		dir := useResumableDir(t)
+		fix := newFileFix(t)
+		content := pngBytes(t, 9, 7)
+		upload := fix.createResumable(t, "photo.png", int64(len(content)))
+		assert.Equal(t, int64(0), upload.Offset)
+
+		half := len(content) / 2
+		got, err := fix.svc.AppendResumableUpload(fix.adminCtx(), upload.ID, 0, bytes.NewReader(content[:half]))
+		require.NoError(t, err)
+		assert.Equal(t, int64(half), got.Offset)
+		assert.False(t, got.Completed())
+
+		// 断线后客户端先 HEAD 取偏移量，再从断点继续。
+		state, err := fix.svc.GetResumableUpload(fix.adminCtx(), upload.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(half), state.Offset)
+
+		got, err = fix.svc.AppendResumableUpload(fix.adminCtx(), upload.ID, state.Offset, bytes.NewReader(content[half:]))
+		require.NoError(t, err)
+		require.True(t, got.Completed())
+		assert.Equal(t, int64(len(content)), got.Offset)
+
+		dto, err := fix.svc.GetFileByID(fix.adminCtx(), got.FileID)
+		require.NoError(t, err)
+		assert.Equal(t, "photo.png", dto.Name)
+		assert.Equal(t, "image/png", dto.ContentType)
+		assert.Equal(t, 9, dto.Width)
+		assert.Equal(t, int64(1), countTemps(t, fix.db))
+		assert.True(t, storedExists(t, fix.mgr, dto.Key))
+
+		_, err = os.Stat(filepath.Join(dir, upload.ID+".part"))
+		assert.True(t, os.IsNotExist(err), "part file is removed once stored")
+		state, err = fix.svc.GetResumableUpload(fix.adminCtx(), upload.ID)
+		require.NoError(t, err)
+		assert.Equal(t, got.FileID, state.FileID)
+	})
+
+	t.Run("offset mismatch is rejected without writing", func(t *testing.T) {
+		useResumableDir(t)
+		fix := newFileFix(t)
+		upload := fix.createResumable(t, "photo.png", 100)
+
+		got, err := fix.svc.AppendResumableUpload(fix.adminCtx(), upload.ID, 10, bytes.NewReader([]byte("x")))
+		require.EqualError(t, err, commonModel.UPLOAD_OFFSET_MISMATCH)
+		assert.Equal(t, int64(0), got.Offset)
+	})
+
+	t.Run("bytes beyond the declared length are ignored", func(t *testing.T) {
+		useResumableDir(t)
+		fix := newFileFix(t)
+		upload := fix.createResumable(t, "photo.png", 4)
+
+		got, err := fix.svc.AppendResumableUpload(fix.adminCtx(), upload.ID, 0, bytes.NewReader([]byte("ab")))
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), got.Offset)
+		_, err = fix.svc.AppendResumableUpload(fix.adminCtx(), upload.ID, 2, bytes.NewReader([]byte("cdefgh")))
+		// 收齐 4 字节后内容嗅探失败，会话随之作废。
+		require.EqualError(t, err, commonModel.FILE_TYPE_NOT_ALLOWED)
+		_, err = fix.svc.GetResumableUpload(fix.adminCtx(), upload.ID)
+		require.EqualError(t, err, commonModel.UPLOAD_SESSION_NOT_FOUND)
+		assert.Equal(t, int64(0), countFiles(t, fix.db))
+	})
+
+	t.Run("create enforces category size limit and extension", func(t *testing.T) {
+		useResumableDir(t)
+		fix := newFileFix(t)
+		fix.expectAdmin()
+		cfg := config.Config()
+		prev := cfg.Upload.ImageMaxSize
+		cfg.Upload.ImageMaxSize = 10
+		t.Cleanup(func() { cfg.Upload.ImageMaxSize = prev })
+
+		_, err := fix.svc.CreateResumableUpload(fix.adminCtx(), fileModel.ResumableUploadCreate{
+			Filename: "photo.png", Category: "image", Length: 11,
+		})
+		require.EqualError(t, err, commonModel.FILE_SIZE_EXCEED_LIMIT)
+
+		_, err = fix.svc.CreateResumableUpload(fix.adminCtx(), fileModel.ResumableUploadCreate{
+			Filename: "run.exe", Category: "image", Length: 5,
+		})
+		require.EqualError(t, err, commonModel.FILE_TYPE_NOT_ALLOWED)
+
+		_, err = fix.svc.CreateResumableUpload(fix.adminCtx(), fileModel.ResumableUploadCreate{
+			Filename: "photo.png", Category: "image", Length: 0,
+		})
+		require.EqualError(t, err, commonModel.UPLOAD_LENGTH_INVALID)
+	})
+
+	t.Run("non-admin cannot create", func(t *testing.T) {
+		useResumableDir(t)
+		fix := newFileFix(t)
+		fix.expectNonAdmin()
+		_, err := fix.svc.CreateResumableUpload(fix.adminCtx(), fileModel.ResumableUploadCreate{
+			Filename: "photo.png", Category: "image", Length: 5,
+		})
+		require.EqualError(t, err, commonModel.NO_PERMISSION_DENIED)
+	})
+
+	t.Run("sessions are invisible to other users", func(t *testing.T) {
+		useResumableDir(t)
+		fix := newFileFix(t)
+		upload := fix.createResumable(t, "photo.png", 10)
+
+		_, err := fix.svc.GetResumableUpload(helpers.CtxAsUser("someone-else"), upload.ID)
+		require.EqualError(t, err, commonModel.UPLOAD_SESSION_NOT_FOUND)
+		_, err = fix.svc.GetResumableUpload(fix.adminCtx(), "../../etc/passwd")
+		require.EqualError(t, err, commonModel.UPLOAD_SESSION_NOT_FOUND)
+	})
+
+	t.Run("delete removes the session and its bytes", func(t *testing.T) {
+		dir := useResumableDir(t)
+		fix := newFileFix(t)
+		upload := fix.createResumable(t, "photo.png", 10)
+		_, err := fix.svc.AppendResumableUpload(fix.adminCtx(), upload.ID, 0, bytes.NewReader([]byte("abc")))
+		require.NoError(t, err)
+
+		require.NoError(t, fix.svc.DeleteResumableUpload(fix.adminCtx(), upload.ID))
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+		_, err = fix.svc.GetResumableUpload(fix.adminCtx(), upload.ID)
+		require.EqualError(t, err, commonModel.UPLOAD_SESSION_NOT_FOUND)
+	})
+
+	t.Run("open sessions per user are capped", func(t *testing.T) {
+		useResumableDir(t)
+		fix := newFileFix(t)
+		var first fileModel.ResumableUpload
+		for i := range 8 {
+			upload := fix.createResumable(t, fmt.Sprintf("%d.png", i), 10)
+			if i == 0 {
+				first = upload
+			}
+		}
+		_, err := fix.svc.CreateResumableUpload(fix.adminCtx(), fileModel.ResumableUploadCreate{
+			Filename: "extra.png", Category: "image", Length: 10,
+		})
+		require.EqualError(t, err, commonModel.UPLOAD_SESSION_LIMIT)
+
+		require.NoError(t, fix.svc.DeleteResumableUpload(fix.adminCtx(), first.ID))
+		fix.createResumable(t, "extra.png", 10)
+	})
+
+	t.Run("orphan cleanup purges expired sessions only", func(t *testing.T) {
+		dir := useResumableDir(t)
+		fix := newFileFix(t)
+		expired := fix.createResumable(t, "old.png", 10)
+		live := fix.createResumable(t, "new.png", 10)
+
+		infoPath := filepath.Join(dir, expired.ID+".info")
+		raw, err := os.ReadFile(infoPath)
+		require.NoError(t, err)
+		var info fileModel.ResumableUpload
+		require.NoError(t, json.Unmarshal(raw, &info))
+		info.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+		raw, err = json.Marshal(info)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(infoPath, raw, 0o600))
+
+		require.NoError(t, fix.svc.CleanupOrphanFiles())
+		_, err = os.Stat(filepath.Join(dir, expired.ID+".part"))
+		assert.True(t, os.IsNotExist(err))
+		_, err = os.Stat(infoPath)
+		assert.True(t, os.IsNotExist(err))
+		_, err = fix.svc.GetResumableUpload(fix.adminCtx(), live.ID)
+		require.NoError(t, err)
+	})
+}
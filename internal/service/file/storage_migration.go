@@ -0,0 +1,302 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	"github.com/lin-snow/ech0/internal/storage"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"github.com/lin-snow/ech0/pkg/viewer"
+	"github.com/lin-snow/ech0/pkg/virefs"
+)
+
+const (
+	storageMigrationPageSize    = 100
+	storageMigrationMaxFailures = 20
+)
+
+// PrepareStorageMigration 在提交迁移作业前校验权限（仅管理员）并就地归一化 payload。
+func (s *FileService) PrepareStorageMigration(ctx context.Context, payload *fileModel.StorageMigrationPayload) error {
+	userid := viewer.MustFromContext(ctx).UserID()
+	user, err := s.commonRepository.GetUserByUserId(context.Background(), userid)
+	if err != nil {
+		return err
+	}
+	if !user.IsAdmin {
+		return errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+	return normalizeStorageMigration(payload)
+}
+
+// normalizeStorageMigration 归一化并校验迁移输入：两端只能是 local/object，不能原地搬；
+// object→object 即换桶，必须指明一个不同于当前设置的源桶（后者在打开路由时才能比对）。
+func normalizeStorageMigration(p *fileModel.StorageMigrationPayload) error {
+	p.From = strings.ToLower(strings.TrimSpace(p.From))
+	p.To = strings.ToLower(strings.TrimSpace(p.To))
+	p.FromBucket = strings.TrimSpace(p.FromBucket)
+	if p.From != string(storage.StorageTypeObject) {
+		p.FromBucket = ""
+	}
+	for _, t := range []string{p.From, p.To} {
+		if t != string(storage.StorageTypeLocal) && t != string(storage.StorageTypeObject) {
+			return commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, commonModel.STORAGE_MIGRATION_INVALID)
+		}
+	}
+	if p.From == p.To && (p.From == string(storage.StorageTypeLocal) || p.FromBucket == "") {
+		return commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, commonModel.STORAGE_MIGRATION_INVALID)
+	}
+	return nil
+}
+
+// MigrateStorage 逐个搬运源路由上的文件：拷贝并校验大小与 SHA-256 → 一条 UPDATE 改写文件行的
+// 路由列 → 按需删除源文件。单个文件失败只记入 Failures、行留在源路由上，不中断整批；
+// 重跑同一 payload 即续跑（已改写的行不再出现在源路由上，目标已有同内容对象的直接复用）。
+// 作业上下文里没有 viewer，权限由提交端点把关。
+func (s *FileService) MigrateStorage(
+	ctx context.Context,
+	payload fileModel.StorageMigrationPayload,
+	onProgress func(fileModel.StorageMigrationProgress),
+) (fileModel.StorageMigrationProgress, error) {
+	var progress fileModel.StorageMigrationProgress
+	if err := normalizeStorageMigration(&payload); err != nil {
+		return progress, err
+	}
+	src, dst, err := s.openMigrationRoutes(ctx, payload)
+	if err != nil {
+		return progress, err
+	}
+	from := routeColumns(src)
+	to := routeColumns(dst)
+
+	total, err := s.fileRepository.CountByRoute(ctx, from.StorageType, from.Bucket)
+	if err != nil {
+		return progress, err
+	}
+	progress.Total = int(total)
+	if onProgress != nil {
+		onProgress(progress)
+	}
+
+	afterID := ""
+	for {
+		files, err := s.fileRepository.ListByRoute(ctx, from.StorageType, from.Bucket, afterID, storageMigrationPageSize)
+		if err != nil {
+			return progress, err
+		}
+		if len(files) == 0 {
+			break
+		}
+		for _, f := range files {
+			if err := ctx.Err(); err != nil {
+				return progress, err
+			}
+			afterID = f.ID
+			progress.Current = f.Key
+
+			reused, size, err := s.migrateFile(ctx, f, src, dst, from, to, payload.DeleteSource)
+			switch {
+			case err != nil:
+				progress.Failed++
+				if len(progress.Failures) < storageMigrationMaxFailures {
+					progress.Failures = append(progress.Failures, fileModel.FileJobFailure{
+						FileID: f.ID,
+						Key:    f.Key,
+						Error:  err.Error(),
+					})
+				}
+			case reused:
+				progress.Reused++
+			default:
+				progress.Migrated++
+				progress.Bytes += size
+			}
+			if onProgress != nil {
+				onProgress(progress)
+			}
+		}
+	}
+	progress.Current = ""
+	return progress, nil
+}
+
+// openMigrationRoutes 打开迁移两端。目标为 object 时必须是正在生效的对象存储：
+// 改写后的行要靠当前选择器出直链，搬进一个未启用的桶等于让文件全部失联。
+func (s *FileService) openMigrationRoutes(
+	ctx context.Context,
+	payload fileModel.StorageMigrationPayload,
+) (src storage.RouteFS, dst storage.RouteFS, err error) {
+	if s.storageManager == nil {
+		return src, dst, errors.New(commonModel.NO_FILE_STORAGE_ERROR)
+	}
+	toType := storage.StorageType(payload.To)
+	if toType == storage.StorageTypeObject && !s.getSelector().ObjectEnabled() {
+		return src, dst, errors.New(commonModel.S3_NOT_ENABLED)
+	}
+	dst, err = s.storageManager.OpenRoute(ctx, toType, "")
+	if err != nil {
+		return src, dst, err
+	}
+	src, err = s.storageManager.OpenRoute(ctx, storage.StorageType(payload.From), payload.FromBucket)
+	if err != nil {
+		return src, dst, fmt.Errorf("%s: %w", commonModel.S3_NOT_CONFIGURED, err)
+	}
+	if src.StorageType == dst.StorageType && src.Bucket == dst.Bucket {
+		return src, dst, commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, commonModel.STORAGE_MIGRATION_INVALID)
+	}
+	return src, dst, nil
+}
+
+// migrateFile 搬运单个文件。目标键已存在时只有内容一致才复用（reused=true），否则判失败而不覆盖；
+// 改写文件行失败时回收本次拷贝出的目标对象，保证「行指向哪里，文件就在哪里」。
+func (s *FileService) migrateFile(
+	ctx context.Context,
+	f fileModel.File,
+	src, dst storage.RouteFS,
+	from, to fileModel.StorageRoute,
+	deleteSource bool,
+) (reused bool, size int64, err error) {
+	exists, err := dst.FS.Exists(ctx, f.Key)
+	if err != nil {
+		return false, 0, fmt.Errorf("check destination: %w", err)
+	}
+	if exists {
+		srcSum, srcSize, err := hashStored(ctx, src.FS, f.Key)
+		if err != nil {
+			return false, 0, fmt.Errorf("read source: %w", err)
+		}
+		dstSum, dstSize, err := hashStored(ctx, dst.FS, f.Key)
+		if err != nil {
+			return false, 0, fmt.Errorf("read destination: %w", err)
+		}
+		if srcSum != dstSum || srcSize != dstSize {
+			return false, 0, fmt.Errorf("destination %w with different content", virefs.ErrAlreadyExist)
+		}
+		reused, size = true, srcSize
+	} else {
+		size, err = copyVerified(ctx, src.FS, dst.FS, f)
+		if err != nil {
+			return false, 0, err
+		}
+	}
+
+	moved, err := s.fileRepository.UpdateRouteByID(ctx, f.ID, from, to, dst.ResolveURL(f.Key))
+	if err == nil && !moved {
+		err = errors.New("file record changed during migration")
+	}
+	if err != nil {
+		if !reused {
+			_ = dst.FS.Delete(ctx, f.Key)
+		}
+		return false, 0, err
+	}
+
+	if deleteSource {
+		// 源对象可能还被其它行共享（内容去重），留到最后一个引用者搬走时再删。数引用与删除
+		// 在 key 锁内完成，与上传复用同内容对象互斥，同 releaseStoredFile。
+		unlock := s.lockStoredKey(f.Key)
+		defer unlock()
+		refs, err := s.fileRepository.CountByRouteKey(ctx, from.StorageType, from.Bucket, f.Key, "")
+		if err != nil || refs > 0 {
+			return reused, size, nil
+		}
+		if err := src.FS.Delete(ctx, f.Key); err != nil && !errors.Is(err, virefs.ErrNotFound) {
+			logUtil.GetLogger().Warn(
+				"Failed to delete migrated source file",
+				slog.String("file_id", f.ID),
+				slog.String("file_key", f.Key),
+				slog.String("storage_type", from.StorageType),
+				logUtil.Err(err),
+			)
+		}
+	}
+	return reused, size, nil
+}
+
+// copyVerified 把源对象写到目标并回读校验：字节数须与源一致（文件行记了大小的也须一致），
+// 目标 Stat 大小与回读 SHA-256 须与写入时一致。校验不过即删掉目标副本。
+func copyVerified(ctx context.Context, src, dst virefs.FS, f fileModel.File) (int64, error) {
+	rc, err := src.Get(ctx, f.Key)
+	if err != nil {
+		return 0, fmt.Errorf("read source: %w", err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	hasher := sha256.New()
+	counter := &countingReader{r: io.TeeReader(rc, hasher)}
+	var opts []virefs.PutOption
+	if f.ContentType != "" {
+		opts = append(opts, virefs.WithContentType(f.ContentType))
+	}
+	if err := dst.Put(ctx, f.Key, counter, opts...); err != nil {
+		return 0, fmt.Errorf("write destination: %w", err)
+	}
+	srcSum := hex.EncodeToString(hasher.Sum(nil))
+
+	verifyErr := func() error {
+		if f.Size > 0 && counter.n != f.Size {
+			return fmt.Errorf("size mismatch: record %d, source %d", f.Size, counter.n)
+		}
+		info, err := dst.Stat(ctx, f.Key)
+		if err != nil {
+			return fmt.Errorf("stat destination: %w", err)
+		}
+		if info.Size != counter.n {
+			return fmt.Errorf("size mismatch: source %d, destination %d", counter.n, info.Size)
+		}
+		dstSum, _, err := hashStored(ctx, dst, f.Key)
+		if err != nil {
+			return fmt.Errorf("read destination: %w", err)
+		}
+		if dstSum != srcSum {
+			return errors.New("checksum mismatch")
+		}
+		return nil
+	}()
+	if verifyErr != nil {
+		_ = dst.Delete(ctx, f.Key)
+		return 0, verifyErr
+	}
+	return counter.n, nil
+}
+
+// hashStored 读出对象并返回其 SHA-256（hex）与字节数。
+func hashStored(ctx context.Context, fs virefs.FS, key string) (string, int64, error) {
+	rc, err := fs.Get(ctx, key)
+	if err != nil {
+		return "", 0, err
+	}
+	defer func() { _ = rc.Close() }()
+	hasher := sha256.New()
+	n, err := io.Copy(hasher, rc)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), n, nil
+}
+
+func routeColumns(r storage.RouteFS) fileModel.StorageRoute {
+	return fileModel.StorageRoute{StorageType: string(r.StorageType), Provider: r.Provider, Bucket: r.Bucket}
+}
+
+// countingReader 统计经过的字节数。
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
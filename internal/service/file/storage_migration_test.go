@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	"github.com/lin-snow/ech0/internal/storage"
+	"github.com/lin-snow/ech0/pkg/virefs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withTestObjectStore 把第二个临时目录挂成 fixture 的对象存储（provider=minio, bucket=media）。
+func (f *fileFix) withTestObjectStore(t *testing.T) virefs.FS {
+	t.Helper()
+	objFS, err := virefs.NewLocalFS(t.TempDir(), virefs.WithCreateRoot())
+	require.NoError(t, err)
+	f.mgr.UseObjectFSForTest(objFS, "minio", "media")
+	return objFS
+}
+
+func readAll(t *testing.T, fs virefs.FS, key string) []byte {
+	t.Helper()
+	rc, err := fs.Get(context.Background(), key)
+	require.NoError(t, err)
+	defer func() { _ = rc.Close() }()
+	b, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	return b
+}
+
+func (f *fileFix) reload(t *testing.T, id string) fileModel.File {
+	t.Helper()
+	var row fileModel.File
+	require.NoError(t, f.db.First(&row, "id = ?", id).Error)
+	return row
+}
+
+func TestFileService_MigrateStorage(t *testing.T) {
+	t.Run("local to object and back", func(t *testing.T) {
+		f := newFileFix(t)
+		a := f.uploadPNG(t, "a.png", 2, 2)
+		b := f.uploadPNG(t, "b.png", 3, 3)
+		objFS := f.withTestObjectStore(t)
+		original := readAll(t, routeFS(t, f.mgr, storage.StorageTypeLocal), a.Key)
+
+		var reports []fileModel.StorageMigrationProgress
+		res, err := f.svc.MigrateStorage(context.Background(), fileModel.StorageMigrationPayload{
+			From:         "local",
+			To:           "object",
+			DeleteSource: true,
+		}, func(p fileModel.StorageMigrationProgress) { reports = append(reports, p) })
+		require.NoError(t, err)
+		assert.Equal(t, 2, res.Total)
+		assert.Equal(t, 2, res.Migrated)
+		assert.Zero(t, res.Failed)
+		assert.Positive(t, res.Bytes)
+		assert.Len(t, reports, 3, "one initial report plus one per file")
+
+		for _, id := range []string{a.ID, b.ID} {
+			row := f.reload(t, id)
+			assert.Equal(t, "object", row.StorageType)
+			assert.Equal(t, "minio", row.Provider)
+			assert.Equal(t, "media", row.Bucket)
+			assert.False(t, storedExists(t, f.mgr, row.Key), "source should be deleted")
+		}
+		assert.Equal(t, original, readAll(t, objFS, a.Key))
+
+		res, err = f.svc.MigrateStorage(context.Background(), fileModel.StorageMigrationPayload{
+			From: "object",
+			To:   "local",
+		}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 2, res.Migrated)
+		row := f.reload(t, a.ID)
+		assert.Equal(t, "local", row.StorageType)
+		assert.Empty(t, row.Provider)
+		assert.Empty(t, row.Bucket)
+		assert.True(t, storedExists(t, f.mgr, a.Key))
+		exists, err := objFS.Exists(context.Background(), a.Key)
+		require.NoError(t, err)
+		assert.True(t, exists, "source is kept without delete_source")
+	})
+
+	t.Run("existing destination is reused only when identical", func(t *testing.T) {
+		f := newFileFix(t)
+		same := f.uploadPNG(t, "same.png", 2, 2)
+		clash := f.uploadPNG(t, "clash.png", 4, 4)
+		objFS := f.withTestObjectStore(t)
+		local := routeFS(t, f.mgr, storage.StorageTypeLocal)
+		ctx := context.Background()
+		require.NoError(t, objFS.Put(ctx, same.Key, bytes.NewReader(readAll(t, local, same.Key))))
+		require.NoError(t, objFS.Put(ctx, clash.Key, bytes.NewReader([]byte("someone else"))))
+
+		res, err := f.svc.MigrateStorage(ctx, fileModel.StorageMigrationPayload{From: "local", To: "object"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, res.Reused)
+		assert.Equal(t, 1, res.Failed)
+		require.Len(t, res.Failures, 1)
+		assert.Equal(t, clash.ID, res.Failures[0].FileID)
+
+		assert.Equal(t, "object", f.reload(t, same.ID).StorageType)
+		assert.Equal(t, "local", f.reload(t, clash.ID).StorageType, "failed file stays on its source route")
+		assert.Equal(t, []byte("someone else"), readAll(t, objFS, clash.Key), "existing object must not be overwritten")
+	})
+
+	t.Run("object destination must be enabled", func(t *testing.T) {
+		f := newFileFix(t)
+		_, err := f.svc.MigrateStorage(context.Background(), fileModel.StorageMigrationPayload{From: "local", To: "object"}, nil)
+		require.EqualError(t, err, commonModel.S3_NOT_ENABLED)
+	})
+}
+
+func TestFileService_PrepareStorageMigration(t *testing.T) {
+	t.Run("non admin is rejected", func(t *testing.T) {
+		f := newFileFix(t)
+		f.expectNonAdmin()
+		err := f.svc.PrepareStorageMigration(f.adminCtx(), &fileModel.StorageMigrationPayload{From: "local", To: "object"})
+		require.EqualError(t, err, commonModel.NO_PERMISSION_DENIED)
+	})
+
+	f := newFileFix(t)
+	f.expectAdmin()
+	cases := []struct {
+		name    string
+		payload fileModel.StorageMigrationPayload
+		wantErr bool
+	}{
+		{"local to object", fileModel.StorageMigrationPayload{From: " LOCAL ", To: "object"}, false},
+		{"object to local", fileModel.StorageMigrationPayload{From: "object", To: "local"}, false},
+		{"bucket to bucket", fileModel.StorageMigrationPayload{From: "object", FromBucket: "old", To: "object"}, false},
+		{"same route", fileModel.StorageMigrationPayload{From: "local", To: "local"}, true},
+		{"object without source bucket", fileModel.StorageMigrationPayload{From: "object", To: "object"}, true},
+		{"external", fileModel.StorageMigrationPayload{From: "external", To: "local"}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := tc.payload
+			err := f.svc.PrepareStorageMigration(f.adminCtx(), &p)
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), commonModel.STORAGE_MIGRATION_INVALID)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+// routeFS 经 OpenRoute 取某存储类型当前生效的 FS。
+func routeFS(t *testing.T, mgr *storage.Manager, storageType storage.StorageType) virefs.FS {
+	t.Helper()
+	route, err := mgr.OpenRoute(context.Background(), storageType, "")
+	require.NoError(t, err)
+	return route.FS
+}
+
+func TestFileService_MigrateStorage_SharedKey(t *testing.T) {
+	f := newFileFix(t)
+	a := f.uploadPNG(t, "a.png", 2, 2)
+	b := f.uploadPNG(t, "b.png", 2, 2)
+	require.Equal(t, a.Key, b.Key)
+	objFS := f.withTestObjectStore(t)
+
+	res, err := f.svc.MigrateStorage(context.Background(), fileModel.StorageMigrationPayload{
+		From:         "local",
+		To:           "object",
+		DeleteSource: true,
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Migrated)
+	assert.Equal(t, 1, res.Reused, "the second row finds the copy made for the first")
+	assert.Zero(t, res.Failed)
+	assert.False(t, storedExists(t, f.mgr, a.Key), "source goes once the last row has moved")
+	exists, err := objFS.Exists(context.Background(), a.Key)
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+// 迁移删源与上传复用同内容对象共用 key 锁：复用方持锁期间落下的新行，删源时必须数得到。
+func TestFileService_MigrateStorage_DeleteSourceWaitsForKeyLock(t *testing.T) {
+	f := newFileFix(t)
+	a := f.uploadPNG(t, "a.png", 2, 2)
+	f.withTestObjectStore(t)
+	row := f.reload(t, a.ID)
+
+	unlock := f.svc.LockStoredKey(a.Key)
+	done := make(chan error, 1)
+	go func() {
+		_, err := f.svc.MigrateStorage(context.Background(), fileModel.StorageMigrationPayload{
+			From:         "local",
+			To:           "object",
+			DeleteSource: true,
+		}, nil)
+		done <- err
+	}()
+	require.Eventually(t, func() bool {
+		return f.reload(t, a.ID).StorageType == "object"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// 模拟持锁的复用方：源路由上又有一行共享这个 key。ID 取最小值，不会被本轮迁移分页扫到。
+	row.ID = "00000000-0000-7000-8000-000000000001"
+	require.NoError(t, f.db.Create(&row).Error)
+	unlock()
+
+	require.NoError(t, <-done)
+	assert.True(t, storedExists(t, f.mgr, a.Key), "source object is still referenced")
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	coreMigrator "github.com/lin-snow/ech0/internal/migrator"
+	"github.com/lin-snow/ech0/internal/migrator/envelope"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+)
+
+// GetEncryptionSetting 读出归档加密配置；Passphrase 脱敏为 PassphraseSet。
+func (s *MigratorService) GetEncryptionSetting(ctx context.Context) (migratorModel.EncryptionSetting, error) {
+	if _, err := s.ensureAdmin(ctx); err != nil {
+		return migratorModel.EncryptionSetting{}, err
+	}
+	setting, err := coreSetting.Get(ctx, s.durableKV, coreSetting.Encryption)
+	if err != nil {
+		return migratorModel.EncryptionSetting{}, err
+	}
+	return sanitizeEncryptionSetting(setting), nil
+}
+
+// UpdateEncryptionSetting 保存归档加密配置。Passphrase 留空即沿用已存的口令；开启时按最终配置
+// 试建一次 Sealer，缺口令或公钥写错当场报错，而不是等到下一次定时快照才失败。
+func (s *MigratorService) UpdateEncryptionSetting(ctx context.Context, setting migratorModel.EncryptionSetting) error {
+	if _, err := s.ensureAdmin(ctx); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(setting.Passphrase) == "" {
+		current, err := coreSetting.Get(ctx, s.durableKV, coreSetting.Encryption)
+		if err == nil {
+			setting.Passphrase = current.Passphrase
+		}
+	}
+	setting.PassphraseSet = false
+	if setting.Enable {
+		if _, err := envelope.NewSealer(setting); err != nil {
+			return err
+		}
+	}
+	return coreSetting.Set(ctx, s.durableKV, coreSetting.Encryption, setting)
+}
+
+// unsealArchive 透明解开加密信封：path 不是信封时原样返回；是信封则用请求给的凭据（兜底已存口令）
+// 解密到迁移暂存目录，返回明文副本路径与清理函数。只有遇到信封才去读加密配置。
+func (s *MigratorService) unsealArchive(
+	ctx context.Context,
+	path string,
+	keys migratorModel.ArchiveKeys,
+) (string, func(), error) {
+	noop := func() {}
+	if _, sealed, err := envelope.Inspect(path); err != nil {
+		return "", noop, err
+	} else if !sealed {
+		return path, noop, nil
+	}
+
+	stored, err := coreSetting.Get(ctx, s.durableKV, coreSetting.Encryption)
+	if err != nil {
+		return "", noop, err
+	}
+	return envelope.Unseal(
+		path,
+		filepath.Join("data", coreMigrator.TmpRelativeDir),
+		envelope.KeysFor(keys, stored),
+	)
+}
+
+func sanitizeEncryptionSetting(in migratorModel.EncryptionSetting) migratorModel.EncryptionSetting {
+	out := in
+	out.PassphraseSet = strings.TrimSpace(out.Passphrase) != ""
+	out.Passphrase = ""
+	return out
+}
@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/lin-snow/ech0/internal/migrator/envelope"
+	jobModel "github.com/lin-snow/ech0/internal/model/job"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/lin-snow/ech0/internal/test/mocks/commonmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptionSetting(t *testing.T) {
+	t.Run("blank passphrase on update keeps stored passphrase", func(t *testing.T) {
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+		require.NoError(t, coreSetting.Set(context.Background(), s.durableKV, coreSetting.Encryption,
+			migratorModel.EncryptionSetting{Passphrase: "correct horse"}))
+
+		require.NoError(t, s.UpdateEncryptionSetting(helpers.CtxAsUser(adminID), migratorModel.EncryptionSetting{
+			Enable: true,
+		}))
+
+		stored, err := coreSetting.Get(context.Background(), s.durableKV, coreSetting.Encryption)
+		require.NoError(t, err)
+		assert.Equal(t, "correct horse", stored.Passphrase)
+		assert.Equal(t, migratorModel.EncryptionModePassphrase, stored.Mode)
+
+		got, err := s.GetEncryptionSetting(helpers.CtxAsUser(adminID))
+		require.NoError(t, err)
+		assert.Empty(t, got.Passphrase)
+		assert.True(t, got.PassphraseSet)
+	})
+
+	t.Run("enabling without a passphrase rejected", func(t *testing.T) {
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+		err := s.UpdateEncryptionSetting(helpers.CtxAsUser(adminID), migratorModel.EncryptionSetting{Enable: true})
+		require.Error(t, err)
+	})
+
+	t.Run("malformed recipient rejected", func(t *testing.T) {
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+		err := s.UpdateEncryptionSetting(helpers.CtxAsUser(adminID), migratorModel.EncryptionSetting{
+			Enable:     true,
+			Mode:       migratorModel.EncryptionModeRecipient,
+			Recipients: []string{"age1notakey"},
+		})
+		require.Error(t, err)
+	})
+}
+
+// sealedZip 把 minimalZip 封进发给 identity 的加密信封。用 X25519 而非口令，测试不必付 scrypt 的代价。
+func sealedZip(t *testing.T, identity *age.X25519Identity) []byte {
+	t.Helper()
+	dir := t.TempDir()
+	plain := filepath.Join(dir, "plain.zip")
+	require.NoError(t, os.WriteFile(plain, minimalZip(t), 0o644))
+
+	sealer, err := envelope.NewSealer(migratorModel.EncryptionSetting{
+		Enable:     true,
+		Mode:       migratorModel.EncryptionModeRecipient,
+		Recipients: []string{identity.Recipient().String()},
+	})
+	require.NoError(t, err)
+	sealed := filepath.Join(dir, "sealed.zip")
+	require.NoError(t, sealer.Seal(envelope.KindSnapshot, plain, sealed))
+	data, err := os.ReadFile(sealed)
+	require.NoError(t, err)
+	return data
+}
+
+func TestRestoreSnapshot_Encrypted(t *testing.T) {
+	const name = "ech0_snapshot_2026-01-01_00-00-00.zip"
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	seed := func(t *testing.T) {
+		t.Helper()
+		chdirTemp(t)
+		snapDir := filepath.Join("data", "files", "snapshots")
+		require.NoError(t, os.MkdirAll(snapDir, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(snapDir, name), sealedZip(t, identity), 0o644))
+	}
+
+	t.Run("listed as encrypted", func(t *testing.T) {
+		seed(t)
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+		list, err := s.ListSnapshots(helpers.CtxAsUser(adminID))
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.True(t, list[0].Encrypted)
+	})
+
+	t.Run("missing key rejected before unpacking", func(t *testing.T) {
+		seed(t)
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+		_, err := s.RestoreSnapshot(helpers.CtxAsUser(adminID), name, migratorModel.ArchiveKeys{})
+		require.ErrorIs(t, err, envelope.ErrKeyRequired)
+		entries, _ := os.ReadDir(filepath.Join("data", "files", "tmp"))
+		assert.Empty(t, entries, "nothing should be left behind when decryption fails")
+	})
+
+	t.Run("identity decrypts and submits ech0 migration", func(t *testing.T) {
+		seed(t)
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+		s.jobManager.Register(jobModel.TypeMigration, noopRunner{})
+
+		dto, err := s.RestoreSnapshot(
+			helpers.CtxAsUser(adminID),
+			name,
+			migratorModel.ArchiveKeys{Identity: identity.String()},
+		)
+		require.NoError(t, err)
+		tmpDir, _ := dto.SourcePayload["tmp_dir"].(string)
+		got, err := os.ReadFile(filepath.Join("data", filepath.FromSlash(tmpDir), "hello.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "hi", string(got))
+
+		entries, err := os.ReadDir(filepath.Join("data", "files", "tmp"))
+		require.NoError(t, err)
+		require.Len(t, entries, 1, "the decrypted copy should be removed after unpacking")
+	})
+}
+
+func TestUploadSourceZip_Encrypted(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	t.Run("wrong identity rejected", func(t *testing.T) {
+		chdirTemp(t)
+		stranger, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+
+		_, err = s.UploadSourceZip(
+			helpers.CtxAsUser(adminID),
+			migratorModel.MigrationSourceEch0,
+			zipFileHeader(t, "ech0-snapshot.zip", sealedZip(t, identity)),
+			migratorModel.ArchiveKeys{Identity: stranger.String()},
+		)
+		require.ErrorIs(t, err, envelope.ErrWrongKey)
+	})
+
+	t.Run("decrypts before unpacking", func(t *testing.T) {
+		chdirTemp(t)
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+
+		resp, err := s.UploadSourceZip(
+			helpers.CtxAsUser(adminID),
+			migratorModel.MigrationSourceEch0,
+			zipFileHeader(t, "ech0-snapshot.zip", sealedZip(t, identity)),
+			migratorModel.ArchiveKeys{Identity: identity.String()},
+		)
+		require.NoError(t, err)
+		got, err := os.ReadFile(filepath.Join("data", filepath.FromSlash(resp.TmpDir), "hello.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "hi", string(got))
+	})
+}
@@ -13,17 +13,21 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/lin-snow/ech0/internal/job"
+	"github.com/lin-snow/ech0/internal/kvstore"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	jobModel "github.com/lin-snow/ech0/internal/model/job"
 	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
 	userModel "github.com/lin-snow/ech0/internal/model/user"
 	"github.com/lin-snow/ech0/internal/test/helpers"
 	"github.com/lin-snow/ech0/internal/test/mocks/commonmock"
+	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
 	"github.com/lin-snow/ech0/pkg/busen"
 	logUtil "github.com/lin-snow/ech0/pkg/log"
 	"github.com/stretchr/testify/assert"
@@ -42,51 +46,106 @@ func TestMain(m *testing.M) {
 }
 
 // fakeJobRepo is a tiny in-memory, concurrency-safe job.JobRepository. The real
-// job.Manager spins a goroutine on Submit success that calls Upsert, so the map
-// is guarded by a mutex to stay race-clean.
+// job.Manager spins a goroutine on Submit success that calls Save, so the map
+// is guarded by a mutex to stay race-clean. Rows are keyed by ID; seeded rows
+// get a fresh UUIDv7 so ordering by ID matches insertion order.
 type fakeJobRepo struct {
 	mu     sync.Mutex
 	jobs   map[string]jobModel.Job
-	getErr error // forced (non-NotFound) error for GetByType, when set
+	getErr error // forced (non-NotFound) error for reads, when set
 }
 
 func newFakeJobRepo() *fakeJobRepo {
 	return &fakeJobRepo{jobs: make(map[string]jobModel.Job)}
 }
 
-func (r *fakeJobRepo) Upsert(_ context.Context, j *jobModel.Job) error {
+func (r *fakeJobRepo) Create(_ context.Context, j *jobModel.Job) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.jobs[j.Type] = *j
+	r.jobs[j.ID] = *j
 	return nil
 }
 
-func (r *fakeJobRepo) GetByType(_ context.Context, jobType string) (jobModel.Job, error) {
+func (r *fakeJobRepo) Save(ctx context.Context, j *jobModel.Job) error {
+	return r.Create(ctx, j)
+}
+
+func (r *fakeJobRepo) GetByID(_ context.Context, id string) (jobModel.Job, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	if r.getErr != nil {
 		return jobModel.Job{}, r.getErr
 	}
-	j, ok := r.jobs[jobType]
+	j, ok := r.jobs[id]
 	if !ok {
 		return jobModel.Job{}, job.ErrNotFound
 	}
 	return j, nil
 }
 
-func (r *fakeJobRepo) SweepRunning(_ context.Context, _ string) error { return nil }
+func (r *fakeJobRepo) sorted(keep func(jobModel.Job) bool) []jobModel.Job {
+	var rows []jobModel.Job
+	for _, j := range r.jobs {
+		if keep(j) {
+			rows = append(rows, j)
+		}
+	}
+	sort.Slice(rows, func(a, b int) bool { return rows[a].ID < rows[b].ID })
+	return rows
+}
+
+func (r *fakeJobRepo) Latest(_ context.Context, jobType string) (jobModel.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.getErr != nil {
+		return jobModel.Job{}, r.getErr
+	}
+	rows := r.sorted(func(j jobModel.Job) bool { return j.Type == jobType && !j.Dismissed })
+	if len(rows) == 0 {
+		return jobModel.Job{}, job.ErrNotFound
+	}
+	return rows[len(rows)-1], nil
+}
+
+func (r *fakeJobRepo) Active(_ context.Context, jobType string) ([]jobModel.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.getErr != nil {
+		return nil, r.getErr
+	}
+	return r.sorted(func(j jobModel.Job) bool {
+		return (jobType == "" || j.Type == jobType) && !j.Status.IsTerminal()
+	}), nil
+}
+
+func (r *fakeJobRepo) List(_ context.Context, q job.ListQuery) ([]jobModel.Job, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows := r.sorted(func(j jobModel.Job) bool { return q.Type == "" || j.Type == q.Type })
+	return rows, int64(len(rows)), nil
+}
 
-func (r *fakeJobRepo) Delete(_ context.Context, jobType string) error {
+func (r *fakeJobRepo) Dismiss(_ context.Context, jobType string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	delete(r.jobs, jobType)
+	for id, j := range r.jobs {
+		if j.Type == jobType && j.Status.IsTerminal() {
+			j.Dismissed = true
+			r.jobs[id] = j
+		}
+	}
 	return nil
 }
 
+func (r *fakeJobRepo) Prune(context.Context, string, int) error { return nil }
+
 func (r *fakeJobRepo) seed(j jobModel.Job) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.jobs[j.Type] = j
+	if j.ID == "" {
+		j.ID = uuidUtil.MustNewV7()
+	}
+	r.jobs[j.ID] = j
 }
 
 // noopRunner immediately succeeds; used only so Submit's happy path can return a
@@ -99,7 +158,7 @@ func (noopRunner) Run(context.Context, []byte, job.ReportFunc) (any, error) { re
 // job.Manager backed by an in-memory repo. busProvider may be nil-returning for
 // methods that don't touch the bus.
 func newService(common CommonService, repo *fakeJobRepo, bus *busen.Bus) *MigratorService {
-	return NewMigratorService(common, job.NewManager(repo), func() *busen.Bus { return bus })
+	return NewMigratorService(common, job.NewManager(repo), func() *busen.Bus { return bus }, nil, kvstore.NewMemory())
 }
 
 func expectUser(t *testing.T, common *commonmock.MockService, u userModel.User, err error) {
@@ -312,7 +371,7 @@ func TestCleanupGlobalMigration(t *testing.T) {
 		assert.Equal(t, "迁移进行中，无法清理", err.Error())
 	})
 
-	t.Run("terminal job cleans tmp and deletes row", func(t *testing.T) {
+	t.Run("terminal job cleans tmp and dismisses row", func(t *testing.T) {
 		common := commonmock.NewMockService(t)
 		expectUser(t, common, adminUser(), nil)
 		repo := newFakeJobRepo()
@@ -323,9 +382,12 @@ func TestCleanupGlobalMigration(t *testing.T) {
 		})
 		s := newService(common, repo, nil)
 		require.NoError(t, s.CleanupGlobalMigration(helpers.CtxAsUser(adminID)))
-		// row removed
-		_, err := repo.GetByType(context.Background(), jobModel.TypeMigration)
+		// row dismissed: back to idle, but kept in history
+		_, err := repo.Latest(context.Background(), jobModel.TypeMigration)
 		assert.ErrorIs(t, err, job.ErrNotFound)
+		rows, _, err := repo.List(context.Background(), job.ListQuery{Type: jobModel.TypeMigration})
+		require.NoError(t, err)
+		assert.Len(t, rows, 1)
 	})
 
 	t.Run("repo error propagates", func(t *testing.T) {
@@ -511,7 +573,7 @@ func TestDownloadExport(t *testing.T) {
 		expectUser(t, common, normalUser(), nil)
 		s := newService(common, newFakeJobRepo(), nil)
 		c, _ := newGinCtx(t)
-		err := s.DownloadExport(c, helpers.CtxAsUser(adminID), "")
+		err := s.DownloadExport(c, helpers.CtxAsUser(adminID), "", "")
 		require.Error(t, err)
 		assert.Equal(t, commonModel.NO_PERMISSION_DENIED, err.Error())
 	})
@@ -522,7 +584,7 @@ func TestDownloadExport(t *testing.T) {
 		expectUser(t, common, adminUser(), nil)
 		s := newService(common, newFakeJobRepo(), nil)
 		c, _ := newGinCtx(t)
-		err := s.DownloadExport(c, helpers.CtxAsUser(adminID), "")
+		err := s.DownloadExport(c, helpers.CtxAsUser(adminID), "", "")
 		require.Error(t, err)
 		assert.Equal(t, "暂无可下载的产物，请先创建导出", err.Error())
 	})
@@ -540,7 +602,7 @@ func TestDownloadExport(t *testing.T) {
 		s := newService(common, newFakeJobRepo(), bus)
 
 		c, w := newGinCtx(t)
-		require.NoError(t, s.DownloadExport(c, helpers.CtxAsUser(adminID), ""))
+		require.NoError(t, s.DownloadExport(c, helpers.CtxAsUser(adminID), "", ""))
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
 		assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment;")
@@ -573,7 +635,7 @@ func TestDownloadExport(t *testing.T) {
 			expectUser(t, common, adminUser(), nil)
 			s := newService(common, newFakeJobRepo(), helpers.NewTestBus(t))
 			c, w := newGinCtx(t)
-			require.NoError(t, s.DownloadExport(c, helpers.CtxAsUser(adminID), tc.format))
+			require.NoError(t, s.DownloadExport(c, helpers.CtxAsUser(adminID), tc.format, ""))
 			assert.Equal(t, tc.want, w.Body.Bytes(), "format=%q", tc.format)
 			assert.Contains(t, w.Header().Get("Content-Disposition"), "ech0-")
 		}
@@ -586,7 +648,127 @@ func TestDownloadExport(t *testing.T) {
 		expectUser(t, common, adminUser(), nil)
 		s := newService(common, newFakeJobRepo(), nil)
 		c, _ := newGinCtx(t)
-		require.Error(t, s.DownloadExport(c, helpers.CtxAsUser(adminID), "tarball"))
+		require.Error(t, s.DownloadExport(c, helpers.CtxAsUser(adminID), "tarball", ""))
+	})
+
+	// 按名取回留存快照：名字来自请求参数，只认本槽位生成的文件名，路径穿越与已清理的名字都拒绝。
+	t.Run("name selects a retained snapshot", func(t *testing.T) {
+		chdirTemp(t)
+		snapDir := filepath.Join("data", "files", "snapshots")
+		require.NoError(t, os.MkdirAll(snapDir, 0o755))
+		older := "ech0_snapshot_2026-01-01_00-00-00.zip"
+		require.NoError(t, os.WriteFile(filepath.Join(snapDir, older), []byte("PK-older"), 0o644))
+		require.NoError(t, os.WriteFile(
+			filepath.Join(snapDir, "ech0_snapshot_2026-02-01_00-00-00.zip"), []byte("PK-newer"), 0o644))
+
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), helpers.NewTestBus(t))
+
+		c, w := newGinCtx(t)
+		require.NoError(t, s.DownloadExport(c, helpers.CtxAsUser(adminID), "snapshot", older))
+		assert.Equal(t, []byte("PK-older"), w.Body.Bytes())
+
+		for _, name := range []string{"../../ech0.db", "ech0_snapshot_2025-01-01_00-00-00.zip"} {
+			c, _ := newGinCtx(t)
+			err := s.DownloadExport(c, helpers.CtxAsUser(adminID), "snapshot", name)
+			require.Error(t, err, "name=%q", name)
+			assert.Equal(t, "快照不存在或已被清理", err.Error())
+		}
+	})
+}
+
+// ---------------------------------------------------------------------------
+// ListSnapshots / RestoreSnapshot
+// ---------------------------------------------------------------------------
+
+func TestListSnapshots(t *testing.T) {
+	t.Run("non-admin denied", func(t *testing.T) {
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, normalUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+		_, err := s.ListSnapshots(helpers.CtxAsUser(adminID))
+		require.Error(t, err)
+		assert.Equal(t, commonModel.NO_PERMISSION_DENIED, err.Error())
+	})
+
+	t.Run("lists retained snapshots newest first", func(t *testing.T) {
+		chdirTemp(t)
+		snapDir := filepath.Join("data", "files", "snapshots")
+		require.NoError(t, os.MkdirAll(snapDir, 0o755))
+		for name, content := range map[string]string{
+			"ech0_snapshot_2026-01-01_00-00-00.zip":      "PK-1",
+			"ech0_snapshot_2026-02-01_00-00-00.zip":      "PK-22",
+			".ech0_snapshot_2026-03-01_00-00-00.zip.tmp": "partial",
+		} {
+			require.NoError(t, os.WriteFile(filepath.Join(snapDir, name), []byte(content), 0o644))
+		}
+
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+		list, err := s.ListSnapshots(helpers.CtxAsUser(adminID))
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		assert.Equal(t, "ech0_snapshot_2026-02-01_00-00-00.zip", list[0].Name)
+		assert.Equal(t, int64(5), list[0].Size)
+		assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC).Unix(), list[0].CreatedAt)
+	})
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	const name = "ech0_snapshot_2026-01-01_00-00-00.zip"
+	seedSnapshot := func(t *testing.T) {
+		t.Helper()
+		chdirTemp(t)
+		snapDir := filepath.Join("data", "files", "snapshots")
+		require.NoError(t, os.MkdirAll(snapDir, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(snapDir, name), minimalZip(t), 0o644))
+	}
+
+	t.Run("unknown snapshot rejected", func(t *testing.T) {
+		seedSnapshot(t)
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+		_, err := s.RestoreSnapshot(
+			helpers.CtxAsUser(adminID),
+			"../ech0_snapshot_2026-01-01_00-00-00.zip",
+			migratorModel.ArchiveKeys{},
+		)
+		require.Error(t, err)
+		assert.Equal(t, "快照不存在或已被清理", err.Error())
+	})
+
+	t.Run("existing migration must be cleaned first", func(t *testing.T) {
+		seedSnapshot(t)
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		repo := newFakeJobRepo()
+		repo.seed(jobModel.Job{Type: jobModel.TypeMigration, Status: jobModel.StatusSuccess})
+		s := newService(common, repo, nil)
+		_, err := s.RestoreSnapshot(helpers.CtxAsUser(adminID), name, migratorModel.ArchiveKeys{})
+		require.Error(t, err)
+		assert.Equal(t, "请先结束/清理当前迁移", err.Error())
+		entries, _ := os.ReadDir(filepath.Join("data", "files", "tmp"))
+		assert.Empty(t, entries, "nothing should be unpacked when the migration slot is busy")
+	})
+
+	t.Run("unpacks snapshot and submits ech0 migration", func(t *testing.T) {
+		seedSnapshot(t)
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+		s.jobManager.Register(jobModel.TypeMigration, noopRunner{})
+
+		dto, err := s.RestoreSnapshot(helpers.CtxAsUser(adminID), name, migratorModel.ArchiveKeys{})
+		require.NoError(t, err)
+		assert.Equal(t, migratorModel.MigrationSourceEch0, dto.SourceType)
+		tmpDir, _ := dto.SourcePayload["tmp_dir"].(string)
+		require.NotEmpty(t, tmpDir)
+		got, err := os.ReadFile(filepath.Join("data", filepath.FromSlash(tmpDir), "hello.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "hi", string(got))
 	})
 }
 
@@ -627,7 +809,7 @@ func TestUploadSourceZip(t *testing.T) {
 	t.Run("invalid source type rejected first", func(t *testing.T) {
 		common := commonmock.NewMockService(t) // no auth call expected
 		s := newService(common, newFakeJobRepo(), nil)
-		_, err := s.UploadSourceZip(helpers.CtxAsUser(adminID), "bogus", nil)
+		_, err := s.UploadSourceZip(helpers.CtxAsUser(adminID), "bogus", nil, migratorModel.ArchiveKeys{})
 		require.Error(t, err)
 		assert.Equal(t, commonModel.INVALID_REQUEST_BODY, err.Error())
 	})
@@ -635,7 +817,12 @@ func TestUploadSourceZip(t *testing.T) {
 	t.Run("nil file rejected", func(t *testing.T) {
 		common := commonmock.NewMockService(t)
 		s := newService(common, newFakeJobRepo(), nil)
-		_, err := s.UploadSourceZip(helpers.CtxAsUser(adminID), migratorModel.MigrationSourceEch0, nil)
+		_, err := s.UploadSourceZip(
+			helpers.CtxAsUser(adminID),
+			migratorModel.MigrationSourceEch0,
+			nil,
+			migratorModel.ArchiveKeys{},
+		)
 		require.Error(t, err)
 		assert.Equal(t, commonModel.INVALID_REQUEST_BODY, err.Error())
 	})
@@ -648,6 +835,7 @@ func TestUploadSourceZip(t *testing.T) {
 			helpers.CtxAsUser(adminID),
 			migratorModel.MigrationSourceEch0,
 			&multipart.FileHeader{Filename: "src.zip"},
+			migratorModel.ArchiveKeys{},
 		)
 		require.Error(t, err)
 		assert.Equal(t, commonModel.NO_PERMISSION_DENIED, err.Error())
@@ -663,6 +851,7 @@ func TestUploadSourceZip(t *testing.T) {
 			helpers.CtxAsUser(adminID),
 			migratorModel.MigrationSourceEch0,
 			&multipart.FileHeader{Filename: "src.zip"},
+			migratorModel.ArchiveKeys{},
 		)
 		require.Error(t, err)
 		assert.Equal(t, "请先结束/清理当前迁移", err.Error())
@@ -676,6 +865,7 @@ func TestUploadSourceZip(t *testing.T) {
 			helpers.CtxAsUser(adminID),
 			migratorModel.MigrationSourceEch0,
 			&multipart.FileHeader{Filename: "src.txt"},
+			migratorModel.ArchiveKeys{},
 		)
 		require.Error(t, err)
 		assert.Equal(t, commonModel.INVALID_REQUEST_BODY, err.Error())
@@ -688,7 +878,12 @@ func TestUploadSourceZip(t *testing.T) {
 		s := newService(common, newFakeJobRepo(), nil)
 
 		header := zipFileHeader(t, "ech0-export.zip", minimalZip(t))
-		resp, err := s.UploadSourceZip(helpers.CtxAsUser(adminID), migratorModel.MigrationSourceEch0, header)
+		resp, err := s.UploadSourceZip(
+			helpers.CtxAsUser(adminID),
+			migratorModel.MigrationSourceEch0,
+			header,
+			migratorModel.ArchiveKeys{},
+		)
 		require.NoError(t, err)
 		assert.Equal(t, migratorModel.MigrationSourceEch0, resp.SourceType)
 		assert.Contains(t, resp.TmpDir, "files/tmp/ech0_")
@@ -698,4 +893,38 @@ func TestUploadSourceZip(t *testing.T) {
 		_, statErr := os.Stat(filepath.Join("data", filepath.FromSlash(resp.TmpDir)))
 		assert.NoError(t, statErr)
 	})
+
+	t.Run("tarball rejected for non-mastodon source", func(t *testing.T) {
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+		_, err := s.UploadSourceZip(
+			helpers.CtxAsUser(adminID),
+			migratorModel.MigrationSourceTwitter,
+			&multipart.FileHeader{Filename: "archive.tar.gz"},
+			migratorModel.ArchiveKeys{},
+		)
+		require.Error(t, err)
+		assert.Equal(t, commonModel.INVALID_REQUEST_BODY, err.Error())
+	})
+
+	t.Run("mastodon tarball stored unopened", func(t *testing.T) {
+		chdirTemp(t)
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+
+		header := zipFileHeader(t, "archive-20260101.tar.gz", []byte("tarball"))
+		resp, err := s.UploadSourceZip(
+			helpers.CtxAsUser(adminID),
+			migratorModel.MigrationSourceMastodon,
+			header,
+			migratorModel.ArchiveKeys{},
+		)
+		require.NoError(t, err)
+		assert.Contains(t, resp.TmpDir, "files/tmp/mastodon_")
+		data, readErr := os.ReadFile(filepath.Join("data", filepath.FromSlash(resp.TmpDir), "archive.tar.gz"))
+		require.NoError(t, readErr)
+		assert.Equal(t, "tarball", string(data))
+	})
 }
@@ -19,8 +19,10 @@ import (
 	"github.com/lin-snow/ech0/internal/event"
 	eventbus "github.com/lin-snow/ech0/internal/event/bus"
 	"github.com/lin-snow/ech0/internal/job"
+	"github.com/lin-snow/ech0/internal/kvstore"
 	coreMigrator "github.com/lin-snow/ech0/internal/migrator"
 	"github.com/lin-snow/ech0/internal/migrator/artifact"
+	"github.com/lin-snow/ech0/internal/migrator/envelope"
 	snapshot "github.com/lin-snow/ech0/internal/migrator/snapshot"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	jobModel "github.com/lin-snow/ech0/internal/model/job"
@@ -37,25 +39,32 @@ type MigratorService struct {
 	commonService CommonService
 	jobManager    *job.Manager
 	bus           *busen.Bus
+	syncEngine    SyncEngine
+	durableKV     kvstore.Store
 }
 
 func NewMigratorService(
 	commonService CommonService,
 	jobManager *job.Manager,
 	busProvider func() *busen.Bus,
+	syncEngine SyncEngine,
+	durableKV kvstore.Store,
 ) *MigratorService {
 	return &MigratorService{
 		commonService: commonService,
 		jobManager:    jobManager,
 		bus:           busProvider(),
+		syncEngine:    syncEngine,
+		durableKV:     durableKV,
 	}
 }
 
 // DownloadExport 流式下发「上一次导出作业产出的产物」(GET /migration/export/download)。
 // 与导入的 upload 对称:重活(打包/S3)在异步 export 作业里完成,这里只同步取回产物,不再现打包。
-// format 决定取哪个槽位——快照与胶囊各自「只保留最新一份」,混用会互删,详见 migrator/artifact。
+// format 决定取哪个槽位——快照与胶囊分居两处,混用会互删,详见 migrator/artifact。
+// name 非空时取该槽位里指定的一份(快照按 GFS 留存多份),否则取最新一份。
 // 无可用产物时报错提示先创建导出。下发后发 SystemExport 事件。
-func (s *MigratorService) DownloadExport(ctx *gin.Context, reqCtx context.Context, format string) error {
+func (s *MigratorService) DownloadExport(ctx *gin.Context, reqCtx context.Context, format, name string) error {
 	if _, err := s.ensureAdmin(reqCtx); err != nil {
 		return err
 	}
@@ -70,9 +79,17 @@ func (s *MigratorService) DownloadExport(ctx *gin.Context, reqCtx context.Contex
 		slot = artifact.Capsules()
 	}
 
-	artifactPath, err := slot.Latest()
-	if errors.Is(err, artifact.ErrNone) {
-		return errors.New("暂无可下载的产物，请先创建导出")
+	var artifactPath string
+	if name = strings.TrimSpace(name); name != "" {
+		artifactPath, err = slot.Lookup(name)
+		if errors.Is(err, artifact.ErrNone) {
+			return errors.New("快照不存在或已被清理")
+		}
+	} else {
+		artifactPath, err = slot.Latest()
+		if errors.Is(err, artifact.ErrNone) {
+			return errors.New("暂无可下载的产物，请先创建导出")
+		}
 	}
 	if err != nil {
 		return err
@@ -98,10 +115,84 @@ func (s *MigratorService) DownloadExport(ctx *gin.Context, reqCtx context.Contex
 	return nil
 }
 
+// ListSnapshots 列出本地留存的快照（新的在前）。留存份数由定时快照计划里的 GFS 策略决定。
+func (s *MigratorService) ListSnapshots(ctx context.Context) ([]migratorModel.SnapshotEntry, error) {
+	if _, err := s.ensureAdmin(ctx); err != nil {
+		return nil, err
+	}
+	entries, err := artifact.Snapshots().List()
+	if err != nil {
+		return nil, err
+	}
+	slot := artifact.Snapshots()
+	out := make([]migratorModel.SnapshotEntry, 0, len(entries))
+	for _, e := range entries {
+		// 读不了清单的按未加密列出，恢复时解包自会报出真正的错误。
+		_, encrypted, _ := envelope.Inspect(slot.Path(e.Name))
+		out = append(out, migratorModel.SnapshotEntry{
+			Name:      e.Name,
+			Size:      e.Size,
+			CreatedAt: e.CreatedAt.Unix(),
+			Encrypted: encrypted,
+		})
+	}
+	return out, nil
+}
+
+// RestoreSnapshot 用一份留存快照发起恢复：解包到迁移暂存目录，再按 ech0 来源提交迁移作业——
+// 与「上传快照 zip → 开始迁移」是同一条路径，只是省掉了下载再上传的往返。加密快照用 keys
+// 解密（留空则用已存口令）。
+func (s *MigratorService) RestoreSnapshot(
+	ctx context.Context,
+	name string,
+	keys migratorModel.ArchiveKeys,
+) (migratorModel.GlobalMigrationStateDTO, error) {
+	if _, err := s.ensureAdmin(ctx); err != nil {
+		return migratorModel.GlobalMigrationStateDTO{}, err
+	}
+	snapshotPath, err := artifact.Snapshots().Lookup(strings.TrimSpace(name))
+	if errors.Is(err, artifact.ErrNone) {
+		return migratorModel.GlobalMigrationStateDTO{}, errors.New("快照不存在或已被清理")
+	}
+	if err != nil {
+		return migratorModel.GlobalMigrationStateDTO{}, err
+	}
+	if _, err := s.jobManager.Get(ctx, jobModel.TypeMigration); err == nil {
+		return migratorModel.GlobalMigrationStateDTO{}, errors.New("请先结束/清理当前迁移")
+	} else if !errors.Is(err, job.ErrNotFound) {
+		return migratorModel.GlobalMigrationStateDTO{}, err
+	}
+
+	plainPath, cleanup, err := s.unsealArchive(ctx, snapshotPath, keys)
+	if err != nil {
+		return migratorModel.GlobalMigrationStateDTO{}, err
+	}
+	defer cleanup()
+
+	folderName := fmt.Sprintf("%s_%s", migratorModel.MigrationSourceEch0, uuidUtil.MustNewV7())
+	extractDir := filepath.Join("data", coreMigrator.TmpRelativeDir, folderName)
+	if err := os.MkdirAll(extractDir, 0o755); err != nil {
+		return migratorModel.GlobalMigrationStateDTO{}, fmt.Errorf("create extract dir: %w", err)
+	}
+	if err := snapshot.Unpack(plainPath, extractDir); err != nil {
+		_ = os.RemoveAll(extractDir)
+		return migratorModel.GlobalMigrationStateDTO{}, fmt.Errorf("unpack snapshot: %w", err)
+	}
+
+	relativeTmpDir := filepath.ToSlash(filepath.Join(coreMigrator.TmpRelativeDir, folderName))
+	return s.StartGlobalMigration(ctx, migratorModel.StartGlobalMigrationRequest{
+		SourceType:    migratorModel.MigrationSourceEch0,
+		SourcePayload: map[string]any{"tmp_dir": relativeTmpDir},
+	})
+}
+
+// UploadSourceZip 接收迁移来源归档并解包到暂存目录。加密的快照 / 胶囊信封在解包前用 keys
+// 透明解密（留空则用已存口令），对后续的迁移作业而言与明文上传无异。
 func (s *MigratorService) UploadSourceZip(
 	ctx context.Context,
 	sourceType string,
 	file *multipart.FileHeader,
+	keys migratorModel.ArchiveKeys,
 ) (migratorModel.UploadMigrationSourceZipResponse, error) {
 	if err := validateSourceType(sourceType); err != nil {
 		return migratorModel.UploadMigrationSourceZipResponse{}, err
@@ -125,7 +216,12 @@ func (s *MigratorService) UploadSourceZip(
 		return migratorModel.UploadMigrationSourceZipResponse{}, err
 	}
 
-	if !strings.HasSuffix(strings.ToLower(file.Filename), ".zip") {
+	lowerName := strings.ToLower(file.Filename)
+	// Mastodon 的账号归档本身就是 tar.gz，逼用户再套一层 zip 没有意义：原样放进暂存目录，
+	// 由适配器自己解开。其余来源仍只收 zip。
+	tarball := sourceType == migratorModel.MigrationSourceMastodon &&
+		(strings.HasSuffix(lowerName, ".tar.gz") || strings.HasSuffix(lowerName, ".tgz"))
+	if !tarball && !strings.HasSuffix(lowerName, ".zip") {
 		return migratorModel.UploadMigrationSourceZipResponse{}, errors.New(commonModel.INVALID_REQUEST_BODY)
 	}
 
@@ -138,6 +234,22 @@ func (s *MigratorService) UploadSourceZip(
 	folderName := fmt.Sprintf("%s_%s", strings.TrimSpace(sourceType), uploadID)
 	zipPath := filepath.Join(baseTmpDir, folderName+".zip")
 	extractDir := filepath.Join(baseTmpDir, folderName)
+	relativeTmpDir := filepath.ToSlash(filepath.Join(coreMigrator.TmpRelativeDir, folderName))
+
+	if tarball {
+		if err := os.MkdirAll(extractDir, 0o755); err != nil {
+			return migratorModel.UploadMigrationSourceZipResponse{}, fmt.Errorf("create extract dir: %w", err)
+		}
+		if err := saveMultipartFile(file, filepath.Join(extractDir, "archive.tar.gz")); err != nil {
+			_ = os.RemoveAll(extractDir)
+			return migratorModel.UploadMigrationSourceZipResponse{}, fmt.Errorf("save uploaded archive: %w", err)
+		}
+		return migratorModel.UploadMigrationSourceZipResponse{
+			SourceType:    sourceType,
+			TmpDir:        relativeTmpDir,
+			SourcePayload: map[string]any{"tmp_dir": relativeTmpDir},
+		}, nil
+	}
 
 	if err := saveMultipartFile(file, zipPath); err != nil {
 		return migratorModel.UploadMigrationSourceZipResponse{}, fmt.Errorf("save uploaded zip: %w", err)
@@ -146,15 +258,20 @@ func (s *MigratorService) UploadSourceZip(
 		_ = os.Remove(zipPath)
 	}()
 
+	plainPath, cleanup, err := s.unsealArchive(ctx, zipPath, keys)
+	if err != nil {
+		return migratorModel.UploadMigrationSourceZipResponse{}, err
+	}
+	defer cleanup()
+
 	if err := os.MkdirAll(extractDir, 0o755); err != nil {
 		return migratorModel.UploadMigrationSourceZipResponse{}, fmt.Errorf("create extract dir: %w", err)
 	}
-	if err := snapshot.Unpack(zipPath, extractDir); err != nil {
+	if err := snapshot.Unpack(plainPath, extractDir); err != nil {
 		_ = os.RemoveAll(extractDir)
 		return migratorModel.UploadMigrationSourceZipResponse{}, fmt.Errorf("unpack migration zip: %w", err)
 	}
 
-	relativeTmpDir := filepath.ToSlash(filepath.Join(coreMigrator.TmpRelativeDir, folderName))
 	return migratorModel.UploadMigrationSourceZipResponse{
 		SourceType:    sourceType,
 		TmpDir:        relativeTmpDir,
@@ -229,7 +346,7 @@ func (s *MigratorService) CancelGlobalMigration(ctx context.Context) (migratorMo
 	if jb.Status != jobModel.StatusPending && jb.Status != jobModel.StatusRunning {
 		return migratorModel.GlobalMigrationStateDTO{}, errors.New(commonModel.INVALID_REQUEST_BODY)
 	}
-	_ = s.jobManager.Cancel(jobModel.TypeMigration)
+	_ = s.jobManager.Cancel(ctx, jobModel.TypeMigration)
 	jb, err = s.jobManager.Get(ctx, jobModel.TypeMigration)
 	if err != nil {
 		return migratorModel.GlobalMigrationStateDTO{}, err
@@ -237,7 +354,7 @@ func (s *MigratorService) CancelGlobalMigration(ctx context.Context) (migratorMo
 	return s.jobToDTO(jb), nil
 }
 
-// CleanupGlobalMigration 清理 tmp 目录并删除作业行（复位 idle）。
+// CleanupGlobalMigration 清理 tmp 目录并收起作业（复位 idle，作业留在历史里）。
 func (s *MigratorService) CleanupGlobalMigration(ctx context.Context) error {
 	if _, err := s.ensureAdmin(ctx); err != nil {
 		return err
@@ -259,11 +376,11 @@ func (s *MigratorService) CleanupGlobalMigration(ctx context.Context) error {
 	if err := coreMigrator.CleanupTmpDirFromPayload(payload.SourcePayload); err != nil {
 		return fmt.Errorf("cleanup migration tmp dir: %w", err)
 	}
-	return s.jobManager.Delete(ctx, jobModel.TypeMigration)
+	return s.jobManager.Dismiss(ctx, jobModel.TypeMigration)
 }
 
 // StartExport 提交一次导出作业（手动导出的异步出口），格式由请求决定：快照或胶囊。
-// 互斥 + 持久化 + goroutine 生命周期由 job.Manager 负责；快照完成由 ExportRunner 发
+// 排队 + 持久化 + goroutine 生命周期由 job.Manager 负责；快照完成由 ExportRunner 发
 // SystemSnapshot 事件，无需 service 介入。
 func (s *MigratorService) StartExport(
 	ctx context.Context,
@@ -290,6 +407,9 @@ func (s *MigratorService) StartExport(
 		if errors.Is(err, job.ErrAlreadyRunning) {
 			return migratorModel.ExportStateDTO{}, errors.New("导出进行中，请稍候")
 		}
+		if errors.Is(err, job.ErrQueueFull) {
+			return migratorModel.ExportStateDTO{}, errors.New("排队中的导出已满，请稍候")
+		}
 		return migratorModel.ExportStateDTO{}, err
 	}
 	return s.jobExportToDTO(jb), nil
@@ -325,7 +445,7 @@ func (s *MigratorService) CancelExport(ctx context.Context) (migratorModel.Expor
 	if jb.Status != jobModel.StatusPending && jb.Status != jobModel.StatusRunning {
 		return migratorModel.ExportStateDTO{}, errors.New(commonModel.INVALID_REQUEST_BODY)
 	}
-	_ = s.jobManager.Cancel(jobModel.TypeExport)
+	_ = s.jobManager.Cancel(ctx, jobModel.TypeExport)
 	jb, err = s.jobManager.Get(ctx, jobModel.TypeExport)
 	if err != nil {
 		return migratorModel.ExportStateDTO{}, err
@@ -419,6 +539,9 @@ func validateSourceType(sourceType string) error {
 	switch strings.TrimSpace(sourceType) {
 	case migratorModel.MigrationSourceMemos,
 		migratorModel.MigrationSourceEch0,
+		migratorModel.MigrationSourceMarkdown,
+		migratorModel.MigrationSourceTwitter,
+		migratorModel.MigrationSourceMastodon,
 		migratorModel.MigrationSourceCapsule:
 		return nil
 	default:
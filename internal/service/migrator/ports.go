@@ -5,15 +5,22 @@ package service
 
 import (
 	"context"
+	"io"
 	"mime/multipart"
 
 	"github.com/gin-gonic/gin"
+	coreMigrator "github.com/lin-snow/ech0/internal/migrator"
 	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
 	commonService "github.com/lin-snow/ech0/internal/service/common"
 )
 
 type Service interface {
-	UploadSourceZip(ctx context.Context, sourceType string, file *multipart.FileHeader) (migratorModel.UploadMigrationSourceZipResponse, error)
+	UploadSourceZip(
+		ctx context.Context,
+		sourceType string,
+		file *multipart.FileHeader,
+		keys migratorModel.ArchiveKeys,
+	) (migratorModel.UploadMigrationSourceZipResponse, error)
 	StartGlobalMigration(ctx context.Context, req migratorModel.StartGlobalMigrationRequest) (migratorModel.GlobalMigrationStateDTO, error)
 	GetGlobalMigrationStatus(ctx context.Context) (migratorModel.GlobalMigrationStateDTO, error)
 	CancelGlobalMigration(ctx context.Context) (migratorModel.GlobalMigrationStateDTO, error)
@@ -22,7 +29,45 @@ type Service interface {
 	StartExport(ctx context.Context, req migratorModel.StartExportRequest) (migratorModel.ExportStateDTO, error)
 	GetExportStatus(ctx context.Context) (migratorModel.ExportStateDTO, error)
 	CancelExport(ctx context.Context) (migratorModel.ExportStateDTO, error)
-	DownloadExport(ctx *gin.Context, reqCtx context.Context, format string) error
+	DownloadExport(ctx *gin.Context, reqCtx context.Context, format, name string) error
+	ListSnapshots(ctx context.Context) ([]migratorModel.SnapshotEntry, error)
+	RestoreSnapshot(
+		ctx context.Context,
+		name string,
+		keys migratorModel.ArchiveKeys,
+	) (migratorModel.GlobalMigrationStateDTO, error)
+
+	GetEncryptionSetting(ctx context.Context) (migratorModel.EncryptionSetting, error)
+	UpdateEncryptionSetting(ctx context.Context, setting migratorModel.EncryptionSetting) error
+
+	GetSyncSetting(ctx context.Context) (migratorModel.SyncSetting, error)
+	UpdateSyncSetting(ctx context.Context, setting migratorModel.SyncSetting) error
+	StartSync(ctx context.Context) (migratorModel.SyncStateDTO, error)
+	GetSyncStatus(ctx context.Context) (migratorModel.SyncStateDTO, error)
+	CancelSync(ctx context.Context) (migratorModel.SyncStateDTO, error)
+	PullSyncChanges(ctx *gin.Context, reqCtx context.Context, since int64, includePrivate bool) error
+	PushSyncChanges(
+		ctx context.Context,
+		body io.Reader,
+		base int64,
+		policy string,
+		includePrivate bool,
+	) (migratorModel.SyncApplyResult, error)
+
+	GetPublishSetting(ctx context.Context) (migratorModel.PublishSetting, error)
+	UpdatePublishSetting(ctx context.Context, setting migratorModel.PublishSetting) error
+	StartPublish(ctx context.Context) (migratorModel.PublishStateDTO, error)
+	GetPublishStatus(ctx context.Context) (migratorModel.PublishStateDTO, error)
+}
+
+// SyncEngine 是同步端点背后的增量胶囊执行端（由 migrator.CapsuleEngine 满足）。
+type SyncEngine interface {
+	ExportChanges(ctx context.Context, since int64, includePrivate bool) (coreMigrator.SyncArchive, error)
+	ApplyChanges(
+		ctx context.Context,
+		archivePath string,
+		opts coreMigrator.ApplyOptions,
+	) (migratorModel.SyncApplyResult, error)
 }
 
 type CommonService = commonService.Service
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	capsulePublish "github.com/lin-snow/ech0/internal/capsule/publish"
+	"github.com/lin-snow/ech0/internal/job"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	jobModel "github.com/lin-snow/ech0/internal/model/job"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+)
+
+// GetPublishSetting 读出静态站发布配置；SecretKey 脱敏为 SecretKeySet。
+func (s *MigratorService) GetPublishSetting(ctx context.Context) (migratorModel.PublishSetting, error) {
+	if _, err := s.ensureAdmin(ctx); err != nil {
+		return migratorModel.PublishSetting{}, err
+	}
+	setting, err := coreSetting.Get(ctx, s.durableKV, coreSetting.Publish)
+	if err != nil {
+		return migratorModel.PublishSetting{}, err
+	}
+	return sanitizePublishSetting(setting), nil
+}
+
+// UpdatePublishSetting 保存静态站发布配置。目标在保存时就解析一遍，写错的地址当场报错；
+// SecretKey 留空即沿用已存的密钥。本机目录与本地 git 仓库不收：它们让管理接口能往服务器
+// 任意路径写文件，只留给 CLI（`ech0 build --publish`）。
+func (s *MigratorService) UpdatePublishSetting(ctx context.Context, setting migratorModel.PublishSetting) error {
+	if _, err := s.ensureAdmin(ctx); err != nil {
+		return err
+	}
+	if strings.TrimSpace(setting.Target) != "" {
+		target, err := capsulePublish.ParseTarget(setting.Target)
+		if err != nil {
+			return errors.New(commonModel.INVALID_REQUEST_BODY)
+		}
+		if target.Local() {
+			return errors.New(commonModel.PUBLISH_TARGET_LOCAL)
+		}
+	}
+
+	if strings.TrimSpace(setting.SecretKey) == "" {
+		current, err := coreSetting.Get(ctx, s.durableKV, coreSetting.Publish)
+		if err == nil {
+			setting.SecretKey = current.SecretKey
+		}
+	}
+	setting.SecretKeySet = false
+	return coreSetting.Set(ctx, s.durableKV, coreSetting.Publish, setting)
+}
+
+// StartPublish 提交一轮发布作业（手动触发）。与 StartSync 一样只在这里校验「已配置」。
+func (s *MigratorService) StartPublish(ctx context.Context) (migratorModel.PublishStateDTO, error) {
+	if _, err := s.ensureAdmin(ctx); err != nil {
+		return migratorModel.PublishStateDTO{}, err
+	}
+	setting, err := coreSetting.Get(ctx, s.durableKV, coreSetting.Publish)
+	if err != nil {
+		return migratorModel.PublishStateDTO{}, err
+	}
+	if setting.Target == "" {
+		return migratorModel.PublishStateDTO{}, errors.New("发布目标未配置")
+	}
+
+	raw, err := json.Marshal(migratorModel.PublishPayload{Trigger: "manual"})
+	if err != nil {
+		return migratorModel.PublishStateDTO{}, err
+	}
+	jb, err := s.jobManager.Submit(ctx, jobModel.TypePublish, raw)
+	if err != nil {
+		if errors.Is(err, job.ErrAlreadyRunning) || errors.Is(err, job.ErrQueueFull) {
+			return migratorModel.PublishStateDTO{}, errors.New("发布进行中，请稍候")
+		}
+		return migratorModel.PublishStateDTO{}, err
+	}
+	return jobPublishToDTO(jb), nil
+}
+
+// GetPublishStatus 查询当前发布状态；查无作业行时合成 idle 哨兵。
+func (s *MigratorService) GetPublishStatus(ctx context.Context) (migratorModel.PublishStateDTO, error) {
+	if _, err := s.ensureAdmin(ctx); err != nil {
+		return migratorModel.PublishStateDTO{}, err
+	}
+	jb, err := s.jobManager.Get(ctx, jobModel.TypePublish)
+	if errors.Is(err, job.ErrNotFound) {
+		return migratorModel.PublishStateDTO{Version: 1, Status: migratorModel.MigrationStatusIdle}, nil
+	}
+	if err != nil {
+		return migratorModel.PublishStateDTO{}, err
+	}
+	return jobPublishToDTO(jb), nil
+}
+
+// jobPublishToDTO 把通用 Job 映射回 PublishStateDTO，规则同 jobSyncToDTO。
+func jobPublishToDTO(jb jobModel.Job) migratorModel.PublishStateDTO {
+	dto := migratorModel.PublishStateDTO{
+		Version:      1,
+		Status:       string(jb.Status),
+		Phase:        jb.Phase,
+		ErrorMessage: jb.Error,
+		StartedAt:    jb.StartedAt,
+		FinishedAt:   jb.FinishedAt,
+	}
+	if jb.Status == jobModel.StatusSuccess && jb.Payload != "" {
+		var report migratorModel.PublishReport
+		if err := json.Unmarshal([]byte(jb.Payload), &report); err == nil {
+			dto.Report = &report
+		}
+	}
+	if jb.UpdatedAt != 0 {
+		updatedAt := jb.UpdatedAt
+		dto.UpdatedAt = &updatedAt
+	}
+	return dto
+}
+
+func sanitizePublishSetting(in migratorModel.PublishSetting) migratorModel.PublishSetting {
+	out := in
+	out.SecretKeySet = strings.TrimSpace(out.SecretKey) != ""
+	out.SecretKey = ""
+	return out
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	jobModel "github.com/lin-snow/ech0/internal/model/job"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/lin-snow/ech0/internal/test/mocks/commonmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishSetting(t *testing.T) {
+	t.Run("blank secret on update keeps stored secret", func(t *testing.T) {
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+		require.NoError(t, coreSetting.Set(context.Background(), s.durableKV, coreSetting.Publish,
+			migratorModel.PublishSetting{Target: "s3://site", AccessKey: "ak", SecretKey: "sk"}))
+
+		require.NoError(t, s.UpdatePublishSetting(helpers.CtxAsUser(adminID), migratorModel.PublishSetting{
+			Enable:    true,
+			Target:    "s3://site/blog?provider=r2",
+			AccessKey: "ak",
+		}))
+
+		stored, err := coreSetting.Get(context.Background(), s.durableKV, coreSetting.Publish)
+		require.NoError(t, err)
+		assert.Equal(t, "sk", stored.SecretKey)
+		assert.Equal(t, 60, stored.DebounceSeconds)
+
+		got, err := s.GetPublishSetting(helpers.CtxAsUser(adminID))
+		require.NoError(t, err)
+		assert.Empty(t, got.SecretKey)
+		assert.True(t, got.SecretKeySet)
+	})
+
+	t.Run("unparseable target rejected", func(t *testing.T) {
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+		err := s.UpdatePublishSetting(helpers.CtxAsUser(adminID), migratorModel.PublishSetting{Target: "ftp://host/site"})
+		require.Error(t, err)
+		assert.Equal(t, commonModel.INVALID_REQUEST_BODY, err.Error())
+	})
+
+	t.Run("local directory and local git targets are CLI-only", func(t *testing.T) {
+		for _, target := range []string{"/var/www/site", "file:///var/www/site", "git+/srv/git/site.git"} {
+			common := commonmock.NewMockService(t)
+			expectUser(t, common, adminUser(), nil)
+			s := newService(common, newFakeJobRepo(), nil)
+			err := s.UpdatePublishSetting(helpers.CtxAsUser(adminID), migratorModel.PublishSetting{Target: target})
+			require.EqualError(t, err, commonModel.PUBLISH_TARGET_LOCAL, target)
+		}
+	})
+}
+
+func TestStartPublish(t *testing.T) {
+	t.Run("unconfigured target rejected before submit", func(t *testing.T) {
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+		_, err := s.StartPublish(helpers.CtxAsUser(adminID))
+		require.Error(t, err)
+		assert.Equal(t, "发布目标未配置", err.Error())
+	})
+
+	t.Run("success returns pending publish DTO", func(t *testing.T) {
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+		s.jobManager.Register(jobModel.TypePublish, noopRunner{})
+		require.NoError(t, coreSetting.Set(context.Background(), s.durableKV, coreSetting.Publish,
+			migratorModel.PublishSetting{Target: "s3://site"}))
+
+		dto, err := s.StartPublish(helpers.CtxAsUser(adminID))
+		require.NoError(t, err)
+		assert.Equal(t, string(jobModel.StatusPending), dto.Status)
+	})
+}
+
+func TestJobPublishToDTO_ParsesReportOnSuccess(t *testing.T) {
+	raw, err := json.Marshal(migratorModel.PublishReport{Target: "s3://site/", Uploaded: 3, Deleted: 1})
+	require.NoError(t, err)
+
+	dto := jobPublishToDTO(jobModel.Job{Type: jobModel.TypePublish, Status: jobModel.StatusSuccess, Payload: string(raw)})
+	require.NotNil(t, dto.Report)
+	assert.Equal(t, 3, dto.Report.Uploaded)
+	assert.Equal(t, 1, dto.Report.Deleted)
+}
@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lin-snow/ech0/internal/capsule/replica"
+	"github.com/lin-snow/ech0/internal/job"
+	coreMigrator "github.com/lin-snow/ech0/internal/migrator"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	jobModel "github.com/lin-snow/ech0/internal/model/job"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	"github.com/lin-snow/ech0/internal/util/egress"
+)
+
+// GetSyncSetting 读出同步对端配置；AccessToken 脱敏为 AccessTokenSet。
+func (s *MigratorService) GetSyncSetting(ctx context.Context) (migratorModel.SyncSetting, error) {
+	if _, err := s.ensureAdmin(ctx); err != nil {
+		return migratorModel.SyncSetting{}, err
+	}
+	setting, err := coreSetting.Get(ctx, s.durableKV, coreSetting.Sync)
+	if err != nil {
+		return migratorModel.SyncSetting{}, err
+	}
+	return sanitizeSyncSetting(setting), nil
+}
+
+// UpdateSyncSetting 保存同步对端配置。AccessToken 留空即沿用已存的令牌，前端拿不到明文也能改其它项。
+// 换了对端不必清水位：水位按对端地址分键存放，新对端自然从零开始。
+// 指向内网 / 本机的对端须同时开启 AllowPrivatePeer，否则当场拒绝（同步时的 client 也会拦）。
+func (s *MigratorService) UpdateSyncSetting(ctx context.Context, setting migratorModel.SyncSetting) error {
+	if _, err := s.ensureAdmin(ctx); err != nil {
+		return err
+	}
+	if _, err := replica.ParsePolicy(setting.ConflictPolicy); err != nil {
+		return errors.New(commonModel.INVALID_REQUEST_BODY)
+	}
+	peerURL := strings.TrimSpace(setting.PeerURL)
+	if peerURL != "" && !strings.HasPrefix(peerURL, "http://") && !strings.HasPrefix(peerURL, "https://") {
+		return errors.New(commonModel.INVALID_REQUEST_BODY)
+	}
+	if peerURL != "" && !setting.AllowPrivatePeer {
+		if err := egress.Validate(peerURL); err != nil {
+			return errors.New(commonModel.INVALID_REQUEST_BODY)
+		}
+	}
+
+	if strings.TrimSpace(setting.AccessToken) == "" {
+		current, err := coreSetting.Get(ctx, s.durableKV, coreSetting.Sync)
+		if err == nil {
+			setting.AccessToken = current.AccessToken
+		}
+	}
+	setting.AccessTokenSet = false
+	return coreSetting.Set(ctx, s.durableKV, coreSetting.Sync, setting)
+}
+
+// StartSync 提交一轮同步作业（手动触发）。对端配置在作业里现读，这里只校验「已配置」，
+// 好让管理员在提交时就拿到错误而不是等作业失败。
+func (s *MigratorService) StartSync(ctx context.Context) (migratorModel.SyncStateDTO, error) {
+	if _, err := s.ensureAdmin(ctx); err != nil {
+		return migratorModel.SyncStateDTO{}, err
+	}
+	setting, err := coreSetting.Get(ctx, s.durableKV, coreSetting.Sync)
+	if err != nil {
+		return migratorModel.SyncStateDTO{}, err
+	}
+	if setting.PeerURL == "" || strings.TrimSpace(setting.AccessToken) == "" {
+		return migratorModel.SyncStateDTO{}, errors.New("同步对端未配置")
+	}
+
+	raw, err := json.Marshal(migratorModel.SyncPayload{Trigger: "manual"})
+	if err != nil {
+		return migratorModel.SyncStateDTO{}, err
+	}
+	jb, err := s.jobManager.Submit(ctx, jobModel.TypeSync, raw)
+	if err != nil {
+		if errors.Is(err, job.ErrAlreadyRunning) {
+			return migratorModel.SyncStateDTO{}, errors.New("同步进行中，请稍候")
+		}
+		return migratorModel.SyncStateDTO{}, err
+	}
+	return jobSyncToDTO(jb), nil
+}
+
+// GetSyncStatus 查询当前同步状态；查无作业行时合成 idle 哨兵。
+func (s *MigratorService) GetSyncStatus(ctx context.Context) (migratorModel.SyncStateDTO, error) {
+	if _, err := s.ensureAdmin(ctx); err != nil {
+		return migratorModel.SyncStateDTO{}, err
+	}
+	jb, err := s.jobManager.Get(ctx, jobModel.TypeSync)
+	if errors.Is(err, job.ErrNotFound) {
+		return migratorModel.SyncStateDTO{Version: 1, Status: migratorModel.MigrationStatusIdle}, nil
+	}
+	if err != nil {
+		return migratorModel.SyncStateDTO{}, err
+	}
+	return jobSyncToDTO(jb), nil
+}
+
+// CancelSync 协作式取消在跑同步。取消发生在合并事务内时整轮回滚，水位不推进。
+func (s *MigratorService) CancelSync(ctx context.Context) (migratorModel.SyncStateDTO, error) {
+	if _, err := s.ensureAdmin(ctx); err != nil {
+		return migratorModel.SyncStateDTO{}, err
+	}
+	jb, err := s.jobManager.Get(ctx, jobModel.TypeSync)
+	if errors.Is(err, job.ErrNotFound) {
+		return migratorModel.SyncStateDTO{}, errors.New(commonModel.INVALID_REQUEST_BODY)
+	}
+	if err != nil {
+		return migratorModel.SyncStateDTO{}, err
+	}
+	if jb.Status != jobModel.StatusPending && jb.Status != jobModel.StatusRunning {
+		return migratorModel.SyncStateDTO{}, errors.New(commonModel.INVALID_REQUEST_BODY)
+	}
+	_ = s.jobManager.Cancel(ctx, jobModel.TypeSync)
+	jb, err = s.jobManager.Get(ctx, jobModel.TypeSync)
+	if err != nil {
+		return migratorModel.SyncStateDTO{}, err
+	}
+	return jobSyncToDTO(jb), nil
+}
+
+// PullSyncChanges 是对端拉取端点（GET /migration/sync/changes）：现导出 since 之后的增量胶囊
+// 流式下发，本端时钟放进 X-Ech0-Sync-Cursor 头。增量通常很小，不值得走异步作业。
+func (s *MigratorService) PullSyncChanges(
+	ctx *gin.Context,
+	reqCtx context.Context,
+	since int64,
+	includePrivate bool,
+) error {
+	if _, err := s.ensureAdmin(reqCtx); err != nil {
+		return err
+	}
+	if since < 0 {
+		return errors.New(commonModel.INVALID_REQUEST_BODY)
+	}
+	archive, err := s.syncEngine.ExportChanges(reqCtx, since, includePrivate)
+	if err != nil {
+		return err
+	}
+	defer archive.Remove()
+
+	info, err := os.Stat(archive.Path)
+	if err != nil {
+		return err
+	}
+	ctx.Writer.Header().Set("Content-Type", "application/zip")
+	ctx.Writer.Header().Set("Content-Length", fmt.Sprintf("%d", info.Size()))
+	ctx.Writer.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	ctx.Writer.Header().Set(migratorModel.SyncCursorHeader, strconv.FormatInt(archive.Cursor, 10))
+	ctx.Writer.WriteHeader(200)
+	ctx.File(archive.Path)
+	return nil
+}
+
+// PushSyncChanges 是对端推送端点（POST /migration/sync/changes）：请求体即增量胶囊 zip，
+// 同步合并并返回报告。base 是发起方上次拿到的本端水位，policy 已由发起方翻成本端视角。
+func (s *MigratorService) PushSyncChanges(
+	ctx context.Context,
+	body io.Reader,
+	base int64,
+	policy string,
+	includePrivate bool,
+) (migratorModel.SyncApplyResult, error) {
+	if _, err := s.ensureAdmin(ctx); err != nil {
+		return migratorModel.SyncApplyResult{}, err
+	}
+	if body == nil || base < 0 {
+		return migratorModel.SyncApplyResult{}, errors.New(commonModel.INVALID_REQUEST_BODY)
+	}
+	if _, err := replica.ParsePolicy(policy); err != nil {
+		return migratorModel.SyncApplyResult{}, errors.New(commonModel.INVALID_REQUEST_BODY)
+	}
+
+	archive, err := coreMigrator.SaveSyncUpload(body)
+	if err != nil {
+		return migratorModel.SyncApplyResult{}, err
+	}
+	defer archive.Remove()
+
+	return s.syncEngine.ApplyChanges(ctx, archive.Path, coreMigrator.ApplyOptions{
+		Base:           base,
+		Policy:         policy,
+		IncludePrivate: includePrivate,
+	})
+}
+
+// jobSyncToDTO 把通用 Job 映射回 SyncStateDTO。终态成功时 Payload 为 SyncReport 的 JSON；
+// 运行中它还是输入 SyncPayload，不带 report 字段，解析出来即为 nil。
+func jobSyncToDTO(jb jobModel.Job) migratorModel.SyncStateDTO {
+	dto := migratorModel.SyncStateDTO{
+		Version:      1,
+		Status:       string(jb.Status),
+		Phase:        jb.Phase,
+		ErrorMessage: jb.Error,
+		StartedAt:    jb.StartedAt,
+		FinishedAt:   jb.FinishedAt,
+	}
+	if jb.Status == jobModel.StatusSuccess && jb.Payload != "" {
+		var report migratorModel.SyncReport
+		if err := json.Unmarshal([]byte(jb.Payload), &report); err == nil {
+			dto.Report = &report
+		}
+	}
+	if jb.UpdatedAt != 0 {
+		updatedAt := jb.UpdatedAt
+		dto.UpdatedAt = &updatedAt
+	}
+	return dto
+}
+
+func sanitizeSyncSetting(in migratorModel.SyncSetting) migratorModel.SyncSetting {
+	out := in
+	out.AccessTokenSet = strings.TrimSpace(out.AccessToken) != ""
+	out.AccessToken = ""
+	return out
+}
@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	jobModel "github.com/lin-snow/ech0/internal/model/job"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/lin-snow/ech0/internal/test/mocks/commonmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncSetting(t *testing.T) {
+	t.Run("read redacts access token", func(t *testing.T) {
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+		require.NoError(t, coreSetting.Set(context.Background(), s.durableKV, coreSetting.Sync,
+			migratorModel.SyncSetting{PeerURL: "https://peer.example.com", AccessToken: "secret"}))
+
+		got, err := s.GetSyncSetting(helpers.CtxAsUser(adminID))
+		require.NoError(t, err)
+		assert.Empty(t, got.AccessToken)
+		assert.True(t, got.AccessTokenSet)
+		assert.Equal(t, "https://peer.example.com", got.PeerURL)
+	})
+
+	t.Run("blank token on update keeps stored token", func(t *testing.T) {
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+		require.NoError(t, coreSetting.Set(context.Background(), s.durableKV, coreSetting.Sync,
+			migratorModel.SyncSetting{PeerURL: "https://peer.example.com", AccessToken: "secret"}))
+
+		err := s.UpdateSyncSetting(helpers.CtxAsUser(adminID), migratorModel.SyncSetting{
+			Enable:          true,
+			PeerURL:         "https://other.example.com/",
+			ConflictPolicy:  "remote",
+			IntervalMinutes: 5,
+		})
+		require.NoError(t, err)
+
+		stored, err := coreSetting.Get(context.Background(), s.durableKV, coreSetting.Sync)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", stored.AccessToken)
+		assert.Equal(t, "https://other.example.com", stored.PeerURL)
+		assert.Equal(t, migratorModel.SyncDirectionBoth, stored.Direction)
+	})
+
+	t.Run("unknown conflict policy rejected", func(t *testing.T) {
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+		err := s.UpdateSyncSetting(helpers.CtxAsUser(adminID), migratorModel.SyncSetting{ConflictPolicy: "coin-flip"})
+		require.Error(t, err)
+		assert.Equal(t, commonModel.INVALID_REQUEST_BODY, err.Error())
+	})
+
+	t.Run("private peer needs explicit opt-in", func(t *testing.T) {
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+		peer := migratorModel.SyncSetting{PeerURL: "http://192.168.1.20:6277"}
+
+		err := s.UpdateSyncSetting(helpers.CtxAsUser(adminID), peer)
+		require.EqualError(t, err, commonModel.INVALID_REQUEST_BODY)
+
+		peer.AllowPrivatePeer = true
+		require.NoError(t, s.UpdateSyncSetting(helpers.CtxAsUser(adminID), peer))
+	})
+}
+
+func TestStartSync(t *testing.T) {
+	t.Run("unconfigured peer rejected before submit", func(t *testing.T) {
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+		_, err := s.StartSync(helpers.CtxAsUser(adminID))
+		require.Error(t, err)
+		assert.Equal(t, "同步对端未配置", err.Error())
+	})
+
+	t.Run("success returns pending sync DTO", func(t *testing.T) {
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+		s.jobManager.Register(jobModel.TypeSync, noopRunner{})
+		require.NoError(t, coreSetting.Set(context.Background(), s.durableKV, coreSetting.Sync,
+			migratorModel.SyncSetting{PeerURL: "https://peer.example.com", AccessToken: "secret"}))
+
+		dto, err := s.StartSync(helpers.CtxAsUser(adminID))
+		require.NoError(t, err)
+		assert.Equal(t, string(jobModel.StatusPending), dto.Status)
+		assert.Nil(t, dto.Report)
+	})
+}
+
+func TestJobSyncToDTO_ParsesReportOnSuccess(t *testing.T) {
+	raw, err := json.Marshal(migratorModel.SyncReport{
+		PeerURL: "https://peer.example.com",
+		Pulled: &migratorModel.SyncApplyResult{
+			EchoesUpdated: 2,
+			Conflicts:     []migratorModel.SyncConflict{{Kind: "echo", ID: "e1", Resolution: "kept_local"}},
+		},
+	})
+	require.NoError(t, err)
+
+	dto := jobSyncToDTO(jobModel.Job{Type: jobModel.TypeSync, Status: jobModel.StatusSuccess, Payload: string(raw)})
+	require.NotNil(t, dto.Report)
+	require.NotNil(t, dto.Report.Pulled)
+	assert.Equal(t, 2, dto.Report.Pulled.EchoesUpdated)
+	assert.Len(t, dto.Report.Pulled.Conflicts, 1)
+	assert.Nil(t, dto.Report.Pushed)
+}
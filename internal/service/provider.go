@@ -16,6 +16,7 @@ import (
 	fileService "github.com/lin-snow/ech0/internal/service/file"
 	initService "github.com/lin-snow/ech0/internal/service/init"
 	migratorService "github.com/lin-snow/ech0/internal/service/migrator"
+	searchService "github.com/lin-snow/ech0/internal/service/search"
 	settingService "github.com/lin-snow/ech0/internal/service/setting"
 	userService "github.com/lin-snow/ech0/internal/service/user"
 )
@@ -60,16 +61,28 @@ var (
 		dashboardService.NewDashboardService,
 		wire.Bind(new(dashboardService.Service), new(*dashboardService.DashboardService)),
 	)
+	DigestSet = wire.NewSet(
+		dashboardService.NewDigester,
+		wire.Bind(new(dashboardService.DigestService), new(*dashboardService.Digester)),
+	)
 	EmbeddingSet = wire.NewSet(
 		embeddingService.NewEmbeddingService,
 		wire.Bind(new(embeddingService.Service), new(*embeddingService.EmbeddingService)),
 		wire.Bind(new(embeddingService.Indexer), new(*embeddingService.EmbeddingService)),
 	)
+	SearchSet = wire.NewSet(
+		searchService.NewSearchService,
+		wire.Bind(new(searchService.Service), new(*searchService.SearchService)),
+	)
 	CopilotSet = wire.NewSet(
 		copilotService.NewCopilotService,
 		wire.Bind(new(copilotService.SummaryService), new(*copilotService.CopilotService)),
 		wire.Bind(new(copilotService.ChatService), new(*copilotService.CopilotService)),
 	)
+	SuggestSet = wire.NewSet(
+		copilotService.NewSuggester,
+		wire.Bind(new(copilotService.SuggestionService), new(*copilotService.Suggester)),
+	)
 	MigratorSet = wire.NewSet(
 		migratorService.NewMigratorService,
 		wire.Bind(new(migratorService.Service), new(*migratorService.MigratorService)),
@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package service 实现面向访客的混合检索（向量 + 关键词）与相关 Echo 推荐。
+package service
+
+import (
+	"context"
+
+	echoService "github.com/lin-snow/ech0/internal/service/echo"
+	embeddingService "github.com/lin-snow/ech0/internal/service/embedding"
+)
+
+// Service 是检索域的对外接口。
+type Service interface {
+	// Hybrid 把向量近邻与关键词命中按倒数排名融合（RRF），结果遵循调用者的私密可见性。
+	Hybrid(ctx context.Context, query HybridQuery) (HybridResult, error)
+	// Related 返回与 echoID 最相关的 Echo：优先用其已存向量做近邻，未建索引时按共同标签降级。
+	Related(ctx context.Context, echoID string, limit int) (RelatedResult, error)
+}
+
+type (
+	EchoService      = echoService.Service
+	EmbeddingService = embeddingService.Service
+)
@@ -0,0 +1,287 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"github.com/lin-snow/ech0/pkg/viewer"
+)
+
+const (
+	// candidatePool 是每一路（向量 / 关键词）参与融合的候选条数。
+	candidatePool = 50
+	// rrfK 是倒数排名融合的平滑常数（Cormack et al. 的经验值 60）：
+	// 排名靠前的差距被拉平，单路第一不至于压过两路都靠前的条目。
+	rrfK = 60
+	// maxQueryRunes 是检索词的长度上限（按字符计），超出直接拒绝，不送去向量化。
+	maxQueryRunes = 200
+
+	defaultHybridLimit  = 10
+	maxHybridLimit      = 50
+	defaultRelatedLimit = 5
+	maxRelatedLimit     = 20
+)
+
+// 检索实际采用的模式，随结果返回，前端据此提示「语义检索未启用」等。
+const (
+	ModeHybrid  = "hybrid"  // 向量 + 关键词融合
+	ModeKeyword = "keyword" // Embedding 未启用、匿名访问或向量检索失败，仅关键词
+	ModeVector  = "vector"  // 相关推荐：按已存向量近邻
+	ModeTags    = "tags"    // 相关推荐：按共同标签降级
+)
+
+// HybridQuery 是混合检索的入参。过滤条件与 /echo/query 同义。
+type HybridQuery struct {
+	Query    string
+	TagIDs   []string
+	DateFrom int64
+	DateTo   int64
+	Limit    int
+}
+
+// HybridHit 是一条融合后的命中。VectorRank / KeywordRank 为该条在各路中的名次（1 起，0 表示未命中该路）。
+type HybridHit struct {
+	Echo        echoModel.Echo `json:"echo"`
+	Score       float64        `json:"score"`
+	VectorRank  int            `json:"vector_rank,omitempty"`
+	KeywordRank int            `json:"keyword_rank,omitempty"`
+}
+
+// HybridResult 是混合检索的结果。
+type HybridResult struct {
+	Items []HybridHit `json:"items"`
+	Mode  string      `json:"mode"`
+}
+
+// RelatedResult 是相关 Echo 推荐的结果。
+type RelatedResult struct {
+	Items []echoModel.Echo `json:"items"`
+	Mode  string           `json:"mode"`
+}
+
+type SearchService struct {
+	echoService EchoService
+	embedding   EmbeddingService
+}
+
+var _ Service = (*SearchService)(nil)
+
+func NewSearchService(echoService EchoService, embedding EmbeddingService) *SearchService {
+	return &SearchService{echoService: echoService, embedding: embedding}
+}
+
+// Hybrid 按关键词与向量两路检索后融合。向量一路每次都要调用 Embedding 接口把检索词向量化，
+// 匿名访问者只走关键词：公开接口不该让任何人随意消耗站长的模型额度。
+func (s *SearchService) Hybrid(ctx context.Context, query HybridQuery) (HybridResult, error) {
+	q := strings.TrimSpace(query.Query)
+	if q == "" {
+		return HybridResult{}, errors.New(commonModel.SEARCH_QUERY_EMPTY)
+	}
+	if utf8.RuneCountInString(q) > maxQueryRunes {
+		return HybridResult{}, errors.New(commonModel.SEARCH_QUERY_TOO_LONG)
+	}
+	limit := clampLimit(query.Limit, defaultHybridLimit, maxHybridLimit)
+	filter := commonModel.EchoQueryDto{
+		Page:     1,
+		PageSize: candidatePool,
+		TagIDs:   query.TagIDs,
+		DateFrom: query.DateFrom,
+		DateTo:   query.DateTo,
+	}
+
+	keywordFilter := filter
+	keywordFilter.Search = q
+	keyword, err := s.echoService.QueryEchos(ctx, keywordFilter)
+	if err != nil {
+		return HybridResult{}, err
+	}
+
+	mode := ModeKeyword
+	var vector []echoModel.Echo
+	if !isAnonymous(ctx) && s.embedding.Enabled(ctx) {
+		hits, err := s.embedding.Search(ctx, q, candidatePool, "")
+		if err != nil {
+			// 向量侧失败（模型服务不可达等）不拖垮检索，降级为纯关键词。
+			logUtil.GetLogger().Warn("hybrid search: vector leg failed, falling back to keyword",
+				slog.String("module", "search"), logUtil.Err(err))
+		} else {
+			ids := make([]string, len(hits))
+			for i, h := range hits {
+				ids[i] = h.EchoID
+			}
+			vector, err = s.visibleInOrder(ctx, ids, filter)
+			if err != nil {
+				return HybridResult{}, err
+			}
+			mode = ModeHybrid
+		}
+	}
+
+	return HybridResult{Items: fuse(vector, keyword.Items, limit), Mode: mode}, nil
+}
+
+// visibleInOrder 用 EchoService.QueryEchos 复核一批向量命中：私密可见性、标签、日期过滤
+// 全部沿用 /echo/query 的口径（向量索引本身不记可见性），并保持原有的距离顺序。
+func (s *SearchService) visibleInOrder(ctx context.Context, ids []string, filter commonModel.EchoQueryDto) ([]echoModel.Echo, error) {
+	if len(ids) == 0 {
+		return []echoModel.Echo{}, nil
+	}
+	filter.IDs = ids
+	filter.PageSize = len(ids)
+	page, err := s.echoService.QueryEchos(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]echoModel.Echo, len(page.Items))
+	for _, e := range page.Items {
+		byID[e.ID] = e
+	}
+	out := make([]echoModel.Echo, 0, len(byID))
+	for _, id := range ids {
+		if e, ok := byID[id]; ok {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// fuse 对两路排好序的命中做倒数排名融合：score = Σ 1/(rrfK + rank)。
+// 同分时较新的 Echo 在前，保证结果稳定。
+func fuse(vector, keyword []echoModel.Echo, limit int) []HybridHit {
+	hits := make(map[string]*HybridHit, len(vector)+len(keyword))
+	order := make([]string, 0, len(vector)+len(keyword))
+	add := func(list []echoModel.Echo, setRank func(*HybridHit, int)) {
+		for i, e := range list {
+			h, ok := hits[e.ID]
+			if !ok {
+				h = &HybridHit{Echo: e}
+				hits[e.ID] = h
+				order = append(order, e.ID)
+			}
+			rank := i + 1
+			setRank(h, rank)
+			h.Score += 1 / float64(rrfK+rank)
+		}
+	}
+	add(vector, func(h *HybridHit, r int) { h.VectorRank = r })
+	add(keyword, func(h *HybridHit, r int) { h.KeywordRank = r })
+
+	out := make([]HybridHit, 0, len(order))
+	for _, id := range order {
+		out = append(out, *hits[id])
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].Echo.CreatedAt > out[j].Echo.CreatedAt
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+func (s *SearchService) Related(ctx context.Context, echoID string, limit int) (RelatedResult, error) {
+	// 先按调用者身份取源 Echo：私密条目对无权限者直接报错，不泄露其「邻居」。
+	source, err := s.echoService.GetEchoById(ctx, echoID)
+	if err != nil {
+		return RelatedResult{}, err
+	}
+	limit = clampLimit(limit, defaultRelatedLimit, maxRelatedLimit)
+
+	if s.embedding.Enabled(ctx) {
+		// 超额取数：近邻里可能混有调用者不可见的私密条目，复核后再裁到 limit。
+		hits, found, err := s.embedding.Related(ctx, echoID, limit*3)
+		if err != nil {
+			logUtil.GetLogger().Warn("related echos: vector lookup failed, falling back to tags",
+				slog.String("module", "search"), logUtil.Err(err))
+		} else if found {
+			ids := make([]string, len(hits))
+			for i, h := range hits {
+				ids[i] = h.EchoID
+			}
+			items, err := s.visibleInOrder(ctx, ids, commonModel.EchoQueryDto{Page: 1})
+			if err != nil {
+				return RelatedResult{}, err
+			}
+			if len(items) > limit {
+				items = items[:limit]
+			}
+			return RelatedResult{Items: items, Mode: ModeVector}, nil
+		}
+	}
+
+	items, err := s.relatedByTags(ctx, source, limit)
+	if err != nil {
+		return RelatedResult{}, err
+	}
+	return RelatedResult{Items: items, Mode: ModeTags}, nil
+}
+
+// relatedByTags 是无向量时的降级：取与源 Echo 有共同标签的条目，共同标签多者在前，
+// 同数按时间倒序。源 Echo 没有标签时返回空。
+func (s *SearchService) relatedByTags(ctx context.Context, source *echoModel.Echo, limit int) ([]echoModel.Echo, error) {
+	if len(source.Tags) == 0 {
+		return []echoModel.Echo{}, nil
+	}
+	tagIDs := make([]string, len(source.Tags))
+	own := make(map[string]bool, len(source.Tags))
+	for i, t := range source.Tags {
+		tagIDs[i] = t.ID
+		own[t.ID] = true
+	}
+	page, err := s.echoService.QueryEchos(ctx, commonModel.EchoQueryDto{
+		Page:     1,
+		PageSize: candidatePool,
+		TagIDs:   tagIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	shared := make(map[string]int, len(page.Items))
+	items := make([]echoModel.Echo, 0, len(page.Items))
+	for _, e := range page.Items {
+		if e.ID == source.ID {
+			continue
+		}
+		for _, t := range e.Tags {
+			if own[t.ID] {
+				shared[e.ID]++
+			}
+		}
+		items = append(items, e)
+	}
+	// QueryEchos 已按时间倒序，稳定排序即保留同数内的时间序。
+	sort.SliceStable(items, func(i, j int) bool { return shared[items[i].ID] > shared[items[j].ID] })
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+func isAnonymous(ctx context.Context) bool {
+	v, ok := viewer.FromContext(ctx)
+	return !ok || v.UserID() == ""
+}
+
+func clampLimit(limit, def, upper int) int {
+	if limit <= 0 {
+		return def
+	}
+	if limit > upper {
+		return upper
+	}
+	return limit
+}
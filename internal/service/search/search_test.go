@@ -0,0 +1,228 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	embeddingModel "github.com/lin-snow/ech0/internal/model/embedding"
+	"github.com/lin-snow/ech0/internal/test/mocks/echomock"
+	"github.com/lin-snow/ech0/pkg/viewer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubEmbedding 覆写检索用到的 Enabled / Search / Related，其余嵌入 nil 接口未调用即不触发。
+type stubEmbedding struct {
+	EmbeddingService
+	enabled    bool
+	hits       []embeddingModel.SearchResult
+	found      bool
+	err        error
+	relatedK   int
+	searchSeen bool
+}
+
+func (f *stubEmbedding) Enabled(context.Context) bool { return f.enabled }
+
+func (f *stubEmbedding) Search(_ context.Context, _ string, _ int, _ string) ([]embeddingModel.SearchResult, error) {
+	f.searchSeen = true
+	return f.hits, f.err
+}
+
+func (f *stubEmbedding) Related(_ context.Context, _ string, k int) ([]embeddingModel.SearchResult, bool, error) {
+	f.relatedK = k
+	return f.hits, f.found, f.err
+}
+
+func hitsOf(ids ...string) []embeddingModel.SearchResult {
+	out := make([]embeddingModel.SearchResult, len(ids))
+	for i, id := range ids {
+		out[i] = embeddingModel.SearchResult{EchoID: id}
+	}
+	return out
+}
+
+func echosOf(ids ...string) []echoModel.Echo {
+	out := make([]echoModel.Echo, len(ids))
+	for i, id := range ids {
+		out[i] = echoModel.Echo{ID: id}
+	}
+	return out
+}
+
+func page(items []echoModel.Echo) commonModel.PageQueryResult[[]echoModel.Echo] {
+	return commonModel.PageQueryResult[[]echoModel.Echo]{Items: items, Total: int64(len(items))}
+}
+
+func hitIDs(hits []HybridHit) []string {
+	ids := make([]string, len(hits))
+	for i, h := range hits {
+		ids[i] = h.Echo.ID
+	}
+	return ids
+}
+
+// userCtx 是已登录访问者的上下文；匿名访问不走向量一路。
+func userCtx() context.Context {
+	return viewer.WithContext(context.Background(), viewer.NewUserViewer("u1"))
+}
+
+func TestFuse_RanksItemsFoundByBothLegsFirst(t *testing.T) {
+	vector := echosOf("v1", "both", "v3")
+	keyword := echosOf("k1", "both")
+
+	got := fuse(vector, keyword, 10)
+
+	require.Len(t, got, 4)
+	assert.Equal(t, "both", got[0].Echo.ID)
+	assert.Equal(t, 2, got[0].VectorRank)
+	assert.Equal(t, 2, got[0].KeywordRank)
+	assert.InDelta(t, 2.0/62, got[0].Score, 1e-9)
+	// v1 与 k1 同为单路第一、同分：按 created_at（此处皆 0）稳定保留先后。
+	assert.Equal(t, []string{"both", "v1", "k1", "v3"}, hitIDs(got))
+
+	assert.Len(t, fuse(vector, keyword, 2), 2)
+}
+
+func TestHybrid_EmptyQuery(t *testing.T) {
+	s := NewSearchService(echomock.NewMockService(t), &stubEmbedding{})
+	_, err := s.Hybrid(context.Background(), HybridQuery{Query: "   "})
+	assert.EqualError(t, err, commonModel.SEARCH_QUERY_EMPTY)
+}
+
+func TestHybrid_QueryTooLong(t *testing.T) {
+	s := NewSearchService(echomock.NewMockService(t), &stubEmbedding{enabled: true})
+	_, err := s.Hybrid(userCtx(), HybridQuery{Query: strings.Repeat("咖", maxQueryRunes+1)})
+	assert.EqualError(t, err, commonModel.SEARCH_QUERY_TOO_LONG)
+}
+
+// 匿名访问者只走关键词，不触发检索词向量化。
+func TestHybrid_AnonymousSkipsVectorLeg(t *testing.T) {
+	echoSvc := echomock.NewMockService(t)
+	emb := &stubEmbedding{enabled: true, hits: hitsOf("v1")}
+	echoSvc.EXPECT().QueryEchos(mock.Anything, mock.Anything).Return(page(echosOf("k1")), nil).Once()
+	ctx := viewer.WithContext(context.Background(), viewer.NewNoopViewer())
+
+	got, err := NewSearchService(echoSvc, emb).Hybrid(ctx, HybridQuery{Query: "coffee"})
+
+	require.NoError(t, err)
+	assert.Equal(t, ModeKeyword, got.Mode)
+	assert.Equal(t, []string{"k1"}, hitIDs(got.Items))
+	assert.False(t, emb.searchSeen)
+}
+
+func TestHybrid_KeywordOnlyWhenEmbeddingDisabled(t *testing.T) {
+	echoSvc := echomock.NewMockService(t)
+	emb := &stubEmbedding{enabled: false}
+	echoSvc.EXPECT().QueryEchos(mock.Anything, mock.MatchedBy(func(q commonModel.EchoQueryDto) bool {
+		return q.Search == "coffee" && q.PageSize == candidatePool && q.DateFrom == 10 && len(q.IDs) == 0
+	})).Return(page(echosOf("k1", "k2")), nil).Once()
+
+	got, err := NewSearchService(echoSvc, emb).Hybrid(context.Background(), HybridQuery{Query: " coffee ", DateFrom: 10})
+
+	require.NoError(t, err)
+	assert.Equal(t, ModeKeyword, got.Mode)
+	assert.Equal(t, []string{"k1", "k2"}, hitIDs(got.Items))
+	assert.False(t, emb.searchSeen)
+}
+
+// 向量命中经 QueryEchos(IDs=…) 复核：不可见的（私密 / 不满足过滤）被剔除，距离顺序保留。
+func TestHybrid_FusesVisibleVectorHits(t *testing.T) {
+	echoSvc := echomock.NewMockService(t)
+	emb := &stubEmbedding{enabled: true, hits: hitsOf("v-private", "v2", "both")}
+	echoSvc.EXPECT().QueryEchos(mock.Anything, mock.MatchedBy(func(q commonModel.EchoQueryDto) bool {
+		return q.Search == "coffee"
+	})).Return(page(echosOf("both")), nil).Once()
+	echoSvc.EXPECT().QueryEchos(mock.Anything, mock.MatchedBy(func(q commonModel.EchoQueryDto) bool {
+		return q.Search == "" && len(q.IDs) == 3 && q.PageSize == 3 && len(q.TagIDs) == 1
+	})).Return(page(echosOf("both", "v2")), nil).Once()
+
+	got, err := NewSearchService(echoSvc, emb).Hybrid(userCtx(), HybridQuery{Query: "coffee", TagIDs: []string{"t1"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, ModeHybrid, got.Mode)
+	assert.Equal(t, []string{"both", "v2"}, hitIDs(got.Items))
+	assert.Equal(t, 1, got.Items[1].VectorRank)
+}
+
+func TestHybrid_VectorFailureFallsBackToKeyword(t *testing.T) {
+	echoSvc := echomock.NewMockService(t)
+	emb := &stubEmbedding{enabled: true, err: errors.New("model down")}
+	echoSvc.EXPECT().QueryEchos(mock.Anything, mock.Anything).Return(page(echosOf("k1")), nil).Once()
+
+	got, err := NewSearchService(echoSvc, emb).Hybrid(userCtx(), HybridQuery{Query: "coffee"})
+
+	require.NoError(t, err)
+	assert.Equal(t, ModeKeyword, got.Mode)
+	assert.Equal(t, []string{"k1"}, hitIDs(got.Items))
+}
+
+func TestRelated_SourceNotVisible(t *testing.T) {
+	echoSvc := echomock.NewMockService(t)
+	echoSvc.EXPECT().GetEchoById(mock.Anything, "secret").Return(nil, errors.New(commonModel.NO_PERMISSION_DENIED)).Once()
+
+	_, err := NewSearchService(echoSvc, &stubEmbedding{enabled: true}).Related(context.Background(), "secret", 5)
+	assert.EqualError(t, err, commonModel.NO_PERMISSION_DENIED)
+}
+
+func TestRelated_VectorNeighbours(t *testing.T) {
+	echoSvc := echomock.NewMockService(t)
+	emb := &stubEmbedding{enabled: true, found: true, hits: hitsOf("n1", "n-private", "n2", "n3")}
+	echoSvc.EXPECT().GetEchoById(mock.Anything, "e1").Return(&echoModel.Echo{ID: "e1"}, nil).Once()
+	echoSvc.EXPECT().QueryEchos(mock.Anything, mock.MatchedBy(func(q commonModel.EchoQueryDto) bool {
+		return len(q.IDs) == 4
+	})).Return(page(echosOf("n3", "n2", "n1")), nil).Once()
+
+	got, err := NewSearchService(echoSvc, emb).Related(context.Background(), "e1", 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, ModeVector, got.Mode)
+	assert.Equal(t, 6, emb.relatedK, "over-fetches to survive visibility filtering")
+	require.Len(t, got.Items, 2)
+	assert.Equal(t, "n1", got.Items[0].ID)
+	assert.Equal(t, "n2", got.Items[1].ID)
+}
+
+// 未建索引：按共同标签降级，共同标签多者在前，排除自身。
+func TestRelated_FallsBackToSharedTags(t *testing.T) {
+	echoSvc := echomock.NewMockService(t)
+	emb := &stubEmbedding{enabled: true, found: false}
+	tagA, tagB := echoModel.Tag{ID: "a"}, echoModel.Tag{ID: "b"}
+	echoSvc.EXPECT().GetEchoById(mock.Anything, "e1").
+		Return(&echoModel.Echo{ID: "e1", Tags: []echoModel.Tag{tagA, tagB}}, nil).Once()
+	echoSvc.EXPECT().QueryEchos(mock.Anything, mock.MatchedBy(func(q commonModel.EchoQueryDto) bool {
+		return len(q.TagIDs) == 2
+	})).Return(page([]echoModel.Echo{
+		{ID: "newer-one-tag", Tags: []echoModel.Tag{tagA}},
+		{ID: "e1", Tags: []echoModel.Tag{tagA, tagB}},
+		{ID: "older-two-tags", Tags: []echoModel.Tag{tagA, tagB}},
+	}), nil).Once()
+
+	got, err := NewSearchService(echoSvc, emb).Related(context.Background(), "e1", 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, ModeTags, got.Mode)
+	require.Len(t, got.Items, 2)
+	assert.Equal(t, "older-two-tags", got.Items[0].ID)
+	assert.Equal(t, "newer-one-tag", got.Items[1].ID)
+}
+
+func TestRelated_NoTagsNoVectorIsEmpty(t *testing.T) {
+	echoSvc := echomock.NewMockService(t)
+	echoSvc.EXPECT().GetEchoById(mock.Anything, "e1").Return(&echoModel.Echo{ID: "e1"}, nil).Once()
+
+	got, err := NewSearchService(echoSvc, &stubEmbedding{}).Related(context.Background(), "e1", 5)
+
+	require.NoError(t, err)
+	assert.Equal(t, ModeTags, got.Mode)
+	assert.NotNil(t, got.Items)
+	assert.Empty(t, got.Items)
+}
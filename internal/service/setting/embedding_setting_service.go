@@ -8,6 +8,9 @@ import (
 	"errors"
 	"strings"
 
+	"github.com/lin-snow/ech0/internal/embedding"
+	"github.com/lin-snow/ech0/internal/event"
+	eventbus "github.com/lin-snow/ech0/internal/event/bus"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	model "github.com/lin-snow/ech0/internal/model/setting"
 	coreSetting "github.com/lin-snow/ech0/internal/setting"
@@ -24,6 +27,34 @@ func (settingService *SettingService) GetEmbeddingSetting(
 	return coreSetting.Get(ctx, settingService.durableKV, coreSetting.Embedding)
 }
 
+// normalizeEmbeddingSettingDto 把入参归一为待落库的设置：未识别的提供商按 OpenAI 兼容处理；
+// hash 提供商不依赖外部模型，模型名与维度留空时补上进程内哈希的缺省值，
+// 开关一开即可用（模型名同时充当索引身份，换维度仍会触发重建）。
+func normalizeEmbeddingSettingDto(dto model.EmbeddingSettingDto) model.EmbeddingSetting {
+	setting := model.EmbeddingSetting{
+		Enable:    dto.Enable,
+		Provider:  strings.TrimSpace(dto.Provider),
+		Model:     strings.TrimSpace(dto.Model),
+		ApiKey:    strings.TrimSpace(dto.ApiKey),
+		BaseURL:   strings.TrimSpace(dto.BaseURL),
+		Dim:       dto.Dim,
+		BatchSize: dto.BatchSize,
+	}
+	switch setting.Provider {
+	case model.EmbeddingProviderOllama, model.EmbeddingProviderLlamaCpp:
+	case model.EmbeddingProviderHash:
+		if setting.Model == "" {
+			setting.Model = embedding.HashModel
+		}
+		if setting.Dim <= 0 {
+			setting.Dim = embedding.DefaultHashDim
+		}
+	default:
+		setting.Provider = model.EmbeddingProviderOpenAI
+	}
+	return setting
+}
+
 // UpdateEmbeddingSetting 更新 Embedding 向量设置。
 func (settingService *SettingService) UpdateEmbeddingSetting(
 	ctx context.Context,
@@ -39,14 +70,22 @@ func (settingService *SettingService) UpdateEmbeddingSetting(
 		return errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 
-	setting := model.EmbeddingSetting{
-		Enable:    dto.Enable,
-		Model:     strings.TrimSpace(dto.Model),
-		ApiKey:    strings.TrimSpace(dto.ApiKey),
-		BaseURL:   strings.TrimSpace(dto.BaseURL),
-		Dim:       dto.Dim,
-		BatchSize: dto.BatchSize,
+	setting := normalizeEmbeddingSettingDto(dto)
+
+	previous, err := coreSetting.Get(ctx, settingService.durableKV, coreSetting.Embedding)
+	if err != nil {
+		return err
+	}
+	if err := coreSetting.Set(ctx, settingService.durableKV, coreSetting.Embedding, setting); err != nil {
+		return err
 	}
 
-	return coreSetting.Set(ctx, settingService.durableKV, coreSetting.Embedding, setting)
+	// 生效状态翻转时通知观察者（MCP 据此推送 tools/list_changed）；写入成功后再发布。
+	if previous.Active() != setting.Active() {
+		eventbus.Notify(context.Background(), settingService.bus, event.FeatureToggled{
+			Feature: event.FeatureEmbedding,
+			Enabled: setting.Active(),
+		})
+	}
+	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/lin-snow/ech0/internal/embedding"
 	authModel "github.com/lin-snow/ech0/internal/model/auth"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	model "github.com/lin-snow/ech0/internal/model/setting"
@@ -103,6 +104,7 @@ func TestNormalizeAgentProtocol(t *testing.T) {
 		want string
 	}{
 		{"openai passthrough", string(commonModel.OpenAI), string(commonModel.OpenAI)},
+		{"ollama passthrough", string(commonModel.Ollama), string(commonModel.Ollama)},
 		{"anthropic passthrough", string(commonModel.Anthropic), string(commonModel.Anthropic)},
 		{"retired gemini falls back to openai", "gemini", string(commonModel.OpenAI)},
 		{"empty falls back to openai", "", string(commonModel.OpenAI)},
@@ -115,6 +117,35 @@ func TestNormalizeAgentProtocol(t *testing.T) {
 	}
 }
 
+func TestNormalizeEmbeddingSettingDto(t *testing.T) {
+	t.Run("hash fills model and dim", func(t *testing.T) {
+		got := normalizeEmbeddingSettingDto(model.EmbeddingSettingDto{Enable: true, Provider: "hash"})
+		assert.Equal(t, model.EmbeddingProviderHash, got.Provider)
+		assert.Equal(t, embedding.HashModel, got.Model)
+		assert.Equal(t, embedding.DefaultHashDim, got.Dim)
+		assert.True(t, got.Active())
+	})
+
+	t.Run("hash keeps explicit dim", func(t *testing.T) {
+		got := normalizeEmbeddingSettingDto(model.EmbeddingSettingDto{Provider: "hash", Dim: 64})
+		assert.Equal(t, 64, got.Dim)
+	})
+
+	t.Run("local providers pass through and trim", func(t *testing.T) {
+		got := normalizeEmbeddingSettingDto(model.EmbeddingSettingDto{
+			Provider: " ollama ", Model: " nomic-embed-text ", BaseURL: " http://gpu:11434 ", Dim: 768,
+		})
+		assert.Equal(t, model.EmbeddingProviderOllama, got.Provider)
+		assert.Equal(t, "nomic-embed-text", got.Model)
+		assert.Equal(t, "http://gpu:11434", got.BaseURL)
+	})
+
+	t.Run("empty or unknown falls back to openai", func(t *testing.T) {
+		assert.Equal(t, model.EmbeddingProviderOpenAI, normalizeEmbeddingSettingDto(model.EmbeddingSettingDto{}).Provider)
+		assert.Equal(t, model.EmbeddingProviderOpenAI, normalizeEmbeddingSettingDto(model.EmbeddingSettingDto{Provider: "cohere"}).Provider)
+	})
+}
+
 func TestNormalizeScopes(t *testing.T) {
 	cases := []struct {
 		name string
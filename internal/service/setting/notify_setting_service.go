@@ -0,0 +1,218 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	notifyModel "github.com/lin-snow/ech0/internal/model/notify"
+	"github.com/lin-snow/ech0/internal/notify"
+	"github.com/lin-snow/ech0/internal/util/egress"
+	"github.com/lin-snow/ech0/pkg/viewer"
+)
+
+// notifyDeliveryListLimit 是投递日志接口单次返回的最大条数。
+const notifyDeliveryListLimit = 100
+
+// GetAllNotifyChannels 获取所有通知渠道，密钥项不回显
+func (settingService *SettingService) GetAllNotifyChannels(ctx context.Context) ([]notifyModel.NotifyChannel, error) {
+	// 鉴权
+	userid := viewer.MustFromContext(ctx).UserID()
+	user, err := settingService.commonService.CommonGetUserByUserId(ctx, userid)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsAdmin {
+		return nil, errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+
+	channels, err := settingService.notifyRepository.GetAllNotifyChannels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range channels {
+		channels[i].Config = maskNotifySecrets(channels[i].Type, channels[i].Config)
+	}
+	return channels, nil
+}
+
+// CreateNotifyChannel 创建通知渠道
+func (settingService *SettingService) CreateNotifyChannel(
+	ctx context.Context,
+	newChannel *notifyModel.NotifyChannelDto,
+) error {
+	// 鉴权
+	userid := viewer.MustFromContext(ctx).UserID()
+	user, err := settingService.commonService.CommonGetUserByUserId(ctx, userid)
+	if err != nil {
+		return err
+	}
+	if !user.IsAdmin {
+		return errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+
+	channel, err := buildNotifyChannel(newChannel)
+	if err != nil {
+		return err
+	}
+
+	return settingService.transactor.Run(ctx, func(ctx context.Context) error {
+		return settingService.notifyRepository.CreateNotifyChannel(ctx, channel)
+	})
+}
+
+// UpdateNotifyChannel 更新通知渠道；类型不变时，留空的密钥项沿用原值
+func (settingService *SettingService) UpdateNotifyChannel(
+	ctx context.Context,
+	id string,
+	newChannel *notifyModel.NotifyChannelDto,
+) error {
+	// 鉴权
+	userid := viewer.MustFromContext(ctx).UserID()
+	user, err := settingService.commonService.CommonGetUserByUserId(ctx, userid)
+	if err != nil {
+		return err
+	}
+	if !user.IsAdmin {
+		return errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+
+	return settingService.transactor.Run(ctx, func(ctx context.Context) error {
+		existing, err := settingService.notifyRepository.GetNotifyChannelByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		dto := *newChannel
+		dto.Config = maps.Clone(newChannel.Config)
+		if dto.Type == existing.Type {
+			for _, key := range notify.SecretKeys(dto.Type) {
+				if strings.TrimSpace(dto.Config[key]) == "" && existing.Config[key] != "" {
+					if dto.Config == nil {
+						dto.Config = map[string]string{}
+					}
+					dto.Config[key] = existing.Config[key]
+				}
+			}
+		}
+
+		channel, err := buildNotifyChannel(&dto)
+		if err != nil {
+			return err
+		}
+		return settingService.notifyRepository.UpdateNotifyChannelByID(ctx, id, channel)
+	})
+}
+
+// DeleteNotifyChannel 删除通知渠道
+func (settingService *SettingService) DeleteNotifyChannel(ctx context.Context, id string) error {
+	// 鉴权
+	userid := viewer.MustFromContext(ctx).UserID()
+	user, err := settingService.commonService.CommonGetUserByUserId(ctx, userid)
+	if err != nil {
+		return err
+	}
+	if !user.IsAdmin {
+		return errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+
+	return settingService.transactor.Run(ctx, func(txCtx context.Context) error {
+		return settingService.notifyRepository.DeleteNotifyChannelByID(txCtx, id)
+	})
+}
+
+// TestNotifyChannel 向通知渠道发送一条测试通知，结果记入投递日志
+func (settingService *SettingService) TestNotifyChannel(ctx context.Context, id string) error {
+	// 鉴权
+	userid := viewer.MustFromContext(ctx).UserID()
+	user, err := settingService.commonService.CommonGetUserByUserId(ctx, userid)
+	if err != nil {
+		return err
+	}
+	if !user.IsAdmin {
+		return errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+
+	channel, err := settingService.notifyRepository.GetNotifyChannelByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := settingService.notifySender.SendTest(ctx, channel); err != nil {
+		return fmt.Errorf("%s: %w", commonModel.NOTIFY_CHANNEL_TEST_FAILED, err)
+	}
+	return nil
+}
+
+// ListNotifyDeliveries 列出最近的通知投递日志，channelID 非空时只看该渠道
+func (settingService *SettingService) ListNotifyDeliveries(
+	ctx context.Context,
+	channelID string,
+) ([]notifyModel.NotifyDelivery, error) {
+	// 鉴权
+	userid := viewer.MustFromContext(ctx).UserID()
+	user, err := settingService.commonService.CommonGetUserByUserId(ctx, userid)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsAdmin {
+		return nil, errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+
+	return settingService.notifyRepository.ListNotifyDeliveries(ctx, channelID, notifyDeliveryListLimit)
+}
+
+// buildNotifyChannel 校验并整理 DTO：去掉空配置项、事件去重排序。
+func buildNotifyChannel(dto *notifyModel.NotifyChannelDto) (*notifyModel.NotifyChannel, error) {
+	name := strings.TrimSpace(dto.Name)
+	if name == "" {
+		return nil, errors.New(commonModel.NOTIFY_CHANNEL_NAME_CANNOT_BE_EMPTY)
+	}
+	config := make(map[string]string, len(dto.Config))
+	for key, value := range dto.Config {
+		if value = strings.TrimSpace(value); value != "" {
+			config[key] = value
+		}
+	}
+	events := slices.Clone(dto.Events)
+	slices.Sort(events)
+	events = slices.Compact(events)
+	if err := notify.Validate(dto.Type, config, events); err != nil {
+		return nil, fmt.Errorf("%s: %w", commonModel.INVALID_NOTIFY_CHANNEL, err)
+	}
+	// 开启内网拦截的渠道在保存时就拒绝内网 / 本机地址；发送时的 client 还会按解析出的 IP 再校验一次。
+	if dto.BlockPrivate {
+		for _, key := range notify.URLKeys(dto.Type) {
+			if value, ok := config[key]; ok {
+				if err := egress.Validate(value); err != nil {
+					return nil, fmt.Errorf("%s: %s: %w", commonModel.INVALID_NOTIFY_CHANNEL, key, err)
+				}
+			}
+		}
+	}
+
+	return &notifyModel.NotifyChannel{
+		Name:         name,
+		Type:         dto.Type,
+		Config:       config,
+		Events:       events,
+		IsActive:     dto.IsActive,
+		BlockPrivate: dto.BlockPrivate,
+	}, nil
+}
+
+// maskNotifySecrets 清空密钥项，前端据此显示「已设置，留空不修改」。
+func maskNotifySecrets(channelType string, config map[string]string) map[string]string {
+	masked := maps.Clone(config)
+	for _, key := range notify.SecretKeys(channelType) {
+		if masked[key] != "" {
+			masked[key] = ""
+		}
+	}
+	return masked
+}
@@ -0,0 +1,218 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	notifyModel "github.com/lin-snow/ech0/internal/model/notify"
+	"github.com/lin-snow/ech0/internal/notify"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/stretchr/testify/assert"
+	mock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateNotifyChannel(t *testing.T) {
+	ctx := helpers.CtxAsUser(testUserID)
+
+	rejected := map[string]notifyModel.NotifyChannelDto{
+		"empty name":            {Name: " ", Type: notifyModel.ChannelNtfy, Config: map[string]string{"topic": "t"}},
+		"unknown type":          {Name: "n", Type: "pager"},
+		"missing required":      {Name: "n", Type: notifyModel.ChannelTelegram, Config: map[string]string{"bot_token": "x"}},
+		"unknown config":        {Name: "n", Type: notifyModel.ChannelNtfy, Config: map[string]string{"topic": "t", "foo": "1"}},
+		"non-http url":          {Name: "n", Type: notifyModel.ChannelGotify, Config: map[string]string{"server": "ftp://x", "token": "t"}},
+		"blocked private":       {Name: "n", Type: notifyModel.ChannelGotify, Config: map[string]string{"server": "http://192.168.1.10", "token": "t"}, BlockPrivate: true},
+		"ntfy priority range":   {Name: "n", Type: notifyModel.ChannelNtfy, Config: map[string]string{"topic": "t", "priority": "8"}},
+		"bad template":          {Name: "n", Type: notifyModel.ChannelHTTP, Config: map[string]string{"url": "https://x", "template": "{{"}},
+		"bad header":            {Name: "n", Type: notifyModel.ChannelHTTP, Config: map[string]string{"url": "https://x", "headers": "nocolon"}},
+		"unknown event":         {Name: "n", Type: notifyModel.ChannelNtfy, Config: map[string]string{"topic": "t"}, Events: []string{"echo.created"}},
+		"test not subscribable": {Name: "n", Type: notifyModel.ChannelNtfy, Config: map[string]string{"topic": "t"}, Events: []string{notifyModel.EventTest}},
+	}
+	for name, dto := range rejected {
+		t.Run(name, func(t *testing.T) {
+			d := newDeps(t)
+			d.expectAdmin()
+			err := d.build().CreateNotifyChannel(ctx, &dto)
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("empty name message", func(t *testing.T) {
+		d := newDeps(t)
+		d.expectAdmin()
+		err := d.build().CreateNotifyChannel(ctx, &notifyModel.NotifyChannelDto{Type: notifyModel.ChannelNtfy})
+		assert.EqualError(t, err, commonModel.NOTIFY_CHANNEL_NAME_CANNOT_BE_EMPTY)
+	})
+
+	t.Run("private address is allowed unless the channel blocks it", func(t *testing.T) {
+		d := newDeps(t)
+		d.expectAdmin()
+		d.tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTxExec()).Once()
+		d.notifyRepo.EXPECT().
+			CreateNotifyChannel(mock.Anything, mock.MatchedBy(func(ch *notifyModel.NotifyChannel) bool {
+				return ch.Config["server"] == "http://192.168.1.10" && !ch.BlockPrivate
+			})).
+			Return(nil).
+			Once()
+
+		err := d.build().CreateNotifyChannel(ctx, &notifyModel.NotifyChannelDto{
+			Name:   "lan",
+			Type:   notifyModel.ChannelGotify,
+			Config: map[string]string{"server": "http://192.168.1.10", "token": "t"},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("valid channel is normalized and saved", func(t *testing.T) {
+		d := newDeps(t)
+		d.expectAdmin()
+		d.tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTxExec()).Once()
+		d.notifyRepo.EXPECT().
+			CreateNotifyChannel(mock.Anything, mock.MatchedBy(func(ch *notifyModel.NotifyChannel) bool {
+				return ch.Name == "phone" &&
+					ch.Config["topic"] == "ech0" &&
+					len(ch.Config) == 1 &&
+					assert.ObjectsAreEqual(
+						[]string{notifyModel.EventCommentNew, notifyModel.EventLoginNewDevice},
+						ch.Events,
+					)
+			})).
+			Return(nil).
+			Once()
+
+		err := d.build().CreateNotifyChannel(ctx, &notifyModel.NotifyChannelDto{
+			Name:   " phone ",
+			Type:   notifyModel.ChannelNtfy,
+			Config: map[string]string{"topic": " ech0 ", "token": "  "},
+			Events: []string{
+				notifyModel.EventLoginNewDevice, notifyModel.EventCommentNew, notifyModel.EventLoginNewDevice,
+			},
+			IsActive: true,
+		})
+		require.NoError(t, err)
+	})
+}
+
+func TestGetAllNotifyChannels_MasksSecrets(t *testing.T) {
+	d := newDeps(t)
+	d.expectAdmin()
+	stored := map[string]string{"server": "https://gotify.local", "token": "app-token"}
+	d.notifyRepo.EXPECT().GetAllNotifyChannels(mock.Anything).Return([]notifyModel.NotifyChannel{
+		{ID: "c1", Type: notifyModel.ChannelGotify, Config: stored},
+	}, nil).Once()
+
+	channels, err := d.build().GetAllNotifyChannels(helpers.CtxAsUser(testUserID))
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	assert.Equal(t, "https://gotify.local", channels[0].Config["server"])
+	assert.Empty(t, channels[0].Config["token"])
+	assert.Equal(t, "app-token", stored["token"], "masking must not touch the stored map")
+}
+
+func TestUpdateNotifyChannel_KeepsBlankSecrets(t *testing.T) {
+	ctx := helpers.CtxAsUser(testUserID)
+	existing := &notifyModel.NotifyChannel{
+		ID:     "c1",
+		Type:   notifyModel.ChannelTelegram,
+		Config: map[string]string{"bot_token": "123:abc", "chat_id": "42"},
+	}
+
+	t.Run("same type keeps the stored secret", func(t *testing.T) {
+		d := newDeps(t)
+		d.expectAdmin()
+		d.tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTxExec()).Once()
+		d.notifyRepo.EXPECT().GetNotifyChannelByID(mock.Anything, "c1").Return(existing, nil).Once()
+		d.notifyRepo.EXPECT().
+			UpdateNotifyChannelByID(mock.Anything, "c1", mock.MatchedBy(func(ch *notifyModel.NotifyChannel) bool {
+				return ch.Config["bot_token"] == "123:abc" && ch.Config["chat_id"] == "43"
+			})).
+			Return(nil).
+			Once()
+
+		err := d.build().UpdateNotifyChannel(ctx, "c1", &notifyModel.NotifyChannelDto{
+			Name:   "tg",
+			Type:   notifyModel.ChannelTelegram,
+			Config: map[string]string{"bot_token": "", "chat_id": "43"},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("type change does not carry secrets over", func(t *testing.T) {
+		d := newDeps(t)
+		d.expectAdmin()
+		d.tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTxExec()).Once()
+		d.notifyRepo.EXPECT().GetNotifyChannelByID(mock.Anything, "c1").Return(existing, nil).Once()
+
+		err := d.build().UpdateNotifyChannel(ctx, "c1", &notifyModel.NotifyChannelDto{
+			Name:   "matrix",
+			Type:   notifyModel.ChannelMatrix,
+			Config: map[string]string{"homeserver": "https://m.local", "room_id": "!r:m.local"},
+		})
+		require.ErrorContains(t, err, "access_token is required")
+	})
+}
+
+func TestTestNotifyChannel(t *testing.T) {
+	ctx := helpers.CtxAsUser(testUserID)
+
+	t.Run("sends a test notification to the stub", func(t *testing.T) {
+		var got map[string]any
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/push", r.URL.Path)
+			_ = json.NewDecoder(r.Body).Decode(&got)
+		}))
+		defer srv.Close()
+
+		d := newDeps(t)
+		d.notifySender = notify.NewSender(nil)
+		d.expectAdmin()
+		d.notifyRepo.EXPECT().GetNotifyChannelByID(mock.Anything, "c1").Return(&notifyModel.NotifyChannel{
+			ID: "c1", Name: "bark", Type: notifyModel.ChannelBark,
+			Config: map[string]string{"server": srv.URL, "device_key": "dk"},
+		}, nil).Once()
+
+		require.NoError(t, d.build().TestNotifyChannel(ctx, "c1"))
+		assert.Equal(t, "dk", got["device_key"])
+		assert.Equal(t, "Ech0 测试通知", got["title"])
+	})
+
+	t.Run("failure is reported with the reason", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "bad token", http.StatusUnauthorized)
+		}))
+		defer srv.Close()
+
+		d := newDeps(t)
+		d.notifySender = notify.NewSender(nil)
+		d.expectAdmin()
+		d.notifyRepo.EXPECT().GetNotifyChannelByID(mock.Anything, "c1").Return(&notifyModel.NotifyChannel{
+			ID: "c1", Type: notifyModel.ChannelGotify,
+			Config: map[string]string{"server": srv.URL, "token": "t"},
+		}, nil).Once()
+
+		err := d.build().TestNotifyChannel(ctx, "c1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), commonModel.NOTIFY_CHANNEL_TEST_FAILED)
+		assert.Contains(t, err.Error(), "401")
+		assert.Contains(t, err.Error(), "bad token")
+	})
+}
+
+func TestListNotifyDeliveries(t *testing.T) {
+	d := newDeps(t)
+	d.expectAdmin()
+	d.notifyRepo.EXPECT().
+		ListNotifyDeliveries(mock.Anything, "c1", 100).
+		Return([]notifyModel.NotifyDelivery{{ID: "d1", ChannelID: "c1"}}, nil).
+		Once()
+
+	got, err := d.build().ListNotifyDeliveries(helpers.CtxAsUser(testUserID), "c1")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"time"
 
+	notifyModel "github.com/lin-snow/ech0/internal/model/notify"
 	model "github.com/lin-snow/ech0/internal/model/setting"
 	webhookModel "github.com/lin-snow/ech0/internal/model/webhook"
 	commonService "github.com/lin-snow/ech0/internal/service/common"
@@ -31,6 +32,12 @@ type Service interface {
 	UpdateWebhook(ctx context.Context, id string, newWebhook *model.WebhookDto) error
 	CreateWebhook(ctx context.Context, newWebhook *model.WebhookDto) error
 	TestWebhook(ctx context.Context, id string) error
+	GetAllNotifyChannels(ctx context.Context) ([]notifyModel.NotifyChannel, error)
+	CreateNotifyChannel(ctx context.Context, newChannel *notifyModel.NotifyChannelDto) error
+	UpdateNotifyChannel(ctx context.Context, id string, newChannel *notifyModel.NotifyChannelDto) error
+	DeleteNotifyChannel(ctx context.Context, id string) error
+	TestNotifyChannel(ctx context.Context, id string) error
+	ListNotifyDeliveries(ctx context.Context, channelID string) ([]notifyModel.NotifyDelivery, error)
 	ListAccessTokens(ctx context.Context) ([]model.AccessTokenSetting, error)
 	CreateAccessToken(ctx context.Context, newToken *model.AccessTokenSettingDto) (string, error)
 	DeleteAccessToken(ctx context.Context, id string) error
@@ -70,3 +77,12 @@ type WebhookRepository interface {
 	UpdateWebhookDeliveryStatus(ctx context.Context, id string, status string, lastTrigger int64) error
 	DeleteWebhookByID(ctx context.Context, id string) error
 }
+
+type NotifyRepository interface {
+	GetAllNotifyChannels(ctx context.Context) ([]notifyModel.NotifyChannel, error)
+	GetNotifyChannelByID(ctx context.Context, id string) (*notifyModel.NotifyChannel, error)
+	CreateNotifyChannel(ctx context.Context, channel *notifyModel.NotifyChannel) error
+	UpdateNotifyChannelByID(ctx context.Context, id string, channel *notifyModel.NotifyChannel) error
+	DeleteNotifyChannelByID(ctx context.Context, id string) error
+	ListNotifyDeliveries(ctx context.Context, channelID string, limit int) ([]notifyModel.NotifyDelivery, error)
+}
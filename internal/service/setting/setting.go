@@ -5,6 +5,7 @@ package service
 
 import (
 	"github.com/lin-snow/ech0/internal/kvstore"
+	"github.com/lin-snow/ech0/internal/notify"
 	"github.com/lin-snow/ech0/internal/storage"
 	"github.com/lin-snow/ech0/internal/transaction"
 	webhookclient "github.com/lin-snow/ech0/internal/webhook"
@@ -20,6 +21,8 @@ type SettingService struct {
 	settingRepository SettingRepository
 	webhookRepository WebhookRepository
 	webhookSender     *webhookclient.Sender
+	notifyRepository  NotifyRepository
+	notifySender      *notify.Sender
 	tokenRevoker      TokenRevoker
 	bus               *busen.Bus
 }
@@ -33,6 +36,8 @@ func NewSettingService(
 	settingRepository SettingRepository,
 	webhookRepository WebhookRepository,
 	webhookSender *webhookclient.Sender,
+	notifyRepository NotifyRepository,
+	notifySender *notify.Sender,
 	tokenRevoker TokenRevoker,
 	busProvider func() *busen.Bus,
 ) *SettingService {
@@ -44,6 +49,8 @@ func NewSettingService(
 		durableKV:         durableKV,
 		webhookRepository: webhookRepository,
 		webhookSender:     webhookSender,
+		notifyRepository:  notifyRepository,
+		notifySender:      notifySender,
 		settingRepository: settingRepository,
 		tokenRevoker:      tokenRevoker,
 		bus:               busProvider(),
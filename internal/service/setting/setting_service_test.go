@@ -10,10 +10,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/lin-snow/ech0/internal/event"
+	"github.com/lin-snow/ech0/internal/kvstore"
 	authModel "github.com/lin-snow/ech0/internal/model/auth"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	notifyModel "github.com/lin-snow/ech0/internal/model/notify"
 	settingModel "github.com/lin-snow/ech0/internal/model/setting"
 	webhookModel "github.com/lin-snow/ech0/internal/model/webhook"
+	"github.com/lin-snow/ech0/internal/notify"
 	settingService "github.com/lin-snow/ech0/internal/service/setting"
 	"github.com/lin-snow/ech0/internal/test/helpers"
 	commonmock "github.com/lin-snow/ech0/internal/test/mocks/commonmock"
@@ -28,13 +32,15 @@ import (
 
 // deps 聚合 SettingService 的协作者 mock，便于按需设置期望后 build。
 type deps struct {
-	tx          *txmock.MockTransactor
-	common      *commonmock.MockService
-	kv          *kvmock.MockStore
-	settingRepo *settingmock.MockSettingRepository
-	webhookRepo *settingmock.MockWebhookRepository
-	revoker     *settingmock.MockTokenRevoker
-	bus         *busen.Bus
+	tx           *txmock.MockTransactor
+	common       *commonmock.MockService
+	kv           *kvmock.MockStore
+	settingRepo  *settingmock.MockSettingRepository
+	webhookRepo  *settingmock.MockWebhookRepository
+	notifyRepo   *settingmock.MockNotifyRepository
+	notifySender *notify.Sender
+	revoker      *settingmock.MockTokenRevoker
+	bus          *busen.Bus
 }
 
 func newDeps(t *testing.T) *deps {
@@ -45,6 +51,7 @@ func newDeps(t *testing.T) *deps {
 		kv:          kvmock.NewMockStore(t),
 		settingRepo: settingmock.NewMockSettingRepository(t),
 		webhookRepo: settingmock.NewMockWebhookRepository(t),
+		notifyRepo:  settingmock.NewMockNotifyRepository(t),
 		revoker:     settingmock.NewMockTokenRevoker(t),
 		bus:         busen.New(),
 	}
@@ -60,6 +67,8 @@ func (d *deps) build() *settingService.SettingService {
 		d.settingRepo,
 		d.webhookRepo,
 		nil, // webhookSender：仅 TestWebhook 成功路径需要，不在此测
+		d.notifyRepo,
+		d.notifySender, // 默认 nil：仅 TestNotifyChannel 需要，由用例自行装配
 		d.revoker,
 		func() *busen.Bus { return d.bus },
 	)
@@ -146,6 +155,26 @@ func TestSettingService_NonAdminDenied(t *testing.T) {
 		"UpdateEmbeddingSetting": func(svc *settingService.SettingService) error {
 			return svc.UpdateEmbeddingSetting(ctx, settingModel.EmbeddingSettingDto{})
 		},
+		"GetAllNotifyChannels": func(svc *settingService.SettingService) error {
+			_, err := svc.GetAllNotifyChannels(ctx)
+			return err
+		},
+		"CreateNotifyChannel": func(svc *settingService.SettingService) error {
+			return svc.CreateNotifyChannel(ctx, &notifyModel.NotifyChannelDto{Name: "n", Type: "ntfy"})
+		},
+		"UpdateNotifyChannel": func(svc *settingService.SettingService) error {
+			return svc.UpdateNotifyChannel(ctx, "id-1", &notifyModel.NotifyChannelDto{Name: "n", Type: "ntfy"})
+		},
+		"DeleteNotifyChannel": func(svc *settingService.SettingService) error {
+			return svc.DeleteNotifyChannel(ctx, "id-1")
+		},
+		"TestNotifyChannel": func(svc *settingService.SettingService) error {
+			return svc.TestNotifyChannel(ctx, "id-1")
+		},
+		"ListNotifyDeliveries": func(svc *settingService.SettingService) error {
+			_, err := svc.ListNotifyDeliveries(ctx, "")
+			return err
+		},
 	}
 
 	for name, call := range calls {
@@ -221,6 +250,10 @@ func TestUpdateWebhook_Valid(t *testing.T) {
 	d := newDeps(t)
 	d.expectAdmin()
 	d.tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTxExec()).Once()
+	d.webhookRepo.EXPECT().
+		GetWebhookByID(mock.Anything, "wh-9").
+		Return(&webhookModel.Webhook{ID: "wh-9", IsActive: true}, nil).
+		Once()
 	d.webhookRepo.EXPECT().
 		UpdateWebhookByID(mock.Anything, "wh-9", mock.MatchedBy(func(w *webhookModel.Webhook) bool {
 			return w != nil && w.URL == "https://hooks.example.com/u"
@@ -228,10 +261,35 @@ func TestUpdateWebhook_Valid(t *testing.T) {
 		Return(nil).
 		Once()
 
-	err := d.build().UpdateWebhook(helpers.CtxAsUser(testUserID), "wh-9", &settingModel.WebhookDto{
+	var got []event.WebhookDisabled
+	unsub, err := busen.Subscribe(d.bus, func(_ context.Context, e busen.Event[event.WebhookDisabled]) error {
+		got = append(got, e.Value)
+		return nil
+	})
+	require.NoError(t, err)
+	defer unsub()
+
+	err = d.build().UpdateWebhook(helpers.CtxAsUser(testUserID), "wh-9", &settingModel.WebhookDto{
 		Name: "n", URL: "https://hooks.example.com/u",
 	})
 	require.NoError(t, err)
+	require.Len(t, got, 1, "active → inactive publishes WebhookDisabled")
+	assert.Equal(t, "wh-9", got[0].Webhook.ID)
+	assert.Equal(t, "https://hooks.example.com/u", got[0].Webhook.URL)
+
+	// 保持启用（或本就停用）时不发布。
+	d.expectAdmin()
+	d.tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTxExec()).Once()
+	d.webhookRepo.EXPECT().
+		GetWebhookByID(mock.Anything, "wh-9").
+		Return(&webhookModel.Webhook{ID: "wh-9", IsActive: true}, nil).
+		Once()
+	d.webhookRepo.EXPECT().UpdateWebhookByID(mock.Anything, "wh-9", mock.Anything).Return(nil).Once()
+	err = d.build().UpdateWebhook(helpers.CtxAsUser(testUserID), "wh-9", &settingModel.WebhookDto{
+		Name: "n", URL: "https://hooks.example.com/u", IsActive: true,
+	})
+	require.NoError(t, err)
+	assert.Len(t, got, 1)
 }
 
 // TestDeleteAccessToken_RevokesJTI 锁定「删除即拉黑 JTI」契约（GHSA-fpw6-hrg5-q5x5）。
@@ -414,6 +472,10 @@ func TestUpdateAgentSettings_NormalizesProtocol(t *testing.T) {
 func TestUpdateEmbeddingSetting_Persists(t *testing.T) {
 	d := newDeps(t)
 	d.expectAdmin()
+	d.kv.EXPECT().
+		Get(mock.Anything, commonModel.EmbeddingSettingKey).
+		Return(settingJSON(t, settingModel.EmbeddingSetting{Enable: true, Model: "m", Dim: 8}), nil).
+		Once()
 	d.kv.EXPECT().
 		Set(mock.Anything, commonModel.EmbeddingSettingKey, mock.Anything).
 		Return(nil).
@@ -425,6 +487,46 @@ func TestUpdateEmbeddingSetting_Persists(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestUpdateEmbeddingSetting_PublishesToggle 仅在生效状态翻转时发布 FeatureToggled。
+func TestUpdateEmbeddingSetting_PublishesToggle(t *testing.T) {
+	d := newDeps(t)
+	var got []event.FeatureToggled
+	unsub, err := busen.Subscribe(d.bus, func(_ context.Context, e busen.Event[event.FeatureToggled]) error {
+		got = append(got, e.Value)
+		return nil
+	})
+	require.NoError(t, err)
+	defer unsub()
+
+	d.common.EXPECT().
+		CommonGetUserByUserId(mock.Anything, mock.Anything).
+		Return(helpers.NewUser(helpers.AsAdmin), nil).
+		Twice()
+	d.kv.EXPECT().
+		Get(mock.Anything, commonModel.EmbeddingSettingKey).
+		Return("", kvstore.ErrNotFound).
+		Once()
+	d.kv.EXPECT().
+		Get(mock.Anything, commonModel.EmbeddingSettingKey).
+		Return(settingJSON(t, settingModel.EmbeddingSetting{Enable: true, Model: "m", Dim: 8}), nil).
+		Once()
+	d.kv.EXPECT().
+		Set(mock.Anything, commonModel.EmbeddingSettingKey, mock.Anything).
+		Return(nil).
+		Twice()
+
+	svc := d.build()
+	ctx := helpers.CtxAsUser(testUserID)
+	enabled := settingModel.EmbeddingSettingDto{Enable: true, Model: "m", Dim: 8}
+	require.NoError(t, svc.UpdateEmbeddingSetting(ctx, enabled))
+	// 已生效时再次保存（只改 BatchSize）不算翻转。
+	enabled.BatchSize = 16
+	require.NoError(t, svc.UpdateEmbeddingSetting(ctx, enabled))
+
+	require.Len(t, got, 1)
+	assert.Equal(t, event.FeatureToggled{Feature: event.FeatureEmbedding, Enabled: true}, got[0])
+}
+
 // TestUpdateS3Setting_PersistsWhenStorageNil 在 storageManager 为 nil 时跳过应用、仅落库。
 func TestUpdateS3Setting_PersistsWhenStorageNil(t *testing.T) {
 	d := newDeps(t)
@@ -46,6 +46,9 @@ func (settingService *SettingService) UpdateSnapshotScheduleSetting(
 	updated := model.SnapshotSchedule{
 		Enable:         newSetting.Enable,
 		CronExpression: newSetting.CronExpression,
+		KeepDaily:      newSetting.KeepDaily,
+		KeepWeekly:     newSetting.KeepWeekly,
+		KeepMonthly:    newSetting.KeepMonthly,
 	}
 
 	// 验证 Cron 表达式是否合法
@@ -8,6 +8,8 @@ import (
 	"errors"
 	"time"
 
+	"github.com/lin-snow/ech0/internal/event"
+	eventbus "github.com/lin-snow/ech0/internal/event/bus"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	model "github.com/lin-snow/ech0/internal/model/setting"
 	webhookModel "github.com/lin-snow/ech0/internal/model/webhook"
@@ -88,9 +90,29 @@ func (settingService *SettingService) UpdateWebhook(
 		IsActive: newWebhook.IsActive,
 	}
 
-	return settingService.transactor.Run(ctx, func(ctx context.Context) error {
-		return settingService.webhookRepository.UpdateWebhookByID(ctx, id, webhook)
-	})
+	var disabled *webhookModel.Webhook
+	if err := settingService.transactor.Run(ctx, func(ctx context.Context) error {
+		previous, err := settingService.webhookRepository.GetWebhookByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := settingService.webhookRepository.UpdateWebhookByID(ctx, id, webhook); err != nil {
+			return err
+		}
+		if previous.IsActive && !webhook.IsActive {
+			webhook.ID = id
+			disabled = webhook
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 由启用变为停用时通知（通知渠道可订阅 webhook.disabled）
+	if disabled != nil {
+		eventbus.Notify(ctx, settingService.bus, event.WebhookDisabled{Webhook: *disabled})
+	}
+	return nil
 }
 
 // CreateWebhook 创建 Webhook
@@ -14,7 +14,9 @@ import (
 	"github.com/lin-snow/ech0/internal/kvstore"
 	commentModel "github.com/lin-snow/ech0/internal/model/comment"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
 	settingModel "github.com/lin-snow/ech0/internal/model/setting"
+	timezoneUtil "github.com/lin-snow/ech0/internal/util/timezone"
 	urlUtil "github.com/lin-snow/ech0/internal/util/url"
 )
 
@@ -119,8 +121,66 @@ var (
 			return settingModel.SnapshotSchedule{
 				Enable:         false,
 				CronExpression: "0 2 * * 0", // 每周日凌晨 2 点
+				KeepDaily:      7,
+				KeepWeekly:     4,
+				KeepMonthly:    3,
 			}
 		},
+		Normalize: normalizeSnapshot,
+	}
+
+	// Digest 站长定期摘要邮件。
+	Digest = Spec[settingModel.DigestSetting]{
+		Key: commonModel.DigestSettingKey,
+		Default: func() settingModel.DigestSetting {
+			return settingModel.DigestSetting{
+				Enable:         false,
+				CronExpression: "0 9 * * 1", // 每周一上午 9 点
+				Period:         settingModel.DigestPeriodWeekly,
+				Timezone:       "UTC",
+				OnThisDay:      true,
+			}
+		},
+		Normalize: normalizeDigest,
+	}
+
+	// Sync 实例间同步的对端配置。AccessToken 的脱敏属输出投影，留在 MigratorService。
+	Sync = Spec[migratorModel.SyncSetting]{
+		Key: commonModel.SyncSettingKey,
+		Default: func() migratorModel.SyncSetting {
+			return migratorModel.SyncSetting{
+				Enable:          false,
+				Direction:       migratorModel.SyncDirectionBoth,
+				ConflictPolicy:  "newer",
+				IntervalMinutes: 30,
+			}
+		},
+		Normalize: normalizeSync,
+	}
+
+	// Publish 静态站自动发布配置。SecretKey 的脱敏属输出投影，留在 MigratorService。
+	Publish = Spec[migratorModel.PublishSetting]{
+		Key: commonModel.PublishSettingKey,
+		Default: func() migratorModel.PublishSetting {
+			return migratorModel.PublishSetting{
+				Enable:          false,
+				BaseURL:         "/",
+				DebounceSeconds: 60,
+			}
+		},
+		Normalize: normalizePublish,
+	}
+
+	// Encryption 快照与胶囊归档的加密配置。Passphrase 的脱敏属输出投影，留在 MigratorService。
+	Encryption = Spec[migratorModel.EncryptionSetting]{
+		Key: commonModel.ArchiveEncryptionKey,
+		Default: func() migratorModel.EncryptionSetting {
+			return migratorModel.EncryptionSetting{
+				Enable: false,
+				Mode:   migratorModel.EncryptionModePassphrase,
+			}
+		},
+		Normalize: normalizeEncryption,
 	}
 
 	// Embedding 向量设置。默认零值（Enable=false），与历史「miss 即视为未启用」一致。
@@ -131,6 +191,15 @@ var (
 		},
 	}
 
+	// StorageQuota 用户存储配额。默认全部为 0（不限），与引入配额前的行为一致。
+	StorageQuota = Spec[settingModel.StorageQuotaSetting]{
+		Key: commonModel.StorageQuotaSettingKey,
+		Default: func() settingModel.StorageQuotaSetting {
+			return settingModel.StorageQuotaSetting{UserQuotas: map[string]int64{}}
+		},
+		Normalize: normalizeStorageQuota,
+	}
+
 	// Comment 评论系统设置（含邮件通知）。SMTPPassword 的脱敏（SMTPPasswordSet 模式）
 	// 属输出投影，留在 CommentService 的读出口，不在此归一化。
 	Comment = Spec[commentModel.SystemSetting]{
@@ -158,7 +227,12 @@ var registry = []seedable{
 	Passkey,
 	Agent,
 	Snapshot,
+	Digest,
+	Sync,
+	Publish,
+	Encryption,
 	Embedding,
+	StorageQuota,
 	Comment,
 }
 
@@ -190,6 +264,74 @@ func normalizeComment(s *commentModel.SystemSetting) {
 	}
 }
 
+// normalizeSnapshot 把负的留存份数收敛为 0。三档全为 0 即「只保留最新一份」——
+// 引入留存策略之前存下的计划解码出来正是这个值，行为不变。
+func normalizeSnapshot(s *settingModel.SnapshotSchedule) {
+	s.KeepDaily = max(s.KeepDaily, 0)
+	s.KeepWeekly = max(s.KeepWeekly, 0)
+	s.KeepMonthly = max(s.KeepMonthly, 0)
+}
+
+// normalizeDigest 把未知的统计窗口拉回按周，非法时区回退为 UTC。
+func normalizeDigest(s *settingModel.DigestSetting) {
+	if s.Period != settingModel.DigestPeriodDaily {
+		s.Period = settingModel.DigestPeriodWeekly
+	}
+	s.Timezone = timezoneUtil.NormalizeTimezone(strings.TrimSpace(s.Timezone))
+}
+
+// normalizeStorageQuota 把负的额度收敛为 0（不限），并丢掉用户 ID 为空的覆盖项。
+func normalizeStorageQuota(s *settingModel.StorageQuotaSetting) {
+	s.OwnerQuota = max(s.OwnerQuota, 0)
+	s.AdminQuota = max(s.AdminQuota, 0)
+	s.UserQuota = max(s.UserQuota, 0)
+	quotas := make(map[string]int64, len(s.UserQuotas))
+	for id, quota := range s.UserQuotas {
+		if id = strings.TrimSpace(id); id != "" {
+			quotas[id] = max(quota, 0)
+		}
+	}
+	s.UserQuotas = quotas
+}
+
+// normalizeSync 去掉对端地址的尾斜杠，并把缺省/非法的方向与间隔拉回默认。
+func normalizeSync(s *migratorModel.SyncSetting) {
+	s.PeerURL = urlUtil.TrimURL(s.PeerURL)
+	switch s.Direction {
+	case migratorModel.SyncDirectionPull, migratorModel.SyncDirectionPush, migratorModel.SyncDirectionBoth:
+	default:
+		s.Direction = migratorModel.SyncDirectionBoth
+	}
+	if s.IntervalMinutes <= 0 {
+		s.IntervalMinutes = 30
+	}
+}
+
+// normalizePublish 去掉目标两端空白，并把缺省的部署基址与防抖间隔拉回默认。
+func normalizePublish(s *migratorModel.PublishSetting) {
+	s.Target = strings.TrimSpace(s.Target)
+	if strings.TrimSpace(s.BaseURL) == "" {
+		s.BaseURL = "/"
+	}
+	if s.DebounceSeconds <= 0 {
+		s.DebounceSeconds = 60
+	}
+}
+
+// normalizeEncryption 把缺省/非法的加密方式拉回 passphrase，并去掉公钥两端空白与空行。
+func normalizeEncryption(s *migratorModel.EncryptionSetting) {
+	if s.Mode != migratorModel.EncryptionModeRecipient {
+		s.Mode = migratorModel.EncryptionModePassphrase
+	}
+	recipients := make([]string, 0, len(s.Recipients))
+	for _, r := range s.Recipients {
+		if r = strings.TrimSpace(r); r != "" {
+			recipients = append(recipients, r)
+		}
+	}
+	s.Recipients = recipients
+}
+
 // migratePasskeyFromLegacy 从旧 oauth2_setting 中读取曾经内联的 WebAuthn 字段。
 func migratePasskeyFromLegacy(ctx context.Context, kv kvstore.Store) (settingModel.PasskeySetting, bool) {
 	var result settingModel.PasskeySetting
@@ -135,6 +135,9 @@ func TestSeed_PopulatesMissingKeys(t *testing.T) {
 		commonModel.PasskeySettingKey,
 		commonModel.AgentSettingKey,
 		commonModel.SnapshotScheduleKey,
+		commonModel.DigestSettingKey,
+		commonModel.SyncSettingKey,
+		commonModel.PublishSettingKey,
 		commonModel.EmbeddingSettingKey,
 		commentModel.CommentSystemSettingKey,
 	} {
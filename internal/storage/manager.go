@@ -14,6 +14,7 @@ import (
 	fileModel "github.com/lin-snow/ech0/internal/model/file"
 	settingModel "github.com/lin-snow/ech0/internal/model/setting"
 	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	"github.com/lin-snow/ech0/pkg/virefs"
 )
 
 type Manager struct {
@@ -52,6 +53,18 @@ func NewStorageManagerForTest(dataRoot string) *Manager {
 	}
 }
 
+// NewStorageManagerWithObjectForTest is NewStorageManagerForTest with objectFS
+// mounted as the object route, so tests can cover object-only flows (direct
+// upload metadata backfill) without a real S3 endpoint.
+func NewStorageManagerWithObjectForTest(dataRoot string, objectFS virefs.FS) *Manager {
+	m := NewStorageManagerForTest(dataRoot)
+	m.selector.objectFS = objectFS
+	m.selector.objectEnabled = true
+	m.selector.objectProvider = "test"
+	m.selector.objectBucket = "test-bucket"
+	return m
+}
+
 func (m *Manager) GetSelector() *StorageSelector {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/lin-snow/ech0/pkg/virefs"
+)
+
+// RouteFS is one end of a file route opened for direct access: the route
+// columns a File row records (storage type / provider / bucket) together with
+// the FS that reads and writes it and the resolver for its public URLs.
+type RouteFS struct {
+	StorageType StorageType
+	Provider    string
+	Bucket      string
+	FS          virefs.FS
+	ResolveURL  URLResolver
+}
+
+// OpenRoute opens the route a storage migration reads from or writes to.
+//
+// Unlike the selector, an object route does not require S3 to be enabled:
+// moving files back to local disk usually happens right after object storage
+// is switched off, and the source bucket must stay reachable for that. The
+// endpoint and credentials always come from the current S3 setting; a
+// non-empty bucket overrides the configured one (bucket-to-bucket moves on the
+// same account). When the requested route is the live object route, the
+// selector's FS is reused.
+func (m *Manager) OpenRoute(ctx context.Context, storageType StorageType, bucket string) (RouteFS, error) {
+	selector := m.GetSelector()
+	switch NormalizeStorageType(string(storageType)) {
+	case StorageTypeLocal:
+		fs, err := selector.getFS(StorageTypeLocal)
+		if err != nil {
+			return RouteFS{}, err
+		}
+		return RouteFS{StorageType: StorageTypeLocal, FS: fs, ResolveURL: selector.localResolve}, nil
+	case StorageTypeObject:
+	default:
+		return RouteFS{}, errors.New("external storage does not support filesystem operations")
+	}
+
+	bucket = strings.TrimSpace(bucket)
+	if provider, liveBucket := selector.ObjectRoute(); liveBucket != "" && (bucket == "" || bucket == liveBucket) {
+		return RouteFS{
+			StorageType: StorageTypeObject,
+			Provider:    provider,
+			Bucket:      liveBucket,
+			FS:          selector.objectFS,
+			ResolveURL:  selector.objectResolve,
+		}, nil
+	}
+
+	cfg := m.resolveStorageConfig(ctx)
+	if bucket != "" {
+		cfg.BucketName = bucket
+	}
+	if cfg.BucketName == "" {
+		return RouteFS{}, errors.New("object storage is not configured")
+	}
+	cfg.ObjectEnabled = true
+	fs, resolve, _, ok := buildOptionalObjectFSAndResolver(cfg, NewFileSchema())
+	if !ok {
+		return RouteFS{}, errors.New("object storage initialization failed")
+	}
+	return RouteFS{
+		StorageType: StorageTypeObject,
+		Provider:    strings.ToLower(strings.TrimSpace(cfg.Provider)),
+		Bucket:      cfg.BucketName,
+		FS:          fs,
+		ResolveURL:  resolve,
+	}, nil
+}
+
+// UseObjectFSForTest swaps in fs as the live object route (provider/bucket)
+// of a Manager built by NewStorageManagerForTest, so tests can exercise
+// local<->object moves against a second temp dir instead of a real bucket.
+// Like NewStorageManagerForTest it exists only because the selector's fields
+// are unexported.
+func (m *Manager) UseObjectFSForTest(fs virefs.FS, provider, bucket string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	selector := *m.selector
+	selector.objectFS = fs
+	selector.objectEnabled = true
+	selector.objectProvider = provider
+	selector.objectBucket = bucket
+	selector.objectResolve = func(key string) string { return "/test-object/" + bucket + "/" + key }
+	m.selector = &selector
+}
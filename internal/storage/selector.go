@@ -95,6 +95,23 @@ func (r *StorageSelector) Put(
 	return fs.Put(ctx, key, reader, opts...)
 }
 
+// PutMultipart is Put for large bodies: backends that support multipart
+// uploads (object storage) receive the body in partSize chunks.
+func (r *StorageSelector) PutMultipart(
+	ctx context.Context,
+	storageType StorageType,
+	key string,
+	reader io.Reader,
+	partSize int64,
+	opts ...virefs.PutOption,
+) error {
+	fs, err := r.getFS(storageType)
+	if err != nil {
+		return err
+	}
+	return virefs.PutMultipart(ctx, fs, key, reader, partSize, opts...)
+}
+
 func (r *StorageSelector) Get(ctx context.Context, storageType StorageType, key string) (io.ReadCloser, error) {
 	fs, err := r.getFS(storageType)
 	if err != nil {
@@ -103,6 +120,22 @@ func (r *StorageSelector) Get(ctx context.Context, storageType StorageType, key
 	return fs.Get(ctx, key)
 }
 
+func (r *StorageSelector) Exists(ctx context.Context, storageType StorageType, key string) (bool, error) {
+	fs, err := r.getFS(storageType)
+	if err != nil {
+		return false, err
+	}
+	return fs.Exists(ctx, key)
+}
+
+func (r *StorageSelector) Stat(ctx context.Context, storageType StorageType, key string) (*virefs.FileInfo, error) {
+	fs, err := r.getFS(storageType)
+	if err != nil {
+		return nil, err
+	}
+	return fs.Stat(ctx, key)
+}
+
 func (r *StorageSelector) Delete(ctx context.Context, storageType StorageType, key string) error {
 	fs, err := r.getFS(storageType)
 	if err != nil {
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package scheduled
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/lin-snow/ech0/internal/event"
+	eventbus "github.com/lin-snow/ech0/internal/event/bus"
+	"github.com/lin-snow/ech0/internal/kvstore"
+	dashboardService "github.com/lin-snow/ech0/internal/service/dashboard"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	"github.com/lin-snow/ech0/pkg/busen"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+const digestScheduleTag = "DigestSchedule"
+
+// Digest 按 DigestSetting 的 cron 定时给 owner 发送站点摘要。生命周期与定时快照相同：
+// Schedule 时捕获 scheduler 并订阅 UpdateDigestSchedule，收到即按持久化的最新设置重挂作业；OnStop 退订。
+// cron 按设置里的时区解释（gocron 的 CRON_TZ 前缀），而非调度器的 UTC。
+type Digest struct {
+	durableKV kvstore.Store
+	digester  dashboardService.DigestService
+	bus       *busen.Bus
+
+	mu        sync.Mutex
+	scheduler gocron.Scheduler
+	unsub     func()
+}
+
+func NewDigest(
+	durableKV kvstore.Store,
+	digester dashboardService.DigestService,
+	busProvider func() *busen.Bus,
+) *Digest {
+	return &Digest{durableKV: durableKV, digester: digester, bus: busProvider()}
+}
+
+func (d *Digest) Name() string { return "digest" }
+
+// Schedule 捕获 scheduler，订阅设置变更，并按当前设置挂上摘要作业。
+func (d *Digest) Schedule(ctx context.Context, scheduler gocron.Scheduler) error {
+	d.mu.Lock()
+	d.scheduler = scheduler
+	d.mu.Unlock()
+
+	unsub, err := eventbus.On(d.handleScheduleChanged, eventbus.AsyncSequential()...)(d.bus)
+	if err != nil {
+		return err
+	}
+	d.mu.Lock()
+	d.unsub = unsub
+	d.mu.Unlock()
+	return d.reload(ctx)
+}
+
+// OnStop 退订总线。实现 task.StopHook。
+func (d *Digest) OnStop(_ context.Context) {
+	d.mu.Lock()
+	unsub := d.unsub
+	d.unsub = nil
+	d.mu.Unlock()
+	if unsub != nil {
+		unsub()
+	}
+}
+
+// handleScheduleChanged 忽略事件载荷，直接重读持久化设置（同 Snapshot.handleScheduleChanged）。
+func (d *Digest) handleScheduleChanged(ctx context.Context, _ event.UpdateDigestSchedule) error {
+	return d.reload(ctx)
+}
+
+// reload 读当前设置 → 移除旧作业 → 启用则按 cron 重新挂上。读取失败时保留现有作业。
+func (d *Digest) reload(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.scheduler == nil {
+		return nil
+	}
+
+	setting, err := coreSetting.Get(ctx, d.durableKV, coreSetting.Digest)
+	if err != nil {
+		logUtil.GetLogger().Error("Failed to read digest setting, keeping current jobs",
+			slog.String("module", logModule), logUtil.Err(err))
+		return err
+	}
+
+	d.scheduler.RemoveByTags(digestScheduleTag)
+	if !setting.Enable {
+		return nil
+	}
+
+	withSeconds := len(strings.Fields(setting.CronExpression)) == 6
+	_, err = d.scheduler.NewJob(
+		gocron.CronJob("CRON_TZ="+setting.Timezone+" "+setting.CronExpression, withSeconds),
+		gocron.NewTask(func() {
+			if err := d.digester.SendScheduledDigest(context.Background()); err != nil {
+				logUtil.GetLogger().Error("Failed to send scheduled digest",
+					slog.String("module", logModule), logUtil.Err(err))
+			}
+		}),
+		gocron.WithTags(digestScheduleTag),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+	)
+	if err != nil {
+		logUtil.GetLogger().Error("Failed to apply digest schedule",
+			slog.String("module", logModule), logUtil.Err(err))
+		return err
+	}
+	logUtil.GetLogger().Info("Digest schedule applied",
+		slog.String("module", logModule),
+		slog.String("cron", setting.CronExpression),
+		slog.String("timezone", setting.Timezone))
+	return nil
+}
@@ -9,5 +9,9 @@ import "github.com/google/wire"
 var ProviderSet = wire.NewSet(
 	NewCleanup,
 	NewSnapshot,
+	NewDigest,
 	NewVisitorSnapshot,
+	NewSync,
+	NewPublish,
+	NewQuotaRecount,
 )
@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package scheduled
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/lin-snow/ech0/internal/event"
+	eventbus "github.com/lin-snow/ech0/internal/event/bus"
+	"github.com/lin-snow/ech0/internal/job"
+	"github.com/lin-snow/ech0/internal/kvstore"
+	jobModel "github.com/lin-snow/ech0/internal/model/job"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	"github.com/lin-snow/ech0/pkg/busen"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+// Publish 在内容变更后重建并重发静态站。它不挂 gocron 作业，而是订阅 Echo 增删改事件并防抖：
+// 最后一次变更之后静默 DebounceSeconds 秒才提交发布作业，连发几条只重建一次。
+//
+// 发布走 job.Manager：进度与上传摘要要在面板上可见，且与手动触发互斥。到点时上一轮还在跑，
+// 就再等一个防抖周期重试——上一轮的导出可能早于这次变更，直接丢掉会让站点停在旧内容上。
+type Publish struct {
+	durableKV  kvstore.Store
+	jobManager *job.Manager
+	bus        *busen.Bus
+
+	mu     sync.Mutex
+	timer  *time.Timer
+	unsubs []func()
+}
+
+func NewPublish(durableKV kvstore.Store, jobManager *job.Manager, busProvider func() *busen.Bus) *Publish {
+	return &Publish{durableKV: durableKV, jobManager: jobManager, bus: busProvider()}
+}
+
+func (p *Publish) Name() string { return "publish" }
+
+// Schedule 订阅 Echo 变更事件。scheduler 用不上：触发源是事件而不是时钟。
+func (p *Publish) Schedule(_ context.Context, _ gocron.Scheduler) error {
+	for _, reg := range []eventbus.Registration{
+		eventbus.On(p.handleEchoCreated, eventbus.AsyncSequential()...),
+		eventbus.On(p.handleEchoUpdated, eventbus.AsyncSequential()...),
+		eventbus.On(p.handleEchoDeleted, eventbus.AsyncSequential()...),
+	} {
+		unsub, err := reg(p.bus)
+		if err != nil {
+			p.OnStop(context.Background())
+			return err
+		}
+		p.mu.Lock()
+		p.unsubs = append(p.unsubs, unsub)
+		p.mu.Unlock()
+	}
+	return nil
+}
+
+// OnStop 退订总线并撤掉尚未触发的防抖计时器。实现 task.StopHook。
+func (p *Publish) OnStop(_ context.Context) {
+	p.mu.Lock()
+	unsubs := p.unsubs
+	p.unsubs = nil
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+	p.mu.Unlock()
+	for _, unsub := range unsubs {
+		unsub()
+	}
+}
+
+func (p *Publish) handleEchoCreated(ctx context.Context, _ event.EchoCreated) error {
+	p.changed(ctx)
+	return nil
+}
+
+func (p *Publish) handleEchoUpdated(ctx context.Context, _ event.EchoUpdated) error {
+	p.changed(ctx)
+	return nil
+}
+
+func (p *Publish) handleEchoDeleted(ctx context.Context, _ event.EchoDeleted) error {
+	p.changed(ctx)
+	return nil
+}
+
+// changed 在自动发布启用时（重新）起一个防抖计时器。
+func (p *Publish) changed(ctx context.Context) {
+	setting, err := coreSetting.Get(ctx, p.durableKV, coreSetting.Publish)
+	if err != nil {
+		logUtil.GetLogger().Error("Failed to read publish setting",
+			slog.String("module", logModule), logUtil.Err(err))
+		return
+	}
+	if !setting.Enable || setting.Target == "" {
+		return
+	}
+	p.arm(time.Duration(setting.DebounceSeconds) * time.Second)
+}
+
+func (p *Publish) arm(delay time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+	p.timer = time.AfterFunc(delay, func() { p.fire(context.Background(), delay) })
+}
+
+// fire 提交发布作业。到点时设置已被关掉则作罢；撞上在跑的发布就排在它之后，已有一轮在排则不再追加。
+func (p *Publish) fire(ctx context.Context, delay time.Duration) {
+	setting, err := coreSetting.Get(ctx, p.durableKV, coreSetting.Publish)
+	if err != nil {
+		logUtil.GetLogger().Error("Failed to read publish setting",
+			slog.String("module", logModule), logUtil.Err(err))
+		return
+	}
+	if !setting.Enable || setting.Target == "" {
+		return
+	}
+
+	raw, _ := json.Marshal(migratorModel.PublishPayload{Trigger: "content"})
+	_, err = p.jobManager.Submit(ctx, jobModel.TypePublish, raw)
+	switch {
+	case errors.Is(err, job.ErrQueueFull):
+		// 已有一轮排在在跑的发布之后，它会带上这次的改动。
+	case errors.Is(err, job.ErrAlreadyRunning):
+		p.arm(delay)
+	case err != nil:
+		logUtil.GetLogger().Error("Failed to submit static site publish",
+			slog.String("module", logModule), logUtil.Err(err))
+	}
+}
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package scheduled
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	fileService "github.com/lin-snow/ech0/internal/service/file"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+// QuotaRecount 周期按实际文件行重算存储用量账本，校正导入、恢复等绕过上传流程造成的漂移。
+type QuotaRecount struct {
+	fileService fileService.Service
+}
+
+func NewQuotaRecount(fileSvc fileService.Service) *QuotaRecount {
+	return &QuotaRecount{fileService: fileSvc}
+}
+
+func (q *QuotaRecount) Name() string { return "recount-storage-usage" }
+
+// Schedule 每天重算一次存储用量。
+func (q *QuotaRecount) Schedule(_ context.Context, s gocron.Scheduler) error {
+	_, err := s.NewJob(
+		gocron.DurationJob(24*time.Hour),
+		gocron.NewTask(func() {
+			if _, err := q.fileService.ReconcileStorageUsage(); err != nil {
+				logUtil.GetLogger().Error("Failed to recount storage usage",
+					slog.String("module", logModule), logUtil.Err(err))
+			}
+		}),
+	)
+	if err != nil {
+		logUtil.GetLogger().Error("Failed to schedule storage usage recount task",
+			slog.String("module", logModule), logUtil.Err(err))
+	}
+	return err
+}
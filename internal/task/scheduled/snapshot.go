@@ -8,25 +8,34 @@ import (
 	"log/slog"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-co-op/gocron/v2"
 	"github.com/lin-snow/ech0/internal/event"
 	eventbus "github.com/lin-snow/ech0/internal/event/bus"
 	"github.com/lin-snow/ech0/internal/kvstore"
 	coreMigrator "github.com/lin-snow/ech0/internal/migrator"
+	"github.com/lin-snow/ech0/internal/migrator/artifact"
 	coreSetting "github.com/lin-snow/ech0/internal/setting"
 	"github.com/lin-snow/ech0/pkg/busen"
 	logUtil "github.com/lin-snow/ech0/pkg/log"
 )
 
-const snapshotScheduleTag = "SnapshotSchedule"
+const (
+	snapshotScheduleTag = "SnapshotSchedule"
+	snapshotPruneTag    = "SnapshotPrune"
+	// snapshotPruneInterval 是独立清理作业的周期。手动导出不经过定时作业，
+	// 靠它把本地与 S3 上的快照数收敛回留存策略，即便定时快照处于关闭状态。
+	snapshotPruneInterval = 24 * time.Hour
+)
 
 // Snapshot 定时创建系统快照（产出统一 Snapshot，含尽力 S3 上传）。它自管「调度 + 订阅」整个
 // 生命周期：Schedule 时捕获 scheduler 并订阅 UpdateSnapshotSchedule，收到即 reload；OnStop 退订。
 // 计划配置统一经 setting 引擎读 durableKV（而非依赖整个 SettingService），从根上断开
 // 「SettingService → Snapshot → SettingService」的构造环，也无需跨注入器的订阅者壳 / 反射查找。
 // 打包 + S3 的执行收敛到 migrator.ExportEngine，定时快照不走 job.Manager（无需 UI 状态/取消，
-// 且避免与手动导出抢占同一作业行）。
+// 且避免与手动导出抢占同一作业行）。每次定时快照后按计划里的 GFS 留存份数清理旧快照；另有一个
+// 不随计划启停的每日清理作业，兜住手动导出产生的快照。
 type Snapshot struct {
 	durableKV kvstore.Store
 	exporter  *coreMigrator.ExportEngine
@@ -57,6 +66,14 @@ func (s *Snapshot) Schedule(ctx context.Context, scheduler gocron.Scheduler) err
 	if err := s.subscribe(); err != nil {
 		return err
 	}
+	if _, err := scheduler.NewJob(
+		gocron.DurationJob(snapshotPruneInterval),
+		gocron.NewTask(func() { s.prune(context.Background()) }),
+		gocron.WithTags(snapshotPruneTag),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+	); err != nil {
+		return err
+	}
 	return s.reload(ctx)
 }
 
@@ -140,9 +157,11 @@ func (s *Snapshot) scheduleJob(cronExpression string) error {
 				logUtil.GetLogger().Error("Failed to execute scheduled snapshot",
 					slog.String("module", logModule),
 					logUtil.Err(err))
+				eventbus.Notify(ctx, s.bus, event.SystemSnapshotFailed{Source: "schedule", Error: err.Error()})
 				return
 			}
 
+			s.prune(ctx)
 			eventbus.Notify(ctx, s.bus, event.SystemSnapshot{Info: "System scheduled snapshot completed"})
 		}),
 		gocron.WithTags(snapshotScheduleTag),
@@ -156,3 +175,26 @@ func (s *Snapshot) scheduleJob(cronExpression string) error {
 	}
 	return err
 }
+
+// prune 按持久化计划里的留存份数清理旧快照。读不到计划时不清理——宁可多留，不可误删。
+func (s *Snapshot) prune(ctx context.Context) {
+	schedule, err := coreSetting.Get(ctx, s.durableKV, coreSetting.Snapshot)
+	if err != nil {
+		logUtil.GetLogger().Error("Failed to read snapshot retention, skip pruning",
+			slog.String("module", logModule), logUtil.Err(err))
+		return
+	}
+	removed, err := s.exporter.PruneSnapshots(ctx, artifact.Retention{
+		Daily:   schedule.KeepDaily,
+		Weekly:  schedule.KeepWeekly,
+		Monthly: schedule.KeepMonthly,
+	})
+	if err != nil {
+		logUtil.GetLogger().Error("Failed to prune snapshots",
+			slog.String("module", logModule), logUtil.Err(err))
+	}
+	if len(removed) > 0 {
+		logUtil.GetLogger().Info("Pruned old snapshots",
+			slog.String("module", logModule), slog.Int("count", len(removed)))
+	}
+}
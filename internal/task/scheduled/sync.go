@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package scheduled
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/lin-snow/ech0/internal/job"
+	"github.com/lin-snow/ech0/internal/kvstore"
+	jobModel "github.com/lin-snow/ech0/internal/model/job"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+// syncTick 是定时同步的检查粒度。间隔由 SyncSetting 决定、可在运行期改，故不按间隔挂 gocron
+// 作业，而是每分钟现读设置、按上一轮作业的结束时刻判断是否到点——改设置无需事件即可生效。
+const syncTick = time.Minute
+
+// Sync 按 SyncSetting 定时提交同步作业。与定时快照不同，它走 job.Manager：同步的进度、
+// 冲突报告需要在管理面板上可见，且必须与手动触发互斥。
+type Sync struct {
+	durableKV  kvstore.Store
+	jobManager *job.Manager
+	now        func() time.Time
+}
+
+func NewSync(durableKV kvstore.Store, jobManager *job.Manager) *Sync {
+	return &Sync{durableKV: durableKV, jobManager: jobManager, now: time.Now}
+}
+
+func (s *Sync) Name() string { return "sync" }
+
+// Schedule 每分钟检查一次是否该跑下一轮同步。
+func (s *Sync) Schedule(_ context.Context, scheduler gocron.Scheduler) error {
+	_, err := scheduler.NewJob(
+		gocron.DurationJob(syncTick),
+		gocron.NewTask(func() { s.tick(context.Background()) }),
+	)
+	if err != nil {
+		logUtil.GetLogger().Error("Failed to schedule sync task",
+			slog.String("module", logModule), logUtil.Err(err))
+	}
+	return err
+}
+
+// tick 在启用且到点时提交一轮同步。上一轮还在跑、或距其结束不足一个间隔即跳过；失败的一轮同样
+// 按间隔等待，避免对端宕机时每分钟打一次。
+func (s *Sync) tick(ctx context.Context) {
+	setting, err := coreSetting.Get(ctx, s.durableKV, coreSetting.Sync)
+	if err != nil {
+		logUtil.GetLogger().Error("Failed to read sync setting",
+			slog.String("module", logModule), logUtil.Err(err))
+		return
+	}
+	if !setting.Enable || setting.PeerURL == "" || setting.AccessToken == "" {
+		return
+	}
+
+	last, err := s.jobManager.Get(ctx, jobModel.TypeSync)
+	switch {
+	case errors.Is(err, job.ErrNotFound):
+	case err != nil:
+		logUtil.GetLogger().Error("Failed to read sync job",
+			slog.String("module", logModule), logUtil.Err(err))
+		return
+	case !last.Status.IsTerminal():
+		return
+	case last.FinishedAt != nil &&
+		s.now().Unix() < *last.FinishedAt+int64(setting.IntervalMinutes)*60:
+		return
+	}
+
+	raw, _ := json.Marshal(migratorModel.SyncPayload{Trigger: "schedule"})
+	if _, err := s.jobManager.Submit(ctx, jobModel.TypeSync, raw); err != nil && !errors.Is(err, job.ErrAlreadyRunning) {
+		logUtil.GetLogger().Error("Failed to submit scheduled sync",
+			slog.String("module", logModule), logUtil.Err(err))
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/lin-snow/ech0/internal/storage"
+	"github.com/lin-snow/ech0/pkg/virefs"
+	"github.com/stretchr/testify/require"
 )
 
 // NewTestStorage 返回一个仅本地、根目录落在 t.TempDir() 的 storage.Manager，
@@ -16,3 +18,12 @@ func NewTestStorage(t *testing.T) *storage.Manager {
 	t.Helper()
 	return storage.NewStorageManagerForTest(t.TempDir())
 }
+
+// NewTestStorageWithObject 在 NewTestStorage 基础上把另一个临时目录挂成对象存储路由，
+// 返回 Manager 与该对象存储本身，测试可以直接往里放对象来模拟客户端直传。
+func NewTestStorageWithObject(t *testing.T) (*storage.Manager, virefs.FS) {
+	t.Helper()
+	objectFS, err := virefs.NewLocalFS(t.TempDir(), virefs.WithCreateRoot())
+	require.NoError(t, err)
+	return storage.NewStorageManagerWithObjectForTest(t.TempDir(), objectFS), objectFS
+}
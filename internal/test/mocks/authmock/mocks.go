@@ -368,8 +368,8 @@ func (_c *MockService_GetOAuthLoginURL_Call) RunAndReturn(run func(provider stri
 }
 
 // HandleOAuthCallback provides a mock function for the type MockService
-func (_mock *MockService) HandleOAuthCallback(provider string, code string, state string) (string, error) {
-	ret := _mock.Called(provider, code, state)
+func (_mock *MockService) HandleOAuthCallback(provider string, code string, state string, client model.LoginClient) (string, error) {
+	ret := _mock.Called(provider, code, state, client)
 
 	if len(ret) == 0 {
 		panic("no return value specified for HandleOAuthCallback")
@@ -377,16 +377,16 @@ func (_mock *MockService) HandleOAuthCallback(provider string, code string, stat
 
 	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string, string, string) (string, error)); ok {
-		return returnFunc(provider, code, state)
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, model.LoginClient) (string, error)); ok {
+		return returnFunc(provider, code, state, client)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string, string, string) string); ok {
-		r0 = returnFunc(provider, code, state)
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, model.LoginClient) string); ok {
+		r0 = returnFunc(provider, code, state, client)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = returnFunc(provider, code, state)
+	if returnFunc, ok := ret.Get(1).(func(string, string, string, model.LoginClient) error); ok {
+		r1 = returnFunc(provider, code, state, client)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -402,11 +402,12 @@ type MockService_HandleOAuthCallback_Call struct {
 //   - provider string
 //   - code string
 //   - state string
-func (_e *MockService_Expecter) HandleOAuthCallback(provider any, code any, state any) *MockService_HandleOAuthCallback_Call {
-	return &MockService_HandleOAuthCallback_Call{Call: _e.mock.On("HandleOAuthCallback", provider, code, state)}
+//   - client model.LoginClient
+func (_e *MockService_Expecter) HandleOAuthCallback(provider any, code any, state any, client any) *MockService_HandleOAuthCallback_Call {
+	return &MockService_HandleOAuthCallback_Call{Call: _e.mock.On("HandleOAuthCallback", provider, code, state, client)}
 }
 
-func (_c *MockService_HandleOAuthCallback_Call) Run(run func(provider string, code string, state string)) *MockService_HandleOAuthCallback_Call {
+func (_c *MockService_HandleOAuthCallback_Call) Run(run func(provider string, code string, state string, client model.LoginClient)) *MockService_HandleOAuthCallback_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
@@ -420,10 +421,15 @@ func (_c *MockService_HandleOAuthCallback_Call) Run(run func(provider string, co
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 model.LoginClient
+		if args[3] != nil {
+			arg3 = args[3].(model.LoginClient)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -434,7 +440,7 @@ func (_c *MockService_HandleOAuthCallback_Call) Return(s string, err error) *Moc
 	return _c
 }
 
-func (_c *MockService_HandleOAuthCallback_Call) RunAndReturn(run func(provider string, code string, state string) (string, error)) *MockService_HandleOAuthCallback_Call {
+func (_c *MockService_HandleOAuthCallback_Call) RunAndReturn(run func(provider string, code string, state string, client model.LoginClient) (string, error)) *MockService_HandleOAuthCallback_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -553,8 +559,8 @@ func (_c *MockService_ListPasskeys_Call) RunAndReturn(run func(ctx context.Conte
 }
 
 // Login provides a mock function for the type MockService
-func (_mock *MockService) Login(loginDto *model.LoginDto) (*model.TokenPair, error) {
-	ret := _mock.Called(loginDto)
+func (_mock *MockService) Login(loginDto *model.LoginDto, client model.LoginClient) (*model.TokenPair, error) {
+	ret := _mock.Called(loginDto, client)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Login")
@@ -562,18 +568,18 @@ func (_mock *MockService) Login(loginDto *model.LoginDto) (*model.TokenPair, err
 
 	var r0 *model.TokenPair
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*model.LoginDto) (*model.TokenPair, error)); ok {
-		return returnFunc(loginDto)
+	if returnFunc, ok := ret.Get(0).(func(*model.LoginDto, model.LoginClient) (*model.TokenPair, error)); ok {
+		return returnFunc(loginDto, client)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*model.LoginDto) *model.TokenPair); ok {
-		r0 = returnFunc(loginDto)
+	if returnFunc, ok := ret.Get(0).(func(*model.LoginDto, model.LoginClient) *model.TokenPair); ok {
+		r0 = returnFunc(loginDto, client)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.TokenPair)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(*model.LoginDto) error); ok {
-		r1 = returnFunc(loginDto)
+	if returnFunc, ok := ret.Get(1).(func(*model.LoginDto, model.LoginClient) error); ok {
+		r1 = returnFunc(loginDto, client)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -587,18 +593,24 @@ type MockService_Login_Call struct {
 
 // Login is a helper method to define mock.On call
 //   - loginDto *model.LoginDto
-func (_e *MockService_Expecter) Login(loginDto any) *MockService_Login_Call {
-	return &MockService_Login_Call{Call: _e.mock.On("Login", loginDto)}
+//   - client model.LoginClient
+func (_e *MockService_Expecter) Login(loginDto any, client any) *MockService_Login_Call {
+	return &MockService_Login_Call{Call: _e.mock.On("Login", loginDto, client)}
 }
 
-func (_c *MockService_Login_Call) Run(run func(loginDto *model.LoginDto)) *MockService_Login_Call {
+func (_c *MockService_Login_Call) Run(run func(loginDto *model.LoginDto, client model.LoginClient)) *MockService_Login_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 *model.LoginDto
 		if args[0] != nil {
 			arg0 = args[0].(*model.LoginDto)
 		}
+		var arg1 model.LoginClient
+		if args[1] != nil {
+			arg1 = args[1].(model.LoginClient)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -609,7 +621,7 @@ func (_c *MockService_Login_Call) Return(tokenPair *model.TokenPair, err error)
 	return _c
 }
 
-func (_c *MockService_Login_Call) RunAndReturn(run func(loginDto *model.LoginDto) (*model.TokenPair, error)) *MockService_Login_Call {
+func (_c *MockService_Login_Call) RunAndReturn(run func(loginDto *model.LoginDto, client model.LoginClient) (*model.TokenPair, error)) *MockService_Login_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -743,8 +755,8 @@ func (_c *MockService_PasskeyLoginBegin_Call) RunAndReturn(run func(rpID string,
 }
 
 // PasskeyLoginFinish provides a mock function for the type MockService
-func (_mock *MockService) PasskeyLoginFinish(rpID string, origin string, nonce string, credential json.RawMessage) (*model.TokenPair, error) {
-	ret := _mock.Called(rpID, origin, nonce, credential)
+func (_mock *MockService) PasskeyLoginFinish(rpID string, origin string, nonce string, credential json.RawMessage, client model.LoginClient) (*model.TokenPair, error) {
+	ret := _mock.Called(rpID, origin, nonce, credential, client)
 
 	if len(ret) == 0 {
 		panic("no return value specified for PasskeyLoginFinish")
@@ -752,18 +764,18 @@ func (_mock *MockService) PasskeyLoginFinish(rpID string, origin string, nonce s
 
 	var r0 *model.TokenPair
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string, string, string, json.RawMessage) (*model.TokenPair, error)); ok {
-		return returnFunc(rpID, origin, nonce, credential)
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, json.RawMessage, model.LoginClient) (*model.TokenPair, error)); ok {
+		return returnFunc(rpID, origin, nonce, credential, client)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string, string, string, json.RawMessage) *model.TokenPair); ok {
-		r0 = returnFunc(rpID, origin, nonce, credential)
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, json.RawMessage, model.LoginClient) *model.TokenPair); ok {
+		r0 = returnFunc(rpID, origin, nonce, credential, client)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.TokenPair)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string, string, string, json.RawMessage) error); ok {
-		r1 = returnFunc(rpID, origin, nonce, credential)
+	if returnFunc, ok := ret.Get(1).(func(string, string, string, json.RawMessage, model.LoginClient) error); ok {
+		r1 = returnFunc(rpID, origin, nonce, credential, client)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -780,11 +792,12 @@ type MockService_PasskeyLoginFinish_Call struct {
 //   - origin string
 //   - nonce string
 //   - credential json.RawMessage
-func (_e *MockService_Expecter) PasskeyLoginFinish(rpID any, origin any, nonce any, credential any) *MockService_PasskeyLoginFinish_Call {
-	return &MockService_PasskeyLoginFinish_Call{Call: _e.mock.On("PasskeyLoginFinish", rpID, origin, nonce, credential)}
+//   - client model.LoginClient
+func (_e *MockService_Expecter) PasskeyLoginFinish(rpID any, origin any, nonce any, credential any, client any) *MockService_PasskeyLoginFinish_Call {
+	return &MockService_PasskeyLoginFinish_Call{Call: _e.mock.On("PasskeyLoginFinish", rpID, origin, nonce, credential, client)}
 }
 
-func (_c *MockService_PasskeyLoginFinish_Call) Run(run func(rpID string, origin string, nonce string, credential json.RawMessage)) *MockService_PasskeyLoginFinish_Call {
+func (_c *MockService_PasskeyLoginFinish_Call) Run(run func(rpID string, origin string, nonce string, credential json.RawMessage, client model.LoginClient)) *MockService_PasskeyLoginFinish_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
@@ -802,11 +815,16 @@ func (_c *MockService_PasskeyLoginFinish_Call) Run(run func(rpID string, origin
 		if args[3] != nil {
 			arg3 = args[3].(json.RawMessage)
 		}
+		var arg4 model.LoginClient
+		if args[4] != nil {
+			arg4 = args[4].(model.LoginClient)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
+			arg4,
 		)
 	})
 	return _c
@@ -817,7 +835,7 @@ func (_c *MockService_PasskeyLoginFinish_Call) Return(tokenPair *model.TokenPair
 	return _c
 }
 
-func (_c *MockService_PasskeyLoginFinish_Call) RunAndReturn(run func(rpID string, origin string, nonce string, credential json.RawMessage) (*model.TokenPair, error)) *MockService_PasskeyLoginFinish_Call {
+func (_c *MockService_PasskeyLoginFinish_Call) RunAndReturn(run func(rpID string, origin string, nonce string, credential json.RawMessage, client model.LoginClient) (*model.TokenPair, error)) *MockService_PasskeyLoginFinish_Call {
 	_c.Call.Return(run)
 	return _c
 }
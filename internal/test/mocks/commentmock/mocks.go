@@ -105,6 +105,63 @@ func (_c *MockService_BatchAction_Call) RunAndReturn(run func(ctx context.Contex
 	return _c
 }
 
+// ConfirmSubscription provides a mock function for the type MockService
+func (_mock *MockService) ConfirmSubscription(ctx context.Context, token string) error {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmSubscription")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_ConfirmSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmSubscription'
+type MockService_ConfirmSubscription_Call struct {
+	*mock.Call
+}
+
+// ConfirmSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockService_Expecter) ConfirmSubscription(ctx any, token any) *MockService_ConfirmSubscription_Call {
+	return &MockService_ConfirmSubscription_Call{Call: _e.mock.On("ConfirmSubscription", ctx, token)}
+}
+
+func (_c *MockService_ConfirmSubscription_Call) Run(run func(ctx context.Context, token string)) *MockService_ConfirmSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ConfirmSubscription_Call) Return(err error) *MockService_ConfirmSubscription_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_ConfirmSubscription_Call) RunAndReturn(run func(ctx context.Context, token string) error) *MockService_ConfirmSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateComment provides a mock function for the type MockService
 func (_mock *MockService) CreateComment(ctx context.Context, clientIP string, userAgent string, dto *model.CreateCommentDto) (model.CreateCommentResult, error) {
 	ret := _mock.Called(ctx, clientIP, userAgent, dto)
@@ -318,6 +375,126 @@ func (_c *MockService_DeleteComment_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// DeleteEmailSuppression provides a mock function for the type MockService
+func (_mock *MockService) DeleteEmailSuppression(ctx context.Context, email string) error {
+	ret := _mock.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEmailSuppression")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_DeleteEmailSuppression_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEmailSuppression'
+type MockService_DeleteEmailSuppression_Call struct {
+	*mock.Call
+}
+
+// DeleteEmailSuppression is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockService_Expecter) DeleteEmailSuppression(ctx any, email any) *MockService_DeleteEmailSuppression_Call {
+	return &MockService_DeleteEmailSuppression_Call{Call: _e.mock.On("DeleteEmailSuppression", ctx, email)}
+}
+
+func (_c *MockService_DeleteEmailSuppression_Call) Run(run func(ctx context.Context, email string)) *MockService_DeleteEmailSuppression_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_DeleteEmailSuppression_Call) Return(err error) *MockService_DeleteEmailSuppression_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_DeleteEmailSuppression_Call) RunAndReturn(run func(ctx context.Context, email string) error) *MockService_DeleteEmailSuppression_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebmention provides a mock function for the type MockService
+func (_mock *MockService) DeleteWebmention(ctx context.Context, echoID string, source string) error {
+	ret := _mock.Called(ctx, echoID, source)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebmention")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, echoID, source)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_DeleteWebmention_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebmention'
+type MockService_DeleteWebmention_Call struct {
+	*mock.Call
+}
+
+// DeleteWebmention is a helper method to define mock.On call
+//   - ctx context.Context
+//   - echoID string
+//   - source string
+func (_e *MockService_Expecter) DeleteWebmention(ctx any, echoID any, source any) *MockService_DeleteWebmention_Call {
+	return &MockService_DeleteWebmention_Call{Call: _e.mock.On("DeleteWebmention", ctx, echoID, source)}
+}
+
+func (_c *MockService_DeleteWebmention_Call) Run(run func(ctx context.Context, echoID string, source string)) *MockService_DeleteWebmention_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_DeleteWebmention_Call) Return(err error) *MockService_DeleteWebmention_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_DeleteWebmention_Call) RunAndReturn(run func(ctx context.Context, echoID string, source string) error) *MockService_DeleteWebmention_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCommentByID provides a mock function for the type MockService
 func (_mock *MockService) GetCommentByID(ctx context.Context, id string) (model.Comment, error) {
 	ret := _mock.Called(ctx, id)
@@ -516,6 +693,68 @@ func (_c *MockService_GetSystemSetting_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// ListEmailSuppressions provides a mock function for the type MockService
+func (_mock *MockService) ListEmailSuppressions(ctx context.Context) ([]model.EmailSuppression, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEmailSuppressions")
+	}
+
+	var r0 []model.EmailSuppression
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]model.EmailSuppression, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []model.EmailSuppression); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.EmailSuppression)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_ListEmailSuppressions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEmailSuppressions'
+type MockService_ListEmailSuppressions_Call struct {
+	*mock.Call
+}
+
+// ListEmailSuppressions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockService_Expecter) ListEmailSuppressions(ctx any) *MockService_ListEmailSuppressions_Call {
+	return &MockService_ListEmailSuppressions_Call{Call: _e.mock.On("ListEmailSuppressions", ctx)}
+}
+
+func (_c *MockService_ListEmailSuppressions_Call) Run(run func(ctx context.Context)) *MockService_ListEmailSuppressions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ListEmailSuppressions_Call) Return(emailSuppressions []model.EmailSuppression, err error) *MockService_ListEmailSuppressions_Call {
+	_c.Call.Return(emailSuppressions, err)
+	return _c
+}
+
+func (_c *MockService_ListEmailSuppressions_Call) RunAndReturn(run func(ctx context.Context) ([]model.EmailSuppression, error)) *MockService_ListEmailSuppressions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListPanelComments provides a mock function for the type MockService
 func (_mock *MockService) ListPanelComments(ctx context.Context, query model.ListCommentQuery) (model.PageResult[model.Comment], error) {
 	ret := _mock.Called(ctx, query)
@@ -718,44 +957,53 @@ func (_c *MockService_ListPublicComments_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
-// SendTestEmail provides a mock function for the type MockService
-func (_mock *MockService) SendTestEmail(ctx context.Context, setting model.SystemSetting) error {
-	ret := _mock.Called(ctx, setting)
+// SaveWebmention provides a mock function for the type MockService
+func (_mock *MockService) SaveWebmention(ctx context.Context, mention model.Webmention) (model.CreateCommentResult, error) {
+	ret := _mock.Called(ctx, mention)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SendTestEmail")
+		panic("no return value specified for SaveWebmention")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.SystemSetting) error); ok {
-		r0 = returnFunc(ctx, setting)
+	var r0 model.CreateCommentResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Webmention) (model.CreateCommentResult, error)); ok {
+		return returnFunc(ctx, mention)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Webmention) model.CreateCommentResult); ok {
+		r0 = returnFunc(ctx, mention)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(model.CreateCommentResult)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.Webmention) error); ok {
+		r1 = returnFunc(ctx, mention)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockService_SendTestEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendTestEmail'
-type MockService_SendTestEmail_Call struct {
+// MockService_SaveWebmention_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveWebmention'
+type MockService_SaveWebmention_Call struct {
 	*mock.Call
 }
 
-// SendTestEmail is a helper method to define mock.On call
+// SaveWebmention is a helper method to define mock.On call
 //   - ctx context.Context
-//   - setting model.SystemSetting
-func (_e *MockService_Expecter) SendTestEmail(ctx any, setting any) *MockService_SendTestEmail_Call {
-	return &MockService_SendTestEmail_Call{Call: _e.mock.On("SendTestEmail", ctx, setting)}
+//   - mention model.Webmention
+func (_e *MockService_Expecter) SaveWebmention(ctx any, mention any) *MockService_SaveWebmention_Call {
+	return &MockService_SaveWebmention_Call{Call: _e.mock.On("SaveWebmention", ctx, mention)}
 }
 
-func (_c *MockService_SendTestEmail_Call) Run(run func(ctx context.Context, setting model.SystemSetting)) *MockService_SendTestEmail_Call {
+func (_c *MockService_SaveWebmention_Call) Run(run func(ctx context.Context, mention model.Webmention)) *MockService_SaveWebmention_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 model.SystemSetting
+		var arg1 model.Webmention
 		if args[1] != nil {
-			arg1 = args[1].(model.SystemSetting)
+			arg1 = args[1].(model.Webmention)
 		}
 		run(
 			arg0,
@@ -765,107 +1013,287 @@ func (_c *MockService_SendTestEmail_Call) Run(run func(ctx context.Context, sett
 	return _c
 }
 
-func (_c *MockService_SendTestEmail_Call) Return(err error) *MockService_SendTestEmail_Call {
-	_c.Call.Return(err)
+func (_c *MockService_SaveWebmention_Call) Return(createCommentResult model.CreateCommentResult, err error) *MockService_SaveWebmention_Call {
+	_c.Call.Return(createCommentResult, err)
 	return _c
 }
 
-func (_c *MockService_SendTestEmail_Call) RunAndReturn(run func(ctx context.Context, setting model.SystemSetting) error) *MockService_SendTestEmail_Call {
+func (_c *MockService_SaveWebmention_Call) RunAndReturn(run func(ctx context.Context, mention model.Webmention) (model.CreateCommentResult, error)) *MockService_SaveWebmention_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateCommentHot provides a mock function for the type MockService
-func (_mock *MockService) UpdateCommentHot(ctx context.Context, id string, hot bool) error {
-	ret := _mock.Called(ctx, id, hot)
+// SendOwnerMail provides a mock function for the type MockService
+func (_mock *MockService) SendOwnerMail(ctx context.Context, mail service.OwnerMail) error {
+	ret := _mock.Called(ctx, mail)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateCommentHot")
+		panic("no return value specified for SendOwnerMail")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
-		r0 = returnFunc(ctx, id, hot)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, service.OwnerMail) error); ok {
+		r0 = returnFunc(ctx, mail)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockService_UpdateCommentHot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCommentHot'
-type MockService_UpdateCommentHot_Call struct {
+// MockService_SendOwnerMail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendOwnerMail'
+type MockService_SendOwnerMail_Call struct {
 	*mock.Call
 }
 
-// UpdateCommentHot is a helper method to define mock.On call
+// SendOwnerMail is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-//   - hot bool
-func (_e *MockService_Expecter) UpdateCommentHot(ctx any, id any, hot any) *MockService_UpdateCommentHot_Call {
-	return &MockService_UpdateCommentHot_Call{Call: _e.mock.On("UpdateCommentHot", ctx, id, hot)}
+//   - mail service.OwnerMail
+func (_e *MockService_Expecter) SendOwnerMail(ctx any, mail any) *MockService_SendOwnerMail_Call {
+	return &MockService_SendOwnerMail_Call{Call: _e.mock.On("SendOwnerMail", ctx, mail)}
 }
 
-func (_c *MockService_UpdateCommentHot_Call) Run(run func(ctx context.Context, id string, hot bool)) *MockService_UpdateCommentHot_Call {
+func (_c *MockService_SendOwnerMail_Call) Run(run func(ctx context.Context, mail service.OwnerMail)) *MockService_SendOwnerMail_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 service.OwnerMail
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 bool
-		if args[2] != nil {
-			arg2 = args[2].(bool)
+			arg1 = args[1].(service.OwnerMail)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_UpdateCommentHot_Call) Return(err error) *MockService_UpdateCommentHot_Call {
+func (_c *MockService_SendOwnerMail_Call) Return(err error) *MockService_SendOwnerMail_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockService_UpdateCommentHot_Call) RunAndReturn(run func(ctx context.Context, id string, hot bool) error) *MockService_UpdateCommentHot_Call {
+func (_c *MockService_SendOwnerMail_Call) RunAndReturn(run func(ctx context.Context, mail service.OwnerMail) error) *MockService_SendOwnerMail_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateCommentStatus provides a mock function for the type MockService
-func (_mock *MockService) UpdateCommentStatus(ctx context.Context, id string, status model.Status) error {
-	ret := _mock.Called(ctx, id, status)
+// SendTestEmail provides a mock function for the type MockService
+func (_mock *MockService) SendTestEmail(ctx context.Context, setting model.SystemSetting) error {
+	ret := _mock.Called(ctx, setting)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateCommentStatus")
+		panic("no return value specified for SendTestEmail")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.Status) error); ok {
-		r0 = returnFunc(ctx, id, status)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.SystemSetting) error); ok {
+		r0 = returnFunc(ctx, setting)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockService_UpdateCommentStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCommentStatus'
-type MockService_UpdateCommentStatus_Call struct {
+// MockService_SendTestEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendTestEmail'
+type MockService_SendTestEmail_Call struct {
 	*mock.Call
 }
 
-// UpdateCommentStatus is a helper method to define mock.On call
+// SendTestEmail is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-//   - status model.Status
-func (_e *MockService_Expecter) UpdateCommentStatus(ctx any, id any, status any) *MockService_UpdateCommentStatus_Call {
-	return &MockService_UpdateCommentStatus_Call{Call: _e.mock.On("UpdateCommentStatus", ctx, id, status)}
+//   - setting model.SystemSetting
+func (_e *MockService_Expecter) SendTestEmail(ctx any, setting any) *MockService_SendTestEmail_Call {
+	return &MockService_SendTestEmail_Call{Call: _e.mock.On("SendTestEmail", ctx, setting)}
+}
+
+func (_c *MockService_SendTestEmail_Call) Run(run func(ctx context.Context, setting model.SystemSetting)) *MockService_SendTestEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.SystemSetting
+		if args[1] != nil {
+			arg1 = args[1].(model.SystemSetting)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_SendTestEmail_Call) Return(err error) *MockService_SendTestEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_SendTestEmail_Call) RunAndReturn(run func(ctx context.Context, setting model.SystemSetting) error) *MockService_SendTestEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unsubscribe provides a mock function for the type MockService
+func (_mock *MockService) Unsubscribe(ctx context.Context, token string) (model.UnsubscribeScope, error) {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unsubscribe")
+	}
+
+	var r0 model.UnsubscribeScope
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (model.UnsubscribeScope, error)); ok {
+		return returnFunc(ctx, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) model.UnsubscribeScope); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Get(0).(model.UnsubscribeScope)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_Unsubscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unsubscribe'
+type MockService_Unsubscribe_Call struct {
+	*mock.Call
+}
+
+// Unsubscribe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockService_Expecter) Unsubscribe(ctx any, token any) *MockService_Unsubscribe_Call {
+	return &MockService_Unsubscribe_Call{Call: _e.mock.On("Unsubscribe", ctx, token)}
+}
+
+func (_c *MockService_Unsubscribe_Call) Run(run func(ctx context.Context, token string)) *MockService_Unsubscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_Unsubscribe_Call) Return(unsubscribeScope model.UnsubscribeScope, err error) *MockService_Unsubscribe_Call {
+	_c.Call.Return(unsubscribeScope, err)
+	return _c
+}
+
+func (_c *MockService_Unsubscribe_Call) RunAndReturn(run func(ctx context.Context, token string) (model.UnsubscribeScope, error)) *MockService_Unsubscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCommentHot provides a mock function for the type MockService
+func (_mock *MockService) UpdateCommentHot(ctx context.Context, id string, hot bool) error {
+	ret := _mock.Called(ctx, id, hot)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCommentHot")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = returnFunc(ctx, id, hot)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_UpdateCommentHot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCommentHot'
+type MockService_UpdateCommentHot_Call struct {
+	*mock.Call
+}
+
+// UpdateCommentHot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - hot bool
+func (_e *MockService_Expecter) UpdateCommentHot(ctx any, id any, hot any) *MockService_UpdateCommentHot_Call {
+	return &MockService_UpdateCommentHot_Call{Call: _e.mock.On("UpdateCommentHot", ctx, id, hot)}
+}
+
+func (_c *MockService_UpdateCommentHot_Call) Run(run func(ctx context.Context, id string, hot bool)) *MockService_UpdateCommentHot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_UpdateCommentHot_Call) Return(err error) *MockService_UpdateCommentHot_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_UpdateCommentHot_Call) RunAndReturn(run func(ctx context.Context, id string, hot bool) error) *MockService_UpdateCommentHot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCommentStatus provides a mock function for the type MockService
+func (_mock *MockService) UpdateCommentStatus(ctx context.Context, id string, status model.Status) error {
+	ret := _mock.Called(ctx, id, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCommentStatus")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.Status) error); ok {
+		r0 = returnFunc(ctx, id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_UpdateCommentStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCommentStatus'
+type MockService_UpdateCommentStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateCommentStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - status model.Status
+func (_e *MockService_Expecter) UpdateCommentStatus(ctx any, id any, status any) *MockService_UpdateCommentStatus_Call {
+	return &MockService_UpdateCommentStatus_Call{Call: _e.mock.On("UpdateCommentStatus", ctx, id, status)}
 }
 
 func (_c *MockService_UpdateCommentStatus_Call) Run(run func(ctx context.Context, id string, status model.Status)) *MockService_UpdateCommentStatus_Call {
@@ -985,6 +1413,63 @@ func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
 	return &MockRepository_Expecter{mock: &_m.Mock}
 }
 
+// AddEmailSuppression provides a mock function for the type MockRepository
+func (_mock *MockRepository) AddEmailSuppression(ctx context.Context, email string) error {
+	ret := _mock.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddEmailSuppression")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_AddEmailSuppression_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddEmailSuppression'
+type MockRepository_AddEmailSuppression_Call struct {
+	*mock.Call
+}
+
+// AddEmailSuppression is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockRepository_Expecter) AddEmailSuppression(ctx any, email any) *MockRepository_AddEmailSuppression_Call {
+	return &MockRepository_AddEmailSuppression_Call{Call: _e.mock.On("AddEmailSuppression", ctx, email)}
+}
+
+func (_c *MockRepository_AddEmailSuppression_Call) Run(run func(ctx context.Context, email string)) *MockRepository_AddEmailSuppression_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_AddEmailSuppression_Call) Return(err error) *MockRepository_AddEmailSuppression_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_AddEmailSuppression_Call) RunAndReturn(run func(ctx context.Context, email string) error) *MockRepository_AddEmailSuppression_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // BatchDelete provides a mock function for the type MockRepository
 func (_mock *MockRepository) BatchDelete(ctx context.Context, ids []string) error {
 	ret := _mock.Called(ctx, ids)
@@ -1105,46 +1590,37 @@ func (_c *MockRepository_BatchUpdateStatus_Call) RunAndReturn(run func(ctx conte
 	return _c
 }
 
-// CountByEmailWithin provides a mock function for the type MockRepository
-func (_mock *MockRepository) CountByEmailWithin(ctx context.Context, email string, seconds int64) (int64, error) {
-	ret := _mock.Called(ctx, email, seconds)
+// ConfirmCommentSubscription provides a mock function for the type MockRepository
+func (_mock *MockRepository) ConfirmCommentSubscription(ctx context.Context, id string, confirmedAt int64) error {
+	ret := _mock.Called(ctx, id, confirmedAt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CountByEmailWithin")
+		panic("no return value specified for ConfirmCommentSubscription")
 	}
 
-	var r0 int64
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) (int64, error)); ok {
-		return returnFunc(ctx, email, seconds)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) int64); ok {
-		r0 = returnFunc(ctx, email, seconds)
-	} else {
-		r0 = ret.Get(0).(int64)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
-		r1 = returnFunc(ctx, email, seconds)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = returnFunc(ctx, id, confirmedAt)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockRepository_CountByEmailWithin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByEmailWithin'
-type MockRepository_CountByEmailWithin_Call struct {
+// MockRepository_ConfirmCommentSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmCommentSubscription'
+type MockRepository_ConfirmCommentSubscription_Call struct {
 	*mock.Call
 }
 
-// CountByEmailWithin is a helper method to define mock.On call
+// ConfirmCommentSubscription is a helper method to define mock.On call
 //   - ctx context.Context
-//   - email string
-//   - seconds int64
-func (_e *MockRepository_Expecter) CountByEmailWithin(ctx any, email any, seconds any) *MockRepository_CountByEmailWithin_Call {
-	return &MockRepository_CountByEmailWithin_Call{Call: _e.mock.On("CountByEmailWithin", ctx, email, seconds)}
+//   - id string
+//   - confirmedAt int64
+func (_e *MockRepository_Expecter) ConfirmCommentSubscription(ctx any, id any, confirmedAt any) *MockRepository_ConfirmCommentSubscription_Call {
+	return &MockRepository_ConfirmCommentSubscription_Call{Call: _e.mock.On("ConfirmCommentSubscription", ctx, id, confirmedAt)}
 }
 
-func (_c *MockRepository_CountByEmailWithin_Call) Run(run func(ctx context.Context, email string, seconds int64)) *MockRepository_CountByEmailWithin_Call {
+func (_c *MockRepository_ConfirmCommentSubscription_Call) Run(run func(ctx context.Context, id string, confirmedAt int64)) *MockRepository_ConfirmCommentSubscription_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1167,28 +1643,100 @@ func (_c *MockRepository_CountByEmailWithin_Call) Run(run func(ctx context.Conte
 	return _c
 }
 
-func (_c *MockRepository_CountByEmailWithin_Call) Return(n int64, err error) *MockRepository_CountByEmailWithin_Call {
-	_c.Call.Return(n, err)
+func (_c *MockRepository_ConfirmCommentSubscription_Call) Return(err error) *MockRepository_ConfirmCommentSubscription_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_CountByEmailWithin_Call) RunAndReturn(run func(ctx context.Context, email string, seconds int64) (int64, error)) *MockRepository_CountByEmailWithin_Call {
+func (_c *MockRepository_ConfirmCommentSubscription_Call) RunAndReturn(run func(ctx context.Context, id string, confirmedAt int64) error) *MockRepository_ConfirmCommentSubscription_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CountByIPWithin provides a mock function for the type MockRepository
-func (_mock *MockRepository) CountByIPWithin(ctx context.Context, ipHash string, seconds int64) (int64, error) {
-	ret := _mock.Called(ctx, ipHash, seconds)
+// CountByEmailWithin provides a mock function for the type MockRepository
+func (_mock *MockRepository) CountByEmailWithin(ctx context.Context, email string, seconds int64) (int64, error) {
+	ret := _mock.Called(ctx, email, seconds)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CountByIPWithin")
+		panic("no return value specified for CountByEmailWithin")
 	}
 
 	var r0 int64
 	var r1 error
 	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) (int64, error)); ok {
-		return returnFunc(ctx, ipHash, seconds)
+		return returnFunc(ctx, email, seconds)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) int64); ok {
+		r0 = returnFunc(ctx, email, seconds)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = returnFunc(ctx, email, seconds)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_CountByEmailWithin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByEmailWithin'
+type MockRepository_CountByEmailWithin_Call struct {
+	*mock.Call
+}
+
+// CountByEmailWithin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - seconds int64
+func (_e *MockRepository_Expecter) CountByEmailWithin(ctx any, email any, seconds any) *MockRepository_CountByEmailWithin_Call {
+	return &MockRepository_CountByEmailWithin_Call{Call: _e.mock.On("CountByEmailWithin", ctx, email, seconds)}
+}
+
+func (_c *MockRepository_CountByEmailWithin_Call) Run(run func(ctx context.Context, email string, seconds int64)) *MockRepository_CountByEmailWithin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_CountByEmailWithin_Call) Return(n int64, err error) *MockRepository_CountByEmailWithin_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepository_CountByEmailWithin_Call) RunAndReturn(run func(ctx context.Context, email string, seconds int64) (int64, error)) *MockRepository_CountByEmailWithin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountByIPWithin provides a mock function for the type MockRepository
+func (_mock *MockRepository) CountByIPWithin(ctx context.Context, ipHash string, seconds int64) (int64, error) {
+	ret := _mock.Called(ctx, ipHash, seconds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByIPWithin")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) (int64, error)); ok {
+		return returnFunc(ctx, ipHash, seconds)
 	}
 	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) int64); ok {
 		r0 = returnFunc(ctx, ipHash, seconds)
@@ -1378,36 +1926,514 @@ func (_c *MockRepository_CreateComment_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
-// DeleteComment provides a mock function for the type MockRepository
-func (_mock *MockRepository) DeleteComment(ctx context.Context, id string) error {
-	ret := _mock.Called(ctx, id)
+// DeleteComment provides a mock function for the type MockRepository
+func (_mock *MockRepository) DeleteComment(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteComment")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_DeleteComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteComment'
+type MockRepository_DeleteComment_Call struct {
+	*mock.Call
+}
+
+// DeleteComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockRepository_Expecter) DeleteComment(ctx any, id any) *MockRepository_DeleteComment_Call {
+	return &MockRepository_DeleteComment_Call{Call: _e.mock.On("DeleteComment", ctx, id)}
+}
+
+func (_c *MockRepository_DeleteComment_Call) Run(run func(ctx context.Context, id string)) *MockRepository_DeleteComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteComment_Call) Return(err error) *MockRepository_DeleteComment_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_DeleteComment_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockRepository_DeleteComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCommentSubscription provides a mock function for the type MockRepository
+func (_mock *MockRepository) DeleteCommentSubscription(ctx context.Context, echoID string, email string) error {
+	ret := _mock.Called(ctx, echoID, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCommentSubscription")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, echoID, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_DeleteCommentSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCommentSubscription'
+type MockRepository_DeleteCommentSubscription_Call struct {
+	*mock.Call
+}
+
+// DeleteCommentSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - echoID string
+//   - email string
+func (_e *MockRepository_Expecter) DeleteCommentSubscription(ctx any, echoID any, email any) *MockRepository_DeleteCommentSubscription_Call {
+	return &MockRepository_DeleteCommentSubscription_Call{Call: _e.mock.On("DeleteCommentSubscription", ctx, echoID, email)}
+}
+
+func (_c *MockRepository_DeleteCommentSubscription_Call) Run(run func(ctx context.Context, echoID string, email string)) *MockRepository_DeleteCommentSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteCommentSubscription_Call) Return(err error) *MockRepository_DeleteCommentSubscription_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_DeleteCommentSubscription_Call) RunAndReturn(run func(ctx context.Context, echoID string, email string) error) *MockRepository_DeleteCommentSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCommentSubscriptionsByEmail provides a mock function for the type MockRepository
+func (_mock *MockRepository) DeleteCommentSubscriptionsByEmail(ctx context.Context, email string) error {
+	ret := _mock.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCommentSubscriptionsByEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_DeleteCommentSubscriptionsByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCommentSubscriptionsByEmail'
+type MockRepository_DeleteCommentSubscriptionsByEmail_Call struct {
+	*mock.Call
+}
+
+// DeleteCommentSubscriptionsByEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockRepository_Expecter) DeleteCommentSubscriptionsByEmail(ctx any, email any) *MockRepository_DeleteCommentSubscriptionsByEmail_Call {
+	return &MockRepository_DeleteCommentSubscriptionsByEmail_Call{Call: _e.mock.On("DeleteCommentSubscriptionsByEmail", ctx, email)}
+}
+
+func (_c *MockRepository_DeleteCommentSubscriptionsByEmail_Call) Run(run func(ctx context.Context, email string)) *MockRepository_DeleteCommentSubscriptionsByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteCommentSubscriptionsByEmail_Call) Return(err error) *MockRepository_DeleteCommentSubscriptionsByEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_DeleteCommentSubscriptionsByEmail_Call) RunAndReturn(run func(ctx context.Context, email string) error) *MockRepository_DeleteCommentSubscriptionsByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteEmailSuppression provides a mock function for the type MockRepository
+func (_mock *MockRepository) DeleteEmailSuppression(ctx context.Context, email string) error {
+	ret := _mock.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEmailSuppression")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_DeleteEmailSuppression_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEmailSuppression'
+type MockRepository_DeleteEmailSuppression_Call struct {
+	*mock.Call
+}
+
+// DeleteEmailSuppression is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockRepository_Expecter) DeleteEmailSuppression(ctx any, email any) *MockRepository_DeleteEmailSuppression_Call {
+	return &MockRepository_DeleteEmailSuppression_Call{Call: _e.mock.On("DeleteEmailSuppression", ctx, email)}
+}
+
+func (_c *MockRepository_DeleteEmailSuppression_Call) Run(run func(ctx context.Context, email string)) *MockRepository_DeleteEmailSuppression_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteEmailSuppression_Call) Return(err error) *MockRepository_DeleteEmailSuppression_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_DeleteEmailSuppression_Call) RunAndReturn(run func(ctx context.Context, email string) error) *MockRepository_DeleteEmailSuppression_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExistsRecentDuplicate provides a mock function for the type MockRepository
+func (_mock *MockRepository) ExistsRecentDuplicate(ctx context.Context, echoID string, content string, email string, ipHash string, userID string, seconds int64) (bool, error) {
+	ret := _mock.Called(ctx, echoID, content, email, ipHash, userID, seconds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExistsRecentDuplicate")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, int64) (bool, error)); ok {
+		return returnFunc(ctx, echoID, content, email, ipHash, userID, seconds)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, int64) bool); ok {
+		r0 = returnFunc(ctx, echoID, content, email, ipHash, userID, seconds)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string, string, int64) error); ok {
+		r1 = returnFunc(ctx, echoID, content, email, ipHash, userID, seconds)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_ExistsRecentDuplicate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExistsRecentDuplicate'
+type MockRepository_ExistsRecentDuplicate_Call struct {
+	*mock.Call
+}
+
+// ExistsRecentDuplicate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - echoID string
+//   - content string
+//   - email string
+//   - ipHash string
+//   - userID string
+//   - seconds int64
+func (_e *MockRepository_Expecter) ExistsRecentDuplicate(ctx any, echoID any, content any, email any, ipHash any, userID any, seconds any) *MockRepository_ExistsRecentDuplicate_Call {
+	return &MockRepository_ExistsRecentDuplicate_Call{Call: _e.mock.On("ExistsRecentDuplicate", ctx, echoID, content, email, ipHash, userID, seconds)}
+}
+
+func (_c *MockRepository_ExistsRecentDuplicate_Call) Run(run func(ctx context.Context, echoID string, content string, email string, ipHash string, userID string, seconds int64)) *MockRepository_ExistsRecentDuplicate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
+		var arg6 int64
+		if args[6] != nil {
+			arg6 = args[6].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+			arg6,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_ExistsRecentDuplicate_Call) Return(b bool, err error) *MockRepository_ExistsRecentDuplicate_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockRepository_ExistsRecentDuplicate_Call) RunAndReturn(run func(ctx context.Context, echoID string, content string, email string, ipHash string, userID string, seconds int64) (bool, error)) *MockRepository_ExistsRecentDuplicate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCommentByID provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetCommentByID(ctx context.Context, id string) (model.Comment, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommentByID")
+	}
+
+	var r0 model.Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (model.Comment, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) model.Comment); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(model.Comment)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_GetCommentByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCommentByID'
+type MockRepository_GetCommentByID_Call struct {
+	*mock.Call
+}
+
+// GetCommentByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockRepository_Expecter) GetCommentByID(ctx any, id any) *MockRepository_GetCommentByID_Call {
+	return &MockRepository_GetCommentByID_Call{Call: _e.mock.On("GetCommentByID", ctx, id)}
+}
+
+func (_c *MockRepository_GetCommentByID_Call) Run(run func(ctx context.Context, id string)) *MockRepository_GetCommentByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetCommentByID_Call) Return(comment model.Comment, err error) *MockRepository_GetCommentByID_Call {
+	_c.Call.Return(comment, err)
+	return _c
+}
+
+func (_c *MockRepository_GetCommentByID_Call) RunAndReturn(run func(ctx context.Context, id string) (model.Comment, error)) *MockRepository_GetCommentByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCommentSubscription provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetCommentSubscription(ctx context.Context, echoID string, email string) (model.CommentSubscription, error) {
+	ret := _mock.Called(ctx, echoID, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommentSubscription")
+	}
+
+	var r0 model.CommentSubscription
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (model.CommentSubscription, error)); ok {
+		return returnFunc(ctx, echoID, email)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) model.CommentSubscription); ok {
+		r0 = returnFunc(ctx, echoID, email)
+	} else {
+		r0 = ret.Get(0).(model.CommentSubscription)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, echoID, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_GetCommentSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCommentSubscription'
+type MockRepository_GetCommentSubscription_Call struct {
+	*mock.Call
+}
+
+// GetCommentSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - echoID string
+//   - email string
+func (_e *MockRepository_Expecter) GetCommentSubscription(ctx any, echoID any, email any) *MockRepository_GetCommentSubscription_Call {
+	return &MockRepository_GetCommentSubscription_Call{Call: _e.mock.On("GetCommentSubscription", ctx, echoID, email)}
+}
+
+func (_c *MockRepository_GetCommentSubscription_Call) Run(run func(ctx context.Context, echoID string, email string)) *MockRepository_GetCommentSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetCommentSubscription_Call) Return(commentSubscription model.CommentSubscription, err error) *MockRepository_GetCommentSubscription_Call {
+	_c.Call.Return(commentSubscription, err)
+	return _c
+}
+
+func (_c *MockRepository_GetCommentSubscription_Call) RunAndReturn(run func(ctx context.Context, echoID string, email string) (model.CommentSubscription, error)) *MockRepository_GetCommentSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebmention provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetWebmention(ctx context.Context, echoID string, source string) (model.Comment, error) {
+	ret := _mock.Called(ctx, echoID, source)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteComment")
+		panic("no return value specified for GetWebmention")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, id)
+	var r0 model.Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (model.Comment, error)); ok {
+		return returnFunc(ctx, echoID, source)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) model.Comment); ok {
+		r0 = returnFunc(ctx, echoID, source)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(model.Comment)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, echoID, source)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockRepository_DeleteComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteComment'
-type MockRepository_DeleteComment_Call struct {
+// MockRepository_GetWebmention_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebmention'
+type MockRepository_GetWebmention_Call struct {
 	*mock.Call
 }
 
-// DeleteComment is a helper method to define mock.On call
+// GetWebmention is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockRepository_Expecter) DeleteComment(ctx any, id any) *MockRepository_DeleteComment_Call {
-	return &MockRepository_DeleteComment_Call{Call: _e.mock.On("DeleteComment", ctx, id)}
+//   - echoID string
+//   - source string
+func (_e *MockRepository_Expecter) GetWebmention(ctx any, echoID any, source any) *MockRepository_GetWebmention_Call {
+	return &MockRepository_GetWebmention_Call{Call: _e.mock.On("GetWebmention", ctx, echoID, source)}
 }
 
-func (_c *MockRepository_DeleteComment_Call) Run(run func(ctx context.Context, id string)) *MockRepository_DeleteComment_Call {
+func (_c *MockRepository_GetWebmention_Call) Run(run func(ctx context.Context, echoID string, source string)) *MockRepository_GetWebmention_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1417,68 +2443,68 @@ func (_c *MockRepository_DeleteComment_Call) Run(run func(ctx context.Context, i
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepository_DeleteComment_Call) Return(err error) *MockRepository_DeleteComment_Call {
-	_c.Call.Return(err)
+func (_c *MockRepository_GetWebmention_Call) Return(comment model.Comment, err error) *MockRepository_GetWebmention_Call {
+	_c.Call.Return(comment, err)
 	return _c
 }
 
-func (_c *MockRepository_DeleteComment_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockRepository_DeleteComment_Call {
+func (_c *MockRepository_GetWebmention_Call) RunAndReturn(run func(ctx context.Context, echoID string, source string) (model.Comment, error)) *MockRepository_GetWebmention_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ExistsRecentDuplicate provides a mock function for the type MockRepository
-func (_mock *MockRepository) ExistsRecentDuplicate(ctx context.Context, echoID string, content string, email string, ipHash string, userID string, seconds int64) (bool, error) {
-	ret := _mock.Called(ctx, echoID, content, email, ipHash, userID, seconds)
+// IsEmailSuppressed provides a mock function for the type MockRepository
+func (_mock *MockRepository) IsEmailSuppressed(ctx context.Context, email string) (bool, error) {
+	ret := _mock.Called(ctx, email)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ExistsRecentDuplicate")
+		panic("no return value specified for IsEmailSuppressed")
 	}
 
 	var r0 bool
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, int64) (bool, error)); ok {
-		return returnFunc(ctx, echoID, content, email, ipHash, userID, seconds)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, email)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, int64) bool); ok {
-		r0 = returnFunc(ctx, echoID, content, email, ipHash, userID, seconds)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, email)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string, string, int64) error); ok {
-		r1 = returnFunc(ctx, echoID, content, email, ipHash, userID, seconds)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, email)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepository_ExistsRecentDuplicate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExistsRecentDuplicate'
-type MockRepository_ExistsRecentDuplicate_Call struct {
+// MockRepository_IsEmailSuppressed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsEmailSuppressed'
+type MockRepository_IsEmailSuppressed_Call struct {
 	*mock.Call
 }
 
-// ExistsRecentDuplicate is a helper method to define mock.On call
+// IsEmailSuppressed is a helper method to define mock.On call
 //   - ctx context.Context
-//   - echoID string
-//   - content string
 //   - email string
-//   - ipHash string
-//   - userID string
-//   - seconds int64
-func (_e *MockRepository_Expecter) ExistsRecentDuplicate(ctx any, echoID any, content any, email any, ipHash any, userID any, seconds any) *MockRepository_ExistsRecentDuplicate_Call {
-	return &MockRepository_ExistsRecentDuplicate_Call{Call: _e.mock.On("ExistsRecentDuplicate", ctx, echoID, content, email, ipHash, userID, seconds)}
+func (_e *MockRepository_Expecter) IsEmailSuppressed(ctx any, email any) *MockRepository_IsEmailSuppressed_Call {
+	return &MockRepository_IsEmailSuppressed_Call{Call: _e.mock.On("IsEmailSuppressed", ctx, email)}
 }
 
-func (_c *MockRepository_ExistsRecentDuplicate_Call) Run(run func(ctx context.Context, echoID string, content string, email string, ipHash string, userID string, seconds int64)) *MockRepository_ExistsRecentDuplicate_Call {
+func (_c *MockRepository_IsEmailSuppressed_Call) Run(run func(ctx context.Context, email string)) *MockRepository_IsEmailSuppressed_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1488,88 +2514,65 @@ func (_c *MockRepository_ExistsRecentDuplicate_Call) Run(run func(ctx context.Co
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
-		var arg4 string
-		if args[4] != nil {
-			arg4 = args[4].(string)
-		}
-		var arg5 string
-		if args[5] != nil {
-			arg5 = args[5].(string)
-		}
-		var arg6 int64
-		if args[6] != nil {
-			arg6 = args[6].(int64)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
-			arg4,
-			arg5,
-			arg6,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepository_ExistsRecentDuplicate_Call) Return(b bool, err error) *MockRepository_ExistsRecentDuplicate_Call {
+func (_c *MockRepository_IsEmailSuppressed_Call) Return(b bool, err error) *MockRepository_IsEmailSuppressed_Call {
 	_c.Call.Return(b, err)
 	return _c
 }
 
-func (_c *MockRepository_ExistsRecentDuplicate_Call) RunAndReturn(run func(ctx context.Context, echoID string, content string, email string, ipHash string, userID string, seconds int64) (bool, error)) *MockRepository_ExistsRecentDuplicate_Call {
+func (_c *MockRepository_IsEmailSuppressed_Call) RunAndReturn(run func(ctx context.Context, email string) (bool, error)) *MockRepository_IsEmailSuppressed_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetCommentByID provides a mock function for the type MockRepository
-func (_mock *MockRepository) GetCommentByID(ctx context.Context, id string) (model.Comment, error) {
-	ret := _mock.Called(ctx, id)
+// ListActiveSubscriberEmails provides a mock function for the type MockRepository
+func (_mock *MockRepository) ListActiveSubscriberEmails(ctx context.Context, echoID string) ([]string, error) {
+	ret := _mock.Called(ctx, echoID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetCommentByID")
+		panic("no return value specified for ListActiveSubscriberEmails")
 	}
 
-	var r0 model.Comment
+	var r0 []string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (model.Comment, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return returnFunc(ctx, echoID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) model.Comment); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = returnFunc(ctx, echoID)
 	} else {
-		r0 = ret.Get(0).(model.Comment)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+		r1 = returnFunc(ctx, echoID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepository_GetCommentByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCommentByID'
-type MockRepository_GetCommentByID_Call struct {
+// MockRepository_ListActiveSubscriberEmails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListActiveSubscriberEmails'
+type MockRepository_ListActiveSubscriberEmails_Call struct {
 	*mock.Call
 }
 
-// GetCommentByID is a helper method to define mock.On call
+// ListActiveSubscriberEmails is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockRepository_Expecter) GetCommentByID(ctx any, id any) *MockRepository_GetCommentByID_Call {
-	return &MockRepository_GetCommentByID_Call{Call: _e.mock.On("GetCommentByID", ctx, id)}
+//   - echoID string
+func (_e *MockRepository_Expecter) ListActiveSubscriberEmails(ctx any, echoID any) *MockRepository_ListActiveSubscriberEmails_Call {
+	return &MockRepository_ListActiveSubscriberEmails_Call{Call: _e.mock.On("ListActiveSubscriberEmails", ctx, echoID)}
 }
 
-func (_c *MockRepository_GetCommentByID_Call) Run(run func(ctx context.Context, id string)) *MockRepository_GetCommentByID_Call {
+func (_c *MockRepository_ListActiveSubscriberEmails_Call) Run(run func(ctx context.Context, echoID string)) *MockRepository_ListActiveSubscriberEmails_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1587,12 +2590,12 @@ func (_c *MockRepository_GetCommentByID_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockRepository_GetCommentByID_Call) Return(comment model.Comment, err error) *MockRepository_GetCommentByID_Call {
-	_c.Call.Return(comment, err)
+func (_c *MockRepository_ListActiveSubscriberEmails_Call) Return(strings []string, err error) *MockRepository_ListActiveSubscriberEmails_Call {
+	_c.Call.Return(strings, err)
 	return _c
 }
 
-func (_c *MockRepository_GetCommentByID_Call) RunAndReturn(run func(ctx context.Context, id string) (model.Comment, error)) *MockRepository_GetCommentByID_Call {
+func (_c *MockRepository_ListActiveSubscriberEmails_Call) RunAndReturn(run func(ctx context.Context, echoID string) ([]string, error)) *MockRepository_ListActiveSubscriberEmails_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1663,6 +2666,68 @@ func (_c *MockRepository_ListComments_Call) RunAndReturn(run func(ctx context.Co
 	return _c
 }
 
+// ListEmailSuppressions provides a mock function for the type MockRepository
+func (_mock *MockRepository) ListEmailSuppressions(ctx context.Context) ([]model.EmailSuppression, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEmailSuppressions")
+	}
+
+	var r0 []model.EmailSuppression
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]model.EmailSuppression, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []model.EmailSuppression); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.EmailSuppression)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_ListEmailSuppressions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEmailSuppressions'
+type MockRepository_ListEmailSuppressions_Call struct {
+	*mock.Call
+}
+
+// ListEmailSuppressions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRepository_Expecter) ListEmailSuppressions(ctx any) *MockRepository_ListEmailSuppressions_Call {
+	return &MockRepository_ListEmailSuppressions_Call{Call: _e.mock.On("ListEmailSuppressions", ctx)}
+}
+
+func (_c *MockRepository_ListEmailSuppressions_Call) Run(run func(ctx context.Context)) *MockRepository_ListEmailSuppressions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListEmailSuppressions_Call) Return(emailSuppressions []model.EmailSuppression, err error) *MockRepository_ListEmailSuppressions_Call {
+	_c.Call.Return(emailSuppressions, err)
+	return _c
+}
+
+func (_c *MockRepository_ListEmailSuppressions_Call) RunAndReturn(run func(ctx context.Context) ([]model.EmailSuppression, error)) *MockRepository_ListEmailSuppressions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListPublicByEchoID provides a mock function for the type MockRepository
 func (_mock *MockRepository) ListPublicByEchoID(ctx context.Context, echoID string) ([]model.Comment, error) {
 	ret := _mock.Called(ctx, echoID)
@@ -1799,6 +2864,63 @@ func (_c *MockRepository_ListPublicComments_Call) RunAndReturn(run func(ctx cont
 	return _c
 }
 
+// SaveCommentSubscription provides a mock function for the type MockRepository
+func (_mock *MockRepository) SaveCommentSubscription(ctx context.Context, sub *model.CommentSubscription) error {
+	ret := _mock.Called(ctx, sub)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveCommentSubscription")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.CommentSubscription) error); ok {
+		r0 = returnFunc(ctx, sub)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_SaveCommentSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveCommentSubscription'
+type MockRepository_SaveCommentSubscription_Call struct {
+	*mock.Call
+}
+
+// SaveCommentSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sub *model.CommentSubscription
+func (_e *MockRepository_Expecter) SaveCommentSubscription(ctx any, sub any) *MockRepository_SaveCommentSubscription_Call {
+	return &MockRepository_SaveCommentSubscription_Call{Call: _e.mock.On("SaveCommentSubscription", ctx, sub)}
+}
+
+func (_c *MockRepository_SaveCommentSubscription_Call) Run(run func(ctx context.Context, sub *model.CommentSubscription)) *MockRepository_SaveCommentSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.CommentSubscription
+		if args[1] != nil {
+			arg1 = args[1].(*model.CommentSubscription)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_SaveCommentSubscription_Call) Return(err error) *MockRepository_SaveCommentSubscription_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_SaveCommentSubscription_Call) RunAndReturn(run func(ctx context.Context, sub *model.CommentSubscription) error) *MockRepository_SaveCommentSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateCommentHot provides a mock function for the type MockRepository
 func (_mock *MockRepository) UpdateCommentHot(ctx context.Context, id string, hot bool) error {
 	ret := _mock.Called(ctx, id, hot)
@@ -1925,6 +3047,81 @@ func (_c *MockRepository_UpdateCommentStatus_Call) RunAndReturn(run func(ctx con
 	return _c
 }
 
+// UpdateWebmention provides a mock function for the type MockRepository
+func (_mock *MockRepository) UpdateWebmention(ctx context.Context, id string, nickname string, content string, status model.Status) error {
+	ret := _mock.Called(ctx, id, nickname, content, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebmention")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, model.Status) error); ok {
+		r0 = returnFunc(ctx, id, nickname, content, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_UpdateWebmention_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWebmention'
+type MockRepository_UpdateWebmention_Call struct {
+	*mock.Call
+}
+
+// UpdateWebmention is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - nickname string
+//   - content string
+//   - status model.Status
+func (_e *MockRepository_Expecter) UpdateWebmention(ctx any, id any, nickname any, content any, status any) *MockRepository_UpdateWebmention_Call {
+	return &MockRepository_UpdateWebmention_Call{Call: _e.mock.On("UpdateWebmention", ctx, id, nickname, content, status)}
+}
+
+func (_c *MockRepository_UpdateWebmention_Call) Run(run func(ctx context.Context, id string, nickname string, content string, status model.Status)) *MockRepository_UpdateWebmention_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 model.Status
+		if args[4] != nil {
+			arg4 = args[4].(model.Status)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpdateWebmention_Call) Return(err error) *MockRepository_UpdateWebmention_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_UpdateWebmention_Call) RunAndReturn(run func(ctx context.Context, id string, nickname string, content string, status model.Status) error) *MockRepository_UpdateWebmention_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockMailer creates a new instance of MockMailer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockMailer(t interface {
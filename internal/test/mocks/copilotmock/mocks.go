@@ -11,6 +11,8 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/lin-snow/ech0/internal/model/echo"
+	model0 "github.com/lin-snow/ech0/internal/model/user"
 	"github.com/lin-snow/ech0/internal/service/copilot"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -255,6 +257,69 @@ func (_c *MockChatService_ClearSession_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// DecideAction provides a mock function for the type MockChatService
+func (_mock *MockChatService) DecideAction(ctx context.Context, id string, approve bool) error {
+	ret := _mock.Called(ctx, id, approve)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecideAction")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = returnFunc(ctx, id, approve)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockChatService_DecideAction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DecideAction'
+type MockChatService_DecideAction_Call struct {
+	*mock.Call
+}
+
+// DecideAction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - approve bool
+func (_e *MockChatService_Expecter) DecideAction(ctx any, id any, approve any) *MockChatService_DecideAction_Call {
+	return &MockChatService_DecideAction_Call{Call: _e.mock.On("DecideAction", ctx, id, approve)}
+}
+
+func (_c *MockChatService_DecideAction_Call) Run(run func(ctx context.Context, id string, approve bool)) *MockChatService_DecideAction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChatService_DecideAction_Call) Return(err error) *MockChatService_DecideAction_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockChatService_DecideAction_Call) RunAndReturn(run func(ctx context.Context, id string, approve bool) error) *MockChatService_DecideAction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetSession provides a mock function for the type MockChatService
 func (_mock *MockChatService) GetSession(ctx context.Context) ([]service.ChatMessage, error) {
 	ret := _mock.Called(ctx)
@@ -316,3 +381,394 @@ func (_c *MockChatService_GetSession_Call) RunAndReturn(run func(ctx context.Con
 	_c.Call.Return(run)
 	return _c
 }
+
+// ListActions provides a mock function for the type MockChatService
+func (_mock *MockChatService) ListActions(ctx context.Context) ([]service.ActionRecord, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListActions")
+	}
+
+	var r0 []service.ActionRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]service.ActionRecord, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []service.ActionRecord); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.ActionRecord)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_ListActions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListActions'
+type MockChatService_ListActions_Call struct {
+	*mock.Call
+}
+
+// ListActions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockChatService_Expecter) ListActions(ctx any) *MockChatService_ListActions_Call {
+	return &MockChatService_ListActions_Call{Call: _e.mock.On("ListActions", ctx)}
+}
+
+func (_c *MockChatService_ListActions_Call) Run(run func(ctx context.Context)) *MockChatService_ListActions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChatService_ListActions_Call) Return(actionRecords []service.ActionRecord, err error) *MockChatService_ListActions_Call {
+	_c.Call.Return(actionRecords, err)
+	return _c
+}
+
+func (_c *MockChatService_ListActions_Call) RunAndReturn(run func(ctx context.Context) ([]service.ActionRecord, error)) *MockChatService_ListActions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSuggestionService creates a new instance of MockSuggestionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSuggestionService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSuggestionService {
+	mock := &MockSuggestionService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSuggestionService is an autogenerated mock type for the SuggestionService type
+type MockSuggestionService struct {
+	mock.Mock
+}
+
+type MockSuggestionService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSuggestionService) EXPECT() *MockSuggestionService_Expecter {
+	return &MockSuggestionService_Expecter{mock: &_m.Mock}
+}
+
+// ApplySuggestion provides a mock function for the type MockSuggestionService
+func (_mock *MockSuggestionService) ApplySuggestion(ctx context.Context, echoID string, dto service.ApplySuggestionDto) error {
+	ret := _mock.Called(ctx, echoID, dto)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplySuggestion")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, service.ApplySuggestionDto) error); ok {
+		r0 = returnFunc(ctx, echoID, dto)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSuggestionService_ApplySuggestion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplySuggestion'
+type MockSuggestionService_ApplySuggestion_Call struct {
+	*mock.Call
+}
+
+// ApplySuggestion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - echoID string
+//   - dto service.ApplySuggestionDto
+func (_e *MockSuggestionService_Expecter) ApplySuggestion(ctx any, echoID any, dto any) *MockSuggestionService_ApplySuggestion_Call {
+	return &MockSuggestionService_ApplySuggestion_Call{Call: _e.mock.On("ApplySuggestion", ctx, echoID, dto)}
+}
+
+func (_c *MockSuggestionService_ApplySuggestion_Call) Run(run func(ctx context.Context, echoID string, dto service.ApplySuggestionDto)) *MockSuggestionService_ApplySuggestion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 service.ApplySuggestionDto
+		if args[2] != nil {
+			arg2 = args[2].(service.ApplySuggestionDto)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSuggestionService_ApplySuggestion_Call) Return(err error) *MockSuggestionService_ApplySuggestion_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSuggestionService_ApplySuggestion_Call) RunAndReturn(run func(ctx context.Context, echoID string, dto service.ApplySuggestionDto) error) *MockSuggestionService_ApplySuggestion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DismissSuggestion provides a mock function for the type MockSuggestionService
+func (_mock *MockSuggestionService) DismissSuggestion(ctx context.Context, echoID string) error {
+	ret := _mock.Called(ctx, echoID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DismissSuggestion")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, echoID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSuggestionService_DismissSuggestion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DismissSuggestion'
+type MockSuggestionService_DismissSuggestion_Call struct {
+	*mock.Call
+}
+
+// DismissSuggestion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - echoID string
+func (_e *MockSuggestionService_Expecter) DismissSuggestion(ctx any, echoID any) *MockSuggestionService_DismissSuggestion_Call {
+	return &MockSuggestionService_DismissSuggestion_Call{Call: _e.mock.On("DismissSuggestion", ctx, echoID)}
+}
+
+func (_c *MockSuggestionService_DismissSuggestion_Call) Run(run func(ctx context.Context, echoID string)) *MockSuggestionService_DismissSuggestion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSuggestionService_DismissSuggestion_Call) Return(err error) *MockSuggestionService_DismissSuggestion_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSuggestionService_DismissSuggestion_Call) RunAndReturn(run func(ctx context.Context, echoID string) error) *MockSuggestionService_DismissSuggestion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Forget provides a mock function for the type MockSuggestionService
+func (_mock *MockSuggestionService) Forget(ctx context.Context, echoID string) error {
+	ret := _mock.Called(ctx, echoID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Forget")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, echoID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSuggestionService_Forget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Forget'
+type MockSuggestionService_Forget_Call struct {
+	*mock.Call
+}
+
+// Forget is a helper method to define mock.On call
+//   - ctx context.Context
+//   - echoID string
+func (_e *MockSuggestionService_Expecter) Forget(ctx any, echoID any) *MockSuggestionService_Forget_Call {
+	return &MockSuggestionService_Forget_Call{Call: _e.mock.On("Forget", ctx, echoID)}
+}
+
+func (_c *MockSuggestionService_Forget_Call) Run(run func(ctx context.Context, echoID string)) *MockSuggestionService_Forget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSuggestionService_Forget_Call) Return(err error) *MockSuggestionService_Forget_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSuggestionService_Forget_Call) RunAndReturn(run func(ctx context.Context, echoID string) error) *MockSuggestionService_Forget_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSuggestions provides a mock function for the type MockSuggestionService
+func (_mock *MockSuggestionService) ListSuggestions(ctx context.Context) ([]service.Suggestion, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSuggestions")
+	}
+
+	var r0 []service.Suggestion
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]service.Suggestion, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []service.Suggestion); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.Suggestion)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSuggestionService_ListSuggestions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSuggestions'
+type MockSuggestionService_ListSuggestions_Call struct {
+	*mock.Call
+}
+
+// ListSuggestions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSuggestionService_Expecter) ListSuggestions(ctx any) *MockSuggestionService_ListSuggestions_Call {
+	return &MockSuggestionService_ListSuggestions_Call{Call: _e.mock.On("ListSuggestions", ctx)}
+}
+
+func (_c *MockSuggestionService_ListSuggestions_Call) Run(run func(ctx context.Context)) *MockSuggestionService_ListSuggestions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSuggestionService_ListSuggestions_Call) Return(suggestions []service.Suggestion, err error) *MockSuggestionService_ListSuggestions_Call {
+	_c.Call.Return(suggestions, err)
+	return _c
+}
+
+func (_c *MockSuggestionService_ListSuggestions_Call) RunAndReturn(run func(ctx context.Context) ([]service.Suggestion, error)) *MockSuggestionService_ListSuggestions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SuggestForEcho provides a mock function for the type MockSuggestionService
+func (_mock *MockSuggestionService) SuggestForEcho(ctx context.Context, echo model.Echo, user model0.User) error {
+	ret := _mock.Called(ctx, echo, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SuggestForEcho")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.Echo, model0.User) error); ok {
+		r0 = returnFunc(ctx, echo, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSuggestionService_SuggestForEcho_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SuggestForEcho'
+type MockSuggestionService_SuggestForEcho_Call struct {
+	*mock.Call
+}
+
+// SuggestForEcho is a helper method to define mock.On call
+//   - ctx context.Context
+//   - echo model.Echo
+//   - user model0.User
+func (_e *MockSuggestionService_Expecter) SuggestForEcho(ctx any, echo any, user any) *MockSuggestionService_SuggestForEcho_Call {
+	return &MockSuggestionService_SuggestForEcho_Call{Call: _e.mock.On("SuggestForEcho", ctx, echo, user)}
+}
+
+func (_c *MockSuggestionService_SuggestForEcho_Call) Run(run func(ctx context.Context, echo model.Echo, user model0.User)) *MockSuggestionService_SuggestForEcho_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.Echo
+		if args[1] != nil {
+			arg1 = args[1].(model.Echo)
+		}
+		var arg2 model0.User
+		if args[2] != nil {
+			arg2 = args[2].(model0.User)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSuggestionService_SuggestForEcho_Call) Return(err error) *MockSuggestionService_SuggestForEcho_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSuggestionService_SuggestForEcho_Call) RunAndReturn(run func(ctx context.Context, echo model.Echo, user model0.User) error) *MockSuggestionService_SuggestForEcho_Call {
+	_c.Call.Return(run)
+	return _c
+}
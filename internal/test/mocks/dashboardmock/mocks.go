@@ -8,14 +8,262 @@
 package dashboardmock
 
 import (
+	"context"
 	"net/http"
 
+	"github.com/lin-snow/ech0/internal/model/setting"
 	"github.com/lin-snow/ech0/internal/service/dashboard"
 	"github.com/lin-snow/ech0/internal/visitor"
 	"github.com/lin-snow/ech0/pkg/log"
 	mock "github.com/stretchr/testify/mock"
 )
 
+// NewMockDigestService creates a new instance of MockDigestService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDigestService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDigestService {
+	mock := &MockDigestService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDigestService is an autogenerated mock type for the DigestService type
+type MockDigestService struct {
+	mock.Mock
+}
+
+type MockDigestService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDigestService) EXPECT() *MockDigestService_Expecter {
+	return &MockDigestService_Expecter{mock: &_m.Mock}
+}
+
+// GetDigestSetting provides a mock function for the type MockDigestService
+func (_mock *MockDigestService) GetDigestSetting(ctx context.Context) (model.DigestSetting, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDigestSetting")
+	}
+
+	var r0 model.DigestSetting
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (model.DigestSetting, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) model.DigestSetting); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(model.DigestSetting)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDigestService_GetDigestSetting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDigestSetting'
+type MockDigestService_GetDigestSetting_Call struct {
+	*mock.Call
+}
+
+// GetDigestSetting is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDigestService_Expecter) GetDigestSetting(ctx any) *MockDigestService_GetDigestSetting_Call {
+	return &MockDigestService_GetDigestSetting_Call{Call: _e.mock.On("GetDigestSetting", ctx)}
+}
+
+func (_c *MockDigestService_GetDigestSetting_Call) Run(run func(ctx context.Context)) *MockDigestService_GetDigestSetting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDigestService_GetDigestSetting_Call) Return(digestSetting model.DigestSetting, err error) *MockDigestService_GetDigestSetting_Call {
+	_c.Call.Return(digestSetting, err)
+	return _c
+}
+
+func (_c *MockDigestService_GetDigestSetting_Call) RunAndReturn(run func(ctx context.Context) (model.DigestSetting, error)) *MockDigestService_GetDigestSetting_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendDigest provides a mock function for the type MockDigestService
+func (_mock *MockDigestService) SendDigest(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendDigest")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDigestService_SendDigest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendDigest'
+type MockDigestService_SendDigest_Call struct {
+	*mock.Call
+}
+
+// SendDigest is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDigestService_Expecter) SendDigest(ctx any) *MockDigestService_SendDigest_Call {
+	return &MockDigestService_SendDigest_Call{Call: _e.mock.On("SendDigest", ctx)}
+}
+
+func (_c *MockDigestService_SendDigest_Call) Run(run func(ctx context.Context)) *MockDigestService_SendDigest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDigestService_SendDigest_Call) Return(err error) *MockDigestService_SendDigest_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDigestService_SendDigest_Call) RunAndReturn(run func(ctx context.Context) error) *MockDigestService_SendDigest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendScheduledDigest provides a mock function for the type MockDigestService
+func (_mock *MockDigestService) SendScheduledDigest(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendScheduledDigest")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDigestService_SendScheduledDigest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendScheduledDigest'
+type MockDigestService_SendScheduledDigest_Call struct {
+	*mock.Call
+}
+
+// SendScheduledDigest is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDigestService_Expecter) SendScheduledDigest(ctx any) *MockDigestService_SendScheduledDigest_Call {
+	return &MockDigestService_SendScheduledDigest_Call{Call: _e.mock.On("SendScheduledDigest", ctx)}
+}
+
+func (_c *MockDigestService_SendScheduledDigest_Call) Run(run func(ctx context.Context)) *MockDigestService_SendScheduledDigest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDigestService_SendScheduledDigest_Call) Return(err error) *MockDigestService_SendScheduledDigest_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDigestService_SendScheduledDigest_Call) RunAndReturn(run func(ctx context.Context) error) *MockDigestService_SendScheduledDigest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateDigestSetting provides a mock function for the type MockDigestService
+func (_mock *MockDigestService) UpdateDigestSetting(ctx context.Context, dto *model.DigestSettingDto) error {
+	ret := _mock.Called(ctx, dto)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDigestSetting")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.DigestSettingDto) error); ok {
+		r0 = returnFunc(ctx, dto)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDigestService_UpdateDigestSetting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateDigestSetting'
+type MockDigestService_UpdateDigestSetting_Call struct {
+	*mock.Call
+}
+
+// UpdateDigestSetting is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dto *model.DigestSettingDto
+func (_e *MockDigestService_Expecter) UpdateDigestSetting(ctx any, dto any) *MockDigestService_UpdateDigestSetting_Call {
+	return &MockDigestService_UpdateDigestSetting_Call{Call: _e.mock.On("UpdateDigestSetting", ctx, dto)}
+}
+
+func (_c *MockDigestService_UpdateDigestSetting_Call) Run(run func(ctx context.Context, dto *model.DigestSettingDto)) *MockDigestService_UpdateDigestSetting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.DigestSettingDto
+		if args[1] != nil {
+			arg1 = args[1].(*model.DigestSettingDto)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDigestService_UpdateDigestSetting_Call) Return(err error) *MockDigestService_UpdateDigestSetting_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDigestService_UpdateDigestSetting_Call) RunAndReturn(run func(ctx context.Context, dto *model.DigestSettingDto) error) *MockDigestService_UpdateDigestSetting_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockService(t interface {
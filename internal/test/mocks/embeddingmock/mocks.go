@@ -709,6 +709,86 @@ func (_c *MockRepository_GetMeta_Call) RunAndReturn(run func(ctx context.Context
 	return _c
 }
 
+// Related provides a mock function for the type MockRepository
+func (_mock *MockRepository) Related(ctx context.Context, echoID string, k int) ([]model1.SearchResult, bool, error) {
+	ret := _mock.Called(ctx, echoID, k)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Related")
+	}
+
+	var r0 []model1.SearchResult
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]model1.SearchResult, bool, error)); ok {
+		return returnFunc(ctx, echoID, k)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []model1.SearchResult); ok {
+		r0 = returnFunc(ctx, echoID, k)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model1.SearchResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) bool); ok {
+		r1 = returnFunc(ctx, echoID, k)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, int) error); ok {
+		r2 = returnFunc(ctx, echoID, k)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockRepository_Related_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Related'
+type MockRepository_Related_Call struct {
+	*mock.Call
+}
+
+// Related is a helper method to define mock.On call
+//   - ctx context.Context
+//   - echoID string
+//   - k int
+func (_e *MockRepository_Expecter) Related(ctx any, echoID any, k any) *MockRepository_Related_Call {
+	return &MockRepository_Related_Call{Call: _e.mock.On("Related", ctx, echoID, k)}
+}
+
+func (_c *MockRepository_Related_Call) Run(run func(ctx context.Context, echoID string, k int)) *MockRepository_Related_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_Related_Call) Return(searchResults []model1.SearchResult, b bool, err error) *MockRepository_Related_Call {
+	_c.Call.Return(searchResults, b, err)
+	return _c
+}
+
+func (_c *MockRepository_Related_Call) RunAndReturn(run func(ctx context.Context, echoID string, k int) ([]model1.SearchResult, bool, error)) *MockRepository_Related_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Search provides a mock function for the type MockRepository
 func (_mock *MockRepository) Search(ctx context.Context, vector []float32, k int, authorUsername string) ([]model1.SearchResult, error) {
 	ret := _mock.Called(ctx, vector, k, authorUsername)
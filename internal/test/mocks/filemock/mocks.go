@@ -9,11 +9,13 @@ package filemock
 
 import (
 	"context"
+	"io"
 	"mime/multipart"
 
 	"github.com/gin-gonic/gin"
 	"github.com/lin-snow/ech0/internal/model/common"
 	model1 "github.com/lin-snow/ech0/internal/model/file"
+	model2 "github.com/lin-snow/ech0/internal/model/setting"
 	model0 "github.com/lin-snow/ech0/internal/model/user"
 	"github.com/lin-snow/ech0/internal/storage"
 	mock "github.com/stretchr/testify/mock"
@@ -46,6 +48,84 @@ func (_m *MockService) EXPECT() *MockService_Expecter {
 	return &MockService_Expecter{mock: &_m.Mock}
 }
 
+// AppendResumableUpload provides a mock function for the type MockService
+func (_mock *MockService) AppendResumableUpload(ctx context.Context, id string, offset int64, body io.Reader) (model1.ResumableUpload, error) {
+	ret := _mock.Called(ctx, id, offset, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AppendResumableUpload")
+	}
+
+	var r0 model1.ResumableUpload
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64, io.Reader) (model1.ResumableUpload, error)); ok {
+		return returnFunc(ctx, id, offset, body)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64, io.Reader) model1.ResumableUpload); ok {
+		r0 = returnFunc(ctx, id, offset, body)
+	} else {
+		r0 = ret.Get(0).(model1.ResumableUpload)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int64, io.Reader) error); ok {
+		r1 = returnFunc(ctx, id, offset, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_AppendResumableUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AppendResumableUpload'
+type MockService_AppendResumableUpload_Call struct {
+	*mock.Call
+}
+
+// AppendResumableUpload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - offset int64
+//   - body io.Reader
+func (_e *MockService_Expecter) AppendResumableUpload(ctx any, id any, offset any, body any) *MockService_AppendResumableUpload_Call {
+	return &MockService_AppendResumableUpload_Call{Call: _e.mock.On("AppendResumableUpload", ctx, id, offset, body)}
+}
+
+func (_c *MockService_AppendResumableUpload_Call) Run(run func(ctx context.Context, id string, offset int64, body io.Reader)) *MockService_AppendResumableUpload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 io.Reader
+		if args[3] != nil {
+			arg3 = args[3].(io.Reader)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_AppendResumableUpload_Call) Return(resumableUpload model1.ResumableUpload, err error) *MockService_AppendResumableUpload_Call {
+	_c.Call.Return(resumableUpload, err)
+	return _c
+}
+
+func (_c *MockService_AppendResumableUpload_Call) RunAndReturn(run func(ctx context.Context, id string, offset int64, body io.Reader) (model1.ResumableUpload, error)) *MockService_AppendResumableUpload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CleanupOrphanFiles provides a mock function for the type MockService
 func (_mock *MockService) CleanupOrphanFiles() error {
 	ret := _mock.Called()
@@ -213,6 +293,138 @@ func (_c *MockService_CreateExternalFile_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
+// CreateResumableUpload provides a mock function for the type MockService
+func (_mock *MockService) CreateResumableUpload(ctx context.Context, in model1.ResumableUploadCreate) (model1.ResumableUpload, error) {
+	ret := _mock.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateResumableUpload")
+	}
+
+	var r0 model1.ResumableUpload
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model1.ResumableUploadCreate) (model1.ResumableUpload, error)); ok {
+		return returnFunc(ctx, in)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model1.ResumableUploadCreate) model1.ResumableUpload); ok {
+		r0 = returnFunc(ctx, in)
+	} else {
+		r0 = ret.Get(0).(model1.ResumableUpload)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model1.ResumableUploadCreate) error); ok {
+		r1 = returnFunc(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_CreateResumableUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateResumableUpload'
+type MockService_CreateResumableUpload_Call struct {
+	*mock.Call
+}
+
+// CreateResumableUpload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in model1.ResumableUploadCreate
+func (_e *MockService_Expecter) CreateResumableUpload(ctx any, in any) *MockService_CreateResumableUpload_Call {
+	return &MockService_CreateResumableUpload_Call{Call: _e.mock.On("CreateResumableUpload", ctx, in)}
+}
+
+func (_c *MockService_CreateResumableUpload_Call) Run(run func(ctx context.Context, in model1.ResumableUploadCreate)) *MockService_CreateResumableUpload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model1.ResumableUploadCreate
+		if args[1] != nil {
+			arg1 = args[1].(model1.ResumableUploadCreate)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_CreateResumableUpload_Call) Return(resumableUpload model1.ResumableUpload, err error) *MockService_CreateResumableUpload_Call {
+	_c.Call.Return(resumableUpload, err)
+	return _c
+}
+
+func (_c *MockService_CreateResumableUpload_Call) RunAndReturn(run func(ctx context.Context, in model1.ResumableUploadCreate) (model1.ResumableUpload, error)) *MockService_CreateResumableUpload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DedupeFiles provides a mock function for the type MockService
+func (_mock *MockService) DedupeFiles(ctx context.Context, onProgress func(model1.FileDedupeProgress)) (model1.FileDedupeProgress, error) {
+	ret := _mock.Called(ctx, onProgress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DedupeFiles")
+	}
+
+	var r0 model1.FileDedupeProgress
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, func(model1.FileDedupeProgress)) (model1.FileDedupeProgress, error)); ok {
+		return returnFunc(ctx, onProgress)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, func(model1.FileDedupeProgress)) model1.FileDedupeProgress); ok {
+		r0 = returnFunc(ctx, onProgress)
+	} else {
+		r0 = ret.Get(0).(model1.FileDedupeProgress)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, func(model1.FileDedupeProgress)) error); ok {
+		r1 = returnFunc(ctx, onProgress)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_DedupeFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DedupeFiles'
+type MockService_DedupeFiles_Call struct {
+	*mock.Call
+}
+
+// DedupeFiles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - onProgress func(model1.FileDedupeProgress)
+func (_e *MockService_Expecter) DedupeFiles(ctx any, onProgress any) *MockService_DedupeFiles_Call {
+	return &MockService_DedupeFiles_Call{Call: _e.mock.On("DedupeFiles", ctx, onProgress)}
+}
+
+func (_c *MockService_DedupeFiles_Call) Run(run func(ctx context.Context, onProgress func(model1.FileDedupeProgress))) *MockService_DedupeFiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 func(model1.FileDedupeProgress)
+		if args[1] != nil {
+			arg1 = args[1].(func(model1.FileDedupeProgress))
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_DedupeFiles_Call) Return(fileDedupeProgress model1.FileDedupeProgress, err error) *MockService_DedupeFiles_Call {
+	_c.Call.Return(fileDedupeProgress, err)
+	return _c
+}
+
+func (_c *MockService_DedupeFiles_Call) RunAndReturn(run func(ctx context.Context, onProgress func(model1.FileDedupeProgress)) (model1.FileDedupeProgress, error)) *MockService_DedupeFiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteFile provides a mock function for the type MockService
 func (_mock *MockService) DeleteFile(ctx context.Context, id string) error {
 	ret := _mock.Called(ctx, id)
@@ -327,6 +539,63 @@ func (_c *MockService_DeleteFileRecord_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// DeleteResumableUpload provides a mock function for the type MockService
+func (_mock *MockService) DeleteResumableUpload(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteResumableUpload")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_DeleteResumableUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteResumableUpload'
+type MockService_DeleteResumableUpload_Call struct {
+	*mock.Call
+}
+
+// DeleteResumableUpload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockService_Expecter) DeleteResumableUpload(ctx any, id any) *MockService_DeleteResumableUpload_Call {
+	return &MockService_DeleteResumableUpload_Call{Call: _e.mock.On("DeleteResumableUpload", ctx, id)}
+}
+
+func (_c *MockService_DeleteResumableUpload_Call) Run(run func(ctx context.Context, id string)) *MockService_DeleteResumableUpload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_DeleteResumableUpload_Call) Return(err error) *MockService_DeleteResumableUpload_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_DeleteResumableUpload_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockService_DeleteResumableUpload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteStoredFile provides a mock function for the type MockService
 func (_mock *MockService) DeleteStoredFile(storageType string, key string) error {
 	ret := _mock.Called(storageType, key)
@@ -584,53 +853,53 @@ func (_c *MockService_GetFilesByIDs_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
-// ListFileTree provides a mock function for the type MockService
-func (_mock *MockService) ListFileTree(ctx context.Context, query model.FileTreeQueryDto) (model.FileTreeResultDto, error) {
-	ret := _mock.Called(ctx, query)
+// GetResumableUpload provides a mock function for the type MockService
+func (_mock *MockService) GetResumableUpload(ctx context.Context, id string) (model1.ResumableUpload, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListFileTree")
+		panic("no return value specified for GetResumableUpload")
 	}
 
-	var r0 model.FileTreeResultDto
+	var r0 model1.ResumableUpload
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.FileTreeQueryDto) (model.FileTreeResultDto, error)); ok {
-		return returnFunc(ctx, query)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (model1.ResumableUpload, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.FileTreeQueryDto) model.FileTreeResultDto); ok {
-		r0 = returnFunc(ctx, query)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) model1.ResumableUpload); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		r0 = ret.Get(0).(model.FileTreeResultDto)
+		r0 = ret.Get(0).(model1.ResumableUpload)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, model.FileTreeQueryDto) error); ok {
-		r1 = returnFunc(ctx, query)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockService_ListFileTree_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFileTree'
-type MockService_ListFileTree_Call struct {
+// MockService_GetResumableUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetResumableUpload'
+type MockService_GetResumableUpload_Call struct {
 	*mock.Call
 }
 
-// ListFileTree is a helper method to define mock.On call
+// GetResumableUpload is a helper method to define mock.On call
 //   - ctx context.Context
-//   - query model.FileTreeQueryDto
-func (_e *MockService_Expecter) ListFileTree(ctx any, query any) *MockService_ListFileTree_Call {
-	return &MockService_ListFileTree_Call{Call: _e.mock.On("ListFileTree", ctx, query)}
+//   - id string
+func (_e *MockService_Expecter) GetResumableUpload(ctx any, id any) *MockService_GetResumableUpload_Call {
+	return &MockService_GetResumableUpload_Call{Call: _e.mock.On("GetResumableUpload", ctx, id)}
 }
 
-func (_c *MockService_ListFileTree_Call) Run(run func(ctx context.Context, query model.FileTreeQueryDto)) *MockService_ListFileTree_Call {
+func (_c *MockService_GetResumableUpload_Call) Run(run func(ctx context.Context, id string)) *MockService_GetResumableUpload_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 model.FileTreeQueryDto
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(model.FileTreeQueryDto)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -640,155 +909,183 @@ func (_c *MockService_ListFileTree_Call) Run(run func(ctx context.Context, query
 	return _c
 }
 
-func (_c *MockService_ListFileTree_Call) Return(fileTreeResultDto model.FileTreeResultDto, err error) *MockService_ListFileTree_Call {
-	_c.Call.Return(fileTreeResultDto, err)
+func (_c *MockService_GetResumableUpload_Call) Return(resumableUpload model1.ResumableUpload, err error) *MockService_GetResumableUpload_Call {
+	_c.Call.Return(resumableUpload, err)
 	return _c
 }
 
-func (_c *MockService_ListFileTree_Call) RunAndReturn(run func(ctx context.Context, query model.FileTreeQueryDto) (model.FileTreeResultDto, error)) *MockService_ListFileTree_Call {
+func (_c *MockService_GetResumableUpload_Call) RunAndReturn(run func(ctx context.Context, id string) (model1.ResumableUpload, error)) *MockService_GetResumableUpload_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListFiles provides a mock function for the type MockService
-func (_mock *MockService) ListFiles(ctx context.Context, query model.FileListQueryDto) (model.FileListResultDto, error) {
-	ret := _mock.Called(ctx, query)
+// GetStorageQuotaSetting provides a mock function for the type MockService
+func (_mock *MockService) GetStorageQuotaSetting(ctx context.Context) (model2.StorageQuotaSetting, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListFiles")
+		panic("no return value specified for GetStorageQuotaSetting")
 	}
 
-	var r0 model.FileListResultDto
+	var r0 model2.StorageQuotaSetting
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.FileListQueryDto) (model.FileListResultDto, error)); ok {
-		return returnFunc(ctx, query)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (model2.StorageQuotaSetting, error)); ok {
+		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.FileListQueryDto) model.FileListResultDto); ok {
-		r0 = returnFunc(ctx, query)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) model2.StorageQuotaSetting); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		r0 = ret.Get(0).(model.FileListResultDto)
+		r0 = ret.Get(0).(model2.StorageQuotaSetting)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, model.FileListQueryDto) error); ok {
-		r1 = returnFunc(ctx, query)
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockService_ListFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFiles'
-type MockService_ListFiles_Call struct {
+// MockService_GetStorageQuotaSetting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStorageQuotaSetting'
+type MockService_GetStorageQuotaSetting_Call struct {
 	*mock.Call
 }
 
-// ListFiles is a helper method to define mock.On call
+// GetStorageQuotaSetting is a helper method to define mock.On call
 //   - ctx context.Context
-//   - query model.FileListQueryDto
-func (_e *MockService_Expecter) ListFiles(ctx any, query any) *MockService_ListFiles_Call {
-	return &MockService_ListFiles_Call{Call: _e.mock.On("ListFiles", ctx, query)}
+func (_e *MockService_Expecter) GetStorageQuotaSetting(ctx any) *MockService_GetStorageQuotaSetting_Call {
+	return &MockService_GetStorageQuotaSetting_Call{Call: _e.mock.On("GetStorageQuotaSetting", ctx)}
 }
 
-func (_c *MockService_ListFiles_Call) Run(run func(ctx context.Context, query model.FileListQueryDto)) *MockService_ListFiles_Call {
+func (_c *MockService_GetStorageQuotaSetting_Call) Run(run func(ctx context.Context)) *MockService_GetStorageQuotaSetting_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 model.FileListQueryDto
-		if args[1] != nil {
-			arg1 = args[1].(model.FileListQueryDto)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_ListFiles_Call) Return(fileListResultDto model.FileListResultDto, err error) *MockService_ListFiles_Call {
-	_c.Call.Return(fileListResultDto, err)
+func (_c *MockService_GetStorageQuotaSetting_Call) Return(storageQuotaSetting model2.StorageQuotaSetting, err error) *MockService_GetStorageQuotaSetting_Call {
+	_c.Call.Return(storageQuotaSetting, err)
 	return _c
 }
 
-func (_c *MockService_ListFiles_Call) RunAndReturn(run func(ctx context.Context, query model.FileListQueryDto) (model.FileListResultDto, error)) *MockService_ListFiles_Call {
+func (_c *MockService_GetStorageQuotaSetting_Call) RunAndReturn(run func(ctx context.Context) (model2.StorageQuotaSetting, error)) *MockService_GetStorageQuotaSetting_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// StreamFileByID provides a mock function for the type MockService
-func (_mock *MockService) StreamFileByID(ctx *gin.Context, id string) {
-	_mock.Called(ctx, id)
-	return
-}
+// GetStorageUsage provides a mock function for the type MockService
+func (_mock *MockService) GetStorageUsage(ctx context.Context) (model1.StorageUsageReport, error) {
+	ret := _mock.Called(ctx)
 
-// MockService_StreamFileByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamFileByID'
-type MockService_StreamFileByID_Call struct {
-	*mock.Call
-}
+	if len(ret) == 0 {
+		panic("no return value specified for GetStorageUsage")
+	}
 
-// StreamFileByID is a helper method to define mock.On call
-//   - ctx *gin.Context
-//   - id string
-func (_e *MockService_Expecter) StreamFileByID(ctx any, id any) *MockService_StreamFileByID_Call {
-	return &MockService_StreamFileByID_Call{Call: _e.mock.On("StreamFileByID", ctx, id)}
+	var r0 model1.StorageUsageReport
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (model1.StorageUsageReport, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) model1.StorageUsageReport); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(model1.StorageUsageReport)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-func (_c *MockService_StreamFileByID_Call) Run(run func(ctx *gin.Context, id string)) *MockService_StreamFileByID_Call {
+// MockService_GetStorageUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStorageUsage'
+type MockService_GetStorageUsage_Call struct {
+	*mock.Call
+}
+
+// GetStorageUsage is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockService_Expecter) GetStorageUsage(ctx any) *MockService_GetStorageUsage_Call {
+	return &MockService_GetStorageUsage_Call{Call: _e.mock.On("GetStorageUsage", ctx)}
+}
+
+func (_c *MockService_GetStorageUsage_Call) Run(run func(ctx context.Context)) *MockService_GetStorageUsage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *gin.Context
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*gin.Context)
-		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg0 = args[0].(context.Context)
 		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_StreamFileByID_Call) Return() *MockService_StreamFileByID_Call {
-	_c.Call.Return()
+func (_c *MockService_GetStorageUsage_Call) Return(storageUsageReport model1.StorageUsageReport, err error) *MockService_GetStorageUsage_Call {
+	_c.Call.Return(storageUsageReport, err)
 	return _c
 }
 
-func (_c *MockService_StreamFileByID_Call) RunAndReturn(run func(ctx *gin.Context, id string)) *MockService_StreamFileByID_Call {
-	_c.Run(run)
+func (_c *MockService_GetStorageUsage_Call) RunAndReturn(run func(ctx context.Context) (model1.StorageUsageReport, error)) *MockService_GetStorageUsage_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// StreamFileByPath provides a mock function for the type MockService
-func (_mock *MockService) StreamFileByPath(ctx *gin.Context, query model.FilePathStreamQueryDto) {
-	_mock.Called(ctx, query)
-	return
+// ListFileTree provides a mock function for the type MockService
+func (_mock *MockService) ListFileTree(ctx context.Context, query model.FileTreeQueryDto) (model.FileTreeResultDto, error) {
+	ret := _mock.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFileTree")
+	}
+
+	var r0 model.FileTreeResultDto
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.FileTreeQueryDto) (model.FileTreeResultDto, error)); ok {
+		return returnFunc(ctx, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.FileTreeQueryDto) model.FileTreeResultDto); ok {
+		r0 = returnFunc(ctx, query)
+	} else {
+		r0 = ret.Get(0).(model.FileTreeResultDto)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.FileTreeQueryDto) error); ok {
+		r1 = returnFunc(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockService_StreamFileByPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamFileByPath'
-type MockService_StreamFileByPath_Call struct {
+// MockService_ListFileTree_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFileTree'
+type MockService_ListFileTree_Call struct {
 	*mock.Call
 }
 
-// StreamFileByPath is a helper method to define mock.On call
-//   - ctx *gin.Context
-//   - query model.FilePathStreamQueryDto
-func (_e *MockService_Expecter) StreamFileByPath(ctx any, query any) *MockService_StreamFileByPath_Call {
-	return &MockService_StreamFileByPath_Call{Call: _e.mock.On("StreamFileByPath", ctx, query)}
+// ListFileTree is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query model.FileTreeQueryDto
+func (_e *MockService_Expecter) ListFileTree(ctx any, query any) *MockService_ListFileTree_Call {
+	return &MockService_ListFileTree_Call{Call: _e.mock.On("ListFileTree", ctx, query)}
 }
 
-func (_c *MockService_StreamFileByPath_Call) Run(run func(ctx *gin.Context, query model.FilePathStreamQueryDto)) *MockService_StreamFileByPath_Call {
+func (_c *MockService_ListFileTree_Call) Run(run func(ctx context.Context, query model.FileTreeQueryDto)) *MockService_ListFileTree_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *gin.Context
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*gin.Context)
+			arg0 = args[0].(context.Context)
 		}
-		var arg1 model.FilePathStreamQueryDto
+		var arg1 model.FileTreeQueryDto
 		if args[1] != nil {
-			arg1 = args[1].(model.FilePathStreamQueryDto)
+			arg1 = args[1].(model.FileTreeQueryDto)
 		}
 		run(
 			arg0,
@@ -798,240 +1095,243 @@ func (_c *MockService_StreamFileByPath_Call) Run(run func(ctx *gin.Context, quer
 	return _c
 }
 
-func (_c *MockService_StreamFileByPath_Call) Return() *MockService_StreamFileByPath_Call {
-	_c.Call.Return()
+func (_c *MockService_ListFileTree_Call) Return(fileTreeResultDto model.FileTreeResultDto, err error) *MockService_ListFileTree_Call {
+	_c.Call.Return(fileTreeResultDto, err)
 	return _c
 }
 
-func (_c *MockService_StreamFileByPath_Call) RunAndReturn(run func(ctx *gin.Context, query model.FilePathStreamQueryDto)) *MockService_StreamFileByPath_Call {
-	_c.Run(run)
+func (_c *MockService_ListFileTree_Call) RunAndReturn(run func(ctx context.Context, query model.FileTreeQueryDto) (model.FileTreeResultDto, error)) *MockService_ListFileTree_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateFileMeta provides a mock function for the type MockService
-func (_mock *MockService) UpdateFileMeta(ctx context.Context, id string, dto model.UpdateFileMetaDto) (model.FileDto, error) {
-	ret := _mock.Called(ctx, id, dto)
+// ListFiles provides a mock function for the type MockService
+func (_mock *MockService) ListFiles(ctx context.Context, query model.FileListQueryDto) (model.FileListResultDto, error) {
+	ret := _mock.Called(ctx, query)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateFileMeta")
+		panic("no return value specified for ListFiles")
 	}
 
-	var r0 model.FileDto
+	var r0 model.FileListResultDto
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.UpdateFileMetaDto) (model.FileDto, error)); ok {
-		return returnFunc(ctx, id, dto)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.FileListQueryDto) (model.FileListResultDto, error)); ok {
+		return returnFunc(ctx, query)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.UpdateFileMetaDto) model.FileDto); ok {
-		r0 = returnFunc(ctx, id, dto)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.FileListQueryDto) model.FileListResultDto); ok {
+		r0 = returnFunc(ctx, query)
 	} else {
-		r0 = ret.Get(0).(model.FileDto)
+		r0 = ret.Get(0).(model.FileListResultDto)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, model.UpdateFileMetaDto) error); ok {
-		r1 = returnFunc(ctx, id, dto)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.FileListQueryDto) error); ok {
+		r1 = returnFunc(ctx, query)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockService_UpdateFileMeta_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateFileMeta'
-type MockService_UpdateFileMeta_Call struct {
+// MockService_ListFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFiles'
+type MockService_ListFiles_Call struct {
 	*mock.Call
 }
 
-// UpdateFileMeta is a helper method to define mock.On call
+// ListFiles is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-//   - dto model.UpdateFileMetaDto
-func (_e *MockService_Expecter) UpdateFileMeta(ctx any, id any, dto any) *MockService_UpdateFileMeta_Call {
-	return &MockService_UpdateFileMeta_Call{Call: _e.mock.On("UpdateFileMeta", ctx, id, dto)}
+//   - query model.FileListQueryDto
+func (_e *MockService_Expecter) ListFiles(ctx any, query any) *MockService_ListFiles_Call {
+	return &MockService_ListFiles_Call{Call: _e.mock.On("ListFiles", ctx, query)}
 }
 
-func (_c *MockService_UpdateFileMeta_Call) Run(run func(ctx context.Context, id string, dto model.UpdateFileMetaDto)) *MockService_UpdateFileMeta_Call {
+func (_c *MockService_ListFiles_Call) Run(run func(ctx context.Context, query model.FileListQueryDto)) *MockService_ListFiles_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 model.FileListQueryDto
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 model.UpdateFileMetaDto
-		if args[2] != nil {
-			arg2 = args[2].(model.UpdateFileMetaDto)
+			arg1 = args[1].(model.FileListQueryDto)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_UpdateFileMeta_Call) Return(fileDto model.FileDto, err error) *MockService_UpdateFileMeta_Call {
-	_c.Call.Return(fileDto, err)
+func (_c *MockService_ListFiles_Call) Return(fileListResultDto model.FileListResultDto, err error) *MockService_ListFiles_Call {
+	_c.Call.Return(fileListResultDto, err)
 	return _c
 }
 
-func (_c *MockService_UpdateFileMeta_Call) RunAndReturn(run func(ctx context.Context, id string, dto model.UpdateFileMetaDto) (model.FileDto, error)) *MockService_UpdateFileMeta_Call {
+func (_c *MockService_ListFiles_Call) RunAndReturn(run func(ctx context.Context, query model.FileListQueryDto) (model.FileListResultDto, error)) *MockService_ListFiles_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UploadFile provides a mock function for the type MockService
-func (_mock *MockService) UploadFile(ctx context.Context, file *multipart.FileHeader, category storage.Category, storageType storage.StorageType) (model.FileDto, error) {
-	ret := _mock.Called(ctx, file, category, storageType)
+// MigrateStorage provides a mock function for the type MockService
+func (_mock *MockService) MigrateStorage(ctx context.Context, payload model1.StorageMigrationPayload, onProgress func(model1.StorageMigrationProgress)) (model1.StorageMigrationProgress, error) {
+	ret := _mock.Called(ctx, payload, onProgress)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UploadFile")
+		panic("no return value specified for MigrateStorage")
 	}
 
-	var r0 model.FileDto
+	var r0 model1.StorageMigrationProgress
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *multipart.FileHeader, storage.Category, storage.StorageType) (model.FileDto, error)); ok {
-		return returnFunc(ctx, file, category, storageType)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model1.StorageMigrationPayload, func(model1.StorageMigrationProgress)) (model1.StorageMigrationProgress, error)); ok {
+		return returnFunc(ctx, payload, onProgress)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *multipart.FileHeader, storage.Category, storage.StorageType) model.FileDto); ok {
-		r0 = returnFunc(ctx, file, category, storageType)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model1.StorageMigrationPayload, func(model1.StorageMigrationProgress)) model1.StorageMigrationProgress); ok {
+		r0 = returnFunc(ctx, payload, onProgress)
 	} else {
-		r0 = ret.Get(0).(model.FileDto)
+		r0 = ret.Get(0).(model1.StorageMigrationProgress)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *multipart.FileHeader, storage.Category, storage.StorageType) error); ok {
-		r1 = returnFunc(ctx, file, category, storageType)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model1.StorageMigrationPayload, func(model1.StorageMigrationProgress)) error); ok {
+		r1 = returnFunc(ctx, payload, onProgress)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockService_UploadFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadFile'
-type MockService_UploadFile_Call struct {
+// MockService_MigrateStorage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MigrateStorage'
+type MockService_MigrateStorage_Call struct {
 	*mock.Call
 }
 
-// UploadFile is a helper method to define mock.On call
+// MigrateStorage is a helper method to define mock.On call
 //   - ctx context.Context
-//   - file *multipart.FileHeader
-//   - category storage.Category
-//   - storageType storage.StorageType
-func (_e *MockService_Expecter) UploadFile(ctx any, file any, category any, storageType any) *MockService_UploadFile_Call {
-	return &MockService_UploadFile_Call{Call: _e.mock.On("UploadFile", ctx, file, category, storageType)}
+//   - payload model1.StorageMigrationPayload
+//   - onProgress func(model1.StorageMigrationProgress)
+func (_e *MockService_Expecter) MigrateStorage(ctx any, payload any, onProgress any) *MockService_MigrateStorage_Call {
+	return &MockService_MigrateStorage_Call{Call: _e.mock.On("MigrateStorage", ctx, payload, onProgress)}
 }
 
-func (_c *MockService_UploadFile_Call) Run(run func(ctx context.Context, file *multipart.FileHeader, category storage.Category, storageType storage.StorageType)) *MockService_UploadFile_Call {
+func (_c *MockService_MigrateStorage_Call) Run(run func(ctx context.Context, payload model1.StorageMigrationPayload, onProgress func(model1.StorageMigrationProgress))) *MockService_MigrateStorage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *multipart.FileHeader
+		var arg1 model1.StorageMigrationPayload
 		if args[1] != nil {
-			arg1 = args[1].(*multipart.FileHeader)
+			arg1 = args[1].(model1.StorageMigrationPayload)
 		}
-		var arg2 storage.Category
+		var arg2 func(model1.StorageMigrationProgress)
 		if args[2] != nil {
-			arg2 = args[2].(storage.Category)
-		}
-		var arg3 storage.StorageType
-		if args[3] != nil {
-			arg3 = args[3].(storage.StorageType)
+			arg2 = args[2].(func(model1.StorageMigrationProgress))
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_UploadFile_Call) Return(fileDto model.FileDto, err error) *MockService_UploadFile_Call {
-	_c.Call.Return(fileDto, err)
+func (_c *MockService_MigrateStorage_Call) Return(storageMigrationProgress model1.StorageMigrationProgress, err error) *MockService_MigrateStorage_Call {
+	_c.Call.Return(storageMigrationProgress, err)
 	return _c
 }
 
-func (_c *MockService_UploadFile_Call) RunAndReturn(run func(ctx context.Context, file *multipart.FileHeader, category storage.Category, storageType storage.StorageType) (model.FileDto, error)) *MockService_UploadFile_Call {
+func (_c *MockService_MigrateStorage_Call) RunAndReturn(run func(ctx context.Context, payload model1.StorageMigrationPayload, onProgress func(model1.StorageMigrationProgress)) (model1.StorageMigrationProgress, error)) *MockService_MigrateStorage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockCommonRepository creates a new instance of MockCommonRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockCommonRepository(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockCommonRepository {
-	mock := &MockCommonRepository{}
-	mock.Mock.Test(t)
+// PrepareFileDedupe provides a mock function for the type MockService
+func (_mock *MockService) PrepareFileDedupe(ctx context.Context) error {
+	ret := _mock.Called(ctx)
 
-	t.Cleanup(func() { mock.AssertExpectations(t) })
+	if len(ret) == 0 {
+		panic("no return value specified for PrepareFileDedupe")
+	}
 
-	return mock
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
 }
 
-// MockCommonRepository is an autogenerated mock type for the CommonRepository type
-type MockCommonRepository struct {
-	mock.Mock
+// MockService_PrepareFileDedupe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PrepareFileDedupe'
+type MockService_PrepareFileDedupe_Call struct {
+	*mock.Call
 }
 
-type MockCommonRepository_Expecter struct {
-	mock *mock.Mock
+// PrepareFileDedupe is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockService_Expecter) PrepareFileDedupe(ctx any) *MockService_PrepareFileDedupe_Call {
+	return &MockService_PrepareFileDedupe_Call{Call: _e.mock.On("PrepareFileDedupe", ctx)}
 }
 
-func (_m *MockCommonRepository) EXPECT() *MockCommonRepository_Expecter {
-	return &MockCommonRepository_Expecter{mock: &_m.Mock}
+func (_c *MockService_PrepareFileDedupe_Call) Run(run func(ctx context.Context)) *MockService_PrepareFileDedupe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
 }
 
-// GetUserByUserId provides a mock function for the type MockCommonRepository
-func (_mock *MockCommonRepository) GetUserByUserId(ctx context.Context, id string) (model0.User, error) {
-	ret := _mock.Called(ctx, id)
+func (_c *MockService_PrepareFileDedupe_Call) Return(err error) *MockService_PrepareFileDedupe_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_PrepareFileDedupe_Call) RunAndReturn(run func(ctx context.Context) error) *MockService_PrepareFileDedupe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PrepareStorageMigration provides a mock function for the type MockService
+func (_mock *MockService) PrepareStorageMigration(ctx context.Context, payload *model1.StorageMigrationPayload) error {
+	ret := _mock.Called(ctx, payload)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserByUserId")
+		panic("no return value specified for PrepareStorageMigration")
 	}
 
-	var r0 model0.User
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (model0.User, error)); ok {
-		return returnFunc(ctx, id)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) model0.User); ok {
-		r0 = returnFunc(ctx, id)
-	} else {
-		r0 = ret.Get(0).(model0.User)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model1.StorageMigrationPayload) error); ok {
+		r0 = returnFunc(ctx, payload)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockCommonRepository_GetUserByUserId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByUserId'
-type MockCommonRepository_GetUserByUserId_Call struct {
+// MockService_PrepareStorageMigration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PrepareStorageMigration'
+type MockService_PrepareStorageMigration_Call struct {
 	*mock.Call
 }
 
-// GetUserByUserId is a helper method to define mock.On call
+// PrepareStorageMigration is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockCommonRepository_Expecter) GetUserByUserId(ctx any, id any) *MockCommonRepository_GetUserByUserId_Call {
-	return &MockCommonRepository_GetUserByUserId_Call{Call: _e.mock.On("GetUserByUserId", ctx, id)}
+//   - payload *model1.StorageMigrationPayload
+func (_e *MockService_Expecter) PrepareStorageMigration(ctx any, payload any) *MockService_PrepareStorageMigration_Call {
+	return &MockService_PrepareStorageMigration_Call{Call: _e.mock.On("PrepareStorageMigration", ctx, payload)}
 }
 
-func (_c *MockCommonRepository_GetUserByUserId_Call) Run(run func(ctx context.Context, id string)) *MockCommonRepository_GetUserByUserId_Call {
+func (_c *MockService_PrepareStorageMigration_Call) Run(run func(ctx context.Context, payload *model1.StorageMigrationPayload)) *MockService_PrepareStorageMigration_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *model1.StorageMigrationPayload
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(*model1.StorageMigrationPayload)
 		}
 		run(
 			arg0,
@@ -1041,81 +1341,156 @@ func (_c *MockCommonRepository_GetUserByUserId_Call) Run(run func(ctx context.Co
 	return _c
 }
 
-func (_c *MockCommonRepository_GetUserByUserId_Call) Return(user model0.User, err error) *MockCommonRepository_GetUserByUserId_Call {
-	_c.Call.Return(user, err)
+func (_c *MockService_PrepareStorageMigration_Call) Return(err error) *MockService_PrepareStorageMigration_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockCommonRepository_GetUserByUserId_Call) RunAndReturn(run func(ctx context.Context, id string) (model0.User, error)) *MockCommonRepository_GetUserByUserId_Call {
+func (_c *MockService_PrepareStorageMigration_Call) RunAndReturn(run func(ctx context.Context, payload *model1.StorageMigrationPayload) error) *MockService_PrepareStorageMigration_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockFileRepository creates a new instance of MockFileRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockFileRepository(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockFileRepository {
-	mock := &MockFileRepository{}
-	mock.Mock.Test(t)
+// ReconcileStorageUsage provides a mock function for the type MockService
+func (_mock *MockService) ReconcileStorageUsage() (model1.StorageUsageRecount, error) {
+	ret := _mock.Called()
 
-	t.Cleanup(func() { mock.AssertExpectations(t) })
+	if len(ret) == 0 {
+		panic("no return value specified for ReconcileStorageUsage")
+	}
 
-	return mock
+	var r0 model1.StorageUsageRecount
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() (model1.StorageUsageRecount, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() model1.StorageUsageRecount); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(model1.StorageUsageRecount)
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockFileRepository is an autogenerated mock type for the FileRepository type
-type MockFileRepository struct {
-	mock.Mock
+// MockService_ReconcileStorageUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReconcileStorageUsage'
+type MockService_ReconcileStorageUsage_Call struct {
+	*mock.Call
 }
 
-type MockFileRepository_Expecter struct {
-	mock *mock.Mock
+// ReconcileStorageUsage is a helper method to define mock.On call
+func (_e *MockService_Expecter) ReconcileStorageUsage() *MockService_ReconcileStorageUsage_Call {
+	return &MockService_ReconcileStorageUsage_Call{Call: _e.mock.On("ReconcileStorageUsage")}
 }
 
-func (_m *MockFileRepository) EXPECT() *MockFileRepository_Expecter {
-	return &MockFileRepository_Expecter{mock: &_m.Mock}
+func (_c *MockService_ReconcileStorageUsage_Call) Run(run func()) *MockService_ReconcileStorageUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
 }
 
-// Create provides a mock function for the type MockFileRepository
-func (_mock *MockFileRepository) Create(ctx context.Context, file *model1.File) error {
-	ret := _mock.Called(ctx, file)
+func (_c *MockService_ReconcileStorageUsage_Call) Return(storageUsageRecount model1.StorageUsageRecount, err error) *MockService_ReconcileStorageUsage_Call {
+	_c.Call.Return(storageUsageRecount, err)
+	return _c
+}
+
+func (_c *MockService_ReconcileStorageUsage_Call) RunAndReturn(run func() (model1.StorageUsageRecount, error)) *MockService_ReconcileStorageUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecountStorageUsage provides a mock function for the type MockService
+func (_mock *MockService) RecountStorageUsage(ctx context.Context) (model1.StorageUsageRecount, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Create")
+		panic("no return value specified for RecountStorageUsage")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model1.File) error); ok {
-		r0 = returnFunc(ctx, file)
+	var r0 model1.StorageUsageRecount
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (model1.StorageUsageRecount, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) model1.StorageUsageRecount); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(model1.StorageUsageRecount)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockFileRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MockFileRepository_Create_Call struct {
+// MockService_RecountStorageUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecountStorageUsage'
+type MockService_RecountStorageUsage_Call struct {
 	*mock.Call
 }
 
-// Create is a helper method to define mock.On call
+// RecountStorageUsage is a helper method to define mock.On call
 //   - ctx context.Context
-//   - file *model1.File
-func (_e *MockFileRepository_Expecter) Create(ctx any, file any) *MockFileRepository_Create_Call {
-	return &MockFileRepository_Create_Call{Call: _e.mock.On("Create", ctx, file)}
+func (_e *MockService_Expecter) RecountStorageUsage(ctx any) *MockService_RecountStorageUsage_Call {
+	return &MockService_RecountStorageUsage_Call{Call: _e.mock.On("RecountStorageUsage", ctx)}
 }
 
-func (_c *MockFileRepository_Create_Call) Run(run func(ctx context.Context, file *model1.File)) *MockFileRepository_Create_Call {
+func (_c *MockService_RecountStorageUsage_Call) Run(run func(ctx context.Context)) *MockService_RecountStorageUsage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *model1.File
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_RecountStorageUsage_Call) Return(storageUsageRecount model1.StorageUsageRecount, err error) *MockService_RecountStorageUsage_Call {
+	_c.Call.Return(storageUsageRecount, err)
+	return _c
+}
+
+func (_c *MockService_RecountStorageUsage_Call) RunAndReturn(run func(ctx context.Context) (model1.StorageUsageRecount, error)) *MockService_RecountStorageUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamFileByID provides a mock function for the type MockService
+func (_mock *MockService) StreamFileByID(ctx *gin.Context, id string) {
+	_mock.Called(ctx, id)
+	return
+}
+
+// MockService_StreamFileByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamFileByID'
+type MockService_StreamFileByID_Call struct {
+	*mock.Call
+}
+
+// StreamFileByID is a helper method to define mock.On call
+//   - ctx *gin.Context
+//   - id string
+func (_e *MockService_Expecter) StreamFileByID(ctx any, id any) *MockService_StreamFileByID_Call {
+	return &MockService_StreamFileByID_Call{Call: _e.mock.On("StreamFileByID", ctx, id)}
+}
+
+func (_c *MockService_StreamFileByID_Call) Run(run func(ctx *gin.Context, id string)) *MockService_StreamFileByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*model1.File)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -1125,103 +1500,165 @@ func (_c *MockFileRepository_Create_Call) Run(run func(ctx context.Context, file
 	return _c
 }
 
-func (_c *MockFileRepository_Create_Call) Return(err error) *MockFileRepository_Create_Call {
-	_c.Call.Return(err)
+func (_c *MockService_StreamFileByID_Call) Return() *MockService_StreamFileByID_Call {
+	_c.Call.Return()
 	return _c
 }
 
-func (_c *MockFileRepository_Create_Call) RunAndReturn(run func(ctx context.Context, file *model1.File) error) *MockFileRepository_Create_Call {
-	_c.Call.Return(run)
+func (_c *MockService_StreamFileByID_Call) RunAndReturn(run func(ctx *gin.Context, id string)) *MockService_StreamFileByID_Call {
+	_c.Run(run)
 	return _c
 }
 
-// CreateTemp provides a mock function for the type MockFileRepository
-func (_mock *MockFileRepository) CreateTemp(ctx context.Context, temp *model1.TempFile) error {
-	ret := _mock.Called(ctx, temp)
+// StreamFileByPath provides a mock function for the type MockService
+func (_mock *MockService) StreamFileByPath(ctx *gin.Context, query model.FilePathStreamQueryDto) {
+	_mock.Called(ctx, query)
+	return
+}
+
+// MockService_StreamFileByPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamFileByPath'
+type MockService_StreamFileByPath_Call struct {
+	*mock.Call
+}
+
+// StreamFileByPath is a helper method to define mock.On call
+//   - ctx *gin.Context
+//   - query model.FilePathStreamQueryDto
+func (_e *MockService_Expecter) StreamFileByPath(ctx any, query any) *MockService_StreamFileByPath_Call {
+	return &MockService_StreamFileByPath_Call{Call: _e.mock.On("StreamFileByPath", ctx, query)}
+}
+
+func (_c *MockService_StreamFileByPath_Call) Run(run func(ctx *gin.Context, query model.FilePathStreamQueryDto)) *MockService_StreamFileByPath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		var arg1 model.FilePathStreamQueryDto
+		if args[1] != nil {
+			arg1 = args[1].(model.FilePathStreamQueryDto)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_StreamFileByPath_Call) Return() *MockService_StreamFileByPath_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockService_StreamFileByPath_Call) RunAndReturn(run func(ctx *gin.Context, query model.FilePathStreamQueryDto)) *MockService_StreamFileByPath_Call {
+	_c.Run(run)
+	return _c
+}
+
+// UpdateFileAltText provides a mock function for the type MockService
+func (_mock *MockService) UpdateFileAltText(ctx context.Context, id string, altText string) error {
+	ret := _mock.Called(ctx, id, altText)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateTemp")
+		panic("no return value specified for UpdateFileAltText")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model1.TempFile) error); ok {
-		r0 = returnFunc(ctx, temp)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, id, altText)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockFileRepository_CreateTemp_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTemp'
-type MockFileRepository_CreateTemp_Call struct {
+// MockService_UpdateFileAltText_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateFileAltText'
+type MockService_UpdateFileAltText_Call struct {
 	*mock.Call
 }
 
-// CreateTemp is a helper method to define mock.On call
+// UpdateFileAltText is a helper method to define mock.On call
 //   - ctx context.Context
-//   - temp *model1.TempFile
-func (_e *MockFileRepository_Expecter) CreateTemp(ctx any, temp any) *MockFileRepository_CreateTemp_Call {
-	return &MockFileRepository_CreateTemp_Call{Call: _e.mock.On("CreateTemp", ctx, temp)}
+//   - id string
+//   - altText string
+func (_e *MockService_Expecter) UpdateFileAltText(ctx any, id any, altText any) *MockService_UpdateFileAltText_Call {
+	return &MockService_UpdateFileAltText_Call{Call: _e.mock.On("UpdateFileAltText", ctx, id, altText)}
 }
 
-func (_c *MockFileRepository_CreateTemp_Call) Run(run func(ctx context.Context, temp *model1.TempFile)) *MockFileRepository_CreateTemp_Call {
+func (_c *MockService_UpdateFileAltText_Call) Run(run func(ctx context.Context, id string, altText string)) *MockService_UpdateFileAltText_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *model1.TempFile
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*model1.TempFile)
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockFileRepository_CreateTemp_Call) Return(err error) *MockFileRepository_CreateTemp_Call {
+func (_c *MockService_UpdateFileAltText_Call) Return(err error) *MockService_UpdateFileAltText_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockFileRepository_CreateTemp_Call) RunAndReturn(run func(ctx context.Context, temp *model1.TempFile) error) *MockFileRepository_CreateTemp_Call {
+func (_c *MockService_UpdateFileAltText_Call) RunAndReturn(run func(ctx context.Context, id string, altText string) error) *MockService_UpdateFileAltText_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Delete provides a mock function for the type MockFileRepository
-func (_mock *MockFileRepository) Delete(ctx context.Context, id string) error {
-	ret := _mock.Called(ctx, id)
+// UpdateFileMeta provides a mock function for the type MockService
+func (_mock *MockService) UpdateFileMeta(ctx context.Context, id string, dto model.UpdateFileMetaDto) (model.FileDto, error) {
+	ret := _mock.Called(ctx, id, dto)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Delete")
+		panic("no return value specified for UpdateFileMeta")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, id)
+	var r0 model.FileDto
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.UpdateFileMetaDto) (model.FileDto, error)); ok {
+		return returnFunc(ctx, id, dto)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.UpdateFileMetaDto) model.FileDto); ok {
+		r0 = returnFunc(ctx, id, dto)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(model.FileDto)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, model.UpdateFileMetaDto) error); ok {
+		r1 = returnFunc(ctx, id, dto)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockFileRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
-type MockFileRepository_Delete_Call struct {
+// MockService_UpdateFileMeta_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateFileMeta'
+type MockService_UpdateFileMeta_Call struct {
 	*mock.Call
 }
 
-// Delete is a helper method to define mock.On call
+// UpdateFileMeta is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id string
-func (_e *MockFileRepository_Expecter) Delete(ctx any, id any) *MockFileRepository_Delete_Call {
-	return &MockFileRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+//   - dto model.UpdateFileMetaDto
+func (_e *MockService_Expecter) UpdateFileMeta(ctx any, id any, dto any) *MockService_UpdateFileMeta_Call {
+	return &MockService_UpdateFileMeta_Call{Call: _e.mock.On("UpdateFileMeta", ctx, id, dto)}
 }
 
-func (_c *MockFileRepository_Delete_Call) Run(run func(ctx context.Context, id string)) *MockFileRepository_Delete_Call {
+func (_c *MockService_UpdateFileMeta_Call) Run(run func(ctx context.Context, id string, dto model.UpdateFileMetaDto)) *MockService_UpdateFileMeta_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1231,57 +1668,1647 @@ func (_c *MockFileRepository_Delete_Call) Run(run func(ctx context.Context, id s
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 model.UpdateFileMetaDto
+		if args[2] != nil {
+			arg2 = args[2].(model.UpdateFileMetaDto)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockFileRepository_Delete_Call) Return(err error) *MockFileRepository_Delete_Call {
-	_c.Call.Return(err)
+func (_c *MockService_UpdateFileMeta_Call) Return(fileDto model.FileDto, err error) *MockService_UpdateFileMeta_Call {
+	_c.Call.Return(fileDto, err)
 	return _c
 }
 
-func (_c *MockFileRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockFileRepository_Delete_Call {
+func (_c *MockService_UpdateFileMeta_Call) RunAndReturn(run func(ctx context.Context, id string, dto model.UpdateFileMetaDto) (model.FileDto, error)) *MockService_UpdateFileMeta_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteByRoute provides a mock function for the type MockFileRepository
-func (_mock *MockFileRepository) DeleteByRoute(ctx context.Context, storageType string, provider string, bucket string, key string) error {
-	ret := _mock.Called(ctx, storageType, provider, bucket, key)
+// UpdateStorageQuotaSetting provides a mock function for the type MockService
+func (_mock *MockService) UpdateStorageQuotaSetting(ctx context.Context, dto model2.StorageQuotaSettingDto) error {
+	ret := _mock.Called(ctx, dto)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteByRoute")
+		panic("no return value specified for UpdateStorageQuotaSetting")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
-		r0 = returnFunc(ctx, storageType, provider, bucket, key)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model2.StorageQuotaSettingDto) error); ok {
+		r0 = returnFunc(ctx, dto)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockFileRepository_DeleteByRoute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByRoute'
-type MockFileRepository_DeleteByRoute_Call struct {
+// MockService_UpdateStorageQuotaSetting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStorageQuotaSetting'
+type MockService_UpdateStorageQuotaSetting_Call struct {
 	*mock.Call
 }
 
-// DeleteByRoute is a helper method to define mock.On call
+// UpdateStorageQuotaSetting is a helper method to define mock.On call
 //   - ctx context.Context
-//   - storageType string
-//   - provider string
-//   - bucket string
-//   - key string
-func (_e *MockFileRepository_Expecter) DeleteByRoute(ctx any, storageType any, provider any, bucket any, key any) *MockFileRepository_DeleteByRoute_Call {
-	return &MockFileRepository_DeleteByRoute_Call{Call: _e.mock.On("DeleteByRoute", ctx, storageType, provider, bucket, key)}
+//   - dto model2.StorageQuotaSettingDto
+func (_e *MockService_Expecter) UpdateStorageQuotaSetting(ctx any, dto any) *MockService_UpdateStorageQuotaSetting_Call {
+	return &MockService_UpdateStorageQuotaSetting_Call{Call: _e.mock.On("UpdateStorageQuotaSetting", ctx, dto)}
+}
+
+func (_c *MockService_UpdateStorageQuotaSetting_Call) Run(run func(ctx context.Context, dto model2.StorageQuotaSettingDto)) *MockService_UpdateStorageQuotaSetting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model2.StorageQuotaSettingDto
+		if args[1] != nil {
+			arg1 = args[1].(model2.StorageQuotaSettingDto)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_UpdateStorageQuotaSetting_Call) Return(err error) *MockService_UpdateStorageQuotaSetting_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_UpdateStorageQuotaSetting_Call) RunAndReturn(run func(ctx context.Context, dto model2.StorageQuotaSettingDto) error) *MockService_UpdateStorageQuotaSetting_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UploadFile provides a mock function for the type MockService
+func (_mock *MockService) UploadFile(ctx context.Context, file *multipart.FileHeader, category storage.Category, storageType storage.StorageType) (model.FileDto, error) {
+	ret := _mock.Called(ctx, file, category, storageType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadFile")
+	}
+
+	var r0 model.FileDto
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *multipart.FileHeader, storage.Category, storage.StorageType) (model.FileDto, error)); ok {
+		return returnFunc(ctx, file, category, storageType)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *multipart.FileHeader, storage.Category, storage.StorageType) model.FileDto); ok {
+		r0 = returnFunc(ctx, file, category, storageType)
+	} else {
+		r0 = ret.Get(0).(model.FileDto)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *multipart.FileHeader, storage.Category, storage.StorageType) error); ok {
+		r1 = returnFunc(ctx, file, category, storageType)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_UploadFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadFile'
+type MockService_UploadFile_Call struct {
+	*mock.Call
+}
+
+// UploadFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - file *multipart.FileHeader
+//   - category storage.Category
+//   - storageType storage.StorageType
+func (_e *MockService_Expecter) UploadFile(ctx any, file any, category any, storageType any) *MockService_UploadFile_Call {
+	return &MockService_UploadFile_Call{Call: _e.mock.On("UploadFile", ctx, file, category, storageType)}
+}
+
+func (_c *MockService_UploadFile_Call) Run(run func(ctx context.Context, file *multipart.FileHeader, category storage.Category, storageType storage.StorageType)) *MockService_UploadFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *multipart.FileHeader
+		if args[1] != nil {
+			arg1 = args[1].(*multipart.FileHeader)
+		}
+		var arg2 storage.Category
+		if args[2] != nil {
+			arg2 = args[2].(storage.Category)
+		}
+		var arg3 storage.StorageType
+		if args[3] != nil {
+			arg3 = args[3].(storage.StorageType)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_UploadFile_Call) Return(fileDto model.FileDto, err error) *MockService_UploadFile_Call {
+	_c.Call.Return(fileDto, err)
+	return _c
+}
+
+func (_c *MockService_UploadFile_Call) RunAndReturn(run func(ctx context.Context, file *multipart.FileHeader, category storage.Category, storageType storage.StorageType) (model.FileDto, error)) *MockService_UploadFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCommonRepository creates a new instance of MockCommonRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCommonRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCommonRepository {
+	mock := &MockCommonRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCommonRepository is an autogenerated mock type for the CommonRepository type
+type MockCommonRepository struct {
+	mock.Mock
+}
+
+type MockCommonRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCommonRepository) EXPECT() *MockCommonRepository_Expecter {
+	return &MockCommonRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetAllUsers provides a mock function for the type MockCommonRepository
+func (_mock *MockCommonRepository) GetAllUsers(ctx context.Context) ([]model0.User, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllUsers")
+	}
+
+	var r0 []model0.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]model0.User, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []model0.User); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model0.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCommonRepository_GetAllUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllUsers'
+type MockCommonRepository_GetAllUsers_Call struct {
+	*mock.Call
+}
+
+// GetAllUsers is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCommonRepository_Expecter) GetAllUsers(ctx any) *MockCommonRepository_GetAllUsers_Call {
+	return &MockCommonRepository_GetAllUsers_Call{Call: _e.mock.On("GetAllUsers", ctx)}
+}
+
+func (_c *MockCommonRepository_GetAllUsers_Call) Run(run func(ctx context.Context)) *MockCommonRepository_GetAllUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCommonRepository_GetAllUsers_Call) Return(users []model0.User, err error) *MockCommonRepository_GetAllUsers_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockCommonRepository_GetAllUsers_Call) RunAndReturn(run func(ctx context.Context) ([]model0.User, error)) *MockCommonRepository_GetAllUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByUserId provides a mock function for the type MockCommonRepository
+func (_mock *MockCommonRepository) GetUserByUserId(ctx context.Context, id string) (model0.User, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByUserId")
+	}
+
+	var r0 model0.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (model0.User, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) model0.User); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(model0.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCommonRepository_GetUserByUserId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByUserId'
+type MockCommonRepository_GetUserByUserId_Call struct {
+	*mock.Call
+}
+
+// GetUserByUserId is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockCommonRepository_Expecter) GetUserByUserId(ctx any, id any) *MockCommonRepository_GetUserByUserId_Call {
+	return &MockCommonRepository_GetUserByUserId_Call{Call: _e.mock.On("GetUserByUserId", ctx, id)}
+}
+
+func (_c *MockCommonRepository_GetUserByUserId_Call) Run(run func(ctx context.Context, id string)) *MockCommonRepository_GetUserByUserId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCommonRepository_GetUserByUserId_Call) Return(user model0.User, err error) *MockCommonRepository_GetUserByUserId_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockCommonRepository_GetUserByUserId_Call) RunAndReturn(run func(ctx context.Context, id string) (model0.User, error)) *MockCommonRepository_GetUserByUserId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockFileRepository creates a new instance of MockFileRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFileRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFileRepository {
+	mock := &MockFileRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockFileRepository is an autogenerated mock type for the FileRepository type
+type MockFileRepository struct {
+	mock.Mock
+}
+
+type MockFileRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFileRepository) EXPECT() *MockFileRepository_Expecter {
+	return &MockFileRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddUsage provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) AddUsage(ctx context.Context, userID string, category string, bytes int64, files int64) error {
+	ret := _mock.Called(ctx, userID, category, bytes, files)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddUsage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int64, int64) error); ok {
+		r0 = returnFunc(ctx, userID, category, bytes, files)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFileRepository_AddUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddUsage'
+type MockFileRepository_AddUsage_Call struct {
+	*mock.Call
+}
+
+// AddUsage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - category string
+//   - bytes int64
+//   - files int64
+func (_e *MockFileRepository_Expecter) AddUsage(ctx any, userID any, category any, bytes any, files any) *MockFileRepository_AddUsage_Call {
+	return &MockFileRepository_AddUsage_Call{Call: _e.mock.On("AddUsage", ctx, userID, category, bytes, files)}
+}
+
+func (_c *MockFileRepository_AddUsage_Call) Run(run func(ctx context.Context, userID string, category string, bytes int64, files int64)) *MockFileRepository_AddUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int64
+		if args[3] != nil {
+			arg3 = args[3].(int64)
+		}
+		var arg4 int64
+		if args[4] != nil {
+			arg4 = args[4].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_AddUsage_Call) Return(err error) *MockFileRepository_AddUsage_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFileRepository_AddUsage_Call) RunAndReturn(run func(ctx context.Context, userID string, category string, bytes int64, files int64) error) *MockFileRepository_AddUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AggregateUsage provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) AggregateUsage(ctx context.Context) ([]model1.StorageUsage, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AggregateUsage")
+	}
+
+	var r0 []model1.StorageUsage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]model1.StorageUsage, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []model1.StorageUsage); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model1.StorageUsage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileRepository_AggregateUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AggregateUsage'
+type MockFileRepository_AggregateUsage_Call struct {
+	*mock.Call
+}
+
+// AggregateUsage is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockFileRepository_Expecter) AggregateUsage(ctx any) *MockFileRepository_AggregateUsage_Call {
+	return &MockFileRepository_AggregateUsage_Call{Call: _e.mock.On("AggregateUsage", ctx)}
+}
+
+func (_c *MockFileRepository_AggregateUsage_Call) Run(run func(ctx context.Context)) *MockFileRepository_AggregateUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_AggregateUsage_Call) Return(storageUsages []model1.StorageUsage, err error) *MockFileRepository_AggregateUsage_Call {
+	_c.Call.Return(storageUsages, err)
+	return _c
+}
+
+func (_c *MockFileRepository_AggregateUsage_Call) RunAndReturn(run func(ctx context.Context) ([]model1.StorageUsage, error)) *MockFileRepository_AggregateUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountByRoute provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) CountByRoute(ctx context.Context, storageType string, bucket string) (int64, error) {
+	ret := _mock.Called(ctx, storageType, bucket)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByRoute")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (int64, error)); ok {
+		return returnFunc(ctx, storageType, bucket)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) int64); ok {
+		r0 = returnFunc(ctx, storageType, bucket)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, storageType, bucket)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileRepository_CountByRoute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByRoute'
+type MockFileRepository_CountByRoute_Call struct {
+	*mock.Call
+}
+
+// CountByRoute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - storageType string
+//   - bucket string
+func (_e *MockFileRepository_Expecter) CountByRoute(ctx any, storageType any, bucket any) *MockFileRepository_CountByRoute_Call {
+	return &MockFileRepository_CountByRoute_Call{Call: _e.mock.On("CountByRoute", ctx, storageType, bucket)}
+}
+
+func (_c *MockFileRepository_CountByRoute_Call) Run(run func(ctx context.Context, storageType string, bucket string)) *MockFileRepository_CountByRoute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_CountByRoute_Call) Return(n int64, err error) *MockFileRepository_CountByRoute_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockFileRepository_CountByRoute_Call) RunAndReturn(run func(ctx context.Context, storageType string, bucket string) (int64, error)) *MockFileRepository_CountByRoute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountByRouteKey provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) CountByRouteKey(ctx context.Context, storageType string, bucket string, key string, excludeID string) (int64, error) {
+	ret := _mock.Called(ctx, storageType, bucket, key, excludeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByRouteKey")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) (int64, error)); ok {
+		return returnFunc(ctx, storageType, bucket, key, excludeID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) int64); ok {
+		r0 = returnFunc(ctx, storageType, bucket, key, excludeID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = returnFunc(ctx, storageType, bucket, key, excludeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileRepository_CountByRouteKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByRouteKey'
+type MockFileRepository_CountByRouteKey_Call struct {
+	*mock.Call
+}
+
+// CountByRouteKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - storageType string
+//   - bucket string
+//   - key string
+//   - excludeID string
+func (_e *MockFileRepository_Expecter) CountByRouteKey(ctx any, storageType any, bucket any, key any, excludeID any) *MockFileRepository_CountByRouteKey_Call {
+	return &MockFileRepository_CountByRouteKey_Call{Call: _e.mock.On("CountByRouteKey", ctx, storageType, bucket, key, excludeID)}
+}
+
+func (_c *MockFileRepository_CountByRouteKey_Call) Run(run func(ctx context.Context, storageType string, bucket string, key string, excludeID string)) *MockFileRepository_CountByRouteKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_CountByRouteKey_Call) Return(n int64, err error) *MockFileRepository_CountByRouteKey_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockFileRepository_CountByRouteKey_Call) RunAndReturn(run func(ctx context.Context, storageType string, bucket string, key string, excludeID string) (int64, error)) *MockFileRepository_CountByRouteKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) Create(ctx context.Context, file *model1.File) error {
+	ret := _mock.Called(ctx, file)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model1.File) error); ok {
+		r0 = returnFunc(ctx, file)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFileRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockFileRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - file *model1.File
+func (_e *MockFileRepository_Expecter) Create(ctx any, file any) *MockFileRepository_Create_Call {
+	return &MockFileRepository_Create_Call{Call: _e.mock.On("Create", ctx, file)}
+}
+
+func (_c *MockFileRepository_Create_Call) Run(run func(ctx context.Context, file *model1.File)) *MockFileRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model1.File
+		if args[1] != nil {
+			arg1 = args[1].(*model1.File)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_Create_Call) Return(err error) *MockFileRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFileRepository_Create_Call) RunAndReturn(run func(ctx context.Context, file *model1.File) error) *MockFileRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTemp provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) CreateTemp(ctx context.Context, temp *model1.TempFile) error {
+	ret := _mock.Called(ctx, temp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTemp")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model1.TempFile) error); ok {
+		r0 = returnFunc(ctx, temp)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFileRepository_CreateTemp_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTemp'
+type MockFileRepository_CreateTemp_Call struct {
+	*mock.Call
+}
+
+// CreateTemp is a helper method to define mock.On call
+//   - ctx context.Context
+//   - temp *model1.TempFile
+func (_e *MockFileRepository_Expecter) CreateTemp(ctx any, temp any) *MockFileRepository_CreateTemp_Call {
+	return &MockFileRepository_CreateTemp_Call{Call: _e.mock.On("CreateTemp", ctx, temp)}
+}
+
+func (_c *MockFileRepository_CreateTemp_Call) Run(run func(ctx context.Context, temp *model1.TempFile)) *MockFileRepository_CreateTemp_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model1.TempFile
+		if args[1] != nil {
+			arg1 = args[1].(*model1.TempFile)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_CreateTemp_Call) Return(err error) *MockFileRepository_CreateTemp_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFileRepository_CreateTemp_Call) RunAndReturn(run func(ctx context.Context, temp *model1.TempFile) error) *MockFileRepository_CreateTemp_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) Delete(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFileRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockFileRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockFileRepository_Expecter) Delete(ctx any, id any) *MockFileRepository_Delete_Call {
+	return &MockFileRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockFileRepository_Delete_Call) Run(run func(ctx context.Context, id string)) *MockFileRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_Delete_Call) Return(err error) *MockFileRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFileRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockFileRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByRoute provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) DeleteByRoute(ctx context.Context, storageType string, provider string, bucket string, key string) error {
+	ret := _mock.Called(ctx, storageType, provider, bucket, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByRoute")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = returnFunc(ctx, storageType, provider, bucket, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFileRepository_DeleteByRoute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByRoute'
+type MockFileRepository_DeleteByRoute_Call struct {
+	*mock.Call
+}
+
+// DeleteByRoute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - storageType string
+//   - provider string
+//   - bucket string
+//   - key string
+func (_e *MockFileRepository_Expecter) DeleteByRoute(ctx any, storageType any, provider any, bucket any, key any) *MockFileRepository_DeleteByRoute_Call {
+	return &MockFileRepository_DeleteByRoute_Call{Call: _e.mock.On("DeleteByRoute", ctx, storageType, provider, bucket, key)}
+}
+
+func (_c *MockFileRepository_DeleteByRoute_Call) Run(run func(ctx context.Context, storageType string, provider string, bucket string, key string)) *MockFileRepository_DeleteByRoute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_DeleteByRoute_Call) Return(err error) *MockFileRepository_DeleteByRoute_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFileRepository_DeleteByRoute_Call) RunAndReturn(run func(ctx context.Context, storageType string, provider string, bucket string, key string) error) *MockFileRepository_DeleteByRoute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTempByFileID provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) DeleteTempByFileID(ctx context.Context, fileID string) error {
+	ret := _mock.Called(ctx, fileID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTempByFileID")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, fileID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFileRepository_DeleteTempByFileID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTempByFileID'
+type MockFileRepository_DeleteTempByFileID_Call struct {
+	*mock.Call
+}
+
+// DeleteTempByFileID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fileID string
+func (_e *MockFileRepository_Expecter) DeleteTempByFileID(ctx any, fileID any) *MockFileRepository_DeleteTempByFileID_Call {
+	return &MockFileRepository_DeleteTempByFileID_Call{Call: _e.mock.On("DeleteTempByFileID", ctx, fileID)}
+}
+
+func (_c *MockFileRepository_DeleteTempByFileID_Call) Run(run func(ctx context.Context, fileID string)) *MockFileRepository_DeleteTempByFileID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_DeleteTempByFileID_Call) Return(err error) *MockFileRepository_DeleteTempByFileID_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFileRepository_DeleteTempByFileID_Call) RunAndReturn(run func(ctx context.Context, fileID string) error) *MockFileRepository_DeleteTempByFileID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTempByID provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) DeleteTempByID(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTempByID")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFileRepository_DeleteTempByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTempByID'
+type MockFileRepository_DeleteTempByID_Call struct {
+	*mock.Call
+}
+
+// DeleteTempByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockFileRepository_Expecter) DeleteTempByID(ctx any, id any) *MockFileRepository_DeleteTempByID_Call {
+	return &MockFileRepository_DeleteTempByID_Call{Call: _e.mock.On("DeleteTempByID", ctx, id)}
+}
+
+func (_c *MockFileRepository_DeleteTempByID_Call) Run(run func(ctx context.Context, id string)) *MockFileRepository_DeleteTempByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_DeleteTempByID_Call) Return(err error) *MockFileRepository_DeleteTempByID_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFileRepository_DeleteTempByID_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockFileRepository_DeleteTempByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByContentHash provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) GetByContentHash(ctx context.Context, storageType string, bucket string, hash string) (*model1.File, error) {
+	ret := _mock.Called(ctx, storageType, bucket, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByContentHash")
+	}
+
+	var r0 *model1.File
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*model1.File, error)); ok {
+		return returnFunc(ctx, storageType, bucket, hash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *model1.File); ok {
+		r0 = returnFunc(ctx, storageType, bucket, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model1.File)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, storageType, bucket, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileRepository_GetByContentHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByContentHash'
+type MockFileRepository_GetByContentHash_Call struct {
+	*mock.Call
+}
+
+// GetByContentHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - storageType string
+//   - bucket string
+//   - hash string
+func (_e *MockFileRepository_Expecter) GetByContentHash(ctx any, storageType any, bucket any, hash any) *MockFileRepository_GetByContentHash_Call {
+	return &MockFileRepository_GetByContentHash_Call{Call: _e.mock.On("GetByContentHash", ctx, storageType, bucket, hash)}
+}
+
+func (_c *MockFileRepository_GetByContentHash_Call) Run(run func(ctx context.Context, storageType string, bucket string, hash string)) *MockFileRepository_GetByContentHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_GetByContentHash_Call) Return(file *model1.File, err error) *MockFileRepository_GetByContentHash_Call {
+	_c.Call.Return(file, err)
+	return _c
+}
+
+func (_c *MockFileRepository_GetByContentHash_Call) RunAndReturn(run func(ctx context.Context, storageType string, bucket string, hash string) (*model1.File, error)) *MockFileRepository_GetByContentHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) GetByID(ctx context.Context, id string) (*model1.File, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *model1.File
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model1.File, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model1.File); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model1.File)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockFileRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockFileRepository_Expecter) GetByID(ctx any, id any) *MockFileRepository_GetByID_Call {
+	return &MockFileRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *MockFileRepository_GetByID_Call) Run(run func(ctx context.Context, id string)) *MockFileRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_GetByID_Call) Return(file *model1.File, err error) *MockFileRepository_GetByID_Call {
+	_c.Call.Return(file, err)
+	return _c
+}
+
+func (_c *MockFileRepository_GetByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*model1.File, error)) *MockFileRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByKey provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) GetByKey(ctx context.Context, key string) (*model1.File, error) {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByKey")
+	}
+
+	var r0 *model1.File
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model1.File, error)); ok {
+		return returnFunc(ctx, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model1.File); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model1.File)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileRepository_GetByKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByKey'
+type MockFileRepository_GetByKey_Call struct {
+	*mock.Call
+}
+
+// GetByKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockFileRepository_Expecter) GetByKey(ctx any, key any) *MockFileRepository_GetByKey_Call {
+	return &MockFileRepository_GetByKey_Call{Call: _e.mock.On("GetByKey", ctx, key)}
+}
+
+func (_c *MockFileRepository_GetByKey_Call) Run(run func(ctx context.Context, key string)) *MockFileRepository_GetByKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_GetByKey_Call) Return(file *model1.File, err error) *MockFileRepository_GetByKey_Call {
+	_c.Call.Return(file, err)
+	return _c
+}
+
+func (_c *MockFileRepository_GetByKey_Call) RunAndReturn(run func(ctx context.Context, key string) (*model1.File, error)) *MockFileRepository_GetByKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByRoute provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) GetByRoute(ctx context.Context, storageType string, provider string, bucket string, key string) (*model1.File, error) {
+	ret := _mock.Called(ctx, storageType, provider, bucket, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByRoute")
+	}
+
+	var r0 *model1.File
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) (*model1.File, error)); ok {
+		return returnFunc(ctx, storageType, provider, bucket, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) *model1.File); ok {
+		r0 = returnFunc(ctx, storageType, provider, bucket, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model1.File)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = returnFunc(ctx, storageType, provider, bucket, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileRepository_GetByRoute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByRoute'
+type MockFileRepository_GetByRoute_Call struct {
+	*mock.Call
+}
+
+// GetByRoute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - storageType string
+//   - provider string
+//   - bucket string
+//   - key string
+func (_e *MockFileRepository_Expecter) GetByRoute(ctx any, storageType any, provider any, bucket any, key any) *MockFileRepository_GetByRoute_Call {
+	return &MockFileRepository_GetByRoute_Call{Call: _e.mock.On("GetByRoute", ctx, storageType, provider, bucket, key)}
+}
+
+func (_c *MockFileRepository_GetByRoute_Call) Run(run func(ctx context.Context, storageType string, provider string, bucket string, key string)) *MockFileRepository_GetByRoute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_GetByRoute_Call) Return(file *model1.File, err error) *MockFileRepository_GetByRoute_Call {
+	_c.Call.Return(file, err)
+	return _c
+}
+
+func (_c *MockFileRepository_GetByRoute_Call) RunAndReturn(run func(ctx context.Context, storageType string, provider string, bucket string, key string) (*model1.File, error)) *MockFileRepository_GetByRoute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByContentGroup provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) ListByContentGroup(ctx context.Context, group model1.ContentGroup) ([]model1.File, error) {
+	ret := _mock.Called(ctx, group)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByContentGroup")
+	}
+
+	var r0 []model1.File
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model1.ContentGroup) ([]model1.File, error)); ok {
+		return returnFunc(ctx, group)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model1.ContentGroup) []model1.File); ok {
+		r0 = returnFunc(ctx, group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model1.File)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model1.ContentGroup) error); ok {
+		r1 = returnFunc(ctx, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileRepository_ListByContentGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByContentGroup'
+type MockFileRepository_ListByContentGroup_Call struct {
+	*mock.Call
+}
+
+// ListByContentGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - group model1.ContentGroup
+func (_e *MockFileRepository_Expecter) ListByContentGroup(ctx any, group any) *MockFileRepository_ListByContentGroup_Call {
+	return &MockFileRepository_ListByContentGroup_Call{Call: _e.mock.On("ListByContentGroup", ctx, group)}
+}
+
+func (_c *MockFileRepository_ListByContentGroup_Call) Run(run func(ctx context.Context, group model1.ContentGroup)) *MockFileRepository_ListByContentGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model1.ContentGroup
+		if args[1] != nil {
+			arg1 = args[1].(model1.ContentGroup)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_ListByContentGroup_Call) Return(files []model1.File, err error) *MockFileRepository_ListByContentGroup_Call {
+	_c.Call.Return(files, err)
+	return _c
+}
+
+func (_c *MockFileRepository_ListByContentGroup_Call) RunAndReturn(run func(ctx context.Context, group model1.ContentGroup) ([]model1.File, error)) *MockFileRepository_ListByContentGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByIDs provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) ListByIDs(ctx context.Context, ids []string) ([]model1.File, error) {
+	ret := _mock.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByIDs")
+	}
+
+	var r0 []model1.File
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]model1.File, error)); ok {
+		return returnFunc(ctx, ids)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []model1.File); ok {
+		r0 = returnFunc(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model1.File)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileRepository_ListByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByIDs'
+type MockFileRepository_ListByIDs_Call struct {
+	*mock.Call
+}
+
+// ListByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []string
+func (_e *MockFileRepository_Expecter) ListByIDs(ctx any, ids any) *MockFileRepository_ListByIDs_Call {
+	return &MockFileRepository_ListByIDs_Call{Call: _e.mock.On("ListByIDs", ctx, ids)}
+}
+
+func (_c *MockFileRepository_ListByIDs_Call) Run(run func(ctx context.Context, ids []string)) *MockFileRepository_ListByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_ListByIDs_Call) Return(files []model1.File, err error) *MockFileRepository_ListByIDs_Call {
+	_c.Call.Return(files, err)
+	return _c
+}
+
+func (_c *MockFileRepository_ListByIDs_Call) RunAndReturn(run func(ctx context.Context, ids []string) ([]model1.File, error)) *MockFileRepository_ListByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByRoute provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) ListByRoute(ctx context.Context, storageType string, bucket string, afterID string, limit int) ([]model1.File, error) {
+	ret := _mock.Called(ctx, storageType, bucket, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByRoute")
+	}
+
+	var r0 []model1.File
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, int) ([]model1.File, error)); ok {
+		return returnFunc(ctx, storageType, bucket, afterID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, int) []model1.File); ok {
+		r0 = returnFunc(ctx, storageType, bucket, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model1.File)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, int) error); ok {
+		r1 = returnFunc(ctx, storageType, bucket, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileRepository_ListByRoute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByRoute'
+type MockFileRepository_ListByRoute_Call struct {
+	*mock.Call
+}
+
+// ListByRoute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - storageType string
+//   - bucket string
+//   - afterID string
+//   - limit int
+func (_e *MockFileRepository_Expecter) ListByRoute(ctx any, storageType any, bucket any, afterID any, limit any) *MockFileRepository_ListByRoute_Call {
+	return &MockFileRepository_ListByRoute_Call{Call: _e.mock.On("ListByRoute", ctx, storageType, bucket, afterID, limit)}
+}
+
+func (_c *MockFileRepository_ListByRoute_Call) Run(run func(ctx context.Context, storageType string, bucket string, afterID string, limit int)) *MockFileRepository_ListByRoute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 int
+		if args[4] != nil {
+			arg4 = args[4].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_ListByRoute_Call) Return(files []model1.File, err error) *MockFileRepository_ListByRoute_Call {
+	_c.Call.Return(files, err)
+	return _c
+}
+
+func (_c *MockFileRepository_ListByRoute_Call) RunAndReturn(run func(ctx context.Context, storageType string, bucket string, afterID string, limit int) ([]model1.File, error)) *MockFileRepository_ListByRoute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByStorageTypeAndKeys provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) ListByStorageTypeAndKeys(ctx context.Context, storageType string, keys []string) ([]model1.File, error) {
+	ret := _mock.Called(ctx, storageType, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByStorageTypeAndKeys")
+	}
+
+	var r0 []model1.File
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) ([]model1.File, error)); ok {
+		return returnFunc(ctx, storageType, keys)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) []model1.File); ok {
+		r0 = returnFunc(ctx, storageType, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model1.File)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = returnFunc(ctx, storageType, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileRepository_ListByStorageTypeAndKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByStorageTypeAndKeys'
+type MockFileRepository_ListByStorageTypeAndKeys_Call struct {
+	*mock.Call
+}
+
+// ListByStorageTypeAndKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - storageType string
+//   - keys []string
+func (_e *MockFileRepository_Expecter) ListByStorageTypeAndKeys(ctx any, storageType any, keys any) *MockFileRepository_ListByStorageTypeAndKeys_Call {
+	return &MockFileRepository_ListByStorageTypeAndKeys_Call{Call: _e.mock.On("ListByStorageTypeAndKeys", ctx, storageType, keys)}
+}
+
+func (_c *MockFileRepository_ListByStorageTypeAndKeys_Call) Run(run func(ctx context.Context, storageType string, keys []string)) *MockFileRepository_ListByStorageTypeAndKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_ListByStorageTypeAndKeys_Call) Return(files []model1.File, err error) *MockFileRepository_ListByStorageTypeAndKeys_Call {
+	_c.Call.Return(files, err)
+	return _c
+}
+
+func (_c *MockFileRepository_ListByStorageTypeAndKeys_Call) RunAndReturn(run func(ctx context.Context, storageType string, keys []string) ([]model1.File, error)) *MockFileRepository_ListByStorageTypeAndKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByStorageTypeAndSearch provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) ListByStorageTypeAndSearch(ctx context.Context, storageType string, search string, page int, pageSize int) ([]model1.File, int64, error) {
+	ret := _mock.Called(ctx, storageType, search, page, pageSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByStorageTypeAndSearch")
+	}
+
+	var r0 []model1.File
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int, int) ([]model1.File, int64, error)); ok {
+		return returnFunc(ctx, storageType, search, page, pageSize)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int, int) []model1.File); ok {
+		r0 = returnFunc(ctx, storageType, search, page, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model1.File)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, int, int) int64); ok {
+		r1 = returnFunc(ctx, storageType, search, page, pageSize)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, int, int) error); ok {
+		r2 = returnFunc(ctx, storageType, search, page, pageSize)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockFileRepository_ListByStorageTypeAndSearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByStorageTypeAndSearch'
+type MockFileRepository_ListByStorageTypeAndSearch_Call struct {
+	*mock.Call
+}
+
+// ListByStorageTypeAndSearch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - storageType string
+//   - search string
+//   - page int
+//   - pageSize int
+func (_e *MockFileRepository_Expecter) ListByStorageTypeAndSearch(ctx any, storageType any, search any, page any, pageSize any) *MockFileRepository_ListByStorageTypeAndSearch_Call {
+	return &MockFileRepository_ListByStorageTypeAndSearch_Call{Call: _e.mock.On("ListByStorageTypeAndSearch", ctx, storageType, search, page, pageSize)}
 }
 
-func (_c *MockFileRepository_DeleteByRoute_Call) Run(run func(ctx context.Context, storageType string, provider string, bucket string, key string)) *MockFileRepository_DeleteByRoute_Call {
+func (_c *MockFileRepository_ListByStorageTypeAndSearch_Call) Run(run func(ctx context.Context, storageType string, search string, page int, pageSize int)) *MockFileRepository_ListByStorageTypeAndSearch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1295,13 +3322,13 @@ func (_c *MockFileRepository_DeleteByRoute_Call) Run(run func(ctx context.Contex
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
-		var arg3 string
+		var arg3 int
 		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg3 = args[3].(int)
 		}
-		var arg4 string
+		var arg4 int
 		if args[4] != nil {
-			arg4 = args[4].(string)
+			arg4 = args[4].(int)
 		}
 		run(
 			arg0,
@@ -1314,46 +3341,58 @@ func (_c *MockFileRepository_DeleteByRoute_Call) Run(run func(ctx context.Contex
 	return _c
 }
 
-func (_c *MockFileRepository_DeleteByRoute_Call) Return(err error) *MockFileRepository_DeleteByRoute_Call {
-	_c.Call.Return(err)
+func (_c *MockFileRepository_ListByStorageTypeAndSearch_Call) Return(files []model1.File, n int64, err error) *MockFileRepository_ListByStorageTypeAndSearch_Call {
+	_c.Call.Return(files, n, err)
 	return _c
 }
 
-func (_c *MockFileRepository_DeleteByRoute_Call) RunAndReturn(run func(ctx context.Context, storageType string, provider string, bucket string, key string) error) *MockFileRepository_DeleteByRoute_Call {
+func (_c *MockFileRepository_ListByStorageTypeAndSearch_Call) RunAndReturn(run func(ctx context.Context, storageType string, search string, page int, pageSize int) ([]model1.File, int64, error)) *MockFileRepository_ListByStorageTypeAndSearch_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteTempByFileID provides a mock function for the type MockFileRepository
-func (_mock *MockFileRepository) DeleteTempByFileID(ctx context.Context, fileID string) error {
-	ret := _mock.Called(ctx, fileID)
+// ListByStorageTypeAndURLs provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) ListByStorageTypeAndURLs(ctx context.Context, storageType string, urls []string) ([]model1.File, error) {
+	ret := _mock.Called(ctx, storageType, urls)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteTempByFileID")
+		panic("no return value specified for ListByStorageTypeAndURLs")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, fileID)
+	var r0 []model1.File
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) ([]model1.File, error)); ok {
+		return returnFunc(ctx, storageType, urls)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) []model1.File); ok {
+		r0 = returnFunc(ctx, storageType, urls)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model1.File)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = returnFunc(ctx, storageType, urls)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockFileRepository_DeleteTempByFileID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTempByFileID'
-type MockFileRepository_DeleteTempByFileID_Call struct {
+// MockFileRepository_ListByStorageTypeAndURLs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByStorageTypeAndURLs'
+type MockFileRepository_ListByStorageTypeAndURLs_Call struct {
 	*mock.Call
 }
 
-// DeleteTempByFileID is a helper method to define mock.On call
+// ListByStorageTypeAndURLs is a helper method to define mock.On call
 //   - ctx context.Context
-//   - fileID string
-func (_e *MockFileRepository_Expecter) DeleteTempByFileID(ctx any, fileID any) *MockFileRepository_DeleteTempByFileID_Call {
-	return &MockFileRepository_DeleteTempByFileID_Call{Call: _e.mock.On("DeleteTempByFileID", ctx, fileID)}
+//   - storageType string
+//   - urls []string
+func (_e *MockFileRepository_Expecter) ListByStorageTypeAndURLs(ctx any, storageType any, urls any) *MockFileRepository_ListByStorageTypeAndURLs_Call {
+	return &MockFileRepository_ListByStorageTypeAndURLs_Call{Call: _e.mock.On("ListByStorageTypeAndURLs", ctx, storageType, urls)}
 }
 
-func (_c *MockFileRepository_DeleteTempByFileID_Call) Run(run func(ctx context.Context, fileID string)) *MockFileRepository_DeleteTempByFileID_Call {
+func (_c *MockFileRepository_ListByStorageTypeAndURLs_Call) Run(run func(ctx context.Context, storageType string, urls []string)) *MockFileRepository_ListByStorageTypeAndURLs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1363,130 +3402,140 @@ func (_c *MockFileRepository_DeleteTempByFileID_Call) Run(run func(ctx context.C
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockFileRepository_DeleteTempByFileID_Call) Return(err error) *MockFileRepository_DeleteTempByFileID_Call {
-	_c.Call.Return(err)
+func (_c *MockFileRepository_ListByStorageTypeAndURLs_Call) Return(files []model1.File, err error) *MockFileRepository_ListByStorageTypeAndURLs_Call {
+	_c.Call.Return(files, err)
 	return _c
 }
 
-func (_c *MockFileRepository_DeleteTempByFileID_Call) RunAndReturn(run func(ctx context.Context, fileID string) error) *MockFileRepository_DeleteTempByFileID_Call {
+func (_c *MockFileRepository_ListByStorageTypeAndURLs_Call) RunAndReturn(run func(ctx context.Context, storageType string, urls []string) ([]model1.File, error)) *MockFileRepository_ListByStorageTypeAndURLs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteTempByID provides a mock function for the type MockFileRepository
-func (_mock *MockFileRepository) DeleteTempByID(ctx context.Context, id string) error {
-	ret := _mock.Called(ctx, id)
+// ListDuplicateContentGroups provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) ListDuplicateContentGroups(ctx context.Context) ([]model1.ContentGroup, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteTempByID")
+		panic("no return value specified for ListDuplicateContentGroups")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, id)
+	var r0 []model1.ContentGroup
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]model1.ContentGroup, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []model1.ContentGroup); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model1.ContentGroup)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockFileRepository_DeleteTempByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTempByID'
-type MockFileRepository_DeleteTempByID_Call struct {
+// MockFileRepository_ListDuplicateContentGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDuplicateContentGroups'
+type MockFileRepository_ListDuplicateContentGroups_Call struct {
 	*mock.Call
 }
 
-// DeleteTempByID is a helper method to define mock.On call
+// ListDuplicateContentGroups is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockFileRepository_Expecter) DeleteTempByID(ctx any, id any) *MockFileRepository_DeleteTempByID_Call {
-	return &MockFileRepository_DeleteTempByID_Call{Call: _e.mock.On("DeleteTempByID", ctx, id)}
+func (_e *MockFileRepository_Expecter) ListDuplicateContentGroups(ctx any) *MockFileRepository_ListDuplicateContentGroups_Call {
+	return &MockFileRepository_ListDuplicateContentGroups_Call{Call: _e.mock.On("ListDuplicateContentGroups", ctx)}
 }
 
-func (_c *MockFileRepository_DeleteTempByID_Call) Run(run func(ctx context.Context, id string)) *MockFileRepository_DeleteTempByID_Call {
+func (_c *MockFileRepository_ListDuplicateContentGroups_Call) Run(run func(ctx context.Context)) *MockFileRepository_ListDuplicateContentGroups_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockFileRepository_DeleteTempByID_Call) Return(err error) *MockFileRepository_DeleteTempByID_Call {
-	_c.Call.Return(err)
+func (_c *MockFileRepository_ListDuplicateContentGroups_Call) Return(contentGroups []model1.ContentGroup, err error) *MockFileRepository_ListDuplicateContentGroups_Call {
+	_c.Call.Return(contentGroups, err)
 	return _c
 }
 
-func (_c *MockFileRepository_DeleteTempByID_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockFileRepository_DeleteTempByID_Call {
+func (_c *MockFileRepository_ListDuplicateContentGroups_Call) RunAndReturn(run func(ctx context.Context) ([]model1.ContentGroup, error)) *MockFileRepository_ListDuplicateContentGroups_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByID provides a mock function for the type MockFileRepository
-func (_mock *MockFileRepository) GetByID(ctx context.Context, id string) (*model1.File, error) {
-	ret := _mock.Called(ctx, id)
+// ListExpiredTemps provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) ListExpiredTemps(ctx context.Context, before int64) ([]model1.TempFile, error) {
+	ret := _mock.Called(ctx, before)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByID")
+		panic("no return value specified for ListExpiredTemps")
 	}
 
-	var r0 *model1.File
+	var r0 []model1.TempFile
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model1.File, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) ([]model1.TempFile, error)); ok {
+		return returnFunc(ctx, before)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model1.File); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) []model1.TempFile); ok {
+		r0 = returnFunc(ctx, before)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model1.File)
+			r0 = ret.Get(0).([]model1.TempFile)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, before)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockFileRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
-type MockFileRepository_GetByID_Call struct {
+// MockFileRepository_ListExpiredTemps_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExpiredTemps'
+type MockFileRepository_ListExpiredTemps_Call struct {
 	*mock.Call
 }
 
-// GetByID is a helper method to define mock.On call
+// ListExpiredTemps is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockFileRepository_Expecter) GetByID(ctx any, id any) *MockFileRepository_GetByID_Call {
-	return &MockFileRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+//   - before int64
+func (_e *MockFileRepository_Expecter) ListExpiredTemps(ctx any, before any) *MockFileRepository_ListExpiredTemps_Call {
+	return &MockFileRepository_ListExpiredTemps_Call{Call: _e.mock.On("ListExpiredTemps", ctx, before)}
 }
 
-func (_c *MockFileRepository_GetByID_Call) Run(run func(ctx context.Context, id string)) *MockFileRepository_GetByID_Call {
+func (_c *MockFileRepository_ListExpiredTemps_Call) Run(run func(ctx context.Context, before int64)) *MockFileRepository_ListExpiredTemps_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 int64
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(int64)
 		}
 		run(
 			arg0,
@@ -1496,57 +3545,58 @@ func (_c *MockFileRepository_GetByID_Call) Run(run func(ctx context.Context, id
 	return _c
 }
 
-func (_c *MockFileRepository_GetByID_Call) Return(file *model1.File, err error) *MockFileRepository_GetByID_Call {
-	_c.Call.Return(file, err)
+func (_c *MockFileRepository_ListExpiredTemps_Call) Return(tempFiles []model1.TempFile, err error) *MockFileRepository_ListExpiredTemps_Call {
+	_c.Call.Return(tempFiles, err)
 	return _c
 }
 
-func (_c *MockFileRepository_GetByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*model1.File, error)) *MockFileRepository_GetByID_Call {
+func (_c *MockFileRepository_ListExpiredTemps_Call) RunAndReturn(run func(ctx context.Context, before int64) ([]model1.TempFile, error)) *MockFileRepository_ListExpiredTemps_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByKey provides a mock function for the type MockFileRepository
-func (_mock *MockFileRepository) GetByKey(ctx context.Context, key string) (*model1.File, error) {
-	ret := _mock.Called(ctx, key)
+// ListUnhashed provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) ListUnhashed(ctx context.Context, afterID string, limit int) ([]model1.File, error) {
+	ret := _mock.Called(ctx, afterID, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByKey")
+		panic("no return value specified for ListUnhashed")
 	}
 
-	var r0 *model1.File
+	var r0 []model1.File
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model1.File, error)); ok {
-		return returnFunc(ctx, key)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]model1.File, error)); ok {
+		return returnFunc(ctx, afterID, limit)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model1.File); ok {
-		r0 = returnFunc(ctx, key)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []model1.File); ok {
+		r0 = returnFunc(ctx, afterID, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model1.File)
+			r0 = ret.Get(0).([]model1.File)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, key)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, afterID, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockFileRepository_GetByKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByKey'
-type MockFileRepository_GetByKey_Call struct {
+// MockFileRepository_ListUnhashed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUnhashed'
+type MockFileRepository_ListUnhashed_Call struct {
 	*mock.Call
 }
 
-// GetByKey is a helper method to define mock.On call
+// ListUnhashed is a helper method to define mock.On call
 //   - ctx context.Context
-//   - key string
-func (_e *MockFileRepository_Expecter) GetByKey(ctx any, key any) *MockFileRepository_GetByKey_Call {
-	return &MockFileRepository_GetByKey_Call{Call: _e.mock.On("GetByKey", ctx, key)}
+//   - afterID string
+//   - limit int
+func (_e *MockFileRepository_Expecter) ListUnhashed(ctx any, afterID any, limit any) *MockFileRepository_ListUnhashed_Call {
+	return &MockFileRepository_ListUnhashed_Call{Call: _e.mock.On("ListUnhashed", ctx, afterID, limit)}
 }
 
-func (_c *MockFileRepository_GetByKey_Call) Run(run func(ctx context.Context, key string)) *MockFileRepository_GetByKey_Call {
+func (_c *MockFileRepository_ListUnhashed_Call) Run(run func(ctx context.Context, afterID string, limit int)) *MockFileRepository_ListUnhashed_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1556,159 +3606,129 @@ func (_c *MockFileRepository_GetByKey_Call) Run(run func(ctx context.Context, ke
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockFileRepository_GetByKey_Call) Return(file *model1.File, err error) *MockFileRepository_GetByKey_Call {
-	_c.Call.Return(file, err)
+func (_c *MockFileRepository_ListUnhashed_Call) Return(files []model1.File, err error) *MockFileRepository_ListUnhashed_Call {
+	_c.Call.Return(files, err)
 	return _c
 }
 
-func (_c *MockFileRepository_GetByKey_Call) RunAndReturn(run func(ctx context.Context, key string) (*model1.File, error)) *MockFileRepository_GetByKey_Call {
+func (_c *MockFileRepository_ListUnhashed_Call) RunAndReturn(run func(ctx context.Context, afterID string, limit int) ([]model1.File, error)) *MockFileRepository_ListUnhashed_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByRoute provides a mock function for the type MockFileRepository
-func (_mock *MockFileRepository) GetByRoute(ctx context.Context, storageType string, provider string, bucket string, key string) (*model1.File, error) {
-	ret := _mock.Called(ctx, storageType, provider, bucket, key)
+// ListUsage provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) ListUsage(ctx context.Context) ([]model1.StorageUsage, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByRoute")
+		panic("no return value specified for ListUsage")
 	}
 
-	var r0 *model1.File
+	var r0 []model1.StorageUsage
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) (*model1.File, error)); ok {
-		return returnFunc(ctx, storageType, provider, bucket, key)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]model1.StorageUsage, error)); ok {
+		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) *model1.File); ok {
-		r0 = returnFunc(ctx, storageType, provider, bucket, key)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []model1.StorageUsage); ok {
+		r0 = returnFunc(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model1.File)
+			r0 = ret.Get(0).([]model1.StorageUsage)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
-		r1 = returnFunc(ctx, storageType, provider, bucket, key)
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockFileRepository_GetByRoute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByRoute'
-type MockFileRepository_GetByRoute_Call struct {
+// MockFileRepository_ListUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsage'
+type MockFileRepository_ListUsage_Call struct {
 	*mock.Call
 }
 
-// GetByRoute is a helper method to define mock.On call
+// ListUsage is a helper method to define mock.On call
 //   - ctx context.Context
-//   - storageType string
-//   - provider string
-//   - bucket string
-//   - key string
-func (_e *MockFileRepository_Expecter) GetByRoute(ctx any, storageType any, provider any, bucket any, key any) *MockFileRepository_GetByRoute_Call {
-	return &MockFileRepository_GetByRoute_Call{Call: _e.mock.On("GetByRoute", ctx, storageType, provider, bucket, key)}
+func (_e *MockFileRepository_Expecter) ListUsage(ctx any) *MockFileRepository_ListUsage_Call {
+	return &MockFileRepository_ListUsage_Call{Call: _e.mock.On("ListUsage", ctx)}
 }
 
-func (_c *MockFileRepository_GetByRoute_Call) Run(run func(ctx context.Context, storageType string, provider string, bucket string, key string)) *MockFileRepository_GetByRoute_Call {
+func (_c *MockFileRepository_ListUsage_Call) Run(run func(ctx context.Context)) *MockFileRepository_ListUsage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
-		var arg4 string
-		if args[4] != nil {
-			arg4 = args[4].(string)
-		}
 		run(
 			arg0,
-			arg1,
-			arg2,
-			arg3,
-			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockFileRepository_GetByRoute_Call) Return(file *model1.File, err error) *MockFileRepository_GetByRoute_Call {
-	_c.Call.Return(file, err)
-	return _c
-}
-
-func (_c *MockFileRepository_GetByRoute_Call) RunAndReturn(run func(ctx context.Context, storageType string, provider string, bucket string, key string) (*model1.File, error)) *MockFileRepository_GetByRoute_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
-// ListByIDs provides a mock function for the type MockFileRepository
-func (_mock *MockFileRepository) ListByIDs(ctx context.Context, ids []string) ([]model1.File, error) {
-	ret := _mock.Called(ctx, ids)
-
-	if len(ret) == 0 {
-		panic("no return value specified for ListByIDs")
-	}
-
-	var r0 []model1.File
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]model1.File, error)); ok {
-		return returnFunc(ctx, ids)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []model1.File); ok {
-		r0 = returnFunc(ctx, ids)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model1.File)
-		}
+func (_c *MockFileRepository_ListUsage_Call) Return(storageUsages []model1.StorageUsage, err error) *MockFileRepository_ListUsage_Call {
+	_c.Call.Return(storageUsages, err)
+	return _c
+}
+
+func (_c *MockFileRepository_ListUsage_Call) RunAndReturn(run func(ctx context.Context) ([]model1.StorageUsage, error)) *MockFileRepository_ListUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceUsage provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) ReplaceUsage(ctx context.Context, usages []model1.StorageUsage) error {
+	ret := _mock.Called(ctx, usages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceUsage")
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
-		r1 = returnFunc(ctx, ids)
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []model1.StorageUsage) error); ok {
+		r0 = returnFunc(ctx, usages)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockFileRepository_ListByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByIDs'
-type MockFileRepository_ListByIDs_Call struct {
+// MockFileRepository_ReplaceUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceUsage'
+type MockFileRepository_ReplaceUsage_Call struct {
 	*mock.Call
 }
 
-// ListByIDs is a helper method to define mock.On call
+// ReplaceUsage is a helper method to define mock.On call
 //   - ctx context.Context
-//   - ids []string
-func (_e *MockFileRepository_Expecter) ListByIDs(ctx any, ids any) *MockFileRepository_ListByIDs_Call {
-	return &MockFileRepository_ListByIDs_Call{Call: _e.mock.On("ListByIDs", ctx, ids)}
+//   - usages []model1.StorageUsage
+func (_e *MockFileRepository_Expecter) ReplaceUsage(ctx any, usages any) *MockFileRepository_ReplaceUsage_Call {
+	return &MockFileRepository_ReplaceUsage_Call{Call: _e.mock.On("ReplaceUsage", ctx, usages)}
 }
 
-func (_c *MockFileRepository_ListByIDs_Call) Run(run func(ctx context.Context, ids []string)) *MockFileRepository_ListByIDs_Call {
+func (_c *MockFileRepository_ReplaceUsage_Call) Run(run func(ctx context.Context, usages []model1.StorageUsage)) *MockFileRepository_ReplaceUsage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []string
+		var arg1 []model1.StorageUsage
 		if args[1] != nil {
-			arg1 = args[1].([]string)
+			arg1 = args[1].([]model1.StorageUsage)
 		}
 		run(
 			arg0,
@@ -1718,58 +3738,55 @@ func (_c *MockFileRepository_ListByIDs_Call) Run(run func(ctx context.Context, i
 	return _c
 }
 
-func (_c *MockFileRepository_ListByIDs_Call) Return(files []model1.File, err error) *MockFileRepository_ListByIDs_Call {
-	_c.Call.Return(files, err)
+func (_c *MockFileRepository_ReplaceUsage_Call) Return(err error) *MockFileRepository_ReplaceUsage_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockFileRepository_ListByIDs_Call) RunAndReturn(run func(ctx context.Context, ids []string) ([]model1.File, error)) *MockFileRepository_ListByIDs_Call {
+func (_c *MockFileRepository_ReplaceUsage_Call) RunAndReturn(run func(ctx context.Context, usages []model1.StorageUsage) error) *MockFileRepository_ReplaceUsage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListByStorageTypeAndKeys provides a mock function for the type MockFileRepository
-func (_mock *MockFileRepository) ListByStorageTypeAndKeys(ctx context.Context, storageType string, keys []string) ([]model1.File, error) {
-	ret := _mock.Called(ctx, storageType, keys)
+// SumUsageByUser provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) SumUsageByUser(ctx context.Context, userID string) (int64, error) {
+	ret := _mock.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListByStorageTypeAndKeys")
+		panic("no return value specified for SumUsageByUser")
 	}
 
-	var r0 []model1.File
+	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) ([]model1.File, error)); ok {
-		return returnFunc(ctx, storageType, keys)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return returnFunc(ctx, userID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) []model1.File); ok {
-		r0 = returnFunc(ctx, storageType, keys)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = returnFunc(ctx, userID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model1.File)
-		}
+		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
-		r1 = returnFunc(ctx, storageType, keys)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockFileRepository_ListByStorageTypeAndKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByStorageTypeAndKeys'
-type MockFileRepository_ListByStorageTypeAndKeys_Call struct {
+// MockFileRepository_SumUsageByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SumUsageByUser'
+type MockFileRepository_SumUsageByUser_Call struct {
 	*mock.Call
 }
 
-// ListByStorageTypeAndKeys is a helper method to define mock.On call
+// SumUsageByUser is a helper method to define mock.On call
 //   - ctx context.Context
-//   - storageType string
-//   - keys []string
-func (_e *MockFileRepository_Expecter) ListByStorageTypeAndKeys(ctx any, storageType any, keys any) *MockFileRepository_ListByStorageTypeAndKeys_Call {
-	return &MockFileRepository_ListByStorageTypeAndKeys_Call{Call: _e.mock.On("ListByStorageTypeAndKeys", ctx, storageType, keys)}
+//   - userID string
+func (_e *MockFileRepository_Expecter) SumUsageByUser(ctx any, userID any) *MockFileRepository_SumUsageByUser_Call {
+	return &MockFileRepository_SumUsageByUser_Call{Call: _e.mock.On("SumUsageByUser", ctx, userID)}
 }
 
-func (_c *MockFileRepository_ListByStorageTypeAndKeys_Call) Run(run func(ctx context.Context, storageType string, keys []string)) *MockFileRepository_ListByStorageTypeAndKeys_Call {
+func (_c *MockFileRepository_SumUsageByUser_Call) Run(run func(ctx context.Context, userID string)) *MockFileRepository_SumUsageByUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1779,79 +3796,55 @@ func (_c *MockFileRepository_ListByStorageTypeAndKeys_Call) Run(run func(ctx con
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 []string
-		if args[2] != nil {
-			arg2 = args[2].([]string)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockFileRepository_ListByStorageTypeAndKeys_Call) Return(files []model1.File, err error) *MockFileRepository_ListByStorageTypeAndKeys_Call {
-	_c.Call.Return(files, err)
+func (_c *MockFileRepository_SumUsageByUser_Call) Return(n int64, err error) *MockFileRepository_SumUsageByUser_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockFileRepository_ListByStorageTypeAndKeys_Call) RunAndReturn(run func(ctx context.Context, storageType string, keys []string) ([]model1.File, error)) *MockFileRepository_ListByStorageTypeAndKeys_Call {
+func (_c *MockFileRepository_SumUsageByUser_Call) RunAndReturn(run func(ctx context.Context, userID string) (int64, error)) *MockFileRepository_SumUsageByUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListByStorageTypeAndSearch provides a mock function for the type MockFileRepository
-func (_mock *MockFileRepository) ListByStorageTypeAndSearch(ctx context.Context, storageType string, search string, page int, pageSize int) ([]model1.File, int64, error) {
-	ret := _mock.Called(ctx, storageType, search, page, pageSize)
+// UpdateAltTextByID provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) UpdateAltTextByID(ctx context.Context, id string, altText string) error {
+	ret := _mock.Called(ctx, id, altText)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListByStorageTypeAndSearch")
+		panic("no return value specified for UpdateAltTextByID")
 	}
 
-	var r0 []model1.File
-	var r1 int64
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int, int) ([]model1.File, int64, error)); ok {
-		return returnFunc(ctx, storageType, search, page, pageSize)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int, int) []model1.File); ok {
-		r0 = returnFunc(ctx, storageType, search, page, pageSize)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model1.File)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, int, int) int64); ok {
-		r1 = returnFunc(ctx, storageType, search, page, pageSize)
-	} else {
-		r1 = ret.Get(1).(int64)
-	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, int, int) error); ok {
-		r2 = returnFunc(ctx, storageType, search, page, pageSize)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, id, altText)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
-	return r0, r1, r2
+	return r0
 }
 
-// MockFileRepository_ListByStorageTypeAndSearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByStorageTypeAndSearch'
-type MockFileRepository_ListByStorageTypeAndSearch_Call struct {
+// MockFileRepository_UpdateAltTextByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAltTextByID'
+type MockFileRepository_UpdateAltTextByID_Call struct {
 	*mock.Call
 }
 
-// ListByStorageTypeAndSearch is a helper method to define mock.On call
+// UpdateAltTextByID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - storageType string
-//   - search string
-//   - page int
-//   - pageSize int
-func (_e *MockFileRepository_Expecter) ListByStorageTypeAndSearch(ctx any, storageType any, search any, page any, pageSize any) *MockFileRepository_ListByStorageTypeAndSearch_Call {
-	return &MockFileRepository_ListByStorageTypeAndSearch_Call{Call: _e.mock.On("ListByStorageTypeAndSearch", ctx, storageType, search, page, pageSize)}
+//   - id string
+//   - altText string
+func (_e *MockFileRepository_Expecter) UpdateAltTextByID(ctx any, id any, altText any) *MockFileRepository_UpdateAltTextByID_Call {
+	return &MockFileRepository_UpdateAltTextByID_Call{Call: _e.mock.On("UpdateAltTextByID", ctx, id, altText)}
 }
 
-func (_c *MockFileRepository_ListByStorageTypeAndSearch_Call) Run(run func(ctx context.Context, storageType string, search string, page int, pageSize int)) *MockFileRepository_ListByStorageTypeAndSearch_Call {
+func (_c *MockFileRepository_UpdateAltTextByID_Call) Run(run func(ctx context.Context, id string, altText string)) *MockFileRepository_UpdateAltTextByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1865,77 +3858,56 @@ func (_c *MockFileRepository_ListByStorageTypeAndSearch_Call) Run(run func(ctx c
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
-		var arg3 int
-		if args[3] != nil {
-			arg3 = args[3].(int)
-		}
-		var arg4 int
-		if args[4] != nil {
-			arg4 = args[4].(int)
-		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
-			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockFileRepository_ListByStorageTypeAndSearch_Call) Return(files []model1.File, n int64, err error) *MockFileRepository_ListByStorageTypeAndSearch_Call {
-	_c.Call.Return(files, n, err)
+func (_c *MockFileRepository_UpdateAltTextByID_Call) Return(err error) *MockFileRepository_UpdateAltTextByID_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockFileRepository_ListByStorageTypeAndSearch_Call) RunAndReturn(run func(ctx context.Context, storageType string, search string, page int, pageSize int) ([]model1.File, int64, error)) *MockFileRepository_ListByStorageTypeAndSearch_Call {
+func (_c *MockFileRepository_UpdateAltTextByID_Call) RunAndReturn(run func(ctx context.Context, id string, altText string) error) *MockFileRepository_UpdateAltTextByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListByStorageTypeAndURLs provides a mock function for the type MockFileRepository
-func (_mock *MockFileRepository) ListByStorageTypeAndURLs(ctx context.Context, storageType string, urls []string) ([]model1.File, error) {
-	ret := _mock.Called(ctx, storageType, urls)
+// UpdateHashByID provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) UpdateHashByID(ctx context.Context, id string, hash string) error {
+	ret := _mock.Called(ctx, id, hash)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListByStorageTypeAndURLs")
+		panic("no return value specified for UpdateHashByID")
 	}
 
-	var r0 []model1.File
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) ([]model1.File, error)); ok {
-		return returnFunc(ctx, storageType, urls)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) []model1.File); ok {
-		r0 = returnFunc(ctx, storageType, urls)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model1.File)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
-		r1 = returnFunc(ctx, storageType, urls)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, id, hash)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockFileRepository_ListByStorageTypeAndURLs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByStorageTypeAndURLs'
-type MockFileRepository_ListByStorageTypeAndURLs_Call struct {
+// MockFileRepository_UpdateHashByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateHashByID'
+type MockFileRepository_UpdateHashByID_Call struct {
 	*mock.Call
 }
 
-// ListByStorageTypeAndURLs is a helper method to define mock.On call
+// UpdateHashByID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - storageType string
-//   - urls []string
-func (_e *MockFileRepository_Expecter) ListByStorageTypeAndURLs(ctx any, storageType any, urls any) *MockFileRepository_ListByStorageTypeAndURLs_Call {
-	return &MockFileRepository_ListByStorageTypeAndURLs_Call{Call: _e.mock.On("ListByStorageTypeAndURLs", ctx, storageType, urls)}
+//   - id string
+//   - hash string
+func (_e *MockFileRepository_Expecter) UpdateHashByID(ctx any, id any, hash any) *MockFileRepository_UpdateHashByID_Call {
+	return &MockFileRepository_UpdateHashByID_Call{Call: _e.mock.On("UpdateHashByID", ctx, id, hash)}
 }
 
-func (_c *MockFileRepository_ListByStorageTypeAndURLs_Call) Run(run func(ctx context.Context, storageType string, urls []string)) *MockFileRepository_ListByStorageTypeAndURLs_Call {
+func (_c *MockFileRepository_UpdateHashByID_Call) Run(run func(ctx context.Context, id string, hash string)) *MockFileRepository_UpdateHashByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1945,9 +3917,9 @@ func (_c *MockFileRepository_ListByStorageTypeAndURLs_Call) Run(run func(ctx con
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 []string
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].([]string)
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
@@ -1958,80 +3930,102 @@ func (_c *MockFileRepository_ListByStorageTypeAndURLs_Call) Run(run func(ctx con
 	return _c
 }
 
-func (_c *MockFileRepository_ListByStorageTypeAndURLs_Call) Return(files []model1.File, err error) *MockFileRepository_ListByStorageTypeAndURLs_Call {
-	_c.Call.Return(files, err)
+func (_c *MockFileRepository_UpdateHashByID_Call) Return(err error) *MockFileRepository_UpdateHashByID_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockFileRepository_ListByStorageTypeAndURLs_Call) RunAndReturn(run func(ctx context.Context, storageType string, urls []string) ([]model1.File, error)) *MockFileRepository_ListByStorageTypeAndURLs_Call {
+func (_c *MockFileRepository_UpdateHashByID_Call) RunAndReturn(run func(ctx context.Context, id string, hash string) error) *MockFileRepository_UpdateHashByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListExpiredTemps provides a mock function for the type MockFileRepository
-func (_mock *MockFileRepository) ListExpiredTemps(ctx context.Context, before int64) ([]model1.TempFile, error) {
-	ret := _mock.Called(ctx, before)
+// UpdateKeyByID provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) UpdateKeyByID(ctx context.Context, id string, route model1.StorageRoute, fromKey string, toKey string, url string) (bool, error) {
+	ret := _mock.Called(ctx, id, route, fromKey, toKey, url)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListExpiredTemps")
+		panic("no return value specified for UpdateKeyByID")
 	}
 
-	var r0 []model1.TempFile
+	var r0 bool
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) ([]model1.TempFile, error)); ok {
-		return returnFunc(ctx, before)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model1.StorageRoute, string, string, string) (bool, error)); ok {
+		return returnFunc(ctx, id, route, fromKey, toKey, url)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) []model1.TempFile); ok {
-		r0 = returnFunc(ctx, before)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model1.StorageRoute, string, string, string) bool); ok {
+		r0 = returnFunc(ctx, id, route, fromKey, toKey, url)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model1.TempFile)
-		}
+		r0 = ret.Get(0).(bool)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = returnFunc(ctx, before)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, model1.StorageRoute, string, string, string) error); ok {
+		r1 = returnFunc(ctx, id, route, fromKey, toKey, url)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockFileRepository_ListExpiredTemps_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExpiredTemps'
-type MockFileRepository_ListExpiredTemps_Call struct {
+// MockFileRepository_UpdateKeyByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateKeyByID'
+type MockFileRepository_UpdateKeyByID_Call struct {
 	*mock.Call
 }
 
-// ListExpiredTemps is a helper method to define mock.On call
+// UpdateKeyByID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - before int64
-func (_e *MockFileRepository_Expecter) ListExpiredTemps(ctx any, before any) *MockFileRepository_ListExpiredTemps_Call {
-	return &MockFileRepository_ListExpiredTemps_Call{Call: _e.mock.On("ListExpiredTemps", ctx, before)}
+//   - id string
+//   - route model1.StorageRoute
+//   - fromKey string
+//   - toKey string
+//   - url string
+func (_e *MockFileRepository_Expecter) UpdateKeyByID(ctx any, id any, route any, fromKey any, toKey any, url any) *MockFileRepository_UpdateKeyByID_Call {
+	return &MockFileRepository_UpdateKeyByID_Call{Call: _e.mock.On("UpdateKeyByID", ctx, id, route, fromKey, toKey, url)}
 }
 
-func (_c *MockFileRepository_ListExpiredTemps_Call) Run(run func(ctx context.Context, before int64)) *MockFileRepository_ListExpiredTemps_Call {
+func (_c *MockFileRepository_UpdateKeyByID_Call) Run(run func(ctx context.Context, id string, route model1.StorageRoute, fromKey string, toKey string, url string)) *MockFileRepository_UpdateKeyByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int64
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(int64)
+			arg1 = args[1].(string)
+		}
+		var arg2 model1.StorageRoute
+		if args[2] != nil {
+			arg2 = args[2].(model1.StorageRoute)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
 		)
 	})
 	return _c
 }
 
-func (_c *MockFileRepository_ListExpiredTemps_Call) Return(tempFiles []model1.TempFile, err error) *MockFileRepository_ListExpiredTemps_Call {
-	_c.Call.Return(tempFiles, err)
+func (_c *MockFileRepository_UpdateKeyByID_Call) Return(b bool, err error) *MockFileRepository_UpdateKeyByID_Call {
+	_c.Call.Return(b, err)
 	return _c
 }
 
-func (_c *MockFileRepository_ListExpiredTemps_Call) RunAndReturn(run func(ctx context.Context, before int64) ([]model1.TempFile, error)) *MockFileRepository_ListExpiredTemps_Call {
+func (_c *MockFileRepository_UpdateKeyByID_Call) RunAndReturn(run func(ctx context.Context, id string, route model1.StorageRoute, fromKey string, toKey string, url string) (bool, error)) *MockFileRepository_UpdateKeyByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2127,3 +4121,87 @@ func (_c *MockFileRepository_UpdateMetaByID_Call) RunAndReturn(run func(ctx cont
 	_c.Call.Return(run)
 	return _c
 }
+
+// UpdateRouteByID provides a mock function for the type MockFileRepository
+func (_mock *MockFileRepository) UpdateRouteByID(ctx context.Context, id string, from model1.StorageRoute, to model1.StorageRoute, url string) (bool, error) {
+	ret := _mock.Called(ctx, id, from, to, url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRouteByID")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model1.StorageRoute, model1.StorageRoute, string) (bool, error)); ok {
+		return returnFunc(ctx, id, from, to, url)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model1.StorageRoute, model1.StorageRoute, string) bool); ok {
+		r0 = returnFunc(ctx, id, from, to, url)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, model1.StorageRoute, model1.StorageRoute, string) error); ok {
+		r1 = returnFunc(ctx, id, from, to, url)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileRepository_UpdateRouteByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRouteByID'
+type MockFileRepository_UpdateRouteByID_Call struct {
+	*mock.Call
+}
+
+// UpdateRouteByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - from model1.StorageRoute
+//   - to model1.StorageRoute
+//   - url string
+func (_e *MockFileRepository_Expecter) UpdateRouteByID(ctx any, id any, from any, to any, url any) *MockFileRepository_UpdateRouteByID_Call {
+	return &MockFileRepository_UpdateRouteByID_Call{Call: _e.mock.On("UpdateRouteByID", ctx, id, from, to, url)}
+}
+
+func (_c *MockFileRepository_UpdateRouteByID_Call) Run(run func(ctx context.Context, id string, from model1.StorageRoute, to model1.StorageRoute, url string)) *MockFileRepository_UpdateRouteByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 model1.StorageRoute
+		if args[2] != nil {
+			arg2 = args[2].(model1.StorageRoute)
+		}
+		var arg3 model1.StorageRoute
+		if args[3] != nil {
+			arg3 = args[3].(model1.StorageRoute)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileRepository_UpdateRouteByID_Call) Return(b bool, err error) *MockFileRepository_UpdateRouteByID_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockFileRepository_UpdateRouteByID_Call) RunAndReturn(run func(ctx context.Context, id string, from model1.StorageRoute, to model1.StorageRoute, url string) (bool, error)) *MockFileRepository_UpdateRouteByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
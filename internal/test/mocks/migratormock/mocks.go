@@ -9,6 +9,7 @@ package migratormock
 
 import (
 	"context"
+	"io"
 	"mime/multipart"
 
 	"github.com/gin-gonic/gin"
@@ -163,6 +164,66 @@ func (_c *MockService_CancelGlobalMigration_Call) RunAndReturn(run func(ctx cont
 	return _c
 }
 
+// CancelSync provides a mock function for the type MockService
+func (_mock *MockService) CancelSync(ctx context.Context) (model.SyncStateDTO, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelSync")
+	}
+
+	var r0 model.SyncStateDTO
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (model.SyncStateDTO, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) model.SyncStateDTO); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(model.SyncStateDTO)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_CancelSync_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelSync'
+type MockService_CancelSync_Call struct {
+	*mock.Call
+}
+
+// CancelSync is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockService_Expecter) CancelSync(ctx any) *MockService_CancelSync_Call {
+	return &MockService_CancelSync_Call{Call: _e.mock.On("CancelSync", ctx)}
+}
+
+func (_c *MockService_CancelSync_Call) Run(run func(ctx context.Context)) *MockService_CancelSync_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_CancelSync_Call) Return(syncStateDTO model.SyncStateDTO, err error) *MockService_CancelSync_Call {
+	_c.Call.Return(syncStateDTO, err)
+	return _c
+}
+
+func (_c *MockService_CancelSync_Call) RunAndReturn(run func(ctx context.Context) (model.SyncStateDTO, error)) *MockService_CancelSync_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CleanupGlobalMigration provides a mock function for the type MockService
 func (_mock *MockService) CleanupGlobalMigration(ctx context.Context) error {
 	ret := _mock.Called(ctx)
@@ -215,16 +276,16 @@ func (_c *MockService_CleanupGlobalMigration_Call) RunAndReturn(run func(ctx con
 }
 
 // DownloadExport provides a mock function for the type MockService
-func (_mock *MockService) DownloadExport(ctx *gin.Context, reqCtx context.Context, format string) error {
-	ret := _mock.Called(ctx, reqCtx, format)
+func (_mock *MockService) DownloadExport(ctx *gin.Context, reqCtx context.Context, format string, name string) error {
+	ret := _mock.Called(ctx, reqCtx, format, name)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DownloadExport")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(*gin.Context, context.Context, string) error); ok {
-		r0 = returnFunc(ctx, reqCtx, format)
+	if returnFunc, ok := ret.Get(0).(func(*gin.Context, context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, reqCtx, format, name)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -240,11 +301,12 @@ type MockService_DownloadExport_Call struct {
 //   - ctx *gin.Context
 //   - reqCtx context.Context
 //   - format string
-func (_e *MockService_Expecter) DownloadExport(ctx any, reqCtx any, format any) *MockService_DownloadExport_Call {
-	return &MockService_DownloadExport_Call{Call: _e.mock.On("DownloadExport", ctx, reqCtx, format)}
+//   - name string
+func (_e *MockService_Expecter) DownloadExport(ctx any, reqCtx any, format any, name any) *MockService_DownloadExport_Call {
+	return &MockService_DownloadExport_Call{Call: _e.mock.On("DownloadExport", ctx, reqCtx, format, name)}
 }
 
-func (_c *MockService_DownloadExport_Call) Run(run func(ctx *gin.Context, reqCtx context.Context, format string)) *MockService_DownloadExport_Call {
+func (_c *MockService_DownloadExport_Call) Run(run func(ctx *gin.Context, reqCtx context.Context, format string, name string)) *MockService_DownloadExport_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 *gin.Context
 		if args[0] != nil {
@@ -258,10 +320,15 @@ func (_c *MockService_DownloadExport_Call) Run(run func(ctx *gin.Context, reqCtx
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -272,7 +339,67 @@ func (_c *MockService_DownloadExport_Call) Return(err error) *MockService_Downlo
 	return _c
 }
 
-func (_c *MockService_DownloadExport_Call) RunAndReturn(run func(ctx *gin.Context, reqCtx context.Context, format string) error) *MockService_DownloadExport_Call {
+func (_c *MockService_DownloadExport_Call) RunAndReturn(run func(ctx *gin.Context, reqCtx context.Context, format string, name string) error) *MockService_DownloadExport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEncryptionSetting provides a mock function for the type MockService
+func (_mock *MockService) GetEncryptionSetting(ctx context.Context) (model.EncryptionSetting, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEncryptionSetting")
+	}
+
+	var r0 model.EncryptionSetting
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (model.EncryptionSetting, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) model.EncryptionSetting); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(model.EncryptionSetting)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_GetEncryptionSetting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEncryptionSetting'
+type MockService_GetEncryptionSetting_Call struct {
+	*mock.Call
+}
+
+// GetEncryptionSetting is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockService_Expecter) GetEncryptionSetting(ctx any) *MockService_GetEncryptionSetting_Call {
+	return &MockService_GetEncryptionSetting_Call{Call: _e.mock.On("GetEncryptionSetting", ctx)}
+}
+
+func (_c *MockService_GetEncryptionSetting_Call) Run(run func(ctx context.Context)) *MockService_GetEncryptionSetting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetEncryptionSetting_Call) Return(encryptionSetting model.EncryptionSetting, err error) *MockService_GetEncryptionSetting_Call {
+	_c.Call.Return(encryptionSetting, err)
+	return _c
+}
+
+func (_c *MockService_GetEncryptionSetting_Call) RunAndReturn(run func(ctx context.Context) (model.EncryptionSetting, error)) *MockService_GetEncryptionSetting_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -397,195 +524,1019 @@ func (_c *MockService_GetGlobalMigrationStatus_Call) RunAndReturn(run func(ctx c
 	return _c
 }
 
-// StartExport provides a mock function for the type MockService
-func (_mock *MockService) StartExport(ctx context.Context, req model.StartExportRequest) (model.ExportStateDTO, error) {
-	ret := _mock.Called(ctx, req)
+// GetPublishSetting provides a mock function for the type MockService
+func (_mock *MockService) GetPublishSetting(ctx context.Context) (model.PublishSetting, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for StartExport")
+		panic("no return value specified for GetPublishSetting")
 	}
 
-	var r0 model.ExportStateDTO
+	var r0 model.PublishSetting
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.StartExportRequest) (model.ExportStateDTO, error)); ok {
-		return returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (model.PublishSetting, error)); ok {
+		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.StartExportRequest) model.ExportStateDTO); ok {
-		r0 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) model.PublishSetting); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		r0 = ret.Get(0).(model.ExportStateDTO)
+		r0 = ret.Get(0).(model.PublishSetting)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, model.StartExportRequest) error); ok {
-		r1 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockService_StartExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartExport'
-type MockService_StartExport_Call struct {
+// MockService_GetPublishSetting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPublishSetting'
+type MockService_GetPublishSetting_Call struct {
 	*mock.Call
 }
 
-// StartExport is a helper method to define mock.On call
+// GetPublishSetting is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req model.StartExportRequest
-func (_e *MockService_Expecter) StartExport(ctx any, req any) *MockService_StartExport_Call {
-	return &MockService_StartExport_Call{Call: _e.mock.On("StartExport", ctx, req)}
+func (_e *MockService_Expecter) GetPublishSetting(ctx any) *MockService_GetPublishSetting_Call {
+	return &MockService_GetPublishSetting_Call{Call: _e.mock.On("GetPublishSetting", ctx)}
 }
 
-func (_c *MockService_StartExport_Call) Run(run func(ctx context.Context, req model.StartExportRequest)) *MockService_StartExport_Call {
+func (_c *MockService_GetPublishSetting_Call) Run(run func(ctx context.Context)) *MockService_GetPublishSetting_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 model.StartExportRequest
-		if args[1] != nil {
-			arg1 = args[1].(model.StartExportRequest)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_StartExport_Call) Return(exportStateDTO model.ExportStateDTO, err error) *MockService_StartExport_Call {
-	_c.Call.Return(exportStateDTO, err)
+func (_c *MockService_GetPublishSetting_Call) Return(publishSetting model.PublishSetting, err error) *MockService_GetPublishSetting_Call {
+	_c.Call.Return(publishSetting, err)
 	return _c
 }
 
-func (_c *MockService_StartExport_Call) RunAndReturn(run func(ctx context.Context, req model.StartExportRequest) (model.ExportStateDTO, error)) *MockService_StartExport_Call {
+func (_c *MockService_GetPublishSetting_Call) RunAndReturn(run func(ctx context.Context) (model.PublishSetting, error)) *MockService_GetPublishSetting_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// StartGlobalMigration provides a mock function for the type MockService
-func (_mock *MockService) StartGlobalMigration(ctx context.Context, req model.StartGlobalMigrationRequest) (model.GlobalMigrationStateDTO, error) {
-	ret := _mock.Called(ctx, req)
+// GetPublishStatus provides a mock function for the type MockService
+func (_mock *MockService) GetPublishStatus(ctx context.Context) (model.PublishStateDTO, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for StartGlobalMigration")
+		panic("no return value specified for GetPublishStatus")
 	}
 
-	var r0 model.GlobalMigrationStateDTO
+	var r0 model.PublishStateDTO
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.StartGlobalMigrationRequest) (model.GlobalMigrationStateDTO, error)); ok {
-		return returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (model.PublishStateDTO, error)); ok {
+		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.StartGlobalMigrationRequest) model.GlobalMigrationStateDTO); ok {
-		r0 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) model.PublishStateDTO); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		r0 = ret.Get(0).(model.GlobalMigrationStateDTO)
+		r0 = ret.Get(0).(model.PublishStateDTO)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, model.StartGlobalMigrationRequest) error); ok {
-		r1 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockService_StartGlobalMigration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartGlobalMigration'
-type MockService_StartGlobalMigration_Call struct {
+// MockService_GetPublishStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPublishStatus'
+type MockService_GetPublishStatus_Call struct {
 	*mock.Call
 }
 
-// StartGlobalMigration is a helper method to define mock.On call
+// GetPublishStatus is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req model.StartGlobalMigrationRequest
-func (_e *MockService_Expecter) StartGlobalMigration(ctx any, req any) *MockService_StartGlobalMigration_Call {
-	return &MockService_StartGlobalMigration_Call{Call: _e.mock.On("StartGlobalMigration", ctx, req)}
+func (_e *MockService_Expecter) GetPublishStatus(ctx any) *MockService_GetPublishStatus_Call {
+	return &MockService_GetPublishStatus_Call{Call: _e.mock.On("GetPublishStatus", ctx)}
 }
 
-func (_c *MockService_StartGlobalMigration_Call) Run(run func(ctx context.Context, req model.StartGlobalMigrationRequest)) *MockService_StartGlobalMigration_Call {
+func (_c *MockService_GetPublishStatus_Call) Run(run func(ctx context.Context)) *MockService_GetPublishStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 model.StartGlobalMigrationRequest
-		if args[1] != nil {
-			arg1 = args[1].(model.StartGlobalMigrationRequest)
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetPublishStatus_Call) Return(publishStateDTO model.PublishStateDTO, err error) *MockService_GetPublishStatus_Call {
+	_c.Call.Return(publishStateDTO, err)
+	return _c
+}
+
+func (_c *MockService_GetPublishStatus_Call) RunAndReturn(run func(ctx context.Context) (model.PublishStateDTO, error)) *MockService_GetPublishStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSyncSetting provides a mock function for the type MockService
+func (_mock *MockService) GetSyncSetting(ctx context.Context) (model.SyncSetting, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSyncSetting")
+	}
+
+	var r0 model.SyncSetting
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (model.SyncSetting, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) model.SyncSetting); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(model.SyncSetting)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_GetSyncSetting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSyncSetting'
+type MockService_GetSyncSetting_Call struct {
+	*mock.Call
+}
+
+// GetSyncSetting is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockService_Expecter) GetSyncSetting(ctx any) *MockService_GetSyncSetting_Call {
+	return &MockService_GetSyncSetting_Call{Call: _e.mock.On("GetSyncSetting", ctx)}
+}
+
+func (_c *MockService_GetSyncSetting_Call) Run(run func(ctx context.Context)) *MockService_GetSyncSetting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
 		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_StartGlobalMigration_Call) Return(globalMigrationStateDTO model.GlobalMigrationStateDTO, err error) *MockService_StartGlobalMigration_Call {
-	_c.Call.Return(globalMigrationStateDTO, err)
+func (_c *MockService_GetSyncSetting_Call) Return(syncSetting model.SyncSetting, err error) *MockService_GetSyncSetting_Call {
+	_c.Call.Return(syncSetting, err)
 	return _c
 }
 
-func (_c *MockService_StartGlobalMigration_Call) RunAndReturn(run func(ctx context.Context, req model.StartGlobalMigrationRequest) (model.GlobalMigrationStateDTO, error)) *MockService_StartGlobalMigration_Call {
+func (_c *MockService_GetSyncSetting_Call) RunAndReturn(run func(ctx context.Context) (model.SyncSetting, error)) *MockService_GetSyncSetting_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UploadSourceZip provides a mock function for the type MockService
-func (_mock *MockService) UploadSourceZip(ctx context.Context, sourceType string, file *multipart.FileHeader) (model.UploadMigrationSourceZipResponse, error) {
-	ret := _mock.Called(ctx, sourceType, file)
+// GetSyncStatus provides a mock function for the type MockService
+func (_mock *MockService) GetSyncStatus(ctx context.Context) (model.SyncStateDTO, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UploadSourceZip")
+		panic("no return value specified for GetSyncStatus")
 	}
 
-	var r0 model.UploadMigrationSourceZipResponse
+	var r0 model.SyncStateDTO
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *multipart.FileHeader) (model.UploadMigrationSourceZipResponse, error)); ok {
-		return returnFunc(ctx, sourceType, file)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (model.SyncStateDTO, error)); ok {
+		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *multipart.FileHeader) model.UploadMigrationSourceZipResponse); ok {
-		r0 = returnFunc(ctx, sourceType, file)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) model.SyncStateDTO); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		r0 = ret.Get(0).(model.UploadMigrationSourceZipResponse)
+		r0 = ret.Get(0).(model.SyncStateDTO)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *multipart.FileHeader) error); ok {
-		r1 = returnFunc(ctx, sourceType, file)
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockService_UploadSourceZip_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadSourceZip'
-type MockService_UploadSourceZip_Call struct {
+// MockService_GetSyncStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSyncStatus'
+type MockService_GetSyncStatus_Call struct {
 	*mock.Call
 }
 
-// UploadSourceZip is a helper method to define mock.On call
+// GetSyncStatus is a helper method to define mock.On call
 //   - ctx context.Context
-//   - sourceType string
-//   - file *multipart.FileHeader
-func (_e *MockService_Expecter) UploadSourceZip(ctx any, sourceType any, file any) *MockService_UploadSourceZip_Call {
-	return &MockService_UploadSourceZip_Call{Call: _e.mock.On("UploadSourceZip", ctx, sourceType, file)}
+func (_e *MockService_Expecter) GetSyncStatus(ctx any) *MockService_GetSyncStatus_Call {
+	return &MockService_GetSyncStatus_Call{Call: _e.mock.On("GetSyncStatus", ctx)}
 }
 
-func (_c *MockService_UploadSourceZip_Call) Run(run func(ctx context.Context, sourceType string, file *multipart.FileHeader)) *MockService_UploadSourceZip_Call {
+func (_c *MockService_GetSyncStatus_Call) Run(run func(ctx context.Context)) *MockService_GetSyncStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetSyncStatus_Call) Return(syncStateDTO model.SyncStateDTO, err error) *MockService_GetSyncStatus_Call {
+	_c.Call.Return(syncStateDTO, err)
+	return _c
+}
+
+func (_c *MockService_GetSyncStatus_Call) RunAndReturn(run func(ctx context.Context) (model.SyncStateDTO, error)) *MockService_GetSyncStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSnapshots provides a mock function for the type MockService
+func (_mock *MockService) ListSnapshots(ctx context.Context) ([]model.SnapshotEntry, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSnapshots")
+	}
+
+	var r0 []model.SnapshotEntry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]model.SnapshotEntry, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []model.SnapshotEntry); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SnapshotEntry)
 		}
-		var arg2 *multipart.FileHeader
-		if args[2] != nil {
-			arg2 = args[2].(*multipart.FileHeader)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_ListSnapshots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSnapshots'
+type MockService_ListSnapshots_Call struct {
+	*mock.Call
+}
+
+// ListSnapshots is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockService_Expecter) ListSnapshots(ctx any) *MockService_ListSnapshots_Call {
+	return &MockService_ListSnapshots_Call{Call: _e.mock.On("ListSnapshots", ctx)}
+}
+
+func (_c *MockService_ListSnapshots_Call) Run(run func(ctx context.Context)) *MockService_ListSnapshots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ListSnapshots_Call) Return(snapshotEntrys []model.SnapshotEntry, err error) *MockService_ListSnapshots_Call {
+	_c.Call.Return(snapshotEntrys, err)
+	return _c
+}
+
+func (_c *MockService_ListSnapshots_Call) RunAndReturn(run func(ctx context.Context) ([]model.SnapshotEntry, error)) *MockService_ListSnapshots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PullSyncChanges provides a mock function for the type MockService
+func (_mock *MockService) PullSyncChanges(ctx *gin.Context, reqCtx context.Context, since int64, includePrivate bool) error {
+	ret := _mock.Called(ctx, reqCtx, since, includePrivate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PullSyncChanges")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*gin.Context, context.Context, int64, bool) error); ok {
+		r0 = returnFunc(ctx, reqCtx, since, includePrivate)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_PullSyncChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PullSyncChanges'
+type MockService_PullSyncChanges_Call struct {
+	*mock.Call
+}
+
+// PullSyncChanges is a helper method to define mock.On call
+//   - ctx *gin.Context
+//   - reqCtx context.Context
+//   - since int64
+//   - includePrivate bool
+func (_e *MockService_Expecter) PullSyncChanges(ctx any, reqCtx any, since any, includePrivate any) *MockService_PullSyncChanges_Call {
+	return &MockService_PullSyncChanges_Call{Call: _e.mock.On("PullSyncChanges", ctx, reqCtx, since, includePrivate)}
+}
+
+func (_c *MockService_PullSyncChanges_Call) Run(run func(ctx *gin.Context, reqCtx context.Context, since int64, includePrivate bool)) *MockService_PullSyncChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		var arg1 context.Context
+		if args[1] != nil {
+			arg1 = args[1].(context.Context)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_PullSyncChanges_Call) Return(err error) *MockService_PullSyncChanges_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_PullSyncChanges_Call) RunAndReturn(run func(ctx *gin.Context, reqCtx context.Context, since int64, includePrivate bool) error) *MockService_PullSyncChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PushSyncChanges provides a mock function for the type MockService
+func (_mock *MockService) PushSyncChanges(ctx context.Context, body io.Reader, base int64, policy string, includePrivate bool) (model.SyncApplyResult, error) {
+	ret := _mock.Called(ctx, body, base, policy, includePrivate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PushSyncChanges")
+	}
+
+	var r0 model.SyncApplyResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, io.Reader, int64, string, bool) (model.SyncApplyResult, error)); ok {
+		return returnFunc(ctx, body, base, policy, includePrivate)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, io.Reader, int64, string, bool) model.SyncApplyResult); ok {
+		r0 = returnFunc(ctx, body, base, policy, includePrivate)
+	} else {
+		r0 = ret.Get(0).(model.SyncApplyResult)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, io.Reader, int64, string, bool) error); ok {
+		r1 = returnFunc(ctx, body, base, policy, includePrivate)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_PushSyncChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushSyncChanges'
+type MockService_PushSyncChanges_Call struct {
+	*mock.Call
+}
+
+// PushSyncChanges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - body io.Reader
+//   - base int64
+//   - policy string
+//   - includePrivate bool
+func (_e *MockService_Expecter) PushSyncChanges(ctx any, body any, base any, policy any, includePrivate any) *MockService_PushSyncChanges_Call {
+	return &MockService_PushSyncChanges_Call{Call: _e.mock.On("PushSyncChanges", ctx, body, base, policy, includePrivate)}
+}
+
+func (_c *MockService_PushSyncChanges_Call) Run(run func(ctx context.Context, body io.Reader, base int64, policy string, includePrivate bool)) *MockService_PushSyncChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 io.Reader
+		if args[1] != nil {
+			arg1 = args[1].(io.Reader)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 bool
+		if args[4] != nil {
+			arg4 = args[4].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_PushSyncChanges_Call) Return(syncApplyResult model.SyncApplyResult, err error) *MockService_PushSyncChanges_Call {
+	_c.Call.Return(syncApplyResult, err)
+	return _c
+}
+
+func (_c *MockService_PushSyncChanges_Call) RunAndReturn(run func(ctx context.Context, body io.Reader, base int64, policy string, includePrivate bool) (model.SyncApplyResult, error)) *MockService_PushSyncChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreSnapshot provides a mock function for the type MockService
+func (_mock *MockService) RestoreSnapshot(ctx context.Context, name string, keys model.ArchiveKeys) (model.GlobalMigrationStateDTO, error) {
+	ret := _mock.Called(ctx, name, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreSnapshot")
+	}
+
+	var r0 model.GlobalMigrationStateDTO
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.ArchiveKeys) (model.GlobalMigrationStateDTO, error)); ok {
+		return returnFunc(ctx, name, keys)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.ArchiveKeys) model.GlobalMigrationStateDTO); ok {
+		r0 = returnFunc(ctx, name, keys)
+	} else {
+		r0 = ret.Get(0).(model.GlobalMigrationStateDTO)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, model.ArchiveKeys) error); ok {
+		r1 = returnFunc(ctx, name, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_RestoreSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreSnapshot'
+type MockService_RestoreSnapshot_Call struct {
+	*mock.Call
+}
+
+// RestoreSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - keys model.ArchiveKeys
+func (_e *MockService_Expecter) RestoreSnapshot(ctx any, name any, keys any) *MockService_RestoreSnapshot_Call {
+	return &MockService_RestoreSnapshot_Call{Call: _e.mock.On("RestoreSnapshot", ctx, name, keys)}
+}
+
+func (_c *MockService_RestoreSnapshot_Call) Run(run func(ctx context.Context, name string, keys model.ArchiveKeys)) *MockService_RestoreSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 model.ArchiveKeys
+		if args[2] != nil {
+			arg2 = args[2].(model.ArchiveKeys)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_RestoreSnapshot_Call) Return(globalMigrationStateDTO model.GlobalMigrationStateDTO, err error) *MockService_RestoreSnapshot_Call {
+	_c.Call.Return(globalMigrationStateDTO, err)
+	return _c
+}
+
+func (_c *MockService_RestoreSnapshot_Call) RunAndReturn(run func(ctx context.Context, name string, keys model.ArchiveKeys) (model.GlobalMigrationStateDTO, error)) *MockService_RestoreSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartExport provides a mock function for the type MockService
+func (_mock *MockService) StartExport(ctx context.Context, req model.StartExportRequest) (model.ExportStateDTO, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartExport")
+	}
+
+	var r0 model.ExportStateDTO
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.StartExportRequest) (model.ExportStateDTO, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.StartExportRequest) model.ExportStateDTO); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Get(0).(model.ExportStateDTO)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.StartExportRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_StartExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartExport'
+type MockService_StartExport_Call struct {
+	*mock.Call
+}
+
+// StartExport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req model.StartExportRequest
+func (_e *MockService_Expecter) StartExport(ctx any, req any) *MockService_StartExport_Call {
+	return &MockService_StartExport_Call{Call: _e.mock.On("StartExport", ctx, req)}
+}
+
+func (_c *MockService_StartExport_Call) Run(run func(ctx context.Context, req model.StartExportRequest)) *MockService_StartExport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.StartExportRequest
+		if args[1] != nil {
+			arg1 = args[1].(model.StartExportRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_StartExport_Call) Return(exportStateDTO model.ExportStateDTO, err error) *MockService_StartExport_Call {
+	_c.Call.Return(exportStateDTO, err)
+	return _c
+}
+
+func (_c *MockService_StartExport_Call) RunAndReturn(run func(ctx context.Context, req model.StartExportRequest) (model.ExportStateDTO, error)) *MockService_StartExport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartGlobalMigration provides a mock function for the type MockService
+func (_mock *MockService) StartGlobalMigration(ctx context.Context, req model.StartGlobalMigrationRequest) (model.GlobalMigrationStateDTO, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartGlobalMigration")
+	}
+
+	var r0 model.GlobalMigrationStateDTO
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.StartGlobalMigrationRequest) (model.GlobalMigrationStateDTO, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.StartGlobalMigrationRequest) model.GlobalMigrationStateDTO); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Get(0).(model.GlobalMigrationStateDTO)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.StartGlobalMigrationRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_StartGlobalMigration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartGlobalMigration'
+type MockService_StartGlobalMigration_Call struct {
+	*mock.Call
+}
+
+// StartGlobalMigration is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req model.StartGlobalMigrationRequest
+func (_e *MockService_Expecter) StartGlobalMigration(ctx any, req any) *MockService_StartGlobalMigration_Call {
+	return &MockService_StartGlobalMigration_Call{Call: _e.mock.On("StartGlobalMigration", ctx, req)}
+}
+
+func (_c *MockService_StartGlobalMigration_Call) Run(run func(ctx context.Context, req model.StartGlobalMigrationRequest)) *MockService_StartGlobalMigration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.StartGlobalMigrationRequest
+		if args[1] != nil {
+			arg1 = args[1].(model.StartGlobalMigrationRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_StartGlobalMigration_Call) Return(globalMigrationStateDTO model.GlobalMigrationStateDTO, err error) *MockService_StartGlobalMigration_Call {
+	_c.Call.Return(globalMigrationStateDTO, err)
+	return _c
+}
+
+func (_c *MockService_StartGlobalMigration_Call) RunAndReturn(run func(ctx context.Context, req model.StartGlobalMigrationRequest) (model.GlobalMigrationStateDTO, error)) *MockService_StartGlobalMigration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartPublish provides a mock function for the type MockService
+func (_mock *MockService) StartPublish(ctx context.Context) (model.PublishStateDTO, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartPublish")
+	}
+
+	var r0 model.PublishStateDTO
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (model.PublishStateDTO, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) model.PublishStateDTO); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(model.PublishStateDTO)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_StartPublish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartPublish'
+type MockService_StartPublish_Call struct {
+	*mock.Call
+}
+
+// StartPublish is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockService_Expecter) StartPublish(ctx any) *MockService_StartPublish_Call {
+	return &MockService_StartPublish_Call{Call: _e.mock.On("StartPublish", ctx)}
+}
+
+func (_c *MockService_StartPublish_Call) Run(run func(ctx context.Context)) *MockService_StartPublish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_StartPublish_Call) Return(publishStateDTO model.PublishStateDTO, err error) *MockService_StartPublish_Call {
+	_c.Call.Return(publishStateDTO, err)
+	return _c
+}
+
+func (_c *MockService_StartPublish_Call) RunAndReturn(run func(ctx context.Context) (model.PublishStateDTO, error)) *MockService_StartPublish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartSync provides a mock function for the type MockService
+func (_mock *MockService) StartSync(ctx context.Context) (model.SyncStateDTO, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartSync")
+	}
+
+	var r0 model.SyncStateDTO
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (model.SyncStateDTO, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) model.SyncStateDTO); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(model.SyncStateDTO)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_StartSync_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartSync'
+type MockService_StartSync_Call struct {
+	*mock.Call
+}
+
+// StartSync is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockService_Expecter) StartSync(ctx any) *MockService_StartSync_Call {
+	return &MockService_StartSync_Call{Call: _e.mock.On("StartSync", ctx)}
+}
+
+func (_c *MockService_StartSync_Call) Run(run func(ctx context.Context)) *MockService_StartSync_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_StartSync_Call) Return(syncStateDTO model.SyncStateDTO, err error) *MockService_StartSync_Call {
+	_c.Call.Return(syncStateDTO, err)
+	return _c
+}
+
+func (_c *MockService_StartSync_Call) RunAndReturn(run func(ctx context.Context) (model.SyncStateDTO, error)) *MockService_StartSync_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateEncryptionSetting provides a mock function for the type MockService
+func (_mock *MockService) UpdateEncryptionSetting(ctx context.Context, setting model.EncryptionSetting) error {
+	ret := _mock.Called(ctx, setting)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEncryptionSetting")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.EncryptionSetting) error); ok {
+		r0 = returnFunc(ctx, setting)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_UpdateEncryptionSetting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEncryptionSetting'
+type MockService_UpdateEncryptionSetting_Call struct {
+	*mock.Call
+}
+
+// UpdateEncryptionSetting is a helper method to define mock.On call
+//   - ctx context.Context
+//   - setting model.EncryptionSetting
+func (_e *MockService_Expecter) UpdateEncryptionSetting(ctx any, setting any) *MockService_UpdateEncryptionSetting_Call {
+	return &MockService_UpdateEncryptionSetting_Call{Call: _e.mock.On("UpdateEncryptionSetting", ctx, setting)}
+}
+
+func (_c *MockService_UpdateEncryptionSetting_Call) Run(run func(ctx context.Context, setting model.EncryptionSetting)) *MockService_UpdateEncryptionSetting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.EncryptionSetting
+		if args[1] != nil {
+			arg1 = args[1].(model.EncryptionSetting)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_UpdateEncryptionSetting_Call) Return(err error) *MockService_UpdateEncryptionSetting_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_UpdateEncryptionSetting_Call) RunAndReturn(run func(ctx context.Context, setting model.EncryptionSetting) error) *MockService_UpdateEncryptionSetting_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePublishSetting provides a mock function for the type MockService
+func (_mock *MockService) UpdatePublishSetting(ctx context.Context, setting model.PublishSetting) error {
+	ret := _mock.Called(ctx, setting)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePublishSetting")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.PublishSetting) error); ok {
+		r0 = returnFunc(ctx, setting)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_UpdatePublishSetting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePublishSetting'
+type MockService_UpdatePublishSetting_Call struct {
+	*mock.Call
+}
+
+// UpdatePublishSetting is a helper method to define mock.On call
+//   - ctx context.Context
+//   - setting model.PublishSetting
+func (_e *MockService_Expecter) UpdatePublishSetting(ctx any, setting any) *MockService_UpdatePublishSetting_Call {
+	return &MockService_UpdatePublishSetting_Call{Call: _e.mock.On("UpdatePublishSetting", ctx, setting)}
+}
+
+func (_c *MockService_UpdatePublishSetting_Call) Run(run func(ctx context.Context, setting model.PublishSetting)) *MockService_UpdatePublishSetting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.PublishSetting
+		if args[1] != nil {
+			arg1 = args[1].(model.PublishSetting)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_UpdatePublishSetting_Call) Return(err error) *MockService_UpdatePublishSetting_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_UpdatePublishSetting_Call) RunAndReturn(run func(ctx context.Context, setting model.PublishSetting) error) *MockService_UpdatePublishSetting_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSyncSetting provides a mock function for the type MockService
+func (_mock *MockService) UpdateSyncSetting(ctx context.Context, setting model.SyncSetting) error {
+	ret := _mock.Called(ctx, setting)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSyncSetting")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.SyncSetting) error); ok {
+		r0 = returnFunc(ctx, setting)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_UpdateSyncSetting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSyncSetting'
+type MockService_UpdateSyncSetting_Call struct {
+	*mock.Call
+}
+
+// UpdateSyncSetting is a helper method to define mock.On call
+//   - ctx context.Context
+//   - setting model.SyncSetting
+func (_e *MockService_Expecter) UpdateSyncSetting(ctx any, setting any) *MockService_UpdateSyncSetting_Call {
+	return &MockService_UpdateSyncSetting_Call{Call: _e.mock.On("UpdateSyncSetting", ctx, setting)}
+}
+
+func (_c *MockService_UpdateSyncSetting_Call) Run(run func(ctx context.Context, setting model.SyncSetting)) *MockService_UpdateSyncSetting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.SyncSetting
+		if args[1] != nil {
+			arg1 = args[1].(model.SyncSetting)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_UpdateSyncSetting_Call) Return(err error) *MockService_UpdateSyncSetting_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_UpdateSyncSetting_Call) RunAndReturn(run func(ctx context.Context, setting model.SyncSetting) error) *MockService_UpdateSyncSetting_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UploadSourceZip provides a mock function for the type MockService
+func (_mock *MockService) UploadSourceZip(ctx context.Context, sourceType string, file *multipart.FileHeader, keys model.ArchiveKeys) (model.UploadMigrationSourceZipResponse, error) {
+	ret := _mock.Called(ctx, sourceType, file, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadSourceZip")
+	}
+
+	var r0 model.UploadMigrationSourceZipResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *multipart.FileHeader, model.ArchiveKeys) (model.UploadMigrationSourceZipResponse, error)); ok {
+		return returnFunc(ctx, sourceType, file, keys)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *multipart.FileHeader, model.ArchiveKeys) model.UploadMigrationSourceZipResponse); ok {
+		r0 = returnFunc(ctx, sourceType, file, keys)
+	} else {
+		r0 = ret.Get(0).(model.UploadMigrationSourceZipResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *multipart.FileHeader, model.ArchiveKeys) error); ok {
+		r1 = returnFunc(ctx, sourceType, file, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_UploadSourceZip_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadSourceZip'
+type MockService_UploadSourceZip_Call struct {
+	*mock.Call
+}
+
+// UploadSourceZip is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sourceType string
+//   - file *multipart.FileHeader
+//   - keys model.ArchiveKeys
+func (_e *MockService_Expecter) UploadSourceZip(ctx any, sourceType any, file any, keys any) *MockService_UploadSourceZip_Call {
+	return &MockService_UploadSourceZip_Call{Call: _e.mock.On("UploadSourceZip", ctx, sourceType, file, keys)}
+}
+
+func (_c *MockService_UploadSourceZip_Call) Run(run func(ctx context.Context, sourceType string, file *multipart.FileHeader, keys model.ArchiveKeys)) *MockService_UploadSourceZip_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *multipart.FileHeader
+		if args[2] != nil {
+			arg2 = args[2].(*multipart.FileHeader)
+		}
+		var arg3 model.ArchiveKeys
+		if args[3] != nil {
+			arg3 = args[3].(model.ArchiveKeys)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -596,7 +1547,7 @@ func (_c *MockService_UploadSourceZip_Call) Return(uploadMigrationSourceZipRespo
 	return _c
 }
 
-func (_c *MockService_UploadSourceZip_Call) RunAndReturn(run func(ctx context.Context, sourceType string, file *multipart.FileHeader) (model.UploadMigrationSourceZipResponse, error)) *MockService_UploadSourceZip_Call {
+func (_c *MockService_UploadSourceZip_Call) RunAndReturn(run func(ctx context.Context, sourceType string, file *multipart.FileHeader, keys model.ArchiveKeys) (model.UploadMigrationSourceZipResponse, error)) *MockService_UploadSourceZip_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -11,6 +11,7 @@ import (
 	"context"
 	"time"
 
+	model1 "github.com/lin-snow/ech0/internal/model/notify"
 	"github.com/lin-snow/ech0/internal/model/setting"
 	model0 "github.com/lin-snow/ech0/internal/model/webhook"
 	mock "github.com/stretchr/testify/mock"
@@ -166,6 +167,63 @@ func (_c *MockService_CreateAccessToken_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
+// CreateNotifyChannel provides a mock function for the type MockService
+func (_mock *MockService) CreateNotifyChannel(ctx context.Context, newChannel *model1.NotifyChannelDto) error {
+	ret := _mock.Called(ctx, newChannel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateNotifyChannel")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model1.NotifyChannelDto) error); ok {
+		r0 = returnFunc(ctx, newChannel)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_CreateNotifyChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateNotifyChannel'
+type MockService_CreateNotifyChannel_Call struct {
+	*mock.Call
+}
+
+// CreateNotifyChannel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - newChannel *model1.NotifyChannelDto
+func (_e *MockService_Expecter) CreateNotifyChannel(ctx any, newChannel any) *MockService_CreateNotifyChannel_Call {
+	return &MockService_CreateNotifyChannel_Call{Call: _e.mock.On("CreateNotifyChannel", ctx, newChannel)}
+}
+
+func (_c *MockService_CreateNotifyChannel_Call) Run(run func(ctx context.Context, newChannel *model1.NotifyChannelDto)) *MockService_CreateNotifyChannel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model1.NotifyChannelDto
+		if args[1] != nil {
+			arg1 = args[1].(*model1.NotifyChannelDto)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_CreateNotifyChannel_Call) Return(err error) *MockService_CreateNotifyChannel_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_CreateNotifyChannel_Call) RunAndReturn(run func(ctx context.Context, newChannel *model1.NotifyChannelDto) error) *MockService_CreateNotifyChannel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateWebhook provides a mock function for the type MockService
 func (_mock *MockService) CreateWebhook(ctx context.Context, newWebhook *model.WebhookDto) error {
 	ret := _mock.Called(ctx, newWebhook)
@@ -280,6 +338,63 @@ func (_c *MockService_DeleteAccessToken_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
+// DeleteNotifyChannel provides a mock function for the type MockService
+func (_mock *MockService) DeleteNotifyChannel(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteNotifyChannel")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_DeleteNotifyChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteNotifyChannel'
+type MockService_DeleteNotifyChannel_Call struct {
+	*mock.Call
+}
+
+// DeleteNotifyChannel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockService_Expecter) DeleteNotifyChannel(ctx any, id any) *MockService_DeleteNotifyChannel_Call {
+	return &MockService_DeleteNotifyChannel_Call{Call: _e.mock.On("DeleteNotifyChannel", ctx, id)}
+}
+
+func (_c *MockService_DeleteNotifyChannel_Call) Run(run func(ctx context.Context, id string)) *MockService_DeleteNotifyChannel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_DeleteNotifyChannel_Call) Return(err error) *MockService_DeleteNotifyChannel_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_DeleteNotifyChannel_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockService_DeleteNotifyChannel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteWebhook provides a mock function for the type MockService
 func (_mock *MockService) DeleteWebhook(ctx context.Context, id string) error {
 	ret := _mock.Called(ctx, id)
@@ -445,6 +560,68 @@ func (_c *MockService_GetAgentSettings_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// GetAllNotifyChannels provides a mock function for the type MockService
+func (_mock *MockService) GetAllNotifyChannels(ctx context.Context) ([]model1.NotifyChannel, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllNotifyChannels")
+	}
+
+	var r0 []model1.NotifyChannel
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]model1.NotifyChannel, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []model1.NotifyChannel); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model1.NotifyChannel)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_GetAllNotifyChannels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllNotifyChannels'
+type MockService_GetAllNotifyChannels_Call struct {
+	*mock.Call
+}
+
+// GetAllNotifyChannels is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockService_Expecter) GetAllNotifyChannels(ctx any) *MockService_GetAllNotifyChannels_Call {
+	return &MockService_GetAllNotifyChannels_Call{Call: _e.mock.On("GetAllNotifyChannels", ctx)}
+}
+
+func (_c *MockService_GetAllNotifyChannels_Call) Run(run func(ctx context.Context)) *MockService_GetAllNotifyChannels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetAllNotifyChannels_Call) Return(notifyChannels []model1.NotifyChannel, err error) *MockService_GetAllNotifyChannels_Call {
+	_c.Call.Return(notifyChannels, err)
+	return _c
+}
+
+func (_c *MockService_GetAllNotifyChannels_Call) RunAndReturn(run func(ctx context.Context) ([]model1.NotifyChannel, error)) *MockService_GetAllNotifyChannels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAllWebhooks provides a mock function for the type MockService
 func (_mock *MockService) GetAllWebhooks(ctx context.Context) ([]model0.Webhook, error) {
 	ret := _mock.Called(ctx)
@@ -1004,6 +1181,74 @@ func (_c *MockService_ListAccessTokens_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// ListNotifyDeliveries provides a mock function for the type MockService
+func (_mock *MockService) ListNotifyDeliveries(ctx context.Context, channelID string) ([]model1.NotifyDelivery, error) {
+	ret := _mock.Called(ctx, channelID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNotifyDeliveries")
+	}
+
+	var r0 []model1.NotifyDelivery
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]model1.NotifyDelivery, error)); ok {
+		return returnFunc(ctx, channelID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []model1.NotifyDelivery); ok {
+		r0 = returnFunc(ctx, channelID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model1.NotifyDelivery)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, channelID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_ListNotifyDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNotifyDeliveries'
+type MockService_ListNotifyDeliveries_Call struct {
+	*mock.Call
+}
+
+// ListNotifyDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - channelID string
+func (_e *MockService_Expecter) ListNotifyDeliveries(ctx any, channelID any) *MockService_ListNotifyDeliveries_Call {
+	return &MockService_ListNotifyDeliveries_Call{Call: _e.mock.On("ListNotifyDeliveries", ctx, channelID)}
+}
+
+func (_c *MockService_ListNotifyDeliveries_Call) Run(run func(ctx context.Context, channelID string)) *MockService_ListNotifyDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ListNotifyDeliveries_Call) Return(notifyDeliverys []model1.NotifyDelivery, err error) *MockService_ListNotifyDeliveries_Call {
+	_c.Call.Return(notifyDeliverys, err)
+	return _c
+}
+
+func (_c *MockService_ListNotifyDeliveries_Call) RunAndReturn(run func(ctx context.Context, channelID string) ([]model1.NotifyDelivery, error)) *MockService_ListNotifyDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TestAgentConnection provides a mock function for the type MockService
 func (_mock *MockService) TestAgentConnection(ctx context.Context, newSetting *model.AgentSettingDto) error {
 	ret := _mock.Called(ctx, newSetting)
@@ -1061,6 +1306,63 @@ func (_c *MockService_TestAgentConnection_Call) RunAndReturn(run func(ctx contex
 	return _c
 }
 
+// TestNotifyChannel provides a mock function for the type MockService
+func (_mock *MockService) TestNotifyChannel(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TestNotifyChannel")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_TestNotifyChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TestNotifyChannel'
+type MockService_TestNotifyChannel_Call struct {
+	*mock.Call
+}
+
+// TestNotifyChannel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockService_Expecter) TestNotifyChannel(ctx any, id any) *MockService_TestNotifyChannel_Call {
+	return &MockService_TestNotifyChannel_Call{Call: _e.mock.On("TestNotifyChannel", ctx, id)}
+}
+
+func (_c *MockService_TestNotifyChannel_Call) Run(run func(ctx context.Context, id string)) *MockService_TestNotifyChannel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_TestNotifyChannel_Call) Return(err error) *MockService_TestNotifyChannel_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_TestNotifyChannel_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockService_TestNotifyChannel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TestS3Connection provides a mock function for the type MockService
 func (_mock *MockService) TestS3Connection(ctx context.Context, newSetting *model.S3SettingDto) error {
 	ret := _mock.Called(ctx, newSetting)
@@ -1289,73 +1591,136 @@ func (_c *MockService_UpdateEmbeddingSetting_Call) RunAndReturn(run func(ctx con
 	return _c
 }
 
-// UpdateOAuth2Setting provides a mock function for the type MockService
-func (_mock *MockService) UpdateOAuth2Setting(ctx context.Context, newSetting *model.OAuth2SettingDto) error {
-	ret := _mock.Called(ctx, newSetting)
+// UpdateNotifyChannel provides a mock function for the type MockService
+func (_mock *MockService) UpdateNotifyChannel(ctx context.Context, id string, newChannel *model1.NotifyChannelDto) error {
+	ret := _mock.Called(ctx, id, newChannel)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateOAuth2Setting")
+		panic("no return value specified for UpdateNotifyChannel")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.OAuth2SettingDto) error); ok {
-		r0 = returnFunc(ctx, newSetting)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *model1.NotifyChannelDto) error); ok {
+		r0 = returnFunc(ctx, id, newChannel)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockService_UpdateOAuth2Setting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateOAuth2Setting'
-type MockService_UpdateOAuth2Setting_Call struct {
+// MockService_UpdateNotifyChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateNotifyChannel'
+type MockService_UpdateNotifyChannel_Call struct {
 	*mock.Call
 }
 
-// UpdateOAuth2Setting is a helper method to define mock.On call
+// UpdateNotifyChannel is a helper method to define mock.On call
 //   - ctx context.Context
-//   - newSetting *model.OAuth2SettingDto
-func (_e *MockService_Expecter) UpdateOAuth2Setting(ctx any, newSetting any) *MockService_UpdateOAuth2Setting_Call {
-	return &MockService_UpdateOAuth2Setting_Call{Call: _e.mock.On("UpdateOAuth2Setting", ctx, newSetting)}
+//   - id string
+//   - newChannel *model1.NotifyChannelDto
+func (_e *MockService_Expecter) UpdateNotifyChannel(ctx any, id any, newChannel any) *MockService_UpdateNotifyChannel_Call {
+	return &MockService_UpdateNotifyChannel_Call{Call: _e.mock.On("UpdateNotifyChannel", ctx, id, newChannel)}
 }
 
-func (_c *MockService_UpdateOAuth2Setting_Call) Run(run func(ctx context.Context, newSetting *model.OAuth2SettingDto)) *MockService_UpdateOAuth2Setting_Call {
+func (_c *MockService_UpdateNotifyChannel_Call) Run(run func(ctx context.Context, id string, newChannel *model1.NotifyChannelDto)) *MockService_UpdateNotifyChannel_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *model.OAuth2SettingDto
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*model.OAuth2SettingDto)
+			arg1 = args[1].(string)
+		}
+		var arg2 *model1.NotifyChannelDto
+		if args[2] != nil {
+			arg2 = args[2].(*model1.NotifyChannelDto)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_UpdateOAuth2Setting_Call) Return(err error) *MockService_UpdateOAuth2Setting_Call {
+func (_c *MockService_UpdateNotifyChannel_Call) Return(err error) *MockService_UpdateNotifyChannel_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockService_UpdateOAuth2Setting_Call) RunAndReturn(run func(ctx context.Context, newSetting *model.OAuth2SettingDto) error) *MockService_UpdateOAuth2Setting_Call {
+func (_c *MockService_UpdateNotifyChannel_Call) RunAndReturn(run func(ctx context.Context, id string, newChannel *model1.NotifyChannelDto) error) *MockService_UpdateNotifyChannel_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdatePasskeySetting provides a mock function for the type MockService
-func (_mock *MockService) UpdatePasskeySetting(ctx context.Context, newSetting *model.PasskeySettingDto) error {
+// UpdateOAuth2Setting provides a mock function for the type MockService
+func (_mock *MockService) UpdateOAuth2Setting(ctx context.Context, newSetting *model.OAuth2SettingDto) error {
 	ret := _mock.Called(ctx, newSetting)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdatePasskeySetting")
+		panic("no return value specified for UpdateOAuth2Setting")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.PasskeySettingDto) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.OAuth2SettingDto) error); ok {
+		r0 = returnFunc(ctx, newSetting)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_UpdateOAuth2Setting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateOAuth2Setting'
+type MockService_UpdateOAuth2Setting_Call struct {
+	*mock.Call
+}
+
+// UpdateOAuth2Setting is a helper method to define mock.On call
+//   - ctx context.Context
+//   - newSetting *model.OAuth2SettingDto
+func (_e *MockService_Expecter) UpdateOAuth2Setting(ctx any, newSetting any) *MockService_UpdateOAuth2Setting_Call {
+	return &MockService_UpdateOAuth2Setting_Call{Call: _e.mock.On("UpdateOAuth2Setting", ctx, newSetting)}
+}
+
+func (_c *MockService_UpdateOAuth2Setting_Call) Run(run func(ctx context.Context, newSetting *model.OAuth2SettingDto)) *MockService_UpdateOAuth2Setting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.OAuth2SettingDto
+		if args[1] != nil {
+			arg1 = args[1].(*model.OAuth2SettingDto)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_UpdateOAuth2Setting_Call) Return(err error) *MockService_UpdateOAuth2Setting_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_UpdateOAuth2Setting_Call) RunAndReturn(run func(ctx context.Context, newSetting *model.OAuth2SettingDto) error) *MockService_UpdateOAuth2Setting_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePasskeySetting provides a mock function for the type MockService
+func (_mock *MockService) UpdatePasskeySetting(ctx context.Context, newSetting *model.PasskeySettingDto) error {
+	ret := _mock.Called(ctx, newSetting)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePasskeySetting")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.PasskeySettingDto) error); ok {
 		r0 = returnFunc(ctx, newSetting)
 	} else {
 		r0 = ret.Error(0)
@@ -2387,3 +2752,411 @@ func (_c *MockWebhookRepository_UpdateWebhookDeliveryStatus_Call) RunAndReturn(r
 	_c.Call.Return(run)
 	return _c
 }
+
+// NewMockNotifyRepository creates a new instance of MockNotifyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNotifyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNotifyRepository {
+	mock := &MockNotifyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockNotifyRepository is an autogenerated mock type for the NotifyRepository type
+type MockNotifyRepository struct {
+	mock.Mock
+}
+
+type MockNotifyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNotifyRepository) EXPECT() *MockNotifyRepository_Expecter {
+	return &MockNotifyRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateNotifyChannel provides a mock function for the type MockNotifyRepository
+func (_mock *MockNotifyRepository) CreateNotifyChannel(ctx context.Context, channel *model1.NotifyChannel) error {
+	ret := _mock.Called(ctx, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateNotifyChannel")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model1.NotifyChannel) error); ok {
+		r0 = returnFunc(ctx, channel)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockNotifyRepository_CreateNotifyChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateNotifyChannel'
+type MockNotifyRepository_CreateNotifyChannel_Call struct {
+	*mock.Call
+}
+
+// CreateNotifyChannel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - channel *model1.NotifyChannel
+func (_e *MockNotifyRepository_Expecter) CreateNotifyChannel(ctx any, channel any) *MockNotifyRepository_CreateNotifyChannel_Call {
+	return &MockNotifyRepository_CreateNotifyChannel_Call{Call: _e.mock.On("CreateNotifyChannel", ctx, channel)}
+}
+
+func (_c *MockNotifyRepository_CreateNotifyChannel_Call) Run(run func(ctx context.Context, channel *model1.NotifyChannel)) *MockNotifyRepository_CreateNotifyChannel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model1.NotifyChannel
+		if args[1] != nil {
+			arg1 = args[1].(*model1.NotifyChannel)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotifyRepository_CreateNotifyChannel_Call) Return(err error) *MockNotifyRepository_CreateNotifyChannel_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockNotifyRepository_CreateNotifyChannel_Call) RunAndReturn(run func(ctx context.Context, channel *model1.NotifyChannel) error) *MockNotifyRepository_CreateNotifyChannel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteNotifyChannelByID provides a mock function for the type MockNotifyRepository
+func (_mock *MockNotifyRepository) DeleteNotifyChannelByID(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteNotifyChannelByID")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockNotifyRepository_DeleteNotifyChannelByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteNotifyChannelByID'
+type MockNotifyRepository_DeleteNotifyChannelByID_Call struct {
+	*mock.Call
+}
+
+// DeleteNotifyChannelByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockNotifyRepository_Expecter) DeleteNotifyChannelByID(ctx any, id any) *MockNotifyRepository_DeleteNotifyChannelByID_Call {
+	return &MockNotifyRepository_DeleteNotifyChannelByID_Call{Call: _e.mock.On("DeleteNotifyChannelByID", ctx, id)}
+}
+
+func (_c *MockNotifyRepository_DeleteNotifyChannelByID_Call) Run(run func(ctx context.Context, id string)) *MockNotifyRepository_DeleteNotifyChannelByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotifyRepository_DeleteNotifyChannelByID_Call) Return(err error) *MockNotifyRepository_DeleteNotifyChannelByID_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockNotifyRepository_DeleteNotifyChannelByID_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockNotifyRepository_DeleteNotifyChannelByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllNotifyChannels provides a mock function for the type MockNotifyRepository
+func (_mock *MockNotifyRepository) GetAllNotifyChannels(ctx context.Context) ([]model1.NotifyChannel, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllNotifyChannels")
+	}
+
+	var r0 []model1.NotifyChannel
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]model1.NotifyChannel, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []model1.NotifyChannel); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model1.NotifyChannel)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotifyRepository_GetAllNotifyChannels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllNotifyChannels'
+type MockNotifyRepository_GetAllNotifyChannels_Call struct {
+	*mock.Call
+}
+
+// GetAllNotifyChannels is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockNotifyRepository_Expecter) GetAllNotifyChannels(ctx any) *MockNotifyRepository_GetAllNotifyChannels_Call {
+	return &MockNotifyRepository_GetAllNotifyChannels_Call{Call: _e.mock.On("GetAllNotifyChannels", ctx)}
+}
+
+func (_c *MockNotifyRepository_GetAllNotifyChannels_Call) Run(run func(ctx context.Context)) *MockNotifyRepository_GetAllNotifyChannels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotifyRepository_GetAllNotifyChannels_Call) Return(notifyChannels []model1.NotifyChannel, err error) *MockNotifyRepository_GetAllNotifyChannels_Call {
+	_c.Call.Return(notifyChannels, err)
+	return _c
+}
+
+func (_c *MockNotifyRepository_GetAllNotifyChannels_Call) RunAndReturn(run func(ctx context.Context) ([]model1.NotifyChannel, error)) *MockNotifyRepository_GetAllNotifyChannels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotifyChannelByID provides a mock function for the type MockNotifyRepository
+func (_mock *MockNotifyRepository) GetNotifyChannelByID(ctx context.Context, id string) (*model1.NotifyChannel, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotifyChannelByID")
+	}
+
+	var r0 *model1.NotifyChannel
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model1.NotifyChannel, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model1.NotifyChannel); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model1.NotifyChannel)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotifyRepository_GetNotifyChannelByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotifyChannelByID'
+type MockNotifyRepository_GetNotifyChannelByID_Call struct {
+	*mock.Call
+}
+
+// GetNotifyChannelByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockNotifyRepository_Expecter) GetNotifyChannelByID(ctx any, id any) *MockNotifyRepository_GetNotifyChannelByID_Call {
+	return &MockNotifyRepository_GetNotifyChannelByID_Call{Call: _e.mock.On("GetNotifyChannelByID", ctx, id)}
+}
+
+func (_c *MockNotifyRepository_GetNotifyChannelByID_Call) Run(run func(ctx context.Context, id string)) *MockNotifyRepository_GetNotifyChannelByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotifyRepository_GetNotifyChannelByID_Call) Return(notifyChannel *model1.NotifyChannel, err error) *MockNotifyRepository_GetNotifyChannelByID_Call {
+	_c.Call.Return(notifyChannel, err)
+	return _c
+}
+
+func (_c *MockNotifyRepository_GetNotifyChannelByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*model1.NotifyChannel, error)) *MockNotifyRepository_GetNotifyChannelByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListNotifyDeliveries provides a mock function for the type MockNotifyRepository
+func (_mock *MockNotifyRepository) ListNotifyDeliveries(ctx context.Context, channelID string, limit int) ([]model1.NotifyDelivery, error) {
+	ret := _mock.Called(ctx, channelID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNotifyDeliveries")
+	}
+
+	var r0 []model1.NotifyDelivery
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]model1.NotifyDelivery, error)); ok {
+		return returnFunc(ctx, channelID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []model1.NotifyDelivery); ok {
+		r0 = returnFunc(ctx, channelID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model1.NotifyDelivery)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, channelID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotifyRepository_ListNotifyDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNotifyDeliveries'
+type MockNotifyRepository_ListNotifyDeliveries_Call struct {
+	*mock.Call
+}
+
+// ListNotifyDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - channelID string
+//   - limit int
+func (_e *MockNotifyRepository_Expecter) ListNotifyDeliveries(ctx any, channelID any, limit any) *MockNotifyRepository_ListNotifyDeliveries_Call {
+	return &MockNotifyRepository_ListNotifyDeliveries_Call{Call: _e.mock.On("ListNotifyDeliveries", ctx, channelID, limit)}
+}
+
+func (_c *MockNotifyRepository_ListNotifyDeliveries_Call) Run(run func(ctx context.Context, channelID string, limit int)) *MockNotifyRepository_ListNotifyDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotifyRepository_ListNotifyDeliveries_Call) Return(notifyDeliverys []model1.NotifyDelivery, err error) *MockNotifyRepository_ListNotifyDeliveries_Call {
+	_c.Call.Return(notifyDeliverys, err)
+	return _c
+}
+
+func (_c *MockNotifyRepository_ListNotifyDeliveries_Call) RunAndReturn(run func(ctx context.Context, channelID string, limit int) ([]model1.NotifyDelivery, error)) *MockNotifyRepository_ListNotifyDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateNotifyChannelByID provides a mock function for the type MockNotifyRepository
+func (_mock *MockNotifyRepository) UpdateNotifyChannelByID(ctx context.Context, id string, channel *model1.NotifyChannel) error {
+	ret := _mock.Called(ctx, id, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNotifyChannelByID")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *model1.NotifyChannel) error); ok {
+		r0 = returnFunc(ctx, id, channel)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockNotifyRepository_UpdateNotifyChannelByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateNotifyChannelByID'
+type MockNotifyRepository_UpdateNotifyChannelByID_Call struct {
+	*mock.Call
+}
+
+// UpdateNotifyChannelByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - channel *model1.NotifyChannel
+func (_e *MockNotifyRepository_Expecter) UpdateNotifyChannelByID(ctx any, id any, channel any) *MockNotifyRepository_UpdateNotifyChannelByID_Call {
+	return &MockNotifyRepository_UpdateNotifyChannelByID_Call{Call: _e.mock.On("UpdateNotifyChannelByID", ctx, id, channel)}
+}
+
+func (_c *MockNotifyRepository_UpdateNotifyChannelByID_Call) Run(run func(ctx context.Context, id string, channel *model1.NotifyChannel)) *MockNotifyRepository_UpdateNotifyChannelByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *model1.NotifyChannel
+		if args[2] != nil {
+			arg2 = args[2].(*model1.NotifyChannel)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotifyRepository_UpdateNotifyChannelByID_Call) Return(err error) *MockNotifyRepository_UpdateNotifyChannelByID_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockNotifyRepository_UpdateNotifyChannelByID_Call) RunAndReturn(run func(ctx context.Context, id string, channel *model1.NotifyChannel) error) *MockNotifyRepository_UpdateNotifyChannelByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
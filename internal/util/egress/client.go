@@ -32,9 +32,7 @@ func Timeout(d time.Duration) Option {
 
 // Guard enables SSRF protection: a dialer that rejects private/reserved
 // destination IPs (defending against DNS rebinding) plus redirect validation.
-// Use it only for user/peer-supplied URLs. Admin-configured infra endpoints
-// (OIDC providers, LLM backends, captcha verifiers) may legitimately live on
-// loopback or private networks, so they must NOT enable it.
+// Every hop, including redirect targets, must resolve to a public address.
 func Guard() Option {
 	return func(c *clientConfig) { c.guard = true }
 }
@@ -23,7 +23,8 @@ const (
 
 // Sender 是 webhook 的唯一出网出口：持有出网 HTTP client，负责签名构造 + 重试发送。
 // 正式投递（Dispatcher）与连通性测试（设置页 TestWebhook）共用它，避免 client 构造、
-// 超时、重试参数在两处各写一份而漂移。
+// 超时、重试参数在两处各写一份而漂移。Webhook 只投递给外部服务，client 启用
+// SSRF 防护，拒绝指向内网 / 本机的地址（保存时另有一次 Validate）。
 type Sender struct {
 	client *http.Client
 }
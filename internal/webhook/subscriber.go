@@ -27,9 +27,13 @@ func (wd *Dispatcher) Registrations() []eventbus.Registration {
 		observe[event.CommentStatusUpdated](wd.HandleObservation),
 		observe[event.CommentDeleted](wd.HandleObservation),
 		observe[event.ResourceUploaded](wd.HandleObservation),
+		observe[event.StorageQuotaWarning](wd.HandleObservation),
 		observe[event.SystemSnapshot](wd.HandleObservation),
 		observe[event.SystemExport](wd.HandleObservation),
 		observe[event.UpdateSnapshotSchedule](wd.HandleObservation),
+		observe[event.SystemSnapshotFailed](wd.HandleObservation),
+		observe[event.WebhookDisabled](wd.HandleObservation),
+		observe[event.UserLoginNewDevice](wd.HandleObservation),
 	}
 }
 
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package webmention
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/lin-snow/ech0/internal/event"
+	eventbus "github.com/lin-snow/ech0/internal/event/bus"
+	"github.com/lin-snow/ech0/internal/job"
+	"github.com/lin-snow/ech0/internal/kvstore"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	jobModel "github.com/lin-snow/ech0/internal/model/job"
+)
+
+// sentKeyPrefix + EchoID 记录该 Echo 上次通知过的目标（JSON 数组），
+// 用于在链接被删掉、Echo 转私密或被删除时通知旧目标。
+const sentKeyPrefix = "webmention_sent:"
+
+// Dispatcher 订阅 Echo 事件，为正文中的外部链接提交外发作业。发现端点与投递由
+// job.Manager 上的 webmention 作业完成：排队的投递随作业行落库，重启不丢。
+type Dispatcher struct {
+	durableKV  kvstore.Store
+	jobManager *job.Manager
+}
+
+func NewDispatcher(durableKV kvstore.Store, jobManager *job.Manager) *Dispatcher {
+	return &Dispatcher{durableKV: durableKV, jobManager: jobManager}
+}
+
+// Registrations 为 Echo 的创建 / 更新 / 删除各登记一条同步订阅；出网在作业里进行。
+func (d *Dispatcher) Registrations() []eventbus.Registration {
+	return []eventbus.Registration{
+		eventbus.On(func(ctx context.Context, e event.EchoCreated) error {
+			return d.Publish(ctx, e.Echo)
+		}),
+		eventbus.On(func(ctx context.Context, e event.EchoUpdated) error {
+			return d.Publish(ctx, e.Echo)
+		}),
+		eventbus.On(func(ctx context.Context, e event.EchoDeleted) error {
+			echo := e.Echo
+			echo.Content = ""
+			return d.Publish(ctx, echo)
+		}),
+	}
+}
+
+// Publish 计算本次应通知的目标并提交投递：当前正文里的外部链接，加上上次通知过、
+// 这次已不再链接的旧目标（对方核实来源时会发现链接已消失或页面已 410，据此删除提及）。
+// 私密 Echo 不对外链接任何目标。站点地址未配置时无法给出可核实的 source，直接跳过。
+func (d *Dispatcher) Publish(ctx context.Context, echo echoModel.Echo) error {
+	base := serverURL(ctx, d.durableKV)
+	if base == "" || echo.ID == "" {
+		return nil
+	}
+
+	var current []string
+	if !echo.Private {
+		for _, link := range ExtractLinks(echo.Content) {
+			if !strings.HasPrefix(link, base+"/") {
+				current = append(current, link)
+			}
+		}
+	}
+	previous := d.loadSent(ctx, echo.ID)
+	if len(current) == 0 && len(previous) == 0 {
+		return nil
+	}
+
+	targets := append([]string(nil), current...)
+	for _, target := range previous {
+		if !slices.Contains(current, target) {
+			targets = append(targets, target)
+		}
+	}
+	// 先入队再记录：入队失败时不更新已发送记录，下次保存 Echo 会再算出同一批目标。
+	raw, err := json.Marshal(SendPayload{Source: sourceURL(base, echo.ID), Targets: targets})
+	if err != nil {
+		return err
+	}
+	if _, err := d.jobManager.Submit(ctx, jobModel.TypeWebmention, raw); err != nil {
+		return err
+	}
+	return d.saveSent(ctx, echo.ID, current)
+}
+
+func (d *Dispatcher) loadSent(ctx context.Context, echoID string) []string {
+	raw, err := d.durableKV.Get(ctx, sentKeyPrefix+echoID)
+	if err != nil {
+		return nil
+	}
+	var targets []string
+	if err := json.Unmarshal([]byte(raw), &targets); err != nil {
+		return nil
+	}
+	return targets
+}
+
+func (d *Dispatcher) saveSent(ctx context.Context, echoID string, targets []string) error {
+	key := sentKeyPrefix + echoID
+	if len(targets) == 0 {
+		return d.durableKV.Delete(ctx, key)
+	}
+	raw, err := json.Marshal(targets)
+	if err != nil {
+		return err
+	}
+	return d.durableKV.Set(ctx, key, string(raw))
+}
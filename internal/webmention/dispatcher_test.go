@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package webmention
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/job"
+	"github.com/lin-snow/ech0/internal/kvstore"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	jobModel "github.com/lin-snow/ech0/internal/model/job"
+	jobRepository "github.com/lin-snow/ech0/internal/repository/job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// receiver 是一个假的外部站点：/post/* 页面经 Link 头声明端点，/plain 不声明，
+// /wm 记录收到的 Webmention；/reject 端点一律 400。
+type receiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	received []url.Values
+	posts    int
+}
+
+func newReceiver(t *testing.T) *receiver {
+	t.Helper()
+	r := &receiver{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /post/", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Link", `</wm>; rel="webmention"`)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<html><body>post</body></html>`))
+	})
+	mux.HandleFunc("GET /rejecting", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<link rel="webmention" href="/reject">`))
+	})
+	mux.HandleFunc("GET /plain", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><body>no endpoint</body></html>`))
+	})
+	mux.HandleFunc("POST /wm", func(w http.ResponseWriter, req *http.Request) {
+		_ = req.ParseForm()
+		r.mu.Lock()
+		r.received = append(r.received, req.PostForm)
+		r.mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("POST /reject", func(w http.ResponseWriter, _ *http.Request) {
+		r.mu.Lock()
+		r.posts++
+		r.mu.Unlock()
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	r.Server = httptest.NewServer(mux)
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *receiver) targets() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []string
+	for _, form := range r.received {
+		out = append(out, form.Get("target"))
+	}
+	sort.Strings(out)
+	return out
+}
+
+// testSender 用不带 SSRF 防护的 client，才能连上 httptest 的回环地址。
+func testSender() *Sender {
+	return &Sender{client: http.DefaultClient}
+}
+
+func TestSender_Send(t *testing.T) {
+	r := newReceiver(t)
+	s := testSender()
+	ctx := context.Background()
+
+	require.NoError(t, s.Send(ctx, "https://ech0.example/api/webmention/source/e1", r.URL+"/post/1"))
+	require.Len(t, r.received, 1)
+	assert.Equal(t, "https://ech0.example/api/webmention/source/e1", r.received[0].Get("source"))
+	assert.Equal(t, r.URL+"/post/1", r.received[0].Get("target"))
+
+	assert.ErrorIs(t, s.Send(ctx, "https://ech0.example/s", r.URL+"/plain"), ErrNoEndpoint)
+	assert.ErrorIs(t, s.Send(ctx, "https://ech0.example/s", r.URL+"/missing"), ErrNoEndpoint)
+
+	// 端点以 4xx 拒收时不重试。
+	require.Error(t, s.Send(ctx, "https://ech0.example/s", r.URL+"/rejecting"))
+	assert.Equal(t, 1, r.posts)
+}
+
+// newTestDispatcher 用内存 SQLite 上的真实 job.Manager 承接外发作业，Runner 换成 testSender。
+func newTestDispatcher(t *testing.T) (*Dispatcher, kvstore.Store, *jobRepository.JobRepository) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&jobModel.Job{}))
+	repo := jobRepository.NewJobRepository(func() *gorm.DB { return db })
+
+	manager := job.NewManager(repo)
+	sender := testSender()
+	manager.Register(jobModel.TypeWebmention, job.Adapt(func(ctx context.Context, p SendPayload, report job.ReportFunc) (any, error) {
+		return sender.SendAll(ctx, p, report)
+	}), job.WithQueue(10))
+
+	kv := kvstore.NewMemory()
+	require.NoError(t, kv.Set(context.Background(), commonModel.ServerURLKey, "https://ech0.example/"))
+	return NewDispatcher(kv, manager), kv, repo
+}
+
+// waitJobs 等到外发作业全部跑完，并返回最近一条的终态。
+func waitJobs(t *testing.T, repo *jobRepository.JobRepository) jobModel.Job {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		active, err := repo.Active(context.Background(), jobModel.TypeWebmention)
+		return err == nil && len(active) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	latest, err := repo.Latest(context.Background(), jobModel.TypeWebmention)
+	require.NoError(t, err)
+	return latest
+}
+
+func TestDispatcher_Publish(t *testing.T) {
+	r := newReceiver(t)
+	d, kv, jobs := newTestDispatcher(t)
+	ctx := context.Background()
+	a, b := r.URL+"/post/a", r.URL+"/post/b"
+
+	echo := echoModel.Echo{
+		ID:      "e1",
+		Content: "见 [A](" + a + ") 与 " + b + " ，以及站内 https://ech0.example/echo/e0",
+	}
+	require.NoError(t, d.Publish(ctx, echo))
+	done := waitJobs(t, jobs)
+	assert.Equal(t, jobModel.StatusSuccess, done.Status)
+	assert.Equal(t, []string{a, b}, r.targets(), "站内链接不发送")
+	assert.Equal(t, "https://ech0.example"+SourcePathPrefix+"e1", r.received[0].Get("source"))
+
+	// 更新后去掉了 b：b 仍会收到一次通知，以便对方核实后删除提及。
+	r.received = nil
+	echo.Content = "只剩 " + a
+	require.NoError(t, d.Publish(ctx, echo))
+	waitJobs(t, jobs)
+	assert.Equal(t, []string{a, b}, r.targets())
+	sent, err := kv.Get(ctx, sentKeyPrefix+"e1")
+	require.NoError(t, err)
+	assert.JSONEq(t, `["`+a+`"]`, sent)
+
+	// 转为私密：通知旧目标并清掉记录。
+	r.received = nil
+	echo.Private = true
+	require.NoError(t, d.Publish(ctx, echo))
+	waitJobs(t, jobs)
+	assert.Equal(t, []string{a}, r.targets())
+	_, err = kv.Get(ctx, sentKeyPrefix+"e1")
+	assert.ErrorIs(t, err, kvstore.ErrNotFound)
+}
+
+// 投递以作业行入队：payload 落库，进程重启后可按原样续跑；有目标失败时作业置 failed。
+func TestDispatcher_PublishQueuesDurableJob(t *testing.T) {
+	r := newReceiver(t)
+	d, _, jobs := newTestDispatcher(t)
+	ctx := context.Background()
+
+	require.NoError(t, d.Publish(ctx, echoModel.Echo{ID: "e2", Content: r.URL + "/rejecting " + r.URL + "/plain"}))
+	done := waitJobs(t, jobs)
+	assert.Equal(t, jobModel.StatusFailed, done.Status)
+	assert.Contains(t, done.Error, "1 of 2 targets failed")
+
+	var payload SendPayload
+	require.NoError(t, json.Unmarshal([]byte(done.Payload), &payload))
+	assert.Equal(t, "https://ech0.example"+SourcePathPrefix+"e2", payload.Source)
+	assert.Equal(t, []string{r.URL + "/rejecting", r.URL + "/plain"}, payload.Targets)
+}
@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package webmention
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lin-snow/ech0/internal/config"
+	"github.com/lin-snow/ech0/internal/kvstore"
+	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	commentService "github.com/lin-snow/ech0/internal/service/comment"
+	echoService "github.com/lin-snow/ech0/internal/service/echo"
+	asyncUtil "github.com/lin-snow/ech0/internal/util/async"
+	mdUtil "github.com/lin-snow/ech0/internal/util/md"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"github.com/lin-snow/ech0/pkg/viewer"
+)
+
+// maxSourceLen 与评论 Website 列宽一致，来源 URL 原样存入该列。
+const maxSourceLen = 255
+
+// sourcePage 是外发 Webmention 的 source：一条 Echo 的极简 h-entry，供对方接收端核实链接、
+// 取作者与正文。正文经 MdToHTML（丢弃原始 HTML、仅保留安全链接）渲染。
+var sourcePage = template.Must(template.New("source").Parse(`<!doctype html>
+<html lang="zh-CN"><head><meta charset="utf-8"><meta name="viewport" content="width=device-width,initial-scale=1">
+<title>{{.Author}} 的 Echo</title><link rel="canonical" href="{{.URL}}"></head>
+<body><article class="h-entry">
+<p><a class="p-author h-card" href="{{.Site}}">{{.Author}}</a> ·
+<a class="u-url" href="{{.URL}}"><time class="dt-published" datetime="{{.Published}}">{{.Published}}</time></a></p>
+<div class="e-content">{{.Content}}</div>
+</article></body></html>`))
+
+type sourcePageData struct {
+	Site      string
+	URL       string
+	Author    string
+	Published string
+	Content   template.HTML
+}
+
+// Handler 提供 Webmention 接收端点与 h-entry 来源页。接收端只做同步的参数校验，
+// 来源核实放进 worker pool 异步完成，核实结果经评论服务落库或撤销。
+type Handler struct {
+	commentService commentService.Service
+	echoService    echoService.Service
+	durableKV      kvstore.Store
+	sender         *Sender
+	pool           *asyncUtil.WorkerPool
+}
+
+func NewHandler(
+	commentSvc commentService.Service,
+	echoSvc echoService.Service,
+	durableKV kvstore.Store,
+) *Handler {
+	return &Handler{
+		commentService: commentSvc,
+		echoService:    echoSvc,
+		durableKV:      durableKV,
+		sender:         NewSender(),
+		pool: asyncUtil.NewWorkerPool(
+			config.Config().Event.WebhookPoolWorkers,
+			config.Config().Event.WebhookPoolQueue,
+		),
+	}
+}
+
+// Receive 处理 POST /api/webmention（form-encoded 的 source 与 target）。
+// 校验通过即回 202，后台再抓取 source 核实；校验失败回 400 与说明文字。
+func (h *Handler) Receive(c *gin.Context) {
+	ctx := c.Request.Context()
+	source := strings.TrimSpace(c.PostForm("source"))
+	target := strings.TrimSpace(c.PostForm("target"))
+
+	echoID, err := h.accept(ctx, h.siteURL(c), source, target)
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	h.pool.Submit(func() error {
+		h.Verify(ctx, echoID, source, target)
+		return nil
+	})
+	c.String(http.StatusAccepted, "Webmention accepted and queued for verification.")
+}
+
+// accept 做规范要求的同步校验并返回 target 指向的 Echo ID：
+// source / target 须为 http(s) 且互不相同，target 须是本站公开 Echo 的永久链接，评论须已开启。
+func (h *Handler) accept(ctx context.Context, site, source, target string) (string, error) {
+	if !isHTTPURL(source) || !isHTTPURL(target) {
+		return "", errors.New("source and target must be http(s) URLs")
+	}
+	if len(source) > maxSourceLen {
+		return "", errors.New("source URL is too long")
+	}
+	if normalizeURL(source) == normalizeURL(target) {
+		return "", errors.New("source and target must be different")
+	}
+	echoID, ok := echoIDFromTarget(site, target)
+	if !ok {
+		return "", errors.New("target is not an echo on this site")
+	}
+	setting, err := h.commentService.GetSystemSetting(ctx)
+	if err != nil || !setting.EnableComment {
+		return "", errors.New("comments are disabled on this site")
+	}
+	// 以匿名身份取 Echo：私密 Echo 与不存在的一样拒绝，不泄露其存在。
+	anonymous := viewer.WithContext(ctx, viewer.NewNoopViewer())
+	if _, err := h.echoService.GetEchoById(anonymous, echoID); err != nil {
+		return "", errors.New("target echo not found")
+	}
+	return echoID, nil
+}
+
+// Verify 抓取 source 并按结果落库或撤销提及：页面 404 / 410 或已不再链接 target 时删除
+// 已有记录（对应规范中的更新与删除），其余失败只记日志，等待对方重发。
+func (h *Handler) Verify(ctx context.Context, echoID, source, target string) {
+	status, body, err := h.sender.Fetch(ctx, source)
+	if err != nil {
+		logUtil.GetLogger().Warn("fetch webmention source failed",
+			slog.String("source_url", source), logUtil.Err(err))
+		return
+	}
+
+	switch {
+	case status == http.StatusNotFound || status == http.StatusGone:
+		h.retract(ctx, echoID, source)
+	case status >= 200 && status < 300:
+		info := parseSource(body, source, target)
+		if !info.LinksTarget {
+			h.retract(ctx, echoID, source)
+			return
+		}
+		if _, err := h.commentService.SaveWebmention(ctx, commentModel.Webmention{
+			EchoID:  echoID,
+			Source:  source,
+			Author:  info.Author,
+			Content: info.Content,
+		}); err != nil {
+			logUtil.GetLogger().Warn("save webmention failed",
+				slog.String("source_url", source), slog.String("echo_id", echoID), logUtil.Err(err))
+		}
+	default:
+		logUtil.GetLogger().Warn("webmention source returned unexpected status",
+			slog.String("source_url", source), slog.Int("status", status))
+	}
+}
+
+func (h *Handler) retract(ctx context.Context, echoID, source string) {
+	if err := h.commentService.DeleteWebmention(ctx, echoID, source); err != nil {
+		logUtil.GetLogger().Warn("delete webmention failed",
+			slog.String("source_url", source), slog.String("echo_id", echoID), logUtil.Err(err))
+	}
+}
+
+// Source 渲染 GET /api/webmention/source/:id 的 h-entry 页。Echo 不存在或为私密时回 410，
+// 对方接收端据此删除先前收录的提及。
+func (h *Handler) Source(c *gin.Context) {
+	anonymous := viewer.WithContext(c.Request.Context(), viewer.NewNoopViewer())
+	echo, err := h.echoService.GetEchoById(anonymous, c.Param("id"))
+	if err != nil || echo == nil {
+		c.String(http.StatusGone, "This echo is no longer available.")
+		return
+	}
+
+	site := h.siteURL(c)
+	author := strings.TrimSpace(echo.Username)
+	if author == "" {
+		author = "Ech0"
+	}
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Header("X-Robots-Tag", "noindex")
+	c.Status(http.StatusOK)
+	_ = sourcePage.Execute(c.Writer, sourcePageData{
+		Site:      site,
+		URL:       echoURL(site, echo.ID),
+		Author:    author,
+		Published: time.Unix(echo.CreatedAt, 0).UTC().Format(time.RFC3339),
+		Content:   template.HTML(mdUtil.MdToHTML([]byte(echo.Content))),
+	})
+}
+
+// siteURL 优先用配置的站点地址，未配置时按请求推断，保证未设 server_url 的实例也能接收。
+func (h *Handler) siteURL(c *gin.Context) string {
+	if base := serverURL(c.Request.Context(), h.durableKV); base != "" {
+		return base
+	}
+	scheme := "http"
+	if c.Request.TLS != nil || strings.EqualFold(c.GetHeader("X-Forwarded-Proto"), "https") {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host
+}
+
+// echoIDFromTarget 从 target 中取出 Echo ID：主机须与站点一致，路径须为 /echo/{id}。
+func echoIDFromTarget(site, target string) (string, bool) {
+	base, err := url.Parse(site)
+	if err != nil {
+		return "", false
+	}
+	u, err := url.Parse(target)
+	if err != nil || !strings.EqualFold(u.Host, base.Host) {
+		return "", false
+	}
+	rest, ok := strings.CutPrefix(u.Path, strings.TrimSuffix(base.Path, "/")+"/echo/")
+	rest = strings.TrimSuffix(rest, "/")
+	if !ok || rest == "" || strings.Contains(rest, "/") {
+		return "", false
+	}
+	return rest, true
+}
+
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && u.User == nil
+}
@@ -0,0 +1,206 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package webmention
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lin-snow/ech0/internal/kvstore"
+	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	"github.com/lin-snow/ech0/internal/test/mocks/commentmock"
+	"github.com/lin-snow/ech0/internal/test/mocks/echomock"
+	asyncUtil "github.com/lin-snow/ech0/internal/util/async"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const target = "https://ech0.example/echo/e1"
+
+type fixture struct {
+	comment *commentmock.MockService
+	echo    *echomock.MockService
+	handler *Handler
+	engine  *gin.Engine
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	kv := kvstore.NewMemory()
+	require.NoError(t, kv.Set(context.Background(), commonModel.ServerURLKey, "https://ech0.example"))
+
+	f := &fixture{
+		comment: commentmock.NewMockService(t),
+		echo:    echomock.NewMockService(t),
+		engine:  gin.New(),
+	}
+	f.handler = &Handler{
+		commentService: f.comment,
+		echoService:    f.echo,
+		durableKV:      kv,
+		sender:         testSender(),
+		pool:           asyncUtil.NewWorkerPool(1, 4),
+	}
+	f.engine.POST(EndpointPath, f.handler.Receive)
+	f.engine.GET(SourcePathPrefix+":id", f.handler.Source)
+	return f
+}
+
+func (f *fixture) post(source, target string) *httptest.ResponseRecorder {
+	form := url.Values{"source": {source}, "target": {target}}
+	req := httptest.NewRequest(http.MethodPost, EndpointPath, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	f.engine.ServeHTTP(w, req)
+	return w
+}
+
+func (f *fixture) commentsEnabled(enabled bool) {
+	f.comment.EXPECT().GetSystemSetting(mock.Anything).
+		Return(commentModel.SystemSetting{EnableComment: enabled}, nil)
+}
+
+func TestReceive_RejectsInvalidRequests(t *testing.T) {
+	cases := []struct {
+		name   string
+		source string
+		target string
+		want   string
+	}{
+		{"missing source", "", target, "http(s)"},
+		{"non-http source", "ftp://blog.example/a", target, "http(s)"},
+		{"same url", target + "/", target, "different"},
+		{"other host", "https://blog.example/a", "https://elsewhere.example/echo/e1", "not an echo"},
+		{"not an echo path", "https://blog.example/a", "https://ech0.example/api/echo/e1", "not an echo"},
+		{"nested path", "https://blog.example/a", "https://ech0.example/echo/e1/x", "not an echo"},
+		{"source too long", "https://blog.example/" + strings.Repeat("a", maxSourceLen), target, "too long"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := newFixture(t).post(tc.source, tc.target)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), tc.want)
+		})
+	}
+
+	t.Run("comments disabled", func(t *testing.T) {
+		f := newFixture(t)
+		f.commentsEnabled(false)
+		w := f.post("https://blog.example/a", target)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("private or missing echo", func(t *testing.T) {
+		f := newFixture(t)
+		f.commentsEnabled(true)
+		f.echo.EXPECT().GetEchoById(mock.Anything, "e1").
+			Return(nil, errors.New(commonModel.NO_PERMISSION_DENIED)).Once()
+		w := f.post("https://blog.example/a", target)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "not found")
+	})
+}
+
+// 受理后异步抓取来源页：仍链接 target 时落库，410 或链接已删除时撤销。
+func TestReceive_VerifiesSourceAsynchronously(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /reply", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<div class="h-entry"><a class="p-author h-card" href="/">Bob</a>
+			<p class="e-content">Nice <a href="` + target + `">echo</a>!</p></div>`))
+	})
+	mux.HandleFunc("GET /unlinked", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<p>nothing here</p>`))
+	})
+	mux.HandleFunc("GET /gone", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	})
+	mux.HandleFunc("GET /broken", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	f := newFixture(t)
+	f.commentsEnabled(true)
+	f.echo.EXPECT().GetEchoById(mock.Anything, "e1").Return(&echoModel.Echo{ID: "e1"}, nil)
+	f.comment.EXPECT().SaveWebmention(mock.Anything, commentModel.Webmention{
+		EchoID:  "e1",
+		Source:  srv.URL + "/reply",
+		Author:  "Bob",
+		Content: "Nice echo!",
+	}).Return(commentModel.CreateCommentResult{ID: "wm-1"}, nil).Once()
+	f.comment.EXPECT().DeleteWebmention(mock.Anything, "e1", srv.URL+"/unlinked").Return(nil).Once()
+	f.comment.EXPECT().DeleteWebmention(mock.Anything, "e1", srv.URL+"/gone").Return(nil).Once()
+
+	for _, path := range []string{"/reply", "/unlinked", "/gone", "/broken"} {
+		w := f.post(srv.URL+path, target)
+		assert.Equal(t, http.StatusAccepted, w.Code, path)
+	}
+	f.handler.pool.Wait()
+	f.handler.pool.Stop()
+}
+
+func TestSource(t *testing.T) {
+	f := newFixture(t)
+	f.echo.EXPECT().GetEchoById(mock.Anything, "e1").Return(&echoModel.Echo{
+		ID:        "e1",
+		Username:  "alice",
+		Content:   "see [this](https://blog.example/a) <script>x</script>",
+		CreatedAt: 1767323045,
+	}, nil).Once()
+	f.echo.EXPECT().GetEchoById(mock.Anything, "private").
+		Return(nil, errors.New(commonModel.NO_PERMISSION_DENIED)).Once()
+
+	w := httptest.NewRecorder()
+	f.engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, SourcePathPrefix+"e1", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Contains(t, body, `class="h-entry"`)
+	assert.Contains(t, body, `<a class="u-url" href="https://ech0.example/echo/e1">`)
+	assert.Contains(t, body, `datetime="2026-01-02T03:04:05Z"`)
+	assert.Contains(t, body, `>alice</a>`)
+	assert.Contains(t, body, `href="https://blog.example/a"`)
+	assert.NotContains(t, body, "<script>")
+
+	// 解析自己的来源页应能核实到外链，与对方接收端看到的一致。
+	info := parseSource([]byte(body), "https://ech0.example"+SourcePathPrefix+"e1", "https://blog.example/a")
+	assert.True(t, info.LinksTarget)
+	assert.Equal(t, "alice", info.Author)
+
+	w = httptest.NewRecorder()
+	f.engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, SourcePathPrefix+"private", nil))
+	assert.Equal(t, http.StatusGone, w.Code)
+}
+
+func TestEchoIDFromTarget(t *testing.T) {
+	cases := []struct {
+		site   string
+		target string
+		want   string
+		wantOK bool
+	}{
+		{"https://ech0.example", "https://ech0.example/echo/abc", "abc", true},
+		{"https://ech0.example", "https://ECH0.example/echo/abc/?x=1#c", "abc", true},
+		{"https://example.com/blog", "https://example.com/blog/echo/abc", "abc", true},
+		{"https://example.com/blog", "https://example.com/echo/abc", "", false},
+		{"https://ech0.example", "https://ech0.example/echo/", "", false},
+	}
+	for _, tc := range cases {
+		got, ok := echoIDFromTarget(tc.site, tc.target)
+		assert.Equal(t, tc.wantOK, ok, tc.target)
+		assert.Equal(t, tc.want, got, tc.target)
+	}
+}
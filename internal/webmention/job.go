@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package webmention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+// SendPayload 是一次外发作业的输入：同一 source 待通知的全部目标。
+type SendPayload struct {
+	Source  string   `json:"source"`
+	Targets []string `json:"targets"`
+}
+
+// SendResult 是外发作业的终态结果，按目标归类。
+type SendResult struct {
+	Source     string   `json:"source"`
+	Sent       []string `json:"sent"`
+	NoEndpoint []string `json:"no_endpoint"`
+	Failed     []string `json:"failed"`
+}
+
+// SendAll 逐个通知 payload 中的目标，单个目标的瞬时失败由 Send 就地重试。目标未声明端点
+// 属正常情况；仍有目标失败时返回错误，作业据此置 failed。
+func (s *Sender) SendAll(
+	ctx context.Context,
+	p SendPayload,
+	report func(phase string, snapshot any),
+) (SendResult, error) {
+	result := SendResult{Source: p.Source}
+	for i, target := range p.Targets {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		report(fmt.Sprintf("sending %d/%d", i+1, len(p.Targets)), nil)
+
+		err := s.Send(ctx, p.Source, target)
+		switch {
+		case err == nil:
+			result.Sent = append(result.Sent, target)
+			logUtil.GetLogger().Info("webmention sent",
+				slog.String("source_url", p.Source), slog.String("target_url", target))
+		case errors.Is(err, ErrNoEndpoint):
+			result.NoEndpoint = append(result.NoEndpoint, target)
+			logUtil.GetLogger().Debug("webmention endpoint not found",
+				slog.String("target_url", target))
+		default:
+			result.Failed = append(result.Failed, target)
+			logUtil.GetLogger().Warn("send webmention failed",
+				slog.String("source_url", p.Source), slog.String("target_url", target), logUtil.Err(err))
+		}
+	}
+	if len(result.Failed) > 0 {
+		return result, fmt.Errorf("webmention: %d of %d targets failed", len(result.Failed), len(p.Targets))
+	}
+	return result, nil
+}
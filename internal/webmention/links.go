@@ -0,0 +1,234 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package webmention
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	mdUtil "github.com/lin-snow/ech0/internal/util/md"
+	"golang.org/x/net/html"
+)
+
+// linkHeaderPattern 匹配 Link 头中的一项：<URL> 及其后的参数串。
+var linkHeaderPattern = regexp.MustCompile(`<([^>]*)>([^<]*)`)
+
+// relParamPattern 取参数串里的 rel 值（带引号或不带引号）。
+var relParamPattern = regexp.MustCompile(`(?i)(?:^|;)\s*rel\s*=\s*(?:"([^"]*)"|([^\s;,]+))`)
+
+// ExtractLinks 渲染 Echo 的 Markdown 正文，按出现顺序返回去重后的外部 http(s) 链接。
+// 走与 RSS 相同的 MdToHTML，Markdown 链接与自动链接都会被识别。
+func ExtractLinks(content string) []string {
+	root, err := html.Parse(bytes.NewReader(mdUtil.MdToHTML([]byte(content))))
+	if err != nil {
+		return nil
+	}
+	var links []string
+	seen := make(map[string]bool)
+	walk(root, func(n *html.Node) {
+		if n.Data != "a" {
+			return
+		}
+		u, err := url.Parse(strings.TrimSpace(attr(n, "href")))
+		if err != nil || !u.IsAbs() || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return
+		}
+		u.Fragment = ""
+		if link := u.String(); !seen[link] {
+			seen[link] = true
+			links = append(links, link)
+		}
+	})
+	return links
+}
+
+// findEndpoint 按规范顺序发现 Webmention 端点：先看 HTTP Link 头，再看文档中第一个
+// rel 含 webmention 的 <link> / <a>。相对地址以 base（跟随重定向后的最终地址）解析；
+// href 为空表示端点就是页面自身。未找到时返回空串。
+func findEndpoint(header http.Header, body []byte, base *url.URL) string {
+	for _, value := range header.Values("Link") {
+		for _, m := range linkHeaderPattern.FindAllStringSubmatch(value, -1) {
+			rel := relParamPattern.FindStringSubmatch(m[2])
+			if rel != nil && hasRel(rel[1]+rel[2]) {
+				return resolve(base, m[1])
+			}
+		}
+	}
+
+	root, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+	endpoint := ""
+	found := false
+	walk(root, func(n *html.Node) {
+		if found || (n.Data != "link" && n.Data != "a") || !hasRel(attr(n, "rel")) {
+			return
+		}
+		for _, a := range n.Attr {
+			if a.Key == "href" {
+				endpoint = resolve(base, a.Val)
+				found = true
+				return
+			}
+		}
+	})
+	return endpoint
+}
+
+// sourceInfo 是从来源页解析出的提及信息。
+type sourceInfo struct {
+	LinksTarget bool
+	Author      string
+	Content     string
+}
+
+// parseSource 校验来源页是否链接到 target，并尽力取出作者与摘要：
+// 优先第一个 h-entry 的 p-author（h-card 取其 p-name）与 e-content / p-summary / p-name，
+// 缺省时内容退回 <title>。
+func parseSource(body []byte, source, target string) sourceInfo {
+	var info sourceInfo
+	root, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return info
+	}
+	base, _ := url.Parse(source)
+	want := normalizeURL(target)
+
+	var entry, title *html.Node
+	walk(root, func(n *html.Node) {
+		if !info.LinksTarget {
+			for _, key := range []string{"href", "src"} {
+				if v := attr(n, key); v != "" && normalizeURL(resolve(base, v)) == want {
+					info.LinksTarget = true
+				}
+			}
+		}
+		if entry == nil && hasClass(n, "h-entry") {
+			entry = n
+		}
+		if title == nil && n.Data == "title" {
+			title = n
+		}
+	})
+
+	if entry != nil {
+		if author := findClass(entry, "p-author"); author != nil {
+			if name := findClass(author, "p-name"); name != nil {
+				author = name
+			}
+			info.Author = text(author)
+		}
+		for _, class := range []string{"e-content", "p-summary", "p-name"} {
+			if n := findClass(entry, class); n != nil {
+				if info.Content = text(n); info.Content != "" {
+					break
+				}
+			}
+		}
+	}
+	if info.Content == "" && title != nil {
+		info.Content = text(title)
+	}
+	return info
+}
+
+func walk(n *html.Node, visit func(*html.Node)) {
+	if n.Type == html.ElementNode {
+		visit(n)
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		walk(c, visit)
+	}
+}
+
+// findClass 返回 n 子树（不含 n 自身）中第一个带指定 class 的元素。
+func findClass(n *html.Node, class string) *html.Node {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && hasClass(c, class) {
+			return c
+		}
+		if found := findClass(c, class); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+var blockElements = map[string]bool{
+	"p": true, "div": true, "br": true, "li": true, "blockquote": true, "pre": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+}
+
+// text 取元素的纯文本，块级元素之间补空格，空白折叠为单个空格。
+func text(n *html.Node) string {
+	var b strings.Builder
+	var collect func(*html.Node)
+	collect = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			collect(c)
+		}
+		if n.Type == html.ElementNode && blockElements[n.Data] {
+			b.WriteString(" ")
+		}
+	}
+	collect(n)
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+func hasClass(n *html.Node, class string) bool {
+	for _, c := range strings.Fields(attr(n, "class")) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+func hasRel(rel string) bool {
+	for _, r := range strings.Fields(rel) {
+		if strings.EqualFold(r, "webmention") {
+			return true
+		}
+	}
+	return false
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func resolve(base *url.URL, ref string) string {
+	u, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return ""
+	}
+	if base == nil {
+		return u.String()
+	}
+	return base.ResolveReference(u).String()
+}
+
+// normalizeURL 去掉片段与末尾斜杠，用于比较来源页中的链接与 target。
+func normalizeURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	u.Fragment = ""
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	return strings.TrimSuffix(u.String(), "/")
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package webmention
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractLinks(t *testing.T) {
+	content := "读了 [这篇](https://blog.example/a#intro) 和 https://blog.example/b ，" +
+		"再看一遍 [同一篇](https://blog.example/a)。\n\n" +
+		"[相对](/echo/1) [邮件](mailto:a@example.com) ![图](https://img.example/x.png)"
+
+	assert.Equal(t, []string{
+		"https://blog.example/a",
+		"https://blog.example/b",
+	}, ExtractLinks(content))
+	assert.Empty(t, ExtractLinks("没有链接"))
+}
+
+func TestFindEndpoint(t *testing.T) {
+	base, _ := url.Parse("https://blog.example/posts/1")
+	header := func(values ...string) http.Header {
+		h := http.Header{}
+		for _, v := range values {
+			h.Add("Link", v)
+		}
+		return h
+	}
+
+	cases := []struct {
+		name   string
+		header http.Header
+		body   string
+		want   string
+	}{
+		{
+			name:   "link header wins over document",
+			header: header(`<https://other.example/>; rel="me", </wm>; rel="webmention"`),
+			body:   `<link rel="webmention" href="/doc">`,
+			want:   "https://blog.example/wm",
+		},
+		{
+			name:   "unquoted rel in a list",
+			header: header(`<https://hooks.example/wm?x=1>; rel=webmention`),
+			want:   "https://hooks.example/wm?x=1",
+		},
+		{
+			name: "first matching element in document order",
+			body: `<html><head><link rel="stylesheet" href="/s.css"></head><body>
+				<a rel="nofollow webmention" href="wm-a">a</a><link rel="webmention" href="/wm-link"></body></html>`,
+			want: "https://blog.example/posts/wm-a",
+		},
+		{
+			name: "empty href means the page itself",
+			body: `<link rel="webmention" href="">`,
+			want: "https://blog.example/posts/1",
+		},
+		{
+			name: "element without href is skipped",
+			body: `<link rel="webmention"><a rel="webmention" href="/wm">x</a>`,
+			want: "https://blog.example/wm",
+		},
+		{name: "none", body: `<a href="/wm">x</a>`, want: ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := tc.header
+			if h == nil {
+				h = http.Header{}
+			}
+			assert.Equal(t, tc.want, findEndpoint(h, []byte(tc.body), base))
+		})
+	}
+}
+
+func TestParseSource(t *testing.T) {
+	const target = "https://ech0.example/echo/e1"
+
+	t.Run("h-entry with h-card author", func(t *testing.T) {
+		body := `<html><head><title>Page title</title></head><body>
+			<article class="h-entry">
+			  <a class="p-author h-card" href="https://alice.example"><img src="/a.png"><span class="p-name">Alice</span></a>
+			  <div class="e-content"><p>Replying to <a href="https://ech0.example/echo/e1/">this echo</a>.</p><p>Second line.</p></div>
+			</article></body></html>`
+		info := parseSource([]byte(body), "https://alice.example/notes/1", target)
+		assert.True(t, info.LinksTarget)
+		assert.Equal(t, "Alice", info.Author)
+		assert.Equal(t, "Replying to this echo. Second line.", info.Content)
+	})
+
+	t.Run("relative link and title fallback", func(t *testing.T) {
+		body := `<html><head><title> Cross  post </title></head><body><a href="/echo/e1#c">x</a></body></html>`
+		info := parseSource([]byte(body), "https://ech0.example/other", target)
+		assert.True(t, info.LinksTarget)
+		assert.Empty(t, info.Author)
+		assert.Equal(t, "Cross post", info.Content)
+	})
+
+	t.Run("link removed", func(t *testing.T) {
+		body := `<div class="h-entry"><p class="p-name">Hello</p><a href="https://ech0.example/echo/e2">other</a></div>`
+		info := parseSource([]byte(body), "https://alice.example/notes/1", target)
+		assert.False(t, info.LinksTarget)
+		assert.Equal(t, "Hello", info.Content)
+	})
+}
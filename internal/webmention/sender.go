@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package webmention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/util/egress"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+	userAgent      = "Ech0-Webmention"
+
+	// maxPageBytes 是发现端点 / 校验来源时读取页面的上限，超出部分直接截断。
+	maxPageBytes = 1 << 20
+
+	sendMaxRetries = 3
+	sendBackoff    = time.Second
+)
+
+// ErrNoEndpoint 表示目标页面未声明 Webmention 端点，属于正常情况，不重试。
+var ErrNoEndpoint = errors.New("webmention endpoint not found")
+
+// Sender 是 Webmention 的出网出口：发现端点、投递通知、抓取来源页。
+// 目标与来源都是外部任意地址，client 启用 SSRF 防护。
+type Sender struct {
+	client *http.Client
+}
+
+func NewSender() *Sender {
+	return &Sender{
+		client: egress.NewClient(egress.Guard(), egress.Timeout(defaultTimeout)),
+	}
+}
+
+// Send 通知 target 页面：source 提及了它。网络错误与 5xx 即时重试；
+// 目标不支持 Webmention 时返回 ErrNoEndpoint，端点返回 4xx 时直接失败。
+func (s *Sender) Send(ctx context.Context, source, target string) error {
+	var endpoint string
+	var permanent error
+	err := egress.Retry(sendMaxRetries, sendBackoff, func() error {
+		permanent = nil
+		var err error
+		if endpoint == "" {
+			endpoint, err = s.discover(ctx, target)
+			if err != nil || endpoint == "" {
+				return err
+			}
+		}
+		err = s.post(ctx, endpoint, source, target)
+		var perm *permanentError
+		if errors.As(err, &perm) {
+			permanent = perm.err
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if endpoint == "" {
+		return ErrNoEndpoint
+	}
+	return permanent
+}
+
+// discover 抓取 target 并返回其 Webmention 端点。target 返回 4xx 视为没有端点。
+func (s *Sender) discover(ctx context.Context, target string) (string, error) {
+	resp, err := s.get(ctx, target)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 500 {
+		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	if resp.StatusCode >= 400 {
+		return "", nil
+	}
+
+	var body []byte
+	if isHTML(resp.Header.Get("Content-Type")) {
+		body, err = io.ReadAll(io.LimitReader(resp.Body, maxPageBytes))
+		if err != nil {
+			return "", err
+		}
+	}
+	return findEndpoint(resp.Header, body, resp.Request.URL), nil
+}
+
+// permanentError 标记重试无意义的失败，如端点地址无效或以 4xx 拒收。
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+// post 向端点提交一次通知。
+func (s *Sender) post(ctx context.Context, endpoint, source, target string) error {
+	form := url.Values{"source": {source}, "target": {target}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return &permanentError{err: err}
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", userAgent)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return &permanentError{err: fmt.Errorf("endpoint rejected webmention: %d", resp.StatusCode)}
+	default:
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+}
+
+// Fetch 抓取来源页用于校验，返回状态码与（仅 HTML 时的）页面内容。
+func (s *Sender) Fetch(ctx context.Context, source string) (int, []byte, error) {
+	resp, err := s.get(ctx, source)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 || !isHTML(resp.Header.Get("Content-Type")) {
+		return resp.StatusCode, nil, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPageBytes))
+	return resp.StatusCode, body, err
+}
+
+func (s *Sender) get(ctx context.Context, target string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/html, application/xhtml+xml;q=0.9, */*;q=0.1")
+	req.Header.Set("User-Agent", userAgent)
+	return s.client.Do(req)
+}
+
+func isHTML(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
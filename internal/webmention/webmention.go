@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package webmention 实现 W3C Webmention（https://www.w3.org/TR/webmention/）的收发两端：
+//
+//   - 发送：Dispatcher 订阅 Echo 的创建 / 更新 / 删除事件，对正文中的外部链接做端点发现并投递；
+//   - 接收：Handler 提供公开端点，先做同步的参数校验并回 202，再在后台抓取来源页核实，
+//     核实通过的提及经评论服务落库为 Source=webmention 的评论，走正常审核流程。
+//
+// SPA 的 /echo/{id} 没有服务端渲染，别人的接收端无法从中核实链接，故发送时以
+// SourcePathPrefix 下的 h-entry 页作为 source。
+package webmention
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/lin-snow/ech0/internal/config"
+	"github.com/lin-snow/ech0/internal/kvstore"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+)
+
+const (
+	// EndpointPath 是接收端点，须与 web/index.html 中的 <link rel="webmention"> 及路由注册一致。
+	EndpointPath = "/api/webmention"
+	// SourcePathPrefix 下是每条 Echo 服务端渲染的 h-entry 页，作为外发 Webmention 的 source。
+	SourcePathPrefix = EndpointPath + "/source/"
+)
+
+// serverURL 取站点地址（去掉末尾斜杠）；未配置时返回空串，收发两端都会跳过。
+func serverURL(ctx context.Context, kv kvstore.Store) string {
+	if kv != nil {
+		if v, err := kv.Get(ctx, commonModel.ServerURLKey); err == nil {
+			if v = strings.TrimSuffix(strings.TrimSpace(v), "/"); v != "" {
+				return v
+			}
+		}
+	}
+	return strings.TrimSuffix(strings.TrimSpace(config.Config().Setting.Serverurl), "/")
+}
+
+func echoURL(base, id string) string {
+	return base + "/echo/" + url.PathEscape(id)
+}
+
+func sourceURL(base, id string) string {
+	return base + SourcePathPrefix + url.PathEscape(id)
+}
@@ -3,7 +3,10 @@
 
 package log
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestDefaultLogConfig(t *testing.T) {
 	cfg := DefaultLogConfig()
@@ -84,3 +87,33 @@ func TestNormalizeDropPolicy(t *testing.T) {
 		})
 	}
 }
+
+func TestRedirectConsole(t *testing.T) {
+	InitLoggerWithConfig(LogConfig{Level: "info", Format: "json"})
+	t.Cleanup(func() { InitLoggerWithConfig(LogConfig{Level: "info", Format: "json"}) })
+
+	var buf strings.Builder
+	RedirectConsole(&buf)
+	GetLogger().Info("redirected")
+
+	if !strings.Contains(buf.String(), `"msg":"redirected"`) {
+		t.Errorf("console output = %q, want it to contain the record", buf.String())
+	}
+}
+
+func TestSetLevel(t *testing.T) {
+	t.Cleanup(func() { SetLevel("info") })
+
+	SetLevel("error")
+	if got := levelVar.Level(); got != parseLevel("error") {
+		t.Errorf("level = %v, want error", got)
+	}
+	if currentConfig.Level != "error" {
+		t.Errorf("currentConfig.Level = %q, want %q", currentConfig.Level, "error")
+	}
+
+	SetLevel("bogus")
+	if got := levelVar.Level(); got != parseLevel("info") {
+		t.Errorf("level = %v, want info fallback", got)
+	}
+}
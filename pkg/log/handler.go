@@ -6,6 +6,7 @@ package log
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"log/slog"
 	"maps"
 	"os"
@@ -68,14 +69,18 @@ func (h *fanoutHandler) WithGroup(name string) slog.Handler {
 // newConsoleLeaf 构建控制台叶子：Format==json 用 slog.JSONHandler（prod 结构化 stdout），
 // 否则用 tint（dev 彩色 / prod 无色纯文本，由 Color 控制）。文件与内存流不受它影响。
 func newConsoleLeaf(config LogConfig, level slog.Leveler) slog.Handler {
+	var w io.Writer = os.Stdout
+	if config.ConsoleWriter != nil {
+		w = config.ConsoleWriter
+	}
 	if config.Format == "json" {
-		return slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		return slog.NewJSONHandler(w, &slog.HandlerOptions{
 			Level:       level,
 			AddSource:   true,
 			ReplaceAttr: fileReplace,
 		})
 	}
-	return tint.NewHandler(os.Stdout, &tint.Options{
+	return tint.NewHandler(w, &tint.Options{
 		Level:      level,
 		NoColor:    !config.Color,
 		TimeFormat: "15:04:05",
@@ -48,6 +48,8 @@ type LogConfig struct {
 	Console bool `yaml:"console" json:"console"`
 	// Color 控制控制台叶子是否彩色（dev 开 / prod 关）；仅作用于控制台，不影响文件与内存流。
 	Color bool `yaml:"-"       json:"-"`
+	// ConsoleWriter 是控制台叶子的输出目标，nil 时为 stdout。
+	ConsoleWriter io.Writer `yaml:"-"       json:"-"`
 	// 文件输出配置
 	File FileConfig `yaml:"file"    json:"file"`
 	// 内存流式日志配置
@@ -125,6 +127,17 @@ func InitLogger() {
 	InitLoggerWithConfig(DefaultLogConfig())
 }
 
+// RedirectConsole 把控制台叶子改写到 w，其余配置保持不变。
+// 用于以 stdout 承载协议的子命令（如 `ech0 mcp` 的 stdio 传输），日志混进去会破坏报文。
+func RedirectConsole(w io.Writer) {
+	loggerMu.Lock()
+	defer loggerMu.Unlock()
+
+	config := currentConfig
+	config.ConsoleWriter = w
+	initializeLogger(config)
+}
+
 // InitLoggerWithConfig 使用自定义配置初始化日志记录器
 func InitLoggerWithConfig(config LogConfig) {
 	loggerMu.Lock()
@@ -133,6 +146,15 @@ func InitLoggerWithConfig(config LogConfig) {
 	initializeLogger(config)
 }
 
+// SetLevel 在运行中调整日志级别（配置热加载用），不重建各输出叶子；无效值回退 info。
+func SetLevel(level string) {
+	loggerMu.Lock()
+	defer loggerMu.Unlock()
+
+	currentConfig.Level = level
+	levelVar.Set(parseLevel(level))
+}
+
 func initializeLogger(config LogConfig) {
 	currentConfig = config
 
@@ -33,6 +33,8 @@
 //   - [Copier] — efficient same-backend copy (LocalFS, ObjectFS).
 //   - [Presigner] — presigned upload/download URLs (ObjectFS).
 //   - [BatchDeleter] — bulk deletion (ObjectFS via S3 DeleteObjects).
+//   - [MultipartPutter] — chunked upload of large bodies (ObjectFS via S3
+//     multipart uploads).
 //
 // # Composition
 //
@@ -47,8 +49,8 @@
 // [WithHooks] wraps any FS with optional interceptors ([Hooks]) for
 // Get, Put, Stat and Delete — no need to implement all seven FS methods
 // just to add behaviour to one. The returned hookFS deliberately does
-// not forward optional interfaces (Copier, Presigner, BatchDeleter)
-// so that all data operations pass through the hooks.
+// not forward optional interfaces (Copier, Presigner, BatchDeleter,
+// MultipartPutter) so that all data operations pass through the hooks.
 //
 // For more complex scenarios (multiple layers, intercepting any method),
 // use [Chain] with [Middleware] functions. Embed [BaseFS] in a custom
@@ -56,8 +58,8 @@
 //
 // # Helpers
 //
-// Package-level functions [Copy], [BatchDelete], [Exists], [Walk], and
-// [Migrate] work with any FS implementation. Migrate supports conflict
+// Package-level functions [Copy], [BatchDelete], [PutMultipart], [Exists],
+// [Walk], and [Migrate] work with any FS implementation. Migrate supports conflict
 // policies ([ConflictSkip], [ConflictOverwrite], [ConflictError]),
 // dry-run mode, and progress callbacks for bulk data migration.
 package virefs
@@ -76,8 +76,9 @@ type PutOption func(*PutConfig)
 
 // PutConfig holds optional parameters for Put.
 type PutConfig struct {
-	ContentType string
-	Metadata    map[string]string
+	ContentType  string
+	CacheControl string
+	Metadata     map[string]string
 }
 
 // BuildPutConfig applies all PutOptions and returns the resulting config.
@@ -95,6 +96,12 @@ func WithContentType(ct string) PutOption {
 	return func(c *PutConfig) { c.ContentType = ct }
 }
 
+// WithCacheControl sets the Cache-Control header served with the object.
+// ObjectFS passes it to S3 PutObject; LocalFS ignores it.
+func WithCacheControl(cc string) PutOption {
+	return func(c *PutConfig) { c.CacheControl = cc }
+}
+
 // WithMetadata attaches custom key-value metadata to the uploaded object.
 // ObjectFS passes it as S3 user metadata; LocalFS ignores it.
 func WithMetadata(m map[string]string) PutOption {
@@ -140,6 +147,24 @@ func BatchDelete(ctx context.Context, fsys FS, keys []string) error {
 	return nil
 }
 
+// MultipartPutter is an optional interface for uploading large objects in
+// parts. ObjectFS implements this using S3 multipart uploads so that a
+// multi-gigabyte body never has to be buffered by a single PutObject call.
+// Use a type assertion to check: if mp, ok := fs.(MultipartPutter); ok { ... }
+type MultipartPutter interface {
+	PutMultipart(ctx context.Context, key string, r io.Reader, partSize int64, opts ...PutOption) error
+}
+
+// PutMultipart writes r to key on fsys. If fsys implements MultipartPutter,
+// the body is uploaded in parts of partSize bytes. Otherwise it falls back
+// to a plain Put.
+func PutMultipart(ctx context.Context, fsys FS, key string, r io.Reader, partSize int64, opts ...PutOption) error {
+	if mp, ok := fsys.(MultipartPutter); ok {
+		return mp.PutMultipart(ctx, key, r, partSize, opts...)
+	}
+	return fsys.Put(ctx, key, r, opts...)
+}
+
 // Copy copies a file from src to dst. If src and dst are the same FS instance
 // and it implements Copier, the native (efficient) copy is used. Otherwise it
 // falls back to Get + Put.
@@ -4,6 +4,7 @@
 package virefs
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -28,6 +29,10 @@ type S3API interface {
 	HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
 	ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
 	CopyObject(ctx context.Context, params *s3.CopyObjectInput, optFns ...func(*s3.Options)) (*s3.CopyObjectOutput, error)
+	CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error)
+	UploadPart(ctx context.Context, params *s3.UploadPartInput, optFns ...func(*s3.Options)) (*s3.UploadPartOutput, error)
+	CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error)
+	AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error)
 }
 
 // PresignAPI is the subset of *s3.PresignClient that ObjectFS needs for
@@ -163,6 +168,9 @@ func (o *ObjectFS) Put(ctx context.Context, key string, r io.Reader, opts ...Put
 	if cfg.ContentType != "" {
 		input.ContentType = aws.String(cfg.ContentType)
 	}
+	if cfg.CacheControl != "" {
+		input.CacheControl = aws.String(cfg.CacheControl)
+	}
 	if len(cfg.Metadata) > 0 {
 		input.Metadata = cfg.Metadata
 	}
@@ -173,6 +181,95 @@ func (o *ObjectFS) Put(ctx context.Context, key string, r io.Reader, opts ...Put
 	return nil
 }
 
+// MinPartSize is the smallest part S3 accepts for every part of a multipart
+// upload except the last one.
+const MinPartSize int64 = 5 << 20
+
+// PutMultipart implements MultipartPutter using S3 multipart uploads.
+// partSize is raised to MinPartSize when smaller. A body that fits in a
+// single part is sent with a plain PutObject. On failure the pending upload
+// is aborted so no orphaned parts are left billed in the bucket.
+func (o *ObjectFS) PutMultipart(ctx context.Context, key string, r io.Reader, partSize int64, opts ...PutOption) error {
+	s3k, err := o.s3Key(key)
+	if err != nil {
+		return &OpError{Op: "PutMultipart", Key: key, Err: err}
+	}
+	if partSize < MinPartSize {
+		partSize = MinPartSize
+	}
+
+	buf := make([]byte, partSize)
+	n, err := io.ReadFull(r, buf)
+	switch {
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return o.Put(ctx, key, bytes.NewReader(buf[:n]), opts...)
+	case err != nil:
+		return &OpError{Op: "PutMultipart", Key: key, Err: err}
+	}
+
+	cfg := BuildPutConfig(opts)
+	input := &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(o.bucket),
+		Key:    aws.String(s3k),
+	}
+	if cfg.ContentType != "" {
+		input.ContentType = aws.String(cfg.ContentType)
+	}
+	if cfg.CacheControl != "" {
+		input.CacheControl = aws.String(cfg.CacheControl)
+	}
+	if len(cfg.Metadata) > 0 {
+		input.Metadata = cfg.Metadata
+	}
+	created, err := o.client.CreateMultipartUpload(ctx, input)
+	if err != nil {
+		return &OpError{Op: "PutMultipart", Key: key, Err: mapS3Error(err)}
+	}
+	uploadID := created.UploadId
+	abort := func(cause error) error {
+		_, _ = o.client.AbortMultipartUpload(context.WithoutCancel(ctx), &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(o.bucket),
+			Key:      aws.String(s3k),
+			UploadId: uploadID,
+		})
+		return &OpError{Op: "PutMultipart", Key: key, Err: cause}
+	}
+
+	var parts []types.CompletedPart
+	for partNumber := int32(1); n > 0; partNumber++ {
+		if err := ctx.Err(); err != nil {
+			return abort(err)
+		}
+		out, err := o.client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:     aws.String(o.bucket),
+			Key:        aws.String(s3k),
+			UploadId:   uploadID,
+			PartNumber: aws.Int32(partNumber),
+			Body:       bytes.NewReader(buf[:n]),
+		})
+		if err != nil {
+			return abort(mapS3Error(err))
+		}
+		parts = append(parts, types.CompletedPart{ETag: out.ETag, PartNumber: aws.Int32(partNumber)})
+
+		n, err = io.ReadFull(r, buf)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return abort(err)
+		}
+	}
+
+	_, err = o.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(o.bucket),
+		Key:             aws.String(s3k),
+		UploadId:        uploadID,
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+	})
+	if err != nil {
+		return abort(mapS3Error(err))
+	}
+	return nil
+}
+
 // Delete implements FS.
 func (o *ObjectFS) Delete(ctx context.Context, key string) error {
 	s3k, err := o.s3Key(key)
@@ -438,10 +535,11 @@ func (o *ObjectFS) BatchDelete(ctx context.Context, keys []string) error {
 
 // Compile-time checks.
 var (
-	_ FS           = (*ObjectFS)(nil)
-	_ Presigner    = (*ObjectFS)(nil)
-	_ Copier       = (*ObjectFS)(nil)
-	_ BatchDeleter = (*ObjectFS)(nil)
+	_ FS              = (*ObjectFS)(nil)
+	_ Presigner       = (*ObjectFS)(nil)
+	_ Copier          = (*ObjectFS)(nil)
+	_ BatchDeleter    = (*ObjectFS)(nil)
+	_ MultipartPutter = (*ObjectFS)(nil)
 )
 
 // mapS3Error converts common S3 error types to virefs sentinel errors.
@@ -24,17 +24,24 @@ import (
 
 // fakeS3 is an in-memory S3 implementation for testing.
 type fakeS3 struct {
-	objects      map[string][]byte
-	contentTypes map[string]string
-	metadata     map[string]map[string]string
-	maxKeys      int // if > 0, limits results per ListObjectsV2 call to simulate pagination
+	objects       map[string][]byte
+	contentTypes  map[string]string
+	cacheControls map[string]string
+	metadata      map[string]map[string]string
+	maxKeys       int // if > 0, limits results per ListObjectsV2 call to simulate pagination
+	uploads       map[string]map[int32][]byte
+	nextUploadID  int
+	aborted       []string
+	failPart      int32 // if > 0, UploadPart fails for this part number
 }
 
 func newFakeS3() *fakeS3 {
 	return &fakeS3{
-		objects:      make(map[string][]byte),
-		contentTypes: make(map[string]string),
-		metadata:     make(map[string]map[string]string),
+		objects:       make(map[string][]byte),
+		contentTypes:  make(map[string]string),
+		cacheControls: make(map[string]string),
+		metadata:      make(map[string]map[string]string),
+		uploads:       make(map[string]map[int32][]byte),
 	}
 }
 
@@ -48,6 +55,9 @@ func (f *fakeS3) PutObject(_ context.Context, in *s3.PutObjectInput, _ ...func(*
 	if in.ContentType != nil {
 		f.contentTypes[key] = aws.ToString(in.ContentType)
 	}
+	if in.CacheControl != nil {
+		f.cacheControls[key] = aws.ToString(in.CacheControl)
+	}
 	if in.Metadata != nil {
 		f.metadata[key] = in.Metadata
 	}
@@ -71,6 +81,55 @@ func (f *fakeS3) CopyObject(_ context.Context, in *s3.CopyObjectInput, _ ...func
 	return &s3.CopyObjectOutput{}, nil
 }
 
+func (f *fakeS3) CreateMultipartUpload(_ context.Context, in *s3.CreateMultipartUploadInput, _ ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error) {
+	f.nextUploadID++
+	id := strconv.Itoa(f.nextUploadID)
+	f.uploads[id] = make(map[int32][]byte)
+	if in.ContentType != nil {
+		f.contentTypes[aws.ToString(in.Key)] = aws.ToString(in.ContentType)
+	}
+	return &s3.CreateMultipartUploadOutput{UploadId: aws.String(id)}, nil
+}
+
+func (f *fakeS3) UploadPart(_ context.Context, in *s3.UploadPartInput, _ ...func(*s3.Options)) (*s3.UploadPartOutput, error) {
+	parts, ok := f.uploads[aws.ToString(in.UploadId)]
+	if !ok {
+		return nil, fmt.Errorf("no such upload: %s", aws.ToString(in.UploadId))
+	}
+	number := aws.ToInt32(in.PartNumber)
+	if f.failPart > 0 && number == f.failPart {
+		return nil, errors.New("part upload failed")
+	}
+	data, err := io.ReadAll(in.Body)
+	if err != nil {
+		return nil, err
+	}
+	parts[number] = data
+	return &s3.UploadPartOutput{ETag: aws.String("etag-" + strconv.Itoa(int(number)))}, nil
+}
+
+func (f *fakeS3) CompleteMultipartUpload(_ context.Context, in *s3.CompleteMultipartUploadInput, _ ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error) {
+	id := aws.ToString(in.UploadId)
+	parts, ok := f.uploads[id]
+	if !ok {
+		return nil, fmt.Errorf("no such upload: %s", id)
+	}
+	var body []byte
+	for _, p := range in.MultipartUpload.Parts {
+		body = append(body, parts[aws.ToInt32(p.PartNumber)]...)
+	}
+	f.objects[aws.ToString(in.Key)] = body
+	delete(f.uploads, id)
+	return &s3.CompleteMultipartUploadOutput{}, nil
+}
+
+func (f *fakeS3) AbortMultipartUpload(_ context.Context, in *s3.AbortMultipartUploadInput, _ ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error) {
+	id := aws.ToString(in.UploadId)
+	delete(f.uploads, id)
+	f.aborted = append(f.aborted, id)
+	return &s3.AbortMultipartUploadOutput{}, nil
+}
+
 func (f *fakeS3) GetObject(_ context.Context, in *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
 	key := aws.ToString(in.Key)
 	data, ok := f.objects[key]
@@ -538,6 +597,18 @@ func TestObjectFS_PutWithContentType(t *testing.T) {
 	}
 }
 
+func TestObjectFS_PutWithCacheControl(t *testing.T) {
+	fake := newFakeS3()
+	fs := NewObjectFS(fake, "bucket")
+	ctx := context.Background()
+
+	_ = fs.Put(ctx, "index.html", strings.NewReader("<html>"), WithCacheControl("no-cache"))
+
+	if cc := fake.cacheControls["index.html"]; cc != "no-cache" {
+		t.Fatalf("CacheControl = %q, want %q", cc, "no-cache")
+	}
+}
+
 func TestObjectFS_StatContentType(t *testing.T) {
 	fake := newFakeS3()
 	fs := NewObjectFS(fake, "bucket")
@@ -778,3 +849,81 @@ func TestBatchDelete_Fallback(t *testing.T) {
 		t.Fatal("y.txt should be deleted")
 	}
 }
+
+func TestObjectFS_PutMultipart(t *testing.T) {
+	fake := newFakeS3()
+	fs := NewObjectFS(fake, "bucket", WithPrefix("pfx/"))
+	ctx := context.Background()
+
+	body := bytes.Repeat([]byte("0123456789"), int(MinPartSize/10)*2+7)
+	if err := PutMultipart(ctx, fs, "big.bin", bytes.NewReader(body), 1, WithContentType("video/mp4")); err != nil {
+		t.Fatalf("PutMultipart: %v", err)
+	}
+	if got := fake.objects["pfx/big.bin"]; !bytes.Equal(got, body) {
+		t.Fatalf("assembled object size = %d, want %d", len(got), len(body))
+	}
+	if got := fake.contentTypes["pfx/big.bin"]; got != "video/mp4" {
+		t.Fatalf("content type = %q, want video/mp4", got)
+	}
+	if len(fake.uploads) != 0 {
+		t.Fatalf("pending uploads = %d, want 0", len(fake.uploads))
+	}
+}
+
+func TestObjectFS_PutMultipart_SmallBodyUsesPut(t *testing.T) {
+	fake := newFakeS3()
+	fs := NewObjectFS(fake, "bucket")
+	ctx := context.Background()
+
+	if err := fs.PutMultipart(ctx, "small.txt", strings.NewReader("hello"), MinPartSize); err != nil {
+		t.Fatalf("PutMultipart: %v", err)
+	}
+	if got := string(fake.objects["small.txt"]); got != "hello" {
+		t.Fatalf("object = %q, want hello", got)
+	}
+	if fake.nextUploadID != 0 {
+		t.Fatal("small body should not start a multipart upload")
+	}
+}
+
+func TestObjectFS_PutMultipart_AbortsOnFailure(t *testing.T) {
+	fake := newFakeS3()
+	fake.failPart = 2
+	fs := NewObjectFS(fake, "bucket")
+	ctx := context.Background()
+
+	body := bytes.Repeat([]byte("x"), int(MinPartSize)*2)
+	err := fs.PutMultipart(ctx, "big.bin", bytes.NewReader(body), MinPartSize)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	var opErr *OpError
+	if !errors.As(err, &opErr) || opErr.Op != "PutMultipart" {
+		t.Fatalf("expected PutMultipart OpError, got %v", err)
+	}
+	if len(fake.aborted) != 1 {
+		t.Fatalf("aborted uploads = %d, want 1", len(fake.aborted))
+	}
+	if _, ok := fake.objects["big.bin"]; ok {
+		t.Fatal("failed upload should not create the object")
+	}
+}
+
+func TestPutMultipart_Fallback(t *testing.T) {
+	dir := t.TempDir()
+	fs := mustNewLocalFS(t, dir)
+	ctx := context.Background()
+
+	if err := PutMultipart(ctx, fs, "a/b.txt", strings.NewReader("local"), MinPartSize); err != nil {
+		t.Fatalf("PutMultipart fallback: %v", err)
+	}
+	rc, err := fs.Get(ctx, "a/b.txt")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	defer rc.Close()
+	data, _ := io.ReadAll(rc)
+	if string(data) != "local" {
+		t.Fatalf("content = %q, want local", data)
+	}
+}
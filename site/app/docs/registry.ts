@@ -131,8 +131,11 @@ const DOC_ORDER: readonly string[] = [
   "guide/chat",
   "guide/embedding",
   "guide/webhook",
+  "guide/notify",
   "guide/accesstoken",
   "guide/mcp",
+  "guide/micropub",
+  "guide/webmention",
   "guide/s3",
   "guide/datacontrol",
   "guide/capsule",
@@ -84,4 +84,13 @@ export enum AccessTokenExpiration {
 export enum AgentProtocol {
   OPENAI = 'openai',
   ANTHROPIC = 'anthropic',
+  OLLAMA = 'ollama',
+}
+
+// Embedding 向量提供商
+export enum EmbeddingProvider {
+  OPENAI = 'openai',
+  OLLAMA = 'ollama',
+  LLAMACPP = 'llamacpp',
+  HASH = 'hash',
 }
@@ -11,4 +11,5 @@ export * from './hooks/useFileAttachments'
 export * from './selectors/file-selectors'
 export * from './compress'
 export * from './upload'
+export * from './tus'
 export * from './useUpload'
@@ -0,0 +1,243 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+import { UploadError } from './upload'
+
+// Minimal tus 1.0 client (core + creation + termination) for the backend's
+// /api/upload/tus endpoint. Chunks are sent with PATCH; after a dropped
+// connection the client asks the server for its offset (HEAD) and continues
+// from there instead of restarting the whole file.
+
+const TUS_VERSION = '1.0.0'
+const FILE_ID_HEADER = 'Ech0-File-Id'
+
+export const TUS_DEFAULT_CHUNK_SIZE = 5 * 1024 * 1024
+
+// Files at or above this size go through tus instead of a single request.
+export const TUS_UPLOAD_THRESHOLD = 8 * 1024 * 1024
+
+// Backoff between attempts after a failed chunk; one entry per retry.
+const DEFAULT_RETRY_DELAYS = [1000, 3000, 5000, 10000, 20000]
+
+export interface TusUploadOptions {
+  /** Creation endpoint, e.g. `${backendURL}/api/upload/tus`. */
+  endpoint: string
+  authHeader: string
+  metadata: Record<string, string>
+  /** Session URL from a previous attempt; resumed when the server still knows it. */
+  uploadUrl?: string
+  onUploadUrl?: (url: string) => void
+  onProgress?: (loaded: number, total: number) => void
+  signal?: AbortSignal
+  chunkSize?: number
+  retryDelays?: number[]
+}
+
+export interface TusUploadResult {
+  fileId: string
+  uploadUrl: string
+}
+
+interface TusResponse {
+  status: number
+  headers: (name: string) => string | null
+  body: unknown
+}
+
+function encodeMetadata(metadata: Record<string, string>): string {
+  return Object.entries(metadata)
+    .map(([key, value]) => {
+      const bytes = new TextEncoder().encode(value)
+      let binary = ''
+      for (const b of bytes) binary += String.fromCharCode(b)
+      return `${key} ${btoa(binary)}`
+    })
+    .join(',')
+}
+
+function parseBody(text: string): unknown {
+  if (!text) return null
+  try {
+    return JSON.parse(text)
+  } catch {
+    return text
+  }
+}
+
+function errorMessage(res: TusResponse): string {
+  const body = res.body
+  if (body && typeof body === 'object' && 'msg' in body) {
+    const msg = (body as { msg?: unknown }).msg
+    if (typeof msg === 'string' && msg.trim()) return msg
+  }
+  return `Upload failed (${res.status})`
+}
+
+function sendTus(
+  method: string,
+  url: string,
+  headers: Record<string, string>,
+  body: Blob | null,
+  signal: AbortSignal | undefined,
+  onProgress?: (loaded: number) => void,
+): Promise<TusResponse> {
+  return new Promise((resolve, reject) => {
+    if (signal?.aborted) {
+      reject(new DOMException('Aborted', 'AbortError'))
+      return
+    }
+    const xhr = new XMLHttpRequest()
+    xhr.open(method, url, true)
+    xhr.setRequestHeader('Tus-Resumable', TUS_VERSION)
+    for (const [k, v] of Object.entries(headers)) xhr.setRequestHeader(k, v)
+    if (onProgress) {
+      xhr.upload.onprogress = (e) => onProgress(e.loaded)
+    }
+
+    const onAbort = () => xhr.abort()
+    signal?.addEventListener('abort', onAbort)
+    const cleanup = () => signal?.removeEventListener('abort', onAbort)
+
+    xhr.onload = () => {
+      cleanup()
+      resolve({
+        status: xhr.status,
+        headers: (name) => xhr.getResponseHeader(name),
+        body: parseBody(xhr.responseText),
+      })
+    }
+    xhr.onerror = () => {
+      cleanup()
+      resolve({ status: 0, headers: () => null, body: null })
+    }
+    xhr.ontimeout = xhr.onerror
+    xhr.onabort = () => {
+      cleanup()
+      reject(new DOMException('Aborted', 'AbortError'))
+    }
+    xhr.send(body)
+  })
+}
+
+function wait(ms: number, signal?: AbortSignal): Promise<void> {
+  return new Promise((resolve, reject) => {
+    const timer = setTimeout(() => {
+      signal?.removeEventListener('abort', onAbort)
+      resolve()
+    }, ms)
+    const onAbort = () => {
+      clearTimeout(timer)
+      reject(new DOMException('Aborted', 'AbortError'))
+    }
+    signal?.addEventListener('abort', onAbort, { once: true })
+  })
+}
+
+// Network failures, offset conflicts, a busy session and server errors are
+// worth another attempt after re-syncing the offset; other 4xx are final.
+function isRetryable(status: number): boolean {
+  return status === 0 || status === 409 || status === 423 || status >= 500
+}
+
+export async function tusUpload(file: File, opts: TusUploadOptions): Promise<TusUploadResult> {
+  const auth: Record<string, string> = opts.authHeader ? { Authorization: opts.authHeader } : {}
+  const chunkSize = opts.chunkSize ?? TUS_DEFAULT_CHUNK_SIZE
+  const retryDelays = opts.retryDelays ?? DEFAULT_RETRY_DELAYS
+  const total = file.size
+
+  let uploadUrl = opts.uploadUrl || ''
+  let offset = -1
+
+  // Returns the server offset for the session, or -1 when it is gone (expired,
+  // purged or belonging to a different file) and must be created afresh.
+  async function resync(): Promise<number | { fileId: string }> {
+    const res = await sendTus('HEAD', uploadUrl, auth, null, opts.signal)
+    if (res.status === 404 || res.status === 410 || res.status === 403) return -1
+    if (res.status < 200 || res.status >= 300) {
+      throw new UploadError(res.status, res.body, errorMessage(res))
+    }
+    if (Number(res.headers('Upload-Length')) !== total) return -1
+    const fileId = res.headers(FILE_ID_HEADER)
+    if (fileId) return { fileId }
+    return Number(res.headers('Upload-Offset') ?? 0)
+  }
+
+  if (uploadUrl) {
+    const state = await resync()
+    if (typeof state === 'object') return { fileId: state.fileId, uploadUrl }
+    offset = state
+  }
+
+  if (offset < 0) {
+    const res = await sendTus(
+      'POST',
+      opts.endpoint,
+      {
+        ...auth,
+        'Upload-Length': String(total),
+        'Upload-Metadata': encodeMetadata(opts.metadata),
+      },
+      null,
+      opts.signal,
+    )
+    const location = res.headers('Location')
+    if (res.status !== 201 || !location) {
+      throw new UploadError(res.status, res.body, errorMessage(res))
+    }
+    uploadUrl = new URL(location, new URL(opts.endpoint, window.location.href)).toString()
+    offset = 0
+    opts.onUploadUrl?.(uploadUrl)
+  }
+
+  let attempt = 0
+  for (;;) {
+    opts.onProgress?.(offset, total)
+    const end = Math.min(offset + chunkSize, total)
+    const res = await sendTus(
+      'PATCH',
+      uploadUrl,
+      {
+        ...auth,
+        'Content-Type': 'application/offset+octet-stream',
+        'Upload-Offset': String(offset),
+      },
+      file.slice(offset, end),
+      opts.signal,
+      (loaded) => opts.onProgress?.(offset + loaded, total),
+    )
+
+    if (res.status === 204) {
+      attempt = 0
+      offset = Number(res.headers('Upload-Offset') ?? end)
+      const fileId = res.headers(FILE_ID_HEADER)
+      if (fileId) {
+        opts.onProgress?.(total, total)
+        return { fileId, uploadUrl }
+      }
+      continue
+    }
+
+    if (!isRetryable(res.status) || attempt >= retryDelays.length) {
+      throw new UploadError(res.status, res.body, errorMessage(res))
+    }
+    await wait(retryDelays[attempt++], opts.signal)
+    const state = await resync()
+    if (typeof state === 'object') return { fileId: state.fileId, uploadUrl }
+    if (state < 0) {
+      throw new UploadError(404, null, errorMessage({ ...res, status: 404 }))
+    }
+    offset = state
+  }
+}
+
+// Best-effort termination of an abandoned session so the server can drop the
+// partial bytes right away instead of waiting for expiry.
+export function tusTerminate(uploadUrl: string, authHeader: string): void {
+  void sendTus(
+    'DELETE',
+    uploadUrl,
+    authHeader ? { Authorization: authHeader } : {},
+    null,
+    undefined,
+  ).catch(() => {})
+}
@@ -10,8 +10,9 @@ import { theToast } from '@/utils/toast'
 import { formatBytes } from '@/utils/file'
 import { getImageSize } from '@/utils/image'
 import { compressImage, inferFileExtFromType } from './compress'
-import { getPresign, updateFileMeta } from './api/adapter'
+import { getFileById, getPresign, updateFileMeta } from './api/adapter'
 import { globalFileRegistry } from './registry/file-registry'
+import { TUS_UPLOAD_THRESHOLD, tusTerminate, tusUpload } from './tus'
 import { httpUpload, UPLOAD_KIND } from './upload'
 
 export const UPLOAD_STATUS = {
@@ -66,6 +67,8 @@ export interface QueueItem {
   result?: App.Api.Ech0.FileToAdd
   abort?: AbortController
   delivered?: boolean
+  /** tus session URL of a large upload; a retry resumes from the server offset. */
+  resumeUrl?: string
 }
 
 export interface UseUploadOptions {
@@ -230,10 +233,14 @@ export function useUpload(opts: UseUploadOptions) {
       item.status = UPLOAD_STATUS.UPLOADING
       item.progress = 0
 
+      // Large files go through the resumable (tus) endpoint so a dropped
+      // connection continues from the last chunk instead of starting over.
       const result =
-        opts.storageType.value === FILE_STORAGE_TYPE.OBJECT
-          ? await uploadToS3(working, item, ctl.signal)
-          : await uploadToLocal(working, item, ctl.signal)
+        working.size >= TUS_UPLOAD_THRESHOLD
+          ? await uploadResumable(working, item, ctl.signal)
+          : opts.storageType.value === FILE_STORAGE_TYPE.OBJECT
+            ? await uploadToS3(working, item, ctl.signal)
+            : await uploadToLocal(working, item, ctl.signal)
 
       item.result = result
       item.status = UPLOAD_STATUS.SUCCESS
@@ -311,6 +318,44 @@ export function useUpload(opts: UseUploadOptions) {
     }
   }
 
+  async function uploadResumable(
+    file: File,
+    item: QueueItem,
+    signal: AbortSignal,
+  ): Promise<App.Api.Ech0.FileToAdd> {
+    const storageType = opts.storageType.value
+    const rawName = String(file.name || '').trim()
+    const fileName = rawName || `upload_${Date.now()}${inferFileExtFromType(file.type)}`
+
+    const { fileId } = await tusUpload(file, {
+      endpoint: `${backendURL}/api/upload/tus`,
+      authHeader: authStore.authHeader,
+      metadata: { filename: fileName, category, storage_type: storageType },
+      uploadUrl: item.resumeUrl,
+      onUploadUrl: (url) => {
+        item.resumeUrl = url
+      },
+      onProgress: (loaded, total) => {
+        if (total > 0) item.progress = Math.round((loaded / total) * 100)
+      },
+      signal,
+    })
+    item.resumeUrl = undefined
+
+    const entity = await getFileById(fileId)
+    return {
+      id: entity.id,
+      url: entity.url,
+      storage_type: entity.storageType ?? storageType,
+      key: entity.key,
+      content_type: entity.contentType ?? file.type,
+      size: entity.size ?? file.size,
+      width: entity.width,
+      height: entity.height,
+      category,
+    }
+  }
+
   async function uploadToS3(
     file: File,
     item: QueueItem,
@@ -418,6 +463,7 @@ export function useUpload(opts: UseUploadOptions) {
     if (idx < 0) return
     const item = items.value[idx]
     if (item.abort) item.abort.abort()
+    if (item.resumeUrl) tusTerminate(item.resumeUrl, authStore.authHeader)
     URL.revokeObjectURL(item.preview)
     items.value.splice(idx, 1)
     pump()
@@ -426,6 +472,7 @@ export function useUpload(opts: UseUploadOptions) {
   function cancelAll() {
     for (const item of items.value) {
       if (item.abort) item.abort.abort()
+      if (item.resumeUrl) tusTerminate(item.resumeUrl, authStore.authHeader)
       URL.revokeObjectURL(item.preview)
     }
     items.value = []
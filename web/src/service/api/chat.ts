@@ -20,6 +20,48 @@ export function clearChatSession() {
   })
 }
 
+/** 确认或拒绝 Chat 中模型提议的写操作（SSE pending_action 中的 id）；流随后继续 */
+export function decideChatAction(id: string, approve: boolean) {
+  return request({
+    url: `/chat/actions/${id}/decision`,
+    method: 'POST',
+    data: { approve },
+  })
+}
+
+/** 获取 Copilot 写操作的审计记录（最新在前） */
+export function getChatActions() {
+  return request<App.Api.Chat.ActionRecord[]>({
+    url: `/chat/actions`,
+    method: 'GET',
+  })
+}
+
+/** 获取 Agent 为新 Echo 生成、待审核的标签 / 替代文本建议（最新在前） */
+export function getCopilotSuggestions() {
+  return request<App.Api.Chat.Suggestion[]>({
+    url: `/copilot/suggestions`,
+    method: 'GET',
+  })
+}
+
+/** 应用一条建议；data 省略的字段使用建议值 */
+export function applyCopilotSuggestion(echoId: string, data: App.Api.Chat.ApplySuggestionDto = {}) {
+  return request({
+    url: `/copilot/suggestions/${echoId}/apply`,
+    method: 'POST',
+    data,
+  })
+}
+
+/** 忽略一条建议（不修改 Echo） */
+export function dismissCopilotSuggestion(echoId: string) {
+  return request({
+    url: `/copilot/suggestions/${echoId}`,
+    method: 'DELETE',
+  })
+}
+
 interface ChatStreamHandlers {
   /** 模型决定检索时触发（Agent 形态，可多次），携带本次检索关键词 */
   onSearching?: (query: string) => void
@@ -32,7 +74,13 @@ interface ChatStreamHandlers {
   /** 推理阶段结束，携带后端权威耗时（毫秒），供展示「已思考（用时 X 秒）」 */
   onReasoningDone?: (durationMs: number) => void
   onDelta?: (text: string) => void
+  /** 模型提议了写操作，需用户经 decideChatAction 确认后才会执行 */
+  onPendingAction?: (action: App.Api.Chat.PendingAction) => void
+  /** 写操作已执行完成 */
+  onActionResult?: (result: App.Api.Chat.ActionResult) => void
   onError?: (message: string) => void
+  /** 整轮累计的 token 用量（随 done 到达；提供商不回报时为 0） */
+  onUsage?: (usage: App.Api.Chat.ChatUsage) => void
   onDone?: () => void
 }
 
@@ -72,12 +120,21 @@ export function chatStream(question: string, handlers: ChatStreamHandlers): () =
         case 'delta':
           handlers.onDelta?.((data as { text: string }).text)
           break
+        case 'pending_action':
+          handlers.onPendingAction?.(data as App.Api.Chat.PendingAction)
+          break
+        case 'action_result':
+          handlers.onActionResult?.(data as App.Api.Chat.ActionResult)
+          break
         case 'error':
           handlers.onError?.((data as { message: string }).message)
           break
-        case 'done':
+        case 'done': {
+          const usage = (data as { usage?: App.Api.Chat.ChatUsage }).usage
+          if (usage) handlers.onUsage?.(usage)
           finish()
           break
+        }
       }
     },
     onError: (message) => handlers.onError?.(message),
@@ -108,3 +108,17 @@ export function fetchTestCommentEmail(setting: App.Api.Comment.SystemSetting) {
     data: { setting },
   })
 }
+
+export function fetchGetCommentEmailSuppressions() {
+  return request<App.Api.Comment.EmailSuppression[]>({
+    url: '/panel/comments/suppressions',
+    method: 'GET',
+  })
+}
+
+export function fetchDeleteCommentEmailSuppression(email: string) {
+  return request({
+    url: `/panel/comments/suppressions/${encodeURIComponent(email)}`,
+    method: 'DELETE',
+  })
+}
@@ -9,3 +9,28 @@ export function fetchGetVisitorStats() {
     method: 'GET',
   })
 }
+
+// 获取站长定期摘要设置
+export function fetchGetDigestSetting() {
+  return request<App.Api.Setting.DigestSetting>({
+    url: '/system/digest',
+    method: 'GET',
+  })
+}
+
+// 更新站长定期摘要设置
+export function fetchUpdateDigestSetting(digestSetting: App.Api.Setting.DigestSetting) {
+  return request({
+    url: '/system/digest',
+    method: 'PUT',
+    data: digestSetting,
+  })
+}
+
+// 立即发送一期摘要给 owner
+export function fetchSendDigest() {
+  return request({
+    url: '/system/digest/send',
+    method: 'POST',
+  })
+}
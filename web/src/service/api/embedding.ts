@@ -43,3 +43,20 @@ export function fetchCancelReindex() {
     method: 'POST',
   })
 }
+
+// 提交本地模型（Ollama）拉取作业（异步：起即返回，前端轮询进度）
+export function fetchPullLocalModel(data: App.Api.Embedding.ModelPullDto) {
+  return request<App.Api.Embedding.ModelPullStatus>({
+    url: '/embedding/local-model/pull',
+    method: 'POST',
+    data,
+  })
+}
+
+// 查询最近一次本地模型拉取作业状态
+export function fetchLocalModelPullStatus() {
+  return request<App.Api.Embedding.ModelPullStatus>({
+    url: '/embedding/local-model/pull/status',
+    method: 'GET',
+  })
+}
@@ -128,3 +128,53 @@ export function fetchDownloadFileByPath(query: App.Api.File.FilePathStreamQuery)
     method: 'GET',
   })
 }
+
+// 提交存储迁移作业（异步：起即返回作业 ID，进度经 /jobs/{id} 轮询）
+export function fetchStartStorageMigration(data: App.Api.File.StorageMigrationDto) {
+  return request<App.Api.File.FileJob>({
+    url: '/files/storage-migration',
+    method: 'POST',
+    data,
+  })
+}
+
+// 提交内容去重作业（异步：回填存量文件的内容哈希并合并重复对象，进度经 /jobs/{id} 轮询）
+export function fetchStartFileDedupe() {
+  return request<App.Api.File.FileJob>({
+    url: '/files/dedupe',
+    method: 'POST',
+  })
+}
+
+// 获取存储配额设置
+export function fetchGetStorageQuota() {
+  return request<App.Api.File.StorageQuotaSetting>({
+    url: '/file/quota',
+    method: 'GET',
+  })
+}
+
+// 更新存储配额设置
+export function fetchUpdateStorageQuota(data: App.Api.File.StorageQuotaSetting) {
+  return request({
+    url: '/file/quota',
+    method: 'PUT',
+    data,
+  })
+}
+
+// 获取全站存储用量报表（按用户、分类）
+export function fetchGetStorageUsage() {
+  return request<App.Api.File.StorageUsageReport>({
+    url: '/file/usage',
+    method: 'GET',
+  })
+}
+
+// 按实际文件重算用量账本
+export function fetchRecountStorageUsage() {
+  return request<App.Api.File.StorageUsageRecount>({
+    url: '/file/usage/recount',
+    method: 'POST',
+  })
+}
@@ -11,7 +11,9 @@ export * from './other.ts'
 export * from './agent.ts'
 export * from './chat.ts'
 export * from './embedding.ts'
+export * from './job.ts'
 export * from './init.ts'
 export * from './system-log.ts'
 export * from './comment.ts'
 export * from './dashboard.ts'
+export * from './search.ts'
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+import { request } from '../request'
+
+// 分页查询作业历史（按提交时间倒序，含排队中与在跑的作业）
+export function fetchListJobs(params: App.Api.Job.ListJobsParams = {}) {
+  const search = new URLSearchParams()
+  if (params.type) search.set('type', params.type)
+  if (params.status) search.set('status', params.status)
+  if (params.page) search.set('page', String(params.page))
+  if (params.pageSize) search.set('pageSize', String(params.pageSize))
+  const query = search.toString()
+  return request<App.Api.Job.JobPageResult>({
+    url: query ? `/jobs?${query}` : '/jobs',
+    method: 'GET',
+  })
+}
+
+// 查询单个作业
+export function fetchGetJob(id: string) {
+  return request<App.Api.Job.JobView>({
+    url: `/jobs/${encodeURIComponent(id)}`,
+    method: 'GET',
+  })
+}
+
+// 按 ID 取消作业（排队中的直接取消，在跑的协作式退出）
+export function fetchCancelJob(id: string) {
+  return request<App.Api.Job.JobView>({
+    url: `/jobs/${encodeURIComponent(id)}/cancel`,
+    method: 'POST',
+  })
+}
@@ -12,9 +12,14 @@ export function fetchHelloEch0() {
 }
 
 // 导出产物（下载）- 使用专门的下载函数；format 缺省时后端按 snapshot 处理，故不拼 query。
-export function fetchDownloadExport(format?: ExportFormat) {
+// name 指定留存快照中的某一份（见 fetchListSnapshots），缺省取最新一份。
+export function fetchDownloadExport(format?: ExportFormat, name?: string) {
+  const query = new URLSearchParams()
+  if (format) query.set('format', format)
+  if (name) query.set('name', name)
+  const qs = query.toString()
   return downloadFile({
-    url: format ? `/migration/export/download?format=${format}` : '/migration/export/download',
+    url: qs ? `/migration/export/download?${qs}` : '/migration/export/download',
     method: 'GET',
   })
 }
@@ -42,8 +47,15 @@ export function fetchGetWebsiteTitle(websiteURL: string) {
   })
 }
 
-// 迁移来源:ech0 快照、memos 导出,以及 Ech0 胶囊(内容交换格式)。
-export type MigrationSourceType = 'ech0' | 'memos' | 'capsule'
+// 迁移来源:ech0 快照、memos 导出、Ech0 胶囊(内容交换格式),以及 Markdown 文件夹 /
+// Twitter 归档 / Mastodon 归档三类按条追加的内容导入。
+export type MigrationSourceType =
+  | 'ech0'
+  | 'memos'
+  | 'capsule'
+  | 'markdown'
+  | 'twitter'
+  | 'mastodon'
 
 export interface StartMigrationPayload {
   source_type: MigrationSourceType
@@ -138,13 +150,22 @@ export interface UploadMigrationSourceZipResponse {
   source_payload: Record<string, unknown>
 }
 
+// 解开加密归档的凭据：口令或 age 身份文件内容，二选一即可；都不给时服务端兜底用已存口令。
+export interface ArchiveKeys {
+  passphrase?: string
+  identity?: string
+}
+
 export function fetchUploadMigrationSourceZip(
   sourceType: UploadMigrationSourceZipResponse['source_type'],
   file: File,
+  keys?: ArchiveKeys,
 ) {
   const formData = new FormData()
   formData.append('source_type', sourceType)
   formData.append('file', file)
+  if (keys?.passphrase) formData.append('passphrase', keys.passphrase)
+  if (keys?.identity) formData.append('identity', keys.identity)
   return request<UploadMigrationSourceZipResponse>({
     url: '/migration/upload',
     method: 'POST',
@@ -153,3 +174,51 @@ export function fetchUploadMigrationSourceZip(
     data: formData,
   })
 }
+
+// 本地留存的快照（按 GFS 策略保留多份，新的在前）。
+export interface SnapshotEntry {
+  name: string
+  size: number
+  created_at: number
+  encrypted: boolean
+}
+
+export function fetchListSnapshots() {
+  return request<SnapshotEntry[]>({
+    url: '/migration/snapshots',
+    method: 'GET',
+  })
+}
+
+// 从留存快照恢复：服务端解包后按 ech0 来源提交迁移作业，进度与导入共用同一作业。
+export function fetchRestoreSnapshot(name: string, keys?: ArchiveKeys) {
+  return request<MigrationStatusPayload>({
+    url: `/migration/snapshots/${encodeURIComponent(name)}/restore`,
+    method: 'POST',
+    data: keys ?? {},
+  })
+}
+
+// 归档加密配置：passphrase 读取时恒为空，只以 passphrase_set 标明是否已设置；保存时留空即沿用。
+export interface EncryptionSetting {
+  enable: boolean
+  mode: 'passphrase' | 'recipient'
+  passphrase?: string
+  passphrase_set?: boolean
+  recipients: string[]
+}
+
+export function fetchGetEncryptionSetting() {
+  return request<EncryptionSetting>({
+    url: '/migration/encryption/setting',
+    method: 'GET',
+  })
+}
+
+export function fetchUpdateEncryptionSetting(setting: EncryptionSetting) {
+  return request({
+    url: '/migration/encryption/setting',
+    method: 'PUT',
+    data: setting,
+  })
+}
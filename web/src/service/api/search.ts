@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+import { request } from '../request'
+
+// 混合检索（向量 + 关键词融合，Embedding 未启用时退化为关键词）
+export function fetchSearchEchos(params: App.Api.Search.HybridQuery) {
+  const search = new URLSearchParams({ q: params.q })
+  if (params.tagIds?.length) search.set('tagIds', params.tagIds.join(','))
+  if (params.dateFrom) search.set('dateFrom', String(params.dateFrom))
+  if (params.dateTo) search.set('dateTo', String(params.dateTo))
+  if (params.limit) search.set('limit', String(params.limit))
+  return request<App.Api.Search.HybridResult>({
+    url: `/search?${search.toString()}`,
+    method: 'GET',
+  })
+}
+
+// 获取与指定 Echo 相关的 Echo
+export function fetchGetRelatedEchos(echoId: string, limit = 5) {
+  return request<App.Api.Search.RelatedResult>({
+    url: `/echo/${echoId}/related?limit=${limit}`,
+    method: 'GET',
+  })
+}
@@ -147,6 +147,59 @@ export function fetchTestWebhook(webhookId: string) {
   })
 }
 
+// 获取通知渠道列表
+export function fetchGetNotifyChannels() {
+  return request<App.Api.Setting.NotifyChannel[]>({
+    url: '/notify/channel',
+    method: 'GET',
+  })
+}
+
+// 创建通知渠道
+export function fetchCreateNotifyChannel(channel: App.Api.Setting.NotifyChannelDto) {
+  return request({
+    url: '/notify/channel',
+    method: 'POST',
+    data: channel,
+  })
+}
+
+// 更新通知渠道
+export function fetchUpdateNotifyChannel(
+  channelId: string,
+  channel: App.Api.Setting.NotifyChannelDto,
+) {
+  return request({
+    url: `/notify/channel/${channelId}`,
+    method: 'PUT',
+    data: channel,
+  })
+}
+
+// 删除通知渠道
+export function fetchDeleteNotifyChannel(channelId: string) {
+  return request({
+    url: `/notify/channel/${channelId}`,
+    method: 'DELETE',
+  })
+}
+
+// 测试通知渠道
+export function fetchTestNotifyChannel(channelId: string) {
+  return request({
+    url: `/notify/channel/${channelId}/test`,
+    method: 'POST',
+  })
+}
+
+// 获取通知投递日志
+export function fetchGetNotifyDeliveries(channelId?: string) {
+  return request<App.Api.Setting.NotifyDelivery[]>({
+    url: '/notify/delivery' + (channelId ? `?channel_id=${encodeURIComponent(channelId)}` : ''),
+    method: 'GET',
+  })
+}
+
 // 列出访问令牌
 export function fetchListAccessTokens() {
   return request<App.Api.Setting.AccessToken[]>({
@@ -37,6 +37,8 @@ export type StaticDataset = {
   comment_form: App.Api.Comment.FormMeta
   connects: App.Api.Connect.Connected[]
   connect: App.Api.Connect.Connect
+  /** 构建期预算的相关推荐：Echo id → 相关 id（相似度降序）；旧版产物没有此字段 */
+  related?: Record<string, string[]>
 }
 
 /** 查询引擎入参，对齐后端 EchoQueryDto（internal/service/echo/echo.go） */
@@ -270,6 +272,27 @@ function route<T>(
       return ok<T>(dataset.connects ?? [])
     case 'GET /connect':
       return ok<T>(dataset.connect)
+    case 'GET /search': {
+      // 静态站没有向量索引：检索退化为关键词，形状与活实例的 keyword 模式一致
+      const q = (query.get('q') ?? '').trim()
+      if (q === '') {
+        return unavailable<T>()
+      }
+      const tagIds = (query.get('tagIds') ?? '').split(',').filter((id) => id !== '')
+      const dateFrom = Number.parseInt(query.get('dateFrom') ?? '', 10)
+      const dateTo = Number.parseInt(query.get('dateTo') ?? '', 10)
+      const { items } = queryEchos(dataset, {
+        search: q,
+        tagIds,
+        dateFrom: Number.isFinite(dateFrom) ? dateFrom : undefined,
+        dateTo: Number.isFinite(dateTo) ? dateTo : undefined,
+        pageSize: readLimit(query, DEFAULT_PAGE_SIZE),
+      })
+      return ok<T>({
+        items: items.map((echo, index) => ({ echo, score: 1 / (60 + index + 1), keyword_rank: index + 1 })),
+        mode: 'keyword',
+      })
+    }
     case 'GET /connects/info':
       // 静态站不主动探测远端实例
       return ok<T>([])
@@ -277,6 +300,20 @@ function route<T>(
       break
   }
 
+  // 相关推荐：GET /echo/{id}/related，读构建期预算的 related 表
+  const relatedMatch = method === 'GET' ? /^\/echo\/([^/]+)\/related$/.exec(path) : null
+  if (relatedMatch) {
+    const byId = new Map(publicEchos(dataset).map((item) => [item.id, item]))
+    if (!byId.has(relatedMatch[1])) {
+      return unavailable<T>('Echo not found')
+    }
+    const items = (dataset.related?.[relatedMatch[1]] ?? [])
+      .map((id) => byId.get(id))
+      .filter((item): item is App.Api.Ech0.Echo => item !== undefined)
+      .slice(0, readLimit(query, 5))
+    return ok<T>({ items, mode: 'lexical' })
+  }
+
   // 命中详情：GET /echo/{id}
   const echoMatch = method === 'GET' ? /^\/echo\/([^/]+)$/.exec(path) : null
   if (echoMatch) {
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+import { defineStore } from 'pinia'
+import { computed, ref } from 'vue'
+import { fetchLocalModelPullStatus, fetchPullLocalModel } from '@/service/api'
+
+// 与 reindex store 同一轮询范式：起作业后每 2s 轮询状态，非进行中即停。
+const POLL_INTERVAL_MS = 2000
+
+type ModelPullStatusValue = App.Api.Embedding.ModelPullStatus['status']
+
+// 本地模型拉取作业全局只有一条在跑（后端同类型排队），Agent 与向量两张设置卡共用本 store。
+export const useModelPullStore = defineStore('modelPullStore', () => {
+  const status = ref<ModelPullStatusValue>('idle')
+  const error = ref('')
+  const progress = ref<App.Api.Embedding.ModelPullProgress | null>(null)
+  const pollTimer = ref<number | null>(null)
+
+  const isRunning = computed(() => status.value === 'pending' || status.value === 'running')
+  // 下载分层时的百分比；清单校验等无字节数的阶段为 null
+  const percent = computed(() => {
+    const p = progress.value
+    if (!p?.total || !p.completed) return null
+    return Math.min(100, Math.floor((p.completed / p.total) * 100))
+  })
+
+  function applyState(next: App.Api.Embedding.ModelPullStatus | null | undefined) {
+    if (!next) return
+    status.value = next.status
+    error.value = next.error ?? ''
+    progress.value = next.payload ?? null
+  }
+
+  function stopPolling() {
+    if (pollTimer.value !== null) {
+      window.clearInterval(pollTimer.value)
+      pollTimer.value = null
+    }
+  }
+
+  function startPolling() {
+    if (pollTimer.value !== null) return
+    pollTimer.value = window.setInterval(async () => {
+      await fetchStatus()
+      if (!isRunning.value) {
+        stopPolling()
+      }
+    }, POLL_INTERVAL_MS)
+  }
+
+  async function fetchStatus() {
+    const res = await fetchLocalModelPullStatus()
+    if (res.code !== 1) {
+      return false
+    }
+    applyState(res.data as App.Api.Embedding.ModelPullStatus)
+    return true
+  }
+
+  async function start(dto: App.Api.Embedding.ModelPullDto) {
+    const res = await fetchPullLocalModel(dto)
+    if (res.code !== 1) {
+      return res
+    }
+    applyState(res.data as App.Api.Embedding.ModelPullStatus)
+    if (isRunning.value) {
+      startPolling()
+    }
+    return res
+  }
+
+  // 页面挂载时拉取一次：若拉取在跑（含刷新后续显），恢复轮询。
+  async function init() {
+    const ok = await fetchStatus()
+    if (ok && isRunning.value) {
+      startPolling()
+    }
+  }
+
+  return {
+    status,
+    error,
+    progress,
+    isRunning,
+    percent,
+    init,
+    fetchStatus,
+    start,
+    startPolling,
+    stopPolling,
+  }
+})
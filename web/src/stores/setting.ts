@@ -82,6 +82,9 @@ export const useSettingStore = defineStore('settingStore', () => {
   const SnapshotSchedule = ref<App.Api.Setting.SnapshotSchedule>({
     enable: false,
     cron_expression: '0 2 * * 0',
+    keep_daily: 7,
+    keep_weekly: 4,
+    keep_monthly: 3,
   })
   const AgentSetting = ref<App.Api.Setting.AgentSetting>({
     enable: false,
@@ -92,6 +95,9 @@ export const useSettingStore = defineStore('settingStore', () => {
     base_url: '',
     multimodal: false,
     context_window: 0,
+    auto_tag: false,
+    auto_alt_text: false,
+    auto_apply: false,
   })
   const hello = ref<App.Api.Ech0.HelloEch0>()
   const loading = ref<boolean>(true)
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+import { defineStore } from 'pinia'
+import { computed, ref } from 'vue'
+import { fetchGetJob, fetchListJobs, fetchStartStorageMigration } from '@/service/api'
+
+// 与 modelPull store 同一轮询范式，只是状态走通用作业端点：按作业 ID 轮询，非进行中即停。
+const POLL_INTERVAL_MS = 2000
+const JOB_TYPE = 'storage_migration'
+
+type JobStatus = App.Api.Job.JobStatus | 'idle'
+
+// 提交时 payload 还是输入，跑起来后才是进度；按字段区分，免得把输入当进度展示。
+function toProgress(payload: unknown): App.Api.File.StorageMigrationProgress | null {
+  if (payload && typeof payload === 'object' && 'total' in payload) {
+    return payload as App.Api.File.StorageMigrationProgress
+  }
+  return null
+}
+
+export const useStorageMigrationStore = defineStore('storageMigrationStore', () => {
+  const jobId = ref('')
+  const status = ref<JobStatus>('idle')
+  const error = ref('')
+  const progress = ref<App.Api.File.StorageMigrationProgress | null>(null)
+  const pollTimer = ref<number | null>(null)
+
+  const isRunning = computed(() => status.value === 'pending' || status.value === 'running')
+  const percent = computed(() => {
+    const p = progress.value
+    if (!p?.total) return null
+    return Math.min(100, Math.floor(((p.migrated + p.reused + p.failed) / p.total) * 100))
+  })
+
+  function applyJob(job: App.Api.Job.JobView | App.Api.File.FileJob | null | undefined) {
+    if (!job) return
+    jobId.value = job.id
+    status.value = job.status
+    error.value = job.error ?? ''
+    progress.value = toProgress(job.payload)
+  }
+
+  function stopPolling() {
+    if (pollTimer.value !== null) {
+      window.clearInterval(pollTimer.value)
+      pollTimer.value = null
+    }
+  }
+
+  function startPolling() {
+    if (pollTimer.value !== null || !jobId.value) return
+    pollTimer.value = window.setInterval(async () => {
+      const res = await fetchGetJob(jobId.value)
+      if (res.code === 1) {
+        applyJob(res.data)
+      }
+      if (!isRunning.value) {
+        stopPolling()
+      }
+    }, POLL_INTERVAL_MS)
+  }
+
+  async function start(dto: App.Api.File.StorageMigrationDto) {
+    const res = await fetchStartStorageMigration(dto)
+    if (res.code !== 1) {
+      return res
+    }
+    applyJob(res.data)
+    if (isRunning.value) {
+      startPolling()
+    }
+    return res
+  }
+
+  // 页面挂载时取最近一次迁移：若仍在跑（含刷新后续显），恢复轮询。
+  async function init() {
+    const res = await fetchListJobs({ type: JOB_TYPE, pageSize: 1 })
+    if (res.code !== 1) {
+      return
+    }
+    applyJob(res.data?.items?.[0])
+    if (isRunning.value) {
+      startPolling()
+    }
+  }
+
+  return {
+    jobId,
+    status,
+    error,
+    progress,
+    isRunning,
+    percent,
+    init,
+    start,
+    startPolling,
+    stopPolling,
+  }
+})
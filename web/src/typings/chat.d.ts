@@ -39,6 +39,62 @@ declare namespace App {
         truncated: boolean // 是否因硬上限截断（保留最近）
       }
 
+      // 模型提议、等待用户确认的写操作（SSE pending_action）
+      type PendingAction = {
+        id: string
+        tool: 'create_draft_echo' | 'tag_echos' | 'moderate_comments' | string
+        args: Record<string, unknown>
+        summary: string // 后端渲染好的一句人读描述
+        expires_at: number // 确认截止时间（Unix 秒），过期后端按拒绝处理
+        // 仅前端瞬态：卡片状态（pending→等待确认；approved/rejected→已提交决定；succeeded→已执行；expired→已失效）
+        state?: 'pending' | 'approved' | 'rejected' | 'succeeded' | 'expired'
+        result?: string // 仅前端瞬态：执行结果文案
+      }
+
+      // 写操作执行结果（SSE action_result）
+      type ActionResult = {
+        id: string
+        tool: string
+        status: 'succeeded' | 'failed'
+        message: string
+      }
+
+      // 写操作审计记录（GET /chat/actions）
+      type ActionRecord = {
+        id: string
+        user_id: string
+        tool: string
+        args: Record<string, unknown>
+        summary: string
+        status: 'succeeded' | 'failed'
+        result?: string
+        error?: string
+        executed_at: number
+      }
+
+      // 新 Echo 的配图替代文本建议
+      type AltTextSuggestion = {
+        file_id: string
+        url?: string
+        alt_text: string
+      }
+
+      // Agent 为新 Echo 生成、待审核的建议（GET /copilot/suggestions）
+      type Suggestion = {
+        echo_id: string
+        user_id: string
+        excerpt: string
+        tags?: string[]
+        alt_texts?: AltTextSuggestion[]
+        created_at: number
+      }
+
+      // 应用建议时的可选改写：省略字段则使用建议值
+      type ApplySuggestionDto = {
+        tags?: string[]
+        alt_texts?: AltTextSuggestion[]
+      }
+
       // 一条聊天消息（前端会话内）
       type ChatMessage = {
         role: 'user' | 'assistant'
@@ -58,6 +114,16 @@ declare namespace App {
         reasoning_ms?: number
         // 仅前端瞬态：推理是否仍在流式（true→「思考中」；false/缺省→已结束，展示耗时）。不持久化。
         reasoningActive?: boolean
+        // 仅前端瞬态：本轮模型提议的写操作（确认卡片），不持久化。
+        actions?: PendingAction[]
+        // 仅前端瞬态：本轮 token 用量（done 事件携带，提供商不回报时缺省），不持久化。
+        usage?: ChatUsage
+      }
+
+      // 一轮问答累计的 token 用量（含工具循环各轮）
+      type ChatUsage = {
+        input_tokens: number
+        output_tokens: number
       }
 
       // SSE 事件载荷
@@ -68,8 +134,10 @@ declare namespace App {
         | { type: 'reasoning'; data: { text: string } }
         | { type: 'reasoning_done'; data: { duration_ms: number } }
         | { type: 'delta'; data: { text: string } }
+        | { type: 'pending_action'; data: PendingAction }
+        | { type: 'action_result'; data: ActionResult }
         | { type: 'error'; data: { message: string } }
-        | { type: 'done'; data: { done: boolean } }
+        | { type: 'done'; data: { done: boolean; usage?: ChatUsage } }
     }
   }
 }
@@ -19,7 +19,7 @@ declare namespace App {
         content: string
         status: CommentStatus
         hot: boolean
-        source: 'guest' | 'system'
+        source: 'guest' | 'system' | 'integration' | 'webmention'
         created_at: number
         updated_at: number
       }
@@ -42,6 +42,7 @@ declare namespace App {
         hp_field: string
         form_token: string
         captcha_token: string
+        subscribe?: boolean
       }
 
       type CreateCommentResult = {
@@ -63,6 +64,11 @@ declare namespace App {
         total: number
       }
 
+      type EmailSuppression = {
+        email: string
+        created_at: number
+      }
+
       type SystemSetting = {
         enable_comment: boolean
         require_approval: boolean
@@ -69,6 +69,7 @@ declare namespace App {
         size?: number // 文件大小（字节）
         width?: number // 图片宽度
         height?: number // 图片高度
+        alt_text?: string // 图片替代文本
       }
 
       type Tag = {
@@ -97,6 +98,7 @@ declare namespace App {
           user_id?: string
           width?: number
           height?: number
+          alt_text?: string
           created_at?: number
         }
       }
@@ -8,6 +8,7 @@ declare namespace App {
       // Embedding 向量设置
       type EmbeddingSetting = {
         enable: boolean
+        provider: 'openai' | 'ollama' | 'llamacpp' | 'hash'
         model: string
         api_key: string
         base_url: string
@@ -23,6 +24,7 @@ declare namespace App {
         indexed: number
         skipped: number
         failed: number
+        tokens?: number
       }
 
       // 重建索引作业状态（异步轮询）。idle 表示从未运行 / 无进行中作业。
@@ -34,6 +36,25 @@ declare namespace App {
         started_at?: number
         finished_at?: number
       }
+
+      // 本地模型（Ollama）拉取进度；total/completed 为当前分层字节数
+      type ModelPullProgress = {
+        model: string
+        status: string
+        digest?: string
+        total?: number
+        completed?: number
+      }
+
+      // 本地模型拉取作业状态（异步轮询），payload 在提交时为输入、运行中为进度
+      type ModelPullStatus = Omit<ReindexStatus, 'payload'> & {
+        payload?: ModelPullProgress
+      }
+
+      type ModelPullDto = {
+        base_url?: string
+        model: string
+      }
     }
   }
 }
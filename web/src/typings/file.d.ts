@@ -80,6 +80,76 @@ declare namespace App {
         height?: number
         content_type?: string
       }
+      // 存储迁移：把 from 路由上的全部受管文件搬到 to（object→object 即换桶，需给 from_bucket）
+      type StorageMigrationDto = {
+        from: 'local' | 'object'
+        from_bucket?: string
+        to: 'local' | 'object'
+        delete_source?: boolean
+      }
+      type FileJobFailure = {
+        file_id: string
+        key: string
+        error: string
+      }
+      type StorageMigrationProgress = {
+        total: number
+        migrated: number
+        reused: number
+        failed: number
+        bytes: number
+        current?: string
+        failures?: FileJobFailure[]
+      }
+      // 内容去重作业进度：先回填存量文件的内容哈希，再把同内容的文件合并到同一个存储对象
+      type FileDedupeProgress = {
+        hashed: number
+        missing: number
+        groups: number
+        relinked: number
+        freed: number
+        freed_bytes: number
+        failed: number
+        current?: string
+        failures?: FileJobFailure[]
+      }
+      type FileJob = {
+        id: string
+        status: App.Api.Job.JobStatus
+        phase?: string
+        error?: string
+        payload?: unknown
+      }
+      // 存储配额（字节，0 表示不限）；user_quotas 按用户 ID 单独覆盖角色默认额度
+      type StorageQuotaSetting = {
+        owner_quota: number
+        admin_quota: number
+        user_quota: number
+        user_quotas: Record<string, number>
+      }
+      type CategoryUsage = {
+        category: string
+        bytes: number
+        files: number
+      }
+      type UserStorageUsage = {
+        user_id: string
+        username: string
+        role: 'owner' | 'admin' | 'user'
+        quota: number
+        used: number
+        files: number
+        categories: CategoryUsage[]
+      }
+      type StorageUsageReport = {
+        users: UserStorageUsage[]
+        used: number
+        files: number
+      }
+      type StorageUsageRecount = {
+        checked: number
+        corrected: number
+      }
     }
   }
 }
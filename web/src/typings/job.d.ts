@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// 通用后台作业相关类型（通过命名空间合并扩展 App.Api）。
+declare namespace App {
+  namespace Api {
+    namespace Job {
+      type JobStatus = 'pending' | 'running' | 'success' | 'failed' | 'cancelled'
+
+      // 一次作业提交；payload 结构随 type 而定。
+      type JobView = {
+        id: string
+        type: string
+        status: JobStatus
+        phase?: string
+        error?: string
+        payload?: unknown
+        attempts: number
+        created_at: number
+        started_at?: number
+        finished_at?: number
+        duration?: number
+      }
+
+      type ListJobsParams = {
+        type?: string
+        status?: JobStatus
+        page?: number
+        pageSize?: number
+      }
+
+      type JobPageResult = {
+        items: JobView[]
+        total: number
+      }
+    }
+  }
+}
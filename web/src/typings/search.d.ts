@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// 混合检索与相关推荐类型（通过命名空间合并扩展 App.Api）。
+declare namespace App {
+  namespace Api {
+    namespace Search {
+      // 检索实际采用的模式：hybrid 向量 + 关键词融合；keyword 语义检索未启用或失败；
+      // vector / tags / lexical 为相关推荐的来源（lexical 仅静态站，由构建期预算）。
+      type Mode = 'hybrid' | 'keyword' | 'vector' | 'tags' | 'lexical'
+
+      type HybridQuery = {
+        q: string
+        tagIds?: string[]
+        dateFrom?: number
+        dateTo?: number
+        limit?: number
+      }
+
+      type HybridHit = {
+        echo: App.Api.Ech0.Echo
+        score: number
+        vector_rank?: number
+        keyword_rank?: number
+      }
+
+      type HybridResult = {
+        items: HybridHit[]
+        mode: Mode
+      }
+
+      type RelatedResult = {
+        items: App.Api.Ech0.Echo[]
+        mode: Mode
+      }
+    }
+  }
+}
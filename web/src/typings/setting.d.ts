@@ -97,6 +97,43 @@ declare namespace App {
         is_active: boolean
       }
 
+      type NotifyChannelType = 'ntfy' | 'gotify' | 'bark' | 'telegram' | 'matrix' | 'http'
+
+      type NotifyChannel = {
+        id: string
+        name: string
+        type: NotifyChannelType
+        config: Record<string, string>
+        events: string[]
+        is_active: boolean
+        block_private: boolean
+        last_status: string
+        last_trigger: number
+        created_at: number
+        updated_at: number
+      }
+
+      type NotifyChannelDto = {
+        name: string
+        type: NotifyChannelType
+        config: Record<string, string>
+        events: string[]
+        is_active: boolean
+        block_private: boolean
+      }
+
+      type NotifyDelivery = {
+        id: string
+        channel_id: string
+        channel_name: string
+        event: string
+        title: string
+        status: string
+        error: string
+        duration_ms: number
+        created_at: number
+      }
+
       type AccessToken = {
         id: string
         user_id: string
@@ -121,11 +158,26 @@ declare namespace App {
       type SnapshotSchedule = {
         enable: boolean
         cron_expression: string
+        keep_daily: number
+        keep_weekly: number
+        keep_monthly: number
       }
 
       type SnapshotScheduleDto = {
         enable: boolean
         cron_expression: string
+        keep_daily: number
+        keep_weekly: number
+        keep_monthly: number
+      }
+
+      type DigestSetting = {
+        enable: boolean
+        cron_expression: string
+        period: 'daily' | 'weekly'
+        timezone: string
+        on_this_day: boolean
+        ai_summary: boolean
       }
 
       type AgentSetting = {
@@ -137,6 +189,9 @@ declare namespace App {
         base_url: string
         multimodal: boolean
         context_window: number
+        auto_tag: boolean
+        auto_alt_text: boolean
+        auto_apply: boolean
       }
 
       type AgentSettingDto = {
@@ -148,6 +203,9 @@ declare namespace App {
         base_url: string
         multimodal: boolean
         context_window: number
+        auto_tag: boolean
+        auto_alt_text: boolean
+        auto_apply: boolean
       }
     }
   }
@@ -22,6 +22,7 @@ type EchoLike = {
       size?: number
       width?: number
       height?: number
+      alt_text?: string
     }
   }> | null
 }
@@ -43,6 +44,7 @@ export function getEchoFiles(echo?: EchoLike | null): App.Api.Ech0.FileObject[]
       size: file?.size,
       width: file?.width,
       height: file?.height,
+      alt_text: file?.alt_text,
     }
   })
 }